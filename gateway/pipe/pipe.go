@@ -143,14 +143,12 @@ func (p Pipe) Start() {
 					} else if currentMsg != nil && msg.MessageId == currentMsg.MessageId {
 						// call result / call error for current CSMS call from CS
 						slog.Warn("CS call response is late", slog.String("messageId", msg.MessageId))
-						msg.Action = currentMsg.Action
-						msg.RequestPayload = currentMsg.RequestPayload
+						completeCSMSCall(msg, currentMsg)
 						p.CSMSTx <- msg
 					} else if csmsCall := findCSMSCall(processedCSMSCalls, msg.MessageId); csmsCall != nil {
 						// call result / call error for previous CSMS call from CS
 						slog.Warn("CS call response is very late", slog.String("messageId", msg.MessageId))
-						msg.Action = csmsCall.Action
-						msg.RequestPayload = csmsCall.RequestPayload
+						completeCSMSCall(msg, csmsCall)
 						p.CSMSTx <- msg
 					} else {
 						slog.Error("CS call response has no corresponding CSMS call", slog.String("messageId", msg.MessageId))
@@ -175,14 +173,12 @@ func (p Pipe) Start() {
 						} else if currentMsg != nil && msg.MessageId == currentMsg.MessageId {
 							// call result / call error for current CSMS call from CS
 							slog.Warn("CS call response is late", slog.String("messageId", msg.MessageId))
-							msg.Action = currentMsg.Action
-							msg.RequestPayload = currentMsg.RequestPayload
+							completeCSMSCall(msg, currentMsg)
 							p.CSMSTx <- msg
 						} else if csmsCall := findCSMSCall(processedCSMSCalls, msg.MessageId); csmsCall != nil {
 							// call result / call error for previous CSMS call from CS
 							slog.Warn("CS call response is very late", slog.String("messageId", msg.MessageId))
-							msg.Action = csmsCall.Action
-							msg.RequestPayload = csmsCall.RequestPayload
+							completeCSMSCall(msg, csmsCall)
 							p.CSMSTx <- msg
 						} else {
 							// call result / call error for unknown CSMS call from CS
@@ -265,15 +261,13 @@ func (p Pipe) Start() {
 						p.CSMSTx <- msg
 					} else if msg.MessageId == currentMsg.MessageId {
 						// call result / call error for current CSMS call from CS
-						msg.Action = currentMsg.Action
-						msg.RequestPayload = currentMsg.RequestPayload
+						completeCSMSCall(msg, currentMsg)
 						status = StatusWaiting
 						p.CSMSTx <- msg
 					} else if csmsCall := findCSMSCall(processedCSMSCalls, msg.MessageId); csmsCall != nil {
 						// call result / call error for previous CSMS call from CS
 						slog.Warn("CS made call when expecting CS call response", slog.String("messageId", msg.MessageId), slog.String("currentMessageid", currentMsg.MessageId))
-						msg.Action = csmsCall.Action
-						msg.RequestPayload = csmsCall.RequestPayload
+						completeCSMSCall(msg, csmsCall)
 						p.CSMSTx <- msg
 					} else {
 						// call result / call error for unknown CSMS call from CS
@@ -294,6 +288,17 @@ func (p Pipe) Close() {
 	p.halt <- struct{}{}
 }
 
+// completeCSMSCall fills in the details of the CSMS call that a call result or call error
+// from the charge station responds to. The response takes the context of the call so
+// that the whole exchange is recorded in the trace started by the CSMS.
+func completeCSMSCall(response, call *GatewayMessage) {
+	response.Action = call.Action
+	response.RequestPayload = call.RequestPayload
+	if call.Context != nil {
+		response.Context = call.Context
+	}
+}
+
 func findMessageId(in *ring.Ring, messageId string) bool {
 	if in.Value == messageId {
 		return true
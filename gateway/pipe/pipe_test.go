@@ -141,6 +141,53 @@ func TestCSMSCallAndResponse(t *testing.T) {
 	}
 }
 
+type contextKey string
+
+func TestChargeStationResponseContinuesCSMSCallContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	p := pipe.NewPipe()
+	p.Start()
+	defer p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	callContext := context.WithValue(context.Background(), contextKey("exchange"), "csms-call")
+	callMessage := &pipe.GatewayMessage{
+		Context:        callContext,
+		MessageType:    ocpp.MessageTypeCall,
+		Action:         "CSMSCall",
+		MessageId:      "4321",
+		RequestPayload: json.RawMessage(`{"call":true}`),
+	}
+	callResponseMessage := &pipe.GatewayMessage{
+		Context:         context.Background(),
+		MessageType:     ocpp.MessageTypeCallResult,
+		MessageId:       "4321",
+		ResponsePayload: json.RawMessage(`{"call":false}`),
+	}
+
+	go func() {
+		// incoming CS messages
+		select {
+		case <-p.ChargeStationTx:
+			p.ChargeStationRx <- callResponseMessage
+		case <-ctx.Done():
+		}
+	}()
+
+	// make call from CSMS
+	p.CSMSRx <- callMessage
+
+	select {
+	case msg := <-p.CSMSTx:
+		assert.Equal(t, "csms-call", msg.Context.Value(contextKey("exchange")))
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for test to complete")
+	}
+}
+
 func TestChargeStationCallMessageIdReused(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/eclipse/paho.golang/paho"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// userPropertiesCarrier adapts the MQTT v5 user properties of a message so that
+// they can carry the trace context
+type userPropertiesCarrier struct {
+	props *paho.UserProperties
+}
+
+func (c userPropertiesCarrier) Get(key string) string {
+	return c.props.Get(key)
+}
+
+func (c userPropertiesCarrier) Set(key, value string) {
+	for i, prop := range *c.props {
+		if prop.Key == key {
+			(*c.props)[i].Value = value
+			return
+		}
+	}
+	c.props.Add(key, value)
+}
+
+func (c userPropertiesCarrier) Keys() []string {
+	keys := make([]string, len(*c.props))
+	for i, prop := range *c.props {
+		keys[i] = prop.Key
+	}
+	return keys
+}
+
+// injectTraceContext adds the trace context to the user properties of a message
+// that is about to be published. The trace context is also added to the correlation
+// data, which is where managers that predate the user properties look for it.
+func injectTraceContext(ctx context.Context, props *paho.PublishProperties) error {
+	otel.GetTextMapPropagator().Inject(ctx, userPropertiesCarrier{props: &props.User})
+
+	correlationMap := make(map[string]string)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(correlationMap))
+	correlationData, err := json.Marshal(correlationMap)
+	if err != nil {
+		return fmt.Errorf("marshalling correlation map: %v", err)
+	}
+	props.CorrelationData = correlationData
+	return nil
+}
+
+// extractTraceContext returns a context that continues the trace of a received
+// message: the user properties are preferred, falling back to the correlation data
+func extractTraceContext(ctx context.Context, props *paho.PublishProperties) context.Context {
+	if props == nil {
+		return ctx
+	}
+
+	if len(props.User) > 0 {
+		userCtx := otel.GetTextMapPropagator().Extract(ctx, userPropertiesCarrier{props: &props.User})
+		if trace.SpanContextFromContext(userCtx).IsValid() {
+			return userCtx
+		}
+	}
+
+	if props.CorrelationData != nil {
+		correlationMap := make(map[string]string)
+		err := json.Unmarshal(props.CorrelationData, &correlationMap)
+		if err != nil {
+			slog.Warn("unmarshalling correlation map", "err", err)
+			return ctx
+		}
+		return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(correlationMap))
+	}
+
+	return ctx
+}
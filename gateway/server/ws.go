@@ -9,10 +9,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 	"io"
@@ -302,12 +300,7 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 
-				correlationMap := make(map[string]string)
-				err = json.Unmarshal(mqttMsg.Properties.CorrelationData, &correlationMap)
-				if err != nil {
-					slog.Warn("unmarshalling correlation map", "err", err)
-				}
-				requestContext := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(correlationMap))
+				requestContext := extractTraceContext(context.Background(), mqttMsg.Properties)
 
 				newCtx, span := s.tracer.Start(requestContext, fmt.Sprintf("%s/out/%s/# receive", s.mqttTopicPrefix, protocol),
 					trace.WithSpanKind(trace.SpanKindConsumer),
@@ -467,22 +460,19 @@ func publish(ctx context.Context, tracer trace.Tracer, mqttConn *autopaho.Connec
 		))
 	defer span.End()
 
-	correlationMap := make(map[string]string)
-	otel.GetTextMapPropagator().Inject(newCtx, propagation.MapCarrier(correlationMap))
-
-	correlationData, err := json.Marshal(correlationMap)
+	props := &paho.PublishProperties{
+		ContentType:   "application/json",
+		ResponseTopic: fmt.Sprintf("%s/out/%s/%s", topicPrefix, protocol, clientId),
+	}
+	err := injectTraceContext(newCtx, props)
 	if err != nil {
-		slog.Warn("marshalling correlation map: %v", err)
+		slog.Warn("adding trace context", "err", err)
 	}
 
 	_, err = mqttConn.Publish(newCtx, &paho.Publish{
-		Topic:   topic,
-		Payload: data,
-		Properties: &paho.PublishProperties{
-			ContentType:     "application/json",
-			ResponseTopic:   fmt.Sprintf("%s/out/%s/%s", topicPrefix, protocol, clientId),
-			CorrelationData: correlationData,
-		},
+		Topic:      topic,
+		Payload:    data,
+		Properties: props,
 	})
 
 	return err
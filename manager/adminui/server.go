@@ -196,7 +196,7 @@ func registerToken(ctx context.Context, engine store.Engine, uid string) error {
 	if err != nil {
 		return fmt.Errorf("emaid: %s: %v", "GBTWK", err)
 	}
-	return engine.SetToken(ctx, &store.Token{
+	return store.ReplaceToken(ctx, engine, &store.Token{
 		Uid:         uid,
 		CountryCode: "GB",
 		PartyId:     "TWK",
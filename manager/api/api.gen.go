@@ -15,23 +15,94 @@ import (
 	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 )
 
+// Defines values for BulkCommandAction.
+const (
+	BulkCommandActionChangeConfiguration BulkCommandAction = "ChangeConfiguration"
+	BulkCommandActionClearCache          BulkCommandAction = "ClearCache"
+	BulkCommandActionReset               BulkCommandAction = "Reset"
+	BulkCommandActionUpdateFirmware      BulkCommandAction = "UpdateFirmware"
+)
+
+// Defines values for BulkCommandResetType.
+const (
+	BulkCommandResetTypeImmediate BulkCommandResetType = "Immediate"
+	BulkCommandResetTypeOnIdle    BulkCommandResetType = "OnIdle"
+)
+
+// Defines values for BulkCommandStatus.
+const (
+	BulkCommandStatusCompleted BulkCommandStatus = "Completed"
+	BulkCommandStatusPaused    BulkCommandStatus = "Paused"
+	BulkCommandStatusRunning   BulkCommandStatus = "Running"
+)
+
+// Defines values for BulkCommandRequestAction.
+const (
+	BulkCommandRequestActionChangeConfiguration BulkCommandRequestAction = "ChangeConfiguration"
+	BulkCommandRequestActionClearCache          BulkCommandRequestAction = "ClearCache"
+	BulkCommandRequestActionReset               BulkCommandRequestAction = "Reset"
+	BulkCommandRequestActionUpdateFirmware      BulkCommandRequestAction = "UpdateFirmware"
+)
+
+// Defines values for BulkCommandRequestResetType.
+const (
+	BulkCommandRequestResetTypeImmediate BulkCommandRequestResetType = "Immediate"
+	BulkCommandRequestResetTypeOnIdle    BulkCommandRequestResetType = "OnIdle"
+)
+
+// Defines values for BulkCommandResultStatus.
+const (
+	BulkCommandResultStatusAccepted BulkCommandResultStatus = "Accepted"
+	BulkCommandResultStatusFailed   BulkCommandResultStatus = "Failed"
+	BulkCommandResultStatusPending  BulkCommandResultStatus = "Pending"
+	BulkCommandResultStatusSent     BulkCommandResultStatus = "Sent"
+)
+
+// Defines values for ChargeStationAvailabilityWindowStatus.
+const (
+	Charging    ChargeStationAvailabilityWindowStatus = "Charging"
+	Free        ChargeStationAvailabilityWindowStatus = "Free"
+	Reserved    ChargeStationAvailabilityWindowStatus = "Reserved"
+	Unavailable ChargeStationAvailabilityWindowStatus = "Unavailable"
+)
+
 // Defines values for ChargeStationInstallCertificatesCertificatesStatus.
 const (
-	Accepted ChargeStationInstallCertificatesCertificatesStatus = "Accepted"
-	Pending  ChargeStationInstallCertificatesCertificatesStatus = "Pending"
-	Rejected ChargeStationInstallCertificatesCertificatesStatus = "Rejected"
+	ChargeStationInstallCertificatesCertificatesStatusAccepted ChargeStationInstallCertificatesCertificatesStatus = "Accepted"
+	ChargeStationInstallCertificatesCertificatesStatusPending  ChargeStationInstallCertificatesCertificatesStatus = "Pending"
+	ChargeStationInstallCertificatesCertificatesStatusRejected ChargeStationInstallCertificatesCertificatesStatus = "Rejected"
 )
 
 // Defines values for ChargeStationInstallCertificatesCertificatesType.
 const (
-	CSMS ChargeStationInstallCertificatesCertificatesType = "CSMS"
-	MF   ChargeStationInstallCertificatesCertificatesType = "MF"
-	MO   ChargeStationInstallCertificatesCertificatesType = "MO"
-	V2G  ChargeStationInstallCertificatesCertificatesType = "V2G"
+	ChargeStationInstallCertificatesCertificatesTypeCSMS ChargeStationInstallCertificatesCertificatesType = "CSMS"
+	ChargeStationInstallCertificatesCertificatesTypeMF   ChargeStationInstallCertificatesCertificatesType = "MF"
+	ChargeStationInstallCertificatesCertificatesTypeMO   ChargeStationInstallCertificatesCertificatesType = "MO"
+	ChargeStationInstallCertificatesCertificatesTypeV2G  ChargeStationInstallCertificatesCertificatesType = "V2G"
+)
+
+// Defines values for ChargeStationInstalledCertificatesRefreshStatus.
+const (
+	ChargeStationInstalledCertificatesRefreshStatusAccepted ChargeStationInstalledCertificatesRefreshStatus = "Accepted"
+	ChargeStationInstalledCertificatesRefreshStatusPending  ChargeStationInstalledCertificatesRefreshStatus = "Pending"
+)
+
+// Defines values for ChargeStationLivenessStatus.
+const (
+	Offline ChargeStationLivenessStatus = "Offline"
+	Online  ChargeStationLivenessStatus = "Online"
+)
+
+// Defines values for ChargeStationRegistrationStatus.
+const (
+	ChargeStationRegistrationStatusAccepted ChargeStationRegistrationStatus = "Accepted"
+	ChargeStationRegistrationStatusPending  ChargeStationRegistrationStatus = "Pending"
+	ChargeStationRegistrationStatusRejected ChargeStationRegistrationStatus = "Rejected"
 )
 
 // Defines values for ChargeStationTriggerTrigger.
@@ -43,6 +114,15 @@ const (
 	StatusNotification             ChargeStationTriggerTrigger = "StatusNotification"
 )
 
+// Defines values for CommandAuditRecordStatus.
+const (
+	Completed CommandAuditRecordStatus = "Completed"
+	Failed    CommandAuditRecordStatus = "Failed"
+	NotSent   CommandAuditRecordStatus = "NotSent"
+	Requested CommandAuditRecordStatus = "Requested"
+	Sent      CommandAuditRecordStatus = "Sent"
+)
+
 // Defines values for ConnectorFormat.
 const (
 	CABLE  ConnectorFormat = "CABLE"
@@ -98,6 +178,35 @@ const (
 	UNKNOWN            ConnectorStandard = "UNKNOWN"
 )
 
+// Defines values for ExportFormat.
+const (
+	Csv  ExportFormat = "csv"
+	Json ExportFormat = "json"
+)
+
+// Defines values for InstalledCertificateHashAlgorithm.
+const (
+	SHA256 InstalledCertificateHashAlgorithm = "SHA256"
+	SHA384 InstalledCertificateHashAlgorithm = "SHA384"
+	SHA512 InstalledCertificateHashAlgorithm = "SHA512"
+)
+
+// Defines values for InstalledCertificateStatus.
+const (
+	DeleteFailed  InstalledCertificateStatus = "DeleteFailed"
+	DeletePending InstalledCertificateStatus = "DeletePending"
+	Installed     InstalledCertificateStatus = "Installed"
+)
+
+// Defines values for InstalledCertificateType.
+const (
+	InstalledCertificateTypeCSMS InstalledCertificateType = "CSMS"
+	InstalledCertificateTypeEVCC InstalledCertificateType = "EVCC"
+	InstalledCertificateTypeMF   InstalledCertificateType = "MF"
+	InstalledCertificateTypeMO   InstalledCertificateType = "MO"
+	InstalledCertificateTypeV2G  InstalledCertificateType = "V2G"
+)
+
 // Defines values for LocationParkingType.
 const (
 	ALONGMOTORWAY     LocationParkingType = "ALONG_MOTORWAY"
@@ -108,12 +217,84 @@ const (
 	UNDERGROUNDGARAGE LocationParkingType = "UNDERGROUND_GARAGE"
 )
 
+// Defines values for PriceComponentType.
+const (
+	ENERGY      PriceComponentType = "ENERGY"
+	FLAT        PriceComponentType = "FLAT"
+	PARKINGTIME PriceComponentType = "PARKING_TIME"
+	TIME        PriceComponentType = "TIME"
+)
+
+// Defines values for ReceiptFormat.
+const (
+	ReceiptFormatHtml ReceiptFormat = "html"
+	ReceiptFormatJson ReceiptFormat = "json"
+)
+
+// Defines values for ReceiptLineType.
+const (
+	ReceiptLineTypeEnergy      ReceiptLineType = "ENERGY"
+	ReceiptLineTypeFlat        ReceiptLineType = "FLAT"
+	ReceiptLineTypeParkingTime ReceiptLineType = "PARKING_TIME"
+	ReceiptLineTypeTime        ReceiptLineType = "TIME"
+	ReceiptLineTypeTotal       ReceiptLineType = "TOTAL"
+)
+
 // Defines values for RegistrationStatus.
 const (
 	PENDING    RegistrationStatus = "PENDING"
 	REGISTERED RegistrationStatus = "REGISTERED"
 )
 
+// Defines values for ReservationStatus.
+const (
+	ReservationStatusAccepted      ReservationStatus = "Accepted"
+	ReservationStatusCancelPending ReservationStatus = "CancelPending"
+	ReservationStatusCancelled     ReservationStatus = "Cancelled"
+	ReservationStatusPending       ReservationStatus = "Pending"
+	ReservationStatusRejected      ReservationStatus = "Rejected"
+	ReservationStatusUsed          ReservationStatus = "Used"
+)
+
+// Defines values for ReservationTokenType.
+const (
+	Central         ReservationTokenType = "Central"
+	EMAID           ReservationTokenType = "eMAID"
+	ISO14443        ReservationTokenType = "ISO14443"
+	ISO15693        ReservationTokenType = "ISO15693"
+	KeyCode         ReservationTokenType = "KeyCode"
+	Local           ReservationTokenType = "Local"
+	MacAddress      ReservationTokenType = "MacAddress"
+	NoAuthorization ReservationTokenType = "NoAuthorization"
+)
+
+// Defines values for ReservationTransferStatus.
+const (
+	ReservationTransferStatusAccepted ReservationTransferStatus = "Accepted"
+	ReservationTransferStatusPending  ReservationTransferStatus = "Pending"
+	ReservationTransferStatusRejected ReservationTransferStatus = "Rejected"
+)
+
+// Defines values for TariffType.
+const (
+	ADHOCPAYMENT TariffType = "AD_HOC_PAYMENT"
+	PROFILECHEAP TariffType = "PROFILE_CHEAP"
+	PROFILEFAST  TariffType = "PROFILE_FAST"
+	PROFILEGREEN TariffType = "PROFILE_GREEN"
+	REGULAR      TariffType = "REGULAR"
+)
+
+// Defines values for TariffRestrictionsDayOfWeek.
+const (
+	FRIDAY    TariffRestrictionsDayOfWeek = "FRIDAY"
+	MONDAY    TariffRestrictionsDayOfWeek = "MONDAY"
+	SATURDAY  TariffRestrictionsDayOfWeek = "SATURDAY"
+	SUNDAY    TariffRestrictionsDayOfWeek = "SUNDAY"
+	THURSDAY  TariffRestrictionsDayOfWeek = "THURSDAY"
+	TUESDAY   TariffRestrictionsDayOfWeek = "TUESDAY"
+	WEDNESDAY TariffRestrictionsDayOfWeek = "WEDNESDAY"
+)
+
 // Defines values for TokenCacheMode.
 const (
 	ALLOWED        TokenCacheMode = "ALLOWED"
@@ -130,12 +311,240 @@ const (
 	RFID      TokenType = "RFID"
 )
 
+// Defines values for TransactionStatus.
+const (
+	TransactionStatusActive TransactionStatus = "Active"
+	TransactionStatusEnded  TransactionStatus = "Ended"
+)
+
+// Defines values for StreamEventsParamsCategory.
+const (
+	StreamEventsParamsCategoryConnector   StreamEventsParamsCategory = "connector"
+	StreamEventsParamsCategoryReservation StreamEventsParamsCategory = "reservation"
+	StreamEventsParamsCategorySecurity    StreamEventsParamsCategory = "security"
+	StreamEventsParamsCategoryTransaction StreamEventsParamsCategory = "transaction"
+)
+
+// Defines values for ListOcppActionsParamsOcppVersion.
+const (
+	ListOcppActionsParamsOcppVersionOcpp16  ListOcppActionsParamsOcppVersion = "ocpp1.6"
+	ListOcppActionsParamsOcppVersionOcpp201 ListOcppActionsParamsOcppVersion = "ocpp2.0.1"
+	ListOcppActionsParamsOcppVersionOcpp21  ListOcppActionsParamsOcppVersion = "ocpp2.1"
+)
+
+// Defines values for UpdateOcppActionParamsOcppVersion.
+const (
+	UpdateOcppActionParamsOcppVersionOcpp16  UpdateOcppActionParamsOcppVersion = "ocpp1.6"
+	UpdateOcppActionParamsOcppVersionOcpp201 UpdateOcppActionParamsOcppVersion = "ocpp2.0.1"
+	UpdateOcppActionParamsOcppVersionOcpp21  UpdateOcppActionParamsOcppVersion = "ocpp2.1"
+)
+
+// Defines values for ListTransactionsParamsStatus.
+const (
+	ListTransactionsParamsStatusActive ListTransactionsParamsStatus = "Active"
+	ListTransactionsParamsStatusEnded  ListTransactionsParamsStatus = "Ended"
+)
+
+// BulkCommand A command sent to a fleet of charge stations
+type BulkCommand struct {
+	Action    BulkCommandAction `json:"action"`
+	CreatedAt time.Time         `json:"createdAt"`
+
+	// CreatedBy The caller that created the bulk command. Not set when the API does not require authentication.
+	CreatedBy            *string    `json:"createdBy,omitempty"`
+	FirmwareLocation     *string    `json:"firmwareLocation,omitempty"`
+	FirmwareRetrieveDate *time.Time `json:"firmwareRetrieveDate,omitempty"`
+
+	// Id The bulk command identifier
+	Id          string                `json:"id"`
+	Key         *string               `json:"key,omitempty"`
+	MaxInFlight int                   `json:"maxInFlight"`
+	ResetType   *BulkCommandResetType `json:"resetType,omitempty"`
+	Results     []BulkCommandResult   `json:"results"`
+
+	// Selector Selects the charge stations that a bulk command is sent to: fields that are not set match all charge stations
+	Selector BulkCommandSelector `json:"selector"`
+
+	// Status Completed once every charge station is Accepted or Failed
+	Status BulkCommandStatus `json:"status"`
+	Value  *string           `json:"value,omitempty"`
+}
+
+// BulkCommandAction defines model for BulkCommand.Action.
+type BulkCommandAction string
+
+// BulkCommandResetType defines model for BulkCommand.ResetType.
+type BulkCommandResetType string
+
+// BulkCommandStatus Completed once every charge station is Accepted or Failed
+type BulkCommandStatus string
+
+// BulkCommandRequest Request to send a command to a fleet of charge stations
+type BulkCommandRequest struct {
+	// Action The command to send
+	Action BulkCommandRequestAction `json:"action"`
+
+	// FirmwareLocation The URI of the firmware to install for an UpdateFirmware
+	FirmwareLocation *string `json:"firmwareLocation,omitempty"`
+
+	// FirmwareRetrieveDate When the charge stations retrieve the firmware for an UpdateFirmware, if not set they retrieve it when they are sent the command
+	FirmwareRetrieveDate *time.Time `json:"firmwareRetrieveDate,omitempty"`
+
+	// Key The configuration key to change for a ChangeConfiguration. OCPP 2.0.1 charge stations are sent a
+	// SetVariables request: the key is the component and variable name, as for the reconfigure operation.
+	Key *string `json:"key,omitempty"`
+
+	// MaxInFlight The maximum number of charge stations that have been sent the command and not yet responded
+	MaxInFlight *int `json:"maxInFlight,omitempty"`
+
+	// ResetType The type of a Reset. OCPP 1.6 charge stations are sent a Hard reset for Immediate and a Soft reset
+	// for OnIdle.
+	ResetType *BulkCommandRequestResetType `json:"resetType,omitempty"`
+
+	// Selector Selects the charge stations that a bulk command is sent to: fields that are not set match all charge stations
+	Selector BulkCommandSelector `json:"selector"`
+
+	// Value The configuration value to set for a ChangeConfiguration
+	Value *string `json:"value,omitempty"`
+}
+
+// BulkCommandRequestAction The command to send
+type BulkCommandRequestAction string
+
+// BulkCommandRequestResetType The type of a Reset. OCPP 1.6 charge stations are sent a Hard reset for Immediate and a Soft reset
+// for OnIdle.
+type BulkCommandRequestResetType string
+
+// BulkCommandResult The outcome of a bulk command for one of the charge stations
+type BulkCommandResult struct {
+	ChargeStationId string     `json:"chargeStationId"`
+	CompletedAt     *time.Time `json:"completedAt,omitempty"`
+
+	// Result The status returned by the charge station, or the reason the command failed
+	Result *string    `json:"result,omitempty"`
+	SentAt *time.Time `json:"sentAt,omitempty"`
+
+	// Status Pending until the command is sent to the charge station, then Sent until the charge station
+	// responds. Accepted when the charge station accepts the command. Failed when the command could not
+	// be sent, the charge station rejected it or the charge station did not respond.
+	Status BulkCommandResultStatus `json:"status"`
+}
+
+// BulkCommandResultStatus Pending until the command is sent to the charge station, then Sent until the charge station
+// responds. Accepted when the charge station accepts the command. Failed when the command could not
+// be sent, the charge station rejected it or the charge station did not respond.
+type BulkCommandResultStatus string
+
+// BulkCommandSelector Selects the charge stations that a bulk command is sent to: fields that are not set match all charge stations
+type BulkCommandSelector struct {
+	// FirmwareVersion Only select the charge stations running the firmware version reported in their last BootNotification
+	FirmwareVersion *string `json:"firmwareVersion,omitempty"`
+
+	// Model Only select the charge stations of the model
+	Model *string `json:"model,omitempty"`
+
+	// SiteId Only select the charge stations at the site or one of its sub-sites
+	SiteId *string `json:"siteId,omitempty"`
+
+	// Tags Only select the charge stations that have all the tags
+	Tags *[]string `json:"tags,omitempty"`
+
+	// Vendor Only select the charge stations from the vendor
+	Vendor *string `json:"vendor,omitempty"`
+}
+
 // Certificate A client certificate
 type Certificate struct {
 	// Certificate The PEM encoded certificate with newlines replaced by `\n`
 	Certificate string `json:"certificate"`
 }
 
+// ChargeDetailRecordExport A charge detail record as it is exported for billing
+type ChargeDetailRecordExport struct {
+	// ChargeStationId The charge station where the transaction took place
+	ChargeStationId string `json:"chargeStationId"`
+
+	// ConnectorId The connector that was used
+	ConnectorId *string `json:"connectorId,omitempty"`
+
+	// ContractId The contract id of the token
+	ContractId *string `json:"contractId,omitempty"`
+
+	// Currency The ISO 4217 currency of the cost
+	Currency *string `json:"currency,omitempty"`
+
+	// EndDateTime The time the transaction ended
+	EndDateTime time.Time `json:"endDateTime"`
+
+	// EvseId The EVSE id of the EVSE that was used
+	EvseId *string `json:"evseId,omitempty"`
+
+	// Id The id of the record
+	Id string `json:"id"`
+
+	// IdToken The id token that authorized the transaction
+	IdToken *string `json:"idToken,omitempty"`
+
+	// LocationId The location of the charge station
+	LocationId *string `json:"locationId,omitempty"`
+
+	// StartDateTime The time the transaction started
+	StartDateTime time.Time `json:"startDateTime"`
+
+	// TokenType The type of the id token
+	TokenType *string `json:"tokenType,omitempty"`
+
+	// TotalCost The cost of the transaction
+	TotalCost float64 `json:"totalCost"`
+
+	// TotalEnergyKwh The energy delivered (in kWh)
+	TotalEnergyKwh float64 `json:"totalEnergyKwh"`
+
+	// TotalTimeHours The duration of the transaction (in hours)
+	TotalTimeHours float64 `json:"totalTimeHours"`
+
+	// TransactionId The transaction that the record is for
+	TransactionId string `json:"transactionId"`
+}
+
+// ChargeStation A charge station in the registry
+type ChargeStation struct {
+	Coordinates *GeoLocation `json:"coordinates,omitempty"`
+
+	// FirmwareVersion The firmware version of the charge station
+	FirmwareVersion *string `json:"firmwareVersion,omitempty"`
+
+	// Id The charge station identifier
+	Id string `json:"id"`
+
+	// LastBoot The time that the last BootNotification was received from the charge station
+	LastBoot *time.Time `json:"lastBoot,omitempty"`
+
+	// Model The model of the charge station
+	Model *string `json:"model,omitempty"`
+
+	// OcppVersion The OCPP version the charge station last booted with
+	OcppVersion *string `json:"ocppVersion,omitempty"`
+
+	// SecurityProfile The security profile that the charge station was registered with: * `0` - unsecured transport with basic auth * `1` - TLS with basic auth * `2` - TLS with client certificate
+	SecurityProfile int `json:"securityProfile"`
+
+	// SerialNumber The serial number of the charge station
+	SerialNumber *string `json:"serialNumber,omitempty"`
+
+	// SiteId The identifier of the site where the charge station is installed
+	SiteId *string `json:"siteId,omitempty"`
+
+	// Tags The names of the tags assigned to the charge station
+	Tags *[]string `json:"tags,omitempty"`
+
+	// TariffId The identifier of the tariff used to price the charge station's transactions
+	TariffId *string `json:"tariffId,omitempty"`
+
+	// Vendor The vendor of the charge station
+	Vendor *string `json:"vendor,omitempty"`
+}
+
 // ChargeStationAuth Connection details for a charge station
 type ChargeStationAuth struct {
 	// Base64SHA256Password The base64 encoded, SHA-256 hash of the charge station password
@@ -148,6 +557,78 @@ type ChargeStationAuth struct {
 	SecurityProfile int `json:"securityProfile"`
 }
 
+// ChargeStationAvailability The availability calendar of an EVSE
+type ChargeStationAvailability struct {
+	// ConnectorId The connector identifier for OCPP 1.6 charge stations
+	ConnectorId *int `json:"connectorId,omitempty"`
+
+	// EvseId The EVSE identifier: 0 for OCPP 1.6 charge stations
+	EvseId int `json:"evseId"`
+
+	// Windows The windows that cover the period in order
+	Windows []ChargeStationAvailabilityWindow `json:"windows"`
+}
+
+// ChargeStationAvailabilityWindow A period during which the EVSE has the same status
+type ChargeStationAvailabilityWindow struct {
+	End    time.Time                             `json:"end"`
+	Start  time.Time                             `json:"start"`
+	Status ChargeStationAvailabilityWindowStatus `json:"status"`
+}
+
+// ChargeStationAvailabilityWindowStatus defines model for ChargeStationAvailabilityWindow.Status.
+type ChargeStationAvailabilityWindowStatus string
+
+// ChargeStationConnector A connector of an EVSE
+type ChargeStationConnector struct {
+	// ConnectorId The connector identifier
+	ConnectorId int `json:"connectorId"`
+
+	// ConnectorType The OCPP 2.0.1 connector type, e.g. cType2 or cCCS2
+	ConnectorType *string `json:"connectorType,omitempty"`
+
+	// MaxPower The maximum power that the connector can deliver in W
+	MaxPower *float64 `json:"maxPower,omitempty"`
+
+	// PhysicalLabel The label on the connector that a driver sees
+	PhysicalLabel *string `json:"physicalLabel,omitempty"`
+}
+
+// ChargeStationConnectorUpdate The metadata of a connector
+type ChargeStationConnectorUpdate struct {
+	// ConnectorType The OCPP 2.0.1 connector type, e.g. cType2 or cCCS2
+	ConnectorType *string `json:"connectorType,omitempty"`
+
+	// MaxPower The maximum power that the connector can deliver in W
+	MaxPower *float64 `json:"maxPower,omitempty"`
+
+	// PhysicalLabel The label on the connector that a driver sees
+	PhysicalLabel *string `json:"physicalLabel,omitempty"`
+}
+
+// ChargeStationDetails The operator maintained details of a charge station
+type ChargeStationDetails struct {
+	Coordinates *GeoLocation `json:"coordinates,omitempty"`
+
+	// SiteId The identifier of the site where the charge station is installed
+	SiteId *string `json:"siteId,omitempty"`
+
+	// TariffId The identifier of a registered tariff used to price the charge station's transactions: the default tariff is used if it is not set
+	TariffId *string `json:"tariffId,omitempty"`
+}
+
+// ChargeStationEvse An EVSE of a charge station
+type ChargeStationEvse struct {
+	// Connectors The connectors of the EVSE, ordered by id
+	Connectors []ChargeStationConnector `json:"connectors"`
+
+	// EvseId The EVSE identifier: 0 for OCPP 1.6 charge stations
+	EvseId int `json:"evseId"`
+
+	// LastUpdated The time the EVSE was last changed
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
 // ChargeStationInstallCertificates The set of certificates to install on the charge station. The certificates will be sent
 // to the charge station asynchronously.
 type ChargeStationInstallCertificates struct {
@@ -167,6 +648,41 @@ type ChargeStationInstallCertificatesCertificatesStatus string
 // ChargeStationInstallCertificatesCertificatesType defines model for ChargeStationInstallCertificates.Certificates.Type.
 type ChargeStationInstallCertificatesCertificatesType string
 
+// ChargeStationInstalledCertificates The certificates installed on a charge station
+type ChargeStationInstalledCertificates struct {
+	Certificates []InstalledCertificate `json:"certificates"`
+
+	// RefreshStatus The status of the most recent inventory refresh
+	RefreshStatus *ChargeStationInstalledCertificatesRefreshStatus `json:"refreshStatus,omitempty"`
+}
+
+// ChargeStationInstalledCertificatesRefreshStatus The status of the most recent inventory refresh
+type ChargeStationInstalledCertificatesRefreshStatus string
+
+// ChargeStationLiveness The liveness of a charge station
+type ChargeStationLiveness struct {
+	// LastHeartbeat The time that a Heartbeat was last received from the charge station
+	LastHeartbeat *time.Time `json:"lastHeartbeat,omitempty"`
+
+	// LastSeen The time that a message was last received from the charge station
+	LastSeen time.Time `json:"lastSeen"`
+
+	// Status Whether the charge station is considered to be online or offline
+	Status ChargeStationLivenessStatus `json:"status"`
+}
+
+// ChargeStationLivenessStatus Whether the charge station is considered to be online or offline
+type ChargeStationLivenessStatus string
+
+// ChargeStationRegistration The registration status of a charge station
+type ChargeStationRegistration struct {
+	// Status The status returned to the charge station in response to a BootNotification: * `Accepted` - the charge station is accepted by the CSMS * `Pending` - the charge station should boot again after the retry interval * `Rejected` - the charge station is not accepted by the CSMS
+	Status ChargeStationRegistrationStatus `json:"status"`
+}
+
+// ChargeStationRegistrationStatus The status returned to the charge station in response to a BootNotification: * `Accepted` - the charge station is accepted by the CSMS * `Pending` - the charge station should boot again after the retry interval * `Rejected` - the charge station is not accepted by the CSMS
+type ChargeStationRegistrationStatus string
+
 // ChargeStationSettings Settings for a charge station
 type ChargeStationSettings map[string]string
 
@@ -178,6 +694,93 @@ type ChargeStationTrigger struct {
 // ChargeStationTriggerTrigger defines model for ChargeStationTrigger.Trigger.
 type ChargeStationTriggerTrigger string
 
+// CommandAuditRecord A command initiated by the CSMS
+type CommandAuditRecord struct {
+	// ChargeStationId The charge station that the command is for
+	ChargeStationId string `json:"chargeStationId"`
+
+	// Command The API operation that was requested or the OCPP action that was sent
+	Command string `json:"command"`
+
+	// CompletedAt The time the charge station responded or the call could not be sent
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+
+	// Id The id of the entry: for a call it is the OCPP message id
+	Id string `json:"id"`
+
+	// OcppVersion The OCPP version of a call
+	OcppVersion *string `json:"ocppVersion,omitempty"`
+
+	// Request The request body of the API operation or the payload of the call, as JSON
+	Request *string `json:"request,omitempty"`
+
+	// RequestedAt The time the command was requested or the call was sent
+	RequestedAt time.Time `json:"requestedAt"`
+
+	// RequestedBy The caller that requested an API operation, or csms for a call sent by the CSMS. Not set when the
+	// API does not require authentication.
+	RequestedBy *string `json:"requestedBy,omitempty"`
+
+	// Result The CallResult payload as JSON, or the error the call failed with
+	Result *string `json:"result,omitempty"`
+
+	// Status Requested when the API accepted the command, which the CSMS will send to the charge station. For a
+	// call: Sent until the charge station responds, then Completed for a CallResult or Failed for a
+	// CallError. NotSent when the call could not be sent.
+	Status CommandAuditRecordStatus `json:"status"`
+}
+
+// CommandAuditRecordStatus Requested when the API accepted the command, which the CSMS will send to the charge station. For a
+// call: Sent until the charge station responds, then Completed for a CallResult or Failed for a
+// CallError. NotSent when the call could not be sent.
+type CommandAuditRecordStatus string
+
+// CommandSchedule A command that is sent to a fleet of charge stations at a time or on a cron schedule
+type CommandSchedule struct {
+	// Command Request to send a command to a fleet of charge stations
+	Command   BulkCommandRequest `json:"command"`
+	CreatedAt time.Time          `json:"createdAt"`
+
+	// CreatedBy The caller that created the schedule. Not set when the API does not require authentication.
+	CreatedBy *string `json:"createdBy,omitempty"`
+	Cron      *string `json:"cron,omitempty"`
+
+	// Id The command schedule identifier
+	Id string `json:"id"`
+
+	// LastBulkCommandId The bulk command created by the last run
+	LastBulkCommandId *string `json:"lastBulkCommandId,omitempty"`
+
+	// LastError Why the last run did not create a bulk command, e.g. because no charge stations were selected
+	LastError *string    `json:"lastError,omitempty"`
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+	Name      string     `json:"name"`
+
+	// NextRunAt When the schedule next runs: not set when it will not run again
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+	RunAt     *time.Time `json:"runAt,omitempty"`
+	TimeZone  *string    `json:"timeZone,omitempty"`
+}
+
+// CommandScheduleRequest Request to send a command to a fleet of charge stations at a time or on a cron schedule
+type CommandScheduleRequest struct {
+	// Command Request to send a command to a fleet of charge stations
+	Command BulkCommandRequest `json:"command"`
+
+	// Cron A cron expression with five fields (minute, hour, day of month, month and day of week) for a
+	// repeating schedule, e.g. `0 2 * * *` for every night at 02:00: one of runAt and cron must be set
+	Cron *string `json:"cron,omitempty"`
+
+	// Name A name that describes the schedule
+	Name string `json:"name"`
+
+	// RunAt When a schedule that runs once creates its bulk command: one of runAt and cron must be set
+	RunAt *time.Time `json:"runAt,omitempty"`
+
+	// TimeZone The IANA time zone that the cron expression is evaluated in, e.g. Europe/London. Defaults to UTC.
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
 // Connector defines model for Connector.
 type Connector struct {
 	Format      ConnectorFormat    `json:"format"`
@@ -197,6 +800,39 @@ type ConnectorPowerType string
 // ConnectorStandard defines model for Connector.Standard.
 type ConnectorStandard string
 
+// DashboardSummary Fleet KPIs for an operator dashboard
+type DashboardSummary struct {
+	// ActiveReservations The number of accepted reservations that have not expired
+	ActiveReservations int `json:"activeReservations"`
+
+	// ActiveTransactions The number of transactions that have started but not yet ended
+	ActiveTransactions int `json:"activeTransactions"`
+
+	// ChargeStations The number of charge stations that have connected to the CSMS
+	ChargeStations int `json:"chargeStations"`
+
+	// ChargeStationsOffline The number of charge stations that have connected to the CSMS but are now offline
+	ChargeStationsOffline int `json:"chargeStationsOffline"`
+
+	// ChargeStationsOnline The number of charge stations that are connected to the CSMS
+	ChargeStationsOnline int `json:"chargeStationsOnline"`
+
+	// CompletedTransactionsToday The number of transactions that have ended since midnight (UTC)
+	CompletedTransactionsToday int `json:"completedTransactionsToday"`
+
+	// EnergyDeliveredTodayWh The energy delivered (in Wh) by transactions that have ended since midnight (UTC)
+	EnergyDeliveredTodayWh float32 `json:"energyDeliveredTodayWh"`
+
+	// ExpiredReservations The number of accepted reservations that expired without being used
+	ExpiredReservations int `json:"expiredReservations"`
+
+	// FaultedConnectors The number of open faults: connectors whose last reported status is Faulted
+	FaultedConnectors int `json:"faultedConnectors"`
+
+	// GeneratedAt The time the summary was computed
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
 // Evse defines model for Evse.
 type Evse struct {
 	Connectors []Connector `json:"connectors"`
@@ -207,12 +843,67 @@ type Evse struct {
 	Uid string `json:"uid"`
 }
 
+// ExportFormat The format of an export
+type ExportFormat string
+
+// FleetStats Aggregates over the fleet of charge stations
+type FleetStats struct {
+	// ActiveReservations The number of accepted reservations that have not expired
+	ActiveReservations int `json:"activeReservations"`
+
+	// ActiveTransactions The number of transactions that have started but not yet ended
+	ActiveTransactions int `json:"activeTransactions"`
+
+	// ChargeStationsOffline The number of charge stations that have connected to the CSMS but are now offline
+	ChargeStationsOffline int `json:"chargeStationsOffline"`
+
+	// ChargeStationsOnline The number of charge stations that are connected to the CSMS
+	ChargeStationsOnline int `json:"chargeStationsOnline"`
+
+	// CompletedTransactionsToday The number of transactions that have ended since midnight (UTC)
+	CompletedTransactionsToday int `json:"completedTransactionsToday"`
+
+	// EnergyDeliveredTodayWh The energy delivered (in Wh) by transactions that have ended since midnight (UTC)
+	EnergyDeliveredTodayWh float32 `json:"energyDeliveredTodayWh"`
+
+	// FaultedConnectors The number of connectors whose last reported status is Faulted
+	FaultedConnectors int `json:"faultedConnectors"`
+
+	// GeneratedAt The time the statistics were computed
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
 // GeoLocation defines model for GeoLocation.
 type GeoLocation struct {
 	Latitude  string `json:"latitude"`
 	Longitude string `json:"longitude"`
 }
 
+// InstalledCertificate A certificate that is installed on a charge station
+type InstalledCertificate struct {
+	HashAlgorithm InstalledCertificateHashAlgorithm `json:"hashAlgorithm"`
+
+	// IssuerKeyHash The hash of the issuer public key
+	IssuerKeyHash string `json:"issuerKeyHash"`
+
+	// IssuerNameHash The hash of the issuer distinguished name
+	IssuerNameHash string `json:"issuerNameHash"`
+
+	// SerialNumber The serial number of the certificate
+	SerialNumber string                     `json:"serialNumber"`
+	Status       InstalledCertificateStatus `json:"status"`
+	Type         InstalledCertificateType   `json:"type"`
+}
+
+// InstalledCertificateHashAlgorithm defines model for InstalledCertificate.HashAlgorithm.
+type InstalledCertificateHashAlgorithm string
+
+// InstalledCertificateStatus defines model for InstalledCertificate.Status.
+type InstalledCertificateStatus string
+
+// InstalledCertificateType defines model for InstalledCertificate.Type.
+type InstalledCertificateType string
+
 // Location A charge station location
 type Location struct {
 	Address     string               `json:"address"`
@@ -230,36 +921,315 @@ type Location struct {
 // LocationParkingType defines model for Location.ParkingType.
 type LocationParkingType string
 
-// Registration Defines the initial connection details for the OCPI registration process
-type Registration struct {
-	// Status The status of the registration request. If the request is marked as `REGISTERED` then the token will be allowed to
-	// be used to access all endpoints avoiding the need for the OCPI registration process. If the request is marked as
-	// `PENDING` then the token will only be allowed to access the `/ocpi/versions`, `/ocpi/2.2` and `/ocpi/2.2/credentials`
-	// endpoints.
-	Status *RegistrationStatus `json:"status,omitempty"`
+// MeterValue The values sampled by a meter at a point in time
+type MeterValue struct {
+	SampledValues []SampledValue `json:"sampledValues"`
 
-	// Token The token to use for communicating with the eMSP (CREDENTIALS_TOKEN_A).
-	Token string `json:"token"`
+	// Timestamp The time the values were sampled
+	Timestamp time.Time `json:"timestamp"`
+}
 
-	// Url The URL of the eMSP versions endpoint. If provided the CSMS will act as the sender of the versions request.
-	Url *string `json:"url,omitempty"`
+// NearbyChargeStation A charge station found near a location
+type NearbyChargeStation struct {
+	// ChargeStation A charge station in the registry
+	ChargeStation ChargeStation `json:"chargeStation"`
+
+	// Distance The distance of the charge station from the location in m
+	Distance float64 `json:"distance"`
 }
 
-// RegistrationStatus The status of the registration request. If the request is marked as `REGISTERED` then the token will be allowed to
-// be used to access all endpoints avoiding the need for the OCPI registration process. If the request is marked as
-// `PENDING` then the token will only be allowed to access the `/ocpi/versions`, `/ocpi/2.2` and `/ocpi/2.2/credentials`
-// endpoints.
-type RegistrationStatus string
+// OcppAction An OCPP action that the CSMS handles
+type OcppAction struct {
+	// Action The OCPP action
+	Action string `json:"action"`
 
-// Status HTTP status
-type Status struct {
-	// Error The error details
-	Error *string `json:"error,omitempty"`
+	// Enabled Whether calls for the action are handled
+	Enabled bool `json:"enabled"`
+}
 
-	// Status The status description
-	Status string `json:"status"`
+// OcppActionUpdate Enables or disables an OCPP action
+type OcppActionUpdate struct {
+	// Enabled Whether calls for the action should be handled
+	Enabled bool `json:"enabled"`
 }
 
+// PriceComponent defines model for PriceComponent.
+type PriceComponent struct {
+	// Price Price per kWh for ENERGY, per hour for TIME and PARKING_TIME and per session for FLAT, excluding VAT
+	Price float64 `json:"price"`
+
+	// StepSize Minimum billing increment in Wh for ENERGY and in seconds for TIME and PARKING_TIME
+	StepSize int                `json:"step_size"`
+	Type     PriceComponentType `json:"type"`
+
+	// Vat Applicable VAT percentage
+	Vat *float64 `json:"vat"`
+}
+
+// PriceComponentType defines model for PriceComponent.Type.
+type PriceComponentType string
+
+// ReceiptEmailRequest defines model for ReceiptEmailRequest.
+type ReceiptEmailRequest struct {
+	// Email The address the receipt is sent to
+	Email openapi_types.Email `json:"email"`
+}
+
+// ReceiptFormat The format of a receipt
+type ReceiptFormat string
+
+// ReceiptLine A charge on a receipt
+type ReceiptLine struct {
+	Description string  `json:"description"`
+	NetAmount   float64 `json:"netAmount"`
+
+	// Quantity The energy or time charged for: it is not set for a fee
+	Quantity  *float64 `json:"quantity,omitempty"`
+	TaxAmount float64  `json:"taxAmount"`
+
+	// TaxRate The tax rate as a percentage
+	TaxRate float64 `json:"taxRate"`
+
+	// Type The dimension of the tariff that is charged for: `TOTAL` charges for the whole session when the tariff service does not itemise the cost
+	Type ReceiptLineType `json:"type"`
+
+	// Unit The unit of the quantity, `kWh` or `h`
+	Unit *string `json:"unit,omitempty"`
+}
+
+// ReceiptLineType The dimension of the tariff that is charged for: `TOTAL` charges for the whole session when the tariff service does not itemise the cost
+type ReceiptLineType string
+
+// Registration Defines the initial connection details for the OCPI registration process
+type Registration struct {
+	// Status The status of the registration request. If the request is marked as `REGISTERED` then the token will be allowed to
+	// be used to access all endpoints avoiding the need for the OCPI registration process. If the request is marked as
+	// `PENDING` then the token will only be allowed to access the `/ocpi/versions`, `/ocpi/2.2` and `/ocpi/2.2/credentials`
+	// endpoints.
+	Status *RegistrationStatus `json:"status,omitempty"`
+
+	// Token The token to use for communicating with the eMSP (CREDENTIALS_TOKEN_A).
+	Token string `json:"token"`
+
+	// Url The URL of the eMSP versions endpoint. If provided the CSMS will act as the sender of the versions request.
+	Url *string `json:"url,omitempty"`
+}
+
+// RegistrationStatus The status of the registration request. If the request is marked as `REGISTERED` then the token will be allowed to
+// be used to access all endpoints avoiding the need for the OCPI registration process. If the request is marked as
+// `PENDING` then the token will only be allowed to access the `/ocpi/versions`, `/ocpi/2.2` and `/ocpi/2.2/credentials`
+// endpoints.
+type RegistrationStatus string
+
+// Reservation A reservation of an EVSE for an idToken
+type Reservation struct {
+	// ChargeStationId The charge station holding the reservation (ignored on create)
+	ChargeStationId *string `json:"chargeStationId,omitempty"`
+
+	// Contact How the driver is notified about a reservation
+	Contact *ReservationContact `json:"contact,omitempty"`
+
+	// EvseId The EVSE to reserve, if not set any EVSE on the charge station may be used
+	EvseId *int `json:"evseId,omitempty"`
+
+	// ExpiryDate The date and time at which the reservation expires
+	ExpiryDate time.Time `json:"expiryDate"`
+
+	// Id The reservation identifier
+	Id int `json:"id"`
+
+	// IdToken The idToken the reservation is made for
+	IdToken string `json:"idToken"`
+
+	// NoShow Whether the reservation was cancelled because no transaction used it (ignored on create)
+	NoShow *bool `json:"noShow,omitempty"`
+
+	// NoShowFee The fee charged for a reservation that was not used
+	NoShowFee *ReservationFee `json:"noShowFee,omitempty"`
+
+	// StartDate The date and time at which the booked time slot starts, if not set the reservation starts immediately
+	StartDate *time.Time `json:"startDate,omitempty"`
+
+	// Status The status of the reservation (ignored on create)
+	Status *ReservationStatus `json:"status,omitempty"`
+
+	// TokenType The type of the idToken
+	TokenType ReservationTokenType `json:"tokenType"`
+
+	// Transfer The most recent transfer of a reservation
+	Transfer *ReservationTransfer `json:"transfer,omitempty"`
+}
+
+// ReservationStatus The status of the reservation (ignored on create)
+type ReservationStatus string
+
+// ReservationTokenType The type of the idToken
+type ReservationTokenType string
+
+// ReservationContact How the driver is notified about a reservation
+type ReservationContact struct {
+	// Email The email address of the driver
+	Email *string `json:"email,omitempty"`
+
+	// Phone The phone number of the driver in E.164 format
+	Phone *string `json:"phone,omitempty"`
+}
+
+// ReservationFee The fee charged for a reservation that was not used
+type ReservationFee struct {
+	// Amount The amount of the fee
+	Amount float64 `json:"amount"`
+
+	// Currency The ISO 4217 currency code of the fee
+	Currency string `json:"currency"`
+}
+
+// ReservationTransfer The most recent transfer of a reservation
+type ReservationTransfer struct {
+	// ChargeStationId The charge station the reservation is being transferred to
+	ChargeStationId string `json:"chargeStationId"`
+
+	// EvseId The EVSE the reservation is being transferred to
+	EvseId *int `json:"evseId,omitempty"`
+
+	// Status The status of the transfer
+	Status ReservationTransferStatus `json:"status"`
+}
+
+// ReservationTransferStatus The status of the transfer
+type ReservationTransferStatus string
+
+// ReservationTransferRequest Request to transfer a reservation
+type ReservationTransferRequest struct {
+	// ChargeStationId The charge station to transfer the reservation to, this may be the current charge station
+	ChargeStationId string `json:"chargeStationId"`
+
+	// EvseId The EVSE to transfer the reservation to, if not set any EVSE on the charge station may be used
+	EvseId *int `json:"evseId,omitempty"`
+}
+
+// SampledValue A single value sampled by a meter
+type SampledValue struct {
+	// Context The reason the value was sampled, e.g. `Transaction.End`
+	Context *string `json:"context,omitempty"`
+
+	// Location Where the value was measured, e.g. `Outlet`
+	Location *string `json:"location,omitempty"`
+
+	// Measurand What was measured, e.g. `Energy.Active.Import.Register`
+	Measurand *string `json:"measurand,omitempty"`
+
+	// Multiplier The power of ten the value is multiplied by
+	Multiplier *int `json:"multiplier,omitempty"`
+
+	// Phase The phase that was measured
+	Phase *string `json:"phase,omitempty"`
+
+	// Unit The unit of the value, e.g. `Wh`
+	Unit *string `json:"unit,omitempty"`
+
+	// Value The sampled value
+	Value float32 `json:"value"`
+}
+
+// Site A site where charge stations are installed
+type Site struct {
+	// Id The site identifier
+	Id string `json:"id"`
+
+	// Name The name of the site
+	Name string `json:"name"`
+
+	// ParentSiteId The site that contains this site: not set for a site at the top of the hierarchy
+	ParentSiteId *string `json:"parentSiteId,omitempty"`
+}
+
+// SiteDetails The details of a site
+type SiteDetails struct {
+	// Name The name of the site
+	Name string `json:"name"`
+
+	// ParentSiteId The site that contains this site: not set for a site at the top of the hierarchy
+	ParentSiteId *string `json:"parentSiteId,omitempty"`
+}
+
+// Status HTTP status
+type Status struct {
+	// Error The error details
+	Error *string `json:"error,omitempty"`
+
+	// Status The status description
+	Status string `json:"status"`
+}
+
+// Tag A tag that groups charge stations
+type Tag struct {
+	// Description What the charge stations with the tag have in common
+	Description *string `json:"description,omitempty"`
+
+	// Name The tag name
+	Name string `json:"name"`
+}
+
+// TagDetails The details of a tag
+type TagDetails struct {
+	// Description What the charge stations with the tag have in common
+	Description *string `json:"description,omitempty"`
+}
+
+// Tariff An OCPI tariff
+type Tariff struct {
+	// Currency ISO-4217 code of the currency of the tariff
+	Currency string          `json:"currency"`
+	Elements []TariffElement `json:"elements"`
+
+	// EndDateTime The time until which the tariff is valid
+	EndDateTime *time.Time `json:"end_date_time"`
+
+	// StartDateTime The time from which the tariff is valid
+	StartDateTime *time.Time  `json:"start_date_time"`
+	Type          *TariffType `json:"type"`
+}
+
+// TariffType defines model for Tariff.Type.
+type TariffType string
+
+// TariffElement defines model for TariffElement.
+type TariffElement struct {
+	PriceComponents []PriceComponent `json:"price_components"`
+
+	// Restrictions Conditions that must be met for a tariff element to apply
+	Restrictions *TariffRestrictions `json:"restrictions,omitempty"`
+}
+
+// TariffRestrictions Conditions that must be met for a tariff element to apply
+type TariffRestrictions struct {
+	DayOfWeek *[]TariffRestrictionsDayOfWeek `json:"day_of_week"`
+
+	// EndDate Local date (YYYY-MM-DD) until which the element applies
+	EndDate *string `json:"end_date"`
+
+	// EndTime Local time of day (HH:MM) until which the element applies
+	EndTime *string `json:"end_time"`
+
+	// MaxDuration Maximum duration of the session in seconds
+	MaxDuration *int     `json:"max_duration"`
+	MaxKwh      *float64 `json:"max_kwh"`
+
+	// MinDuration Minimum duration of the session in seconds
+	MinDuration *int     `json:"min_duration"`
+	MinKwh      *float64 `json:"min_kwh"`
+
+	// StartDate Local date (YYYY-MM-DD) from which the element applies
+	StartDate *string `json:"start_date"`
+
+	// StartTime Local time of day (HH:MM) from which the element applies
+	StartTime *string `json:"start_time"`
+}
+
+// TariffRestrictionsDayOfWeek defines model for TariffRestrictions.DayOfWeek.
+type TariffRestrictionsDayOfWeek string
+
 // Token An authorization token
 type Token struct {
 	// CacheMode Indicates what type of token caching is allowed
@@ -289,7 +1259,7 @@ type Token struct {
 	// Type The type of token
 	Type TokenType `json:"type"`
 
-	// Uid The unique token id
+	// Uid The unique token id: the hexadecimal UID for RFID tokens, or sha256: followed by the hexadecimal SHA-256 hash of the id token for tokens registered by their hash
 	Uid string `json:"uid"`
 
 	// Valid Is this token valid
@@ -305,38 +1275,398 @@ type TokenCacheMode string
 // TokenType The type of token
 type TokenType string
 
+// Transaction A charging transaction
+type Transaction struct {
+	// ChargeStationId The charge station where the transaction took place
+	ChargeStationId string `json:"chargeStationId"`
+
+	// Cost The itemised cost of a transaction, excluding VAT
+	Cost *TransactionCost `json:"cost,omitempty"`
+
+	// IdToken The id token (OCPP 1.6 idTag) that authorized the transaction
+	IdToken     *string      `json:"idToken,omitempty"`
+	MeterValues []MeterValue `json:"meterValues"`
+
+	// Offline Whether the transaction was started while the charge station was offline
+	Offline bool `json:"offline"`
+
+	// StartTime The time of the transaction's first meter value
+	StartTime *time.Time `json:"startTime,omitempty"`
+
+	// Status Whether the charge station has reported the end of the transaction
+	Status TransactionStatus `json:"status"`
+
+	// TokenType The type of the id token
+	TokenType *string `json:"tokenType,omitempty"`
+
+	// TransactionId The transaction id assigned by the charge station or the CSMS
+	TransactionId string `json:"transactionId"`
+}
+
+// TransactionStatus Whether the charge station has reported the end of the transaction
+type TransactionStatus string
+
+// TransactionCost The itemised cost of a transaction, excluding VAT
+type TransactionCost struct {
+	// Currency The ISO 4217 currency of the costs
+	Currency *string `json:"currency,omitempty"`
+
+	// EnergyCost The cost of the energy delivered
+	EnergyCost float64 `json:"energyCost"`
+
+	// ParkingCost The cost of the time spent parked without charging
+	ParkingCost float64                 `json:"parkingCost"`
+	Periods     []TransactionCostPeriod `json:"periods"`
+
+	// SessionFee The fee charged per transaction
+	SessionFee float64 `json:"sessionFee"`
+
+	// TariffId The registered tariff that priced the transaction: it is not set for the default tariff
+	TariffId *string `json:"tariffId,omitempty"`
+
+	// TimeCost The cost of the time spent charging
+	TimeCost float64 `json:"timeCost"`
+
+	// TotalCost The total cost of the transaction
+	TotalCost float64 `json:"totalCost"`
+}
+
+// TransactionCostPeriod A part of a transaction during which prices do not change, e.g. a time-of-use band
+type TransactionCostPeriod struct {
+	// ChargingHours The time spent charging in hours
+	ChargingHours float64 `json:"chargingHours"`
+
+	// Cost The cost of the period, including any rounding up to the tariff's step size
+	Cost    float64   `json:"cost"`
+	EndTime time.Time `json:"endTime"`
+
+	// EnergyKwh The energy delivered in kWh
+	EnergyKwh float64 `json:"energyKwh"`
+
+	// EnergyPrice The price per kWh
+	EnergyPrice float64 `json:"energyPrice"`
+
+	// ParkingHours The time spent parked without charging in hours
+	ParkingHours float64 `json:"parkingHours"`
+
+	// ParkingPrice The price per hour of parking
+	ParkingPrice float64   `json:"parkingPrice"`
+	StartTime    time.Time `json:"startTime"`
+
+	// TimePrice The price per hour of charging
+	TimePrice float64 `json:"timePrice"`
+}
+
+// TransactionExport A transaction as it is exported for billing
+type TransactionExport struct {
+	// ChargeStationId The charge station where the transaction took place
+	ChargeStationId string `json:"chargeStationId"`
+
+	// EndTime The time of the outlet energy reading taken when the transaction ended
+	EndTime *time.Time `json:"endTime,omitempty"`
+
+	// EnergyRegisterWh The outlet energy register (in Wh) when the transaction ended, as used for billing
+	EnergyRegisterWh *float64 `json:"energyRegisterWh,omitempty"`
+
+	// IdToken The id token (OCPP 1.6 idTag) that authorized the transaction
+	IdToken *string `json:"idToken,omitempty"`
+
+	// Offline Whether the transaction was started while the charge station was offline
+	Offline bool `json:"offline"`
+
+	// StartTime The time of the transaction's first meter value
+	StartTime *time.Time `json:"startTime,omitempty"`
+
+	// Status Whether the charge station has reported the end of the transaction, Active or Ended
+	Status string `json:"status"`
+
+	// TokenType The type of the id token
+	TokenType *string `json:"tokenType,omitempty"`
+
+	// TransactionId The transaction id assigned by the charge station or the CSMS
+	TransactionId string `json:"transactionId"`
+}
+
+// TransactionReceipt The receipt of a charging session. Amounts are rounded to two decimal places.
+type TransactionReceipt struct {
+	ChargeStationId string `json:"chargeStationId"`
+
+	// Currency The ISO 4217 currency of the amounts: it is not set if the transaction could not be priced
+	Currency *string `json:"currency,omitempty"`
+
+	// DurationSeconds The duration of the session
+	DurationSeconds int       `json:"durationSeconds"`
+	EndTime         time.Time `json:"endTime"`
+
+	// EnergyKwh The energy delivered in kWh
+	EnergyKwh float64 `json:"energyKwh"`
+
+	// IdToken The id token (OCPP 1.6 idTag) that authorized the transaction
+	IdToken *string       `json:"idToken,omitempty"`
+	Lines   []ReceiptLine `json:"lines"`
+
+	// NetTotal The total excluding tax
+	NetTotal float64 `json:"netTotal"`
+
+	// Seller The operator that issued the receipt
+	Seller    *string   `json:"seller,omitempty"`
+	StartTime time.Time `json:"startTime"`
+
+	// TariffId The registered tariff that priced the transaction: it is not set for the default tariff
+	TariffId *string `json:"tariffId,omitempty"`
+	TaxTotal float64 `json:"taxTotal"`
+
+	// Total The total including tax
+	Total         float64 `json:"total"`
+	TransactionId string  `json:"transactionId"`
+}
+
+// ExportChargeDetailRecordsParams defines parameters for ExportChargeDetailRecords.
+type ExportChargeDetailRecordsParams struct {
+	// Format The format of the export, defaults to csv
+	Format *ExportFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// From Only export records of transactions that ended at or after the time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only export records of transactions that ended before the time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListCommandAuditRecordsParams defines parameters for ListCommandAuditRecords.
+type ListCommandAuditRecordsParams struct {
+	// ChargeStationId Only return commands for the charge station
+	ChargeStationId *string `form:"chargeStationId,omitempty" json:"chargeStationId,omitempty"`
+
+	// Command Only return the API operation or OCPP action
+	Command *string `form:"command,omitempty" json:"command,omitempty"`
+
+	// From Only return commands requested at or after the time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return commands requested before the time
+	To     *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	Offset *int       `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int       `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListCommandSchedulesParams defines parameters for ListCommandSchedules.
+type ListCommandSchedulesParams struct {
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListChargeStationsParams defines parameters for ListChargeStations.
+type ListChargeStationsParams struct {
+	// SiteId Only include the charge stations assigned to the site or one of its sub-sites
+	SiteId *string `form:"siteId,omitempty" json:"siteId,omitempty"`
+
+	// Tag Only include the charge stations that have the tag
+	Tag    *string `form:"tag,omitempty" json:"tag,omitempty"`
+	Offset *int    `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListChargeStationsNearbyParams defines parameters for ListChargeStationsNearby.
+type ListChargeStationsNearbyParams struct {
+	// Lat The latitude of the location in degrees
+	Lat float64 `form:"lat" json:"lat"`
+
+	// Lng The longitude of the location in degrees
+	Lng float64 `form:"lng" json:"lng"`
+
+	// Radius The radius of the search in m
+	Radius float64 `form:"radius" json:"radius"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetChargeStationAvailabilityParams defines parameters for GetChargeStationAvailability.
+type GetChargeStationAvailabilityParams struct {
+	// From The start of the period: defaults to now, and a time in the past is treated as now
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To The end of the period: defaults to 24 hours after the start and can be at most 7 days after it
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetDashboardSummaryParams defines parameters for GetDashboardSummary.
+type GetDashboardSummaryParams struct {
+	// SiteId Only include the charge stations assigned to the site or one of its sub-sites
+	SiteId *string `form:"siteId,omitempty" json:"siteId,omitempty"`
+
+	// Tag Only include the charge stations that have the tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// Category Only stream events in the categories
+	Category *[]StreamEventsParamsCategory `form:"category,omitempty" json:"category,omitempty"`
+
+	// LastEventId Resume the stream after the event with this id
+	LastEventId *string `form:"lastEventId,omitempty" json:"lastEventId,omitempty"`
+
+	// LastEventID Resume the stream after the event with this id: takes precedence over lastEventId
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// StreamEventsParamsCategory defines parameters for StreamEvents.
+type StreamEventsParamsCategory string
+
+// ListOcppActionsParamsOcppVersion defines parameters for ListOcppActions.
+type ListOcppActionsParamsOcppVersion string
+
+// UpdateOcppActionParamsOcppVersion defines parameters for UpdateOcppAction.
+type UpdateOcppActionParamsOcppVersion string
+
+// ListSitesParams defines parameters for ListSites.
+type ListSitesParams struct {
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetFleetStatsParams defines parameters for GetFleetStats.
+type GetFleetStatsParams struct {
+	// SiteId Only include the charge stations assigned to the site or one of its sub-sites
+	SiteId *string `form:"siteId,omitempty" json:"siteId,omitempty"`
+
+	// Tag Only include the charge stations that have the tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// ListTagsParams defines parameters for ListTags.
+type ListTagsParams struct {
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // ListTokensParams defines parameters for ListTokens.
 type ListTokensParams struct {
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// RevokeTokenParams defines parameters for RevokeToken.
+type RevokeTokenParams struct {
+	// IfMatch The ETag of the version of the token that is being revoked
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// UpdateTokenParams defines parameters for UpdateToken.
+type UpdateTokenParams struct {
+	// IfMatch The ETag of the version of the token that is being updated
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// ListTransactionsParams defines parameters for ListTransactions.
+type ListTransactionsParams struct {
+	// ChargeStationId Only return transactions at the charge station
+	ChargeStationId *string `form:"chargeStationId,omitempty" json:"chargeStationId,omitempty"`
+
+	// IdToken Only return transactions authorized by the id token (OCPP 1.6 idTag)
+	IdToken *string `form:"idToken,omitempty" json:"idToken,omitempty"`
+
+	// From Only return transactions that started at or after the time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return transactions that started before the time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Status Only return transactions with the status
+	Status *ListTransactionsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Offset *int                          `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int                          `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListTransactionsParamsStatus defines parameters for ListTransactions.
+type ListTransactionsParamsStatus string
+
+// ExportTransactionsParams defines parameters for ExportTransactions.
+type ExportTransactionsParams struct {
+	// Format The format of the export, defaults to csv
+	Format *ExportFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// From Only export transactions that started at or after the time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only export transactions that started before the time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetTransactionReceiptParams defines parameters for GetTransactionReceipt.
+type GetTransactionReceiptParams struct {
+	// Format The format of the receipt, defaults to json
+	Format *ReceiptFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
 // UploadCertificateJSONRequestBody defines body for UploadCertificate for application/json ContentType.
 type UploadCertificateJSONRequestBody = Certificate
 
+// CreateBulkCommandJSONRequestBody defines body for CreateBulkCommand for application/json ContentType.
+type CreateBulkCommandJSONRequestBody = BulkCommandRequest
+
+// CreateCommandScheduleJSONRequestBody defines body for CreateCommandSchedule for application/json ContentType.
+type CreateCommandScheduleJSONRequestBody = CommandScheduleRequest
+
 // RegisterChargeStationJSONRequestBody defines body for RegisterChargeStation for application/json ContentType.
 type RegisterChargeStationJSONRequestBody = ChargeStationAuth
 
+// UpdateChargeStationJSONRequestBody defines body for UpdateChargeStation for application/json ContentType.
+type UpdateChargeStationJSONRequestBody = ChargeStationDetails
+
 // InstallChargeStationCertificatesJSONRequestBody defines body for InstallChargeStationCertificates for application/json ContentType.
 type InstallChargeStationCertificatesJSONRequestBody = ChargeStationInstallCertificates
 
+// UpdateChargeStationConnectorJSONRequestBody defines body for UpdateChargeStationConnector for application/json ContentType.
+type UpdateChargeStationConnectorJSONRequestBody = ChargeStationConnectorUpdate
+
 // ReconfigureChargeStationJSONRequestBody defines body for ReconfigureChargeStation for application/json ContentType.
 type ReconfigureChargeStationJSONRequestBody = ChargeStationSettings
 
+// SetChargeStationRegistrationJSONRequestBody defines body for SetChargeStationRegistration for application/json ContentType.
+type SetChargeStationRegistrationJSONRequestBody = ChargeStationRegistration
+
+// CreateReservationJSONRequestBody defines body for CreateReservation for application/json ContentType.
+type CreateReservationJSONRequestBody = Reservation
+
 // TriggerChargeStationJSONRequestBody defines body for TriggerChargeStation for application/json ContentType.
 type TriggerChargeStationJSONRequestBody = ChargeStationTrigger
 
 // RegisterLocationJSONRequestBody defines body for RegisterLocation for application/json ContentType.
 type RegisterLocationJSONRequestBody = Location
 
+// UpdateOcppActionJSONRequestBody defines body for UpdateOcppAction for application/json ContentType.
+type UpdateOcppActionJSONRequestBody = OcppActionUpdate
+
 // RegisterPartyJSONRequestBody defines body for RegisterParty for application/json ContentType.
 type RegisterPartyJSONRequestBody = Registration
 
+// TransferReservationJSONRequestBody defines body for TransferReservation for application/json ContentType.
+type TransferReservationJSONRequestBody = ReservationTransferRequest
+
+// RegisterSiteJSONRequestBody defines body for RegisterSite for application/json ContentType.
+type RegisterSiteJSONRequestBody = SiteDetails
+
+// RegisterTagJSONRequestBody defines body for RegisterTag for application/json ContentType.
+type RegisterTagJSONRequestBody = TagDetails
+
+// RegisterTariffJSONRequestBody defines body for RegisterTariff for application/json ContentType.
+type RegisterTariffJSONRequestBody = Tariff
+
 // SetTokenJSONRequestBody defines body for SetToken for application/json ContentType.
 type SetTokenJSONRequestBody = Token
 
+// UpdateTokenJSONRequestBody defines body for UpdateToken for application/json ContentType.
+type UpdateTokenJSONRequestBody = Token
+
+// EmailTransactionReceiptJSONRequestBody defines body for EmailTransactionReceipt for application/json ContentType.
+type EmailTransactionReceiptJSONRequestBody = ReceiptEmailRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Export charge detail records
+	// (GET /cdrs/export)
+	ExportChargeDetailRecords(w http.ResponseWriter, r *http.Request, params ExportChargeDetailRecordsParams)
 	// Upload a certificate
 	// (POST /certificate)
 	UploadCertificate(w http.ResponseWriter, r *http.Request)
@@ -346,36 +1676,189 @@ type ServerInterface interface {
 	// Lookup a certificate
 	// (GET /certificate/{certificateHash})
 	LookupCertificate(w http.ResponseWriter, r *http.Request, certificateHash string)
+	// List the command audit log
+	// (GET /commands)
+	ListCommandAuditRecords(w http.ResponseWriter, r *http.Request, params ListCommandAuditRecordsParams)
+	// Send a command to a fleet of charge stations
+	// (POST /commands/bulk)
+	CreateBulkCommand(w http.ResponseWriter, r *http.Request)
+	// Lookup a bulk command
+	// (GET /commands/bulk/{bulkCommandId})
+	LookupBulkCommand(w http.ResponseWriter, r *http.Request, bulkCommandId string)
+	// Pause a bulk command
+	// (POST /commands/bulk/{bulkCommandId}/pause)
+	PauseBulkCommand(w http.ResponseWriter, r *http.Request, bulkCommandId string)
+	// Resume a bulk command
+	// (POST /commands/bulk/{bulkCommandId}/resume)
+	ResumeBulkCommand(w http.ResponseWriter, r *http.Request, bulkCommandId string)
+	// List command schedules
+	// (GET /commands/schedule)
+	ListCommandSchedules(w http.ResponseWriter, r *http.Request, params ListCommandSchedulesParams)
+	// Schedule a command for a fleet of charge stations
+	// (POST /commands/schedule)
+	CreateCommandSchedule(w http.ResponseWriter, r *http.Request)
+	// Delete a command schedule
+	// (DELETE /commands/schedule/{scheduleId})
+	DeleteCommandSchedule(w http.ResponseWriter, r *http.Request, scheduleId string)
+	// Lookup a command schedule
+	// (GET /commands/schedule/{scheduleId})
+	LookupCommandSchedule(w http.ResponseWriter, r *http.Request, scheduleId string)
+	// List charge stations
+	// (GET /cs)
+	ListChargeStations(w http.ResponseWriter, r *http.Request, params ListChargeStationsParams)
+	// Find the charge stations near a location
+	// (GET /cs/nearby)
+	ListChargeStationsNearby(w http.ResponseWriter, r *http.Request, params ListChargeStationsNearbyParams)
+	// Delete a charge station
+	// (DELETE /cs/{csId})
+	DeleteChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	// Lookup a charge station
+	// (GET /cs/{csId})
+	LookupChargeStation(w http.ResponseWriter, r *http.Request, csId string)
 	// Register a new charge station
 	// (POST /cs/{csId})
 	RegisterChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	// Update a charge station
+	// (PUT /cs/{csId})
+	UpdateChargeStation(w http.ResponseWriter, r *http.Request, csId string)
 	// Returns the authentication details
 	// (GET /cs/{csId}/auth)
 	LookupChargeStationAuth(w http.ResponseWriter, r *http.Request, csId string)
+	// Get the availability calendar of the charge station
+	// (GET /cs/{csId}/availability)
+	GetChargeStationAvailability(w http.ResponseWriter, r *http.Request, csId string, params GetChargeStationAvailabilityParams)
 	// Install certificates on the charge station
 	// (POST /cs/{csId}/certificates)
 	InstallChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string)
+	// List the certificates installed on the charge station
+	// (GET /cs/{csId}/certificates/installed)
+	ListInstalledChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string)
+	// Refresh the installed certificate inventory
+	// (POST /cs/{csId}/certificates/installed)
+	RefreshInstalledChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string)
+	// Delete a certificate installed on the charge station
+	// (DELETE /cs/{csId}/certificates/installed/{serialNumber})
+	DeleteInstalledChargeStationCertificate(w http.ResponseWriter, r *http.Request, csId string, serialNumber string)
+	// List the EVSEs of the charge station
+	// (GET /cs/{csId}/evses)
+	ListChargeStationEvses(w http.ResponseWriter, r *http.Request, csId string)
+	// Set the metadata of a connector
+	// (PUT /cs/{csId}/evses/{evseId}/connectors/{connectorId})
+	UpdateChargeStationConnector(w http.ResponseWriter, r *http.Request, csId string, evseId int, connectorId int)
+	// Returns the liveness of the charge station
+	// (GET /cs/{csId}/liveness)
+	LookupChargeStationLiveness(w http.ResponseWriter, r *http.Request, csId string)
 	// Reconfigure the charge station
 	// (POST /cs/{csId}/reconfigure)
 	ReconfigureChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	// Returns the registration status of the charge station
+	// (GET /cs/{csId}/registration)
+	LookupChargeStationRegistration(w http.ResponseWriter, r *http.Request, csId string)
+	// Sets the registration status of the charge station
+	// (POST /cs/{csId}/registration)
+	SetChargeStationRegistration(w http.ResponseWriter, r *http.Request, csId string)
+	// Reserve an EVSE on the charge station
+	// (POST /cs/{csId}/reservation)
+	CreateReservation(w http.ResponseWriter, r *http.Request, csId string)
 
 	// (POST /cs/{csId}/trigger)
 	TriggerChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	// Operator dashboard summary
+	// (GET /dashboard)
+	GetDashboardSummary(w http.ResponseWriter, r *http.Request, params GetDashboardSummaryParams)
+	// Stream events
+	// (GET /events)
+	StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams)
 	// Registers a location with the CSMS
 	// (POST /location/{locationId})
 	RegisterLocation(w http.ResponseWriter, r *http.Request, locationId string)
+	// List the OCPP actions
+	// (GET /ocpp/{ocppVersion}/actions)
+	ListOcppActions(w http.ResponseWriter, r *http.Request, ocppVersion ListOcppActionsParamsOcppVersion)
+	// Enable or disable an OCPP action
+	// (PUT /ocpp/{ocppVersion}/actions/{action})
+	UpdateOcppAction(w http.ResponseWriter, r *http.Request, ocppVersion UpdateOcppActionParamsOcppVersion, action string)
 	// Registers an OCPI party with the CSMS
 	// (POST /register)
 	RegisterParty(w http.ResponseWriter, r *http.Request)
+	// Cancel a reservation
+	// (DELETE /reservation/{reservationId})
+	CancelReservation(w http.ResponseWriter, r *http.Request, reservationId int)
+	// Lookup a reservation
+	// (GET /reservation/{reservationId})
+	LookupReservation(w http.ResponseWriter, r *http.Request, reservationId int)
+	// Transfer a reservation
+	// (POST /reservation/{reservationId}/transfer)
+	TransferReservation(w http.ResponseWriter, r *http.Request, reservationId int)
+	// List sites
+	// (GET /site)
+	ListSites(w http.ResponseWriter, r *http.Request, params ListSitesParams)
+	// Delete a site
+	// (DELETE /site/{siteId})
+	DeleteSite(w http.ResponseWriter, r *http.Request, siteId string)
+	// Lookup a site
+	// (GET /site/{siteId})
+	LookupSite(w http.ResponseWriter, r *http.Request, siteId string)
+	// Register a site
+	// (POST /site/{siteId})
+	RegisterSite(w http.ResponseWriter, r *http.Request, siteId string)
+	// Remove a charge station from a site
+	// (DELETE /site/{siteId}/cs/{csId})
+	UnassignChargeStationSite(w http.ResponseWriter, r *http.Request, siteId string, csId string)
+	// Assign a charge station to a site
+	// (POST /site/{siteId}/cs/{csId})
+	AssignChargeStationSite(w http.ResponseWriter, r *http.Request, siteId string, csId string)
+	// Fleet statistics
+	// (GET /stats)
+	GetFleetStats(w http.ResponseWriter, r *http.Request, params GetFleetStatsParams)
+	// List tags
+	// (GET /tag)
+	ListTags(w http.ResponseWriter, r *http.Request, params ListTagsParams)
+	// Delete a tag
+	// (DELETE /tag/{tagName})
+	DeleteTag(w http.ResponseWriter, r *http.Request, tagName string)
+	// Lookup a tag
+	// (GET /tag/{tagName})
+	LookupTag(w http.ResponseWriter, r *http.Request, tagName string)
+	// Register a tag
+	// (POST /tag/{tagName})
+	RegisterTag(w http.ResponseWriter, r *http.Request, tagName string)
+	// Untag a charge station
+	// (DELETE /tag/{tagName}/cs/{csId})
+	UntagChargeStation(w http.ResponseWriter, r *http.Request, tagName string, csId string)
+	// Tag a charge station
+	// (POST /tag/{tagName}/cs/{csId})
+	TagChargeStation(w http.ResponseWriter, r *http.Request, tagName string, csId string)
+	// Registers a tariff with the CSMS
+	// (POST /tariff/{tariffId})
+	RegisterTariff(w http.ResponseWriter, r *http.Request, tariffId string)
 	// List authorization tokens
 	// (GET /token)
 	ListTokens(w http.ResponseWriter, r *http.Request, params ListTokensParams)
 	// Create/update an authorization token
 	// (POST /token)
 	SetToken(w http.ResponseWriter, r *http.Request)
+	// Revoke an authorization token
+	// (DELETE /token/{tokenUid})
+	RevokeToken(w http.ResponseWriter, r *http.Request, tokenUid string, params RevokeTokenParams)
 	// Lookup an authorization token
 	// (GET /token/{tokenUid})
 	LookupToken(w http.ResponseWriter, r *http.Request, tokenUid string)
+	// Update an authorization token
+	// (PUT /token/{tokenUid})
+	UpdateToken(w http.ResponseWriter, r *http.Request, tokenUid string, params UpdateTokenParams)
+	// List transactions
+	// (GET /transactions)
+	ListTransactions(w http.ResponseWriter, r *http.Request, params ListTransactionsParams)
+	// Export transactions
+	// (GET /transactions/export)
+	ExportTransactions(w http.ResponseWriter, r *http.Request, params ExportTransactionsParams)
+	// Get the receipt of a transaction
+	// (GET /transactions/{csId}/{transactionId}/receipt)
+	GetTransactionReceipt(w http.ResponseWriter, r *http.Request, csId string, transactionId string, params GetTransactionReceiptParams)
+	// Email the receipt of a transaction
+	// (POST /transactions/{csId}/{transactionId}/receipt/email)
+	EmailTransactionReceipt(w http.ResponseWriter, r *http.Request, csId string, transactionId string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -387,12 +1870,41 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// UploadCertificate operation middleware
-func (siw *ServerInterfaceWrapper) UploadCertificate(w http.ResponseWriter, r *http.Request) {
+// ExportChargeDetailRecords operation middleware
+func (siw *ServerInterfaceWrapper) ExportChargeDetailRecords(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportChargeDetailRecordsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UploadCertificate(w, r)
+		siw.Handler.ExportChargeDetailRecords(w, r, params)
 	})
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -402,8 +1914,23 @@ func (siw *ServerInterfaceWrapper) UploadCertificate(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteCertificate operation middleware
-func (siw *ServerInterfaceWrapper) DeleteCertificate(w http.ResponseWriter, r *http.Request) {
+// UploadCertificate operation middleware
+func (siw *ServerInterfaceWrapper) UploadCertificate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadCertificate(w, r)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteCertificate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCertificate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -454,23 +1981,65 @@ func (siw *ServerInterfaceWrapper) LookupCertificate(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// RegisterChargeStation operation middleware
-func (siw *ServerInterfaceWrapper) RegisterChargeStation(w http.ResponseWriter, r *http.Request) {
+// ListCommandAuditRecords operation middleware
+func (siw *ServerInterfaceWrapper) ListCommandAuditRecords(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "csId" -------------
-	var csId string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCommandAuditRecordsParams
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	// ------------- Optional query parameter "chargeStationId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "chargeStationId", r.URL.Query(), &params.ChargeStationId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chargeStationId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "command" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "command", r.URL.Query(), &params.Command)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "command", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RegisterChargeStation(w, r, csId)
+		siw.Handler.ListCommandAuditRecords(w, r, params)
 	})
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -480,23 +2049,1636 @@ func (siw *ServerInterfaceWrapper) RegisterChargeStation(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// LookupChargeStationAuth operation middleware
-func (siw *ServerInterfaceWrapper) LookupChargeStationAuth(w http.ResponseWriter, r *http.Request) {
+// CreateBulkCommand operation middleware
+func (siw *ServerInterfaceWrapper) CreateBulkCommand(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBulkCommand(w, r)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LookupBulkCommand operation middleware
+func (siw *ServerInterfaceWrapper) LookupBulkCommand(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "csId" -------------
-	var csId string
+	// ------------- Path parameter "bulkCommandId" -------------
+	var bulkCommandId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bulkCommandId", runtime.ParamLocationPath, chi.URLParam(r, "bulkCommandId"), &bulkCommandId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bulkCommandId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupBulkCommand(w, r, bulkCommandId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PauseBulkCommand operation middleware
+func (siw *ServerInterfaceWrapper) PauseBulkCommand(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "bulkCommandId" -------------
+	var bulkCommandId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bulkCommandId", runtime.ParamLocationPath, chi.URLParam(r, "bulkCommandId"), &bulkCommandId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bulkCommandId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PauseBulkCommand(w, r, bulkCommandId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ResumeBulkCommand operation middleware
+func (siw *ServerInterfaceWrapper) ResumeBulkCommand(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "bulkCommandId" -------------
+	var bulkCommandId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bulkCommandId", runtime.ParamLocationPath, chi.URLParam(r, "bulkCommandId"), &bulkCommandId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bulkCommandId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResumeBulkCommand(w, r, bulkCommandId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListCommandSchedules operation middleware
+func (siw *ServerInterfaceWrapper) ListCommandSchedules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCommandSchedulesParams
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCommandSchedules(w, r, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateCommandSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CreateCommandSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCommandSchedule(w, r)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteCommandSchedule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCommandSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "scheduleId", runtime.ParamLocationPath, chi.URLParam(r, "scheduleId"), &scheduleId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduleId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCommandSchedule(w, r, scheduleId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LookupCommandSchedule operation middleware
+func (siw *ServerInterfaceWrapper) LookupCommandSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "scheduleId", runtime.ParamLocationPath, chi.URLParam(r, "scheduleId"), &scheduleId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduleId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupCommandSchedule(w, r, scheduleId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListChargeStations operation middleware
+func (siw *ServerInterfaceWrapper) ListChargeStations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListChargeStationsParams
+
+	// ------------- Optional query parameter "siteId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "siteId", r.URL.Query(), &params.SiteId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "siteId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChargeStations(w, r, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListChargeStationsNearby operation middleware
+func (siw *ServerInterfaceWrapper) ListChargeStationsNearby(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListChargeStationsNearbyParams
+
+	// ------------- Required query parameter "lat" -------------
+
+	if paramValue := r.URL.Query().Get("lat"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "lat"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lat", r.URL.Query(), &params.Lat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lat", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "lng" -------------
+
+	if paramValue := r.URL.Query().Get("lng"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "lng"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lng", r.URL.Query(), &params.Lng)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lng", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "radius" -------------
+
+	if paramValue := r.URL.Query().Get("radius"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "radius"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "radius", r.URL.Query(), &params.Radius)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "radius", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChargeStationsNearby(w, r, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) DeleteChargeStation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteChargeStation(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LookupChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStation(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RegisterChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) RegisterChargeStation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterChargeStation(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChargeStation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateChargeStation(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LookupChargeStationAuth operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationAuth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationAuth(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetChargeStationAvailability operation middleware
+func (siw *ServerInterfaceWrapper) GetChargeStationAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChargeStationAvailabilityParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetChargeStationAvailability(w, r, csId, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// InstallChargeStationCertificates operation middleware
+func (siw *ServerInterfaceWrapper) InstallChargeStationCertificates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.InstallChargeStationCertificates(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListInstalledChargeStationCertificates operation middleware
+func (siw *ServerInterfaceWrapper) ListInstalledChargeStationCertificates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListInstalledChargeStationCertificates(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RefreshInstalledChargeStationCertificates operation middleware
+func (siw *ServerInterfaceWrapper) RefreshInstalledChargeStationCertificates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshInstalledChargeStationCertificates(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteInstalledChargeStationCertificate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteInstalledChargeStationCertificate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "serialNumber" -------------
+	var serialNumber string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "serialNumber", runtime.ParamLocationPath, chi.URLParam(r, "serialNumber"), &serialNumber)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "serialNumber", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteInstalledChargeStationCertificate(w, r, csId, serialNumber)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListChargeStationEvses operation middleware
+func (siw *ServerInterfaceWrapper) ListChargeStationEvses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChargeStationEvses(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateChargeStationConnector operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChargeStationConnector(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "evseId" -------------
+	var evseId int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "evseId", runtime.ParamLocationPath, chi.URLParam(r, "evseId"), &evseId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "evseId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "connectorId" -------------
+	var connectorId int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "connectorId", runtime.ParamLocationPath, chi.URLParam(r, "connectorId"), &connectorId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "connectorId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateChargeStationConnector(w, r, csId, evseId, connectorId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LookupChargeStationLiveness operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationLiveness(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationLiveness(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReconfigureChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) ReconfigureChargeStation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReconfigureChargeStation(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LookupChargeStationRegistration operation middleware
+func (siw *ServerInterfaceWrapper) LookupChargeStationRegistration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupChargeStationRegistration(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetChargeStationRegistration operation middleware
+func (siw *ServerInterfaceWrapper) SetChargeStationRegistration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetChargeStationRegistration(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateReservation operation middleware
+func (siw *ServerInterfaceWrapper) CreateReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateReservation(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// TriggerChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) TriggerChargeStation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TriggerChargeStation(w, r, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetDashboardSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardSummaryParams
+
+	// ------------- Optional query parameter "siteId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "siteId", r.URL.Query(), &params.SiteId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "siteId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardSummary(w, r, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "lastEventId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lastEventId", r.URL.Query(), &params.LastEventId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lastEventId", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, valueList[0], &LastEventID)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamEvents(w, r, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RegisterLocation operation middleware
+func (siw *ServerInterfaceWrapper) RegisterLocation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "locationId" -------------
+	var locationId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "locationId", runtime.ParamLocationPath, chi.URLParam(r, "locationId"), &locationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "locationId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterLocation(w, r, locationId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListOcppActions operation middleware
+func (siw *ServerInterfaceWrapper) ListOcppActions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ocppVersion" -------------
+	var ocppVersion ListOcppActionsParamsOcppVersion
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ocppVersion", runtime.ParamLocationPath, chi.URLParam(r, "ocppVersion"), &ocppVersion)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ocppVersion", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOcppActions(w, r, ocppVersion)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateOcppAction operation middleware
+func (siw *ServerInterfaceWrapper) UpdateOcppAction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ocppVersion" -------------
+	var ocppVersion UpdateOcppActionParamsOcppVersion
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ocppVersion", runtime.ParamLocationPath, chi.URLParam(r, "ocppVersion"), &ocppVersion)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ocppVersion", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "action" -------------
+	var action string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "action", runtime.ParamLocationPath, chi.URLParam(r, "action"), &action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateOcppAction(w, r, ocppVersion, action)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RegisterParty operation middleware
+func (siw *ServerInterfaceWrapper) RegisterParty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterParty(w, r)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CancelReservation operation middleware
+func (siw *ServerInterfaceWrapper) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reservationId" -------------
+	var reservationId int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reservationId", runtime.ParamLocationPath, chi.URLParam(r, "reservationId"), &reservationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reservationId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelReservation(w, r, reservationId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LookupReservation operation middleware
+func (siw *ServerInterfaceWrapper) LookupReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reservationId" -------------
+	var reservationId int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reservationId", runtime.ParamLocationPath, chi.URLParam(r, "reservationId"), &reservationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reservationId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupReservation(w, r, reservationId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// TransferReservation operation middleware
+func (siw *ServerInterfaceWrapper) TransferReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reservationId" -------------
+	var reservationId int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reservationId", runtime.ParamLocationPath, chi.URLParam(r, "reservationId"), &reservationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reservationId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TransferReservation(w, r, reservationId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListSites operation middleware
+func (siw *ServerInterfaceWrapper) ListSites(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSitesParams
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSites(w, r, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteSite operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "siteId" -------------
+	var siteId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "siteId", runtime.ParamLocationPath, chi.URLParam(r, "siteId"), &siteId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "siteId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSite(w, r, siteId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LookupSite operation middleware
+func (siw *ServerInterfaceWrapper) LookupSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "siteId" -------------
+	var siteId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "siteId", runtime.ParamLocationPath, chi.URLParam(r, "siteId"), &siteId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "siteId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupSite(w, r, siteId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RegisterSite operation middleware
+func (siw *ServerInterfaceWrapper) RegisterSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "siteId" -------------
+	var siteId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "siteId", runtime.ParamLocationPath, chi.URLParam(r, "siteId"), &siteId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "siteId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterSite(w, r, siteId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UnassignChargeStationSite operation middleware
+func (siw *ServerInterfaceWrapper) UnassignChargeStationSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "siteId" -------------
+	var siteId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "siteId", runtime.ParamLocationPath, chi.URLParam(r, "siteId"), &siteId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "siteId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnassignChargeStationSite(w, r, siteId, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AssignChargeStationSite operation middleware
+func (siw *ServerInterfaceWrapper) AssignChargeStationSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "siteId" -------------
+	var siteId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "siteId", runtime.ParamLocationPath, chi.URLParam(r, "siteId"), &siteId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "siteId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AssignChargeStationSite(w, r, siteId, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetFleetStats operation middleware
+func (siw *ServerInterfaceWrapper) GetFleetStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFleetStatsParams
+
+	// ------------- Optional query parameter "siteId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "siteId", r.URL.Query(), &params.SiteId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "siteId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFleetStats(w, r, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListTags operation middleware
+func (siw *ServerInterfaceWrapper) ListTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTagsParams
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTags(w, r, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteTag operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "tagName" -------------
+	var tagName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tagName", runtime.ParamLocationPath, chi.URLParam(r, "tagName"), &tagName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tagName", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTag(w, r, tagName)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LookupTag operation middleware
+func (siw *ServerInterfaceWrapper) LookupTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "tagName" -------------
+	var tagName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tagName", runtime.ParamLocationPath, chi.URLParam(r, "tagName"), &tagName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tagName", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupTag(w, r, tagName)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RegisterTag operation middleware
+func (siw *ServerInterfaceWrapper) RegisterTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "tagName" -------------
+	var tagName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tagName", runtime.ParamLocationPath, chi.URLParam(r, "tagName"), &tagName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tagName", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterTag(w, r, tagName)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UntagChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) UntagChargeStation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "tagName" -------------
+	var tagName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tagName", runtime.ParamLocationPath, chi.URLParam(r, "tagName"), &tagName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tagName", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UntagChargeStation(w, r, tagName, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// TagChargeStation operation middleware
+func (siw *ServerInterfaceWrapper) TagChargeStation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "tagName" -------------
+	var tagName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tagName", runtime.ParamLocationPath, chi.URLParam(r, "tagName"), &tagName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tagName", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TagChargeStation(w, r, tagName, csId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RegisterTariff operation middleware
+func (siw *ServerInterfaceWrapper) RegisterTariff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "tariffId" -------------
+	var tariffId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tariffId", runtime.ParamLocationPath, chi.URLParam(r, "tariffId"), &tariffId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tariffId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterTariff(w, r, tariffId)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListTokens operation middleware
+func (siw *ServerInterfaceWrapper) ListTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTokensParams
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTokens(w, r, params)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetToken operation middleware
+func (siw *ServerInterfaceWrapper) SetToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetToken(w, r)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RevokeToken operation middleware
+func (siw *ServerInterfaceWrapper) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "tokenUid" -------------
+	var tokenUid string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tokenUid", runtime.ParamLocationPath, chi.URLParam(r, "tokenUid"), &tokenUid)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tokenUid", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RevokeTokenParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
-		return
 	}
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LookupChargeStationAuth(w, r, csId)
+		siw.Handler.RevokeToken(w, r, tokenUid, params)
 	})
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -506,23 +3688,23 @@ func (siw *ServerInterfaceWrapper) LookupChargeStationAuth(w http.ResponseWriter
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// InstallChargeStationCertificates operation middleware
-func (siw *ServerInterfaceWrapper) InstallChargeStationCertificates(w http.ResponseWriter, r *http.Request) {
+// LookupToken operation middleware
+func (siw *ServerInterfaceWrapper) LookupToken(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "csId" -------------
-	var csId string
+	// ------------- Path parameter "tokenUid" -------------
+	var tokenUid string
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tokenUid", runtime.ParamLocationPath, chi.URLParam(r, "tokenUid"), &tokenUid)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tokenUid", Err: err})
 		return
 	}
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.InstallChargeStationCertificates(w, r, csId)
+		siw.Handler.LookupToken(w, r, tokenUid)
 	})
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -532,23 +3714,47 @@ func (siw *ServerInterfaceWrapper) InstallChargeStationCertificates(w http.Respo
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReconfigureChargeStation operation middleware
-func (siw *ServerInterfaceWrapper) ReconfigureChargeStation(w http.ResponseWriter, r *http.Request) {
+// UpdateToken operation middleware
+func (siw *ServerInterfaceWrapper) UpdateToken(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "csId" -------------
-	var csId string
+	// ------------- Path parameter "tokenUid" -------------
+	var tokenUid string
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tokenUid", runtime.ParamLocationPath, chi.URLParam(r, "tokenUid"), &tokenUid)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tokenUid", Err: err})
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateTokenParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReconfigureChargeStation(w, r, csId)
+		siw.Handler.UpdateToken(w, r, tokenUid, params)
 	})
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -558,64 +3764,73 @@ func (siw *ServerInterfaceWrapper) ReconfigureChargeStation(w http.ResponseWrite
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// TriggerChargeStation operation middleware
-func (siw *ServerInterfaceWrapper) TriggerChargeStation(w http.ResponseWriter, r *http.Request) {
+// ListTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ListTransactions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "csId" -------------
-	var csId string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTransactionsParams
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	// ------------- Optional query parameter "chargeStationId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "chargeStationId", r.URL.Query(), &params.ChargeStationId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chargeStationId", Err: err})
 		return
 	}
 
-	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.TriggerChargeStation(w, r, csId)
-	})
+	// ------------- Optional query parameter "idToken" -------------
 
-	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
-		handler = siw.HandlerMiddlewares[i](handler)
+	err = runtime.BindQueryParameter("form", true, false, "idToken", r.URL.Query(), &params.IdToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "idToken", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// ------------- Optional query parameter "from" -------------
 
-// RegisterLocation operation middleware
-func (siw *ServerInterfaceWrapper) RegisterLocation(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
 
-	var err error
+	// ------------- Optional query parameter "to" -------------
 
-	// ------------- Path parameter "locationId" -------------
-	var locationId string
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "locationId", runtime.ParamLocationPath, chi.URLParam(r, "locationId"), &locationId)
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "locationId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
 		return
 	}
 
-	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RegisterLocation(w, r, locationId)
-	})
+	// ------------- Optional query parameter "offset" -------------
 
-	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
-		handler = siw.HandlerMiddlewares[i](handler)
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// ------------- Optional query parameter "limit" -------------
 
-// RegisterParty operation middleware
-func (siw *ServerInterfaceWrapper) RegisterParty(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RegisterParty(w, r)
+		siw.Handler.ListTransactions(w, r, params)
 	})
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -625,33 +3840,41 @@ func (siw *ServerInterfaceWrapper) RegisterParty(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ListTokens operation middleware
-func (siw *ServerInterfaceWrapper) ListTokens(w http.ResponseWriter, r *http.Request) {
+// ExportTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ExportTransactions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListTokensParams
+	var params ExportTransactionsParams
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "format" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "from" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
 		return
 	}
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListTokens(w, r, params)
+		siw.Handler.ExportTransactions(w, r, params)
 	})
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -661,12 +3884,43 @@ func (siw *ServerInterfaceWrapper) ListTokens(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// SetToken operation middleware
-func (siw *ServerInterfaceWrapper) SetToken(w http.ResponseWriter, r *http.Request) {
+// GetTransactionReceipt operation middleware
+func (siw *ServerInterfaceWrapper) GetTransactionReceipt(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "transactionId" -------------
+	var transactionId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "transactionId", runtime.ParamLocationPath, chi.URLParam(r, "transactionId"), &transactionId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transactionId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTransactionReceiptParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SetToken(w, r)
+		siw.Handler.GetTransactionReceipt(w, r, csId, transactionId, params)
 	})
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -676,23 +3930,32 @@ func (siw *ServerInterfaceWrapper) SetToken(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// LookupToken operation middleware
-func (siw *ServerInterfaceWrapper) LookupToken(w http.ResponseWriter, r *http.Request) {
+// EmailTransactionReceipt operation middleware
+func (siw *ServerInterfaceWrapper) EmailTransactionReceipt(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "tokenUid" -------------
-	var tokenUid string
+	// ------------- Path parameter "csId" -------------
+	var csId string
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "tokenUid", runtime.ParamLocationPath, chi.URLParam(r, "tokenUid"), &tokenUid)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "csId", runtime.ParamLocationPath, chi.URLParam(r, "csId"), &csId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tokenUid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "transactionId" -------------
+	var transactionId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "transactionId", runtime.ParamLocationPath, chi.URLParam(r, "transactionId"), &transactionId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transactionId", Err: err})
 		return
 	}
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LookupToken(w, r, tokenUid)
+		siw.Handler.EmailTransactionReceipt(w, r, csId, transactionId)
 	})
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -815,6 +4078,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cdrs/export", wrapper.ExportChargeDetailRecords)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/certificate", wrapper.UploadCertificate)
 	})
@@ -824,36 +4090,189 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/certificate/{certificateHash}", wrapper.LookupCertificate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/commands", wrapper.ListCommandAuditRecords)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/commands/bulk", wrapper.CreateBulkCommand)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/commands/bulk/{bulkCommandId}", wrapper.LookupBulkCommand)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/commands/bulk/{bulkCommandId}/pause", wrapper.PauseBulkCommand)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/commands/bulk/{bulkCommandId}/resume", wrapper.ResumeBulkCommand)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/commands/schedule", wrapper.ListCommandSchedules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/commands/schedule", wrapper.CreateCommandSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/commands/schedule/{scheduleId}", wrapper.DeleteCommandSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/commands/schedule/{scheduleId}", wrapper.LookupCommandSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs", wrapper.ListChargeStations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/nearby", wrapper.ListChargeStationsNearby)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/cs/{csId}", wrapper.DeleteChargeStation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{csId}", wrapper.LookupChargeStation)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cs/{csId}", wrapper.RegisterChargeStation)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/cs/{csId}", wrapper.UpdateChargeStation)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/cs/{csId}/auth", wrapper.LookupChargeStationAuth)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{csId}/availability", wrapper.GetChargeStationAvailability)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cs/{csId}/certificates", wrapper.InstallChargeStationCertificates)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{csId}/certificates/installed", wrapper.ListInstalledChargeStationCertificates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{csId}/certificates/installed", wrapper.RefreshInstalledChargeStationCertificates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/cs/{csId}/certificates/installed/{serialNumber}", wrapper.DeleteInstalledChargeStationCertificate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{csId}/evses", wrapper.ListChargeStationEvses)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/cs/{csId}/evses/{evseId}/connectors/{connectorId}", wrapper.UpdateChargeStationConnector)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{csId}/liveness", wrapper.LookupChargeStationLiveness)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cs/{csId}/reconfigure", wrapper.ReconfigureChargeStation)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{csId}/registration", wrapper.LookupChargeStationRegistration)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{csId}/registration", wrapper.SetChargeStationRegistration)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cs/{csId}/reservation", wrapper.CreateReservation)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cs/{csId}/trigger", wrapper.TriggerChargeStation)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard", wrapper.GetDashboardSummary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events", wrapper.StreamEvents)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/location/{locationId}", wrapper.RegisterLocation)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/ocpp/{ocppVersion}/actions", wrapper.ListOcppActions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/ocpp/{ocppVersion}/actions/{action}", wrapper.UpdateOcppAction)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/register", wrapper.RegisterParty)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/reservation/{reservationId}", wrapper.CancelReservation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/reservation/{reservationId}", wrapper.LookupReservation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/reservation/{reservationId}/transfer", wrapper.TransferReservation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/site", wrapper.ListSites)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/site/{siteId}", wrapper.DeleteSite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/site/{siteId}", wrapper.LookupSite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/site/{siteId}", wrapper.RegisterSite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/site/{siteId}/cs/{csId}", wrapper.UnassignChargeStationSite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/site/{siteId}/cs/{csId}", wrapper.AssignChargeStationSite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/stats", wrapper.GetFleetStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tag", wrapper.ListTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/tag/{tagName}", wrapper.DeleteTag)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tag/{tagName}", wrapper.LookupTag)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tag/{tagName}", wrapper.RegisterTag)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/tag/{tagName}/cs/{csId}", wrapper.UntagChargeStation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tag/{tagName}/cs/{csId}", wrapper.TagChargeStation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tariff/{tariffId}", wrapper.RegisterTariff)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/token", wrapper.ListTokens)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/token", wrapper.SetToken)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/token/{tokenUid}", wrapper.RevokeToken)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/token/{tokenUid}", wrapper.LookupToken)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/token/{tokenUid}", wrapper.UpdateToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transactions", wrapper.ListTransactions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transactions/export", wrapper.ExportTransactions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transactions/{csId}/{transactionId}/receipt", wrapper.GetTransactionReceipt)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/transactions/{csId}/{transactionId}/receipt/email", wrapper.EmailTransactionReceipt)
+	})
 
 	return r
 }
@@ -861,68 +4280,281 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbX3MaO5b/KqrefUi22gbbiWvjl1kCxGZiAwU4t2YvKSy6D6Bxt9RXUtthXP7uW0dq",
-	"QTctjOfOZCebuy926/+R9Dt/dXgKIpFmggPXKrh4ClS0gpSazzZIzRYsohqwGIOKJMs0Ezy4CFokShhw",
-	"TaJSrzDIpMiwAswM0UszTFZAht0bAjwSMcTlicgj0yvC4TFhHBSRkCU0gpjM1+RuOuV3QRjodQbBRaC0",
-	"ZHwZPD+HgYTfciYhDi5+rSz8ddNZzP8KkQ6ew6C9onIJY02RllauV3Xy2oJziLBAYtCUJYoshCSURGYs",
-	"UXZwbc9zquD83fiqdfr+fEiVehQy9m/e9nT7D8n4qnV0+v6crKhaEbEgegU7i5HMTRgGKf12DXyJpJ+/",
-	"q51HGDD+QBMW3yqQnKbQShLxCB5KeguiQBMtiJY54KKcUE6K4SQvxpNHliSEC00yCQ948R7youLM+HJ7",
-	"Q3MhEqAcSVIQ5ZLp9VCKBUv2QMJ1IpnthZTlCszh15e8IP9B7pp35Ijk3IyEmGhJucqE1BZGc6pYRGiu",
-	"V9j3BPtOrse+ttNKWx3fU77dFuMaliBryNvd40H09bjSNElKzKb2HYxGVJToUXg2zI4ngnuO55jgyMoQ",
-	"c49znI7rKcdrr98jVWseraTgIlfJ+njKX+JsU2Ya0t9F979QZoQB7jffR7ZpC0kMC5on2tA8BB5bcAPP",
-	"U7zuVhRBpgEZcgR4v+bT9fvqWdNWPG1m+HJ6GYTBzQD/fArCoD2+GXsG7sDMtIYH5VxRQaWk65eEpDqM",
-	"0zFoZGxzWjSOGdbRZFi5u/op3sOaMGUwZqRIIdaUneyYfBKSDNrDITk9bh6fbPuplciTmKzogxFJZCFQ",
-	"fjG+JBnVGiS/mPJp3myeRRv1ZYrQsLUPVDI6T8BWFmzgetolIiPloiSPAQWeyOyOSt0MRHlUkER5TOBB",
-	"AWHxlCvIqKTa4ktByo4ikQiu7Epu9ZcX2vSqr0O1lmyeo8jBWyEvL5fSbyzNU5IYfWAkpTnTk+NzPPz3",
-	"zaZhcBppkMpyc0l7nDSbTQ9Oq3fpbn+fDnwZOxPJligs6xCxDbUZCY28ylVvJ3L881EI3RcFkO2YsWHd",
-	"3Uq25F9OL9sVcwUrDaWMLwtaPR1EOmcc4raX2fYxaEGpl6+smhRmH9UNLoRMqS7vbzxof+5OUDC0Pl53",
-	"vSKFGbVeq07ptxlNM5B0CeW5UXednXpUmR3yIBL9+hGZeAQ52xVqrfbsZDa8ao27QYiFs02h0/ZuARkg",
-	"ptZScpO0r1qdrhGM7avW4M89HD246Y4nvfasVS58LBfa5UKnXOiWC5/Khcty4apcqCz653Lhc7lwHYTB",
-	"5cfJrNUuPjr40eu2Z+fNs+aH2elMMb5MYHZyvlOvVxL2Vpszr1efv3PVpycfzmeTk53irD24+TioVp7u",
-	"FH19zlo7ZdxEv3vTmr2fnTbd9/nsrPT9fvN90iw1nDTLLe/KLe9sy7DVnwwuR63h1ezjYDIZ3Mxuh9Xq",
-	"yWA46wx+6QdhMOmOr1uz0eZrHITBbf9zH1sPsmKBYsMnO1xRRXwFzSVM+ni4+6Cgzr6R4+yqUfTvEhbB",
-	"RfBvja2v1SgcrcZWGNT0dRigvplZ9uZ5kqC2CC7QTvewUM481v0tZ7/lkKwJi4Gj9AKrjLtfxl1jMTFr",
-	"NraHA0WyhGo8LPKGctRx+Rz3RrWQmyb19vig85Wbcy5sq7B8Jr6DvARxLQopXTvPhGqm8xi88i0RfLmv",
-	"dYekzTzlUT5qyqTUHN6qikpE5PcAaRxLUMpLc8T02t8ghIwZd/b0S4gpn5gZmXMt981q2mZoLXs7IMBe",
-	"j1UD+udwHxY3sEU75lWYzai8Z3xZ1x/Xg/7l7GYwGYx+af3FiIXR517/cnbZGrUuu6WK6wHqxkF/1hn1",
-	"vnRt50F/Np6MukZr3vY73dHlaHDb77jBX8NXEabXsz2KNRPoumwO9cBkO1B06CiwsL2/nduqQqJEkQ+2",
-	"I1gypeUe6HZgYXwiZHTGmWbGyvWGN7DLoD3sEVmaEV3xyNJcRfph/8kZ/JXp8DhA6WPSc42mjLZqSuU9",
-	"xIQqcjfqXvbGk+6o27mzUQnsqsU98I0PS21Qg2gx5XMguTLfhEZILbYS4HEmGNeK0AfB0CmzPgZAfHi/",
-	"LxM45XfDbr/T61/66RM8WVeJdIRhx7uGiDLWeACpmODqLnQ1p8end8YH2JYbkQQjvmmi7qZ8sydryjue",
-	"KYhBT3Rzcn4PFGn0X5olvxRxiUSa5txY0XxpXWykHm7GQ/KmPep2uv1Jr3U9nk0Gn7v9Wcuoh0OhqVwm",
-	"/uVvR9cOMGYFdzqbazQ3kknxwND7N4prfDO2500jjddi3Useg3RTbWZxuAvCrWWbS3ZQodkD8/HdeA8D",
-	"XE0mQ7LRgFWmASmF9O/fNDl+/H0BC1JuOLSxYjrfziZ+kLS4iZYJyf5mWcWeTS2IQ6MV3BTycSfeyGMX",
-	"iVpRTYx7ixdlkIfjEGhMObYpx1quf2n9BS2/1vX14JduZ/s1G3z6dN3rd42N+aU78sI+ElyjD9zbE451",
-	"7aTXIW/gptXrvCVUKREx43hvsG8pfWPKnqBB4aoLqd4aqW2iFcFF8ObX1tF/06O/fX06fX775uhPb7cV",
-	"Z9WK5tGHr08f6nVv/xSEe1V823vYdl+mA0Gt4liCKZXjOSOXVRn2NAxSxkul2oJLKfLMf4hMERYT00Gh",
-	"Ty/yLNnergmhpfQeiH4UREiSCgmu6VHIe2RfwaFK0Nm5z+NVKvfFE3rFvvA6KF+HJBVKu01rlkI9FFV0",
-	"JZlErzZ2cdTRp16HRFTGoQl6c0DJTSVL1hvx5LuNhPJlTpew/zoyCQuQEmLi+jp564KaVJHeeEDOzz4c",
-	"nWw7FUbB33VVCVX6NosRv35isKnQcJGQMXmkiuAgkttR5A1bciHtsUQSqIaGbXpbFqJYcYTHG+wzpfYx",
-	"nWlE0BwE5lllt2cvRFY9aq0sZMoSpTO7GrRnt+Muupat4dB9DiZX5j+iwCtMvM4WLpUbh6sQEix+BZbN",
-	"O4sPykQjQ9mZbCffo8oDUzlN+nk6hz1axfZoSKCxDUqavg3nEUbO5tngn/It/A8/tZXkz/ayQxehts5g",
-	"SfZumNftPCxpi7omejZPWQtReNeaRjY6llKWBBdBSuEBjjTQ9L/0SuTLlUZBoo4jkQbODwluaPcLEOxU",
-	"D2z2OMpnmpDWsGefJTQYLbCR93Y02hkhgW9Fb/s4pFycGtkXzUg0LRIWAbfBgWL9VoYbJKfHTQNTppMt",
-	"VSbcHwaFjRJcBM3jpu0nMuA0Y8FFcGaqjDJZGfXa2HkkQYfE4/tniaCxEcS1pyxSPPzg8jaIbAypiHKz",
-	"F119NTK2a45Gri4ewjyPRrlCxk1zndPEvqI5oxgLNpJgzDAqgcwBO4vFAkksjGOC30dzmlAegbTG7WYY",
-	"CpBiR9UIbWHUfRTx2mEEuDkNmmVJge7GX5V1jKwnezAmU1rhuQp4dPFMhcoEL3zn0+aJ5/3YSMvYIs48",
-	"If3TyCusTkPZbrgHvmXmFcrakoZbVZ6mVK4354eAqGwwrACq8VQqXFG1erabS8D3Itcx9ftAhhbeiioy",
-	"B+Akz7aXvTHdLWrozmN45S18ygvl0OmOyHytQfmwYQmpYgMtsRQ0SBVc/PoUMCQYmWgrGna2GuxedVi6",
-	"kpfdmuevNVS8qx9XXxAHgecweGe7fGdQ9IUmC5HzHwuL9r52sRgGS/CIsmsh7vPsXw8yS8cPBbLm95N6",
-	"OwKtdObORf2DY3gLy5o8VY2nSPXi5/3q2cbsQKLs5PDozdxQa6UhLeIbSuVpAfe6+p1yZAF0VdagLSuY",
-	"OAlaFehT8NjOYrIifDkfjBsdnNnUBVMNU64EYdqYBWbKSPAFW5osG6PdmTaxFtzCXKCbVHpz9fGP23Pl",
-	"dbjOQx4ndodY95qBZqSP45SxNL1sdfqfe9jqO9gRtTSzn8macJfpxe8OGzRokWTnFe8j0Lnk1jd30Wh3",
-	"SGS+3gryJdXwSNco3GOES8o4kJV4fI2Bul+c127pBwHk95LzflTuAG4nHyPXK+Io+t8T+7f8notHXsPW",
-	"D8UFW+yWIFh6WNllhd3cOaceqth0eYHly6okCf4xpKYvPfJVQrRZFzODzz8UcoqtVTMjvWmcuwiSsFHE",
-	"++2LcY5bAmWFc9G/CNqjDVGELuZAzN63JnPdEGGKMGNcTDnDEyvsa/fkRlH/L4GDpEktY9kZISY4ANGK",
-	"cqbSEC0HnLWYbcoXQhLBbZAW5+BLKOmAOJfm6Q6UTRtsLVDvbI/BrBV6zSL3VigBLRSIiRLFE+DuqaCV",
-	"o+k9EFgsINKELUxmnszNDWrhN2g2N/FHtGk2SaE/CUuWrvMVbFjKRvTL8CK98Y+IDJfy+X8YGOa2XZZP",
-	"48l9vdqpcwO2oWQTbX3BLboupRQdwshm9kPo2NId/L2Bhn8+RraJSz+hI7T/0q3kkEW/V8GH27wU+0BW",
-	"RRDpgHPTnblQUWT+BIkpB6ZXIIsUIBN7q2S9bBaxaxbZMY6AJCGPlOlN1oytNy8mxXRTvm/CQ7gf4lzf",
-	"KZZfSY36SVG3HysWeJuMH39wlSlts6XcMz2ahzbms82pKrI+YJOr7/OnmdImcUTtiYv+loPJdCtEk1gs",
-	"FOiqWGKcpXkaXDR9P7XyT5OwlOld4WZnOWk2w+2cJ545/1FP+1WJkzabpv6DnNqd4wluEyZ+rGAnkuZJ",
-	"/lEuJXIf2ygUJTZrACVkkWP2ezE2Bgux7yQuipv6ieREu5y2gbLCl8C1FRONJ/Pvllkz5+XnmH/wLu08",
-	"7joPP6Q4yl77guLJt/iukbUSeHbS5upH/v9vKNU3lH24NL8blg8vG8IJieEBEpGlNgUP+wdFommw0jq7",
-	"aBhLPlkJpS8+vDtpNmjGGg/N4Pnr8/8EAAD//5Lxm9kDPwAA",
+	"H4sIAAAAAAAC/+y9e3PbtrY4+lUwumemyW/kZ9LsXf9zrmoriXf9GktJpqfqdWASknBCAdoAaEc7N9/9",
+	"N1h4ECRBiXL9UJpMZxqLBIEFYK2FhfX80kn4bM4ZYUp2Dr50ZDIlMwx//ppnnw75bIZZqn+mRCaCzhXl",
+	"rHPQ6aHEvEKSMIUURxiNM0IU4mOUTLGYECQV1q1lp9uZCz4nQlECPePE9PKlQ1g+6xz80bkkkqhOt3M4",
+	"xWxCDjkb00ku4PNOt/NunmJFXlMxu8WC6GYZweIQJ1PS+bPbUYs56Rx0pBKUTTpfu51EEKxI2lN6iDEX",
+	"M6w6Bx3dx5aiM9Jp/uTXRX2qwylBCc4yIpCaYoVsU6SmBF3n2Se3EtvojCskiUK3U8Lgde/iGKWcSMS4",
+	"QoL8O6eCIJyrKWGKJjC97Rg0YzvVE24aaaAaG10SJSi5IUdYkfYTpml8puGMEE01oGNKRKyLT2QRBWyG",
+	"Px+z1xmdTFXwnjJFJkToBkLv9hAeFyhwPJuRlOo5dDvn7DjN4nsriMwzg6tUkRn88V+CjDsHnf9np8Dl",
+	"HYvIOwEWX8Knna++VywEXujfkmQkUVys0dnAfaI/V1jlsr6eh3w2z4jGFc4SgsgNEYsKcSAqUS9JyBxa",
+	"CfQa04yknW5BGTljeurdzgXOJbzy3dYXqNv5vKW/3LrBguGZJrc/QkIeAKRFn7VXfpDam2DUr93ODc5y",
+	"Etl92CHA81QPTXVXltyDZS7jiF/AkHKLnS4mya//lySwgaVd/XdOpKqvvn2heZMkLEXYo/VduVWELxQ9",
+	"6jHCfbtXjhZjCHVo3l0e6wlpzuM+0JBRJhXOMjTmAmGGamN7hpEL2um2ZzRlAD44pldZTyTsZ2W4osB0",
+	"ER0jZrmompJF8TEt2OoC6Q7MuVPsQTiRpZzPsq3YZgabhD6RhV68BPbPgIsim7mNzg8vLtD+9u72Xm3q",
+	"Hk48YgOi3mNB8XVGJJwFRKoDmIAeiUo3F8NzkMaqG9seaVLuIiwBDN1OEAcsQRpXDSgjFptvhR2nZIw1",
+	"GzzY2+1GFmGGP9NZPkMsn10TEaEPcwhO8Q1B14Sw2j4A5HoPF0QhQeScsxQ4iu1ZD7zb7cwos7+6q04I",
+	"D3HpjKiDrrvRAGMExGd3Zm/71ZJ9QW+xSBEMB4vrR4BpYDTgY2Vej5h+b04ms9Jrnlx/8ZDxHHcV4kJD",
+	"w5FUM97WMaXCuutceyUjhuM1CiHPVcJndndKIoYGkDPi+NYqZmzeD8zr4zQqfyTupFpHABRLoDfHExJE",
+	"5YKRFF0vIrB2kadNLDkrkcTYneoRpGBqHTCbRI0LwlLKJihnimalsan0InoMaKWZ6kA3CD4ttRkxS8Vy",
+	"uxBVbuPcHmFoIEMItq1QE3xjQUt4ngGvGLFrQ5DdWJ+CaGwjKaLKrXGlRUpTK2IDoGXytEvT6Xb0NDvd",
+	"jptEp9sxkK0vRxlctyKT77+hgR224W0ATUMLB2SVQqu04LFjBaEOAkZUxiLzRkaPcWD7FeItcOsAjSnJ",
+	"UtdMEH+Mz7BKpkjLH6to28kG74mQURnnnGULZNhRFERh5NqyoHFjekOCzLkALAIkpAJlWCr0K+fqjOtL",
+	"TtLAF7udGU9Jtj44lqWZr2O0TBU5TtfvF5vH+nNUcE+qJJL59ZZ+LGPDKTyR6w9WnPZ6C3UL6Kdb3L7q",
+	"A1VuVzeEpVysP/RY8Bm8sB3ETqwanh8SYTeTRLUWGSVMoSRoVTtilvWgj4OL/ikiLOEpScOO0C1VU8TI",
+	"bUYZiHjzDCfmtPg4GrGPK0/ccOAYCR/C+hwRhWl2SRIu0v5njdPReZq1TKExiIsi1QIkVZpoyWdLC/r0",
+	"vaZZZvjXyqM2InqUGfHtlAgj6CuBmTQyBFKcf0KwGlHVC2cM+FHjGK6BQcZbLJG9pMb6UgInaklX8B7R",
+	"1JGn4p9IlOqTXAjCkobLwvHgHL3c3/sHcs1cfwmXKtYdYam+OA3prAGt9JFfWzpihed20gG5kaRp6v33",
+	"g34wbfi5cj2btERFPwa14t8OYWmbOoCFt+dFrqZc0P+QtLoAsY4zewlumql7H5cpG6Qqoe6wO/DdGvsD",
+	"Uy6uNU0XGBUsULwbhbNDLlUTkkvlsbu0lAWUPL/OAhDNbc/33WdETBa/3U7jAxB4jVKS0RsiSIqeUYY+",
+	"fZg+X2MIvdJveS5kfIjU3Wbq84DRpvrTtuMV3zahTNg9YGSB2ppfjrlYyb1B1VUXycqDV1GtzBZqq19b",
+	"q3Dvm48IO/6Sc8FrH5md6YRKJRb1M4BzkVKGFVmpaH1DuNdOfe2uFuaGMSGtNcXSdufRcgW2FgC1/LeU",
+	"4i0yRIVF4J2CJITekLSQV2rgt+MODWLm0MmQ7VeHJ/P50oUHzYhb9HqfZrbXnMNVj6pp/Oqa5IKqxYXg",
+	"Y5o1sDTXCM1Nq2JBq5IDlhYNibCDHqD/gz7ufkRbKGfQD0kNoWrhBVqgayxpAseHbrun2w5PBrF3+6V3",
+	"dTEw1J0FiihJBMXZmeElDTPULQJ9Wcsjp0H4N4ejw1rXn24dCFd1G4LV8cZP8bjkr0eCu60bRDdDWEo6",
+	"YSSN6wvWkvsVFnQ8bj9J0x5kET36XNAkNt2fZMiuo1edphvH0F8m2m5UjMlXEX8lI+7lahqzDoFYC/oL",
+	"ENOl1dbVYCrz5GssyauXg7e9/Z9fXWApb7losuVBS3dX6aLB297W/s+v0BTLaXwB0Nx1CPraE8ImGvRX",
+	"L2MsmN3gjKbvJAEVSS/L+C2JQHI8Nup8jpTIiVE4YYbs5yi336NbmmWgNZgLcuOVymXw7FXAXFcsRNec",
+	"ZwSzv8CSuAbC69bLQz49E6qg4PrYd4Nphq9pRlXDXQYHLbSpm7AUA4VgBveEiFjQ+soWkDnozxtU8lHm",
+	"2+I64zo/QLvr939LWcpvG1ijfWlt/vyGGOyYE0E5KJG4SIkIGeIy6ahxRz7AMHX2Wdl1uxQFzGttux0k",
+	"Ig/a6aS5pmp0O6XJtLgeTrFRBUo8MyuZ15V2xHiHtNZeizspu50u97UguqW274gbYwzXczb84B2ziBw1",
+	"wFTJCEAB+Xu54rS0qIcOr+MeMfblvZNOFHt9w+YLZWia9N3qnrqIbE+2UaI/3UdcoOTwcLDfYD284LdN",
+	"wo+zFs51k0C284MlmLl7oqaZD+1ubPPpQtIEZyf4ukkizvQrxFllPKulTgWMKElMFVpVuwU70h4BjOG6",
+	"YVWIwilW2Ji7fP/NuHCfOxgc2/u7T7Sh3ri7+/CbG8z3xat2KuJwQ406teEMMKZ1LtAMU6YwZST1sprZ",
+	"2+Wi2t2vz497PWgvp+PwknY3gd04PVijvuuDGh0komOrorbmo876O9q/kTHVv+HHLbfNYpxcwaJlqE3t",
+	"GonAaPxpeifJoDhfIvephxaH9KXfsLV0hQ4URtM3dv2JdZFpqwVtEmyCRS+DspInHxvkDiw/sknyNw5f",
+	"QcPQPyqqCtlGsOPhJ3BRsQbrEYvekxGWC5ZMBWc8l9liexRBsgq4HlnWhfsJDVjN/giF20TXkTrAXBjL",
+	"nUgX2L8vraFfOzvadjFHGlXxHH2//6bT7Zye6/+91iLh4HSwWgCEt92VRrelUnlpD9viKUlXY2ppqz3z",
+	"1hi6mnk14dUyJhQDLcaCBBkLIqeDlbteGL+lAg0pU/rGT5jiYoFsN1EnDY8Pf65hL22x+if0hjAiG6DO",
+	"7NtW54PmTm8JFuqa4JXKY4x804Jl3pvOWPc2IE1WthCKGZEST8gDwNDEAz5MiZoS0SCSJJxJas5LxTU7",
+	"5UzzHfBoGI/1nwF6nDP74Ny+anO/M9dVv0IrMeTSWEGWONiKoEWA6CsRZjWXLJzL4ucJZda5SRLjvlw1",
+	"RYCeytGOVjPFVx3bFs6HTfNK/aWlv4YP5RQ8tbRBAOEJpgzhsSLO3U2JBaJMEXGDM92XY+PNUDCuopCU",
+	"/LaCg6HgDq7vli5bjftb871a0bKAYEXDAsAGjFyJhgOiFGVGY4/TlOpnOLsoIVQdjQInYj17fzMwnW2j",
+	"11yEl0nfzm4tePjoh2Ou9biUTdAcK0UEOxixUb67+yLxxwb8JDvmqfNRNg+ttORamiES0PYmWZ4ShBni",
+	"czOjoBmccCyxIGGWIi0WIpqOmCRzLLDFE0lmdCvhGWfSjFTykG4cyLeqj4OVEvQ616pXvSto+XDudpzB",
+	"hbMsYVOJft7dBWTHiSJCGqEvuJ7u7e7GLuRVFzyz+022gOW4MxR0Mone7c2LWo8IJ1GOpYqOHD1GPOUM",
+	"ylcf0gl7v//msORipR86VZ2769Qb8Nk1ZWUhZLUcZyGN0pXxU+zlKVXGY2pZJBtlVFFcYUn34hYV6FG8",
+	"42TUp6DbsS3i3ep4Mu/uX3jv2GgCE0GknNYIJ+VW0rihrnKXXnLtq/nlWud+N2wCXp7OqdddkVrLEaud",
+	"jQhTYnHgSEOPZpQFfs5OyKHpXzeLm4PdXLBqXYmmqCMjJsBLdM1T7xZW3jq7YHO8yDj209ODQZjHvwbn",
+	"Z0tGbbNVFtGi6AErF6BEWw95202bIMliTMzKcwc/+UTOZLiNGpCQ6uqxlCPWJphyxNb17T/EWWacrf1u",
+	"2A3wDv1EiHDhxtabvcktokHWu/QrUgoP9WJQsGvdwBADAhpoHCCILSodmtMdj5iG72C5N7+jWmld/4sY",
+	"RRsqUqyHD0c0r0ZMv+vrxYDtgWH8XOK0X/bE90tQ+OLbfkpRjc1u+S19roqIsJBgllt5zCeDZErSPCPL",
+	"jgnA7yCqojmUEMHFC2gS/LQ1tgstTrtR6opHz/xbx7TCBB836tnBf48Rz3pdok4ljY5edjccLG1cvYpl",
+	"O24T/eymbPmSuTTnrKl7oIzYLbj8uQ9WMd1XQiqsWeeaJDiXBDFew6lbIoj1mo+r8/VAlzlbBw+0OBxd",
+	"fUY+F301xHv6HdCN9QzlAWIhXlBlGBjjZgHgBtn+2FlvKvr5/3DWNjYZZh5yjIKKWrCJ+449fmyG0eAj",
+	"qockn+eCSBCEQC08pjfExfg8m1GWK9IFL9wuSjFIOTPO1LRr/oG7lX1+S8in5+4IEWROsL7g+DlZjP+4",
+	"i/bR/9H/fYS2Jlie6ahVvSq7+we7uwcu1gVwAhka5QzNcmlPHBUXABx+V2eqnxv2Zt5cE1lC6aX4GCEH",
+	"7L80vWpiMLH/Bq0kROmE9N5iSq0pJUT9SMBC76xnUOs/nFn44OCu7DaViOgQUmxipez29HONdzsnnKVa",
+	"3jgKVPrvhofbK808FTqL01bgW1GJDLPzLy6jg/PD3/pDLTb0fj3pR80ENG3KTnGFZ1oanZTTZVCmXuxH",
+	"DWP6kxueqfZfgPn8qmqo6B1e7V1dvO2BW0jv8OqF/3F0GJ2CVJilWKRhJ4dve0d9MHYcvu2d/+tYf31+",
+	"2h8Mjw+veuGPX8Mfh+GPo/BHP/zxOvzxJvzxNvxRGvRf4Y/fwh8nnW7nza/Dq96h/eNI/3HcP7x6tfti",
+	"95er/StJ2SQjV3uvKs/VVJDGxy/2o49fvXSP9/d+eXU13Kv8vDo8P/31vPxwv/Iz1uZFr/JbT+Ksf9q7",
+	"+vlqf9f9/erqRfD3z/7vvd3gxd5u+OZl+OaleXPROxuev7nsXby9+vV8ODw/vXp3UX48PL+4Ojr/oO+H",
+	"w/7gpHd16f/Syop3Z7+d6bcrZWeLxV1zDpaooozxJWwOcDJGw0dYTq85Fukgn82wiMiVr+Ho++3iWLoU",
+	"EN7JInUfR1Nw3BDj9WUN2HF3Zu+E7a9VIvgoCGZkXGmmB4sRI2Az4jD0M14xYujiEAxkQ4TQda58VgQX",
+	"1FUftnSXWTlkc6ymNaYX5gSrylo1ojOv3O/AMHkTE3wbGHdWAsPuDAsWDaDEh3VXz3C/hzzFiztuOmww",
+	"kpQlBM1oaqSYZ++Gh8+j45t4qiMXTgUjf1gn9urD9DncUu4OTeGeZaninojN9gYyJM+1SANZCmQD/oNU",
+	"QdLDFQ44xdB8ThiCr+RB6JdzO+XS3blc4Le1tFGJXpthoiBMCCMCt9GxScPjQJOmUShXd3WCqZB9AyE0",
+	"EWuUWy3F6kaU68ZYbRwnYptVXr3YAeGcs5Y5XLVzm1rlKXVl5D+WZ8Yj+ECJnERkrDymYXjH6L9zki0K",
+	"rYIMHJ+omtrAucOLc6kjm5XedPQMM22xyq/9keZeyeerReScpqVUWMWaRBcSQrhfe8E4ElwH76wvson4",
+	"DnRxibzpdDv/KzmLSp5wTGtEi1BgbzIRZALXGe8Wv1Y2re/wKP9xsH73B+vaZ9tjH2d6o6WiidUx3ueR",
+	"9uQn2F3OqtAlu3ZkZVhRladxtWnG2aTpbWWdfD/hVzFooi56Me1d8dqbKtbzINThgb1swgVV01lJ6QIx",
+	"h9p487b34p8vzR8/7+3H1S9S5kT8RhZvsWwguTAO0TRH8/w6o4n2Zuk09nmGZ2StTlON1mySUzklKbKq",
+	"KFWPZ75bqG/JjaFFDNNx4It/RDR+F85F5nej+aul72u3039/eNjaBba837VVrm5lZaWWmtWa81XWUhG4",
+	"rB11iSFNhfXbrJuNbExj/cWdIy8SnjMlmnqFd1cJbyB8LXi2l2FBGP7abZJRvTjr1NcrZdk5Fp8om9QV",
+	"jyfnZ2+uTs+H55cfer+DPunyt+OzN1dvepe9N/3gwcn5UHtZnl0dXR6/75vG52dXg+FlH9St786O+pdv",
+	"Ls/fnR25j//stgJMLa4aNLJzrgnCL+qKzio47LDD4kKxf5XdKqNEAFEMbU+JIuJ9c8JFSLEodVzkPDMG",
+	"Qoxm+htjwJlzCk5tyJ6UFWdQ8xV03x5XBsFX0WB7OiNS4dl8xSlvQTdWRNPn3Q74YsBuZUqxFT0jWFwv",
+	"1k1PMuY5SxEjWCDczCCSaq+tw200ZCk1PoHxdXNvG8Llveu0A07v+qxNkOMycakTQBVbzPNkPu81ZCXu",
+	"sbr3l78uTDFLM7JeiuOgt3haK02rabP3t3YiKBLXWrCwIBaYNBLI35SJ1I21fE2aIjP78LVEHAQC8zdm",
+	"lflVg5vvMjnnLb3GFJfN7ELQhBw6TK5LohB2F8kIqh+jORE6MxOA2D/rX775vQvPtOUWHg6PT/tgdnQn",
+	"gH+gm0lrFNQtX5/0hl1EPmv/Wsom6H1v2C6aVyoyv5L0PxEgT02gqMtBhyhLBJkZl2BUAhsZD00kScJZ",
+	"Kpthj96Cqgei6VN7Gp3ALCodwD8x8esmpmzpzecZTfQG6jXR65YQZk0n9eVpON4a+IIV0cweh0sZw5RL",
+	"khA6V/0ZJAj0rgkVnNZv46Ruz1KX/Er3FTg6hbMxnaw6IkyrJZC2VF85YAL1FSiuup2pmmUtQwFKQ/7L",
+	"fF569hb6KoA7iepI/BnFWQmwebN/ftSvRvVmWjwpO7Y0ktC/c8xUY/4Oq7rgwhzzBkDw2jsoR9NaH78x",
+	"Ie0oV+HPa4Gp8OfLxqhDhT8jAR5PEuFVVFLvujFEPqUzwsLsYTak2F1+S8vxcXg+7J18tA8L1n075Rnx",
+	"7M67s9mutCqBJqTwbKOKzKgkPuFjyc3R8hTPYyxTqfIYDch6uKsxUsf7v87AcFt5atLG1Z/b1HKVpxfm",
+	"qhB/OeQKG1rIGW2gT/3GrbhDzy76+OnD9KPGxI/T1QlPLXML+w5Jo8CnEBPj7GRZ5NYRGUNAq4bU+Phn",
+	"KIknX7KO5MflSK+54Im5Y6wd1uWzZAbdWafUbXTsXsJvjaszLD4R8ED+eNl/czwY9i/7Rx+R8vgIOTNd",
+	"ADI2KZeQ4pBA28Xh40RDq98iwlK4jEiEbzhNXXJkRgw9LJ/vcgBH7ONF/+zo+OxNHD7OskUZSAeYbvhx",
+	"hydzumN97eXHrnuyv73/Ec704vdOIgiYYXAmP46Yn1MlzbcBRiOzX7m4CqU5K6kBP8gHpf2VcgaOq2xi",
+	"HOE09OR0cIGeHV72j/pnw+PeyeBqeP5b/+yq93y7HPITTZyVi6ypfseJQxgYwa2O30bYkbngNzQlaSHW",
+	"w3rjRCGXr4ewtFBQ+V4c3q0s+VElU1iwON15JWvspAwMNkFKHOf34fLD3keAzZRnHrnDUZ/RCePC6D2N",
+	"C97zprTBOFGr7o7BdA/tF23SMihuYSrXN8FsYd7HUzDO8AJZoo7bNrTJa3HUeNymrogFyARYBTEF4QpB",
+	"N0SGOHGnIJ2wz5Ir9vJCHyuSBA9tjuBK/5oPpcSGUC1PANPtMD6Y8tvmO1y1dzDnY5YQUJgHnthhkljg",
+	"tVQtR7AgM50B4jUha+CYbh0mJ157n685/0TsC5lptNNdyWqVndLsTRNEXU2TbPGX48Bjx+EqEl2SF6CU",
+	"J+IQNqpodeg2TusqZZMSfZ00zI5Jef9LwpTAmX5y2jvWrpTHg/O9ly9fvrB//vzqF/3nb2RxaBSPWrus",
+	"25/ipOe1lWe8Z5NeG/YZhVMj3JiINXBm6D6JersXsymWoMRJVjD5w4JPlpftLb+F5bKpmcyNQ3OAFOFr",
+	"ro3U4Z7X9SzNd1J45W+mfBwMU0l29XPsrJ1PGx2i4VXFmOPgZ6i/vffqJfJOksuzan1dvmyvSQMIY1K6",
+	"rZVXqYjc1LRqz4GK3s5fzup9m3duYq2vfOtmvU94SsqDrLALu/67DvoVODcMiCCWGLnIL+LIxekMmvHt",
+	"bjG8tUPIOLO5YU0mizul5V+77zBDcWuu6zprzV7/XMvIv6oCTWRP28TP+F19gC0Neq9ugeJdpKZUOjlM",
+	"vze4q+r285A7/PNOCLACkvsSG1fsX2zbSoaniJBvXPht1bG6RSyWXE2Rz42R0752lukQopRNpy5QKHAG",
+	"2e6z9OOyEhFRqU+QygAzgmUuihHOc5URFe3YNI0G6H9w7LranVHMbPfAL2X7eDbnQm1f2jR68VHyTNF5",
+	"Rpu4nsnOqMmahIulsdV9qfcgHpoyxbLxRMSSIFWdRwzCdnohAMstw4dpdK5L6to5bDJNVqnKTasoClPV",
+	"gLo+bWKsQmCYNLGMw02XIOhweSBqPBRtWE3bQuPeJHMsCFODJckhAQSbsxhSVkrDxvTzg4oeGNpa+6Di",
+	"czf4lBKBRTJdtEuHDjNqWval6TVLqTTtlMsL/bdareaFajjC3w6HF415l+PRxkOfr8Au7h0vauGLlqm2",
+	"YjMb4kmM8BSemFWfCJ7P5Uqn4Yo5JcJ3Y/XDvLpODwcOm5SBRo+z9ShTfx/3Gmu7w0M8aU8JCk+eYgW+",
+	"RuHW9o8mL4Njax+pn/GNl4jjwfmWuUAE94ZqCS3fa6jaAU1S8Ku2fyQDy3F7lxozub75DI5dyo7Nh3uR",
+	"mAKWXqVYkSu1vEaUSbxRKGGKBLdQ76BJm7LShQoUM60gAKeU+weg5lR2dPX2/PDqovf7af8MjOiX56+P",
+	"T/pXh2/7vYvg9+veIHz95rLfPzNq+ncnvcsW/mPNV0i/5382Iq/b37jfxFWBF63xpuKQsQpxBNHzKCIP",
+	"VqPkZfhFdfY1sJunflkZuVZ/xCSXsy7tLuR85k88izl2kcGCM59n9SpRKV5c8fGVDvQvLaLDlNPzsyPw",
+	"JBy+6w/MXx/6R2fu7+Hbd5f2z9eXx+aPQW/47tL++Q6+jinIVjlOOpqtTx50ckZ3+uz333//fev0dOvo",
+	"6HmNet3c9cQpqMmrY9o0eZ2Dzv/3x+7WL39+efl1y/yxX/zxXw0VARtI2UCn32mWmOIFevb27cHp6V+E",
+	"79kfu1t7fwJM///+H7tbL/58fvDH7tbP5tF/NaT/v3K12CLuOzYfXrVam7OjF/46zQymEmf/yRSdW9tv",
+	"BohwGajW0+i+QKXsL4Ba8PL2mFnh6g+JmAa8dVHzrwC4NmZGhZW4JanHfH1Jp1CJmh1xMiWn1ge5IrSw",
+	"1KX+BknL2Qd0P0h/B65r0pm6wxymJx96vw+0eu3k5PxD/6j46+r89euT47M+JAB437+M8rfW5UyPj9Az",
+	"MEc8R1hKnpg0hoX4B5A+g9+R9Js26SU3FRWLbXn2R2/rf/DWfzSiPH+29d/Piwcvyg8Am36pP3v+33Fz",
+	"KzhmH0YX28wLGpSERB2EoNdZW8YrKreSaLgfGRCuGfFFpBLR1N1DsB55nhW7CwlOZvgTQeqWIy7QjAvi",
+	"Xt1y8QlhiTgjLcyQJogiglx2Xno7MFt0jUrbThqcrGtJXW1TNBeUKWM5048vXx8foQSLtAs3V0YSIiUW",
+	"NFt4l4J4vig2yfGENG/HXBCrg3ZtnY+EyyKPJZgGXr34ZWuvaGQd79faqpVFCFIT2OTLc/p81rn5KmJQ",
+	"3DGvnre2ZEJwQBPRwcsgKWUzYq6+s6jVRsiK+dFK3e8G/UvNTC4u3J/nw7fwr8aCKDPJm7RWOQQ7m5EQ",
+	"TU19jin5jFOS0BnO0LvjI5AIAcEM8kNyRDnF+z+/OrA5g4tMaeG3sZpzvviv7tQSU1BWxPRCBXxTXtF/",
+	"7MU1iLGpHVtdjhnK3X3qpvkbKvPlcV+mxY4gODXJhqHtjlP0Jc5rylMjZgUxtqg/VHDDAvXsV10bFh6c",
+	"BJ6VuJl3g7MrehkoFOaN/qze2NTgCf94xcDlSjecYD5QCLd1relnRbLmdIgnz+9SfHrmQ4PaXxiDcKJI",
+	"5A5vCg0PHVTCFQSbiI1Fv52aoqrReqq1iPAA66GDFeWu67WXf5JoTIVUNtrJKeYfrE7AFNLX2oBnBa7O",
+	"aby0dZE1XltZOt1OHyLz/3zAKtzrlZWmaVFb9XoRmywX3qWvs77lNVJo2uiNQ4wtsG0Fo2iuLm5dn1Nf",
+	"ZhyH06wHiLTVDK4sqi/jd2htW2tXC70a4d+yFp1xlG43hHG1mhOmtJjwKUh8kxR1CtsMCmUZ11Bllnfu",
+	"Aj6PMRt73W3lkTIn4g7145cWE6sXDwMWDDqtGvuNBS/US4c1ZUJce8PW26EVNfjh9V+pxF+r+ur3rYT0",
+	"wVzLqBpCWOBTC6q3uBOrFYpFneDLpUNhIyVKOWyaKQxm7b8mr+kWH2/pi8O1ybYaETIom5ha983nUnm/",
+	"EGUQzibbbVzSCi/MgnVtOQk9ir5wCR2Pqn/kc5f7xCDhT/pEJnMEoVmtwCAsdadvu9PTbPpvt23Tl1Cm",
+	"A//aAqM/vogHEpobYBBMuBbfbLeXDcxyza21Q7aah+5Wb7b9pm0kYyA0tU/KuhZA67ChWE1bG9Hj8CtE",
+	"nG6FwiqbVMaDEPTK0loiWsFMTIaqqPm3aISwtFzeZKiyjpA2HvTJriEBdS6XjTm4CjnqEwSbMAQMYTA+",
+	"KiYY1yWMWofona9QU+qiKhCmtU9g1AwHFJvIZW3RW5DC4924ftyP/vL9qIvMtQjpcOpyxrLv9lrU8iJk",
+	"IyObxFl4GdSA09RvZbVtZCIWjTMZiA42ZdotR049BhxIbrdhdHVdyd3uUsbtWlala1rDmnI5DyOjx3bc",
+	"mdQG1n4WBafB7taQtm2DZKPHY3QaHdtf+cIo9chFjxFloniXXE+Kq7rCn1tKQCTLmtSkPuuljbyWuZ13",
+	"ESUfNzWuKVFtxA0Tf/bL2/a2uGwrKFt7K2qM9h5YY0R8rFJ3WaA0OBugW7A0btp1Dqtho2zMnT+4jSmy",
+	"YUCdGSY3ZEsRPPt/9X1gMlXa1Ca3Ez5z/qba96D/niDdqF5ET1ehAcMdA1vVlCDTGqJlwU5d4CrnmYT4",
+	"uWucfNri4zFNiAv4l10kOJ6ZaohCMSKky+6iL7HaBW4bFiEhzDhVW+B6c62R17UWzR1AZQXI9iC7cYXI",
+	"Onvbu6YdnxOG57Rz0HkBj8AWOwVOsJOkQu4QL1JPSORIMhK3DA9R41hobWUyIhq4PM1wOFFWuvyW6pmD",
+	"rHg4eD9iYEbGaEpwSgQSOvWoQJDUQZfNQsCCTL1ENywWBEklCJ6F5Wal4oIgjOZ6k3zlk23UYyNmZmpg",
+	"G4NrpN4AdIu1iCA0Tmi6TXKl90OoAze4/c7UhINqkDaHJGxxgpk5zUZsjoUkqQkh97XJjlO/iiZPlHHa",
+	"NKUD7X0Jg2in0zIsz1qiZ2igKVfANol3qf7g3zmBRGUWaXywmWHxK7PHhRmAv37tVsE51wH4dj3C/W/Y",
+	"ewwFv4q6rpb9RgEVQIgFmO3Sh/1FAK/JmLuLXDNsiq8P2Z/djiuuC8S2v7vrOJP1HMQmxY+GaQdSzxx8",
+	"CQZZo85/iFBmA6NFxnWEzI7GlNI4Vbi/diMYGCV8wyIBCdea2dKMdEaSjoDxTpOvyRBs3NR1E+kqQlgC",
+	"awL0a7ezU6lgP4+q7N7NdbU+cNrIKCgEi6/CzMSGFem/NP0D467k7dStgxpluhZs/RqSS30MzHKV4wwN",
+	"TwbFtVr/8CzEMDsTvaevGBxbqR8j/ffWNc4wS4iIcR4zo3JdVJu04VeeLu5t58IRvpblBCVy8rVGDnsR",
+	"71FTJ2ujEMusn0aI0gTLCLXzJfihE5p+NZPLSMwbzyRkbUIykwlaB2sShvJ5sdkO9yzWYHSNJXn10vvL",
+	"hF4RI2ZPi6P+JbpeKCJjuGEAKeNG5TQCdqglhoIbVqbaqW51yCqXpy2JMMmX9eU648ihwNdu56Vp8sBI",
+	"oWsQQqLIjcJFs19VXOzGBbcTzj/l86dHMgPHRiHZ7sNxvQpDK1772KnvHIcLtKzxU1PHTTZeRU6odBcR",
+	"2zReXrt0yVBBotqFSVHrC7iaUzzjE8j1o29sOhOUEgu4tBOcTN1IRcEI22Xv4riLZJ5MzSWllJUIKhBz",
+	"NqYTp59yRkvnPGuq6tbrGlPVNRXkGarC4Ssaw7FfVTzbfu3zEbMvfpLIof42ek0zTXFFzQWnoZhhpeeR",
+	"ZW62cTqmUtVrn6+8wIBALojKBSu2zelEakHvMek7khAgRvov/9n2emChiVbQLmdujYLjy3o2C9HdVqtQ",
+	"7PsT3ZOaAXq4e1H3S7QrPh6bspTB3rokT7uxnAPxbjI6o6qKITZV1O7u8sRRj3Rlq5FQ5LJWY6qa+Ez5",
+	"DssjN4qla+ACtoywnpzmq2XGvqOLlDbfvQ5tOdNy8WLDriTR+BnkQ1bc8MVoCu2Ax7mixnCzGjGjmrX1",
+	"eA37j0XpG0YbzkguWDIVnPFcZosuYtx67U8xQzP8+Zi9zlxZWTxiGvcNJ7f23ITPSMDMS0NqiMxNtXC7",
+	"D1dgGw1gEly4Wrl6diPWwMLL0zFzLC1ogoWgRCJvxtZ7RcYav8bOYgMiIJW6tq61esaOBLNjQSHgB7pb",
+	"xkoNt75i3jcEUTmvuLi+3N19BIo8ZuCm7Ti2Pj0iZb0d8m8UtxisUbs6wj92vlyHJde/NkqLl3C4yRo5",
+	"FcJSjS6XMROq/HI2XnLKlLBSr1uCqpThI3IhKs166XXoMe8/K+ji13CGj34Besc+MX7LSuu8mXehMoQr",
+	"cX5njnO5RIs5UHwu4cx0+UsDaoNzK3pKOHpwZAB1CIrKZDgTBKcL0BmMGByRVCKpaJb58yt2SlxoYH+Q",
+	"xjdBGi93f3mE0Utzn9pKp1CUTR9kkCsVLswm5xfj4Kkk3Mm/QQQMqH0H+hVE5rMlBHxYiGgNVNx4Tvny",
+	"laDaM1SqQkl3xMofjKEwWIPYO8GUdZHkCAd7VJEmGbomyMwIQBNEiQWYO2YxdnAJLX/wgx/8ID73b4j8",
+	"DSovp389SJpnpL1WE7lP5CpFpr3HLlfWDVxvDXr371wR45bnDlqYYqM2Tx0TBXGV3sU1ttnr4voYrJDI",
+	"WU91kbs2Wax0+cKEyQAvjDPmiIFugkgbsqM4knysQGOuakcYuSFigaDo7TLtjNXp+NGNcd2DL3It0nrE",
+	"Nx8pDukzJTcEJHNxQ7VUi6yKw+hroEuRF/ngrxeIMzJixu9LmASOLCHb6DIPFU0zKqX3DWfOTwzSVouc",
+	"MTCmWYVNgrX7GcrnzTqVKnI+kM2+PMoT6VZqhLh5+pXNUqA4NC+UKLYEUzstiiOTnS/ur+N0qb+CuU6W",
+	"KAz87TxeaymxrmgsFCcg4pstLIjAHPFL3BJqJLBSSqwyvZWSYrEAf1FMfNnk5ZE+vgxXXYUNdWGIQLlS",
+	"j1fb4CIbkY2doUoiRj7DGVVTwNv3puR5zpqdFb4l1Nt9VEZcneaT3VQ2GssLJ4calMCKW/k4VCQfysKq",
+	"X4vS3aBqUPK4FxWiRsxe2sfgEWC6MJmINcHgSaP5v1Rov5Xl38QgkOiMfIyW1WcACFwgzjypyvx6Sz+W",
+	"DRZoafIwr2+LXwZXoUexaW4bBjdvlo78fd+wqmWRW9+vqvLLxt2uogKW3GFQkfoOxH075ZKgoJo4yEn6",
+	"dHNkj1NaVN1wpQi6SI9IdK1ICBHdRoeRbM0Q/V7pGgpOGhpI25G7qbbd5izMsKIqT0kVWkQZSslEkEZy",
+	"NjUom0/DegSTx/lfQpTf+mU3EtcehZWzyV2BZZO7Arv3zxK0e/9sC24ZDSTBIpm68uAxGE37u4L58+5u",
+	"iZPEofw2mVOsePzdWZQnRBOq/eOiqlnla2rl7yq3qxbfd+zzSyJXXEUvyYzfGNf5hqL5Tj6KST4/yViN",
+	"VssOR8xeSa2OiMqiNO9ckBtvG4kM7HplkyX32Uo1/tVXiiahLn6h0GvX5BsZKSC09iV2A6+QpQVq4Qhf",
+	"WVAWQZgbwlIuumjGU5J1kSSC4swmZ+xq4p7danyxgZZaYB4x8Bn1TwQpvLk8VtbwEO6gv3KuzniR7nGJ",
+	"+/zGI889XkPLPDlyCS3P7YdjfeXOWSOLuN7fZYDR3JSR28p3hhzkQioys8WAJVi7VLwwyIhNbVXBBbHG",
+	"ZygqrImCpMg4XmU2OUXke0QZuILNrb1bP9a2ao6oskZmwrxbvXXoRNS5hmJdmFTZCpFJMIPqKHLEcBoE",
+	"uzjyR3QcBO47X5e5IJIwFbdjm+XbSNJ8AFtBOE1dZvQ+ovwex7w8jGfq+WaMzAbPolQK1J3HXMCIvWn6",
+	"pAQzTKEaFUnLlYGWn4pdhNPC/6TsQPKXScjk5P4eCcgVbmpFQ494tL6z+c6TJUfsD5JdHTNsCmjXiLV0",
+	"z9nR4eGt3JcdvTpECYPd0AQrAiksuG5HxIwygqb8tk0Aejt5E7j9dyRzFqfbUrlTL64Pr3sCA0jtINig",
+	"I6vA3QAFS5ykQgo3mGb4mmZULVqRxC1lKb+V5Uyx4Lxh6tiOoyKmRGNBSNeGa5K06xOsjRgXKGcWjoxY",
+	"JQBkkLEFvK3RhI/HYDPBQQ18xaEuvpE4HWhYmKoaObhoBQoKHyoauI/4+FJwgKUKjlyux5OEqa5lA3Ms",
+	"VC7KmQL770fMGNqlnw3k1ZnQGwLnOVXFG8QISWURm2Q1F1zYIo1E6lw5qP/eqKRHrDIolSi3CEilvRKA",
+	"LG2X2tSSqmQNNLl/rDzuBtTix4j5FGcxRx/IIkk1CISlhCkdeSVt4NcUknxKBKSAM8JSHM1/8YaU9di9",
+	"ENOenql1G+plikrW4INSvh/Gb80WWiR0OZawBDRQztdCS2K3DxtIOoxtdRna/Zcm4W4Q4GpmGPgPY2Vq",
+	"1PwDpXjhWlLVAPuGJ+SJoVoL3fJwSjwq6/X07OyHOnlhKNmcKMGyltarzvGrx0yQc0CG3vdllnFsajSX",
+	"9vIw/PL7uKO4ZQhn3v6+UrG5/7ZRqGSnFuagkPHa98swaKco5t1GZKFM2xC4gKSppZEbVGrWUcml4tUH",
+	"nhtwxCjzsqeJoXxD1LF7HezZcRHX32TnLT7bdIx/DOk/togNvNI2LG/mjxvB0hQB4VJ5dG6ivWbVNSB0",
+	"M+UYopGRIY03qh8ZhFId5g4dGhX1NfGZViJdl1MBxDXDY0Hk9Bslq/1Idn17OdmwSyassmWtEVIsGG47",
+	"Jr7zxVj7TNW2pWboUyw+SYRZw8BjKJWfkcIKsRq/Rqw9ghkL6Er82tjrTWhU9enfYysZByLcpraJwV7u",
+	"3gPuPzI7Lyd52+gsdKtZeZkCyY1d85VSk77/mFzKJc1BZAygtAUoDlIqE27Ssju9y4iZCYf2dugWHiwu",
+	"4cBAMyIlniwRycDaCKFPehywJI6Yz9o5IwqnWGFrWXEAQ5w8UduoUdthAuyRpGySmTmD596I0RTtGmBs",
+	"irIss4Usi+Vo5b/XhxX/9uW49a/heubruHYBxm2m9GSIgY/bUtjOF/2P/llgy84X/7d1tlpuQPStoVpH",
+	"F835LRFIoxoUsErRfLqQNMEZyvA1yTx07rOSBVFPYMRK1IxobDrIeROElUhmaKGp6NRRmVN78hlVuok2",
+	"7kMmo9z5cm2jXmUCmnRLU1glQmYYVFFM53Uq8QpBTKEN+BiqsniANLG3s3ceOuA29bAGXlSMdIB2Qbxp",
+	"4mRxSAwWruXyHi1M7jBm1cQLBI+P2eh1+sAqFb/bBhOe1PxbYF6DKtLVvk6Khj/UkMAYl531VT6sy8Mw",
+	"IlcLO16IAKuuVf0khN6ArckKJk0ehca/qqjpNGKV9xRcXyU1IUPGsmSrFaFrkkAeE3s3tpHMikMQ8wJN",
+	"CRbqmmAl25mLT9yMvyOlkZ/zarOxQ4gnUBSdcY9HPvmzx7EGzNpYw7Jfx1bikCDedXBJ3qxcT4lI42hV",
+	"yuALJ7st06LNVdCwIROPvohQaf2gtKZWEVem69oaNbFEGE0IIwJXUzYWbpNQG4BoSYbKWde6VrneRmxs",
+	"Q+XAAmjkncBFxBrGFZHglo56YFErlsHGD8V8QpySQhDtU0lSmzMhsioJZlCXEJHxmCRQ74syqUQOO6h4",
+	"XDvmd+J79PwaEKU35G9jSgm2sxUZgqugeVeciCvPlMvwu+/oXCnNe/XZEi7vD0PEqhOktFrG/aXpMGky",
+	"RPhbcqwvk1676ZSoObBHokGOIw66cBJpZ0gdlERBR4wurNM8F+iS/K+ZPZWFU5GyQ1nOPmJYfjJwScjG",
+	"an0p28SjDIhqxtDvg4XXifJvUutmKTa3FLO8c1ubRN9Bc5PDhiFbGjOuRnbGlOIrj9HtLXYIEgDIfB7U",
+	"hgZ1xv727vZeLTXqiI1YrzIm1KozPkyp0X7DuOMcHOW0K6AM/QNdSUgOAxeA1m5pUHcuW4Tp9/X3Zijr",
+	"tUclSjBLCCjb6RgxXqqvOrXVlyHXueR6+oXXVdHXNirPyVax00rzDM8D7+qiiUGAEZN4ZtRCzemrLovP",
+	"/r48IZzkvcTCPFHi8Kqvvwt6tRQS4oDcBFnicSIQgt2FetI2moWaYm+2folRjkr/1vkVb1qWSg0UwszA",
+	"28pApwSdTIgImXiZ0Iemwfd4hbNT/5ZvcLDbKZbTa47Favc1jCw66UPApJn77eJYevf2QnkEGtEpySoF",
+	"mZwzvaQ6iTDyIxe5iK5zmil/tBo7qPNbg1MfBgSj62QiyCQs+iQVF3hCEGETykhR1cJyMKnPwi6CE+2W",
+	"SsjpqOnVGaV8Rdla1dgPTifrpk9lPWmS5gV4YsJrYkDqM9V04vJW1kWMmNv6kVukgRn8R76lFfmWHvJW",
+	"XtuMCJ35Ng5dNuoYOHeBoGkdTM0NIMNFs5liAKWWffYiUDZKQlhI5z45KsISDfShI7YGhCnUh74PatEm",
+	"rqfuiJUq9IKM6uzL5m7bLQfOmGKoLqmkkWLYxAWO51ZWliTJBVWLETOz20Z9nExLSnANO7yEU97ElNC0",
+	"GzwHq7F9Y55ofPQRNDA3hIHJSFMwWjNBsDwHRd6oRDLhc1e3VRGGmTI3AquCD2ApKqSZdj/JEaszDX0v",
+	"scUdbR05u76yyItIfWQGWJTsTAvTUtfdXU6wVFswl63jI1eHm4sRc9/Cu+MUeR7UNbluyuDrH0y5WbgE",
+	"oMaktI3K8DpJc8Q+ETJH+bwA235PpXHlgVnZLAJWCe8n6yYA15JbvICFMVE0GmPNEruaR0HffBzB28IZ",
+	"wMBJfcYY2LgDc7bo2+YNqO7dh4bpp2Se8QUJsce8wJnkyMeWFael2Y7rPHoEGIozpNOK99sJu7WzYh5W",
+	"ZML15DvdDvk8z3hKOgdjnEnSUN/OfLDodGNeN4Rp+/kfYZ1/cMEL73tJ4NPgKDAo2O/4ddUjp9uRapG5",
+	"ouWd+kFi062rYm+Lq61ZSYs/VJNwY4oxj8rrHWPrjX4AdhFQ0iUkJRq3QA4oDw8AGlorICxR4l888KDm",
+	"N0C3ZYBes/h3z1PR2OLVZimsQoyHdzsuqdTOF/eX83JamQTFfVCwISj1vST3x4n9olVWPJ60u/IUcHfW",
+	"rXJ7/xefkyJH199Hz7l60w0u8WQ+3/mi///eZHf6umMllBYpHm3L4kjR/aIpZmlGivO9nDsqcOKA4D8q",
+	"EWH6yNA5Gg+hHpNVk5revWiRUgnNbPopawKwt6kzrgZe26l76es1afIaPU/m817SKsPrsDKBOD4H61dC",
+	"aHeU6Pd72680MMl8DkpY//de588GVH9oF9JiGdbxHXXosZHeo0FtW7kKwXe+mD+aHUT7gJgSceGwz+A9",
+	"YDgYo8YBopq6CIXA5asi+CxNbALR5Vp3oH0mtuaCJ0RK0J1j65ehbGK+ajEGI+RZ7bj1y0wLj6qS08KI",
+	"QRtb8tySoevQ1oKQzd6bAV5sJnV0G+EwW2HrcDg7HmWTC8HHNGsItvAy3lOfRMXCP43zZMgQljtM4qRQ",
+	"VT+mqrwYd2N4j2ESAY9AmIXIaNiQsOdxKzENvj/W11C1qEhq6Ii49HE8QvnGsyolqf8CzIcjRigcua6Y",
+	"PBgmAwOoH8SMyUXwQ3cACTvQuPRc8aK7EWvqcJV8eaH76jyU+epvasVuhSsO8fy9dedL8GNFFtpDsL/K",
+	"ajqXtqF+a4SSmpHWNKeKkulq+WWjNOk2lSiWpWneqPA5EZpnn8Rq6LSUoCsZEwH1p8ArFNKOEwFM8IZ8",
+	"Y0UvDU6WvTdaVW8p4SXYA5wFpnAxwWzh1wtRYNkTQWSz6/m3Qhu7D+d30IyCT1adpYIam5cktwTgiqNg",
+	"xyFks3xyarKSs8LlLkQ00PinFBKHMWWt8FXHCyuXu2AMZdISGH8C88kUS5SSG5KBGQEjWFNz6GjXbFHm",
+	"PaZ6XeFIxAWdUIazEas09N5EBy4UT2m4lNUj1GlXuXtVY5efyNwCZnOnGV94TWveHmGLMplmBclLNCdC",
+	"K4C1C1P5gDQXPCU9U3DJo8Y8y/it4ZwZ5580W8nnteM5wkOGdtwN5iIP6rhUzH+N0n8rj/kncmSyaLvZ",
+	"/kwh6tR4wJMJKnp9HOvqhh5NNQnmGxNWHILHWL6kqk1ZXt3MqnGrnguW/wSOFaWyXPrLgD80KFwHVP0o",
+	"zlvdb6rWitmHPdo8rasFyyHbzhfjP7P0cmnSa4DPlUYf6xwDtwbvnQMnIeNLPXyoaswcA6vb4pir4G/8",
+	"fPMuQa3ysDQqDDe2nKa0qPh4ia9h2fWWF/tdOynkZqaEcYu1JK5r45HvHqUXqqJVNPXzp7ubuT3avEuZ",
+	"g2x52AgXVtPueOQ2giNUa2BnCKMpJQKLZLo4sO/dMe3MQqaqHkZzDNcx3cRevrB2RxX6xaeimVbd6usZ",
+	"bqhmFTJdO6D3+bHZl4ORTKB/zZ2rXNjcyg7LVMObSEb3f1XRs1yrVsImxFg4Qgt2PTAa+EdUhgUeN44q",
+	"g7ojBraaFNNUsq1iNmWGQMpR0JuFvd37i1RoBcPGldRqU/fj6aJ+HPXUc5rAvTXi1v6jUklbItf1FBvK",
+	"Ka46kHuw8LGCtuFWdK1Dhtf1+1sNNXHMwf5FzrveD+bxd2Aeu495d7om2r4G7kKO+Iwf/dMI20+p5/u2",
+	"2ZMh/jp78pK2lUoUVqsTbPmQLFmEZJlYtno9X/Dm4LWIGWmsFUFPWEcD+DC4aDxaFwlscHCKWRh7pmMI",
+	"Fjb6zJbzEKSIZYDgFC4JosxEYdQCd8IANb0E8H1DfBpSLjlqJDRtxPzVo11s2mu9bgNY9h9RaU8XlRZs",
+	"Q4S44K2ZkFQ02SyVUR04Tcl6MVdr5BWeOIV8TQFfr8wdKOT1ljVo4Yd48kMJX97bIZ6so4PXu7KBjs94",
+	"UuDWzheFJ2d4Rlpq4A3fxCp21cBsUcW1RoX7EE/quFU/qPVwgB1RGdGC/vfVtCs8eVzBSK83lcu4x2Zq",
+	"2e1CLVGyby7C3d/hB+wpsrF48nR6C7szm6dat4CtoVn3vG8ieD6X9XMVNOZaTr7Os08o4bMZZil0YlJn",
+	"Q1XFRv31RmHo/Suuh3hyj3rrTVQKA0bVztW2OmGFJ2unjnlwXPihydloNfAPrUnL8tOauiLVp9vocfWX",
+	"0WCFrq2xq1M1VG9htKj2PsUxKXj4g9p/6G1XiuIbpLbV4PzQ2t7V5zLKfYyoIOh4vPPF/Ns2MYON4jIf",
+	"VUP+DPLAG6sMyqW9yuEsyTOsrHqPg5aikmjIhgyHMVrkdHBhqzHAsFT6KEIIw18i0Woo2rE2gHcVc3Gr",
+	"1JbBvHj1iOItzPXvmhQihmoWg/knwlaoKKFGl25XVlI6vMS5mnJB/1OYXZtUktDHD6VkGfNgA9ZRS5pV",
+	"3DjFpEMDZ9FyUK5zRdcftcQxX31fCZwodHyEnpHT3vHRc6iaxbiY4Yz+h6RhyJDpn0owaMWZ34AYNH2g",
+	"IGW7299adl3lkHRzgjZhKXYM7kCgWh39Ag638wX+eUfTFnVQC1TBElG7BC7jJzW4WcS8tsBSh3c6Mdxc",
+	"+a7mWCpUOr51z1LZQGuslKDXuXLu79vo2ESafTweb51ilUw/urx2cOQrY/n0SG5z8d3wTyaxNlW+CB1U",
+	"ynHSl6QmUxzxeXv8Qe4twHYcLbTpe1FcbNAjOeKJsfiKNGA3pK008I+9thXetLzGx6UZ2Z8Bg/HRzHaF",
+	"mrKXubVe0yb6MiYBmoEesTySQmOeMzPk3v5jXX1gkX1BprZo5hd6s4QovWeN/KUhSLzQUq99oDk0+kmi",
+	"jxqRCxJ3iyUNmccw2+k07FhzDIXPKGvgGq5gB5U+1QsouwFNtxsj1B+XxB/U4lKcxhUNUn2zvRqha1kE",
+	"QKO3qL7/wwbOs5SHfH0avrBxpp1GUotm7bp0RUsxQ+QzlaYEqv6k1XGJyqelI4PSaTliD3FcGmXVt3dc",
+	"2iX668flkwrXj8BDnDYS3zsvcVjalqc8+k3B+bfnNC3C1WcaLeAxIPYPKejbkYLetbhlBdeYNh5vQfNS",
+	"mJiaOo/PsqdbpDxs0Aei6TZ6bT7TvqVYgWeoRjtJHOqBGisYtylh6DCcShtvUFMXrTynaA3uBo/LJDRs",
+	"NGfJfdmmxHUzQE7g9MvsqfWZL35N0yGePG8A01ay6qyhrW0PHuyZVBhyumJIf1Gko1Z0RhqA0oEdJYh0",
+	"0CRWnYOORtkt++U9wRWqkppBUvwhAfI6XJNjqgEG/7KegLMHObo63U6fpSRtyLj5fatki/VeSzEb8o3N",
+	"8xstQVdl2Tvk85wL1ci5+/A6wrtL9GFvmnMiKE/XY99dnRwJHQ7euyTPVoQW/BZ4gUTY1KKAbQiSLOEk",
+	"iDeG9Olh+R5Q9BaFcHTmSk2BRQGfDOAyEPv8J2YxoMADsz9M67GW2iD9oq7MgNRU8HwCaa2TXJlqdwcj",
+	"ZiG1H1Jpy1VCWIXxeGSpK1AH13Qh4/dts+rrnEd6WQzDccKigaKLLDaCNTqRN03sFL7tdFuipAHwtfmo",
+	"iYu5Bdw0dr8Srodj94/Nxsw+RZhZ11RU0AixXiGFKv1tVpbe+s5GWJ6tDPcleGgrqxM6VyvDr1x1GDoH",
+	"WsPhcEWWG6ht3i1ObcKImCxQSnS5d+FKq6Quo6+vSUmkz6BPlXNWNfZ9hT8745dU1pRV5AYPoYDBQb0n",
+	"jVJDmUpiEnFGuiNWVBCrfkklmguaFMKitSDrjFM0IbbukOXqVGqYDCHrtu97Qz8R891Ptjvkd7kLJK9K",
+	"SY0JzA0JrEiQuU8P6y+RE0gvyryyFJbfZkC3xYmxKVrUdccGQ2+HpyfmBBB6RYSrJBDuoCKzeYbjKSre",
+	"kJAHX5ovHq42YCv1TqvLwLC6qysAKFFCm9R+y4cuH0R2pcsnETCEezmK7Lb4s+hB9cZ1bPCsdKpm2R14",
+	"qeM6j+8ZVhZ2HzFYpVLkV5M3MKyNOkreWD1xE6df+1zZITNMs2Y/sf4MZEw9JvCtlkeM8/Yy+Um3kcVL",
+	"I9KCblvLtbpvnYcUTgvM0OB0eAE83SjFA0Zss7/PomKp7uYHR1zOER8s1ymsNWzBWklOX8ZVyw69vKJS",
+	"Euef+gRZT3+wP8P+ft7dewQwPI9wulLLHzZLltcwrWLB+gvgYsuKlWUu2fSMMGW5XqfbyUXWOehMlZof",
+	"7EC1tWzKpTr45eXe7g6e052bvc7XP7/+3wEAkqM+bwSUAQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
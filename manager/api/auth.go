@@ -0,0 +1,391 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"golang.org/x/exp/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Role is the level of access that a caller has to the API. Each role can do
+// everything that the roles below it can do.
+type Role int
+
+const (
+	// RoleNone is the role of a caller that has not been granted access to the API
+	RoleNone Role = iota
+	// RoleReadOnly can read from the API
+	RoleReadOnly
+	// RoleOperator can also make changes through the API, e.g. registering charge
+	// stations or sending them calls
+	RoleOperator
+	// RoleAdmin can also manage the manager itself, e.g. draining it or reloading
+	// its configuration
+	RoleAdmin
+)
+
+var roleNames = map[Role]string{
+	RoleNone:     "none",
+	RoleReadOnly: "read-only",
+	RoleOperator: "operator",
+	RoleAdmin:    "admin",
+}
+
+func (r Role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("Role(%d)", r)
+}
+
+// ParseRole returns the role with the name: one of "read-only", "operator" or "admin"
+func ParseRole(name string) (Role, error) {
+	for role, roleName := range roleNames {
+		if role != RoleNone && roleName == name {
+			return role, nil
+		}
+	}
+	return RoleNone, fmt.Errorf("unknown role: %s", name)
+}
+
+// Principal is the caller of the API
+type Principal struct {
+	Name string
+	Role Role
+	// Tenant, if set, scopes the caller to the charge stations, tokens, transactions
+	// and reservations of one operator
+	Tenant string
+}
+
+var (
+	// ErrNoCredentials is returned by an Authenticator when the request does not
+	// carry any credentials that it understands
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials is returned by an Authenticator when the credentials
+	// carried by the request are not valid
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator identifies the caller of the API from the credentials in a request
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// Authenticators tries each Authenticator in turn, returning the result of the
+// first one that understands the credentials in the request
+type Authenticators []Authenticator
+
+func (a Authenticators) Authenticate(r *http.Request) (*Principal, error) {
+	for _, authenticator := range a {
+		principal, err := authenticator.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return principal, err
+	}
+	return nil, ErrNoCredentials
+}
+
+var bearerRegexp = regexp.MustCompile(`(?i)^Bearer (.*)$`)
+
+func bearerToken(r *http.Request) (string, bool) {
+	matches := bearerRegexp.FindStringSubmatch(r.Header.Get("Authorization"))
+	if len(matches) != 2 {
+		return "", false
+	}
+	return matches[1], true
+}
+
+// ApiKeyHeader is the header that carries an API key
+const ApiKeyHeader = "X-API-Key"
+
+type apiKey struct {
+	hash      [sha256.Size]byte
+	principal Principal
+}
+
+// ApiKeyAuthenticator authenticates requests that carry an API key, either in the
+// X-API-Key header or as the bearer token in the Authorization header
+type ApiKeyAuthenticator struct {
+	keys []apiKey
+}
+
+func NewApiKeyAuthenticator() *ApiKeyAuthenticator {
+	return new(ApiKeyAuthenticator)
+}
+
+// Add grants the principal access to the API with the key
+func (a *ApiKeyAuthenticator) Add(key string, principal Principal) {
+	a.keys = append(a.keys, apiKey{
+		hash:      sha256.Sum256([]byte(key)),
+		principal: principal,
+	})
+}
+
+func (a *ApiKeyAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	key := r.Header.Get(ApiKeyHeader)
+	fromHeader := key != ""
+	if !fromHeader {
+		var ok bool
+		key, ok = bearerToken(r)
+		if !ok {
+			return nil, ErrNoCredentials
+		}
+	}
+
+	// compare against every key so that the time taken does not reveal which key matched
+	hash := sha256.Sum256([]byte(key))
+	var principal *Principal
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(hash[:], a.keys[i].hash[:]) == 1 {
+			matched := a.keys[i].principal
+			principal = &matched
+		}
+	}
+	if principal != nil {
+		return principal, nil
+	}
+	if fromHeader {
+		return nil, ErrInvalidCredentials
+	}
+	// a bearer token that is not an API key may be understood by another authenticator
+	return nil, ErrNoCredentials
+}
+
+// OIDCAuthenticator authenticates requests that carry a JWT issued by an OpenID
+// Connect provider as the bearer token in the Authorization header. The role of the
+// caller is read from a claim of the token that holds a role name or a list of
+// role names: the caller is given the highest of the roles. The caller can also be
+// scoped to a tenant by a claim.
+type OIDCAuthenticator struct {
+	issuer      string
+	audience    string
+	rolesClaim  string
+	tenantClaim string
+	httpClient  *http.Client
+	keys        *jwk.AutoRefresh
+
+	mu      sync.Mutex
+	jwksUrl string
+}
+
+type OIDCOpt func(*OIDCAuthenticator)
+
+// WithJwksUrl sets the URL that the token signing keys are read from. By default,
+// the URL is discovered from the provider's OpenID configuration.
+func WithJwksUrl(url string) OIDCOpt {
+	return func(a *OIDCAuthenticator) {
+		a.jwksUrl = url
+	}
+}
+
+// WithRolesClaim sets the claim that holds the caller's roles, "roles" by default
+func WithRolesClaim(claim string) OIDCOpt {
+	return func(a *OIDCAuthenticator) {
+		a.rolesClaim = claim
+	}
+}
+
+// WithTenantClaim sets the claim that holds the tenant that the caller is scoped to: by
+// default callers are not scoped to a tenant
+func WithTenantClaim(claim string) OIDCOpt {
+	return func(a *OIDCAuthenticator) {
+		a.tenantClaim = claim
+	}
+}
+
+// WithOIDCHttpClient sets the client used to read the provider's configuration
+// and keys
+func WithOIDCHttpClient(client *http.Client) OIDCOpt {
+	return func(a *OIDCAuthenticator) {
+		a.httpClient = client
+	}
+}
+
+// NewOIDCAuthenticator returns an OIDCAuthenticator that accepts tokens from the
+// issuer for the audience. The provider's keys are read when the first token is
+// received and refreshed in the background until the context is done.
+func NewOIDCAuthenticator(ctx context.Context, issuer, audience string, opts ...OIDCOpt) *OIDCAuthenticator {
+	a := &OIDCAuthenticator{
+		issuer:     issuer,
+		audience:   audience,
+		rolesClaim: "roles",
+		httpClient: http.DefaultClient,
+		keys:       jwk.NewAutoRefresh(ctx),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.jwksUrl != "" {
+		a.keys.Configure(a.jwksUrl, jwk.WithHTTPClient(a.httpClient))
+	}
+	return a
+}
+
+func (a *OIDCAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	jwksUrl, err := a.discoverJwksUrl(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	keys, err := a.keys.Fetch(r.Context(), jwksUrl)
+	if err != nil {
+		return nil, fmt.Errorf("fetching oidc signing keys: %w", err)
+	}
+
+	parsed, err := jwt.ParseString(token,
+		jwt.WithKeySet(keys),
+		jwt.InferAlgorithmFromKey(true),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(a.issuer),
+		jwt.WithAudience(a.audience))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	principal := &Principal{Name: parsed.Subject()}
+	if a.tenantClaim != "" {
+		// a caller whose token does not name its tenant must not see every tenant
+		tenant, _ := parsed.Get(a.tenantClaim)
+		principal.Tenant, _ = tenant.(string)
+		if principal.Tenant == "" {
+			return nil, fmt.Errorf("%w: no %s claim", ErrInvalidCredentials, a.tenantClaim)
+		}
+	}
+	if claim, ok := parsed.Get(a.rolesClaim); ok {
+		var names []string
+		switch roles := claim.(type) {
+		case string:
+			names = strings.Fields(roles)
+		case []any:
+			for _, role := range roles {
+				if name, ok := role.(string); ok {
+					names = append(names, name)
+				}
+			}
+		}
+		for _, name := range names {
+			role, err := ParseRole(name)
+			if err == nil && role > principal.Role {
+				principal.Role = role
+			}
+		}
+	}
+	return principal, nil
+}
+
+// discoverJwksUrl reads the URL of the provider's keys from its OpenID configuration
+func (a *OIDCAuthenticator) discoverJwksUrl(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.jwksUrl != "" {
+		return a.jwksUrl, nil
+	}
+
+	url := strings.TrimSuffix(a.issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating oidc discovery request: %w", err)
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("reading oidc configuration: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("reading oidc configuration: status code %d", resp.StatusCode)
+	}
+	var discovery struct {
+		JwksUri string `json:"jwks_uri"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&discovery)
+	if err != nil {
+		return "", fmt.Errorf("decoding oidc configuration: %w", err)
+	}
+	if discovery.JwksUri == "" {
+		return "", errors.New("oidc configuration has no jwks_uri")
+	}
+
+	a.jwksUrl = discovery.JwksUri
+	a.keys.Configure(a.jwksUrl, jwk.WithHTTPClient(a.httpClient))
+	return a.jwksUrl, nil
+}
+
+type principalContextKey struct{}
+
+// PrincipalFromContext returns the caller that was authenticated by the Authorize
+// middleware, or nil when the API does not require authentication
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return principal
+}
+
+// Authorize returns middleware that only lets a request through when the caller
+// has at least the role required by the route group: read is required for GET,
+// HEAD and OPTIONS requests and write for the other methods. A request without
+// valid credentials is rejected as unauthorized and one from a caller without the
+// role as forbidden. When there is no authenticator every request is let through.
+func Authorize(authenticator Authenticator, read, write Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if authenticator == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authenticator.Authenticate(r)
+			if err != nil {
+				if !errors.Is(err, ErrNoCredentials) {
+					slog.Warn("authenticating api request", "path", r.URL.Path, "err", err)
+				}
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			required := write
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				required = read
+			}
+			if principal.Role < required {
+				slog.Warn("forbidden api request", "path", r.URL.Path, "method", r.Method,
+					"principal", principal.Name, "role", principal.Role, "required", required)
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalContextKey{}, principal)))
+		})
+	}
+}
+
+// RejectTenants is middleware that forbids callers that are scoped to a tenant from
+// using routes that act on the whole manager, e.g. draining it or the admin UI. It
+// follows Authorize, which identifies the caller.
+func RejectTenants(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principal := PrincipalFromContext(r.Context()); principal != nil && principal.Tenant != "" {
+			slog.Warn("forbidden api request from tenant", "path", r.URL.Path, "method", r.Method,
+				"principal", principal.Name, "tenant", principal.Tenant)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func authorizedHandler(authenticator api.Authenticator, read, write api.Role) http.Handler {
+	return api.Authorize(authenticator, read, write)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := api.PrincipalFromContext(r.Context())
+		if principal != nil {
+			_, _ = w.Write([]byte(principal.Name))
+		}
+	}))
+}
+
+func TestParseRole(t *testing.T) {
+	for _, role := range []api.Role{api.RoleReadOnly, api.RoleOperator, api.RoleAdmin} {
+		parsed, err := api.ParseRole(role.String())
+		require.NoError(t, err)
+		assert.Equal(t, role, parsed)
+	}
+
+	_, err := api.ParseRole("none")
+	assert.Error(t, err)
+}
+
+func TestAuthorizeWithoutAuthenticator(t *testing.T) {
+	handler := authorizedHandler(nil, api.RoleAdmin, api.RoleAdmin)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthorizeWithApiKeys(t *testing.T) {
+	apiKeys := api.NewApiKeyAuthenticator()
+	apiKeys.Add("reader-key", api.Principal{Name: "reader", Role: api.RoleReadOnly})
+	apiKeys.Add("operator-key", api.Principal{Name: "operator", Role: api.RoleOperator})
+	handler := authorizedHandler(apiKeys, api.RoleReadOnly, api.RoleOperator)
+
+	tests := map[string]struct {
+		method string
+		header string
+		value  string
+		want   int
+	}{
+		"no credentials":           {http.MethodGet, "", "", http.StatusUnauthorized},
+		"unknown key":              {http.MethodGet, api.ApiKeyHeader, "unknown", http.StatusUnauthorized},
+		"unknown bearer token":     {http.MethodGet, "Authorization", "Bearer unknown", http.StatusUnauthorized},
+		"reader reads":             {http.MethodGet, api.ApiKeyHeader, "reader-key", http.StatusOK},
+		"reader writes":            {http.MethodPost, api.ApiKeyHeader, "reader-key", http.StatusForbidden},
+		"operator writes":          {http.MethodPost, api.ApiKeyHeader, "operator-key", http.StatusOK},
+		"operator key as a bearer": {http.MethodPost, "Authorization", "Bearer operator-key", http.StatusOK},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/", nil)
+			if tc.header != "" {
+				req.Header.Set(tc.header, tc.value)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tc.want, w.Code)
+			if tc.want == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+type oidcProvider struct {
+	server *httptest.Server
+	key    jwk.Key
+}
+
+func newOidcProvider(t *testing.T) *oidcProvider {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key, err := jwk.New(rsaKey)
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, "test"))
+	require.NoError(t, key.Set(jwk.AlgorithmKey, jwa.RS256))
+	publicKey, err := key.PublicKey()
+	require.NoError(t, err)
+	keys := jwk.NewSet()
+	keys.Add(publicKey)
+
+	p := &oidcProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   p.server.URL,
+			"jwks_uri": p.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(keys)
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *oidcProvider) token(t *testing.T, audience string, roles any) string {
+	return p.tokenWithClaims(t, audience, roles, nil)
+}
+
+func (p *oidcProvider) tokenWithClaims(t *testing.T, audience string, roles any, claims map[string]any) string {
+	token := jwt.New()
+	for name, value := range claims {
+		require.NoError(t, token.Set(name, value))
+	}
+	require.NoError(t, token.Set(jwt.IssuerKey, p.server.URL))
+	require.NoError(t, token.Set(jwt.SubjectKey, "someone@example.com"))
+	require.NoError(t, token.Set(jwt.AudienceKey, audience))
+	require.NoError(t, token.Set(jwt.ExpirationKey, time.Now().Add(time.Hour)))
+	if roles != nil {
+		require.NoError(t, token.Set("roles", roles))
+	}
+	signed, err := jwt.Sign(token, jwa.RS256, p.key)
+	require.NoError(t, err)
+	return string(signed)
+}
+
+func TestAuthorizeWithOidcTokens(t *testing.T) {
+	provider := newOidcProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	authenticator := api.NewOIDCAuthenticator(ctx, provider.server.URL, "csms")
+	handler := authorizedHandler(authenticator, api.RoleReadOnly, api.RoleAdmin)
+
+	tests := map[string]struct {
+		method string
+		token  string
+		want   int
+	}{
+		"not a token":       {http.MethodGet, "not-a-token", http.StatusUnauthorized},
+		"wrong audience":    {http.MethodGet, provider.token(t, "other", "admin"), http.StatusUnauthorized},
+		"no roles":          {http.MethodGet, provider.token(t, "csms", nil), http.StatusForbidden},
+		"reader reads":      {http.MethodGet, provider.token(t, "csms", "read-only"), http.StatusOK},
+		"operator writes":   {http.MethodPost, provider.token(t, "csms", []string{"read-only", "operator"}), http.StatusForbidden},
+		"admin writes":      {http.MethodPost, provider.token(t, "csms", []string{"operator", "admin"}), http.StatusOK},
+		"unknown role name": {http.MethodGet, provider.token(t, "csms", []string{"superuser"}), http.StatusForbidden},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tc.want, w.Code)
+			if tc.want == http.StatusOK {
+				assert.Equal(t, "someone@example.com", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthenticatorsTryEachAuthenticator(t *testing.T) {
+	provider := newOidcProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	apiKeys := api.NewApiKeyAuthenticator()
+	apiKeys.Add("admin-key", api.Principal{Name: "admin", Role: api.RoleAdmin})
+	authenticators := api.Authenticators{apiKeys,
+		api.NewOIDCAuthenticator(ctx, provider.server.URL, "csms", api.WithJwksUrl(provider.server.URL+"/keys"))}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer admin-key")
+	principal, err := authenticators.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, &api.Principal{Name: "admin", Role: api.RoleAdmin}, principal)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+provider.token(t, "csms", "operator"))
+	principal, err = authenticators.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, &api.Principal{Name: "someone@example.com", Role: api.RoleOperator}, principal)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err = authenticators.Authenticate(req)
+	assert.ErrorIs(t, err, api.ErrNoCredentials)
+}
+
+func TestOidcTokensScopeCallersToTenants(t *testing.T) {
+	provider := newOidcProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	authenticator := api.NewOIDCAuthenticator(ctx, provider.server.URL, "csms", api.WithTenantClaim("tenant"))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+provider.tokenWithClaims(t, "csms", "operator", map[string]any{"tenant": "GB*TWK"}))
+	principal, err := authenticator.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, &api.Principal{Name: "someone@example.com", Role: api.RoleOperator, Tenant: "GB*TWK"}, principal)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+provider.token(t, "csms", "operator"))
+	_, err = authenticator.Authenticate(req)
+	assert.ErrorIs(t, err, api.ErrInvalidCredentials)
+}
+
+func TestRejectTenants(t *testing.T) {
+	apiKeys := api.NewApiKeyAuthenticator()
+	apiKeys.Add("tenant-key", api.Principal{Name: "tenant", Role: api.RoleAdmin, Tenant: "a"})
+	apiKeys.Add("global-key", api.Principal{Name: "global", Role: api.RoleAdmin})
+	handler := api.Authorize(apiKeys, api.RoleAdmin, api.RoleAdmin)(api.RejectTenants(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(api.ApiKeyHeader, "tenant-key")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(api.ApiKeyHeader, "global-key")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+)
+
+// defaultBulkCommandMaxInFlight is the number of charge stations that are sent a bulk
+// command at a time when the request does not say
+const defaultBulkCommandMaxInFlight = 10
+
+func (s *Server) CreateBulkCommand(w http.ResponseWriter, r *http.Request) {
+	req := new(BulkCommandRequest)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	bulkCommand, err := newStoreBulkCommand(req)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	bulkCommand.Id = store.NewBulkCommandId()
+	bulkCommand.Status = store.BulkCommandStatusRunning
+	bulkCommand.TenantId = tenantOf(r)
+	bulkCommand.CreatedAt = s.clock.Now().UTC()
+	if principal := PrincipalFromContext(r.Context()); principal != nil {
+		bulkCommand.CreatedBy = principal.Name
+	}
+
+	chargeStationIds, err := store.SelectBulkCommandChargeStations(r.Context(), s.store, &bulkCommand.Selector, bulkCommand.TenantId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if len(chargeStationIds) == 0 {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("no charge stations selected")))
+		return
+	}
+
+	for _, csId := range chargeStationIds {
+		if err = s.auditCommand(r, csId, "createBulkCommand", map[string]any{
+			"bulkCommandId": bulkCommand.Id, "request": req}); err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
+			return
+		}
+		bulkCommand.Results = append(bulkCommand.Results, &store.BulkCommandResult{
+			ChargeStationId: csId,
+			Status:          store.BulkCommandResultStatusPending,
+		})
+	}
+
+	err = s.store.SetBulkCommand(r.Context(), bulkCommand)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.Render(w, r, newBulkCommand(bulkCommand))
+}
+
+func (s *Server) LookupBulkCommand(w http.ResponseWriter, r *http.Request, bulkCommandId string) {
+	bulkCommand := s.lookupBulkCommand(w, r, bulkCommandId)
+	if bulkCommand == nil {
+		return
+	}
+
+	_ = render.Render(w, r, newBulkCommand(bulkCommand))
+}
+
+func (s *Server) PauseBulkCommand(w http.ResponseWriter, r *http.Request, bulkCommandId string) {
+	bulkCommand := s.lookupBulkCommand(w, r, bulkCommandId)
+	if bulkCommand == nil {
+		return
+	}
+	if bulkCommand.Status == store.BulkCommandStatusCompleted {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("bulk command %s is %s", bulkCommandId, bulkCommand.Status)))
+		return
+	}
+
+	bulkCommand.Status = store.BulkCommandStatusPaused
+	err := s.store.SetBulkCommand(r.Context(), bulkCommand)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	_ = render.Render(w, r, newBulkCommand(bulkCommand))
+}
+
+func (s *Server) ResumeBulkCommand(w http.ResponseWriter, r *http.Request, bulkCommandId string) {
+	bulkCommand := s.lookupBulkCommand(w, r, bulkCommandId)
+	if bulkCommand == nil {
+		return
+	}
+
+	// the charge stations that failed are sent the command again
+	for _, result := range bulkCommand.Results {
+		if result.Status == store.BulkCommandResultStatusFailed {
+			*result = store.BulkCommandResult{
+				ChargeStationId: result.ChargeStationId,
+				Status:          store.BulkCommandResultStatusPending,
+			}
+		}
+	}
+	bulkCommand.Status = store.BulkCommandStatusRunning
+	err := s.store.SetBulkCommand(r.Context(), bulkCommand)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	_ = render.Render(w, r, newBulkCommand(bulkCommand))
+}
+
+// newStoreBulkCommand returns the bulk command for the request, without the fields that
+// are set when it is created, or an error if the request is missing the parameters of
+// its action
+func newStoreBulkCommand(req *BulkCommandRequest) (*store.BulkCommand, error) {
+	value := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+	bulkCommand := &store.BulkCommand{
+		Action: store.BulkCommandAction(req.Action),
+		Selector: store.BulkCommandSelector{
+			SiteId:          value(req.Selector.SiteId),
+			FirmwareVersion: value(req.Selector.FirmwareVersion),
+			Vendor:          value(req.Selector.Vendor),
+			Model:           value(req.Selector.Model),
+		},
+		MaxInFlight: defaultBulkCommandMaxInFlight,
+	}
+	if req.Selector.Tags != nil {
+		bulkCommand.Selector.Tags = *req.Selector.Tags
+	}
+	if req.MaxInFlight != nil {
+		bulkCommand.MaxInFlight = *req.MaxInFlight
+	}
+
+	switch req.Action {
+	case BulkCommandRequestActionReset:
+		bulkCommand.ResetType = string(BulkCommandRequestResetTypeImmediate)
+		if req.ResetType != nil {
+			bulkCommand.ResetType = string(*req.ResetType)
+		}
+	case BulkCommandRequestActionChangeConfiguration:
+		if req.Key == nil || *req.Key == "" || req.Value == nil {
+			return nil, errors.New("key and value are required for ChangeConfiguration")
+		}
+		bulkCommand.Key = *req.Key
+		bulkCommand.Value = *req.Value
+	case BulkCommandRequestActionUpdateFirmware:
+		if req.FirmwareLocation == nil || *req.FirmwareLocation == "" {
+			return nil, errors.New("firmwareLocation is required for UpdateFirmware")
+		}
+		bulkCommand.FirmwareLocation = *req.FirmwareLocation
+		if req.FirmwareRetrieveDate != nil {
+			bulkCommand.FirmwareRetrieveDate = req.FirmwareRetrieveDate.UTC()
+		}
+	}
+	return bulkCommand, nil
+}
+
+// lookupBulkCommand returns the bulk command, or renders an error response and returns
+// nil if it does not exist or belongs to another tenant
+func (s *Server) lookupBulkCommand(w http.ResponseWriter, r *http.Request, bulkCommandId string) *store.BulkCommand {
+	bulkCommand, err := s.store.LookupBulkCommand(r.Context(), bulkCommandId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return nil
+	}
+	if bulkCommand == nil || !inTenant(r, bulkCommand.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return nil
+	}
+	return bulkCommand
+}
+
+func newBulkCommand(bulkCommand *store.BulkCommand) *BulkCommand {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	resp := &BulkCommand{
+		Id:               bulkCommand.Id,
+		Action:           BulkCommandAction(bulkCommand.Action),
+		Selector:         newBulkCommandSelector(&bulkCommand.Selector),
+		Key:              optional(bulkCommand.Key),
+		Value:            optional(bulkCommand.Value),
+		FirmwareLocation: optional(bulkCommand.FirmwareLocation),
+		MaxInFlight:      bulkCommand.MaxInFlight,
+		Status:           BulkCommandStatus(bulkCommand.Status),
+		CreatedBy:        optional(bulkCommand.CreatedBy),
+		CreatedAt:        bulkCommand.CreatedAt.UTC(),
+		Results:          make([]BulkCommandResult, len(bulkCommand.Results)),
+	}
+	if bulkCommand.Action == store.BulkCommandActionChangeConfiguration {
+		// an empty value is a valid configuration value
+		resp.Value = &bulkCommand.Value
+	}
+	if bulkCommand.ResetType != "" {
+		resetType := BulkCommandResetType(bulkCommand.ResetType)
+		resp.ResetType = &resetType
+	}
+	if !bulkCommand.FirmwareRetrieveDate.IsZero() {
+		retrieveDate := bulkCommand.FirmwareRetrieveDate.UTC()
+		resp.FirmwareRetrieveDate = &retrieveDate
+	}
+	for i, result := range bulkCommand.Results {
+		resp.Results[i] = BulkCommandResult{
+			ChargeStationId: result.ChargeStationId,
+			Status:          BulkCommandResultStatus(result.Status),
+			Result:          optional(result.Result),
+		}
+		if !result.SentAt.IsZero() {
+			sentAt := result.SentAt.UTC()
+			resp.Results[i].SentAt = &sentAt
+		}
+		if !result.CompletedAt.IsZero() {
+			completedAt := result.CompletedAt.UTC()
+			resp.Results[i].CompletedAt = &completedAt
+		}
+	}
+	return resp
+}
+
+func newBulkCommandSelector(selector *store.BulkCommandSelector) BulkCommandSelector {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	resp := BulkCommandSelector{
+		SiteId:          optional(selector.SiteId),
+		FirmwareVersion: optional(selector.FirmwareVersion),
+		Vendor:          optional(selector.Vendor),
+		Model:           optional(selector.Model),
+	}
+	if len(selector.Tags) > 0 {
+		resp.Tags = &selector.Tags
+	}
+	return resp
+}
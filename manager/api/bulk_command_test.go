@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"testing"
+)
+
+func TestCreateBulkCommand(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a", Vendor: "Acme"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{TenantId: "a", Vendor: "Other"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs003", &store.ChargeStation{TenantId: "b", Vendor: "Acme"}))
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/commands/bulk",
+		`{"action":"Reset","selector":{"vendor":"Acme"},"resetType":"OnIdle","maxInFlight":5}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var bulkCommand api.BulkCommand
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &bulkCommand))
+	assert.Equal(t, api.BulkCommandActionReset, bulkCommand.Action)
+	require.NotNil(t, bulkCommand.ResetType)
+	assert.Equal(t, api.BulkCommandResetTypeOnIdle, *bulkCommand.ResetType)
+	assert.Equal(t, 5, bulkCommand.MaxInFlight)
+	assert.Equal(t, api.BulkCommandStatusRunning, bulkCommand.Status)
+	require.NotNil(t, bulkCommand.CreatedBy)
+	assert.Equal(t, "a", *bulkCommand.CreatedBy)
+	require.Len(t, bulkCommand.Results, 1)
+	assert.Equal(t, "cs001", bulkCommand.Results[0].ChargeStationId)
+	assert.Equal(t, api.BulkCommandResultStatusPending, bulkCommand.Results[0].Status)
+
+	got, err := engine.LookupBulkCommand(ctx, bulkCommand.Id)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "a", got.TenantId)
+	assert.Equal(t, "OnIdle", got.ResetType)
+
+	records := listCommands(t, handler, "a-key", "?command=createBulkCommand")
+	require.Len(t, records, 1)
+	assert.Equal(t, "cs001", records[0].ChargeStationId)
+
+	// another tenant cannot see the bulk command
+	rr = serveAs(handler, "b-key", http.MethodGet, "/commands/bulk/"+bulkCommand.Id, "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveAs(handler, "a-key", http.MethodGet, "/commands/bulk/"+bulkCommand.Id, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestCreateBulkCommandRejectsInvalidRequests(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	require.NoError(t, engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{TenantId: "a"}))
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/commands/bulk",
+		`{"action":"ChangeConfiguration","selector":{},"key":"HeartbeatInterval"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serveAs(handler, "a-key", http.MethodPost, "/commands/bulk", `{"action":"UpdateFirmware","selector":{}}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serveAs(handler, "a-key", http.MethodPost, "/commands/bulk", `{"action":"ClearCache","selector":{"siteId":"unknown"}}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestPauseAndResumeBulkCommand(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	bulkCommand := &store.BulkCommand{
+		Id:          store.NewBulkCommandId(),
+		Action:      store.BulkCommandActionClearCache,
+		MaxInFlight: 10,
+		Status:      store.BulkCommandStatusRunning,
+		TenantId:    "a",
+		Results: []*store.BulkCommandResult{
+			{ChargeStationId: "cs001", Status: store.BulkCommandResultStatusAccepted, Result: "Accepted"},
+			{ChargeStationId: "cs002", Status: store.BulkCommandResultStatusFailed, Result: "Rejected"},
+		},
+	}
+	require.NoError(t, engine.SetBulkCommand(ctx, bulkCommand))
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/commands/bulk/"+bulkCommand.Id+"/pause", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	got, err := engine.LookupBulkCommand(ctx, bulkCommand.Id)
+	require.NoError(t, err)
+	assert.Equal(t, store.BulkCommandStatusPaused, got.Status)
+
+	rr = serveAs(handler, "a-key", http.MethodPost, "/commands/bulk/"+bulkCommand.Id+"/resume", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	got, err = engine.LookupBulkCommand(ctx, bulkCommand.Id)
+	require.NoError(t, err)
+	assert.Equal(t, store.BulkCommandStatusRunning, got.Status)
+	assert.Equal(t, store.BulkCommandResultStatusAccepted, got.Results[0].Status)
+	// the charge stations that failed are sent the command again
+	assert.Equal(t, store.BulkCommandResultStatusPending, got.Results[1].Status)
+	assert.Empty(t, got.Results[1].Result)
+
+	got.Status = store.BulkCommandStatusCompleted
+	require.NoError(t, engine.SetBulkCommand(ctx, got))
+	rr = serveAs(handler, "a-key", http.MethodPost, "/commands/bulk/"+bulkCommand.Id+"/pause", "")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
@@ -0,0 +1,11225 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.13.0 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+)
+
+// Defines values for BulkCommandAction.
+const (
+	BulkCommandActionChangeConfiguration BulkCommandAction = "ChangeConfiguration"
+	BulkCommandActionClearCache          BulkCommandAction = "ClearCache"
+	BulkCommandActionReset               BulkCommandAction = "Reset"
+	BulkCommandActionUpdateFirmware      BulkCommandAction = "UpdateFirmware"
+)
+
+// Defines values for BulkCommandResetType.
+const (
+	BulkCommandResetTypeImmediate BulkCommandResetType = "Immediate"
+	BulkCommandResetTypeOnIdle    BulkCommandResetType = "OnIdle"
+)
+
+// Defines values for BulkCommandStatus.
+const (
+	BulkCommandStatusCompleted BulkCommandStatus = "Completed"
+	BulkCommandStatusPaused    BulkCommandStatus = "Paused"
+	BulkCommandStatusRunning   BulkCommandStatus = "Running"
+)
+
+// Defines values for BulkCommandRequestAction.
+const (
+	BulkCommandRequestActionChangeConfiguration BulkCommandRequestAction = "ChangeConfiguration"
+	BulkCommandRequestActionClearCache          BulkCommandRequestAction = "ClearCache"
+	BulkCommandRequestActionReset               BulkCommandRequestAction = "Reset"
+	BulkCommandRequestActionUpdateFirmware      BulkCommandRequestAction = "UpdateFirmware"
+)
+
+// Defines values for BulkCommandRequestResetType.
+const (
+	BulkCommandRequestResetTypeImmediate BulkCommandRequestResetType = "Immediate"
+	BulkCommandRequestResetTypeOnIdle    BulkCommandRequestResetType = "OnIdle"
+)
+
+// Defines values for BulkCommandResultStatus.
+const (
+	BulkCommandResultStatusAccepted BulkCommandResultStatus = "Accepted"
+	BulkCommandResultStatusFailed   BulkCommandResultStatus = "Failed"
+	BulkCommandResultStatusPending  BulkCommandResultStatus = "Pending"
+	BulkCommandResultStatusSent     BulkCommandResultStatus = "Sent"
+)
+
+// Defines values for ChargeStationAvailabilityWindowStatus.
+const (
+	Charging    ChargeStationAvailabilityWindowStatus = "Charging"
+	Free        ChargeStationAvailabilityWindowStatus = "Free"
+	Reserved    ChargeStationAvailabilityWindowStatus = "Reserved"
+	Unavailable ChargeStationAvailabilityWindowStatus = "Unavailable"
+)
+
+// Defines values for ChargeStationInstallCertificatesCertificatesStatus.
+const (
+	ChargeStationInstallCertificatesCertificatesStatusAccepted ChargeStationInstallCertificatesCertificatesStatus = "Accepted"
+	ChargeStationInstallCertificatesCertificatesStatusPending  ChargeStationInstallCertificatesCertificatesStatus = "Pending"
+	ChargeStationInstallCertificatesCertificatesStatusRejected ChargeStationInstallCertificatesCertificatesStatus = "Rejected"
+)
+
+// Defines values for ChargeStationInstallCertificatesCertificatesType.
+const (
+	ChargeStationInstallCertificatesCertificatesTypeCSMS ChargeStationInstallCertificatesCertificatesType = "CSMS"
+	ChargeStationInstallCertificatesCertificatesTypeMF   ChargeStationInstallCertificatesCertificatesType = "MF"
+	ChargeStationInstallCertificatesCertificatesTypeMO   ChargeStationInstallCertificatesCertificatesType = "MO"
+	ChargeStationInstallCertificatesCertificatesTypeV2G  ChargeStationInstallCertificatesCertificatesType = "V2G"
+)
+
+// Defines values for ChargeStationInstalledCertificatesRefreshStatus.
+const (
+	ChargeStationInstalledCertificatesRefreshStatusAccepted ChargeStationInstalledCertificatesRefreshStatus = "Accepted"
+	ChargeStationInstalledCertificatesRefreshStatusPending  ChargeStationInstalledCertificatesRefreshStatus = "Pending"
+)
+
+// Defines values for ChargeStationLivenessStatus.
+const (
+	Offline ChargeStationLivenessStatus = "Offline"
+	Online  ChargeStationLivenessStatus = "Online"
+)
+
+// Defines values for ChargeStationRegistrationStatus.
+const (
+	ChargeStationRegistrationStatusAccepted ChargeStationRegistrationStatus = "Accepted"
+	ChargeStationRegistrationStatusPending  ChargeStationRegistrationStatus = "Pending"
+	ChargeStationRegistrationStatusRejected ChargeStationRegistrationStatus = "Rejected"
+)
+
+// Defines values for ChargeStationTriggerTrigger.
+const (
+	BootNotification               ChargeStationTriggerTrigger = "BootNotification"
+	SignChargingStationCertificate ChargeStationTriggerTrigger = "SignChargingStationCertificate"
+	SignCombinedCertificate        ChargeStationTriggerTrigger = "SignCombinedCertificate"
+	SignV2GCertificate             ChargeStationTriggerTrigger = "SignV2GCertificate"
+	StatusNotification             ChargeStationTriggerTrigger = "StatusNotification"
+)
+
+// Defines values for CommandAuditRecordStatus.
+const (
+	Completed CommandAuditRecordStatus = "Completed"
+	Failed    CommandAuditRecordStatus = "Failed"
+	NotSent   CommandAuditRecordStatus = "NotSent"
+	Requested CommandAuditRecordStatus = "Requested"
+	Sent      CommandAuditRecordStatus = "Sent"
+)
+
+// Defines values for ConnectorFormat.
+const (
+	CABLE  ConnectorFormat = "CABLE"
+	SOCKET ConnectorFormat = "SOCKET"
+)
+
+// Defines values for ConnectorPowerType.
+const (
+	AC1PHASE ConnectorPowerType = "AC_1_PHASE"
+	AC3PHASE ConnectorPowerType = "AC_3_PHASE"
+	DC       ConnectorPowerType = "DC"
+)
+
+// Defines values for ConnectorStandard.
+const (
+	CHADEMO            ConnectorStandard = "CHADEMO"
+	CHAOJI             ConnectorStandard = "CHAOJI"
+	DOMESTICA          ConnectorStandard = "DOMESTIC_A"
+	DOMESTICB          ConnectorStandard = "DOMESTIC_B"
+	DOMESTICC          ConnectorStandard = "DOMESTIC_C"
+	DOMESTICD          ConnectorStandard = "DOMESTIC_D"
+	DOMESTICE          ConnectorStandard = "DOMESTIC_E"
+	DOMESTICF          ConnectorStandard = "DOMESTIC_F"
+	DOMESTICG          ConnectorStandard = "DOMESTIC_G"
+	DOMESTICH          ConnectorStandard = "DOMESTIC_H"
+	DOMESTICI          ConnectorStandard = "DOMESTIC_I"
+	DOMESTICJ          ConnectorStandard = "DOMESTIC_J"
+	DOMESTICK          ConnectorStandard = "DOMESTIC_K"
+	DOMESTICL          ConnectorStandard = "DOMESTIC_L"
+	GBTAC              ConnectorStandard = "GBT_AC"
+	GBTDC              ConnectorStandard = "GBT_DC"
+	IEC603092Single16  ConnectorStandard = "IEC_60309_2_single_16"
+	IEC603092Three16   ConnectorStandard = "IEC_60309_2_three_16"
+	IEC603092Three32   ConnectorStandard = "IEC_60309_2_three_32"
+	IEC603092Three64   ConnectorStandard = "IEC_60309_2_three_64"
+	IEC62196T1         ConnectorStandard = "IEC_62196_T1"
+	IEC62196T1COMBO    ConnectorStandard = "IEC_62196_T1_COMBO"
+	IEC62196T2         ConnectorStandard = "IEC_62196_T2"
+	IEC62196T2COMBO    ConnectorStandard = "IEC_62196_T2_COMBO"
+	IEC62196T3A        ConnectorStandard = "IEC_62196_T3A"
+	IEC62196T3C        ConnectorStandard = "IEC_62196_T3C"
+	NEMA1030           ConnectorStandard = "NEMA_10_30"
+	NEMA1050           ConnectorStandard = "NEMA_10_50"
+	NEMA1430           ConnectorStandard = "NEMA_14_30"
+	NEMA1450           ConnectorStandard = "NEMA_14_50"
+	NEMA520            ConnectorStandard = "NEMA_5_20"
+	NEMA630            ConnectorStandard = "NEMA_6_30"
+	NEMA650            ConnectorStandard = "NEMA_6_50"
+	PANTOGRAPHBOTTOMUP ConnectorStandard = "PANTOGRAPH_BOTTOM_UP"
+	PANTOGRAPHTOPDOWN  ConnectorStandard = "PANTOGRAPH_TOP_DOWN"
+	TESLAR             ConnectorStandard = "TESLA_R"
+	TESLAS             ConnectorStandard = "TESLA_S"
+	UNKNOWN            ConnectorStandard = "UNKNOWN"
+)
+
+// Defines values for ExportFormat.
+const (
+	Csv  ExportFormat = "csv"
+	Json ExportFormat = "json"
+)
+
+// Defines values for InstalledCertificateHashAlgorithm.
+const (
+	SHA256 InstalledCertificateHashAlgorithm = "SHA256"
+	SHA384 InstalledCertificateHashAlgorithm = "SHA384"
+	SHA512 InstalledCertificateHashAlgorithm = "SHA512"
+)
+
+// Defines values for InstalledCertificateStatus.
+const (
+	DeleteFailed  InstalledCertificateStatus = "DeleteFailed"
+	DeletePending InstalledCertificateStatus = "DeletePending"
+	Installed     InstalledCertificateStatus = "Installed"
+)
+
+// Defines values for InstalledCertificateType.
+const (
+	InstalledCertificateTypeCSMS InstalledCertificateType = "CSMS"
+	InstalledCertificateTypeEVCC InstalledCertificateType = "EVCC"
+	InstalledCertificateTypeMF   InstalledCertificateType = "MF"
+	InstalledCertificateTypeMO   InstalledCertificateType = "MO"
+	InstalledCertificateTypeV2G  InstalledCertificateType = "V2G"
+)
+
+// Defines values for LocationParkingType.
+const (
+	ALONGMOTORWAY     LocationParkingType = "ALONG_MOTORWAY"
+	ONDRIVEWAY        LocationParkingType = "ON_DRIVEWAY"
+	ONSTREET          LocationParkingType = "ON_STREET"
+	PARKINGGARAGE     LocationParkingType = "PARKING_GARAGE"
+	PARKINGLOT        LocationParkingType = "PARKING_LOT"
+	UNDERGROUNDGARAGE LocationParkingType = "UNDERGROUND_GARAGE"
+)
+
+// Defines values for PriceComponentType.
+const (
+	ENERGY      PriceComponentType = "ENERGY"
+	FLAT        PriceComponentType = "FLAT"
+	PARKINGTIME PriceComponentType = "PARKING_TIME"
+	TIME        PriceComponentType = "TIME"
+)
+
+// Defines values for ReceiptFormat.
+const (
+	ReceiptFormatHtml ReceiptFormat = "html"
+	ReceiptFormatJson ReceiptFormat = "json"
+)
+
+// Defines values for ReceiptLineType.
+const (
+	ReceiptLineTypeEnergy      ReceiptLineType = "ENERGY"
+	ReceiptLineTypeFlat        ReceiptLineType = "FLAT"
+	ReceiptLineTypeParkingTime ReceiptLineType = "PARKING_TIME"
+	ReceiptLineTypeTime        ReceiptLineType = "TIME"
+	ReceiptLineTypeTotal       ReceiptLineType = "TOTAL"
+)
+
+// Defines values for RegistrationStatus.
+const (
+	PENDING    RegistrationStatus = "PENDING"
+	REGISTERED RegistrationStatus = "REGISTERED"
+)
+
+// Defines values for ReservationStatus.
+const (
+	ReservationStatusAccepted      ReservationStatus = "Accepted"
+	ReservationStatusCancelPending ReservationStatus = "CancelPending"
+	ReservationStatusCancelled     ReservationStatus = "Cancelled"
+	ReservationStatusPending       ReservationStatus = "Pending"
+	ReservationStatusRejected      ReservationStatus = "Rejected"
+	ReservationStatusUsed          ReservationStatus = "Used"
+)
+
+// Defines values for ReservationTokenType.
+const (
+	Central         ReservationTokenType = "Central"
+	EMAID           ReservationTokenType = "eMAID"
+	ISO14443        ReservationTokenType = "ISO14443"
+	ISO15693        ReservationTokenType = "ISO15693"
+	KeyCode         ReservationTokenType = "KeyCode"
+	Local           ReservationTokenType = "Local"
+	MacAddress      ReservationTokenType = "MacAddress"
+	NoAuthorization ReservationTokenType = "NoAuthorization"
+)
+
+// Defines values for ReservationTransferStatus.
+const (
+	ReservationTransferStatusAccepted ReservationTransferStatus = "Accepted"
+	ReservationTransferStatusPending  ReservationTransferStatus = "Pending"
+	ReservationTransferStatusRejected ReservationTransferStatus = "Rejected"
+)
+
+// Defines values for TariffType.
+const (
+	ADHOCPAYMENT TariffType = "AD_HOC_PAYMENT"
+	PROFILECHEAP TariffType = "PROFILE_CHEAP"
+	PROFILEFAST  TariffType = "PROFILE_FAST"
+	PROFILEGREEN TariffType = "PROFILE_GREEN"
+	REGULAR      TariffType = "REGULAR"
+)
+
+// Defines values for TariffRestrictionsDayOfWeek.
+const (
+	FRIDAY    TariffRestrictionsDayOfWeek = "FRIDAY"
+	MONDAY    TariffRestrictionsDayOfWeek = "MONDAY"
+	SATURDAY  TariffRestrictionsDayOfWeek = "SATURDAY"
+	SUNDAY    TariffRestrictionsDayOfWeek = "SUNDAY"
+	THURSDAY  TariffRestrictionsDayOfWeek = "THURSDAY"
+	TUESDAY   TariffRestrictionsDayOfWeek = "TUESDAY"
+	WEDNESDAY TariffRestrictionsDayOfWeek = "WEDNESDAY"
+)
+
+// Defines values for TokenCacheMode.
+const (
+	ALLOWED        TokenCacheMode = "ALLOWED"
+	ALLOWEDOFFLINE TokenCacheMode = "ALLOWED_OFFLINE"
+	ALWAYS         TokenCacheMode = "ALWAYS"
+	NEVER          TokenCacheMode = "NEVER"
+)
+
+// Defines values for TokenType.
+const (
+	ADHOCUSER TokenType = "AD_HOC_USER"
+	APPUSER   TokenType = "APP_USER"
+	OTHER     TokenType = "OTHER"
+	RFID      TokenType = "RFID"
+)
+
+// Defines values for TransactionStatus.
+const (
+	TransactionStatusActive TransactionStatus = "Active"
+	TransactionStatusEnded  TransactionStatus = "Ended"
+)
+
+// Defines values for StreamEventsParamsCategory.
+const (
+	StreamEventsParamsCategoryConnector   StreamEventsParamsCategory = "connector"
+	StreamEventsParamsCategoryReservation StreamEventsParamsCategory = "reservation"
+	StreamEventsParamsCategorySecurity    StreamEventsParamsCategory = "security"
+	StreamEventsParamsCategoryTransaction StreamEventsParamsCategory = "transaction"
+)
+
+// Defines values for ListOcppActionsParamsOcppVersion.
+const (
+	ListOcppActionsParamsOcppVersionOcpp16  ListOcppActionsParamsOcppVersion = "ocpp1.6"
+	ListOcppActionsParamsOcppVersionOcpp201 ListOcppActionsParamsOcppVersion = "ocpp2.0.1"
+	ListOcppActionsParamsOcppVersionOcpp21  ListOcppActionsParamsOcppVersion = "ocpp2.1"
+)
+
+// Defines values for UpdateOcppActionParamsOcppVersion.
+const (
+	UpdateOcppActionParamsOcppVersionOcpp16  UpdateOcppActionParamsOcppVersion = "ocpp1.6"
+	UpdateOcppActionParamsOcppVersionOcpp201 UpdateOcppActionParamsOcppVersion = "ocpp2.0.1"
+	UpdateOcppActionParamsOcppVersionOcpp21  UpdateOcppActionParamsOcppVersion = "ocpp2.1"
+)
+
+// Defines values for ListTransactionsParamsStatus.
+const (
+	ListTransactionsParamsStatusActive ListTransactionsParamsStatus = "Active"
+	ListTransactionsParamsStatusEnded  ListTransactionsParamsStatus = "Ended"
+)
+
+// BulkCommand A command sent to a fleet of charge stations
+type BulkCommand struct {
+	Action    BulkCommandAction `json:"action"`
+	CreatedAt time.Time         `json:"createdAt"`
+
+	// CreatedBy The caller that created the bulk command. Not set when the API does not require authentication.
+	CreatedBy            *string    `json:"createdBy,omitempty"`
+	FirmwareLocation     *string    `json:"firmwareLocation,omitempty"`
+	FirmwareRetrieveDate *time.Time `json:"firmwareRetrieveDate,omitempty"`
+
+	// Id The bulk command identifier
+	Id          string                `json:"id"`
+	Key         *string               `json:"key,omitempty"`
+	MaxInFlight int                   `json:"maxInFlight"`
+	ResetType   *BulkCommandResetType `json:"resetType,omitempty"`
+	Results     []BulkCommandResult   `json:"results"`
+
+	// Selector Selects the charge stations that a bulk command is sent to: fields that are not set match all charge stations
+	Selector BulkCommandSelector `json:"selector"`
+
+	// Status Completed once every charge station is Accepted or Failed
+	Status BulkCommandStatus `json:"status"`
+	Value  *string           `json:"value,omitempty"`
+}
+
+// BulkCommandAction defines model for BulkCommand.Action.
+type BulkCommandAction string
+
+// BulkCommandResetType defines model for BulkCommand.ResetType.
+type BulkCommandResetType string
+
+// BulkCommandStatus Completed once every charge station is Accepted or Failed
+type BulkCommandStatus string
+
+// BulkCommandRequest Request to send a command to a fleet of charge stations
+type BulkCommandRequest struct {
+	// Action The command to send
+	Action BulkCommandRequestAction `json:"action"`
+
+	// FirmwareLocation The URI of the firmware to install for an UpdateFirmware
+	FirmwareLocation *string `json:"firmwareLocation,omitempty"`
+
+	// FirmwareRetrieveDate When the charge stations retrieve the firmware for an UpdateFirmware, if not set they retrieve it when they are sent the command
+	FirmwareRetrieveDate *time.Time `json:"firmwareRetrieveDate,omitempty"`
+
+	// Key The configuration key to change for a ChangeConfiguration. OCPP 2.0.1 charge stations are sent a
+	// SetVariables request: the key is the component and variable name, as for the reconfigure operation.
+	Key *string `json:"key,omitempty"`
+
+	// MaxInFlight The maximum number of charge stations that have been sent the command and not yet responded
+	MaxInFlight *int `json:"maxInFlight,omitempty"`
+
+	// ResetType The type of a Reset. OCPP 1.6 charge stations are sent a Hard reset for Immediate and a Soft reset
+	// for OnIdle.
+	ResetType *BulkCommandRequestResetType `json:"resetType,omitempty"`
+
+	// Selector Selects the charge stations that a bulk command is sent to: fields that are not set match all charge stations
+	Selector BulkCommandSelector `json:"selector"`
+
+	// Value The configuration value to set for a ChangeConfiguration
+	Value *string `json:"value,omitempty"`
+}
+
+// BulkCommandRequestAction The command to send
+type BulkCommandRequestAction string
+
+// BulkCommandRequestResetType The type of a Reset. OCPP 1.6 charge stations are sent a Hard reset for Immediate and a Soft reset
+// for OnIdle.
+type BulkCommandRequestResetType string
+
+// BulkCommandResult The outcome of a bulk command for one of the charge stations
+type BulkCommandResult struct {
+	ChargeStationId string     `json:"chargeStationId"`
+	CompletedAt     *time.Time `json:"completedAt,omitempty"`
+
+	// Result The status returned by the charge station, or the reason the command failed
+	Result *string    `json:"result,omitempty"`
+	SentAt *time.Time `json:"sentAt,omitempty"`
+
+	// Status Pending until the command is sent to the charge station, then Sent until the charge station
+	// responds. Accepted when the charge station accepts the command. Failed when the command could not
+	// be sent, the charge station rejected it or the charge station did not respond.
+	Status BulkCommandResultStatus `json:"status"`
+}
+
+// BulkCommandResultStatus Pending until the command is sent to the charge station, then Sent until the charge station
+// responds. Accepted when the charge station accepts the command. Failed when the command could not
+// be sent, the charge station rejected it or the charge station did not respond.
+type BulkCommandResultStatus string
+
+// BulkCommandSelector Selects the charge stations that a bulk command is sent to: fields that are not set match all charge stations
+type BulkCommandSelector struct {
+	// FirmwareVersion Only select the charge stations running the firmware version reported in their last BootNotification
+	FirmwareVersion *string `json:"firmwareVersion,omitempty"`
+
+	// Model Only select the charge stations of the model
+	Model *string `json:"model,omitempty"`
+
+	// SiteId Only select the charge stations at the site or one of its sub-sites
+	SiteId *string `json:"siteId,omitempty"`
+
+	// Tags Only select the charge stations that have all the tags
+	Tags *[]string `json:"tags,omitempty"`
+
+	// Vendor Only select the charge stations from the vendor
+	Vendor *string `json:"vendor,omitempty"`
+}
+
+// Certificate A client certificate
+type Certificate struct {
+	// Certificate The PEM encoded certificate with newlines replaced by `\n`
+	Certificate string `json:"certificate"`
+}
+
+// ChargeDetailRecordExport A charge detail record as it is exported for billing
+type ChargeDetailRecordExport struct {
+	// ChargeStationId The charge station where the transaction took place
+	ChargeStationId string `json:"chargeStationId"`
+
+	// ConnectorId The connector that was used
+	ConnectorId *string `json:"connectorId,omitempty"`
+
+	// ContractId The contract id of the token
+	ContractId *string `json:"contractId,omitempty"`
+
+	// Currency The ISO 4217 currency of the cost
+	Currency *string `json:"currency,omitempty"`
+
+	// EndDateTime The time the transaction ended
+	EndDateTime time.Time `json:"endDateTime"`
+
+	// EvseId The EVSE id of the EVSE that was used
+	EvseId *string `json:"evseId,omitempty"`
+
+	// Id The id of the record
+	Id string `json:"id"`
+
+	// IdToken The id token that authorized the transaction
+	IdToken *string `json:"idToken,omitempty"`
+
+	// LocationId The location of the charge station
+	LocationId *string `json:"locationId,omitempty"`
+
+	// StartDateTime The time the transaction started
+	StartDateTime time.Time `json:"startDateTime"`
+
+	// TokenType The type of the id token
+	TokenType *string `json:"tokenType,omitempty"`
+
+	// TotalCost The cost of the transaction
+	TotalCost float64 `json:"totalCost"`
+
+	// TotalEnergyKwh The energy delivered (in kWh)
+	TotalEnergyKwh float64 `json:"totalEnergyKwh"`
+
+	// TotalTimeHours The duration of the transaction (in hours)
+	TotalTimeHours float64 `json:"totalTimeHours"`
+
+	// TransactionId The transaction that the record is for
+	TransactionId string `json:"transactionId"`
+}
+
+// ChargeStation A charge station in the registry
+type ChargeStation struct {
+	Coordinates *GeoLocation `json:"coordinates,omitempty"`
+
+	// FirmwareVersion The firmware version of the charge station
+	FirmwareVersion *string `json:"firmwareVersion,omitempty"`
+
+	// Id The charge station identifier
+	Id string `json:"id"`
+
+	// LastBoot The time that the last BootNotification was received from the charge station
+	LastBoot *time.Time `json:"lastBoot,omitempty"`
+
+	// Model The model of the charge station
+	Model *string `json:"model,omitempty"`
+
+	// OcppVersion The OCPP version the charge station last booted with
+	OcppVersion *string `json:"ocppVersion,omitempty"`
+
+	// SecurityProfile The security profile that the charge station was registered with: * `0` - unsecured transport with basic auth * `1` - TLS with basic auth * `2` - TLS with client certificate
+	SecurityProfile int `json:"securityProfile"`
+
+	// SerialNumber The serial number of the charge station
+	SerialNumber *string `json:"serialNumber,omitempty"`
+
+	// SiteId The identifier of the site where the charge station is installed
+	SiteId *string `json:"siteId,omitempty"`
+
+	// Tags The names of the tags assigned to the charge station
+	Tags *[]string `json:"tags,omitempty"`
+
+	// TariffId The identifier of the tariff used to price the charge station's transactions
+	TariffId *string `json:"tariffId,omitempty"`
+
+	// Vendor The vendor of the charge station
+	Vendor *string `json:"vendor,omitempty"`
+}
+
+// ChargeStationAuth Connection details for a charge station
+type ChargeStationAuth struct {
+	// Base64SHA256Password The base64 encoded, SHA-256 hash of the charge station password
+	Base64SHA256Password *string `json:"base64SHA256Password,omitempty"`
+
+	// InvalidUsernameAllowed If set to true then an invalid username will not prevent the charge station connecting
+	InvalidUsernameAllowed *bool `json:"invalidUsernameAllowed,omitempty"`
+
+	// SecurityProfile The security profile to use for the charge station: * `0` - unsecured transport with basic auth * `1` - TLS with basic auth * `2` - TLS with client certificate
+	SecurityProfile int `json:"securityProfile"`
+}
+
+// ChargeStationAvailability The availability calendar of an EVSE
+type ChargeStationAvailability struct {
+	// ConnectorId The connector identifier for OCPP 1.6 charge stations
+	ConnectorId *int `json:"connectorId,omitempty"`
+
+	// EvseId The EVSE identifier: 0 for OCPP 1.6 charge stations
+	EvseId int `json:"evseId"`
+
+	// Windows The windows that cover the period in order
+	Windows []ChargeStationAvailabilityWindow `json:"windows"`
+}
+
+// ChargeStationAvailabilityWindow A period during which the EVSE has the same status
+type ChargeStationAvailabilityWindow struct {
+	End    time.Time                             `json:"end"`
+	Start  time.Time                             `json:"start"`
+	Status ChargeStationAvailabilityWindowStatus `json:"status"`
+}
+
+// ChargeStationAvailabilityWindowStatus defines model for ChargeStationAvailabilityWindow.Status.
+type ChargeStationAvailabilityWindowStatus string
+
+// ChargeStationConnector A connector of an EVSE
+type ChargeStationConnector struct {
+	// ConnectorId The connector identifier
+	ConnectorId int `json:"connectorId"`
+
+	// ConnectorType The OCPP 2.0.1 connector type, e.g. cType2 or cCCS2
+	ConnectorType *string `json:"connectorType,omitempty"`
+
+	// MaxPower The maximum power that the connector can deliver in W
+	MaxPower *float64 `json:"maxPower,omitempty"`
+
+	// PhysicalLabel The label on the connector that a driver sees
+	PhysicalLabel *string `json:"physicalLabel,omitempty"`
+}
+
+// ChargeStationConnectorUpdate The metadata of a connector
+type ChargeStationConnectorUpdate struct {
+	// ConnectorType The OCPP 2.0.1 connector type, e.g. cType2 or cCCS2
+	ConnectorType *string `json:"connectorType,omitempty"`
+
+	// MaxPower The maximum power that the connector can deliver in W
+	MaxPower *float64 `json:"maxPower,omitempty"`
+
+	// PhysicalLabel The label on the connector that a driver sees
+	PhysicalLabel *string `json:"physicalLabel,omitempty"`
+}
+
+// ChargeStationDetails The operator maintained details of a charge station
+type ChargeStationDetails struct {
+	Coordinates *GeoLocation `json:"coordinates,omitempty"`
+
+	// SiteId The identifier of the site where the charge station is installed
+	SiteId *string `json:"siteId,omitempty"`
+
+	// TariffId The identifier of a registered tariff used to price the charge station's transactions: the default tariff is used if it is not set
+	TariffId *string `json:"tariffId,omitempty"`
+}
+
+// ChargeStationEvse An EVSE of a charge station
+type ChargeStationEvse struct {
+	// Connectors The connectors of the EVSE, ordered by id
+	Connectors []ChargeStationConnector `json:"connectors"`
+
+	// EvseId The EVSE identifier: 0 for OCPP 1.6 charge stations
+	EvseId int `json:"evseId"`
+
+	// LastUpdated The time the EVSE was last changed
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// ChargeStationInstallCertificates The set of certificates to install on the charge station. The certificates will be sent
+// to the charge station asynchronously.
+type ChargeStationInstallCertificates struct {
+	Certificates []struct {
+		// Certificate The PEM encoded certificate with newlines replaced by `\n`
+		Certificate string `json:"certificate"`
+
+		// Status The status, defaults to Pending
+		Status *ChargeStationInstallCertificatesCertificatesStatus `json:"status,omitempty"`
+		Type   ChargeStationInstallCertificatesCertificatesType    `json:"type"`
+	} `json:"certificates"`
+}
+
+// ChargeStationInstallCertificatesCertificatesStatus The status, defaults to Pending
+type ChargeStationInstallCertificatesCertificatesStatus string
+
+// ChargeStationInstallCertificatesCertificatesType defines model for ChargeStationInstallCertificates.Certificates.Type.
+type ChargeStationInstallCertificatesCertificatesType string
+
+// ChargeStationInstalledCertificates The certificates installed on a charge station
+type ChargeStationInstalledCertificates struct {
+	Certificates []InstalledCertificate `json:"certificates"`
+
+	// RefreshStatus The status of the most recent inventory refresh
+	RefreshStatus *ChargeStationInstalledCertificatesRefreshStatus `json:"refreshStatus,omitempty"`
+}
+
+// ChargeStationInstalledCertificatesRefreshStatus The status of the most recent inventory refresh
+type ChargeStationInstalledCertificatesRefreshStatus string
+
+// ChargeStationLiveness The liveness of a charge station
+type ChargeStationLiveness struct {
+	// LastHeartbeat The time that a Heartbeat was last received from the charge station
+	LastHeartbeat *time.Time `json:"lastHeartbeat,omitempty"`
+
+	// LastSeen The time that a message was last received from the charge station
+	LastSeen time.Time `json:"lastSeen"`
+
+	// Status Whether the charge station is considered to be online or offline
+	Status ChargeStationLivenessStatus `json:"status"`
+}
+
+// ChargeStationLivenessStatus Whether the charge station is considered to be online or offline
+type ChargeStationLivenessStatus string
+
+// ChargeStationRegistration The registration status of a charge station
+type ChargeStationRegistration struct {
+	// Status The status returned to the charge station in response to a BootNotification: * `Accepted` - the charge station is accepted by the CSMS * `Pending` - the charge station should boot again after the retry interval * `Rejected` - the charge station is not accepted by the CSMS
+	Status ChargeStationRegistrationStatus `json:"status"`
+}
+
+// ChargeStationRegistrationStatus The status returned to the charge station in response to a BootNotification: * `Accepted` - the charge station is accepted by the CSMS * `Pending` - the charge station should boot again after the retry interval * `Rejected` - the charge station is not accepted by the CSMS
+type ChargeStationRegistrationStatus string
+
+// ChargeStationSettings Settings for a charge station
+type ChargeStationSettings map[string]string
+
+// ChargeStationTrigger Trigger a charge station action
+type ChargeStationTrigger struct {
+	Trigger ChargeStationTriggerTrigger `json:"trigger"`
+}
+
+// ChargeStationTriggerTrigger defines model for ChargeStationTrigger.Trigger.
+type ChargeStationTriggerTrigger string
+
+// CommandAuditRecord A command initiated by the CSMS
+type CommandAuditRecord struct {
+	// ChargeStationId The charge station that the command is for
+	ChargeStationId string `json:"chargeStationId"`
+
+	// Command The API operation that was requested or the OCPP action that was sent
+	Command string `json:"command"`
+
+	// CompletedAt The time the charge station responded or the call could not be sent
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+
+	// Id The id of the entry: for a call it is the OCPP message id
+	Id string `json:"id"`
+
+	// OcppVersion The OCPP version of a call
+	OcppVersion *string `json:"ocppVersion,omitempty"`
+
+	// Request The request body of the API operation or the payload of the call, as JSON
+	Request *string `json:"request,omitempty"`
+
+	// RequestedAt The time the command was requested or the call was sent
+	RequestedAt time.Time `json:"requestedAt"`
+
+	// RequestedBy The caller that requested an API operation, or csms for a call sent by the CSMS. Not set when the
+	// API does not require authentication.
+	RequestedBy *string `json:"requestedBy,omitempty"`
+
+	// Result The CallResult payload as JSON, or the error the call failed with
+	Result *string `json:"result,omitempty"`
+
+	// Status Requested when the API accepted the command, which the CSMS will send to the charge station. For a
+	// call: Sent until the charge station responds, then Completed for a CallResult or Failed for a
+	// CallError. NotSent when the call could not be sent.
+	Status CommandAuditRecordStatus `json:"status"`
+}
+
+// CommandAuditRecordStatus Requested when the API accepted the command, which the CSMS will send to the charge station. For a
+// call: Sent until the charge station responds, then Completed for a CallResult or Failed for a
+// CallError. NotSent when the call could not be sent.
+type CommandAuditRecordStatus string
+
+// CommandSchedule A command that is sent to a fleet of charge stations at a time or on a cron schedule
+type CommandSchedule struct {
+	// Command Request to send a command to a fleet of charge stations
+	Command   BulkCommandRequest `json:"command"`
+	CreatedAt time.Time          `json:"createdAt"`
+
+	// CreatedBy The caller that created the schedule. Not set when the API does not require authentication.
+	CreatedBy *string `json:"createdBy,omitempty"`
+	Cron      *string `json:"cron,omitempty"`
+
+	// Id The command schedule identifier
+	Id string `json:"id"`
+
+	// LastBulkCommandId The bulk command created by the last run
+	LastBulkCommandId *string `json:"lastBulkCommandId,omitempty"`
+
+	// LastError Why the last run did not create a bulk command, e.g. because no charge stations were selected
+	LastError *string    `json:"lastError,omitempty"`
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+	Name      string     `json:"name"`
+
+	// NextRunAt When the schedule next runs: not set when it will not run again
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+	RunAt     *time.Time `json:"runAt,omitempty"`
+	TimeZone  *string    `json:"timeZone,omitempty"`
+}
+
+// CommandScheduleRequest Request to send a command to a fleet of charge stations at a time or on a cron schedule
+type CommandScheduleRequest struct {
+	// Command Request to send a command to a fleet of charge stations
+	Command BulkCommandRequest `json:"command"`
+
+	// Cron A cron expression with five fields (minute, hour, day of month, month and day of week) for a
+	// repeating schedule, e.g. `0 2 * * *` for every night at 02:00: one of runAt and cron must be set
+	Cron *string `json:"cron,omitempty"`
+
+	// Name A name that describes the schedule
+	Name string `json:"name"`
+
+	// RunAt When a schedule that runs once creates its bulk command: one of runAt and cron must be set
+	RunAt *time.Time `json:"runAt,omitempty"`
+
+	// TimeZone The IANA time zone that the cron expression is evaluated in, e.g. Europe/London. Defaults to UTC.
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
+// Connector defines model for Connector.
+type Connector struct {
+	Format      ConnectorFormat    `json:"format"`
+	Id          string             `json:"id"`
+	MaxAmperage int32              `json:"max_amperage"`
+	MaxVoltage  int32              `json:"max_voltage"`
+	PowerType   ConnectorPowerType `json:"power_type"`
+	Standard    ConnectorStandard  `json:"standard"`
+}
+
+// ConnectorFormat defines model for Connector.Format.
+type ConnectorFormat string
+
+// ConnectorPowerType defines model for Connector.PowerType.
+type ConnectorPowerType string
+
+// ConnectorStandard defines model for Connector.Standard.
+type ConnectorStandard string
+
+// DashboardSummary Fleet KPIs for an operator dashboard
+type DashboardSummary struct {
+	// ActiveReservations The number of accepted reservations that have not expired
+	ActiveReservations int `json:"activeReservations"`
+
+	// ActiveTransactions The number of transactions that have started but not yet ended
+	ActiveTransactions int `json:"activeTransactions"`
+
+	// ChargeStations The number of charge stations that have connected to the CSMS
+	ChargeStations int `json:"chargeStations"`
+
+	// ChargeStationsOffline The number of charge stations that have connected to the CSMS but are now offline
+	ChargeStationsOffline int `json:"chargeStationsOffline"`
+
+	// ChargeStationsOnline The number of charge stations that are connected to the CSMS
+	ChargeStationsOnline int `json:"chargeStationsOnline"`
+
+	// CompletedTransactionsToday The number of transactions that have ended since midnight (UTC)
+	CompletedTransactionsToday int `json:"completedTransactionsToday"`
+
+	// EnergyDeliveredTodayWh The energy delivered (in Wh) by transactions that have ended since midnight (UTC)
+	EnergyDeliveredTodayWh float32 `json:"energyDeliveredTodayWh"`
+
+	// ExpiredReservations The number of accepted reservations that expired without being used
+	ExpiredReservations int `json:"expiredReservations"`
+
+	// FaultedConnectors The number of open faults: connectors whose last reported status is Faulted
+	FaultedConnectors int `json:"faultedConnectors"`
+
+	// GeneratedAt The time the summary was computed
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// Evse defines model for Evse.
+type Evse struct {
+	Connectors []Connector `json:"connectors"`
+	EvseId     *string     `json:"evse_id"`
+
+	// Uid Uniquely identifies the EVSE within the CPOs platform (and
+	// suboperator platforms).
+	Uid string `json:"uid"`
+}
+
+// ExportFormat The format of an export
+type ExportFormat string
+
+// FleetStats Aggregates over the fleet of charge stations
+type FleetStats struct {
+	// ActiveReservations The number of accepted reservations that have not expired
+	ActiveReservations int `json:"activeReservations"`
+
+	// ActiveTransactions The number of transactions that have started but not yet ended
+	ActiveTransactions int `json:"activeTransactions"`
+
+	// ChargeStationsOffline The number of charge stations that have connected to the CSMS but are now offline
+	ChargeStationsOffline int `json:"chargeStationsOffline"`
+
+	// ChargeStationsOnline The number of charge stations that are connected to the CSMS
+	ChargeStationsOnline int `json:"chargeStationsOnline"`
+
+	// CompletedTransactionsToday The number of transactions that have ended since midnight (UTC)
+	CompletedTransactionsToday int `json:"completedTransactionsToday"`
+
+	// EnergyDeliveredTodayWh The energy delivered (in Wh) by transactions that have ended since midnight (UTC)
+	EnergyDeliveredTodayWh float32 `json:"energyDeliveredTodayWh"`
+
+	// FaultedConnectors The number of connectors whose last reported status is Faulted
+	FaultedConnectors int `json:"faultedConnectors"`
+
+	// GeneratedAt The time the statistics were computed
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// GeoLocation defines model for GeoLocation.
+type GeoLocation struct {
+	Latitude  string `json:"latitude"`
+	Longitude string `json:"longitude"`
+}
+
+// InstalledCertificate A certificate that is installed on a charge station
+type InstalledCertificate struct {
+	HashAlgorithm InstalledCertificateHashAlgorithm `json:"hashAlgorithm"`
+
+	// IssuerKeyHash The hash of the issuer public key
+	IssuerKeyHash string `json:"issuerKeyHash"`
+
+	// IssuerNameHash The hash of the issuer distinguished name
+	IssuerNameHash string `json:"issuerNameHash"`
+
+	// SerialNumber The serial number of the certificate
+	SerialNumber string                     `json:"serialNumber"`
+	Status       InstalledCertificateStatus `json:"status"`
+	Type         InstalledCertificateType   `json:"type"`
+}
+
+// InstalledCertificateHashAlgorithm defines model for InstalledCertificate.HashAlgorithm.
+type InstalledCertificateHashAlgorithm string
+
+// InstalledCertificateStatus defines model for InstalledCertificate.Status.
+type InstalledCertificateStatus string
+
+// InstalledCertificateType defines model for InstalledCertificate.Type.
+type InstalledCertificateType string
+
+// Location A charge station location
+type Location struct {
+	Address     string               `json:"address"`
+	City        string               `json:"city"`
+	Coordinates GeoLocation          `json:"coordinates"`
+	Country     string               `json:"country"`
+	CountryCode string               `json:"country_code"`
+	Evses       *[]Evse              `json:"evses"`
+	Name        *string              `json:"name"`
+	ParkingType *LocationParkingType `json:"parking_type"`
+	PartyId     string               `json:"party_id"`
+	PostalCode  *string              `json:"postal_code"`
+}
+
+// LocationParkingType defines model for Location.ParkingType.
+type LocationParkingType string
+
+// MeterValue The values sampled by a meter at a point in time
+type MeterValue struct {
+	SampledValues []SampledValue `json:"sampledValues"`
+
+	// Timestamp The time the values were sampled
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NearbyChargeStation A charge station found near a location
+type NearbyChargeStation struct {
+	// ChargeStation A charge station in the registry
+	ChargeStation ChargeStation `json:"chargeStation"`
+
+	// Distance The distance of the charge station from the location in m
+	Distance float64 `json:"distance"`
+}
+
+// OcppAction An OCPP action that the CSMS handles
+type OcppAction struct {
+	// Action The OCPP action
+	Action string `json:"action"`
+
+	// Enabled Whether calls for the action are handled
+	Enabled bool `json:"enabled"`
+}
+
+// OcppActionUpdate Enables or disables an OCPP action
+type OcppActionUpdate struct {
+	// Enabled Whether calls for the action should be handled
+	Enabled bool `json:"enabled"`
+}
+
+// PriceComponent defines model for PriceComponent.
+type PriceComponent struct {
+	// Price Price per kWh for ENERGY, per hour for TIME and PARKING_TIME and per session for FLAT, excluding VAT
+	Price float64 `json:"price"`
+
+	// StepSize Minimum billing increment in Wh for ENERGY and in seconds for TIME and PARKING_TIME
+	StepSize int                `json:"step_size"`
+	Type     PriceComponentType `json:"type"`
+
+	// Vat Applicable VAT percentage
+	Vat *float64 `json:"vat"`
+}
+
+// PriceComponentType defines model for PriceComponent.Type.
+type PriceComponentType string
+
+// ReceiptEmailRequest defines model for ReceiptEmailRequest.
+type ReceiptEmailRequest struct {
+	// Email The address the receipt is sent to
+	Email openapi_types.Email `json:"email"`
+}
+
+// ReceiptFormat The format of a receipt
+type ReceiptFormat string
+
+// ReceiptLine A charge on a receipt
+type ReceiptLine struct {
+	Description string  `json:"description"`
+	NetAmount   float64 `json:"netAmount"`
+
+	// Quantity The energy or time charged for: it is not set for a fee
+	Quantity  *float64 `json:"quantity,omitempty"`
+	TaxAmount float64  `json:"taxAmount"`
+
+	// TaxRate The tax rate as a percentage
+	TaxRate float64 `json:"taxRate"`
+
+	// Type The dimension of the tariff that is charged for: `TOTAL` charges for the whole session when the tariff service does not itemise the cost
+	Type ReceiptLineType `json:"type"`
+
+	// Unit The unit of the quantity, `kWh` or `h`
+	Unit *string `json:"unit,omitempty"`
+}
+
+// ReceiptLineType The dimension of the tariff that is charged for: `TOTAL` charges for the whole session when the tariff service does not itemise the cost
+type ReceiptLineType string
+
+// Registration Defines the initial connection details for the OCPI registration process
+type Registration struct {
+	// Status The status of the registration request. If the request is marked as `REGISTERED` then the token will be allowed to
+	// be used to access all endpoints avoiding the need for the OCPI registration process. If the request is marked as
+	// `PENDING` then the token will only be allowed to access the `/ocpi/versions`, `/ocpi/2.2` and `/ocpi/2.2/credentials`
+	// endpoints.
+	Status *RegistrationStatus `json:"status,omitempty"`
+
+	// Token The token to use for communicating with the eMSP (CREDENTIALS_TOKEN_A).
+	Token string `json:"token"`
+
+	// Url The URL of the eMSP versions endpoint. If provided the CSMS will act as the sender of the versions request.
+	Url *string `json:"url,omitempty"`
+}
+
+// RegistrationStatus The status of the registration request. If the request is marked as `REGISTERED` then the token will be allowed to
+// be used to access all endpoints avoiding the need for the OCPI registration process. If the request is marked as
+// `PENDING` then the token will only be allowed to access the `/ocpi/versions`, `/ocpi/2.2` and `/ocpi/2.2/credentials`
+// endpoints.
+type RegistrationStatus string
+
+// Reservation A reservation of an EVSE for an idToken
+type Reservation struct {
+	// ChargeStationId The charge station holding the reservation (ignored on create)
+	ChargeStationId *string `json:"chargeStationId,omitempty"`
+
+	// Contact How the driver is notified about a reservation
+	Contact *ReservationContact `json:"contact,omitempty"`
+
+	// EvseId The EVSE to reserve, if not set any EVSE on the charge station may be used
+	EvseId *int `json:"evseId,omitempty"`
+
+	// ExpiryDate The date and time at which the reservation expires
+	ExpiryDate time.Time `json:"expiryDate"`
+
+	// Id The reservation identifier
+	Id int `json:"id"`
+
+	// IdToken The idToken the reservation is made for
+	IdToken string `json:"idToken"`
+
+	// NoShow Whether the reservation was cancelled because no transaction used it (ignored on create)
+	NoShow *bool `json:"noShow,omitempty"`
+
+	// NoShowFee The fee charged for a reservation that was not used
+	NoShowFee *ReservationFee `json:"noShowFee,omitempty"`
+
+	// StartDate The date and time at which the booked time slot starts, if not set the reservation starts immediately
+	StartDate *time.Time `json:"startDate,omitempty"`
+
+	// Status The status of the reservation (ignored on create)
+	Status *ReservationStatus `json:"status,omitempty"`
+
+	// TokenType The type of the idToken
+	TokenType ReservationTokenType `json:"tokenType"`
+
+	// Transfer The most recent transfer of a reservation
+	Transfer *ReservationTransfer `json:"transfer,omitempty"`
+}
+
+// ReservationStatus The status of the reservation (ignored on create)
+type ReservationStatus string
+
+// ReservationTokenType The type of the idToken
+type ReservationTokenType string
+
+// ReservationContact How the driver is notified about a reservation
+type ReservationContact struct {
+	// Email The email address of the driver
+	Email *string `json:"email,omitempty"`
+
+	// Phone The phone number of the driver in E.164 format
+	Phone *string `json:"phone,omitempty"`
+}
+
+// ReservationFee The fee charged for a reservation that was not used
+type ReservationFee struct {
+	// Amount The amount of the fee
+	Amount float64 `json:"amount"`
+
+	// Currency The ISO 4217 currency code of the fee
+	Currency string `json:"currency"`
+}
+
+// ReservationTransfer The most recent transfer of a reservation
+type ReservationTransfer struct {
+	// ChargeStationId The charge station the reservation is being transferred to
+	ChargeStationId string `json:"chargeStationId"`
+
+	// EvseId The EVSE the reservation is being transferred to
+	EvseId *int `json:"evseId,omitempty"`
+
+	// Status The status of the transfer
+	Status ReservationTransferStatus `json:"status"`
+}
+
+// ReservationTransferStatus The status of the transfer
+type ReservationTransferStatus string
+
+// ReservationTransferRequest Request to transfer a reservation
+type ReservationTransferRequest struct {
+	// ChargeStationId The charge station to transfer the reservation to, this may be the current charge station
+	ChargeStationId string `json:"chargeStationId"`
+
+	// EvseId The EVSE to transfer the reservation to, if not set any EVSE on the charge station may be used
+	EvseId *int `json:"evseId,omitempty"`
+}
+
+// SampledValue A single value sampled by a meter
+type SampledValue struct {
+	// Context The reason the value was sampled, e.g. `Transaction.End`
+	Context *string `json:"context,omitempty"`
+
+	// Location Where the value was measured, e.g. `Outlet`
+	Location *string `json:"location,omitempty"`
+
+	// Measurand What was measured, e.g. `Energy.Active.Import.Register`
+	Measurand *string `json:"measurand,omitempty"`
+
+	// Multiplier The power of ten the value is multiplied by
+	Multiplier *int `json:"multiplier,omitempty"`
+
+	// Phase The phase that was measured
+	Phase *string `json:"phase,omitempty"`
+
+	// Unit The unit of the value, e.g. `Wh`
+	Unit *string `json:"unit,omitempty"`
+
+	// Value The sampled value
+	Value float32 `json:"value"`
+}
+
+// Site A site where charge stations are installed
+type Site struct {
+	// Id The site identifier
+	Id string `json:"id"`
+
+	// Name The name of the site
+	Name string `json:"name"`
+
+	// ParentSiteId The site that contains this site: not set for a site at the top of the hierarchy
+	ParentSiteId *string `json:"parentSiteId,omitempty"`
+}
+
+// SiteDetails The details of a site
+type SiteDetails struct {
+	// Name The name of the site
+	Name string `json:"name"`
+
+	// ParentSiteId The site that contains this site: not set for a site at the top of the hierarchy
+	ParentSiteId *string `json:"parentSiteId,omitempty"`
+}
+
+// Status HTTP status
+type Status struct {
+	// Error The error details
+	Error *string `json:"error,omitempty"`
+
+	// Status The status description
+	Status string `json:"status"`
+}
+
+// Tag A tag that groups charge stations
+type Tag struct {
+	// Description What the charge stations with the tag have in common
+	Description *string `json:"description,omitempty"`
+
+	// Name The tag name
+	Name string `json:"name"`
+}
+
+// TagDetails The details of a tag
+type TagDetails struct {
+	// Description What the charge stations with the tag have in common
+	Description *string `json:"description,omitempty"`
+}
+
+// Tariff An OCPI tariff
+type Tariff struct {
+	// Currency ISO-4217 code of the currency of the tariff
+	Currency string          `json:"currency"`
+	Elements []TariffElement `json:"elements"`
+
+	// EndDateTime The time until which the tariff is valid
+	EndDateTime *time.Time `json:"end_date_time"`
+
+	// StartDateTime The time from which the tariff is valid
+	StartDateTime *time.Time  `json:"start_date_time"`
+	Type          *TariffType `json:"type"`
+}
+
+// TariffType defines model for Tariff.Type.
+type TariffType string
+
+// TariffElement defines model for TariffElement.
+type TariffElement struct {
+	PriceComponents []PriceComponent `json:"price_components"`
+
+	// Restrictions Conditions that must be met for a tariff element to apply
+	Restrictions *TariffRestrictions `json:"restrictions,omitempty"`
+}
+
+// TariffRestrictions Conditions that must be met for a tariff element to apply
+type TariffRestrictions struct {
+	DayOfWeek *[]TariffRestrictionsDayOfWeek `json:"day_of_week"`
+
+	// EndDate Local date (YYYY-MM-DD) until which the element applies
+	EndDate *string `json:"end_date"`
+
+	// EndTime Local time of day (HH:MM) until which the element applies
+	EndTime *string `json:"end_time"`
+
+	// MaxDuration Maximum duration of the session in seconds
+	MaxDuration *int     `json:"max_duration"`
+	MaxKwh      *float64 `json:"max_kwh"`
+
+	// MinDuration Minimum duration of the session in seconds
+	MinDuration *int     `json:"min_duration"`
+	MinKwh      *float64 `json:"min_kwh"`
+
+	// StartDate Local date (YYYY-MM-DD) from which the element applies
+	StartDate *string `json:"start_date"`
+
+	// StartTime Local time of day (HH:MM) from which the element applies
+	StartTime *string `json:"start_time"`
+}
+
+// TariffRestrictionsDayOfWeek defines model for TariffRestrictions.DayOfWeek.
+type TariffRestrictionsDayOfWeek string
+
+// Token An authorization token
+type Token struct {
+	// CacheMode Indicates what type of token caching is allowed
+	CacheMode TokenCacheMode `json:"cacheMode"`
+
+	// ContractId The contract ID (eMAID) associated with the token (with optional component separators)
+	ContractId string `json:"contractId"`
+
+	// CountryCode The country code of the issuing eMSP
+	CountryCode string `json:"countryCode"`
+
+	// GroupId This id groups a couple of tokens to make two or more tokens work as one
+	GroupId *string `json:"groupId,omitempty"`
+
+	// Issuer Issuing company, most of the times the name of the company printed on the RFID card, not necessarily the eMSP
+	Issuer string `json:"issuer"`
+
+	// LanguageCode The preferred language to use encoded as ISO 639-1 language code
+	LanguageCode *string `json:"languageCode,omitempty"`
+
+	// LastUpdated The date the record was last updated (ignored on create/update)
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+
+	// PartyId The party id of the issuing eMSP
+	PartyId string `json:"partyId"`
+
+	// Type The type of token
+	Type TokenType `json:"type"`
+
+	// Uid The unique token id: the hexadecimal UID for RFID tokens, or sha256: followed by the hexadecimal SHA-256 hash of the id token for tokens registered by their hash
+	Uid string `json:"uid"`
+
+	// Valid Is this token valid
+	Valid bool `json:"valid"`
+
+	// VisualNumber The visual/readable number/identification printed on an RFID card
+	VisualNumber *string `json:"visualNumber,omitempty"`
+}
+
+// TokenCacheMode Indicates what type of token caching is allowed
+type TokenCacheMode string
+
+// TokenType The type of token
+type TokenType string
+
+// Transaction A charging transaction
+type Transaction struct {
+	// ChargeStationId The charge station where the transaction took place
+	ChargeStationId string `json:"chargeStationId"`
+
+	// Cost The itemised cost of a transaction, excluding VAT
+	Cost *TransactionCost `json:"cost,omitempty"`
+
+	// IdToken The id token (OCPP 1.6 idTag) that authorized the transaction
+	IdToken     *string      `json:"idToken,omitempty"`
+	MeterValues []MeterValue `json:"meterValues"`
+
+	// Offline Whether the transaction was started while the charge station was offline
+	Offline bool `json:"offline"`
+
+	// StartTime The time of the transaction's first meter value
+	StartTime *time.Time `json:"startTime,omitempty"`
+
+	// Status Whether the charge station has reported the end of the transaction
+	Status TransactionStatus `json:"status"`
+
+	// TokenType The type of the id token
+	TokenType *string `json:"tokenType,omitempty"`
+
+	// TransactionId The transaction id assigned by the charge station or the CSMS
+	TransactionId string `json:"transactionId"`
+}
+
+// TransactionStatus Whether the charge station has reported the end of the transaction
+type TransactionStatus string
+
+// TransactionCost The itemised cost of a transaction, excluding VAT
+type TransactionCost struct {
+	// Currency The ISO 4217 currency of the costs
+	Currency *string `json:"currency,omitempty"`
+
+	// EnergyCost The cost of the energy delivered
+	EnergyCost float64 `json:"energyCost"`
+
+	// ParkingCost The cost of the time spent parked without charging
+	ParkingCost float64                 `json:"parkingCost"`
+	Periods     []TransactionCostPeriod `json:"periods"`
+
+	// SessionFee The fee charged per transaction
+	SessionFee float64 `json:"sessionFee"`
+
+	// TariffId The registered tariff that priced the transaction: it is not set for the default tariff
+	TariffId *string `json:"tariffId,omitempty"`
+
+	// TimeCost The cost of the time spent charging
+	TimeCost float64 `json:"timeCost"`
+
+	// TotalCost The total cost of the transaction
+	TotalCost float64 `json:"totalCost"`
+}
+
+// TransactionCostPeriod A part of a transaction during which prices do not change, e.g. a time-of-use band
+type TransactionCostPeriod struct {
+	// ChargingHours The time spent charging in hours
+	ChargingHours float64 `json:"chargingHours"`
+
+	// Cost The cost of the period, including any rounding up to the tariff's step size
+	Cost    float64   `json:"cost"`
+	EndTime time.Time `json:"endTime"`
+
+	// EnergyKwh The energy delivered in kWh
+	EnergyKwh float64 `json:"energyKwh"`
+
+	// EnergyPrice The price per kWh
+	EnergyPrice float64 `json:"energyPrice"`
+
+	// ParkingHours The time spent parked without charging in hours
+	ParkingHours float64 `json:"parkingHours"`
+
+	// ParkingPrice The price per hour of parking
+	ParkingPrice float64   `json:"parkingPrice"`
+	StartTime    time.Time `json:"startTime"`
+
+	// TimePrice The price per hour of charging
+	TimePrice float64 `json:"timePrice"`
+}
+
+// TransactionExport A transaction as it is exported for billing
+type TransactionExport struct {
+	// ChargeStationId The charge station where the transaction took place
+	ChargeStationId string `json:"chargeStationId"`
+
+	// EndTime The time of the outlet energy reading taken when the transaction ended
+	EndTime *time.Time `json:"endTime,omitempty"`
+
+	// EnergyRegisterWh The outlet energy register (in Wh) when the transaction ended, as used for billing
+	EnergyRegisterWh *float64 `json:"energyRegisterWh,omitempty"`
+
+	// IdToken The id token (OCPP 1.6 idTag) that authorized the transaction
+	IdToken *string `json:"idToken,omitempty"`
+
+	// Offline Whether the transaction was started while the charge station was offline
+	Offline bool `json:"offline"`
+
+	// StartTime The time of the transaction's first meter value
+	StartTime *time.Time `json:"startTime,omitempty"`
+
+	// Status Whether the charge station has reported the end of the transaction, Active or Ended
+	Status string `json:"status"`
+
+	// TokenType The type of the id token
+	TokenType *string `json:"tokenType,omitempty"`
+
+	// TransactionId The transaction id assigned by the charge station or the CSMS
+	TransactionId string `json:"transactionId"`
+}
+
+// TransactionReceipt The receipt of a charging session. Amounts are rounded to two decimal places.
+type TransactionReceipt struct {
+	ChargeStationId string `json:"chargeStationId"`
+
+	// Currency The ISO 4217 currency of the amounts: it is not set if the transaction could not be priced
+	Currency *string `json:"currency,omitempty"`
+
+	// DurationSeconds The duration of the session
+	DurationSeconds int       `json:"durationSeconds"`
+	EndTime         time.Time `json:"endTime"`
+
+	// EnergyKwh The energy delivered in kWh
+	EnergyKwh float64 `json:"energyKwh"`
+
+	// IdToken The id token (OCPP 1.6 idTag) that authorized the transaction
+	IdToken *string       `json:"idToken,omitempty"`
+	Lines   []ReceiptLine `json:"lines"`
+
+	// NetTotal The total excluding tax
+	NetTotal float64 `json:"netTotal"`
+
+	// Seller The operator that issued the receipt
+	Seller    *string   `json:"seller,omitempty"`
+	StartTime time.Time `json:"startTime"`
+
+	// TariffId The registered tariff that priced the transaction: it is not set for the default tariff
+	TariffId *string `json:"tariffId,omitempty"`
+	TaxTotal float64 `json:"taxTotal"`
+
+	// Total The total including tax
+	Total         float64 `json:"total"`
+	TransactionId string  `json:"transactionId"`
+}
+
+// ExportChargeDetailRecordsParams defines parameters for ExportChargeDetailRecords.
+type ExportChargeDetailRecordsParams struct {
+	// Format The format of the export, defaults to csv
+	Format *ExportFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// From Only export records of transactions that ended at or after the time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only export records of transactions that ended before the time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListCommandAuditRecordsParams defines parameters for ListCommandAuditRecords.
+type ListCommandAuditRecordsParams struct {
+	// ChargeStationId Only return commands for the charge station
+	ChargeStationId *string `form:"chargeStationId,omitempty" json:"chargeStationId,omitempty"`
+
+	// Command Only return the API operation or OCPP action
+	Command *string `form:"command,omitempty" json:"command,omitempty"`
+
+	// From Only return commands requested at or after the time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return commands requested before the time
+	To     *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	Offset *int       `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int       `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListCommandSchedulesParams defines parameters for ListCommandSchedules.
+type ListCommandSchedulesParams struct {
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListChargeStationsParams defines parameters for ListChargeStations.
+type ListChargeStationsParams struct {
+	// SiteId Only include the charge stations assigned to the site or one of its sub-sites
+	SiteId *string `form:"siteId,omitempty" json:"siteId,omitempty"`
+
+	// Tag Only include the charge stations that have the tag
+	Tag    *string `form:"tag,omitempty" json:"tag,omitempty"`
+	Offset *int    `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListChargeStationsNearbyParams defines parameters for ListChargeStationsNearby.
+type ListChargeStationsNearbyParams struct {
+	// Lat The latitude of the location in degrees
+	Lat float64 `form:"lat" json:"lat"`
+
+	// Lng The longitude of the location in degrees
+	Lng float64 `form:"lng" json:"lng"`
+
+	// Radius The radius of the search in m
+	Radius float64 `form:"radius" json:"radius"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetChargeStationAvailabilityParams defines parameters for GetChargeStationAvailability.
+type GetChargeStationAvailabilityParams struct {
+	// From The start of the period: defaults to now, and a time in the past is treated as now
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To The end of the period: defaults to 24 hours after the start and can be at most 7 days after it
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetDashboardSummaryParams defines parameters for GetDashboardSummary.
+type GetDashboardSummaryParams struct {
+	// SiteId Only include the charge stations assigned to the site or one of its sub-sites
+	SiteId *string `form:"siteId,omitempty" json:"siteId,omitempty"`
+
+	// Tag Only include the charge stations that have the tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// Category Only stream events in the categories
+	Category *[]StreamEventsParamsCategory `form:"category,omitempty" json:"category,omitempty"`
+
+	// LastEventId Resume the stream after the event with this id
+	LastEventId *string `form:"lastEventId,omitempty" json:"lastEventId,omitempty"`
+
+	// LastEventID Resume the stream after the event with this id: takes precedence over lastEventId
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// StreamEventsParamsCategory defines parameters for StreamEvents.
+type StreamEventsParamsCategory string
+
+// ListOcppActionsParamsOcppVersion defines parameters for ListOcppActions.
+type ListOcppActionsParamsOcppVersion string
+
+// UpdateOcppActionParamsOcppVersion defines parameters for UpdateOcppAction.
+type UpdateOcppActionParamsOcppVersion string
+
+// ListSitesParams defines parameters for ListSites.
+type ListSitesParams struct {
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetFleetStatsParams defines parameters for GetFleetStats.
+type GetFleetStatsParams struct {
+	// SiteId Only include the charge stations assigned to the site or one of its sub-sites
+	SiteId *string `form:"siteId,omitempty" json:"siteId,omitempty"`
+
+	// Tag Only include the charge stations that have the tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// ListTagsParams defines parameters for ListTags.
+type ListTagsParams struct {
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListTokensParams defines parameters for ListTokens.
+type ListTokensParams struct {
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// RevokeTokenParams defines parameters for RevokeToken.
+type RevokeTokenParams struct {
+	// IfMatch The ETag of the version of the token that is being revoked
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// UpdateTokenParams defines parameters for UpdateToken.
+type UpdateTokenParams struct {
+	// IfMatch The ETag of the version of the token that is being updated
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// ListTransactionsParams defines parameters for ListTransactions.
+type ListTransactionsParams struct {
+	// ChargeStationId Only return transactions at the charge station
+	ChargeStationId *string `form:"chargeStationId,omitempty" json:"chargeStationId,omitempty"`
+
+	// IdToken Only return transactions authorized by the id token (OCPP 1.6 idTag)
+	IdToken *string `form:"idToken,omitempty" json:"idToken,omitempty"`
+
+	// From Only return transactions that started at or after the time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return transactions that started before the time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Status Only return transactions with the status
+	Status *ListTransactionsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Offset *int                          `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int                          `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListTransactionsParamsStatus defines parameters for ListTransactions.
+type ListTransactionsParamsStatus string
+
+// ExportTransactionsParams defines parameters for ExportTransactions.
+type ExportTransactionsParams struct {
+	// Format The format of the export, defaults to csv
+	Format *ExportFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// From Only export transactions that started at or after the time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only export transactions that started before the time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetTransactionReceiptParams defines parameters for GetTransactionReceipt.
+type GetTransactionReceiptParams struct {
+	// Format The format of the receipt, defaults to json
+	Format *ReceiptFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// UploadCertificateJSONRequestBody defines body for UploadCertificate for application/json ContentType.
+type UploadCertificateJSONRequestBody = Certificate
+
+// CreateBulkCommandJSONRequestBody defines body for CreateBulkCommand for application/json ContentType.
+type CreateBulkCommandJSONRequestBody = BulkCommandRequest
+
+// CreateCommandScheduleJSONRequestBody defines body for CreateCommandSchedule for application/json ContentType.
+type CreateCommandScheduleJSONRequestBody = CommandScheduleRequest
+
+// RegisterChargeStationJSONRequestBody defines body for RegisterChargeStation for application/json ContentType.
+type RegisterChargeStationJSONRequestBody = ChargeStationAuth
+
+// UpdateChargeStationJSONRequestBody defines body for UpdateChargeStation for application/json ContentType.
+type UpdateChargeStationJSONRequestBody = ChargeStationDetails
+
+// InstallChargeStationCertificatesJSONRequestBody defines body for InstallChargeStationCertificates for application/json ContentType.
+type InstallChargeStationCertificatesJSONRequestBody = ChargeStationInstallCertificates
+
+// UpdateChargeStationConnectorJSONRequestBody defines body for UpdateChargeStationConnector for application/json ContentType.
+type UpdateChargeStationConnectorJSONRequestBody = ChargeStationConnectorUpdate
+
+// ReconfigureChargeStationJSONRequestBody defines body for ReconfigureChargeStation for application/json ContentType.
+type ReconfigureChargeStationJSONRequestBody = ChargeStationSettings
+
+// SetChargeStationRegistrationJSONRequestBody defines body for SetChargeStationRegistration for application/json ContentType.
+type SetChargeStationRegistrationJSONRequestBody = ChargeStationRegistration
+
+// CreateReservationJSONRequestBody defines body for CreateReservation for application/json ContentType.
+type CreateReservationJSONRequestBody = Reservation
+
+// TriggerChargeStationJSONRequestBody defines body for TriggerChargeStation for application/json ContentType.
+type TriggerChargeStationJSONRequestBody = ChargeStationTrigger
+
+// RegisterLocationJSONRequestBody defines body for RegisterLocation for application/json ContentType.
+type RegisterLocationJSONRequestBody = Location
+
+// UpdateOcppActionJSONRequestBody defines body for UpdateOcppAction for application/json ContentType.
+type UpdateOcppActionJSONRequestBody = OcppActionUpdate
+
+// RegisterPartyJSONRequestBody defines body for RegisterParty for application/json ContentType.
+type RegisterPartyJSONRequestBody = Registration
+
+// TransferReservationJSONRequestBody defines body for TransferReservation for application/json ContentType.
+type TransferReservationJSONRequestBody = ReservationTransferRequest
+
+// RegisterSiteJSONRequestBody defines body for RegisterSite for application/json ContentType.
+type RegisterSiteJSONRequestBody = SiteDetails
+
+// RegisterTagJSONRequestBody defines body for RegisterTag for application/json ContentType.
+type RegisterTagJSONRequestBody = TagDetails
+
+// RegisterTariffJSONRequestBody defines body for RegisterTariff for application/json ContentType.
+type RegisterTariffJSONRequestBody = Tariff
+
+// SetTokenJSONRequestBody defines body for SetToken for application/json ContentType.
+type SetTokenJSONRequestBody = Token
+
+// UpdateTokenJSONRequestBody defines body for UpdateToken for application/json ContentType.
+type UpdateTokenJSONRequestBody = Token
+
+// EmailTransactionReceiptJSONRequestBody defines body for EmailTransactionReceipt for application/json ContentType.
+type EmailTransactionReceiptJSONRequestBody = ReceiptEmailRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ExportChargeDetailRecords request
+	ExportChargeDetailRecords(ctx context.Context, params *ExportChargeDetailRecordsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UploadCertificate request with any body
+	UploadCertificateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UploadCertificate(ctx context.Context, body UploadCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteCertificate request
+	DeleteCertificate(ctx context.Context, certificateHash string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupCertificate request
+	LookupCertificate(ctx context.Context, certificateHash string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListCommandAuditRecords request
+	ListCommandAuditRecords(ctx context.Context, params *ListCommandAuditRecordsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateBulkCommand request with any body
+	CreateBulkCommandWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateBulkCommand(ctx context.Context, body CreateBulkCommandJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupBulkCommand request
+	LookupBulkCommand(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PauseBulkCommand request
+	PauseBulkCommand(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResumeBulkCommand request
+	ResumeBulkCommand(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListCommandSchedules request
+	ListCommandSchedules(ctx context.Context, params *ListCommandSchedulesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateCommandSchedule request with any body
+	CreateCommandScheduleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateCommandSchedule(ctx context.Context, body CreateCommandScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteCommandSchedule request
+	DeleteCommandSchedule(ctx context.Context, scheduleId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupCommandSchedule request
+	LookupCommandSchedule(ctx context.Context, scheduleId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListChargeStations request
+	ListChargeStations(ctx context.Context, params *ListChargeStationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListChargeStationsNearby request
+	ListChargeStationsNearby(ctx context.Context, params *ListChargeStationsNearbyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteChargeStation request
+	DeleteChargeStation(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupChargeStation request
+	LookupChargeStation(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterChargeStation request with any body
+	RegisterChargeStationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterChargeStation(ctx context.Context, csId string, body RegisterChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateChargeStation request with any body
+	UpdateChargeStationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateChargeStation(ctx context.Context, csId string, body UpdateChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupChargeStationAuth request
+	LookupChargeStationAuth(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChargeStationAvailability request
+	GetChargeStationAvailability(ctx context.Context, csId string, params *GetChargeStationAvailabilityParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// InstallChargeStationCertificates request with any body
+	InstallChargeStationCertificatesWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	InstallChargeStationCertificates(ctx context.Context, csId string, body InstallChargeStationCertificatesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListInstalledChargeStationCertificates request
+	ListInstalledChargeStationCertificates(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RefreshInstalledChargeStationCertificates request
+	RefreshInstalledChargeStationCertificates(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteInstalledChargeStationCertificate request
+	DeleteInstalledChargeStationCertificate(ctx context.Context, csId string, serialNumber string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListChargeStationEvses request
+	ListChargeStationEvses(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateChargeStationConnector request with any body
+	UpdateChargeStationConnectorWithBody(ctx context.Context, csId string, evseId int, connectorId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateChargeStationConnector(ctx context.Context, csId string, evseId int, connectorId int, body UpdateChargeStationConnectorJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupChargeStationLiveness request
+	LookupChargeStationLiveness(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReconfigureChargeStation request with any body
+	ReconfigureChargeStationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReconfigureChargeStation(ctx context.Context, csId string, body ReconfigureChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupChargeStationRegistration request
+	LookupChargeStationRegistration(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetChargeStationRegistration request with any body
+	SetChargeStationRegistrationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetChargeStationRegistration(ctx context.Context, csId string, body SetChargeStationRegistrationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateReservation request with any body
+	CreateReservationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateReservation(ctx context.Context, csId string, body CreateReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TriggerChargeStation request with any body
+	TriggerChargeStationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TriggerChargeStation(ctx context.Context, csId string, body TriggerChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDashboardSummary request
+	GetDashboardSummary(ctx context.Context, params *GetDashboardSummaryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamEvents request
+	StreamEvents(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterLocation request with any body
+	RegisterLocationWithBody(ctx context.Context, locationId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterLocation(ctx context.Context, locationId string, body RegisterLocationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListOcppActions request
+	ListOcppActions(ctx context.Context, ocppVersion ListOcppActionsParamsOcppVersion, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateOcppAction request with any body
+	UpdateOcppActionWithBody(ctx context.Context, ocppVersion UpdateOcppActionParamsOcppVersion, action string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateOcppAction(ctx context.Context, ocppVersion UpdateOcppActionParamsOcppVersion, action string, body UpdateOcppActionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterParty request with any body
+	RegisterPartyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterParty(ctx context.Context, body RegisterPartyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelReservation request
+	CancelReservation(ctx context.Context, reservationId int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupReservation request
+	LookupReservation(ctx context.Context, reservationId int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TransferReservation request with any body
+	TransferReservationWithBody(ctx context.Context, reservationId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TransferReservation(ctx context.Context, reservationId int, body TransferReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSites request
+	ListSites(ctx context.Context, params *ListSitesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteSite request
+	DeleteSite(ctx context.Context, siteId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupSite request
+	LookupSite(ctx context.Context, siteId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterSite request with any body
+	RegisterSiteWithBody(ctx context.Context, siteId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterSite(ctx context.Context, siteId string, body RegisterSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnassignChargeStationSite request
+	UnassignChargeStationSite(ctx context.Context, siteId string, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AssignChargeStationSite request
+	AssignChargeStationSite(ctx context.Context, siteId string, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFleetStats request
+	GetFleetStats(ctx context.Context, params *GetFleetStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListTags request
+	ListTags(ctx context.Context, params *ListTagsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteTag request
+	DeleteTag(ctx context.Context, tagName string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupTag request
+	LookupTag(ctx context.Context, tagName string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterTag request with any body
+	RegisterTagWithBody(ctx context.Context, tagName string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterTag(ctx context.Context, tagName string, body RegisterTagJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UntagChargeStation request
+	UntagChargeStation(ctx context.Context, tagName string, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TagChargeStation request
+	TagChargeStation(ctx context.Context, tagName string, csId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterTariff request with any body
+	RegisterTariffWithBody(ctx context.Context, tariffId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterTariff(ctx context.Context, tariffId string, body RegisterTariffJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListTokens request
+	ListTokens(ctx context.Context, params *ListTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetToken request with any body
+	SetTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetToken(ctx context.Context, body SetTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeToken request
+	RevokeToken(ctx context.Context, tokenUid string, params *RevokeTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupToken request
+	LookupToken(ctx context.Context, tokenUid string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateToken request with any body
+	UpdateTokenWithBody(ctx context.Context, tokenUid string, params *UpdateTokenParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateToken(ctx context.Context, tokenUid string, params *UpdateTokenParams, body UpdateTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListTransactions request
+	ListTransactions(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportTransactions request
+	ExportTransactions(ctx context.Context, params *ExportTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTransactionReceipt request
+	GetTransactionReceipt(ctx context.Context, csId string, transactionId string, params *GetTransactionReceiptParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EmailTransactionReceipt request with any body
+	EmailTransactionReceiptWithBody(ctx context.Context, csId string, transactionId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	EmailTransactionReceipt(ctx context.Context, csId string, transactionId string, body EmailTransactionReceiptJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ExportChargeDetailRecords(ctx context.Context, params *ExportChargeDetailRecordsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportChargeDetailRecordsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UploadCertificateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadCertificateRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UploadCertificate(ctx context.Context, body UploadCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadCertificateRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteCertificate(ctx context.Context, certificateHash string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteCertificateRequest(c.Server, certificateHash)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupCertificate(ctx context.Context, certificateHash string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupCertificateRequest(c.Server, certificateHash)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListCommandAuditRecords(ctx context.Context, params *ListCommandAuditRecordsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCommandAuditRecordsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBulkCommandWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBulkCommandRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBulkCommand(ctx context.Context, body CreateBulkCommandJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBulkCommandRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupBulkCommand(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupBulkCommandRequest(c.Server, bulkCommandId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PauseBulkCommand(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPauseBulkCommandRequest(c.Server, bulkCommandId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResumeBulkCommand(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResumeBulkCommandRequest(c.Server, bulkCommandId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListCommandSchedules(ctx context.Context, params *ListCommandSchedulesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCommandSchedulesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateCommandScheduleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCommandScheduleRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateCommandSchedule(ctx context.Context, body CreateCommandScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCommandScheduleRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteCommandSchedule(ctx context.Context, scheduleId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteCommandScheduleRequest(c.Server, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupCommandSchedule(ctx context.Context, scheduleId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupCommandScheduleRequest(c.Server, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListChargeStations(ctx context.Context, params *ListChargeStationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListChargeStationsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListChargeStationsNearby(ctx context.Context, params *ListChargeStationsNearbyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListChargeStationsNearbyRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteChargeStation(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteChargeStationRequest(c.Server, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupChargeStation(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupChargeStationRequest(c.Server, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterChargeStationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterChargeStationRequestWithBody(c.Server, csId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterChargeStation(ctx context.Context, csId string, body RegisterChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterChargeStationRequest(c.Server, csId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateChargeStationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateChargeStationRequestWithBody(c.Server, csId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateChargeStation(ctx context.Context, csId string, body UpdateChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateChargeStationRequest(c.Server, csId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupChargeStationAuth(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupChargeStationAuthRequest(c.Server, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChargeStationAvailability(ctx context.Context, csId string, params *GetChargeStationAvailabilityParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChargeStationAvailabilityRequest(c.Server, csId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) InstallChargeStationCertificatesWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewInstallChargeStationCertificatesRequestWithBody(c.Server, csId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) InstallChargeStationCertificates(ctx context.Context, csId string, body InstallChargeStationCertificatesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewInstallChargeStationCertificatesRequest(c.Server, csId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListInstalledChargeStationCertificates(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListInstalledChargeStationCertificatesRequest(c.Server, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RefreshInstalledChargeStationCertificates(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRefreshInstalledChargeStationCertificatesRequest(c.Server, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteInstalledChargeStationCertificate(ctx context.Context, csId string, serialNumber string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteInstalledChargeStationCertificateRequest(c.Server, csId, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListChargeStationEvses(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListChargeStationEvsesRequest(c.Server, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateChargeStationConnectorWithBody(ctx context.Context, csId string, evseId int, connectorId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateChargeStationConnectorRequestWithBody(c.Server, csId, evseId, connectorId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateChargeStationConnector(ctx context.Context, csId string, evseId int, connectorId int, body UpdateChargeStationConnectorJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateChargeStationConnectorRequest(c.Server, csId, evseId, connectorId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupChargeStationLiveness(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupChargeStationLivenessRequest(c.Server, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReconfigureChargeStationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReconfigureChargeStationRequestWithBody(c.Server, csId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReconfigureChargeStation(ctx context.Context, csId string, body ReconfigureChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReconfigureChargeStationRequest(c.Server, csId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupChargeStationRegistration(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupChargeStationRegistrationRequest(c.Server, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetChargeStationRegistrationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetChargeStationRegistrationRequestWithBody(c.Server, csId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetChargeStationRegistration(ctx context.Context, csId string, body SetChargeStationRegistrationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetChargeStationRegistrationRequest(c.Server, csId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateReservationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateReservationRequestWithBody(c.Server, csId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateReservation(ctx context.Context, csId string, body CreateReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateReservationRequest(c.Server, csId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TriggerChargeStationWithBody(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTriggerChargeStationRequestWithBody(c.Server, csId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TriggerChargeStation(ctx context.Context, csId string, body TriggerChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTriggerChargeStationRequest(c.Server, csId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDashboardSummary(ctx context.Context, params *GetDashboardSummaryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDashboardSummaryRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamEvents(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterLocationWithBody(ctx context.Context, locationId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterLocationRequestWithBody(c.Server, locationId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterLocation(ctx context.Context, locationId string, body RegisterLocationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterLocationRequest(c.Server, locationId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListOcppActions(ctx context.Context, ocppVersion ListOcppActionsParamsOcppVersion, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListOcppActionsRequest(c.Server, ocppVersion)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateOcppActionWithBody(ctx context.Context, ocppVersion UpdateOcppActionParamsOcppVersion, action string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateOcppActionRequestWithBody(c.Server, ocppVersion, action, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateOcppAction(ctx context.Context, ocppVersion UpdateOcppActionParamsOcppVersion, action string, body UpdateOcppActionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateOcppActionRequest(c.Server, ocppVersion, action, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterPartyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterPartyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterParty(ctx context.Context, body RegisterPartyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterPartyRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CancelReservation(ctx context.Context, reservationId int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelReservationRequest(c.Server, reservationId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupReservation(ctx context.Context, reservationId int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupReservationRequest(c.Server, reservationId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransferReservationWithBody(ctx context.Context, reservationId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransferReservationRequestWithBody(c.Server, reservationId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransferReservation(ctx context.Context, reservationId int, body TransferReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransferReservationRequest(c.Server, reservationId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSites(ctx context.Context, params *ListSitesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSitesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSite(ctx context.Context, siteId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSiteRequest(c.Server, siteId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupSite(ctx context.Context, siteId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupSiteRequest(c.Server, siteId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterSiteWithBody(ctx context.Context, siteId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterSiteRequestWithBody(c.Server, siteId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterSite(ctx context.Context, siteId string, body RegisterSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterSiteRequest(c.Server, siteId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnassignChargeStationSite(ctx context.Context, siteId string, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnassignChargeStationSiteRequest(c.Server, siteId, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AssignChargeStationSite(ctx context.Context, siteId string, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAssignChargeStationSiteRequest(c.Server, siteId, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetFleetStats(ctx context.Context, params *GetFleetStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFleetStatsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTags(ctx context.Context, params *ListTagsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTagsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteTag(ctx context.Context, tagName string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTagRequest(c.Server, tagName)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupTag(ctx context.Context, tagName string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupTagRequest(c.Server, tagName)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterTagWithBody(ctx context.Context, tagName string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterTagRequestWithBody(c.Server, tagName, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterTag(ctx context.Context, tagName string, body RegisterTagJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterTagRequest(c.Server, tagName, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UntagChargeStation(ctx context.Context, tagName string, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUntagChargeStationRequest(c.Server, tagName, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TagChargeStation(ctx context.Context, tagName string, csId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTagChargeStationRequest(c.Server, tagName, csId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterTariffWithBody(ctx context.Context, tariffId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterTariffRequestWithBody(c.Server, tariffId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterTariff(ctx context.Context, tariffId string, body RegisterTariffJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterTariffRequest(c.Server, tariffId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTokens(ctx context.Context, params *ListTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTokensRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetTokenRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetToken(ctx context.Context, body SetTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetTokenRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeToken(ctx context.Context, tokenUid string, params *RevokeTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeTokenRequest(c.Server, tokenUid, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupToken(ctx context.Context, tokenUid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupTokenRequest(c.Server, tokenUid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTokenWithBody(ctx context.Context, tokenUid string, params *UpdateTokenParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTokenRequestWithBody(c.Server, tokenUid, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateToken(ctx context.Context, tokenUid string, params *UpdateTokenParams, body UpdateTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTokenRequest(c.Server, tokenUid, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTransactions(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTransactionsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportTransactions(ctx context.Context, params *ExportTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportTransactionsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTransactionReceipt(ctx context.Context, csId string, transactionId string, params *GetTransactionReceiptParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTransactionReceiptRequest(c.Server, csId, transactionId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EmailTransactionReceiptWithBody(ctx context.Context, csId string, transactionId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEmailTransactionReceiptRequestWithBody(c.Server, csId, transactionId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EmailTransactionReceipt(ctx context.Context, csId string, transactionId string, body EmailTransactionReceiptJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEmailTransactionReceiptRequest(c.Server, csId, transactionId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewExportChargeDetailRecordsRequest generates requests for ExportChargeDetailRecords
+func NewExportChargeDetailRecordsRequest(server string, params *ExportChargeDetailRecordsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cdrs/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUploadCertificateRequest calls the generic UploadCertificate builder with application/json body
+func NewUploadCertificateRequest(server string, body UploadCertificateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUploadCertificateRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewUploadCertificateRequestWithBody generates requests for UploadCertificate with any type of body
+func NewUploadCertificateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/certificate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteCertificateRequest generates requests for DeleteCertificate
+func NewDeleteCertificateRequest(server string, certificateHash string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "certificateHash", runtime.ParamLocationPath, certificateHash)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/certificate/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLookupCertificateRequest generates requests for LookupCertificate
+func NewLookupCertificateRequest(server string, certificateHash string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "certificateHash", runtime.ParamLocationPath, certificateHash)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/certificate/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListCommandAuditRecordsRequest generates requests for ListCommandAuditRecords
+func NewListCommandAuditRecordsRequest(server string, params *ListCommandAuditRecordsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/commands")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ChargeStationId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chargeStationId", runtime.ParamLocationQuery, *params.ChargeStationId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Command != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "command", runtime.ParamLocationQuery, *params.Command); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateBulkCommandRequest calls the generic CreateBulkCommand builder with application/json body
+func NewCreateBulkCommandRequest(server string, body CreateBulkCommandJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateBulkCommandRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateBulkCommandRequestWithBody generates requests for CreateBulkCommand with any type of body
+func NewCreateBulkCommandRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/commands/bulk")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLookupBulkCommandRequest generates requests for LookupBulkCommand
+func NewLookupBulkCommandRequest(server string, bulkCommandId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "bulkCommandId", runtime.ParamLocationPath, bulkCommandId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/commands/bulk/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPauseBulkCommandRequest generates requests for PauseBulkCommand
+func NewPauseBulkCommandRequest(server string, bulkCommandId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "bulkCommandId", runtime.ParamLocationPath, bulkCommandId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/commands/bulk/%s/pause", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewResumeBulkCommandRequest generates requests for ResumeBulkCommand
+func NewResumeBulkCommandRequest(server string, bulkCommandId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "bulkCommandId", runtime.ParamLocationPath, bulkCommandId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/commands/bulk/%s/resume", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListCommandSchedulesRequest generates requests for ListCommandSchedules
+func NewListCommandSchedulesRequest(server string, params *ListCommandSchedulesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/commands/schedule")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateCommandScheduleRequest calls the generic CreateCommandSchedule builder with application/json body
+func NewCreateCommandScheduleRequest(server string, body CreateCommandScheduleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateCommandScheduleRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateCommandScheduleRequestWithBody generates requests for CreateCommandSchedule with any type of body
+func NewCreateCommandScheduleRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/commands/schedule")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteCommandScheduleRequest generates requests for DeleteCommandSchedule
+func NewDeleteCommandScheduleRequest(server string, scheduleId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "scheduleId", runtime.ParamLocationPath, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/commands/schedule/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLookupCommandScheduleRequest generates requests for LookupCommandSchedule
+func NewLookupCommandScheduleRequest(server string, scheduleId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "scheduleId", runtime.ParamLocationPath, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/commands/schedule/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListChargeStationsRequest generates requests for ListChargeStations
+func NewListChargeStationsRequest(server string, params *ListChargeStationsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.SiteId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "siteId", runtime.ParamLocationQuery, *params.SiteId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListChargeStationsNearbyRequest generates requests for ListChargeStationsNearby
+func NewListChargeStationsNearbyRequest(server string, params *ListChargeStationsNearbyParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/nearby")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lat", runtime.ParamLocationQuery, params.Lat); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lng", runtime.ParamLocationQuery, params.Lng); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "radius", runtime.ParamLocationQuery, params.Radius); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteChargeStationRequest generates requests for DeleteChargeStation
+func NewDeleteChargeStationRequest(server string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLookupChargeStationRequest generates requests for LookupChargeStation
+func NewLookupChargeStationRequest(server string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterChargeStationRequest calls the generic RegisterChargeStation builder with application/json body
+func NewRegisterChargeStationRequest(server string, csId string, body RegisterChargeStationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterChargeStationRequestWithBody(server, csId, "application/json", bodyReader)
+}
+
+// NewRegisterChargeStationRequestWithBody generates requests for RegisterChargeStation with any type of body
+func NewRegisterChargeStationRequestWithBody(server string, csId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUpdateChargeStationRequest calls the generic UpdateChargeStation builder with application/json body
+func NewUpdateChargeStationRequest(server string, csId string, body UpdateChargeStationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateChargeStationRequestWithBody(server, csId, "application/json", bodyReader)
+}
+
+// NewUpdateChargeStationRequestWithBody generates requests for UpdateChargeStation with any type of body
+func NewUpdateChargeStationRequestWithBody(server string, csId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLookupChargeStationAuthRequest generates requests for LookupChargeStationAuth
+func NewLookupChargeStationAuthRequest(server string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/auth", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChargeStationAvailabilityRequest generates requests for GetChargeStationAvailability
+func NewGetChargeStationAvailabilityRequest(server string, csId string, params *GetChargeStationAvailabilityParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/availability", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewInstallChargeStationCertificatesRequest calls the generic InstallChargeStationCertificates builder with application/json body
+func NewInstallChargeStationCertificatesRequest(server string, csId string, body InstallChargeStationCertificatesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewInstallChargeStationCertificatesRequestWithBody(server, csId, "application/json", bodyReader)
+}
+
+// NewInstallChargeStationCertificatesRequestWithBody generates requests for InstallChargeStationCertificates with any type of body
+func NewInstallChargeStationCertificatesRequestWithBody(server string, csId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/certificates", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListInstalledChargeStationCertificatesRequest generates requests for ListInstalledChargeStationCertificates
+func NewListInstalledChargeStationCertificatesRequest(server string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/certificates/installed", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRefreshInstalledChargeStationCertificatesRequest generates requests for RefreshInstalledChargeStationCertificates
+func NewRefreshInstalledChargeStationCertificatesRequest(server string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/certificates/installed", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteInstalledChargeStationCertificateRequest generates requests for DeleteInstalledChargeStationCertificate
+func NewDeleteInstalledChargeStationCertificateRequest(server string, csId string, serialNumber string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "serialNumber", runtime.ParamLocationPath, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/certificates/installed/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListChargeStationEvsesRequest generates requests for ListChargeStationEvses
+func NewListChargeStationEvsesRequest(server string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/evses", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateChargeStationConnectorRequest calls the generic UpdateChargeStationConnector builder with application/json body
+func NewUpdateChargeStationConnectorRequest(server string, csId string, evseId int, connectorId int, body UpdateChargeStationConnectorJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateChargeStationConnectorRequestWithBody(server, csId, evseId, connectorId, "application/json", bodyReader)
+}
+
+// NewUpdateChargeStationConnectorRequestWithBody generates requests for UpdateChargeStationConnector with any type of body
+func NewUpdateChargeStationConnectorRequestWithBody(server string, csId string, evseId int, connectorId int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "evseId", runtime.ParamLocationPath, evseId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "connectorId", runtime.ParamLocationPath, connectorId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/evses/%s/connectors/%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLookupChargeStationLivenessRequest generates requests for LookupChargeStationLiveness
+func NewLookupChargeStationLivenessRequest(server string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/liveness", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReconfigureChargeStationRequest calls the generic ReconfigureChargeStation builder with application/json body
+func NewReconfigureChargeStationRequest(server string, csId string, body ReconfigureChargeStationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReconfigureChargeStationRequestWithBody(server, csId, "application/json", bodyReader)
+}
+
+// NewReconfigureChargeStationRequestWithBody generates requests for ReconfigureChargeStation with any type of body
+func NewReconfigureChargeStationRequestWithBody(server string, csId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/reconfigure", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLookupChargeStationRegistrationRequest generates requests for LookupChargeStationRegistration
+func NewLookupChargeStationRegistrationRequest(server string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/registration", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetChargeStationRegistrationRequest calls the generic SetChargeStationRegistration builder with application/json body
+func NewSetChargeStationRegistrationRequest(server string, csId string, body SetChargeStationRegistrationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetChargeStationRegistrationRequestWithBody(server, csId, "application/json", bodyReader)
+}
+
+// NewSetChargeStationRegistrationRequestWithBody generates requests for SetChargeStationRegistration with any type of body
+func NewSetChargeStationRegistrationRequestWithBody(server string, csId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/registration", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateReservationRequest calls the generic CreateReservation builder with application/json body
+func NewCreateReservationRequest(server string, csId string, body CreateReservationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateReservationRequestWithBody(server, csId, "application/json", bodyReader)
+}
+
+// NewCreateReservationRequestWithBody generates requests for CreateReservation with any type of body
+func NewCreateReservationRequestWithBody(server string, csId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/reservation", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTriggerChargeStationRequest calls the generic TriggerChargeStation builder with application/json body
+func NewTriggerChargeStationRequest(server string, csId string, body TriggerChargeStationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTriggerChargeStationRequestWithBody(server, csId, "application/json", bodyReader)
+}
+
+// NewTriggerChargeStationRequestWithBody generates requests for TriggerChargeStation with any type of body
+func NewTriggerChargeStationRequestWithBody(server string, csId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cs/%s/trigger", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetDashboardSummaryRequest generates requests for GetDashboardSummary
+func NewGetDashboardSummaryRequest(server string, params *GetDashboardSummaryParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/dashboard")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.SiteId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "siteId", runtime.ParamLocationQuery, *params.SiteId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStreamEventsRequest generates requests for StreamEvents
+func NewStreamEventsRequest(server string, params *StreamEventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Category != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", false, "category", runtime.ParamLocationQuery, *params.Category); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.LastEventId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lastEventId", runtime.ParamLocationQuery, *params.LastEventId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.LastEventID != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Last-Event-ID", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewRegisterLocationRequest calls the generic RegisterLocation builder with application/json body
+func NewRegisterLocationRequest(server string, locationId string, body RegisterLocationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterLocationRequestWithBody(server, locationId, "application/json", bodyReader)
+}
+
+// NewRegisterLocationRequestWithBody generates requests for RegisterLocation with any type of body
+func NewRegisterLocationRequestWithBody(server string, locationId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "locationId", runtime.ParamLocationPath, locationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/location/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListOcppActionsRequest generates requests for ListOcppActions
+func NewListOcppActionsRequest(server string, ocppVersion ListOcppActionsParamsOcppVersion) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ocppVersion", runtime.ParamLocationPath, ocppVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ocpp/%s/actions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateOcppActionRequest calls the generic UpdateOcppAction builder with application/json body
+func NewUpdateOcppActionRequest(server string, ocppVersion UpdateOcppActionParamsOcppVersion, action string, body UpdateOcppActionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateOcppActionRequestWithBody(server, ocppVersion, action, "application/json", bodyReader)
+}
+
+// NewUpdateOcppActionRequestWithBody generates requests for UpdateOcppAction with any type of body
+func NewUpdateOcppActionRequestWithBody(server string, ocppVersion UpdateOcppActionParamsOcppVersion, action string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ocppVersion", runtime.ParamLocationPath, ocppVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "action", runtime.ParamLocationPath, action)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ocpp/%s/actions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRegisterPartyRequest calls the generic RegisterParty builder with application/json body
+func NewRegisterPartyRequest(server string, body RegisterPartyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterPartyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRegisterPartyRequestWithBody generates requests for RegisterParty with any type of body
+func NewRegisterPartyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/register")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCancelReservationRequest generates requests for CancelReservation
+func NewCancelReservationRequest(server string, reservationId int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "reservationId", runtime.ParamLocationPath, reservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservation/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLookupReservationRequest generates requests for LookupReservation
+func NewLookupReservationRequest(server string, reservationId int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "reservationId", runtime.ParamLocationPath, reservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservation/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTransferReservationRequest calls the generic TransferReservation builder with application/json body
+func NewTransferReservationRequest(server string, reservationId int, body TransferReservationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTransferReservationRequestWithBody(server, reservationId, "application/json", bodyReader)
+}
+
+// NewTransferReservationRequestWithBody generates requests for TransferReservation with any type of body
+func NewTransferReservationRequestWithBody(server string, reservationId int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "reservationId", runtime.ParamLocationPath, reservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservation/%s/transfer", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListSitesRequest generates requests for ListSites
+func NewListSitesRequest(server string, params *ListSitesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/site")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteSiteRequest generates requests for DeleteSite
+func NewDeleteSiteRequest(server string, siteId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "siteId", runtime.ParamLocationPath, siteId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/site/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLookupSiteRequest generates requests for LookupSite
+func NewLookupSiteRequest(server string, siteId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "siteId", runtime.ParamLocationPath, siteId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/site/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterSiteRequest calls the generic RegisterSite builder with application/json body
+func NewRegisterSiteRequest(server string, siteId string, body RegisterSiteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterSiteRequestWithBody(server, siteId, "application/json", bodyReader)
+}
+
+// NewRegisterSiteRequestWithBody generates requests for RegisterSite with any type of body
+func NewRegisterSiteRequestWithBody(server string, siteId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "siteId", runtime.ParamLocationPath, siteId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/site/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUnassignChargeStationSiteRequest generates requests for UnassignChargeStationSite
+func NewUnassignChargeStationSiteRequest(server string, siteId string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "siteId", runtime.ParamLocationPath, siteId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/site/%s/cs/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAssignChargeStationSiteRequest generates requests for AssignChargeStationSite
+func NewAssignChargeStationSiteRequest(server string, siteId string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "siteId", runtime.ParamLocationPath, siteId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/site/%s/cs/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFleetStatsRequest generates requests for GetFleetStats
+func NewGetFleetStatsRequest(server string, params *GetFleetStatsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stats")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.SiteId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "siteId", runtime.ParamLocationQuery, *params.SiteId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListTagsRequest generates requests for ListTags
+func NewListTagsRequest(server string, params *ListTagsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tag")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteTagRequest generates requests for DeleteTag
+func NewDeleteTagRequest(server string, tagName string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tagName", runtime.ParamLocationPath, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tag/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLookupTagRequest generates requests for LookupTag
+func NewLookupTagRequest(server string, tagName string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tagName", runtime.ParamLocationPath, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tag/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterTagRequest calls the generic RegisterTag builder with application/json body
+func NewRegisterTagRequest(server string, tagName string, body RegisterTagJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterTagRequestWithBody(server, tagName, "application/json", bodyReader)
+}
+
+// NewRegisterTagRequestWithBody generates requests for RegisterTag with any type of body
+func NewRegisterTagRequestWithBody(server string, tagName string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tagName", runtime.ParamLocationPath, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tag/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUntagChargeStationRequest generates requests for UntagChargeStation
+func NewUntagChargeStationRequest(server string, tagName string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tagName", runtime.ParamLocationPath, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tag/%s/cs/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTagChargeStationRequest generates requests for TagChargeStation
+func NewTagChargeStationRequest(server string, tagName string, csId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tagName", runtime.ParamLocationPath, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tag/%s/cs/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterTariffRequest calls the generic RegisterTariff builder with application/json body
+func NewRegisterTariffRequest(server string, tariffId string, body RegisterTariffJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterTariffRequestWithBody(server, tariffId, "application/json", bodyReader)
+}
+
+// NewRegisterTariffRequestWithBody generates requests for RegisterTariff with any type of body
+func NewRegisterTariffRequestWithBody(server string, tariffId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tariffId", runtime.ParamLocationPath, tariffId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tariff/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListTokensRequest generates requests for ListTokens
+func NewListTokensRequest(server string, params *ListTokensParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/token")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetTokenRequest calls the generic SetToken builder with application/json body
+func NewSetTokenRequest(server string, body SetTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetTokenRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetTokenRequestWithBody generates requests for SetToken with any type of body
+func NewSetTokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/token")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeTokenRequest generates requests for RevokeToken
+func NewRevokeTokenRequest(server string, tokenUid string, params *RevokeTokenParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tokenUid", runtime.ParamLocationPath, tokenUid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/token/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.IfMatch != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("If-Match", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewLookupTokenRequest generates requests for LookupToken
+func NewLookupTokenRequest(server string, tokenUid string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tokenUid", runtime.ParamLocationPath, tokenUid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/token/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateTokenRequest calls the generic UpdateToken builder with application/json body
+func NewUpdateTokenRequest(server string, tokenUid string, params *UpdateTokenParams, body UpdateTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateTokenRequestWithBody(server, tokenUid, params, "application/json", bodyReader)
+}
+
+// NewUpdateTokenRequestWithBody generates requests for UpdateToken with any type of body
+func NewUpdateTokenRequestWithBody(server string, tokenUid string, params *UpdateTokenParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tokenUid", runtime.ParamLocationPath, tokenUid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/token/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params.IfMatch != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("If-Match", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewListTransactionsRequest generates requests for ListTransactions
+func NewListTransactionsRequest(server string, params *ListTransactionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transactions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ChargeStationId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chargeStationId", runtime.ParamLocationQuery, *params.ChargeStationId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IdToken != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "idToken", runtime.ParamLocationQuery, *params.IdToken); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportTransactionsRequest generates requests for ExportTransactions
+func NewExportTransactionsRequest(server string, params *ExportTransactionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transactions/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTransactionReceiptRequest generates requests for GetTransactionReceipt
+func NewGetTransactionReceiptRequest(server string, csId string, transactionId string, params *GetTransactionReceiptParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "transactionId", runtime.ParamLocationPath, transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transactions/%s/%s/receipt", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewEmailTransactionReceiptRequest calls the generic EmailTransactionReceipt builder with application/json body
+func NewEmailTransactionReceiptRequest(server string, csId string, transactionId string, body EmailTransactionReceiptJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewEmailTransactionReceiptRequestWithBody(server, csId, transactionId, "application/json", bodyReader)
+}
+
+// NewEmailTransactionReceiptRequestWithBody generates requests for EmailTransactionReceipt with any type of body
+func NewEmailTransactionReceiptRequestWithBody(server string, csId string, transactionId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "csId", runtime.ParamLocationPath, csId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "transactionId", runtime.ParamLocationPath, transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transactions/%s/%s/receipt/email", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ExportChargeDetailRecords request
+	ExportChargeDetailRecordsWithResponse(ctx context.Context, params *ExportChargeDetailRecordsParams, reqEditors ...RequestEditorFn) (*ExportChargeDetailRecordsResponse, error)
+
+	// UploadCertificate request with any body
+	UploadCertificateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadCertificateResponse, error)
+
+	UploadCertificateWithResponse(ctx context.Context, body UploadCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*UploadCertificateResponse, error)
+
+	// DeleteCertificate request
+	DeleteCertificateWithResponse(ctx context.Context, certificateHash string, reqEditors ...RequestEditorFn) (*DeleteCertificateResponse, error)
+
+	// LookupCertificate request
+	LookupCertificateWithResponse(ctx context.Context, certificateHash string, reqEditors ...RequestEditorFn) (*LookupCertificateResponse, error)
+
+	// ListCommandAuditRecords request
+	ListCommandAuditRecordsWithResponse(ctx context.Context, params *ListCommandAuditRecordsParams, reqEditors ...RequestEditorFn) (*ListCommandAuditRecordsResponse, error)
+
+	// CreateBulkCommand request with any body
+	CreateBulkCommandWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBulkCommandResponse, error)
+
+	CreateBulkCommandWithResponse(ctx context.Context, body CreateBulkCommandJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBulkCommandResponse, error)
+
+	// LookupBulkCommand request
+	LookupBulkCommandWithResponse(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*LookupBulkCommandResponse, error)
+
+	// PauseBulkCommand request
+	PauseBulkCommandWithResponse(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*PauseBulkCommandResponse, error)
+
+	// ResumeBulkCommand request
+	ResumeBulkCommandWithResponse(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*ResumeBulkCommandResponse, error)
+
+	// ListCommandSchedules request
+	ListCommandSchedulesWithResponse(ctx context.Context, params *ListCommandSchedulesParams, reqEditors ...RequestEditorFn) (*ListCommandSchedulesResponse, error)
+
+	// CreateCommandSchedule request with any body
+	CreateCommandScheduleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCommandScheduleResponse, error)
+
+	CreateCommandScheduleWithResponse(ctx context.Context, body CreateCommandScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCommandScheduleResponse, error)
+
+	// DeleteCommandSchedule request
+	DeleteCommandScheduleWithResponse(ctx context.Context, scheduleId string, reqEditors ...RequestEditorFn) (*DeleteCommandScheduleResponse, error)
+
+	// LookupCommandSchedule request
+	LookupCommandScheduleWithResponse(ctx context.Context, scheduleId string, reqEditors ...RequestEditorFn) (*LookupCommandScheduleResponse, error)
+
+	// ListChargeStations request
+	ListChargeStationsWithResponse(ctx context.Context, params *ListChargeStationsParams, reqEditors ...RequestEditorFn) (*ListChargeStationsResponse, error)
+
+	// ListChargeStationsNearby request
+	ListChargeStationsNearbyWithResponse(ctx context.Context, params *ListChargeStationsNearbyParams, reqEditors ...RequestEditorFn) (*ListChargeStationsNearbyResponse, error)
+
+	// DeleteChargeStation request
+	DeleteChargeStationWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*DeleteChargeStationResponse, error)
+
+	// LookupChargeStation request
+	LookupChargeStationWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*LookupChargeStationResponse, error)
+
+	// RegisterChargeStation request with any body
+	RegisterChargeStationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterChargeStationResponse, error)
+
+	RegisterChargeStationWithResponse(ctx context.Context, csId string, body RegisterChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterChargeStationResponse, error)
+
+	// UpdateChargeStation request with any body
+	UpdateChargeStationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateChargeStationResponse, error)
+
+	UpdateChargeStationWithResponse(ctx context.Context, csId string, body UpdateChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateChargeStationResponse, error)
+
+	// LookupChargeStationAuth request
+	LookupChargeStationAuthWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*LookupChargeStationAuthResponse, error)
+
+	// GetChargeStationAvailability request
+	GetChargeStationAvailabilityWithResponse(ctx context.Context, csId string, params *GetChargeStationAvailabilityParams, reqEditors ...RequestEditorFn) (*GetChargeStationAvailabilityResponse, error)
+
+	// InstallChargeStationCertificates request with any body
+	InstallChargeStationCertificatesWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*InstallChargeStationCertificatesResponse, error)
+
+	InstallChargeStationCertificatesWithResponse(ctx context.Context, csId string, body InstallChargeStationCertificatesJSONRequestBody, reqEditors ...RequestEditorFn) (*InstallChargeStationCertificatesResponse, error)
+
+	// ListInstalledChargeStationCertificates request
+	ListInstalledChargeStationCertificatesWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*ListInstalledChargeStationCertificatesResponse, error)
+
+	// RefreshInstalledChargeStationCertificates request
+	RefreshInstalledChargeStationCertificatesWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*RefreshInstalledChargeStationCertificatesResponse, error)
+
+	// DeleteInstalledChargeStationCertificate request
+	DeleteInstalledChargeStationCertificateWithResponse(ctx context.Context, csId string, serialNumber string, reqEditors ...RequestEditorFn) (*DeleteInstalledChargeStationCertificateResponse, error)
+
+	// ListChargeStationEvses request
+	ListChargeStationEvsesWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*ListChargeStationEvsesResponse, error)
+
+	// UpdateChargeStationConnector request with any body
+	UpdateChargeStationConnectorWithBodyWithResponse(ctx context.Context, csId string, evseId int, connectorId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateChargeStationConnectorResponse, error)
+
+	UpdateChargeStationConnectorWithResponse(ctx context.Context, csId string, evseId int, connectorId int, body UpdateChargeStationConnectorJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateChargeStationConnectorResponse, error)
+
+	// LookupChargeStationLiveness request
+	LookupChargeStationLivenessWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*LookupChargeStationLivenessResponse, error)
+
+	// ReconfigureChargeStation request with any body
+	ReconfigureChargeStationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReconfigureChargeStationResponse, error)
+
+	ReconfigureChargeStationWithResponse(ctx context.Context, csId string, body ReconfigureChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*ReconfigureChargeStationResponse, error)
+
+	// LookupChargeStationRegistration request
+	LookupChargeStationRegistrationWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*LookupChargeStationRegistrationResponse, error)
+
+	// SetChargeStationRegistration request with any body
+	SetChargeStationRegistrationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetChargeStationRegistrationResponse, error)
+
+	SetChargeStationRegistrationWithResponse(ctx context.Context, csId string, body SetChargeStationRegistrationJSONRequestBody, reqEditors ...RequestEditorFn) (*SetChargeStationRegistrationResponse, error)
+
+	// CreateReservation request with any body
+	CreateReservationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateReservationResponse, error)
+
+	CreateReservationWithResponse(ctx context.Context, csId string, body CreateReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateReservationResponse, error)
+
+	// TriggerChargeStation request with any body
+	TriggerChargeStationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TriggerChargeStationResponse, error)
+
+	TriggerChargeStationWithResponse(ctx context.Context, csId string, body TriggerChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*TriggerChargeStationResponse, error)
+
+	// GetDashboardSummary request
+	GetDashboardSummaryWithResponse(ctx context.Context, params *GetDashboardSummaryParams, reqEditors ...RequestEditorFn) (*GetDashboardSummaryResponse, error)
+
+	// StreamEvents request
+	StreamEventsWithResponse(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*StreamEventsResponse, error)
+
+	// RegisterLocation request with any body
+	RegisterLocationWithBodyWithResponse(ctx context.Context, locationId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterLocationResponse, error)
+
+	RegisterLocationWithResponse(ctx context.Context, locationId string, body RegisterLocationJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterLocationResponse, error)
+
+	// ListOcppActions request
+	ListOcppActionsWithResponse(ctx context.Context, ocppVersion ListOcppActionsParamsOcppVersion, reqEditors ...RequestEditorFn) (*ListOcppActionsResponse, error)
+
+	// UpdateOcppAction request with any body
+	UpdateOcppActionWithBodyWithResponse(ctx context.Context, ocppVersion UpdateOcppActionParamsOcppVersion, action string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateOcppActionResponse, error)
+
+	UpdateOcppActionWithResponse(ctx context.Context, ocppVersion UpdateOcppActionParamsOcppVersion, action string, body UpdateOcppActionJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateOcppActionResponse, error)
+
+	// RegisterParty request with any body
+	RegisterPartyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterPartyResponse, error)
+
+	RegisterPartyWithResponse(ctx context.Context, body RegisterPartyJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterPartyResponse, error)
+
+	// CancelReservation request
+	CancelReservationWithResponse(ctx context.Context, reservationId int, reqEditors ...RequestEditorFn) (*CancelReservationResponse, error)
+
+	// LookupReservation request
+	LookupReservationWithResponse(ctx context.Context, reservationId int, reqEditors ...RequestEditorFn) (*LookupReservationResponse, error)
+
+	// TransferReservation request with any body
+	TransferReservationWithBodyWithResponse(ctx context.Context, reservationId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransferReservationResponse, error)
+
+	TransferReservationWithResponse(ctx context.Context, reservationId int, body TransferReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*TransferReservationResponse, error)
+
+	// ListSites request
+	ListSitesWithResponse(ctx context.Context, params *ListSitesParams, reqEditors ...RequestEditorFn) (*ListSitesResponse, error)
+
+	// DeleteSite request
+	DeleteSiteWithResponse(ctx context.Context, siteId string, reqEditors ...RequestEditorFn) (*DeleteSiteResponse, error)
+
+	// LookupSite request
+	LookupSiteWithResponse(ctx context.Context, siteId string, reqEditors ...RequestEditorFn) (*LookupSiteResponse, error)
+
+	// RegisterSite request with any body
+	RegisterSiteWithBodyWithResponse(ctx context.Context, siteId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterSiteResponse, error)
+
+	RegisterSiteWithResponse(ctx context.Context, siteId string, body RegisterSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterSiteResponse, error)
+
+	// UnassignChargeStationSite request
+	UnassignChargeStationSiteWithResponse(ctx context.Context, siteId string, csId string, reqEditors ...RequestEditorFn) (*UnassignChargeStationSiteResponse, error)
+
+	// AssignChargeStationSite request
+	AssignChargeStationSiteWithResponse(ctx context.Context, siteId string, csId string, reqEditors ...RequestEditorFn) (*AssignChargeStationSiteResponse, error)
+
+	// GetFleetStats request
+	GetFleetStatsWithResponse(ctx context.Context, params *GetFleetStatsParams, reqEditors ...RequestEditorFn) (*GetFleetStatsResponse, error)
+
+	// ListTags request
+	ListTagsWithResponse(ctx context.Context, params *ListTagsParams, reqEditors ...RequestEditorFn) (*ListTagsResponse, error)
+
+	// DeleteTag request
+	DeleteTagWithResponse(ctx context.Context, tagName string, reqEditors ...RequestEditorFn) (*DeleteTagResponse, error)
+
+	// LookupTag request
+	LookupTagWithResponse(ctx context.Context, tagName string, reqEditors ...RequestEditorFn) (*LookupTagResponse, error)
+
+	// RegisterTag request with any body
+	RegisterTagWithBodyWithResponse(ctx context.Context, tagName string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterTagResponse, error)
+
+	RegisterTagWithResponse(ctx context.Context, tagName string, body RegisterTagJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterTagResponse, error)
+
+	// UntagChargeStation request
+	UntagChargeStationWithResponse(ctx context.Context, tagName string, csId string, reqEditors ...RequestEditorFn) (*UntagChargeStationResponse, error)
+
+	// TagChargeStation request
+	TagChargeStationWithResponse(ctx context.Context, tagName string, csId string, reqEditors ...RequestEditorFn) (*TagChargeStationResponse, error)
+
+	// RegisterTariff request with any body
+	RegisterTariffWithBodyWithResponse(ctx context.Context, tariffId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterTariffResponse, error)
+
+	RegisterTariffWithResponse(ctx context.Context, tariffId string, body RegisterTariffJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterTariffResponse, error)
+
+	// ListTokens request
+	ListTokensWithResponse(ctx context.Context, params *ListTokensParams, reqEditors ...RequestEditorFn) (*ListTokensResponse, error)
+
+	// SetToken request with any body
+	SetTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetTokenResponse, error)
+
+	SetTokenWithResponse(ctx context.Context, body SetTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*SetTokenResponse, error)
+
+	// RevokeToken request
+	RevokeTokenWithResponse(ctx context.Context, tokenUid string, params *RevokeTokenParams, reqEditors ...RequestEditorFn) (*RevokeTokenResponse, error)
+
+	// LookupToken request
+	LookupTokenWithResponse(ctx context.Context, tokenUid string, reqEditors ...RequestEditorFn) (*LookupTokenResponse, error)
+
+	// UpdateToken request with any body
+	UpdateTokenWithBodyWithResponse(ctx context.Context, tokenUid string, params *UpdateTokenParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTokenResponse, error)
+
+	UpdateTokenWithResponse(ctx context.Context, tokenUid string, params *UpdateTokenParams, body UpdateTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTokenResponse, error)
+
+	// ListTransactions request
+	ListTransactionsWithResponse(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error)
+
+	// ExportTransactions request
+	ExportTransactionsWithResponse(ctx context.Context, params *ExportTransactionsParams, reqEditors ...RequestEditorFn) (*ExportTransactionsResponse, error)
+
+	// GetTransactionReceipt request
+	GetTransactionReceiptWithResponse(ctx context.Context, csId string, transactionId string, params *GetTransactionReceiptParams, reqEditors ...RequestEditorFn) (*GetTransactionReceiptResponse, error)
+
+	// EmailTransactionReceipt request with any body
+	EmailTransactionReceiptWithBodyWithResponse(ctx context.Context, csId string, transactionId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EmailTransactionReceiptResponse, error)
+
+	EmailTransactionReceiptWithResponse(ctx context.Context, csId string, transactionId string, body EmailTransactionReceiptJSONRequestBody, reqEditors ...RequestEditorFn) (*EmailTransactionReceiptResponse, error)
+}
+
+type ExportChargeDetailRecordsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ChargeDetailRecordExport
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportChargeDetailRecordsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportChargeDetailRecordsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UploadCertificateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r UploadCertificateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UploadCertificateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteCertificateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteCertificateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteCertificateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupCertificateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Certificate
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupCertificateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupCertificateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListCommandAuditRecordsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]CommandAuditRecord
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCommandAuditRecordsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCommandAuditRecordsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateBulkCommandResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *BulkCommand
+	JSON400      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateBulkCommandResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateBulkCommandResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupBulkCommandResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BulkCommand
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupBulkCommandResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupBulkCommandResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PauseBulkCommandResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BulkCommand
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r PauseBulkCommandResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PauseBulkCommandResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResumeBulkCommandResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BulkCommand
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ResumeBulkCommandResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResumeBulkCommandResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListCommandSchedulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]CommandSchedule
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCommandSchedulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCommandSchedulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateCommandScheduleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CommandSchedule
+	JSON400      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateCommandScheduleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateCommandScheduleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteCommandScheduleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteCommandScheduleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteCommandScheduleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupCommandScheduleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CommandSchedule
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupCommandScheduleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupCommandScheduleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListChargeStationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ChargeStation
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListChargeStationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListChargeStationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListChargeStationsNearbyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]NearbyChargeStation
+	JSON400      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListChargeStationsNearbyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListChargeStationsNearbyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteChargeStationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteChargeStationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteChargeStationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupChargeStationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStation
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupChargeStationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupChargeStationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterChargeStationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterChargeStationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterChargeStationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateChargeStationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStation
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateChargeStationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateChargeStationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupChargeStationAuthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStationAuth
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupChargeStationAuthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupChargeStationAuthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChargeStationAvailabilityResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ChargeStationAvailability
+	JSON400      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChargeStationAvailabilityResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChargeStationAvailabilityResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type InstallChargeStationCertificatesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r InstallChargeStationCertificatesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r InstallChargeStationCertificatesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListInstalledChargeStationCertificatesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStationInstalledCertificates
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListInstalledChargeStationCertificatesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListInstalledChargeStationCertificatesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RefreshInstalledChargeStationCertificatesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r RefreshInstalledChargeStationCertificatesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RefreshInstalledChargeStationCertificatesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteInstalledChargeStationCertificateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteInstalledChargeStationCertificateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteInstalledChargeStationCertificateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListChargeStationEvsesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ChargeStationEvse
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListChargeStationEvsesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListChargeStationEvsesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateChargeStationConnectorResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStationConnector
+	JSON400      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateChargeStationConnectorResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateChargeStationConnectorResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupChargeStationLivenessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStationLiveness
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupChargeStationLivenessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupChargeStationLivenessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReconfigureChargeStationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ReconfigureChargeStationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReconfigureChargeStationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupChargeStationRegistrationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStationRegistration
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupChargeStationRegistrationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupChargeStationRegistrationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetChargeStationRegistrationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r SetChargeStationRegistrationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetChargeStationRegistrationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Status
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TriggerChargeStationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r TriggerChargeStationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TriggerChargeStationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDashboardSummaryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DashboardSummary
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDashboardSummaryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDashboardSummaryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterLocationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterLocationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterLocationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListOcppActionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]OcppAction
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListOcppActionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListOcppActionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateOcppActionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OcppAction
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateOcppActionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateOcppActionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterPartyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterPartyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterPartyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Reservation
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TransferReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Status
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r TransferReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TransferReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSitesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Site
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSitesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSitesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSiteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSiteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSiteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupSiteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Site
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupSiteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupSiteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterSiteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterSiteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterSiteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnassignChargeStationSiteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStation
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r UnassignChargeStationSiteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnassignChargeStationSiteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AssignChargeStationSiteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStation
+	JSON400      *Status
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r AssignChargeStationSiteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AssignChargeStationSiteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFleetStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FleetStats
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFleetStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFleetStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Tag
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTagsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTagsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTagResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTagResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTagResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupTagResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Tag
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupTagResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupTagResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterTagResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterTagResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterTagResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UntagChargeStationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStation
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r UntagChargeStationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UntagChargeStationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TagChargeStationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChargeStation
+	JSON400      *Status
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r TagChargeStationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TagChargeStationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterTariffResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterTariffResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterTariffResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Token
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r SetTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Status
+	JSON412      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Token
+	JSON404      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Token
+	JSON400      *Status
+	JSON404      *Status
+	JSON412      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Transaction
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]TransactionExport
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionReceiptResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionReceipt
+	JSON404      *Status
+	JSON409      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionReceiptResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionReceiptResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EmailTransactionReceiptResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Status
+	JSON404      *Status
+	JSON409      *Status
+	JSON501      *Status
+	JSONDefault  *Status
+}
+
+// Status returns HTTPResponse.Status
+func (r EmailTransactionReceiptResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EmailTransactionReceiptResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ExportChargeDetailRecordsWithResponse request returning *ExportChargeDetailRecordsResponse
+func (c *ClientWithResponses) ExportChargeDetailRecordsWithResponse(ctx context.Context, params *ExportChargeDetailRecordsParams, reqEditors ...RequestEditorFn) (*ExportChargeDetailRecordsResponse, error) {
+	rsp, err := c.ExportChargeDetailRecords(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportChargeDetailRecordsResponse(rsp)
+}
+
+// UploadCertificateWithBodyWithResponse request with arbitrary body returning *UploadCertificateResponse
+func (c *ClientWithResponses) UploadCertificateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadCertificateResponse, error) {
+	rsp, err := c.UploadCertificateWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadCertificateResponse(rsp)
+}
+
+func (c *ClientWithResponses) UploadCertificateWithResponse(ctx context.Context, body UploadCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*UploadCertificateResponse, error) {
+	rsp, err := c.UploadCertificate(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadCertificateResponse(rsp)
+}
+
+// DeleteCertificateWithResponse request returning *DeleteCertificateResponse
+func (c *ClientWithResponses) DeleteCertificateWithResponse(ctx context.Context, certificateHash string, reqEditors ...RequestEditorFn) (*DeleteCertificateResponse, error) {
+	rsp, err := c.DeleteCertificate(ctx, certificateHash, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteCertificateResponse(rsp)
+}
+
+// LookupCertificateWithResponse request returning *LookupCertificateResponse
+func (c *ClientWithResponses) LookupCertificateWithResponse(ctx context.Context, certificateHash string, reqEditors ...RequestEditorFn) (*LookupCertificateResponse, error) {
+	rsp, err := c.LookupCertificate(ctx, certificateHash, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupCertificateResponse(rsp)
+}
+
+// ListCommandAuditRecordsWithResponse request returning *ListCommandAuditRecordsResponse
+func (c *ClientWithResponses) ListCommandAuditRecordsWithResponse(ctx context.Context, params *ListCommandAuditRecordsParams, reqEditors ...RequestEditorFn) (*ListCommandAuditRecordsResponse, error) {
+	rsp, err := c.ListCommandAuditRecords(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCommandAuditRecordsResponse(rsp)
+}
+
+// CreateBulkCommandWithBodyWithResponse request with arbitrary body returning *CreateBulkCommandResponse
+func (c *ClientWithResponses) CreateBulkCommandWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBulkCommandResponse, error) {
+	rsp, err := c.CreateBulkCommandWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBulkCommandResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateBulkCommandWithResponse(ctx context.Context, body CreateBulkCommandJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBulkCommandResponse, error) {
+	rsp, err := c.CreateBulkCommand(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBulkCommandResponse(rsp)
+}
+
+// LookupBulkCommandWithResponse request returning *LookupBulkCommandResponse
+func (c *ClientWithResponses) LookupBulkCommandWithResponse(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*LookupBulkCommandResponse, error) {
+	rsp, err := c.LookupBulkCommand(ctx, bulkCommandId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupBulkCommandResponse(rsp)
+}
+
+// PauseBulkCommandWithResponse request returning *PauseBulkCommandResponse
+func (c *ClientWithResponses) PauseBulkCommandWithResponse(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*PauseBulkCommandResponse, error) {
+	rsp, err := c.PauseBulkCommand(ctx, bulkCommandId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePauseBulkCommandResponse(rsp)
+}
+
+// ResumeBulkCommandWithResponse request returning *ResumeBulkCommandResponse
+func (c *ClientWithResponses) ResumeBulkCommandWithResponse(ctx context.Context, bulkCommandId string, reqEditors ...RequestEditorFn) (*ResumeBulkCommandResponse, error) {
+	rsp, err := c.ResumeBulkCommand(ctx, bulkCommandId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResumeBulkCommandResponse(rsp)
+}
+
+// ListCommandSchedulesWithResponse request returning *ListCommandSchedulesResponse
+func (c *ClientWithResponses) ListCommandSchedulesWithResponse(ctx context.Context, params *ListCommandSchedulesParams, reqEditors ...RequestEditorFn) (*ListCommandSchedulesResponse, error) {
+	rsp, err := c.ListCommandSchedules(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCommandSchedulesResponse(rsp)
+}
+
+// CreateCommandScheduleWithBodyWithResponse request with arbitrary body returning *CreateCommandScheduleResponse
+func (c *ClientWithResponses) CreateCommandScheduleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCommandScheduleResponse, error) {
+	rsp, err := c.CreateCommandScheduleWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCommandScheduleResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateCommandScheduleWithResponse(ctx context.Context, body CreateCommandScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCommandScheduleResponse, error) {
+	rsp, err := c.CreateCommandSchedule(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCommandScheduleResponse(rsp)
+}
+
+// DeleteCommandScheduleWithResponse request returning *DeleteCommandScheduleResponse
+func (c *ClientWithResponses) DeleteCommandScheduleWithResponse(ctx context.Context, scheduleId string, reqEditors ...RequestEditorFn) (*DeleteCommandScheduleResponse, error) {
+	rsp, err := c.DeleteCommandSchedule(ctx, scheduleId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteCommandScheduleResponse(rsp)
+}
+
+// LookupCommandScheduleWithResponse request returning *LookupCommandScheduleResponse
+func (c *ClientWithResponses) LookupCommandScheduleWithResponse(ctx context.Context, scheduleId string, reqEditors ...RequestEditorFn) (*LookupCommandScheduleResponse, error) {
+	rsp, err := c.LookupCommandSchedule(ctx, scheduleId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupCommandScheduleResponse(rsp)
+}
+
+// ListChargeStationsWithResponse request returning *ListChargeStationsResponse
+func (c *ClientWithResponses) ListChargeStationsWithResponse(ctx context.Context, params *ListChargeStationsParams, reqEditors ...RequestEditorFn) (*ListChargeStationsResponse, error) {
+	rsp, err := c.ListChargeStations(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListChargeStationsResponse(rsp)
+}
+
+// ListChargeStationsNearbyWithResponse request returning *ListChargeStationsNearbyResponse
+func (c *ClientWithResponses) ListChargeStationsNearbyWithResponse(ctx context.Context, params *ListChargeStationsNearbyParams, reqEditors ...RequestEditorFn) (*ListChargeStationsNearbyResponse, error) {
+	rsp, err := c.ListChargeStationsNearby(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListChargeStationsNearbyResponse(rsp)
+}
+
+// DeleteChargeStationWithResponse request returning *DeleteChargeStationResponse
+func (c *ClientWithResponses) DeleteChargeStationWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*DeleteChargeStationResponse, error) {
+	rsp, err := c.DeleteChargeStation(ctx, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteChargeStationResponse(rsp)
+}
+
+// LookupChargeStationWithResponse request returning *LookupChargeStationResponse
+func (c *ClientWithResponses) LookupChargeStationWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*LookupChargeStationResponse, error) {
+	rsp, err := c.LookupChargeStation(ctx, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupChargeStationResponse(rsp)
+}
+
+// RegisterChargeStationWithBodyWithResponse request with arbitrary body returning *RegisterChargeStationResponse
+func (c *ClientWithResponses) RegisterChargeStationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterChargeStationResponse, error) {
+	rsp, err := c.RegisterChargeStationWithBody(ctx, csId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterChargeStationResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterChargeStationWithResponse(ctx context.Context, csId string, body RegisterChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterChargeStationResponse, error) {
+	rsp, err := c.RegisterChargeStation(ctx, csId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterChargeStationResponse(rsp)
+}
+
+// UpdateChargeStationWithBodyWithResponse request with arbitrary body returning *UpdateChargeStationResponse
+func (c *ClientWithResponses) UpdateChargeStationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateChargeStationResponse, error) {
+	rsp, err := c.UpdateChargeStationWithBody(ctx, csId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateChargeStationResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateChargeStationWithResponse(ctx context.Context, csId string, body UpdateChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateChargeStationResponse, error) {
+	rsp, err := c.UpdateChargeStation(ctx, csId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateChargeStationResponse(rsp)
+}
+
+// LookupChargeStationAuthWithResponse request returning *LookupChargeStationAuthResponse
+func (c *ClientWithResponses) LookupChargeStationAuthWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*LookupChargeStationAuthResponse, error) {
+	rsp, err := c.LookupChargeStationAuth(ctx, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupChargeStationAuthResponse(rsp)
+}
+
+// GetChargeStationAvailabilityWithResponse request returning *GetChargeStationAvailabilityResponse
+func (c *ClientWithResponses) GetChargeStationAvailabilityWithResponse(ctx context.Context, csId string, params *GetChargeStationAvailabilityParams, reqEditors ...RequestEditorFn) (*GetChargeStationAvailabilityResponse, error) {
+	rsp, err := c.GetChargeStationAvailability(ctx, csId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChargeStationAvailabilityResponse(rsp)
+}
+
+// InstallChargeStationCertificatesWithBodyWithResponse request with arbitrary body returning *InstallChargeStationCertificatesResponse
+func (c *ClientWithResponses) InstallChargeStationCertificatesWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*InstallChargeStationCertificatesResponse, error) {
+	rsp, err := c.InstallChargeStationCertificatesWithBody(ctx, csId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInstallChargeStationCertificatesResponse(rsp)
+}
+
+func (c *ClientWithResponses) InstallChargeStationCertificatesWithResponse(ctx context.Context, csId string, body InstallChargeStationCertificatesJSONRequestBody, reqEditors ...RequestEditorFn) (*InstallChargeStationCertificatesResponse, error) {
+	rsp, err := c.InstallChargeStationCertificates(ctx, csId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInstallChargeStationCertificatesResponse(rsp)
+}
+
+// ListInstalledChargeStationCertificatesWithResponse request returning *ListInstalledChargeStationCertificatesResponse
+func (c *ClientWithResponses) ListInstalledChargeStationCertificatesWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*ListInstalledChargeStationCertificatesResponse, error) {
+	rsp, err := c.ListInstalledChargeStationCertificates(ctx, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListInstalledChargeStationCertificatesResponse(rsp)
+}
+
+// RefreshInstalledChargeStationCertificatesWithResponse request returning *RefreshInstalledChargeStationCertificatesResponse
+func (c *ClientWithResponses) RefreshInstalledChargeStationCertificatesWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*RefreshInstalledChargeStationCertificatesResponse, error) {
+	rsp, err := c.RefreshInstalledChargeStationCertificates(ctx, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRefreshInstalledChargeStationCertificatesResponse(rsp)
+}
+
+// DeleteInstalledChargeStationCertificateWithResponse request returning *DeleteInstalledChargeStationCertificateResponse
+func (c *ClientWithResponses) DeleteInstalledChargeStationCertificateWithResponse(ctx context.Context, csId string, serialNumber string, reqEditors ...RequestEditorFn) (*DeleteInstalledChargeStationCertificateResponse, error) {
+	rsp, err := c.DeleteInstalledChargeStationCertificate(ctx, csId, serialNumber, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteInstalledChargeStationCertificateResponse(rsp)
+}
+
+// ListChargeStationEvsesWithResponse request returning *ListChargeStationEvsesResponse
+func (c *ClientWithResponses) ListChargeStationEvsesWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*ListChargeStationEvsesResponse, error) {
+	rsp, err := c.ListChargeStationEvses(ctx, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListChargeStationEvsesResponse(rsp)
+}
+
+// UpdateChargeStationConnectorWithBodyWithResponse request with arbitrary body returning *UpdateChargeStationConnectorResponse
+func (c *ClientWithResponses) UpdateChargeStationConnectorWithBodyWithResponse(ctx context.Context, csId string, evseId int, connectorId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateChargeStationConnectorResponse, error) {
+	rsp, err := c.UpdateChargeStationConnectorWithBody(ctx, csId, evseId, connectorId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateChargeStationConnectorResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateChargeStationConnectorWithResponse(ctx context.Context, csId string, evseId int, connectorId int, body UpdateChargeStationConnectorJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateChargeStationConnectorResponse, error) {
+	rsp, err := c.UpdateChargeStationConnector(ctx, csId, evseId, connectorId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateChargeStationConnectorResponse(rsp)
+}
+
+// LookupChargeStationLivenessWithResponse request returning *LookupChargeStationLivenessResponse
+func (c *ClientWithResponses) LookupChargeStationLivenessWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*LookupChargeStationLivenessResponse, error) {
+	rsp, err := c.LookupChargeStationLiveness(ctx, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupChargeStationLivenessResponse(rsp)
+}
+
+// ReconfigureChargeStationWithBodyWithResponse request with arbitrary body returning *ReconfigureChargeStationResponse
+func (c *ClientWithResponses) ReconfigureChargeStationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReconfigureChargeStationResponse, error) {
+	rsp, err := c.ReconfigureChargeStationWithBody(ctx, csId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReconfigureChargeStationResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReconfigureChargeStationWithResponse(ctx context.Context, csId string, body ReconfigureChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*ReconfigureChargeStationResponse, error) {
+	rsp, err := c.ReconfigureChargeStation(ctx, csId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReconfigureChargeStationResponse(rsp)
+}
+
+// LookupChargeStationRegistrationWithResponse request returning *LookupChargeStationRegistrationResponse
+func (c *ClientWithResponses) LookupChargeStationRegistrationWithResponse(ctx context.Context, csId string, reqEditors ...RequestEditorFn) (*LookupChargeStationRegistrationResponse, error) {
+	rsp, err := c.LookupChargeStationRegistration(ctx, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupChargeStationRegistrationResponse(rsp)
+}
+
+// SetChargeStationRegistrationWithBodyWithResponse request with arbitrary body returning *SetChargeStationRegistrationResponse
+func (c *ClientWithResponses) SetChargeStationRegistrationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetChargeStationRegistrationResponse, error) {
+	rsp, err := c.SetChargeStationRegistrationWithBody(ctx, csId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetChargeStationRegistrationResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetChargeStationRegistrationWithResponse(ctx context.Context, csId string, body SetChargeStationRegistrationJSONRequestBody, reqEditors ...RequestEditorFn) (*SetChargeStationRegistrationResponse, error) {
+	rsp, err := c.SetChargeStationRegistration(ctx, csId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetChargeStationRegistrationResponse(rsp)
+}
+
+// CreateReservationWithBodyWithResponse request with arbitrary body returning *CreateReservationResponse
+func (c *ClientWithResponses) CreateReservationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateReservationResponse, error) {
+	rsp, err := c.CreateReservationWithBody(ctx, csId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateReservationResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateReservationWithResponse(ctx context.Context, csId string, body CreateReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateReservationResponse, error) {
+	rsp, err := c.CreateReservation(ctx, csId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateReservationResponse(rsp)
+}
+
+// TriggerChargeStationWithBodyWithResponse request with arbitrary body returning *TriggerChargeStationResponse
+func (c *ClientWithResponses) TriggerChargeStationWithBodyWithResponse(ctx context.Context, csId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TriggerChargeStationResponse, error) {
+	rsp, err := c.TriggerChargeStationWithBody(ctx, csId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTriggerChargeStationResponse(rsp)
+}
+
+func (c *ClientWithResponses) TriggerChargeStationWithResponse(ctx context.Context, csId string, body TriggerChargeStationJSONRequestBody, reqEditors ...RequestEditorFn) (*TriggerChargeStationResponse, error) {
+	rsp, err := c.TriggerChargeStation(ctx, csId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTriggerChargeStationResponse(rsp)
+}
+
+// GetDashboardSummaryWithResponse request returning *GetDashboardSummaryResponse
+func (c *ClientWithResponses) GetDashboardSummaryWithResponse(ctx context.Context, params *GetDashboardSummaryParams, reqEditors ...RequestEditorFn) (*GetDashboardSummaryResponse, error) {
+	rsp, err := c.GetDashboardSummary(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDashboardSummaryResponse(rsp)
+}
+
+// StreamEventsWithResponse request returning *StreamEventsResponse
+func (c *ClientWithResponses) StreamEventsWithResponse(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*StreamEventsResponse, error) {
+	rsp, err := c.StreamEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamEventsResponse(rsp)
+}
+
+// RegisterLocationWithBodyWithResponse request with arbitrary body returning *RegisterLocationResponse
+func (c *ClientWithResponses) RegisterLocationWithBodyWithResponse(ctx context.Context, locationId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterLocationResponse, error) {
+	rsp, err := c.RegisterLocationWithBody(ctx, locationId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterLocationResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterLocationWithResponse(ctx context.Context, locationId string, body RegisterLocationJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterLocationResponse, error) {
+	rsp, err := c.RegisterLocation(ctx, locationId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterLocationResponse(rsp)
+}
+
+// ListOcppActionsWithResponse request returning *ListOcppActionsResponse
+func (c *ClientWithResponses) ListOcppActionsWithResponse(ctx context.Context, ocppVersion ListOcppActionsParamsOcppVersion, reqEditors ...RequestEditorFn) (*ListOcppActionsResponse, error) {
+	rsp, err := c.ListOcppActions(ctx, ocppVersion, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListOcppActionsResponse(rsp)
+}
+
+// UpdateOcppActionWithBodyWithResponse request with arbitrary body returning *UpdateOcppActionResponse
+func (c *ClientWithResponses) UpdateOcppActionWithBodyWithResponse(ctx context.Context, ocppVersion UpdateOcppActionParamsOcppVersion, action string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateOcppActionResponse, error) {
+	rsp, err := c.UpdateOcppActionWithBody(ctx, ocppVersion, action, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateOcppActionResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateOcppActionWithResponse(ctx context.Context, ocppVersion UpdateOcppActionParamsOcppVersion, action string, body UpdateOcppActionJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateOcppActionResponse, error) {
+	rsp, err := c.UpdateOcppAction(ctx, ocppVersion, action, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateOcppActionResponse(rsp)
+}
+
+// RegisterPartyWithBodyWithResponse request with arbitrary body returning *RegisterPartyResponse
+func (c *ClientWithResponses) RegisterPartyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterPartyResponse, error) {
+	rsp, err := c.RegisterPartyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterPartyResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterPartyWithResponse(ctx context.Context, body RegisterPartyJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterPartyResponse, error) {
+	rsp, err := c.RegisterParty(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterPartyResponse(rsp)
+}
+
+// CancelReservationWithResponse request returning *CancelReservationResponse
+func (c *ClientWithResponses) CancelReservationWithResponse(ctx context.Context, reservationId int, reqEditors ...RequestEditorFn) (*CancelReservationResponse, error) {
+	rsp, err := c.CancelReservation(ctx, reservationId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelReservationResponse(rsp)
+}
+
+// LookupReservationWithResponse request returning *LookupReservationResponse
+func (c *ClientWithResponses) LookupReservationWithResponse(ctx context.Context, reservationId int, reqEditors ...RequestEditorFn) (*LookupReservationResponse, error) {
+	rsp, err := c.LookupReservation(ctx, reservationId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupReservationResponse(rsp)
+}
+
+// TransferReservationWithBodyWithResponse request with arbitrary body returning *TransferReservationResponse
+func (c *ClientWithResponses) TransferReservationWithBodyWithResponse(ctx context.Context, reservationId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransferReservationResponse, error) {
+	rsp, err := c.TransferReservationWithBody(ctx, reservationId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransferReservationResponse(rsp)
+}
+
+func (c *ClientWithResponses) TransferReservationWithResponse(ctx context.Context, reservationId int, body TransferReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*TransferReservationResponse, error) {
+	rsp, err := c.TransferReservation(ctx, reservationId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransferReservationResponse(rsp)
+}
+
+// ListSitesWithResponse request returning *ListSitesResponse
+func (c *ClientWithResponses) ListSitesWithResponse(ctx context.Context, params *ListSitesParams, reqEditors ...RequestEditorFn) (*ListSitesResponse, error) {
+	rsp, err := c.ListSites(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSitesResponse(rsp)
+}
+
+// DeleteSiteWithResponse request returning *DeleteSiteResponse
+func (c *ClientWithResponses) DeleteSiteWithResponse(ctx context.Context, siteId string, reqEditors ...RequestEditorFn) (*DeleteSiteResponse, error) {
+	rsp, err := c.DeleteSite(ctx, siteId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSiteResponse(rsp)
+}
+
+// LookupSiteWithResponse request returning *LookupSiteResponse
+func (c *ClientWithResponses) LookupSiteWithResponse(ctx context.Context, siteId string, reqEditors ...RequestEditorFn) (*LookupSiteResponse, error) {
+	rsp, err := c.LookupSite(ctx, siteId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupSiteResponse(rsp)
+}
+
+// RegisterSiteWithBodyWithResponse request with arbitrary body returning *RegisterSiteResponse
+func (c *ClientWithResponses) RegisterSiteWithBodyWithResponse(ctx context.Context, siteId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterSiteResponse, error) {
+	rsp, err := c.RegisterSiteWithBody(ctx, siteId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterSiteResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterSiteWithResponse(ctx context.Context, siteId string, body RegisterSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterSiteResponse, error) {
+	rsp, err := c.RegisterSite(ctx, siteId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterSiteResponse(rsp)
+}
+
+// UnassignChargeStationSiteWithResponse request returning *UnassignChargeStationSiteResponse
+func (c *ClientWithResponses) UnassignChargeStationSiteWithResponse(ctx context.Context, siteId string, csId string, reqEditors ...RequestEditorFn) (*UnassignChargeStationSiteResponse, error) {
+	rsp, err := c.UnassignChargeStationSite(ctx, siteId, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnassignChargeStationSiteResponse(rsp)
+}
+
+// AssignChargeStationSiteWithResponse request returning *AssignChargeStationSiteResponse
+func (c *ClientWithResponses) AssignChargeStationSiteWithResponse(ctx context.Context, siteId string, csId string, reqEditors ...RequestEditorFn) (*AssignChargeStationSiteResponse, error) {
+	rsp, err := c.AssignChargeStationSite(ctx, siteId, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAssignChargeStationSiteResponse(rsp)
+}
+
+// GetFleetStatsWithResponse request returning *GetFleetStatsResponse
+func (c *ClientWithResponses) GetFleetStatsWithResponse(ctx context.Context, params *GetFleetStatsParams, reqEditors ...RequestEditorFn) (*GetFleetStatsResponse, error) {
+	rsp, err := c.GetFleetStats(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFleetStatsResponse(rsp)
+}
+
+// ListTagsWithResponse request returning *ListTagsResponse
+func (c *ClientWithResponses) ListTagsWithResponse(ctx context.Context, params *ListTagsParams, reqEditors ...RequestEditorFn) (*ListTagsResponse, error) {
+	rsp, err := c.ListTags(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTagsResponse(rsp)
+}
+
+// DeleteTagWithResponse request returning *DeleteTagResponse
+func (c *ClientWithResponses) DeleteTagWithResponse(ctx context.Context, tagName string, reqEditors ...RequestEditorFn) (*DeleteTagResponse, error) {
+	rsp, err := c.DeleteTag(ctx, tagName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTagResponse(rsp)
+}
+
+// LookupTagWithResponse request returning *LookupTagResponse
+func (c *ClientWithResponses) LookupTagWithResponse(ctx context.Context, tagName string, reqEditors ...RequestEditorFn) (*LookupTagResponse, error) {
+	rsp, err := c.LookupTag(ctx, tagName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupTagResponse(rsp)
+}
+
+// RegisterTagWithBodyWithResponse request with arbitrary body returning *RegisterTagResponse
+func (c *ClientWithResponses) RegisterTagWithBodyWithResponse(ctx context.Context, tagName string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterTagResponse, error) {
+	rsp, err := c.RegisterTagWithBody(ctx, tagName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterTagResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterTagWithResponse(ctx context.Context, tagName string, body RegisterTagJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterTagResponse, error) {
+	rsp, err := c.RegisterTag(ctx, tagName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterTagResponse(rsp)
+}
+
+// UntagChargeStationWithResponse request returning *UntagChargeStationResponse
+func (c *ClientWithResponses) UntagChargeStationWithResponse(ctx context.Context, tagName string, csId string, reqEditors ...RequestEditorFn) (*UntagChargeStationResponse, error) {
+	rsp, err := c.UntagChargeStation(ctx, tagName, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUntagChargeStationResponse(rsp)
+}
+
+// TagChargeStationWithResponse request returning *TagChargeStationResponse
+func (c *ClientWithResponses) TagChargeStationWithResponse(ctx context.Context, tagName string, csId string, reqEditors ...RequestEditorFn) (*TagChargeStationResponse, error) {
+	rsp, err := c.TagChargeStation(ctx, tagName, csId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTagChargeStationResponse(rsp)
+}
+
+// RegisterTariffWithBodyWithResponse request with arbitrary body returning *RegisterTariffResponse
+func (c *ClientWithResponses) RegisterTariffWithBodyWithResponse(ctx context.Context, tariffId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterTariffResponse, error) {
+	rsp, err := c.RegisterTariffWithBody(ctx, tariffId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterTariffResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterTariffWithResponse(ctx context.Context, tariffId string, body RegisterTariffJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterTariffResponse, error) {
+	rsp, err := c.RegisterTariff(ctx, tariffId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterTariffResponse(rsp)
+}
+
+// ListTokensWithResponse request returning *ListTokensResponse
+func (c *ClientWithResponses) ListTokensWithResponse(ctx context.Context, params *ListTokensParams, reqEditors ...RequestEditorFn) (*ListTokensResponse, error) {
+	rsp, err := c.ListTokens(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTokensResponse(rsp)
+}
+
+// SetTokenWithBodyWithResponse request with arbitrary body returning *SetTokenResponse
+func (c *ClientWithResponses) SetTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetTokenResponse, error) {
+	rsp, err := c.SetTokenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetTokenWithResponse(ctx context.Context, body SetTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*SetTokenResponse, error) {
+	rsp, err := c.SetToken(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetTokenResponse(rsp)
+}
+
+// RevokeTokenWithResponse request returning *RevokeTokenResponse
+func (c *ClientWithResponses) RevokeTokenWithResponse(ctx context.Context, tokenUid string, params *RevokeTokenParams, reqEditors ...RequestEditorFn) (*RevokeTokenResponse, error) {
+	rsp, err := c.RevokeToken(ctx, tokenUid, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeTokenResponse(rsp)
+}
+
+// LookupTokenWithResponse request returning *LookupTokenResponse
+func (c *ClientWithResponses) LookupTokenWithResponse(ctx context.Context, tokenUid string, reqEditors ...RequestEditorFn) (*LookupTokenResponse, error) {
+	rsp, err := c.LookupToken(ctx, tokenUid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupTokenResponse(rsp)
+}
+
+// UpdateTokenWithBodyWithResponse request with arbitrary body returning *UpdateTokenResponse
+func (c *ClientWithResponses) UpdateTokenWithBodyWithResponse(ctx context.Context, tokenUid string, params *UpdateTokenParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTokenResponse, error) {
+	rsp, err := c.UpdateTokenWithBody(ctx, tokenUid, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateTokenWithResponse(ctx context.Context, tokenUid string, params *UpdateTokenParams, body UpdateTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTokenResponse, error) {
+	rsp, err := c.UpdateToken(ctx, tokenUid, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTokenResponse(rsp)
+}
+
+// ListTransactionsWithResponse request returning *ListTransactionsResponse
+func (c *ClientWithResponses) ListTransactionsWithResponse(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error) {
+	rsp, err := c.ListTransactions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTransactionsResponse(rsp)
+}
+
+// ExportTransactionsWithResponse request returning *ExportTransactionsResponse
+func (c *ClientWithResponses) ExportTransactionsWithResponse(ctx context.Context, params *ExportTransactionsParams, reqEditors ...RequestEditorFn) (*ExportTransactionsResponse, error) {
+	rsp, err := c.ExportTransactions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportTransactionsResponse(rsp)
+}
+
+// GetTransactionReceiptWithResponse request returning *GetTransactionReceiptResponse
+func (c *ClientWithResponses) GetTransactionReceiptWithResponse(ctx context.Context, csId string, transactionId string, params *GetTransactionReceiptParams, reqEditors ...RequestEditorFn) (*GetTransactionReceiptResponse, error) {
+	rsp, err := c.GetTransactionReceipt(ctx, csId, transactionId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionReceiptResponse(rsp)
+}
+
+// EmailTransactionReceiptWithBodyWithResponse request with arbitrary body returning *EmailTransactionReceiptResponse
+func (c *ClientWithResponses) EmailTransactionReceiptWithBodyWithResponse(ctx context.Context, csId string, transactionId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EmailTransactionReceiptResponse, error) {
+	rsp, err := c.EmailTransactionReceiptWithBody(ctx, csId, transactionId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEmailTransactionReceiptResponse(rsp)
+}
+
+func (c *ClientWithResponses) EmailTransactionReceiptWithResponse(ctx context.Context, csId string, transactionId string, body EmailTransactionReceiptJSONRequestBody, reqEditors ...RequestEditorFn) (*EmailTransactionReceiptResponse, error) {
+	rsp, err := c.EmailTransactionReceipt(ctx, csId, transactionId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEmailTransactionReceiptResponse(rsp)
+}
+
+// ParseExportChargeDetailRecordsResponse parses an HTTP response from a ExportChargeDetailRecordsWithResponse call
+func ParseExportChargeDetailRecordsResponse(rsp *http.Response) (*ExportChargeDetailRecordsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportChargeDetailRecordsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ChargeDetailRecordExport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/csv) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseUploadCertificateResponse parses an HTTP response from a UploadCertificateWithResponse call
+func ParseUploadCertificateResponse(rsp *http.Response) (*UploadCertificateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UploadCertificateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteCertificateResponse parses an HTTP response from a DeleteCertificateWithResponse call
+func ParseDeleteCertificateResponse(rsp *http.Response) (*DeleteCertificateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteCertificateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupCertificateResponse parses an HTTP response from a LookupCertificateWithResponse call
+func ParseLookupCertificateResponse(rsp *http.Response) (*LookupCertificateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupCertificateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Certificate
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCommandAuditRecordsResponse parses an HTTP response from a ListCommandAuditRecordsWithResponse call
+func ParseListCommandAuditRecordsResponse(rsp *http.Response) (*ListCommandAuditRecordsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCommandAuditRecordsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []CommandAuditRecord
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateBulkCommandResponse parses an HTTP response from a CreateBulkCommandWithResponse call
+func ParseCreateBulkCommandResponse(rsp *http.Response) (*CreateBulkCommandResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateBulkCommandResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest BulkCommand
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupBulkCommandResponse parses an HTTP response from a LookupBulkCommandWithResponse call
+func ParseLookupBulkCommandResponse(rsp *http.Response) (*LookupBulkCommandResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupBulkCommandResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BulkCommand
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePauseBulkCommandResponse parses an HTTP response from a PauseBulkCommandWithResponse call
+func ParsePauseBulkCommandResponse(rsp *http.Response) (*PauseBulkCommandResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PauseBulkCommandResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BulkCommand
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseResumeBulkCommandResponse parses an HTTP response from a ResumeBulkCommandWithResponse call
+func ParseResumeBulkCommandResponse(rsp *http.Response) (*ResumeBulkCommandResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResumeBulkCommandResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BulkCommand
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCommandSchedulesResponse parses an HTTP response from a ListCommandSchedulesWithResponse call
+func ParseListCommandSchedulesResponse(rsp *http.Response) (*ListCommandSchedulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCommandSchedulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []CommandSchedule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateCommandScheduleResponse parses an HTTP response from a CreateCommandScheduleWithResponse call
+func ParseCreateCommandScheduleResponse(rsp *http.Response) (*CreateCommandScheduleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateCommandScheduleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CommandSchedule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteCommandScheduleResponse parses an HTTP response from a DeleteCommandScheduleWithResponse call
+func ParseDeleteCommandScheduleResponse(rsp *http.Response) (*DeleteCommandScheduleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteCommandScheduleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupCommandScheduleResponse parses an HTTP response from a LookupCommandScheduleWithResponse call
+func ParseLookupCommandScheduleResponse(rsp *http.Response) (*LookupCommandScheduleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupCommandScheduleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CommandSchedule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListChargeStationsResponse parses an HTTP response from a ListChargeStationsWithResponse call
+func ParseListChargeStationsResponse(rsp *http.Response) (*ListChargeStationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListChargeStationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ChargeStation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListChargeStationsNearbyResponse parses an HTTP response from a ListChargeStationsNearbyWithResponse call
+func ParseListChargeStationsNearbyResponse(rsp *http.Response) (*ListChargeStationsNearbyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListChargeStationsNearbyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []NearbyChargeStation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteChargeStationResponse parses an HTTP response from a DeleteChargeStationWithResponse call
+func ParseDeleteChargeStationResponse(rsp *http.Response) (*DeleteChargeStationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteChargeStationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupChargeStationResponse parses an HTTP response from a LookupChargeStationWithResponse call
+func ParseLookupChargeStationResponse(rsp *http.Response) (*LookupChargeStationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupChargeStationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterChargeStationResponse parses an HTTP response from a RegisterChargeStationWithResponse call
+func ParseRegisterChargeStationResponse(rsp *http.Response) (*RegisterChargeStationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterChargeStationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateChargeStationResponse parses an HTTP response from a UpdateChargeStationWithResponse call
+func ParseUpdateChargeStationResponse(rsp *http.Response) (*UpdateChargeStationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateChargeStationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupChargeStationAuthResponse parses an HTTP response from a LookupChargeStationAuthWithResponse call
+func ParseLookupChargeStationAuthResponse(rsp *http.Response) (*LookupChargeStationAuthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupChargeStationAuthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStationAuth
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChargeStationAvailabilityResponse parses an HTTP response from a GetChargeStationAvailabilityWithResponse call
+func ParseGetChargeStationAvailabilityResponse(rsp *http.Response) (*GetChargeStationAvailabilityResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChargeStationAvailabilityResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ChargeStationAvailability
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseInstallChargeStationCertificatesResponse parses an HTTP response from a InstallChargeStationCertificatesWithResponse call
+func ParseInstallChargeStationCertificatesResponse(rsp *http.Response) (*InstallChargeStationCertificatesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &InstallChargeStationCertificatesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListInstalledChargeStationCertificatesResponse parses an HTTP response from a ListInstalledChargeStationCertificatesWithResponse call
+func ParseListInstalledChargeStationCertificatesResponse(rsp *http.Response) (*ListInstalledChargeStationCertificatesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListInstalledChargeStationCertificatesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStationInstalledCertificates
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRefreshInstalledChargeStationCertificatesResponse parses an HTTP response from a RefreshInstalledChargeStationCertificatesWithResponse call
+func ParseRefreshInstalledChargeStationCertificatesResponse(rsp *http.Response) (*RefreshInstalledChargeStationCertificatesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RefreshInstalledChargeStationCertificatesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteInstalledChargeStationCertificateResponse parses an HTTP response from a DeleteInstalledChargeStationCertificateWithResponse call
+func ParseDeleteInstalledChargeStationCertificateResponse(rsp *http.Response) (*DeleteInstalledChargeStationCertificateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteInstalledChargeStationCertificateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListChargeStationEvsesResponse parses an HTTP response from a ListChargeStationEvsesWithResponse call
+func ParseListChargeStationEvsesResponse(rsp *http.Response) (*ListChargeStationEvsesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListChargeStationEvsesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ChargeStationEvse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateChargeStationConnectorResponse parses an HTTP response from a UpdateChargeStationConnectorWithResponse call
+func ParseUpdateChargeStationConnectorResponse(rsp *http.Response) (*UpdateChargeStationConnectorResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateChargeStationConnectorResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStationConnector
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupChargeStationLivenessResponse parses an HTTP response from a LookupChargeStationLivenessWithResponse call
+func ParseLookupChargeStationLivenessResponse(rsp *http.Response) (*LookupChargeStationLivenessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupChargeStationLivenessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStationLiveness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReconfigureChargeStationResponse parses an HTTP response from a ReconfigureChargeStationWithResponse call
+func ParseReconfigureChargeStationResponse(rsp *http.Response) (*ReconfigureChargeStationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReconfigureChargeStationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupChargeStationRegistrationResponse parses an HTTP response from a LookupChargeStationRegistrationWithResponse call
+func ParseLookupChargeStationRegistrationResponse(rsp *http.Response) (*LookupChargeStationRegistrationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupChargeStationRegistrationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStationRegistration
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetChargeStationRegistrationResponse parses an HTTP response from a SetChargeStationRegistrationWithResponse call
+func ParseSetChargeStationRegistrationResponse(rsp *http.Response) (*SetChargeStationRegistrationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetChargeStationRegistrationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateReservationResponse parses an HTTP response from a CreateReservationWithResponse call
+func ParseCreateReservationResponse(rsp *http.Response) (*CreateReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTriggerChargeStationResponse parses an HTTP response from a TriggerChargeStationWithResponse call
+func ParseTriggerChargeStationResponse(rsp *http.Response) (*TriggerChargeStationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TriggerChargeStationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDashboardSummaryResponse parses an HTTP response from a GetDashboardSummaryWithResponse call
+func ParseGetDashboardSummaryResponse(rsp *http.Response) (*GetDashboardSummaryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDashboardSummaryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DashboardSummary
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamEventsResponse parses an HTTP response from a StreamEventsWithResponse call
+func ParseStreamEventsResponse(rsp *http.Response) (*StreamEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterLocationResponse parses an HTTP response from a RegisterLocationWithResponse call
+func ParseRegisterLocationResponse(rsp *http.Response) (*RegisterLocationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterLocationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListOcppActionsResponse parses an HTTP response from a ListOcppActionsWithResponse call
+func ParseListOcppActionsResponse(rsp *http.Response) (*ListOcppActionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListOcppActionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []OcppAction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateOcppActionResponse parses an HTTP response from a UpdateOcppActionWithResponse call
+func ParseUpdateOcppActionResponse(rsp *http.Response) (*UpdateOcppActionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateOcppActionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OcppAction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterPartyResponse parses an HTTP response from a RegisterPartyWithResponse call
+func ParseRegisterPartyResponse(rsp *http.Response) (*RegisterPartyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterPartyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCancelReservationResponse parses an HTTP response from a CancelReservationWithResponse call
+func ParseCancelReservationResponse(rsp *http.Response) (*CancelReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupReservationResponse parses an HTTP response from a LookupReservationWithResponse call
+func ParseLookupReservationResponse(rsp *http.Response) (*LookupReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Reservation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTransferReservationResponse parses an HTTP response from a TransferReservationWithResponse call
+func ParseTransferReservationResponse(rsp *http.Response) (*TransferReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransferReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListSitesResponse parses an HTTP response from a ListSitesWithResponse call
+func ParseListSitesResponse(rsp *http.Response) (*ListSitesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSitesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Site
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteSiteResponse parses an HTTP response from a DeleteSiteWithResponse call
+func ParseDeleteSiteResponse(rsp *http.Response) (*DeleteSiteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteSiteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupSiteResponse parses an HTTP response from a LookupSiteWithResponse call
+func ParseLookupSiteResponse(rsp *http.Response) (*LookupSiteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupSiteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Site
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterSiteResponse parses an HTTP response from a RegisterSiteWithResponse call
+func ParseRegisterSiteResponse(rsp *http.Response) (*RegisterSiteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterSiteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUnassignChargeStationSiteResponse parses an HTTP response from a UnassignChargeStationSiteWithResponse call
+func ParseUnassignChargeStationSiteResponse(rsp *http.Response) (*UnassignChargeStationSiteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnassignChargeStationSiteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAssignChargeStationSiteResponse parses an HTTP response from a AssignChargeStationSiteWithResponse call
+func ParseAssignChargeStationSiteResponse(rsp *http.Response) (*AssignChargeStationSiteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AssignChargeStationSiteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFleetStatsResponse parses an HTTP response from a GetFleetStatsWithResponse call
+func ParseGetFleetStatsResponse(rsp *http.Response) (*GetFleetStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFleetStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FleetStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTagsResponse parses an HTTP response from a ListTagsWithResponse call
+func ParseListTagsResponse(rsp *http.Response) (*ListTagsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTagsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Tag
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteTagResponse parses an HTTP response from a DeleteTagWithResponse call
+func ParseDeleteTagResponse(rsp *http.Response) (*DeleteTagResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteTagResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupTagResponse parses an HTTP response from a LookupTagWithResponse call
+func ParseLookupTagResponse(rsp *http.Response) (*LookupTagResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupTagResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Tag
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterTagResponse parses an HTTP response from a RegisterTagWithResponse call
+func ParseRegisterTagResponse(rsp *http.Response) (*RegisterTagResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterTagResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUntagChargeStationResponse parses an HTTP response from a UntagChargeStationWithResponse call
+func ParseUntagChargeStationResponse(rsp *http.Response) (*UntagChargeStationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UntagChargeStationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTagChargeStationResponse parses an HTTP response from a TagChargeStationWithResponse call
+func ParseTagChargeStationResponse(rsp *http.Response) (*TagChargeStationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TagChargeStationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChargeStation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterTariffResponse parses an HTTP response from a RegisterTariffWithResponse call
+func ParseRegisterTariffResponse(rsp *http.Response) (*RegisterTariffResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterTariffResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTokensResponse parses an HTTP response from a ListTokensWithResponse call
+func ParseListTokensResponse(rsp *http.Response) (*ListTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Token
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetTokenResponse parses an HTTP response from a SetTokenWithResponse call
+func ParseSetTokenResponse(rsp *http.Response) (*SetTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeTokenResponse parses an HTTP response from a RevokeTokenWithResponse call
+func ParseRevokeTokenResponse(rsp *http.Response) (*RevokeTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupTokenResponse parses an HTTP response from a LookupTokenWithResponse call
+func ParseLookupTokenResponse(rsp *http.Response) (*LookupTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Token
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateTokenResponse parses an HTTP response from a UpdateTokenWithResponse call
+func ParseUpdateTokenResponse(rsp *http.Response) (*UpdateTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Token
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTransactionsResponse parses an HTTP response from a ListTransactionsWithResponse call
+func ParseListTransactionsResponse(rsp *http.Response) (*ListTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportTransactionsResponse parses an HTTP response from a ExportTransactionsWithResponse call
+func ParseExportTransactionsResponse(rsp *http.Response) (*ExportTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []TransactionExport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/csv) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTransactionReceiptResponse parses an HTTP response from a GetTransactionReceiptWithResponse call
+func ParseGetTransactionReceiptResponse(rsp *http.Response) (*GetTransactionReceiptResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTransactionReceiptResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransactionReceipt
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/html) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseEmailTransactionReceiptResponse parses an HTTP response from a EmailTransactionReceiptWithResponse call
+func ParseEmailTransactionReceiptResponse(rsp *http.Response) (*EmailTransactionReceiptResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EmailTransactionReceiptResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
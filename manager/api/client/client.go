@@ -0,0 +1,5 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+//go:generate oapi-codegen -config cfg.yaml ../api-spec.yaml
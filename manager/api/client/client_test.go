@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/api/client"
+	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/server"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientCallsTheApi(t *testing.T) {
+	apiKeys := api.NewApiKeyAuthenticator()
+	apiKeys.Add("operator-key", api.Principal{Name: "operator", Role: api.RoleOperator})
+	handler := server.NewApiHandler(config.ApiSettings{Authenticator: apiKeys}, inmemory.NewStore(clock.RealClock{}), nil, nil)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	c, err := client.NewClientWithResponses(srv.URL+"/api/v1", client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(api.ApiKeyHeader, "operator-key")
+		return nil
+	}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	token := client.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        client.RFID,
+		Uid:         "DEADBEEF",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   client.ALWAYS,
+	}
+	set, err := c.SetTokenWithResponse(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, set.StatusCode())
+
+	lookup, err := c.LookupTokenWithResponse(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, lookup.StatusCode())
+	require.NotNil(t, lookup.JSON200)
+	assert.Equal(t, "GBTWK012345678V", lookup.JSON200.ContractId)
+
+	unknown, err := c.LookupTokenWithResponse(ctx, "UNKNOWN")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, unknown.StatusCode())
+}
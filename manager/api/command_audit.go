@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+)
+
+// auditCommand records a command that the caller has asked to be sent to a charge
+// station in the command audit log. The command must not be carried out if it cannot
+// be recorded.
+func (s *Server) auditCommand(r *http.Request, chargeStationId, command string, request any) error {
+	record := &store.CommandAuditRecord{
+		Id:              store.NewCommandAuditId(),
+		ChargeStationId: chargeStationId,
+		Command:         command,
+		RequestedAt:     s.clock.Now(),
+		Status:          store.CommandAuditStatusRequested,
+	}
+	if principal := PrincipalFromContext(r.Context()); principal != nil {
+		record.RequestedBy = principal.Name
+	}
+	if request != nil {
+		requestBytes, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		record.Request = string(requestBytes)
+	}
+	return s.store.SetCommandAuditRecord(r.Context(), record)
+}
+
+func (s *Server) ListCommandAuditRecords(w http.ResponseWriter, r *http.Request, params ListCommandAuditRecordsParams) {
+	offset := 0
+	limit := 20
+
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	filter := &store.CommandAuditFilter{
+		RequestedFrom:   params.From,
+		RequestedBefore: params.To,
+	}
+	if params.ChargeStationId != nil {
+		filter.ChargeStationId = *params.ChargeStationId
+	}
+	if params.Command != nil {
+		filter.Command = *params.Command
+	}
+	if tenant := tenantOf(r); tenant != "" {
+		ids, err := store.TenantChargeStationIds(r.Context(), s.store, tenant)
+		if err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
+			return
+		}
+		filter.ChargeStationIds = ids
+	}
+
+	records, err := s.store.QueryCommandAuditRecords(r.Context(), filter, offset, limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, len(records))
+	for i, record := range records {
+		resp[i] = newCommandAuditRecord(record)
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func newCommandAuditRecord(record *store.CommandAuditRecord) *CommandAuditRecord {
+	resp := &CommandAuditRecord{
+		Id:              record.Id,
+		ChargeStationId: record.ChargeStationId,
+		Command:         record.Command,
+		RequestedAt:     record.RequestedAt.UTC(),
+		Status:          CommandAuditRecordStatus(record.Status),
+	}
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	resp.OcppVersion = optional(record.OcppVersion)
+	resp.Request = optional(record.Request)
+	resp.RequestedBy = optional(record.RequestedBy)
+	resp.Result = optional(record.Result)
+	if !record.CompletedAt.IsZero() {
+		completedAt := record.CompletedAt.UTC()
+		resp.CompletedAt = &completedAt
+	}
+	return resp
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func listCommands(t *testing.T, handler http.Handler, key, query string) []api.CommandAuditRecord {
+	rr := serveAs(handler, key, http.MethodGet, "/commands"+query, "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var records []api.CommandAuditRecord
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &records))
+	return records
+}
+
+func TestCommandsRequestedThroughTheApiAreAudited(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a"}))
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/cs/cs001/reconfigure", `{"HeartbeatInterval":"60"}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	rr = serveAs(handler, "global-key", http.MethodPost, "/cs/cs001/trigger", `{"trigger":"StatusNotification"}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	records := listCommands(t, handler, "global-key", "")
+	require.Len(t, records, 2)
+	assert.Equal(t, "cs001", records[0].ChargeStationId)
+	assert.Equal(t, "reconfigureChargeStation", records[0].Command)
+	assert.Equal(t, api.Requested, records[0].Status)
+	require.NotNil(t, records[0].RequestedBy)
+	assert.Equal(t, "a", *records[0].RequestedBy)
+	require.NotNil(t, records[0].Request)
+	assert.JSONEq(t, `{"HeartbeatInterval":"60"}`, *records[0].Request)
+	assert.Equal(t, "triggerChargeStation", records[1].Command)
+	assert.Equal(t, "global", *records[1].RequestedBy)
+}
+
+func TestCommandsRequestedWithoutAuthenticationAreAudited(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/trigger", strings.NewReader(`{"trigger":"StatusNotification"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/commands?command=triggerChargeStation", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var records []api.CommandAuditRecord
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &records))
+	require.Len(t, records, 1)
+	assert.Nil(t, records[0].RequestedBy)
+}
+
+type failingAuditEngine struct {
+	store.Engine
+}
+
+func (failingAuditEngine) SetCommandAuditRecord(context.Context, *store.CommandAuditRecord) error {
+	return errors.New("failed")
+}
+
+func TestCommandsThatCannotBeAuditedAreNotCarriedOut(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	srv, err := api.NewServer(failingAuditEngine{engine}, clock.RealClock{}, nil)
+	require.NoError(t, err)
+	handler := api.Handler(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/trigger", strings.NewReader(`{"trigger":"StatusNotification"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	trigger, err := engine.LookupChargeStationTriggerMessage(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, trigger)
+}
+
+func TestListCommandAuditRecords(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{TenantId: "b"}))
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, chargeStationId := range []string{"cs001", "cs002", "cs001"} {
+		require.NoError(t, engine.SetCommandAuditRecord(ctx, &store.CommandAuditRecord{
+			Id:              store.NewCommandAuditId(),
+			ChargeStationId: chargeStationId,
+			Command:         "ReserveNow",
+			OcppVersion:     "ocpp1.6",
+			RequestedBy:     "csms",
+			RequestedAt:     start.Add(time.Duration(i) * time.Hour),
+			Status:          store.CommandAuditStatusCompleted,
+			Result:          `{"status":"Accepted"}`,
+			CompletedAt:     start.Add(time.Duration(i) * time.Hour).Add(time.Second),
+		}))
+	}
+
+	records := listCommands(t, handler, "global-key", "?from=2023-06-01T13:00:00Z")
+	require.Len(t, records, 2)
+	assert.Equal(t, "cs002", records[0].ChargeStationId)
+	assert.Equal(t, api.Completed, records[0].Status)
+	require.NotNil(t, records[0].CompletedAt)
+	assert.Equal(t, start.Add(time.Hour).Add(time.Second), *records[0].CompletedAt)
+
+	assert.Len(t, listCommands(t, handler, "global-key", "?chargeStationId=cs001&limit=1"), 1)
+
+	records = listCommands(t, handler, "a-key", "")
+	require.Len(t, records, 2)
+	for _, record := range records {
+		assert.Equal(t, "cs001", record.ChargeStationId)
+	}
+}
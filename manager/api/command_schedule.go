@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"time"
+)
+
+func (s *Server) CreateCommandSchedule(w http.ResponseWriter, r *http.Request) {
+	req := new(CommandScheduleRequest)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	command, err := newStoreBulkCommand(&req.Command)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	now := s.clock.Now().UTC()
+	schedule := &store.CommandSchedule{
+		Id:        store.NewCommandScheduleId(),
+		Name:      req.Name,
+		Command:   *command,
+		TenantId:  tenantOf(r),
+		CreatedAt: now,
+	}
+	if principal := PrincipalFromContext(r.Context()); principal != nil {
+		schedule.CreatedBy = principal.Name
+	}
+
+	switch {
+	case (req.RunAt == nil) == (req.Cron == nil):
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("one of runAt and cron is required")))
+		return
+	case req.RunAt != nil:
+		if req.TimeZone != nil {
+			_ = render.Render(w, r, ErrInvalidRequest(errors.New("timeZone is only used with cron")))
+			return
+		}
+		schedule.RunAt = req.RunAt.UTC()
+	default:
+		schedule.Cron = *req.Cron
+		if req.TimeZone != nil {
+			schedule.TimeZone = *req.TimeZone
+		}
+	}
+
+	schedule.NextRunAt, err = schedule.NextRun(now)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid schedule: %w", err)))
+		return
+	}
+	if schedule.NextRunAt.IsZero() {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("the schedule never runs")))
+		return
+	}
+
+	err = s.store.SetCommandSchedule(r.Context(), schedule)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.Render(w, r, newCommandSchedule(schedule))
+}
+
+func (s *Server) ListCommandSchedules(w http.ResponseWriter, r *http.Request, params ListCommandSchedulesParams) {
+	offset := 0
+	limit := 20
+
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	schedules, err := store.ListTenantCommandSchedules(r.Context(), s.store, tenantOf(r), offset, limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, len(schedules))
+	for i, schedule := range schedules {
+		resp[i] = newCommandSchedule(schedule)
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) LookupCommandSchedule(w http.ResponseWriter, r *http.Request, scheduleId string) {
+	schedule := s.lookupCommandSchedule(w, r, scheduleId)
+	if schedule == nil {
+		return
+	}
+
+	_ = render.Render(w, r, newCommandSchedule(schedule))
+}
+
+func (s *Server) DeleteCommandSchedule(w http.ResponseWriter, r *http.Request, scheduleId string) {
+	schedule := s.lookupCommandSchedule(w, r, scheduleId)
+	if schedule == nil {
+		return
+	}
+
+	err := s.store.DeleteCommandSchedule(r.Context(), scheduleId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupCommandSchedule returns the command schedule, or renders an error response and
+// returns nil if it does not exist or belongs to another tenant
+func (s *Server) lookupCommandSchedule(w http.ResponseWriter, r *http.Request, scheduleId string) *store.CommandSchedule {
+	schedule, err := s.store.LookupCommandSchedule(r.Context(), scheduleId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return nil
+	}
+	if schedule == nil || !inTenant(r, schedule.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return nil
+	}
+	return schedule
+}
+
+func newCommandSchedule(schedule *store.CommandSchedule) *CommandSchedule {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	optionalTime := func(value time.Time) *time.Time {
+		if value.IsZero() {
+			return nil
+		}
+		value = value.UTC()
+		return &value
+	}
+	command := &schedule.Command
+	resp := &CommandSchedule{
+		Id:   schedule.Id,
+		Name: schedule.Name,
+		Command: BulkCommandRequest{
+			Action:               BulkCommandRequestAction(command.Action),
+			Selector:             newBulkCommandSelector(&command.Selector),
+			Key:                  optional(command.Key),
+			Value:                optional(command.Value),
+			FirmwareLocation:     optional(command.FirmwareLocation),
+			FirmwareRetrieveDate: optionalTime(command.FirmwareRetrieveDate),
+			MaxInFlight:          &command.MaxInFlight,
+		},
+		RunAt:             optionalTime(schedule.RunAt),
+		Cron:              optional(schedule.Cron),
+		TimeZone:          optional(schedule.TimeZone),
+		NextRunAt:         optionalTime(schedule.NextRunAt),
+		LastRunAt:         optionalTime(schedule.LastRunAt),
+		LastBulkCommandId: optional(schedule.LastBulkCommandId),
+		LastError:         optional(schedule.LastError),
+		CreatedBy:         optional(schedule.CreatedBy),
+		CreatedAt:         schedule.CreatedAt.UTC(),
+	}
+	if command.Action == store.BulkCommandActionChangeConfiguration {
+		// an empty value is a valid configuration value
+		resp.Command.Value = &command.Value
+	}
+	if command.ResetType != "" {
+		resetType := BulkCommandRequestResetType(command.ResetType)
+		resp.Command.ResetType = &resetType
+	}
+	return resp
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCreateCommandSchedule(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/commands/schedule",
+		`{"name":"nightly reset","command":{"action":"Reset","selector":{"tags":["dc"]},"resetType":"OnIdle"},"cron":"0 2 * * *","timeZone":"Europe/London"}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var schedule api.CommandSchedule
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &schedule))
+	assert.Equal(t, "nightly reset", schedule.Name)
+	assert.Equal(t, api.BulkCommandRequestActionReset, schedule.Command.Action)
+	require.NotNil(t, schedule.Command.Selector.Tags)
+	assert.Equal(t, []string{"dc"}, *schedule.Command.Selector.Tags)
+	require.NotNil(t, schedule.NextRunAt)
+	assert.True(t, schedule.NextRunAt.After(time.Now()))
+	require.NotNil(t, schedule.CreatedBy)
+	assert.Equal(t, "a", *schedule.CreatedBy)
+
+	got, err := engine.LookupCommandSchedule(context.Background(), schedule.Id)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "a", got.TenantId)
+	assert.Equal(t, "OnIdle", got.Command.ResetType)
+	assert.Equal(t, "Europe/London", got.TimeZone)
+
+	// another tenant cannot see or delete the schedule
+	rr = serveAs(handler, "b-key", http.MethodGet, "/commands/schedule/"+schedule.Id, "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodDelete, "/commands/schedule/"+schedule.Id, "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodGet, "/commands/schedule", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, "[]", rr.Body.String())
+
+	rr = serveAs(handler, "a-key", http.MethodGet, "/commands/schedule", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var schedules []api.CommandSchedule
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &schedules))
+	require.Len(t, schedules, 1)
+	assert.Equal(t, schedule.Id, schedules[0].Id)
+
+	rr = serveAs(handler, "a-key", http.MethodDelete, "/commands/schedule/"+schedule.Id, "")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	got, err = engine.LookupCommandSchedule(context.Background(), schedule.Id)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestCreateCommandScheduleToRunOnce(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	runAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/commands/schedule",
+		`{"name":"clear caches","command":{"action":"ClearCache","selector":{}},"runAt":"`+runAt.Format(time.RFC3339)+`"}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var schedule api.CommandSchedule
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &schedule))
+	got, err := engine.LookupCommandSchedule(context.Background(), schedule.Id)
+	require.NoError(t, err)
+	assert.Equal(t, runAt, got.RunAt)
+	assert.Equal(t, runAt, got.NextRunAt)
+	assert.Equal(t, store.BulkCommandActionClearCache, got.Command.Action)
+}
+
+func TestCreateCommandScheduleRejectsInvalidRequests(t *testing.T) {
+	handler, _ := setupTenantServer(t)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	for name, body := range map[string]string{
+		"no time":           `{"name":"x","command":{"action":"ClearCache","selector":{}}}`,
+		"runAt and cron":    `{"name":"x","command":{"action":"ClearCache","selector":{}},"cron":"* * * * *","runAt":"` + past + `"}`,
+		"runAt in the past": `{"name":"x","command":{"action":"ClearCache","selector":{}},"runAt":"` + past + `"}`,
+		"invalid cron":      `{"name":"x","command":{"action":"ClearCache","selector":{}},"cron":"0 25 * * *"}`,
+		"unknown time zone": `{"name":"x","command":{"action":"ClearCache","selector":{}},"cron":"0 2 * * *","timeZone":"Nowhere/Special"}`,
+		"missing key":       `{"name":"x","command":{"action":"ChangeConfiguration","selector":{}},"cron":"0 2 * * *"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rr := serveAs(handler, "a-key", http.MethodPost, "/commands/schedule", body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
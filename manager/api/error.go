@@ -3,7 +3,9 @@
 package api
 
 import (
+	"errors"
 	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
 	"net/http"
 )
 
@@ -39,6 +41,79 @@ func ErrInternalError(err error) render.Renderer {
 	}
 }
 
+func ErrConflict(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusConflict,
+		StatusText:     http.StatusText(http.StatusConflict),
+		ErrorText:      err.Error(),
+	}
+}
+
+// ErrStoreWrite reports a failed write to the store: a version conflict means that the
+// record was changed by another request after it was read
+func ErrStoreWrite(err error) render.Renderer {
+	return ErrFromError(err)
+}
+
+// ErrFromError reports an error with the HTTP status for its kind, as defined by
+// package errs: any other error is an internal error
+func ErrFromError(err error) render.Renderer {
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+		return &ErrResponse{
+			Err:            err,
+			HTTPStatusCode: http.StatusNotFound,
+			StatusText:     http.StatusText(http.StatusNotFound),
+			ErrorText:      err.Error(),
+		}
+	case errors.Is(err, errs.ErrConflict):
+		return ErrConflict(err)
+	case errors.Is(err, errs.ErrUnauthorized):
+		return ErrForbidden(err)
+	case errors.Is(err, errs.ErrDependencyUnavailable):
+		return ErrServiceUnavailable(err)
+	default:
+		return ErrInternalError(err)
+	}
+}
+
+func ErrPreconditionFailed(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusPreconditionFailed,
+		StatusText:     http.StatusText(http.StatusPreconditionFailed),
+		ErrorText:      err.Error(),
+	}
+}
+
+func ErrForbidden(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusForbidden,
+		StatusText:     http.StatusText(http.StatusForbidden),
+		ErrorText:      err.Error(),
+	}
+}
+
+func ErrServiceUnavailable(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusServiceUnavailable,
+		StatusText:     http.StatusText(http.StatusServiceUnavailable),
+		ErrorText:      err.Error(),
+	}
+}
+
+func ErrNotImplemented(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusNotImplemented,
+		StatusText:     http.StatusText(http.StatusNotImplemented),
+		ErrorText:      err.Error(),
+	}
+}
+
 var ErrNotFound = &ErrResponse{
 	HTTPStatusCode: http.StatusNotFound,
 	StatusText:     http.StatusText(http.StatusNotFound),
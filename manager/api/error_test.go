@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"testing"
+)
+
+func TestErrFromError(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		status int
+	}{
+		"not found":              {errs.New(errs.ErrNotFound, "transaction cs001/tx001 not found"), http.StatusNotFound},
+		"version conflict":       {fmt.Errorf("updating charge station: %w", store.ErrVersionConflict), http.StatusConflict},
+		"unauthorized":           {errs.New(errs.ErrUnauthorized, "tenant mismatch"), http.StatusForbidden},
+		"dependency unavailable": {fmt.Errorf("providing certificate: %w", services.HttpError(http.StatusBadGateway)), http.StatusServiceUnavailable},
+		"other":                  {errors.New("disk full"), http.StatusInternalServerError},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := api.ErrFromError(tc.err).(*api.ErrResponse)
+			assert.Equal(t, tc.status, resp.HTTPStatusCode)
+			assert.Equal(t, http.StatusText(tc.status), resp.StatusText)
+			assert.Equal(t, tc.err.Error(), resp.ErrorText)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/events"
+	"golang.org/x/exp/slices"
+	"golang.org/x/exp/slog"
+	"net/http"
+	"time"
+)
+
+// eventStreamKeepAlive is the interval at which a comment is sent on an idle event
+// stream so that proxies do not close the connection
+var eventStreamKeepAlive = 30 * time.Second
+
+func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	if s.eventStream == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	var filter func(*events.Event) bool
+	if params.Category != nil {
+		categories := *params.Category
+		filter = func(event *events.Event) bool {
+			return slices.Contains(categories, StreamEventsParamsCategory(event.Category()))
+		}
+	}
+	lastEventId := ""
+	if params.LastEventID != nil {
+		lastEventId = *params.LastEventID
+	} else if params.LastEventId != nil {
+		lastEventId = *params.LastEventId
+	}
+
+	subscription, missed, err := s.eventStream.Subscribe(lastEventId, filter)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	defer subscription.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	if err := rc.Flush(); err != nil {
+		slog.Warn("event stream cannot be flushed", "err", err)
+		return
+	}
+
+	// the events for a tenant are those of the tenant's charge stations: the tenant of
+	// each charge station is looked up once per stream
+	tenant := tenantOf(r)
+	chargeStationTenants := make(map[string]string)
+	send := func(event *events.Event) error {
+		if tenant != "" {
+			chargeStationTenant, ok := chargeStationTenants[event.ChargeStationId]
+			if !ok {
+				chargeStationTenant, err = s.chargeStationTenant(r, event.ChargeStationId)
+				if err != nil {
+					return err
+				}
+				chargeStationTenants[event.ChargeStationId] = chargeStationTenant
+			}
+			if chargeStationTenant != tenant {
+				return nil
+			}
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+		if err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	for _, event := range missed {
+		if err := send(event); err != nil {
+			slog.Warn("failed to send event", "err", err)
+			return
+		}
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			if err := send(event); err != nil {
+				slog.Warn("failed to send event", "err", err)
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
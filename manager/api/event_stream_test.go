@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/events"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type streamedEvent struct {
+	id        string
+	eventType string
+	event     events.Event
+}
+
+// openEventStream connects to the event stream as the caller with the key and returns
+// the events as they are received
+func openEventStream(t *testing.T, server *httptest.Server, key, query, lastEventId string) <-chan streamedEvent {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events"+query, nil)
+	require.NoError(t, err)
+	req.Header.Set(api.ApiKeyHeader, key)
+	if lastEventId != "" {
+		req.Header.Set("Last-Event-ID", lastEventId)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	eventCh := make(chan streamedEvent)
+	go func() {
+		defer close(eventCh)
+		defer resp.Body.Close()
+		var received streamedEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			field, value, _ := strings.Cut(scanner.Text(), ": ")
+			switch field {
+			case "id":
+				received.id = value
+			case "event":
+				received.eventType = value
+			case "data":
+				if json.Unmarshal([]byte(value), &received.event) != nil {
+					return
+				}
+			case "":
+				if received.id != "" {
+					eventCh <- received
+				}
+				received = streamedEvent{}
+			}
+		}
+	}()
+	return eventCh
+}
+
+func setupEventStreamServer(t *testing.T) (*httptest.Server, *events.Broadcaster) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ctx := context.Background()
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{TenantId: "b"}))
+
+	broadcaster := events.NewBroadcaster(10)
+	t.Cleanup(func() { _ = broadcaster.Close() })
+	srv, err := api.NewServer(engine, clock.RealClock{}, nil, api.WithEventStream(broadcaster))
+	require.NoError(t, err)
+
+	apiKeys := api.NewApiKeyAuthenticator()
+	apiKeys.Add("a-key", api.Principal{Name: "a", Role: api.RoleReadOnly, Tenant: "a"})
+	apiKeys.Add("global-key", api.Principal{Name: "global", Role: api.RoleReadOnly})
+
+	r := chi.NewRouter()
+	r.Use(api.ValidationMiddleware, api.Authorize(apiKeys, api.RoleReadOnly, api.RoleOperator))
+	r.Mount("/", api.Handler(srv))
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server, broadcaster
+}
+
+func publishEvent(t *testing.T, broadcaster *events.Broadcaster, id string, eventType events.Type, chargeStationId string) {
+	err := broadcaster.Publish(context.Background(), &events.Event{
+		SchemaVersion:   events.SchemaVersion,
+		Id:              id,
+		Type:            eventType,
+		ChargeStationId: chargeStationId,
+	})
+	require.NoError(t, err)
+}
+
+func TestStreamEvents(t *testing.T) {
+	server, broadcaster := setupEventStreamServer(t)
+
+	stream := openEventStream(t, server, "global-key", "", "")
+	publishEvent(t, broadcaster, "1", events.TypeConnectorStatusChanged, "cs001")
+	publishEvent(t, broadcaster, "2", events.TypeTransactionStarted, "cs002")
+
+	received := <-stream
+	assert.Equal(t, "1", received.id)
+	assert.Equal(t, "connector.status_changed", received.eventType)
+	assert.Equal(t, "cs001", received.event.ChargeStationId)
+	assert.Equal(t, "2", (<-stream).id)
+}
+
+func TestStreamEventsFiltersByCategory(t *testing.T) {
+	server, broadcaster := setupEventStreamServer(t)
+
+	stream := openEventStream(t, server, "global-key", "?category=transaction,reservation", "")
+	publishEvent(t, broadcaster, "1", events.TypeConnectorStatusChanged, "cs001")
+	publishEvent(t, broadcaster, "2", events.TypeTransactionStarted, "cs001")
+
+	assert.Equal(t, "2", (<-stream).id)
+}
+
+func TestStreamEventsAreScopedToTenants(t *testing.T) {
+	server, broadcaster := setupEventStreamServer(t)
+
+	stream := openEventStream(t, server, "a-key", "", "")
+	publishEvent(t, broadcaster, "1", events.TypeTransactionStarted, "cs002")
+	publishEvent(t, broadcaster, "2", events.TypeTransactionStarted, "cs001")
+
+	assert.Equal(t, "2", (<-stream).id)
+}
+
+func TestStreamEventsResumesAfterTheLastEventId(t *testing.T) {
+	server, broadcaster := setupEventStreamServer(t)
+	publishEvent(t, broadcaster, "1", events.TypeTransactionStarted, "cs001")
+	publishEvent(t, broadcaster, "2", events.TypeTransactionEnded, "cs001")
+
+	stream := openEventStream(t, server, "global-key", "", "1")
+	publishEvent(t, broadcaster, "3", events.TypeReservationCreated, "cs001")
+
+	assert.Equal(t, "2", (<-stream).id)
+	assert.Equal(t, "3", (<-stream).id)
+
+	stream = openEventStream(t, server, "global-key", "?lastEventId=2", "")
+	assert.Equal(t, "3", (<-stream).id)
+}
+
+func TestStreamEventsEndsWhenTheBroadcasterIsClosed(t *testing.T) {
+	server, broadcaster := setupEventStreamServer(t)
+
+	stream := openEventStream(t, server, "global-key", "", "")
+	require.NoError(t, broadcaster.Close())
+
+	_, ok := <-stream
+	assert.False(t, ok)
+}
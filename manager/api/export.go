@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// exportPageSize is the number of records that are read from the store at a time when
+// they are exported
+const exportPageSize = 100
+
+var transactionExportHeader = []string{"chargeStationId", "transactionId", "idToken", "tokenType",
+	"status", "startTime", "endTime", "energyRegisterWh", "offline"}
+
+var chargeDetailRecordExportHeader = []string{"id", "chargeStationId", "transactionId", "idToken", "tokenType",
+	"contractId", "locationId", "evseId", "connectorId", "startDateTime", "endDateTime", "totalEnergyKwh",
+	"totalTimeHours", "totalCost", "currency"}
+
+// exportWriter writes the records of an export to the response as they are read from
+// the store: as CSV with a header row, or as a JSON array
+type exportWriter struct {
+	w       io.Writer
+	rc      *http.ResponseController
+	csv     *csv.Writer
+	json    *json.Encoder
+	written int
+}
+
+// newExportWriter starts the response for the export: it is sent as an attachment
+// named after the export and the format
+func newExportWriter(w http.ResponseWriter, format *ExportFormat, name string, header []string) (*exportWriter, error) {
+	e := &exportWriter{
+		w:  w,
+		rc: http.NewResponseController(w),
+	}
+	if format != nil && *format == Json {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".json"))
+		w.WriteHeader(http.StatusOK)
+		e.json = json.NewEncoder(w)
+		_, err := io.WriteString(w, "[")
+		return e, err
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".csv"))
+	w.WriteHeader(http.StatusOK)
+	e.csv = csv.NewWriter(w)
+	return e, e.csv.Write(header)
+}
+
+// write adds a record to the export: row holds the record's CSV fields
+func (e *exportWriter) write(record any, row []string) error {
+	defer func() { e.written++ }()
+	if e.json != nil {
+		if e.written > 0 {
+			if _, err := io.WriteString(e.w, ","); err != nil {
+				return err
+			}
+		}
+		return e.json.Encode(record)
+	}
+	return e.csv.Write(row)
+}
+
+// flush sends the records written so far to the client
+func (e *exportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	err := e.rc.Flush()
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
+
+// close completes the export
+func (e *exportWriter) close() error {
+	if e.json != nil {
+		if _, err := io.WriteString(e.w, "]"); err != nil {
+			return err
+		}
+	}
+	return e.flush()
+}
+
+// exportPages writes the records returned by next to the export until next returns an
+// empty page. The first page is read before the response is started so that the caller
+// receives an error response if the store cannot be read: once the export has started a
+// failure can only cut it short.
+func exportPages[T any](w http.ResponseWriter, r *http.Request, format *ExportFormat, name string, header []string,
+	next func() ([]T, error), convert func(T) (any, []string, error)) {
+	page, err := next()
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	e, err := newExportWriter(w, format, name, header)
+	for err == nil && len(page) > 0 {
+		for _, item := range page {
+			var record any
+			var row []string
+			record, row, err = convert(item)
+			if err == nil {
+				err = e.write(record, row)
+			}
+			if err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = e.flush()
+		}
+		if err == nil {
+			page, err = next()
+		}
+	}
+	if err == nil {
+		err = e.close()
+	}
+	if err != nil {
+		slog.Warn("export cut short", "export", name, "records", e.written, "err", err)
+	}
+}
+
+func (s *Server) ExportTransactions(w http.ResponseWriter, r *http.Request, params ExportTransactionsParams) {
+	filter := &store.TransactionFilter{
+		StartedFrom:   params.From,
+		StartedBefore: params.To,
+	}
+	err := s.tenantTransactionFilter(r, filter)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	var previous *store.Transaction
+	next := func() ([]*store.Transaction, error) {
+		transactions, err := s.store.QueryTransactionsAfter(r.Context(), filter, exportPageSize, previous)
+		if err != nil || len(transactions) == 0 {
+			return nil, err
+		}
+		previous = transactions[len(transactions)-1]
+		return transactions, nil
+	}
+	exportPages(w, r, params.Format, "transactions", transactionExportHeader, next, newTransactionExport)
+}
+
+func newTransactionExport(transaction *store.Transaction) (any, []string, error) {
+	record := &TransactionExport{
+		ChargeStationId: transaction.ChargeStationId,
+		TransactionId:   transaction.TransactionId,
+		Status:          string(transaction.Status()),
+		Offline:         transaction.Offline,
+	}
+	row := []string{transaction.ChargeStationId, transaction.TransactionId, transaction.IdToken,
+		transaction.TokenType, record.Status, "", "", "", strconv.FormatBool(transaction.Offline)}
+
+	if transaction.IdToken != "" {
+		record.IdToken = &transaction.IdToken
+	}
+	if transaction.TokenType != "" {
+		record.TokenType = &transaction.TokenType
+	}
+	if startTime, ok := transaction.StartTime(); ok {
+		startTime = startTime.UTC()
+		record.StartTime = &startTime
+		row[5] = startTime.Format(time.RFC3339)
+	}
+	if endTime, energy, ok := transaction.EndOutletEnergy(); ok {
+		endTime = endTime.UTC()
+		record.EndTime = &endTime
+		record.EnergyRegisterWh = &energy
+		row[6] = endTime.Format(time.RFC3339)
+		row[7] = strconv.FormatFloat(energy, 'f', -1, 64)
+	}
+	return record, row, nil
+}
+
+func (s *Server) ExportChargeDetailRecords(w http.ResponseWriter, r *http.Request, params ExportChargeDetailRecordsParams) {
+	filter := &store.ChargeDetailRecordFilter{
+		EndedFrom:   params.From,
+		EndedBefore: params.To,
+	}
+	// a caller that is scoped to a tenant exports the records of the tenant's charge
+	// stations
+	if tenant := tenantOf(r); tenant != "" {
+		var err error
+		filter.ChargeStationIds, err = store.TenantChargeStationIds(r.Context(), s.store, tenant)
+		if err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
+			return
+		}
+	}
+
+	previousCdrId := ""
+	next := func() ([]*store.ChargeDetailRecord, error) {
+		records, err := s.store.QueryChargeDetailRecords(r.Context(), filter, exportPageSize, previousCdrId)
+		if err != nil || len(records) == 0 {
+			return nil, err
+		}
+		previousCdrId = records[len(records)-1].Id
+		return records, nil
+	}
+	exportPages(w, r, params.Format, "charge-detail-records", chargeDetailRecordExportHeader, next, newChargeDetailRecordExport)
+}
+
+func newChargeDetailRecordExport(cdr *store.ChargeDetailRecord) (any, []string, error) {
+	startDateTime, err := time.Parse(time.RFC3339, cdr.StartDateTime)
+	if err != nil {
+		return nil, nil, fmt.Errorf("charge detail record %s start date time: %w", cdr.Id, err)
+	}
+	endDateTime, err := time.Parse(time.RFC3339, cdr.EndDateTime)
+	if err != nil {
+		return nil, nil, fmt.Errorf("charge detail record %s end date time: %w", cdr.Id, err)
+	}
+
+	record := &ChargeDetailRecordExport{
+		Id:              cdr.Id,
+		ChargeStationId: cdr.ChargeStationId,
+		TransactionId:   cdr.TransactionId,
+		StartDateTime:   startDateTime.UTC(),
+		EndDateTime:     endDateTime.UTC(),
+		TotalEnergyKwh:  cdr.TotalEnergy,
+		TotalTimeHours:  cdr.TotalTime,
+		TotalCost:       cdr.TotalCost,
+	}
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	record.IdToken = optional(cdr.IdToken)
+	record.TokenType = optional(cdr.TokenType)
+	record.ContractId = optional(cdr.ContractId)
+	record.LocationId = optional(cdr.LocationId)
+	record.EvseId = optional(cdr.EvseId)
+	record.ConnectorId = optional(cdr.ConnectorId)
+	record.Currency = optional(cdr.Currency)
+
+	row := []string{cdr.Id, cdr.ChargeStationId, cdr.TransactionId, cdr.IdToken, cdr.TokenType,
+		cdr.ContractId, cdr.LocationId, cdr.EvseId, cdr.ConnectorId,
+		record.StartDateTime.Format(time.RFC3339), record.EndDateTime.Format(time.RFC3339),
+		strconv.FormatFloat(cdr.TotalEnergy, 'f', -1, 64), strconv.FormatFloat(cdr.TotalTime, 'f', -1, 64),
+		strconv.FormatFloat(cdr.TotalCost, 'f', -1, 64), cdr.Currency}
+	return record, row, nil
+}
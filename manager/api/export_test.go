@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func endedTransaction(t *testing.T, engine store.Engine, chargeStationId, transactionId, start, end string, energy float64) {
+	ctx := context.Background()
+	transactionBegin := "Transaction.Begin"
+	transactionEnd := "Transaction.End"
+	measurand := "Energy.Active.Import.Register"
+	outlet := "Outlet"
+	require.NoError(t, engine.CreateTransaction(ctx, chargeStationId, transactionId, "DEADBEEF", "ISO14443",
+		[]store.MeterValue{{Timestamp: start, SampledValues: []store.SampledValue{{
+			Context: &transactionBegin, Measurand: &measurand, Location: &outlet, Value: 0}}}}, 0, false))
+	require.NoError(t, engine.EndTransaction(ctx, chargeStationId, transactionId, "DEADBEEF", "ISO14443",
+		[]store.MeterValue{{Timestamp: end, SampledValues: []store.SampledValue{{
+			Context: &transactionEnd, Measurand: &measurand, Location: &outlet, Value: energy}}}}, 1))
+}
+
+func readCsv(t *testing.T, rr *httptest.ResponseRecorder) [][]string {
+	records, err := csv.NewReader(rr.Body).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestExportTransactions(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+	endedTransaction(t, engine, "cs001", "tx001", "2023-06-01T10:00:00Z", "2023-06-01T11:00:00Z", 12345.5)
+	endedTransaction(t, engine, "cs001", "tx002", "2023-06-02T10:00:00Z", "2023-06-02T11:00:00Z", 100)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/export?to=2023-06-02T00:00:00Z", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="transactions.csv"`, rr.Header().Get("Content-Disposition"))
+	assert.Equal(t, [][]string{
+		{"chargeStationId", "transactionId", "idToken", "tokenType", "status", "startTime", "endTime", "energyRegisterWh", "offline"},
+		{"cs001", "tx001", "DEADBEEF", "ISO14443", "Ended", "2023-06-01T10:00:00Z", "2023-06-01T11:00:00Z", "12345.5", "false"},
+	}, readCsv(t, rr))
+}
+
+func TestExportTransactionsAsJson(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+	endedTransaction(t, engine, "cs001", "tx001", "2023-06-01T10:00:00Z", "2023-06-01T11:00:00Z", 12345.5)
+	require.NoError(t, engine.CreateTransaction(context.Background(), "cs002", "tx002", "", "", nil, 0, true))
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/export?format=json", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var transactions []api.TransactionExport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &transactions))
+	require.Len(t, transactions, 2)
+	assert.Equal(t, "tx001", transactions[0].TransactionId)
+	require.NotNil(t, transactions[0].EnergyRegisterWh)
+	assert.Equal(t, 12345.5, *transactions[0].EnergyRegisterWh)
+	assert.Equal(t, "Active", transactions[1].Status)
+	assert.True(t, transactions[1].Offline)
+	assert.Nil(t, transactions[1].EndTime)
+}
+
+func TestExportTransactionsWithoutTransactions(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/export?format=json", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[]`, rr.Body.String())
+}
+
+func TestExportTransactionsPagesThroughTheStore(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+	for i := 0; i < 250; i++ {
+		require.NoError(t, engine.CreateTransaction(context.Background(), "cs001", strings.Repeat("x", i+1), "", "", nil, 0, false))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/export", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Len(t, readCsv(t, rr), 251)
+}
+
+func chargeDetailRecord(id, chargeStationId, end string) *store.ChargeDetailRecord {
+	return &store.ChargeDetailRecord{
+		Id:              id,
+		ChargeStationId: chargeStationId,
+		TransactionId:   id,
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ContractId:      "GBTWK012345678V",
+		LocationId:      "loc001",
+		EvseId:          "GB*TWK*E001",
+		ConnectorId:     "1",
+		StartDateTime:   "2023-06-01T10:00:00Z",
+		EndDateTime:     end,
+		TotalEnergy:     12.3455,
+		TotalTime:       1.5,
+		TotalCost:       4.32,
+		Currency:        "GBP",
+		LastUpdated:     end,
+	}
+}
+
+func TestExportChargeDetailRecords(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+	ctx := context.Background()
+	require.NoError(t, engine.SetChargeDetailRecord(ctx, chargeDetailRecord("cdr001", "cs001", "2023-06-01T11:30:00Z")))
+	require.NoError(t, engine.SetChargeDetailRecord(ctx, chargeDetailRecord("cdr002", "cs001", "2023-06-02T11:30:00Z")))
+
+	req := httptest.NewRequest(http.MethodGet, "/cdrs/export?from=2023-06-01T00:00:00Z&to=2023-06-02T00:00:00Z", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `attachment; filename="charge-detail-records.csv"`, rr.Header().Get("Content-Disposition"))
+	assert.Equal(t, [][]string{
+		{"id", "chargeStationId", "transactionId", "idToken", "tokenType", "contractId", "locationId", "evseId",
+			"connectorId", "startDateTime", "endDateTime", "totalEnergyKwh", "totalTimeHours", "totalCost", "currency"},
+		{"cdr001", "cs001", "cdr001", "DEADBEEF", "ISO14443", "GBTWK012345678V", "loc001", "GB*TWK*E001",
+			"1", "2023-06-01T10:00:00Z", "2023-06-01T11:30:00Z", "12.3455", "1.5", "4.32", "GBP"},
+	}, readCsv(t, rr))
+
+	req = httptest.NewRequest(http.MethodGet, "/cdrs/export?format=json", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var records []api.ChargeDetailRecordExport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &records))
+	require.Len(t, records, 2)
+	assert.Equal(t, time.Date(2023, 6, 2, 11, 30, 0, 0, time.UTC), records[1].EndDateTime)
+	assert.Equal(t, 4.32, records[1].TotalCost)
+}
+
+func TestExportsAreScopedToTenants(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{TenantId: "b"}))
+	endedTransaction(t, engine, "cs001", "tx001", "2023-06-01T10:00:00Z", "2023-06-01T11:00:00Z", 100)
+	endedTransaction(t, engine, "cs002", "tx002", "2023-06-01T10:00:00Z", "2023-06-01T11:00:00Z", 100)
+	require.NoError(t, engine.SetChargeDetailRecord(ctx, chargeDetailRecord("cdr001", "cs001", "2023-06-01T11:00:00Z")))
+	require.NoError(t, engine.SetChargeDetailRecord(ctx, chargeDetailRecord("cdr002", "cs002", "2023-06-01T11:00:00Z")))
+
+	rr := serveAs(handler, "a-key", http.MethodGet, "/transactions/export", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	records := readCsv(t, rr)
+	require.Len(t, records, 2)
+	assert.Equal(t, "tx001", records[1][1])
+
+	rr = serveAs(handler, "a-key", http.MethodGet, "/cdrs/export", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	records = readCsv(t, rr)
+	require.Len(t, records, 2)
+	assert.Equal(t, "cdr001", records[1][0])
+
+	rr = serveAs(handler, "global-key", http.MethodGet, "/cdrs/export", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Len(t, readCsv(t, rr), 3)
+}
@@ -0,0 +1,394 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slices"
+	"net/http"
+	"sort"
+)
+
+func (s *Server) ListSites(w http.ResponseWriter, r *http.Request, params ListSitesParams) {
+	offset := 0
+	limit := 20
+
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	var sites []*store.Site
+	var err error
+	if tenant := tenantOf(r); tenant != "" {
+		sites, err = store.ListTenantSites(r.Context(), s.store, tenant, offset, limit)
+	} else {
+		sites, err = s.store.ListSites(r.Context(), offset, limit)
+	}
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, len(sites))
+	for i, site := range sites {
+		resp[i] = newSite(site)
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) RegisterSite(w http.ResponseWriter, r *http.Request, siteId string) {
+	req := new(SiteDetails)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	site, err := s.store.LookupSite(r.Context(), siteId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if site == nil {
+		site = &store.Site{Id: siteId, TenantId: tenantOf(r)}
+	} else if !inTenant(r, site.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	site.Name = req.Name
+	site.ParentSiteId = ""
+	if req.ParentSiteId != nil {
+		parent, err := s.store.LookupSite(r.Context(), *req.ParentSiteId)
+		if err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
+			return
+		}
+		if parent == nil || !inTenant(r, parent.TenantId) {
+			_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("unknown parent site: %s", *req.ParentSiteId)))
+			return
+		}
+		sites, err := store.ListAllSites(r.Context(), s.store)
+		if err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
+			return
+		}
+		if slices.Contains(store.SiteAncestors(sites, parent.Id), siteId) {
+			_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("site %s is within site %s", parent.Id, siteId)))
+			return
+		}
+		site.ParentSiteId = parent.Id
+	}
+
+	err = s.store.SetSite(r.Context(), site)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) LookupSite(w http.ResponseWriter, r *http.Request, siteId string) {
+	site := s.lookupSite(w, r, siteId)
+	if site == nil {
+		return
+	}
+
+	_ = render.Render(w, r, newSite(site))
+}
+
+func (s *Server) DeleteSite(w http.ResponseWriter, r *http.Request, siteId string) {
+	site := s.lookupSite(w, r, siteId)
+	if site == nil {
+		return
+	}
+
+	sites, err := store.ListAllSites(r.Context(), s.store)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if subSites := store.SiteTree(sites, siteId); len(subSites) > 1 {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("site %s contains site %s", siteId, subSites[1])))
+		return
+	}
+	chargeStationIds, err := store.GroupChargeStationIds(r.Context(), s.store, &store.ChargeStationGroup{
+		SiteIds: []string{siteId},
+	})
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if len(chargeStationIds) > 0 {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("site %s has %d charge stations", siteId, len(chargeStationIds))))
+		return
+	}
+
+	err = s.store.DeleteSite(r.Context(), siteId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) AssignChargeStationSite(w http.ResponseWriter, r *http.Request, siteId string, csId string) {
+	site := s.lookupSite(w, r, siteId)
+	if site == nil {
+		return
+	}
+	chargeStation := s.lookupTenantChargeStation(w, r, csId)
+	if chargeStation == nil {
+		return
+	}
+	if site.TenantId != "" && site.TenantId != chargeStation.TenantId {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("site %s belongs to another tenant", siteId)))
+		return
+	}
+
+	chargeStation.SiteId = siteId
+	s.setChargeStationGroups(w, r, csId, chargeStation)
+}
+
+func (s *Server) UnassignChargeStationSite(w http.ResponseWriter, r *http.Request, siteId string, csId string) {
+	chargeStation := s.lookupTenantChargeStation(w, r, csId)
+	if chargeStation == nil {
+		return
+	}
+	if chargeStation.SiteId != siteId {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	chargeStation.SiteId = ""
+	s.setChargeStationGroups(w, r, csId, chargeStation)
+}
+
+func (s *Server) ListTags(w http.ResponseWriter, r *http.Request, params ListTagsParams) {
+	offset := 0
+	limit := 20
+
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	var tags []*store.Tag
+	var err error
+	if tenant := tenantOf(r); tenant != "" {
+		tags, err = store.ListTenantTags(r.Context(), s.store, tenant, offset, limit)
+	} else {
+		tags, err = s.store.ListTags(r.Context(), offset, limit)
+	}
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, len(tags))
+	for i, tag := range tags {
+		resp[i] = newTag(tag)
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) RegisterTag(w http.ResponseWriter, r *http.Request, tagName string) {
+	req := new(TagDetails)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	tag, err := s.store.LookupTag(r.Context(), tagName)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if tag == nil {
+		tag = &store.Tag{Name: tagName, TenantId: tenantOf(r)}
+	} else if !inTenant(r, tag.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	tag.Description = ""
+	if req.Description != nil {
+		tag.Description = *req.Description
+	}
+
+	err = s.store.SetTag(r.Context(), tag)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) LookupTag(w http.ResponseWriter, r *http.Request, tagName string) {
+	tag := s.lookupTag(w, r, tagName)
+	if tag == nil {
+		return
+	}
+
+	_ = render.Render(w, r, newTag(tag))
+}
+
+func (s *Server) DeleteTag(w http.ResponseWriter, r *http.Request, tagName string) {
+	tag := s.lookupTag(w, r, tagName)
+	if tag == nil {
+		return
+	}
+
+	chargeStationIds, err := store.GroupChargeStationIds(r.Context(), s.store, &store.ChargeStationGroup{
+		Tags: []string{tagName},
+	})
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if len(chargeStationIds) > 0 {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("tag %s is assigned to %d charge stations", tagName, len(chargeStationIds))))
+		return
+	}
+
+	err = s.store.DeleteTag(r.Context(), tagName)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) TagChargeStation(w http.ResponseWriter, r *http.Request, tagName string, csId string) {
+	tag := s.lookupTag(w, r, tagName)
+	if tag == nil {
+		return
+	}
+	chargeStation := s.lookupTenantChargeStation(w, r, csId)
+	if chargeStation == nil {
+		return
+	}
+	if tag.TenantId != "" && tag.TenantId != chargeStation.TenantId {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("tag %s belongs to another tenant", tagName)))
+		return
+	}
+
+	if !slices.Contains(chargeStation.Tags, tagName) {
+		chargeStation.Tags = append(chargeStation.Tags, tagName)
+		sort.Strings(chargeStation.Tags)
+	}
+	s.setChargeStationGroups(w, r, csId, chargeStation)
+}
+
+func (s *Server) UntagChargeStation(w http.ResponseWriter, r *http.Request, tagName string, csId string) {
+	chargeStation := s.lookupTenantChargeStation(w, r, csId)
+	if chargeStation == nil {
+		return
+	}
+
+	if i := slices.Index(chargeStation.Tags, tagName); i >= 0 {
+		chargeStation.Tags = slices.Delete(chargeStation.Tags, i, i+1)
+	}
+	s.setChargeStationGroups(w, r, csId, chargeStation)
+}
+
+// chargeStationGroup returns the group of the charge stations that the caller can see,
+// selected by the optional site and tag query parameters
+func (s *Server) chargeStationGroup(r *http.Request, siteId, tag *string) (*store.ChargeStationGroup, error) {
+	var site string
+	if siteId != nil {
+		site = *siteId
+	}
+	var tags []string
+	if tag != nil {
+		tags = []string{*tag}
+	}
+	return store.NewChargeStationGroup(r.Context(), s.store, tenantOf(r), site, tags)
+}
+
+// lookupSite returns the site, or renders an error response and returns nil if it does
+// not exist or belongs to another tenant
+func (s *Server) lookupSite(w http.ResponseWriter, r *http.Request, siteId string) *store.Site {
+	site, err := s.store.LookupSite(r.Context(), siteId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return nil
+	}
+	if site == nil || !inTenant(r, site.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return nil
+	}
+	return site
+}
+
+// lookupTag returns the tag, or renders an error response and returns nil if it does not
+// exist or belongs to another tenant
+func (s *Server) lookupTag(w http.ResponseWriter, r *http.Request, tagName string) *store.Tag {
+	tag, err := s.store.LookupTag(r.Context(), tagName)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return nil
+	}
+	if tag == nil || !inTenant(r, tag.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return nil
+	}
+	return tag
+}
+
+// lookupTenantChargeStation returns the charge station, or renders an error response and
+// returns nil if it is not in the registry or belongs to another tenant
+func (s *Server) lookupTenantChargeStation(w http.ResponseWriter, r *http.Request, csId string) *store.ChargeStation {
+	chargeStation, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return nil
+	}
+	if chargeStation == nil || !inTenant(r, chargeStation.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return nil
+	}
+	return chargeStation
+}
+
+func (s *Server) setChargeStationGroups(w http.ResponseWriter, r *http.Request, csId string, chargeStation *store.ChargeStation) {
+	err := s.store.SetChargeStation(r.Context(), csId, chargeStation)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	chargeStation.ChargeStationId = csId
+	_ = render.Render(w, r, newChargeStation(chargeStation))
+}
+
+func newSite(site *store.Site) *Site {
+	resp := &Site{
+		Id:   site.Id,
+		Name: site.Name,
+	}
+	if site.ParentSiteId != "" {
+		resp.ParentSiteId = &site.ParentSiteId
+	}
+	return resp
+}
+
+func newTag(tag *store.Tag) *Tag {
+	resp := &Tag{
+		Name: tag.Name,
+	}
+	if tag.Description != "" {
+		resp.Description = &tag.Description
+	}
+	return resp
+}
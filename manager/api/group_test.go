@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRegisterAndAssignSites(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{TenantId: "b"}))
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/site/region", `{"name":"Region"}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	rr = serveAs(handler, "a-key", http.MethodPost, "/site/car-park", `{"name":"Car park","parentSiteId":"region"}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	site, err := engine.LookupSite(ctx, "car-park")
+	require.NoError(t, err)
+	assert.Equal(t, &store.Site{Id: "car-park", Name: "Car park", ParentSiteId: "region", TenantId: "a"}, site)
+
+	// a site cannot be moved below one of its own sub-sites
+	rr = serveAs(handler, "a-key", http.MethodPost, "/site/region", `{"name":"Region","parentSiteId":"car-park"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// another tenant cannot see the site or use it as a parent
+	rr = serveAs(handler, "b-key", http.MethodGet, "/site/region", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodPost, "/site/depot", `{"name":"Depot","parentSiteId":"region"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serveAs(handler, "a-key", http.MethodPost, "/site/car-park/cs/cs001", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var chargeStation api.ChargeStation
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &chargeStation))
+	require.NotNil(t, chargeStation.SiteId)
+	assert.Equal(t, "car-park", *chargeStation.SiteId)
+
+	// a site cannot contain another tenant's charge stations
+	rr = serveAs(handler, "global-key", http.MethodPost, "/site/car-park/cs/cs002", "")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// the charge stations in a sub-site are in the site
+	rr = serveAs(handler, "a-key", http.MethodGet, "/cs?siteId=region", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var chargeStations []api.ChargeStation
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &chargeStations))
+	require.Len(t, chargeStations, 1)
+	assert.Equal(t, "cs001", chargeStations[0].Id)
+
+	// a site that contains sub-sites or charge stations cannot be deleted
+	rr = serveAs(handler, "a-key", http.MethodDelete, "/site/region", "")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	rr = serveAs(handler, "a-key", http.MethodDelete, "/site/car-park", "")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = serveAs(handler, "a-key", http.MethodDelete, "/site/car-park/cs/cs001", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	rr = serveAs(handler, "a-key", http.MethodDelete, "/site/car-park", "")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestTagChargeStations(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs001", &store.ChargeStationLiveness{LastSeen: now}))
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs002", &store.ChargeStationLiveness{LastSeen: now}))
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/tag/dc", `{"description":"DC chargers"}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodPost, "/tag/dc/cs/cs001", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveAs(handler, "a-key", http.MethodPost, "/tag/dc/cs/cs001", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var chargeStation api.ChargeStation
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &chargeStation))
+	require.NotNil(t, chargeStation.Tags)
+	assert.Equal(t, []string{"dc"}, *chargeStation.Tags)
+
+	// tagging a charge station again does not duplicate the tag
+	rr = serveAs(handler, "a-key", http.MethodPost, "/tag/dc/cs/cs001", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	got, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dc"}, got.Tags)
+
+	var stats api.DashboardSummary
+	rr = serveAs(handler, "a-key", http.MethodGet, "/dashboard?tag=dc", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stats))
+	assert.Equal(t, 1, stats.ChargeStationsOnline)
+
+	// a tag that is assigned to charge stations cannot be deleted
+	rr = serveAs(handler, "a-key", http.MethodDelete, "/tag/dc", "")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = serveAs(handler, "a-key", http.MethodDelete, "/tag/dc/cs/cs001", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	rr = serveAs(handler, "a-key", http.MethodDelete, "/tag/dc", "")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bytes"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"net/http"
+)
+
+func (s *Server) GetTransactionReceipt(w http.ResponseWriter, r *http.Request, csId string, transactionId string, params GetTransactionReceiptParams) {
+	receipt := s.transactionReceipt(w, r, csId, transactionId)
+	if receipt == nil {
+		return
+	}
+
+	if params.Format != nil && *params.Format == ReceiptFormatHtml {
+		var html bytes.Buffer
+		if err := s.receipts.RenderHtml(&html, receipt); err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(html.Bytes())
+		return
+	}
+
+	_ = render.Render(w, r, newTransactionReceipt(receipt))
+}
+
+func (s *Server) EmailTransactionReceipt(w http.ResponseWriter, r *http.Request, csId string, transactionId string) {
+	req := new(ReceiptEmailRequest)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	if s.receipts.Sender == nil {
+		_ = render.Render(w, r, ErrNotImplemented(errors.New("receipts are not emailed")))
+		return
+	}
+
+	receipt := s.transactionReceipt(w, r, csId, transactionId)
+	if receipt == nil {
+		return
+	}
+	if err := s.receipts.Email(r.Context(), string(req.Email), receipt); err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// transactionReceipt returns the receipt of the transaction, or renders an error response
+// and returns nil if the transaction does not exist, belongs to another tenant or has
+// not ended
+func (s *Server) transactionReceipt(w http.ResponseWriter, r *http.Request, csId, transactionId string) *services.Receipt {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return nil
+	}
+	transaction, err := s.store.FindTransaction(r.Context(), csId, transactionId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return nil
+	}
+	if transaction == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return nil
+	}
+
+	receipt, err := s.receipts.NewReceipt(r.Context(), transaction)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return nil
+	}
+	return receipt
+}
+
+func newTransactionReceipt(receipt *services.Receipt) *TransactionReceipt {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	resp := &TransactionReceipt{
+		Seller:          optional(receipt.Seller),
+		ChargeStationId: receipt.ChargeStationId,
+		TransactionId:   receipt.TransactionId,
+		IdToken:         optional(receipt.IdToken),
+		StartTime:       receipt.StartTime,
+		EndTime:         receipt.EndTime,
+		DurationSeconds: int(receipt.Duration.Seconds()),
+		EnergyKwh:       receipt.EnergyKwh,
+		TariffId:        optional(receipt.TariffId),
+		Currency:        optional(receipt.Currency),
+		Lines:           make([]ReceiptLine, len(receipt.Lines)),
+		NetTotal:        receipt.NetTotal,
+		TaxTotal:        receipt.TaxTotal,
+		Total:           receipt.Total,
+	}
+	for i, line := range receipt.Lines {
+		resp.Lines[i] = ReceiptLine{
+			Type:        ReceiptLineType(line.Type),
+			Description: line.Description,
+			NetAmount:   line.NetAmount,
+			TaxRate:     line.TaxRate,
+			TaxAmount:   line.TaxAmount,
+			Unit:        optional(line.Unit),
+		}
+		if line.Unit != "" {
+			quantity := line.Quantity
+			resp.Lines[i].Quantity = &quantity
+		}
+	}
+	return resp
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func createEndedTransaction(t *testing.T, engine store.Engine, csId, transactionId string) {
+	ctx := context.Background()
+	start := []store.MeterValue{{Timestamp: "2023-05-05T12:00:00Z"}}
+	err := engine.CreateTransaction(ctx, csId, transactionId, "SOMERFID", "ISO14443", start, 0, false)
+	require.NoError(t, err)
+	end := []store.MeterValue{{Timestamp: "2023-05-05T13:00:00Z"}}
+	err = engine.EndTransaction(ctx, csId, transactionId, "SOMERFID", "ISO14443", end, 1)
+	require.NoError(t, err)
+	err = engine.SetTransactionCost(ctx, csId, transactionId, &store.CostBreakdown{
+		Currency:   "GBP",
+		SessionFee: 1,
+		TimeCost:   2.5,
+		TotalCost:  3.5,
+		Periods:    []store.CostPeriod{{ChargingHours: 1}},
+	})
+	require.NoError(t, err)
+}
+
+func TestGetTransactionReceipt(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+	createEndedTransaction(t, engine, "cs001", "1234")
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/cs001/1234/receipt", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var receipt api.TransactionReceipt
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &receipt))
+	assert.Equal(t, "cs001", receipt.ChargeStationId)
+	assert.Equal(t, "1234", receipt.TransactionId)
+	assert.Equal(t, 3600, receipt.DurationSeconds)
+	require.Len(t, receipt.Lines, 2)
+	assert.Equal(t, api.ReceiptLineTypeFlat, receipt.Lines[0].Type)
+	assert.Nil(t, receipt.Lines[0].Quantity)
+	assert.Equal(t, api.ReceiptLineTypeTime, receipt.Lines[1].Type)
+	require.NotNil(t, receipt.Lines[1].Quantity)
+	assert.Equal(t, 1.0, *receipt.Lines[1].Quantity)
+	assert.Equal(t, 3.5, receipt.NetTotal)
+	assert.Equal(t, 3.5, receipt.Total)
+
+	req = httptest.NewRequest(http.MethodGet, "/transactions/cs001/1234/receipt?format=html", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), "Charging time")
+}
+
+func TestGetTransactionReceiptForATransactionThatHasNotEnded(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+	err := engine.CreateTransaction(context.Background(), "cs001", "1234", "SOMERFID", "ISO14443", nil, 0, false)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/cs001/1234/receipt", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/transactions/cs001/5678/receipt", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestTransactionReceiptsAreScopedToTenants(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	rr := serveAs(handler, "a-key", http.MethodPost, "/cs/cs001", `{"securityProfile":0}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	createEndedTransaction(t, engine, "cs001", "1234")
+
+	rr = serveAs(handler, "a-key", http.MethodGet, "/transactions/cs001/1234/receipt", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodGet, "/transactions/cs001/1234/receipt", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+type fakeReceiptSender struct {
+	email string
+	html  string
+}
+
+func (f *fakeReceiptSender) SendReceipt(_ context.Context, email string, _ *services.Receipt, html string) error {
+	f.email, f.html = email, html
+	return nil
+}
+
+func TestEmailTransactionReceipt(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	sender := &fakeReceiptSender{}
+	srv, err := api.NewServer(engine, clock.RealClock{}, nil,
+		api.WithReceipts(&services.ReceiptService{Tariffs: engine, Sender: sender}))
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Use(api.ValidationMiddleware)
+	r.Mount("/", api.Handler(srv))
+	createEndedTransaction(t, engine, "cs001", "1234")
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/cs001/1234/receipt/email", strings.NewReader(`{"email":"driver@example.com"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "driver@example.com", sender.email)
+	assert.Contains(t, sender.html, "Session fee")
+}
+
+func TestEmailTransactionReceiptWithoutASender(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+	createEndedTransaction(t, engine, "cs001", "1234")
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/cs001/1234/receipt/email", strings.NewReader(`{"email":"driver@example.com"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotImplemented, rr.Code)
+}
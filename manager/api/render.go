@@ -4,6 +4,14 @@ package api
 
 import "net/http"
 
+func (c ChargeStation) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationDetails) Bind(r *http.Request) error {
+	return nil
+}
+
 func (c ChargeStationAuth) Bind(r *http.Request) error {
 	return nil
 }
@@ -20,10 +28,38 @@ func (c ChargeStationInstallCertificates) Bind(r *http.Request) error {
 	return nil
 }
 
+func (c ChargeStationInstalledCertificates) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationLiveness) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationRegistration) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationRegistration) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
 func (c ChargeStationTrigger) Bind(r *http.Request) error {
 	return nil
 }
 
+func (c Reservation) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c Reservation) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ReservationTransferRequest) Bind(r *http.Request) error {
+	return nil
+}
+
 func (t Token) Bind(r *http.Request) error {
 	return nil
 }
@@ -47,3 +83,91 @@ func (r Registration) Bind(req *http.Request) error {
 func (r Location) Bind(req *http.Request) error {
 	return nil
 }
+
+func (r Tariff) Bind(req *http.Request) error {
+	return nil
+}
+
+func (d DashboardSummary) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (f FleetStats) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (t Transaction) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c OcppAction) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c OcppActionUpdate) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c NearbyChargeStation) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationEvse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationConnector) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationConnectorUpdate) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c ChargeStationAvailability) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c CommandAuditRecord) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c BulkCommandRequest) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c BulkCommand) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c SiteDetails) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c Site) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c TagDetails) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c Tag) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c CommandScheduleRequest) Bind(r *http.Request) error {
+	return nil
+}
+
+func (c CommandSchedule) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c TransactionReceipt) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c ReceiptEmailRequest) Bind(r *http.Request) error {
+	return nil
+}
@@ -3,37 +3,86 @@
 package api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/events"
+	routing "github.com/thoughtworks/maeve-csms/manager/handlers"
 	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
 	"net/http"
+	"net/mail"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/render"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"k8s.io/utils/clock"
 )
 
 type Server struct {
-	store   store.Engine
-	clock   clock.PassiveClock
-	swagger *openapi3.T
-	ocpi    ocpi.Api
+	store       store.Engine
+	clock       clock.PassiveClock
+	swagger     *openapi3.T
+	ocpi        ocpi.Api
+	actionFlags *routing.ActionFlags
+	eventStream *events.Broadcaster
+	receipts    *services.ReceiptService
 }
 
-func NewServer(engine store.Engine, clock clock.PassiveClock, ocpi ocpi.Api) (*Server, error) {
+type ServerOpt func(*Server)
+
+// WithActionFlags allows the OCPP actions to be enabled and disabled through the API
+func WithActionFlags(flags *routing.ActionFlags) ServerOpt {
+	return func(s *Server) {
+		s.actionFlags = flags
+	}
+}
+
+// WithEventStream allows callers to subscribe to the events published through the
+// broadcaster
+func WithEventStream(broadcaster *events.Broadcaster) ServerOpt {
+	return func(s *Server) {
+		s.eventStream = broadcaster
+	}
+}
+
+// WithReceipts sets the service that produces the receipts of transactions: without it
+// receipts are priced with the transaction's stored cost, are not taxed and cannot be
+// emailed
+func WithReceipts(receipts *services.ReceiptService) ServerOpt {
+	return func(s *Server) {
+		if receipts != nil {
+			s.receipts = receipts
+		}
+	}
+}
+
+func NewServer(engine store.Engine, clock clock.PassiveClock, ocpi ocpi.Api, opts ...ServerOpt) (*Server, error) {
 	swagger, err := GetSwagger()
 	if err != nil {
 		return nil, err
 	}
-	return &Server{
+	s := &Server{
 		store:   engine,
 		clock:   clock,
 		ocpi:    ocpi,
 		swagger: swagger,
-	}, nil
+		receipts: &services.ReceiptService{
+			Tariffs: engine,
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 func (s *Server) RegisterChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
@@ -51,20 +100,246 @@ func (s *Server) RegisterChargeStation(w http.ResponseWriter, r *http.Request, c
 	if req.InvalidUsernameAllowed != nil {
 		invalidUsernameAllowed = *req.InvalidUsernameAllowed
 	}
-	err := s.store.SetChargeStationAuth(r.Context(), csId, &store.ChargeStationAuth{
+
+	// a charge station that is registered by a caller that is scoped to a tenant
+	// belongs to the tenant
+	chargeStation, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if chargeStation == nil {
+		chargeStation = &store.ChargeStation{TenantId: tenantOf(r)}
+	} else if !inTenant(r, chargeStation.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	err = s.store.SetChargeStationAuth(r.Context(), csId, &store.ChargeStationAuth{
 		SecurityProfile:        store.SecurityProfile(req.SecurityProfile),
 		Base64SHA256Password:   pwd,
 		InvalidUsernameAllowed: invalidUsernameAllowed,
 	})
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	chargeStation.SecurityProfile = store.SecurityProfile(req.SecurityProfile)
+	err = s.store.SetChargeStation(r.Context(), csId, chargeStation)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
 		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
 }
 
+// lookupOrNewChargeStation returns the charge station's registry entry or a new entry
+// if the charge station is not in the registry
+func (s *Server) lookupOrNewChargeStation(r *http.Request, csId string) (*store.ChargeStation, error) {
+	chargeStation, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil {
+		return nil, err
+	}
+	if chargeStation == nil {
+		chargeStation = &store.ChargeStation{}
+	}
+	return chargeStation, nil
+}
+
+func (s *Server) ListChargeStations(w http.ResponseWriter, r *http.Request, params ListChargeStationsParams) {
+	offset := 0
+	limit := 20
+
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	var chargeStations []*store.ChargeStation
+	var err error
+	if params.SiteId != nil || params.Tag != nil {
+		var group *store.ChargeStationGroup
+		group, err = s.chargeStationGroup(r, params.SiteId, params.Tag)
+		if err == nil {
+			chargeStations, err = store.ListGroupChargeStations(r.Context(), s.store, group, offset, limit)
+		}
+	} else if tenant := tenantOf(r); tenant != "" {
+		chargeStations, err = store.ListTenantChargeStations(r.Context(), s.store, tenant, offset, limit)
+	} else {
+		chargeStations, err = s.store.ListChargeStations(r.Context(), offset, limit)
+	}
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, len(chargeStations))
+	for i, chargeStation := range chargeStations {
+		resp[i] = newChargeStation(chargeStation)
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) ListChargeStationsNearby(w http.ResponseWriter, r *http.Request, params ListChargeStationsNearbyParams) {
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	chargeStations, err := s.store.ListChargeStationsNearby(r.Context(), params.Lat, params.Lng, params.Radius)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	resp := make([]render.Renderer, 0, limit)
+	for _, chargeStation := range chargeStations {
+		if len(resp) == limit {
+			break
+		}
+		if !inTenant(r, chargeStation.TenantId) {
+			continue
+		}
+		// the engines only return charge stations with valid coordinates
+		lat, lng, _ := chargeStation.Coordinates.Parse()
+		resp = append(resp, &NearbyChargeStation{
+			ChargeStation: *newChargeStation(chargeStation),
+			Distance:      store.Distance(params.Lat, params.Lng, lat, lng),
+		})
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) LookupChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	chargeStation, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if chargeStation == nil || !inTenant(r, chargeStation.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, newChargeStation(chargeStation))
+}
+
+func (s *Server) UpdateChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	req := new(ChargeStationDetails)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	chargeStation, err := s.lookupOrNewChargeStation(r, csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	chargeStation.SiteId = ""
+	if req.SiteId != nil {
+		chargeStation.SiteId = *req.SiteId
+	}
+	chargeStation.Coordinates = nil
+	if req.Coordinates != nil {
+		chargeStation.Coordinates = &store.GeoLocation{
+			Latitude:  req.Coordinates.Latitude,
+			Longitude: req.Coordinates.Longitude,
+		}
+	}
+	chargeStation.TariffId = ""
+	if req.TariffId != nil {
+		tariff, err := s.store.LookupTariff(r.Context(), *req.TariffId)
+		if err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
+			return
+		}
+		if tariff == nil {
+			_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("unknown tariff: %s", *req.TariffId)))
+			return
+		}
+		chargeStation.TariffId = *req.TariffId
+	}
+
+	err = s.store.SetChargeStation(r.Context(), csId, chargeStation)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	chargeStation.ChargeStationId = csId
+	_ = render.Render(w, r, newChargeStation(chargeStation))
+}
+
+func (s *Server) DeleteChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	err := s.store.DeleteChargeStation(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newChargeStation(chargeStation *store.ChargeStation) *ChargeStation {
+	resp := &ChargeStation{
+		Id:              chargeStation.ChargeStationId,
+		SecurityProfile: int(chargeStation.SecurityProfile),
+	}
+	if chargeStation.OcppVersion != "" {
+		resp.OcppVersion = &chargeStation.OcppVersion
+	}
+	if chargeStation.Vendor != "" {
+		resp.Vendor = &chargeStation.Vendor
+	}
+	if chargeStation.Model != "" {
+		resp.Model = &chargeStation.Model
+	}
+	if chargeStation.SerialNumber != "" {
+		resp.SerialNumber = &chargeStation.SerialNumber
+	}
+	if chargeStation.FirmwareVersion != "" {
+		resp.FirmwareVersion = &chargeStation.FirmwareVersion
+	}
+	if chargeStation.SiteId != "" {
+		resp.SiteId = &chargeStation.SiteId
+	}
+	if len(chargeStation.Tags) > 0 {
+		resp.Tags = &chargeStation.Tags
+	}
+	if chargeStation.Coordinates != nil {
+		resp.Coordinates = &GeoLocation{
+			Latitude:  chargeStation.Coordinates.Latitude,
+			Longitude: chargeStation.Coordinates.Longitude,
+		}
+	}
+	if !chargeStation.LastBoot.IsZero() {
+		resp.LastBoot = &chargeStation.LastBoot
+	}
+	if chargeStation.TariffId != "" {
+		resp.TariffId = &chargeStation.TariffId
+	}
+	return resp
+}
+
 func (s *Server) ReconfigureChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
 	req := new(ChargeStationSettings)
 	if err := render.Bind(r, req); err != nil {
 		_ = render.Render(w, r, ErrInvalidRequest(err))
@@ -79,16 +354,25 @@ func (s *Server) ReconfigureChargeStation(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	if err := s.auditCommand(r, csId, "reconfigureChargeStation", req); err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
 	err := s.store.UpdateChargeStationSettings(r.Context(), csId, &store.ChargeStationSettings{
 		Settings: chargeStationSettings,
 	})
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 }
 
 func (s *Server) InstallChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
 	req := new(ChargeStationInstallCertificates)
 	if err := render.Bind(r, req); err != nil {
 		_ = render.Render(w, r, ErrInvalidRequest(err))
@@ -116,20 +400,137 @@ func (s *Server) InstallChargeStationCertificates(w http.ResponseWriter, r *http
 		})
 	}
 
+	if err := s.auditCommand(r, csId, "installChargeStationCertificates", req); err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
 	err := s.store.UpdateChargeStationInstallCertificates(r.Context(), csId, &store.ChargeStationInstallCertificates{
 		ChargeStationId: csId,
 		Certificates:    certs,
 	})
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 }
 
+func (s *Server) ListInstalledChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	installed, err := s.store.LookupChargeStationInstalledCertificates(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if installed == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	resp := &ChargeStationInstalledCertificates{
+		Certificates: make([]InstalledCertificate, 0, len(installed.Certificates)),
+	}
+	for _, cert := range installed.Certificates {
+		resp.Certificates = append(resp.Certificates, InstalledCertificate{
+			Type:           InstalledCertificateType(cert.CertificateType),
+			HashAlgorithm:  InstalledCertificateHashAlgorithm(cert.HashAlgorithm),
+			IssuerNameHash: cert.IssuerNameHash,
+			IssuerKeyHash:  cert.IssuerKeyHash,
+			SerialNumber:   cert.SerialNumber,
+			Status:         InstalledCertificateStatus(cert.Status),
+		})
+	}
+	if installed.RefreshStatus != "" {
+		refreshStatus := ChargeStationInstalledCertificatesRefreshStatus(installed.RefreshStatus)
+		resp.RefreshStatus = &refreshStatus
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
+func (s *Server) RefreshInstalledChargeStationCertificates(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	installed, err := s.store.LookupChargeStationInstalledCertificates(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if installed == nil {
+		installed = &store.ChargeStationInstalledCertificates{
+			ChargeStationId: csId,
+		}
+	}
+	installed.RefreshStatus = store.InstalledCertificatesRefreshPending
+	installed.SendAfter = time.Time{}
+
+	if err := s.auditCommand(r, csId, "refreshInstalledChargeStationCertificates", nil); err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	err = s.store.SetChargeStationInstalledCertificates(r.Context(), csId, installed)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) DeleteInstalledChargeStationCertificate(w http.ResponseWriter, r *http.Request, csId string, serialNumber string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	installed, err := s.store.LookupChargeStationInstalledCertificates(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	found := false
+	if installed != nil {
+		for _, cert := range installed.Certificates {
+			if cert.SerialNumber == serialNumber {
+				cert.Status = store.InstalledCertificateStatusDeletePending
+				cert.SendAfter = time.Time{}
+				found = true
+			}
+		}
+	}
+	if !found {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	if err := s.auditCommand(r, csId, "deleteInstalledChargeStationCertificate", map[string]string{"serialNumber": serialNumber}); err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	err = s.store.SetChargeStationInstalledCertificates(r.Context(), csId, installed)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
 func (s *Server) LookupChargeStationAuth(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
 	auth, err := s.store.LookupChargeStationAuth(r.Context(), csId)
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 	if auth == nil {
@@ -148,25 +549,375 @@ func (s *Server) LookupChargeStationAuth(w http.ResponseWriter, r *http.Request,
 	_ = render.Render(w, r, resp)
 }
 
+func (s *Server) SetChargeStationRegistration(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	req := new(ChargeStationRegistration)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	previous, err := s.store.LookupChargeStationRegistration(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	err = s.store.SetChargeStationRegistration(r.Context(), csId, &store.ChargeStationRegistration{
+		Status: store.RegistrationStatus(req.Status),
+	})
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	// ask a charge station that has been waiting for approval to boot again
+	if previous != nil && previous.Status != store.RegistrationStatusAccepted &&
+		req.Status == ChargeStationRegistrationStatusAccepted {
+		err = s.store.SetChargeStationTriggerMessage(r.Context(), csId, &store.ChargeStationTriggerMessage{
+			TriggerMessage: store.TriggerMessageBootNotification,
+			TriggerStatus:  store.TriggerStatusPending,
+		})
+		if err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) LookupChargeStationRegistration(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	registration, err := s.store.LookupChargeStationRegistration(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if registration == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, &ChargeStationRegistration{
+		Status: ChargeStationRegistrationStatus(registration.Status),
+	})
+}
+
+func (s *Server) LookupChargeStationLiveness(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	liveness, err := s.store.LookupChargeStationLiveness(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if liveness == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	resp := &ChargeStationLiveness{
+		Status:   Online,
+		LastSeen: liveness.LastSeen,
+	}
+	if liveness.Offline {
+		resp.Status = Offline
+	}
+	if !liveness.LastHeartbeat.IsZero() {
+		resp.LastHeartbeat = &liveness.LastHeartbeat
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
+func (s *Server) ListChargeStationEvses(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	evses, err := s.store.ListEvses(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	resp := make([]render.Renderer, len(evses))
+	for i, evse := range evses {
+		resp[i] = newChargeStationEvse(evse)
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) UpdateChargeStationConnector(w http.ResponseWriter, r *http.Request, csId string, evseId int, connectorId int) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	req := new(ChargeStationConnectorUpdate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	evse, err := s.store.LookupEvse(r.Context(), csId, evseId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if evse == nil {
+		evse = &store.ChargeStationEvse{
+			ChargeStationId: csId,
+			EvseId:          evseId,
+		}
+	}
+	connector := evse.AddConnector(connectorId)
+	if req.ConnectorType != nil {
+		connector.ConnectorType = *req.ConnectorType
+	}
+	if req.MaxPower != nil {
+		connector.MaxPower = *req.MaxPower
+	}
+	if req.PhysicalLabel != nil {
+		connector.PhysicalLabel = *req.PhysicalLabel
+	}
+	resp := newChargeStationConnector(connector)
+	evse.LastUpdated = s.clock.Now()
+
+	err = s.store.SetEvse(r.Context(), evse)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
+func newChargeStationEvse(evse *store.ChargeStationEvse) *ChargeStationEvse {
+	resp := &ChargeStationEvse{
+		EvseId:      evse.EvseId,
+		Connectors:  make([]ChargeStationConnector, len(evse.Connectors)),
+		LastUpdated: evse.LastUpdated,
+	}
+	for i := range evse.Connectors {
+		resp.Connectors[i] = *newChargeStationConnector(&evse.Connectors[i])
+	}
+	return resp
+}
+
+func newChargeStationConnector(connector *store.ChargeStationConnector) *ChargeStationConnector {
+	resp := &ChargeStationConnector{
+		ConnectorId: connector.ConnectorId,
+	}
+	if connector.ConnectorType != "" {
+		resp.ConnectorType = &connector.ConnectorType
+	}
+	if connector.MaxPower != 0 {
+		resp.MaxPower = &connector.MaxPower
+	}
+	if connector.PhysicalLabel != "" {
+		resp.PhysicalLabel = &connector.PhysicalLabel
+	}
+	return resp
+}
+
+func (s *Server) GetChargeStationAvailability(w http.ResponseWriter, r *http.Request, csId string, params GetChargeStationAvailabilityParams) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	now := s.clock.Now()
+	from := now
+	if params.From != nil && params.From.After(now) {
+		from = *params.From
+	}
+	to := from.Add(24 * time.Hour)
+	if params.To != nil {
+		to = *params.To
+	}
+	if !to.After(from) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("to must be after the start of the period")))
+		return
+	}
+	if to.Sub(from) > 7*24*time.Hour {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("period must be at most 7 days")))
+		return
+	}
+
+	availability, err := store.ChargeStationAvailability(r.Context(), s.store, csId, from, to, now)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	resp := make([]render.Renderer, len(availability))
+	for i, evse := range availability {
+		resp[i] = newChargeStationAvailability(evse)
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func newChargeStationAvailability(evse *store.EvseAvailability) *ChargeStationAvailability {
+	resp := &ChargeStationAvailability{
+		EvseId:  evse.EvseId,
+		Windows: make([]ChargeStationAvailabilityWindow, len(evse.Windows)),
+	}
+	if evse.ConnectorId != 0 {
+		resp.ConnectorId = &evse.ConnectorId
+	}
+	for i, window := range evse.Windows {
+		resp.Windows[i] = ChargeStationAvailabilityWindow{
+			Start:  window.Start,
+			End:    window.End,
+			Status: ChargeStationAvailabilityWindowStatus(window.Status),
+		}
+	}
+	return resp
+}
+
 func (s *Server) TriggerChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
 	req := new(ChargeStationTrigger)
 	if err := render.Bind(r, req); err != nil {
 		_ = render.Render(w, r, ErrInvalidRequest(err))
 		return
 	}
 
+	if err := s.auditCommand(r, csId, "triggerChargeStation", req); err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
 	err := s.store.SetChargeStationTriggerMessage(r.Context(), csId, &store.ChargeStationTriggerMessage{
 		TriggerMessage: store.TriggerMessage(req.Trigger),
 		TriggerStatus:  store.TriggerStatusPending,
 	})
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
 }
 
+func (s *Server) CreateReservation(w http.ResponseWriter, r *http.Request, csId string) {
+	if !s.checkChargeStationTenant(w, r, csId) {
+		return
+	}
+
+	req := new(Reservation)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	now := s.clock.Now()
+	if !req.ExpiryDate.After(now) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("reservation expiry date must be in the future")))
+		return
+	}
+	if req.StartDate != nil && !req.StartDate.Before(req.ExpiryDate) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("reservation start date must be before the expiry date")))
+		return
+	}
+
+	contact, err := newReservationContact(req.Contact)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	if !s.checkReservationsSupported(w, r, csId) {
+		return
+	}
+
+	tenant, err := s.chargeStationTenant(r, csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	existing, err := s.store.LookupReservation(r.Context(), req.Id)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if existing != nil {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("reservation %d already exists", req.Id)))
+		return
+	}
+
+	reservation := &store.Reservation{
+		ReservationId:   req.Id,
+		ChargeStationId: csId,
+		EvseId:          req.EvseId,
+		IdToken:         req.IdToken,
+		TokenType:       string(req.TokenType),
+		ExpiryDate:      req.ExpiryDate.UTC(),
+		StartDate:       now.UTC(),
+		Status:          store.ReservationStatusPending,
+		Contact:         contact,
+		TenantId:        tenant,
+	}
+	if req.StartDate != nil && req.StartDate.After(now) {
+		// the reservation is sent by the sync once the lead time is reached
+		reservation.StartDate = req.StartDate.UTC()
+		reservation.SendAfter = reservation.StartDate.Add(-store.ReservationLeadTime)
+	}
+
+	overlapping, err := store.OverlappingReservation(r.Context(), s.store, reservation, now)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if overlapping != nil {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("reservation overlaps reservation %d", overlapping.ReservationId)))
+		return
+	}
+
+	if err = s.auditCommand(r, csId, "createReservation", req); err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	err = s.store.SetReservation(r.Context(), reservation)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// checkReservationsSupported renders an error response and returns false if the
+// charge station is unknown or does not support reservations.
+func (s *Server) checkReservationsSupported(w http.ResponseWriter, r *http.Request, csId string) bool {
+	details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return false
+	}
+	if details == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return false
+	}
+	if details.OcppVersion == "1.6" {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("reservations not supported for OCPP 1.6")))
+		return false
+	}
+	return true
+}
+
 func (s *Server) SetToken(w http.ResponseWriter, r *http.Request) {
 	req := new(Token)
 	if err := render.Bind(r, req); err != nil {
@@ -174,13 +925,61 @@ func (s *Server) SetToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	normContractId, err := ocpp.NormalizeEmaid(req.ContractId)
+	tok, err := s.newStoreToken(req)
 	if err != nil {
 		_ = render.Render(w, r, ErrInvalidRequest(err))
 		return
 	}
 
-	err = s.store.SetToken(r.Context(), &store.Token{
+	// a token keeps the tenant that it was first registered by
+	existing, err := s.store.LookupToken(r.Context(), tok.Uid)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if existing == nil {
+		tok.TenantId = tenantOf(r)
+	} else if inTenant(r, existing.TenantId) {
+		tok.TenantId = existing.TenantId
+		tok.Version = existing.Version
+	} else {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("token %s belongs to another tenant", tok.Uid)))
+		return
+	}
+
+	err = s.store.SetToken(r.Context(), tok)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// newStoreToken validates the token and converts it to the stored representation
+func (s *Server) newStoreToken(req *Token) (*store.Token, error) {
+	return NewStoreToken(req, s.clock.Now())
+}
+
+// NewStoreToken validates the token and converts it to the stored representation, last
+// updated at the given time
+func NewStoreToken(req *Token, now time.Time) (*store.Token, error) {
+	normContractId, err := ocpp.NormalizeEmaid(req.ContractId)
+	if err != nil {
+		return nil, err
+	}
+	if hash, ok := strings.CutPrefix(req.Uid, store.HashedTokenUidPrefix); ok {
+		// the token is registered by the hash of its id token
+		if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("hashed token uid %s is not a hexadecimal sha256 hash", req.Uid)
+		}
+	} else if req.Type == RFID {
+		if strings.Trim(req.Uid, "0123456789abcdefABCDEF") != "" {
+			return nil, fmt.Errorf("rfid token uid %s is not hexadecimal", req.Uid)
+		}
+	}
+
+	return &store.Token{
 		CountryCode:  req.CountryCode,
 		PartyId:      req.PartyId,
 		Type:         string(req.Type),
@@ -192,14 +991,122 @@ func (s *Server) SetToken(w http.ResponseWriter, r *http.Request) {
 		Valid:        req.Valid,
 		LanguageCode: req.LanguageCode,
 		CacheMode:    string(req.CacheMode),
-		LastUpdated:  s.clock.Now().Format(time.RFC3339),
-	})
+		LastUpdated:  now.Format(time.RFC3339),
+	}, nil
+}
+
+// tokenETag identifies the version of a token: it changes whenever any of the token's
+// fields change
+func tokenETag(tok *store.Token) (string, error) {
+	b, err := json.Marshal(tok)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(b)
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(hash[:16])), nil
+}
+
+// lookupTokenForUpdate returns the token that is to be changed, rendering an error
+// response and returning nil if it does not exist or does not match the If-Match header
+func (s *Server) lookupTokenForUpdate(w http.ResponseWriter, r *http.Request, tokenUid string, ifMatch *string) *store.Token {
+	tok, err := s.store.LookupToken(r.Context(), tokenUid)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return nil
+	}
+	if tok == nil || !inTenant(r, tok.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return nil
+	}
+	if ifMatch != nil && *ifMatch != "*" {
+		etag, err := tokenETag(tok)
+		if err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
+			return nil
+		}
+		if *ifMatch != etag {
+			_ = render.Render(w, r, ErrPreconditionFailed(fmt.Errorf("token %s has been changed", tokenUid)))
+			return nil
+		}
+	}
+	return tok
+}
+
+func (s *Server) UpdateToken(w http.ResponseWriter, r *http.Request, tokenUid string, params UpdateTokenParams) {
+	req := new(Token)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	if req.Uid != tokenUid {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("token uid %s does not match %s", req.Uid, tokenUid)))
+		return
+	}
+
+	tok, err := s.newStoreToken(req)
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrInvalidRequest(err))
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	existing := s.lookupTokenForUpdate(w, r, tokenUid, params.IfMatch)
+	if existing == nil {
+		return
+	}
+	tok.TenantId = existing.TenantId
+	tok.Version = existing.Version
+
+	err = s.store.SetToken(r.Context(), tok)
+	if err != nil {
+		_ = render.Render(w, r, errTokenWrite(tokenUid, err))
+		return
+	}
+
+	s.renderToken(w, r, tok)
+}
+
+func (s *Server) RevokeToken(w http.ResponseWriter, r *http.Request, tokenUid string, params RevokeTokenParams) {
+	tok := s.lookupTokenForUpdate(w, r, tokenUid, params.IfMatch)
+	if tok == nil {
+		return
+	}
+
+	tok.Valid = false
+	tok.LastUpdated = s.clock.Now().Format(time.RFC3339)
+	err := s.store.SetToken(r.Context(), tok)
+	if err != nil {
+		_ = render.Render(w, r, errTokenWrite(tokenUid, err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// errTokenWrite returns the response to a failure to write a token that was read by
+// lookupTokenForUpdate: a token that was changed after it was read no longer matches
+// the If-Match header
+func errTokenWrite(tokenUid string, err error) render.Renderer {
+	if errors.Is(err, store.ErrVersionConflict) {
+		return ErrPreconditionFailed(fmt.Errorf("token %s has been changed", tokenUid))
+	}
+	return ErrFromError(err)
+}
+
+// renderToken renders the token with its ETag
+func (s *Server) renderToken(w http.ResponseWriter, r *http.Request, tok *store.Token) {
+	etag, err := tokenETag(tok)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	resp, err := newToken(tok)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	_ = render.Render(w, r, resp)
 }
 
 func newToken(tok *store.Token) (*Token, error) {
@@ -227,21 +1134,15 @@ func newToken(tok *store.Token) (*Token, error) {
 func (s *Server) LookupToken(w http.ResponseWriter, r *http.Request, tokenUid string) {
 	tok, err := s.store.LookupToken(r.Context(), tokenUid)
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
-	if tok == nil {
+	if tok == nil || !inTenant(r, tok.TenantId) {
 		_ = render.Render(w, r, ErrNotFound)
 		return
 	}
 
-	resp, err := newToken(tok)
-	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
-		return
-	}
-
-	_ = render.Render(w, r, resp)
+	s.renderToken(w, r, tok)
 }
 
 func (s *Server) ListTokens(w http.ResponseWriter, r *http.Request, params ListTokensParams) {
@@ -258,9 +1159,15 @@ func (s *Server) ListTokens(w http.ResponseWriter, r *http.Request, params ListT
 		limit = 100
 	}
 
-	tokens, err := s.store.ListTokens(r.Context(), offset, limit)
+	var tokens []*store.Token
+	var err error
+	if tenant := tenantOf(r); tenant != "" {
+		tokens, err = store.ListTenantTokens(r.Context(), s.store, tenant, offset, limit)
+	} else {
+		tokens, err = s.store.ListTokens(r.Context(), offset, limit)
+	}
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 
@@ -268,14 +1175,171 @@ func (s *Server) ListTokens(w http.ResponseWriter, r *http.Request, params ListT
 	for i, tok := range tokens {
 		resp[i], err = newToken(tok)
 		if err != nil {
-			_ = render.Render(w, r, ErrInternalError(err))
+			_ = render.Render(w, r, ErrFromError(err))
+			return
+		}
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) ListTransactions(w http.ResponseWriter, r *http.Request, params ListTransactionsParams) {
+	offset := 0
+	limit := 20
+
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	filter := &store.TransactionFilter{
+		StartedFrom:   params.From,
+		StartedBefore: params.To,
+	}
+	if params.ChargeStationId != nil {
+		filter.ChargeStationId = *params.ChargeStationId
+	}
+	if params.IdToken != nil {
+		filter.IdToken = *params.IdToken
+	}
+	if params.Status != nil {
+		switch *params.Status {
+		case ListTransactionsParamsStatusActive, ListTransactionsParamsStatusEnded:
+			filter.Status = store.TransactionStatus(*params.Status)
+		default:
+			_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("unknown transaction status: %s", *params.Status)))
+			return
+		}
+	}
+
+	err := s.tenantTransactionFilter(r, filter)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	transactions, err := s.store.QueryTransactions(r.Context(), filter, offset, limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	var resp = make([]render.Renderer, len(transactions))
+	for i, transaction := range transactions {
+		resp[i], err = newTransaction(transaction)
+		if err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
 			return
 		}
 	}
 	_ = render.RenderList(w, r, resp)
 }
 
+func newTransaction(transaction *store.Transaction) (*Transaction, error) {
+	meterValues := make([]MeterValue, len(transaction.MeterValues))
+	for i, mv := range transaction.MeterValues {
+		timestamp, err := time.Parse(time.RFC3339, mv.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s/%s meter value timestamp: %w",
+				transaction.ChargeStationId, transaction.TransactionId, err)
+		}
+		sampledValues := make([]SampledValue, len(mv.SampledValues))
+		for j, sv := range mv.SampledValues {
+			sampledValues[j] = SampledValue{
+				Context:   sv.Context,
+				Location:  sv.Location,
+				Measurand: sv.Measurand,
+				Phase:     sv.Phase,
+				Value:     float32(sv.Value),
+			}
+			if sv.UnitOfMeasure != nil {
+				sampledValues[j].Unit = &sv.UnitOfMeasure.Unit
+				sampledValues[j].Multiplier = &sv.UnitOfMeasure.Multipler
+			}
+		}
+		meterValues[i] = MeterValue{
+			Timestamp:     timestamp,
+			SampledValues: sampledValues,
+		}
+	}
+
+	resp := &Transaction{
+		ChargeStationId: transaction.ChargeStationId,
+		TransactionId:   transaction.TransactionId,
+		Status:          TransactionStatus(transaction.Status()),
+		Offline:         transaction.Offline,
+		MeterValues:     meterValues,
+	}
+	if transaction.IdToken != "" {
+		resp.IdToken = &transaction.IdToken
+	}
+	if transaction.TokenType != "" {
+		resp.TokenType = &transaction.TokenType
+	}
+	if startTime, ok := transaction.StartTime(); ok {
+		resp.StartTime = &startTime
+	}
+	if transaction.Cost != nil {
+		cost, err := newTransactionCost(transaction.Cost)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s/%s cost: %w",
+				transaction.ChargeStationId, transaction.TransactionId, err)
+		}
+		resp.Cost = cost
+	}
+	return resp, nil
+}
+
+func newTransactionCost(cost *store.CostBreakdown) (*TransactionCost, error) {
+	periods := make([]TransactionCostPeriod, len(cost.Periods))
+	for i, period := range cost.Periods {
+		startTime, err := time.Parse(time.RFC3339, period.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		endTime, err := time.Parse(time.RFC3339, period.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		periods[i] = TransactionCostPeriod{
+			StartTime:     startTime,
+			EndTime:       endTime,
+			EnergyKwh:     period.EnergyKwh,
+			ChargingHours: period.ChargingHours,
+			ParkingHours:  period.ParkingHours,
+			EnergyPrice:   period.EnergyPrice,
+			TimePrice:     period.TimePrice,
+			ParkingPrice:  period.ParkingPrice,
+			Cost:          period.Cost,
+		}
+	}
+
+	resp := &TransactionCost{
+		SessionFee:  cost.SessionFee,
+		EnergyCost:  cost.EnergyCost,
+		TimeCost:    cost.TimeCost,
+		ParkingCost: cost.ParkingCost,
+		TotalCost:   cost.TotalCost,
+		Periods:     periods,
+	}
+	if cost.TariffId != "" {
+		resp.TariffId = &cost.TariffId
+	}
+	if cost.Currency != "" {
+		resp.Currency = &cost.Currency
+	}
+	return resp, nil
+}
+
 func (s *Server) UploadCertificate(w http.ResponseWriter, r *http.Request) {
+	if !checkNotTenant(w, r) {
+		return
+	}
+
 	req := new(Certificate)
 	if err := render.Bind(r, req); err != nil {
 		_ = render.Render(w, r, ErrInvalidRequest(err))
@@ -284,7 +1348,7 @@ func (s *Server) UploadCertificate(w http.ResponseWriter, r *http.Request) {
 
 	err := s.store.SetCertificate(r.Context(), req.Certificate)
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 
@@ -292,9 +1356,13 @@ func (s *Server) UploadCertificate(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) DeleteCertificate(w http.ResponseWriter, r *http.Request, certificateHash string) {
+	if !checkNotTenant(w, r) {
+		return
+	}
+
 	err := s.store.DeleteCertificate(r.Context(), certificateHash)
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 
@@ -304,7 +1372,7 @@ func (s *Server) DeleteCertificate(w http.ResponseWriter, r *http.Request, certi
 func (s *Server) LookupCertificate(w http.ResponseWriter, r *http.Request, certificateHash string) {
 	cert, err := s.store.LookupCertificate(r.Context(), certificateHash)
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 	if cert == "" {
@@ -319,6 +1387,10 @@ func (s *Server) LookupCertificate(w http.ResponseWriter, r *http.Request, certi
 }
 
 func (s *Server) RegisterParty(w http.ResponseWriter, r *http.Request) {
+	if !checkNotTenant(w, r) {
+		return
+	}
+
 	if s.ocpi == nil {
 		_ = render.Render(w, r, ErrNotFound)
 		return
@@ -333,7 +1405,7 @@ func (s *Server) RegisterParty(w http.ResponseWriter, r *http.Request) {
 	if req.Url != nil {
 		err := s.ocpi.RegisterNewParty(r.Context(), *req.Url, req.Token)
 		if err != nil {
-			_ = render.Render(w, r, ErrInternalError(err))
+			_ = render.Render(w, r, ErrFromError(err))
 			return
 		}
 	} else {
@@ -347,7 +1419,7 @@ func (s *Server) RegisterParty(w http.ResponseWriter, r *http.Request) {
 			Status: status,
 		})
 		if err != nil {
-			_ = render.Render(w, r, ErrInternalError(err))
+			_ = render.Render(w, r, ErrFromError(err))
 			return
 		}
 	}
@@ -356,6 +1428,10 @@ func (s *Server) RegisterParty(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) RegisterLocation(w http.ResponseWriter, r *http.Request, locationId string) {
+	if !checkNotTenant(w, r) {
+		return
+	}
+
 	if s.ocpi == nil {
 		_ = render.Render(w, r, ErrNotFound)
 		return
@@ -407,12 +1483,13 @@ func (s *Server) RegisterLocation(w http.ResponseWriter, r *http.Request, locati
 		Country:     req.Country,
 		Evses:       &storeEvses,
 		Id:          locationId,
+		LastUpdated: now.Format(time.RFC3339),
 		Name:        *req.Name,
 		ParkingType: string(*req.ParkingType),
 		PostalCode:  *req.PostalCode,
 	})
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 
@@ -459,9 +1536,391 @@ func (s *Server) RegisterLocation(w http.ResponseWriter, r *http.Request, locati
 		Publish:     true,
 	})
 	if err != nil {
-		_ = render.Render(w, r, ErrInternalError(err))
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) RegisterTariff(w http.ResponseWriter, r *http.Request, tariffId string) {
+	if !checkNotTenant(w, r) {
+		return
+	}
+
+	req := new(Tariff)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	tariff := &store.Tariff{
+		Id:          tariffId,
+		Currency:    req.Currency,
+		Elements:    make([]store.TariffElement, len(req.Elements)),
+		LastUpdated: s.clock.Now().Format(time.RFC3339),
+	}
+	if req.Type != nil {
+		tariff.Type = string(*req.Type)
+	}
+	if req.StartDateTime != nil {
+		tariff.StartDateTime = req.StartDateTime.UTC().Format(time.RFC3339)
+	}
+	if req.EndDateTime != nil {
+		tariff.EndDateTime = req.EndDateTime.UTC().Format(time.RFC3339)
+	}
+	for i, reqElement := range req.Elements {
+		components := make([]store.PriceComponent, len(reqElement.PriceComponents))
+		for j, reqComponent := range reqElement.PriceComponents {
+			components[j] = store.PriceComponent{
+				Type:     string(reqComponent.Type),
+				Price:    reqComponent.Price,
+				Vat:      reqComponent.Vat,
+				StepSize: reqComponent.StepSize,
+			}
+		}
+		tariff.Elements[i] = store.TariffElement{
+			PriceComponents: components,
+			Restrictions:    newStoreTariffRestrictions(reqElement.Restrictions),
+		}
+	}
+
+	err := s.store.SetTariff(r.Context(), tariff)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
 		return
 	}
 
+	if s.ocpi != nil {
+		err = s.ocpi.PushTariff(r.Context(), tariff)
+		if err != nil {
+			_ = render.Render(w, r, ErrFromError(err))
+			return
+		}
+	}
+
 	w.WriteHeader(http.StatusCreated)
 }
+
+func newStoreTariffRestrictions(restrictions *TariffRestrictions) *store.TariffRestrictions {
+	if restrictions == nil {
+		return nil
+	}
+	result := &store.TariffRestrictions{
+		MinKwh:      restrictions.MinKwh,
+		MaxKwh:      restrictions.MaxKwh,
+		MinDuration: restrictions.MinDuration,
+		MaxDuration: restrictions.MaxDuration,
+	}
+	if restrictions.StartTime != nil {
+		result.StartTime = *restrictions.StartTime
+	}
+	if restrictions.EndTime != nil {
+		result.EndTime = *restrictions.EndTime
+	}
+	if restrictions.StartDate != nil {
+		result.StartDate = *restrictions.StartDate
+	}
+	if restrictions.EndDate != nil {
+		result.EndDate = *restrictions.EndDate
+	}
+	if restrictions.DayOfWeek != nil {
+		for _, day := range *restrictions.DayOfWeek {
+			result.DayOfWeek = append(result.DayOfWeek, string(day))
+		}
+	}
+	return result
+}
+
+// e164Regexp matches a phone number in E.164 format
+var e164Regexp = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// newReservationContact validates the driver's contact details: it returns nil if
+// none were given
+func newReservationContact(contact *ReservationContact) (*store.ReservationContact, error) {
+	if contact == nil || (contact.Email == nil && contact.Phone == nil) {
+		return nil, nil
+	}
+	result := &store.ReservationContact{}
+	if contact.Email != nil {
+		address, err := mail.ParseAddress(*contact.Email)
+		if err != nil || address.Address != *contact.Email {
+			return nil, fmt.Errorf("invalid contact email address: %q", *contact.Email)
+		}
+		result.Email = address.Address
+	}
+	if contact.Phone != nil {
+		if !e164Regexp.MatchString(*contact.Phone) {
+			return nil, fmt.Errorf("invalid contact phone number: %q", *contact.Phone)
+		}
+		result.Phone = *contact.Phone
+	}
+	return result, nil
+}
+
+func newReservation(reservation *store.Reservation) *Reservation {
+	status := ReservationStatus(reservation.Status)
+	resp := &Reservation{
+		Id:              reservation.ReservationId,
+		ChargeStationId: &reservation.ChargeStationId,
+		EvseId:          reservation.EvseId,
+		IdToken:         reservation.IdToken,
+		TokenType:       ReservationTokenType(reservation.TokenType),
+		ExpiryDate:      reservation.ExpiryDate,
+		Status:          &status,
+	}
+	if !reservation.StartDate.IsZero() {
+		resp.StartDate = &reservation.StartDate
+	}
+	if reservation.NoShow {
+		resp.NoShow = &reservation.NoShow
+	}
+	if reservation.NoShowFee != nil {
+		resp.NoShowFee = &ReservationFee{
+			Currency: reservation.NoShowFee.Currency,
+			Amount:   reservation.NoShowFee.TotalCost,
+		}
+	}
+	if reservation.Contact != nil {
+		resp.Contact = &ReservationContact{}
+		if reservation.Contact.Email != "" {
+			resp.Contact.Email = &reservation.Contact.Email
+		}
+		if reservation.Contact.Phone != "" {
+			resp.Contact.Phone = &reservation.Contact.Phone
+		}
+	}
+	if reservation.Transfer != nil {
+		resp.Transfer = &ReservationTransfer{
+			ChargeStationId: reservation.Transfer.ChargeStationId,
+			EvseId:          reservation.Transfer.EvseId,
+			Status:          ReservationTransferStatus(reservation.Transfer.Status),
+		}
+	}
+	return resp
+}
+
+func (s *Server) LookupReservation(w http.ResponseWriter, r *http.Request, reservationId int) {
+	reservation, err := s.store.LookupReservation(r.Context(), reservationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if reservation == nil || !inTenant(r, reservation.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, newReservation(reservation))
+}
+
+func (s *Server) CancelReservation(w http.ResponseWriter, r *http.Request, reservationId int) {
+	reservation, err := s.store.LookupReservation(r.Context(), reservationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if reservation == nil || !inTenant(r, reservation.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+	if isReservationTransferInProgress(reservation) {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("reservation %d is being transferred", reservationId)))
+		return
+	}
+
+	switch reservation.Status {
+	case store.ReservationStatusCancelPending:
+		w.WriteHeader(http.StatusAccepted)
+		return
+	case store.ReservationStatusRejected, store.ReservationStatusCancelled, store.ReservationStatusUsed:
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("reservation %d is %s", reservationId, reservation.Status)))
+		return
+	}
+
+	if err = s.auditCommand(r, reservation.ChargeStationId, "cancelReservation",
+		map[string]int{"reservationId": reservationId}); err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	if reservation.Scheduled(s.clock.Now()) {
+		// the charge station does not know about the reservation yet
+		reservation.Status = store.ReservationStatusCancelled
+	} else {
+		reservation.Status = store.ReservationStatusCancelPending
+		reservation.SendAfter = time.Time{}
+	}
+	err = s.store.SetReservation(r.Context(), reservation)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) TransferReservation(w http.ResponseWriter, r *http.Request, reservationId int) {
+	req := new(ReservationTransferRequest)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	reservation, err := s.store.LookupReservation(r.Context(), reservationId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+	if reservation == nil || !inTenant(r, reservation.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+	if reservation.Status != store.ReservationStatusAccepted {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("reservation %d is %s", reservationId, reservation.Status)))
+		return
+	}
+	if isReservationTransferInProgress(reservation) {
+		_ = render.Render(w, r, ErrConflict(fmt.Errorf("reservation %d is already being transferred", reservationId)))
+		return
+	}
+	if req.ChargeStationId == reservation.ChargeStationId && equalEvseId(req.EvseId, reservation.EvseId) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("reservation %d is already held by the target", reservationId)))
+		return
+	}
+
+	if !s.checkChargeStationTenant(w, r, req.ChargeStationId) || !s.checkReservationsSupported(w, r, req.ChargeStationId) {
+		return
+	}
+
+	if err = s.auditCommand(r, reservation.ChargeStationId, "transferReservation", map[string]any{
+		"reservationId": reservationId, "chargeStationId": req.ChargeStationId, "evseId": req.EvseId}); err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	reservation.Transfer = &store.ReservationTransfer{
+		ChargeStationId: req.ChargeStationId,
+		EvseId:          req.EvseId,
+		Status:          store.ReservationTransferStatusPending,
+	}
+	reservation.SendAfter = time.Time{}
+	err = s.store.SetReservation(r.Context(), reservation)
+	if err != nil {
+		_ = render.Render(w, r, ErrStoreWrite(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func isReservationTransferInProgress(reservation *store.Reservation) bool {
+	return reservation.Transfer != nil && reservation.Transfer.Status != store.ReservationTransferStatusRejected
+}
+
+func equalEvseId(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (s *Server) GetDashboardSummary(w http.ResponseWriter, r *http.Request, params GetDashboardSummaryParams) {
+	stats, now, err := s.fleetStats(r, params.SiteId, params.Tag)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	_ = render.Render(w, r, &DashboardSummary{
+		ChargeStations:             stats.ChargeStationsOnline + stats.ChargeStationsOffline,
+		ChargeStationsOnline:       stats.ChargeStationsOnline,
+		ChargeStationsOffline:      stats.ChargeStationsOffline,
+		ActiveTransactions:         stats.ActiveTransactions,
+		CompletedTransactionsToday: stats.CompletedTransactions,
+		EnergyDeliveredTodayWh:     float32(stats.EnergyDeliveredWh),
+		ActiveReservations:         stats.ActiveReservations,
+		ExpiredReservations:        stats.ExpiredReservations,
+		FaultedConnectors:          stats.FaultedConnectors,
+		GeneratedAt:                now,
+	})
+}
+
+func (s *Server) GetFleetStats(w http.ResponseWriter, r *http.Request, params GetFleetStatsParams) {
+	stats, now, err := s.fleetStats(r, params.SiteId, params.Tag)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return
+	}
+
+	_ = render.Render(w, r, &FleetStats{
+		ChargeStationsOnline:       stats.ChargeStationsOnline,
+		ChargeStationsOffline:      stats.ChargeStationsOffline,
+		ActiveTransactions:         stats.ActiveTransactions,
+		CompletedTransactionsToday: stats.CompletedTransactions,
+		EnergyDeliveredTodayWh:     float32(stats.EnergyDeliveredWh),
+		ActiveReservations:         stats.ActiveReservations,
+		FaultedConnectors:          stats.FaultedConnectors,
+		GeneratedAt:                now,
+	})
+}
+
+// fleetStats returns the FleetStats for the day so far and the time they were computed.
+// The engine aggregates the stats for callers that see every tenant: the stats for a
+// tenant, site or tag are aggregated from the records of its charge stations.
+func (s *Server) fleetStats(r *http.Request, siteId, tag *string) (*store.FleetStats, time.Time, error) {
+	now := s.clock.Now().UTC()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if tenantOf(r) == "" && siteId == nil && tag == nil {
+		stats, err := store.ComputeFleetStats(r.Context(), s.store, startOfDay, now)
+		return stats, now, err
+	}
+	group, err := s.chargeStationGroup(r, siteId, tag)
+	if err != nil {
+		return nil, now, err
+	}
+	ids, err := store.GroupChargeStationIds(r.Context(), s.store, group)
+	if err != nil {
+		return nil, now, err
+	}
+	stats, err := store.AggregateFleetStats(r.Context(), s.store, ids, startOfDay, now)
+	return stats, now, err
+}
+
+func (s *Server) ListOcppActions(w http.ResponseWriter, r *http.Request, ocppVersion ListOcppActionsParamsOcppVersion) {
+	resp := make([]render.Renderer, 0)
+	if s.actionFlags != nil {
+		for _, flag := range s.actionFlags.List(transport.OcppVersion(ocppVersion)) {
+			resp = append(resp, &OcppAction{
+				Action:  flag.Action,
+				Enabled: flag.Enabled,
+			})
+		}
+	}
+	_ = render.RenderList(w, r, resp)
+}
+
+func (s *Server) UpdateOcppAction(w http.ResponseWriter, r *http.Request, ocppVersion UpdateOcppActionParamsOcppVersion, action string) {
+	if !checkNotTenant(w, r) {
+		return
+	}
+
+	req := new(OcppActionUpdate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	version := transport.OcppVersion(ocppVersion)
+	if s.actionFlags == nil || !s.actionFlags.Known(version, action) {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+	s.actionFlags.SetEnabled(version, action, req.Enabled)
+
+	_ = render.Render(w, r, &OcppAction{
+		Action:  action,
+		Enabled: req.Enabled,
+	})
+}
@@ -19,16 +19,20 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"io"
 	"k8s.io/utils/clock"
 	clockTest "k8s.io/utils/clock/testing"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -48,6 +52,225 @@ func TestRegisterChargeStation(t *testing.T) {
 	assert.Equal(t, "", string(b))
 }
 
+func TestRegisterChargeStationAddsItToTheRegistry(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{
+		SiteId: "site001",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001", strings.NewReader(`{"securityProfile":2}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	got, err := engine.LookupChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStation{
+		ChargeStationId: "cs001",
+		SecurityProfile: store.TLSWithClientSideCertificates,
+		SiteId:          "site001",
+		Version:         2,
+	}, got)
+}
+
+func TestListChargeStationsNearby(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	for id, coordinates := range map[string]*store.GeoLocation{
+		"cs001": {Latitude: "51.5080", Longitude: "-0.1281"},
+		"cs002": {Latitude: "51.5074", Longitude: "-0.1278"},
+		"cs003": {Latitude: "48.8566", Longitude: "2.3522"},
+	} {
+		err := engine.SetChargeStation(context.Background(), id, &store.ChargeStation{Coordinates: coordinates})
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/nearby?lat=51.5074&lng=-0.1278&radius=1000", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	b, err := io.ReadAll(rr.Result().Body)
+	require.NoError(t, err)
+
+	var got []api.NearbyChargeStation
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs002", got[0].ChargeStation.Id)
+	assert.Equal(t, 0.0, got[0].Distance)
+	assert.Equal(t, "cs001", got[1].ChargeStation.Id)
+	assert.InDelta(t, 70, got[1].Distance, 5)
+}
+
+func TestListChargeStationsNearbyWithInvalidRadius(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/nearby?lat=51.5074&lng=-0.1278&radius=100000", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestLookupChargeStation(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	lastBoot := c.Now().UTC().Truncate(time.Second)
+	err := engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{
+		SecurityProfile: store.TLSWithBasicAuth,
+		OcppVersion:     "2.0.1",
+		Vendor:          "Acme",
+		Model:           "Charger 1",
+		LastBoot:        lastBoot,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.ChargeStation
+	err = json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+
+	ocppVersion := "2.0.1"
+	vendor := "Acme"
+	model := "Charger 1"
+	want := api.ChargeStation{
+		Id:              "cs001",
+		SecurityProfile: 1,
+		OcppVersion:     &ocppVersion,
+		Vendor:          &vendor,
+		Model:           &model,
+		LastBoot:        &lastBoot,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupChargeStationThatDoesNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/unknown", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestUpdateChargeStation(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{
+		OcppVersion: "1.6",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/cs/cs001",
+		strings.NewReader(`{"siteId":"site001","coordinates":{"latitude":"51.5072","longitude":"-0.1276"}}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	got, err := engine.LookupChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStation{
+		ChargeStationId: "cs001",
+		OcppVersion:     "1.6",
+		SiteId:          "site001",
+		Coordinates: &store.GeoLocation{
+			Latitude:  "51.5072",
+			Longitude: "-0.1276",
+		},
+		Version: 2,
+	}, got)
+}
+
+func TestUpdateChargeStationTariff(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetTariff(context.Background(), &store.Tariff{Id: "tariff001", Currency: "GBP"})
+	require.NoError(t, err)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/cs/cs001", strings.NewReader(body))
+		req.Header.Set("content-type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := put(`{"tariffId":"tariff001"}`)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got api.ChargeStation
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&got))
+	require.NotNil(t, got.TariffId)
+	assert.Equal(t, "tariff001", *got.TariffId)
+
+	cs, err := engine.LookupChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "tariff001", cs.TariffId)
+
+	rr = put(`{"tariffId":"unknown"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+	rr = put(`{}`)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	cs, err = engine.LookupChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Empty(t, cs.TariffId)
+}
+
+func TestListAndDeleteChargeStations(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	for i := 0; i < 3; i++ {
+		err := engine.SetChargeStation(context.Background(), fmt.Sprintf("cs%03d", i), &store.ChargeStation{})
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/cs/cs001", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/cs?limit=10", nil)
+	req.Header.Set("accept", "application/json")
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got []api.ChargeStation
+	err := json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs000", got[0].Id)
+	assert.Equal(t, "cs002", got[1].Id)
+}
+
 func TestLookupChargeStationAuth(t *testing.T) {
 	server, r, engine, _ := setupServer(t)
 	defer server.Close()
@@ -88,13 +311,477 @@ func TestLookupChargeStationAuthThatDoesNotExist(t *testing.T) {
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 
-	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestSetChargeStationRegistration(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/registration", strings.NewReader(`{"status":"Rejected"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	registration, err := engine.LookupChargeStationRegistration(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationRegistration{
+		ChargeStationId: "cs001",
+		Status:          store.RegistrationStatusRejected,
+	}, registration)
+
+	triggerMessage, err := engine.LookupChargeStationTriggerMessage(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, triggerMessage)
+}
+
+func TestSetChargeStationRegistrationAcceptsPendingChargeStation(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationRegistration(context.Background(), "cs001", &store.ChargeStationRegistration{
+		Status: store.RegistrationStatusPending,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/registration", strings.NewReader(`{"status":"Accepted"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	registration, err := engine.LookupChargeStationRegistration(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.RegistrationStatusAccepted, registration.Status)
+
+	triggerMessage, err := engine.LookupChargeStationTriggerMessage(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, triggerMessage)
+	assert.Equal(t, store.TriggerMessageBootNotification, triggerMessage.TriggerMessage)
+	assert.Equal(t, store.TriggerStatusPending, triggerMessage.TriggerStatus)
+}
+
+func TestSetChargeStationRegistrationWithInvalidStatus(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/registration", strings.NewReader(`{"status":"Unknown"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestLookupChargeStationRegistration(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationRegistration(context.Background(), "cs001", &store.ChargeStationRegistration{
+		Status: store.RegistrationStatusPending,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/registration", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	b, err := io.ReadAll(rr.Result().Body)
+	require.NoError(t, err)
+
+	got := new(api.ChargeStationRegistration)
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, &api.ChargeStationRegistration{
+		Status: api.ChargeStationRegistrationStatusPending,
+	}, got)
+}
+
+func TestLookupChargeStationRegistrationThatDoesNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/registration", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestLookupChargeStationLiveness(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	lastSeen := time.Date(2023, 6, 15, 15, 5, 0, 0, time.UTC)
+	err := engine.SetChargeStationLiveness(context.Background(), "cs001", &store.ChargeStationLiveness{
+		LastSeen: lastSeen,
+		Offline:  true,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/liveness", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	b, err := io.ReadAll(rr.Result().Body)
+	require.NoError(t, err)
+
+	got := new(api.ChargeStationLiveness)
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, &api.ChargeStationLiveness{
+		Status:   api.Offline,
+		LastSeen: lastSeen,
+	}, got)
+}
+
+func TestLookupChargeStationLivenessThatDoesNotExist(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/liveness", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestListChargeStationEvses(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	lastUpdated := time.Date(2023, 6, 15, 15, 5, 0, 0, time.UTC)
+	for _, evse := range []*store.ChargeStationEvse{
+		{ChargeStationId: "cs001", EvseId: 2, Connectors: []store.ChargeStationConnector{{ConnectorId: 1}}, LastUpdated: lastUpdated},
+		{ChargeStationId: "cs001", EvseId: 1, Connectors: []store.ChargeStationConnector{
+			{ConnectorId: 1, ConnectorType: "cType2", MaxPower: 22000, PhysicalLabel: "A"},
+		}, LastUpdated: lastUpdated},
+	} {
+		require.NoError(t, engine.SetEvse(context.Background(), evse))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/evses", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	b, err := io.ReadAll(rr.Result().Body)
+	require.NoError(t, err)
+
+	var got []api.ChargeStationEvse
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, []api.ChargeStationEvse{
+		{
+			EvseId: 1,
+			Connectors: []api.ChargeStationConnector{
+				{ConnectorId: 1, ConnectorType: makePtr("cType2"), MaxPower: makePtr(22000.0), PhysicalLabel: makePtr("A")},
+			},
+			LastUpdated: lastUpdated,
+		},
+		{
+			EvseId:      2,
+			Connectors:  []api.ChargeStationConnector{{ConnectorId: 1}},
+			LastUpdated: lastUpdated,
+		},
+	}, got)
+}
+
+func TestGetChargeStationAvailability(t *testing.T) {
+	server, r, engine, clk := setupServer(t)
+	defer server.Close()
+
+	from := clk.Now().Add(time.Hour).Truncate(time.Second)
+	to := from.Add(6 * time.Hour)
+
+	require.NoError(t, engine.SetEvse(context.Background(), &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		Connectors:      []store.ChargeStationConnector{{ConnectorId: 1}},
+	}))
+	require.NoError(t, engine.SetConnectorStatus(context.Background(), &store.ConnectorStatus{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		ConnectorId:     1,
+		Status:          "Available",
+	}))
+	require.NoError(t, engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		EvseId:          makePtr(1),
+		ExpiryDate:      from.Add(2 * time.Hour),
+		Status:          store.ReservationStatusAccepted,
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/cs/cs001/availability?from=%s&to=%s",
+		url.QueryEscape(from.Format(time.RFC3339)), url.QueryEscape(to.Format(time.RFC3339))), nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	b, err := io.ReadAll(rr.Result().Body)
+	require.NoError(t, err)
+
+	var got []api.ChargeStationAvailability
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	require.Len(t, got, 1)
+	assert.Equal(t, 1, got[0].EvseId)
+	assert.Nil(t, got[0].ConnectorId)
+	require.Len(t, got[0].Windows, 2)
+	assert.True(t, from.Equal(got[0].Windows[0].Start))
+	assert.True(t, from.Add(2*time.Hour).Equal(got[0].Windows[0].End))
+	assert.Equal(t, api.Reserved, got[0].Windows[0].Status)
+	assert.True(t, to.Equal(got[0].Windows[1].End))
+	assert.Equal(t, api.Free, got[0].Windows[1].Status)
+}
+
+func TestGetChargeStationAvailabilityRejectsLongPeriods(t *testing.T) {
+	server, r, _, clk := setupServer(t)
+	defer server.Close()
+
+	to := clk.Now().Add(8 * 24 * time.Hour)
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/cs/cs001/availability?to=%s",
+		url.QueryEscape(to.Format(time.RFC3339))), nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestUpdateChargeStationConnector(t *testing.T) {
+	server, r, engine, clk := setupServer(t)
+	defer server.Close()
+
+	lastUpdated := time.Date(2023, 6, 15, 15, 5, 0, 0, time.UTC)
+	err := engine.SetEvse(context.Background(), &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		Connectors:      []store.ChargeStationConnector{{ConnectorId: 1, ConnectorType: "cType2"}},
+		LastUpdated:     lastUpdated,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/cs/cs001/evses/1/connectors/1", strings.NewReader(`{"maxPower":11000,"physicalLabel":"A"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	evse, err := engine.LookupEvse(context.Background(), "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		Connectors:      []store.ChargeStationConnector{{ConnectorId: 1, ConnectorType: "cType2", MaxPower: 11000, PhysicalLabel: "A"}},
+		LastUpdated:     clk.Now(),
+	}, evse)
+}
+
+func TestUpdateChargeStationConnectorThatHasNotBeenReported(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/cs/cs001/evses/0/connectors/2", strings.NewReader(`{"connectorType":"sType2"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	b, err := io.ReadAll(rr.Result().Body)
+	require.NoError(t, err)
+
+	got := new(api.ChargeStationConnector)
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+	assert.Equal(t, &api.ChargeStationConnector{ConnectorId: 2, ConnectorType: makePtr("sType2")}, got)
+
+	evses, err := engine.ListEvses(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.Len(t, evses, 1)
+	assert.Equal(t, 0, evses[0].EvseId)
+}
+
+func TestUpdateChargeStationConnectorWithInvalidPower(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/cs/cs001/evses/1/connectors/1", strings.NewReader(`{"maxPower":-1}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestListInstalledChargeStationCertificates(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationInstalledCertificates(context.Background(), "cs001", &store.ChargeStationInstalledCertificates{
+		Certificates: []*store.ChargeStationInstalledCertificate{
+			{
+				CertificateType: store.CertificateTypeMO,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "name-hash",
+				IssuerKeyHash:   "key-hash",
+				SerialNumber:    "0123",
+				Status:          store.InstalledCertificateStatusInstalled,
+			},
+		},
+		RefreshStatus: store.InstalledCertificatesRefreshAccepted,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/certificates/installed", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	refreshStatus := api.ChargeStationInstalledCertificatesRefreshStatusAccepted
+	want := &api.ChargeStationInstalledCertificates{
+		Certificates: []api.InstalledCertificate{
+			{
+				Type:           api.InstalledCertificateTypeMO,
+				HashAlgorithm:  api.SHA256,
+				IssuerNameHash: "name-hash",
+				IssuerKeyHash:  "key-hash",
+				SerialNumber:   "0123",
+				Status:         api.Installed,
+			},
+		},
+		RefreshStatus: &refreshStatus,
+	}
+
+	got := new(api.ChargeStationInstalledCertificates)
+	err = json.NewDecoder(rr.Result().Body).Decode(got)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestRefreshInstalledChargeStationCertificates(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/certificates/installed", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+
+	got, err := engine.LookupChargeStationInstalledCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, store.InstalledCertificatesRefreshPending, got.RefreshStatus)
+}
+
+func TestDeleteInstalledChargeStationCertificate(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationInstalledCertificates(context.Background(), "cs001", &store.ChargeStationInstalledCertificates{
+		Certificates: []*store.ChargeStationInstalledCertificate{
+			{
+				CertificateType: store.CertificateTypeMO,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "name-hash",
+				IssuerKeyHash:   "key-hash",
+				SerialNumber:    "0123",
+				Status:          store.InstalledCertificateStatusInstalled,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodDelete, "/cs/cs001/certificates/installed/0123", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+
+	got, err := engine.LookupChargeStationInstalledCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.InstalledCertificateStatusDeletePending, got.Certificates[0].Status)
+
+	req = httptest.NewRequest(http.MethodDelete, "/cs/cs001/certificates/installed/9999", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestSetToken(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	token := api.Token{
+		CacheMode:   "ALWAYS",
+		ContractId:  "GB-TWK-012345678-V",
+		CountryCode: "GB",
+		Issuer:      "Thoughtworks",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "012345678",
+		Valid:       true,
+	}
+	tokenPayload, err := json.Marshal(token)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/token", bytes.NewReader(tokenPayload))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	b, err := io.ReadAll(rr.Result().Body)
+	require.NoError(t, err)
+	assert.Equal(t, "", string(b))
+
+	want := &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "012345678",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   "ALWAYS",
+		Version:     1,
+	}
+
+	got, err := engine.LookupToken(context.Background(), "012345678")
+
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z`, got.LastUpdated)
+	got.LastUpdated = ""
+
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
 }
 
-func TestSetToken(t *testing.T) {
+func TestSetTokenWithHashedUid(t *testing.T) {
 	server, r, engine, _ := setupServer(t)
 	defer server.Close()
 
+	uid := store.HashTokenUid("DEADBEEF")
 	token := api.Token{
 		CacheMode:   "ALWAYS",
 		ContractId:  "GB-TWK-012345678-V",
@@ -102,7 +789,7 @@ func TestSetToken(t *testing.T) {
 		Issuer:      "Thoughtworks",
 		PartyId:     "TWK",
 		Type:        "RFID",
-		Uid:         "012345678",
+		Uid:         uid,
 		Valid:       true,
 	}
 	tokenPayload, err := json.Marshal(token)
@@ -114,28 +801,22 @@ func TestSetToken(t *testing.T) {
 	r.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
-	b, err := io.ReadAll(rr.Result().Body)
-	require.NoError(t, err)
-	assert.Equal(t, "", string(b))
 
-	want := &store.Token{
-		CountryCode: "GB",
-		PartyId:     "TWK",
-		Type:        "RFID",
-		Uid:         "012345678",
-		ContractId:  "GBTWK012345678V",
-		Issuer:      "Thoughtworks",
-		Valid:       true,
-		CacheMode:   "ALWAYS",
-	}
+	got, err := engine.LookupToken(context.Background(), uid)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, uid, got.Uid)
 
-	got, err := engine.LookupToken(context.Background(), "012345678")
+	token.Uid = store.HashedTokenUidPrefix + "DEADBEEF"
+	tokenPayload, err = json.Marshal(token)
+	require.NoError(t, err)
 
-	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z`, got.LastUpdated)
-	got.LastUpdated = ""
+	req = httptest.NewRequest(http.MethodPost, "/token", bytes.NewReader(tokenPayload))
+	req.Header.Set("content-type", "application/json")
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
 
-	require.NoError(t, err)
-	assert.Equal(t, want, got)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
 }
 
 func TestLookupToken(t *testing.T) {
@@ -204,23 +885,415 @@ func TestListTokens(t *testing.T) {
 		}
 	}
 
-	for _, token := range tokens {
-		err := engine.SetToken(ctx, token)
+	for _, token := range tokens {
+		err := engine.SetToken(ctx, token)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/token", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	decoder := json.NewDecoder(rr.Result().Body)
+	var got []api.Token
+	err := decoder.Decode(&got)
+	require.NoError(t, err)
+
+	t.Logf("got: %+v", got)
+}
+
+func TestUpdateToken(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetToken(context.Background(), &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "012345678",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   "ALWAYS",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/token/012345678", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	etag := rr.Result().Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	token := api.Token{
+		CacheMode:   "NEVER",
+		ContractId:  "GB-TWK-012345678-V",
+		CountryCode: "GB",
+		Issuer:      "Thoughtworks",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "012345678",
+		Valid:       true,
+	}
+	tokenPayload, err := json.Marshal(token)
+	require.NoError(t, err)
+
+	req = httptest.NewRequest(http.MethodPut, "/token/012345678", bytes.NewReader(tokenPayload))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", etag)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.NotEqual(t, etag, rr.Result().Header.Get("ETag"))
+
+	got, err := engine.LookupToken(context.Background(), "012345678")
+	require.NoError(t, err)
+	assert.Equal(t, "NEVER", got.CacheMode)
+	assert.Equal(t, "GBTWK012345678V", got.ContractId)
+
+	// the token has changed so the original ETag no longer matches
+	req = httptest.NewRequest(http.MethodPut, "/token/012345678", bytes.NewReader(tokenPayload))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", etag)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Result().StatusCode)
+}
+
+// barrierTokenStore holds each token lookup until two lookups have been made, so that
+// two concurrent updates read the same version of the token
+type barrierTokenStore struct {
+	store.Engine
+	barrier *sync.WaitGroup
+}
+
+func (s *barrierTokenStore) LookupToken(ctx context.Context, tokenUid string) (*store.Token, error) {
+	tok, err := s.Engine.LookupToken(ctx, tokenUid)
+	if s.barrier != nil {
+		s.barrier.Done()
+		s.barrier.Wait()
+	}
+	return tok, err
+}
+
+func TestConcurrentUpdatesWithTheSameETag(t *testing.T) {
+	engine := &barrierTokenStore{Engine: inmemory.NewStore(clock.RealClock{})}
+	srv, err := api.NewServer(engine, clock.RealClock{}, nil)
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Use(api.ValidationMiddleware)
+	r.Mount("/", api.Handler(srv))
+
+	err = engine.SetToken(context.Background(), &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "012345678",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   "ALWAYS",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/token/012345678", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	etag := rr.Result().Header.Get("ETag")
+
+	engine.barrier = new(sync.WaitGroup)
+	engine.barrier.Add(2)
+	statuses := make(chan int, 2)
+	for _, cacheMode := range []string{"NEVER", "ALLOWED"} {
+		token := api.Token{
+			CacheMode:   api.TokenCacheMode(cacheMode),
+			ContractId:  "GB-TWK-012345678-V",
+			CountryCode: "GB",
+			Issuer:      "Thoughtworks",
+			PartyId:     "TWK",
+			Type:        "RFID",
+			Uid:         "012345678",
+			Valid:       true,
+		}
+		tokenPayload, err := json.Marshal(token)
+		require.NoError(t, err)
+
+		go func() {
+			req := httptest.NewRequest(http.MethodPut, "/token/012345678", bytes.NewReader(tokenPayload))
+			req.Header.Set("content-type", "application/json")
+			req.Header.Set("If-Match", etag)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			statuses <- rr.Result().StatusCode
+		}()
+	}
+
+	// both updates matched the ETag, but only the first to be written succeeds
+	got := []int{<-statuses, <-statuses}
+	assert.ElementsMatch(t, []int{http.StatusOK, http.StatusPreconditionFailed}, got)
+}
+
+func TestUpdateTokenNotFound(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	token := api.Token{
+		CacheMode:   "ALWAYS",
+		ContractId:  "GBTWK012345678V",
+		CountryCode: "GB",
+		Issuer:      "Thoughtworks",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "012345678",
+		Valid:       true,
+	}
+	tokenPayload, err := json.Marshal(token)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/token/012345678", bytes.NewReader(tokenPayload))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestUpdateTokenWithMismatchedUid(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	token := api.Token{
+		CacheMode:   "ALWAYS",
+		ContractId:  "GBTWK012345678V",
+		CountryCode: "GB",
+		Issuer:      "Thoughtworks",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "87654321",
+		Valid:       true,
+	}
+	tokenPayload, err := json.Marshal(token)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/token/012345678", bytes.NewReader(tokenPayload))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestSetTokenWithNonHexRfidUid(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	token := api.Token{
+		CacheMode:   "ALWAYS",
+		ContractId:  "GBTWK012345678V",
+		CountryCode: "GB",
+		Issuer:      "Thoughtworks",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "not-hex",
+		Valid:       true,
+	}
+	tokenPayload, err := json.Marshal(token)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/token", bytes.NewReader(tokenPayload))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestRevokeToken(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetToken(context.Background(), &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "012345678",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   "ALWAYS",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodDelete, "/token/012345678", nil)
+	req.Header.Set("If-Match", `"stale"`)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodDelete, "/token/012345678", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	got, err := engine.LookupToken(context.Background(), "012345678")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.False(t, got.Valid)
+}
+
+func TestListTransactions(t *testing.T) {
+	ctx := context.Background()
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	energyRegister := "Energy.Active.Import.Register"
+	meterValues := func(timestamp string) []store.MeterValue {
+		return []store.MeterValue{
+			{
+				Timestamp:     timestamp,
+				SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: 100}},
+			},
+		}
+	}
+	err := engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", meterValues("2023-06-01T12:00:00Z"), 0, false)
+	require.NoError(t, err)
+	err = engine.EndTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", meterValues("2023-06-01T13:00:00Z"), 1)
+	require.NoError(t, err)
+	err = engine.CreateTransaction(ctx, "cs001", "tx002", "DEADBEEF", "ISO14443", meterValues("2023-06-02T12:00:00Z"), 0, false)
+	require.NoError(t, err)
+	err = engine.CreateTransaction(ctx, "cs002", "tx003", "CAFEBABE", "ISO14443", meterValues("2023-06-01T12:00:00Z"), 0, false)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/transactions?chargeStationId=cs001&idToken=DEADBEEF&from=2023-06-01T00:00:00Z&to=2023-06-02T00:00:00Z&status=Ended", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got []api.Transaction
+	err = json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+
+	idToken := "DEADBEEF"
+	tokenType := "ISO14443"
+	startTime := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, []api.Transaction{
+		{
+			ChargeStationId: "cs001",
+			TransactionId:   "tx001",
+			IdToken:         &idToken,
+			TokenType:       &tokenType,
+			Status:          api.TransactionStatusEnded,
+			StartTime:       &startTime,
+			MeterValues: []api.MeterValue{
+				{
+					Timestamp:     startTime,
+					SampledValues: []api.SampledValue{{Measurand: &energyRegister, Value: 100}},
+				},
+				{
+					Timestamp:     time.Date(2023, 6, 1, 13, 0, 0, 0, time.UTC),
+					SampledValues: []api.SampledValue{{Measurand: &energyRegister, Value: 100}},
+				},
+			},
+		},
+	}, got)
+}
+
+func TestListTransactionsIncludesCost(t *testing.T) {
+	ctx := context.Background()
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	err := engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 0, false)
+	require.NoError(t, err)
+	err = engine.SetTransactionCost(ctx, "cs001", "tx001", &store.CostBreakdown{
+		TariffId:   "tariff001",
+		Currency:   "GBP",
+		SessionFee: 1,
+		EnergyCost: 2,
+		TotalCost:  3,
+		Periods: []store.CostPeriod{{
+			StartTime:   "2023-06-01T12:00:00Z",
+			EndTime:     "2023-06-01T13:00:00Z",
+			EnergyKwh:   4,
+			EnergyPrice: 0.5,
+			Cost:        2,
+		}},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got []api.Transaction
+	err = json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+
+	tariffId := "tariff001"
+	currency := "GBP"
+	require.Len(t, got, 1)
+	assert.Equal(t, &api.TransactionCost{
+		TariffId:   &tariffId,
+		Currency:   &currency,
+		SessionFee: 1,
+		EnergyCost: 2,
+		TotalCost:  3,
+		Periods: []api.TransactionCostPeriod{{
+			StartTime:   time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+			EndTime:     time.Date(2023, 6, 1, 13, 0, 0, 0, time.UTC),
+			EnergyKwh:   4,
+			EnergyPrice: 0.5,
+			Cost:        2,
+		}},
+	}, got[0].Cost)
+}
+
+func TestListTransactionsPages(t *testing.T) {
+	ctx := context.Background()
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	for i := 0; i < 5; i++ {
+		err := engine.CreateTransaction(ctx, "cs001", fmt.Sprintf("tx%03d", i), "DEADBEEF", "ISO14443", nil, 0, false)
 		require.NoError(t, err)
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/token", nil)
+	req := httptest.NewRequest(http.MethodGet, "/transactions?offset=1&limit=2", nil)
 	req.Header.Set("accept", "application/json")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 
-	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
-	decoder := json.NewDecoder(rr.Result().Body)
-	var got []api.Token
-	err := decoder.Decode(&got)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got []api.Transaction
+	err := json.NewDecoder(rr.Result().Body).Decode(&got)
 	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "tx001", got[0].TransactionId)
+	assert.Equal(t, "tx002", got[1].TransactionId)
+	assert.Equal(t, api.TransactionStatusActive, got[0].Status)
+}
 
-	t.Logf("got: %+v", got)
+func TestListTransactionsWithInvalidStatus(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions?status=Unknown", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
 }
 
 func TestSetCertificate(t *testing.T) {
@@ -308,7 +1381,7 @@ func TestLookupCertificate(t *testing.T) {
 }
 
 func TestRegisterLocation(t *testing.T) {
-	server, r, engine, _ := setupServer(t)
+	server, r, engine, clk := setupServer(t)
 	defer server.Close()
 
 	req := httptest.NewRequest(http.MethodPost, "/location/loc001", strings.NewReader(`{
@@ -344,6 +1417,7 @@ func TestRegisterLocation(t *testing.T) {
 		Country:     "BEL",
 		Evses:       &[]store.Evse{},
 		Id:          "loc001",
+		LastUpdated: clk.Now().Format(time.RFC3339),
 		Name:        "Gent Zuid",
 		ParkingType: "ON_STREET",
 		PostalCode:  "9000",
@@ -353,6 +1427,144 @@ func TestRegisterLocation(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestRegisterTariff(t *testing.T) {
+	server, r, engine, clk := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/tariff/tariff001", strings.NewReader(`{
+  "currency": "EUR",
+  "type": "REGULAR",
+  "elements": [
+    {
+      "price_components": [
+        {"type": "ENERGY", "price": 0.25, "vat": 20, "step_size": 1}
+      ],
+      "restrictions": {
+        "start_time": "22:00",
+        "end_time": "06:00",
+        "day_of_week": ["SATURDAY", "SUNDAY"]
+      }
+    },
+    {
+      "price_components": [
+        {"type": "ENERGY", "price": 0.35, "step_size": 1},
+        {"type": "PARKING_TIME", "price": 6, "step_size": 900}
+      ]
+    }
+  ],
+  "start_date_time": "2023-06-01T00:00:00Z"
+}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	vat := 20.0
+	want := &store.Tariff{
+		Id:       "tariff001",
+		Currency: "EUR",
+		Type:     "REGULAR",
+		Elements: []store.TariffElement{
+			{
+				PriceComponents: []store.PriceComponent{
+					{Type: "ENERGY", Price: 0.25, Vat: &vat, StepSize: 1},
+				},
+				Restrictions: &store.TariffRestrictions{
+					StartTime: "22:00",
+					EndTime:   "06:00",
+					DayOfWeek: []string{"SATURDAY", "SUNDAY"},
+				},
+			},
+			{
+				PriceComponents: []store.PriceComponent{
+					{Type: "ENERGY", Price: 0.35, StepSize: 1},
+					{Type: "PARKING_TIME", Price: 6, StepSize: 900},
+				},
+			},
+		},
+		StartDateTime: "2023-06-01T00:00:00Z",
+		LastUpdated:   clk.Now().Format(time.RFC3339),
+	}
+
+	got, err := engine.LookupTariff(context.Background(), "tariff001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestRegisterTariffRequiresPriceComponents(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/tariff/tariff001", strings.NewReader(`{
+  "currency": "EUR",
+  "elements": [{"price_components": []}]
+}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func setupActionFlagsServer(t *testing.T) (*chi.Mux, *handlers.ActionFlags) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	flags := handlers.NewActionFlags()
+	flags.Register(transport.OcppVersion201, "Heartbeat", "SetChargingProfile")
+
+	srv, err := api.NewServer(engine, clock.RealClock{}, nil, api.WithActionFlags(flags))
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(api.ValidationMiddleware)
+	r.Mount("/", api.Handler(srv))
+	return r, flags
+}
+
+func TestListOcppActions(t *testing.T) {
+	r, flags := setupActionFlagsServer(t)
+	flags.SetEnabled(transport.OcppVersion201, "SetChargingProfile", false)
+
+	req := httptest.NewRequest(http.MethodGet, "/ocpp/ocpp2.0.1/actions", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got []api.OcppAction
+	err := json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+	assert.Equal(t, []api.OcppAction{
+		{Action: "Heartbeat", Enabled: true},
+		{Action: "SetChargingProfile", Enabled: false},
+	}, got)
+}
+
+func TestUpdateOcppAction(t *testing.T) {
+	r, flags := setupActionFlagsServer(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/ocpp/ocpp2.0.1/actions/SetChargingProfile", strings.NewReader(`{"enabled":false}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.False(t, flags.Enabled(transport.OcppVersion201, "SetChargingProfile"))
+	assert.True(t, flags.Enabled(transport.OcppVersion16, "SetChargingProfile"))
+}
+
+func TestUpdateOcppActionThatIsNotKnown(t *testing.T) {
+	r, flags := setupActionFlagsServer(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/ocpp/ocpp1.6/actions/SetChargingProfile", strings.NewReader(`{"enabled":false}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+	assert.True(t, flags.Enabled(transport.OcppVersion16, "SetChargingProfile"))
+}
+
 func setupServer(t *testing.T) (*httptest.Server, *chi.Mux, store.Engine, clock.PassiveClock) {
 	engine := inmemory.NewStore(clock.RealClock{})
 	ocpiApi := ocpi.NewOCPI(engine, nil, "GB", "TWK")
@@ -408,3 +1620,523 @@ func getCertificateHash(cert *x509.Certificate) string {
 	b64Hash := base64.RawURLEncoding.EncodeToString(hash[:])
 	return b64Hash
 }
+
+func TestGetDashboardSummary(t *testing.T) {
+	server, r, engine, clk := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	now := clk.Now()
+	err := engine.SetChargeStationLiveness(ctx, "cs001", &store.ChargeStationLiveness{LastSeen: now})
+	require.NoError(t, err)
+	err = engine.SetChargeStationLiveness(ctx, "cs002", &store.ChargeStationLiveness{Offline: true})
+	require.NoError(t, err)
+	err = engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs002", ConnectorId: 1, Status: "Faulted"})
+	require.NoError(t, err)
+
+	endMeterValues := func(ts time.Time, wh float64) []store.MeterValue {
+		transactionEnd := "Transaction.End"
+		outlet := "Outlet"
+		energyRegister := "Energy.Active.Import.Register"
+		return []store.MeterValue{
+			{
+				Timestamp: ts.Format(time.RFC3339),
+				SampledValues: []store.SampledValue{
+					{
+						Context:   &transactionEnd,
+						Location:  &outlet,
+						Measurand: &energyRegister,
+						Value:     wh,
+					},
+				},
+			},
+		}
+	}
+
+	err = engine.CreateTransaction(ctx, "cs001", "tx001", "token", "ISO14443", nil, 0, false)
+	require.NoError(t, err)
+	err = engine.CreateTransaction(ctx, "cs001", "tx002", "token", "ISO14443", nil, 0, false)
+	require.NoError(t, err)
+	err = engine.EndTransaction(ctx, "cs001", "tx002", "token", "ISO14443", endMeterValues(now, 1500), 1)
+	require.NoError(t, err)
+	err = engine.CreateTransaction(ctx, "cs002", "tx003", "token", "ISO14443", nil, 0, false)
+	require.NoError(t, err)
+	err = engine.EndTransaction(ctx, "cs002", "tx003", "token", "ISO14443", endMeterValues(now.Add(-48*time.Hour), 2000), 1)
+	require.NoError(t, err)
+
+	err = engine.SetReservation(ctx, &store.Reservation{ReservationId: 1, ChargeStationId: "cs001",
+		Status: store.ReservationStatusAccepted, ExpiryDate: now.Add(time.Hour)})
+	require.NoError(t, err)
+	err = engine.SetReservation(ctx, &store.Reservation{ReservationId: 2, ChargeStationId: "cs001",
+		Status: store.ReservationStatusAccepted, ExpiryDate: now.Add(-time.Hour)})
+	require.NoError(t, err)
+	err = engine.SetReservation(ctx, &store.Reservation{ReservationId: 3, ChargeStationId: "cs002",
+		Status: store.ReservationStatusUsed, ExpiryDate: now.Add(-time.Hour)})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	got := new(api.DashboardSummary)
+	err = json.NewDecoder(rr.Result().Body).Decode(got)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, got.ChargeStations)
+	assert.Equal(t, 1, got.ChargeStationsOnline)
+	assert.Equal(t, 1, got.ChargeStationsOffline)
+	assert.Equal(t, 1, got.ActiveTransactions)
+	assert.Equal(t, 1, got.CompletedTransactionsToday)
+	assert.Equal(t, float32(1500), got.EnergyDeliveredTodayWh)
+	assert.Equal(t, 1, got.ActiveReservations)
+	assert.Equal(t, 1, got.ExpiredReservations)
+	assert.Equal(t, 1, got.FaultedConnectors)
+	assert.True(t, now.Equal(got.GeneratedAt))
+}
+
+func TestGetFleetStats(t *testing.T) {
+	server, r, engine, clk := setupServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	now := clk.Now()
+	err := engine.SetChargeStationLiveness(ctx, "cs001", &store.ChargeStationLiveness{LastSeen: now})
+	require.NoError(t, err)
+	err = engine.SetChargeStationLiveness(ctx, "cs002", &store.ChargeStationLiveness{Offline: true})
+	require.NoError(t, err)
+	err = engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs002", ConnectorId: 1, Status: "Faulted"})
+	require.NoError(t, err)
+	err = engine.CreateTransaction(ctx, "cs001", "tx001", "token", "ISO14443", nil, 0, false)
+	require.NoError(t, err)
+	err = engine.SetReservation(ctx, &store.Reservation{ReservationId: 1, ChargeStationId: "cs001",
+		Status: store.ReservationStatusAccepted, ExpiryDate: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	got := new(api.FleetStats)
+	err = json.NewDecoder(rr.Result().Body).Decode(got)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, got.ChargeStationsOnline)
+	assert.Equal(t, 1, got.ChargeStationsOffline)
+	assert.Equal(t, 1, got.ActiveTransactions)
+	assert.Equal(t, 0, got.CompletedTransactionsToday)
+	assert.Equal(t, 1, got.ActiveReservations)
+	assert.Equal(t, 1, got.FaultedConnectors)
+	assert.True(t, now.Equal(got.GeneratedAt))
+}
+
+func TestCreateReservation(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationRuntimeDetails(context.Background(), "cs002", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+	})
+	require.NoError(t, err)
+
+	evseId := 1
+	expiry := c.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	payload, err := json.Marshal(api.Reservation{
+		Id:         1,
+		EvseId:     &evseId,
+		IdToken:    "DEADBEEF",
+		TokenType:  api.ISO14443,
+		ExpiryDate: expiry,
+	})
+	require.NoError(t, err)
+
+	post := func(csId string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/cs/"+csId+"/reservation", bytes.NewReader(payload))
+		req.Header.Set("content-type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Result()
+	}
+
+	assert.Equal(t, http.StatusCreated, post("cs001").StatusCode)
+
+	got, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		EvseId:          &evseId,
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		StartDate:       c.Now(),
+		ExpiryDate:      expiry,
+		Status:          store.ReservationStatusPending,
+		Version:         1,
+	}, got)
+
+	assert.Equal(t, http.StatusConflict, post("cs001").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, post("cs002").StatusCode)
+	assert.Equal(t, http.StatusNotFound, post("cs003").StatusCode)
+}
+
+func TestCreateReservationForABookedTimeSlot(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+
+	post := func(reservation api.Reservation) *http.Response {
+		payload, err := json.Marshal(reservation)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/cs/cs001/reservation", bytes.NewReader(payload))
+		req.Header.Set("content-type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Result()
+	}
+
+	evseId := 1
+	start := c.Now().Add(2 * time.Hour).Truncate(time.Second).UTC()
+	expiry := start.Add(time.Hour)
+	assert.Equal(t, http.StatusCreated, post(api.Reservation{
+		Id:         1,
+		EvseId:     &evseId,
+		IdToken:    "DEADBEEF",
+		TokenType:  api.ISO14443,
+		StartDate:  &start,
+		ExpiryDate: expiry,
+	}).StatusCode)
+
+	got, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		EvseId:          &evseId,
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		StartDate:       start,
+		ExpiryDate:      expiry,
+		Status:          store.ReservationStatusPending,
+		SendAfter:       start.Add(-store.ReservationLeadTime),
+		Version:         1,
+	}, got)
+
+	// the slot overlaps the booking of the same EVSE
+	overlappingStart := start.Add(30 * time.Minute)
+	assert.Equal(t, http.StatusConflict, post(api.Reservation{
+		Id:         2,
+		IdToken:    "DEADBEEF",
+		TokenType:  api.ISO14443,
+		StartDate:  &overlappingStart,
+		ExpiryDate: overlappingStart.Add(time.Hour),
+	}).StatusCode)
+
+	// the slot follows the booking
+	assert.Equal(t, http.StatusCreated, post(api.Reservation{
+		Id:         3,
+		EvseId:     &evseId,
+		IdToken:    "DEADBEEF",
+		TokenType:  api.ISO14443,
+		StartDate:  &expiry,
+		ExpiryDate: expiry.Add(time.Hour),
+	}).StatusCode)
+
+	// the slot ends before it starts
+	assert.Equal(t, http.StatusBadRequest, post(api.Reservation{
+		Id:         4,
+		IdToken:    "DEADBEEF",
+		TokenType:  api.ISO14443,
+		StartDate:  &expiry,
+		ExpiryDate: start,
+	}).StatusCode)
+}
+
+func TestLookupReservation(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	targetEvseId := 2
+	expiry := c.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ExpiryDate:      expiry,
+		Status:          store.ReservationStatusAccepted,
+		Transfer: &store.ReservationTransfer{
+			ChargeStationId: "cs002",
+			EvseId:          &targetEvseId,
+			Status:          store.ReservationTransferStatusPending,
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/reservation/1", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.Reservation
+	err = json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+
+	csId := "cs001"
+	status := api.ReservationStatusAccepted
+	assert.Equal(t, api.Reservation{
+		Id:              1,
+		ChargeStationId: &csId,
+		IdToken:         "DEADBEEF",
+		TokenType:       api.ISO14443,
+		ExpiryDate:      expiry,
+		Status:          &status,
+		Transfer: &api.ReservationTransfer{
+			ChargeStationId: "cs002",
+			EvseId:          &targetEvseId,
+			Status:          api.ReservationTransferStatusPending,
+		},
+	}, got)
+
+	req = httptest.NewRequest(http.MethodGet, "/reservation/2", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestCreateReservationWithContactDetails(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+
+	post := func(id int, contact *api.ReservationContact) *http.Response {
+		payload, err := json.Marshal(api.Reservation{
+			Id:         id,
+			IdToken:    "DEADBEEF",
+			TokenType:  api.ISO14443,
+			ExpiryDate: c.Now().Add(time.Hour),
+			Contact:    contact,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/cs/cs001/reservation", bytes.NewReader(payload))
+		req.Header.Set("content-type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Result()
+	}
+
+	assert.Equal(t, http.StatusBadRequest, post(1, &api.ReservationContact{Email: makePtr("Driver <driver@example.com>")}).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, post(1, &api.ReservationContact{Phone: makePtr("07700 900123")}).StatusCode)
+	assert.Equal(t, http.StatusCreated, post(1, &api.ReservationContact{
+		Email: makePtr("driver@example.com"),
+		Phone: makePtr("+447700900123"),
+	}).StatusCode)
+
+	got, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, &store.ReservationContact{Email: "driver@example.com", Phone: "+447700900123"}, got.Contact)
+
+	req := httptest.NewRequest(http.MethodGet, "/reservation/1", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var reservation api.Reservation
+	err = json.NewDecoder(rr.Result().Body).Decode(&reservation)
+	require.NoError(t, err)
+	assert.Equal(t, &api.ReservationContact{
+		Email: makePtr("driver@example.com"),
+		Phone: makePtr("+447700900123"),
+	}, reservation.Contact)
+}
+
+func TestLookupNoShowReservation(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	start := c.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+	expiry := start.Add(2 * time.Hour)
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		StartDate:       start,
+		ExpiryDate:      expiry,
+		Status:          store.ReservationStatusCancelled,
+		NoShow:          true,
+		NoShowFee:       &store.CostBreakdown{Currency: "GBP", SessionFee: 5, TotalCost: 5},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/reservation/1", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var got api.Reservation
+	err = json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+
+	csId := "cs001"
+	status := api.ReservationStatusCancelled
+	assert.Equal(t, api.Reservation{
+		Id:              1,
+		ChargeStationId: &csId,
+		IdToken:         "DEADBEEF",
+		TokenType:       api.ISO14443,
+		StartDate:       &start,
+		ExpiryDate:      expiry,
+		Status:          &status,
+		NoShow:          makePtr(true),
+		NoShowFee:       &api.ReservationFee{Currency: "GBP", Amount: 5},
+	}, got)
+}
+
+func TestCancelReservation(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	for id, status := range map[int]store.ReservationStatus{
+		1: store.ReservationStatusAccepted,
+		2: store.ReservationStatusCancelled,
+	} {
+		err := engine.SetReservation(context.Background(), &store.Reservation{
+			ReservationId:   id,
+			ChargeStationId: "cs001",
+			ExpiryDate:      c.Now().Add(time.Hour),
+			Status:          status,
+			SendAfter:       c.Now().Add(time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	deleteReservation := func(id int) *http.Response {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/reservation/%d", id), nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Result()
+	}
+
+	assert.Equal(t, http.StatusAccepted, deleteReservation(1).StatusCode)
+
+	got, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusCancelPending, got.Status)
+	assert.True(t, got.SendAfter.IsZero())
+
+	assert.Equal(t, http.StatusConflict, deleteReservation(2).StatusCode)
+	assert.Equal(t, http.StatusNotFound, deleteReservation(3).StatusCode)
+}
+
+func TestCancelReservationForABookedTimeSlot(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		StartDate:       c.Now().Add(time.Hour),
+		ExpiryDate:      c.Now().Add(2 * time.Hour),
+		Status:          store.ReservationStatusPending,
+		SendAfter:       c.Now().Add(time.Hour - store.ReservationLeadTime),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodDelete, "/reservation/1", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+
+	// the reservation has not been sent to the charge station so there is nothing to
+	// cancel there
+	got, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusCancelled, got.Status)
+}
+
+func TestTransferReservation(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	for csId, version := range map[string]string{"cs001": "2.0.1", "cs002": "2.0.1", "cs003": "1.6"} {
+		err := engine.SetChargeStationRuntimeDetails(context.Background(), csId, &store.ChargeStationRuntimeDetails{
+			OcppVersion: version,
+		})
+		require.NoError(t, err)
+	}
+
+	evseId := 1
+	for id, status := range map[int]store.ReservationStatus{
+		1: store.ReservationStatusAccepted,
+		2: store.ReservationStatusPending,
+	} {
+		err := engine.SetReservation(context.Background(), &store.Reservation{
+			ReservationId:   id,
+			ChargeStationId: "cs001",
+			EvseId:          &evseId,
+			ExpiryDate:      c.Now().Add(time.Hour),
+			Status:          status,
+			SendAfter:       c.Now().Add(time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	transfer := func(id int, csId string, evseId *int) *http.Response {
+		payload, err := json.Marshal(api.ReservationTransferRequest{
+			ChargeStationId: csId,
+			EvseId:          evseId,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/reservation/%d/transfer", id), bytes.NewReader(payload))
+		req.Header.Set("content-type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Result()
+	}
+
+	assert.Equal(t, http.StatusBadRequest, transfer(1, "cs001", &evseId).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, transfer(1, "cs003", nil).StatusCode)
+	assert.Equal(t, http.StatusNotFound, transfer(1, "cs004", nil).StatusCode)
+
+	targetEvseId := 2
+	assert.Equal(t, http.StatusAccepted, transfer(1, "cs002", &targetEvseId).StatusCode)
+
+	got, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusAccepted, got.Status)
+	assert.Equal(t, "cs001", got.ChargeStationId)
+	assert.Equal(t, &store.ReservationTransfer{
+		ChargeStationId: "cs002",
+		EvseId:          &targetEvseId,
+		Status:          store.ReservationTransferStatusPending,
+	}, got.Transfer)
+	assert.True(t, got.SendAfter.IsZero())
+
+	assert.Equal(t, http.StatusConflict, transfer(1, "cs002", nil).StatusCode)
+	assert.Equal(t, http.StatusConflict, transfer(2, "cs002", nil).StatusCode)
+	assert.Equal(t, http.StatusNotFound, transfer(3, "cs002", nil).StatusCode)
+}
+
+func makePtr[T any](t T) *T {
+	return &t
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+)
+
+// tenantOf returns the tenant that the caller is scoped to, or an empty string for a
+// caller that can see every tenant
+func tenantOf(r *http.Request) string {
+	if principal := PrincipalFromContext(r.Context()); principal != nil {
+		return principal.Tenant
+	}
+	return ""
+}
+
+// inTenant reports whether the caller can see a record that belongs to the tenant
+func inTenant(r *http.Request, tenantId string) bool {
+	tenant := tenantOf(r)
+	return tenant == "" || tenant == tenantId
+}
+
+// checkChargeStationTenant renders a not found response and returns false if the
+// caller is scoped to a tenant that the charge station does not belong to
+func (s *Server) checkChargeStationTenant(w http.ResponseWriter, r *http.Request, csId string) bool {
+	if tenantOf(r) == "" {
+		return true
+	}
+	chargeStation, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrFromError(err))
+		return false
+	}
+	if chargeStation == nil || !inTenant(r, chargeStation.TenantId) {
+		_ = render.Render(w, r, ErrNotFound)
+		return false
+	}
+	return true
+}
+
+// chargeStationTenant returns the tenant that the charge station belongs to, or an
+// empty string if it is not in the registry
+func (s *Server) chargeStationTenant(r *http.Request, csId string) (string, error) {
+	chargeStation, err := s.store.LookupChargeStation(r.Context(), csId)
+	if err != nil || chargeStation == nil {
+		return "", err
+	}
+	return chargeStation.TenantId, nil
+}
+
+// checkNotTenant renders a forbidden response and returns false if the caller is
+// scoped to a tenant: it guards the endpoints that change every tenant, such as the
+// trusted certificates or the OCPP actions
+func checkNotTenant(w http.ResponseWriter, r *http.Request) bool {
+	if tenant := tenantOf(r); tenant != "" {
+		_ = render.Render(w, r, ErrForbidden(fmt.Errorf("not available to tenant %s", tenant)))
+		return false
+	}
+	return true
+}
+
+// tenantTransactionFilter restricts the filter to the transactions of the charge
+// stations that belong to the caller's tenant: the engines select the charge stations
+// in their queries rather than the transactions being filtered as they are read
+func (s *Server) tenantTransactionFilter(r *http.Request, filter *store.TransactionFilter) error {
+	tenant := tenantOf(r)
+	if tenant == "" {
+		return nil
+	}
+	ids, err := store.TenantChargeStationIds(r.Context(), s.store, tenant)
+	if err != nil {
+		return err
+	}
+	filter.ChargeStationIds = ids
+	return nil
+}
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"io"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// setupTenantServer returns an API handler that authenticates callers with the keys
+// "a-key" and "b-key", which are scoped to tenants "a" and "b", and "global-key", which
+// is not scoped to a tenant
+func setupTenantServer(t *testing.T) (http.Handler, store.Engine) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	srv, err := api.NewServer(engine, clock.RealClock{}, nil)
+	require.NoError(t, err)
+
+	apiKeys := api.NewApiKeyAuthenticator()
+	apiKeys.Add("a-key", api.Principal{Name: "a", Role: api.RoleOperator, Tenant: "a"})
+	apiKeys.Add("b-key", api.Principal{Name: "b", Role: api.RoleOperator, Tenant: "b"})
+	apiKeys.Add("global-key", api.Principal{Name: "global", Role: api.RoleOperator})
+
+	r := chi.NewRouter()
+	r.Use(api.ValidationMiddleware, api.Authorize(apiKeys, api.RoleReadOnly, api.RoleOperator))
+	r.Mount("/", api.Handler(srv))
+	return r, engine
+}
+
+func serveAs(handler http.Handler, key, method, path, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set(api.ApiKeyHeader, key)
+	if body != "" {
+		req.Header.Set("content-type", "application/json")
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestChargeStationsAreScopedToTenants(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/cs/cs001", `{"securityProfile":0}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	cs, err := engine.LookupChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "a", cs.TenantId)
+
+	// another tenant cannot see or take over the charge station
+	rr = serveAs(handler, "b-key", http.MethodGet, "/cs/cs001", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodPost, "/cs/cs001", `{"securityProfile":1}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodPost, "/cs/cs001/trigger", `{"trigger":"BootNotification"}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveAs(handler, "a-key", http.MethodGet, "/cs/cs001", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	rr = serveAs(handler, "global-key", http.MethodGet, "/cs/cs001", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = serveAs(handler, "b-key", http.MethodGet, "/cs", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[]`, rr.Body.String())
+}
+
+func TestTokensAreScopedToTenants(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	token := `{"countryCode":"GB","partyId":"TWK","type":"RFID","uid":"DEADBEEF","contractId":"GBTWK012345678V",
+		"issuer":"Thoughtworks","valid":true,"cacheMode":"ALWAYS"}`
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/token", token)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	tok, err := engine.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+	assert.Equal(t, "a", tok.TenantId)
+
+	rr = serveAs(handler, "b-key", http.MethodPost, "/token", token)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodGet, "/token/DEADBEEF", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodDelete, "/token/DEADBEEF", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// the tenant is kept when a caller that sees every tenant changes the token
+	rr = serveAs(handler, "global-key", http.MethodPost, "/token", token)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	tok, err = engine.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+	assert.Equal(t, "a", tok.TenantId)
+
+	rr = serveAs(handler, "a-key", http.MethodGet, "/token", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var tokens []api.Token
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tokens))
+	assert.Len(t, tokens, 1)
+	rr = serveAs(handler, "b-key", http.MethodGet, "/token", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[]`, rr.Body.String())
+}
+
+func TestTransactionsAreScopedToTheTenantOfTheirChargeStation(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{TenantId: "b"}))
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx1", "TOKEN", "ISO14443", nil, 0, false))
+	require.NoError(t, engine.CreateTransaction(ctx, "cs002", "tx2", "TOKEN", "ISO14443", nil, 0, false))
+
+	rr := serveAs(handler, "a-key", http.MethodGet, "/transactions", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var transactions []api.Transaction
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &transactions))
+	require.Len(t, transactions, 1)
+	assert.Equal(t, "tx1", transactions[0].TransactionId)
+
+	rr = serveAs(handler, "global-key", http.MethodGet, "/transactions", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &transactions))
+	assert.Len(t, transactions, 2)
+}
+
+func TestDashboardSummaryIsScopedToTenants(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{TenantId: "b"}))
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs001", &store.ChargeStationLiveness{}))
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs002", &store.ChargeStationLiveness{}))
+
+	var stats api.DashboardSummary
+	rr := serveAs(handler, "a-key", http.MethodGet, "/dashboard", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stats))
+	assert.Equal(t, 1, stats.ChargeStationsOnline)
+
+	rr = serveAs(handler, "global-key", http.MethodGet, "/dashboard", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stats))
+	assert.Equal(t, 2, stats.ChargeStationsOnline)
+
+	var fleetStats api.FleetStats
+	rr = serveAs(handler, "a-key", http.MethodGet, "/stats", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &fleetStats))
+	assert.Equal(t, 1, fleetStats.ChargeStationsOnline)
+}
+
+func TestReservationsAreScopedToTenants(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+	ctx := context.Background()
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusAccepted,
+		TenantId:        "a",
+	}))
+
+	rr := serveAs(handler, "a-key", http.MethodGet, "/reservation/1", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodGet, "/reservation/1", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveAs(handler, "b-key", http.MethodDelete, "/reservation/1", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestTenantsCannotChangeEveryTenant(t *testing.T) {
+	handler, _ := setupTenantServer(t)
+
+	rr := serveAs(handler, "a-key", http.MethodPost, "/register", `{"token":"abc"}`)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	rr = serveAs(handler, "a-key", http.MethodPost, "/certificate", `{"certificate":"abc"}`)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestChargeStationsNearbyAreScopedToTenants(t *testing.T) {
+	handler, engine := setupTenantServer(t)
+
+	for id, tenant := range map[string]string{"cs001": "a", "cs002": "b"} {
+		err := engine.SetChargeStation(context.Background(), id, &store.ChargeStation{
+			Coordinates: &store.GeoLocation{Latitude: "51.5074", Longitude: "-0.1278"},
+			TenantId:    tenant,
+		})
+		require.NoError(t, err)
+	}
+
+	rr := serveAs(handler, "a-key", http.MethodGet, "/cs/nearby?lat=51.5074&lng=-0.1278&radius=100", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got []api.NearbyChargeStation
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs001", got[0].ChargeStation.Id)
+
+	rr = serveAs(handler, "global-key", http.MethodGet, "/cs/nearby?lat=51.5074&lng=-0.1278&radius=100", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Len(t, got, 2)
+}
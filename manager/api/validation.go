@@ -49,7 +49,8 @@ func ValidationMiddleware(next http.Handler) http.Handler {
 
 func matchPath(r *http.Request, swagger *openapi3.T) (*string, map[string]string) {
 	pathParams := make(map[string]string)
-	requestElements := strings.Split(r.RequestURI, "/")
+	// the query is validated with the operation, so only the path is matched
+	requestElements := strings.Split(r.URL.Path, "/")
 
 	for _, path := range swagger.Paths.InMatchingOrder() {
 		match := true
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"io"
+	"k8s.io/utils/clock"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	hubjectSandboxUrl      = "https://open.plugncharge-test.hubject.com"
+	hubjectSandboxTokenUrl = "https://hubject.stoplight.io/api/v1/projects/cHJqOjk0NTg5/nodes/6bb8b3bc79c2e-authorization-token"
+
+	evRootCertificatesFile = "root-certificates.pem"
+	evInstallationResFile  = "certificate-installation-res.exi"
+)
+
+// exiCookie is the optional cookie that starts an EXI stream
+var exiCookie = []byte("$EXI")
+
+var (
+	provisionEvDir       string
+	provisionRequestFile string
+	provisionIsoVersion  string
+	provisionMoUrl       string
+	provisionTokenUrl    string
+	provisionTokenEnvVar string
+)
+
+// provisionCmd represents the provision command
+var provisionCmd = &cobra.Command{
+	Use:   "provision",
+	Short: "Provision a contract certificate into a simulated EV",
+	Long: `Drives the Plug & Charge contract certificate installation flow against the MO
+OPCP (by default the Hubject sandbox) for end-to-end testing of the ISO 15118 services.
+
+The EXI encoded CertificateInstallationReq is read (base64 encoded) from the --request
+file: it must be created and signed by the EV, e.g. an ISO 15118 EV simulator, which
+holds the key pair.
+
+The MO root certificates and the decoded CertificateInstallationRes returned by the MO
+OPCP are installed into the simulated EV directory, from which the EV simulator can
+read them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exiRequest, err := readExiRequest(provisionRequestFile)
+		if err != nil {
+			return err
+		}
+
+		isoVersion := services.ISOVersion(provisionIsoVersion)
+		if isoVersion != services.ISO15118V2 && isoVersion != services.ISO15118V20 {
+			return fmt.Errorf("iso version must be %s or %s", services.ISO15118V2, services.ISO15118V20)
+		}
+
+		tokenService, err := provisionTokenService()
+		if err != nil {
+			return err
+		}
+
+		return provisionContract(context.Background(), cmd.OutOrStdout(), provisionEvDir, exiRequest, isoVersion,
+			services.OpcpRootCertificateProviderService{
+				BaseURL:      provisionMoUrl,
+				TokenService: tokenService,
+				HttpClient:   http.DefaultClient,
+			},
+			services.OpcpContractCertificateProvider{
+				BaseURL:          provisionMoUrl,
+				HttpTokenService: tokenService,
+				HttpClient:       http.DefaultClient,
+			})
+	},
+}
+
+// provisionContract requests the contract certificate for the EXI request and installs
+// the MO root certificates and the verified CertificateInstallationRes into the EV directory
+func provisionContract(ctx context.Context, out io.Writer, evDir, exiRequest string, isoVersion services.ISOVersion,
+	rootCertificateProvider services.RootCertificateProviderService,
+	contractCertificateProvider services.ContractCertificateProvider) error {
+	err := os.MkdirAll(evDir, 0700)
+	if err != nil {
+		return fmt.Errorf("creating ev directory: %w", err)
+	}
+
+	rootCerts, err := rootCertificateProvider.ProvideCertificates(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieving mo root certificates: %w", err)
+	}
+	var rootCertsPem []byte
+	for _, cert := range rootCerts {
+		rootCertsPem = append(rootCertsPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	rootCertsFile := filepath.Join(evDir, evRootCertificatesFile)
+	err = os.WriteFile(rootCertsFile, rootCertsPem, 0600)
+	if err != nil {
+		return fmt.Errorf("writing mo root certificates: %w", err)
+	}
+	_, _ = fmt.Fprintf(out, "installed %d mo root certificates: %s\n", len(rootCerts), rootCertsFile)
+
+	resp, err := contractCertificateProvider.ProvideCertificate(ctx, exiRequest, isoVersion)
+	if err != nil {
+		return fmt.Errorf("requesting contract certificate: %w", err)
+	}
+	if resp.Status != ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted {
+		return fmt.Errorf("contract certificate request was not accepted: %s", resp.Status)
+	}
+	exiResponse, err := decodeExi(resp.CertificateInstallationRes)
+	if err != nil {
+		return fmt.Errorf("certificate installation response: %w", err)
+	}
+	resFile := filepath.Join(evDir, evInstallationResFile)
+	err = os.WriteFile(resFile, exiResponse, 0600)
+	if err != nil {
+		return fmt.Errorf("writing certificate installation response: %w", err)
+	}
+	_, _ = fmt.Fprintf(out, "installed contract certificate installation response (%d bytes): %s\n", len(exiResponse), resFile)
+
+	return nil
+}
+
+// readExiRequest reads the base64 encoded EXI request from the file
+func readExiRequest(requestFile string) (string, error) {
+	//#nosec G304 - only files specified by the person running the application will be loaded
+	data, err := os.ReadFile(requestFile)
+	if err != nil {
+		return "", fmt.Errorf("reading certificate installation request: %w", err)
+	}
+	exiRequest := strings.TrimSpace(string(data))
+	if _, err := decodeExi(exiRequest); err != nil {
+		return "", fmt.Errorf("certificate installation request: %w", err)
+	}
+	return exiRequest, nil
+}
+
+// decodeExi decodes the base64 encoded EXI stream and verifies that it starts with an
+// EXI header: the optional "$EXI" cookie followed by the distinguishing bits (10)
+func decodeExi(encoded string) ([]byte, error) {
+	if encoded == "" {
+		return nil, errors.New("is empty")
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("is not base64 encoded: %w", err)
+	}
+	header := bytes.TrimPrefix(data, exiCookie)
+	if len(header) == 0 || header[0]&0xc0 != 0x80 {
+		return nil, errors.New("is not an EXI stream")
+	}
+	return data, nil
+}
+
+// provisionTokenService returns the service that provides the token for the MO OPCP:
+// the token in the environment variable, if one is specified, otherwise a Hubject
+// sandbox test token
+func provisionTokenService() (services.HttpTokenService, error) {
+	if provisionTokenEnvVar != "" {
+		return services.NewEnvHttpTokenService(provisionTokenEnvVar)
+	}
+	return services.NewCachingHttpTokenService(
+		services.NewHubjectTestHttpTokenService(provisionTokenUrl, http.DefaultClient),
+		time.Hour, clock.RealClock{}), nil
+}
+
+func init() {
+	contractCmd.AddCommand(provisionCmd)
+
+	provisionCmd.Flags().StringVar(&provisionEvDir, "ev-dir", "ev",
+		"The directory that the certificates are installed into for the simulated EV")
+	provisionCmd.Flags().StringVar(&provisionRequestFile, "request", "",
+		"The file containing the base64 encoded EXI CertificateInstallationReq")
+	_ = provisionCmd.MarkFlagRequired("request")
+	provisionCmd.Flags().StringVar(&provisionIsoVersion, "iso-version", string(services.ISO15118V2),
+		"The ISO 15118 version of the request: ISO15118-2 or ISO15118-20")
+	provisionCmd.Flags().StringVar(&provisionMoUrl, "mo-url", hubjectSandboxUrl,
+		"The base URL of the MO OPCP")
+	provisionCmd.Flags().StringVar(&provisionTokenUrl, "token-url", hubjectSandboxTokenUrl,
+		"The URL of the Hubject sandbox test token")
+	provisionCmd.Flags().StringVar(&provisionTokenEnvVar, "token-env-var", "",
+		"The environment variable that contains the MO OPCP token (instead of a Hubject sandbox test token)")
+}
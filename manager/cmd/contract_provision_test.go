@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var exiStream = []byte{0x80, 0x98, 0x02, 0x10}
+
+type fakeRootCertificateProvider struct {
+	certs []*x509.Certificate
+}
+
+func (f fakeRootCertificateProvider) ProvideCertificates(context.Context) ([]*x509.Certificate, error) {
+	return f.certs, nil
+}
+
+type fakeContractCertificateProvider struct {
+	exiRequest string
+	isoVersion services.ISOVersion
+	resp       services.EvCertificate15118Response
+	err        error
+}
+
+func (f *fakeContractCertificateProvider) ProvideCertificate(_ context.Context, exiRequest string, isoVersion services.ISOVersion) (services.EvCertificate15118Response, error) {
+	f.exiRequest, f.isoVersion = exiRequest, isoVersion
+	return f.resp, f.err
+}
+
+func TestDecodeExi(t *testing.T) {
+	data, err := decodeExi(base64.StdEncoding.EncodeToString(exiStream))
+	require.NoError(t, err)
+	assert.Equal(t, exiStream, data)
+
+	withCookie := append([]byte("$EXI"), exiStream...)
+	data, err = decodeExi(base64.StdEncoding.EncodeToString(withCookie))
+	require.NoError(t, err)
+	assert.Equal(t, withCookie, data)
+
+	_, err = decodeExi("")
+	assert.EqualError(t, err, "is empty")
+
+	_, err = decodeExi("not base64!")
+	assert.ErrorContains(t, err, "is not base64 encoded")
+
+	_, err = decodeExi(base64.StdEncoding.EncodeToString([]byte(`{"json":true}`)))
+	assert.EqualError(t, err, "is not an EXI stream")
+}
+
+func TestReadExiRequest(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "req.b64")
+	encoded := base64.StdEncoding.EncodeToString(exiStream)
+	require.NoError(t, os.WriteFile(file, []byte(encoded+"\n"), 0600))
+	exiRequest, err := readExiRequest(file)
+	require.NoError(t, err)
+	assert.Equal(t, encoded, exiRequest)
+
+	require.NoError(t, os.WriteFile(file, []byte("\n"), 0600))
+	_, err = readExiRequest(file)
+	assert.EqualError(t, err, "certificate installation request: is empty")
+
+	_, err = readExiRequest(filepath.Join(t.TempDir(), "missing.b64"))
+	assert.ErrorContains(t, err, "reading certificate installation request")
+}
+
+func TestProvisionContractInstallsTheDecodedResponse(t *testing.T) {
+	evDir := filepath.Join(t.TempDir(), "ev")
+	rootCert := &x509.Certificate{Raw: []byte("root certificate")}
+	contracts := &fakeContractCertificateProvider{
+		resp: services.EvCertificate15118Response{
+			Status:                     ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted,
+			CertificateInstallationRes: base64.StdEncoding.EncodeToString(exiStream),
+		},
+	}
+
+	var out bytes.Buffer
+	err := provisionContract(context.Background(), &out, evDir, "gJgCEA==", services.ISO15118V20,
+		fakeRootCertificateProvider{certs: []*x509.Certificate{rootCert}}, contracts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "gJgCEA==", contracts.exiRequest)
+	assert.Equal(t, services.ISO15118V20, contracts.isoVersion)
+
+	rootCertsPem, err := os.ReadFile(filepath.Join(evDir, evRootCertificatesFile))
+	require.NoError(t, err)
+	block, _ := pem.Decode(rootCertsPem)
+	require.NotNil(t, block)
+	assert.Equal(t, rootCert.Raw, block.Bytes)
+
+	res, err := os.ReadFile(filepath.Join(evDir, evInstallationResFile))
+	require.NoError(t, err)
+	assert.Equal(t, exiStream, res)
+	assert.Contains(t, out.String(), "installed 1 mo root certificates")
+}
+
+func TestProvisionContractRejectsAnInvalidResponse(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp services.EvCertificate15118Response
+		err  error
+		want string
+	}{
+		"request failed": {
+			err:  errors.New("401"),
+			want: "requesting contract certificate: 401",
+		},
+		"not accepted": {
+			resp: services.EvCertificate15118Response{Status: ocpp201.Iso15118EVCertificateStatusEnumTypeFailed},
+			want: "contract certificate request was not accepted: Failed",
+		},
+		"not exi": {
+			resp: services.EvCertificate15118Response{
+				Status:                     ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted,
+				CertificateInstallationRes: base64.StdEncoding.EncodeToString([]byte("<xml/>")),
+			},
+			want: "certificate installation response: is not an EXI stream",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			evDir := t.TempDir()
+			contracts := &fakeContractCertificateProvider{resp: tc.resp, err: tc.err}
+			var out bytes.Buffer
+			err := provisionContract(context.Background(), &out, evDir, "gJgCEA==", services.ISO15118V2,
+				fakeRootCertificateProvider{}, contracts)
+			assert.EqualError(t, err, tc.want)
+			assert.NoFileExists(t, filepath.Join(evDir, evInstallationResFile))
+		})
+	}
+}
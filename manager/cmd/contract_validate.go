@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/services"
 	"net/http"
 	"os"
@@ -35,7 +36,11 @@ var validateCmd = &cobra.Command{
 			if len(parts) != 2 {
 				return fmt.Errorf("input must be list of <emaid>:<pemFile> pairs")
 			}
-			emaid := parts[0]
+			emaid, err := ocpp.NormalizeEmaid(parts[0])
+			if err != nil {
+				fmt.Printf("%s: %v\n", parts[0], err)
+				continue
+			}
 			pemFile := parts[1]
 			//#nosec G304 - only files specified by the person running the application will be loaded
 			pemData, err := os.ReadFile(pemFile)
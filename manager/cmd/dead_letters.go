@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+)
+
+var (
+	deadLettersConfigFile      string
+	deadLettersChargeStationId string
+)
+
+// deadLettersCmd represents the dead-letters command
+var deadLettersCmd = &cobra.Command{
+	Use:   "dead-letters",
+	Short: "Inspect and replay messages that could not be routed",
+	Long: `Inspect and replay the messages received from charge stations that could not
+be routed, e.g. because the action is unknown, the message does not match the
+schema or the handler failed. The transport section of the config file must
+set a dead-letter topic.`,
+}
+
+func openDeadLetterQueue() (transport.DeadLetterQueue, error) {
+	cfg := config.DefaultConfig
+	err := cfg.LoadFromFile(deadLettersConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	return config.OpenDeadLetterQueue(&cfg.Transport)
+}
+
+// filterDeadLetters returns the dead letters for the charge station selected with
+// the --charge-station flag, or all of them if no charge station is selected
+func filterDeadLetters(letters []*transport.DeadLetter) []*transport.DeadLetter {
+	if deadLettersChargeStationId == "" {
+		return letters
+	}
+	var filtered []*transport.DeadLetter
+	for _, letter := range letters {
+		if letter.ChargeStationId == deadLettersChargeStationId {
+			filtered = append(filtered, letter)
+		}
+	}
+	return filtered
+}
+
+func init() {
+	rootCmd.AddCommand(deadLettersCmd)
+
+	deadLettersCmd.PersistentFlags().StringVarP(&deadLettersConfigFile, "config-file", "c", "/config/config.toml",
+		"The config file whose transport section describes the dead-letter topic")
+	deadLettersCmd.PersistentFlags().StringVar(&deadLettersChargeStationId, "charge-station", "",
+		"Only include the dead letters received from this charge station")
+}
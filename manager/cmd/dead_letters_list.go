@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"sort"
+	"time"
+)
+
+var deadLettersListJson bool
+
+var deadLettersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the messages that could not be routed",
+	Long: `Lists the dead letters that have not been replayed, oldest first, with the
+reason that each message could not be routed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		queue, err := openDeadLetterQueue()
+		if err != nil {
+			return err
+		}
+		letters, err := queue.ListDeadLetters(ctx)
+		if err != nil {
+			return err
+		}
+		letters = filterDeadLetters(letters)
+		sort.SliceStable(letters, func(i, j int) bool {
+			return letters[i].Timestamp.Before(letters[j].Timestamp)
+		})
+
+		if deadLettersListJson {
+			for _, letter := range letters {
+				b, err := json.MarshalIndent(letter, "", "  ")
+				if err != nil {
+					return fmt.Errorf("formatting dead letter: %w", err)
+				}
+				fmt.Println(string(b))
+			}
+			return nil
+		}
+
+		tbl := table.New("Timestamp", "Version", "Charge Station", "Message Id", "Action", "Failures", "Error Code", "Error")
+		for _, letter := range letters {
+			tbl.AddRow(letter.Timestamp.Format(time.RFC3339), letter.OcppVersion, letter.ChargeStationId,
+				letter.Message.MessageId, letter.Message.Action, letter.Failures, letter.ErrorCode, letter.Error)
+		}
+		tbl.Print()
+		return nil
+	},
+}
+
+func init() {
+	deadLettersCmd.AddCommand(deadLettersListCmd)
+
+	deadLettersListCmd.Flags().BoolVar(&deadLettersListJson, "json", false,
+		"Print the dead letters, including the original message, as JSON")
+}
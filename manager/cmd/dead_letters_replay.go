@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+var deadLettersReplayAll bool
+
+var deadLettersReplayCmd = &cobra.Command{
+	Use:   "replay [message-id...]",
+	Short: "Send messages that could not be routed to be routed again",
+	Long: `Sends the messages held as dead letters back to the manager as if they had
+been received from the charge station again, e.g. once a handler has been
+fixed, and removes them from the dead-letter topic. Either list the ids of the
+messages to replay or use --all.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && !deadLettersReplayAll {
+			return errors.New("provide the ids of the messages to replay or use --all")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		queue, err := openDeadLetterQueue()
+		if err != nil {
+			return err
+		}
+		letters, err := queue.ListDeadLetters(ctx)
+		if err != nil {
+			return err
+		}
+
+		messageIds := make(map[string]bool)
+		for _, id := range args {
+			messageIds[id] = true
+		}
+
+		var replayed int
+		for _, letter := range filterDeadLetters(letters) {
+			if len(args) > 0 && !messageIds[letter.Message.MessageId] {
+				continue
+			}
+			err = queue.ReplayDeadLetter(ctx, letter)
+			if err != nil {
+				return fmt.Errorf("replaying message %s from %s: %w", letter.Message.MessageId, letter.ChargeStationId, err)
+			}
+			replayed++
+		}
+		fmt.Printf("Replayed %d message(s)\n", replayed)
+		return nil
+	},
+}
+
+func init() {
+	deadLettersCmd.AddCommand(deadLettersReplayCmd)
+
+	deadLettersReplayCmd.Flags().BoolVar(&deadLettersReplayAll, "all", false,
+		"Replay all the dead letters, or all those from the charge station selected with --charge-station")
+}
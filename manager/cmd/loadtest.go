@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/loadtest"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"golang.org/x/exp/slog"
+	"io"
+	"k8s.io/utils/clock"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+var (
+	loadtestCorpusFile  string
+	loadtestRate        float64
+	loadtestConcurrency int
+	loadtestDuration    time.Duration
+	loadtestVerbose     bool
+)
+
+// loadtestCmd represents the loadtest command
+var loadtestCmd = &cobra.Command{
+	Use:   "loadtest",
+	Short: "Measure the routing performance of a message corpus",
+	Long: `Replays a corpus of messages captured from the MQTT broker to the manager's
+routers, backed by an in-memory store, and reports the throughput and the latency
+of each action to quantify the performance of routing and validation.
+
+The corpus is captured with mosquitto_sub, e.g.
+
+  mosquitto_sub -h localhost -v -t 'cs/in/#' > corpus.txt
+
+Messages that are routed once the corpus has been replayed find the state that
+the earlier messages left in the store. The certificate services are not
+configured, so Plug & Charge messages fail.`,
+	Example: `  manager loadtest --corpus corpus.txt
+  manager loadtest --corpus corpus.txt --rate 500 --duration 10m`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		//#nosec G304 - only files specified by the person running the application will be loaded
+		file, err := os.Open(loadtestCorpusFile)
+		if err != nil {
+			return fmt.Errorf("opening corpus: %w", err)
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		corpus, err := loadtest.ReadCorpus(file)
+		if err != nil {
+			return err
+		}
+
+		// routing failures are reported as errors, rather than logged, unless --verbose is set
+		if !loadtestVerbose {
+			slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		}
+
+		emitter := new(loadtest.Emitter)
+		runner := loadtest.Runner{
+			Handlers:    loadtest.NewRouters(emitter, inmemory.NewStore(clock.RealClock{})),
+			Emitter:     emitter,
+			Rate:        loadtestRate,
+			Concurrency: loadtestConcurrency,
+			Duration:    loadtestDuration,
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		report, err := runner.Run(ctx, corpus)
+		if err != nil {
+			return err
+		}
+
+		tbl := table.New("OCPP Version", "Action", "Type", "Messages", "Errors", "Mean", "P50", "P95", "P99", "Max")
+		for _, action := range report.Actions {
+			tbl.AddRow(action.OcppVersion, action.Action, action.MessageType, action.Messages, action.Errors,
+				action.Mean, action.P50, action.P95, action.P99, action.Max)
+		}
+		tbl.Print()
+		fmt.Printf("\nRouted %d message(s) in %s (%.1f/s) with %d error(s)\n",
+			report.Messages, report.Elapsed.Round(time.Millisecond), report.Throughput(), report.Errors)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(loadtestCmd)
+
+	loadtestCmd.Flags().StringVar(&loadtestCorpusFile, "corpus", "",
+		"The file containing the captured messages, one per line in the format written by mosquitto_sub -v")
+	loadtestCmd.Flags().Float64Var(&loadtestRate, "rate", 0,
+		"The number of messages routed per second: as fast as possible if not set")
+	loadtestCmd.Flags().IntVar(&loadtestConcurrency, "concurrency", runtime.GOMAXPROCS(0),
+		"The number of messages routed at once")
+	loadtestCmd.Flags().DurationVar(&loadtestDuration, "duration", 0,
+		"How long to replay the corpus for, repeating it as necessary: it is replayed once if not set")
+	loadtestCmd.Flags().BoolVar(&loadtestVerbose, "verbose", false,
+		"Log the routing of each message")
+	_ = loadtestCmd.MarkFlagRequired("corpus")
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/migrate"
+	"sort"
+)
+
+var (
+	migrateFromConfigFile string
+	migrateToConfigFile   string
+	migrateCheckpointFile string
+	migratePageSize       int
+)
+
+// migrateStoreCmd represents the migrate-store command
+var migrateStoreCmd = &cobra.Command{
+	Use:   "migrate-store",
+	Short: "Copy the data held by one storage engine to another",
+	Long: `Copies the durable data from the storage configured in one config file to
+the storage configured in another, e.g. to promote an environment or to move
+to a different storage engine. Only the storage section of each config file
+is used.
+
+Progress is recorded in the checkpoint file after each page of entities is
+copied: if the command is interrupted it can be run again with the same
+checkpoint file to resume where it stopped. The manager should not be
+writing to the target storage while the command runs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		from, err := openConfiguredStorage(ctx, migrateFromConfigFile)
+		if err != nil {
+			return fmt.Errorf("opening source storage: %w", err)
+		}
+		to, err := openConfiguredStorage(ctx, migrateToConfigFile)
+		if err != nil {
+			return fmt.Errorf("opening target storage: %w", err)
+		}
+
+		checkpoint, err := migrate.LoadCheckpoint(migrateCheckpointFile)
+		if err != nil {
+			return err
+		}
+
+		migrator := &migrate.Migrator{
+			From:       from,
+			To:         to,
+			Checkpoint: checkpoint,
+			Save: func(checkpoint *migrate.Checkpoint) error {
+				return checkpoint.Save(migrateCheckpointFile)
+			},
+			PageSize: migratePageSize,
+		}
+		err = migrator.Run(ctx)
+
+		tbl := table.New("Entity", "Copied", "Completed")
+		kinds := make([]string, 0, len(checkpoint.Counts))
+		for kind := range checkpoint.Counts {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			tbl.AddRow(kind, checkpoint.Counts[kind], checkpoint.Completed[kind])
+		}
+		tbl.Print()
+
+		return err
+	},
+}
+
+func openConfiguredStorage(ctx context.Context, configFile string) (store.Engine, error) {
+	cfg := config.DefaultConfig
+	err := cfg.LoadFromFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	return config.OpenStorage(ctx, &cfg.Storage)
+}
+
+func init() {
+	rootCmd.AddCommand(migrateStoreCmd)
+
+	migrateStoreCmd.Flags().StringVar(&migrateFromConfigFile, "from-config", "",
+		"The config file whose storage section describes the storage to copy from")
+	migrateStoreCmd.Flags().StringVar(&migrateToConfigFile, "to-config", "",
+		"The config file whose storage section describes the storage to copy to")
+	migrateStoreCmd.Flags().StringVar(&migrateCheckpointFile, "checkpoint", "migrate-store.checkpoint.json",
+		"The file in which progress is recorded so that the migration can be resumed")
+	migrateStoreCmd.Flags().IntVar(&migratePageSize, "page-size", 100,
+		"The number of entities copied between checkpoints")
+	_ = migrateStoreCmd.MarkFlagRequired("from-config")
+	_ = migrateStoreCmd.MarkFlagRequired("to-config")
+}
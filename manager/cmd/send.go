@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	sendConfigFile  string
+	sendOcppVersion string
+)
+
+// sendCmd represents the send command
+var sendCmd = &cobra.Command{
+	Use:   "send <charge-station-id> <action> <payload>",
+	Short: "Send a call to a charge station",
+	Long: `Sends a CSMS-initiated call, e.g. a Reset or ReserveNow, to the charge station
+through the transport configured in the transport section of the config file.
+The payload is the JSON request, or @ followed by the name of a file that
+contains it, and is validated against the schema of the action's request.
+
+Unless outbound calls are disabled, the call goes through the charge station's
+outbound call queue in the storage configured in the storage section, as the
+manager's own calls do: if a call to the charge station is waiting for a
+response, the call is queued and the manager sends it once the charge station
+has responded.
+
+The charge station's response is handled by the manager in the same way as the
+response to a call that the manager made itself.`,
+	Example: `  manager send cs001 Reset '{"type":"Immediate"}'
+  manager send cs001 ReserveNow @reserve-now.json --ocpp-version ocpp1.6`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		chargeStationId, action := args[0], args[1]
+
+		payload, err := readSendPayload(args[2])
+		if err != nil {
+			return err
+		}
+
+		ocppVersion := transport.OcppVersion(sendOcppVersion)
+		schemaFile, err := requestSchemaFile(ocppVersion, action)
+		if err != nil {
+			return err
+		}
+		err = schemas.Validate(payload, schemas.OcppSchemas, schemaFile)
+		if err != nil {
+			return fmt.Errorf("validating %s request: %w", action, err)
+		}
+
+		cfg := config.DefaultConfig
+		err = cfg.LoadFromFile(sendConfigFile)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		emitter, err := config.OpenEmitter(&cfg.Transport)
+		if err != nil {
+			return err
+		}
+		var queues store.OutboundCallQueueStore
+		if cfg.Ocpp.OutboundCalls == nil || !cfg.Ocpp.OutboundCalls.Disabled {
+			engine, err := config.OpenStorage(ctx, &cfg.Storage)
+			if err != nil {
+				return fmt.Errorf("opening storage: %w", err)
+			}
+			scheduler, err := config.OpenCallScheduler(cfg.Ocpp.OutboundCalls, emitter, engine)
+			if err != nil {
+				return err
+			}
+			emitter, queues = scheduler, engine
+		}
+
+		msg := &transport.Message{
+			MessageType:    transport.MessageTypeCall,
+			MessageId:      uuid.Must(uuid.NewV7()).String(),
+			Action:         action,
+			RequestPayload: payload,
+		}
+		queued, err := sendCall(ctx, emitter, queues, ocppVersion, chargeStationId, msg)
+		if err != nil {
+			return fmt.Errorf("sending %s to %s: %w", action, chargeStationId, err)
+		}
+		if queued {
+			fmt.Printf("Queued %s to %s with message id %s behind the call in flight\n", action, chargeStationId, msg.MessageId)
+		} else {
+			fmt.Printf("Sent %s to %s with message id %s\n", action, chargeStationId, msg.MessageId)
+		}
+		return nil
+	},
+}
+
+// sendCall emits the call and reports whether it was queued rather than sent, which
+// is read from the charge station's outbound call queue if there is one
+func sendCall(ctx context.Context, emitter transport.Emitter, queues store.OutboundCallQueueStore, ocppVersion transport.OcppVersion, chargeStationId string, msg *transport.Message) (bool, error) {
+	err := emitter.Emit(ctx, ocppVersion, chargeStationId, msg)
+	if err != nil || queues == nil {
+		return false, err
+	}
+	queue, err := queues.LookupOutboundCallQueue(ctx, chargeStationId)
+	if err != nil {
+		return false, err
+	}
+	if queue == nil {
+		return false, nil
+	}
+	for _, call := range queue.Queued {
+		if call.MessageId == msg.MessageId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// readSendPayload returns the JSON payload given on the command line or, if it starts
+// with @, read from the file
+func readSendPayload(arg string) (json.RawMessage, error) {
+	payload := []byte(arg)
+	if file, ok := strings.CutPrefix(arg, "@"); ok {
+		var err error
+		//#nosec G304 - only files specified by the person running the application will be loaded
+		payload, err = os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading payload: %w", err)
+		}
+	}
+	if !json.Valid(payload) {
+		return nil, errors.New("payload is not valid JSON")
+	}
+	return payload, nil
+}
+
+// requestSchemaFile returns the schema of the action's request for the OCPP version.
+// The messages that OCPP 2.1 shares with OCPP 2.0.1 have the OCPP 2.0.1 schemas.
+func requestSchemaFile(ocppVersion transport.OcppVersion, action string) (string, error) {
+	var schemaFiles []string
+	switch ocppVersion {
+	case transport.OcppVersion16:
+		schemaFiles = []string{fmt.Sprintf("ocpp16/%s.json", action)}
+	case transport.OcppVersion201:
+		schemaFiles = []string{fmt.Sprintf("ocpp201/%sRequest.json", action)}
+	case transport.OcppVersion21:
+		schemaFiles = []string{fmt.Sprintf("ocpp21/%sRequest.json", action), fmt.Sprintf("ocpp201/%sRequest.json", action)}
+	default:
+		return "", fmt.Errorf("unknown ocpp version: %s", ocppVersion)
+	}
+	for _, schemaFile := range schemaFiles {
+		if _, err := fs.Stat(schemas.OcppSchemas, schemaFile); err == nil {
+			return schemaFile, nil
+		}
+	}
+	return "", fmt.Errorf("unknown %s action: %s", ocppVersion, action)
+}
+
+func init() {
+	rootCmd.AddCommand(sendCmd)
+
+	sendCmd.Flags().StringVarP(&sendConfigFile, "config-file", "c", "/config/config.toml",
+		"The config file whose transport section describes how to reach the charge stations")
+	sendCmd.Flags().StringVar(&sendOcppVersion, "ocpp-version", string(transport.OcppVersion201),
+		"The OCPP version that the charge station uses, one of [ocpp1.6, ocpp2.0.1, ocpp2.1]")
+}
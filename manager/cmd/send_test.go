@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadSendPayload(t *testing.T) {
+	payload, err := readSendPayload(`{"type":"Immediate"}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"Immediate"}`, string(payload))
+
+	file := filepath.Join(t.TempDir(), "reset.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"type":"OnIdle"}`), 0600))
+	payload, err = readSendPayload("@" + file)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"OnIdle"}`, string(payload))
+
+	_, err = readSendPayload(`{"type":`)
+	assert.EqualError(t, err, "payload is not valid JSON")
+
+	_, err = readSendPayload("@" + filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "reading payload")
+}
+
+func TestRequestSchemaFile(t *testing.T) {
+	for _, tc := range []struct {
+		ocppVersion transport.OcppVersion
+		action      string
+		want        string
+	}{
+		{transport.OcppVersion16, "Reset", "ocpp16/Reset.json"},
+		{transport.OcppVersion201, "Reset", "ocpp201/ResetRequest.json"},
+		{transport.OcppVersion21, "ReserveNow", "ocpp201/ReserveNowRequest.json"},
+		{transport.OcppVersion21, "BatterySwap", "ocpp21/BatterySwapRequest.json"},
+	} {
+		t.Run(string(tc.ocppVersion)+"/"+tc.action, func(t *testing.T) {
+			got, err := requestSchemaFile(tc.ocppVersion, tc.action)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := requestSchemaFile(transport.OcppVersion201, "Teleport")
+	assert.EqualError(t, err, "unknown ocpp2.0.1 action: Teleport")
+
+	_, err = requestSchemaFile("ocpp3.0", "Reset")
+	assert.EqualError(t, err, "unknown ocpp version: ocpp3.0")
+}
+
+type recordingEmitter struct {
+	ids []string
+}
+
+func (r *recordingEmitter) Emit(_ context.Context, _ transport.OcppVersion, _ string, message *transport.Message) error {
+	r.ids = append(r.ids, message.MessageId)
+	return nil
+}
+
+func TestSendCallQueuesBehindTheCallInFlight(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	emitter := &recordingEmitter{}
+	scheduler := transport.NewCallScheduler(emitter, engine)
+
+	reset := func(id string) *transport.Message {
+		return &transport.Message{
+			MessageType:    transport.MessageTypeCall,
+			MessageId:      id,
+			Action:         "Reset",
+			RequestPayload: []byte(`{"type":"Immediate"}`),
+		}
+	}
+
+	queued, err := sendCall(ctx, scheduler, engine, transport.OcppVersion201, "cs001", reset("1"))
+	require.NoError(t, err)
+	assert.False(t, queued)
+
+	queued, err = sendCall(ctx, scheduler, engine, transport.OcppVersion201, "cs001", reset("2"))
+	require.NoError(t, err)
+	assert.True(t, queued)
+	assert.Equal(t, []string{"1"}, emitter.ids)
+}
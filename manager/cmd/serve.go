@@ -4,17 +4,32 @@ package cmd
 
 import (
 	"context"
+	clone "github.com/huandu/go-clone/generic"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/thoughtworks/maeve-csms/manager/config"
 	"github.com/thoughtworks/maeve-csms/manager/server"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/sync"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"golang.org/x/exp/slog"
+	"io"
 	"k8s.io/utils/clock"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 var (
-	configFile string
+	configFile         string
+	serveStorageEngine string
+	sqlitePath         string
+	tokenCacheSize     int
+	tokenCacheTtl      string
+	eventLog           bool
+	transportType      string
+	logLevel           string
+	logFormat          string
 )
 
 // serveCmd represents the serve command
@@ -24,75 +39,237 @@ var serveCmd = &cobra.Command{
 	Long: `Starts the server which will subscribe to messages from
 the gateway and send appropriate responses.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg := config.DefaultConfig
-		if configFile != "" {
-			err := cfg.LoadFromFile(configFile)
-			if err != nil {
-				return err
-			}
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
 		}
 
-		settings, err := config.Configure(context.Background(), &cfg)
+		settings, err := config.Configure(context.Background(), cfg)
 		if err != nil {
 			return err
 		}
-		defer func() {
-			err := settings.TracerProvider.Shutdown(context.Background())
-			if err != nil {
-				slog.Warn("shutting down tracer provider", "error", err)
+
+		stopCh := make(chan os.Signal, 1)
+		signal.Notify(stopCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(stopCh)
+
+		settings.Api.Reloader.SetLoader(func() (*config.BaseConfig, error) {
+			return loadConfig(cmd)
+		})
+		reloadCh := make(chan os.Signal, 1)
+		signal.Notify(reloadCh, syscall.SIGHUP)
+		defer signal.Stop(reloadCh)
+		go reloadConfig(reloadCh, settings.Api.Reloader)
+
+		var transports []transport.HealthReporter
+		for _, t := range []any{settings.MsgListener, settings.MsgEmitter} {
+			if len(transports) > 0 && t == transports[0] {
+				// the transport both emits and listens
+				continue
+			}
+			if reporter, ok := t.(transport.HealthReporter); ok {
+				transports = append(transports, reporter)
 			}
-		}()
+		}
 
 		apiServer := server.New("api", cfg.Api.Addr, nil,
-			server.NewApiHandler(settings.Api, settings.Storage, settings.OcpiApi, settings.ChargeStationCertProviderService))
+			server.NewApiHandler(settings.Api, settings.Storage, settings.OcpiApi, settings.ChargeStationCertProviderService, transports...))
 
-		sync.Sync(settings.Storage, clock.RealClock{}, settings.Tracer, settings.MsgEmitter)
+		syncCtx, stopSync := context.WithCancel(context.Background())
+		defer stopSync()
+		syncDone := sync.Sync(syncCtx, settings.Storage, clock.RealClock{}, settings.Tracer, settings.MsgEmitter, settings.ProvisioningScript, settings.LivenessService, settings.OcpiApi, settings.OicpApi, settings.RetentionService, settings.OcspRevalidator, settings.RootCertificateRefresher, settings.CertificateExpiryService, settings.ReservationNoShowPolicy, settings.PaymentAuthorization, settings.DriverNotificationPolicy)
 
 		errCh := make(chan error, 1)
 		apiServer.Start(errCh)
-		var ocpp16Connection transport.Connection
-		if settings.Ocpp16Handler != nil {
-			ocpp16Connection, err = settings.MsgListener.Connect(context.Background(), transport.OcppVersion16, nil, settings.Ocpp16Handler)
-			if err != nil {
-				errCh <- err
-			}
-		}
-
-		var ocpp201Connection transport.Connection
-		if settings.Ocpp201Handler != nil {
-			ocpp201Connection, err = settings.MsgListener.Connect(context.Background(), transport.OcppVersion201, nil, settings.Ocpp201Handler)
+		servers := []*server.Server{apiServer}
+		for _, ocppVersion := range settings.Protocols.Versions() {
+			connection, err := settings.MsgListener.Connect(context.Background(), ocppVersion, nil, settings.Protocols.Handler(ocppVersion))
 			if err != nil {
 				errCh <- err
+				break
 			}
+			settings.Api.Drain.Add(connection)
 		}
 
 		if settings.OcpiApi != nil {
 			ocpiServer := server.New("ocpi", cfg.Ocpi.Addr, nil, server.NewOcpiHandler(settings.Storage, clock.RealClock{}, settings.OcpiApi, settings.MsgEmitter))
 			ocpiServer.Start(errCh)
+			servers = append(servers, ocpiServer)
 		}
 
-		err = <-errCh
-
-		if ocpp16Connection != nil {
-			err := ocpp16Connection.Disconnect(context.Background())
-			if err != nil {
-				slog.Warn("disconnecting from broker", "err", err)
+		if settings.Websocket != nil {
+			if settings.Websocket.Addr != "" {
+				wsServer := server.New("ws", settings.Websocket.Addr, nil, settings.Websocket.Transport.Handler())
+				wsServer.Start(errCh)
+				servers = append(servers, wsServer)
 			}
-		}
-		if ocpp201Connection != nil {
-			err := ocpp201Connection.Disconnect(context.Background())
-			if err != nil {
-				slog.Warn("disconnecting from broker", "err", err)
+			if settings.Websocket.TlsAddr != "" {
+				wssServer := server.New("wss", settings.Websocket.TlsAddr, settings.Websocket.TlsConfig, settings.Websocket.Transport.Handler())
+				wssServer.Start(errCh)
+				servers = append(servers, wssServer)
 			}
 		}
 
+		select {
+		case err = <-errCh:
+		case sig := <-stopCh:
+			slog.Info("shutting down", "signal", sig.String())
+		}
+
+		shutdown(settings, servers, stopSync, syncDone)
+
 		return err
 	},
 }
 
+// shutdown stops the manager without losing work: the manager is drained, so that no
+// more messages are received and the messages that have been received are handled,
+// the background jobs are stopped and their lease released, and then the calls waiting
+// to be stored, the events and the spans are written out before the storage is closed.
+// Draining, and then the remaining steps, are each bounded by the shutdown timeout.
+func shutdown(settings *config.Config, servers []*server.Server, stopSync context.CancelFunc, syncDone <-chan struct{}) {
+	err := settings.Api.Drain.Start(context.Background())
+	if err != nil {
+		slog.Warn("draining", "err", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), settings.ShutdownTimeout)
+	defer cancel()
+
+	// ends the event streams, which would otherwise keep the API server from stopping
+	if settings.Api.EventStream != nil {
+		_ = settings.Api.EventStream.Close()
+	}
+
+	for _, srv := range servers {
+		err := srv.Stop(ctx)
+		if err != nil {
+			slog.Warn("stopping server", "addr", srv.Addr(), "err", err)
+		}
+	}
+
+	// releasing the lease lets another manager instance take over the jobs without
+	// waiting for it to expire
+	stopSync()
+	select {
+	case <-syncDone:
+	case <-ctx.Done():
+		slog.Warn("stopping background jobs", "err", ctx.Err())
+	}
+
+	if closer, ok := settings.EventPublisher.(io.Closer); ok {
+		// sends the events from the messages that were handled before shutdown
+		err := closer.Close()
+		if err != nil {
+			slog.Warn("closing event publisher", "err", err)
+		}
+	}
+
+	err = settings.TracerProvider.Shutdown(ctx)
+	if err != nil {
+		slog.Warn("shutting down tracer provider", "err", err)
+	}
+
+	err = store.Close(settings.Storage)
+	if err != nil {
+		slog.Warn("closing storage", "err", err)
+	}
+}
+
+// loadConfig reads the configuration from the config file, the environment and the
+// flags, in increasing order of precedence
+func loadConfig(cmd *cobra.Command) (*config.BaseConfig, error) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	if configFile != "" {
+		err := cfg.LoadFromFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// the environment overrides the file and the flags override the environment
+	err := cfg.LoadFromEnv(config.EnvPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Flags().Changed("storage-engine") {
+		cfg.Storage.Type = serveStorageEngine
+	}
+	if cmd.Flags().Changed("sqlite-path") {
+		cfg.Storage.SqliteStorage = &config.SqliteStorageConfig{Path: sqlitePath}
+	}
+	if cmd.Flags().Changed("token-cache-size") || cmd.Flags().Changed("token-cache-ttl") {
+		if cfg.Storage.TokenCache == nil {
+			cfg.Storage.TokenCache = &config.TokenCacheConfig{}
+		}
+		if cmd.Flags().Changed("token-cache-size") {
+			cfg.Storage.TokenCache.Size = tokenCacheSize
+		}
+		if cmd.Flags().Changed("token-cache-ttl") {
+			cfg.Storage.TokenCache.Ttl = tokenCacheTtl
+		}
+	}
+
+	if cmd.Flags().Changed("transaction-event-log") {
+		cfg.Storage.EventLog = eventLog
+	}
+	if cmd.Flags().Changed("transport") {
+		cfg.Transport.Type = transportType
+		if transportType == "nats" && cfg.Transport.Nats == nil {
+			cfg.Transport.Nats = &config.NatsSettingsConfig{}
+		}
+		if transportType == "websocket" && cfg.Transport.Websocket == nil {
+			cfg.Transport.Websocket = &config.WebsocketSettingsConfig{}
+		}
+	}
+	if cmd.Flags().Changed("log-level") {
+		cfg.Observability.LogLevel = logLevel
+	}
+	if cmd.Flags().Changed("log-format") {
+		cfg.Observability.LogFormat = logFormat
+	}
+
+	return cfg, nil
+}
+
+// reloadConfig reloads the configuration each time the manager is sent SIGHUP, so
+// that settings such as the disabled actions can be changed without a restart
+func reloadConfig(signals <-chan os.Signal, reloader *config.Reloader) {
+	for range signals {
+		changes, err := reloader.Reload()
+		if err != nil {
+			slog.Error("reloading configuration", "error", err)
+			continue
+		}
+		slog.Info("reloaded configuration", "changes", len(changes))
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(serveCmd)
 
 	serveCmd.Flags().StringVarP(&configFile, "config-file", "c", "/config/config.toml",
-		"The config file to use")
+		"The config file to use, TOML or YAML (.yaml or .yml): settings can be overridden by MANAGER_ environment variables")
+	serveCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		// --config is accepted as well as --config-file
+		if name == "config" {
+			name = "config-file"
+		}
+		return pflag.NormalizedName(name)
+	})
+	serveCmd.Flags().StringVar(&serveStorageEngine, "storage-engine", "",
+		"The storage engine to use, one of [firestore, in_memory, sqlite, dynamodb] or a registered custom engine, overriding the config file")
+	serveCmd.Flags().StringVar(&sqlitePath, "sqlite-path", "",
+		"The SQLite database file to use (if chosen storage-engine), overriding the config file")
+	serveCmd.Flags().IntVar(&tokenCacheSize, "token-cache-size", 0,
+		"The maximum number of tokens to cache in-process (0 disables the cache), overriding the config file")
+	serveCmd.Flags().StringVar(&tokenCacheTtl, "token-cache-ttl", "",
+		"How long a cached token remains valid, e.g. 1m, overriding the config file")
+	serveCmd.Flags().BoolVar(&eventLog, "transaction-event-log", false,
+		"Record each change to a transaction in an append-only event log, overriding the config file")
+	serveCmd.Flags().StringVar(&transportType, "transport", "",
+		"The transport used to exchange messages with charge stations, one of [mqtt, nats, websocket], overriding the config file")
+	serveCmd.Flags().StringVar(&logLevel, "log-level", "",
+		"The lowest level of log record written, one of [debug, info, warn, error], overriding the config file")
+	serveCmd.Flags().StringVar(&logFormat, "log-format", "",
+		"The format of the log records, one of [text, json], overriding the config file")
 }
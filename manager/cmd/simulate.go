@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/simulator"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+var (
+	simulateCount               int
+	simulatePrefix              string
+	simulateUrl                 string
+	simulateOcppVersion         string
+	simulatePassword            string
+	simulateIdToken             string
+	simulateIdTokenType         string
+	simulateTransactionInterval time.Duration
+	simulateTransactionDuration time.Duration
+	simulateMeterValueInterval  time.Duration
+	simulateRampUp              time.Duration
+	simulateDuration            time.Duration
+	simulateStatsInterval       time.Duration
+)
+
+// simulateCmd represents the simulate command
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate charge stations",
+	Long: `Runs a number of simulated charge stations that connect to the gateway, for load
+testing and demo environments. Each charge station has a single connector: it boots,
+sends heartbeats and makes transactions at random intervals with the id token, and
+accepts reservations and remote start and stop requests from the CSMS.
+
+The charge stations must be registered with the CSMS, e.g. with the security
+profile that only uses basic authentication and the --password, and the id token
+must be valid.`,
+	Example: `  manager simulate --count 100 --prefix sim --password password --ramp-up 1m
+  manager simulate --ocpp-version ocpp1.6 --transaction-interval 30s --duration 10m`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ocppVersion := transport.OcppVersion(simulateOcppVersion)
+		if ocppVersion != transport.OcppVersion16 && ocppVersion != transport.OcppVersion201 {
+			return fmt.Errorf("ocpp version must be %s or %s", transport.OcppVersion16, transport.OcppVersion201)
+		}
+		if simulateCount < 1 {
+			return errors.New("count must be at least 1")
+		}
+		if simulateTransactionDuration <= 0 || simulateMeterValueInterval <= 0 {
+			return errors.New("transaction duration and meter value interval must be positive")
+		}
+
+		cfg := simulator.DefaultConfig
+		cfg.Url = simulateUrl
+		cfg.OcppVersion = ocppVersion
+		cfg.Password = simulatePassword
+		cfg.IdToken = simulateIdToken
+		cfg.IdTokenType = simulateIdTokenType
+		cfg.TransactionInterval = simulateTransactionInterval
+		cfg.TransactionDuration = simulateTransactionDuration
+		cfg.MeterValueInterval = simulateMeterValueInterval
+
+		sim := &simulator.Simulator{
+			Config: cfg,
+			Count:  simulateCount,
+			Prefix: simulatePrefix,
+			RampUp: simulateRampUp,
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		if simulateDuration > 0 {
+			var cancelDuration context.CancelFunc
+			ctx, cancelDuration = context.WithTimeout(ctx, simulateDuration)
+			defer cancelDuration()
+		}
+
+		if simulateStatsInterval > 0 {
+			go func() {
+				ticker := time.NewTicker(simulateStatsInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						fmt.Println(sim.Stats.String())
+					}
+				}
+			}()
+		}
+
+		err := sim.Run(ctx)
+		fmt.Println(sim.Stats.String())
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+
+	simulateCmd.Flags().IntVar(&simulateCount, "count", 1,
+		"The number of charge stations to simulate")
+	simulateCmd.Flags().StringVar(&simulatePrefix, "prefix", "sim",
+		"The prefix of the charge station ids, which are numbered from 1")
+	simulateCmd.Flags().StringVar(&simulateUrl, "url", simulator.DefaultConfig.Url,
+		"The gateway's websocket URL, to which the charge station id is appended")
+	simulateCmd.Flags().StringVar(&simulateOcppVersion, "ocpp-version", string(simulator.DefaultConfig.OcppVersion),
+		"The OCPP version that the charge stations use, one of [ocpp1.6, ocpp2.0.1]")
+	simulateCmd.Flags().StringVar(&simulatePassword, "password", "",
+		"The password that the charge stations use for basic authentication")
+	simulateCmd.Flags().StringVar(&simulateIdToken, "id-token", simulator.DefaultConfig.IdToken,
+		"The id token that authorizes the transactions")
+	simulateCmd.Flags().StringVar(&simulateIdTokenType, "id-token-type", simulator.DefaultConfig.IdTokenType,
+		"The OCPP 2.0.1 type of the id token")
+	simulateCmd.Flags().DurationVar(&simulateTransactionInterval, "transaction-interval", simulator.DefaultConfig.TransactionInterval,
+		"The mean time between the transactions of each charge station")
+	simulateCmd.Flags().DurationVar(&simulateTransactionDuration, "transaction-duration", simulator.DefaultConfig.TransactionDuration,
+		"The mean duration of a transaction")
+	simulateCmd.Flags().DurationVar(&simulateMeterValueInterval, "meter-value-interval", simulator.DefaultConfig.MeterValueInterval,
+		"The interval between meter values during a transaction")
+	simulateCmd.Flags().DurationVar(&simulateRampUp, "ramp-up", 0,
+		"The time over which the charge stations are started")
+	simulateCmd.Flags().DurationVar(&simulateDuration, "duration", 0,
+		"How long to run the simulation for: it runs until interrupted if not set")
+	simulateCmd.Flags().DurationVar(&simulateStatsInterval, "stats-interval", time.Minute,
+		"The interval at which the simulation statistics are printed: they are only printed at the end if not set")
+}
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/api/client"
+	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"os"
+	"time"
+)
+
+var (
+	tokenConfigFile   string
+	tokenApiUrl       string
+	tokenApiKeyEnvVar string
+)
+
+// tokenCmd represents the token command
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage the tokens that authorize charges",
+	Long: `Add, list and revoke the tokens (e.g. RFID cards and eMAIDs) that authorize
+charges. The tokens are managed in the storage configured in the storage section
+of the config file or, if --api-url is set, through the manager's API: the API
+must be used when the manager uses in-memory storage.`,
+}
+
+// tokenManager manages the tokens held by the storage engine or the API
+type tokenManager interface {
+	SetToken(ctx context.Context, token *api.Token) error
+	ListTokens(ctx context.Context, offset, limit int) ([]*store.Token, error)
+	RevokeToken(ctx context.Context, uid string) error
+}
+
+func openTokenManager(ctx context.Context) (tokenManager, error) {
+	if tokenApiUrl != "" {
+		apiKey := os.Getenv(tokenApiKeyEnvVar)
+		c, err := client.NewClientWithResponses(tokenApiUrl, client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			if apiKey != "" {
+				req.Header.Set(api.ApiKeyHeader, apiKey)
+			}
+			return nil
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("creating api client: %w", err)
+		}
+		return apiTokenManager{client: c}, nil
+	}
+
+	cfg := config.DefaultConfig
+	err := cfg.LoadFromFile(tokenConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Storage.Type == "in_memory" {
+		return nil, errors.New("in-memory storage is only reachable through the manager: use --api-url")
+	}
+	engine, err := config.OpenStorage(ctx, &cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("opening storage: %w", err)
+	}
+	return storeTokenManager{store: engine}, nil
+}
+
+// storeTokenManager manages the tokens held by the storage engine
+type storeTokenManager struct {
+	store store.TokenStore
+}
+
+func (s storeTokenManager) SetToken(ctx context.Context, token *api.Token) error {
+	tok, err := api.NewStoreToken(token, time.Now())
+	if err != nil {
+		return err
+	}
+	// a token keeps the tenant that it was first registered by
+	existing, err := s.store.LookupToken(ctx, tok.Uid)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		tok.TenantId = existing.TenantId
+		tok.Version = existing.Version
+	}
+	return s.store.SetToken(ctx, tok)
+}
+
+func (s storeTokenManager) ListTokens(ctx context.Context, offset, limit int) ([]*store.Token, error) {
+	return s.store.ListTokens(ctx, offset, limit)
+}
+
+func (s storeTokenManager) RevokeToken(ctx context.Context, uid string) error {
+	tok, err := s.store.LookupToken(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return fmt.Errorf("token %s not found", uid)
+	}
+	tok.Valid = false
+	tok.LastUpdated = time.Now().Format(time.RFC3339)
+	return s.store.SetToken(ctx, tok)
+}
+
+// apiTokenManager manages the tokens through the manager's API
+type apiTokenManager struct {
+	client client.ClientWithResponsesInterface
+}
+
+func (a apiTokenManager) SetToken(ctx context.Context, token *api.Token) error {
+	resp, err := a.client.SetTokenWithResponse(ctx, client.Token{
+		CacheMode:    client.TokenCacheMode(token.CacheMode),
+		ContractId:   token.ContractId,
+		CountryCode:  token.CountryCode,
+		GroupId:      token.GroupId,
+		Issuer:       token.Issuer,
+		LanguageCode: token.LanguageCode,
+		PartyId:      token.PartyId,
+		Type:         client.TokenType(token.Type),
+		Uid:          token.Uid,
+		Valid:        token.Valid,
+		VisualNumber: token.VisualNumber,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return apiError(resp.HTTPResponse, resp.JSON400, resp.JSONDefault)
+	}
+	return nil
+}
+
+func (a apiTokenManager) ListTokens(ctx context.Context, offset, limit int) ([]*store.Token, error) {
+	resp, err := a.client.ListTokensWithResponse(ctx, &client.ListTokensParams{Offset: &offset, Limit: &limit})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, apiError(resp.HTTPResponse, resp.JSONDefault)
+	}
+	var tokens []*store.Token
+	for _, token := range *resp.JSON200 {
+		var lastUpdated string
+		if token.LastUpdated != nil {
+			lastUpdated = token.LastUpdated.Format(time.RFC3339)
+		}
+		tokens = append(tokens, &store.Token{
+			CountryCode:  token.CountryCode,
+			PartyId:      token.PartyId,
+			Type:         string(token.Type),
+			Uid:          token.Uid,
+			ContractId:   token.ContractId,
+			VisualNumber: token.VisualNumber,
+			Issuer:       token.Issuer,
+			GroupId:      token.GroupId,
+			Valid:        token.Valid,
+			LanguageCode: token.LanguageCode,
+			CacheMode:    string(token.CacheMode),
+			LastUpdated:  lastUpdated,
+		})
+	}
+	return tokens, nil
+}
+
+func (a apiTokenManager) RevokeToken(ctx context.Context, uid string) error {
+	resp, err := a.client.RevokeTokenWithResponse(ctx, uid, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return fmt.Errorf("token %s not found", uid)
+	}
+	if resp.StatusCode() != http.StatusNoContent {
+		return apiError(resp.HTTPResponse, resp.JSONDefault)
+	}
+	return nil
+}
+
+// apiError describes an unsuccessful API response using the first status that the API
+// returned
+func apiError(resp *http.Response, statuses ...*client.Status) error {
+	for _, status := range statuses {
+		if status != nil {
+			if status.Error != nil {
+				return fmt.Errorf("%s: %s: %s", resp.Status, status.Status, *status.Error)
+			}
+			return fmt.Errorf("%s: %s", resp.Status, status.Status)
+		}
+	}
+	return fmt.Errorf("unexpected response: %s", resp.Status)
+}
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+
+	tokenCmd.PersistentFlags().StringVarP(&tokenConfigFile, "config-file", "c", "/config/config.toml",
+		"The config file whose storage section describes the storage that holds the tokens")
+	tokenCmd.PersistentFlags().StringVar(&tokenApiUrl, "api-url", "",
+		"The base URL of the manager's API, e.g. http://localhost:9410/api/v1, to manage the tokens through")
+	tokenCmd.PersistentFlags().StringVar(&tokenApiKeyEnvVar, "api-key-env-var", "MANAGER_API_KEY",
+		"The environment variable that contains the API key used to authenticate with the API")
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"time"
+)
+
+var (
+	tokenAddType         string
+	tokenAddContractId   string
+	tokenAddCountryCode  string
+	tokenAddPartyId      string
+	tokenAddIssuer       string
+	tokenAddVisualNumber string
+	tokenAddGroupId      string
+	tokenAddLanguageCode string
+	tokenAddCacheMode    string
+	tokenAddInvalid      bool
+)
+
+var tokenAddCmd = &cobra.Command{
+	Use:   "add <uid>",
+	Short: "Add or update a token",
+	Long: `Adds the token with the uid, or replaces it if it already exists. The uid of an
+RFID token is the hexadecimal UID of the card. The country code and party id of
+the issuing eMSP default to those of the contract id (eMAID).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		emaid, err := ocpp.ParseEmaid(tokenAddContractId)
+		if err != nil {
+			return err
+		}
+		token := &api.Token{
+			CacheMode:   api.TokenCacheMode(tokenAddCacheMode),
+			ContractId:  emaid.String(),
+			CountryCode: tokenAddCountryCode,
+			Issuer:      tokenAddIssuer,
+			PartyId:     tokenAddPartyId,
+			Type:        api.TokenType(tokenAddType),
+			Uid:         args[0],
+			Valid:       !tokenAddInvalid,
+		}
+		if token.CountryCode == "" {
+			token.CountryCode = emaid.CountryCode
+		}
+		if token.PartyId == "" {
+			token.PartyId = emaid.ProviderId
+		}
+		if tokenAddVisualNumber != "" {
+			token.VisualNumber = &tokenAddVisualNumber
+		}
+		if tokenAddGroupId != "" {
+			token.GroupId = &tokenAddGroupId
+		}
+		if tokenAddLanguageCode != "" {
+			token.LanguageCode = &tokenAddLanguageCode
+		}
+
+		manager, err := openTokenManager(ctx)
+		if err != nil {
+			return err
+		}
+		err = manager.SetToken(ctx, token)
+		if err != nil {
+			return fmt.Errorf("setting token %s: %w", token.Uid, err)
+		}
+		fmt.Printf("%s: added\n", token.Uid)
+		return nil
+	},
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenAddCmd)
+
+	tokenAddCmd.Flags().StringVar(&tokenAddType, "type", string(api.RFID),
+		"The type of token, one of [RFID, AD_HOC_USER, APP_USER, OTHER]")
+	tokenAddCmd.Flags().StringVar(&tokenAddContractId, "contract-id", "",
+		"The contract id (eMAID) associated with the token")
+	tokenAddCmd.Flags().StringVar(&tokenAddCountryCode, "country-code", "",
+		"The country code of the issuing eMSP (defaults to that of the contract id)")
+	tokenAddCmd.Flags().StringVar(&tokenAddPartyId, "party-id", "",
+		"The party id of the issuing eMSP (defaults to that of the contract id)")
+	tokenAddCmd.Flags().StringVar(&tokenAddIssuer, "issuer", "",
+		"The company that issued the token")
+	tokenAddCmd.Flags().StringVar(&tokenAddVisualNumber, "visual-number", "",
+		"The number printed on the RFID card")
+	tokenAddCmd.Flags().StringVar(&tokenAddGroupId, "group-id", "",
+		"The id of the group of tokens that work as one")
+	tokenAddCmd.Flags().StringVar(&tokenAddLanguageCode, "language-code", "",
+		"The preferred language of the token holder as an ISO 639-1 language code")
+	tokenAddCmd.Flags().StringVar(&tokenAddCacheMode, "cache-mode", string(api.ALWAYS),
+		"The type of token caching that is allowed, one of [ALWAYS, ALLOWED, ALLOWED_OFFLINE, NEVER]")
+	tokenAddCmd.Flags().BoolVar(&tokenAddInvalid, "invalid", false,
+		"Add the token as invalid, so that it does not authorize charges")
+	_ = tokenAddCmd.MarkFlagRequired("contract-id")
+	_ = tokenAddCmd.MarkFlagRequired("issuer")
+}
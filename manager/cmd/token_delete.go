@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+var tokenDeleteCmd = &cobra.Command{
+	Use:     "delete <uid>...",
+	Aliases: []string{"revoke"},
+	Short:   "Revoke tokens",
+	Long: `Marks each of the tokens as invalid so that it can no longer be used to
+authorize a charge. The tokens are kept so that past transactions can still be
+attributed to them.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		manager, err := openTokenManager(ctx)
+		if err != nil {
+			return err
+		}
+		for _, uid := range args {
+			err = manager.RevokeToken(ctx, uid)
+			if err != nil {
+				return fmt.Errorf("revoking token %s: %w", uid, err)
+			}
+			fmt.Printf("%s: revoked\n", uid)
+		}
+		return nil
+	},
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenDeleteCmd)
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+var tokenListJson bool
+
+// tokenListPageSize is the number of tokens requested at a time: the most that the API
+// returns
+const tokenListPageSize = 100
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the tokens",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		manager, err := openTokenManager(ctx)
+		if err != nil {
+			return err
+		}
+
+		var tokens []*store.Token
+		for offset := 0; ; offset += tokenListPageSize {
+			page, err := manager.ListTokens(ctx, offset, tokenListPageSize)
+			if err != nil {
+				return fmt.Errorf("listing tokens: %w", err)
+			}
+			tokens = append(tokens, page...)
+			if len(page) < tokenListPageSize {
+				break
+			}
+		}
+
+		if tokenListJson {
+			b, err := json.MarshalIndent(tokens, "", "  ")
+			if err != nil {
+				return fmt.Errorf("formatting tokens: %w", err)
+			}
+			fmt.Println(string(b))
+			return nil
+		}
+
+		tbl := table.New("Uid", "Type", "Contract Id", "Issuer", "Valid", "Cache Mode", "Last Updated")
+		for _, token := range tokens {
+			tbl.AddRow(token.Uid, token.Type, token.ContractId, token.Issuer, token.Valid, token.CacheMode, token.LastUpdated)
+		}
+		tbl.Print()
+		return nil
+	},
+}
+
+func init() {
+	tokenCmd.AddCommand(tokenListCmd)
+
+	tokenListCmd.Flags().BoolVar(&tokenListJson, "json", false,
+		"Print the tokens as JSON")
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/store/eventlog"
+)
+
+var transactionConfigFile string
+
+var transactionEventsCmd = &cobra.Command{
+	Use:   "events cs-id transaction-id",
+	Short: "List the events recorded for a transaction",
+	Long: `Lists the events recorded in the transaction event log for a transaction in the
+order that they were recorded. The storage section of the config file must
+enable the event log.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		log, err := openTransactionEventLog(ctx)
+		if err != nil {
+			return err
+		}
+
+		events, err := log.ListTransactionEvents(ctx, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			b, err := json.MarshalIndent(event, "", "  ")
+			if err != nil {
+				return fmt.Errorf("formatting transaction event: %w", err)
+			}
+			fmt.Println(string(b))
+		}
+		return nil
+	},
+}
+
+func openTransactionEventLog(ctx context.Context) (*eventlog.Store, error) {
+	engine, err := openConfiguredStorage(ctx, transactionConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("opening storage: %w", err)
+	}
+	log, ok := engine.(*eventlog.Store)
+	if !ok {
+		return nil, fmt.Errorf("the transaction event log is not enabled: set event_log in the storage section of %s", transactionConfigFile)
+	}
+	return log, nil
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionEventsCmd)
+
+	addTransactionConfigFileFlag(transactionEventsCmd)
+}
+
+func addTransactionConfigFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&transactionConfigFile, "config-file", "c", "/config/config.toml",
+		"The config file whose storage section describes the storage holding the transaction event log")
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+var transactionReplayCmd = &cobra.Command{
+	Use:   "replay [cs-id transaction-id]...",
+	Short: "Rebuild transactions from their events",
+	Long: `Rebuilds transactions from the events recorded in the transaction event log,
+replacing the stored transactions, e.g. after a handler bug wrote the wrong
+values. Requires an even number of arguments in the format "cs-id
+transaction-id": if there are none every transaction is replayed.
+
+Transactions without events are left unchanged. Transactions that started
+before the event log was enabled must not be replayed as their earlier
+changes were not recorded.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args)%2 != 0 {
+			return fmt.Errorf("incorrect number of arguments: %v", args)
+		}
+
+		ctx := context.Background()
+		log, err := openTransactionEventLog(ctx)
+		if err != nil {
+			return err
+		}
+
+		var ids [][2]string
+		for i := 0; i < len(args); i += 2 {
+			ids = append(ids, [2]string{args[i], args[i+1]})
+		}
+		if len(ids) == 0 {
+			transactions, err := log.Transactions(ctx)
+			if err != nil {
+				return fmt.Errorf("getting transactions: %w", err)
+			}
+			for _, transaction := range transactions {
+				ids = append(ids, [2]string{transaction.ChargeStationId, transaction.TransactionId})
+			}
+		}
+
+		tbl := table.New("Charge Station", "Transaction", "Replayed")
+		defer tbl.Print()
+		for _, id := range ids {
+			transaction, err := log.Replay(ctx, id[0], id[1])
+			if err != nil {
+				return err
+			}
+			tbl.AddRow(id[0], id[1], transaction != nil)
+		}
+		return nil
+	},
+}
+
+func init() {
+	transactionCmd.AddCommand(transactionReplayCmd)
+	addTransactionConfigFileFlag(transactionReplayCmd)
+}
@@ -4,14 +4,19 @@ package config
 
 import (
 	"bufio"
+	"bytes"
+	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // BaseConfig provides the data structures that represent the configuration
-// and provides the ability to load the configuration from a TOML file.
+// and provides the ability to load the configuration from a TOML or YAML file.
 type BaseConfig struct {
 	Api                       ApiSettingsConfig               `mapstructure:"api" toml:"api" validate:"required"`
 	Transport                 TransportConfig                 `mapstructure:"transport" toml:"transport" validate:"required"`
@@ -22,18 +27,25 @@ type BaseConfig struct {
 	ContractCertProvider      ContractCertProviderConfig      `mapstructure:"contract_cert_provider" toml:"contract_cert_provider" validate:"required"`
 	ChargeStationCertProvider ChargeStationCertProviderConfig `mapstructure:"charge_station_cert_provider" toml:"charge_station_cert_provider" validate:"required"`
 	TariffService             TariffServiceConfig             `mapstructure:"tariff_service" toml:"tariff_service" validate:"required"`
+	LoadManagement            *LoadManagementConfig           `mapstructure:"load_management,omitempty" toml:"load_management,omitempty"`
 	Ocpi                      *OcpiConfig                     `mapstructure:"ocpi,omitempty" toml:"ocpi,omitempty"`
+	Oicp                      *OicpConfig                     `mapstructure:"oicp,omitempty" toml:"oicp,omitempty"`
+	Retention                 *RetentionConfig                `mapstructure:"retention,omitempty" toml:"retention,omitempty"`
+	Receipts                  *ReceiptsConfig                 `mapstructure:"receipts,omitempty" toml:"receipts,omitempty"`
+	Events                    *EventsConfig                   `mapstructure:"events,omitempty" toml:"events,omitempty"`
+	Shutdown                  *ShutdownConfig                 `mapstructure:"shutdown,omitempty" toml:"shutdown,omitempty"`
 }
 
 // DefaultConfig provides the default configuration. The configuration
 // read from the TOML file will overlay this configuration.
 var DefaultConfig = BaseConfig{
 	Api: ApiSettingsConfig{
-		Addr:    "localhost:9410",
-		Host:    "localhost",
-		WsPort:  80,
-		WssPort: 443,
-		OrgName: "Thoughtworks",
+		Addr:         "localhost:9410",
+		Host:         "localhost",
+		WsPort:       80,
+		WssPort:      443,
+		OrgName:      "Thoughtworks",
+		EventHistory: 1000,
 	},
 	Transport: TransportConfig{
 		Type: "mqtt",
@@ -91,14 +103,39 @@ func (c *BaseConfig) Load(reader io.Reader) error {
 	return nil
 }
 
-// LoadFromFile reads TOML configuration from a file.
+// LoadYaml reads YAML configuration from a reader. The YAML document has the same
+// structure and keys as the TOML configuration.
+func (c *BaseConfig) LoadYaml(reader io.Reader) error {
+	var doc map[string]any
+	err := yaml.NewDecoder(reader).Decode(&doc)
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("decoding yaml: %w", err)
+	}
+	var buf bytes.Buffer
+	err = toml.NewEncoder(&buf).Encode(doc)
+	if err != nil {
+		return fmt.Errorf("converting yaml: %w", err)
+	}
+	return c.Load(&buf)
+}
+
+// LoadFromFile reads configuration from a file: files with a .yaml or .yml extension
+// are read as YAML, all others as TOML.
 func (c *BaseConfig) LoadFromFile(configFile string) error {
 	//#nosec G304 - only files specified by the person running the application will be loaded
 	f, err := os.Open(configFile)
 	if err != nil {
 		return err
 	}
-	err = c.Load(bufio.NewReader(f))
+	defer func() {
+		_ = f.Close()
+	}()
+	switch strings.ToLower(filepath.Ext(configFile)) {
+	case ".yaml", ".yml":
+		err = c.LoadYaml(bufio.NewReader(f))
+	default:
+		err = c.Load(bufio.NewReader(f))
+	}
 	c.replaceDefaults()
 	return err
 }
@@ -114,6 +151,13 @@ func (c *BaseConfig) replaceDefaults() {
 				c.ContractCertValidator.Ocsp.RootCertProvider.File = nil
 			}
 		}
+	case "crl":
+		// the OCSP configuration is only used if the CRL validator checks OCSP status
+		if c.ContractCertValidator.Crl == nil || !c.ContractCertValidator.Crl.CheckOcsp {
+			c.ContractCertValidator.Ocsp = nil
+		} else if c.ContractCertValidator.Ocsp != nil && c.ContractCertValidator.Ocsp.RootCertProvider.Type != "file" {
+			c.ContractCertValidator.Ocsp.RootCertProvider.File = nil
+		}
 	}
 }
 
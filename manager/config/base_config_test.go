@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/config"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -17,11 +19,12 @@ func TestParseConfig(t *testing.T) {
 
 	want := &config.BaseConfig{
 		Api: config.ApiSettingsConfig{
-			Addr:    ":9410",
-			Host:    "example.com",
-			WsPort:  80,
-			WssPort: 443,
-			OrgName: "Example",
+			Addr:         ":9410",
+			Host:         "example.com",
+			WsPort:       80,
+			WssPort:      443,
+			OrgName:      "Example",
+			EventHistory: 1000,
 		},
 		Transport: config.TransportConfig{
 			Type: "mqtt",
@@ -109,3 +112,77 @@ func TestValidateConfig(t *testing.T) {
 	err := cfg.Validate()
 	assert.NoError(t, err)
 }
+
+func TestParseYamlConfig(t *testing.T) {
+	want := clone.Clone(&config.DefaultConfig)
+	err := want.LoadFromFile("testdata/config.toml")
+	require.NoError(t, err)
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	err = cfg.LoadFromFile("testdata/config.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, want, cfg)
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("MANAGER_API_ADDR", ":9411")
+	t.Setenv("MANAGER_OCPP_OCPP16_ENABLED", "false")
+	t.Setenv("MANAGER_TRANSPORT_MQTT_URLS", "mqtt://one:1883, mqtt://two:1883")
+	t.Setenv("MANAGER_STORAGE_TYPE", "sqlite")
+	t.Setenv("MANAGER_STORAGE_SQLITE_PATH", "/data/manager.db")
+	t.Setenv("MANAGER_SHUTDOWN_TIMEOUT", "10s")
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	err := cfg.LoadFromEnv(config.EnvPrefix)
+	require.NoError(t, err)
+
+	assert.Equal(t, ":9411", cfg.Api.Addr)
+	assert.False(t, cfg.Ocpp.Ocpp16Enabled)
+	assert.True(t, cfg.Ocpp.Ocpp201Enabled)
+	assert.Equal(t, []string{"mqtt://one:1883", "mqtt://two:1883"}, cfg.Transport.Mqtt.Urls)
+	assert.Equal(t, "cs", cfg.Transport.Mqtt.Prefix)
+	assert.Equal(t, "sqlite", cfg.Storage.Type)
+	require.NotNil(t, cfg.Storage.SqliteStorage)
+	assert.Equal(t, "/data/manager.db", cfg.Storage.SqliteStorage.Path)
+	require.NotNil(t, cfg.Shutdown)
+	assert.Equal(t, "10s", cfg.Shutdown.Timeout)
+	assert.Nil(t, cfg.Storage.FirestoreStorage)
+	assert.Equal(t, []string{"mqtt://localhost:1883"}, config.DefaultConfig.Transport.Mqtt.Urls)
+
+	err = cfg.Validate()
+	assert.NoError(t, err)
+}
+
+func TestLoadConfigFromEnvRejectsInvalidValues(t *testing.T) {
+	t.Setenv("MANAGER_OCPP_OCPP16_ENABLED", "maybe")
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	err := cfg.LoadFromEnv(config.EnvPrefix)
+	assert.ErrorContains(t, err, "MANAGER_OCPP_OCPP16_ENABLED")
+}
+
+func TestParseCrlContractCertValidatorConfigRemovesDefaultOcspConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	err := os.WriteFile(configFile, []byte(`
+[contract_cert_validator]
+type = "crl"
+
+[contract_cert_validator.crl]
+max_crl_age = "1h"
+
+[contract_cert_validator.crl.root_certs]
+type = "file"
+file.files = ["root_ca.pem"]
+`), 0600)
+	require.NoError(t, err)
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	err = cfg.LoadFromFile(configFile)
+	require.NoError(t, err)
+
+	assert.Nil(t, cfg.ContractCertValidator.Ocsp)
+	require.NotNil(t, cfg.ContractCertValidator.Crl)
+	assert.Equal(t, "1h", cfg.ContractCertValidator.Crl.MaxCrlAge)
+	assert.NoError(t, cfg.Validate())
+}
@@ -3,14 +3,24 @@
 package config
 
 type OpcpChargeStationCertProviderConfig struct {
-	Url      string         `mapstructure:"url" toml:"url" validate:"required"`
-	HttpAuth HttpAuthConfig `mapstructure:"auth" toml:"auth" validate:"required"`
+	Url        string         `mapstructure:"url" toml:"url" validate:"required"`
+	HttpAuth   HttpAuthConfig `mapstructure:"auth" toml:"auth" validate:"required"`
+	IsoVersion string         `mapstructure:"iso_version,omitempty" toml:"iso_version,omitempty" validate:"omitempty,oneof=ISO15118-2 ISO15118-20"`
 }
 
 type LocalSourceConfig struct {
-	Type              string `mapstructure:"type" toml:"type" validate:"required,oneof=file google_cloud_secret"`
-	File              string `mapstructure:"file,omitempty" toml:"file,omitempty" validate:"required_if=Type file"`
-	GoogleCloudSecret string `mapstructure:"google_cloud_secret,omitempty" toml:"google_cloud_secret,omitempty" validate:"required_if=Type google_cloud_secret"`
+	Type              string             `mapstructure:"type" toml:"type" validate:"required,oneof=file env google_cloud_secret vault"`
+	File              string             `mapstructure:"file,omitempty" toml:"file,omitempty" validate:"required_if=Type file"`
+	Env               string             `mapstructure:"env,omitempty" toml:"env,omitempty" validate:"required_if=Type env"`
+	GoogleCloudSecret string             `mapstructure:"google_cloud_secret,omitempty" toml:"google_cloud_secret,omitempty" validate:"required_if=Type google_cloud_secret"`
+	Vault             *VaultSecretConfig `mapstructure:"vault,omitempty" toml:"vault,omitempty" validate:"required_if=Type vault"`
+}
+
+type VaultSecretConfig struct {
+	Address     string `mapstructure:"address,omitempty" toml:"address,omitempty"`
+	Path        string `mapstructure:"path" toml:"path" validate:"required"`
+	Key         string `mapstructure:"key" toml:"key" validate:"required"`
+	TokenEnvVar string `mapstructure:"token_env_var,omitempty" toml:"token_env_var,omitempty"`
 }
 
 type LocalChargeStationCertProviderConfig struct {
@@ -5,18 +5,35 @@ package config
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	goredis "github.com/redis/go-redis/v9"
 	"github.com/subnova/slog-exporter/slogtrace"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/events"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	ocpp16types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/oicp"
 	"github.com/thoughtworks/maeve-csms/manager/schemas"
 	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
-	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
-	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/store/cache"
+	_ "github.com/thoughtworks/maeve-csms/manager/store/dynamodb"
+	"github.com/thoughtworks/maeve-csms/manager/store/eventlog"
+	_ "github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	_ "github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/store/redis"
+	"github.com/thoughtworks/maeve-csms/manager/store/resilient"
+	_ "github.com/thoughtworks/maeve-csms/manager/store/sqlite"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	mqtt2 "github.com/thoughtworks/maeve-csms/manager/transport/mqtt"
+	"github.com/thoughtworks/maeve-csms/manager/transport/nats"
+	"github.com/thoughtworks/maeve-csms/manager/transport/ws"
 	"go.opentelemetry.io/contrib/detectors/gcp"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
@@ -26,22 +43,38 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.19.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"golang.org/x/exp/slog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"html/template"
 	"k8s.io/utils/clock"
+	"net"
 	"net/http"
+	"net/smtp"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type ApiSettings struct {
-	Addr    string
-	Host    string
-	WsPort  int
-	WssPort int
-	OrgName string
+	Addr          string
+	Host          string
+	WsPort        int
+	WssPort       int
+	OrgName       string
+	ActionFlags   *handlers.ActionFlags
+	Drain         *transport.Drain
+	Reloader      *Reloader
+	Authenticator api.Authenticator
+	EventStream   *events.Broadcaster
+	// RootCertificates refreshes the root certificates used to validate contract
+	// certificates
+	RootCertificates services.RootCertificateRefresher
+	// Receipts produces the receipts of transactions
+	Receipts *services.ReceiptService
 }
 
 type Config struct {
@@ -51,13 +84,38 @@ type Config struct {
 	Storage                          store.Engine
 	MsgEmitter                       transport.Emitter
 	MsgListener                      transport.Listener
-	Ocpp16Handler                    transport.MessageHandler
-	Ocpp201Handler                   transport.MessageHandler
+	DeadLetters                      transport.DeadLetterQueue
+	Protocols                        *handlers.ProtocolRegistry
 	ContractCertValidationService    services.CertificateValidationService
+	OcspRevalidator                  services.OcspRevalidator
+	RootCertificateRefresher         services.RootCertificateRefresher
 	ContractCertProviderService      services.ContractCertificateProvider
 	ChargeStationCertProviderService services.ChargeStationCertificateProvider
 	TariffService                    services.TariffService
+	SchedulingStrategy               services.SchedulingStrategy
 	OcpiApi                          ocpi.Api
+	OicpApi                          oicp.Api
+	ProvisioningScript               *ocpp16.ProvisioningScript
+	RegistrationPolicy               handlers.RegistrationPolicy
+	LivenessService                  *services.LivenessService
+	RetentionService                 *services.RetentionService
+	CertificateExpiryService         *services.CertificateExpiryService
+	ReservationNoShowPolicy          *services.ReservationNoShowPolicy
+	PaymentAuthorization             services.PaymentAuthorization
+	DriverNotificationPolicy         *services.DriverNotificationPolicy
+	EventPublisher                   events.Publisher
+	Websocket                        *WebsocketSettings
+	CallScheduler                    *transport.CallScheduler
+	ShutdownTimeout                  time.Duration
+}
+
+// WebsocketSettings holds the servers that charge stations connect to when the
+// manager accepts WebSocket connections directly
+type WebsocketSettings struct {
+	Addr      string
+	TlsAddr   string
+	TlsConfig *tls.Config
+	Transport *ws.Transport
 }
 
 func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
@@ -66,26 +124,44 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
-	heartbeatInterval, err := time.ParseDuration(cfg.Ocpp.HeartbeatInterval)
+	interval, err := time.ParseDuration(cfg.Ocpp.HeartbeatInterval)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse heartbeat interval: %s", err)
 	}
+	heartbeatInterval := services.NewHeartbeatInterval(interval)
+
+	level, err := getLogLevel(cfg.Observability.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(level)
+
+	shutdownTimeout, err := getShutdownTimeout(cfg.Shutdown)
+	if err != nil {
+		return nil, err
+	}
 
 	c = &Config{
 		Api: ApiSettings{
-			Addr:    cfg.Api.Addr,
-			Host:    cfg.Api.Host,
-			WsPort:  cfg.Api.WsPort,
-			WssPort: cfg.Api.WssPort,
-			OrgName: cfg.Api.OrgName,
+			Addr:        cfg.Api.Addr,
+			Host:        cfg.Api.Host,
+			WsPort:      cfg.Api.WsPort,
+			WssPort:     cfg.Api.WssPort,
+			OrgName:     cfg.Api.OrgName,
+			ActionFlags: handlers.NewActionFlags(),
+			Drain:       transport.NewDrain(shutdownTimeout),
+			Reloader:    NewReloader(cfg),
+			EventStream: events.NewBroadcaster(cfg.Api.EventHistory),
 		},
+		ShutdownTimeout: shutdownTimeout,
 	}
 
 	switch cfg.Observability.LogFormat {
 	case "json":
-		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel})))
 	case "text":
-		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, nil)))
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel})))
 	default:
 		return nil, fmt.Errorf("unknown log format: %s", cfg.Observability.LogFormat)
 	}
@@ -95,6 +171,13 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
+	if cfg.Api.Auth != nil {
+		c.Api.Authenticator, err = getApiAuthenticator(ctx, cfg.Api.Auth, httpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	c.TracerProvider, err = getTracerProvider(ctx, cfg.Observability.OtelCollectorAddr)
 	if err != nil {
 		return nil, err
@@ -102,53 +185,273 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 
 	c.Tracer = c.TracerProvider.Tracer("manager")
 
-	c.Storage, err = getStorage(ctx, &cfg.Storage)
+	var transient *redis.Store
+	c.Storage, transient, err = getStorage(ctx, &cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	// events are always streamed to the API's subscribers and, if configured, are also
+	// published to the event bus
+	publishers := events.Publishers{c.Api.EventStream}
+	if cfg.Events != nil {
+		c.EventPublisher, err = getEventPublisher(cfg.Events, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		publishers = append(publishers, c.EventPublisher)
+	}
+	c.Storage = events.NewStore(c.Storage, publishers, clock.RealClock{})
+
+	// the certificate and tariff services are replaced when their configuration is reloaded
+	contractCertValidator, err := getContractCertValidator(&cfg.ContractCertValidator, c.Storage, httpClient)
 	if err != nil {
 		return nil, err
 	}
+	reloadableContractCertValidator := services.NewReloadableCertificateValidationService(contractCertValidator)
+	c.ContractCertValidationService = reloadableContractCertValidator
+	c.OcspRevalidator = reloadableContractCertValidator
+	c.RootCertificateRefresher = reloadableContractCertValidator
+	c.Api.RootCertificates = reloadableContractCertValidator
 
-	c.ContractCertValidationService, err = getContractCertValidator(&cfg.ContractCertValidator, httpClient)
+	contractCertProvider, err := getContractCertProvider(&cfg.ContractCertProvider, httpClient)
 	if err != nil {
 		return nil, err
 	}
+	reloadableContractCertProvider := services.NewReloadableContractCertificateProvider(contractCertProvider)
+	c.ContractCertProviderService = reloadableContractCertProvider
 
-	c.ContractCertProviderService, err = getContractCertProvider(&cfg.ContractCertProvider, httpClient)
+	chargeStationCertProvider, err := getChargeStationCertProvider(ctx, &cfg.ChargeStationCertProvider, c.Storage, httpClient)
 	if err != nil {
 		return nil, err
 	}
+	reloadableChargeStationCertProvider := services.NewReloadableChargeStationCertificateProvider(chargeStationCertProvider)
+	c.ChargeStationCertProviderService = reloadableChargeStationCertProvider
 
-	c.ChargeStationCertProviderService, err = getChargeStationCertProvider(ctx, &cfg.ChargeStationCertProvider, c.Storage, httpClient)
+	tariffService, err := getTariffService(&cfg.TariffService, c.Storage, httpClient)
 	if err != nil {
 		return nil, err
 	}
+	reloadableTariffService := services.NewReloadableTariffService(tariffService)
+	c.TariffService = reloadableTariffService
 
-	c.TariffService, err = getTariffService(&cfg.TariffService)
+	c.SchedulingStrategy, err = getSchedulingStrategy(cfg.LoadManagement, c.Storage)
 	if err != nil {
 		return nil, err
 	}
 
-	c.MsgEmitter, err = getMsgEmitter(&cfg.Transport, c.Tracer)
+	if cfg.Transport.Type == "websocket" {
+		c.Websocket, err = getWebsocketSettings(cfg.Transport.Websocket, c.Storage, cfg.Api.OrgName, c.Tracer)
+		if err != nil {
+			return nil, err
+		}
+		c.MsgEmitter, c.MsgListener = c.Websocket.Transport, c.Websocket.Transport
+	} else {
+		c.MsgEmitter, c.MsgListener, err = getTransport(&cfg.Transport, c.Tracer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	callScheduler, err := getCallScheduler(cfg.Ocpp.OutboundCalls, c.MsgEmitter, c.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if callScheduler != nil {
+		c.MsgEmitter = callScheduler
+		c.CallScheduler = callScheduler
+	}
+
+	// every call is recorded in the command audit log before it is queued or sent
+	commandAuditor := transport.NewCommandAuditor(c.MsgEmitter, c.Storage, clock.RealClock{})
+	c.MsgEmitter = commandAuditor
+
+	c.DeadLetters, err = getDeadLetterQueue(&cfg.Transport, c.Tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	c.ProvisioningScript, err = getProvisioningScript(cfg.Ocpp.Provisioning)
+	if err != nil {
+		return nil, err
+	}
+
+	c.RegistrationPolicy, err = getRegistrationPolicy(cfg.Ocpp.Registration, c.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	c.LivenessService = &services.LivenessService{
+		Clock:             clock.RealClock{},
+		Store:             c.Storage,
+		Notifier:          services.LogLivenessNotifier{},
+		HeartbeatInterval: heartbeatInterval,
+		MissedHeartbeats:  cfg.Ocpp.OfflineAfterMissedHeartbeats,
+	}
+
+	c.CertificateExpiryService, err = getCertificateExpiryService(cfg.Ocpp.CertificateRenewal, c.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	c.ReservationNoShowPolicy, err = getReservationNoShowPolicy(cfg.Ocpp.ReservationNoShow, reloadableTariffService)
+	if err != nil {
+		return nil, err
+	}
+
+	c.DriverNotificationPolicy, err = getDriverNotificationPolicy(cfg.Ocpp.ReservationNotifications, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Api.Receipts, err = getReceiptService(cfg.Receipts, cfg.Api.OrgName, c.Storage, reloadableTariffService)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Retention != nil {
+		c.RetentionService, err = getRetentionService(cfg.Retention, c.Storage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Ocpi != nil {
+		c.OcpiApi, err = getOcpiApi(cfg.Ocpi, c.Storage, c.TariffService, httpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Oicp != nil {
+		c.OicpApi, err = getOicpApi(cfg.Oicp, c.Storage, cfg.Observability.TlsKeylogFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// when OCPI is configured the OCPI parties are notified of changes to connector statuses,
+	// transactions and the results of their commands, and are asked to authorize unknown tokens.
+	// When OICP is configured Hubject is notified in the same way, and is asked about tokens
+	// that are not known to the OCPI parties. Connector statuses and security events are
+	// also streamed to the API's subscribers and, when events are configured, published to
+	// the downstream consumers.
+	var connectorStatusListeners handlers.ConnectorStatusListeners
+	var transactionListeners handlers.TransactionListeners
+	var remoteTokenAuthorizers services.RemoteTokenAuthorizers
+	var commandResultListener handlers.CommandResultListener
+	var securityEventListener handlers.SecurityEventListener
+	if c.OcpiApi != nil {
+		connectorStatusListeners = append(connectorStatusListeners, c.OcpiApi)
+		transactionListeners = append(transactionListeners, c.OcpiApi)
+		remoteTokenAuthorizers = append(remoteTokenAuthorizers, c.OcpiApi)
+		commandResultListener = c.OcpiApi
+	}
+	if c.OicpApi != nil {
+		connectorStatusListeners = append(connectorStatusListeners, c.OicpApi)
+		transactionListeners = append(transactionListeners, c.OicpApi)
+		remoteTokenAuthorizers = append(remoteTokenAuthorizers, c.OicpApi)
+	}
+
+	eventListener := events.NewListener(publishers, clock.RealClock{})
+	connectorStatusListeners = append(connectorStatusListeners, eventListener)
+	securityEventListener = eventListener
+
+	var connectorStatusListener handlers.ConnectorStatusListener
+	var transactionListener handlers.TransactionListener
+	var remoteTokenAuthorizer services.RemoteTokenAuthorizer
+	if len(connectorStatusListeners) > 0 {
+		connectorStatusListener = connectorStatusListeners
+	}
+	if len(transactionListeners) > 0 {
+		transactionListener = transactionListeners
+		remoteTokenAuthorizer = remoteTokenAuthorizers
+	}
+
+	var callMiddleware []handlers.CallMiddleware
+	if cfg.Ocpp.RateLimit != nil {
+		rateLimiter, err := getRateLimiter(cfg.Ocpp.RateLimit)
+		if err != nil {
+			return nil, err
+		}
+		callMiddleware = append(callMiddleware, rateLimiter.Middleware)
+	}
+
+	callResponses, err := getCallResponses(cfg.Ocpp.DuplicateCalls, transient)
 	if err != nil {
 		return nil, err
 	}
 
-	c.MsgListener, err = getMsgListener(&cfg.Transport, c.Tracer)
+	poisonMessages, err := getPoisonMessages(cfg.Ocpp.PoisonMessages)
 	if err != nil {
 		return nil, err
 	}
 
+	// every message is recorded for liveness and may release a queued call: the
+	// responses to calls are recorded in the command audit log
+	wrapRouter := func(router transport.MessageHandler) transport.MessageHandler {
+		router = handlers.LivenessHandler{
+			Handler:  router,
+			Liveness: c.LivenessService,
+		}
+		router = commandAuditor.Handler(router)
+		if callScheduler != nil {
+			router = callScheduler.Handler(router)
+		}
+		return router
+	}
+
+	c.Protocols = handlers.NewProtocolRegistry(c.Storage)
 	if cfg.Ocpp.Ocpp16Enabled {
-		c.Ocpp16Handler = ocpp16.NewRouter(c.MsgEmitter,
+		router := ocpp16.NewRouter(c.MsgEmitter,
 			clock.RealClock{},
 			c.Storage,
 			c.ContractCertValidationService,
 			c.ChargeStationCertProviderService,
 			c.ContractCertProviderService,
 			heartbeatInterval,
-			schemas.OcppSchemas)
+			c.RegistrationPolicy,
+			connectorStatusListener,
+			transactionListener,
+			securityEventListener,
+			remoteTokenAuthorizer,
+			commandResultListener,
+			c.ProvisioningScript,
+			c.DeadLetters,
+			callResponses,
+			poisonMessages,
+			c.Api.ActionFlags,
+			cfg.Ocpp.Ocpp16SoapTranslation,
+			schemas.OcppSchemas,
+			callMiddleware...)
+		c.Protocols.Register(transport.OcppVersion16, wrapRouter(router))
 	}
 	if cfg.Ocpp.Ocpp201Enabled {
-		c.Ocpp201Handler = ocpp201.NewRouter(c.MsgEmitter,
+		router := ocpp201.NewRouter(c.MsgEmitter,
+			clock.RealClock{},
+			c.Storage,
+			c.TariffService,
+			c.ContractCertValidationService,
+			c.ChargeStationCertProviderService,
+			c.ContractCertProviderService,
+			heartbeatInterval,
+			c.RegistrationPolicy,
+			connectorStatusListener,
+			transactionListener,
+			securityEventListener,
+			remoteTokenAuthorizer,
+			c.SchedulingStrategy,
+			c.DeadLetters,
+			callResponses,
+			poisonMessages,
+			c.Api.ActionFlags,
+			schemas.OcppSchemas,
+			callMiddleware...)
+		c.Protocols.Register(transport.OcppVersion201, wrapRouter(router))
+	}
+	if cfg.Ocpp.Ocpp21Enabled {
+		slog.Warn("OCPP 2.1 support is experimental: the specification is a draft")
+		router := ocpp21.NewRouter(c.MsgEmitter,
 			clock.RealClock{},
 			c.Storage,
 			c.TariffService,
@@ -156,68 +459,807 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 			c.ChargeStationCertProviderService,
 			c.ContractCertProviderService,
 			heartbeatInterval,
-			schemas.OcppSchemas)
+			c.RegistrationPolicy,
+			connectorStatusListener,
+			transactionListener,
+			securityEventListener,
+			remoteTokenAuthorizer,
+			c.SchedulingStrategy,
+			c.DeadLetters,
+			callResponses,
+			poisonMessages,
+			c.Api.ActionFlags,
+			schemas.OcppSchemas,
+			callMiddleware...)
+		c.Protocols.Register(transport.OcppVersion21, wrapRouter(router))
+	}
+
+	ApplyDisabledActions(c.Api.ActionFlags, &cfg.Ocpp)
+
+	watchDisabledActions(c.Api.Reloader, c.Api.ActionFlags)
+	c.Api.Reloader.watch(reloadableSetting{
+		name:  "observability.log_level",
+		value: func(cfg *BaseConfig) any { return cfg.Observability.LogLevel },
+		apply: func(cfg *BaseConfig) (func(), error) {
+			level, err := getLogLevel(cfg.Observability.LogLevel)
+			return func() { logLevel.Set(level) }, err
+		},
+	})
+	c.Api.Reloader.watch(reloadableSetting{
+		name:  "ocpp.heartbeat_interval",
+		value: func(cfg *BaseConfig) any { return cfg.Ocpp.HeartbeatInterval },
+		apply: func(cfg *BaseConfig) (func(), error) {
+			interval, err := time.ParseDuration(cfg.Ocpp.HeartbeatInterval)
+			return func() { heartbeatInterval.Set(interval) }, err
+		},
+	})
+	c.Api.Reloader.watch(reloadableSetting{
+		name:  "tariff_service",
+		value: func(cfg *BaseConfig) any { return cfg.TariffService },
+		apply: func(cfg *BaseConfig) (func(), error) {
+			tariffService, err := getTariffService(&cfg.TariffService, c.Storage, httpClient)
+			return func() { reloadableTariffService.Set(tariffService) }, err
+		},
+	})
+	c.Api.Reloader.watch(reloadableSetting{
+		name:   "contract_cert_validator",
+		value:  func(cfg *BaseConfig) any { return cfg.ContractCertValidator },
+		secret: true,
+		apply: func(cfg *BaseConfig) (func(), error) {
+			validator, err := getContractCertValidator(&cfg.ContractCertValidator, c.Storage, httpClient)
+			return func() { reloadableContractCertValidator.Set(validator) }, err
+		},
+	})
+	c.Api.Reloader.watch(reloadableSetting{
+		name:   "contract_cert_provider",
+		value:  func(cfg *BaseConfig) any { return cfg.ContractCertProvider },
+		secret: true,
+		apply: func(cfg *BaseConfig) (func(), error) {
+			provider, err := getContractCertProvider(&cfg.ContractCertProvider, httpClient)
+			return func() { reloadableContractCertProvider.Set(provider) }, err
+		},
+	})
+	c.Api.Reloader.watch(reloadableSetting{
+		name:   "charge_station_cert_provider",
+		value:  func(cfg *BaseConfig) any { return cfg.ChargeStationCertProvider },
+		secret: true,
+		apply: func(cfg *BaseConfig) (func(), error) {
+			provider, err := getChargeStationCertProvider(ctx, &cfg.ChargeStationCertProvider, c.Storage, httpClient)
+			return func() { reloadableChargeStationCertProvider.Set(provider) }, err
+		},
+	})
+
+	return
+}
+
+// watchDisabledActions reapplies the disabled actions each time the configuration is
+// reloaded, replacing any changes made through the API
+func watchDisabledActions(reloader *Reloader, flags *handlers.ActionFlags) {
+	for _, ocppVersion := range []transport.OcppVersion{transport.OcppVersion16, transport.OcppVersion201, transport.OcppVersion21} {
+		ocppVersion := ocppVersion
+		reloader.watch(reloadableSetting{
+			name:   "ocpp." + strings.ReplaceAll(string(ocppVersion), ".", "") + "_disabled_actions",
+			value:  func(cfg *BaseConfig) any { return disabledActions(&cfg.Ocpp)[ocppVersion] },
+			always: true,
+			apply: func(cfg *BaseConfig) (func(), error) {
+				actions := disabledActions(&cfg.Ocpp)[ocppVersion]
+				return func() { setDisabledActions(flags, ocppVersion, actions) }, nil
+			},
+		})
+	}
+}
+
+// ApplyDisabledActions switches off the actions that are disabled in the configuration
+// and switches on all the others
+func ApplyDisabledActions(flags *handlers.ActionFlags, cfg *OcppSettingsConfig) {
+	for ocppVersion, actions := range disabledActions(cfg) {
+		setDisabledActions(flags, ocppVersion, actions)
+	}
+}
+
+func disabledActions(cfg *OcppSettingsConfig) map[transport.OcppVersion][]string {
+	return map[transport.OcppVersion][]string{
+		transport.OcppVersion16:  cfg.Ocpp16DisabledActions,
+		transport.OcppVersion201: cfg.Ocpp201DisabledActions,
+		transport.OcppVersion21:  cfg.Ocpp21DisabledActions,
+	}
+}
+
+func setDisabledActions(flags *handlers.ActionFlags, ocppVersion transport.OcppVersion, actions []string) {
+	for _, action := range actions {
+		if !flags.Known(ocppVersion, action) {
+			slog.Warn("disabling unknown action", slog.String("ocppVersion", string(ocppVersion)), slog.String("action", action))
+		}
+	}
+	flags.SetDisabled(ocppVersion, actions)
+}
+
+func getRegistrationPolicy(cfg *RegistrationConfig, engine store.ChargeStationRegistrationStore) (handlers.RegistrationPolicy, error) {
+	policy := handlers.RegistrationPolicy{
+		Store:         engine,
+		DefaultStatus: store.RegistrationStatusAccepted,
+		RetryInterval: time.Minute,
+	}
+	if cfg == nil {
+		return policy, nil
+	}
+
+	if cfg.DefaultStatus != "" {
+		policy.DefaultStatus = store.RegistrationStatus(cfg.DefaultStatus)
+	}
+	if cfg.RetryInterval != "" {
+		var err error
+		policy.RetryInterval, err = time.ParseDuration(cfg.RetryInterval)
+		if err != nil {
+			return policy, fmt.Errorf("failed to parse registration retry interval: %w", err)
+		}
+	}
+
+	return policy, nil
+}
+
+func getRateLimiter(cfg *RateLimitConfig) (*handlers.RateLimiter, error) {
+	var maxWait time.Duration
+	if cfg.MaxWait != "" {
+		var err error
+		maxWait, err = time.ParseDuration(cfg.MaxWait)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rate limit max wait: %w", err)
+		}
+	}
+
+	limits := make(map[string]handlers.RateLimit)
+	for action, limit := range cfg.Actions {
+		limits[action] = handlers.RateLimit{
+			PerMinute: limit.PerMinute,
+			Burst:     limit.Burst,
+		}
+	}
+
+	return handlers.NewRateLimiter(clock.RealClock{}, limits, maxWait), nil
+}
+
+// getCallResponses returns nil when duplicate call detection is disabled. By default
+// the responses to the last 10,000 calls are held for 10 minutes. If Redis is
+// configured the responses are also held in Redis so that a call retransmitted to
+// another manager instance is recognised.
+func getCallResponses(cfg *DuplicateCallsConfig, shared *redis.Store) (*handlers.CallResponses, error) {
+	size := 10000
+	ttl := 10 * time.Minute
+	if cfg != nil {
+		if cfg.Disabled {
+			return nil, nil
+		}
+		if cfg.CacheSize != 0 {
+			size = cfg.CacheSize
+		}
+		if cfg.Ttl != "" {
+			var err error
+			ttl, err = time.ParseDuration(cfg.Ttl)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse duplicate calls ttl: %w", err)
+			}
+		}
+	}
+
+	var opts []handlers.CallResponsesOpt
+	if shared != nil {
+		opts = append(opts, handlers.WithSharedCallResponses(shared))
+	}
+
+	return handlers.NewCallResponses(clock.RealClock{}, size, ttl, opts...), nil
+}
+
+// getPoisonMessages returns nil when poison message detection is disabled. By default
+// a message that has failed 3 times is not routed again and the failures of the last
+// 10,000 messages are counted for 10 minutes.
+func getPoisonMessages(cfg *PoisonMessagesConfig) (*handlers.PoisonMessages, error) {
+	maxFailures := 3
+	size := 10000
+	ttl := 10 * time.Minute
+	if cfg != nil {
+		if cfg.Disabled {
+			return nil, nil
+		}
+		if cfg.MaxFailures != 0 {
+			maxFailures = cfg.MaxFailures
+		}
+		if cfg.CacheSize != 0 {
+			size = cfg.CacheSize
+		}
+		if cfg.Ttl != "" {
+			var err error
+			ttl, err = time.ParseDuration(cfg.Ttl)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse poison messages ttl: %w", err)
+			}
+		}
+	}
+
+	return handlers.NewPoisonMessages(clock.RealClock{}, maxFailures, size, ttl), nil
+}
+
+func getCallScheduler(cfg *OutboundCallsConfig, emitter transport.Emitter, engine store.OutboundCallQueueStore) (*transport.CallScheduler, error) {
+	var opts []transport.CallSchedulerOpt
+	if cfg != nil {
+		if cfg.Disabled {
+			return nil, nil
+		}
+		if cfg.Timeout != "" {
+			timeout, err := time.ParseDuration(cfg.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse outbound calls timeout: %w", err)
+			}
+			opts = append(opts, transport.WithCallSchedulerTimeout(timeout))
+		}
+	}
+
+	return transport.NewCallScheduler(emitter, engine, opts...), nil
+}
+
+// getCertificateExpiryService returns the service that renews charge station
+// certificates 30 days before they expire and alerts 14 days before they expire,
+// unless the configuration says otherwise
+func getCertificateExpiryService(cfg *CertificateRenewalConfig, engine store.Engine) (*services.CertificateExpiryService, error) {
+	expiry := &services.CertificateExpiryService{
+		Clock:       clock.RealClock{},
+		Store:       engine,
+		Notifier:    services.LogCertificateExpiryNotifier{},
+		RenewBefore: 30 * 24 * time.Hour,
+		AlertBefore: 14 * 24 * time.Hour,
+	}
+
+	if cfg != nil {
+		if cfg.Disabled {
+			return nil, nil
+		}
+		var err error
+		if cfg.RenewBefore != "" {
+			expiry.RenewBefore, err = parseRetentionPeriod(cfg.RenewBefore)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate renew before: %w", err)
+			}
+		}
+		if cfg.AlertBefore != "" {
+			expiry.AlertBefore, err = parseRetentionPeriod(cfg.AlertBefore)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate alert before: %w", err)
+			}
+		}
+	}
+
+	return expiry, nil
+}
+
+// getReservationNoShowPolicy returns the policy that releases reservations that are
+// not used within 15 minutes of starting, unless the configuration says otherwise. The
+// no-show fee is calculated by the tariff service.
+func getReservationNoShowPolicy(cfg *ReservationNoShowConfig, tariffService services.NoShowFeeCalculator) (*services.ReservationNoShowPolicy, error) {
+	policy := &services.ReservationNoShowPolicy{
+		GracePeriod: 15 * time.Minute,
+	}
+
+	if cfg != nil {
+		if cfg.Disabled {
+			return nil, nil
+		}
+		if cfg.GracePeriod != "" {
+			var err error
+			policy.GracePeriod, err = time.ParseDuration(cfg.GracePeriod)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse reservation no-show grace period: %w", err)
+			}
+		}
+		if cfg.Fee {
+			policy.Fees = tariffService
+		}
+	}
+
+	return policy, nil
+}
+
+// getDriverNotificationPolicy returns the policy that notifies drivers about their
+// reservations, or nil if no notifiers are configured. Drivers are reminded 15 minutes
+// before their reservations expire unless the configuration says otherwise.
+func getDriverNotificationPolicy(cfg *ReservationNotificationsConfig, httpClient *http.Client) (*services.DriverNotificationPolicy, error) {
+	if cfg == nil || len(cfg.Notifiers) == 0 {
+		return nil, nil
+	}
+
+	policy := &services.DriverNotificationPolicy{
+		ReminderBefore: 15 * time.Minute,
+	}
+	if cfg.ReminderBefore != "" {
+		var err error
+		policy.ReminderBefore, err = time.ParseDuration(cfg.ReminderBefore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reservation reminder before: %w", err)
+		}
+	}
+
+	tenants := make(map[string]services.DriverNotifiers)
+	for _, notifierCfg := range cfg.Notifiers {
+		notifier, err := getDriverNotifier(&notifierCfg, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		tenants[notifierCfg.Tenant] = append(tenants[notifierCfg.Tenant], notifier)
+	}
+	notifier := services.TenantDriverNotifier{
+		Tenants: make(map[string]services.DriverNotifier),
+	}
+	for tenant, notifiers := range tenants {
+		if tenant == "" {
+			notifier.Default = notifiers
+		} else {
+			notifier.Tenants[tenant] = notifiers
+		}
+	}
+	policy.Notifier = notifier
+
+	return policy, nil
+}
+
+func getDriverNotifier(cfg *DriverNotifierConfig, httpClient *http.Client) (services.DriverNotifier, error) {
+	switch cfg.Type {
+	case "smtp":
+		return getSmtpDriverNotifier(cfg.Smtp)
+	case "sms":
+		var tokenService services.HttpTokenService
+		if cfg.Sms.Auth != nil {
+			var err error
+			tokenService, err = getHttpTokenService(cfg.Sms.Auth, httpClient)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return services.SmsDriverNotifier{
+			Url:          cfg.Sms.Url,
+			From:         cfg.Sms.From,
+			HttpClient:   httpClient,
+			TokenService: tokenService,
+		}, nil
+	case "webhook":
+		var tokenService services.HttpTokenService
+		if cfg.Webhook.Auth != nil {
+			var err error
+			tokenService, err = getHttpTokenService(cfg.Webhook.Auth, httpClient)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return services.WebhookDriverNotifier{
+			Url:          cfg.Webhook.Url,
+			HttpClient:   httpClient,
+			TokenService: tokenService,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown driver notifier type: %s", cfg.Type)
+	}
+}
+
+func getSmtpDriverNotifier(cfg *SmtpDriverNotifierConfig) (services.SmtpDriverNotifier, error) {
+	notifier := services.SmtpDriverNotifier{
+		Addr: cfg.Addr,
+		From: cfg.From,
+	}
+	if cfg.Username != "" {
+		host, _, err := net.SplitHostPort(cfg.Addr)
+		if err != nil {
+			return notifier, fmt.Errorf("failed to parse smtp addr: %w", err)
+		}
+		notifier.Auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+	return notifier, nil
+}
+
+// getReceiptService returns the service that produces receipts: without configuration
+// receipts are not taxed or emailed and are issued in the name of the organization
+func getReceiptService(cfg *ReceiptsConfig, orgName string, engine store.Engine, tariffService services.TariffService) (*services.ReceiptService, error) {
+	receipts := &services.ReceiptService{
+		Tariffs:       engine,
+		TariffService: tariffService,
+		Seller:        orgName,
+	}
+	if cfg == nil {
+		return receipts, nil
+	}
+
+	receipts.TaxRate = cfg.TaxRate
+	if cfg.Seller != "" {
+		receipts.Seller = cfg.Seller
+	}
+	if cfg.TemplateFile != "" {
+		var err error
+		receipts.Template, err = template.ParseFiles(cfg.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse receipt template: %w", err)
+		}
+	}
+	if cfg.Smtp != nil {
+		sender, err := getSmtpDriverNotifier(cfg.Smtp)
+		if err != nil {
+			return nil, err
+		}
+		receipts.Sender = sender
+	}
+	return receipts, nil
+}
+
+func getLogLevel(logLevel string) (slog.Level, error) {
+	if logLevel == "" {
+		return slog.LevelInfo, nil
+	}
+	var level slog.Level
+	err := level.UnmarshalText([]byte(logLevel))
+	if err != nil {
+		return level, fmt.Errorf("failed to parse log level: %w", err)
+	}
+	return level, nil
+}
+
+func getApiAuthenticator(ctx context.Context, cfg *ApiAuthConfig, httpClient *http.Client) (api.Authenticator, error) {
+	var authenticators api.Authenticators
+	if len(cfg.ApiKeys) > 0 {
+		apiKeys := api.NewApiKeyAuthenticator()
+		for _, keyCfg := range cfg.ApiKeys {
+			role, err := api.ParseRole(keyCfg.Role)
+			if err != nil {
+				return nil, fmt.Errorf("api key %s: %w", keyCfg.Name, err)
+			}
+			var key string
+			if keyCfg.Key != nil {
+				key = *keyCfg.Key
+			} else if keyCfg.KeyEnvVar != nil {
+				key = os.Getenv(*keyCfg.KeyEnvVar)
+			} else if keyCfg.KeySource != nil {
+				key, err = readLocalSource(ctx, keyCfg.KeySource, httpClient)
+				if err != nil {
+					return nil, fmt.Errorf("api key %s: %w", keyCfg.Name, err)
+				}
+			}
+			if key == "" {
+				return nil, fmt.Errorf("api key %s: key, key_env_var or key_source must be provided", keyCfg.Name)
+			}
+			apiKeys.Add(key, api.Principal{Name: keyCfg.Name, Role: role, Tenant: keyCfg.Tenant})
+		}
+		authenticators = append(authenticators, apiKeys)
+	}
+	if cfg.Oidc != nil {
+		opts := []api.OIDCOpt{api.WithOIDCHttpClient(httpClient)}
+		if cfg.Oidc.JwksUrl != "" {
+			opts = append(opts, api.WithJwksUrl(cfg.Oidc.JwksUrl))
+		}
+		if cfg.Oidc.RolesClaim != "" {
+			opts = append(opts, api.WithRolesClaim(cfg.Oidc.RolesClaim))
+		}
+		if cfg.Oidc.TenantClaim != "" {
+			opts = append(opts, api.WithTenantClaim(cfg.Oidc.TenantClaim))
+		}
+		authenticators = append(authenticators, api.NewOIDCAuthenticator(ctx, cfg.Oidc.Issuer, cfg.Oidc.Audience, opts...))
+	}
+	if len(authenticators) == 0 {
+		return nil, errors.New("api auth must configure api_keys or oidc")
+	}
+	return authenticators, nil
+}
+
+// getShutdownTimeout returns how long the manager waits for the messages that it has
+// received to be handled when it is drained or shut down, defaulting to 30s
+func getShutdownTimeout(cfg *ShutdownConfig) (time.Duration, error) {
+	if cfg == nil || cfg.Timeout == "" {
+		return 30 * time.Second, nil
+	}
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse shutdown timeout: %w", err)
+	}
+	return timeout, nil
+}
+
+func getRetentionService(cfg *RetentionConfig, engine store.Engine) (*services.RetentionService, error) {
+	retention := &services.RetentionService{
+		Clock: clock.RealClock{},
+		Store: engine,
+	}
+
+	var err error
+	if cfg.MeterValues != "" {
+		retention.MeterValues, err = parseRetentionPeriod(cfg.MeterValues)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse meter values retention period: %w", err)
+		}
+	}
+	if cfg.Transactions != "" {
+		retention.Transactions, err = parseRetentionPeriod(cfg.Transactions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse transactions retention period: %w", err)
+		}
+	}
+
+	return retention, nil
+}
+
+// parseRetentionPeriod accepts a number of days, e.g. "90d", as well as any duration
+// accepted by time.ParseDuration
+func parseRetentionPeriod(period string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(period, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number of days: %s", period)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(period)
+}
+
+func getProvisioningScript(cfg *ProvisioningConfig) (*ocpp16.ProvisioningScript, error) {
+	if cfg == nil || len(cfg.Steps) == 0 {
+		return nil, nil
+	}
+
+	script := &ocpp16.ProvisioningScript{
+		PendingInterval: time.Minute,
+		RetryAfter:      2 * time.Minute,
+	}
+	var err error
+	if cfg.PendingInterval != "" {
+		script.PendingInterval, err = time.ParseDuration(cfg.PendingInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse provisioning pending interval: %w", err)
+		}
+	}
+	if cfg.RetryAfter != "" {
+		script.RetryAfter, err = time.ParseDuration(cfg.RetryAfter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse provisioning retry after: %w", err)
+		}
+	}
+
+	for i, step := range cfg.Steps {
+		switch step.Type {
+		case "change_configuration":
+			script.Steps = append(script.Steps, ocpp16.ProvisioningStep{
+				ChangeConfiguration: &ocpp16types.ChangeConfigurationJson{
+					Key:   step.Key,
+					Value: step.Value,
+				},
+			})
+		case "trigger_message":
+			message := ocpp16types.TriggerMessageJsonRequestedMessage(step.Message)
+			switch message {
+			case ocpp16types.TriggerMessageJsonRequestedMessageBootNotification,
+				ocpp16types.TriggerMessageJsonRequestedMessageDiagnosticsStatusNotification,
+				ocpp16types.TriggerMessageJsonRequestedMessageFirmwareStatusNotification,
+				ocpp16types.TriggerMessageJsonRequestedMessageHeartbeat,
+				ocpp16types.TriggerMessageJsonRequestedMessageMeterValues,
+				ocpp16types.TriggerMessageJsonRequestedMessageStatusNotification:
+			default:
+				return nil, fmt.Errorf("unknown trigger message in provisioning step %d: %s", i, step.Message)
+			}
+			script.Steps = append(script.Steps, ocpp16.ProvisioningStep{
+				TriggerMessage: &ocpp16types.TriggerMessageJson{
+					RequestedMessage: message,
+					ConnectorId:      step.ConnectorId,
+				},
+			})
+		default:
+			return nil, fmt.Errorf("unknown provisioning step type: %s", step.Type)
+		}
+	}
+
+	return script, nil
+}
+
+func getOcpiApi(o *OcpiConfig, engine store.Engine, tariffService services.TariffService, httpClient *http.Client) (ocpi.Api, error) {
+	api := ocpi.NewOCPI(engine, httpClient, o.CountryCode, o.PartyId)
+	api.SetExternalUrl(o.ExternalURL)
+	api.SetTariffService(tariffService)
+	return api, nil
+}
+
+func getOicpApi(o *OicpConfig, engine store.Engine, keylogFile string) (oicp.Api, error) {
+	httpClient, err := getOicpHttpClient(o, keylogFile)
+	if err != nil {
+		return nil, err
+	}
+
+	api := oicp.NewOICP(engine, httpClient, o.Url, o.OperatorId, o.OperatorName, o.HotlinePhoneNumber)
+	if o.HttpAuth != nil {
+		tokenService, err := getHttpTokenService(o.HttpAuth, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("oicp http auth: %w", err)
+		}
+		api.SetHttpTokenService(tokenService)
+	}
+	return api, nil
+}
+
+// getOicpHttpClient returns the client used to connect to Hubject, which presents the
+// operator's client certificate when one is configured
+func getOicpHttpClient(o *OicpConfig, keylogFile string) (*http.Client, error) {
+	if o.ClientCertificate == "" {
+		return getHttpClient(keylogFile)
+	}
+
+	cert, err := tls.LoadX509KeyPair(o.ClientCertificate, o.ClientKey)
+	if err != nil {
+		return nil, fmt.Errorf("loading oicp client certificate: %w", err)
+	}
+
+	return getHttpClient(keylogFile, cert)
+}
+
+func getHttpClient(keylogFile string, certificates ...tls.Certificate) (*http.Client, error) {
+	var httpTransport http.RoundTripper
+
+	if keylogFile != "" || len(certificates) > 0 {
+		tlsConfig := &tls.Config{
+			Certificates: certificates,
+			MinVersion:   tls.VersionTLS12,
+		}
+
+		if keylogFile != "" {
+			slog.Warn("***** TLS key logging enabled *****")
+
+			//#nosec G304 - only files specified by the person running the application will be used
+			keyLog, err := os.OpenFile(keylogFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return nil, fmt.Errorf("opening key log file: %v", err)
+			}
+			tlsConfig.KeyLogWriter = keyLog
+		}
+
+		baseTransport := http.DefaultTransport.(*http.Transport).Clone()
+		baseTransport.TLSClientConfig = tlsConfig
+
+		httpTransport = otelhttp.NewTransport(baseTransport)
+	} else {
+		httpTransport = otelhttp.NewTransport(http.DefaultTransport)
+	}
+
+	return &http.Client{Transport: httpTransport}, nil
+}
+
+// OpenStorage creates the storage engine described by the storage section of the
+// configuration in the same way as Configure, e.g. so that it can be used by tools
+func OpenStorage(ctx context.Context, cfg *StorageConfig) (store.Engine, error) {
+	engine, _, err := getStorage(ctx, cfg)
+	return engine, err
+}
+
+// getStorage also returns the Redis store that holds transient state, or nil if Redis
+// is not configured
+func getStorage(ctx context.Context, cfg *StorageConfig) (engine store.Engine, transient *redis.Store, err error) {
+	engine, err = store.Open(ctx, cfg.Type, storageOptions(cfg))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var eventLog store.TransactionEventStore
+	if cfg.EventLog {
+		var ok bool
+		eventLog, ok = engine.(store.TransactionEventStore)
+		if !ok {
+			return nil, nil, fmt.Errorf("storage engine %s does not support a transaction event log", cfg.Type)
+		}
+	}
+
+	if cfg.Redis != nil {
+		transient, err = getRedisStorage(cfg.Redis)
+		if err != nil {
+			return nil, nil, err
+		}
+		engine = redis.NewLayeredStore(engine, transient)
+	}
+
+	if cfg.TokenCache != nil && cfg.TokenCache.Size > 0 {
+		engine, err = getTokenCacheStorage(engine, transient, cfg.TokenCache)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var opts []resilient.Opt
+	if cfg.Retry != nil {
+		opts, err = getStorageRetryOpts(cfg.Retry)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	engine = resilient.NewStore(engine, clock.RealClock{}, opts...)
+
+	if eventLog != nil {
+		engine = eventlog.NewStore(engine, eventLog, clock.RealClock{})
+	}
+
+	return engine, transient, nil
+}
+
+// storageOptions returns the options passed to the storage engine's factory: the
+// configuration sections of the built-in engines are converted to options so that
+// they are configured in the same way as engines registered by other modules
+func storageOptions(cfg *StorageConfig) map[string]any {
+	options := make(map[string]any)
+	switch {
+	case cfg.Type == "firestore" && cfg.FirestoreStorage != nil:
+		options["project_id"] = cfg.FirestoreStorage.ProjectId
+	case cfg.Type == "sqlite" && cfg.SqliteStorage != nil:
+		options["path"] = cfg.SqliteStorage.Path
+	case cfg.Type == "dynamodb" && cfg.DynamodbStorage != nil:
+		options["table"] = cfg.DynamodbStorage.Table
+		options["endpoint"] = cfg.DynamodbStorage.Endpoint
 	}
+	for k, v := range cfg.Options {
+		options[k] = v
+	}
+	return options
+}
 
-	if cfg.Ocpi != nil {
-		c.OcpiApi, err = getOcpiApi(cfg.Ocpi, c.Storage, httpClient)
+func getRedisStorage(cfg *RedisStorageConfig) (*redis.Store, error) {
+	var opts []redis.Opt
+	if cfg.Ttl != "" {
+		ttl, err := time.ParseDuration(cfg.Ttl)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to parse redis storage ttl: %w", err)
 		}
+		opts = append(opts, redis.WithTTL(ttl))
 	}
 
-	return
-}
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.Db,
+	})
 
-func getOcpiApi(o *OcpiConfig, engine store.Engine, httpClient *http.Client) (ocpi.Api, error) {
-	api := ocpi.NewOCPI(engine, httpClient, o.CountryCode, o.PartyId)
-	api.SetExternalUrl(o.ExternalURL)
-	return api, nil
+	return redis.NewStore(client, clock.RealClock{}, opts...), nil
 }
 
-func getHttpClient(keylogFile string) (*http.Client, error) {
-	var httpTransport http.RoundTripper
-
-	if keylogFile != "" {
-		slog.Warn("***** TLS key logging enabled *****")
-
-		//#nosec G304 - only files specified by the person running the application will be used
-		keyLog, err := os.OpenFile(keylogFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+// getTokenCacheStorage caches token lookups in-process and, if Redis is configured,
+// in Redis so that the cached tokens are shared between manager instances
+func getTokenCacheStorage(engine store.Engine, shared *redis.Store, cfg *TokenCacheConfig) (store.Engine, error) {
+	ttl := time.Minute
+	if cfg.Ttl != "" {
+		var err error
+		ttl, err = time.ParseDuration(cfg.Ttl)
 		if err != nil {
-			return nil, fmt.Errorf("opening key log file: %v", err)
-		}
-
-		baseTransport := http.DefaultTransport.(*http.Transport).Clone()
-		baseTransport.TLSClientConfig = &tls.Config{
-			KeyLogWriter: keyLog,
-			MinVersion:   tls.VersionTLS12,
+			return nil, fmt.Errorf("failed to parse token cache ttl: %w", err)
 		}
+	}
 
-		httpTransport = otelhttp.NewTransport(baseTransport)
-	} else {
-		httpTransport = otelhttp.NewTransport(http.DefaultTransport)
+	var opts []cache.Opt
+	if shared != nil {
+		opts = append(opts, cache.WithSharedCache(shared))
 	}
 
-	return &http.Client{Transport: httpTransport}, nil
+	return cache.NewStore(engine, clock.RealClock{}, cfg.Size, ttl, opts...), nil
 }
 
-func getStorage(ctx context.Context, cfg *StorageConfig) (engine store.Engine, err error) {
-	switch cfg.Type {
-	case "firestore":
-		engine, err = firestore.NewStore(ctx, cfg.FirestoreStorage.ProjectId, clock.RealClock{})
+func getStorageRetryOpts(cfg *StorageRetryConfig) ([]resilient.Opt, error) {
+	var initialBackoff, maxBackoff time.Duration
+	var err error
+	if cfg.InitialBackoff != "" {
+		initialBackoff, err = time.ParseDuration(cfg.InitialBackoff)
 		if err != nil {
-			return nil, fmt.Errorf("create firestore storage: %w", err)
+			return nil, fmt.Errorf("failed to parse storage retry initial backoff: %w", err)
+		}
+	}
+	if cfg.MaxBackoff != "" {
+		maxBackoff, err = time.ParseDuration(cfg.MaxBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse storage retry max backoff: %w", err)
 		}
-	case "in_memory":
-		engine = inmemory.NewStore(clock.RealClock{})
-	default:
-		return nil, fmt.Errorf("unknown storage type: %s", cfg.Type)
 	}
 
-	return
+	return []resilient.Opt{
+		resilient.WithMaxAttempts(cfg.MaxAttempts),
+		resilient.WithBackoff(initialBackoff, maxBackoff),
+		resilient.WithUnhealthyAfter(cfg.UnhealthyAfter),
+	}, nil
 }
 
-func getContractCertValidator(cfg *ContractCertValidatorConfig, httpClient *http.Client) (contractCertValidator services.CertificateValidationService, err error) {
+func getContractCertValidator(cfg *ContractCertValidatorConfig, engine store.Engine, httpClient *http.Client) (contractCertValidator services.CertificateValidationService, err error) {
 	switch cfg.Type {
 	case "ocsp":
 		var rootCertificateProvider services.RootCertificateProviderService
@@ -226,11 +1268,54 @@ func getContractCertValidator(cfg *ContractCertValidatorConfig, httpClient *http
 			return nil, fmt.Errorf("create root certificate provider: %w", err)
 		}
 
+		revalidateBefore := 15 * time.Minute
+		if cfg.Ocsp.RevalidateBefore != "" {
+			revalidateBefore, err = time.ParseDuration(cfg.Ocsp.RevalidateBefore)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse revalidate before: %w", err)
+			}
+		}
+
 		contractCertValidator, err = &services.OnlineCertificateValidationService{
 			RootCertificateProvider: rootCertificateProvider,
 			MaxOCSPAttempts:         cfg.Ocsp.MaxAttempts,
 			HttpClient:              httpClient,
+			OcspResponseStore:       engine,
+			Clock:                   clock.RealClock{},
+			FallbackPolicy:          services.OcspFallbackPolicy(cfg.Ocsp.FallbackPolicy),
+			RevalidateBefore:        revalidateBefore,
 		}, nil
+	case "crl":
+		var rootCertificateProvider services.RootCertificateProviderService
+		rootCertificateProvider, err = getRootCertProvider(&cfg.Crl.RootCertProvider, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("create root certificate provider: %w", err)
+		}
+
+		var maxCrlAge time.Duration
+		if cfg.Crl.MaxCrlAge != "" {
+			maxCrlAge, err = time.ParseDuration(cfg.Crl.MaxCrlAge)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse max crl age: %w", err)
+			}
+		}
+
+		crlValidator := &services.CrlCertificateValidationService{
+			RootCertificateProvider: rootCertificateProvider,
+			HttpClient:              httpClient,
+			MaxCrlAge:               maxCrlAge,
+			Clock:                   clock.RealClock{},
+		}
+		if cfg.Crl.CheckOcsp {
+			if cfg.Ocsp == nil {
+				return nil, errors.New("crl contract certificate validator requires ocsp configuration to check ocsp")
+			}
+			crlValidator.Ocsp, err = getContractCertValidator(&ContractCertValidatorConfig{Type: "ocsp", Ocsp: cfg.Ocsp}, engine, httpClient)
+			if err != nil {
+				return nil, err
+			}
+		}
+		contractCertValidator = crlValidator
 	default:
 		return nil, fmt.Errorf("unknown contract certificate validator type: %s", cfg.Type)
 	}
@@ -299,6 +1384,29 @@ func getContractCertProvider(cfg *ContractCertProviderConfig, httpClient *http.C
 			HttpTokenService: httpTokenService,
 			HttpClient:       httpClient,
 		}
+	case "fallback":
+		providers := make([]services.ContractCertificateProvider, len(cfg.Fallback.Providers))
+		for index, providerCfg := range cfg.Fallback.Providers {
+			providerCfg := providerCfg
+			providers[index], err = getContractCertProvider(&providerCfg, httpClient)
+			if err != nil {
+				return nil, fmt.Errorf("creating fallback contract certificate provider %d: %w", index, err)
+			}
+		}
+
+		failureThreshold := 3
+		if cfg.Fallback.FailureThreshold != 0 {
+			failureThreshold = cfg.Fallback.FailureThreshold
+		}
+		retryAfter := time.Minute
+		if cfg.Fallback.RetryAfter != "" {
+			retryAfter, err = time.ParseDuration(cfg.Fallback.RetryAfter)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse fallback contract certificate provider retry after: %w", err)
+			}
+		}
+
+		evCertificateProvider = services.NewFallbackContractCertificateProvider(providers, failureThreshold, retryAfter, clock.RealClock{})
 	case "default":
 		evCertificateProvider = &services.DefaultContractCertificateProvider{}
 	default:
@@ -316,18 +1424,24 @@ func getChargeStationCertProvider(ctx context.Context, cfg *ChargeStationCertPro
 			return nil, fmt.Errorf("create http auth service: %w", err)
 		}
 
+		isoVersion := services.ISO15118V2
+		if cfg.Opcp.IsoVersion != "" {
+			isoVersion = services.ISOVersion(cfg.Opcp.IsoVersion)
+		}
+
 		chargeStationCertProvider = &services.OpcpChargeStationCertificateProvider{
-			BaseURL:          cfg.Opcp.Url,
-			ISOVersion:       services.ISO15118V2,
-			HttpTokenService: httpTokenService,
-			HttpClient:       httpClient,
+			BaseURL:             cfg.Opcp.Url,
+			ISOVersion:          isoVersion,
+			HttpTokenService:    httpTokenService,
+			HttpClient:          httpClient,
+			RuntimeDetailsStore: engine,
 		}
 	case "local":
-		certificateSource, err := getLocalSource(cfg.Local.CertificateSource)
+		certificateSource, err := getLocalSource(cfg.Local.CertificateSource, httpClient)
 		if err != nil {
 			return nil, fmt.Errorf("create local source: %w", err)
 		}
-		privateKeySource, err := getLocalSource(cfg.Local.PrivateKeySource)
+		privateKeySource, err := getLocalSource(cfg.Local.PrivateKeySource, httpClient)
 		if err != nil {
 			return nil, fmt.Errorf("create private key source: %w", err)
 		}
@@ -368,16 +1482,42 @@ func getHttpTokenService(cfg *HttpAuthConfig, httpClient *http.Client) (httpToke
 		httpTokenService, err = services.NewEnvHttpTokenService(cfg.EnvToken.EnvVar)
 	case "fixed_token":
 		httpTokenService = services.NewFixedHttpTokenService(cfg.FixedToken.Token)
+	case "source_token":
+		source, err := getLocalSource(cfg.SourceToken.Source, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		ttl := 5 * time.Minute
+		if cfg.SourceToken.Ttl != "" {
+			ttl, err = time.ParseDuration(cfg.SourceToken.Ttl)
+			if err != nil {
+				return nil, fmt.Errorf("parse source token ttl: %w", err)
+			}
+		}
+		httpTokenService = services.NewCachingHttpTokenService(services.NewSourceHttpTokenService(source), ttl, clock.RealClock{})
 	case "oauth2_token":
-		var clientSecret string
+		var clientSecret services.LocalSource
 		if cfg.OAuth2Token.ClientSecret != nil {
-			clientSecret = *cfg.OAuth2Token.ClientSecret
+			clientSecret = services.StringSource{Data: *cfg.OAuth2Token.ClientSecret}
 		} else if cfg.OAuth2Token.ClientSecretEnvVar != nil {
-			clientSecret = os.Getenv(*cfg.OAuth2Token.ClientSecretEnvVar)
+			clientSecret = services.StringSource{Data: os.Getenv(*cfg.OAuth2Token.ClientSecretEnvVar)}
+		} else if cfg.OAuth2Token.ClientSecretSource != nil {
+			clientSecret, err = getLocalSource(cfg.OAuth2Token.ClientSecretSource, httpClient)
+			if err != nil {
+				return nil, err
+			}
 		} else {
-			return nil, fmt.Errorf("client_secret or client_secret_env_var must be provided")
+			return nil, fmt.Errorf("client_secret, client_secret_env_var or client_secret_source must be provided")
+		}
+		refreshBefore := time.Minute
+		if cfg.OAuth2Token.RefreshBefore != "" {
+			refreshBefore, err = time.ParseDuration(cfg.OAuth2Token.RefreshBefore)
+			if err != nil {
+				return nil, fmt.Errorf("parse oauth2 token refresh before: %w", err)
+			}
 		}
-		httpTokenService = services.NewOAuth2HttpTokenService(cfg.OAuth2Token.Url, cfg.OAuth2Token.ClientId, clientSecret, httpClient, clock.RealClock{})
+		httpTokenService = services.NewRotatingOAuth2HttpTokenService(cfg.OAuth2Token.Url, cfg.OAuth2Token.ClientId, clientSecret,
+			refreshBefore, httpClient, clock.RealClock{})
 	case "hubject_test_token":
 		ttl := 24 * time.Hour
 		if cfg.HubjectTestToken.Ttl != "" {
@@ -395,16 +1535,39 @@ func getHttpTokenService(cfg *HttpAuthConfig, httpClient *http.Client) (httpToke
 	return
 }
 
-func getLocalSource(cfg *LocalSourceConfig) (source services.LocalSource, err error) {
+func getLocalSource(cfg *LocalSourceConfig, httpClient *http.Client) (source services.LocalSource, err error) {
 	switch cfg.Type {
 	case "file":
 		source = services.FileSource{
 			FileName: cfg.File,
 		}
+	case "env":
+		source = services.EnvSource{
+			Variable: cfg.Env,
+		}
 	case "google_cloud_secret":
 		source = services.GoogleSecretSource{
 			SecretName: cfg.GoogleCloudSecret,
 		}
+	case "vault":
+		address := cfg.Vault.Address
+		if address == "" {
+			address = os.Getenv("VAULT_ADDR")
+		}
+		if address == "" {
+			return nil, errors.New("vault address must be configured or set with VAULT_ADDR")
+		}
+		tokenEnvVar := cfg.Vault.TokenEnvVar
+		if tokenEnvVar == "" {
+			tokenEnvVar = "VAULT_TOKEN"
+		}
+		source = services.VaultSecretSource{
+			Address:     address,
+			Path:        cfg.Vault.Path,
+			Key:         cfg.Vault.Key,
+			TokenEnvVar: tokenEnvVar,
+			HttpClient:  httpClient,
+		}
 	default:
 		return nil, fmt.Errorf("unknown local source type: %s", cfg.Type)
 	}
@@ -412,10 +1575,80 @@ func getLocalSource(cfg *LocalSourceConfig) (source services.LocalSource, err er
 	return
 }
 
-func getTariffService(cfg *TariffServiceConfig) (tariffService services.TariffService, err error) {
+// readLocalSource reads a secret, such as a password or key, that is only needed when
+// the manager starts: surrounding whitespace is removed
+func readLocalSource(ctx context.Context, cfg *LocalSourceConfig, httpClient *http.Client) (string, error) {
+	source, err := getLocalSource(cfg, httpClient)
+	if err != nil {
+		return "", err
+	}
+	data, err := source.GetData(ctx)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(data), nil
+}
+
+// defaultCurrency is the currency of the tariff engine's default tariff when one is
+// not configured: it matches the currency reported to OCPI parties
+const defaultCurrency = "EUR"
+
+func getTariffService(cfg *TariffServiceConfig, engine store.Engine, httpClient *http.Client) (tariffService services.TariffService, err error) {
 	switch cfg.Type {
 	case "kwh":
-		tariffService = services.BasicKwhTariffService{}
+		// charge stations that have been assigned a tariff are priced with it
+		tariffService = services.TariffEngine{
+			Store:         engine,
+			DefaultTariff: services.NewDefaultTariff(defaultCurrency, 0, 0.55, 0),
+			Location:      time.UTC,
+		}
+	case "time":
+		var idleGracePeriod time.Duration
+		if cfg.Time.IdleGracePeriod != "" {
+			idleGracePeriod, err = time.ParseDuration(cfg.Time.IdleGracePeriod)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse idle grace period: %w", err)
+			}
+		}
+		tariffService = services.TimeBasedTariffService{
+			PricePerKwh:          cfg.Time.PricePerKwh,
+			PricePerChargingHour: cfg.Time.PricePerChargingHour,
+			IdleFeePerMinute:     cfg.Time.IdleFeePerMinute,
+			IdleGracePeriod:      idleGracePeriod,
+		}
+	case "ocpi":
+		location := time.UTC
+		if cfg.Ocpi.Timezone != "" {
+			location, err = time.LoadLocation(cfg.Ocpi.Timezone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load timezone: %w", err)
+			}
+		}
+		tariffService = services.OcpiTariffService{
+			Store:    engine,
+			TariffId: cfg.Ocpi.TariffId,
+			Location: location,
+		}
+	case "engine":
+		location := time.UTC
+		if cfg.Engine.Timezone != "" {
+			location, err = time.LoadLocation(cfg.Engine.Timezone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load timezone: %w", err)
+			}
+		}
+		currency := cfg.Engine.Currency
+		if currency == "" {
+			currency = defaultCurrency
+		}
+		tariffService = services.TariffEngine{
+			Store:           engine,
+			DefaultTariffId: cfg.Engine.DefaultTariffId,
+			DefaultTariff:   services.NewDefaultTariff(currency, cfg.Engine.SessionFee, cfg.Engine.PricePerKwh, cfg.Engine.PricePerMinute),
+			Location:        location,
+		}
+	case "http":
+		tariffService, err = getHttpTariffService(cfg.Http, engine, httpClient)
 	default:
 		return nil, fmt.Errorf("unknown tariff service type: %s", cfg.Type)
 	}
@@ -423,83 +1656,421 @@ func getTariffService(cfg *TariffServiceConfig) (tariffService services.TariffSe
 	return
 }
 
-func getMsgEmitter(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.Emitter, error) {
+// getHttpTariffService returns a tariff service that asks an external pricing service
+// for the cost of each transaction, falling back to the tariff engine when it fails
+func getHttpTariffService(cfg *HttpTariffServiceConfig, engine store.Engine, httpClient *http.Client) (services.TariffService, error) {
+	var err error
+	location := time.UTC
+	if cfg.Timezone != "" {
+		location, err = time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load timezone: %w", err)
+		}
+	}
+
+	timeout := 5 * time.Second
+	if cfg.Timeout != "" {
+		timeout, err = time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pricing service timeout: %w", err)
+		}
+	}
+
+	openFor := time.Minute
+	if cfg.OpenFor != "" {
+		openFor, err = time.ParseDuration(cfg.OpenFor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pricing service open for: %w", err)
+		}
+	}
+
+	failureThreshold := cfg.FailureThreshold
+	if failureThreshold == 0 {
+		failureThreshold = 5
+	}
+
+	var tokenService services.HttpTokenService
+	if cfg.Auth != nil {
+		tokenService, err = getHttpTokenService(cfg.Auth, httpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fallback := services.TariffEngine{
+		Store:           engine,
+		DefaultTariffId: cfg.FallbackTariffId,
+		Location:        location,
+	}
+	return services.NewHttpTariffService(cfg.Url, httpClient, tokenService, fallback, timeout, failureThreshold, openFor,
+		clock.RealClock{}), nil
+}
+
+func getSchedulingStrategy(cfg *LoadManagementConfig, engine store.ReservationStore) (services.SchedulingStrategy, error) {
+	if cfg == nil {
+		return services.AsSoonAsPossibleSchedulingStrategy{Clock: clock.RealClock{}}, nil
+	}
+
 	switch cfg.Type {
-	case "mqtt":
-		var mqttUrls []*url.URL
-		for _, urlStr := range cfg.Mqtt.Urls {
-			u, err := url.Parse(urlStr)
+	case "asap":
+		return services.AsSoonAsPossibleSchedulingStrategy{Clock: clock.RealClock{}}, nil
+	case "low_carbon":
+		signal := services.DailyCarbonIntensitySignal{
+			Location: time.UTC,
+		}
+		copy(signal.HourlyIntensity[:], cfg.LowCarbon.HourlyCarbonIntensity)
+		if cfg.LowCarbon.Timezone != "" {
+			loc, err := time.LoadLocation(cfg.LowCarbon.Timezone)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse mqtt url: %w", err)
+				return nil, fmt.Errorf("failed to load timezone: %w", err)
 			}
-			mqttUrls = append(mqttUrls, u)
+			signal.Location = loc
 		}
+		return services.LowCarbonSchedulingStrategy{
+			Clock:        clock.RealClock{},
+			Signal:       signal,
+			Reservations: engine,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown load management type: %s", cfg.Type)
+	}
+}
 
-		mqttConnectTimeout, err := time.ParseDuration(cfg.Mqtt.ConnectTimeout)
+// getTransport returns the emitter and listener for the configured transport. The
+// MQTT emitter and listener each have their own connection to the broker, whereas
+// a NATS transport does both over a single connection.
+func getTransport(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.Emitter, transport.Listener, error) {
+	switch cfg.Type {
+	case "mqtt":
+		emitterOpts, err := getMqttOpts[mqtt2.Emitter](cfg.Mqtt, tracer)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse mqtt connect timeout: %w", err)
+			return nil, nil, err
 		}
 
-		mqttConnectRetryDelay, err := time.ParseDuration(cfg.Mqtt.ConnectRetryDelay)
+		listenerOpts, err := getMqttOpts[mqtt2.Listener](cfg.Mqtt, tracer)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse mqtt connect retry delay: %w", err)
+			return nil, nil, err
 		}
+		listenerOpts = append(listenerOpts, mqtt2.WithMqttGroup[mqtt2.Listener](cfg.Mqtt.Group))
+		listenerOpts = append(listenerOpts, mqtt2.WithMqttWorkers[mqtt2.Listener](cfg.Mqtt.Workers, cfg.Mqtt.WorkerQueueSize))
 
-		mqttKeepAliveInterval, err := time.ParseDuration(cfg.Mqtt.KeepAliveInterval)
+		return mqtt2.NewEmitter(emitterOpts...), mqtt2.NewListener(listenerOpts...), nil
+	case "nats":
+		natsTransport, err := getNatsTransport(cfg.Nats, tracer)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse mqtt keep alive interval: %w", err)
+			return nil, nil, err
 		}
 
-		mqttEmitter := mqtt2.NewEmitter(
-			mqtt2.WithMqttBrokerUrls[mqtt2.Emitter](mqttUrls),
-			mqtt2.WithMqttPrefix[mqtt2.Emitter](cfg.Mqtt.Prefix),
-			mqtt2.WithMqttConnectSettings[mqtt2.Emitter](mqttConnectTimeout, mqttConnectRetryDelay, mqttKeepAliveInterval),
-			mqtt2.WithOtelTracer[mqtt2.Emitter](tracer))
-
-		return mqttEmitter, nil
+		return natsTransport, natsTransport, nil
 	default:
-		return nil, fmt.Errorf("unknown transport type: %s", cfg.Type)
+		return nil, nil, fmt.Errorf("unknown transport type: %s", cfg.Type)
+	}
+}
+
+func getNatsTransport(cfg *NatsSettingsConfig, tracer oteltrace.Tracer) (transport.Transport, error) {
+	opts := []nats.Opt{
+		nats.WithUrls(cfg.Urls),
+		nats.WithPrefix(cfg.Prefix),
+		nats.WithGroup(cfg.Group),
+		nats.WithStream(cfg.Stream),
+		nats.WithWorkers(cfg.Workers, cfg.WorkerQueueSize),
+		nats.WithOtelTracer(tracer),
+	}
+
+	var connectTimeout, reconnectWait time.Duration
+	var err error
+	if cfg.ConnectTimeout != "" {
+		connectTimeout, err = time.ParseDuration(cfg.ConnectTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nats connect timeout: %w", err)
+		}
+	}
+	if cfg.ReconnectWait != "" {
+		reconnectWait, err = time.ParseDuration(cfg.ReconnectWait)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nats reconnect wait: %w", err)
+		}
+	}
+	opts = append(opts, nats.WithConnectSettings(connectTimeout, reconnectWait))
+
+	if cfg.Username != "" || cfg.Password != "" {
+		opts = append(opts, nats.WithCredentials(cfg.Username, cfg.Password))
+	}
+
+	return nats.NewTransport(opts...), nil
+}
+
+// getWebsocketSettings returns a transport that accepts connections from charge
+// stations on the configured addresses. Client certificates are accepted from the
+// API's organization unless other organizations are configured.
+func getWebsocketSettings(cfg *WebsocketSettingsConfig, engine store.Engine, orgName string, tracer oteltrace.Tracer) (*WebsocketSettings, error) {
+	opts := []ws.Opt{
+		ws.WithOtelTracer(tracer),
+	}
+
+	orgNames := cfg.OrgNames
+	if len(orgNames) == 0 {
+		orgNames = []string{orgName}
+	}
+	opts = append(opts, ws.WithOrgNames(orgNames))
+
+	if cfg.CallTimeout != "" {
+		callTimeout, err := time.ParseDuration(cfg.CallTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse websocket call timeout: %w", err)
+		}
+		opts = append(opts, ws.WithCallTimeout(callTimeout))
+	}
+
+	settings := &WebsocketSettings{
+		Addr:      cfg.Addr,
+		TlsAddr:   cfg.TlsAddr,
+		Transport: ws.NewTransport(engine, opts...),
+	}
+	if settings.Addr == "" && settings.TlsAddr == "" {
+		settings.Addr = ":9310"
+	}
+
+	if cfg.Tls != nil {
+		var err error
+		settings.TlsConfig, err = getWebsocketTlsConfig(cfg.Tls)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return settings, nil
+}
+
+// getWebsocketTlsConfig returns the TLS configuration for the wss server: client
+// certificates are optional so that charge stations using basic auth over TLS can
+// also connect
+func getWebsocketTlsConfig(cfg *WebsocketTlsConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.ServerCertificate, cfg.ServerKey)
+	if err != nil {
+		return nil, fmt.Errorf("loading websocket server certificate: %w", err)
+	}
+
+	trustedCerts := x509.NewCertPool()
+	for _, tc := range cfg.TrustCertificates {
+		//#nosec G304 - only files specified by the person running the application will be used
+		pemData, err := os.ReadFile(tc)
+		if err != nil {
+			return nil, fmt.Errorf("reading websocket trust certificate: %w", err)
+		}
+		if !trustedCerts.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("no certificates found in websocket trust certificate %s", tc)
+		}
 	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    trustedCerts,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
 }
 
-func getMsgListener(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.Listener, error) {
+// getDeadLetterQueue returns nil when no dead-letter topic is configured, in which
+// case messages that cannot be routed are only logged
+func getDeadLetterQueue(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.DeadLetterQueue, error) {
 	switch cfg.Type {
 	case "mqtt":
-		var mqttUrls []*url.URL
-		for _, urlStr := range cfg.Mqtt.Urls {
-			u, err := url.Parse(urlStr)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse mqtt url: %w", err)
-			}
-			mqttUrls = append(mqttUrls, u)
+		if cfg.Mqtt.DeadLetterTopic == "" {
+			return nil, nil
+		}
+		opts, err := getMqttOpts[mqtt2.Emitter](cfg.Mqtt, tracer)
+		if err != nil {
+			return nil, err
+		}
+
+		return mqtt2.NewDeadLetterQueue(cfg.Mqtt.DeadLetterTopic, opts...), nil
+	case "nats", "websocket":
+		// dead letters are only published by the mqtt transport
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown transport type: %s", cfg.Type)
+	}
+}
+
+// OpenDeadLetterQueue returns the dead-letter queue configured for the transport
+// without configuring the rest of the manager
+func OpenDeadLetterQueue(cfg *TransportConfig) (transport.DeadLetterQueue, error) {
+	queue, err := getDeadLetterQueue(cfg, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		return nil, err
+	}
+	if queue == nil {
+		return nil, errors.New("no dead-letter topic is configured")
+	}
+	return queue, nil
+}
+
+// OpenEmitter returns the emitter that sends messages to the charge stations over the
+// configured transport without configuring the rest of the manager
+func OpenEmitter(cfg *TransportConfig) (transport.Emitter, error) {
+	emitter, _, err := getTransport(cfg, noop.NewTracerProvider().Tracer(""))
+	return emitter, err
+}
+
+// OpenCallScheduler returns the CallScheduler that queues the calls sent through the
+// emitter in the engine, as the manager does, or nil if outbound calls are not queued
+func OpenCallScheduler(cfg *OutboundCallsConfig, emitter transport.Emitter, engine store.OutboundCallQueueStore) (*transport.CallScheduler, error) {
+	return getCallScheduler(cfg, emitter, engine)
+}
+
+// getMqttOpts returns the connection options shared by the MQTT emitter and listener
+func getMqttOpts[T mqtt2.Emitter | mqtt2.Listener](cfg *MqttSettingsConfig, tracer oteltrace.Tracer) ([]mqtt2.Opt[T], error) {
+	var mqttUrls []*url.URL
+	for _, urlStr := range cfg.Urls {
+		u, err := url.Parse(urlStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse mqtt url: %w", err)
+		}
+		mqttUrls = append(mqttUrls, u)
+	}
+
+	mqttConnectTimeout, err := time.ParseDuration(cfg.ConnectTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mqtt connect timeout: %w", err)
+	}
+
+	mqttConnectRetryDelay, err := time.ParseDuration(cfg.ConnectRetryDelay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mqtt connect retry delay: %w", err)
+	}
+
+	mqttKeepAliveInterval, err := time.ParseDuration(cfg.KeepAliveInterval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mqtt keep alive interval: %w", err)
+	}
+
+	opts := []mqtt2.Opt[T]{
+		mqtt2.WithMqttBrokerUrls[T](mqttUrls),
+		mqtt2.WithMqttPrefix[T](cfg.Prefix),
+		mqtt2.WithMqttConnectSettings[T](mqttConnectTimeout, mqttConnectRetryDelay, mqttKeepAliveInterval),
+		mqtt2.WithOtelTracer[T](tracer),
+	}
+
+	if cfg.MaxConnectRetryDelay != "" {
+		mqttMaxRetryDelay, err := time.ParseDuration(cfg.MaxConnectRetryDelay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse mqtt max connect retry delay: %w", err)
+		}
+		opts = append(opts, mqtt2.WithMqttMaxConnectRetryDelay[T](mqttMaxRetryDelay))
+	}
+
+	password := cfg.Password
+	if cfg.PasswordSource != nil {
+		password, err = readLocalSource(context.Background(), cfg.PasswordSource, nil)
+		if err != nil {
+			return nil, fmt.Errorf("reading mqtt password: %w", err)
 		}
+	}
+	if cfg.Username != "" || password != "" {
+		opts = append(opts, mqtt2.WithMqttCredentials[T](cfg.Username, password))
+	}
 
-		mqttConnectTimeout, err := time.ParseDuration(cfg.Mqtt.ConnectTimeout)
+	if cfg.Tls != nil {
+		tlsConfig, err := getMqttTlsConfig(cfg.Tls)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse mqtt connect timeout: %w", err)
+			return nil, err
 		}
+		opts = append(opts, mqtt2.WithMqttTlsConfig[T](tlsConfig))
+	}
+
+	return opts, nil
+}
+
+// getMqttTlsConfig returns the TLS configuration used to connect to brokers with a
+// mqtts:// URL: the system roots are trusted unless a CA certificate is configured
+func getMqttTlsConfig(cfg *MqttTlsConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: cfg.Alpn,
+	}
+
+	if cfg.MinVersion == "1.3" {
+		tlsConfig.MinVersion = tls.VersionTLS13
+	}
 
-		mqttConnectRetryDelay, err := time.ParseDuration(cfg.Mqtt.ConnectRetryDelay)
+	if cfg.CaCertificate != "" {
+		//#nosec G304 - only files specified by the person running the application will be used
+		pemData, err := os.ReadFile(cfg.CaCertificate)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse mqtt connect retry delay: %w", err)
+			return nil, fmt.Errorf("reading mqtt ca certificate: %w", err)
 		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("no certificates found in mqtt ca certificate %s", cfg.CaCertificate)
+		}
+		tlsConfig.RootCAs = roots
+	}
 
-		mqttKeepAliveInterval, err := time.ParseDuration(cfg.Mqtt.KeepAliveInterval)
+	if cfg.ClientCertificate != "" {
+		var cert tls.Certificate
+		var err error
+		switch {
+		case cfg.ClientKeySource != nil:
+			cert, err = loadX509KeyPairFromSource(cfg.ClientCertificate, cfg.ClientKeySource)
+		case cfg.ClientKey != "":
+			cert, err = tls.LoadX509KeyPair(cfg.ClientCertificate, cfg.ClientKey)
+		default:
+			err = errors.New("client_key or client_key_source must be provided")
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse mqtt keep alive interval: %w", err)
+			return nil, fmt.Errorf("loading mqtt client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// loadX509KeyPairFromSource loads a certificate from a file along with its private key
+// from a local source, so that the key can be held in a secrets manager
+func loadX509KeyPairFromSource(certFile string, keySource *LocalSourceConfig) (tls.Certificate, error) {
+	//#nosec G304 - only files specified by the person running the application will be used
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	source, err := getLocalSource(keySource, nil)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM, err := source.GetData(context.Background())
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(certPEM, []byte(keyPEM))
+}
+
+func getEventPublisher(cfg *EventsConfig, httpClient *http.Client) (events.Publisher, error) {
+	switch cfg.Type {
+	case "kafka":
+		opts := []events.KafkaOpt{
+			events.WithTopicPrefix(cfg.Kafka.TopicPrefix),
+			events.WithHttpClient(httpClient),
+		}
+
+		var flushInterval time.Duration
+		if cfg.Kafka.FlushInterval != "" {
+			var err error
+			flushInterval, err = time.ParseDuration(cfg.Kafka.FlushInterval)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse kafka flush interval: %w", err)
+			}
 		}
+		opts = append(opts, events.WithBuffer(cfg.Kafka.BufferSize, cfg.Kafka.BatchSize, flushInterval))
 
-		opts := []mqtt2.Opt[mqtt2.Listener]{
-			mqtt2.WithMqttBrokerUrls[mqtt2.Listener](mqttUrls),
-			mqtt2.WithMqttPrefix[mqtt2.Listener](cfg.Mqtt.Prefix),
-			mqtt2.WithMqttConnectSettings[mqtt2.Listener](mqttConnectTimeout, mqttConnectRetryDelay, mqttKeepAliveInterval),
-			mqtt2.WithMqttGroup[mqtt2.Listener](cfg.Mqtt.Group),
-			mqtt2.WithOtelTracer[mqtt2.Listener](tracer),
+		if cfg.Kafka.Username != "" || cfg.Kafka.Password != "" {
+			opts = append(opts, events.WithBasicAuth(cfg.Kafka.Username, cfg.Kafka.Password))
 		}
 
-		return mqtt2.NewListener(opts...), nil
+		return events.NewKafkaPublisher(cfg.Kafka.RestProxyUrl, opts...), nil
 	default:
-		return nil, fmt.Errorf("unknown transport type: %s", cfg.Type)
+		return nil, fmt.Errorf("unknown events type: %s", cfg.Type)
 	}
 }
 
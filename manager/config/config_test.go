@@ -7,9 +7,24 @@ import (
 	"github.com/huandu/go-clone/generic"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
 	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/events"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	ocpp16types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/eventlog"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/nats"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestConfigure(t *testing.T) {
@@ -20,21 +35,38 @@ func TestConfigure(t *testing.T) {
 	require.NoError(t, err)
 
 	wantApiSettings := config.ApiSettings{
-		Addr:    "localhost:9410",
-		Host:    "localhost",
-		WsPort:  80,
-		WssPort: 443,
-		OrgName: "Thoughtworks",
+		Addr:             "localhost:9410",
+		Host:             "localhost",
+		WsPort:           80,
+		WssPort:          443,
+		OrgName:          "Thoughtworks",
+		ActionFlags:      settings.Api.ActionFlags,
+		Drain:            settings.Api.Drain,
+		Reloader:         settings.Api.Reloader,
+		EventStream:      settings.Api.EventStream,
+		RootCertificates: settings.Api.RootCertificates,
+		Receipts:         settings.Api.Receipts,
 	}
 
 	assert.Equal(t, wantApiSettings, settings.Api)
+	assert.NotNil(t, settings.Api.EventStream)
+	assert.NotNil(t, settings.Api.Reloader)
+	assert.NotNil(t, settings.Api.ActionFlags)
+	assert.NotNil(t, settings.Api.Drain)
+	assert.NotNil(t, settings.Api.RootCertificates)
+	assert.NotNil(t, settings.Api.Receipts)
+	assert.NotNil(t, settings.RootCertificateRefresher)
+	assert.NotNil(t, settings.CallScheduler)
+	assert.Equal(t, 30*time.Second, settings.ShutdownTimeout)
 	assert.NotNil(t, settings.Tracer)
 	assert.NotNil(t, settings.TracerProvider)
 	assert.NotNil(t, settings.Storage)
 	assert.NotNil(t, settings.MsgEmitter)
 	assert.NotNil(t, settings.MsgListener)
-	assert.NotNil(t, settings.Ocpp16Handler)
-	assert.NotNil(t, settings.Ocpp201Handler)
+	assert.NotNil(t, settings.Protocols.Router(transport.OcppVersion16))
+	assert.NotNil(t, settings.Protocols.Router(transport.OcppVersion201))
+	assert.Equal(t, []transport.OcppVersion{transport.OcppVersion16, transport.OcppVersion201}, settings.Protocols.Versions())
+	assert.Equal(t, 5*time.Minute, settings.LivenessService.HeartbeatInterval.Get())
 	assert.NotNil(t, settings.ContractCertValidationService)
 	assert.NotNil(t, settings.ContractCertProviderService)
 	assert.NotNil(t, settings.ChargeStationCertProviderService)
@@ -66,193 +98,1579 @@ func TestConfigureInMemoryStorage(t *testing.T) {
 	require.NotNil(t, settings.Storage)
 }
 
+func TestConfigureSqliteStorage(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "sqlite"
+	cfg.Storage.SqliteStorage = &config.SqliteStorageConfig{
+		Path: filepath.Join(t.TempDir(), "manager.db"),
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestCloseSqliteStorage(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.Storage.Type = "sqlite"
+	cfg.Storage.SqliteStorage = &config.SqliteStorageConfig{
+		Path: filepath.Join(t.TempDir(), "manager.db"),
+	}
+	cfg.Storage.EventLog = true
+	cfg.Storage.TokenCache = &config.TokenCacheConfig{Size: 10}
+
+	engine, err := config.OpenStorage(context.TODO(), &cfg.Storage)
+	require.NoError(t, err)
+
+	// the sqlite engine is closed through the wrappers
+	require.NoError(t, store.Close(engine))
+	_, err = engine.LookupChargeStationAuth(context.TODO(), "cs001")
+	assert.ErrorContains(t, err, "closed")
+}
+
+func TestConfigureDynamodbStorage(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "dynamodb"
+	cfg.Storage.DynamodbStorage = &config.DynamodbStorageConfig{
+		Table:    "maeve-csms",
+		Endpoint: "http://localhost:8000",
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestConfigureRegisteredStorage(t *testing.T) {
+	var gotOptions map[string]any
+	store.Register("test_registered", func(ctx context.Context, options map[string]any) (store.Engine, error) {
+		gotOptions = options
+		return inmemory.NewStore(clock.RealClock{}), nil
+	})
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "test_registered"
+	cfg.Storage.Options = map[string]any{
+		"url": "custom://localhost",
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+	assert.Equal(t, map[string]any{"url": "custom://localhost"}, gotOptions)
+}
+
+func TestConfigureUnknownStorage(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "unknown"
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "unknown storage type: unknown")
+}
+
+func TestConfigureSqliteStorageWithOptions(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "sqlite"
+	cfg.Storage.SqliteStorage = &config.SqliteStorageConfig{
+		Path: filepath.Join(t.TempDir(), "does", "not", "exist", "manager.db"),
+	}
+	// options take precedence over the sqlite section
+	cfg.Storage.Options = map[string]any{
+		"path": filepath.Join(t.TempDir(), "manager.db"),
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestConfigureSqliteStorageWithOnlyOptions(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "sqlite"
+	cfg.Storage.Options = map[string]any{
+		"path": filepath.Join(t.TempDir(), "manager.db"),
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestConfigureSqliteStorageWithoutPath(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "sqlite"
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "option path is required")
+}
+
+func TestConfigureRedisLayeredStorage(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Redis = &config.RedisStorageConfig{
+		Addr: "localhost:6379",
+		Ttl:  "1h",
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestConfigureRedisLayeredStorageWithInvalidTtl(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Redis = &config.RedisStorageConfig{
+		Addr: "localhost:6379",
+		Ttl:  "soon",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "redis storage ttl")
+}
+
+func TestConfigureStorageRetry(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Retry = &config.StorageRetryConfig{
+		MaxAttempts:    5,
+		InitialBackoff: "50ms",
+		MaxBackoff:     "2s",
+		UnhealthyAfter: 10,
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestConfigureStorageRetryWithInvalidBackoff(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Retry = &config.StorageRetryConfig{
+		InitialBackoff: "soon",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	require.Error(t, err)
+}
+
+func TestConfigureTokenCache(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.TokenCache = &config.TokenCacheConfig{
+		Size: 100,
+		Ttl:  "30s",
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestConfigureTokenCacheWithInvalidTtl(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.TokenCache = &config.TokenCacheConfig{
+		Size: 100,
+		Ttl:  "soon",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "token cache ttl")
+}
+
+func TestConfigureTransactionEventLog(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.EventLog = true
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.IsType(t, &events.Store{}, settings.Storage)
+	assert.IsType(t, &eventlog.Store{}, settings.Storage.(*events.Store).Engine)
+}
+
+func TestConfigureTransactionEventLogWithUnsupportedStorage(t *testing.T) {
+	store.Register("test_without_event_log", func(ctx context.Context, options map[string]any) (store.Engine, error) {
+		return struct{ store.Engine }{inmemory.NewStore(clock.RealClock{})}, nil
+	})
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "test_without_event_log"
+	cfg.Storage.EventLog = true
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "does not support a transaction event log")
+}
+
+func TestConfigureMqttTls(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Mqtt.Urls = []string{"mqtts://localhost:8883"}
+	cfg.Transport.Mqtt.Username = "manager"
+	cfg.Transport.Mqtt.Password = "secret"
+	cfg.Transport.Mqtt.Tls = &config.MqttTlsConfig{
+		CaCertificate:     "testdata/root_ca.pem",
+		ClientCertificate: "testdata/mqtt_client.pem",
+		ClientKey:         "testdata/mqtt_client.key",
+		Alpn:              []string{"mqtt"},
+		MinVersion:        "1.3",
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, settings.MsgEmitter)
+	assert.NotNil(t, settings.MsgListener)
+}
+
+func TestConfigureMqttWithSecretSources(t *testing.T) {
+	t.Setenv("TEST_MQTT_PASSWORD", "secret")
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Mqtt.Urls = []string{"mqtts://localhost:8883"}
+	cfg.Transport.Mqtt.Username = "manager"
+	cfg.Transport.Mqtt.PasswordSource = &config.LocalSourceConfig{
+		Type: "env",
+		Env:  "TEST_MQTT_PASSWORD",
+	}
+	cfg.Transport.Mqtt.Tls = &config.MqttTlsConfig{
+		ClientCertificate: "testdata/mqtt_client.pem",
+		ClientKeySource: &config.LocalSourceConfig{
+			Type: "file",
+			File: "testdata/mqtt_client.key",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, settings.MsgEmitter)
+}
+
+func TestConfigureMqttWithMissingPasswordSource(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Mqtt.Username = "manager"
+	cfg.Transport.Mqtt.PasswordSource = &config.LocalSourceConfig{
+		Type: "env",
+		Env:  "TEST_UNSET_MQTT_PASSWORD",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "reading mqtt password")
+}
+
+func TestConfigureMqttTlsWithMissingClientKey(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Mqtt.Tls = &config.MqttTlsConfig{
+		ClientCertificate: "testdata/mqtt_client.pem",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "client_key or client_key_source must be provided")
+}
+
+func TestConfigureMqttMaxConnectRetryDelayWithInvalidDuration(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Mqtt.MaxConnectRetryDelay = "later"
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "max connect retry delay")
+}
+
+func TestConfigureMqttTlsWithMissingCaCertificate(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Mqtt.Tls = &config.MqttTlsConfig{
+		CaCertificate: "testdata/missing.pem",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "reading mqtt ca certificate")
+}
+
+func TestConfigureMqttWorkersWithInvalidCount(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Mqtt.Workers = -1
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "Workers")
+}
+
+func TestConfigureNatsTransport(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Type = "nats"
+	cfg.Transport.Nats = &config.NatsSettingsConfig{
+		Urls:           []string{"nats://localhost:4222"},
+		ConnectTimeout: "5s",
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &transport.CommandAuditor{}, settings.MsgEmitter)
+	assert.IsType(t, &nats.Transport{}, settings.MsgListener)
+	assert.Nil(t, settings.DeadLetters)
+}
+
+func TestConfigureNatsTransportWithInvalidDuration(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Type = "nats"
+	cfg.Transport.Nats = &config.NatsSettingsConfig{
+		ReconnectWait: "soon",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "nats reconnect wait")
+}
+
+func TestConfigureWebsocketTransport(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Type = "websocket"
+	cfg.Transport.Websocket = &config.WebsocketSettingsConfig{
+		CallTimeout: "30s",
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Websocket)
+	assert.Equal(t, ":9310", settings.Websocket.Addr)
+	assert.Nil(t, settings.Websocket.TlsConfig)
+	assert.IsType(t, &transport.CommandAuditor{}, settings.MsgEmitter)
+	assert.Same(t, settings.Websocket.Transport, settings.MsgListener)
+	assert.Nil(t, settings.DeadLetters)
+}
+
+func TestConfigureWebsocketTransportRequiresTlsSettingsForTlsAddr(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Type = "websocket"
+	cfg.Transport.Websocket = &config.WebsocketSettingsConfig{
+		TlsAddr: ":9311",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "Tls")
+}
+
+func TestConfigureDeadLetters(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Mqtt.DeadLetterTopic = "dead-letters"
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, settings.DeadLetters)
+}
+
+func TestConfigureWithoutDeadLetters(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Nil(t, settings.DeadLetters)
+
+	_, err = config.OpenDeadLetterQueue(&cfg.Transport)
+	assert.ErrorContains(t, err, "no dead-letter topic")
+}
+
+func TestConfigureKafkaEvents(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Events = &config.EventsConfig{
+		Type: "kafka",
+		Kafka: &config.KafkaEventsConfig{
+			RestProxyUrl:  "http://localhost:8082",
+			TopicPrefix:   "csms",
+			FlushInterval: "500ms",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.IsType(t, &events.KafkaPublisher{}, settings.EventPublisher)
+	assert.IsType(t, &events.Store{}, settings.Storage)
+	assert.NoError(t, settings.EventPublisher.(*events.KafkaPublisher).Close())
+}
+
+func TestConfigureKafkaEventsWithoutRestProxyUrl(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Events = &config.EventsConfig{
+		Type:  "kafka",
+		Kafka: &config.KafkaEventsConfig{},
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "RestProxyUrl")
+}
+
+func TestConfigureRetention(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Retention = &config.RetentionConfig{
+		MeterValues:  "90d",
+		Transactions: "17520h",
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.RetentionService)
+	assert.Equal(t, 90*24*time.Hour, settings.RetentionService.MeterValues)
+	assert.Equal(t, 2*365*24*time.Hour, settings.RetentionService.Transactions)
+}
+
+func TestConfigureRetentionWithInvalidPeriod(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Retention = &config.RetentionConfig{
+		MeterValues: "ninety days",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "meter values retention period")
+}
+
+func TestConfigureReceipts(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Receipts = &config.ReceiptsConfig{
+		TaxRate: 20,
+		Seller:  "Thoughtworks",
+		Smtp: &config.SmtpDriverNotifierConfig{
+			Addr: "smtp.example.com:587",
+			From: "receipts@example.com",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Api.Receipts)
+	assert.Equal(t, 20.0, settings.Api.Receipts.TaxRate)
+	assert.Equal(t, "Thoughtworks", settings.Api.Receipts.Seller)
+	assert.NotNil(t, settings.Api.Receipts.Sender)
+}
+
+func TestConfigureReceiptsWithMissingTemplate(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Receipts = &config.ReceiptsConfig{
+		TemplateFile: "testdata/missing.html",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "receipt template")
+}
+
+func TestConfigureApiAuth(t *testing.T) {
+	t.Setenv("TEST_API_KEY", "operator-key")
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	adminKey := "admin-key"
+	operatorKeyEnvVar := "TEST_API_KEY"
+	cfg.Api.Auth = &config.ApiAuthConfig{
+		ApiKeys: []config.ApiKeyConfig{
+			{Name: "admin", Key: &adminKey, Role: "admin"},
+			{Name: "operator", KeyEnvVar: &operatorKeyEnvVar, Role: "operator", Tenant: "GB*TWK"},
+		},
+		Oidc: &config.OidcConfig{
+			Issuer:   "https://login.example.com/",
+			Audience: "csms",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Api.Authenticator)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(api.ApiKeyHeader, "operator-key")
+	principal, err := settings.Api.Authenticator.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, &api.Principal{Name: "operator", Role: api.RoleOperator, Tenant: "GB*TWK"}, principal)
+}
+
+func TestConfigureApiAuthWithVaultKeySource(t *testing.T) {
+	t.Setenv("TEST_VAULT_TOKEN", "vault-token")
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/csms/api" || r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"admin":"admin-key"}}}`))
+	}))
+	defer vault.Close()
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Api.Auth = &config.ApiAuthConfig{
+		ApiKeys: []config.ApiKeyConfig{
+			{
+				Name: "admin",
+				KeySource: &config.LocalSourceConfig{
+					Type: "vault",
+					Vault: &config.VaultSecretConfig{
+						Address:     vault.URL,
+						Path:        "secret/data/csms/api",
+						Key:         "admin",
+						TokenEnvVar: "TEST_VAULT_TOKEN",
+					},
+				},
+				Role: "admin",
+			},
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(api.ApiKeyHeader, "admin-key")
+	principal, err := settings.Api.Authenticator.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", principal.Name)
+}
+
+func TestConfigureApiAuthWithVaultKeySourceWithoutAddress(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Api.Auth = &config.ApiAuthConfig{
+		ApiKeys: []config.ApiKeyConfig{
+			{
+				Name: "admin",
+				KeySource: &config.LocalSourceConfig{
+					Type:  "vault",
+					Vault: &config.VaultSecretConfig{Path: "secret/data/csms/api", Key: "admin"},
+				},
+				Role: "admin",
+			},
+		},
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "VAULT_ADDR")
+}
+
+func TestConfigureApiAuthWithInvalidRole(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	key := "key"
+	cfg.Api.Auth = &config.ApiAuthConfig{
+		ApiKeys: []config.ApiKeyConfig{{Name: "root", Key: &key, Role: "root"}},
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.Error(t, err)
+}
+
+func TestConfigureApiAuthWithMissingKey(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	keyEnvVar := "TEST_UNSET_API_KEY"
+	cfg.Api.Auth = &config.ApiAuthConfig{
+		ApiKeys: []config.ApiKeyConfig{{Name: "reader", KeyEnvVar: &keyEnvVar, Role: "read-only"}},
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "api key reader")
+}
+
 func TestConfigureOcspContractCertValidator(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
 	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
 
 	settings, err := config.Configure(context.TODO(), cfg)
 	require.NoError(t, err)
-	require.NotNil(t, settings.ContractCertValidationService)
+	require.NotNil(t, settings.ContractCertValidationService)
+}
+
+func TestConfigureOcspContractCertValidatorFallbackPolicy(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertValidator.Ocsp.FallbackPolicy = "use_cached_status"
+	cfg.ContractCertValidator.Ocsp.RevalidateBefore = "30m"
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	validator := settings.ContractCertValidationService.(*services.ReloadableCertificateValidationService).Get().(*services.OnlineCertificateValidationService)
+	assert.Equal(t, services.OcspFallbackUseCachedStatus, validator.FallbackPolicy)
+	assert.Equal(t, 30*time.Minute, validator.RevalidateBefore)
+	assert.NotNil(t, settings.OcspRevalidator)
+}
+
+func TestConfigureOcspContractCertValidatorWithInvalidRevalidateBefore(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertValidator.Ocsp.RevalidateBefore = "later"
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "failed to parse revalidate before")
+}
+
+func TestConfigureCrlContractCertValidator(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator = config.ContractCertValidatorConfig{
+		Type: "crl",
+		Crl: &config.CrlContractCertValidatorConfig{
+			RootCertProvider: config.RootCertProviderConfig{
+				Type: "file",
+				File: &config.FileRootCertProviderConfig{
+					FileNames: []string{"testdata/root_ca.pem"},
+				},
+			},
+			MaxCrlAge: "1h",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	validator := settings.ContractCertValidationService.(*services.ReloadableCertificateValidationService).Get().(*services.CrlCertificateValidationService)
+	assert.Equal(t, time.Hour, validator.MaxCrlAge)
+	assert.Nil(t, validator.Ocsp)
+}
+
+func TestConfigureCrlContractCertValidatorWithOcsp(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Type = "crl"
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertValidator.Crl = &config.CrlContractCertValidatorConfig{
+		RootCertProvider: config.RootCertProviderConfig{
+			Type: "file",
+			File: &config.FileRootCertProviderConfig{
+				FileNames: []string{"testdata/root_ca.pem"},
+			},
+		},
+		CheckOcsp: true,
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	validator := settings.ContractCertValidationService.(*services.ReloadableCertificateValidationService).Get().(*services.CrlCertificateValidationService)
+	assert.IsType(t, &services.OnlineCertificateValidationService{}, validator.Ocsp)
+}
+
+func TestConfigureCrlContractCertValidatorWithInvalidMaxCrlAge(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator = config.ContractCertValidatorConfig{
+		Type: "crl",
+		Crl: &config.CrlContractCertValidatorConfig{
+			RootCertProvider: config.RootCertProviderConfig{
+				Type: "file",
+				File: &config.FileRootCertProviderConfig{
+					FileNames: []string{"testdata/root_ca.pem"},
+				},
+			},
+			MaxCrlAge: "forever",
+		},
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "failed to parse max crl age")
+}
+
+func TestConfigureOpcpContractCertProvider(t *testing.T) {
+	_ = os.Setenv("TEST_OPCP_TOKEN", "test-token")
+	defer func() {
+		_ = os.Unsetenv("TEST_OPCP_TOKEN")
+	}()
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertProvider.Type = "opcp"
+	cfg.ContractCertProvider.Opcp = &config.OpcpContractCertProviderConfig{
+		Url: "http://localhost:8080",
+		HttpAuth: config.HttpAuthConfig{
+			Type: "env_token",
+			EnvToken: &config.EnvHttpTokenConfig{
+				EnvVar: "TEST_OPCP_TOKEN",
+			},
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ContractCertProviderService)
+}
+
+func TestConfigureOpcpContractCertProviderWithSourceToken(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertProvider.Type = "opcp"
+	cfg.ContractCertProvider.Opcp = &config.OpcpContractCertProviderConfig{
+		Url: "http://localhost:8080",
+		HttpAuth: config.HttpAuthConfig{
+			Type: "source_token",
+			SourceToken: &config.SourceHttpTokenConfig{
+				Source: &config.LocalSourceConfig{
+					Type: "file",
+					File: "testdata/token",
+				},
+				Ttl: "1m",
+			},
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ContractCertProviderService)
+}
+
+func TestConfigureOpcpContractCertProviderWithOAuth2ClientSecretSource(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertProvider.Type = "opcp"
+	cfg.ContractCertProvider.Opcp = &config.OpcpContractCertProviderConfig{
+		Url: "http://localhost:8080",
+		HttpAuth: config.HttpAuthConfig{
+			Type: "oauth2_token",
+			OAuth2Token: &config.OAuth2HttpTokenConfig{
+				Url:      "http://localhost:8080/token",
+				ClientId: "client_id",
+				ClientSecretSource: &config.LocalSourceConfig{
+					Type: "file",
+					File: "testdata/token",
+				},
+				RefreshBefore: "2m",
+			},
+		},
+	}
+
+	require.NoError(t, cfg.Validate())
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ContractCertProviderService)
+}
+
+func TestConfigureOpcpContractCertProviderWithInvalidOAuth2RefreshBefore(t *testing.T) {
+	secret := "client_secret"
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertProvider.Type = "opcp"
+	cfg.ContractCertProvider.Opcp = &config.OpcpContractCertProviderConfig{
+		Url: "http://localhost:8080",
+		HttpAuth: config.HttpAuthConfig{
+			Type: "oauth2_token",
+			OAuth2Token: &config.OAuth2HttpTokenConfig{
+				Url:           "http://localhost:8080/token",
+				ClientId:      "client_id",
+				ClientSecret:  &secret,
+				RefreshBefore: "soon",
+			},
+		},
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "parse oauth2 token refresh before")
+}
+
+func TestConfigureOcspContractCertProviderWithCompositeRootCertificateProvider(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.Type = "composite"
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.Composite = &config.CompositeRootCertProviderConfig{
+		Providers: []config.RootCertProviderConfig{
+			{
+				Type: "file",
+				File: &config.FileRootCertProviderConfig{
+					FileNames: []string{"testdata/root_ca.pem"},
+				},
+			},
+			{
+				Type: "file",
+				File: &config.FileRootCertProviderConfig{
+					FileNames: []string{"testdata/ca.pem"},
+				},
+			},
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ContractCertValidationService)
+}
+
+func TestConfigureFallbackContractCertProvider(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertProvider.Type = "fallback"
+	cfg.ContractCertProvider.Fallback = &config.FallbackContractCertProviderConfig{
+		Providers: []config.ContractCertProviderConfig{
+			{
+				Type: "opcp",
+				Opcp: &config.OpcpContractCertProviderConfig{
+					Url: "http://localhost:8080",
+					HttpAuth: config.HttpAuthConfig{
+						Type: "fixed_token",
+						FixedToken: &config.FixedHttpTokenConfig{
+							Token: "primary-token",
+						},
+					},
+				},
+			},
+			{
+				Type: "opcp",
+				Opcp: &config.OpcpContractCertProviderConfig{
+					Url: "http://localhost:8081",
+					HttpAuth: config.HttpAuthConfig{
+						Type: "fixed_token",
+						FixedToken: &config.FixedHttpTokenConfig{
+							Token: "secondary-token",
+						},
+					},
+				},
+			},
+		},
+		FailureThreshold: 5,
+		RetryAfter:       "5m",
+	}
+
+	require.NoError(t, cfg.Validate())
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ContractCertProviderService)
+}
+
+func TestConfigureFallbackContractCertProviderWithInvalidRetryAfter(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertProvider.Type = "fallback"
+	cfg.ContractCertProvider.Fallback = &config.FallbackContractCertProviderConfig{
+		Providers: []config.ContractCertProviderConfig{
+			{
+				Type: "default",
+			},
+		},
+		RetryAfter: "later",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "failed to parse fallback contract certificate provider retry after")
+}
+
+func TestValidateFallbackContractCertProviderWithoutProviders(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertProvider.Type = "fallback"
+	cfg.ContractCertProvider.Fallback = &config.FallbackContractCertProviderConfig{}
+
+	assert.Error(t, cfg.Validate())
+}
+
+func TestConfigureDefaultContractCertProvider(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ContractCertProvider.Type = "default"
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ContractCertProviderService)
+}
+
+func TestConfigureOpcpChargeStationCertProvider(t *testing.T) {
+	_ = os.Setenv("TEST_OPCP_TOKEN", "test-token")
+	defer func() {
+		_ = os.Unsetenv("TEST_OPCP_TOKEN")
+	}()
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ChargeStationCertProvider.Type = "opcp"
+	cfg.ChargeStationCertProvider.Opcp = &config.OpcpChargeStationCertProviderConfig{
+		Url: "http://localhost:8080",
+		HttpAuth: config.HttpAuthConfig{
+			Type: "env_token",
+			EnvToken: &config.EnvHttpTokenConfig{
+				EnvVar: "TEST_OPCP_TOKEN",
+			},
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ChargeStationCertProviderService)
+}
+
+func TestConfigureLocalChargeStationCertProviderWithFile(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ChargeStationCertProvider.Type = "local"
+	cfg.ChargeStationCertProvider.Local = &config.LocalChargeStationCertProviderConfig{
+		CertificateSource: &config.LocalSourceConfig{
+			Type: "file",
+			File: "testdata/mqtt_client.pem",
+		},
+		PrivateKeySource: &config.LocalSourceConfig{
+			Type: "file",
+			File: "testdata/ca.key",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ChargeStationCertProviderService)
+}
+
+func TestConfigureLocalChargeStationCertProviderWithGoogleCloudSecret(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ChargeStationCertProvider.Type = "local"
+	cfg.ChargeStationCertProvider.Local = &config.LocalChargeStationCertProviderConfig{
+		CertificateSource: &config.LocalSourceConfig{
+			Type:              "google_cloud_secret",
+			GoogleCloudSecret: "project/12345678/secrets/certificate/versions/1",
+		},
+		PrivateKeySource: &config.LocalSourceConfig{
+			Type:              "google_cloud_secret",
+			GoogleCloudSecret: "project/12345678/secrets/privatekey/versions/1",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ChargeStationCertProviderService)
+}
+
+func TestConfigureDelegatingChargeStationCertProvider(t *testing.T) {
+	_ = os.Setenv("TEST_OPCP_TOKEN", "test-token")
+	defer func() {
+		_ = os.Unsetenv("TEST_OPCP_TOKEN")
+	}()
+
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ChargeStationCertProvider.Type = "delegating"
+	cfg.ChargeStationCertProvider.Delegating = &config.DelegatingChargeStationCertProviderConfig{
+		V2G: &config.ChargeStationCertProviderConfig{
+			Type: "opcp",
+			Opcp: &config.OpcpChargeStationCertProviderConfig{
+				Url: "http://localhost:8080",
+				HttpAuth: config.HttpAuthConfig{
+					Type: "env_token",
+					EnvToken: &config.EnvHttpTokenConfig{
+						EnvVar: "TEST_OPCP_TOKEN",
+					},
+				},
+			},
+		},
+		CSO: &config.ChargeStationCertProviderConfig{
+			Type: "local",
+			Local: &config.LocalChargeStationCertProviderConfig{
+				CertificateSource: &config.LocalSourceConfig{
+					Type: "file",
+					File: "testdata/mqtt_client.pem",
+				},
+				PrivateKeySource: &config.LocalSourceConfig{
+					Type: "file",
+					File: "testdata/ca.key",
+				},
+			},
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ChargeStationCertProviderService)
+}
+
+func TestConfigureDefaultChargeStationCertProvider(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.ChargeStationCertProvider.Type = "default"
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.ChargeStationCertProviderService)
+}
+
+func TestConfigureKwHTariffService(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.TariffService.Type = "kwh"
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.IsType(t, services.TariffEngine{}, settings.TariffService.(*services.ReloadableTariffService).Get())
+	tariffService := settings.TariffService.(*services.ReloadableTariffService).Get().(services.TariffEngine)
+	assert.Equal(t, services.NewDefaultTariff("EUR", 0, 0.55, 0), tariffService.DefaultTariff)
+	assert.NotNil(t, tariffService.Store)
+}
+
+func TestConfigureTimeTariffService(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.TariffService = config.TariffServiceConfig{
+		Type: "time",
+		Time: &config.TimeTariffServiceConfig{
+			PricePerKwh:          0.5,
+			PricePerChargingHour: 1,
+			IdleFeePerMinute:     0.1,
+			IdleGracePeriod:      "15m",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, services.TimeBasedTariffService{
+		PricePerKwh:          0.5,
+		PricePerChargingHour: 1,
+		IdleFeePerMinute:     0.1,
+		IdleGracePeriod:      15 * time.Minute,
+	}, settings.TariffService.(*services.ReloadableTariffService).Get())
+}
+
+func TestConfigureOcpiTariffService(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.TariffService = config.TariffServiceConfig{
+		Type: "ocpi",
+		Ocpi: &config.OcpiTariffServiceConfig{
+			TariffId: "tariff001",
+			Timezone: "Europe/London",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.IsType(t, services.OcpiTariffService{}, settings.TariffService.(*services.ReloadableTariffService).Get())
+	tariffService := settings.TariffService.(*services.ReloadableTariffService).Get().(services.OcpiTariffService)
+	assert.Equal(t, "tariff001", tariffService.TariffId)
+	assert.Equal(t, "Europe/London", tariffService.Location.String())
+	assert.NotNil(t, tariffService.Store)
+}
+
+func TestConfigureEngineTariffService(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.TariffService = config.TariffServiceConfig{
+		Type: "engine",
+		Engine: &config.EngineTariffServiceConfig{
+			DefaultTariffId: "tariff001",
+			Currency:        "GBP",
+			SessionFee:      1,
+			PricePerKwh:     0.3,
+			PricePerMinute:  0.05,
+			Timezone:        "Europe/London",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.IsType(t, services.TariffEngine{}, settings.TariffService.(*services.ReloadableTariffService).Get())
+	tariffService := settings.TariffService.(*services.ReloadableTariffService).Get().(services.TariffEngine)
+	assert.Equal(t, "tariff001", tariffService.DefaultTariffId)
+	assert.Equal(t, services.NewDefaultTariff("GBP", 1, 0.3, 0.05), tariffService.DefaultTariff)
+	assert.Equal(t, "Europe/London", tariffService.Location.String())
+	assert.NotNil(t, tariffService.Store)
+}
+
+func TestConfigureHttpTariffService(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.TariffService = config.TariffServiceConfig{
+		Type: "http",
+		Http: &config.HttpTariffServiceConfig{
+			Url: "https://pricing.example.com/price",
+			Auth: &config.HttpAuthConfig{
+				Type:       "fixed_token",
+				FixedToken: &config.FixedHttpTokenConfig{Token: "secret"},
+			},
+			Timeout:          "2s",
+			FailureThreshold: 3,
+			OpenFor:          "30s",
+			FallbackTariffId: "tariff001",
+			Timezone:         "Europe/London",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &services.HttpTariffService{}, settings.TariffService.(*services.ReloadableTariffService).Get())
+}
+
+func TestConfigureHttpTariffServiceWithInvalidTimeout(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.TariffService = config.TariffServiceConfig{
+		Type: "http",
+		Http: &config.HttpTariffServiceConfig{
+			Url:     "https://pricing.example.com/price",
+			Timeout: "soon",
+		},
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "failed to parse pricing service timeout")
+}
+
+func TestConfigureDefaultLoadManagement(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.IsType(t, services.AsSoonAsPossibleSchedulingStrategy{}, settings.SchedulingStrategy)
+}
+
+func TestConfigureLowCarbonLoadManagement(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	hourly := make([]float64, 24)
+	for i := range hourly {
+		hourly[i] = float64(200 + i)
+	}
+	cfg.LoadManagement = &config.LoadManagementConfig{
+		Type: "low_carbon",
+		LowCarbon: &config.LowCarbonLoadManagementConfig{
+			HourlyCarbonIntensity: hourly,
+			Timezone:              "Europe/London",
+		},
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.IsType(t, services.LowCarbonSchedulingStrategy{}, settings.SchedulingStrategy)
+	strategy := settings.SchedulingStrategy.(services.LowCarbonSchedulingStrategy)
+	require.IsType(t, services.DailyCarbonIntensitySignal{}, strategy.Signal)
+	signal := strategy.Signal.(services.DailyCarbonIntensitySignal)
+	assert.Equal(t, "Europe/London", signal.Location.String())
+	assert.Equal(t, float64(223), signal.HourlyIntensity[23])
+	assert.NotNil(t, strategy.Reservations)
 }
 
-func TestConfigureOpcpContractCertProvider(t *testing.T) {
-	_ = os.Setenv("TEST_OPCP_TOKEN", "test-token")
-	defer func() {
-		_ = os.Unsetenv("TEST_OPCP_TOKEN")
-	}()
+func TestConfigureLowCarbonLoadManagementRequiresHourlyIntensity(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.LoadManagement = &config.LoadManagementConfig{
+		Type: "low_carbon",
+		LowCarbon: &config.LowCarbonLoadManagementConfig{
+			HourlyCarbonIntensity: []float64{100, 200},
+		},
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.Error(t, err)
+}
 
+func TestConfigureProvisioningScript(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
 	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
-	cfg.ContractCertProvider.Type = "opcp"
-	cfg.ContractCertProvider.Opcp = &config.OpcpContractCertProviderConfig{
-		Url: "http://localhost:8080",
-		HttpAuth: config.HttpAuthConfig{
-			Type: "env_token",
-			EnvToken: &config.EnvHttpTokenConfig{
-				EnvVar: "TEST_OPCP_TOKEN",
-			},
+	connectorId := 1
+	cfg.Ocpp.Provisioning = &config.ProvisioningConfig{
+		PendingInterval: "30s",
+		Steps: []config.ProvisioningStepConfig{
+			{Type: "change_configuration", Key: "MeterValueSampleInterval", Value: "60"},
+			{Type: "trigger_message", Message: "StatusNotification", ConnectorId: &connectorId},
 		},
 	}
 
 	settings, err := config.Configure(context.TODO(), cfg)
 	require.NoError(t, err)
-	require.NotNil(t, settings.ContractCertProviderService)
+	assert.Equal(t, &ocpp16.ProvisioningScript{
+		Steps: []ocpp16.ProvisioningStep{
+			{ChangeConfiguration: &ocpp16types.ChangeConfigurationJson{Key: "MeterValueSampleInterval", Value: "60"}},
+			{TriggerMessage: &ocpp16types.TriggerMessageJson{
+				RequestedMessage: ocpp16types.TriggerMessageJsonRequestedMessageStatusNotification,
+				ConnectorId:      &connectorId,
+			}},
+		},
+		PendingInterval: 30 * time.Second,
+		RetryAfter:      2 * time.Minute,
+	}, settings.ProvisioningScript)
 }
 
-func TestConfigureOcspContractCertProviderWithCompositeRootCertificateProvider(t *testing.T) {
+func TestConfigureProvisioningScriptWithUnknownTriggerMessage(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
-	cfg.ContractCertValidator.Ocsp.RootCertProvider.Type = "composite"
-	cfg.ContractCertValidator.Ocsp.RootCertProvider.Composite = &config.CompositeRootCertProviderConfig{
-		Providers: []config.RootCertProviderConfig{
-			{
-				Type: "file",
-				File: &config.FileRootCertProviderConfig{
-					FileNames: []string{"testdata/root_ca.pem"},
-				},
-			},
-			{
-				Type: "file",
-				File: &config.FileRootCertProviderConfig{
-					FileNames: []string{"testdata/ca.pem"},
-				},
-			},
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.Provisioning = &config.ProvisioningConfig{
+		Steps: []config.ProvisioningStepConfig{
+			{Type: "trigger_message", Message: "SignV2GCertificate"},
 		},
 	}
 
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.Error(t, err)
+}
+
+func TestConfigureWithoutProvisioningScript(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+
 	settings, err := config.Configure(context.TODO(), cfg)
 	require.NoError(t, err)
-	require.NotNil(t, settings.ContractCertValidationService)
+	assert.Nil(t, settings.ProvisioningScript)
 }
 
-func TestConfigureDefaultContractCertProvider(t *testing.T) {
+func TestConfigureRegistrationPolicy(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
 	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
-	cfg.ContractCertProvider.Type = "default"
+	cfg.Ocpp.Registration = &config.RegistrationConfig{
+		DefaultStatus: "Pending",
+		RetryInterval: "30s",
+	}
 
 	settings, err := config.Configure(context.TODO(), cfg)
 	require.NoError(t, err)
-	require.NotNil(t, settings.ContractCertProviderService)
+	assert.Equal(t, store.RegistrationStatusPending, settings.RegistrationPolicy.DefaultStatus)
+	assert.Equal(t, 30*time.Second, settings.RegistrationPolicy.RetryInterval)
+	assert.NotNil(t, settings.RegistrationPolicy.Store)
 }
 
-func TestConfigureOpcpChargeStationCertProvider(t *testing.T) {
-	_ = os.Setenv("TEST_OPCP_TOKEN", "test-token")
-	defer func() {
-		_ = os.Unsetenv("TEST_OPCP_TOKEN")
-	}()
+func TestConfigureDefaultRegistrationPolicy(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, store.RegistrationStatusAccepted, settings.RegistrationPolicy.DefaultStatus)
+	assert.Equal(t, time.Minute, settings.RegistrationPolicy.RetryInterval)
+}
 
+func TestConfigureRegistrationPolicyWithUnknownStatus(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
 	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
-	cfg.ChargeStationCertProvider.Type = "opcp"
-	cfg.ChargeStationCertProvider.Opcp = &config.OpcpChargeStationCertProviderConfig{
-		Url: "http://localhost:8080",
-		HttpAuth: config.HttpAuthConfig{
-			Type: "env_token",
-			EnvToken: &config.EnvHttpTokenConfig{
-				EnvVar: "TEST_OPCP_TOKEN",
-			},
+	cfg.Ocpp.Registration = &config.RegistrationConfig{
+		DefaultStatus: "Unknown",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.Error(t, err)
+}
+
+func TestConfigureRateLimit(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.RateLimit = &config.RateLimitConfig{
+		MaxWait: "2s",
+		Actions: map[string]config.RateLimitActionConfig{
+			"Heartbeat":   {PerMinute: 2},
+			"MeterValues": {PerMinute: 60, Burst: 10},
 		},
 	}
 
-	settings, err := config.Configure(context.TODO(), cfg)
-	require.NoError(t, err)
-	require.NotNil(t, settings.ChargeStationCertProviderService)
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.NoError(t, err)
 }
 
-func TestConfigureLocalChargeStationCertProviderWithFile(t *testing.T) {
+func TestConfigureRateLimitWithInvalidRate(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
-	cfg.ChargeStationCertProvider.Type = "local"
-	cfg.ChargeStationCertProvider.Local = &config.LocalChargeStationCertProviderConfig{
-		CertificateSource: &config.LocalSourceConfig{
-			Type: "file",
-			File: "testdata/ca.pem",
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.RateLimit = &config.RateLimitConfig{
+		Actions: map[string]config.RateLimitActionConfig{
+			"Heartbeat": {PerMinute: 0},
 		},
-		PrivateKeySource: &config.LocalSourceConfig{
-			Type: "file",
-			File: "testdata/ca.key",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "PerMinute")
+}
+
+func TestConfigureRateLimitWithInvalidMaxWait(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.RateLimit = &config.RateLimitConfig{
+		MaxWait: "soon",
+		Actions: map[string]config.RateLimitActionConfig{
+			"Heartbeat": {PerMinute: 2},
 		},
 	}
 
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "rate limit max wait")
+}
+
+func TestConfigureDuplicateCalls(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.DuplicateCalls = &config.DuplicateCallsConfig{
+		CacheSize: 100,
+		Ttl:       "1m",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.NoError(t, err)
+}
+
+func TestConfigureDuplicateCallsWithInvalidTtl(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.DuplicateCalls = &config.DuplicateCallsConfig{
+		Ttl: "forever",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "duplicate calls ttl")
+}
+
+func TestConfigurePoisonMessages(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.PoisonMessages = &config.PoisonMessagesConfig{
+		MaxFailures: 5,
+		CacheSize:   100,
+		Ttl:         "1m",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.NoError(t, err)
+}
+
+func TestConfigurePoisonMessagesWithInvalidTtl(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.PoisonMessages = &config.PoisonMessagesConfig{
+		Ttl: "forever",
+	}
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "poison messages ttl")
+}
+
+func TestConfigureOutboundCallsDisabled(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Transport.Type = "nats"
+	cfg.Transport.Nats = &config.NatsSettingsConfig{
+		Urls: []string{"nats://localhost:4222"},
+	}
+	cfg.Ocpp.OutboundCalls = &config.OutboundCallsConfig{
+		Disabled: true,
+	}
+
 	settings, err := config.Configure(context.TODO(), cfg)
 	require.NoError(t, err)
-	require.NotNil(t, settings.ChargeStationCertProviderService)
+	assert.Nil(t, settings.CallScheduler)
+	assert.IsType(t, &transport.CommandAuditor{}, settings.MsgEmitter)
 }
 
-func TestConfigureLocalChargeStationCertProviderWithGoogleCloudSecret(t *testing.T) {
+func TestConfigureOutboundCallsWithInvalidTimeout(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
-	cfg.ChargeStationCertProvider.Type = "local"
-	cfg.ChargeStationCertProvider.Local = &config.LocalChargeStationCertProviderConfig{
-		CertificateSource: &config.LocalSourceConfig{
-			Type:              "google_cloud_secret",
-			GoogleCloudSecret: "project/12345678/secrets/certificate/versions/1",
-		},
-		PrivateKeySource: &config.LocalSourceConfig{
-			Type:              "google_cloud_secret",
-			GoogleCloudSecret: "project/12345678/secrets/privatekey/versions/1",
-		},
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.OutboundCalls = &config.OutboundCallsConfig{
+		Timeout: "never",
 	}
 
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "outbound calls timeout")
+}
+
+func TestConfigureDisabledActions(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.Ocpp16DisabledActions = []string{"DataTransfer"}
+
 	settings, err := config.Configure(context.TODO(), cfg)
 	require.NoError(t, err)
-	require.NotNil(t, settings.ChargeStationCertProviderService)
+	flags := settings.Api.ActionFlags
+	assert.True(t, flags.Known(transport.OcppVersion16, "DataTransfer"))
+	assert.False(t, flags.Enabled(transport.OcppVersion16, "DataTransfer"))
+	assert.True(t, flags.Enabled(transport.OcppVersion16, "Heartbeat"))
+
+	// the configuration is reloaded
+	config.ApplyDisabledActions(flags, &config.OcppSettingsConfig{
+		Ocpp16DisabledActions: []string{"Heartbeat"},
+	})
+	assert.True(t, flags.Enabled(transport.OcppVersion16, "DataTransfer"))
+	assert.False(t, flags.Enabled(transport.OcppVersion16, "Heartbeat"))
 }
 
-func TestConfigureDelegatingChargeStationCertProvider(t *testing.T) {
-	_ = os.Setenv("TEST_OPCP_TOKEN", "test-token")
-	defer func() {
-		_ = os.Unsetenv("TEST_OPCP_TOKEN")
-	}()
+func TestConfigureOcpp16SoapTranslation(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.Ocpp16SoapTranslation = true
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, settings.Protocols.Router(transport.OcppVersion16))
+}
 
+func TestConfigureOcpp21(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
-	cfg.ChargeStationCertProvider.Type = "delegating"
-	cfg.ChargeStationCertProvider.Delegating = &config.DelegatingChargeStationCertProviderConfig{
-		V2G: &config.ChargeStationCertProviderConfig{
-			Type: "opcp",
-			Opcp: &config.OpcpChargeStationCertProviderConfig{
-				Url: "http://localhost:8080",
-				HttpAuth: config.HttpAuthConfig{
-					Type: "env_token",
-					EnvToken: &config.EnvHttpTokenConfig{
-						EnvVar: "TEST_OPCP_TOKEN",
-					},
-				},
-			},
-		},
-		CSO: &config.ChargeStationCertProviderConfig{
-			Type: "local",
-			Local: &config.LocalChargeStationCertProviderConfig{
-				CertificateSource: &config.LocalSourceConfig{
-					Type: "file",
-					File: "testdata/ca.pem",
-				},
-				PrivateKeySource: &config.LocalSourceConfig{
-					Type: "file",
-					File: "testdata/ca.key",
-				},
-			},
-		},
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.Ocpp21Enabled = true
+	cfg.Ocpp.Ocpp21DisabledActions = []string{"BatterySwap"}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, settings.Protocols.Router(transport.OcppVersion21))
+	assert.Equal(t, []transport.OcppVersion{transport.OcppVersion16, transport.OcppVersion201, transport.OcppVersion21}, settings.Protocols.Versions())
+	assert.False(t, settings.Api.ActionFlags.Enabled(transport.OcppVersion21, "BatterySwap"))
+	assert.True(t, settings.Api.ActionFlags.Enabled(transport.OcppVersion21, "NotifyDERAlarm"))
+}
+
+func TestConfigureShutdownTimeout(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Shutdown = &config.ShutdownConfig{Timeout: "1m"}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, settings.ShutdownTimeout)
+
+	cfg.Shutdown.Timeout = "soon"
+	_, err = config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "failed to parse shutdown timeout")
+}
+
+func TestConfigureOfflineAfterMissedHeartbeats(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.OfflineAfterMissedHeartbeats = 5
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 5, settings.LivenessService.MissedHeartbeats)
+}
+
+func TestConfigureCertificateRenewal(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.CertificateExpiryService)
+	assert.Equal(t, 30*24*time.Hour, settings.CertificateExpiryService.RenewBefore)
+	assert.Equal(t, 14*24*time.Hour, settings.CertificateExpiryService.AlertBefore)
+
+	cfg.Ocpp.CertificateRenewal = &config.CertificateRenewalConfig{
+		RenewBefore: "60d",
+		AlertBefore: "168h",
+	}
+	settings, err = config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 60*24*time.Hour, settings.CertificateExpiryService.RenewBefore)
+	assert.Equal(t, 7*24*time.Hour, settings.CertificateExpiryService.AlertBefore)
+
+	cfg.Ocpp.CertificateRenewal = &config.CertificateRenewalConfig{
+		Disabled: true,
+	}
+	settings, err = config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Nil(t, settings.CertificateExpiryService)
+}
+
+func TestConfigureCertificateRenewalWithInvalidPeriod(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Ocpp.CertificateRenewal = &config.CertificateRenewalConfig{
+		RenewBefore: "a month",
 	}
 
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "failed to parse certificate renew before")
+}
+
+func TestConfigureReservationNoShow(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+
 	settings, err := config.Configure(context.TODO(), cfg)
 	require.NoError(t, err)
-	require.NotNil(t, settings.ChargeStationCertProviderService)
+	require.NotNil(t, settings.ReservationNoShowPolicy)
+	assert.Equal(t, 15*time.Minute, settings.ReservationNoShowPolicy.GracePeriod)
+	assert.Nil(t, settings.ReservationNoShowPolicy.Fees)
+
+	cfg.Ocpp.ReservationNoShow = &config.ReservationNoShowConfig{
+		GracePeriod: "10m",
+		Fee:         true,
+	}
+	settings, err = config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, settings.ReservationNoShowPolicy.GracePeriod)
+	assert.NotNil(t, settings.ReservationNoShowPolicy.Fees)
+
+	cfg.Ocpp.ReservationNoShow = &config.ReservationNoShowConfig{
+		Disabled: true,
+	}
+	settings, err = config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	assert.Nil(t, settings.ReservationNoShowPolicy)
+
+	cfg.Ocpp.ReservationNoShow = &config.ReservationNoShowConfig{
+		GracePeriod: "a while",
+	}
+	_, err = config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "failed to parse reservation no-show grace period")
 }
 
-func TestConfigureDefaultChargeStationCertProvider(t *testing.T) {
+func TestConfigureReservationNotifications(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
 	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
-	cfg.ChargeStationCertProvider.Type = "default"
 
 	settings, err := config.Configure(context.TODO(), cfg)
 	require.NoError(t, err)
-	require.NotNil(t, settings.ChargeStationCertProviderService)
+	assert.Nil(t, settings.DriverNotificationPolicy)
+
+	cfg.Ocpp.ReservationNotifications = &config.ReservationNotificationsConfig{
+		ReminderBefore: "30m",
+		Notifiers: []config.DriverNotifierConfig{
+			{
+				Type: "smtp",
+				Smtp: &config.SmtpDriverNotifierConfig{
+					Addr:     "smtp.example.com:587",
+					From:     "reservations@example.com",
+					Username: "csms",
+					Password: "secret",
+				},
+			},
+			{
+				Tenant: "acme",
+				Type:   "webhook",
+				Webhook: &config.WebhookDriverNotifierConfig{
+					Url: "https://acme.example.com/notifications",
+				},
+			},
+			{
+				Tenant: "acme",
+				Type:   "sms",
+				Sms: &config.SmsDriverNotifierConfig{
+					Url:  "https://sms.example.com/messages",
+					From: "ACME",
+				},
+			},
+		},
+	}
+	settings, err = config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.DriverNotificationPolicy)
+	assert.Equal(t, 30*time.Minute, settings.DriverNotificationPolicy.ReminderBefore)
+	notifier, ok := settings.DriverNotificationPolicy.Notifier.(services.TenantDriverNotifier)
+	require.True(t, ok)
+	assert.Len(t, notifier.Default, 1)
+	assert.Len(t, notifier.Tenants["acme"], 2)
+
+	cfg.Ocpp.ReservationNotifications.ReminderBefore = "soon"
+	_, err = config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "failed to parse reservation reminder before")
 }
 
-func TestConfigureKwHTariffService(t *testing.T) {
+func TestConfigureOicp(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
 	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
-	cfg.TariffService.Type = "kwh"
+	cfg.Oicp = &config.OicpConfig{
+		Url:                "https://api.hubject.com",
+		OperatorId:         "DE*TWK",
+		OperatorName:       "Thoughtworks",
+		HotlinePhoneNumber: "+4930123456",
+		HttpAuth: &config.HttpAuthConfig{
+			Type: "fixed_token",
+			FixedToken: &config.FixedHttpTokenConfig{
+				Token: "hubject-token",
+			},
+		},
+	}
 
 	settings, err := config.Configure(context.TODO(), cfg)
 	require.NoError(t, err)
-	require.NotNil(t, settings.ContractCertProviderService)
+	assert.NotNil(t, settings.OicpApi)
+	assert.Nil(t, settings.OcpiApi)
 }
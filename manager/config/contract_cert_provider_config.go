@@ -7,7 +7,14 @@ type OpcpContractCertProviderConfig struct {
 	HttpAuth HttpAuthConfig `mapstructure:"auth" toml:"auth" validate:"required"`
 }
 
+type FallbackContractCertProviderConfig struct {
+	Providers        []ContractCertProviderConfig `mapstructure:"providers" toml:"providers" validate:"required,min=1,dive,required"`
+	FailureThreshold int                          `mapstructure:"failure_threshold,omitempty" toml:"failure_threshold,omitempty" validate:"omitempty,min=1"`
+	RetryAfter       string                       `mapstructure:"retry_after,omitempty" toml:"retry_after,omitempty"`
+}
+
 type ContractCertProviderConfig struct {
-	Type string                          `mapstructure:"type" toml:"type" validate:"required,oneof=default opcp"`
-	Opcp *OpcpContractCertProviderConfig `mapstructure:"opcp,omitempty" toml:"opcp,omitempty" validate:"required_if=Type opcp"`
+	Type     string                              `mapstructure:"type" toml:"type" validate:"required,oneof=default opcp fallback"`
+	Opcp     *OpcpContractCertProviderConfig     `mapstructure:"opcp,omitempty" toml:"opcp,omitempty" validate:"required_if=Type opcp"`
+	Fallback *FallbackContractCertProviderConfig `mapstructure:"fallback,omitempty" toml:"fallback,omitempty" validate:"required_if=Type fallback"`
 }
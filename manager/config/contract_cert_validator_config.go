@@ -5,9 +5,18 @@ package config
 type OcspContractCertValidatorConfig struct {
 	RootCertProvider RootCertProviderConfig `mapstructure:"root_certs" toml:"root_certs" validate:"required"`
 	MaxAttempts      int                    `mapstructure:"max_attempts" toml:"max_attempts" validate:"required"`
+	FallbackPolicy   string                 `mapstructure:"fallback_policy,omitempty" toml:"fallback_policy,omitempty" validate:"omitempty,oneof=reject accept_with_warning use_cached_status"`
+	RevalidateBefore string                 `mapstructure:"revalidate_before,omitempty" toml:"revalidate_before,omitempty"`
+}
+
+type CrlContractCertValidatorConfig struct {
+	RootCertProvider RootCertProviderConfig `mapstructure:"root_certs" toml:"root_certs" validate:"required"`
+	MaxCrlAge        string                 `mapstructure:"max_crl_age,omitempty" toml:"max_crl_age,omitempty"`
+	CheckOcsp        bool                   `mapstructure:"check_ocsp,omitempty" toml:"check_ocsp,omitempty"`
 }
 
 type ContractCertValidatorConfig struct {
-	Type string                           `mapstructure:"type" toml:"type" validate:"required,oneof=ocsp"`
+	Type string                           `mapstructure:"type" toml:"type" validate:"required,oneof=ocsp crl"`
 	Ocsp *OcspContractCertValidatorConfig `mapstructure:"ocsp,omitempty" toml:"ocsp,omitempty" validate:"required_if=Type ocsp"`
+	Crl  *CrlContractCertValidatorConfig  `mapstructure:"crl,omitempty" toml:"crl,omitempty" validate:"required_if=Type crl"`
 }
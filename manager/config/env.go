@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is the prefix of the environment variables that override the configuration
+const EnvPrefix = "MANAGER"
+
+// LoadFromEnv overrides the configuration with the values of environment variables.
+// Each setting has a variable named by joining the prefix and the TOML keys of the
+// setting with underscores, in upper case: e.g. `MANAGER_STORAGE_TYPE` sets the `type`
+// of the `[storage]` section and `MANAGER_TRANSPORT_MQTT_URLS` sets the `urls` of the
+// `[transport.mqtt]` section. Lists are given as comma-separated values. Sections
+// that are arrays or maps can only be configured in the file.
+func (c *BaseConfig) LoadFromEnv(prefix string) error {
+	_, err := loadEnvStruct(reflect.ValueOf(c).Elem(), prefix)
+	if err != nil {
+		return err
+	}
+	c.replaceDefaults()
+	return nil
+}
+
+// loadEnvStruct sets the fields of the struct from the environment, reporting
+// whether any field was set
+func loadEnvStruct(v reflect.Value, name string) (bool, error) {
+	set := false
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if key == "" || key == "-" || !field.IsExported() {
+			continue
+		}
+		fieldSet, err := loadEnvValue(v.Field(i), name+"_"+strings.ToUpper(key))
+		if err != nil {
+			return false, err
+		}
+		set = set || fieldSet
+	}
+	return set, nil
+}
+
+func loadEnvValue(v reflect.Value, name string) (bool, error) {
+	switch v.Kind() {
+	case reflect.Struct:
+		return loadEnvStruct(v, name)
+	case reflect.Pointer:
+		// a section or setting that is not in the file is only created when a variable
+		// sets it: sections can be nested within sections of the same type, so a
+		// section is only visited when there are variables for it
+		if _, ok := os.LookupEnv(name); !ok && !envHasPrefix(name+"_") {
+			return false, nil
+		}
+		value := reflect.New(v.Type().Elem())
+		if !v.IsNil() {
+			value.Elem().Set(v.Elem())
+		}
+		set, err := loadEnvValue(value.Elem(), name)
+		if err != nil || !set {
+			return false, err
+		}
+		v.Set(value)
+		return true, nil
+	}
+
+	s, ok := os.LookupEnv(name)
+	if !ok {
+		return false, nil
+	}
+	err := parseEnvValue(v, s)
+	if err != nil {
+		return false, fmt.Errorf("environment variable %s: %w", name, err)
+	}
+	return true, nil
+}
+
+func envHasPrefix(prefix string) bool {
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseEnvValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		if strings.TrimSpace(s) != "" {
+			items = strings.Split(s, ",")
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			err := parseEnvValue(slice.Index(i), strings.TrimSpace(item))
+			if err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("%s settings cannot be set from the environment", v.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type KafkaEventsConfig struct {
+	RestProxyUrl  string `mapstructure:"rest_proxy_url" toml:"rest_proxy_url" validate:"required,url"`
+	TopicPrefix   string `mapstructure:"topic_prefix,omitempty" toml:"topic_prefix,omitempty"`
+	Username      string `mapstructure:"username,omitempty" toml:"username,omitempty"`
+	Password      string `mapstructure:"password,omitempty" toml:"password,omitempty"`
+	BufferSize    int    `mapstructure:"buffer_size,omitempty" toml:"buffer_size,omitempty" validate:"omitempty,min=1"`
+	BatchSize     int    `mapstructure:"batch_size,omitempty" toml:"batch_size,omitempty" validate:"omitempty,min=1"`
+	FlushInterval string `mapstructure:"flush_interval,omitempty" toml:"flush_interval,omitempty"`
+}
+
+type EventsConfig struct {
+	Type  string             `mapstructure:"type" toml:"type" validate:"required,oneof=kafka"`
+	Kafka *KafkaEventsConfig `mapstructure:"kafka,omitempty" toml:"kafka,omitempty" validate:"required_if=Type kafka"`
+}
@@ -11,10 +11,17 @@ type FixedHttpTokenConfig struct {
 }
 
 type OAuth2HttpTokenConfig struct {
-	Url                string  `mapstructure:"url" toml:"url" validate:"required"`
-	ClientId           string  `mapstructure:"client_id" toml:"client_id" validate:"required"`
-	ClientSecret       *string `mapstructure:"client_secret,omitempty" toml:"client_secret,omitempty" validate:"required_without=ClientSecretEnvVar"`
-	ClientSecretEnvVar *string `mapstructure:"client_secret_env_var,omitempty" toml:"client_secret_env_var,omitempty" validate:"required_without=ClientSecret"`
+	Url                string             `mapstructure:"url" toml:"url" validate:"required"`
+	ClientId           string             `mapstructure:"client_id" toml:"client_id" validate:"required"`
+	ClientSecret       *string            `mapstructure:"client_secret,omitempty" toml:"client_secret,omitempty" validate:"required_without_all=ClientSecretEnvVar ClientSecretSource"`
+	ClientSecretEnvVar *string            `mapstructure:"client_secret_env_var,omitempty" toml:"client_secret_env_var,omitempty" validate:"required_without_all=ClientSecret ClientSecretSource"`
+	ClientSecretSource *LocalSourceConfig `mapstructure:"client_secret_source,omitempty" toml:"client_secret_source,omitempty" validate:"required_without_all=ClientSecret ClientSecretEnvVar"`
+	RefreshBefore      string             `mapstructure:"refresh_before,omitempty" toml:"refresh_before,omitempty"`
+}
+
+type SourceHttpTokenConfig struct {
+	Source *LocalSourceConfig `mapstructure:"source" toml:"source" validate:"required"`
+	Ttl    string             `mapstructure:"ttl,omitempty" toml:"ttl,omitempty"`
 }
 
 type HubjectTestHttpTokenConfig struct {
@@ -23,9 +30,10 @@ type HubjectTestHttpTokenConfig struct {
 }
 
 type HttpAuthConfig struct {
-	Type             string                      `mapstructure:"type" toml:"type" validate:"required,oneof=env_token fixed_token oauth2_token hubject_test_token"`
+	Type             string                      `mapstructure:"type" toml:"type" validate:"required,oneof=env_token fixed_token source_token oauth2_token hubject_test_token"`
 	EnvToken         *EnvHttpTokenConfig         `mapstructure:"env_token,omitempty" toml:"env_token,omitempty" validate:"required_if=Type env_token"`
 	FixedToken       *FixedHttpTokenConfig       `mapstructure:"fixed_token,omitempty" toml:"fixed_token,omitempty" validate:"required_if=Type fixed_token"`
+	SourceToken      *SourceHttpTokenConfig      `mapstructure:"source_token,omitempty" toml:"source_token,omitempty" validate:"required_if=Type source_token"`
 	OAuth2Token      *OAuth2HttpTokenConfig      `mapstructure:"oauth2_token,omitempty" toml:"oauth2_token,omitempty" validate:"required_if=Type oauth2_token"`
 	HubjectTestToken *HubjectTestHttpTokenConfig `mapstructure:"hubject_test_token,omitempty" toml:"hubject_test_token,omitempty" validate:"required_if=Type hubject_test_token"`
 }
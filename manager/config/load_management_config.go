@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type LowCarbonLoadManagementConfig struct {
+	HourlyCarbonIntensity []float64 `mapstructure:"hourly_carbon_intensity" toml:"hourly_carbon_intensity" validate:"len=24"`
+	Timezone              string    `mapstructure:"timezone,omitempty" toml:"timezone,omitempty"`
+}
+
+type LoadManagementConfig struct {
+	Type      string                         `mapstructure:"type" toml:"type" validate:"required,oneof=asap low_carbon"`
+	LowCarbon *LowCarbonLoadManagementConfig `mapstructure:"low_carbon,omitempty" toml:"low_carbon,omitempty" validate:"required_if=Type low_carbon"`
+}
@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type OicpConfig struct {
+	Url                string          `mapstructure:"url" toml:"url" validate:"required"`
+	OperatorId         string          `mapstructure:"operator_id" toml:"operator_id" validate:"required"`
+	OperatorName       string          `mapstructure:"operator_name" toml:"operator_name" validate:"required"`
+	HotlinePhoneNumber string          `mapstructure:"hotline_phone_number" toml:"hotline_phone_number" validate:"required"`
+	ClientCertificate  string          `mapstructure:"client_certificate,omitempty" toml:"client_certificate,omitempty" validate:"required_with=ClientKey"`
+	ClientKey          string          `mapstructure:"client_key,omitempty" toml:"client_key,omitempty" validate:"required_with=ClientCertificate"`
+	HttpAuth           *HttpAuthConfig `mapstructure:"http_auth,omitempty" toml:"http_auth,omitempty"`
+}
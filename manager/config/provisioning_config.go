@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type ProvisioningStepConfig struct {
+	Type        string `mapstructure:"type" toml:"type" validate:"required,oneof=change_configuration trigger_message"`
+	Key         string `mapstructure:"key,omitempty" toml:"key,omitempty" validate:"required_if=Type change_configuration"`
+	Value       string `mapstructure:"value,omitempty" toml:"value,omitempty"`
+	Message     string `mapstructure:"message,omitempty" toml:"message,omitempty" validate:"required_if=Type trigger_message"`
+	ConnectorId *int   `mapstructure:"connector_id,omitempty" toml:"connector_id,omitempty"`
+}
+
+type ProvisioningConfig struct {
+	PendingInterval string                   `mapstructure:"pending_interval,omitempty" toml:"pending_interval,omitempty"`
+	RetryAfter      string                   `mapstructure:"retry_after,omitempty" toml:"retry_after,omitempty"`
+	Steps           []ProvisioningStepConfig `mapstructure:"steps" toml:"steps" validate:"dive"`
+}
@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type ReceiptsConfig struct {
+	// TaxRate is the percentage of tax added to the lines of a receipt whose tariff does
+	// not give a VAT
+	TaxRate float64 `mapstructure:"tax_rate,omitempty" toml:"tax_rate,omitempty" validate:"min=0,max=100"`
+	// Seller is the name shown on receipts: it defaults to the API's org_name
+	Seller string `mapstructure:"seller,omitempty" toml:"seller,omitempty"`
+	// TemplateFile is an HTML template that replaces the default receipt
+	TemplateFile string `mapstructure:"template_file,omitempty" toml:"template_file,omitempty"`
+	// Smtp is the server that emails receipts: receipts cannot be emailed without it
+	Smtp *SmtpDriverNotifierConfig `mapstructure:"smtp,omitempty" toml:"smtp,omitempty"`
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang.org/x/exp/slog"
+	"reflect"
+	"sync"
+)
+
+// Change records a setting that was changed when the configuration was reloaded.
+// The values of settings that hold credentials are not recorded.
+type Change struct {
+	Setting string `json:"setting"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+}
+
+// Reloader applies the settings that can be changed while the manager is running
+// when the configuration is reloaded, through SIGHUP or the API. Each change is
+// written to the log. The other settings only take effect when the manager is
+// restarted.
+type Reloader struct {
+	mu       sync.Mutex
+	load     func() (*BaseConfig, error)
+	current  *BaseConfig
+	settings []reloadableSetting
+}
+
+type reloadableSetting struct {
+	name   string
+	value  func(cfg *BaseConfig) any
+	apply  func(cfg *BaseConfig) (func(), error)
+	always bool // the setting is applied even when it has not changed
+	secret bool // the setting holds credentials
+}
+
+// NewReloader returns a Reloader for the configuration that the manager was started with
+func NewReloader(cfg *BaseConfig) *Reloader {
+	return &Reloader{
+		current: cfg,
+	}
+}
+
+// SetLoader sets the function that reads the configuration when it is reloaded
+func (r *Reloader) SetLoader(load func() (*BaseConfig, error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.load = load
+}
+
+// watch registers a setting that can be changed while the manager is running: apply
+// checks the new value of the setting and returns a function that puts it into effect
+func (r *Reloader) watch(setting reloadableSetting) {
+	r.settings = append(r.settings, setting)
+}
+
+// Reload reads the configuration and applies the settings that have changed. Nothing
+// is changed if the configuration is not valid or any of the new values cannot be
+// applied.
+func (r *Reloader) Reload() ([]Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.load == nil {
+		return nil, errors.New("no configuration to reload")
+	}
+	cfg, err := r.load()
+	if err != nil {
+		return nil, fmt.Errorf("loading configuration: %w", err)
+	}
+	err = cfg.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("validating configuration: %w", err)
+	}
+
+	var changes []Change
+	var commits []func()
+	for _, setting := range r.settings {
+		from, to := setting.value(r.current), setting.value(cfg)
+		changed := !reflect.DeepEqual(from, to)
+		if !changed && !setting.always {
+			continue
+		}
+		commit, err := setting.apply(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("reloading %s: %w", setting.name, err)
+		}
+		commits = append(commits, commit)
+		if changed {
+			change := Change{Setting: setting.name}
+			if !setting.secret {
+				change.From, change.To = describeSetting(from), describeSetting(to)
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	for _, commit := range commits {
+		commit()
+	}
+	for _, change := range changes {
+		slog.Info("configuration changed", slog.String("setting", change.Setting),
+			slog.String("from", change.From), slog.String("to", change.To))
+	}
+	r.current = cfg
+	return changes, nil
+}
+
+func describeSetting(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
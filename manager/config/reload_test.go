@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"context"
+	"errors"
+	clone "github.com/huandu/go-clone/generic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+	"testing"
+	"time"
+)
+
+func TestReloadAppliesChangedSettings(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	// changes made through the api are replaced
+	settings.Api.ActionFlags.SetEnabled(transport.OcppVersion201, "Heartbeat", false)
+
+	reloaded := clone.Clone(cfg)
+	reloaded.Observability.LogLevel = "debug"
+	reloaded.Ocpp.HeartbeatInterval = "10m"
+	reloaded.Ocpp.Ocpp201DisabledActions = []string{"DataTransfer"}
+	reloaded.TariffService = config.TariffServiceConfig{
+		Type: "time",
+		Time: &config.TimeTariffServiceConfig{
+			PricePerKwh: 0.5,
+		},
+	}
+	reloaded.Api.Addr = "localhost:9411"
+	settings.Api.Reloader.SetLoader(func() (*config.BaseConfig, error) {
+		return reloaded, nil
+	})
+
+	changes, err := settings.Api.Reloader.Reload()
+	require.NoError(t, err)
+
+	assert.Equal(t, []config.Change{
+		{Setting: "ocpp.ocpp201_disabled_actions", From: "null", To: `["DataTransfer"]`},
+		{Setting: "observability.log_level", From: "", To: "debug"},
+		{Setting: "ocpp.heartbeat_interval", From: "5m", To: "10m"},
+		{Setting: "tariff_service", From: `{"Type":"kwh","Time":null,"Ocpi":null,"Engine":null,"Http":null}`,
+			To: `{"Type":"time","Time":{"PricePerKwh":0.5,"PricePerChargingHour":0,"IdleFeePerMinute":0,"IdleGracePeriod":""},"Ocpi":null,"Engine":null,"Http":null}`},
+	}, changes)
+	assert.True(t, slog.Default().Enabled(context.TODO(), slog.LevelDebug))
+	assert.Equal(t, 10*time.Minute, settings.LivenessService.HeartbeatInterval.Get())
+	assert.Equal(t, services.TimeBasedTariffService{PricePerKwh: 0.5}, settings.TariffService.(*services.ReloadableTariffService).Get())
+	assert.True(t, settings.Api.ActionFlags.Enabled(transport.OcppVersion201, "Heartbeat"))
+	assert.False(t, settings.Api.ActionFlags.Enabled(transport.OcppVersion201, "DataTransfer"))
+}
+
+func TestReloadDoesNotRecordCredentials(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+
+	reloaded := clone.Clone(cfg)
+	reloaded.ContractCertProvider = config.ContractCertProviderConfig{
+		Type: "opcp",
+		Opcp: &config.OpcpContractCertProviderConfig{
+			Url: "https://opcp.example.com",
+			HttpAuth: config.HttpAuthConfig{
+				Type:       "fixed_token",
+				FixedToken: &config.FixedHttpTokenConfig{Token: "secret"},
+			},
+		},
+	}
+	settings.Api.Reloader.SetLoader(func() (*config.BaseConfig, error) {
+		return reloaded, nil
+	})
+
+	changes, err := settings.Api.Reloader.Reload()
+	require.NoError(t, err)
+
+	assert.Equal(t, []config.Change{{Setting: "contract_cert_provider"}}, changes)
+	assert.IsType(t, &services.OpcpContractCertificateProvider{},
+		settings.ContractCertProviderService.(*services.ReloadableContractCertificateProvider).Get())
+}
+
+func TestReloadRejectsInvalidSettings(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+
+	reloaded := clone.Clone(cfg)
+	reloaded.Ocpp.HeartbeatInterval = "10m"
+	reloaded.TariffService = config.TariffServiceConfig{
+		Type: "time",
+		Time: &config.TimeTariffServiceConfig{
+			IdleGracePeriod: "soon",
+		},
+	}
+	settings.Api.Reloader.SetLoader(func() (*config.BaseConfig, error) {
+		return reloaded, nil
+	})
+
+	_, err = settings.Api.Reloader.Reload()
+	assert.ErrorContains(t, err, "reloading tariff_service")
+	assert.Equal(t, 5*time.Minute, settings.LivenessService.HeartbeatInterval.Get())
+	assert.IsType(t, services.TariffEngine{}, settings.TariffService.(*services.ReloadableTariffService).Get())
+}
+
+func TestReloadReportsLoadErrors(t *testing.T) {
+	reloader := config.NewReloader(clone.Clone(&config.DefaultConfig))
+
+	_, err := reloader.Reload()
+	assert.ErrorContains(t, err, "no configuration to reload")
+
+	reloader.SetLoader(func() (*config.BaseConfig, error) {
+		return nil, errors.New("file not found")
+	})
+	_, err = reloader.Reload()
+	assert.ErrorContains(t, err, "file not found")
+}
@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type RetentionConfig struct {
+	MeterValues  string `mapstructure:"meter_values,omitempty" toml:"meter_values,omitempty"`
+	Transactions string `mapstructure:"transactions,omitempty" toml:"transactions,omitempty"`
+}
@@ -3,21 +3,141 @@
 package config
 
 type ApiSettingsConfig struct {
-	Addr    string `mapstructure:"addr" toml:"addr" validate:"required"`
-	Host    string `mapstructure:"host,omitempty" toml:"host,omitempty"`
-	WsPort  int    `mapstructure:"ws_port,omitempty" toml:"ws_port,omitempty"`
-	WssPort int    `mapstructure:"wss_port,omitempty" toml:"wss_port,omitempty"`
-	OrgName string `mapstructure:"org_name,omitempty" toml:"org_name,omitempty"`
+	Addr    string         `mapstructure:"addr" toml:"addr" validate:"required"`
+	Host    string         `mapstructure:"host,omitempty" toml:"host,omitempty"`
+	WsPort  int            `mapstructure:"ws_port,omitempty" toml:"ws_port,omitempty"`
+	WssPort int            `mapstructure:"wss_port,omitempty" toml:"wss_port,omitempty"`
+	OrgName string         `mapstructure:"org_name,omitempty" toml:"org_name,omitempty"`
+	Auth    *ApiAuthConfig `mapstructure:"auth,omitempty" toml:"auth,omitempty"`
+	// EventHistory is the number of recent events that are kept so that event stream
+	// clients can resume after reconnecting
+	EventHistory int `mapstructure:"event_history,omitempty" toml:"event_history,omitempty" validate:"omitempty,min=0"`
+}
+
+type ApiAuthConfig struct {
+	ApiKeys []ApiKeyConfig `mapstructure:"api_keys,omitempty" toml:"api_keys,omitempty" validate:"dive"`
+	Oidc    *OidcConfig    `mapstructure:"oidc,omitempty" toml:"oidc,omitempty"`
+}
+
+type ApiKeyConfig struct {
+	Name      string             `mapstructure:"name" toml:"name" validate:"required"`
+	Key       *string            `mapstructure:"key,omitempty" toml:"key,omitempty" validate:"required_without_all=KeyEnvVar KeySource"`
+	KeyEnvVar *string            `mapstructure:"key_env_var,omitempty" toml:"key_env_var,omitempty" validate:"required_without_all=Key KeySource"`
+	KeySource *LocalSourceConfig `mapstructure:"key_source,omitempty" toml:"key_source,omitempty" validate:"required_without_all=Key KeyEnvVar"`
+	Role      string             `mapstructure:"role" toml:"role" validate:"required,oneof=read-only operator admin"`
+	Tenant    string             `mapstructure:"tenant,omitempty" toml:"tenant,omitempty"`
+}
+
+type OidcConfig struct {
+	Issuer      string `mapstructure:"issuer" toml:"issuer" validate:"required,url"`
+	Audience    string `mapstructure:"audience" toml:"audience" validate:"required"`
+	JwksUrl     string `mapstructure:"jwks_url,omitempty" toml:"jwks_url,omitempty" validate:"omitempty,url"`
+	RolesClaim  string `mapstructure:"roles_claim,omitempty" toml:"roles_claim,omitempty"`
+	TenantClaim string `mapstructure:"tenant_claim,omitempty" toml:"tenant_claim,omitempty"`
 }
 
 type OcppSettingsConfig struct {
-	HeartbeatInterval string `mapstructure:"heartbeat_interval" toml:"heartbeat_interval" validate:"required"`
-	Ocpp16Enabled     bool   `mapstructure:"ocpp16_enabled" toml:"ocpp16_enabled" validate:"required_without=Ocpp201Enabled"`
-	Ocpp201Enabled    bool   `mapstructure:"ocpp201_enabled" toml:"ocpp201_enabled" validate:"required_without=Ocpp16Enabled"`
+	HeartbeatInterval            string                          `mapstructure:"heartbeat_interval" toml:"heartbeat_interval" validate:"required"`
+	OfflineAfterMissedHeartbeats int                             `mapstructure:"offline_after_missed_heartbeats,omitempty" toml:"offline_after_missed_heartbeats,omitempty" validate:"omitempty,min=1"`
+	Ocpp16Enabled                bool                            `mapstructure:"ocpp16_enabled" toml:"ocpp16_enabled" validate:"required_without=Ocpp201Enabled"`
+	Ocpp201Enabled               bool                            `mapstructure:"ocpp201_enabled" toml:"ocpp201_enabled" validate:"required_without=Ocpp16Enabled"`
+	Provisioning                 *ProvisioningConfig             `mapstructure:"provisioning,omitempty" toml:"provisioning,omitempty"`
+	Registration                 *RegistrationConfig             `mapstructure:"registration,omitempty" toml:"registration,omitempty"`
+	RateLimit                    *RateLimitConfig                `mapstructure:"rate_limit,omitempty" toml:"rate_limit,omitempty"`
+	DuplicateCalls               *DuplicateCallsConfig           `mapstructure:"duplicate_calls,omitempty" toml:"duplicate_calls,omitempty"`
+	PoisonMessages               *PoisonMessagesConfig           `mapstructure:"poison_messages,omitempty" toml:"poison_messages,omitempty"`
+	OutboundCalls                *OutboundCallsConfig            `mapstructure:"outbound_calls,omitempty" toml:"outbound_calls,omitempty"`
+	CertificateRenewal           *CertificateRenewalConfig       `mapstructure:"certificate_renewal,omitempty" toml:"certificate_renewal,omitempty"`
+	ReservationNoShow            *ReservationNoShowConfig        `mapstructure:"reservation_no_show,omitempty" toml:"reservation_no_show,omitempty"`
+	ReservationNotifications     *ReservationNotificationsConfig `mapstructure:"reservation_notifications,omitempty" toml:"reservation_notifications,omitempty"`
+	Ocpp16DisabledActions        []string                        `mapstructure:"ocpp16_disabled_actions,omitempty" toml:"ocpp16_disabled_actions,omitempty"`
+	Ocpp201DisabledActions       []string                        `mapstructure:"ocpp201_disabled_actions,omitempty" toml:"ocpp201_disabled_actions,omitempty"`
+	Ocpp16SoapTranslation        bool                            `mapstructure:"ocpp16_soap_translation,omitempty" toml:"ocpp16_soap_translation,omitempty"`
+	Ocpp21Enabled                bool                            `mapstructure:"ocpp21_enabled,omitempty" toml:"ocpp21_enabled,omitempty"`
+	Ocpp21DisabledActions        []string                        `mapstructure:"ocpp21_disabled_actions,omitempty" toml:"ocpp21_disabled_actions,omitempty"`
+}
+
+type RegistrationConfig struct {
+	DefaultStatus string `mapstructure:"default_status,omitempty" toml:"default_status,omitempty" validate:"omitempty,oneof=Accepted Pending Rejected"`
+	RetryInterval string `mapstructure:"retry_interval,omitempty" toml:"retry_interval,omitempty"`
+}
+
+type RateLimitActionConfig struct {
+	PerMinute float64 `mapstructure:"per_minute" toml:"per_minute" validate:"gt=0"`
+	Burst     int     `mapstructure:"burst,omitempty" toml:"burst,omitempty" validate:"omitempty,min=1"`
+}
+
+type RateLimitConfig struct {
+	MaxWait string                           `mapstructure:"max_wait,omitempty" toml:"max_wait,omitempty"`
+	Actions map[string]RateLimitActionConfig `mapstructure:"actions" toml:"actions" validate:"required,dive"`
+}
+
+type DuplicateCallsConfig struct {
+	Disabled  bool   `mapstructure:"disabled,omitempty" toml:"disabled,omitempty"`
+	CacheSize int    `mapstructure:"cache_size,omitempty" toml:"cache_size,omitempty" validate:"omitempty,min=1"`
+	Ttl       string `mapstructure:"ttl,omitempty" toml:"ttl,omitempty"`
+}
+
+type PoisonMessagesConfig struct {
+	Disabled    bool   `mapstructure:"disabled,omitempty" toml:"disabled,omitempty"`
+	MaxFailures int    `mapstructure:"max_failures,omitempty" toml:"max_failures,omitempty" validate:"omitempty,min=1"`
+	CacheSize   int    `mapstructure:"cache_size,omitempty" toml:"cache_size,omitempty" validate:"omitempty,min=1"`
+	Ttl         string `mapstructure:"ttl,omitempty" toml:"ttl,omitempty"`
+}
+
+type OutboundCallsConfig struct {
+	Disabled bool   `mapstructure:"disabled,omitempty" toml:"disabled,omitempty"`
+	Timeout  string `mapstructure:"timeout,omitempty" toml:"timeout,omitempty"`
+}
+
+type CertificateRenewalConfig struct {
+	Disabled    bool   `mapstructure:"disabled,omitempty" toml:"disabled,omitempty"`
+	RenewBefore string `mapstructure:"renew_before,omitempty" toml:"renew_before,omitempty"`
+	AlertBefore string `mapstructure:"alert_before,omitempty" toml:"alert_before,omitempty"`
+}
+
+type ReservationNoShowConfig struct {
+	Disabled    bool   `mapstructure:"disabled,omitempty" toml:"disabled,omitempty"`
+	GracePeriod string `mapstructure:"grace_period,omitempty" toml:"grace_period,omitempty"`
+	Fee         bool   `mapstructure:"fee,omitempty" toml:"fee,omitempty"`
+}
+
+type SmtpDriverNotifierConfig struct {
+	Addr     string `mapstructure:"addr" toml:"addr" validate:"required,hostname_port"`
+	From     string `mapstructure:"from" toml:"from" validate:"required,email"`
+	Username string `mapstructure:"username,omitempty" toml:"username,omitempty"`
+	Password string `mapstructure:"password,omitempty" toml:"password,omitempty"`
+}
+
+type SmsDriverNotifierConfig struct {
+	Url  string          `mapstructure:"url" toml:"url" validate:"required,url"`
+	From string          `mapstructure:"from,omitempty" toml:"from,omitempty"`
+	Auth *HttpAuthConfig `mapstructure:"auth,omitempty" toml:"auth,omitempty"`
+}
+
+type WebhookDriverNotifierConfig struct {
+	Url  string          `mapstructure:"url" toml:"url" validate:"required,url"`
+	Auth *HttpAuthConfig `mapstructure:"auth,omitempty" toml:"auth,omitempty"`
+}
+
+type DriverNotifierConfig struct {
+	// Tenant is the tenant whose reservations the notifier is used for: a notifier
+	// without a tenant is used for the tenants that do not have their own notifiers
+	Tenant  string                       `mapstructure:"tenant,omitempty" toml:"tenant,omitempty"`
+	Type    string                       `mapstructure:"type" toml:"type" validate:"required,oneof=smtp sms webhook"`
+	Smtp    *SmtpDriverNotifierConfig    `mapstructure:"smtp,omitempty" toml:"smtp,omitempty" validate:"required_if=Type smtp"`
+	Sms     *SmsDriverNotifierConfig     `mapstructure:"sms,omitempty" toml:"sms,omitempty" validate:"required_if=Type sms"`
+	Webhook *WebhookDriverNotifierConfig `mapstructure:"webhook,omitempty" toml:"webhook,omitempty" validate:"required_if=Type webhook"`
+}
+
+type ReservationNotificationsConfig struct {
+	ReminderBefore string                 `mapstructure:"reminder_before,omitempty" toml:"reminder_before,omitempty"`
+	Notifiers      []DriverNotifierConfig `mapstructure:"notifiers" toml:"notifiers" validate:"dive"`
 }
 
 type ObservabilitySettingsConfig struct {
 	LogFormat         string `mapstructure:"log_format" toml:"log_format" validate:"required"`
+	LogLevel          string `mapstructure:"log_level,omitempty" toml:"log_level,omitempty" validate:"omitempty,oneof=debug info warn error"`
 	OtelCollectorAddr string `mapstructure:"otel_collector_addr" toml:"otel_collector_addr"`
 	TlsKeylogFile     string `mapstructure:"tls_keylog_file" toml:"tls_keylog_file"`
 }
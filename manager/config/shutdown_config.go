@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type ShutdownConfig struct {
+	Timeout string `mapstructure:"timeout,omitempty" toml:"timeout,omitempty"`
+}
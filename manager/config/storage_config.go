@@ -8,8 +8,46 @@ type FirestoreStorageConfig struct {
 	ProjectId string `mapstructure:"project_id" toml:"project_id" validate:"required"`
 }
 
+type SqliteStorageConfig struct {
+	Path string `mapstructure:"path" toml:"path" validate:"required"`
+}
+
+type DynamodbStorageConfig struct {
+	Table    string `mapstructure:"table" toml:"table" validate:"required"`
+	Endpoint string `mapstructure:"endpoint" toml:"endpoint"`
+}
+
+type RedisStorageConfig struct {
+	Addr     string `mapstructure:"addr" toml:"addr" validate:"required"`
+	Password string `mapstructure:"password" toml:"password"`
+	Db       int    `mapstructure:"db" toml:"db"`
+	Ttl      string `mapstructure:"ttl" toml:"ttl"`
+}
+
+type StorageRetryConfig struct {
+	MaxAttempts    int    `mapstructure:"max_attempts" toml:"max_attempts"`
+	InitialBackoff string `mapstructure:"initial_backoff" toml:"initial_backoff"`
+	MaxBackoff     string `mapstructure:"max_backoff" toml:"max_backoff"`
+	UnhealthyAfter int    `mapstructure:"unhealthy_after" toml:"unhealthy_after"`
+}
+
+type TokenCacheConfig struct {
+	Size int    `mapstructure:"size" toml:"size"`
+	Ttl  string `mapstructure:"ttl" toml:"ttl"`
+}
+
+// StorageConfig selects the storage engine. The settings of the built-in engines can be
+// given in their own sections or in Options, so the engines' factories, rather than the
+// sections, check that the settings they require are present.
 type StorageConfig struct {
-	Type             string                  `mapstructure:"type" toml:"type" validate:"required,oneof=firestore in_memory"`
-	FirestoreStorage *FirestoreStorageConfig `mapstructure:"firestore,omitempty" toml:"firestore,omitempty" validate:"required_if=Type firestore"`
+	Type             string                  `mapstructure:"type" toml:"type" validate:"required"`
+	FirestoreStorage *FirestoreStorageConfig `mapstructure:"firestore,omitempty" toml:"firestore,omitempty"`
 	InMemoryStorage  *InMemoryStorageConfig  `mapstructure:"in_memory,omitempty" toml:"in_memory,omitempty"`
+	SqliteStorage    *SqliteStorageConfig    `mapstructure:"sqlite,omitempty" toml:"sqlite,omitempty"`
+	DynamodbStorage  *DynamodbStorageConfig  `mapstructure:"dynamodb,omitempty" toml:"dynamodb,omitempty"`
+	Redis            *RedisStorageConfig     `mapstructure:"redis,omitempty" toml:"redis,omitempty"`
+	Retry            *StorageRetryConfig     `mapstructure:"retry,omitempty" toml:"retry,omitempty"`
+	TokenCache       *TokenCacheConfig       `mapstructure:"token_cache,omitempty" toml:"token_cache,omitempty"`
+	EventLog         bool                    `mapstructure:"event_log,omitempty" toml:"event_log,omitempty"`
+	Options          map[string]any          `mapstructure:"options,omitempty" toml:"options,omitempty"`
 }
@@ -2,6 +2,43 @@
 
 package config
 
+type TimeTariffServiceConfig struct {
+	PricePerKwh          float64 `mapstructure:"price_per_kwh" toml:"price_per_kwh"`
+	PricePerChargingHour float64 `mapstructure:"price_per_charging_hour" toml:"price_per_charging_hour"`
+	IdleFeePerMinute     float64 `mapstructure:"idle_fee_per_minute" toml:"idle_fee_per_minute"`
+	IdleGracePeriod      string  `mapstructure:"idle_grace_period" toml:"idle_grace_period"`
+}
+
+type OcpiTariffServiceConfig struct {
+	TariffId string `mapstructure:"tariff_id" toml:"tariff_id" validate:"required"`
+	Timezone string `mapstructure:"timezone,omitempty" toml:"timezone,omitempty"`
+}
+
+type EngineTariffServiceConfig struct {
+	DefaultTariffId string  `mapstructure:"default_tariff_id,omitempty" toml:"default_tariff_id,omitempty"`
+	Currency        string  `mapstructure:"currency,omitempty" toml:"currency,omitempty"`
+	SessionFee      float64 `mapstructure:"session_fee" toml:"session_fee"`
+	PricePerKwh     float64 `mapstructure:"price_per_kwh" toml:"price_per_kwh"`
+	PricePerMinute  float64 `mapstructure:"price_per_minute" toml:"price_per_minute"`
+	Timezone        string  `mapstructure:"timezone,omitempty" toml:"timezone,omitempty"`
+}
+
+type HttpTariffServiceConfig struct {
+	Url string `mapstructure:"url" toml:"url" validate:"required"`
+	// Auth is left out of the changes reported when the configuration is reloaded as it
+	// may hold credentials
+	Auth             *HttpAuthConfig `mapstructure:"auth,omitempty" toml:"auth,omitempty" json:"-"`
+	Timeout          string          `mapstructure:"timeout,omitempty" toml:"timeout,omitempty"`
+	FailureThreshold int             `mapstructure:"failure_threshold,omitempty" toml:"failure_threshold,omitempty" validate:"min=0"`
+	OpenFor          string          `mapstructure:"open_for,omitempty" toml:"open_for,omitempty"`
+	FallbackTariffId string          `mapstructure:"fallback_tariff_id,omitempty" toml:"fallback_tariff_id,omitempty"`
+	Timezone         string          `mapstructure:"timezone,omitempty" toml:"timezone,omitempty"`
+}
+
 type TariffServiceConfig struct {
-	Type string `mapstructure:"type" toml:"type" validate:"required,oneof=kwh"`
+	Type   string                     `mapstructure:"type" toml:"type" validate:"required,oneof=kwh time ocpi engine http"`
+	Time   *TimeTariffServiceConfig   `mapstructure:"time,omitempty" toml:"time,omitempty" validate:"required_if=Type time"`
+	Ocpi   *OcpiTariffServiceConfig   `mapstructure:"ocpi,omitempty" toml:"ocpi,omitempty" validate:"required_if=Type ocpi"`
+	Engine *EngineTariffServiceConfig `mapstructure:"engine,omitempty" toml:"engine,omitempty" validate:"required_if=Type engine"`
+	Http   *HttpTariffServiceConfig   `mapstructure:"http,omitempty" toml:"http,omitempty" validate:"required_if=Type http"`
 }
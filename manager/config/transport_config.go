@@ -2,16 +2,62 @@
 
 package config
 
+type MqttTlsConfig struct {
+	CaCertificate     string             `mapstructure:"ca_certificate,omitempty" toml:"ca_certificate,omitempty"`
+	ClientCertificate string             `mapstructure:"client_certificate,omitempty" toml:"client_certificate,omitempty" validate:"required_with=ClientKey ClientKeySource"`
+	ClientKey         string             `mapstructure:"client_key,omitempty" toml:"client_key,omitempty" validate:"excluded_with=ClientKeySource"`
+	ClientKeySource   *LocalSourceConfig `mapstructure:"client_key_source,omitempty" toml:"client_key_source,omitempty"`
+	Alpn              []string           `mapstructure:"alpn,omitempty" toml:"alpn,omitempty"`
+	MinVersion        string             `mapstructure:"min_version,omitempty" toml:"min_version,omitempty" validate:"omitempty,oneof=1.2 1.3"`
+}
+
 type MqttSettingsConfig struct {
-	Urls              []string `mapstructure:"urls" toml:"urls" validate:"required,dive,required"`
-	Prefix            string   `mapstructure:"prefix" toml:"prefix" validate:"required"`
-	Group             string   `mapstructure:"group" toml:"group" validate:"required"`
-	ConnectTimeout    string   `mapstructure:"connect_timeout" toml:"connect_timeout" validate:"required"`
-	ConnectRetryDelay string   `mapstructure:"connect_retry_delay" toml:"connect_retry_delay" validate:"required"`
-	KeepAliveInterval string   `mapstructure:"keep_alive_interval" toml:"keep_alive_interval" validate:"required"`
+	Urls                 []string           `mapstructure:"urls" toml:"urls" validate:"required,dive,required"`
+	Prefix               string             `mapstructure:"prefix" toml:"prefix" validate:"required"`
+	Group                string             `mapstructure:"group" toml:"group" validate:"required"`
+	ConnectTimeout       string             `mapstructure:"connect_timeout" toml:"connect_timeout" validate:"required"`
+	ConnectRetryDelay    string             `mapstructure:"connect_retry_delay" toml:"connect_retry_delay" validate:"required"`
+	MaxConnectRetryDelay string             `mapstructure:"max_connect_retry_delay,omitempty" toml:"max_connect_retry_delay,omitempty"`
+	KeepAliveInterval    string             `mapstructure:"keep_alive_interval" toml:"keep_alive_interval" validate:"required"`
+	Username             string             `mapstructure:"username,omitempty" toml:"username,omitempty"`
+	Password             string             `mapstructure:"password,omitempty" toml:"password,omitempty" validate:"excluded_with=PasswordSource"`
+	PasswordSource       *LocalSourceConfig `mapstructure:"password_source,omitempty" toml:"password_source,omitempty"`
+	Tls                  *MqttTlsConfig     `mapstructure:"tls,omitempty" toml:"tls,omitempty"`
+	DeadLetterTopic      string             `mapstructure:"dead_letter_topic,omitempty" toml:"dead_letter_topic,omitempty"`
+	Workers              int                `mapstructure:"workers,omitempty" toml:"workers,omitempty" validate:"omitempty,min=1"`
+	WorkerQueueSize      int                `mapstructure:"worker_queue_size,omitempty" toml:"worker_queue_size,omitempty" validate:"omitempty,min=1"`
+}
+
+type NatsSettingsConfig struct {
+	Urls            []string `mapstructure:"urls,omitempty" toml:"urls,omitempty" validate:"dive,required"`
+	Prefix          string   `mapstructure:"prefix,omitempty" toml:"prefix,omitempty"`
+	Group           string   `mapstructure:"group,omitempty" toml:"group,omitempty"`
+	Stream          string   `mapstructure:"stream,omitempty" toml:"stream,omitempty"`
+	ConnectTimeout  string   `mapstructure:"connect_timeout,omitempty" toml:"connect_timeout,omitempty"`
+	ReconnectWait   string   `mapstructure:"reconnect_wait,omitempty" toml:"reconnect_wait,omitempty"`
+	Username        string   `mapstructure:"username,omitempty" toml:"username,omitempty"`
+	Password        string   `mapstructure:"password,omitempty" toml:"password,omitempty"`
+	Workers         int      `mapstructure:"workers,omitempty" toml:"workers,omitempty" validate:"omitempty,min=1"`
+	WorkerQueueSize int      `mapstructure:"worker_queue_size,omitempty" toml:"worker_queue_size,omitempty" validate:"omitempty,min=1"`
+}
+
+type WebsocketTlsConfig struct {
+	ServerCertificate string   `mapstructure:"server_certificate" toml:"server_certificate" validate:"required"`
+	ServerKey         string   `mapstructure:"server_key" toml:"server_key" validate:"required"`
+	TrustCertificates []string `mapstructure:"trust_certificates,omitempty" toml:"trust_certificates,omitempty" validate:"dive,required"`
+}
+
+type WebsocketSettingsConfig struct {
+	Addr        string              `mapstructure:"addr,omitempty" toml:"addr,omitempty"`
+	TlsAddr     string              `mapstructure:"tls_addr,omitempty" toml:"tls_addr,omitempty"`
+	Tls         *WebsocketTlsConfig `mapstructure:"tls,omitempty" toml:"tls,omitempty" validate:"required_with=TlsAddr"`
+	OrgNames    []string            `mapstructure:"org_names,omitempty" toml:"org_names,omitempty" validate:"dive,required"`
+	CallTimeout string              `mapstructure:"call_timeout,omitempty" toml:"call_timeout,omitempty"`
 }
 
 type TransportConfig struct {
-	Type string              `mapstructure:"type" toml:"type" validate:"required,oneof=mqtt"`
-	Mqtt *MqttSettingsConfig `mapstructure:"mqtt,omitempty" toml:"mqtt,omitempty" validate:"required_if=Type mqtt"`
+	Type      string                   `mapstructure:"type" toml:"type" validate:"required,oneof=mqtt nats websocket"`
+	Mqtt      *MqttSettingsConfig      `mapstructure:"mqtt,omitempty" toml:"mqtt,omitempty" validate:"required_if=Type mqtt"`
+	Nats      *NatsSettingsConfig      `mapstructure:"nats,omitempty" toml:"nats,omitempty" validate:"required_if=Type nats"`
+	Websocket *WebsocketSettingsConfig `mapstructure:"websocket,omitempty" toml:"websocket,omitempty" validate:"required_if=Type websocket"`
 }
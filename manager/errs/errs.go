@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package errs defines the kinds of failure that the manager reports in the same way
+// wherever they occur. A charge station is sent the OCPP CallError code for the kind
+// (see handlers.ErrorCode) and an API client the HTTP status (see api.ErrFromError),
+// so a store or service only has to say what went wrong, not how to report it.
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is the kind of error returned when a record that must exist does not
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is the kind of error returned when a write conflicts with the current
+	// state of a record, e.g. because it was changed by another request
+	ErrConflict = errors.New("conflict")
+	// ErrUnauthorized is the kind of error returned when the caller is not allowed to
+	// perform an operation
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrDependencyUnavailable is the kind of error returned when a service that the
+	// manager depends on fails or cannot be reached
+	ErrDependencyUnavailable = errors.New("dependency unavailable")
+)
+
+// kindError is an error of a kind: it matches both the kind and the error that it
+// describes
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// New returns an error of the kind with a message formatted as fmt.Errorf does: any
+// %w verb wraps its argument so that the returned error matches it as well as the kind.
+func New(kind error, format string, args ...any) error {
+	return &kindError{kind: kind, err: fmt.Errorf(format, args...)}
+}
+
+// Wrap returns an error of the kind that wraps err, keeping its message. It returns
+// nil if err is nil.
+func Wrap(kind error, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"io"
+	"testing"
+)
+
+func TestNewMatchesKind(t *testing.T) {
+	err := errs.New(errs.ErrNotFound, "transaction %s/%s not found", "cs001", "tx001")
+
+	assert.EqualError(t, err, "transaction cs001/tx001 not found")
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.NotErrorIs(t, err, errs.ErrConflict)
+}
+
+func TestNewMatchesWrappedError(t *testing.T) {
+	err := fmt.Errorf("ending transaction: %w", errs.New(errs.ErrDependencyUnavailable, "reading response: %w", io.ErrUnexpectedEOF))
+
+	assert.EqualError(t, err, "ending transaction: reading response: unexpected EOF")
+	assert.ErrorIs(t, err, errs.ErrDependencyUnavailable)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestWrap(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := errs.Wrap(errs.ErrDependencyUnavailable, cause)
+
+	assert.EqualError(t, err, "connection refused")
+	assert.ErrorIs(t, err, errs.ErrDependencyUnavailable)
+	assert.ErrorIs(t, err, cause)
+	assert.NoError(t, errs.Wrap(errs.ErrDependencyUnavailable, nil))
+}
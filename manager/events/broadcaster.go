@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"sync"
+)
+
+// subscriptionBufferSize is the number of events that are held for a subscriber that
+// has not yet received them: a subscriber that falls further behind is disconnected
+const subscriptionBufferSize = 100
+
+var eventSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "manager_event_subscribers",
+	Help: "The number of clients that are subscribed to the event stream",
+})
+
+// Publishers is a Publisher that publishes each event to all of the publishers
+type Publishers []Publisher
+
+func (p Publishers) Publish(ctx context.Context, event *Event) error {
+	var errs []error
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Broadcaster is a Publisher that streams the events published by this manager
+// instance to its subscribers, e.g. the clients of the API's event stream. The most
+// recent events are kept so that a subscriber that reconnects can resume from the
+// last event that it received.
+//
+// Publish never waits for a subscriber: a subscriber that does not keep up with the
+// events is disconnected and is expected to resubscribe from its last event.
+type Broadcaster struct {
+	mu          sync.Mutex
+	history     []*Event
+	historySize int
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// Subscription receives the events published after it was created that match its
+// filter
+type Subscription struct {
+	broadcaster *Broadcaster
+	filter      func(*Event) bool
+	eventCh     chan *Event
+}
+
+func NewBroadcaster(historySize int) *Broadcaster {
+	return &Broadcaster{
+		historySize: historySize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+func (b *Broadcaster) Publish(_ context.Context, event *Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+
+	if b.historySize > 0 {
+		if len(b.history) == b.historySize {
+			copy(b.history, b.history[1:])
+			b.history = b.history[:len(b.history)-1]
+		}
+		b.history = append(b.history, event)
+	}
+
+	for subscription := range b.subscribers {
+		if subscription.filter != nil && !subscription.filter(event) {
+			continue
+		}
+		select {
+		case subscription.eventCh <- event:
+		default:
+			b.unsubscribe(subscription)
+			eventsDropped.WithLabelValues("slow_subscriber").Inc()
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns a subscription to the events that match the filter, which may be
+// nil to receive every event. If lastEventId is not empty, the events that follow it
+// are also returned so that the subscriber can resume: if the event is no longer
+// held, all the events that are held are returned.
+func (b *Broadcaster) Subscribe(lastEventId string, filter func(*Event) bool) (*Subscription, []*Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, nil, ErrClosed
+	}
+
+	var missed []*Event
+	if lastEventId != "" {
+		start := 0
+		for i, event := range b.history {
+			if event.Id == lastEventId {
+				start = i + 1
+				break
+			}
+		}
+		for _, event := range b.history[start:] {
+			if filter == nil || filter(event) {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	subscription := &Subscription{
+		broadcaster: b,
+		filter:      filter,
+		eventCh:     make(chan *Event, subscriptionBufferSize),
+	}
+	b.subscribers[subscription] = struct{}{}
+	eventSubscribers.Inc()
+
+	return subscription, missed, nil
+}
+
+// Close ends all the subscriptions: events that are published after the broadcaster
+// is closed are discarded and new subscriptions are rejected with ErrClosed
+func (b *Broadcaster) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for subscription := range b.subscribers {
+		b.unsubscribe(subscription)
+	}
+	return nil
+}
+
+func (b *Broadcaster) unsubscribe(subscription *Subscription) {
+	if _, ok := b.subscribers[subscription]; ok {
+		delete(b.subscribers, subscription)
+		close(subscription.eventCh)
+		eventSubscribers.Dec()
+	}
+}
+
+// Events returns the channel that the events are delivered on: it is closed when the
+// subscription ends
+func (s *Subscription) Events() <-chan *Event {
+	return s.eventCh
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() {
+	s.broadcaster.mu.Lock()
+	defer s.broadcaster.mu.Unlock()
+	s.broadcaster.unsubscribe(s)
+}
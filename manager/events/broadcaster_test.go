@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/events"
+	"testing"
+)
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(context.Context, *events.Event) error {
+	return errors.New("failed")
+}
+
+func publishedEvent(id string, eventType events.Type) *events.Event {
+	return &events.Event{Id: id, Type: eventType, ChargeStationId: "cs001"}
+}
+
+func TestBroadcasterDeliversMatchingEvents(t *testing.T) {
+	ctx := context.Background()
+	b := events.NewBroadcaster(10)
+
+	all, _, err := b.Subscribe("", nil)
+	require.NoError(t, err)
+	transactions, _, err := b.Subscribe("", func(event *events.Event) bool {
+		return event.Category() == "transaction"
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, b.Publish(ctx, publishedEvent("1", events.TypeConnectorStatusChanged)))
+	require.NoError(t, b.Publish(ctx, publishedEvent("2", events.TypeTransactionStarted)))
+
+	assert.Equal(t, "1", (<-all.Events()).Id)
+	assert.Equal(t, "2", (<-all.Events()).Id)
+	assert.Equal(t, "2", (<-transactions.Events()).Id)
+
+	all.Close()
+	_, ok := <-all.Events()
+	assert.False(t, ok)
+}
+
+func TestBroadcasterReplaysEventsAfterTheLastEventId(t *testing.T) {
+	ctx := context.Background()
+	b := events.NewBroadcaster(3)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		require.NoError(t, b.Publish(ctx, publishedEvent(id, events.TypeTransactionStarted)))
+	}
+
+	replayed := func(lastEventId string) []string {
+		subscription, missed, err := b.Subscribe(lastEventId, nil)
+		require.NoError(t, err)
+		defer subscription.Close()
+		var ids []string
+		for _, event := range missed {
+			ids = append(ids, event.Id)
+		}
+		return ids
+	}
+
+	assert.Empty(t, replayed(""))
+	assert.Equal(t, []string{"4"}, replayed("3"))
+	assert.Empty(t, replayed("4"))
+	// the first event is no longer held, so every event that is held is replayed
+	assert.Equal(t, []string{"2", "3", "4"}, replayed("1"))
+}
+
+func TestBroadcasterDisconnectsSlowSubscribers(t *testing.T) {
+	ctx := context.Background()
+	b := events.NewBroadcaster(0)
+	subscription, _, err := b.Subscribe("", nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 101; i++ {
+		require.NoError(t, b.Publish(ctx, publishedEvent("", events.TypeTransactionStarted)))
+	}
+
+	received := 0
+	for range subscription.Events() {
+		received++
+	}
+	assert.Equal(t, 100, received)
+}
+
+func TestBroadcasterCloseEndsSubscriptions(t *testing.T) {
+	b := events.NewBroadcaster(10)
+	subscription, _, err := b.Subscribe("", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, b.Close())
+	_, ok := <-subscription.Events()
+	assert.False(t, ok)
+	subscription.Close()
+
+	_, _, err = b.Subscribe("", nil)
+	assert.ErrorIs(t, err, events.ErrClosed)
+	assert.NoError(t, b.Publish(context.Background(), publishedEvent("1", events.TypeTransactionStarted)))
+}
+
+func TestPublishersPublishToEachPublisher(t *testing.T) {
+	first := &recordingPublisher{}
+	second := &recordingPublisher{}
+	publishers := events.Publishers{first, failingPublisher{}, second}
+
+	err := publishers.Publish(context.Background(), publishedEvent("1", events.TypeTransactionStarted))
+	assert.Error(t, err)
+	assert.Len(t, first.events, 1)
+	assert.Len(t, second.events, 1)
+}
@@ -0,0 +1,6 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events mirrors the activity of the CSMS (transactions, reservations,
+// connector statuses and security events) to downstream consumers, such as analytics
+// and billing pipelines, as events with a stable JSON schema.
+package events
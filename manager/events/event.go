@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is included in each event: it is incremented when a change is made
+// to the schema that existing consumers cannot accept. Adding fields does not change
+// the version.
+const SchemaVersion = 1
+
+type Type string
+
+var (
+	TypeTransactionStarted       Type = "transaction.started"
+	TypeTransactionEnded         Type = "transaction.ended"
+	TypeReservationCreated       Type = "reservation.created"
+	TypeReservationStatusChanged Type = "reservation.status_changed"
+	TypeConnectorStatusChanged   Type = "connector.status_changed"
+	TypeSecurityEventReported    Type = "security.event_reported"
+)
+
+// Event is the envelope that is published for each change: Data holds one of the
+// *Data types below, depending on the Type.
+type Event struct {
+	SchemaVersion   int       `json:"schemaVersion"`
+	Id              string    `json:"id"`
+	Type            Type      `json:"type"`
+	OccurredAt      time.Time `json:"occurredAt"`
+	ChargeStationId string    `json:"chargeStationId"`
+	Data            any       `json:"data"`
+}
+
+// Category returns the first part of the event type, e.g. "transaction", which
+// groups the events that are published together
+func (e *Event) Category() string {
+	category, _, _ := strings.Cut(string(e.Type), ".")
+	return category
+}
+
+func newEvent(eventType Type, occurredAt time.Time, chargeStationId string, data any) *Event {
+	return &Event{
+		SchemaVersion:   SchemaVersion,
+		Id:              uuid.NewString(),
+		Type:            eventType,
+		OccurredAt:      occurredAt.UTC(),
+		ChargeStationId: chargeStationId,
+		Data:            data,
+	}
+}
+
+// Publisher sends events to downstream consumers. Publishing is best effort: an
+// error is logged by the caller and does not affect the change that was made.
+type Publisher interface {
+	Publish(ctx context.Context, event *Event) error
+}
+
+type TransactionData struct {
+	TransactionId string           `json:"transactionId"`
+	IdToken       string           `json:"idToken,omitempty"`
+	TokenType     string           `json:"tokenType,omitempty"`
+	SeqNo         int              `json:"seqNo"`
+	Offline       bool             `json:"offline"`
+	MeterValues   []MeterValueData `json:"meterValues,omitempty"`
+}
+
+type MeterValueData struct {
+	Timestamp     string             `json:"timestamp"`
+	SampledValues []SampledValueData `json:"sampledValues"`
+}
+
+type SampledValueData struct {
+	Measurand  *string `json:"measurand,omitempty"`
+	Context    *string `json:"context,omitempty"`
+	Location   *string `json:"location,omitempty"`
+	Phase      *string `json:"phase,omitempty"`
+	Unit       *string `json:"unit,omitempty"`
+	Multiplier int     `json:"multiplier,omitempty"`
+	Value      float64 `json:"value"`
+}
+
+type ReservationData struct {
+	ReservationId  int       `json:"reservationId"`
+	EvseId         *int      `json:"evseId,omitempty"`
+	IdToken        string    `json:"idToken"`
+	TokenType      string    `json:"tokenType"`
+	ExpiryDate     time.Time `json:"expiryDate"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previousStatus,omitempty"`
+	NoShow         bool      `json:"noShow,omitempty"`
+}
+
+type ConnectorStatusData struct {
+	EvseId      int    `json:"evseId"`
+	ConnectorId int    `json:"connectorId"`
+	Status      string `json:"status"`
+}
+
+type SecurityEventData struct {
+	EventType string  `json:"eventType"`
+	Timestamp string  `json:"timestamp"`
+	TechInfo  *string `json:"techInfo,omitempty"`
+}
+
+func meterValueData(meterValues []store.MeterValue) []MeterValueData {
+	var data []MeterValueData
+	for _, meterValue := range meterValues {
+		sampledValues := make([]SampledValueData, len(meterValue.SampledValues))
+		for i, sampledValue := range meterValue.SampledValues {
+			sampledValues[i] = SampledValueData{
+				Measurand: sampledValue.Measurand,
+				Context:   sampledValue.Context,
+				Location:  sampledValue.Location,
+				Phase:     sampledValue.Phase,
+				Value:     sampledValue.Value,
+			}
+			if sampledValue.UnitOfMeasure != nil {
+				unit := sampledValue.UnitOfMeasure.Unit
+				sampledValues[i].Unit = &unit
+				sampledValues[i].Multiplier = sampledValue.UnitOfMeasure.Multipler
+			}
+		}
+		data = append(data, MeterValueData{
+			Timestamp:     meterValue.Timestamp,
+			SampledValues: sampledValues,
+		})
+	}
+	return data
+}
+
+func reservationData(reservation *store.Reservation, previousStatus store.ReservationStatus) ReservationData {
+	return ReservationData{
+		ReservationId:  reservation.ReservationId,
+		EvseId:         reservation.EvseId,
+		IdToken:        reservation.IdToken,
+		TokenType:      reservation.TokenType,
+		ExpiryDate:     reservation.ExpiryDate.UTC(),
+		Status:         string(reservation.Status),
+		PreviousStatus: string(previousStatus),
+		NoShow:         reservation.NoShow,
+	}
+}
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/exp/slog"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBufferFull is returned by KafkaPublisher.Publish when events are being published
+// more quickly than they can be sent: the event is dropped
+var ErrBufferFull = errors.New("event buffer is full")
+
+// ErrClosed is returned by KafkaPublisher.Publish once the publisher has been closed
+var ErrClosed = errors.New("event publisher is closed")
+
+var (
+	eventsPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_events_published_total",
+		Help: "The number of events sent to downstream consumers, by topic",
+	}, []string{"topic"})
+	eventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_events_dropped_total",
+		Help: "The number of events that could not be sent to downstream consumers, by reason",
+	}, []string{"reason"})
+)
+
+const kafkaJsonContentType = "application/vnd.kafka.json.v2+json"
+
+// KafkaPublisher publishes events to Kafka through a Kafka REST Proxy
+// (https://docs.confluent.io/platform/current/kafka-rest/). Each category of event
+// is published to its own topic, <topic-prefix>.<category>, e.g. csms.transaction,
+// keyed by the charge station id so that the events for a charge station are kept
+// in order.
+//
+// Publish does not wait for the event to be sent: events are buffered and sent in
+// batches by a background goroutine, so that a slow proxy does not delay the
+// charge stations. Events are dropped when the buffer is full.
+type KafkaPublisher struct {
+	url           string
+	topicPrefix   string
+	client        *http.Client
+	username      string
+	password      string
+	bufferSize    int
+	batchSize     int
+	flushInterval time.Duration
+	eventCh       chan *Event
+	doneCh        chan struct{}
+	mu            sync.RWMutex
+	closed        bool
+}
+
+type KafkaOpt func(p *KafkaPublisher)
+
+func WithTopicPrefix(topicPrefix string) KafkaOpt {
+	return func(p *KafkaPublisher) {
+		p.topicPrefix = topicPrefix
+	}
+}
+
+func WithHttpClient(client *http.Client) KafkaOpt {
+	return func(p *KafkaPublisher) {
+		p.client = client
+	}
+}
+
+// WithBasicAuth sets the credentials presented to the REST proxy
+func WithBasicAuth(username, password string) KafkaOpt {
+	return func(p *KafkaPublisher) {
+		p.username = username
+		p.password = password
+	}
+}
+
+// WithBuffer sets the number of events that can wait to be sent, the maximum number
+// of events sent in a request and how long an event waits for a batch to fill
+func WithBuffer(bufferSize, batchSize int, flushInterval time.Duration) KafkaOpt {
+	return func(p *KafkaPublisher) {
+		p.bufferSize = bufferSize
+		p.batchSize = batchSize
+		p.flushInterval = flushInterval
+	}
+}
+
+// NewKafkaPublisher starts the goroutine that sends events to the REST proxy at
+// restProxyUrl: Close must be called to send the remaining events and stop it.
+func NewKafkaPublisher(restProxyUrl string, opts ...KafkaOpt) *KafkaPublisher {
+	p := &KafkaPublisher{
+		url: strings.TrimSuffix(restProxyUrl, "/"),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.topicPrefix == "" {
+		p.topicPrefix = "csms"
+	}
+	if p.client == nil {
+		p.client = http.DefaultClient
+	}
+	if p.bufferSize <= 0 {
+		p.bufferSize = 1000
+	}
+	if p.batchSize <= 0 {
+		p.batchSize = 100
+	}
+	if p.flushInterval <= 0 {
+		p.flushInterval = time.Second
+	}
+
+	p.eventCh = make(chan *Event, p.bufferSize)
+	p.doneCh = make(chan struct{})
+	go p.run()
+	return p
+}
+
+func (p *KafkaPublisher) Publish(_ context.Context, event *Event) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		eventsDropped.WithLabelValues("closed").Inc()
+		return ErrClosed
+	}
+
+	select {
+	case p.eventCh <- event:
+		return nil
+	default:
+		eventsDropped.WithLabelValues("buffer_full").Inc()
+		return ErrBufferFull
+	}
+}
+
+// Close sends the events that are buffered and stops the background goroutine:
+// events that are published afterwards are dropped
+func (p *KafkaPublisher) Close() error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.eventCh)
+	}
+	p.mu.Unlock()
+	<-p.doneCh
+	return nil
+}
+
+func (p *KafkaPublisher) run() {
+	defer close(p.doneCh)
+
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	batches := make(map[string][]kafkaRecord)
+	count := 0
+	flush := func() {
+		for topic, records := range batches {
+			p.send(topic, records)
+		}
+		batches = make(map[string][]kafkaRecord)
+		count = 0
+	}
+
+	for {
+		select {
+		case event, ok := <-p.eventCh:
+			if !ok {
+				flush()
+				return
+			}
+			topic := p.topic(event)
+			batches[topic] = append(batches[topic], kafkaRecord{Key: event.ChargeStationId, Value: event})
+			count++
+			if count >= p.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (p *KafkaPublisher) topic(event *Event) string {
+	return fmt.Sprintf("%s.%s", p.topicPrefix, event.Category())
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value *Event `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (p *KafkaPublisher) send(topic string, records []kafkaRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	failed, err := p.produce(ctx, topic, records)
+	if err != nil {
+		slog.Warn("unable to publish events", slog.String("topic", topic), slog.Int("count", failed), "err", err)
+	}
+	eventsPublished.WithLabelValues(topic).Add(float64(len(records) - failed))
+	if failed > 0 {
+		eventsDropped.WithLabelValues("publish_failed").Add(float64(failed))
+	}
+}
+
+// produce returns the number of records that were not published
+func (p *KafkaPublisher) produce(ctx context.Context, topic string, records []kafkaRecord) (int, error) {
+	body, err := json.Marshal(kafkaProduceRequest{Records: records})
+	if err != nil {
+		return len(records), fmt.Errorf("marshalling events: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/topics/%s", p.url, url.PathEscape(topic)), bytes.NewReader(body))
+	if err != nil {
+		return len(records), fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", kafkaJsonContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if p.username != "" || p.password != "" {
+		req.SetBasicAuth(p.username, p.password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return len(records), fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return len(records), fmt.Errorf("reading response body failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return len(records), fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var produceResponse kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &produceResponse); err != nil {
+		return len(records), fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+	failed := 0
+	var lastErr string
+	for _, offset := range produceResponse.Offsets {
+		if offset.ErrorCode != nil {
+			failed++
+			lastErr = offset.Error
+		}
+	}
+	if failed > 0 {
+		return failed, fmt.Errorf("kafka rejected %d events: %s", failed, lastErr)
+	}
+	return 0, nil
+}
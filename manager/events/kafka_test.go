@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/events"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type produceRequest struct {
+	Path        string
+	ContentType string
+	Username    string
+	Records     []struct {
+		Key   string          `json:"key"`
+		Value json.RawMessage `json:"value"`
+	} `json:"records"`
+}
+
+func newRestProxy(t *testing.T, status int) (*httptest.Server, func() []produceRequest) {
+	var mu sync.Mutex
+	var requests []produceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := produceRequest{
+			Path:        r.URL.Path,
+			ContentType: r.Header.Get("Content-Type"),
+		}
+		req.Username, _, _ = r.BasicAuth()
+		require.NoError(t, json.Unmarshal(body, &req))
+
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"offsets":[]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []produceRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestKafkaPublisherSendsEventsToTheirTopics(t *testing.T) {
+	server, requests := newRestProxy(t, http.StatusOK)
+
+	publisher := events.NewKafkaPublisher(server.URL+"/",
+		events.WithTopicPrefix("test"),
+		events.WithBasicAuth("manager", "secret"),
+		events.WithBuffer(10, 10, time.Hour))
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionStarted, ChargeStationId: "cs001"}))
+	require.NoError(t, publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionEnded, ChargeStationId: "cs001"}))
+	require.NoError(t, publisher.Publish(ctx, &events.Event{Type: events.TypeConnectorStatusChanged, ChargeStationId: "cs002"}))
+	require.NoError(t, publisher.Close())
+
+	byPath := make(map[string]produceRequest)
+	for _, req := range requests() {
+		byPath[req.Path] = req
+	}
+	require.Len(t, byPath, 2)
+
+	transactions := byPath["/topics/test.transaction"]
+	assert.Equal(t, "application/vnd.kafka.json.v2+json", transactions.ContentType)
+	assert.Equal(t, "manager", transactions.Username)
+	require.Len(t, transactions.Records, 2)
+	assert.Equal(t, "cs001", transactions.Records[0].Key)
+	var event events.Event
+	require.NoError(t, json.Unmarshal(transactions.Records[1].Value, &event))
+	assert.Equal(t, events.TypeTransactionEnded, event.Type)
+
+	connectors := byPath["/topics/test.connector"]
+	require.Len(t, connectors.Records, 1)
+	assert.Equal(t, "cs002", connectors.Records[0].Key)
+}
+
+func TestKafkaPublisherSendsEventsWhenTheBatchIsFull(t *testing.T) {
+	server, requests := newRestProxy(t, http.StatusOK)
+
+	publisher := events.NewKafkaPublisher(server.URL, events.WithBuffer(10, 2, time.Hour))
+	defer func() {
+		_ = publisher.Close()
+	}()
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionStarted}))
+	require.NoError(t, publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionEnded}))
+
+	assert.Eventually(t, func() bool {
+		return len(requests()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "/topics/csms.transaction", requests()[0].Path)
+}
+
+func TestKafkaPublisherDropsEventsWhenTheBufferIsFull(t *testing.T) {
+	blockCh := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blockCh
+		_, _ = w.Write([]byte(`{"offsets":[]}`))
+	}))
+	defer server.Close()
+
+	publisher := events.NewKafkaPublisher(server.URL, events.WithBuffer(1, 1, time.Hour))
+
+	ctx := context.Background()
+	// the first event is being sent and the second fills the buffer
+	require.NoError(t, publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionStarted}))
+	var err error
+	assert.Eventually(t, func() bool {
+		err = publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionStarted})
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	err = publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionStarted})
+	assert.ErrorIs(t, err, events.ErrBufferFull)
+
+	close(blockCh)
+	require.NoError(t, publisher.Close())
+	err = publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionStarted})
+	assert.ErrorIs(t, err, events.ErrClosed)
+}
+
+func TestKafkaPublisherContinuesAfterTheProxyRejectsEvents(t *testing.T) {
+	server, requests := newRestProxy(t, http.StatusInternalServerError)
+
+	publisher := events.NewKafkaPublisher(server.URL, events.WithBuffer(10, 1, time.Hour))
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionStarted}))
+	require.NoError(t, publisher.Publish(ctx, &events.Event{Type: events.TypeTransactionEnded}))
+	require.NoError(t, publisher.Close())
+
+	assert.Len(t, requests(), 2)
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+)
+
+// Listener publishes an event when a connector changes status or a charge station
+// reports a security event. It is a handlers.ConnectorStatusListener and a
+// handlers.SecurityEventListener.
+type Listener struct {
+	publisher Publisher
+	clock     clock.PassiveClock
+}
+
+func NewListener(publisher Publisher, clock clock.PassiveClock) *Listener {
+	return &Listener{
+		publisher: publisher,
+		clock:     clock,
+	}
+}
+
+func (l *Listener) ConnectorStatusChanged(ctx context.Context, status *store.ConnectorStatus) error {
+	return l.publisher.Publish(ctx, newEvent(TypeConnectorStatusChanged, status.LastUpdated, status.ChargeStationId, ConnectorStatusData{
+		EvseId:      status.EvseId,
+		ConnectorId: status.ConnectorId,
+		Status:      status.Status,
+	}))
+}
+
+func (l *Listener) SecurityEventReported(ctx context.Context, chargeStationId, eventType, timestamp string, techInfo *string) error {
+	return l.publisher.Publish(ctx, newEvent(TypeSecurityEventReported, l.clock.Now(), chargeStationId, SecurityEventData{
+		EventType: eventType,
+		Timestamp: timestamp,
+		TechInfo:  techInfo,
+	}))
+}
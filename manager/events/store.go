@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// Store is a store.Engine that publishes an event when a transaction starts or ends
+// and when a reservation is created or changes status. Events are published once the
+// change has been applied to the underlying engine.
+type Store struct {
+	store.Engine
+	publisher Publisher
+	clock     clock.PassiveClock
+}
+
+func NewStore(engine store.Engine, publisher Publisher, clock clock.PassiveClock) *Store {
+	return &Store{
+		Engine:    engine,
+		publisher: publisher,
+		clock:     clock,
+	}
+}
+
+func (s *Store) CreateTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int, offline bool) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationStart,
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     meterValues,
+		SeqNo:           seqNo,
+		Offline:         offline,
+	})
+}
+
+func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationEnd,
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     meterValues,
+		SeqNo:           seqNo,
+	})
+}
+
+func (s *Store) WriteTransactionBatch(ctx context.Context, batch *store.TransactionBatch) error {
+	err := store.WriteTransactionBatch(ctx, s.Engine, batch)
+	if err != nil {
+		return err
+	}
+
+	var eventType Type
+	switch batch.Operation {
+	case store.TransactionOperationStart:
+		eventType = TypeTransactionStarted
+	case store.TransactionOperationEnd:
+		eventType = TypeTransactionEnded
+	default:
+		return nil
+	}
+	s.publish(ctx, newEvent(eventType, s.clock.Now(), batch.ChargeStationId, TransactionData{
+		TransactionId: batch.TransactionId,
+		IdToken:       batch.IdToken,
+		TokenType:     batch.TokenType,
+		SeqNo:         batch.SeqNo,
+		Offline:       batch.Offline,
+		MeterValues:   meterValueData(batch.MeterValues),
+	}))
+	return nil
+}
+
+func (s *Store) FleetStats(ctx context.Context, since, now time.Time) (*store.FleetStats, error) {
+	return store.ComputeFleetStats(ctx, s.Engine, since, now)
+}
+
+func (s *Store) ListTenantChargeStations(ctx context.Context, tenantId string, offset, limit int) ([]*store.ChargeStation, error) {
+	return store.ListTenantChargeStations(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) ListTenantTokens(ctx context.Context, tenantId string, offset, limit int) ([]*store.Token, error) {
+	return store.ListTenantTokens(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) ListTenantSites(ctx context.Context, tenantId string, offset, limit int) ([]*store.Site, error) {
+	return store.ListTenantSites(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) ListTenantTags(ctx context.Context, tenantId string, offset, limit int) ([]*store.Tag, error) {
+	return store.ListTenantTags(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) SetReservation(ctx context.Context, reservation *store.Reservation) error {
+	created := reservation.Version == 0
+	var previousStatus store.ReservationStatus
+	if !created {
+		previous, err := s.Engine.LookupReservation(ctx, reservation.ReservationId)
+		if err != nil {
+			return err
+		}
+		if previous != nil {
+			previousStatus = previous.Status
+		}
+	}
+
+	err := s.Engine.SetReservation(ctx, reservation)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case created:
+		s.publish(ctx, newEvent(TypeReservationCreated, s.clock.Now(), reservation.ChargeStationId, reservationData(reservation, "")))
+	case reservation.Status != previousStatus:
+		s.publish(ctx, newEvent(TypeReservationStatusChanged, s.clock.Now(), reservation.ChargeStationId, reservationData(reservation, previousStatus)))
+	}
+	return nil
+}
+
+func (s *Store) publish(ctx context.Context, event *Event) {
+	err := s.publisher.Publish(ctx, event)
+	if err != nil {
+		slog.Warn("unable to publish event", slog.String("type", string(event.Type)),
+			slog.String("chargeStationId", event.ChargeStationId), "err", err)
+	}
+}
+
+// LockReservation always acquires the lock if the underlying engine cannot hold locks
+func (s *Store) LockReservation(ctx context.Context, reservationId int, ttl time.Duration) (bool, error) {
+	if locker, ok := s.Engine.(store.ReservationLockStore); ok {
+		return locker.LockReservation(ctx, reservationId, ttl)
+	}
+	return true, nil
+}
+
+func (s *Store) UnlockReservation(ctx context.Context, reservationId int) error {
+	if locker, ok := s.Engine.(store.ReservationLockStore); ok {
+		return locker.UnlockReservation(ctx, reservationId)
+	}
+	return nil
+}
+
+// Healthy reports the health of the underlying engine
+func (s *Store) Healthy() error {
+	if reporter, ok := s.Engine.(store.HealthReporter); ok {
+		return reporter.Healthy()
+	}
+	return nil
+}
+
+// Close closes the underlying engine
+func (s *Store) Close() error {
+	return store.Close(s.Engine)
+}
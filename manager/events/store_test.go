@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/events"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingPublisher struct {
+	sync.Mutex
+	events []*events.Event
+}
+
+func (r *recordingPublisher) Publish(_ context.Context, event *events.Event) error {
+	r.Lock()
+	defer r.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestStorePublishesTransactionStartedAndEnded(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	engine := inmemory.NewStore(clock.RealClock{})
+	publisher := &recordingPublisher{}
+	s := events.NewStore(engine, publisher, clockTest.NewFakePassiveClock(now))
+
+	energyRegister := "Energy.Active.Import.Register"
+	err := s.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{{
+			Timestamp: "2023-06-01T12:00:00Z",
+			SampledValues: []store.SampledValue{{
+				Measurand:     &energyRegister,
+				UnitOfMeasure: &store.UnitOfMeasure{Unit: "Wh"},
+				Value:         100,
+			}},
+		}}, 0, false)
+	require.NoError(t, err)
+	err = s.UpdateTransaction(ctx, "cs001", "tx001", nil)
+	require.NoError(t, err)
+	err = store.WriteTransactionBatch(ctx, s, &store.TransactionBatch{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		Operation:       store.TransactionOperationEnd,
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		SeqNo:           2,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, publisher.events, 2)
+	started := publisher.events[0]
+	assert.Equal(t, events.TypeTransactionStarted, started.Type)
+	assert.Equal(t, events.SchemaVersion, started.SchemaVersion)
+	assert.Equal(t, now, started.OccurredAt)
+	assert.Equal(t, "cs001", started.ChargeStationId)
+	assert.NotEmpty(t, started.Id)
+	unit := "Wh"
+	assert.Equal(t, events.TransactionData{
+		TransactionId: "tx001",
+		IdToken:       "DEADBEEF",
+		TokenType:     "ISO14443",
+		MeterValues: []events.MeterValueData{{
+			Timestamp: "2023-06-01T12:00:00Z",
+			SampledValues: []events.SampledValueData{{
+				Measurand: &energyRegister,
+				Unit:      &unit,
+				Value:     100,
+			}},
+		}},
+	}, started.Data)
+
+	ended := publisher.events[1]
+	assert.Equal(t, events.TypeTransactionEnded, ended.Type)
+	assert.Equal(t, 2, ended.Data.(events.TransactionData).SeqNo)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.EndedSeqNo)
+}
+
+func TestStorePublishesReservationLifecycle(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	publisher := &recordingPublisher{}
+	s := events.NewStore(engine, publisher, clock.RealClock{})
+
+	reservation := &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ExpiryDate:      time.Date(2023, 6, 1, 13, 0, 0, 0, time.UTC),
+		Status:          store.ReservationStatusPending,
+	}
+	require.NoError(t, s.SetReservation(ctx, reservation))
+
+	// a write that does not change the status is not published
+	reservation.SendAfter = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, s.SetReservation(ctx, reservation))
+
+	reservation.Status = store.ReservationStatusAccepted
+	require.NoError(t, s.SetReservation(ctx, reservation))
+
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, events.TypeReservationCreated, publisher.events[0].Type)
+	assert.Equal(t, "Pending", publisher.events[0].Data.(events.ReservationData).Status)
+	assert.Equal(t, events.TypeReservationStatusChanged, publisher.events[1].Type)
+	assert.Equal(t, events.ReservationData{
+		ReservationId:  1,
+		IdToken:        "DEADBEEF",
+		TokenType:      "ISO14443",
+		ExpiryDate:     time.Date(2023, 6, 1, 13, 0, 0, 0, time.UTC),
+		Status:         "Accepted",
+		PreviousStatus: "Pending",
+	}, publisher.events[1].Data)
+}
+
+func TestStoreDoesNotPublishFailedChanges(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	publisher := &recordingPublisher{}
+	s := events.NewStore(engine, publisher, clock.RealClock{})
+
+	err := s.SetReservation(ctx, &store.Reservation{ReservationId: 1, Version: 3})
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+	assert.Empty(t, publisher.events)
+}
+
+func TestListenerPublishesConnectorStatusAndSecurityEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	publisher := &recordingPublisher{}
+	listener := events.NewListener(publisher, clockTest.NewFakePassiveClock(now))
+
+	err := listener.ConnectorStatusChanged(ctx, &store.ConnectorStatus{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		ConnectorId:     2,
+		Status:          "Occupied",
+		LastUpdated:     now.Add(-time.Second),
+	})
+	require.NoError(t, err)
+	techInfo := "bad firmware signature"
+	err = listener.SecurityEventReported(ctx, "cs001", "InvalidFirmwareSignature", "2023-06-01T11:59:00Z", &techInfo)
+	require.NoError(t, err)
+
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, events.TypeConnectorStatusChanged, publisher.events[0].Type)
+	assert.Equal(t, now.Add(-time.Second), publisher.events[0].OccurredAt)
+	assert.Equal(t, events.ConnectorStatusData{EvseId: 1, ConnectorId: 2, Status: "Occupied"}, publisher.events[0].Data)
+	assert.Equal(t, events.TypeSecurityEventReported, publisher.events[1].Type)
+	assert.Equal(t, "security", publisher.events[1].Category())
+	assert.Equal(t, events.SecurityEventData{
+		EventType: "InvalidFirmwareSignature",
+		Timestamp: "2023-06-01T11:59:00Z",
+		TechInfo:  &techInfo,
+	}, publisher.events[1].Data)
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"sort"
+	"sync"
+)
+
+// ActionFlags switches the handling of individual actions on and off while the
+// manager is running. The Router answers a call for an action that is switched off
+// with a NotSupported CallError and drops its call results. Actions are switched on
+// unless they have been switched off: the flags are held in-process, so they are
+// lost when the manager restarts.
+type ActionFlags struct {
+	mu       sync.RWMutex
+	actions  map[transport.OcppVersion]map[string]bool
+	disabled map[transport.OcppVersion]map[string]bool
+}
+
+// ActionFlag is the state of a single action
+type ActionFlag struct {
+	Action  string
+	Enabled bool
+}
+
+func NewActionFlags() *ActionFlags {
+	return &ActionFlags{
+		actions:  make(map[transport.OcppVersion]map[string]bool),
+		disabled: make(map[transport.OcppVersion]map[string]bool),
+	}
+}
+
+// Register records the actions that are routed for the OCPP version, so that they
+// can be listed
+func (f *ActionFlags) Register(ocppVersion transport.OcppVersion, actions ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.actions[ocppVersion] == nil {
+		f.actions[ocppVersion] = make(map[string]bool)
+	}
+	for _, action := range actions {
+		f.actions[ocppVersion][action] = true
+	}
+}
+
+// Known reports whether the action has been registered for the OCPP version
+func (f *ActionFlags) Known(ocppVersion transport.OcppVersion, action string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.actions[ocppVersion][action]
+}
+
+// Enabled reports whether the action should be handled. A nil ActionFlags enables
+// every action.
+func (f *ActionFlags) Enabled(ocppVersion transport.OcppVersion, action string) bool {
+	if f == nil {
+		return true
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return !f.disabled[ocppVersion][action]
+}
+
+// SetEnabled switches a single action on or off
+func (f *ActionFlags) SetEnabled(ocppVersion transport.OcppVersion, action string, enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if enabled {
+		delete(f.disabled[ocppVersion], action)
+		return
+	}
+	if f.disabled[ocppVersion] == nil {
+		f.disabled[ocppVersion] = make(map[string]bool)
+	}
+	f.disabled[ocppVersion][action] = true
+}
+
+// SetDisabled switches off exactly the actions given for the OCPP version, switching
+// all the other actions on
+func (f *ActionFlags) SetDisabled(ocppVersion transport.OcppVersion, actions []string) {
+	disabled := make(map[string]bool)
+	for _, action := range actions {
+		disabled[action] = true
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.disabled[ocppVersion] = disabled
+}
+
+// List returns the state of the registered actions for the OCPP version, ordered
+// by action
+func (f *ActionFlags) List(ocppVersion transport.OcppVersion) []ActionFlag {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	flags := make([]ActionFlag, 0, len(f.actions[ocppVersion]))
+	for action := range f.actions[ocppVersion] {
+		flags = append(flags, ActionFlag{
+			Action:  action,
+			Enabled: !f.disabled[ocppVersion][action],
+		})
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Action < flags[j].Action
+	})
+	return flags
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"testing"
+)
+
+func TestActionFlagsEnableActionsByDefault(t *testing.T) {
+	flags := handlers.NewActionFlags()
+	assert.True(t, flags.Enabled(transport.OcppVersion16, "Heartbeat"))
+
+	var nilFlags *handlers.ActionFlags
+	assert.True(t, nilFlags.Enabled(transport.OcppVersion16, "Heartbeat"))
+}
+
+func TestActionFlagsSwitchActionsPerOcppVersion(t *testing.T) {
+	flags := handlers.NewActionFlags()
+
+	flags.SetEnabled(transport.OcppVersion201, "SetChargingProfile", false)
+	assert.False(t, flags.Enabled(transport.OcppVersion201, "SetChargingProfile"))
+	assert.True(t, flags.Enabled(transport.OcppVersion16, "SetChargingProfile"))
+
+	flags.SetEnabled(transport.OcppVersion201, "SetChargingProfile", true)
+	assert.True(t, flags.Enabled(transport.OcppVersion201, "SetChargingProfile"))
+}
+
+func TestActionFlagsSetDisabledReplacesDisabledActions(t *testing.T) {
+	flags := handlers.NewActionFlags()
+	flags.SetEnabled(transport.OcppVersion16, "Heartbeat", false)
+
+	flags.SetDisabled(transport.OcppVersion16, []string{"SetChargingProfile", "ClearChargingProfile"})
+
+	assert.True(t, flags.Enabled(transport.OcppVersion16, "Heartbeat"))
+	assert.False(t, flags.Enabled(transport.OcppVersion16, "SetChargingProfile"))
+	assert.False(t, flags.Enabled(transport.OcppVersion16, "ClearChargingProfile"))
+}
+
+func TestActionFlagsListRegisteredActions(t *testing.T) {
+	flags := handlers.NewActionFlags()
+	flags.Register(transport.OcppVersion16, "StatusNotification", "Heartbeat", "BootNotification")
+	flags.SetEnabled(transport.OcppVersion16, "Heartbeat", false)
+
+	assert.True(t, flags.Known(transport.OcppVersion16, "Heartbeat"))
+	assert.False(t, flags.Known(transport.OcppVersion201, "Heartbeat"))
+	assert.Equal(t, []handlers.ActionFlag{
+		{Action: "BootNotification", Enabled: true},
+		{Action: "Heartbeat", Enabled: false},
+		{Action: "StatusNotification", Enabled: true},
+	}, flags.List(transport.OcppVersion16))
+	assert.Empty(t, flags.List(transport.OcppVersion201))
+}
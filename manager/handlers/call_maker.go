@@ -31,9 +31,11 @@ func (b OcppCallMaker) Send(ctx context.Context, chargeStationId string, request
 		return err
 	}
 
+	// the message ids are time ordered so that the command audit log lists the calls in
+	// the order they were sent
 	msg := &transport.Message{
 		MessageType:    transport.MessageTypeCall,
-		MessageId:      uuid.New().String(),
+		MessageId:      uuid.Must(uuid.NewV7()).String(),
 		Action:         action,
 		RequestPayload: requestBytes,
 	}
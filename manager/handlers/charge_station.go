@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// maxBootNotificationAttempts is the number of times the registry entry is read and
+// written before a BootNotification is abandoned because the entry keeps changing
+const maxBootNotificationAttempts = 3
+
+// RecordBootNotification copies the details reported in a BootNotification to the
+// charge station's registry entry, creating the entry if it does not exist. The
+// operator maintained details (site and coordinates) are left unchanged. If the
+// entry is changed concurrently the update is reapplied to the changed entry.
+func RecordBootNotification(ctx context.Context, chargeStationStore store.ChargeStationStore, chargeStationId string, boot *store.ChargeStation) error {
+	if chargeStationStore == nil {
+		return nil
+	}
+
+	var err error
+	for attempt := 0; attempt < maxBootNotificationAttempts; attempt++ {
+		err = recordBootNotification(ctx, chargeStationStore, chargeStationId, boot)
+		if !errors.Is(err, store.ErrVersionConflict) {
+			return err
+		}
+	}
+	return err
+}
+
+func recordBootNotification(ctx context.Context, chargeStationStore store.ChargeStationStore, chargeStationId string, boot *store.ChargeStation) error {
+	chargeStation, err := chargeStationStore.LookupChargeStation(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station: %w", err)
+	}
+	if chargeStation == nil {
+		chargeStation = &store.ChargeStation{}
+	}
+
+	chargeStation.OcppVersion = boot.OcppVersion
+	chargeStation.Vendor = boot.Vendor
+	chargeStation.Model = boot.Model
+	chargeStation.SerialNumber = boot.SerialNumber
+	chargeStation.FirmwareVersion = boot.FirmwareVersion
+	chargeStation.LastBoot = boot.LastBoot
+
+	err = chargeStationStore.SetChargeStation(ctx, chargeStationId, chargeStation)
+	if err != nil {
+		return fmt.Errorf("set charge station: %w", err)
+	}
+	return nil
+}
+
+// RecordOcppVersion sets the OCPP version in the charge station's runtime details,
+// keeping the ISO 15118 version that was recorded from the charge station's earlier
+// certificate requests
+func RecordOcppVersion(ctx context.Context, runtimeDetailsStore store.ChargeStationRuntimeDetailsStore, chargeStationId, ocppVersion string) error {
+	details, err := runtimeDetailsStore.LookupChargeStationRuntimeDetails(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	if details == nil {
+		details = &store.ChargeStationRuntimeDetails{}
+	}
+	return runtimeDetailsStore.SetChargeStationRuntimeDetails(ctx, chargeStationId, &store.ChargeStationRuntimeDetails{
+		OcppVersion:     ocppVersion,
+		Iso15118Version: details.Iso15118Version,
+	})
+}
+
+// RecordIso15118Version sets the ISO 15118 version in the charge station's runtime
+// details if it has changed: the runtime details are not created if the charge station
+// has not sent a BootNotification
+func RecordIso15118Version(ctx context.Context, runtimeDetailsStore store.ChargeStationRuntimeDetailsStore, chargeStationId, iso15118Version string) error {
+	details, err := runtimeDetailsStore.LookupChargeStationRuntimeDetails(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	if details == nil || details.Iso15118Version == iso15118Version {
+		return nil
+	}
+	return runtimeDetailsStore.SetChargeStationRuntimeDetails(ctx, chargeStationId, &store.ChargeStationRuntimeDetails{
+		OcppVersion:     details.OcppVersion,
+		Iso15118Version: iso15118Version,
+	})
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+// racingChargeStationStore changes the registry entry before the first write, as
+// another manager instance would if it handled a request for the same charge station
+type racingChargeStationStore struct {
+	store.ChargeStationStore
+	raced bool
+}
+
+func (s *racingChargeStationStore) SetChargeStation(ctx context.Context, chargeStationId string, chargeStation *store.ChargeStation) error {
+	if !s.raced {
+		s.raced = true
+		other, err := s.ChargeStationStore.LookupChargeStation(ctx, chargeStationId)
+		if err != nil {
+			return err
+		}
+		other.SiteId = "site002"
+		if err = s.ChargeStationStore.SetChargeStation(ctx, chargeStationId, other); err != nil {
+			return err
+		}
+	}
+	return s.ChargeStationStore.SetChargeStation(ctx, chargeStationId, chargeStation)
+}
+
+func TestRecordBootNotificationReappliesConcurrentlyChangedEntry(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{SiteId: "site001"})
+	require.NoError(t, err)
+
+	err = handlers.RecordBootNotification(ctx, &racingChargeStationStore{ChargeStationStore: engine}, "cs001",
+		&store.ChargeStation{Vendor: "vendor", Model: "model"})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "site002", got.SiteId)
+	assert.Equal(t, "vendor", got.Vendor)
+	assert.Equal(t, 3, got.Version)
+}
+
+func TestRecordOcppVersionKeepsIso15118Version(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	require.NoError(t, handlers.RecordOcppVersion(ctx, engine, "cs001", "2.0.1"))
+	require.NoError(t, handlers.RecordIso15118Version(ctx, engine, "cs001", "ISO15118-20"))
+	require.NoError(t, handlers.RecordOcppVersion(ctx, engine, "cs001", "2.1"))
+
+	details, err := engine.LookupChargeStationRuntimeDetails(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationRuntimeDetails{
+		OcppVersion:     "2.1",
+		Iso15118Version: "ISO15118-20",
+	}, details)
+}
+
+func TestRecordIso15118VersionOfUnknownChargeStation(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	require.NoError(t, handlers.RecordIso15118Version(ctx, engine, "cs001", "ISO15118-20"))
+
+	details, err := engine.LookupChargeStationRuntimeDetails(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, details)
+}
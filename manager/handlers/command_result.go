@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+)
+
+// CommandResultListener is informed when a charge station responds to a call that may
+// have been requested on behalf of another party. The action is the OCPP action of the
+// call, the reference distinguishes calls with the same action (e.g. the connector id)
+// and the status is the OCPP status returned by the charge station.
+type CommandResultListener interface {
+	CommandResult(ctx context.Context, chargeStationId, action, reference, status string) error
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// ConnectorStatusListener is informed when the status of a connector changes.
+type ConnectorStatusListener interface {
+	ConnectorStatusChanged(ctx context.Context, status *store.ConnectorStatus) error
+}
+
+// ConnectorStatusRecorder records the connector statuses reported by charge stations
+// and informs the Listener (if any) when a status changes. The connectors are added
+// to the charge station's topology when Evses has a store.
+type ConnectorStatusRecorder struct {
+	Clock    clock.PassiveClock
+	Store    store.ConnectorStatusStore
+	Listener ConnectorStatusListener
+	Evses    EvseRecorder
+}
+
+func (c ConnectorStatusRecorder) Record(ctx context.Context, chargeStationId string, evseId, connectorId int, status string) error {
+	if c.Store == nil {
+		return nil
+	}
+
+	// connector 0 is the charge station as a whole in OCPP 1.6
+	if connectorId != 0 {
+		err := c.Evses.Update(ctx, chargeStationId, evseId, func(evse *store.ChargeStationEvse) bool {
+			changed := false
+			if evse.Connector(connectorId) == nil {
+				evse.AddConnector(connectorId)
+				changed = true
+			}
+			// the EV that gave the departure time has left
+			if status == "Available" && !evse.DepartureTime.IsZero() {
+				evse.DepartureTime = time.Time{}
+				changed = true
+			}
+			return changed
+		})
+		if err != nil {
+			return fmt.Errorf("add connector: %w", err)
+		}
+	}
+
+	statuses, err := c.Store.ListConnectorStatuses(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("list connector statuses: %w", err)
+	}
+	for _, existing := range statuses {
+		if existing.EvseId == evseId && existing.ConnectorId == connectorId && existing.Status == status {
+			return nil
+		}
+	}
+
+	connectorStatus := &store.ConnectorStatus{
+		ChargeStationId: chargeStationId,
+		EvseId:          evseId,
+		ConnectorId:     connectorId,
+		Status:          status,
+		LastUpdated:     c.Clock.Now(),
+	}
+	err = c.Store.SetConnectorStatus(ctx, connectorStatus)
+	if err != nil {
+		return fmt.Errorf("set connector status: %w", err)
+	}
+
+	if c.Listener != nil {
+		// publishing is best effort: the status has been recorded so it is still
+		// available to anyone who pulls it
+		if err := c.Listener.ConnectorStatusChanged(ctx, connectorStatus); err != nil {
+			LoggerFromContext(ctx).Warn("unable to publish connector status", "err", err)
+		}
+	}
+	return nil
+}
+
+// ConnectorStatusListeners informs each of the listeners when the status of a connector
+// changes, e.g. when both OCPI and OICP are configured.
+type ConnectorStatusListeners []ConnectorStatusListener
+
+func (l ConnectorStatusListeners) ConnectorStatusChanged(ctx context.Context, status *store.ConnectorStatus) error {
+	var errs []error
+	for _, listener := range l {
+		if err := listener.ConnectorStatusChanged(ctx, status); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
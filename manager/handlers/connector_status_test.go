@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type fakeConnectorStatusListener struct {
+	statuses []*store.ConnectorStatus
+	err      error
+}
+
+func (f *fakeConnectorStatusListener) ConnectorStatusChanged(_ context.Context, status *store.ConnectorStatus) error {
+	f.statuses = append(f.statuses, status)
+	return f.err
+}
+
+func TestConnectorStatusRecorderNotifiesChanges(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	listener := &fakeConnectorStatusListener{}
+	recorder := handlers.ConnectorStatusRecorder{
+		Clock:    clockTest.NewFakePassiveClock(now),
+		Store:    engine,
+		Listener: listener,
+	}
+
+	require.NoError(t, recorder.Record(context.Background(), "cs001", 1, 1, "Available"))
+	require.NoError(t, recorder.Record(context.Background(), "cs001", 1, 1, "Available"))
+	require.NoError(t, recorder.Record(context.Background(), "cs001", 1, 1, "Occupied"))
+
+	assert.Equal(t, []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now},
+	}, listener.statuses)
+
+	statuses, err := engine.ListConnectorStatuses(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now},
+	}, statuses)
+}
+
+func TestConnectorStatusRecorderIgnoresListenerErrors(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	recorder := handlers.ConnectorStatusRecorder{
+		Clock:    clockTest.NewFakePassiveClock(now),
+		Store:    engine,
+		Listener: &fakeConnectorStatusListener{err: errors.New("push failed")},
+	}
+
+	err := recorder.Record(context.Background(), "cs001", 0, 1, "Faulted")
+	require.NoError(t, err)
+
+	statuses, err := engine.ListConnectorStatuses(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Len(t, statuses, 1)
+}
+
+func TestConnectorStatusRecorderAddsConnectorsToTheTopology(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	recorder := handlers.ConnectorStatusRecorder{
+		Clock: clockTest.NewFakePassiveClock(now),
+		Store: engine,
+		Evses: handlers.EvseRecorder{
+			Clock: clockTest.NewFakePassiveClock(now),
+			Store: engine,
+		},
+	}
+
+	require.NoError(t, recorder.Record(context.Background(), "cs001", 0, 0, "Available"))
+	require.NoError(t, recorder.Record(context.Background(), "cs001", 0, 2, "Available"))
+	require.NoError(t, recorder.Record(context.Background(), "cs001", 0, 1, "Available"))
+	require.NoError(t, recorder.Record(context.Background(), "cs001", 0, 1, "Occupied"))
+
+	evses, err := engine.ListEvses(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationEvse{
+		{
+			ChargeStationId: "cs001",
+			Connectors:      []store.ChargeStationConnector{{ConnectorId: 1}, {ConnectorId: 2}},
+			LastUpdated:     now,
+		},
+	}, evses)
+}
+
+func TestConnectorStatusRecorderClearsTheDepartureTimeWhenAvailable(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, engine.SetEvse(context.Background(), &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		Connectors:      []store.ChargeStationConnector{{ConnectorId: 1}},
+		DepartureTime:   now.Add(8 * time.Hour),
+	}))
+	recorder := handlers.ConnectorStatusRecorder{
+		Clock: clockTest.NewFakePassiveClock(now),
+		Store: engine,
+		Evses: handlers.EvseRecorder{
+			Clock: clockTest.NewFakePassiveClock(now),
+			Store: engine,
+		},
+	}
+
+	require.NoError(t, recorder.Record(context.Background(), "cs001", 1, 1, "Occupied"))
+	evse, err := engine.LookupEvse(context.Background(), "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(8*time.Hour), evse.DepartureTime)
+
+	require.NoError(t, recorder.Record(context.Background(), "cs001", 1, 1, "Available"))
+	evse, err = engine.LookupEvse(context.Background(), "cs001", 1)
+	require.NoError(t, err)
+	assert.True(t, evse.DepartureTime.IsZero())
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store/cache"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+var duplicateCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_duplicate_calls_total",
+	Help: "The number of retransmitted calls that were answered with the response to the original call, by action",
+}, []string{"action"})
+
+// SharedCallResponses holds the responses to calls where every manager instance can
+// read them: a charge station that reconnects to another instance retransmits its
+// call to that instance.
+type SharedCallResponses interface {
+	// LookupCallResponse returns nil if no response is held for the call
+	LookupCallResponse(ctx context.Context, chargeStationId, messageId, action string) (json.RawMessage, error)
+	SetCallResponse(ctx context.Context, chargeStationId, messageId, action string, response json.RawMessage, ttl time.Duration) error
+}
+
+// CallResponses holds the responses to recent calls from charge stations. A charge
+// station retransmits a call when it does not receive the response, so the Router
+// answers a call that it has already handled with the original response rather than
+// handling it again, which would, for example, record a transaction twice. Calls are
+// identified by charge station, message id and action. Only successful responses are
+// held: a call that failed is handled again when it is retransmitted.
+type CallResponses struct {
+	responses *cache.LRU[json.RawMessage]
+	shared    SharedCallResponses
+	ttl       time.Duration
+}
+
+type CallResponsesOpt func(c *CallResponses)
+
+// WithSharedCallResponses also holds the responses in shared, which is consulted when
+// a call is not known to this manager instance
+func WithSharedCallResponses(shared SharedCallResponses) CallResponsesOpt {
+	return func(c *CallResponses) {
+		c.shared = shared
+	}
+}
+
+// NewCallResponses holds the responses to up to size calls, each for at most ttl
+func NewCallResponses(clock clock.PassiveClock, size int, ttl time.Duration, opts ...CallResponsesOpt) *CallResponses {
+	c := &CallResponses{
+		responses: cache.NewLRU[json.RawMessage](clock, size, ttl),
+		ttl:       ttl,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func callResponseKey(chargeStationId, messageId, action string) string {
+	return chargeStationId + "\x00" + messageId + "\x00" + action
+}
+
+// lookup treats a failure to read the shared responses as a miss: the call is handled
+// again rather than not answered
+func (c *CallResponses) lookup(ctx context.Context, chargeStationId, messageId, action string) (json.RawMessage, bool) {
+	if messageId == "" {
+		return nil, false
+	}
+	key := callResponseKey(chargeStationId, messageId, action)
+	response, ok := c.responses.Get(key)
+	if !ok && c.shared != nil {
+		var err error
+		response, err = c.shared.LookupCallResponse(ctx, chargeStationId, messageId, action)
+		if err != nil {
+			LoggerFromContext(ctx).Warn("lookup shared call response", "err", err)
+		}
+		if response != nil {
+			c.responses.Put(key, response)
+			ok = true
+		}
+	}
+	if ok {
+		duplicateCalls.WithLabelValues(action).Inc()
+	}
+	return response, ok
+}
+
+func (c *CallResponses) record(ctx context.Context, chargeStationId, messageId, action string, response json.RawMessage) {
+	if messageId == "" {
+		return
+	}
+	c.responses.Put(callResponseKey(chargeStationId, messageId, action), response)
+	if c.shared != nil {
+		if err := c.shared.SetCallResponse(ctx, chargeStationId, messageId, action, response, c.ttl); err != nil {
+			LoggerFromContext(ctx).Warn("set shared call response", "err", err)
+		}
+	}
+}
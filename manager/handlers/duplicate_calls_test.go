@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestRouterAnswersRetransmittedCallWithOriginalResponse(t *testing.T) {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	emitter := new(FakeEmitter)
+	var handled int
+	router := routerWithHeartbeatHandler(emitter, func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		handled++
+		return &ocpp201.HeartbeatResponseJson{CurrentTime: clock.Now().Add(time.Duration(handled) * time.Second).Format(time.RFC3339)}, nil
+	})
+	router.CallResponses = handlers.NewCallResponses(clock, 10, time.Minute)
+
+	call := &transport.Message{
+		Action:         "Heartbeat",
+		MessageType:    transport.MessageTypeCall,
+		MessageId:      "msg-1",
+		RequestPayload: []byte("{}"),
+	}
+
+	router.Handle(context.Background(), "cs001", call)
+	first := emitter.msg
+
+	router.Handle(context.Background(), "cs001", call)
+	assert.Equal(t, 1, handled)
+	assert.Equal(t, transport.MessageTypeCallResult, emitter.msg.MessageType)
+	assert.Equal(t, "msg-1", emitter.msg.MessageId)
+	assert.Equal(t, first.ResponsePayload, emitter.msg.ResponsePayload)
+
+	// a new call, or the same message id from another charge station, is handled
+	router.Handle(context.Background(), "cs001", &transport.Message{
+		Action:         "Heartbeat",
+		MessageType:    transport.MessageTypeCall,
+		MessageId:      "msg-2",
+		RequestPayload: []byte("{}"),
+	})
+	router.Handle(context.Background(), "cs002", call)
+	assert.Equal(t, 3, handled)
+
+	// the original response is forgotten after the ttl
+	clock.SetTime(clock.Now().Add(2 * time.Minute))
+	router.Handle(context.Background(), "cs001", call)
+	assert.Equal(t, 4, handled)
+}
+
+func TestRouterHandlesRetransmittedCallThatFailed(t *testing.T) {
+	emitter := new(FakeEmitter)
+	var handled int
+	router := routerWithHeartbeatHandler(emitter, func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		handled++
+		if handled == 1 {
+			return nil, errors.New("store unavailable")
+		}
+		return &ocpp201.HeartbeatResponseJson{CurrentTime: "2023-06-15T15:05:00Z"}, nil
+	})
+	router.CallResponses = handlers.NewCallResponses(clockTest.NewFakePassiveClock(time.Now()), 10, time.Minute)
+
+	call := &transport.Message{
+		Action:         "Heartbeat",
+		MessageType:    transport.MessageTypeCall,
+		MessageId:      "msg-1",
+		RequestPayload: []byte("{}"),
+	}
+
+	router.Handle(context.Background(), "cs001", call)
+	assert.Equal(t, transport.MessageTypeCallError, emitter.msg.MessageType)
+
+	router.Handle(context.Background(), "cs001", call)
+	assert.Equal(t, 2, handled)
+	assert.Equal(t, transport.MessageTypeCallResult, emitter.msg.MessageType)
+}
+
+type fakeSharedCallResponses struct {
+	responses map[string]json.RawMessage
+}
+
+func (f *fakeSharedCallResponses) LookupCallResponse(_ context.Context, chargeStationId, messageId, action string) (json.RawMessage, error) {
+	return f.responses[chargeStationId+"/"+messageId+"/"+action], nil
+}
+
+func (f *fakeSharedCallResponses) SetCallResponse(_ context.Context, chargeStationId, messageId, action string, response json.RawMessage, _ time.Duration) error {
+	f.responses[chargeStationId+"/"+messageId+"/"+action] = response
+	return nil
+}
+
+func TestRouterAnswersCallRetransmittedToAnotherInstance(t *testing.T) {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	shared := &fakeSharedCallResponses{responses: make(map[string]json.RawMessage)}
+	var handled int
+	handler := func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		handled++
+		return &ocpp201.HeartbeatResponseJson{CurrentTime: clock.Now().Add(time.Duration(handled) * time.Second).Format(time.RFC3339)}, nil
+	}
+
+	firstEmitter, secondEmitter := new(FakeEmitter), new(FakeEmitter)
+	first := routerWithHeartbeatHandler(firstEmitter, handler)
+	first.CallResponses = handlers.NewCallResponses(clock, 10, time.Minute, handlers.WithSharedCallResponses(shared))
+	second := routerWithHeartbeatHandler(secondEmitter, handler)
+	second.CallResponses = handlers.NewCallResponses(clock, 10, time.Minute, handlers.WithSharedCallResponses(shared))
+
+	call := &transport.Message{
+		Action:         "Heartbeat",
+		MessageType:    transport.MessageTypeCall,
+		MessageId:      "msg-1",
+		RequestPayload: []byte("{}"),
+	}
+
+	first.Handle(context.Background(), "cs001", call)
+	second.Handle(context.Background(), "cs001", call)
+	assert.Equal(t, 1, handled)
+	assert.Equal(t, transport.MessageTypeCallResult, secondEmitter.msg.MessageType)
+	assert.Equal(t, firstEmitter.msg.ResponsePayload, secondEmitter.msg.ResponsePayload)
+}
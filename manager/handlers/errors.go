@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/santhosh-tekuri/jsonschema"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"strings"
+)
+
+// maxErrorDescriptionLength is the longest errorDescription that OCPP-J allows in a
+// CallError
+const maxErrorDescriptionLength = 255
+
+// ErrorCode returns the OCPP error code that reports the error to a charge station.
+// An error that wraps a transport.Error uses its code; otherwise payloads that fail
+// schema validation or cannot be decoded are reported as format or type violations,
+// errors of a kind defined by package errs with the code for the kind and any other
+// error as an InternalError.
+func ErrorCode(err error) transport.ErrorCode {
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) {
+		return transportErr.ErrorCode
+	}
+
+	var validationErr *jsonschema.ValidationError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErr), errors.As(err, &syntaxErr):
+		return transport.ErrorFormatViolation
+	case errors.As(err, &typeErr):
+		return transport.ErrorTypeConstraintViolation
+	case errors.Is(err, errs.ErrNotFound):
+		// the payload refers to something that the CSMS does not know about
+		return transport.ErrorPropertyConstraintViolation
+	case errors.Is(err, errs.ErrUnauthorized):
+		return transport.ErrorSecurityError
+	case errors.Is(err, errs.ErrConflict):
+		return transport.ErrorGenericError
+	default:
+		return transport.ErrorInternalError
+	}
+}
+
+// newCallError returns the CallError that responds to a call that could not be
+// handled
+func newCallError(msg *transport.Message, err error) *transport.Message {
+	description := err.Error()
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) && transportErr.WrappedError != nil {
+		description = transportErr.WrappedError.Error()
+	}
+	if len(description) > maxErrorDescriptionLength {
+		// drop any character that the cut splits
+		description = strings.ToValidUTF8(description[:maxErrorDescriptionLength], "")
+	}
+
+	return &transport.Message{
+		MessageType:      transport.MessageTypeCallError,
+		Action:           msg.Action,
+		MessageId:        msg.MessageId,
+		ErrorCode:        ErrorCode(err),
+		ErrorDescription: description,
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"strings"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	var typed struct {
+		Value int `json:"value"`
+	}
+	typeErr := json.Unmarshal([]byte(`{"value":"one"}`), &typed)
+	syntaxErr := json.Unmarshal([]byte(`{`), &typed)
+	validationErr := schemas.Validate([]byte(`{}`), schemas.OcppSchemas, "ocpp201/BootNotificationRequest.json")
+
+	assert.Equal(t, transport.ErrorSecurityError, handlers.ErrorCode(fmt.Errorf("wrapped: %w", transport.NewError(transport.ErrorSecurityError, nil))))
+	assert.Equal(t, transport.ErrorTypeConstraintViolation, handlers.ErrorCode(fmt.Errorf("wrapped: %w", typeErr)))
+	assert.Equal(t, transport.ErrorFormatViolation, handlers.ErrorCode(syntaxErr))
+	assert.Equal(t, transport.ErrorFormatViolation, handlers.ErrorCode(validationErr))
+	assert.Equal(t, transport.ErrorInternalError, handlers.ErrorCode(errors.New("store unavailable")))
+}
+
+func TestErrorCodeForErrorKind(t *testing.T) {
+	assert.Equal(t, transport.ErrorPropertyConstraintViolation,
+		handlers.ErrorCode(fmt.Errorf("stopping: %w", errs.New(errs.ErrNotFound, "transaction cs001/tx001 not found"))))
+	assert.Equal(t, transport.ErrorGenericError, handlers.ErrorCode(fmt.Errorf("wrapped: %w", store.ErrVersionConflict)))
+	assert.Equal(t, transport.ErrorSecurityError, handlers.ErrorCode(errs.New(errs.ErrUnauthorized, "token revoked")))
+	assert.Equal(t, transport.ErrorInternalError, handlers.ErrorCode(errs.New(errs.ErrDependencyUnavailable, "http status: 503")))
+	// a transport error says exactly what to report
+	assert.Equal(t, transport.ErrorNotSupported,
+		handlers.ErrorCode(transport.NewError(transport.ErrorNotSupported, errs.New(errs.ErrNotFound, "no such evse"))))
+}
+
+func routerWithHeartbeatHandler(emitter transport.Emitter, handler handlers.CallHandlerFunc) handlers.Router {
+	return handlers.Router{
+		Emitter:  emitter,
+		SchemaFS: schemas.OcppSchemas,
+		CallRoutes: map[string]handlers.CallRoute{
+			"Heartbeat": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
+				RequestSchema:  "ocpp201/HeartbeatRequest.json",
+				ResponseSchema: "ocpp201/HeartbeatResponse.json",
+				Handler:        handler,
+			},
+		},
+	}
+}
+
+func TestRouterCallErrorForTransportErrorWithoutWrappedError(t *testing.T) {
+	emitter := new(FakeEmitter)
+	router := routerWithHeartbeatHandler(emitter, func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		return nil, transport.NewError(transport.ErrorNotSupported, nil)
+	})
+
+	router.Handle(context.Background(), "id", &heartbeatMsg)
+
+	assert.Equal(t, transport.MessageTypeCallError, emitter.msg.MessageType)
+	assert.Equal(t, transport.ErrorNotSupported, emitter.msg.ErrorCode)
+	assert.Equal(t, "NotSupported", emitter.msg.ErrorDescription)
+}
+
+func TestRouterCallErrorDescriptionIsTruncated(t *testing.T) {
+	emitter := new(FakeEmitter)
+	router := routerWithHeartbeatHandler(emitter, func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		return nil, errors.New(strings.Repeat("x", 300))
+	})
+
+	router.Handle(context.Background(), "id", &heartbeatMsg)
+
+	assert.Equal(t, transport.ErrorInternalError, emitter.msg.ErrorCode)
+	assert.Len(t, emitter.msg.ErrorDescription, 255)
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+)
+
+// EvseRecorder records the EVSEs and connectors that charge stations report, so that
+// the topology of each charge station is known without it being configured. The
+// metadata of existing connectors is only changed by the update functions, so
+// metadata set through the API is kept.
+type EvseRecorder struct {
+	Clock clock.PassiveClock
+	Store store.EvseStore
+}
+
+// Update applies the update to the EVSE, which is created if the charge station has not
+// reported it before. The update returns whether it changed the EVSE: the EVSE is only
+// written when it has changed.
+func (e EvseRecorder) Update(ctx context.Context, chargeStationId string, evseId int, update func(evse *store.ChargeStationEvse) bool) error {
+	if e.Store == nil {
+		return nil
+	}
+
+	evse, err := e.Store.LookupEvse(ctx, chargeStationId, evseId)
+	if err != nil {
+		return fmt.Errorf("lookup evse: %w", err)
+	}
+	created := evse == nil
+	if created {
+		evse = &store.ChargeStationEvse{
+			ChargeStationId: chargeStationId,
+			EvseId:          evseId,
+		}
+	}
+	if !update(evse) && !created {
+		return nil
+	}
+
+	evse.LastUpdated = e.Clock.Now()
+	err = e.Store.SetEvse(ctx, evse)
+	if err != nil {
+		return fmt.Errorf("set evse: %w", err)
+	}
+	return nil
+}
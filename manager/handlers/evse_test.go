@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestEvseRecorderKeepsConnectorMetadata(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	then := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, engine.SetEvse(context.Background(), &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		Connectors: []store.ChargeStationConnector{
+			{ConnectorId: 1, ConnectorType: "cType2", MaxPower: 22000, PhysicalLabel: "A"},
+		},
+		LastUpdated: then,
+	}))
+
+	now := then.Add(time.Hour)
+	recorder := handlers.EvseRecorder{
+		Clock: clockTest.NewFakePassiveClock(now),
+		Store: engine,
+	}
+
+	addConnector := func(connectorId int) func(evse *store.ChargeStationEvse) bool {
+		return func(evse *store.ChargeStationEvse) bool {
+			if evse.Connector(connectorId) != nil {
+				return false
+			}
+			evse.AddConnector(connectorId)
+			return true
+		}
+	}
+
+	require.NoError(t, recorder.Update(context.Background(), "cs001", 1, addConnector(1)))
+	evse, err := engine.LookupEvse(context.Background(), "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, then, evse.LastUpdated)
+
+	require.NoError(t, recorder.Update(context.Background(), "cs001", 1, addConnector(2)))
+	evse, err = engine.LookupEvse(context.Background(), "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		Connectors: []store.ChargeStationConnector{
+			{ConnectorId: 1, ConnectorType: "cType2", MaxPower: 22000, PhysicalLabel: "A"},
+			{ConnectorId: 2},
+		},
+		LastUpdated: now,
+	}, evse)
+}
+
+func TestEvseRecorderWithoutStore(t *testing.T) {
+	recorder := handlers.EvseRecorder{}
+
+	err := recorder.Update(context.Background(), "cs001", 1, func(evse *store.ChargeStationEvse) bool {
+		t.Fatal("the update should not be applied without a store")
+		return false
+	})
+	assert.NoError(t, err)
+}
@@ -17,7 +17,7 @@ var calledTimes int
 
 type dummyContractCertificateProvider struct{}
 
-func (d dummyContractCertificateProvider) ProvideCertificate(_ context.Context, exiRequest string) (services.EvCertificate15118Response, error) {
+func (d dummyContractCertificateProvider) ProvideCertificate(_ context.Context, exiRequest string, _ services.ISOVersion) (services.EvCertificate15118Response, error) {
 	calledTimes++
 	if exiRequest == "success" {
 		return services.EvCertificate15118Response{
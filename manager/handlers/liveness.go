@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+)
+
+// LivenessRecorder is informed of every message received from a charge station.
+type LivenessRecorder interface {
+	MessageReceived(ctx context.Context, chargeStationId string, action string) error
+}
+
+// LivenessHandler is a transport.MessageHandler that records each message received
+// from a charge station before passing it on to the wrapped handler.
+type LivenessHandler struct {
+	Handler  transport.MessageHandler
+	Liveness LivenessRecorder
+}
+
+func (l LivenessHandler) Handle(ctx context.Context, chargeStationId string, msg *transport.Message) {
+	// failing to record liveness must not prevent the message from being processed
+	if err := l.Liveness.MessageReceived(ctx, chargeStationId, msg.Action); err != nil {
+		slog.Warn("unable to record charge station liveness", slog.String("chargeStationId", chargeStationId), "err", err)
+	}
+	l.Handler.Handle(ctx, chargeStationId, msg)
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"testing"
+)
+
+type fakeLivenessRecorder struct {
+	actions []string
+	err     error
+}
+
+func (f *fakeLivenessRecorder) MessageReceived(_ context.Context, _ string, action string) error {
+	f.actions = append(f.actions, action)
+	return f.err
+}
+
+func TestLivenessHandlerRecordsMessage(t *testing.T) {
+	for name, recordErr := range map[string]error{
+		"recorded":        nil,
+		"recording fails": errors.New("store unavailable"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			recorder := &fakeLivenessRecorder{err: recordErr}
+			var handled []string
+
+			handler := handlers.LivenessHandler{
+				Handler: transport.MessageHandlerFunc(func(_ context.Context, chargeStationId string, msg *transport.Message) {
+					handled = append(handled, chargeStationId+":"+msg.Action)
+				}),
+				Liveness: recorder,
+			}
+
+			handler.Handle(context.Background(), "cs001", &heartbeatMsg)
+
+			assert.Equal(t, []string{"Heartbeat"}, recorder.actions)
+			assert.Equal(t, []string{"cs001:Heartbeat"}, handled)
+		})
+	}
+}
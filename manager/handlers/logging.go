@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+type loggerKey struct{}
+
+// ContextWithLogger returns a context that carries the logger used while handling a
+// message.
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger that the Router adds to the context passed to
+// handlers: it attaches the charge station id, action and message id of the message
+// being handled and the id of its trace to each record. Outside the Router it returns
+// the default logger.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// messageLogger returns the logger for a message that a charge station sent
+func messageLogger(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, msg *transport.Message) *slog.Logger {
+	attrs := []any{
+		slog.String("chargeStationId", chargeStationId),
+		slog.String("ocppVersion", string(ocppVersion)),
+		slog.String("action", msg.Action),
+		slog.String("messageId", msg.MessageId),
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		attrs = append(attrs, slog.String("traceId", spanContext.TraceID().String()))
+	}
+	return slog.Default().With(attrs...)
+}
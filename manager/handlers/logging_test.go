@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"testing"
+)
+
+func TestRouterAddsMessageLoggerToContext(t *testing.T) {
+	var buf bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	router := routerWithHeartbeatHandler(new(FakeEmitter), func(ctx context.Context, _ string, _ ocpp.Request) (ocpp.Response, error) {
+		handlers.LoggerFromContext(ctx).Info("heartbeat")
+		return &ocpp201.HeartbeatResponseJson{CurrentTime: "2023-06-15T15:05:00+01:00"}, nil
+	})
+	router.OcppVersion = transport.OcppVersion201
+
+	traceId := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceId,
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	}))
+	msg := heartbeatMsg
+	msg.MessageId = "1234"
+	router.Handle(ctx, "cs001", &msg)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "heartbeat", record["msg"])
+	assert.Equal(t, "cs001", record["chargeStationId"])
+	assert.Equal(t, "ocpp2.0.1", record["ocppVersion"])
+	assert.Equal(t, "Heartbeat", record["action"])
+	assert.Equal(t, "1234", record["messageId"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["traceId"])
+}
+
+func TestLoggerFromContextDefaultsToDefaultLogger(t *testing.T) {
+	assert.Same(t, slog.Default(), handlers.LoggerFromContext(context.Background()))
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"runtime/debug"
+	"time"
+)
+
+var handlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "manager_handler_duration_seconds",
+	Help:    "The time taken to handle calls and call results, by OCPP version, message type, action and outcome",
+	Buckets: prometheus.DefBuckets,
+}, []string{"ocpp_version", "message_type", "action", "outcome"})
+
+// CallMiddleware wraps a CallHandler with behaviour that applies to many actions,
+// such as logging, metrics or authorization.
+type CallMiddleware func(next CallHandler) CallHandler
+
+// CallResultMiddleware wraps a CallResultHandler with behaviour that applies to many
+// actions.
+type CallResultMiddleware func(next CallResultHandler) CallResultHandler
+
+// ChainCall wraps the handler with the middleware: the first middleware is the
+// outermost, so it sees the call first and the response last.
+func ChainCall(handler CallHandler, middleware ...CallMiddleware) CallHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// ChainCallResult wraps the handler with the middleware: the first middleware is
+// the outermost.
+func ChainCallResult(handler CallResultHandler, middleware ...CallResultMiddleware) CallResultHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+type actionKey struct{}
+
+// ContextWithAction returns a context that carries the OCPP action being handled.
+func ContextWithAction(ctx context.Context, action string) context.Context {
+	return context.WithValue(ctx, actionKey{}, action)
+}
+
+// ActionFromContext returns the OCPP action being handled, which the Router adds to
+// the context passed to handlers so that middleware can tell the actions apart.
+func ActionFromContext(ctx context.Context) string {
+	action, _ := ctx.Value(actionKey{}).(string)
+	return action
+}
+
+// RecoverCalls turns a panic in a handler into an InternalError for the call, so
+// that a bug in one handler does not stop the manager.
+func RecoverCalls(next CallHandler) CallHandler {
+	return CallHandlerFunc(func(ctx context.Context, chargeStationId string, request ocpp.Request) (response ocpp.Response, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, chargeStationId, r)
+			}
+		}()
+		return next.HandleCall(ctx, chargeStationId, request)
+	})
+}
+
+// RecoverCallResults turns a panic in a handler into an error.
+func RecoverCallResults(next CallResultHandler) CallResultHandler {
+	return CallResultHandlerFunc(func(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, chargeStationId, r)
+			}
+		}()
+		return next.HandleCallResult(ctx, chargeStationId, request, response, state)
+	})
+}
+
+func recovered(ctx context.Context, chargeStationId string, r any) error {
+	action := ActionFromContext(ctx)
+	LoggerFromContext(ctx).Error("handler panicked", "panic", r, "stack", string(debug.Stack()))
+	return transport.NewError(transport.ErrorInternalError, fmt.Errorf("handling %s: panic: %v", action, r))
+}
+
+// CallMetrics records the time taken to handle each call.
+func CallMetrics(ocppVersion transport.OcppVersion) CallMiddleware {
+	return func(next CallHandler) CallHandler {
+		return CallHandlerFunc(func(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+			start := time.Now()
+			response, err := next.HandleCall(ctx, chargeStationId, request)
+			observeDuration(ctx, ocppVersion, "call", start, err)
+			return response, err
+		})
+	}
+}
+
+// CallResultMetrics records the time taken to handle each call result.
+func CallResultMetrics(ocppVersion transport.OcppVersion) CallResultMiddleware {
+	return func(next CallResultHandler) CallResultHandler {
+		return CallResultHandlerFunc(func(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+			start := time.Now()
+			err := next.HandleCallResult(ctx, chargeStationId, request, response, state)
+			observeDuration(ctx, ocppVersion, "call_result", start, err)
+			return err
+		})
+	}
+}
+
+func observeDuration(ctx context.Context, ocppVersion transport.OcppVersion, messageType string, start time.Time, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	handlerDuration.WithLabelValues(string(ocppVersion), messageType, ActionFromContext(ctx), outcome).
+		Observe(time.Since(start).Seconds())
+}
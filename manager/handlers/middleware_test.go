@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"testing"
+)
+
+func recordingCallMiddleware(name string, calls *[]string) handlers.CallMiddleware {
+	return func(next handlers.CallHandler) handlers.CallHandler {
+		return handlers.CallHandlerFunc(func(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+			*calls = append(*calls, name+" "+handlers.ActionFromContext(ctx))
+			return next.HandleCall(ctx, chargeStationId, request)
+		})
+	}
+}
+
+func TestChainCallAppliesMiddlewareInOrder(t *testing.T) {
+	var calls []string
+	handler := handlers.CallHandlerFunc(func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		calls = append(calls, "handler")
+		return &ocpp201.HeartbeatResponseJson{}, nil
+	})
+
+	chained := handlers.ChainCall(handler, recordingCallMiddleware("first", &calls), recordingCallMiddleware("second", &calls))
+	_, err := chained.HandleCall(handlers.ContextWithAction(context.Background(), "Heartbeat"), "cs001", &ocpp201.HeartbeatRequestJson{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"first Heartbeat", "second Heartbeat", "handler"}, calls)
+}
+
+func TestRecoverCallsReturnsInternalError(t *testing.T) {
+	handler := handlers.RecoverCalls(handlers.CallHandlerFunc(func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		panic("boom")
+	}))
+
+	resp, err := handler.HandleCall(context.Background(), "cs001", &ocpp201.HeartbeatRequestJson{})
+	assert.Nil(t, resp)
+	var transportErr *transport.Error
+	require.ErrorAs(t, err, &transportErr)
+	assert.Equal(t, transport.ErrorInternalError, transportErr.ErrorCode)
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestRecoverCallResultsReturnsError(t *testing.T) {
+	handler := handlers.RecoverCallResults(handlers.CallResultHandlerFunc(func(context.Context, string, ocpp.Request, ocpp.Response, any) error {
+		panic("boom")
+	}))
+
+	err := handler.HandleCallResult(context.Background(), "cs001", &ocpp201.ResetRequestJson{}, &ocpp201.ResetResponseJson{}, nil)
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestRouterUseWrapsRouteHandlers(t *testing.T) {
+	emitter := new(FakeEmitter)
+
+	router := handlers.Router{
+		Emitter:     emitter,
+		SchemaFS:    schemas.OcppSchemas,
+		OcppVersion: transport.OcppVersion201,
+		CallRoutes: map[string]handlers.CallRoute{
+			"Heartbeat": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
+				RequestSchema:  "ocpp201/HeartbeatRequest.json",
+				ResponseSchema: "ocpp201/HeartbeatResponse.json",
+				Handler: handlers.CallHandlerFunc(func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+					return nil, errors.New("should not be called")
+				}),
+			},
+		},
+	}
+
+	var calls []string
+	denyAll := func(handlers.CallHandler) handlers.CallHandler {
+		return handlers.CallHandlerFunc(func(ctx context.Context, chargeStationId string, _ ocpp.Request) (ocpp.Response, error) {
+			calls = append(calls, chargeStationId+" "+handlers.ActionFromContext(ctx))
+			return nil, transport.NewError(transport.ErrorSecurityError, errors.New("not allowed"))
+		})
+	}
+	router.Use([]handlers.CallMiddleware{denyAll}, nil)
+
+	router.Handle(context.Background(), "cs001", &heartbeatMsg)
+
+	assert.Equal(t, []string{"cs001 Heartbeat"}, calls)
+	assert.Equal(t, transport.MessageTypeCallError, emitter.msg.MessageType)
+	assert.Equal(t, transport.ErrorSecurityError, emitter.msg.ErrorCode)
+}
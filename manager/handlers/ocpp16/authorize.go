@@ -9,11 +9,14 @@ import (
 
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 )
 
 type AuthorizeHandler struct {
 	TokenStore store.TokenStore
+	// RemoteTokenAuthorizer (if any) is asked about tokens that are not in the TokenStore
+	RemoteTokenAuthorizer services.RemoteTokenAuthorizer
 }
 
 func (a AuthorizeHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
@@ -22,12 +25,20 @@ func (a AuthorizeHandler) HandleCall(ctx context.Context, chargeStationId string
 	req := request.(*types.AuthorizeJson)
 
 	status := types.AuthorizeResponseJsonIdTagInfoStatusInvalid
-	tok, err := a.TokenStore.LookupToken(ctx, req.IdTag)
+	tok, err := services.LookupToken(ctx, a.TokenStore, req.IdTag)
 	if err != nil {
 		return nil, err
 	}
 	if tok != nil {
 		status = types.AuthorizeResponseJsonIdTagInfoStatusAccepted
+	} else if a.RemoteTokenAuthorizer != nil {
+		tok, err = a.RemoteTokenAuthorizer.AuthorizeToken(ctx, req.IdTag, "")
+		if err != nil {
+			return nil, err
+		}
+		if tok != nil && tok.Valid {
+			status = types.AuthorizeResponseJsonIdTagInfoStatusAccepted
+		}
 	}
 
 	span.SetAttributes(
@@ -50,6 +50,41 @@ func TestAuthorizeKnownRfidCard(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestAuthorizeKnownEmaidWithSeparators(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetToken(context.Background(), &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "OTHER",
+		Uid:         "GBTWK012345678V",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   "NEVER",
+		LastUpdated: time.Now().Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+
+	ah := handlers.AuthorizeHandler{
+		TokenStore: engine,
+	}
+
+	req := &types.AuthorizeJson{
+		IdTag: "GB-TWK-012345678-V",
+	}
+
+	got, err := ah.HandleCall(context.Background(), "cs001", req)
+	assert.NoError(t, err)
+
+	want := &types.AuthorizeResponseJson{
+		IdTagInfo: types.AuthorizeResponseJsonIdTagInfo{
+			Status: types.AuthorizeResponseJsonIdTagInfoStatusAccepted,
+		},
+	}
+
+	assert.Equal(t, want, got)
+}
+
 func TestAuthorizeWithUnknownRfidCard(t *testing.T) {
 	engine := inmemory.NewStore(clock.RealClock{})
 
@@ -72,3 +107,39 @@ func TestAuthorizeWithUnknownRfidCard(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+type fakeRemoteTokenAuthorizer struct {
+	tokens map[string]*store.Token
+}
+
+func (f fakeRemoteTokenAuthorizer) AuthorizeToken(_ context.Context, idToken, _ string) (*store.Token, error) {
+	return f.tokens[idToken], nil
+}
+
+func TestAuthorizeRfidCardRemotely(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	ah := handlers.AuthorizeHandler{
+		TokenStore: engine,
+		RemoteTokenAuthorizer: fakeRemoteTokenAuthorizer{
+			tokens: map[string]*store.Token{
+				"MYRFIDCARD":    {Uid: "MYRFIDCARD", Valid: true},
+				"MYBLOCKEDRFID": {Uid: "MYBLOCKEDRFID", Valid: false},
+			},
+		},
+	}
+
+	tests := map[string]types.AuthorizeResponseJsonIdTagInfoStatus{
+		"MYRFIDCARD":    types.AuthorizeResponseJsonIdTagInfoStatusAccepted,
+		"MYBLOCKEDRFID": types.AuthorizeResponseJsonIdTagInfoStatusInvalid,
+		"MYBADRFID":     types.AuthorizeResponseJsonIdTagInfoStatusInvalid,
+	}
+
+	for idTag, want := range tests {
+		t.Run(idTag, func(t *testing.T) {
+			got, err := ah.HandleCall(context.Background(), "cs001", &types.AuthorizeJson{IdTag: idTag})
+			require.NoError(t, err)
+			assert.Equal(t, want, got.(*types.AuthorizeResponseJson).IdTagInfo.Status)
+		})
+	}
+}
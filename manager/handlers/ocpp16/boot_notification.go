@@ -4,6 +4,8 @@ package ocpp16
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -17,8 +19,12 @@ import (
 type BootNotificationHandler struct {
 	Clock               clock.PassiveClock
 	RuntimeDetailsStore store.ChargeStationRuntimeDetailsStore
+	ChargeStationStore  store.ChargeStationStore
 	SettingsStore       store.ChargeStationSettingsStore
-	HeartbeatInterval   int
+	Registration        handlers.RegistrationPolicy
+	ProvisioningStore   store.ChargeStationProvisioningStore
+	ProvisioningScript  *ProvisioningScript
+	HeartbeatInterval   *services.HeartbeatInterval
 }
 
 func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
@@ -27,7 +33,6 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 	req := request.(*types.BootNotificationJson)
 
 	span.SetAttributes(
-		attribute.String("request.status", string(types.BootNotificationResponseJsonStatusAccepted)),
 		attribute.String("boot.vendor", req.ChargePointVendor),
 		attribute.String("boot.model", req.ChargePointModel))
 
@@ -38,9 +43,24 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 		span.SetAttributes(attribute.String("boot.firmware", *req.FirmwareVersion))
 	}
 
-	err := b.RuntimeDetailsStore.SetChargeStationRuntimeDetails(ctx, chargeStationId, &store.ChargeStationRuntimeDetails{
+	err := handlers.RecordOcppVersion(ctx, b.RuntimeDetailsStore, chargeStationId, "1.6")
+	if err != nil {
+		return nil, err
+	}
+
+	boot := &store.ChargeStation{
 		OcppVersion: "1.6",
-	})
+		Vendor:      req.ChargePointVendor,
+		Model:       req.ChargePointModel,
+		LastBoot:    b.Clock.Now(),
+	}
+	if req.ChargePointSerialNumber != nil {
+		boot.SerialNumber = *req.ChargePointSerialNumber
+	}
+	if req.FirmwareVersion != nil {
+		boot.FirmwareVersion = *req.FirmwareVersion
+	}
+	err = handlers.RecordBootNotification(ctx, b.ChargeStationStore, chargeStationId, boot)
 	if err != nil {
 		return nil, err
 	}
@@ -68,9 +88,66 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 		}
 	}
 
+	registrationStatus, err := b.Registration.RegistrationStatus(ctx, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+	if registrationStatus != store.RegistrationStatusAccepted {
+		status := types.BootNotificationResponseJsonStatusPending
+		if registrationStatus == store.RegistrationStatusRejected {
+			status = types.BootNotificationResponseJsonStatusRejected
+		}
+		span.SetAttributes(attribute.String("request.status", string(status)))
+		return &types.BootNotificationResponseJson{
+			CurrentTime: b.Clock.Now().Format(time.RFC3339),
+			Interval:    int(b.Registration.RetryInterval.Seconds()),
+			Status:      status,
+		}, nil
+	}
+
+	pending, err := b.provisioningPending(ctx, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		span.SetAttributes(attribute.String("request.status", string(types.BootNotificationResponseJsonStatusPending)))
+		return &types.BootNotificationResponseJson{
+			CurrentTime: b.Clock.Now().Format(time.RFC3339),
+			Interval:    int(b.ProvisioningScript.PendingInterval.Seconds()),
+			Status:      types.BootNotificationResponseJsonStatusPending,
+		}, nil
+	}
+
+	span.SetAttributes(attribute.String("request.status", string(types.BootNotificationResponseJsonStatusAccepted)))
 	return &types.BootNotificationResponseJson{
 		CurrentTime: b.Clock.Now().Format(time.RFC3339),
-		Interval:    b.HeartbeatInterval,
+		Interval:    int(b.HeartbeatInterval.Get().Seconds()),
 		Status:      types.BootNotificationResponseJsonStatusAccepted,
 	}, nil
 }
+
+// provisioningPending determines whether the charge station must still run the
+// provisioning script, starting the script if the charge station has not been
+// provisioned or the last attempt failed.
+func (b BootNotificationHandler) provisioningPending(ctx context.Context, chargeStationId string) (bool, error) {
+	if !b.ProvisioningScript.enabled() || b.ProvisioningStore == nil {
+		return false, nil
+	}
+
+	provisioning, err := b.ProvisioningStore.LookupChargeStationProvisioning(ctx, chargeStationId)
+	if err != nil {
+		return false, err
+	}
+	if provisioning != nil && provisioning.Status == store.ProvisioningStatusCompleted {
+		return false, nil
+	}
+	if provisioning == nil || provisioning.Status == store.ProvisioningStatusFailed {
+		err = b.ProvisioningStore.SetChargeStationProvisioning(ctx, chargeStationId, &store.ChargeStationProvisioning{
+			Status: store.ProvisioningStatusPending,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -6,8 +6,10 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	csmsHandlers "github.com/thoughtworks/maeve-csms/manager/handlers"
 	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"k8s.io/utils/clock"
@@ -34,7 +36,7 @@ func TestBootNotificationHandler(t *testing.T) {
 		Clock:               clockTest.NewFakePassiveClock(now),
 		RuntimeDetailsStore: engine,
 		SettingsStore:       engine,
-		HeartbeatInterval:   10,
+		HeartbeatInterval:   services.NewHeartbeatInterval(10 * time.Second),
 	}
 
 	serialNumber := "cs001-1234"
@@ -65,3 +67,225 @@ func TestBootNotificationHandler(t *testing.T) {
 		assert.NotEqual(t, store.ChargeStationSettingStatusRebootRequired, v.Status)
 	}
 }
+
+func TestBootNotificationHandlerRecordsChargeStationDetails(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2023-06-15T15:05:00+01:00")
+	require.NoError(t, err)
+
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err = engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{
+		SecurityProfile: store.TLSWithBasicAuth,
+		SiteId:          "site001",
+	})
+	require.NoError(t, err)
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clockTest.NewFakePassiveClock(now),
+		RuntimeDetailsStore: engine,
+		ChargeStationStore:  engine,
+		SettingsStore:       engine,
+		HeartbeatInterval:   services.NewHeartbeatInterval(10 * time.Second),
+	}
+
+	serialNumber := "cs001-1234"
+	firmwareVersion := "1.2.3"
+	req := &types.BootNotificationJson{
+		ChargePointVendor:       "Acme",
+		ChargePointModel:        "Charger 1",
+		ChargePointSerialNumber: &serialNumber,
+		FirmwareVersion:         &firmwareVersion,
+	}
+
+	_, err = handler.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStation{
+		ChargeStationId: "cs001",
+		SecurityProfile: store.TLSWithBasicAuth,
+		OcppVersion:     "1.6",
+		Vendor:          "Acme",
+		Model:           "Charger 1",
+		SerialNumber:    "cs001-1234",
+		FirmwareVersion: "1.2.3",
+		SiteId:          "site001",
+		LastBoot:        now,
+		Version:         2,
+	}, got)
+}
+
+func TestBootNotificationHandlerStartsProvisioning(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2023-06-15T15:05:00+01:00")
+	require.NoError(t, err)
+
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clockTest.NewFakePassiveClock(now),
+		RuntimeDetailsStore: engine,
+		SettingsStore:       engine,
+		ProvisioningStore:   engine,
+		ProvisioningScript: &handlers.ProvisioningScript{
+			Steps: []handlers.ProvisioningStep{
+				{ChangeConfiguration: &types.ChangeConfigurationJson{Key: "foo", Value: "bar"}},
+			},
+			PendingInterval: 30 * time.Second,
+		},
+		HeartbeatInterval: services.NewHeartbeatInterval(10 * time.Second),
+	}
+
+	got, err := handler.HandleCall(context.Background(), "cs001", &types.BootNotificationJson{})
+	require.NoError(t, err)
+
+	assert.Equal(t, &types.BootNotificationResponseJson{
+		CurrentTime: "2023-06-15T15:05:00+01:00",
+		Status:      types.BootNotificationResponseJsonStatusPending,
+		Interval:    30,
+	}, got)
+
+	provisioning, err := engine.LookupChargeStationProvisioning(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ProvisioningStatusPending, provisioning.Status)
+	assert.Equal(t, 0, provisioning.Step)
+}
+
+func TestBootNotificationHandlerWithProvisioningInProgress(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2023-06-15T15:05:00+01:00")
+	require.NoError(t, err)
+
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clockTest.NewFakePassiveClock(now),
+		RuntimeDetailsStore: engine,
+		SettingsStore:       engine,
+		ProvisioningStore:   engine,
+		ProvisioningScript: &handlers.ProvisioningScript{
+			Steps: []handlers.ProvisioningStep{
+				{ChangeConfiguration: &types.ChangeConfigurationJson{Key: "foo", Value: "bar"}},
+				{ChangeConfiguration: &types.ChangeConfigurationJson{Key: "baz", Value: "qux"}},
+			},
+			PendingInterval: 30 * time.Second,
+		},
+		HeartbeatInterval: services.NewHeartbeatInterval(10 * time.Second),
+	}
+
+	tests := map[string]struct {
+		status     store.ProvisioningStatus
+		wantStatus types.BootNotificationResponseJsonStatus
+		wantStep   int
+	}{
+		"pending": {
+			status:     store.ProvisioningStatusPending,
+			wantStatus: types.BootNotificationResponseJsonStatusPending,
+			wantStep:   1,
+		},
+		"failed": {
+			status:     store.ProvisioningStatusFailed,
+			wantStatus: types.BootNotificationResponseJsonStatusPending,
+			wantStep:   0,
+		},
+		"completed": {
+			status:     store.ProvisioningStatusCompleted,
+			wantStatus: types.BootNotificationResponseJsonStatusAccepted,
+			wantStep:   1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := engine.SetChargeStationProvisioning(context.Background(), "cs001", &store.ChargeStationProvisioning{
+				Status: tc.status,
+				Step:   1,
+			})
+			require.NoError(t, err)
+
+			got, err := handler.HandleCall(context.Background(), "cs001", &types.BootNotificationJson{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, got.(*types.BootNotificationResponseJson).Status)
+
+			provisioning, err := engine.LookupChargeStationProvisioning(context.Background(), "cs001")
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStep, provisioning.Step)
+		})
+	}
+}
+
+func TestBootNotificationHandlerWithPendingRegistration(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2023-06-15T15:05:00+01:00")
+	require.NoError(t, err)
+
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clockTest.NewFakePassiveClock(now),
+		RuntimeDetailsStore: engine,
+		SettingsStore:       engine,
+		Registration: csmsHandlers.RegistrationPolicy{
+			Store:         engine,
+			DefaultStatus: store.RegistrationStatusPending,
+			RetryInterval: 5 * time.Minute,
+		},
+		ProvisioningStore: engine,
+		ProvisioningScript: &handlers.ProvisioningScript{
+			Steps: []handlers.ProvisioningStep{
+				{ChangeConfiguration: &types.ChangeConfigurationJson{Key: "foo", Value: "bar"}},
+			},
+			PendingInterval: 30 * time.Second,
+		},
+		HeartbeatInterval: services.NewHeartbeatInterval(10 * time.Second),
+	}
+
+	got, err := handler.HandleCall(context.Background(), "cs001", &types.BootNotificationJson{})
+	require.NoError(t, err)
+
+	want := &types.BootNotificationResponseJson{
+		CurrentTime: "2023-06-15T15:05:00+01:00",
+		Interval:    300,
+		Status:      types.BootNotificationResponseJsonStatusPending,
+	}
+	assert.Equal(t, want, got)
+
+	registration, err := engine.LookupChargeStationRegistration(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.RegistrationStatusPending, registration.Status)
+
+	// provisioning is not started until the charge station is accepted
+	provisioning, err := engine.LookupChargeStationProvisioning(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, provisioning)
+}
+
+func TestBootNotificationHandlerWithRejectedRegistration(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2023-06-15T15:05:00+01:00")
+	require.NoError(t, err)
+
+	engine := inmemory.NewStore(clock.RealClock{})
+	err = engine.SetChargeStationRegistration(context.Background(), "cs001", &store.ChargeStationRegistration{
+		Status: store.RegistrationStatusRejected,
+	})
+	require.NoError(t, err)
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clockTest.NewFakePassiveClock(now),
+		RuntimeDetailsStore: engine,
+		SettingsStore:       engine,
+		Registration: csmsHandlers.RegistrationPolicy{
+			Store:         engine,
+			RetryInterval: time.Minute,
+		},
+		HeartbeatInterval: services.NewHeartbeatInterval(10 * time.Second),
+	}
+
+	got, err := handler.HandleCall(context.Background(), "cs001", &types.BootNotificationJson{})
+	require.NoError(t, err)
+
+	want := &types.BootNotificationResponseJson{
+		CurrentTime: "2023-06-15T15:05:00+01:00",
+		Interval:    60,
+		Status:      types.BootNotificationResponseJsonStatusRejected,
+	}
+	assert.Equal(t, want, got)
+}
@@ -16,6 +16,7 @@ import (
 type ChangeConfigurationResultHandler struct {
 	SettingsStore store.ChargeStationSettingsStore
 	CallMaker     handlers.CallMaker
+	Provisioning  ProvisioningProgress
 }
 
 func (c ChangeConfigurationResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
@@ -41,6 +42,13 @@ func (c ChangeConfigurationResultHandler) HandleCallResult(ctx context.Context,
 		return fmt.Errorf("update charge station settings: %w", err)
 	}
 
+	accepted := resp.Status == ocpp16.ChangeConfigurationResponseJsonStatusAccepted ||
+		resp.Status == ocpp16.ChangeConfigurationResponseJsonStatusRebootRequired
+	err = c.Provisioning.StepCompleted(ctx, chargeStationId, req, accepted)
+	if err != nil {
+		return err
+	}
+
 	settings, err := c.SettingsStore.LookupChargeStationSettings(ctx, chargeStationId)
 	if err != nil {
 		return fmt.Errorf("lookup charge station settings: %w", err)
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ClearCacheResultHandler struct{}
+
+func (h ClearCacheResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	resp := response.(*ocpp16.ClearCacheResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(
+		attribute.String("clear_cache.status", string(resp.Status)))
+
+	return nil
+}
@@ -28,7 +28,7 @@ func (d DataTransferResultHandler) HandleCallResult(ctx context.Context, chargeS
 	if req.MessageId != nil {
 		messageId = *req.MessageId
 	}
-	slog.Info("data transfer result",
+	handlers.LoggerFromContext(ctx).Info("data transfer result",
 		slog.String("vendorId", req.VendorId), slog.String("messageId", messageId))
 
 	vendorMap, ok := d.CallResultRoutes[req.VendorId]
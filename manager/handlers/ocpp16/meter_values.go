@@ -4,6 +4,8 @@ package ocpp16
 
 import (
 	"context"
+	"errors"
+	"strconv"
 
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
@@ -15,7 +17,65 @@ type MeterValuesHandler struct {
 }
 
 func (m MeterValuesHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (response ocpp.Response, err error) {
-	// TODO: store in transaction store
+	req := request.(*types.MeterValuesJson)
+
+	if req.TransactionId != nil {
+		meterValues, err := convertMeterValuesElems(req.MeterValue)
+		if err != nil {
+			return nil, err
+		}
+
+		err = m.TransactionStore.UpdateTransaction(ctx, chargeStationId, ConvertToUUID(*req.TransactionId), meterValues)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &types.MeterValuesResponseJson{}, nil
 }
+
+func convertMeterValuesElems(meterValues []types.MeterValuesJsonMeterValueElem) ([]store.MeterValue, error) {
+	var converted []store.MeterValue
+	for _, meterValue := range meterValues {
+		var sampledValues []store.SampledValue
+		for _, sampledValue := range meterValue.SampledValue {
+			convertedSampledValue, err := convertMeterValuesSampledValue(sampledValue)
+			if err != nil {
+				return nil, err
+			}
+			sampledValues = append(sampledValues, convertedSampledValue)
+		}
+		converted = append(converted, store.MeterValue{
+			SampledValues: sampledValues,
+			Timestamp:     meterValue.Timestamp,
+		})
+	}
+	return converted, nil
+}
+
+func convertMeterValuesSampledValue(sampledValue types.MeterValuesJsonMeterValueElemSampledValueElem) (store.SampledValue, error) {
+	if sampledValue.Format != nil && *sampledValue.Format != types.MeterValuesJsonMeterValueElemSampledValueElemFormatRaw {
+		return store.SampledValue{}, errors.New("conversion from signed data not implemented")
+	}
+	value, err := strconv.ParseFloat(sampledValue.Value, 64)
+	if err != nil {
+		return store.SampledValue{}, err
+	}
+
+	var unitOfMeasure *store.UnitOfMeasure
+	if sampledValue.Unit != nil {
+		unitOfMeasure = &store.UnitOfMeasure{
+			Unit:      string(*sampledValue.Unit),
+			Multipler: 1,
+		}
+	}
+
+	return store.SampledValue{
+		Context:       (*string)(sampledValue.Context),
+		Location:      (*string)(sampledValue.Location),
+		Measurand:     (*string)(sampledValue.Measurand),
+		Phase:         (*string)(sampledValue.Phase),
+		UnitOfMeasure: unitOfMeasure,
+		Value:         value,
+	}, nil
+}
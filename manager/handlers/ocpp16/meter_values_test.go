@@ -1,3 +1,100 @@
 // SPDX-License-Identifier: Apache-2.0
 
 package ocpp16_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+)
+
+func TestMeterValuesHandlerUpdatesTransaction(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.MeterValuesHandler{
+		TransactionStore: engine,
+	}
+
+	transactionId := 42
+	measurand := types.MeterValuesJsonMeterValueElemSampledValueElemMeasurandEnergyActiveImportRegister
+	unit := types.MeterValuesJsonMeterValueElemSampledValueElemUnitWh
+	req := &types.MeterValuesJson{
+		ConnectorId:   1,
+		TransactionId: &transactionId,
+		MeterValue: []types.MeterValuesJsonMeterValueElem{
+			{
+				SampledValue: []types.MeterValuesJsonMeterValueElemSampledValueElem{
+					{
+						Measurand: &measurand,
+						Unit:      &unit,
+						Value:     "150.5",
+					},
+				},
+				Timestamp: "2023-06-15T15:06:00Z",
+			},
+		},
+	}
+
+	got, err := handler.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.MeterValuesResponseJson{}, got)
+
+	transaction, err := engine.FindTransaction(context.Background(), "cs001", handlers.ConvertToUUID(transactionId))
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+
+	measurandStr := string(measurand)
+	want := []store.MeterValue{
+		{
+			SampledValues: []store.SampledValue{
+				{
+					Measurand: &measurandStr,
+					UnitOfMeasure: &store.UnitOfMeasure{
+						Unit:      "Wh",
+						Multipler: 1,
+					},
+					Value: 150.5,
+				},
+			},
+			Timestamp: "2023-06-15T15:06:00Z",
+		},
+	}
+	assert.Equal(t, want, transaction.MeterValues)
+}
+
+func TestMeterValuesHandlerWithoutTransaction(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.MeterValuesHandler{
+		TransactionStore: engine,
+	}
+
+	req := &types.MeterValuesJson{
+		ConnectorId: 1,
+		MeterValue: []types.MeterValuesJsonMeterValueElem{
+			{
+				SampledValue: []types.MeterValuesJsonMeterValueElemSampledValueElem{
+					{
+						Value: "150.5",
+					},
+				},
+				Timestamp: "2023-06-15T15:06:00Z",
+			},
+		},
+	}
+
+	got, err := handler.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.MeterValuesResponseJson{}, got)
+
+	transactions, err := engine.Transactions(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, transactions)
+}
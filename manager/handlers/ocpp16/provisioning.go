@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// ProvisioningStep is a single call made to a charge station as part of the
+// provisioning script: exactly one of ChangeConfiguration or TriggerMessage is set.
+type ProvisioningStep struct {
+	ChangeConfiguration *types.ChangeConfigurationJson
+	TriggerMessage      *types.TriggerMessageJson
+}
+
+func (p ProvisioningStep) Request() ocpp.Request {
+	if p.ChangeConfiguration != nil {
+		return p.ChangeConfiguration
+	}
+	return p.TriggerMessage
+}
+
+// matches determines whether the request was sent for this step.
+func (p ProvisioningStep) matches(request ocpp.Request) bool {
+	switch req := request.(type) {
+	case *types.ChangeConfigurationJson:
+		return p.ChangeConfiguration != nil &&
+			p.ChangeConfiguration.Key == req.Key &&
+			p.ChangeConfiguration.Value == req.Value
+	case *types.TriggerMessageJson:
+		return p.TriggerMessage != nil &&
+			p.TriggerMessage.RequestedMessage == req.RequestedMessage &&
+			equalConnectorId(p.TriggerMessage.ConnectorId, req.ConnectorId)
+	}
+	return false
+}
+
+func equalConnectorId(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// ProvisioningScript is the sequence of calls made to a charge station that boots
+// for the first time. The charge station is kept in the Pending registration state
+// until every step has been accepted.
+type ProvisioningScript struct {
+	Steps []ProvisioningStep
+	// PendingInterval is the interval returned to a Pending charge station after
+	// which it should send another BootNotification
+	PendingInterval time.Duration
+	// RetryAfter is the time to wait for the response to a step before it is resent
+	RetryAfter time.Duration
+}
+
+func (p *ProvisioningScript) enabled() bool {
+	return p != nil && len(p.Steps) > 0
+}
+
+// ProvisioningProgress advances a charge station through the provisioning script
+// when the charge station responds to a call made as part of the script.
+type ProvisioningProgress struct {
+	Clock             clock.PassiveClock
+	ProvisioningStore store.ChargeStationProvisioningStore
+	Script            *ProvisioningScript
+	CallMaker         handlers.CallMaker
+}
+
+// StepCompleted records the outcome of a call: if the call was made for the current
+// step then provisioning either moves on to the next step or, if the charge station
+// did not accept the call, fails. A failed script is restarted the next time the
+// charge station boots.
+func (p ProvisioningProgress) StepCompleted(ctx context.Context, chargeStationId string, request ocpp.Request, accepted bool) error {
+	if !p.Script.enabled() || p.ProvisioningStore == nil {
+		return nil
+	}
+
+	provisioning, err := p.ProvisioningStore.LookupChargeStationProvisioning(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station provisioning: %w", err)
+	}
+	if provisioning == nil || provisioning.Status != store.ProvisioningStatusPending ||
+		provisioning.Step >= len(p.Script.Steps) || !p.Script.Steps[provisioning.Step].matches(request) {
+		return nil
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("provisioning.step", provisioning.Step))
+
+	var next ocpp.Request
+	if !accepted {
+		provisioning.Status = store.ProvisioningStatusFailed
+	} else {
+		provisioning.Step++
+		if provisioning.Step == len(p.Script.Steps) {
+			provisioning.Status = store.ProvisioningStatusCompleted
+			// ask the charge station to boot again so that it is accepted without waiting
+			// for the pending interval to elapse
+			next = &types.TriggerMessageJson{
+				RequestedMessage: types.TriggerMessageJsonRequestedMessageBootNotification,
+			}
+		} else {
+			provisioning.SendAfter = p.Clock.Now().Add(p.Script.RetryAfter)
+			next = p.Script.Steps[provisioning.Step].Request()
+		}
+	}
+	span.SetAttributes(attribute.String("provisioning.status", string(provisioning.Status)))
+
+	err = p.ProvisioningStore.SetChargeStationProvisioning(ctx, chargeStationId, provisioning)
+	if err != nil {
+		return fmt.Errorf("set charge station provisioning: %w", err)
+	}
+
+	if next != nil && p.CallMaker != nil {
+		return p.CallMaker.Send(ctx, chargeStationId, next)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type recordingCallMaker struct {
+	requests []ocpp.Request
+}
+
+func (r *recordingCallMaker) Send(_ context.Context, _ string, request ocpp.Request) error {
+	r.requests = append(r.requests, request)
+	return nil
+}
+
+func newProvisioningProgress(engine store.Engine, callMaker *recordingCallMaker) handlers.ProvisioningProgress {
+	connectorId := 1
+	return handlers.ProvisioningProgress{
+		Clock:             clockTest.NewFakePassiveClock(time.Now()),
+		ProvisioningStore: engine,
+		CallMaker:         callMaker,
+		Script: &handlers.ProvisioningScript{
+			Steps: []handlers.ProvisioningStep{
+				{ChangeConfiguration: &types.ChangeConfigurationJson{Key: "MeterValueSampleInterval", Value: "60"}},
+				{TriggerMessage: &types.TriggerMessageJson{
+					RequestedMessage: types.TriggerMessageJsonRequestedMessageStatusNotification,
+					ConnectorId:      &connectorId,
+				}},
+			},
+			RetryAfter: time.Minute,
+		},
+	}
+}
+
+func TestChangeConfigurationResultAdvancesProvisioning(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	callMaker := &recordingCallMaker{}
+	progress := newProvisioningProgress(engine, callMaker)
+
+	err := engine.SetChargeStationProvisioning(context.Background(), "cs001", &store.ChargeStationProvisioning{
+		Status: store.ProvisioningStatusPending,
+	})
+	require.NoError(t, err)
+
+	handler := handlers.ChangeConfigurationResultHandler{
+		SettingsStore: engine,
+		CallMaker:     callMaker,
+		Provisioning:  progress,
+	}
+
+	err = handler.HandleCallResult(context.Background(), "cs001",
+		&types.ChangeConfigurationJson{Key: "MeterValueSampleInterval", Value: "60"},
+		&types.ChangeConfigurationResponseJson{Status: types.ChangeConfigurationResponseJsonStatusAccepted}, nil)
+	require.NoError(t, err)
+
+	provisioning, err := engine.LookupChargeStationProvisioning(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ProvisioningStatusPending, provisioning.Status)
+	assert.Equal(t, 1, provisioning.Step)
+	assert.Equal(t, progress.Clock.Now().Add(time.Minute), provisioning.SendAfter)
+
+	require.Len(t, callMaker.requests, 1)
+	assert.Equal(t, progress.Script.Steps[1].TriggerMessage, callMaker.requests[0])
+}
+
+func TestTriggerMessageResultCompletesProvisioning(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	callMaker := &recordingCallMaker{}
+	progress := newProvisioningProgress(engine, callMaker)
+
+	err := engine.SetChargeStationProvisioning(context.Background(), "cs001", &store.ChargeStationProvisioning{
+		Status: store.ProvisioningStatusPending,
+		Step:   1,
+	})
+	require.NoError(t, err)
+
+	handler := handlers.TriggerMessageResultHandler{
+		Provisioning: progress,
+	}
+
+	connectorId := 1
+	err = handler.HandleCallResult(context.Background(), "cs001",
+		&types.TriggerMessageJson{
+			RequestedMessage: types.TriggerMessageJsonRequestedMessageStatusNotification,
+			ConnectorId:      &connectorId,
+		},
+		&types.TriggerMessageResponseJson{Status: types.TriggerMessageResponseJsonStatusAccepted}, nil)
+	require.NoError(t, err)
+
+	provisioning, err := engine.LookupChargeStationProvisioning(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ProvisioningStatusCompleted, provisioning.Status)
+
+	require.Len(t, callMaker.requests, 1)
+	assert.Equal(t, &types.TriggerMessageJson{
+		RequestedMessage: types.TriggerMessageJsonRequestedMessageBootNotification,
+	}, callMaker.requests[0])
+}
+
+func TestRejectedStepFailsProvisioning(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	callMaker := &recordingCallMaker{}
+	progress := newProvisioningProgress(engine, callMaker)
+
+	err := engine.SetChargeStationProvisioning(context.Background(), "cs001", &store.ChargeStationProvisioning{
+		Status: store.ProvisioningStatusPending,
+	})
+	require.NoError(t, err)
+
+	err = progress.StepCompleted(context.Background(), "cs001",
+		&types.ChangeConfigurationJson{Key: "MeterValueSampleInterval", Value: "60"}, false)
+	require.NoError(t, err)
+
+	provisioning, err := engine.LookupChargeStationProvisioning(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ProvisioningStatusFailed, provisioning.Status)
+	assert.Empty(t, callMaker.requests)
+}
+
+func TestResultForOtherCallDoesNotAdvanceProvisioning(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	callMaker := &recordingCallMaker{}
+	progress := newProvisioningProgress(engine, callMaker)
+
+	err := engine.SetChargeStationProvisioning(context.Background(), "cs001", &store.ChargeStationProvisioning{
+		Status: store.ProvisioningStatusPending,
+	})
+	require.NoError(t, err)
+
+	err = progress.StepCompleted(context.Background(), "cs001",
+		&types.ChangeConfigurationJson{Key: "MeterValueSampleInterval", Value: "30"}, true)
+	require.NoError(t, err)
+
+	provisioning, err := engine.LookupChargeStationProvisioning(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ProvisioningStatusPending, provisioning.Status)
+	assert.Equal(t, 0, provisioning.Step)
+	assert.Empty(t, callMaker.requests)
+}
@@ -17,10 +17,10 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
 	"io/fs"
 	"k8s.io/utils/clock"
 	"reflect"
-	"time"
 )
 
 func NewRouter(emitter transport.Emitter,
@@ -29,15 +29,39 @@ func NewRouter(emitter transport.Emitter,
 	certValidationService services.CertificateValidationService,
 	chargeStationCertProvider services.ChargeStationCertificateProvider,
 	contractCertProvider services.ContractCertificateProvider,
-	heartbeatInterval time.Duration,
-	schemaFS fs.FS) transport.MessageHandler {
+	heartbeatInterval *services.HeartbeatInterval,
+	registrationPolicy handlers.RegistrationPolicy,
+	connectorStatusListener handlers.ConnectorStatusListener,
+	transactionListener handlers.TransactionListener,
+	securityEventListener handlers.SecurityEventListener,
+	remoteTokenAuthorizer services.RemoteTokenAuthorizer,
+	commandResultListener handlers.CommandResultListener,
+	provisioningScript *ProvisioningScript,
+	deadLetters transport.DeadLetterEmitter,
+	callResponses *handlers.CallResponses,
+	poisonMessages *handlers.PoisonMessages,
+	actionFlags *handlers.ActionFlags,
+	soapTranslation bool,
+	schemaFS fs.FS,
+	callMiddleware ...handlers.CallMiddleware) transport.MessageHandler {
 
 	standardCallMaker := NewCallMaker(emitter)
+	provisioning := ProvisioningProgress{
+		Clock:             clk,
+		ProvisioningStore: engine,
+		Script:            provisioningScript,
+		CallMaker:         standardCallMaker,
+	}
 
-	return &handlers.Router{
-		Emitter:     emitter,
-		SchemaFS:    schemaFS,
-		OcppVersion: transport.OcppVersion16,
+	router := &handlers.Router{
+		Emitter:         emitter,
+		SchemaFS:        schemaFS,
+		DeadLetters:     deadLetters,
+		CallResponses:   callResponses,
+		PoisonMessages:  poisonMessages,
+		ActionFlags:     actionFlags,
+		SoapTranslation: soapTranslation,
+		OcppVersion:     transport.OcppVersion16,
 		CallRoutes: map[string]handlers.CallRoute{
 			"BootNotification": {
 				NewRequest:     func() ocpp.Request { return new(ocpp16.BootNotificationJson) },
@@ -46,8 +70,12 @@ func NewRouter(emitter transport.Emitter,
 				Handler: BootNotificationHandler{
 					Clock:               clk,
 					RuntimeDetailsStore: engine,
+					ChargeStationStore:  engine,
 					SettingsStore:       engine,
-					HeartbeatInterval:   int(heartbeatInterval.Seconds()),
+					Registration:        registrationPolicy,
+					ProvisioningStore:   engine,
+					ProvisioningScript:  provisioningScript,
+					HeartbeatInterval:   heartbeatInterval,
 				},
 			},
 			"Heartbeat": {
@@ -62,14 +90,25 @@ func NewRouter(emitter transport.Emitter,
 				NewRequest:     func() ocpp.Request { return new(ocpp16.StatusNotificationJson) },
 				RequestSchema:  "ocpp16/StatusNotification.json",
 				ResponseSchema: "ocpp16/StatusNotificationResponse.json",
-				Handler:        handlers.CallHandlerFunc(StatusNotificationHandler),
+				Handler: StatusNotificationHandler{
+					ConnectorStatus: handlers.ConnectorStatusRecorder{
+						Clock:    clk,
+						Store:    engine,
+						Listener: connectorStatusListener,
+						Evses: handlers.EvseRecorder{
+							Clock: clk,
+							Store: engine,
+						},
+					},
+				},
 			},
 			"Authorize": {
 				NewRequest:     func() ocpp.Request { return new(ocpp16.AuthorizeJson) },
 				RequestSchema:  "ocpp16/Authorize.json",
 				ResponseSchema: "ocpp16/AuthorizeResponse.json",
 				Handler: AuthorizeHandler{
-					TokenStore: engine,
+					TokenStore:            engine,
+					RemoteTokenAuthorizer: remoteTokenAuthorizer,
 				},
 			},
 			"StartTransaction": {
@@ -80,6 +119,7 @@ func NewRouter(emitter transport.Emitter,
 					Clock:            clk,
 					TokenStore:       engine,
 					TransactionStore: engine,
+					Listener:         transactionListener,
 				},
 			},
 			"StopTransaction": {
@@ -90,6 +130,7 @@ func NewRouter(emitter transport.Emitter,
 					Clock:            clk,
 					TokenStore:       engine,
 					TransactionStore: engine,
+					Listener:         transactionListener,
 				},
 			},
 			"MeterValues": {
@@ -104,7 +145,9 @@ func NewRouter(emitter transport.Emitter,
 				NewRequest:     func() ocpp.Request { return new(ocpp16.SecurityEventNotificationJson) },
 				RequestSchema:  "ocpp16/SecurityEventNotification.json",
 				ResponseSchema: "ocpp16/SecurityEventNotificationResponse.json",
-				Handler:        SecurityEventNotificationHandler{},
+				Handler: SecurityEventNotificationHandler{
+					Listener: securityEventListener,
+				},
 			},
 			"DataTransfer": {
 				NewRequest:     func() ocpp.Request { return new(ocpp16.DataTransferJson) },
@@ -120,8 +163,9 @@ func NewRouter(emitter transport.Emitter,
 								ResponseSchema: "ocpp201/AuthorizeResponse.json",
 								Handler: handlers201.AuthorizeHandler{
 									TokenAuthService: &services.OcppTokenAuthService{
-										Clock:      clk,
-										TokenStore: engine,
+										Clock:                 clk,
+										TokenStore:            engine,
+										RemoteTokenAuthorizer: remoteTokenAuthorizer,
 									},
 									CertificateValidationService: certValidationService,
 								},
@@ -149,6 +193,7 @@ func NewRouter(emitter transport.Emitter,
 								ResponseSchema: "ocpp201/Get15118EVCertificateResponse.json",
 								Handler: handlers201.Get15118EvCertificateHandler{
 									ContractCertificateProvider: contractCertProvider,
+									RuntimeDetailsStore:         engine,
 								},
 							},
 						},
@@ -160,8 +205,9 @@ func NewRouter(emitter transport.Emitter,
 								Handler: handlersHasToBe.AuthorizeHandler{
 									Handler201: handlers201.AuthorizeHandler{
 										TokenAuthService: &services.OcppTokenAuthService{
-											Clock:      clk,
-											TokenStore: engine,
+											Clock:                 clk,
+											TokenStore:            engine,
+											RemoteTokenAuthorizer: remoteTokenAuthorizer,
 										},
 										CertificateValidationService: certValidationService,
 									},
@@ -184,6 +230,7 @@ func NewRouter(emitter transport.Emitter,
 								Handler: handlersHasToBe.Get15118EvCertificateHandler{
 									Handler201: handlers201.Get15118EvCertificateHandler{
 										ContractCertificateProvider: contractCertProvider,
+										RuntimeDetailsStore:         engine,
 									},
 								},
 							},
@@ -261,17 +308,71 @@ func NewRouter(emitter transport.Emitter,
 				Handler: ChangeConfigurationResultHandler{
 					SettingsStore: engine,
 					CallMaker:     standardCallMaker,
+					Provisioning:  provisioning,
 				},
 			},
+			"ClearCache": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.ClearCacheJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.ClearCacheResponseJson) },
+				RequestSchema:  "ocpp16/ClearCache.json",
+				ResponseSchema: "ocpp16/ClearCacheResponse.json",
+				Handler:        ClearCacheResultHandler{},
+			},
+			"Reset": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.ResetJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.ResetResponseJson) },
+				RequestSchema:  "ocpp16/Reset.json",
+				ResponseSchema: "ocpp16/ResetResponse.json",
+				Handler:        ResetResultHandler{},
+			},
 			"TriggerMessage": {
 				NewRequest:     func() ocpp.Request { return new(ocpp16.TriggerMessageJson) },
 				NewResponse:    func() ocpp.Response { return new(ocpp16.TriggerMessageResponseJson) },
 				RequestSchema:  "ocpp16/TriggerMessage.json",
 				ResponseSchema: "ocpp16/TriggerMessageResponse.json",
-				Handler:        TriggerMessageResultHandler{},
+				Handler: TriggerMessageResultHandler{
+					Provisioning: provisioning,
+				},
+			},
+			"UnlockConnector": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.UnlockConnectorJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.UnlockConnectorResponseJson) },
+				RequestSchema:  "ocpp16/UnlockConnector.json",
+				ResponseSchema: "ocpp16/UnlockConnectorResponse.json",
+				Handler: UnlockConnectorResultHandler{
+					Listener: commandResultListener,
+				},
+			},
+			"UpdateFirmware": {
+				NewRequest:     func() ocpp.Request { return new(ocpp16.UpdateFirmwareJson) },
+				NewResponse:    func() ocpp.Response { return new(ocpp16.UpdateFirmwareResponseJson) },
+				RequestSchema:  "ocpp16/UpdateFirmware.json",
+				ResponseSchema: "ocpp16/UpdateFirmwareResponse.json",
+				Handler:        UpdateFirmwareResultHandler{},
 			},
 		},
 	}
+
+	router.Use(
+		append([]handlers.CallMiddleware{
+			handlers.CallMetrics(transport.OcppVersion16),
+			handlers.RecoverCalls,
+		}, callMiddleware...),
+		[]handlers.CallResultMiddleware{
+			handlers.CallResultMetrics(transport.OcppVersion16),
+			handlers.RecoverCallResults,
+		})
+
+	if actionFlags != nil {
+		actionFlags.Register(router.OcppVersion, router.Actions()...)
+	}
+
+	// an invalid schema is reported again when a message for the action is handled
+	if err := router.Precompile(); err != nil {
+		slog.Warn("unable to precompile schemas", slog.String("ocppVersion", string(router.OcppVersion)), "err", err)
+	}
+
+	return router
 }
 
 func NewCallMaker(e transport.Emitter) *handlers.OcppCallMaker {
@@ -280,8 +381,12 @@ func NewCallMaker(e transport.Emitter) *handlers.OcppCallMaker {
 		OcppVersion: transport.OcppVersion16,
 		Actions: map[reflect.Type]string{
 			reflect.TypeOf(&ocpp16.ChangeConfigurationJson{}):    "ChangeConfiguration",
+			reflect.TypeOf(&ocpp16.ClearCacheJson{}):             "ClearCache",
+			reflect.TypeOf(&ocpp16.ResetJson{}):                  "Reset",
 			reflect.TypeOf(&ocpp16.TriggerMessageJson{}):         "TriggerMessage",
 			reflect.TypeOf(&ocpp16.RemoteStartTransactionJson{}): "RemoteStartTransaction",
+			reflect.TypeOf(&ocpp16.UnlockConnectorJson{}):        "UnlockConnector",
+			reflect.TypeOf(&ocpp16.UpdateFirmwareJson{}):         "UpdateFirmware",
 		},
 	}
 }
@@ -339,9 +444,11 @@ func (d DataTransferCallMaker) Send(ctx context.Context, chargeStationId string,
 		return fmt.Errorf("marshaling data transfer request: %w", err)
 	}
 
+	// the message ids are time ordered so that the command audit log lists the calls in
+	// the order they were sent
 	msg := &transport.Message{
 		MessageType:    transport.MessageTypeCall,
-		MessageId:      uuid.New().String(),
+		MessageId:      uuid.Must(uuid.NewV7()).String(),
 		Action:         "DataTransfer",
 		RequestPayload: dataTransferBytes,
 	}
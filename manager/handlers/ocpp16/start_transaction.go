@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"golang.org/x/exp/slog"
 	"k8s.io/utils/clock"
@@ -19,16 +21,17 @@ type StartTransactionHandler struct {
 	Clock            clock.PassiveClock
 	TokenStore       store.TokenStore
 	TransactionStore store.TransactionStore
+	Listener         handlers.TransactionListener
 }
 
 func (t StartTransactionHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
 	req := request.(*types.StartTransactionJson)
 
-	slog.Info("starting transaction", slog.Any("request", req))
+	handlers.LoggerFromContext(ctx).Info("starting transaction", slog.Any("request", req))
 
 	transactionId := -1
 	status := types.StartTransactionResponseJsonIdTagInfoStatusInvalid
-	tok, err := t.TokenStore.LookupToken(ctx, req.IdTag)
+	tok, err := services.LookupToken(ctx, t.TokenStore, req.IdTag)
 	if err != nil {
 		return nil, err
 	}
@@ -61,6 +64,9 @@ func (t StartTransactionHandler) HandleCall(ctx context.Context, chargeStationId
 	if err != nil {
 		return nil, err
 	}
+	if tok != nil {
+		handlers.NotifyTransactionChanged(ctx, t.TransactionStore, t.Listener, chargeStationId, transactionUuid)
+	}
 
 	return &types.StartTransactionResponseJson{
 		IdTagInfo: types.StartTransactionResponseJsonIdTagInfo{
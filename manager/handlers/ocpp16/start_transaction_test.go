@@ -99,6 +99,7 @@ func TestStartTransaction(t *testing.T) {
 		EndedSeqNo:        0,
 		UpdatedSeqNoCount: 0,
 		Offline:           false,
+		Version:           1,
 	}
 
 	assert.Equal(t, expected, found)
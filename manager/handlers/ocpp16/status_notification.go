@@ -4,6 +4,7 @@ package ocpp16
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
@@ -11,7 +12,11 @@ import (
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
 )
 
-func StatusNotificationHandler(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+type StatusNotificationHandler struct {
+	ConnectorStatus handlers.ConnectorStatusRecorder
+}
+
+func (s StatusNotificationHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
 	span := trace.SpanFromContext(ctx)
 
 	req := request.(*types.StatusNotificationJson)
@@ -20,5 +25,10 @@ func StatusNotificationHandler(ctx context.Context, chargeStationId string, requ
 		attribute.Int("status.connector_id", req.ConnectorId),
 		attribute.String("status.connector_status", string(req.Status)))
 
+	err := s.ConnectorStatus.Record(ctx, chargeStationId, 0, req.ConnectorId, string(req.Status))
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.StatusNotificationResponseJson{}, nil
 }
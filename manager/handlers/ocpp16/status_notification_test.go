@@ -5,12 +5,28 @@ package ocpp16_test
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
-	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 	"testing"
+	"time"
 )
 
 func TestStatusNotificationHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	handler := ocpp16.StatusNotificationHandler{
+		ConnectorStatus: handlers.ConnectorStatusRecorder{
+			Clock: clockTest.NewFakePassiveClock(now),
+			Store: engine,
+		},
+	}
+
 	timestamp := "2023-05-01T01:00:00+01:00"
 	req := &types.StatusNotificationJson{
 		Timestamp:   &timestamp,
@@ -19,10 +35,22 @@ func TestStatusNotificationHandler(t *testing.T) {
 		Status:      types.StatusNotificationJsonStatusPreparing,
 	}
 
-	got, err := handlers.StatusNotificationHandler(context.Background(), "cs001", req)
+	got, err := handler.HandleCall(context.Background(), "cs001", req)
 	assert.NoError(t, err)
 
 	want := &types.StatusNotificationResponseJson{}
 
 	assert.Equal(t, want, got)
+
+	statuses, err := engine.ListConnectorStatuses(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ConnectorStatus{
+		{
+			ChargeStationId: "cs001",
+			EvseId:          0,
+			ConnectorId:     2,
+			Status:          "Preparing",
+			LastUpdated:     now,
+		},
+	}, statuses)
 }
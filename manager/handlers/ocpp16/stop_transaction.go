@@ -8,8 +8,10 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"golang.org/x/exp/slog"
 	"k8s.io/utils/clock"
@@ -19,6 +21,7 @@ type StopTransactionHandler struct {
 	Clock            clock.PassiveClock
 	TokenStore       store.TokenStore
 	TransactionStore store.TransactionStore
+	Listener         handlers.TransactionListener
 }
 
 func (s StopTransactionHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (response ocpp.Response, err error) {
@@ -29,12 +32,12 @@ func (s StopTransactionHandler) HandleCall(ctx context.Context, chargeStationId
 		reason = string(*req.Reason)
 	}
 	transactionId := ConvertToUUID(req.TransactionId)
-	slog.Info("stopping transaction", slog.String("transactionId", transactionId), slog.String("reason", reason))
+	handlers.LoggerFromContext(ctx).Info("stopping transaction", slog.String("transactionId", transactionId), slog.String("reason", reason))
 
 	var idTagInfo *types.StopTransactionResponseJsonIdTagInfo
 	if req.IdTag != nil {
 		status := types.StopTransactionResponseJsonIdTagInfoStatusInvalid
-		tok, err := s.TokenStore.LookupToken(ctx, *req.IdTag)
+		tok, err := services.LookupToken(ctx, s.TokenStore, *req.IdTag)
 		if err != nil {
 			return nil, err
 		}
@@ -76,6 +79,7 @@ func (s StopTransactionHandler) HandleCall(ctx context.Context, chargeStationId
 	if err != nil {
 		return nil, err
 	}
+	handlers.NotifyTransactionChanged(ctx, s.TransactionStore, s.Listener, chargeStationId, transactionId)
 
 	return &types.StopTransactionResponseJson{
 		IdTagInfo: idTagInfo,
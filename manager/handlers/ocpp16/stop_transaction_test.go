@@ -154,6 +154,7 @@ func TestStopTransactionHandler(t *testing.T) {
 		EndedSeqNo:        1,
 		UpdatedSeqNoCount: 0,
 		Offline:           false,
+		Version:           2,
 	}
 
 	assert.Equal(t, expected, found)
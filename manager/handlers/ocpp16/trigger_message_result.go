@@ -10,7 +10,9 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-type TriggerMessageResultHandler struct{}
+type TriggerMessageResultHandler struct {
+	Provisioning ProvisioningProgress
+}
 
 func (c TriggerMessageResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*ocpp16.TriggerMessageJson)
@@ -26,5 +28,6 @@ func (c TriggerMessageResultHandler) HandleCallResult(ctx context.Context, charg
 		span.SetAttributes(attribute.Int("trigger.connector_id", *req.ConnectorId))
 	}
 
-	return nil
+	return c.Provisioning.StepCompleted(ctx, chargeStationId, req,
+		resp.Status == ocpp16.TriggerMessageResponseJsonStatusAccepted)
 }
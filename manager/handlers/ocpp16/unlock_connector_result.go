@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"strconv"
+)
+
+type UnlockConnectorResultHandler struct {
+	Listener handlers.CommandResultListener
+}
+
+func (u UnlockConnectorResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*ocpp16.UnlockConnectorJson)
+	resp := response.(*ocpp16.UnlockConnectorResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(
+		attribute.Int("unlock.connector_id", req.ConnectorId),
+		attribute.String("unlock.status", string(resp.Status)))
+
+	if u.Listener == nil {
+		return nil
+	}
+
+	// the charge station has already acted on the call, so failures are logged rather
+	// than returned
+	err := u.Listener.CommandResult(ctx, chargeStationId, "UnlockConnector", strconv.Itoa(req.ConnectorId), string(resp.Status))
+	if err != nil {
+		handlers.LoggerFromContext(ctx).Warn("unable to report unlock connector result", slog.Int("connectorId", req.ConnectorId), "err", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"testing"
+)
+
+type commandResult struct {
+	chargeStationId, action, reference, status string
+}
+
+type fakeCommandResultListener struct {
+	results []commandResult
+}
+
+func (f *fakeCommandResultListener) CommandResult(_ context.Context, chargeStationId, action, reference, status string) error {
+	f.results = append(f.results, commandResult{chargeStationId, action, reference, status})
+	return nil
+}
+
+func TestUnlockConnectorResultHandler(t *testing.T) {
+	listener := &fakeCommandResultListener{}
+	handler := handlers.UnlockConnectorResultHandler{
+		Listener: listener,
+	}
+
+	req := &types.UnlockConnectorJson{
+		ConnectorId: 2,
+	}
+	resp := &types.UnlockConnectorResponseJson{
+		Status: types.UnlockConnectorResponseJsonStatusUnlocked,
+	}
+
+	err := handler.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []commandResult{{"cs001", "UnlockConnector", "2", "Unlocked"}}, listener.results)
+}
+
+func TestUnlockConnectorResultHandlerWithoutListener(t *testing.T) {
+	handler := handlers.UnlockConnectorResultHandler{}
+
+	err := handler.HandleCallResult(context.Background(), "cs001",
+		&types.UnlockConnectorJson{ConnectorId: 1},
+		&types.UnlockConnectorResponseJson{Status: types.UnlockConnectorResponseJsonStatusUnlockFailed}, nil)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UpdateFirmwareResultHandler handles the response to an UpdateFirmware call: the
+// response is empty, the progress of the update is reported by the charge station
+// with FirmwareStatusNotifications
+type UpdateFirmwareResultHandler struct{}
+
+func (h UpdateFirmwareResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*ocpp16.UpdateFirmwareJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(
+		attribute.String("update_firmware.location", req.Location),
+		attribute.String("update_firmware.retrieve_date", req.RetrieveDate))
+
+	return nil
+}
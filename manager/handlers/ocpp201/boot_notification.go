@@ -4,6 +4,8 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -17,7 +19,10 @@ import (
 type BootNotificationHandler struct {
 	Clock               clock.PassiveClock
 	RuntimeDetailsStore store.ChargeStationRuntimeDetailsStore
-	HeartbeatInterval   int
+	ChargeStationStore  store.ChargeStationStore
+	Registration        handlers.RegistrationPolicy
+	HeartbeatInterval   *services.HeartbeatInterval
+	OcppVersion         string // the version recorded for the charge station, defaults to "2.0.1"
 }
 
 func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
@@ -26,7 +31,6 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 	req := request.(*types.BootNotificationRequestJson)
 
 	span.SetAttributes(
-		attribute.String("request.status", string(types.RegistrationStatusEnumTypeAccepted)),
 		attribute.String("boot.reason", string(req.Reason)),
 		attribute.String("boot.vendor", req.ChargingStation.VendorName),
 		attribute.String("boot.model", req.ChargingStation.Model))
@@ -38,16 +42,48 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 		span.SetAttributes(attribute.String("boot.firmware", *req.ChargingStation.FirmwareVersion))
 	}
 
-	err := b.RuntimeDetailsStore.SetChargeStationRuntimeDetails(ctx, chargeStationId, &store.ChargeStationRuntimeDetails{
-		OcppVersion: "2.0.1",
-	})
+	ocppVersion := b.OcppVersion
+	if ocppVersion == "" {
+		ocppVersion = "2.0.1"
+	}
+
+	err := handlers.RecordOcppVersion(ctx, b.RuntimeDetailsStore, chargeStationId, ocppVersion)
 	if err != nil {
 		return nil, err
 	}
 
+	boot := &store.ChargeStation{
+		OcppVersion: ocppVersion,
+		Vendor:      req.ChargingStation.VendorName,
+		Model:       req.ChargingStation.Model,
+		LastBoot:    b.Clock.Now(),
+	}
+	if req.ChargingStation.SerialNumber != nil {
+		boot.SerialNumber = *req.ChargingStation.SerialNumber
+	}
+	if req.ChargingStation.FirmwareVersion != nil {
+		boot.FirmwareVersion = *req.ChargingStation.FirmwareVersion
+	}
+	err = handlers.RecordBootNotification(ctx, b.ChargeStationStore, chargeStationId, boot)
+	if err != nil {
+		return nil, err
+	}
+
+	registrationStatus, err := b.Registration.RegistrationStatus(ctx, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+
+	status := types.RegistrationStatusEnumType(registrationStatus)
+	interval := int(b.HeartbeatInterval.Get().Seconds())
+	if registrationStatus != store.RegistrationStatusAccepted {
+		interval = int(b.Registration.RetryInterval.Seconds())
+	}
+	span.SetAttributes(attribute.String("request.status", string(status)))
+
 	return &types.BootNotificationResponseJson{
 		CurrentTime: b.Clock.Now().Format(time.RFC3339),
-		Interval:    b.HeartbeatInterval,
-		Status:      types.RegistrationStatusEnumTypeAccepted,
+		Interval:    interval,
+		Status:      status,
 	}, nil
 }
@@ -6,8 +6,10 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	csmsHandlers "github.com/thoughtworks/maeve-csms/manager/handlers"
 	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"k8s.io/utils/clock"
@@ -29,7 +31,7 @@ func TestBootNotificationHandler(t *testing.T) {
 	handler := handlers.BootNotificationHandler{
 		Clock:               clockTest.NewFakePassiveClock(now),
 		RuntimeDetailsStore: engine,
-		HeartbeatInterval:   10,
+		HeartbeatInterval:   services.NewHeartbeatInterval(10 * time.Second),
 	}
 
 	req := &types.BootNotificationRequestJson{
@@ -57,3 +59,113 @@ func TestBootNotificationHandler(t *testing.T) {
 		OcppVersion: "2.0.1",
 	}, *details)
 }
+
+func TestBootNotificationHandlerRecordsChargeStationDetails(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2023-06-15T15:05:00+01:00")
+	require.NoError(t, err)
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.BootNotificationHandler{
+		Clock:               clockTest.NewFakePassiveClock(now),
+		RuntimeDetailsStore: engine,
+		ChargeStationStore:  engine,
+		HeartbeatInterval:   services.NewHeartbeatInterval(10 * time.Second),
+	}
+
+	req := &types.BootNotificationRequestJson{
+		ChargingStation: types.ChargingStationType{
+			VendorName:      "Acme",
+			Model:           "testy",
+			SerialNumber:    makePtr("cs001"),
+			FirmwareVersion: makePtr("1.2.3"),
+		},
+		Reason: types.BootReasonEnumTypePowerUp,
+	}
+
+	_, err = handler.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStation{
+		ChargeStationId: "cs001",
+		OcppVersion:     "2.0.1",
+		Vendor:          "Acme",
+		Model:           "testy",
+		SerialNumber:    "cs001",
+		FirmwareVersion: "1.2.3",
+		LastBoot:        now,
+		Version:         1,
+	}, got)
+}
+
+func TestBootNotificationHandlerWithRegistration(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2023-06-15T15:05:00+01:00")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		registration  *store.ChargeStationRegistration
+		defaultStatus store.RegistrationStatus
+		wantStatus    types.RegistrationStatusEnumType
+		wantInterval  int
+	}{
+		"unknown with default accepted": {
+			wantStatus:   types.RegistrationStatusEnumTypeAccepted,
+			wantInterval: 10,
+		},
+		"unknown with default pending": {
+			defaultStatus: store.RegistrationStatusPending,
+			wantStatus:    types.RegistrationStatusEnumTypePending,
+			wantInterval:  60,
+		},
+		"pending": {
+			registration: &store.ChargeStationRegistration{Status: store.RegistrationStatusPending},
+			wantStatus:   types.RegistrationStatusEnumTypePending,
+			wantInterval: 60,
+		},
+		"rejected": {
+			registration: &store.ChargeStationRegistration{Status: store.RegistrationStatusRejected},
+			wantStatus:   types.RegistrationStatusEnumTypeRejected,
+			wantInterval: 60,
+		},
+		"accepted with default rejected": {
+			registration:  &store.ChargeStationRegistration{Status: store.RegistrationStatusAccepted},
+			defaultStatus: store.RegistrationStatusRejected,
+			wantStatus:    types.RegistrationStatusEnumTypeAccepted,
+			wantInterval:  10,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			engine := inmemory.NewStore(clock.RealClock{})
+			if tc.registration != nil {
+				err := engine.SetChargeStationRegistration(context.Background(), "cs001", tc.registration)
+				require.NoError(t, err)
+			}
+
+			handler := handlers.BootNotificationHandler{
+				Clock:               clockTest.NewFakePassiveClock(now),
+				RuntimeDetailsStore: engine,
+				Registration: csmsHandlers.RegistrationPolicy{
+					Store:         engine,
+					DefaultStatus: tc.defaultStatus,
+					RetryInterval: time.Minute,
+				},
+				HeartbeatInterval: services.NewHeartbeatInterval(10 * time.Second),
+			}
+
+			got, err := handler.HandleCall(context.Background(), "cs001", &types.BootNotificationRequestJson{
+				Reason: types.BootReasonEnumTypePowerUp,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, got.(*types.BootNotificationResponseJson).Status)
+			assert.Equal(t, tc.wantInterval, got.(*types.BootNotificationResponseJson).Interval)
+
+			registration, err := engine.LookupChargeStationRegistration(context.Background(), "cs001")
+			require.NoError(t, err)
+			require.NotNil(t, registration)
+			assert.Equal(t, string(tc.wantStatus), string(registration.Status))
+		})
+	}
+}
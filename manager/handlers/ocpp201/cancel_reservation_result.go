@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type CancelReservationResultHandler struct {
+	Store    store.ReservationStore
+	Notifier services.ReservationNotifier
+}
+
+func (h CancelReservationResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.CancelReservationRequestJson)
+	resp := response.(*types.CancelReservationResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(
+		attribute.Int("cancel_reservation.reservation_id", req.ReservationId),
+		attribute.String("cancel_reservation.status", string(resp.Status)))
+
+	reservation, err := h.Store.LookupReservation(ctx, req.ReservationId)
+	if err != nil {
+		return err
+	}
+	if reservation == nil || reservation.ChargeStationId != chargeStationId {
+		return nil
+	}
+
+	if reservation.Transfer != nil && reservation.Transfer.Status == store.ReservationTransferStatusAccepted {
+		// the reservation is already held by the target, so the transfer completes even
+		// if the original charge station no longer knows about the reservation
+		return completeReservationTransfer(ctx, h.Store, h.Notifier, reservation)
+	}
+
+	if reservation.Status == store.ReservationStatusCancelPending {
+		reservation.Status = store.ReservationStatusCancelled
+		return h.Store.SetReservation(ctx, reservation)
+	}
+
+	return nil
+}
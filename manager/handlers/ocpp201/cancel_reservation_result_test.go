@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestCancelReservationResultHandlerCancelsReservation(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.CancelReservationResultHandler{
+		Store:    engine,
+		Notifier: &fakeReservationNotifier{},
+	}
+
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusCancelPending,
+	})
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		req := &types.CancelReservationRequestJson{
+			ReservationId: 1,
+		}
+		resp := &types.CancelReservationResponseJson{
+			Status: types.CancelReservationStatusEnumTypeAccepted,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"cancel_reservation.reservation_id": 1,
+		"cancel_reservation.status":         "Accepted",
+	})
+
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusCancelled, reservation.Status)
+}
+
+func TestCancelReservationResultHandlerCompletesTransfer(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	notifier := &fakeReservationNotifier{}
+	handler := ocpp201.CancelReservationResultHandler{
+		Store:    engine,
+		Notifier: notifier,
+	}
+
+	evseId := 3
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusAccepted,
+		Transfer: &store.ReservationTransfer{
+			ChargeStationId: "cs002",
+			EvseId:          &evseId,
+			Status:          store.ReservationTransferStatusAccepted,
+		},
+	})
+	require.NoError(t, err)
+
+	req := &types.CancelReservationRequestJson{
+		ReservationId: 1,
+	}
+	resp := &types.CancelReservationResponseJson{
+		Status: types.CancelReservationStatusEnumTypeRejected,
+	}
+
+	err = handler.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "cs002", reservation.ChargeStationId)
+	assert.Equal(t, &evseId, reservation.EvseId)
+	assert.Nil(t, reservation.Transfer)
+	assert.Equal(t, store.ReservationStatusAccepted, reservation.Status)
+	require.Len(t, notifier.transferred, 1)
+	assert.Equal(t, "cs001", notifier.previousCsIds[0])
+}
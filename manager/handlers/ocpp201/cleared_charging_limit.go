@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ClearedChargingLimitHandler struct{}
+
+func (h ClearedChargingLimitHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.ClearedChargingLimitRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(attribute.String("cleared_charging_limit.source", string(req.ChargingLimitSource)))
+	if req.EvseId != nil {
+		span.SetAttributes(attribute.Int("cleared_charging_limit.evse_id", *req.EvseId))
+	}
+
+	return &types.ClearedChargingLimitResponseJson{}, nil
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"testing"
+)
+
+func TestClearedChargingLimitHandler(t *testing.T) {
+	handler := ocpp201.ClearedChargingLimitHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.ClearedChargingLimitRequestJson{
+			ChargingLimitSource: types.ChargingLimitSourceEnumTypeSO,
+			EvseId:              makePtr(1),
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.ClearedChargingLimitResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"cleared_charging_limit.source":  "SO",
+		"cleared_charging_limit.evse_id": 1,
+	})
+}
@@ -6,11 +6,14 @@ import (
 	"context"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
-type DeleteCertificateResultHandler struct{}
+type DeleteCertificateResultHandler struct {
+	Store store.ChargeStationInstalledCertificatesStore
+}
 
 func (h DeleteCertificateResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.DeleteCertificateRequestJson)
@@ -22,5 +25,29 @@ func (h DeleteCertificateResultHandler) HandleCallResult(ctx context.Context, ch
 		attribute.String("delete_certificate.serial_number", req.CertificateHashData.SerialNumber),
 		attribute.String("delete_certificate.status", string(resp.Status)))
 
-	return nil
+	installed, err := h.Store.LookupChargeStationInstalledCertificates(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	if installed == nil {
+		return nil
+	}
+
+	var certificates []*store.ChargeStationInstalledCertificate
+	for _, cert := range installed.Certificates {
+		if cert.SerialNumber == req.CertificateHashData.SerialNumber &&
+			cert.IssuerNameHash == req.CertificateHashData.IssuerNameHash &&
+			cert.IssuerKeyHash == req.CertificateHashData.IssuerKeyHash {
+			if resp.Status == types.DeleteCertificateStatusEnumTypeFailed {
+				cert.Status = store.InstalledCertificateStatusDeleteFailed
+			} else {
+				// either deleted or no longer present on the charge station
+				continue
+			}
+		}
+		certificates = append(certificates, cert)
+	}
+	installed.Certificates = certificates
+
+	return h.Store.SetChargeStationInstalledCertificates(ctx, chargeStationId, installed)
 }
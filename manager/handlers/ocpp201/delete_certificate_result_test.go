@@ -4,15 +4,42 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
 	"testing"
 )
 
 func TestDeleteCertificateResultHandler(t *testing.T) {
-	handler := ocpp201.DeleteCertificateResultHandler{}
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.DeleteCertificateResultHandler{Store: engine}
+
+	err := engine.SetChargeStationInstalledCertificates(context.Background(), "cs001", &store.ChargeStationInstalledCertificates{
+		Certificates: []*store.ChargeStationInstalledCertificate{
+			{
+				CertificateType: store.CertificateTypeV2G,
+				HashAlgorithm:   "SHA256",
+				IssuerKeyHash:   "ABC123",
+				IssuerNameHash:  "ABCDEF",
+				SerialNumber:    "12345678",
+				Status:          store.InstalledCertificateStatusDeletePending,
+			},
+			{
+				CertificateType: store.CertificateTypeMO,
+				HashAlgorithm:   "SHA256",
+				IssuerKeyHash:   "DEF456",
+				IssuerNameHash:  "FEDCBA",
+				SerialNumber:    "87654321",
+				Status:          store.InstalledCertificateStatusInstalled,
+			},
+		},
+	})
+	require.NoError(t, err)
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -42,4 +69,48 @@ func TestDeleteCertificateResultHandler(t *testing.T) {
 		"delete_certificate.serial_number": "12345678",
 		"delete_certificate.status":        "Accepted",
 	})
+
+	installed, err := engine.LookupChargeStationInstalledCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.Len(t, installed.Certificates, 1)
+	assert.Equal(t, "87654321", installed.Certificates[0].SerialNumber)
+}
+
+func TestDeleteCertificateResultHandlerWhenDeleteFails(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.DeleteCertificateResultHandler{Store: engine}
+
+	err := engine.SetChargeStationInstalledCertificates(context.Background(), "cs001", &store.ChargeStationInstalledCertificates{
+		Certificates: []*store.ChargeStationInstalledCertificate{
+			{
+				CertificateType: store.CertificateTypeV2G,
+				HashAlgorithm:   "SHA256",
+				IssuerKeyHash:   "ABC123",
+				IssuerNameHash:  "ABCDEF",
+				SerialNumber:    "12345678",
+				Status:          store.InstalledCertificateStatusDeletePending,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	req := &types.DeleteCertificateRequestJson{
+		CertificateHashData: types.CertificateHashDataType{
+			HashAlgorithm:  types.HashAlgorithmEnumTypeSHA256,
+			IssuerKeyHash:  "ABC123",
+			IssuerNameHash: "ABCDEF",
+			SerialNumber:   "12345678",
+		},
+	}
+	resp := &types.DeleteCertificateResponseJson{
+		Status: types.DeleteCertificateStatusEnumTypeFailed,
+	}
+
+	err = handler.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	installed, err := engine.LookupChargeStationInstalledCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.Len(t, installed.Certificates, 1)
+	assert.Equal(t, store.InstalledCertificateStatusDeleteFailed, installed.Certificates[0].Status)
 }
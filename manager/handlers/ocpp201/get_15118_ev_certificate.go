@@ -4,29 +4,92 @@ package ocpp201
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+	"time"
 
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 )
 
+// ExiResponseChunkVendorId is the customData vendorId used to split an EXI response that
+// exceeds the maximum length of the exiResponse field across several Get15118EVCertificate
+// responses. The first response carries chunk 0 and the number of remaining chunks; the
+// charge station retrieves each remaining chunk by repeating the request with customData
+// containing the chunk number, and concatenates the chunks in order.
+const ExiResponseChunkVendorId = "org.thoughtworks.maeve-csms.exiResponseChunk"
+
+const (
+	defaultMaxExiResponseLength = 5600
+	defaultExiResponseChunkTTL  = 5 * time.Minute
+)
+
+// Get15118EvCertificateHandler requests a contract certificate for the EV using the
+// ISO 15118 version identified by the request's schema version. If RuntimeDetailsStore
+// is set the version is recorded so that the charge station's own certificates are
+// issued for the same version.
 type Get15118EvCertificateHandler struct {
 	ContractCertificateProvider services.ContractCertificateProvider
+	Clock                       clock.PassiveClock
+	ExiResponseStore            store.ExiResponseStore
+	RuntimeDetailsStore         store.ChargeStationRuntimeDetailsStore
+	MaxExiResponseLength        int           // defaults to the schema limit of 5600
+	ExiResponseChunkTTL         time.Duration // defaults to 5 minutes
 }
 
-func (g Get15118EvCertificateHandler) HandleCall(ctx context.Context, _ string, request ocpp.Request) (ocpp.Response, error) {
+func (g Get15118EvCertificateHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
 	span := trace.SpanFromContext(ctx)
 
 	req := request.(*types.Get15118EVCertificateRequestJson)
 
+	chunk, err := requestedExiResponseChunk(req.CustomData)
+	if err != nil {
+		span.SetAttributes(attribute.String("get_ev_cert.error", err.Error()))
+		return &types.Get15118EVCertificateResponseJson{
+			Status: types.Iso15118EVCertificateStatusEnumTypeFailed,
+		}, nil
+	}
+	if chunk > 0 {
+		span.SetAttributes(attribute.Int("get_ev_cert.chunk", chunk))
+		return g.nextExiResponseChunk(ctx, chargeStationId, req, chunk)
+	}
+
+	isoVersion := services.ISOVersionOfSchema(req.Iso15118SchemaVersion)
+	span.SetAttributes(attribute.String("get_ev_cert.iso_version", string(isoVersion)))
+
+	if g.RuntimeDetailsStore != nil {
+		err = handlers.RecordIso15118Version(ctx, g.RuntimeDetailsStore, chargeStationId, string(isoVersion))
+		if err != nil {
+			handlers.LoggerFromContext(ctx).Error("failed to record iso 15118 version", "err", err)
+			span.AddEvent("failed to record iso 15118 version", trace.WithAttributes(attribute.String("err", err.Error())))
+		}
+	}
+
+	// ISO 15118-20 has no CertificateUpdateReq: the EV installs a new certificate instead
+	if isoVersion == services.ISO15118V20 && req.Action == types.CertificateActionEnumTypeUpdate {
+		span.SetAttributes(attribute.String("get_ev_cert.error", "update not supported by ISO 15118-20"))
+		return &types.Get15118EVCertificateResponseJson{
+			Status: types.Iso15118EVCertificateStatusEnumTypeFailed,
+			StatusInfo: &types.StatusInfoType{
+				ReasonCode: "UnsupportedRequest",
+			},
+		}, nil
+	}
+
 	status := types.Iso15118EVCertificateStatusEnumTypeFailed
 	response := types.Get15118EVCertificateResponseJson{
 		Status: status,
 	}
 	if g.ContractCertificateProvider != nil {
-		res, err := g.ContractCertificateProvider.ProvideCertificate(ctx, req.ExiRequest)
+		res, err := g.ContractCertificateProvider.ProvideCertificate(ctx, req.ExiRequest, isoVersion)
 
 		if err != nil {
 			span.SetAttributes(attribute.String("get_ev_cert.error", err.Error()))
@@ -38,7 +101,113 @@ func (g Get15118EvCertificateHandler) HandleCall(ctx context.Context, _ string,
 		}
 	}
 
+	if g.ExiResponseStore != nil && len(response.ExiResponse) > g.maxExiResponseLength() {
+		chunks := splitExiResponse(response.ExiResponse, g.maxExiResponseLength())
+		err = g.ExiResponseStore.SetExiResponseChunks(ctx, &store.ExiResponseChunks{
+			ChargeStationId: chargeStationId,
+			ExiRequestHash:  exiRequestHash(req.ExiRequest),
+			Chunks:          chunks,
+			ExpiresAt:       g.Clock.Now().Add(g.exiResponseChunkTTL()),
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.ExiResponse = chunks[0]
+		response.CustomData = exiResponseChunkCustomData(0, len(chunks)-1)
+		span.SetAttributes(attribute.Int("get_ev_cert.chunks", len(chunks)))
+	}
+
 	span.SetAttributes(attribute.String("request.status", string(status)))
 
 	return &response, nil
 }
+
+func (g Get15118EvCertificateHandler) nextExiResponseChunk(ctx context.Context, chargeStationId string, req *types.Get15118EVCertificateRequestJson, chunk int) (ocpp.Response, error) {
+	failed := &types.Get15118EVCertificateResponseJson{
+		Status: types.Iso15118EVCertificateStatusEnumTypeFailed,
+		StatusInfo: &types.StatusInfoType{
+			ReasonCode: "UnknownChunk",
+		},
+	}
+	if g.ExiResponseStore == nil {
+		return failed, nil
+	}
+
+	requestHash := exiRequestHash(req.ExiRequest)
+	chunks, err := g.ExiResponseStore.LookupExiResponseChunks(ctx, chargeStationId, requestHash)
+	if err != nil {
+		return nil, err
+	}
+	if chunks == nil || chunk >= len(chunks.Chunks) || g.Clock.Now().After(chunks.ExpiresAt) {
+		return failed, nil
+	}
+
+	remaining := len(chunks.Chunks) - chunk - 1
+	if remaining == 0 {
+		err = g.ExiResponseStore.DeleteExiResponseChunks(ctx, chargeStationId, requestHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &types.Get15118EVCertificateResponseJson{
+		Status:      types.Iso15118EVCertificateStatusEnumTypeAccepted,
+		ExiResponse: chunks.Chunks[chunk],
+		CustomData:  exiResponseChunkCustomData(chunk, remaining),
+	}, nil
+}
+
+func (g Get15118EvCertificateHandler) maxExiResponseLength() int {
+	if g.MaxExiResponseLength <= 0 {
+		return defaultMaxExiResponseLength
+	}
+	return g.MaxExiResponseLength
+}
+
+func (g Get15118EvCertificateHandler) exiResponseChunkTTL() time.Duration {
+	if g.ExiResponseChunkTTL <= 0 {
+		return defaultExiResponseChunkTTL
+	}
+	return g.ExiResponseChunkTTL
+}
+
+// requestedExiResponseChunk returns the chunk number requested by the charge station or
+// 0 if the request is for a new EXI response.
+func requestedExiResponseChunk(customData *types.CustomDataType) (int, error) {
+	if customData == nil || customData.VendorId != ExiResponseChunkVendorId {
+		return 0, nil
+	}
+	raw, ok := customData.Properties["chunk"]
+	if !ok {
+		return 0, nil
+	}
+	var chunk int
+	if err := json.Unmarshal(raw, &chunk); err != nil || chunk < 0 {
+		return 0, fmt.Errorf("invalid exi response chunk: %s", string(raw))
+	}
+	return chunk, nil
+}
+
+func exiResponseChunkCustomData(chunk, remaining int) *types.CustomDataType {
+	return &types.CustomDataType{
+		VendorId: ExiResponseChunkVendorId,
+		Properties: map[string]json.RawMessage{
+			"chunk":           json.RawMessage(fmt.Sprintf("%d", chunk)),
+			"remainingChunks": json.RawMessage(fmt.Sprintf("%d", remaining)),
+		},
+	}
+}
+
+func splitExiResponse(exiResponse string, maxLength int) []string {
+	var chunks []string
+	for len(exiResponse) > maxLength {
+		chunks = append(chunks, exiResponse[:maxLength])
+		exiResponse = exiResponse[maxLength:]
+	}
+	return append(chunks, exiResponse)
+}
+
+func exiRequestHash(exiRequest string) string {
+	hash := sha256.Sum256([]byte(exiRequest))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
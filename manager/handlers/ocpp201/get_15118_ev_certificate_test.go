@@ -4,25 +4,39 @@ package ocpp201_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 	"testing"
+	"time"
 )
 
 var calledTimes int
+var lastIsoVersion services.ISOVersion
 
 type dummyEvCertificateProvider struct{}
 
-func (d dummyEvCertificateProvider) ProvideCertificate(_ context.Context, exiRequest string) (services.EvCertificate15118Response, error) {
+func (d dummyEvCertificateProvider) ProvideCertificate(_ context.Context, exiRequest string, isoVersion services.ISOVersion) (services.EvCertificate15118Response, error) {
 	calledTimes++
+	lastIsoVersion = isoVersion
 	if exiRequest == "success" {
 		return services.EvCertificate15118Response{
 			Status:                     types.Iso15118EVCertificateStatusEnumTypeAccepted,
 			CertificateInstallationRes: "dummy exi",
 		}, nil
+	} else if exiRequest == "large" {
+		return services.EvCertificate15118Response{
+			Status:                     types.Iso15118EVCertificateStatusEnumTypeAccepted,
+			CertificateInstallationRes: "0123456789",
+		}, nil
 	} else {
 		return services.EvCertificate15118Response{}, errors.New("failure, try again")
 	}
@@ -49,3 +63,149 @@ func TestGet15118EvCertificate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, got)
 }
+
+func chunkCustomData(props map[string]int) *types.CustomDataType {
+	customData := &types.CustomDataType{
+		VendorId:   handlers.ExiResponseChunkVendorId,
+		Properties: make(map[string]json.RawMessage),
+	}
+	for k, v := range props {
+		b, _ := json.Marshal(v)
+		customData.Properties[k] = b
+	}
+	return customData
+}
+
+func TestGet15118EvCertificateSplitsLargeExiResponse(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now())
+
+	h := handlers.Get15118EvCertificateHandler{
+		ContractCertificateProvider: dummyEvCertificateProvider{},
+		Clock:                       clk,
+		ExiResponseStore:            engine,
+		MaxExiResponseLength:        4,
+	}
+
+	req := &types.Get15118EVCertificateRequestJson{
+		Action:                types.CertificateActionEnumTypeInstall,
+		Iso15118SchemaVersion: "urn:iso:15118:2:2013:MsgDef",
+		ExiRequest:            "large",
+	}
+
+	got, err := h.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.Get15118EVCertificateResponseJson{
+		Status:      types.Iso15118EVCertificateStatusEnumTypeAccepted,
+		ExiResponse: "0123",
+		CustomData:  chunkCustomData(map[string]int{"chunk": 0, "remainingChunks": 2}),
+	}, got)
+
+	req.CustomData = chunkCustomData(map[string]int{"chunk": 1})
+	got, err = h.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.Get15118EVCertificateResponseJson{
+		Status:      types.Iso15118EVCertificateStatusEnumTypeAccepted,
+		ExiResponse: "4567",
+		CustomData:  chunkCustomData(map[string]int{"chunk": 1, "remainingChunks": 1}),
+	}, got)
+
+	req.CustomData = chunkCustomData(map[string]int{"chunk": 2})
+	got, err = h.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.Get15118EVCertificateResponseJson{
+		Status:      types.Iso15118EVCertificateStatusEnumTypeAccepted,
+		ExiResponse: "89",
+		CustomData:  chunkCustomData(map[string]int{"chunk": 2, "remainingChunks": 0}),
+	}, got)
+
+	// all chunks have been retrieved
+	got, err = h.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, types.Iso15118EVCertificateStatusEnumTypeFailed, got.(*types.Get15118EVCertificateResponseJson).Status)
+}
+
+func TestGet15118EvCertificateChunkExpires(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	clk := clockTest.NewFakePassiveClock(time.Now())
+
+	h := handlers.Get15118EvCertificateHandler{
+		ContractCertificateProvider: dummyEvCertificateProvider{},
+		Clock:                       clk,
+		ExiResponseStore:            engine,
+		MaxExiResponseLength:        4,
+	}
+
+	req := &types.Get15118EVCertificateRequestJson{
+		Action:                types.CertificateActionEnumTypeInstall,
+		Iso15118SchemaVersion: "urn:iso:15118:2:2013:MsgDef",
+		ExiRequest:            "large",
+	}
+
+	_, err := h.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+
+	clk.SetTime(clk.Now().Add(10 * time.Minute))
+
+	req.CustomData = chunkCustomData(map[string]int{"chunk": 1})
+	got, err := h.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.Get15118EVCertificateResponseJson{
+		Status: types.Iso15118EVCertificateStatusEnumTypeFailed,
+		StatusInfo: &types.StatusInfoType{
+			ReasonCode: "UnknownChunk",
+		},
+	}, got)
+}
+
+func TestGet15118EvCertificateWithIso15118V20RecordsVersion(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+
+	req := &types.Get15118EVCertificateRequestJson{
+		Action:                types.CertificateActionEnumTypeInstall,
+		Iso15118SchemaVersion: "urn:iso:std:iso:15118:-20:CommonMessages",
+		ExiRequest:            "success",
+	}
+
+	h := handlers.Get15118EvCertificateHandler{
+		ContractCertificateProvider: dummyEvCertificateProvider{},
+		RuntimeDetailsStore:         engine,
+	}
+
+	got, err := h.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, types.Iso15118EVCertificateStatusEnumTypeAccepted, got.(*types.Get15118EVCertificateResponseJson).Status)
+	assert.Equal(t, services.ISO15118V20, lastIsoVersion)
+
+	details, err := engine.LookupChargeStationRuntimeDetails(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationRuntimeDetails{
+		OcppVersion:     "2.0.1",
+		Iso15118Version: "ISO15118-20",
+	}, details)
+}
+
+func TestGet15118EvCertificateWithIso15118V20RejectsUpdate(t *testing.T) {
+	req := &types.Get15118EVCertificateRequestJson{
+		Action:                types.CertificateActionEnumTypeUpdate,
+		Iso15118SchemaVersion: "urn:iso:std:iso:15118:-20:CommonMessages",
+		ExiRequest:            "success",
+	}
+
+	h := handlers.Get15118EvCertificateHandler{
+		ContractCertificateProvider: dummyEvCertificateProvider{},
+	}
+
+	got, err := h.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.Get15118EVCertificateResponseJson{
+		Status: types.Iso15118EVCertificateStatusEnumTypeFailed,
+		StatusInfo: &types.StatusInfoType{
+			ReasonCode: "UnsupportedRequest",
+		},
+	}, got)
+}
@@ -6,12 +6,16 @@ import (
 	"context"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slices"
 	"strings"
 )
 
-type GetInstalledCertificateIdsResultHandler struct{}
+type GetInstalledCertificateIdsResultHandler struct {
+	Store store.ChargeStationInstalledCertificatesStore
+}
 
 func (h GetInstalledCertificateIdsResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
 	req := request.(*types.GetInstalledCertificateIdsRequestJson)
@@ -20,9 +24,11 @@ func (h GetInstalledCertificateIdsResultHandler) HandleCallResult(ctx context.Co
 	span := trace.SpanFromContext(ctx)
 
 	var certTypes []string
+	var storeTypes []store.CertificateType
 	if req.CertificateType != nil {
 		for _, ct := range req.CertificateType {
 			certTypes = append(certTypes, string(ct))
+			storeTypes = append(storeTypes, mapGetCertificateIdUseToCertificateType(ct))
 		}
 	}
 
@@ -30,5 +36,66 @@ func (h GetInstalledCertificateIdsResultHandler) HandleCallResult(ctx context.Co
 		attribute.String("get_installed_certificate.types", strings.Join(certTypes, ",")),
 		attribute.String("get_installed_certificate.status", string(resp.Status)))
 
-	return nil
+	existing, err := h.Store.LookupChargeStationInstalledCertificates(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+
+	// certificates of types that were not requested are retained, everything else
+	// is replaced by what the charge station has reported
+	var certificates []*store.ChargeStationInstalledCertificate
+	if existing != nil && len(storeTypes) > 0 {
+		for _, cert := range existing.Certificates {
+			if !slices.Contains(storeTypes, cert.CertificateType) {
+				certificates = append(certificates, cert)
+			}
+		}
+	}
+
+	if resp.Status == types.GetInstalledCertificateStatusEnumTypeAccepted {
+		for _, chain := range resp.CertificateHashDataChain {
+			cert := &store.ChargeStationInstalledCertificate{
+				CertificateType: mapGetCertificateIdUseToCertificateType(chain.CertificateType),
+				HashAlgorithm:   string(chain.CertificateHashData.HashAlgorithm),
+				IssuerNameHash:  chain.CertificateHashData.IssuerNameHash,
+				IssuerKeyHash:   chain.CertificateHashData.IssuerKeyHash,
+				SerialNumber:    chain.CertificateHashData.SerialNumber,
+				Status:          store.InstalledCertificateStatusInstalled,
+			}
+			if existing != nil {
+				for _, prev := range existing.Certificates {
+					if prev.SerialNumber == cert.SerialNumber && prev.IssuerNameHash == cert.IssuerNameHash {
+						cert.Status = prev.Status
+						cert.SendAfter = prev.SendAfter
+						break
+					}
+				}
+			}
+			certificates = append(certificates, cert)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("get_installed_certificate.count", len(resp.CertificateHashDataChain)))
+
+	return h.Store.SetChargeStationInstalledCertificates(ctx, chargeStationId, &store.ChargeStationInstalledCertificates{
+		ChargeStationId: chargeStationId,
+		Certificates:    certificates,
+		RefreshStatus:   store.InstalledCertificatesRefreshAccepted,
+	})
+}
+
+func mapGetCertificateIdUseToCertificateType(use types.GetCertificateIdUseEnumType) store.CertificateType {
+	switch use {
+	case types.GetCertificateIdUseEnumTypeV2GRootCertificate:
+		return store.CertificateTypeV2G
+	case types.GetCertificateIdUseEnumTypeMORootCertificate:
+		return store.CertificateTypeMO
+	case types.GetCertificateIdUseEnumTypeCSMSRootCertificate:
+		return store.CertificateTypeCSMS
+	case types.GetCertificateIdUseEnumTypeManufacturerRootCertificate:
+		return store.CertificateTypeMF
+	case types.GetCertificateIdUseEnumTypeV2GCertificateChain:
+		return store.CertificateTypeEVCC
+	}
+	return store.CertificateType(use)
 }
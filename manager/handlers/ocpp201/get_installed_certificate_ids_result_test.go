@@ -4,15 +4,43 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
 	"testing"
 )
 
 func TestGetInstalledCertificateIdsResultHandler(t *testing.T) {
-	handler := ocpp201.GetInstalledCertificateIdsResultHandler{}
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.GetInstalledCertificateIdsResultHandler{Store: engine}
+
+	err := engine.SetChargeStationInstalledCertificates(context.Background(), "cs001", &store.ChargeStationInstalledCertificates{
+		Certificates: []*store.ChargeStationInstalledCertificate{
+			{
+				CertificateType: store.CertificateTypeV2G,
+				HashAlgorithm:   "SHA256",
+				IssuerKeyHash:   "DEF456",
+				IssuerNameHash:  "FEDCBA",
+				SerialNumber:    "87654321",
+				Status:          store.InstalledCertificateStatusInstalled,
+			},
+			{
+				CertificateType: store.CertificateTypeMO,
+				HashAlgorithm:   "SHA256",
+				IssuerKeyHash:   "XYZ789",
+				IssuerNameHash:  "ZYXWVU",
+				SerialNumber:    "11111111",
+				Status:          store.InstalledCertificateStatusInstalled,
+			},
+		},
+		RefreshStatus: store.InstalledCertificatesRefreshPending,
+	})
+	require.NoError(t, err)
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -51,5 +79,21 @@ func TestGetInstalledCertificateIdsResultHandler(t *testing.T) {
 	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
 		"get_installed_certificate.types":  "CSMSRootCertificate,MORootCertificate",
 		"get_installed_certificate.status": "Accepted",
+		"get_installed_certificate.count":  1,
 	})
+
+	installed, err := engine.LookupChargeStationInstalledCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.InstalledCertificatesRefreshAccepted, installed.RefreshStatus)
+	require.Len(t, installed.Certificates, 2)
+	assert.Equal(t, "87654321", installed.Certificates[0].SerialNumber)
+	assert.Equal(t, store.CertificateTypeV2G, installed.Certificates[0].CertificateType)
+	assert.Equal(t, &store.ChargeStationInstalledCertificate{
+		CertificateType: store.CertificateTypeCSMS,
+		HashAlgorithm:   "SHA256",
+		IssuerKeyHash:   "ABC123",
+		IssuerNameHash:  "ABCDEF",
+		SerialNumber:    "12345678",
+		Status:          store.InstalledCertificateStatusInstalled,
+	}, installed.Certificates[1])
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"time"
 )
 
 type InstallCertificateResultHandler struct {
@@ -67,5 +68,25 @@ func (i InstallCertificateResultHandler) HandleCallResult(ctx context.Context, c
 		return err
 	}
 
+	if resp.Status == ocpp201.InstallCertificateStatusEnumTypeAccepted {
+		// ask the charge station for its updated inventory so the hash data
+		// for the new certificate is recorded
+		installed, err := i.Store.LookupChargeStationInstalledCertificates(ctx, chargeStationId)
+		if err != nil {
+			return err
+		}
+		if installed == nil {
+			installed = &store.ChargeStationInstalledCertificates{
+				ChargeStationId: chargeStationId,
+			}
+		}
+		installed.RefreshStatus = store.InstalledCertificatesRefreshPending
+		installed.SendAfter = time.Time{}
+		err = i.Store.SetChargeStationInstalledCertificates(ctx, chargeStationId, installed)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
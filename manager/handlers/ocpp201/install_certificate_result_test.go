@@ -164,3 +164,30 @@ func TestInstallCertificateResultHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestInstallCertificateResultHandlerRequestsInstalledCertificatesRefresh(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := handlers201.InstallCertificateResultHandler{Store: engine}
+
+	pemBlock := &pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: []byte("test"),
+	}
+	pemBytes := pem.EncodeToMemory(pemBlock)
+
+	req := &ocpp201.InstallCertificateRequestJson{
+		Certificate:     string(pemBytes),
+		CertificateType: ocpp201.InstallCertificateUseEnumTypeMORootCertificate,
+	}
+	resp := &ocpp201.InstallCertificateResponseJson{
+		Status: ocpp201.InstallCertificateStatusEnumTypeAccepted,
+	}
+
+	err := handler.HandleCallResult(context.Background(), "cs001", req, resp, nil)
+	require.NoError(t, err)
+
+	installed, err := engine.LookupChargeStationInstalledCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, installed)
+	assert.Equal(t, store.InstalledCertificatesRefreshPending, installed.RefreshStatus)
+}
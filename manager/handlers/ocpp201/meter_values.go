@@ -4,20 +4,34 @@ package ocpp201
 
 import (
 	"context"
+	"fmt"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
-type MeterValuesHandler struct{}
+// MeterValuesHandler records the meter values that a charge station sends outside
+// of a transaction: meter values sampled during a transaction are reported in
+// TransactionEvent messages.
+type MeterValuesHandler struct {
+	Store store.MeterValueStore
+}
 
 func (h MeterValuesHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (response ocpp.Response, err error) {
 	req := request.(*ocpp201.MeterValuesRequestJson)
 
 	span := trace.SpanFromContext(ctx)
 
-	span.SetAttributes(attribute.Int("meter_values.evse_id", req.EvseId))
+	span.SetAttributes(
+		attribute.Int("meter_values.evse_id", req.EvseId),
+		attribute.Int("meter_values.count", len(req.MeterValue)))
+
+	err = h.Store.AddMeterValues(ctx, chargeStationId, req.EvseId, convertMeterValues(req.MeterValue))
+	if err != nil {
+		return nil, fmt.Errorf("adding meter values: %w", err)
+	}
 
 	return &ocpp201.MeterValuesResponseJson{}, nil
 }
@@ -8,12 +8,18 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
 	"testing"
 )
 
 func TestMeterValuesHandler(t *testing.T) {
-	handler := ocpp201.MeterValuesHandler{}
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.MeterValuesHandler{
+		Store: engine,
+	}
 
 	tracer, exporter := testutil.GetTracer()
 
@@ -47,6 +53,26 @@ func TestMeterValuesHandler(t *testing.T) {
 
 	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
 		"meter_values.evse_id": 1,
+		"meter_values.count":   1,
 	})
 
+	got, err := engine.LookupMeterValues(ctx, "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, &store.EvseMeterValues{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		MeterValues: []store.MeterValue{
+			{
+				SampledValues: []store.SampledValue{
+					{
+						Measurand: makePtr(string(types.MeasurandEnumTypeEnergyActiveImportRegister)),
+						Location:  makePtr(string(types.LocationEnumTypeOutlet)),
+						Value:     100,
+					},
+				},
+				Timestamp: "2023-06-15T15:05:00+01:00",
+			},
+		},
+	}, got)
+
 }
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// chargingNeedsProfileIdBase is added to the EVSE id to identify the charging profile
+// set in response to a NotifyEVChargingNeeds so that a later profile for the same
+// EVSE replaces it
+const chargingNeedsProfileIdBase = 1000
+
+// NotifyEVChargingNeedsHandler uses the SchedulingStrategy to determine how the
+// charging needs of the EV are met and sends the resulting schedule to the charge
+// station as a charging profile. The departure time of the EV is recorded on the EVSE,
+// so that the EVSE's availability is known.
+type NotifyEVChargingNeedsHandler struct {
+	Clock              clock.PassiveClock
+	SchedulingStrategy services.SchedulingStrategy
+	CallMaker          handlers.CallMaker
+	Evses              handlers.EvseRecorder
+}
+
+func (h NotifyEVChargingNeedsHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.NotifyEVChargingNeedsRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	now := h.Clock.Now()
+	needs := chargingNeeds(req.ChargingNeeds)
+
+	span.SetAttributes(
+		attribute.Int("charging_needs.evse_id", req.EvseId),
+		attribute.String("charging_needs.requested_energy_transfer", string(req.ChargingNeeds.RequestedEnergyTransfer)),
+		attribute.Float64("charging_needs.energy_amount", needs.EnergyAmount),
+		attribute.Float64("charging_needs.max_power", needs.MaxPower))
+	if req.ChargingNeeds.DepartureTime != nil {
+		span.SetAttributes(attribute.String("charging_needs.departure_time", *req.ChargingNeeds.DepartureTime))
+	}
+
+	err := h.Evses.Update(ctx, chargeStationId, req.EvseId, func(evse *store.ChargeStationEvse) bool {
+		if evse.DepartureTime.Equal(needs.DepartureTime) {
+			return false
+		}
+		evse.DepartureTime = needs.DepartureTime
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	asap := []services.ChargingSchedulePeriod{{Start: now, Limit: needs.MaxPower}}
+	schedule, err := h.SchedulingStrategy.Schedule(ctx, chargeStationId, req.EvseId, needs)
+	if err != nil {
+		// the EV should still be charged even if it can't be charged optimally
+		handlers.LoggerFromContext(ctx).Warn("unable to schedule charging, charging as soon as possible", "err", err)
+		schedule = asap
+	}
+	if req.MaxScheduleTuples != nil && len(schedule) > *req.MaxScheduleTuples {
+		schedule = asap
+	}
+	span.SetAttributes(attribute.Int("charging_needs.schedule_periods", len(schedule)))
+
+	var periods []types.ChargingSchedulePeriodType
+	for _, period := range schedule {
+		startPeriod := int(period.Start.Sub(now).Seconds())
+		if startPeriod < 0 {
+			startPeriod = 0
+		}
+		periods = append(periods, types.ChargingSchedulePeriodType{
+			StartPeriod: startPeriod,
+			Limit:       period.Limit,
+		})
+	}
+
+	startSchedule := now.Format(time.RFC3339)
+	chargingSchedule := types.ChargingScheduleType{
+		Id:                     1,
+		StartSchedule:          &startSchedule,
+		ChargingRateUnit:       types.ChargingRateUnitEnumTypeW,
+		ChargingSchedulePeriod: periods,
+	}
+	if needs.DepartureTime.After(now) {
+		duration := int(needs.DepartureTime.Sub(now).Seconds())
+		chargingSchedule.Duration = &duration
+	}
+
+	err = h.CallMaker.Send(ctx, chargeStationId, &types.SetChargingProfileRequestJson{
+		EvseId: req.EvseId,
+		ChargingProfile: types.ChargingProfileType{
+			Id:                     chargingNeedsProfileIdBase + req.EvseId,
+			StackLevel:             0,
+			ChargingProfilePurpose: types.ChargingProfilePurposeEnumTypeTxDefaultProfile,
+			ChargingProfileKind:    types.ChargingProfileKindEnumTypeAbsolute,
+			ChargingSchedule:       []types.ChargingScheduleType{chargingSchedule},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sending charging profile: %w", err)
+	}
+
+	return &types.NotifyEVChargingNeedsResponseJson{
+		Status: types.NotifyEVChargingNeedsStatusEnumTypeAccepted,
+	}, nil
+}
+
+func chargingNeeds(needs types.ChargingNeedsType) services.ChargingNeeds {
+	var result services.ChargingNeeds
+
+	if ac := needs.AcChargingParameters; ac != nil {
+		phases := 1
+		switch needs.RequestedEnergyTransfer {
+		case types.EnergyTransferModeEnumTypeACTwoPhase:
+			phases = 2
+		case types.EnergyTransferModeEnumTypeACThreePhase:
+			phases = 3
+		}
+		result.EnergyAmount = float64(ac.EnergyAmount)
+		result.MaxPower = float64(ac.EvMaxCurrent * ac.EvMaxVoltage * phases)
+	} else if dc := needs.DcChargingParameters; dc != nil {
+		if dc.EnergyAmount != nil {
+			result.EnergyAmount = float64(*dc.EnergyAmount)
+		}
+		if dc.EvMaxPower != nil {
+			result.MaxPower = float64(*dc.EvMaxPower)
+		} else {
+			result.MaxPower = float64(dc.EvMaxCurrent * dc.EvMaxVoltage)
+		}
+	}
+
+	if needs.DepartureTime != nil {
+		// an unparseable departure time is treated as unknown
+		departureTime, err := time.Parse(time.RFC3339, *needs.DepartureTime)
+		if err == nil {
+			result.DepartureTime = departureTime
+		}
+	}
+
+	return result
+}
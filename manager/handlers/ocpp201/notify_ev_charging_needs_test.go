@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type recordingCallMaker struct {
+	requests []ocpp.Request
+}
+
+func (r *recordingCallMaker) Send(_ context.Context, _ string, request ocpp.Request) error {
+	r.requests = append(r.requests, request)
+	return nil
+}
+
+type fixedSchedulingStrategy struct {
+	needs    services.ChargingNeeds
+	schedule []services.ChargingSchedulePeriod
+	err      error
+}
+
+func (f *fixedSchedulingStrategy) Schedule(_ context.Context, _ string, _ int, needs services.ChargingNeeds) ([]services.ChargingSchedulePeriod, error) {
+	f.needs = needs
+	return f.schedule, f.err
+}
+
+func TestNotifyEVChargingNeedsHandler(t *testing.T) {
+	now := time.Date(2023, 6, 1, 22, 0, 0, 0, time.UTC)
+	callMaker := &recordingCallMaker{}
+	strategy := &fixedSchedulingStrategy{
+		schedule: []services.ChargingSchedulePeriod{
+			{Start: now, Limit: 0},
+			{Start: now.Add(3 * time.Hour), Limit: 11040},
+			{Start: now.Add(5 * time.Hour), Limit: 0},
+		},
+	}
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.NotifyEVChargingNeedsHandler{
+		Clock:              clockTest.NewFakePassiveClock(now),
+		SchedulingStrategy: strategy,
+		CallMaker:          callMaker,
+		Evses: handlers.EvseRecorder{
+			Clock: clockTest.NewFakePassiveClock(now),
+			Store: engine,
+		},
+	}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.NotifyEVChargingNeedsRequestJson{
+			EvseId: 2,
+			ChargingNeeds: types.ChargingNeedsType{
+				RequestedEnergyTransfer: types.EnergyTransferModeEnumTypeACThreePhase,
+				DepartureTime:           makePtr("2023-06-02T06:00:00Z"),
+				AcChargingParameters: &types.ACChargingParametersType{
+					EnergyAmount: 20000,
+					EvMaxCurrent: 16,
+					EvMaxVoltage: 230,
+					EvMinCurrent: 6,
+				},
+			},
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.NotifyEVChargingNeedsResponseJson{
+			Status: types.NotifyEVChargingNeedsStatusEnumTypeAccepted,
+		}, resp)
+	}()
+
+	assert.Equal(t, services.ChargingNeeds{
+		EnergyAmount:  20000,
+		MaxPower:      11040,
+		DepartureTime: time.Date(2023, 6, 2, 6, 0, 0, 0, time.UTC),
+	}, strategy.needs)
+
+	evse, err := engine.LookupEvse(ctx, "cs001", 2)
+	require.NoError(t, err)
+	require.NotNil(t, evse)
+	assert.Equal(t, time.Date(2023, 6, 2, 6, 0, 0, 0, time.UTC), evse.DepartureTime)
+
+	require.Len(t, callMaker.requests, 1)
+	assert.Equal(t, &types.SetChargingProfileRequestJson{
+		EvseId: 2,
+		ChargingProfile: types.ChargingProfileType{
+			Id:                     1002,
+			StackLevel:             0,
+			ChargingProfilePurpose: types.ChargingProfilePurposeEnumTypeTxDefaultProfile,
+			ChargingProfileKind:    types.ChargingProfileKindEnumTypeAbsolute,
+			ChargingSchedule: []types.ChargingScheduleType{
+				{
+					Id:               1,
+					StartSchedule:    makePtr("2023-06-01T22:00:00Z"),
+					Duration:         makePtr(8 * 60 * 60),
+					ChargingRateUnit: types.ChargingRateUnitEnumTypeW,
+					ChargingSchedulePeriod: []types.ChargingSchedulePeriodType{
+						{StartPeriod: 0, Limit: 0},
+						{StartPeriod: 3 * 60 * 60, Limit: 11040},
+						{StartPeriod: 5 * 60 * 60, Limit: 0},
+					},
+				},
+			},
+		},
+	}, callMaker.requests[0])
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"charging_needs.evse_id":                   2,
+		"charging_needs.requested_energy_transfer": "AC_three_phase",
+		"charging_needs.energy_amount":             float64(20000),
+		"charging_needs.max_power":                 float64(11040),
+		"charging_needs.departure_time":            "2023-06-02T06:00:00Z",
+		"charging_needs.schedule_periods":          3,
+	})
+}
+
+func TestNotifyEVChargingNeedsHandlerChargesAsSoonAsPossible(t *testing.T) {
+	now := time.Date(2023, 6, 1, 22, 0, 0, 0, time.UTC)
+	schedule := []services.ChargingSchedulePeriod{
+		{Start: now, Limit: 0},
+		{Start: now.Add(3 * time.Hour), Limit: 50000},
+	}
+
+	tests := map[string]struct {
+		strategy          *fixedSchedulingStrategy
+		maxScheduleTuples *int
+	}{
+		"strategy fails": {
+			strategy: &fixedSchedulingStrategy{err: errors.New("no forecast")},
+		},
+		"too many periods": {
+			strategy:          &fixedSchedulingStrategy{schedule: schedule},
+			maxScheduleTuples: makePtr(1),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			callMaker := &recordingCallMaker{}
+			handler := ocpp201.NotifyEVChargingNeedsHandler{
+				Clock:              clockTest.NewFakePassiveClock(now),
+				SchedulingStrategy: tc.strategy,
+				CallMaker:          callMaker,
+			}
+
+			req := &types.NotifyEVChargingNeedsRequestJson{
+				EvseId:            1,
+				MaxScheduleTuples: tc.maxScheduleTuples,
+				ChargingNeeds: types.ChargingNeedsType{
+					RequestedEnergyTransfer: types.EnergyTransferModeEnumTypeDC,
+					DcChargingParameters: &types.DCChargingParametersType{
+						EnergyAmount: makePtr(40000),
+						EvMaxCurrent: 125,
+						EvMaxVoltage: 400,
+					},
+				},
+			}
+
+			resp, err := handler.HandleCall(context.Background(), "cs001", req)
+			require.NoError(t, err)
+			assert.Equal(t, &types.NotifyEVChargingNeedsResponseJson{
+				Status: types.NotifyEVChargingNeedsStatusEnumTypeAccepted,
+			}, resp)
+
+			require.Len(t, callMaker.requests, 1)
+			got := callMaker.requests[0].(*types.SetChargingProfileRequestJson)
+			require.Len(t, got.ChargingProfile.ChargingSchedule, 1)
+			assert.Nil(t, got.ChargingProfile.ChargingSchedule[0].Duration)
+			assert.Equal(t, []types.ChargingSchedulePeriodType{
+				{StartPeriod: 0, Limit: 50000},
+			}, got.ChargingProfile.ChargingSchedule[0].ChargingSchedulePeriod)
+		})
+	}
+}
@@ -4,13 +4,22 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"strings"
 )
 
-type NotifyReportHandler struct{}
+// NotifyReportHandler records the EVSEs and connectors that are reported by the
+// charge station: the type of each connector is taken from the Connector component's
+// ConnectorType variable and the power of each connector from the maximum of the EVSE
+// component's Power variable.
+type NotifyReportHandler struct {
+	Evses handlers.EvseRecorder
+}
 
 func (h NotifyReportHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (response ocpp.Response, err error) {
 	req := request.(*ocpp201.NotifyReportRequestJson)
@@ -23,5 +32,67 @@ func (h NotifyReportHandler) HandleCall(ctx context.Context, chargeStationId str
 		attribute.Int("notify_report.seq_no", req.SeqNo),
 		attribute.Bool("notify_report.tbc", req.Tbc))
 
+	// the connectors are recorded first so that the power of their EVSE applies to
+	// them wherever the EVSE appears in the report
+	for _, data := range req.ReportData {
+		evse := data.Component.Evse
+		if !strings.EqualFold(data.Component.Name, "Connector") || evse == nil || evse.ConnectorId == nil {
+			continue
+		}
+		connectorType := ""
+		if strings.EqualFold(data.Variable.Name, "ConnectorType") {
+			connectorType = actualValue(data.VariableAttribute)
+		}
+		err = h.Evses.Update(ctx, chargeStationId, evse.Id, func(cse *store.ChargeStationEvse) bool {
+			connector := cse.Connector(*evse.ConnectorId)
+			if connector == nil {
+				connector = cse.AddConnector(*evse.ConnectorId)
+			} else if connectorType == "" || connector.ConnectorType == connectorType {
+				return false
+			}
+			if connectorType != "" {
+				connector.ConnectorType = connectorType
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, data := range req.ReportData {
+		evse := data.Component.Evse
+		if !strings.EqualFold(data.Component.Name, "EVSE") || evse == nil ||
+			!strings.EqualFold(data.Variable.Name, "Power") ||
+			data.VariableCharacteristics == nil || data.VariableCharacteristics.MaxLimit == nil {
+			continue
+		}
+		maxPower := *data.VariableCharacteristics.MaxLimit
+		err = h.Evses.Update(ctx, chargeStationId, evse.Id, func(cse *store.ChargeStationEvse) bool {
+			changed := false
+			for i := range cse.Connectors {
+				if cse.Connectors[i].MaxPower != maxPower {
+					cse.Connectors[i].MaxPower = maxPower
+					changed = true
+				}
+			}
+			return changed
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &ocpp201.NotifyReportResponseJson{}, nil
 }
+
+// actualValue returns the value of the Actual attribute, which is the attribute
+// reported when the type is omitted
+func actualValue(attributes []ocpp201.VariableAttributeType) string {
+	for _, attr := range attributes {
+		if (attr.Type == nil || *attr.Type == ocpp201.AttributeEnumTypeActual) && attr.Value != nil {
+			return *attr.Value
+		}
+	}
+	return ""
+}
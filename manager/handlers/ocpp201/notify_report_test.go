@@ -6,10 +6,16 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 	"testing"
+	"time"
 )
 
 func TestNotifyReport(t *testing.T) {
@@ -63,3 +69,83 @@ func TestNotifyReport(t *testing.T) {
 		"notify_report.tbc":          false,
 	})
 }
+
+func TestNotifyReportRecordsTheTopology(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2024, 3, 18, 17, 10, 0, 0, time.UTC)
+	handler := ocpp201.NotifyReportHandler{
+		Evses: handlers.EvseRecorder{
+			Clock: clockTest.NewFakePassiveClock(now),
+			Store: engine,
+		},
+	}
+
+	req := &types.NotifyReportRequestJson{
+		GeneratedAt: "2024-03-18T17:10:00.000Z",
+		ReportData: []types.ReportDataType{
+			{
+				Component: types.ComponentType{
+					Name: "EVSE",
+					Evse: &types.EVSEType{Id: 1},
+				},
+				Variable: types.VariableType{
+					Name: "Power",
+				},
+				VariableAttribute: []types.VariableAttributeType{
+					{Value: makePtr("0")},
+				},
+				VariableCharacteristics: &types.VariableCharacteristicsType{
+					DataType: types.DataEnumTypeDecimal,
+					MaxLimit: makePtr(22000.0),
+					Unit:     makePtr("W"),
+				},
+			},
+			{
+				Component: types.ComponentType{
+					Name: "Connector",
+					Evse: &types.EVSEType{Id: 1, ConnectorId: makePtr(1)},
+				},
+				Variable: types.VariableType{
+					Name: "ConnectorType",
+				},
+				VariableAttribute: []types.VariableAttributeType{
+					{Type: makePtr(types.AttributeEnumTypeActual), Value: makePtr("cType2")},
+				},
+			},
+			{
+				Component: types.ComponentType{
+					Name: "Connector",
+					Evse: &types.EVSEType{Id: 2, ConnectorId: makePtr(1)},
+				},
+				Variable: types.VariableType{
+					Name: "AvailabilityState",
+				},
+				VariableAttribute: []types.VariableAttributeType{
+					{Value: makePtr("Available")},
+				},
+			},
+		},
+		RequestId: 42,
+		SeqNo:     0,
+	}
+
+	_, err := handler.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+
+	evses, err := engine.ListEvses(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationEvse{
+		{
+			ChargeStationId: "cs001",
+			EvseId:          1,
+			Connectors:      []store.ChargeStationConnector{{ConnectorId: 1, ConnectorType: "cType2", MaxPower: 22000}},
+			LastUpdated:     now,
+		},
+		{
+			ChargeStationId: "cs001",
+			EvseId:          2,
+			Connectors:      []store.ChargeStationConnector{{ConnectorId: 1}},
+			LastUpdated:     now,
+		},
+	}, evses)
+}
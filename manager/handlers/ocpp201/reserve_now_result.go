@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+)
+
+type ReserveNowResultHandler struct {
+	Clock    clock.PassiveClock
+	Store    store.ReservationStore
+	Notifier services.ReservationNotifier
+}
+
+func (h ReserveNowResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.ReserveNowRequestJson)
+	resp := response.(*types.ReserveNowResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(
+		attribute.Int("reserve_now.reservation_id", req.Id),
+		attribute.String("reserve_now.status", string(resp.Status)))
+
+	reservation, err := h.Store.LookupReservation(ctx, req.Id)
+	if err != nil {
+		return err
+	}
+	if reservation == nil {
+		return nil
+	}
+
+	accepted := resp.Status == types.ReserveNowStatusEnumTypeAccepted
+
+	transfer := reservation.Transfer
+	if transfer != nil && transfer.Status == store.ReservationTransferStatusPending && transfer.ChargeStationId == chargeStationId {
+		span.SetAttributes(attribute.Bool("reserve_now.transfer", true))
+		if !accepted {
+			transfer.Status = store.ReservationTransferStatusRejected
+			err = h.Store.SetReservation(ctx, reservation)
+			if err != nil {
+				return err
+			}
+			err = h.Notifier.ReservationTransferFailed(ctx, reservation)
+			if err != nil {
+				handlers.LoggerFromContext(ctx).Error("error sending reservation notification", "err", err)
+			}
+			return nil
+		}
+
+		if transfer.ChargeStationId == reservation.ChargeStationId {
+			// a ReserveNow with an existing reservation id replaces that reservation
+			// so there is nothing to cancel
+			return completeReservationTransfer(ctx, h.Store, h.Notifier, reservation)
+		}
+
+		transfer.Status = store.ReservationTransferStatusAccepted
+		reservation.SendAfter = h.Clock.Now()
+		return h.Store.SetReservation(ctx, reservation)
+	}
+
+	if reservation.ChargeStationId != chargeStationId || reservation.Status != store.ReservationStatusPending {
+		return nil
+	}
+
+	if accepted {
+		reservation.Status = store.ReservationStatusAccepted
+	} else {
+		reservation.Status = store.ReservationStatusRejected
+	}
+	return h.Store.SetReservation(ctx, reservation)
+}
+
+// completeReservationTransfer moves the reservation to the transfer target and
+// lets the driver know where the reservation is now held.
+func completeReservationTransfer(ctx context.Context, reservationStore store.ReservationStore, notifier services.ReservationNotifier, reservation *store.Reservation) error {
+	previousChargeStationId := reservation.ChargeStationId
+	previousEvseId := reservation.EvseId
+
+	reservation.ChargeStationId = reservation.Transfer.ChargeStationId
+	reservation.EvseId = reservation.Transfer.EvseId
+	reservation.Status = store.ReservationStatusAccepted
+	reservation.Transfer = nil
+
+	err := reservationStore.SetReservation(ctx, reservation)
+	if err != nil {
+		return err
+	}
+
+	err = notifier.ReservationTransferred(ctx, reservation, previousChargeStationId, previousEvseId)
+	if err != nil {
+		handlers.LoggerFromContext(ctx).Error("error sending reservation notification", "err", err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type fakeReservationNotifier struct {
+	transferred    []*store.Reservation
+	previousCsIds  []string
+	transferFailed []*store.Reservation
+}
+
+func (f *fakeReservationNotifier) ReservationTransferred(_ context.Context, reservation *store.Reservation, previousChargeStationId string, _ *int) error {
+	f.transferred = append(f.transferred, reservation)
+	f.previousCsIds = append(f.previousCsIds, previousChargeStationId)
+	return nil
+}
+
+func (f *fakeReservationNotifier) ReservationTransferFailed(_ context.Context, reservation *store.Reservation) error {
+	f.transferFailed = append(f.transferFailed, reservation)
+	return nil
+}
+
+func reserveNowRequest(reservationId int) *types.ReserveNowRequestJson {
+	return &types.ReserveNowRequestJson{
+		Id:             reservationId,
+		ExpiryDateTime: "2023-06-15T16:30:00Z",
+		IdToken: types.IdTokenType{
+			IdToken: "SOMERFID",
+			Type:    types.IdTokenEnumTypeISO14443,
+		},
+	}
+}
+
+func TestReserveNowResultHandlerAcceptsReservation(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	notifier := &fakeReservationNotifier{}
+	handler := ocpp201.ReserveNowResultHandler{
+		Clock:    clock.RealClock{},
+		Store:    engine,
+		Notifier: notifier,
+	}
+
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusPending,
+	})
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		resp := &types.ReserveNowResponseJson{
+			Status: types.ReserveNowStatusEnumTypeAccepted,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", reserveNowRequest(1), resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"reserve_now.reservation_id": 1,
+		"reserve_now.status":         "Accepted",
+	})
+
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusAccepted, reservation.Status)
+}
+
+func TestReserveNowResultHandlerRejectsReservation(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	handler := ocpp201.ReserveNowResultHandler{
+		Clock:    clock.RealClock{},
+		Store:    engine,
+		Notifier: &fakeReservationNotifier{},
+	}
+
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusPending,
+	})
+	require.NoError(t, err)
+
+	resp := &types.ReserveNowResponseJson{
+		Status: types.ReserveNowStatusEnumTypeOccupied,
+	}
+
+	err = handler.HandleCallResult(context.Background(), "cs001", reserveNowRequest(1), resp, nil)
+	require.NoError(t, err)
+
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusRejected, reservation.Status)
+}
+
+func TestReserveNowResultHandlerAcceptsTransferToAnotherChargeStation(t *testing.T) {
+	now := time.Now().UTC()
+	engine := inmemory.NewStore(clock.RealClock{})
+	notifier := &fakeReservationNotifier{}
+	handler := ocpp201.ReserveNowResultHandler{
+		Clock:    clockTest.NewFakePassiveClock(now),
+		Store:    engine,
+		Notifier: notifier,
+	}
+
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusAccepted,
+		Transfer: &store.ReservationTransfer{
+			ChargeStationId: "cs002",
+			Status:          store.ReservationTransferStatusPending,
+		},
+	})
+	require.NoError(t, err)
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		resp := &types.ReserveNowResponseJson{
+			Status: types.ReserveNowStatusEnumTypeAccepted,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs002", reserveNowRequest(1), resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"reserve_now.reservation_id": 1,
+		"reserve_now.status":         "Accepted",
+		"reserve_now.transfer":       true,
+	})
+
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "cs001", reservation.ChargeStationId)
+	assert.Equal(t, store.ReservationTransferStatusAccepted, reservation.Transfer.Status)
+	assert.Equal(t, now, reservation.SendAfter)
+	assert.Empty(t, notifier.transferred)
+}
+
+func TestReserveNowResultHandlerCompletesTransferToAnotherEvse(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	notifier := &fakeReservationNotifier{}
+	handler := ocpp201.ReserveNowResultHandler{
+		Clock:    clock.RealClock{},
+		Store:    engine,
+		Notifier: notifier,
+	}
+
+	evseId := 1
+	targetEvseId := 2
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		EvseId:          &evseId,
+		Status:          store.ReservationStatusAccepted,
+		Transfer: &store.ReservationTransfer{
+			ChargeStationId: "cs001",
+			EvseId:          &targetEvseId,
+			Status:          store.ReservationTransferStatusPending,
+		},
+	})
+	require.NoError(t, err)
+
+	resp := &types.ReserveNowResponseJson{
+		Status: types.ReserveNowStatusEnumTypeAccepted,
+	}
+
+	err = handler.HandleCallResult(context.Background(), "cs001", reserveNowRequest(1), resp, nil)
+	require.NoError(t, err)
+
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "cs001", reservation.ChargeStationId)
+	assert.Equal(t, &targetEvseId, reservation.EvseId)
+	assert.Nil(t, reservation.Transfer)
+	assert.Equal(t, store.ReservationStatusAccepted, reservation.Status)
+	require.Len(t, notifier.transferred, 1)
+	assert.Equal(t, "cs001", notifier.previousCsIds[0])
+}
+
+func TestReserveNowResultHandlerRejectsTransfer(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	notifier := &fakeReservationNotifier{}
+	handler := ocpp201.ReserveNowResultHandler{
+		Clock:    clock.RealClock{},
+		Store:    engine,
+		Notifier: notifier,
+	}
+
+	err := engine.SetReservation(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusAccepted,
+		Transfer: &store.ReservationTransfer{
+			ChargeStationId: "cs002",
+			Status:          store.ReservationTransferStatusPending,
+		},
+	})
+	require.NoError(t, err)
+
+	resp := &types.ReserveNowResponseJson{
+		Status: types.ReserveNowStatusEnumTypeFaulted,
+	}
+
+	err = handler.HandleCallResult(context.Background(), "cs002", reserveNowRequest(1), resp, nil)
+	require.NoError(t, err)
+
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "cs001", reservation.ChargeStationId)
+	assert.Equal(t, store.ReservationStatusAccepted, reservation.Status)
+	assert.Equal(t, store.ReservationTransferStatusRejected, reservation.Transfer.Status)
+	assert.Len(t, notifier.transferFailed, 1)
+}
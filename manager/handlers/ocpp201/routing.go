@@ -9,10 +9,11 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
 	"io/fs"
 	"k8s.io/utils/clock"
 	"reflect"
-	"time"
+	"strings"
 )
 
 func NewRouter(emitter transport.Emitter,
@@ -22,264 +23,435 @@ func NewRouter(emitter transport.Emitter,
 	certValidationService services.CertificateValidationService,
 	chargeStationCertProvider services.ChargeStationCertificateProvider,
 	contractCertProvider services.ContractCertificateProvider,
-	heartbeatInterval time.Duration,
-	schemaFS fs.FS) transport.MessageHandler {
+	heartbeatInterval *services.HeartbeatInterval,
+	registrationPolicy handlers.RegistrationPolicy,
+	connectorStatusListener handlers.ConnectorStatusListener,
+	transactionListener handlers.TransactionListener,
+	securityEventListener handlers.SecurityEventListener,
+	remoteTokenAuthorizer services.RemoteTokenAuthorizer,
+	schedulingStrategy services.SchedulingStrategy,
+	deadLetters transport.DeadLetterEmitter,
+	callResponses *handlers.CallResponses,
+	poisonMessages *handlers.PoisonMessages,
+	actionFlags *handlers.ActionFlags,
+	schemaFS fs.FS,
+	callMiddleware ...handlers.CallMiddleware) transport.MessageHandler {
 
-	return &handlers.Router{
-		Emitter:     emitter,
-		SchemaFS:    schemaFS,
-		OcppVersion: transport.OcppVersion201,
-		CallRoutes: map[string]handlers.CallRoute{
-			"Authorize": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.AuthorizeRequestJson) },
-				RequestSchema:  "ocpp201/AuthorizeRequest.json",
-				ResponseSchema: "ocpp201/AuthorizeResponse.json",
-				Handler: AuthorizeHandler{
-					// PENDING: inject token auth service
-					TokenAuthService: &services.OcppTokenAuthService{
-						Clock:      clk,
-						TokenStore: engine,
-					},
-					CertificateValidationService: certValidationService,
-				},
-			},
-			"BootNotification": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.BootNotificationRequestJson) },
-				RequestSchema:  "ocpp201/BootNotificationRequest.json",
-				ResponseSchema: "ocpp201/BootNotificationResponse.json",
-				Handler: BootNotificationHandler{
-					Clock:               clk,
-					HeartbeatInterval:   int(heartbeatInterval.Seconds()),
-					RuntimeDetailsStore: engine,
-				},
-			},
-			"FirmwareStatusNotification": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.FirmwareStatusNotificationRequestJson) },
-				RequestSchema:  "ocpp201/FirmwareStatusNotificationRequest.json",
-				ResponseSchema: "ocpp201/FirmwareStatusNotificationResponse.json",
-				Handler:        FirmwareStatusNotificationHandler{},
-			},
-			"GetCertificateStatus": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.GetCertificateStatusRequestJson) },
-				RequestSchema:  "ocpp201/GetCertificateStatusRequest.json",
-				ResponseSchema: "ocpp201/GetCertificateStatusResponse.json",
-				Handler: GetCertificateStatusHandler{
-					CertificateValidationService: certValidationService,
-				},
-			},
-			"Get15118EVCertificate": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.Get15118EVCertificateRequestJson) },
-				RequestSchema:  "ocpp201/Get15118EVCertificateRequest.json",
-				ResponseSchema: "ocpp201/Get15118EVCertificateResponse.json",
-				Handler: Get15118EvCertificateHandler{
-					ContractCertificateProvider: contractCertProvider,
-				},
-			},
-			"Heartbeat": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
-				RequestSchema:  "ocpp201/HeartbeatRequest.json",
-				ResponseSchema: "ocpp201/HeartbeatResponse.json",
-				Handler: HeartbeatHandler{
-					Clock: clk,
+	callRoutes, callResultRoutes := NewRoutes(emitter,
+		transport.OcppVersion201,
+		clk,
+		engine,
+		tariffService,
+		certValidationService,
+		chargeStationCertProvider,
+		contractCertProvider,
+		heartbeatInterval,
+		registrationPolicy,
+		connectorStatusListener,
+		transactionListener,
+		securityEventListener,
+		remoteTokenAuthorizer,
+		schedulingStrategy)
+
+	router := &handlers.Router{
+		Emitter:          emitter,
+		SchemaFS:         schemaFS,
+		DeadLetters:      deadLetters,
+		CallResponses:    callResponses,
+		PoisonMessages:   poisonMessages,
+		ActionFlags:      actionFlags,
+		OcppVersion:      transport.OcppVersion201,
+		CallRoutes:       callRoutes,
+		CallResultRoutes: callResultRoutes,
+	}
+
+	router.Use(
+		append([]handlers.CallMiddleware{
+			handlers.CallMetrics(transport.OcppVersion201),
+			handlers.RecoverCalls,
+		}, callMiddleware...),
+		[]handlers.CallResultMiddleware{
+			handlers.CallResultMetrics(transport.OcppVersion201),
+			handlers.RecoverCallResults,
+		})
+
+	if actionFlags != nil {
+		actionFlags.Register(router.OcppVersion, router.Actions()...)
+	}
+
+	// an invalid schema is reported again when a message for the action is handled
+	if err := router.Precompile(); err != nil {
+		slog.Warn("unable to precompile schemas", slog.String("ocppVersion", string(router.OcppVersion)), "err", err)
+	}
+
+	return router
+}
+
+// NewRoutes returns the routes for the OCPP 2.0.1 messages. Later versions of OCPP 2
+// handle the same messages, so the routes are shared: the ocppVersion is the version
+// used for the calls that the handlers make and recorded when a charge station boots.
+func NewRoutes(emitter transport.Emitter,
+	ocppVersion transport.OcppVersion,
+	clk clock.PassiveClock,
+	engine store.Engine,
+	tariffService services.TariffService,
+	certValidationService services.CertificateValidationService,
+	chargeStationCertProvider services.ChargeStationCertificateProvider,
+	contractCertProvider services.ContractCertificateProvider,
+	heartbeatInterval *services.HeartbeatInterval,
+	registrationPolicy handlers.RegistrationPolicy,
+	connectorStatusListener handlers.ConnectorStatusListener,
+	transactionListener handlers.TransactionListener,
+	securityEventListener handlers.SecurityEventListener,
+	remoteTokenAuthorizer services.RemoteTokenAuthorizer,
+	schedulingStrategy services.SchedulingStrategy) (map[string]handlers.CallRoute, map[string]handlers.CallResultRoute) {
+
+	// PENDING: inject reservation notifier
+	reservationNotifier := services.LogReservationNotifier{}
+
+	standardCallMaker := NewCallMaker(emitter)
+	standardCallMaker.OcppVersion = ocppVersion
+
+	callRoutes := map[string]handlers.CallRoute{
+		"Authorize": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.AuthorizeRequestJson) },
+			RequestSchema:  "ocpp201/AuthorizeRequest.json",
+			ResponseSchema: "ocpp201/AuthorizeResponse.json",
+			Handler: AuthorizeHandler{
+				// PENDING: inject token auth service
+				TokenAuthService: &services.OcppTokenAuthService{
+					Clock:                 clk,
+					TokenStore:            engine,
+					RemoteTokenAuthorizer: remoteTokenAuthorizer,
 				},
+				CertificateValidationService: certValidationService,
 			},
-			"LogStatusNotification": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.LogStatusNotificationRequestJson) },
-				RequestSchema:  "ocpp201/LogStatusNotificationRequest.json",
-				ResponseSchema: "ocpp201/LogStatusNotificationResponse.json",
-				Handler:        LogStatusNotificationHandler{},
-			},
-			"MeterValues": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.MeterValuesRequestJson) },
-				RequestSchema:  "ocpp201/MeterValuesRequest.json",
-				ResponseSchema: "ocpp201/MeterValuesResponse.json",
-				Handler:        MeterValuesHandler{},
+		},
+		"BootNotification": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.BootNotificationRequestJson) },
+			RequestSchema:  "ocpp201/BootNotificationRequest.json",
+			ResponseSchema: "ocpp201/BootNotificationResponse.json",
+			Handler: BootNotificationHandler{
+				Clock:               clk,
+				HeartbeatInterval:   heartbeatInterval,
+				RuntimeDetailsStore: engine,
+				ChargeStationStore:  engine,
+				Registration:        registrationPolicy,
+				OcppVersion:         strings.TrimPrefix(string(ocppVersion), "ocpp"),
 			},
-			"NotifyReport": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.NotifyReportRequestJson) },
-				RequestSchema:  "ocpp201/NotifyReportRequest.json",
-				ResponseSchema: "ocpp201/NotifyReportResponse.json",
-				Handler:        NotifyReportHandler{},
+		},
+		"ClearedChargingLimit": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.ClearedChargingLimitRequestJson) },
+			RequestSchema:  "ocpp201/ClearedChargingLimitRequest.json",
+			ResponseSchema: "ocpp201/ClearedChargingLimitResponse.json",
+			Handler:        ClearedChargingLimitHandler{},
+		},
+		"FirmwareStatusNotification": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.FirmwareStatusNotificationRequestJson) },
+			RequestSchema:  "ocpp201/FirmwareStatusNotificationRequest.json",
+			ResponseSchema: "ocpp201/FirmwareStatusNotificationResponse.json",
+			Handler:        FirmwareStatusNotificationHandler{},
+		},
+		"GetCertificateStatus": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.GetCertificateStatusRequestJson) },
+			RequestSchema:  "ocpp201/GetCertificateStatusRequest.json",
+			ResponseSchema: "ocpp201/GetCertificateStatusResponse.json",
+			Handler: GetCertificateStatusHandler{
+				CertificateValidationService: certValidationService,
 			},
-			"StatusNotification": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.StatusNotificationRequestJson) },
-				RequestSchema:  "ocpp201/StatusNotificationRequest.json",
-				ResponseSchema: "ocpp201/StatusNotificationResponse.json",
-				Handler:        handlers.CallHandlerFunc(StatusNotificationHandler),
+		},
+		"Get15118EVCertificate": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.Get15118EVCertificateRequestJson) },
+			RequestSchema:  "ocpp201/Get15118EVCertificateRequest.json",
+			ResponseSchema: "ocpp201/Get15118EVCertificateResponse.json",
+			Handler: Get15118EvCertificateHandler{
+				ContractCertificateProvider: contractCertProvider,
+				Clock:                       clk,
+				ExiResponseStore:            engine,
+				RuntimeDetailsStore:         engine,
 			},
-			"SignCertificate": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.SignCertificateRequestJson) },
-				RequestSchema:  "ocpp201/SignCertificateRequest.json",
-				ResponseSchema: "ocpp201/SignCertificateResponse.json",
-				Handler: SignCertificateHandler{
-					ChargeStationCertificateProvider: chargeStationCertProvider,
-					Store:                            engine,
-				},
+		},
+		"Heartbeat": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
+			RequestSchema:  "ocpp201/HeartbeatRequest.json",
+			ResponseSchema: "ocpp201/HeartbeatResponse.json",
+			Handler: HeartbeatHandler{
+				Clock: clk,
 			},
-			"SecurityEventNotification": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.SecurityEventNotificationRequestJson) },
-				RequestSchema:  "ocpp201/SecurityEventNotificationRequest.json",
-				ResponseSchema: "ocpp201/SecurityEventNotificationResponse.json",
-				Handler:        SecurityEventNotificationHandler{},
+		},
+		"LogStatusNotification": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.LogStatusNotificationRequestJson) },
+			RequestSchema:  "ocpp201/LogStatusNotificationRequest.json",
+			ResponseSchema: "ocpp201/LogStatusNotificationResponse.json",
+			Handler:        LogStatusNotificationHandler{},
+		},
+		"MeterValues": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.MeterValuesRequestJson) },
+			RequestSchema:  "ocpp201/MeterValuesRequest.json",
+			ResponseSchema: "ocpp201/MeterValuesResponse.json",
+			Handler: MeterValuesHandler{
+				Store: engine,
 			},
-			"TransactionEvent": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.TransactionEventRequestJson) },
-				RequestSchema:  "ocpp201/TransactionEventRequest.json",
-				ResponseSchema: "ocpp201/TransactionEventResponse.json",
-				Handler: TransactionEventHandler{
+		},
+		"NotifyEVChargingNeeds": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.NotifyEVChargingNeedsRequestJson) },
+			RequestSchema:  "ocpp201/NotifyEVChargingNeedsRequest.json",
+			ResponseSchema: "ocpp201/NotifyEVChargingNeedsResponse.json",
+			Handler: NotifyEVChargingNeedsHandler{
+				Clock:              clk,
+				SchedulingStrategy: schedulingStrategy,
+				CallMaker:          standardCallMaker,
+				Evses: handlers.EvseRecorder{
+					Clock: clk,
 					Store: engine,
-					TokenAuthService: &services.OcppTokenAuthService{
-						Clock:      clk,
-						TokenStore: engine,
-					},
-					TariffService: tariffService,
 				},
 			},
 		},
-		CallResultRoutes: map[string]handlers.CallResultRoute{
-			"CertificateSigned": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.CertificateSignedRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.CertificateSignedResponseJson) },
-				RequestSchema:  "ocpp201/CertificateSignedRequest.json",
-				ResponseSchema: "ocpp201/CertificateSignedResponse.json",
-				Handler: CertificateSignedResultHandler{
+		"NotifyReport": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.NotifyReportRequestJson) },
+			RequestSchema:  "ocpp201/NotifyReportRequest.json",
+			ResponseSchema: "ocpp201/NotifyReportResponse.json",
+			Handler: NotifyReportHandler{
+				Evses: handlers.EvseRecorder{
+					Clock: clk,
 					Store: engine,
 				},
 			},
-			"ChangeAvailability": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.ChangeAvailabilityRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.ChangeAvailabilityResponseJson) },
-				RequestSchema:  "ocpp201/ChangeAvailabilityRequest.json",
-				ResponseSchema: "ocpp201/ChangeAvailabilityResponse.json",
-				Handler:        ChangeAvailabilityResultHandler{},
-			},
-			"ClearCache": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.ClearCacheRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.ClearCacheResponseJson) },
-				RequestSchema:  "ocpp201/ClearCacheRequest.json",
-				ResponseSchema: "ocpp201/ClearCacheResponse.json",
-				Handler:        ClearCacheResultHandler{},
-			},
-			"DeleteCertificate": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.DeleteCertificateRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.DeleteCertificateResponseJson) },
-				RequestSchema:  "ocpp201/DeleteCertificateRequest.json",
-				ResponseSchema: "ocpp201/DeleteCertificateResponse.json",
-				Handler:        DeleteCertificateResultHandler{},
-			},
-			"GetBaseReport": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.GetBaseReportRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.GetBaseReportResponseJson) },
-				RequestSchema:  "ocpp201/GetBaseReportRequest.json",
-				ResponseSchema: "ocpp201/GetBaseReportResponse.json",
-				Handler:        GetBaseReportResultHandler{},
-			},
-			"GetInstalledCertificateIds": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.GetInstalledCertificateIdsRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.GetInstalledCertificateIdsResponseJson) },
-				RequestSchema:  "ocpp201/GetInstalledCertificateIdsRequest.json",
-				ResponseSchema: "ocpp201/GetInstalledCertificateIdsResponse.json",
-				Handler:        GetInstalledCertificateIdsResultHandler{},
-			},
-			"GetLocalListVersion": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.GetLocalListVersionRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.GetLocalListVersionResponseJson) },
-				RequestSchema:  "ocpp201/GetLocalListVersionRequest.json",
-				ResponseSchema: "ocpp201/GetLocalListVersionResponse.json",
-				Handler:        GetLocalListVersionResultHandler{},
-			},
-			"GetReport": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.GetReportRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.GetReportResponseJson) },
-				RequestSchema:  "ocpp201/GetReportRequest.json",
-				ResponseSchema: "ocpp201/GetReportResponse.json",
-				Handler:        GetReportResultHandler{},
+		},
+		"StatusNotification": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.StatusNotificationRequestJson) },
+			RequestSchema:  "ocpp201/StatusNotificationRequest.json",
+			ResponseSchema: "ocpp201/StatusNotificationResponse.json",
+			Handler: StatusNotificationHandler{
+				ConnectorStatus: handlers.ConnectorStatusRecorder{
+					Clock:    clk,
+					Store:    engine,
+					Listener: connectorStatusListener,
+					Evses: handlers.EvseRecorder{
+						Clock: clk,
+						Store: engine,
+					},
+				},
 			},
-			"GetTransactionStatus": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.GetTransactionStatusRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.GetTransactionStatusResponseJson) },
-				RequestSchema:  "ocpp201/GetTransactionStatusRequest.json",
-				ResponseSchema: "ocpp201/GetTransactionStatusResponse.json",
-				Handler:        GetTransactionStatusResultHandler{},
+		},
+		"SignCertificate": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.SignCertificateRequestJson) },
+			RequestSchema:  "ocpp201/SignCertificateRequest.json",
+			ResponseSchema: "ocpp201/SignCertificateResponse.json",
+			Handler: SignCertificateHandler{
+				ChargeStationCertificateProvider: chargeStationCertProvider,
+				Store:                            engine,
 			},
-			"GetVariables": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.GetVariablesRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.GetVariablesResponseJson) },
-				RequestSchema:  "ocpp201/GetVariablesRequest.json",
-				ResponseSchema: "ocpp201/GetVariablesResponse.json",
-				Handler:        GetVariablesResultHandler{},
+		},
+		"SecurityEventNotification": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.SecurityEventNotificationRequestJson) },
+			RequestSchema:  "ocpp201/SecurityEventNotificationRequest.json",
+			ResponseSchema: "ocpp201/SecurityEventNotificationResponse.json",
+			Handler: SecurityEventNotificationHandler{
+				Listener: securityEventListener,
 			},
-			"InstallCertificate": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.InstallCertificateRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.InstallCertificateResponseJson) },
-				RequestSchema:  "ocpp201/InstallCertificateRequest.json",
-				ResponseSchema: "ocpp201/InstallCertificateResponse.json",
-				Handler: InstallCertificateResultHandler{
-					Store: engine,
+		},
+		"TransactionEvent": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.TransactionEventRequestJson) },
+			RequestSchema:  "ocpp201/TransactionEventRequest.json",
+			ResponseSchema: "ocpp201/TransactionEventResponse.json",
+			Handler: TransactionEventHandler{
+				Store: engine,
+				TokenAuthService: &services.OcppTokenAuthService{
+					Clock:                 clk,
+					TokenStore:            engine,
+					RemoteTokenAuthorizer: remoteTokenAuthorizer,
 				},
+				TariffService: tariffService,
+				Listener:      transactionListener,
 			},
-			"RequestStartTransaction": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.RequestStartTransactionRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.RequestStartTransactionResponseJson) },
-				RequestSchema:  "ocpp201/RequestStartTransactionRequest.json",
-				ResponseSchema: "ocpp201/RequestStartTransactionResponse.json",
-				Handler:        RequestStartTransactionResultHandler{},
+		},
+	}
+
+	callResultRoutes := map[string]handlers.CallResultRoute{
+		"CancelReservation": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.CancelReservationRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.CancelReservationResponseJson) },
+			RequestSchema:  "ocpp201/CancelReservationRequest.json",
+			ResponseSchema: "ocpp201/CancelReservationResponse.json",
+			Handler: CancelReservationResultHandler{
+				Store:    engine,
+				Notifier: reservationNotifier,
 			},
-			"RequestStopTransaction": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.RequestStopTransactionRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.RequestStopTransactionResponseJson) },
-				RequestSchema:  "ocpp201/RequestStopTransactionRequest.json",
-				ResponseSchema: "ocpp201/RequestStopTransactionResponse.json",
-				Handler:        RequestStopTransactionResultHandler{},
+		},
+		"CertificateSigned": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.CertificateSignedRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.CertificateSignedResponseJson) },
+			RequestSchema:  "ocpp201/CertificateSignedRequest.json",
+			ResponseSchema: "ocpp201/CertificateSignedResponse.json",
+			Handler: CertificateSignedResultHandler{
+				Store: engine,
 			},
-			"Reset": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.ResetRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.ResetResponseJson) },
-				RequestSchema:  "ocpp201/ResetRequest.json",
-				ResponseSchema: "ocpp201/ResetResponse.json",
-				Handler:        ResetResultHandler{},
+		},
+		"ChangeAvailability": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.ChangeAvailabilityRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.ChangeAvailabilityResponseJson) },
+			RequestSchema:  "ocpp201/ChangeAvailabilityRequest.json",
+			ResponseSchema: "ocpp201/ChangeAvailabilityResponse.json",
+			Handler:        ChangeAvailabilityResultHandler{},
+		},
+		"ClearCache": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.ClearCacheRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.ClearCacheResponseJson) },
+			RequestSchema:  "ocpp201/ClearCacheRequest.json",
+			ResponseSchema: "ocpp201/ClearCacheResponse.json",
+			Handler:        ClearCacheResultHandler{},
+		},
+		"DeleteCertificate": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.DeleteCertificateRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.DeleteCertificateResponseJson) },
+			RequestSchema:  "ocpp201/DeleteCertificateRequest.json",
+			ResponseSchema: "ocpp201/DeleteCertificateResponse.json",
+			Handler: DeleteCertificateResultHandler{
+				Store: engine,
 			},
-			"SendLocalList": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.SendLocalListRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.SendLocalListResponseJson) },
-				RequestSchema:  "ocpp201/SendLocalListRequest.json",
-				ResponseSchema: "ocpp201/SendLocalListResponse.json",
-				Handler:        SendLocalListResultHandler{},
+		},
+		"GetBaseReport": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.GetBaseReportRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.GetBaseReportResponseJson) },
+			RequestSchema:  "ocpp201/GetBaseReportRequest.json",
+			ResponseSchema: "ocpp201/GetBaseReportResponse.json",
+			Handler:        GetBaseReportResultHandler{},
+		},
+		"GetInstalledCertificateIds": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.GetInstalledCertificateIdsRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.GetInstalledCertificateIdsResponseJson) },
+			RequestSchema:  "ocpp201/GetInstalledCertificateIdsRequest.json",
+			ResponseSchema: "ocpp201/GetInstalledCertificateIdsResponse.json",
+			Handler: GetInstalledCertificateIdsResultHandler{
+				Store: engine,
 			},
-			"SetNetworkProfile": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.SetNetworkProfileRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.SetNetworkProfileResponseJson) },
-				RequestSchema:  "ocpp201/SetNetworkProfileRequest.json",
-				ResponseSchema: "ocpp201/SetNetworkProfileResponse.json",
-				Handler:        SetNetworkProfileResultHandler{},
+		},
+		"GetLocalListVersion": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.GetLocalListVersionRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.GetLocalListVersionResponseJson) },
+			RequestSchema:  "ocpp201/GetLocalListVersionRequest.json",
+			ResponseSchema: "ocpp201/GetLocalListVersionResponse.json",
+			Handler:        GetLocalListVersionResultHandler{},
+		},
+		"GetReport": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.GetReportRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.GetReportResponseJson) },
+			RequestSchema:  "ocpp201/GetReportRequest.json",
+			ResponseSchema: "ocpp201/GetReportResponse.json",
+			Handler:        GetReportResultHandler{},
+		},
+		"GetTransactionStatus": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.GetTransactionStatusRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.GetTransactionStatusResponseJson) },
+			RequestSchema:  "ocpp201/GetTransactionStatusRequest.json",
+			ResponseSchema: "ocpp201/GetTransactionStatusResponse.json",
+			Handler:        GetTransactionStatusResultHandler{},
+		},
+		"GetVariables": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.GetVariablesRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.GetVariablesResponseJson) },
+			RequestSchema:  "ocpp201/GetVariablesRequest.json",
+			ResponseSchema: "ocpp201/GetVariablesResponse.json",
+			Handler:        GetVariablesResultHandler{},
+		},
+		"InstallCertificate": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.InstallCertificateRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.InstallCertificateResponseJson) },
+			RequestSchema:  "ocpp201/InstallCertificateRequest.json",
+			ResponseSchema: "ocpp201/InstallCertificateResponse.json",
+			Handler: InstallCertificateResultHandler{
+				Store: engine,
 			},
-			"SetVariables": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.SetVariablesRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.SetVariablesResponseJson) },
-				RequestSchema:  "ocpp201/SetVariablesRequest.json",
-				ResponseSchema: "ocpp201/SetVariablesResponse.json",
-				Handler: SetVariablesResultHandler{
-					Store: engine,
-				},
+		},
+		"RequestStartTransaction": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.RequestStartTransactionRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.RequestStartTransactionResponseJson) },
+			RequestSchema:  "ocpp201/RequestStartTransactionRequest.json",
+			ResponseSchema: "ocpp201/RequestStartTransactionResponse.json",
+			Handler:        RequestStartTransactionResultHandler{},
+		},
+		"RequestStopTransaction": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.RequestStopTransactionRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.RequestStopTransactionResponseJson) },
+			RequestSchema:  "ocpp201/RequestStopTransactionRequest.json",
+			ResponseSchema: "ocpp201/RequestStopTransactionResponse.json",
+			Handler:        RequestStopTransactionResultHandler{},
+		},
+		"ReserveNow": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.ReserveNowRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.ReserveNowResponseJson) },
+			RequestSchema:  "ocpp201/ReserveNowRequest.json",
+			ResponseSchema: "ocpp201/ReserveNowResponse.json",
+			Handler: ReserveNowResultHandler{
+				Clock:    clk,
+				Store:    engine,
+				Notifier: reservationNotifier,
 			},
-			"TriggerMessage": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.TriggerMessageRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.TriggerMessageResponseJson) },
-				RequestSchema:  "ocpp201/TriggerMessageRequest.json",
-				ResponseSchema: "ocpp201/TriggerMessageResponse.json",
-				Handler: TriggerMessageResultHandler{
-					Store: engine,
-				},
+		},
+		"Reset": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.ResetRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.ResetResponseJson) },
+			RequestSchema:  "ocpp201/ResetRequest.json",
+			ResponseSchema: "ocpp201/ResetResponse.json",
+			Handler:        ResetResultHandler{},
+		},
+		"SendLocalList": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.SendLocalListRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.SendLocalListResponseJson) },
+			RequestSchema:  "ocpp201/SendLocalListRequest.json",
+			ResponseSchema: "ocpp201/SendLocalListResponse.json",
+			Handler:        SendLocalListResultHandler{},
+		},
+		"SetChargingProfile": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.SetChargingProfileRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.SetChargingProfileResponseJson) },
+			RequestSchema:  "ocpp201/SetChargingProfileRequest.json",
+			ResponseSchema: "ocpp201/SetChargingProfileResponse.json",
+			Handler:        SetChargingProfileResultHandler{},
+		},
+		"SetNetworkProfile": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.SetNetworkProfileRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.SetNetworkProfileResponseJson) },
+			RequestSchema:  "ocpp201/SetNetworkProfileRequest.json",
+			ResponseSchema: "ocpp201/SetNetworkProfileResponse.json",
+			Handler:        SetNetworkProfileResultHandler{},
+		},
+		"SetVariables": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.SetVariablesRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.SetVariablesResponseJson) },
+			RequestSchema:  "ocpp201/SetVariablesRequest.json",
+			ResponseSchema: "ocpp201/SetVariablesResponse.json",
+			Handler: SetVariablesResultHandler{
+				Store: engine,
 			},
-			"UnlockConnector": {
-				NewRequest:     func() ocpp.Request { return new(ocpp201.UnlockConnectorRequestJson) },
-				NewResponse:    func() ocpp.Response { return new(ocpp201.UnlockConnectorResponseJson) },
-				RequestSchema:  "ocpp201/UnlockConnectorRequest.json",
-				ResponseSchema: "ocpp201/UnclockConnectorResponse.json",
-				Handler:        UnlockConnectorResultHandler{},
+		},
+		"TriggerMessage": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.TriggerMessageRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.TriggerMessageResponseJson) },
+			RequestSchema:  "ocpp201/TriggerMessageRequest.json",
+			ResponseSchema: "ocpp201/TriggerMessageResponse.json",
+			Handler: TriggerMessageResultHandler{
+				Store: engine,
 			},
 		},
+		"UnlockConnector": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.UnlockConnectorRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.UnlockConnectorResponseJson) },
+			RequestSchema:  "ocpp201/UnlockConnectorRequest.json",
+			ResponseSchema: "ocpp201/UnclockConnectorResponse.json",
+			Handler:        UnlockConnectorResultHandler{},
+		},
+		"UpdateFirmware": {
+			NewRequest:     func() ocpp.Request { return new(ocpp201.UpdateFirmwareRequestJson) },
+			NewResponse:    func() ocpp.Response { return new(ocpp201.UpdateFirmwareResponseJson) },
+			RequestSchema:  "ocpp201/UpdateFirmwareRequest.json",
+			ResponseSchema: "ocpp201/UpdateFirmwareResponse.json",
+			Handler:        UpdateFirmwareResultHandler{},
+		},
 	}
+
+	return callRoutes, callResultRoutes
 }
 
 func NewCallMaker(e transport.Emitter) *handlers.OcppCallMaker {
@@ -287,6 +459,7 @@ func NewCallMaker(e transport.Emitter) *handlers.OcppCallMaker {
 		Emitter:     e,
 		OcppVersion: transport.OcppVersion201,
 		Actions: map[reflect.Type]string{
+			reflect.TypeOf(&ocpp201.CancelReservationRequestJson{}):          "CancelReservation",
 			reflect.TypeOf(&ocpp201.CertificateSignedRequestJson{}):          "CertificateSigned",
 			reflect.TypeOf(&ocpp201.ChangeAvailabilityRequestJson{}):         "ChangeAvailability",
 			reflect.TypeOf(&ocpp201.ClearCacheRequestJson{}):                 "ClearCache",
@@ -300,12 +473,15 @@ func NewCallMaker(e transport.Emitter) *handlers.OcppCallMaker {
 			reflect.TypeOf(&ocpp201.InstallCertificateRequestJson{}):         "InstallCertificate",
 			reflect.TypeOf(&ocpp201.RequestStartTransactionRequestJson{}):    "RequestStartTransaction",
 			reflect.TypeOf(&ocpp201.RequestStopTransactionRequestJson{}):     "RequestStopTransaction",
+			reflect.TypeOf(&ocpp201.ReserveNowRequestJson{}):                 "ReserveNow",
 			reflect.TypeOf(&ocpp201.ResetRequestJson{}):                      "Reset",
 			reflect.TypeOf(&ocpp201.SendLocalListRequestJson{}):              "SendLocalList",
+			reflect.TypeOf(&ocpp201.SetChargingProfileRequestJson{}):         "SetChargingProfile",
 			reflect.TypeOf(&ocpp201.SetNetworkProfileRequestJson{}):          "SetNetworkProfile",
 			reflect.TypeOf(&ocpp201.SetVariablesRequestJson{}):               "SetVariables",
 			reflect.TypeOf(&ocpp201.TriggerMessageRequestJson{}):             "TriggerMessage",
 			reflect.TypeOf(&ocpp201.UnlockConnectorRequestJson{}):            "UnlockConnector",
+			reflect.TypeOf(&ocpp201.UpdateFirmwareRequestJson{}):             "UpdateFirmware",
 		},
 	}
 }
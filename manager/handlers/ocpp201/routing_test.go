@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
@@ -63,7 +64,7 @@ func (f fakeChargeStationCertProvider) ProvideCertificate(ctx context.Context, t
 
 type fakeContractCertProvider struct{}
 
-func (f fakeContractCertProvider) ProvideCertificate(ctx context.Context, exiRequest string) (services.EvCertificate15118Response, error) {
+func (f fakeContractCertProvider) ProvideCertificate(ctx context.Context, exiRequest string, isoVersion services.ISOVersion) (services.EvCertificate15118Response, error) {
 	return services.EvCertificate15118Response{
 		Status:                     types.Iso15118EVCertificateStatusEnumTypeAccepted,
 		CertificateInstallationRes: "",
@@ -84,7 +85,17 @@ func TestRoutingCalls(t *testing.T) {
 		&fakeCertValidationService{},
 		&fakeChargeStationCertProvider{},
 		&fakeContractCertProvider{},
-		5*time.Minute,
+		services.NewHeartbeatInterval(5*time.Minute),
+		handlers.RegistrationPolicy{Store: engine},
+		nil,
+		nil,
+		nil,
+		nil,
+		services.AsSoonAsPossibleSchedulingStrategy{Clock: clock},
+		nil,
+		nil,
+		nil,
+		nil,
 		schemas.OcppSchemas,
 	)
 
@@ -103,6 +114,10 @@ func TestRoutingCalls(t *testing.T) {
 			},
 			Reason: types.BootReasonEnumTypePowerUp,
 		},
+		"ClearedChargingLimit": &types.ClearedChargingLimitRequestJson{
+			ChargingLimitSource: types.ChargingLimitSourceEnumTypeEMS,
+			EvseId:              makePtr(1),
+		},
 		"FirmwareStatusNotification": &types.FirmwareStatusNotificationRequestJson{
 			Status: types.FirmwareStatusEnumTypeDownloading,
 		},
@@ -139,6 +154,18 @@ func TestRoutingCalls(t *testing.T) {
 				},
 			},
 		},
+		"NotifyEVChargingNeeds": &types.NotifyEVChargingNeedsRequestJson{
+			EvseId: 1,
+			ChargingNeeds: types.ChargingNeedsType{
+				RequestedEnergyTransfer: types.EnergyTransferModeEnumTypeACThreePhase,
+				AcChargingParameters: &types.ACChargingParametersType{
+					EnergyAmount: 20000,
+					EvMaxCurrent: 32,
+					EvMaxVoltage: 230,
+					EvMinCurrent: 6,
+				},
+			},
+		},
 		"NotifyReport": &types.NotifyReportRequestJson{
 			GeneratedAt: "2024-03-18T17:50:00.000Z",
 			RequestId:   33,
@@ -233,7 +260,17 @@ func TestRoutingCallResults(t *testing.T) {
 		&fakeCertValidationService{},
 		&fakeChargeStationCertProvider{},
 		&fakeContractCertProvider{},
-		5*time.Minute,
+		services.NewHeartbeatInterval(5*time.Minute),
+		handlers.RegistrationPolicy{Store: engine},
+		nil,
+		nil,
+		nil,
+		nil,
+		services.AsSoonAsPossibleSchedulingStrategy{Clock: clock},
+		nil,
+		nil,
+		nil,
+		nil,
 		schemas.OcppSchemas,
 	)
 
@@ -418,6 +455,29 @@ func TestRoutingCallResults(t *testing.T) {
 				Status: types.SendLocalListStatusEnumTypeAccepted,
 			},
 		},
+		"SetChargingProfile": {
+			request: &types.SetChargingProfileRequestJson{
+				EvseId: 1,
+				ChargingProfile: types.ChargingProfileType{
+					Id:                     1001,
+					ChargingProfileKind:    types.ChargingProfileKindEnumTypeAbsolute,
+					ChargingProfilePurpose: types.ChargingProfilePurposeEnumTypeTxDefaultProfile,
+					ChargingSchedule: []types.ChargingScheduleType{
+						{
+							Id:               1,
+							StartSchedule:    makePtr("2023-06-15T15:05:00+01:00"),
+							ChargingRateUnit: types.ChargingRateUnitEnumTypeW,
+							ChargingSchedulePeriod: []types.ChargingSchedulePeriodType{
+								{StartPeriod: 0, Limit: 22080},
+							},
+						},
+					},
+				},
+			},
+			response: &types.SetChargingProfileResponseJson{
+				Status: types.ChargingProfileStatusEnumTypeAccepted,
+			},
+		},
 		"SetNetworkProfile": {
 			request: &types.SetNetworkProfileRequestJson{
 				ConfigurationSlot: 1,
@@ -583,6 +643,24 @@ func TestCallMaker(t *testing.T) {
 			UpdateType:    types.UpdateEnumTypeDifferential,
 			VersionNumber: 12,
 		},
+		"SetChargingProfile": &types.SetChargingProfileRequestJson{
+			EvseId: 1,
+			ChargingProfile: types.ChargingProfileType{
+				Id:                     1001,
+				ChargingProfileKind:    types.ChargingProfileKindEnumTypeAbsolute,
+				ChargingProfilePurpose: types.ChargingProfilePurposeEnumTypeTxDefaultProfile,
+				ChargingSchedule: []types.ChargingScheduleType{
+					{
+						Id:               1,
+						StartSchedule:    makePtr("2023-06-15T15:05:00+01:00"),
+						ChargingRateUnit: types.ChargingRateUnitEnumTypeW,
+						ChargingSchedulePeriod: []types.ChargingSchedulePeriodType{
+							{StartPeriod: 0, Limit: 22080},
+						},
+					},
+				},
+			},
+		},
 		"SetNetworkProfile": &types.SetNetworkProfileRequestJson{
 			ConfigurationSlot: 1,
 			ConnectionData: types.NetworkConnectionProfileType{
@@ -4,13 +4,16 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
-type SecurityEventNotificationHandler struct{}
+type SecurityEventNotificationHandler struct {
+	Listener handlers.SecurityEventListener
+}
 
 func (s SecurityEventNotificationHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (response ocpp.Response, err error) {
 	req := request.(*ocpp201.SecurityEventNotificationRequestJson)
@@ -22,5 +25,7 @@ func (s SecurityEventNotificationHandler) HandleCall(ctx context.Context, charge
 		span.SetAttributes(attribute.String("security_event.tech_info", *req.TechInfo))
 	}
 
+	handlers.NotifySecurityEventReported(ctx, s.Listener, chargeStationId, req.Type, req.Timestamp, req.TechInfo)
+
 	return &ocpp201.SecurityEventNotificationResponseJson{}, nil
 }
@@ -41,3 +41,39 @@ func TestSecurityEventNotificationHandler(t *testing.T) {
 		"security_event.type":      "SomeSecurityEvent",
 	})
 }
+
+type recordingSecurityEventListener struct {
+	chargeStationId, eventType, timestamp string
+	techInfo                              *string
+}
+
+func (r *recordingSecurityEventListener) SecurityEventReported(_ context.Context, chargeStationId, eventType, timestamp string, techInfo *string) error {
+	r.chargeStationId = chargeStationId
+	r.eventType = eventType
+	r.timestamp = timestamp
+	r.techInfo = techInfo
+	return nil
+}
+
+func TestSecurityEventNotificationHandlerNotifiesListener(t *testing.T) {
+	listener := &recordingSecurityEventListener{}
+	handler := SecurityEventNotificationHandler{Listener: listener}
+
+	techInfo := "some tech info"
+	req := &ocpp201.SecurityEventNotificationRequestJson{
+		Timestamp: "2023-06-15T15:05:00Z",
+		Type:      "SomeSecurityEvent",
+		TechInfo:  &techInfo,
+	}
+
+	resp, err := handler.HandleCall(context.Background(), "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, &ocpp201.SecurityEventNotificationResponseJson{}, resp)
+
+	assert.Equal(t, &recordingSecurityEventListener{
+		chargeStationId: "cs001",
+		eventType:       "SomeSecurityEvent",
+		timestamp:       "2023-06-15T15:05:00Z",
+		techInfo:        &techInfo,
+	}, listener)
+}
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type SetChargingProfileResultHandler struct{}
+
+func (h SetChargingProfileResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.SetChargingProfileRequestJson)
+	resp := response.(*types.SetChargingProfileResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(
+		attribute.Int("set_charging_profile.evse_id", req.EvseId),
+		attribute.Int("set_charging_profile.profile_id", req.ChargingProfile.Id),
+		attribute.String("set_charging_profile.purpose", string(req.ChargingProfile.ChargingProfilePurpose)),
+		attribute.String("set_charging_profile.status", string(resp.Status)))
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"testing"
+)
+
+func TestSetChargingProfileResultHandler(t *testing.T) {
+	handler := ocpp201.SetChargingProfileResultHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.SetChargingProfileRequestJson{
+			EvseId: 1,
+			ChargingProfile: types.ChargingProfileType{
+				Id:                     1001,
+				ChargingProfileKind:    types.ChargingProfileKindEnumTypeAbsolute,
+				ChargingProfilePurpose: types.ChargingProfilePurposeEnumTypeTxDefaultProfile,
+				ChargingSchedule: []types.ChargingScheduleType{
+					{
+						Id:               1,
+						ChargingRateUnit: types.ChargingRateUnitEnumTypeW,
+						ChargingSchedulePeriod: []types.ChargingSchedulePeriodType{
+							{StartPeriod: 0, Limit: 7400},
+						},
+					},
+				},
+			},
+		}
+		resp := &types.SetChargingProfileResponseJson{
+			Status: types.ChargingProfileStatusEnumTypeRejected,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"set_charging_profile.evse_id":    1,
+		"set_charging_profile.profile_id": 1001,
+		"set_charging_profile.purpose":    "TxDefaultProfile",
+		"set_charging_profile.status":     "Rejected",
+	})
+}
@@ -5,12 +5,12 @@ package ocpp201
 import (
 	"context"
 	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
-	"golang.org/x/exp/slog"
 )
 
 type SetVariablesResultHandler struct {
@@ -30,7 +30,7 @@ func (i SetVariablesResultHandler) HandleCallResult(ctx context.Context, chargeS
 
 		err := i.Store.DeleteChargeStationSettings(ctx, chargeStationId)
 		if err != nil {
-			slog.Error("failed to delete charge station settings", "err", err)
+			handlers.LoggerFromContext(ctx).Error("failed to delete charge station settings", "err", err)
 			span.AddEvent("failed to delete charge station settings", trace.WithAttributes(attribute.String("err", err.Error())))
 		}
 	}
@@ -8,10 +8,10 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/services"
-	"golang.org/x/exp/slog"
 )
 
 type SignCertificateHandler struct {
@@ -46,12 +46,12 @@ func (s SignCertificateHandler) HandleCall(ctx context.Context, chargeStationId
 
 		pemChain, err := s.ChargeStationCertificateProvider.ProvideCertificate(ctx, certType, req.Csr, chargeStationId)
 		if err != nil {
-			slog.Error("failed to sign certificate", "err", err)
+			handlers.LoggerFromContext(ctx).Error("failed to sign certificate", "err", err)
 			span.AddEvent("failed to sign certificate", trace.WithAttributes(attribute.String("err", err.Error())))
 		} else {
 			certId, err := GetCertificateId(pemChain)
 			if err != nil {
-				slog.Error("failed to get certificate id", "err", err)
+				handlers.LoggerFromContext(ctx).Error("failed to get certificate id", "err", err)
 				span.AddEvent("failed to get certificate id", trace.WithAttributes(attribute.String("err", err.Error())))
 			} else {
 				err = s.Store.UpdateChargeStationInstallCertificates(ctx, chargeStationId, &store.ChargeStationInstallCertificates{
@@ -65,7 +65,7 @@ func (s SignCertificateHandler) HandleCall(ctx context.Context, chargeStationId
 					},
 				})
 				if err != nil {
-					slog.Error("failed to update charge station install certificates", "err", err)
+					handlers.LoggerFromContext(ctx).Error("failed to update charge station install certificates", "err", err)
 					span.AddEvent("failed to update charge station install certificates", trace.WithAttributes(attribute.String("err", err.Error())))
 				} else {
 					status = types.GenericStatusEnumTypeAccepted
@@ -4,6 +4,7 @@ package ocpp201
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
@@ -11,7 +12,11 @@ import (
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 )
 
-func StatusNotificationHandler(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+type StatusNotificationHandler struct {
+	ConnectorStatus handlers.ConnectorStatusRecorder
+}
+
+func (s StatusNotificationHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
 	span := trace.SpanFromContext(ctx)
 
 	req := request.(*types.StatusNotificationRequestJson)
@@ -21,5 +26,10 @@ func StatusNotificationHandler(ctx context.Context, chargeStationId string, requ
 		attribute.Int("status.connector_id", req.ConnectorId),
 		attribute.String("status.connector_status", string(req.ConnectorStatus)))
 
+	err := s.ConnectorStatus.Record(ctx, chargeStationId, req.EvseId, req.ConnectorId, string(req.ConnectorStatus))
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.StatusNotificationResponseJson{}, nil
 }
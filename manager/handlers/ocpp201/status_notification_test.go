@@ -5,12 +5,28 @@ package ocpp201_test
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
-	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 	"testing"
+	"time"
 )
 
 func TestStatusNotificationHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	handler := ocpp201.StatusNotificationHandler{
+		ConnectorStatus: handlers.ConnectorStatusRecorder{
+			Clock: clockTest.NewFakePassiveClock(now),
+			Store: engine,
+		},
+	}
+
 	req := &types.StatusNotificationRequestJson{
 		Timestamp:       "2023-05-01T01:00:00+01:00",
 		EvseId:          1,
@@ -18,10 +34,22 @@ func TestStatusNotificationHandler(t *testing.T) {
 		ConnectorStatus: types.ConnectorStatusEnumTypeOccupied,
 	}
 
-	got, err := handlers.StatusNotificationHandler(context.Background(), "cs001", req)
+	got, err := handler.HandleCall(context.Background(), "cs001", req)
 	assert.NoError(t, err)
 
 	want := &types.StatusNotificationResponseJson{}
 
 	assert.Equal(t, want, got)
+
+	statuses, err := engine.ListConnectorStatuses(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ConnectorStatus{
+		{
+			ChargeStationId: "cs001",
+			EvseId:          1,
+			ConnectorId:     2,
+			Status:          "Occupied",
+			LastUpdated:     now,
+		},
+	}, statuses)
 }
@@ -5,6 +5,7 @@ package ocpp201
 import (
 	"context"
 
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/services"
@@ -16,11 +17,12 @@ type TransactionEventHandler struct {
 	Store            store.Engine
 	TokenAuthService services.TokenAuthService
 	TariffService    services.TariffService
+	Listener         handlers.TransactionListener
 }
 
 func (t TransactionEventHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
 	req := request.(*types.TransactionEventRequestJson)
-	slog.Info("transaction event", slog.String("chargeStationId", chargeStationId),
+	handlers.LoggerFromContext(ctx).Info("transaction event",
 		slog.String("transactionId", req.TransactionInfo.TransactionId),
 		slog.String("eventType", string(req.EventType)),
 		slog.String("triggerReason", string(req.TriggerReason)),
@@ -36,56 +38,103 @@ func (t TransactionEventHandler) HandleCall(ctx context.Context, chargeStationId
 		response.IdTokenInfo = &idTokenInfo
 	}
 
-	var err error
+	existing, err := t.Store.FindTransaction(ctx, chargeStationId, req.TransactionInfo.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the changes are written in one batch so that engines that support it apply
+	// them atomically
+	batch := &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   req.TransactionInfo.TransactionId,
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     convertMeterValues(req.MeterValue),
+		SeqNo:           req.SeqNo,
+		Offline:         req.Offline,
+	}
 	switch req.EventType {
 	case types.TransactionEventEnumTypeStarted:
-		err = t.Store.CreateTransaction(
-			ctx,
-			chargeStationId,
-			req.TransactionInfo.TransactionId,
-			idToken,
-			tokenType,
-			convertMeterValues(req.MeterValue),
-			req.SeqNo,
-			req.Offline)
+		batch.Operation = store.TransactionOperationStart
+		batch.ReservationId = req.ReservationId
 	case types.TransactionEventEnumTypeUpdated:
-		err = t.Store.UpdateTransaction(
-			ctx,
-			chargeStationId,
-			req.TransactionInfo.TransactionId,
-			convertMeterValues(req.MeterValue))
+		batch.Operation = store.TransactionOperationUpdate
 	case types.TransactionEventEnumTypeEnded:
-		err = t.Store.EndTransaction(
-			ctx,
-			chargeStationId,
-			req.TransactionInfo.TransactionId,
-			idToken,
-			tokenType,
-			convertMeterValues(req.MeterValue),
-			req.SeqNo)
+		batch.Operation = store.TransactionOperationEnd
+	}
+
+	if req.Offline || isOutOfSequence(existing, req) {
+		if existing == nil || !existing.RecoveredFromOffline {
+			handlers.LoggerFromContext(ctx).Info("transaction recovered from offline",
+				slog.String("transactionId", req.TransactionInfo.TransactionId),
+				slog.Bool("offline", req.Offline))
+			batch.RecoveredFromOffline = true
+		}
+	}
+
+	chargingState := req.TransactionInfo.ChargingState
+	if chargingState == nil && req.EventType == types.TransactionEventEnumTypeEnded {
+		// mark the end of the last reported charging state so time-based fees stop accruing
+		idle := types.ChargingStateEnumTypeIdle
+		chargingState = &idle
+	}
+	if chargingState != nil {
+		batch.ChargingState = &store.ChargingStateChange{
+			State:     string(*chargingState),
+			Timestamp: req.Timestamp,
+		}
 	}
 
+	err = store.WriteTransactionBatch(ctx, t.Store, batch)
 	if err != nil {
 		return nil, err
 	}
 
-	if req.EventType == types.TransactionEventEnumTypeEnded {
+	// the cost is calculated from the complete timeline, so it is recalculated if an
+	// event replayed after the station reconnects arrives after the Ended event
+	if req.EventType == types.TransactionEventEnumTypeEnded || (existing != nil && existing.EndedSeqNo != 0) {
 		transaction, err := t.Store.FindTransaction(ctx, chargeStationId, req.TransactionInfo.TransactionId)
 		if err != nil {
 			return nil, err
 		}
-		cost, err := t.TariffService.CalculateCost(transaction)
+		cost, err := services.CalculateCostBreakdown(t.TariffService, transaction)
 		if err != nil {
-			slog.Error("error calculating tariff", "err", err)
+			handlers.LoggerFromContext(ctx).Error("error calculating tariff", "err", err)
 		} else {
-			slog.Info("total cost", slog.Float64("cost", cost))
-			response.TotalCost = &cost
+			handlers.LoggerFromContext(ctx).Info("total cost", slog.Float64("cost", cost.TotalCost), slog.String("currency", cost.Currency))
+			response.TotalCost = &cost.TotalCost
+			err = store.WriteTransactionBatch(ctx, t.Store, &store.TransactionBatch{
+				ChargeStationId: chargeStationId,
+				TransactionId:   req.TransactionInfo.TransactionId,
+				Cost:            cost,
+			})
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
+	handlers.NotifyTransactionChanged(ctx, t.Store, t.Listener, chargeStationId, req.TransactionInfo.TransactionId)
+
 	return response, nil
 }
 
+// isOutOfSequence reports whether the event arrived in a different order to the
+// one in which the charge station generated it: this happens when a station
+// replays the events it queued while it was offline.
+func isOutOfSequence(existing *store.Transaction, req *types.TransactionEventRequestJson) bool {
+	switch req.EventType {
+	case types.TransactionEventEnumTypeStarted:
+		return existing != nil
+	case types.TransactionEventEnumTypeUpdated:
+		return existing == nil || existing.EndedSeqNo != 0 || req.SeqNo <= existing.StartSeqNo
+	case types.TransactionEventEnumTypeEnded:
+		return existing == nil || req.SeqNo <= existing.StartSeqNo
+	}
+	return false
+}
+
 func convertMeterValues(meterValues []types.MeterValueType) []store.MeterValue {
 	var converted []store.MeterValue
 	for _, meterValue := range meterValues {
@@ -4,6 +4,7 @@ package ocpp201_test
 
 import (
 	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"k8s.io/utils/clock"
 	"testing"
@@ -238,5 +239,331 @@ func TestTransactionEventHandlerWithEndedEvent(t *testing.T) {
 
 	transaction, err := engine.FindTransaction(ctx, "cs001", "5555")
 	require.NoError(t, err)
-	assert.NotNil(t, transaction)
+	require.NotNil(t, transaction)
+	assert.Equal(t, &store.CostBreakdown{TotalCost: 0.055}, transaction.Cost)
+}
+
+func TestTransactionEventHandlerStoresCostBreakdown(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TariffId: "tariff001"}))
+	require.NoError(t, engine.SetTariff(ctx, &store.Tariff{
+		Id:       "tariff001",
+		Currency: "GBP",
+		Elements: []store.TariffElement{
+			{PriceComponents: []store.PriceComponent{{Type: "FLAT", Price: 1}, {Type: "ENERGY", Price: 0.5}}},
+		},
+	}))
+
+	handler := handlers.TransactionEventHandler{
+		Store: engine,
+		TokenAuthService: &services.OcppTokenAuthService{
+			Clock:      clock.RealClock{},
+			TokenStore: engine,
+		},
+		TariffService: services.TariffEngine{Store: engine},
+	}
+
+	energy := func(context types.ReadingContextEnumType, value float64) []types.MeterValueType {
+		return []types.MeterValueType{{
+			Timestamp: "2023-05-05T12:00:00Z",
+			SampledValue: []types.SampledValueType{{
+				Context:   makePtr(context),
+				Measurand: makePtr(types.MeasurandEnumTypeEnergyActiveImportRegister),
+				Location:  makePtr(types.LocationEnumTypeOutlet),
+				Value:     value,
+			}},
+		}}
+	}
+	_, err := handler.HandleCall(ctx, "cs001", &types.TransactionEventRequestJson{
+		EventType:       types.TransactionEventEnumTypeStarted,
+		TriggerReason:   types.TriggerReasonEnumTypeAuthorized,
+		Timestamp:       "2023-05-05T12:00:00Z",
+		MeterValue:      energy(types.ReadingContextEnumTypeTransactionBegin, 0),
+		TransactionInfo: types.TransactionType{TransactionId: "5555"},
+	})
+	require.NoError(t, err)
+	endEnergy := energy(types.ReadingContextEnumTypeTransactionEnd, 4000)
+	endEnergy[0].Timestamp = "2023-05-05T13:00:00Z"
+	got, err := handler.HandleCall(ctx, "cs001", &types.TransactionEventRequestJson{
+		EventType:       types.TransactionEventEnumTypeEnded,
+		TriggerReason:   types.TriggerReasonEnumTypeStopAuthorized,
+		Timestamp:       "2023-05-05T13:00:00Z",
+		MeterValue:      endEnergy,
+		SeqNo:           1,
+		TransactionInfo: types.TransactionType{TransactionId: "5555"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &types.TransactionEventResponseJson{TotalCost: makePtr(3.0)}, got)
+
+	transaction, err := engine.FindTransaction(ctx, "cs001", "5555")
+	require.NoError(t, err)
+	require.NotNil(t, transaction.Cost)
+	assert.Equal(t, "tariff001", transaction.Cost.TariffId)
+	assert.Equal(t, "GBP", transaction.Cost.Currency)
+	assert.Equal(t, 1.0, transaction.Cost.SessionFee)
+	assert.Equal(t, 2.0, transaction.Cost.EnergyCost)
+	assert.Equal(t, 3.0, transaction.Cost.TotalCost)
+	require.Len(t, transaction.Cost.Periods, 1)
+	assert.Equal(t, 4.0, transaction.Cost.Periods[0].EnergyKwh)
+}
+
+func TestTransactionEventHandlerWithOfflineEventsReplayedOutOfOrder(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tariffService := services.BasicKwhTariffService{}
+
+	tokenAuthService := &services.OcppTokenAuthService{
+		Clock:      clock.RealClock{},
+		TokenStore: engine,
+	}
+
+	handler := handlers.TransactionEventHandler{
+		Store:            engine,
+		TokenAuthService: tokenAuthService,
+		TariffService:    tariffService,
+	}
+
+	makeReq := func(eventType types.TransactionEventEnumType, seqNo int, timestamp string, sampledValue types.SampledValueType) *types.TransactionEventRequestJson {
+		return &types.TransactionEventRequestJson{
+			EventType:     eventType,
+			TriggerReason: types.TriggerReasonEnumTypeMeterValuePeriodic,
+			Timestamp:     timestamp,
+			Offline:       true,
+			MeterValue: []types.MeterValueType{
+				{
+					Timestamp:    timestamp,
+					SampledValue: []types.SampledValueType{sampledValue},
+				},
+			},
+			SeqNo: seqNo,
+			TransactionInfo: types.TransactionType{
+				TransactionId: "5555",
+			},
+		}
+	}
+
+	ended := makeReq(types.TransactionEventEnumTypeEnded, 2, "2023-05-05T12:20:00+01:00", types.SampledValueType{
+		Context:   makePtr(types.ReadingContextEnumTypeTransactionEnd),
+		Measurand: makePtr(types.MeasurandEnumTypeEnergyActiveImportRegister),
+		Location:  makePtr(types.LocationEnumTypeOutlet),
+		Value:     300,
+	})
+	started := makeReq(types.TransactionEventEnumTypeStarted, 0, "2023-05-05T12:00:00+01:00", types.SampledValueType{
+		Measurand: makePtr(types.MeasurandEnumTypeEnergyActiveImportRegister),
+		Location:  makePtr(types.LocationEnumTypeOutlet),
+		Value:     100,
+	})
+	updated := makeReq(types.TransactionEventEnumTypeUpdated, 1, "2023-05-05T12:10:00+01:00", types.SampledValueType{
+		Measurand: makePtr(types.MeasurandEnumTypeEnergyActiveImportRegister),
+		Location:  makePtr(types.LocationEnumTypeOutlet),
+		Value:     200,
+	})
+
+	got, err := handler.HandleCall(ctx, "cs001", ended)
+	require.NoError(t, err)
+	assert.Equal(t, &types.TransactionEventResponseJson{TotalCost: makePtr(0.165)}, got)
+
+	_, err = handler.HandleCall(ctx, "cs001", started)
+	require.NoError(t, err)
+
+	got, err = handler.HandleCall(ctx, "cs001", updated)
+	require.NoError(t, err)
+	assert.Equal(t, &types.TransactionEventResponseJson{TotalCost: makePtr(0.165)}, got)
+
+	transaction, err := engine.FindTransaction(ctx, "cs001", "5555")
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+
+	assert.True(t, transaction.RecoveredFromOffline)
+	assert.Equal(t, 0, transaction.StartSeqNo)
+	assert.Equal(t, 2, transaction.EndedSeqNo)
+	assert.Equal(t, 1, transaction.UpdatedSeqNoCount)
+	require.Len(t, transaction.MeterValues, 3)
+	assert.Equal(t, "2023-05-05T12:00:00+01:00", transaction.MeterValues[0].Timestamp)
+	assert.Equal(t, "2023-05-05T12:10:00+01:00", transaction.MeterValues[1].Timestamp)
+	assert.Equal(t, "2023-05-05T12:20:00+01:00", transaction.MeterValues[2].Timestamp)
+}
+
+func TestTransactionEventHandlerWithOnlineEventsInOrder(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.TransactionEventHandler{
+		Store: engine,
+		TokenAuthService: &services.OcppTokenAuthService{
+			Clock:      clock.RealClock{},
+			TokenStore: engine,
+		},
+		TariffService: services.BasicKwhTariffService{},
+	}
+
+	for seqNo, eventType := range []types.TransactionEventEnumType{
+		types.TransactionEventEnumTypeStarted,
+		types.TransactionEventEnumTypeUpdated,
+		types.TransactionEventEnumTypeEnded,
+	} {
+		_, err := handler.HandleCall(ctx, "cs001", &types.TransactionEventRequestJson{
+			EventType:     eventType,
+			TriggerReason: types.TriggerReasonEnumTypeMeterValuePeriodic,
+			Timestamp:     "2023-05-05T12:00:00+01:00",
+			SeqNo:         seqNo,
+			TransactionInfo: types.TransactionType{
+				TransactionId: "5555",
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	transaction, err := engine.FindTransaction(ctx, "cs001", "5555")
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+	assert.False(t, transaction.RecoveredFromOffline)
+}
+
+func TestTransactionEventHandlerRecordsChargingStateChanges(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.TransactionEventHandler{
+		Store: engine,
+		TokenAuthService: &services.OcppTokenAuthService{
+			Clock:      clock.RealClock{},
+			TokenStore: engine,
+		},
+		TariffService: services.TimeBasedTariffService{
+			PricePerChargingHour: 1,
+			IdleFeePerMinute:     0.1,
+		},
+	}
+
+	events := []struct {
+		eventType     types.TransactionEventEnumType
+		timestamp     string
+		chargingState *types.ChargingStateEnumType
+	}{
+		{types.TransactionEventEnumTypeStarted, "2023-05-05T12:00:00Z", makePtr(types.ChargingStateEnumTypeCharging)},
+		{types.TransactionEventEnumTypeUpdated, "2023-05-05T13:00:00Z", makePtr(types.ChargingStateEnumTypeSuspendedEV)},
+		{types.TransactionEventEnumTypeEnded, "2023-05-05T13:10:00Z", nil},
+	}
+
+	var got ocpp.Response
+	for seqNo, event := range events {
+		var err error
+		got, err = handler.HandleCall(ctx, "cs001", &types.TransactionEventRequestJson{
+			EventType:     event.eventType,
+			TriggerReason: types.TriggerReasonEnumTypeChargingStateChanged,
+			Timestamp:     event.timestamp,
+			SeqNo:         seqNo,
+			TransactionInfo: types.TransactionType{
+				TransactionId: "5555",
+				ChargingState: event.chargingState,
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	transaction, err := engine.FindTransaction(ctx, "cs001", "5555")
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+
+	want := []store.ChargingStateChange{
+		{State: "Charging", Timestamp: "2023-05-05T12:00:00Z"},
+		{State: "SuspendedEV", Timestamp: "2023-05-05T13:00:00Z"},
+		{State: "Idle", Timestamp: "2023-05-05T13:10:00Z"},
+	}
+	assert.Equal(t, want, transaction.ChargingStates)
+
+	resp := got.(*types.TransactionEventResponseJson)
+	require.NotNil(t, resp.TotalCost)
+	assert.InDelta(t, 2, *resp.TotalCost, 0.0001)
+}
+
+func TestTransactionEventHandlerConsumesReservation(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   7,
+		ChargeStationId: "cs001",
+		IdToken:         "SOMERFID",
+		TokenType:       "ISO14443",
+		Status:          store.ReservationStatusAccepted,
+	})
+	require.NoError(t, err)
+
+	handler := handlers.TransactionEventHandler{
+		Store: engine,
+		TokenAuthService: &services.OcppTokenAuthService{
+			Clock:      clock.RealClock{},
+			TokenStore: engine,
+		},
+		TariffService: services.BasicKwhTariffService{},
+	}
+
+	_, err = handler.HandleCall(ctx, "cs001", &types.TransactionEventRequestJson{
+		EventType:     types.TransactionEventEnumTypeStarted,
+		TriggerReason: types.TriggerReasonEnumTypeAuthorized,
+		Timestamp:     "2023-05-05T12:00:00Z",
+		ReservationId: makePtr(7),
+		TransactionInfo: types.TransactionType{
+			TransactionId: "5555",
+		},
+	})
+	require.NoError(t, err)
+
+	reservation, err := engine.LookupReservation(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusUsed, reservation.Status)
+	assert.Equal(t, "5555", reservation.TransactionId)
+}
+
+type fakeTransactionListener struct {
+	transactions []*store.Transaction
+}
+
+func (f *fakeTransactionListener) TransactionChanged(_ context.Context, transaction *store.Transaction) error {
+	// the store may update the transaction in place, so keep a copy of its state
+	snapshot := *transaction
+	f.transactions = append(f.transactions, &snapshot)
+	return nil
+}
+
+func TestTransactionEventHandlerNotifiesListener(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	listener := &fakeTransactionListener{}
+
+	handler := handlers.TransactionEventHandler{
+		Store: engine,
+		TokenAuthService: &services.OcppTokenAuthService{
+			Clock:      clock.RealClock{},
+			TokenStore: engine,
+		},
+		TariffService: services.BasicKwhTariffService{},
+		Listener:      listener,
+	}
+
+	for seqNo, eventType := range []types.TransactionEventEnumType{
+		types.TransactionEventEnumTypeStarted,
+		types.TransactionEventEnumTypeUpdated,
+		types.TransactionEventEnumTypeEnded,
+	} {
+		_, err := handler.HandleCall(ctx, "cs001", &types.TransactionEventRequestJson{
+			EventType:     eventType,
+			TriggerReason: types.TriggerReasonEnumTypeMeterValuePeriodic,
+			Timestamp:     "2023-05-05T12:00:00+01:00",
+			SeqNo:         seqNo,
+			TransactionInfo: types.TransactionType{
+				TransactionId: "5555",
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, listener.transactions, 3)
+	assert.Equal(t, "5555", listener.transactions[0].TransactionId)
+	assert.Zero(t, listener.transactions[1].EndedSeqNo)
+	assert.Equal(t, 2, listener.transactions[2].EndedSeqNo)
 }
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"testing"
+)
+
+func TestUpdateFirmwareResultHandler(t *testing.T) {
+	handler := ocpp201.UpdateFirmwareResultHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, `test`)
+		defer span.End()
+
+		req := &types.UpdateFirmwareRequestJson{
+			RequestId: 42,
+			Firmware: types.FirmwareType{
+				Location:         "https://firmware.example.com/cs-2.1.0.bin",
+				RetrieveDateTime: "2024-03-01T10:00:00Z",
+			},
+		}
+		resp := &types.UpdateFirmwareResponseJson{
+			Status: types.UpdateFirmwareStatusEnumTypeAccepted,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"update_firmware.request_id": 42,
+		"update_firmware.location":   "https://firmware.example.com/cs-2.1.0.bin",
+		"update_firmware.status":     "Accepted",
+	})
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type BatterySwapHandler struct{}
+
+func (h BatterySwapHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*ocpp21.BatterySwapRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	serialNumbers := make([]string, len(req.BatteryData))
+	for i, battery := range req.BatteryData {
+		serialNumbers[i] = battery.SerialNumber
+	}
+	span.SetAttributes(
+		attribute.String("battery_swap.event_type", string(req.EventType)),
+		attribute.Int("battery_swap.request_id", req.RequestId),
+		attribute.StringSlice("battery_swap.serial_numbers", serialNumbers))
+
+	return &ocpp21.BatterySwapResponseJson{}, nil
+}
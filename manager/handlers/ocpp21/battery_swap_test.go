@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"go.opentelemetry.io/otel/attribute"
+	"testing"
+)
+
+func TestBatterySwap(t *testing.T) {
+	handler := ocpp21.BatterySwapHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.BatterySwapRequestJson{
+			BatteryData: []types.BatteryDataType{
+				{EvseId: 1, SerialNumber: "BAT-001", SoC: 12, SoH: 97},
+				{EvseId: 2, SerialNumber: "BAT-002", SoC: 15, SoH: 95},
+			},
+			EventType: types.BatterySwapEventEnumTypeBatteryIn,
+			IdToken: types.IdTokenType{
+				IdToken: "DEADBEEF",
+				Type:    "ISO14443",
+			},
+			RequestId: 42,
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.BatterySwapResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"battery_swap.event_type": "BatteryIn",
+		"battery_swap.request_id": 42,
+		"battery_swap.serial_numbers": func(value attribute.Value) bool {
+			return assert.Equal(t, []string{"BAT-001", "BAT-002"}, value.AsStringSlice())
+		},
+	})
+}
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ocpp21 defines handlers for processing OCPP 2.1 messages. OCPP 2.1 is a
+// draft: the messages that it shares with OCPP 2.0.1 are handled by the OCPP 2.0.1
+// handlers and only the messages for bidirectional charging and battery swapping
+// have handlers here.
+package ocpp21
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+type NotifyDERAlarmHandler struct{}
+
+func (h NotifyDERAlarmHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*ocpp21.NotifyDERAlarmRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	alarmEnded := req.AlarmEnded != nil && *req.AlarmEnded
+	span.SetAttributes(
+		attribute.String("der_alarm.control_type", string(req.ControlType)),
+		attribute.Bool("der_alarm.ended", alarmEnded))
+	if req.GridEventFault != nil {
+		span.SetAttributes(attribute.String("der_alarm.grid_event_fault", string(*req.GridEventFault)))
+	}
+
+	if !alarmEnded {
+		handlers.LoggerFromContext(ctx).Warn("der alarm",
+			slog.String("controlType", string(req.ControlType)), slog.String("timestamp", req.Timestamp))
+	}
+
+	return &ocpp21.NotifyDERAlarmResponseJson{}, nil
+}
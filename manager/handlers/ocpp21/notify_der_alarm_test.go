@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"testing"
+)
+
+func TestNotifyDERAlarm(t *testing.T) {
+	handler := ocpp21.NotifyDERAlarmHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.NotifyDERAlarmRequestJson{
+			ControlType:    types.DERControlEnumTypeLVMustTrip,
+			GridEventFault: makePtr(types.GridEventFaultEnumTypeUnderVoltage),
+			Timestamp:      "2024-05-01T12:00:00Z",
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.NotifyDERAlarmResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"der_alarm.control_type":     "LVMustTrip",
+		"der_alarm.ended":            false,
+		"der_alarm.grid_event_fault": "UnderVoltage",
+	})
+}
+
+func TestNotifyDERAlarmEnded(t *testing.T) {
+	handler := ocpp21.NotifyDERAlarmHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.NotifyDERAlarmRequestJson{
+			AlarmEnded:  makePtr(true),
+			ControlType: types.DERControlEnumTypeLVMustTrip,
+			Timestamp:   "2024-05-01T12:05:00Z",
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.NotifyDERAlarmResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"der_alarm.control_type": "LVMustTrip",
+		"der_alarm.ended":        true,
+	})
+}
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type NotifyDERStartStopHandler struct{}
+
+func (h NotifyDERStartStopHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*ocpp21.NotifyDERStartStopRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(
+		attribute.String("der_control.id", req.ControlId),
+		attribute.Bool("der_control.started", req.Started))
+	if len(req.SupersededIds) > 0 {
+		span.SetAttributes(attribute.StringSlice("der_control.superseded_ids", req.SupersededIds))
+	}
+
+	return &ocpp21.NotifyDERStartStopResponseJson{}, nil
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"go.opentelemetry.io/otel/attribute"
+	"testing"
+)
+
+func TestNotifyDERStartStop(t *testing.T) {
+	handler := ocpp21.NotifyDERStartStopHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.NotifyDERStartStopRequestJson{
+			ControlId:     "der-002",
+			Started:       true,
+			SupersededIds: []string{"der-001"},
+			Timestamp:     "2024-05-01T12:00:00Z",
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.NotifyDERStartStopResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"der_control.id":      "der-002",
+		"der_control.started": true,
+		"der_control.superseded_ids": func(value attribute.Value) bool {
+			return assert.Equal(t, []string{"der-001"}, value.AsStringSlice())
+		},
+	})
+}
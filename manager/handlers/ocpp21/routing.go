@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+	"io/fs"
+	"k8s.io/utils/clock"
+)
+
+// NewRouter returns a router for charge stations that use the OCPP 2.1 draft. The
+// messages that OCPP 2.1 shares with OCPP 2.0.1 are validated against the OCPP 2.0.1
+// schemas and handled in the same way: the router adds the routes for the OCPP 2.1
+// messages that are used by bidirectional charging and battery swapping.
+func NewRouter(emitter transport.Emitter,
+	clk clock.PassiveClock,
+	engine store.Engine,
+	tariffService services.TariffService,
+	certValidationService services.CertificateValidationService,
+	chargeStationCertProvider services.ChargeStationCertificateProvider,
+	contractCertProvider services.ContractCertificateProvider,
+	heartbeatInterval *services.HeartbeatInterval,
+	registrationPolicy handlers.RegistrationPolicy,
+	connectorStatusListener handlers.ConnectorStatusListener,
+	transactionListener handlers.TransactionListener,
+	securityEventListener handlers.SecurityEventListener,
+	remoteTokenAuthorizer services.RemoteTokenAuthorizer,
+	schedulingStrategy services.SchedulingStrategy,
+	deadLetters transport.DeadLetterEmitter,
+	callResponses *handlers.CallResponses,
+	poisonMessages *handlers.PoisonMessages,
+	actionFlags *handlers.ActionFlags,
+	schemaFS fs.FS,
+	callMiddleware ...handlers.CallMiddleware) transport.MessageHandler {
+
+	callRoutes, callResultRoutes := ocpp201.NewRoutes(emitter,
+		transport.OcppVersion21,
+		clk,
+		engine,
+		tariffService,
+		certValidationService,
+		chargeStationCertProvider,
+		contractCertProvider,
+		heartbeatInterval,
+		registrationPolicy,
+		connectorStatusListener,
+		transactionListener,
+		securityEventListener,
+		remoteTokenAuthorizer,
+		schedulingStrategy)
+
+	callRoutes["BatterySwap"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.BatterySwapRequestJson) },
+		RequestSchema:  "ocpp21/BatterySwapRequest.json",
+		ResponseSchema: "ocpp21/BatterySwapResponse.json",
+		Handler:        BatterySwapHandler{},
+	}
+	callRoutes["NotifyDERAlarm"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.NotifyDERAlarmRequestJson) },
+		RequestSchema:  "ocpp21/NotifyDERAlarmRequest.json",
+		ResponseSchema: "ocpp21/NotifyDERAlarmResponse.json",
+		Handler:        NotifyDERAlarmHandler{},
+	}
+	callRoutes["NotifyDERStartStop"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.NotifyDERStartStopRequestJson) },
+		RequestSchema:  "ocpp21/NotifyDERStartStopRequest.json",
+		ResponseSchema: "ocpp21/NotifyDERStartStopResponse.json",
+		Handler:        NotifyDERStartStopHandler{},
+	}
+
+	router := &handlers.Router{
+		Emitter:          emitter,
+		SchemaFS:         schemaFS,
+		DeadLetters:      deadLetters,
+		CallResponses:    callResponses,
+		PoisonMessages:   poisonMessages,
+		ActionFlags:      actionFlags,
+		OcppVersion:      transport.OcppVersion21,
+		CallRoutes:       callRoutes,
+		CallResultRoutes: callResultRoutes,
+	}
+
+	router.Use(
+		append([]handlers.CallMiddleware{
+			handlers.CallMetrics(transport.OcppVersion21),
+			handlers.RecoverCalls,
+		}, callMiddleware...),
+		[]handlers.CallResultMiddleware{
+			handlers.CallResultMetrics(transport.OcppVersion21),
+			handlers.RecoverCallResults,
+		})
+
+	if actionFlags != nil {
+		actionFlags.Register(router.OcppVersion, router.Actions()...)
+	}
+
+	// an invalid schema is reported again when a message for the action is handled
+	if err := router.Precompile(); err != nil {
+		slog.Warn("unable to precompile schemas", slog.String("ocppVersion", string(router.OcppVersion)), "err", err)
+	}
+
+	return router
+}
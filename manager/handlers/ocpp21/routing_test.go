@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type fakeEmitter struct {
+	OcppVersion transport.OcppVersion
+	Message     *transport.Message
+}
+
+func (f *fakeEmitter) Emit(_ context.Context, ocppVersion transport.OcppVersion, _ string, message *transport.Message) error {
+	f.OcppVersion = ocppVersion
+	f.Message = message
+	return nil
+}
+
+func newRouter(emitter transport.Emitter, engine store.Engine, flags *handlers.ActionFlags) transport.MessageHandler {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	return ocpp21.NewRouter(emitter,
+		clock,
+		engine,
+		nil,
+		nil,
+		nil,
+		nil,
+		services.NewHeartbeatInterval(5*time.Minute),
+		handlers.RegistrationPolicy{Store: engine},
+		nil,
+		nil,
+		nil,
+		nil,
+		services.AsSoonAsPossibleSchedulingStrategy{Clock: clock},
+		nil,
+		nil,
+		nil,
+		flags,
+		schemas.OcppSchemas,
+	)
+}
+
+func TestRoutingCalls(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+
+	inputMessages := map[string]ocpp.Request{
+		"BatterySwap": &types.BatterySwapRequestJson{
+			BatteryData: []types.BatteryDataType{
+				{
+					EvseId:       1,
+					SerialNumber: "BAT-001",
+					SoC:          95,
+					SoH:          98.5,
+				},
+			},
+			EventType: types.BatterySwapEventEnumTypeBatteryOut,
+			IdToken: types.IdTokenType{
+				IdToken: "DEADBEEF",
+				Type:    "ISO14443",
+			},
+			RequestId: 42,
+		},
+		"Heartbeat": &ocpp201.HeartbeatRequestJson{},
+		"NotifyDERAlarm": &types.NotifyDERAlarmRequestJson{
+			ControlType:    types.DERControlEnumTypeHVMustTrip,
+			GridEventFault: makePtr(types.GridEventFaultEnumTypeOverVoltage),
+			Timestamp:      "2024-05-01T12:00:00Z",
+		},
+		"NotifyDERStartStop": &types.NotifyDERStartStopRequestJson{
+			ControlId: "der-001",
+			Started:   true,
+			Timestamp: "2024-05-01T12:00:00Z",
+		},
+	}
+
+	for action, req := range inputMessages {
+		t.Run(action, func(t *testing.T) {
+			emitter := &fakeEmitter{}
+			router := newRouter(emitter, engine, nil)
+
+			reqBytes, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			router.Handle(context.Background(), "cs001", &transport.Message{
+				MessageType:    transport.MessageTypeCall,
+				Action:         action,
+				MessageId:      "1234",
+				RequestPayload: reqBytes,
+			})
+
+			require.NotNil(t, emitter.Message)
+			assert.Equal(t, transport.OcppVersion21, emitter.OcppVersion)
+			assert.Equal(t, transport.MessageTypeCallResult, emitter.Message.MessageType)
+		})
+	}
+}
+
+func TestRoutingRejectsInvalidCall(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	emitter := &fakeEmitter{}
+	router := newRouter(emitter, engine, nil)
+
+	router.Handle(context.Background(), "cs001", &transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "NotifyDERAlarm",
+		MessageId:      "1234",
+		RequestPayload: json.RawMessage(`{"controlType":"Unknown","timestamp":"2024-05-01T12:00:00Z"}`),
+	})
+
+	require.NotNil(t, emitter.Message)
+	assert.Equal(t, transport.MessageTypeCallError, emitter.Message.MessageType)
+}
+
+func TestBootNotificationRecordsOcpp21(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	router := newRouter(&fakeEmitter{}, engine, nil)
+
+	req, err := json.Marshal(&ocpp201.BootNotificationRequestJson{
+		ChargingStation: ocpp201.ChargingStationType{
+			Model:      "Bidi",
+			VendorName: "Vendor",
+		},
+		Reason: ocpp201.BootReasonEnumTypePowerUp,
+	})
+	require.NoError(t, err)
+
+	router.Handle(ctx, "cs001", &transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "BootNotification",
+		MessageId:      "1234",
+		RequestPayload: req,
+	})
+
+	chargeStation, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, chargeStation)
+	assert.Equal(t, "2.1", chargeStation.OcppVersion)
+}
+
+func TestRouterRegistersActions(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	flags := handlers.NewActionFlags()
+	newRouter(&fakeEmitter{}, engine, flags)
+
+	assert.True(t, flags.Known(transport.OcppVersion21, "NotifyDERAlarm"))
+	assert.True(t, flags.Known(transport.OcppVersion21, "TransactionEvent"))
+	assert.False(t, flags.Known(transport.OcppVersion201, "NotifyDERAlarm"))
+}
+
+func makePtr[T any](t T) *T {
+	v := t
+	return &v
+}
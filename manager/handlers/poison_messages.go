@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store/cache"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var poisonMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_poison_messages_total",
+	Help: "The number of messages that were not routed because they had already failed repeatedly, by OCPP version and action",
+}, []string{"ocpp_version", "action"})
+
+// PoisonMessages counts the failures of recent messages from charge stations. A
+// message that fails every time it is received, for example because it makes a
+// handler panic, is a poison message: once it has failed maxFailures times the
+// Router no longer routes it when it is received again, but answers it with the
+// error that it last failed with and records it as a dead letter, so that it does not
+// hold up the charge station's later messages. Messages are identified by charge
+// station, message type, message id and action.
+type PoisonMessages struct {
+	mu          sync.Mutex
+	failures    *cache.LRU[messageFailure]
+	maxFailures int
+}
+
+// messageFailure is the number of times that a message has failed and the error that
+// it last failed with
+type messageFailure struct {
+	count int
+	err   error
+}
+
+// NewPoisonMessages counts the failures of up to size messages, each for at most ttl
+// after it last failed
+func NewPoisonMessages(clock clock.PassiveClock, maxFailures, size int, ttl time.Duration) *PoisonMessages {
+	return &PoisonMessages{
+		failures:    cache.NewLRU[messageFailure](clock, size, ttl),
+		maxFailures: maxFailures,
+	}
+}
+
+func poisonMessageKey(chargeStationId string, msg *transport.Message) string {
+	return chargeStationId + "\x00" + strconv.Itoa(int(msg.MessageType)) + "\x00" + msg.MessageId + "\x00" + msg.Action
+}
+
+// poisoned returns the error that the message last failed with if it has failed
+// maxFailures times, otherwise it returns nil
+func (p *PoisonMessages) poisoned(ocppVersion transport.OcppVersion, chargeStationId string, msg *transport.Message) error {
+	if msg.MessageId == "" {
+		return nil
+	}
+	failure, ok := p.failures.Get(poisonMessageKey(chargeStationId, msg))
+	if !ok || failure.count < p.maxFailures {
+		return nil
+	}
+	poisonMessages.WithLabelValues(string(ocppVersion), msg.Action).Inc()
+	return failure.err
+}
+
+// failed records that the message failed with the error and returns the number of
+// times that it has failed
+func (p *PoisonMessages) failed(chargeStationId string, msg *transport.Message, err error) int {
+	if msg.MessageId == "" {
+		return 1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := poisonMessageKey(chargeStationId, msg)
+	failure, _ := p.failures.Get(key)
+	failure.count++
+	failure.err = err
+	p.failures.Put(key, failure)
+	return failure.count
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestRouterRecoversFromPanicOutsideMiddleware(t *testing.T) {
+	emitter := new(FakeEmitter)
+	router := routerWithHeartbeatHandler(emitter, func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		panic("nil map")
+	})
+
+	router.Handle(context.Background(), "cs001", &transport.Message{
+		Action:         "Heartbeat",
+		MessageType:    transport.MessageTypeCall,
+		MessageId:      "msg-1",
+		RequestPayload: []byte("{}"),
+	})
+
+	require.True(t, emitter.called)
+	assert.Equal(t, transport.MessageTypeCallError, emitter.msg.MessageType)
+	assert.Equal(t, transport.ErrorInternalError, emitter.msg.ErrorCode)
+	assert.Equal(t, "handling Heartbeat: panic: nil map", emitter.msg.ErrorDescription)
+}
+
+func TestRouterStopsRoutingPoisonMessage(t *testing.T) {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	emitter := new(FakeEmitter)
+	deadLetters := new(fakeDeadLetterEmitter)
+	var handled int
+	router := routerWithHeartbeatHandler(emitter, func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		handled++
+		panic("nil map")
+	})
+	router.DeadLetters = deadLetters
+	router.PoisonMessages = handlers.NewPoisonMessages(clock, 2, 10, time.Minute)
+
+	call := &transport.Message{
+		Action:         "Heartbeat",
+		MessageType:    transport.MessageTypeCall,
+		MessageId:      "msg-1",
+		RequestPayload: []byte("{}"),
+	}
+
+	for i := 0; i < 3; i++ {
+		router.Handle(context.Background(), "cs001", call)
+		assert.Equal(t, transport.MessageTypeCallError, emitter.msg.MessageType)
+		assert.Equal(t, transport.ErrorInternalError, emitter.msg.ErrorCode)
+		assert.Equal(t, "handling Heartbeat: panic: nil map", emitter.msg.ErrorDescription)
+	}
+	assert.Equal(t, 2, handled)
+	require.Len(t, deadLetters.letters, 3)
+	for i, letter := range deadLetters.letters {
+		assert.Equal(t, i+1, letter.Failures)
+		assert.Equal(t, transport.ErrorInternalError, letter.ErrorCode)
+	}
+
+	// the same message id from another charge station is routed
+	router.Handle(context.Background(), "cs002", call)
+	assert.Equal(t, 3, handled)
+
+	// the failures are forgotten after the ttl
+	clock.SetTime(clock.Now().Add(2 * time.Minute))
+	router.Handle(context.Background(), "cs001", call)
+	assert.Equal(t, 4, handled)
+}
+
+func TestRouterRoutesMessageThatRecovered(t *testing.T) {
+	emitter := new(FakeEmitter)
+	var handled int
+	router := routerWithHeartbeatHandler(emitter, func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		handled++
+		if handled == 1 {
+			return nil, errors.New("store unavailable")
+		}
+		return &ocpp201.HeartbeatResponseJson{CurrentTime: "2023-06-15T15:05:00Z"}, nil
+	})
+	router.PoisonMessages = handlers.NewPoisonMessages(clockTest.NewFakePassiveClock(time.Now()), 2, 10, time.Minute)
+
+	call := &transport.Message{
+		Action:         "Heartbeat",
+		MessageType:    transport.MessageTypeCall,
+		MessageId:      "msg-1",
+		RequestPayload: []byte("{}"),
+	}
+	router.Handle(context.Background(), "cs001", call)
+	router.Handle(context.Background(), "cs001", call)
+
+	assert.Equal(t, 2, handled)
+	assert.Equal(t, transport.MessageTypeCallResult, emitter.msg.MessageType)
+}
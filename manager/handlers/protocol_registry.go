@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+)
+
+// ProtocolRegistry holds the router for each supported OCPP version and selects the
+// router for each message. A message is routed using the OCPP version that the
+// transport received it with, so that a charge station that has been upgraded can
+// announce the new version in its BootNotification. When the transport does not know
+// the version, or there is no router for it, the version that the charge station
+// registered with its last BootNotification is used. Supporting another version of
+// OCPP is a matter of registering its router.
+type ProtocolRegistry struct {
+	store    store.ChargeStationStore
+	versions []transport.OcppVersion
+	routers  map[transport.OcppVersion]transport.MessageHandler
+}
+
+func NewProtocolRegistry(engine store.ChargeStationStore) *ProtocolRegistry {
+	return &ProtocolRegistry{
+		store:   engine,
+		routers: make(map[transport.OcppVersion]transport.MessageHandler),
+	}
+}
+
+// Register sets the router for the OCPP version. Routers are registered while the
+// manager starts, before any messages are handled.
+func (p *ProtocolRegistry) Register(ocppVersion transport.OcppVersion, router transport.MessageHandler) {
+	if _, ok := p.routers[ocppVersion]; !ok {
+		p.versions = append(p.versions, ocppVersion)
+	}
+	p.routers[ocppVersion] = router
+}
+
+// Versions returns the OCPP versions that have a router, in the order that they
+// were registered
+func (p *ProtocolRegistry) Versions() []transport.OcppVersion {
+	return p.versions
+}
+
+// Router returns the router for the OCPP version, or nil if the version is not supported
+func (p *ProtocolRegistry) Router(ocppVersion transport.OcppVersion) transport.MessageHandler {
+	return p.routers[ocppVersion]
+}
+
+// Handler returns the handler for the messages that a transport receives using the
+// OCPP version: the version is empty if the transport does not know it
+func (p *ProtocolRegistry) Handler(ocppVersion transport.OcppVersion) transport.MessageHandler {
+	return transport.MessageHandlerFunc(func(ctx context.Context, chargeStationId string, message *transport.Message) {
+		router := p.routers[ocppVersion]
+		if router == nil {
+			registered, err := p.registeredVersion(ctx, chargeStationId)
+			if err != nil {
+				slog.Error("lookup registered ocpp version", slog.String("chargeStationId", chargeStationId), "err", err)
+				return
+			}
+			router = p.routers[registered]
+			if router == nil {
+				slog.Warn("no router for charge station", slog.String("chargeStationId", chargeStationId),
+					slog.String("ocppVersion", string(ocppVersion)), slog.String("registeredOcppVersion", string(registered)),
+					slog.String("action", message.Action))
+				return
+			}
+		}
+		router.Handle(ctx, chargeStationId, message)
+	})
+}
+
+// registeredVersion returns the OCPP version that the charge station reported when it
+// last booted: the store holds the version without the "ocpp" prefix
+func (p *ProtocolRegistry) registeredVersion(ctx context.Context, chargeStationId string) (transport.OcppVersion, error) {
+	chargeStation, err := p.store.LookupChargeStation(ctx, chargeStationId)
+	if err != nil {
+		return "", err
+	}
+	if chargeStation == nil || chargeStation.OcppVersion == "" {
+		return "", nil
+	}
+	return transport.OcppVersion("ocpp" + chargeStation.OcppVersion), nil
+}
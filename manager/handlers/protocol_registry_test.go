@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+type recordingRouter struct {
+	handled []string
+}
+
+func (r *recordingRouter) Handle(_ context.Context, chargeStationId string, _ *transport.Message) {
+	r.handled = append(r.handled, chargeStationId)
+}
+
+func TestProtocolRegistryRoutesUsingTransportVersion(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{OcppVersion: "1.6"})
+	require.NoError(t, err)
+
+	ocpp16Router, ocpp201Router := &recordingRouter{}, &recordingRouter{}
+	registry := handlers.NewProtocolRegistry(engine)
+	registry.Register(transport.OcppVersion16, ocpp16Router)
+	registry.Register(transport.OcppVersion201, ocpp201Router)
+
+	// e.g. the BootNotification after a firmware upgrade
+	registry.Handler(transport.OcppVersion201).Handle(context.Background(), "cs001", &transport.Message{Action: "BootNotification"})
+
+	assert.Empty(t, ocpp16Router.handled)
+	assert.Equal(t, []string{"cs001"}, ocpp201Router.handled)
+	assert.Equal(t, []transport.OcppVersion{transport.OcppVersion16, transport.OcppVersion201}, registry.Versions())
+}
+
+func TestProtocolRegistryRoutesUsingRegisteredVersion(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{OcppVersion: "2.0.1"})
+	require.NoError(t, err)
+
+	ocpp16Router, ocpp201Router := &recordingRouter{}, &recordingRouter{}
+	registry := handlers.NewProtocolRegistry(engine)
+	registry.Register(transport.OcppVersion16, ocpp16Router)
+	registry.Register(transport.OcppVersion201, ocpp201Router)
+
+	handler := registry.Handler("")
+	handler.Handle(context.Background(), "cs001", &transport.Message{Action: "Heartbeat"})
+	handler.Handle(context.Background(), "cs002", &transport.Message{Action: "Heartbeat"})
+
+	assert.Empty(t, ocpp16Router.handled)
+	assert.Equal(t, []string{"cs001"}, ocpp201Router.handled)
+}
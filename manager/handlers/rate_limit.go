@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/time/rate"
+	"k8s.io/utils/clock"
+	"sync"
+	"time"
+)
+
+var rateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_rate_limited_calls_total",
+	Help: "The number of calls from charge stations that exceeded their rate limit, by action and outcome (throttled or rejected)",
+}, []string{"action", "outcome"})
+
+// ErrRateLimited is wrapped by the error returned for a call that exceeds its rate limit
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimit is the rate that a charge station may send calls for an action: calls
+// are allowed at PerMinute on average, with bursts of up to Burst calls.
+type RateLimit struct {
+	PerMinute float64
+	Burst     int
+}
+
+// RateLimiter is middleware that limits the rate at which each charge station can
+// send calls for the actions that have a limit, protecting the store and downstream
+// services from misbehaving firmware. A call that exceeds the limit is delayed until
+// it is within the limit if that takes no more than MaxWait, otherwise it is rejected
+// with a GenericError. Delaying a call also delays the messages that follow it from
+// the charge station.
+type RateLimiter struct {
+	limits   map[string]RateLimit
+	maxWait  time.Duration
+	clock    clock.PassiveClock
+	mu       sync.Mutex
+	limiters map[rateLimiterKey]*rate.Limiter
+	pruned   time.Time
+}
+
+type rateLimiterKey struct {
+	chargeStationId string
+	action          string
+}
+
+// rateLimiterPruneInterval is how often limiters that have refilled are discarded
+const rateLimiterPruneInterval = time.Minute
+
+// NewRateLimiter returns a RateLimiter for the limits, indexed by action. A maxWait
+// of zero rejects calls as soon as they exceed the limit.
+func NewRateLimiter(clk clock.PassiveClock, limits map[string]RateLimit, maxWait time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limits:   limits,
+		maxWait:  maxWait,
+		clock:    clk,
+		limiters: make(map[rateLimiterKey]*rate.Limiter),
+		pruned:   clk.Now(),
+	}
+}
+
+// Middleware is the CallMiddleware that applies the limits
+func (l *RateLimiter) Middleware(next CallHandler) CallHandler {
+	return CallHandlerFunc(func(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+		action := ActionFromContext(ctx)
+		err := l.wait(ctx, chargeStationId, action)
+		if err != nil {
+			return nil, err
+		}
+		return next.HandleCall(ctx, chargeStationId, request)
+	})
+}
+
+func (l *RateLimiter) wait(ctx context.Context, chargeStationId, action string) error {
+	limit, ok := l.limits[action]
+	if !ok {
+		return nil
+	}
+
+	now := l.clock.Now()
+	reservation := l.limiter(now, chargeStationId, action, limit).ReserveN(now, 1)
+	if !reservation.OK() {
+		return l.reject(ctx, action)
+	}
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+	if delay > l.maxWait {
+		reservation.CancelAt(now)
+		return l.reject(ctx, action)
+	}
+
+	rateLimited.WithLabelValues(action, "throttled").Inc()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.CancelAt(l.clock.Now())
+		return ctx.Err()
+	}
+}
+
+func (l *RateLimiter) reject(ctx context.Context, action string) error {
+	rateLimited.WithLabelValues(action, "rejected").Inc()
+	LoggerFromContext(ctx).Warn("rejecting call that exceeds rate limit")
+	return transport.NewError(transport.ErrorGenericError, fmt.Errorf("%s: %w", action, ErrRateLimited))
+}
+
+func (l *RateLimiter) limiter(now time.Time, chargeStationId, action string, limit RateLimit) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.pruned) >= rateLimiterPruneInterval {
+		// a limiter that has refilled behaves the same as a new one
+		for key, limiter := range l.limiters {
+			if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+				delete(l.limiters, key)
+			}
+		}
+		l.pruned = now
+	}
+
+	key := rateLimiterKey{chargeStationId: chargeStationId, action: action}
+	limiter, ok := l.limiters[key]
+	if !ok {
+		burst := limit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(limit.PerMinute/60), burst)
+		l.limiters[key] = limiter
+	}
+	return limiter
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func rateLimitedCall(limiter *handlers.RateLimiter) func(chargeStationId, action string) error {
+	handler := limiter.Middleware(handlers.CallHandlerFunc(func(context.Context, string, ocpp.Request) (ocpp.Response, error) {
+		return &ocpp201.HeartbeatResponseJson{}, nil
+	}))
+	return func(chargeStationId, action string) error {
+		ctx := handlers.ContextWithAction(context.Background(), action)
+		_, err := handler.HandleCall(ctx, chargeStationId, &ocpp201.HeartbeatRequestJson{})
+		return err
+	}
+}
+
+func TestRateLimiterRejectsCallsOverTheLimit(t *testing.T) {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	limiter := handlers.NewRateLimiter(clock, map[string]handlers.RateLimit{
+		"Heartbeat": {PerMinute: 1, Burst: 2},
+	}, 0)
+	call := rateLimitedCall(limiter)
+
+	assert.NoError(t, call("cs001", "Heartbeat"))
+	assert.NoError(t, call("cs001", "Heartbeat"))
+
+	err := call("cs001", "Heartbeat")
+	assert.ErrorIs(t, err, handlers.ErrRateLimited)
+	var transportErr *transport.Error
+	require.ErrorAs(t, err, &transportErr)
+	assert.Equal(t, transport.ErrorGenericError, transportErr.ErrorCode)
+
+	// other charge stations and actions are not affected
+	assert.NoError(t, call("cs002", "Heartbeat"))
+	assert.NoError(t, call("cs001", "StatusNotification"))
+
+	clock.SetTime(clock.Now().Add(time.Minute))
+	assert.NoError(t, call("cs001", "Heartbeat"))
+	assert.ErrorIs(t, call("cs001", "Heartbeat"), handlers.ErrRateLimited)
+}
+
+func TestRateLimiterThrottlesCallsWithinMaxWait(t *testing.T) {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	limiter := handlers.NewRateLimiter(clock, map[string]handlers.RateLimit{
+		"MeterValues": {PerMinute: 1200},
+	}, time.Second)
+	call := rateLimitedCall(limiter)
+
+	assert.NoError(t, call("cs001", "MeterValues"))
+
+	start := time.Now()
+	assert.NoError(t, call("cs001", "MeterValues"))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestRateLimiterRejectsCallsThatWouldWaitTooLong(t *testing.T) {
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	limiter := handlers.NewRateLimiter(clock, map[string]handlers.RateLimit{
+		"MeterValues": {PerMinute: 1},
+	}, time.Second)
+	call := rateLimitedCall(limiter)
+
+	assert.NoError(t, call("cs001", "MeterValues"))
+	assert.ErrorIs(t, call("cs001", "MeterValues"), handlers.ErrRateLimited)
+}
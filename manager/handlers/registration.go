@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+// RegistrationPolicy determines the registration status returned to a charge station
+// in response to a BootNotification.
+type RegistrationPolicy struct {
+	Store store.ChargeStationRegistrationStore
+	// DefaultStatus is the status assigned to a charge station that has no registration:
+	// it defaults to Accepted
+	DefaultStatus store.RegistrationStatus
+	// RetryInterval is the interval returned to a Pending or Rejected charge station
+	// after which it should send another BootNotification
+	RetryInterval time.Duration
+}
+
+// RegistrationStatus returns the registration status of the charge station. A charge
+// station without a registration is recorded with the default status so that it can
+// subsequently be approved (or rejected) through the API.
+func (p RegistrationPolicy) RegistrationStatus(ctx context.Context, chargeStationId string) (store.RegistrationStatus, error) {
+	if p.Store == nil {
+		return store.RegistrationStatusAccepted, nil
+	}
+
+	registration, err := p.Store.LookupChargeStationRegistration(ctx, chargeStationId)
+	if err != nil {
+		return "", fmt.Errorf("lookup charge station registration: %w", err)
+	}
+	if registration != nil {
+		return registration.Status, nil
+	}
+
+	status := p.DefaultStatus
+	if status == "" {
+		status = store.RegistrationStatusAccepted
+	}
+	err = p.Store.SetChargeStationRegistration(ctx, chargeStationId, &store.ChargeStationRegistration{
+		Status: status,
+	})
+	if err != nil {
+		return "", fmt.Errorf("set charge station registration: %w", err)
+	}
+	return status, nil
+}
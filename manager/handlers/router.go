@@ -5,47 +5,136 @@ package handlers
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"github.com/santhosh-tekuri/jsonschema"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/thoughtworks/maeve-csms/manager/schemas"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
-	"golang.org/x/exp/slog"
 	"io/fs"
+	"sort"
+	"time"
+)
+
+var (
+	messagesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_messages_received_total",
+		Help: "The number of messages received from charge stations, by OCPP version, message type and action",
+	}, []string{"ocpp_version", "message_type", "action"})
+	schemaValidationFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_schema_validation_failures_total",
+		Help: "The number of payloads that did not match their schema, by OCPP version, action and payload (request or response)",
+	}, []string{"ocpp_version", "action", "payload"})
 )
 
 // Router is the primary implementation of the transport.Router interface.
 type Router struct {
-	Emitter          transport.Emitter          // used to send responses to the gateway
-	SchemaFS         fs.FS                      // used to obtain schema files
-	OcppVersion      transport.OcppVersion      // the OCPP version that this router supports
-	CallRoutes       map[string]CallRoute       // the set of routes for incoming calls (indexed by action)
-	CallResultRoutes map[string]CallResultRoute // the set of routes for call results (indexed by action)
+	Emitter          transport.Emitter           // used to send responses to the gateway
+	SchemaFS         fs.FS                       // used to obtain schema files
+	OcppVersion      transport.OcppVersion       // the OCPP version that this router supports
+	CallRoutes       map[string]CallRoute        // the set of routes for incoming calls (indexed by action)
+	CallResultRoutes map[string]CallResultRoute  // the set of routes for call results (indexed by action)
+	DeadLetters      transport.DeadLetterEmitter // optional: records the messages that could not be routed
+	CallResponses    *CallResponses              // optional: answers retransmitted calls with the original response
+	PoisonMessages   *PoisonMessages             // optional: stops routing messages that have failed repeatedly
+	ActionFlags      *ActionFlags                // optional: switches actions on and off while the manager is running
+	SoapTranslation  bool                        // optional: translates the payloads of messages from OCPP 1.6 SOAP charge stations
+	CallErrors       CallErrorHandler            // optional: informed of the errors that charge stations return for calls
+}
+
+// Use wraps the handlers of all the routes with the middleware. It is called once
+// the routes are in place, before any messages are handled.
+func (r Router) Use(callMiddleware []CallMiddleware, callResultMiddleware []CallResultMiddleware) {
+	for action, route := range r.CallRoutes {
+		route.Handler = ChainCall(route.Handler, callMiddleware...)
+		r.CallRoutes[action] = route
+	}
+	for action, route := range r.CallResultRoutes {
+		route.Handler = ChainCallResult(route.Handler, callResultMiddleware...)
+		r.CallResultRoutes[action] = route
+	}
+}
+
+// Precompile compiles the request and response schemas of all the routes, so that
+// they are not compiled while handling the first message for each action
+func (r Router) Precompile() error {
+	var schemaFiles []string
+	for _, route := range r.CallRoutes {
+		schemaFiles = append(schemaFiles, route.RequestSchema, route.ResponseSchema)
+	}
+	for _, route := range r.CallResultRoutes {
+		schemaFiles = append(schemaFiles, route.RequestSchema, route.ResponseSchema)
+	}
+	return schemas.Precompile(r.SchemaFS, schemaFiles...)
+}
+
+// Actions returns the actions that have a call or call result route
+func (r Router) Actions() []string {
+	seen := make(map[string]bool)
+	var actions []string
+	for action := range r.CallRoutes {
+		seen[action] = true
+		actions = append(actions, action)
+	}
+	for action := range r.CallResultRoutes {
+		if !seen[action] {
+			actions = append(actions, action)
+		}
+	}
+	sort.Strings(actions)
+	return actions
 }
 
 func (r Router) Handle(ctx context.Context, chargeStationId string, msg *transport.Message) {
 	span := trace.SpanFromContext(ctx)
+	messagesReceived.WithLabelValues(string(r.OcppVersion), msg.MessageType.String(), msg.Action).Inc()
+
+	logger := messageLogger(ctx, r.OcppVersion, chargeStationId, msg)
+	ctx = ContextWithLogger(ctx, logger)
 
-	err := r.route(ctx, chargeStationId, msg)
+	var err error
+	if r.PoisonMessages != nil {
+		err = r.PoisonMessages.poisoned(r.OcppVersion, chargeStationId, msg)
+		if err != nil {
+			logger.Warn("not routing message that has failed repeatedly")
+		}
+	}
+	if err == nil {
+		err = r.recoverRoute(ctx, chargeStationId, msg)
+	}
 	if err != nil {
-		slog.Error("unable to route message", slog.String("chargeStationId", chargeStationId), slog.String("action", msg.Action), "err", err)
+		logger.Error("unable to route message", "err", err)
 		span.SetStatus(codes.Error, "routing request failed")
 		span.RecordError(err)
 
-		// only emit an error on a call (the charge station will not be expecting any response message)
-		if msg.MessageType == transport.MessageTypeCall {
-			var mqttError *transport.Error
-			var errMsg *transport.Message
-			if errors.As(err, &mqttError) {
-				errMsg = transport.NewErrorMessage(msg.Action, msg.MessageId, mqttError.ErrorCode, mqttError.WrappedError)
-			} else {
-				errMsg = transport.NewErrorMessage(msg.Action, msg.MessageId, transport.ErrorInternalError, err)
+		errorCode := ErrorCode(err)
+		var failures int
+		if r.PoisonMessages != nil {
+			failures = r.PoisonMessages.failed(chargeStationId, msg, err)
+		}
+
+		if r.DeadLetters != nil {
+			deadLetterErr := r.DeadLetters.EmitDeadLetter(ctx, &transport.DeadLetter{
+				OcppVersion:     r.OcppVersion,
+				ChargeStationId: chargeStationId,
+				Message:         msg,
+				ErrorCode:       errorCode,
+				Error:           err.Error(),
+				Failures:        failures,
+				Timestamp:       time.Now().UTC(),
+			})
+			if deadLetterErr != nil {
+				logger.Error("unable to emit dead letter", "err", deadLetterErr)
 			}
-			err = r.Emitter.Emit(ctx, r.OcppVersion, chargeStationId, errMsg)
+		}
+
+		// only emit an error on a call (the charge station will not be expecting any response message):
+		// without a response the charge station would keep retransmitting the call
+		if msg.MessageType == transport.MessageTypeCall {
+			err = r.Emitter.Emit(ctx, r.OcppVersion, chargeStationId, newCallError(msg, err))
 			if err != nil {
-				slog.Error("unable to emit error message", "err", err)
+				logger.Error("unable to emit error message", "err", err)
 			}
 		}
 	} else {
@@ -53,6 +142,18 @@ func (r Router) Handle(ctx context.Context, chargeStationId string, msg *transpo
 	}
 }
 
+// recoverRoute routes the message, turning a panic into an InternalError: the
+// handlers recover from their own panics, but this covers decoding, validating and
+// emitting the response too
+func (r Router) recoverRoute(ctx context.Context, chargeStationId string, message *transport.Message) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recovered(ContextWithAction(ctx, message.Action), chargeStationId, p)
+		}
+	}()
+	return r.route(ctx, chargeStationId, message)
+}
+
 func (r Router) route(ctx context.Context, chargeStationId string, message *transport.Message) error {
 	switch message.MessageType {
 	case transport.MessageTypeCall:
@@ -60,20 +161,29 @@ func (r Router) route(ctx context.Context, chargeStationId string, message *tran
 		if !ok {
 			return fmt.Errorf("routing request: %w", transport.NewError(transport.ErrorNotImplemented, fmt.Errorf("%s not implemented", message.Action)))
 		}
-		err := schemas.Validate(message.RequestPayload, r.SchemaFS, route.RequestSchema)
-		if err != nil {
-			var validationErr *jsonschema.ValidationError
-			if errors.As(validationErr, &validationErr) {
-				err = transport.NewError(transport.ErrorFormatViolation, err)
+		if !r.ActionFlags.Enabled(r.OcppVersion, message.Action) {
+			return fmt.Errorf("routing request: %w", transport.NewError(transport.ErrorNotSupported, fmt.Errorf("%s is disabled", message.Action)))
+		}
+		if r.CallResponses != nil {
+			if responseJson, ok := r.CallResponses.lookup(ctx, chargeStationId, message.MessageId, message.Action); ok {
+				LoggerFromContext(ctx).Info("answering retransmitted call with original response")
+				return r.emitCallResult(ctx, chargeStationId, message, responseJson)
 			}
-			return fmt.Errorf("validating %s request: %w", message.Action, err)
+		}
+		requestPayload, err := r.translate(message, message.RequestPayload, route.RequestSchema)
+		if err != nil {
+			return fmt.Errorf("translating %s request: %w", message.Action, transport.NewError(transport.ErrorFormatViolation, err))
+		}
+		err = r.validate(requestPayload, route.RequestSchema, message.Action, "request")
+		if err != nil {
+			return fmt.Errorf("validating %s request: %w", message.Action, transport.NewError(transport.ErrorFormatViolation, err))
 		}
 		req := route.NewRequest()
-		err = json.Unmarshal(message.RequestPayload, &req)
+		err = json.Unmarshal(requestPayload, &req)
 		if err != nil {
 			return fmt.Errorf("unmarshalling %s request payload: %w", message.Action, err)
 		}
-		resp, err := route.Handler.HandleCall(ctx, chargeStationId, req)
+		resp, err := route.Handler.HandleCall(ContextWithAction(ctx, message.Action), chargeStationId, req)
 		if err != nil {
 			return err
 		}
@@ -84,37 +194,38 @@ func (r Router) route(ctx context.Context, chargeStationId string, message *tran
 		if err != nil {
 			return fmt.Errorf("marshalling %s call response: %w", message.Action, err)
 		}
-		err = schemas.Validate(responseJson, r.SchemaFS, route.ResponseSchema)
+		err = r.validate(responseJson, route.ResponseSchema, message.Action, "response")
 		if err != nil {
 			mqttErr := transport.NewError(transport.ErrorPropertyConstraintViolation, err)
-			slog.Warn("response not valid", slog.String("action", message.Action), mqttErr)
+			LoggerFromContext(ctx).Warn("response not valid", "err", mqttErr)
 		}
-		out := &transport.Message{
-			MessageType:     transport.MessageTypeCallResult,
-			Action:          message.Action,
-			MessageId:       message.MessageId,
-			ResponsePayload: responseJson,
+		if r.CallResponses != nil {
+			// the call has been handled, even if the response does not reach the charge station
+			r.CallResponses.record(ctx, chargeStationId, message.MessageId, message.Action, responseJson)
 		}
-		err = r.Emitter.Emit(ctx, r.OcppVersion, chargeStationId, out)
+		err = r.emitCallResult(ctx, chargeStationId, message, responseJson)
 		if err != nil {
-			return fmt.Errorf("sending call response: %w", err)
+			return err
 		}
 	case transport.MessageTypeCallResult:
 		route, ok := r.CallResultRoutes[message.Action]
 		if !ok {
 			return fmt.Errorf("routing request: %w", transport.NewError(transport.ErrorNotImplemented, fmt.Errorf("%s result not implemented", message.Action)))
 		}
-		err := schemas.Validate(message.RequestPayload, r.SchemaFS, route.RequestSchema)
+		if !r.ActionFlags.Enabled(r.OcppVersion, message.Action) {
+			return fmt.Errorf("routing request: %w", transport.NewError(transport.ErrorNotSupported, fmt.Errorf("%s is disabled", message.Action)))
+		}
+		err := r.validate(message.RequestPayload, route.RequestSchema, message.Action, "request")
 		if err != nil {
 			return fmt.Errorf("validating %s request: %w", message.Action, err)
 		}
-		err = schemas.Validate(message.ResponsePayload, r.SchemaFS, route.ResponseSchema)
+		responsePayload, err := r.translate(message, message.ResponsePayload, route.ResponseSchema)
 		if err != nil {
-			var validationErr *jsonschema.ValidationError
-			if errors.As(validationErr, &validationErr) {
-				err = transport.NewError(transport.ErrorFormatViolation, err)
-			}
-			return fmt.Errorf("validating %s response: %w", message.Action, err)
+			return fmt.Errorf("translating %s response: %w", message.Action, transport.NewError(transport.ErrorFormatViolation, err))
+		}
+		err = r.validate(responsePayload, route.ResponseSchema, message.Action, "response")
+		if err != nil {
+			return fmt.Errorf("validating %s response: %w", message.Action, transport.NewError(transport.ErrorFormatViolation, err))
 		}
 		req := route.NewRequest()
 		err = json.Unmarshal(message.RequestPayload, &req)
@@ -122,18 +233,55 @@ func (r Router) route(ctx context.Context, chargeStationId string, message *tran
 			return fmt.Errorf("unmarshalling %s request payload: %w", message.Action, err)
 		}
 		resp := route.NewResponse()
-		err = json.Unmarshal(message.ResponsePayload, &resp)
+		err = json.Unmarshal(responsePayload, &resp)
 		if err != nil {
 			return fmt.Errorf("unmarshalling %s response payload: %v", message.Action, err)
 		}
-		err = route.Handler.HandleCallResult(ctx, chargeStationId, req, resp, message.State)
+		err = route.Handler.HandleCallResult(ContextWithAction(ctx, message.Action), chargeStationId, req, resp, message.State)
 		if err != nil {
 			return err
 		}
 	case transport.MessageTypeCallError:
-		// TODO: what do we want to do with errors?
-		return errors.New("we shouldn't get here at the moment")
+		// the charge station has answered the call, so the call is complete even though
+		// it was not successful
+		LoggerFromContext(ctx).Warn("call returned an error",
+			"errorCode", message.ErrorCode, "errorDescription", message.ErrorDescription)
+		if r.CallErrors != nil {
+			return r.CallErrors.HandleCallError(ContextWithAction(ctx, message.Action), chargeStationId, message)
+		}
+	}
+
+	return nil
+}
+
+// validate checks the payload against the schema, counting the payloads that do not match
+func (r Router) validate(payload json.RawMessage, schemaFile, action, kind string) error {
+	err := schemas.Validate(payload, r.SchemaFS, schemaFile)
+	if err != nil {
+		schemaValidationFailures.WithLabelValues(string(r.OcppVersion), action, kind).Inc()
 	}
+	return err
+}
+
+// translate returns the payload converted to the conventions of OCPP-J when the message
+// was received from a SOAP charge station, otherwise it returns the payload unchanged
+func (r Router) translate(message *transport.Message, payload json.RawMessage, schemaFile string) (json.RawMessage, error) {
+	if !r.SoapTranslation || message.Origin != transport.MessageOriginSoap {
+		return payload, nil
+	}
+	return TranslateSoapPayload(payload, r.SchemaFS, schemaFile)
+}
 
+func (r Router) emitCallResult(ctx context.Context, chargeStationId string, call *transport.Message, responseJson json.RawMessage) error {
+	out := &transport.Message{
+		MessageType:     transport.MessageTypeCallResult,
+		Action:          call.Action,
+		MessageId:       call.MessageId,
+		ResponsePayload: responseJson,
+	}
+	err := r.Emitter.Emit(ctx, r.OcppVersion, chargeStationId, out)
+	if err != nil {
+		return fmt.Errorf("sending call response: %w", err)
+	}
 	return nil
 }
@@ -64,6 +64,118 @@ func TestRouterHandlesCall(t *testing.T) {
 	assert.NotNil(t, emitter.msg.ResponsePayload)
 }
 
+func TestRouterPrecompileReportsMissingSchema(t *testing.T) {
+	router := handlers.Router{
+		SchemaFS:    schemas.OcppSchemas,
+		OcppVersion: transport.OcppVersion201,
+		CallRoutes: map[string]handlers.CallRoute{
+			"Heartbeat": {
+				RequestSchema:  "ocpp201/HeartbeatRequest.json",
+				ResponseSchema: "ocpp201/Missing.json",
+			},
+		},
+	}
+
+	err := router.Precompile()
+	assert.ErrorContains(t, err, "ocpp201/Missing.json")
+}
+
+func BenchmarkRouterHandlesCall(b *testing.B) {
+	emitter := new(FakeEmitter)
+
+	router := handlers.Router{
+		Emitter:     emitter,
+		SchemaFS:    schemas.OcppSchemas,
+		OcppVersion: transport.OcppVersion201,
+		CallRoutes: map[string]handlers.CallRoute{
+			"Heartbeat": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
+				RequestSchema:  "ocpp201/HeartbeatRequest.json",
+				ResponseSchema: "ocpp201/HeartbeatResponse.json",
+				Handler: handlers201.HeartbeatHandler{
+					Clock: clock.RealClock{},
+				},
+			},
+		},
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		router.Handle(context.Background(), "id", &heartbeatMsg)
+		if emitter.msg.MessageType != transport.MessageTypeCallResult {
+			b.Fatalf("unexpected response: %v", emitter.msg)
+		}
+	}
+}
+
+func TestRouterErrorWhenActionIsDisabled(t *testing.T) {
+	emitter := new(FakeEmitter)
+	flags := handlers.NewActionFlags()
+
+	router := handlers.Router{
+		Emitter:     emitter,
+		SchemaFS:    schemas.OcppSchemas,
+		OcppVersion: transport.OcppVersion201,
+		ActionFlags: flags,
+		CallRoutes: map[string]handlers.CallRoute{
+			"Heartbeat": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
+				RequestSchema:  "ocpp201/HeartbeatRequest.json",
+				ResponseSchema: "ocpp201/HeartbeatResponse.json",
+				Handler: handlers201.HeartbeatHandler{
+					Clock: clock.RealClock{},
+				},
+			},
+		},
+	}
+
+	flags.SetEnabled(transport.OcppVersion201, "Heartbeat", false)
+	router.Handle(context.Background(), "id", &heartbeatMsg)
+
+	assert.Equal(t, transport.MessageTypeCallError, emitter.msg.MessageType)
+	assert.Equal(t, transport.ErrorNotSupported, emitter.msg.ErrorCode)
+
+	flags.SetEnabled(transport.OcppVersion201, "Heartbeat", true)
+	router.Handle(context.Background(), "id", &heartbeatMsg)
+
+	assert.Equal(t, transport.MessageTypeCallResult, emitter.msg.MessageType)
+}
+
+func TestRouterTranslatesSoapPayloads(t *testing.T) {
+	emitter := new(FakeEmitter)
+
+	router := handlers.Router{
+		Emitter:         emitter,
+		SchemaFS:        schemas.OcppSchemas,
+		OcppVersion:     transport.OcppVersion201,
+		SoapTranslation: true,
+		CallRoutes: map[string]handlers.CallRoute{
+			"Heartbeat": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
+				RequestSchema:  "ocpp201/HeartbeatRequest.json",
+				ResponseSchema: "ocpp201/HeartbeatResponse.json",
+				Handler: handlers201.HeartbeatHandler{
+					Clock: clock.RealClock{},
+				},
+			},
+		},
+	}
+
+	msg := transport.Message{
+		Action:         "Heartbeat",
+		MessageType:    transport.MessageTypeCall,
+		RequestPayload: []byte(`{"@xmlns":"urn://Ocpp/Cs/2015/10/"}`),
+	}
+
+	router.Handle(context.Background(), "id", &msg)
+	assert.Equal(t, transport.MessageTypeCallError, emitter.msg.MessageType, "only messages from soap charge stations are translated")
+	assert.Equal(t, transport.ErrorFormatViolation, emitter.msg.ErrorCode)
+
+	msg.Origin = transport.MessageOriginSoap
+	router.Handle(context.Background(), "id", &msg)
+	assert.Equal(t, transport.MessageTypeCallResult, emitter.msg.MessageType)
+}
+
 func TestRouterErrorWhenNoCallRoute(t *testing.T) {
 	emitter := new(FakeEmitter)
 
@@ -84,6 +196,42 @@ func TestRouterErrorWhenNoCallRoute(t *testing.T) {
 	assert.Nil(t, emitter.msg.ResponsePayload)
 }
 
+type fakeDeadLetterEmitter struct {
+	letters []*transport.DeadLetter
+}
+
+func (f *fakeDeadLetterEmitter) EmitDeadLetter(_ context.Context, letter *transport.DeadLetter) error {
+	f.letters = append(f.letters, letter)
+	return nil
+}
+
+func TestRouterEmitsDeadLetterWhenNoCallRoute(t *testing.T) {
+	emitter := new(FakeEmitter)
+	deadLetters := new(fakeDeadLetterEmitter)
+
+	router := handlers.Router{
+		Emitter:     emitter,
+		SchemaFS:    schemas.OcppSchemas,
+		OcppVersion: transport.OcppVersion201,
+		CallRoutes:  map[string]handlers.CallRoute{},
+		DeadLetters: deadLetters,
+	}
+
+	router.Handle(context.Background(), "id", &heartbeatMsg)
+
+	require.Len(t, deadLetters.letters, 1)
+	letter := deadLetters.letters[0]
+	assert.Equal(t, transport.OcppVersion201, letter.OcppVersion)
+	assert.Equal(t, "id", letter.ChargeStationId)
+	assert.Equal(t, &heartbeatMsg, letter.Message)
+	assert.Equal(t, transport.ErrorNotImplemented, letter.ErrorCode)
+	assert.NotEmpty(t, letter.Error)
+	assert.False(t, letter.Timestamp.IsZero())
+
+	// the charge station still receives an error response
+	assert.Equal(t, transport.MessageTypeCallError, emitter.msg.MessageType)
+}
+
 func TestRouterErrorWhenCallRequestPayloadIsInvalid(t *testing.T) {
 	emitter := new(FakeEmitter)
 
@@ -253,6 +401,42 @@ func TestRouterHandlesCallResult(t *testing.T) {
 	assert.Equal(t, codes.Ok, exporter.GetSpans()[0].Status.Code)
 }
 
+func TestRouterHandlesCallError(t *testing.T) {
+	tracer, exporter := testutil.GetTracer()
+
+	emitter := new(FakeEmitter)
+
+	var got *transport.Message
+	router := handlers.Router{
+		Emitter:  emitter,
+		SchemaFS: os.DirFS("testdata"),
+		CallErrors: handlers.CallErrorHandlerFunc(func(ctx context.Context, chargeStationId string, message *transport.Message) error {
+			got = message
+			return nil
+		}),
+	}
+
+	errorMsg := transport.Message{
+		Action:           "Result",
+		MessageType:      transport.MessageTypeCallError,
+		ErrorCode:        transport.ErrorNotSupported,
+		ErrorDescription: "not supported",
+	}
+	func() {
+		ctx, span := tracer.Start(context.Background(), "test")
+		defer span.End()
+		router.Handle(ctx, "id", &errorMsg)
+	}()
+
+	// for a call error the emitter should never be called
+	assert.False(t, emitter.called)
+	assert.Equal(t, &errorMsg, got)
+
+	// check that no error was produced using telemetry
+	require.Greater(t, len(exporter.GetSpans()), 0)
+	assert.Equal(t, codes.Ok, exporter.GetSpans()[0].Status.Code)
+}
+
 func TestRouterErrorWhenNoCallResultRoute(t *testing.T) {
 	tracer, exporter := testutil.GetTracer()
 
@@ -583,3 +767,56 @@ func (*noMarshalResponse) IsResponse() {}
 func (*noMarshalResponse) MarshalJSON() ([]byte, error) {
 	return nil, errors.New("expected to fail")
 }
+
+func TestRouterCountsMessages(t *testing.T) {
+	router := handlers.Router{
+		Emitter:     new(FakeEmitter),
+		SchemaFS:    schemas.OcppSchemas,
+		OcppVersion: transport.OcppVersion201,
+		CallRoutes: map[string]handlers.CallRoute{
+			"Heartbeat": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
+				RequestSchema:  "ocpp201/HeartbeatRequest.json",
+				ResponseSchema: "ocpp201/HeartbeatResponse.json",
+				Handler: handlers201.HeartbeatHandler{
+					Clock: clock.RealClock{},
+				},
+			},
+		},
+	}
+	labels := map[string]string{"ocpp_version": "ocpp2.0.1", "message_type": "call", "action": "Heartbeat"}
+	before := testutil.MetricValue(t, "manager_messages_received_total", labels)
+
+	router.Handle(context.Background(), "id", &heartbeatMsg)
+	router.Handle(context.Background(), "id", &heartbeatMsg)
+
+	assert.Equal(t, before+2, testutil.MetricValue(t, "manager_messages_received_total", labels))
+}
+
+func TestRouterCountsSchemaValidationFailures(t *testing.T) {
+	emitter := new(FakeEmitter)
+
+	router := handlers.Router{
+		Emitter:     emitter,
+		SchemaFS:    schemas.OcppSchemas,
+		OcppVersion: transport.OcppVersion201,
+		CallRoutes: map[string]handlers.CallRoute{
+			"BootNotification": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.BootNotificationRequestJson) },
+				RequestSchema:  "ocpp201/BootNotificationRequest.json",
+				ResponseSchema: "ocpp201/BootNotificationResponse.json",
+			},
+		},
+	}
+	labels := map[string]string{"ocpp_version": "ocpp2.0.1", "action": "BootNotification", "payload": "request"}
+	before := testutil.MetricValue(t, "manager_schema_validation_failures_total", labels)
+
+	router.Handle(context.Background(), "id", &transport.Message{
+		Action:         "BootNotification",
+		MessageType:    transport.MessageTypeCall,
+		RequestPayload: []byte("{}"),
+	})
+
+	assert.Equal(t, transport.ErrorFormatViolation, emitter.msg.ErrorCode)
+	assert.Equal(t, before+1, testutil.MetricValue(t, "manager_schema_validation_failures_total", labels))
+}
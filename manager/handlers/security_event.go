@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"golang.org/x/exp/slog"
+)
+
+// SecurityEventListener is informed when a charge station reports a security event.
+type SecurityEventListener interface {
+	SecurityEventReported(ctx context.Context, chargeStationId, eventType, timestamp string, techInfo *string) error
+}
+
+// NotifySecurityEventReported informs the listener (if any) of a security event. The
+// charge station only needs the event to be acknowledged, so failures are logged
+// rather than returned to the charge station.
+func NotifySecurityEventReported(ctx context.Context, listener SecurityEventListener, chargeStationId, eventType, timestamp string, techInfo *string) {
+	if listener == nil {
+		return
+	}
+
+	err := listener.SecurityEventReported(ctx, chargeStationId, eventType, timestamp, techInfo)
+	if err != nil {
+		LoggerFromContext(ctx).Warn("unable to notify security event", slog.String("type", eventType), "err", err)
+	}
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/santhosh-tekuri/jsonschema"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// soapTimestampLayouts are the forms of xs:dateTime that are accepted: a timestamp
+// without a time zone is taken to be UTC
+var soapTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// TranslateSoapPayload converts the payload of a message that the gateway received
+// from an OCPP 1.6 SOAP charge station to the conventions of OCPP-J, so that it can
+// be handled in the same way as a JSON message. The gateway converts the SOAP body
+// to JSON without knowing the types of the fields, so, guided by the schema:
+//
+//   - numbers and booleans that arrive as strings are converted
+//   - a repeated element that occurs once arrives as a single value and is made into an array
+//   - field names are matched ignoring case and any namespace prefix
+//   - enumerated values are matched ignoring case
+//   - timestamps are converted to RFC 3339 in UTC
+//   - fields that the schema does not allow, such as XML attributes, are removed
+func TranslateSoapPayload(payload json.RawMessage, schemaFS fs.FS, schemaFile string) (json.RawMessage, error) {
+	schema, err := schemas.Compile(schemaFS, schemaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("decoding soap payload: %w", err)
+	}
+
+	return json.Marshal(translateSoapValue(schema, value))
+}
+
+func translateSoapValue(schema *jsonschema.Schema, value any) any {
+	for schema.Ref != nil {
+		schema = schema.Ref
+	}
+	if len(schema.Types) != 1 {
+		return value
+	}
+
+	switch schema.Types[0] {
+	case "object":
+		return translateSoapObject(schema, value)
+	case "array":
+		return translateSoapArray(schema, value)
+	case "integer":
+		if s, ok := value.(string); ok {
+			if _, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+				return json.Number(strings.TrimSpace(s))
+			}
+		}
+	case "number":
+		if s, ok := value.(string); ok {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+				return json.Number(strings.TrimSpace(s))
+			}
+		}
+	case "boolean":
+		if s, ok := value.(string); ok {
+			if b, err := strconv.ParseBool(strings.TrimSpace(s)); err == nil {
+				return b
+			}
+		}
+	case "string":
+		return translateSoapString(schema, value)
+	}
+	return value
+}
+
+func translateSoapObject(schema *jsonschema.Schema, value any) any {
+	if s, ok := value.(string); ok && strings.TrimSpace(s) == "" {
+		// an empty element
+		return map[string]any{}
+	}
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+
+	translated := make(map[string]any, len(fields))
+	for name, field := range fields {
+		property, propertySchema := soapProperty(schema, name)
+		if propertySchema == nil {
+			if additional, ok := schema.AdditionalProperties.(bool); ok && !additional {
+				continue
+			}
+			translated[name] = field
+			continue
+		}
+		translated[property] = translateSoapValue(propertySchema, field)
+	}
+	return translated
+}
+
+// soapProperty finds the property of the schema that the field name refers to
+func soapProperty(schema *jsonschema.Schema, name string) (string, *jsonschema.Schema) {
+	if propertySchema, ok := schema.Properties[name]; ok {
+		return name, propertySchema
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+	for property, propertySchema := range schema.Properties {
+		if strings.EqualFold(property, name) {
+			return property, propertySchema
+		}
+	}
+	return "", nil
+}
+
+func translateSoapArray(schema *jsonschema.Schema, value any) any {
+	itemSchema, _ := schema.Items.(*jsonschema.Schema)
+	translateItem := func(item any) any {
+		if itemSchema == nil {
+			return item
+		}
+		return translateSoapValue(itemSchema, item)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = translateItem(item)
+		}
+		return items
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return []any{}
+		}
+	}
+	return []any{translateItem(value)}
+}
+
+func translateSoapString(schema *jsonschema.Schema, value any) any {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case bool:
+		s = strconv.FormatBool(v)
+	default:
+		return value
+	}
+
+	for _, allowed := range schema.Enum {
+		if a, ok := allowed.(string); ok && strings.EqualFold(a, strings.TrimSpace(s)) {
+			return a
+		}
+	}
+
+	if schema.FormatName == "date-time" {
+		for _, layout := range soapTimestampLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+				return t.UTC().Format(time.RFC3339Nano)
+			}
+		}
+	}
+
+	return s
+}
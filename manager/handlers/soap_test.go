@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"testing"
+)
+
+func TestTranslateSoapPayload(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaFile string
+		payload    string
+		want       string
+	}{
+		{
+			name:       "numbers and timestamps",
+			schemaFile: "ocpp16/StartTransaction.json",
+			payload:    `{"connectorId":"1","idTag":"DEADBEEF","meterStart":" 1200 ","timestamp":"2023-06-01T12:00:00"}`,
+			want:       `{"connectorId":1,"idTag":"DEADBEEF","meterStart":1200,"timestamp":"2023-06-01T12:00:00Z"}`,
+		},
+		{
+			name:       "timestamp with offset",
+			schemaFile: "ocpp16/StopTransaction.json",
+			payload:    `{"meterStop":"1500","timestamp":"2023-06-01T14:00:00.5+0200","transactionId":"7"}`,
+			want:       `{"meterStop":1500,"timestamp":"2023-06-01T12:00:00.5Z","transactionId":7}`,
+		},
+		{
+			name:       "field names and enumerations",
+			schemaFile: "ocpp16/StatusNotification.json",
+			payload:    `{"ns:ConnectorId":"2","ErrorCode":"noerror","Status":"AVAILABLE"}`,
+			want:       `{"connectorId":2,"errorCode":"NoError","status":"Available"}`,
+		},
+		{
+			name:       "single element arrays",
+			schemaFile: "ocpp16/MeterValues.json",
+			payload: `{"connectorId":"1","transactionId":"7","meterValue":{"timestamp":"2023-06-01T12:00:00Z",` +
+				`"sampledValue":{"value":1234,"measurand":"energy.active.import.register","unit":"Wh"}}}`,
+			want: `{"connectorId":1,"transactionId":7,"meterValue":[{"timestamp":"2023-06-01T12:00:00Z",` +
+				`"sampledValue":[{"value":"1234","measurand":"Energy.Active.Import.Register","unit":"Wh"}]}]}`,
+		},
+		{
+			name:       "xml attributes",
+			schemaFile: "ocpp16/Heartbeat.json",
+			payload:    `{"@xmlns":"urn://Ocpp/Cs/2015/10/"}`,
+			want:       `{}`,
+		},
+		{
+			name:       "ocpp-j payload",
+			schemaFile: "ocpp16/StartTransaction.json",
+			payload:    `{"connectorId":1,"idTag":"DEADBEEF","meterStart":1200,"timestamp":"2023-06-01T12:00:00Z"}`,
+			want:       `{"connectorId":1,"idTag":"DEADBEEF","meterStart":1200,"timestamp":"2023-06-01T12:00:00Z"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handlers.TranslateSoapPayload([]byte(tt.payload), schemas.OcppSchemas, tt.schemaFile)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+
+			err = schemas.Validate(got, schemas.OcppSchemas, tt.schemaFile)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTranslateSoapPayloadWithInvalidJson(t *testing.T) {
+	_, err := handlers.TranslateSoapPayload([]byte(`{`), schemas.OcppSchemas, "ocpp16/Heartbeat.json")
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+)
+
+// TransactionListener is informed when a transaction is started, updated or ended.
+type TransactionListener interface {
+	TransactionChanged(ctx context.Context, transaction *store.Transaction) error
+}
+
+// NotifyTransactionChanged informs the listener (if any) of the current state of the
+// transaction. The transaction has already been stored, so failures are logged rather
+// than returned to the charge station.
+func NotifyTransactionChanged(ctx context.Context, transactionStore store.TransactionStore, listener TransactionListener, chargeStationId, transactionId string) {
+	if listener == nil {
+		return
+	}
+
+	transaction, err := transactionStore.FindTransaction(ctx, chargeStationId, transactionId)
+	if err != nil {
+		LoggerFromContext(ctx).Warn("unable to find transaction", slog.String("transactionId", transactionId), "err", err)
+		return
+	}
+	if transaction == nil {
+		return
+	}
+
+	err = listener.TransactionChanged(ctx, transaction)
+	if err != nil {
+		LoggerFromContext(ctx).Warn("unable to publish transaction", slog.String("transactionId", transactionId), "err", err)
+	}
+}
+
+// TransactionListeners informs each of the listeners when a transaction changes.
+type TransactionListeners []TransactionListener
+
+func (l TransactionListeners) TransactionChanged(ctx context.Context, transaction *store.Transaction) error {
+	var errs []error
+	for _, listener := range l {
+		if err := listener.TransactionChanged(ctx, transaction); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
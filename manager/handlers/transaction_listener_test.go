@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+type fakeTransactionListener struct {
+	transactions []*store.Transaction
+}
+
+func (f *fakeTransactionListener) TransactionChanged(_ context.Context, transaction *store.Transaction) error {
+	f.transactions = append(f.transactions, transaction)
+	return nil
+}
+
+func TestNotifyTransactionChanged(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.CreateTransaction(context.Background(), "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 0, false)
+	require.NoError(t, err)
+
+	listener := &fakeTransactionListener{}
+	handlers.NotifyTransactionChanged(context.Background(), engine, listener, "cs001", "tx001")
+	// an unknown transaction is not published
+	handlers.NotifyTransactionChanged(context.Background(), engine, listener, "cs001", "tx002")
+
+	require.Len(t, listener.transactions, 1)
+	assert.Equal(t, "tx001", listener.transactions[0].TransactionId)
+}
+
+func TestNotifyTransactionChangedWithoutListener(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	assert.NotPanics(t, func() {
+		handlers.NotifyTransactionChanged(context.Background(), engine, nil, "cs001", "tx001")
+	})
+}
+
+func TestTransactionListenersInformsEachListener(t *testing.T) {
+	first := &fakeTransactionListener{}
+	second := &fakeTransactionListener{}
+	listeners := handlers.TransactionListeners{first, second}
+
+	err := listeners.TransactionChanged(context.Background(), &store.Transaction{TransactionId: "tx001"})
+	require.NoError(t, err)
+
+	assert.Len(t, first.transactions, 1)
+	assert.Len(t, second.transactions, 1)
+}
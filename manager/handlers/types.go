@@ -5,6 +5,7 @@ package handlers
 import (
 	"context"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 )
 
 // CallHandler is the interface implemented by handlers that are designed to process an OCPP Call.
@@ -54,6 +55,20 @@ type CallResultRoute struct {
 	Handler        CallResultHandler    // Function to process a call result
 }
 
+// CallErrorHandler is the interface implemented by handlers that want to know when a
+// charge station answers a call made by the CSMS with an OCPP CallError. The message
+// holds the action of the call, the error code and description and any cached state.
+type CallErrorHandler interface {
+	HandleCallError(ctx context.Context, chargeStationId string, message *transport.Message) error
+}
+
+// CallErrorHandlerFunc allows a plain function to be used as a CallErrorHandler
+type CallErrorHandlerFunc func(ctx context.Context, chargeStationId string, message *transport.Message) error
+
+func (ceh CallErrorHandlerFunc) HandleCallError(ctx context.Context, chargeStationId string, message *transport.Message) error {
+	return ceh(ctx, chargeStationId, message)
+}
+
 // CallMaker is the interface used by handlers (and other parts of the system) that want to initiate
 // an OCPP call from the CSMS.
 type CallMaker interface {
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"io"
+	"strings"
+)
+
+// maxLineLength is the longest line in a corpus: messages that carry certificates can
+// be large
+const maxLineLength = 1024 * 1024
+
+// Entry is a message received from a charge station
+type Entry struct {
+	OcppVersion     transport.OcppVersion
+	ChargeStationId string
+	Message         *transport.Message
+}
+
+// ReadCorpus reads messages captured from the topics on which the gateway publishes
+// the messages that it receives from charge stations, in the format written by
+// mosquitto_sub -v -t '<prefix>/in/#': one message per line, the topic followed by a
+// space and the message. Blank lines and lines starting with # are ignored.
+func ReadCorpus(r io.Reader) ([]Entry, error) {
+	var corpus []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineLength)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry, err := parseEntry(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		corpus = append(corpus, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading corpus: %w", err)
+	}
+	return corpus, nil
+}
+
+func parseEntry(line string) (Entry, error) {
+	topic, payload, ok := strings.Cut(line, " ")
+	if !ok {
+		return Entry{}, fmt.Errorf("expected a topic and a message")
+	}
+
+	// the topic is <prefix>/in/<ocpp-version>/<cs-id>
+	parts := strings.Split(topic, "/")
+	if len(parts) < 3 || parts[len(parts)-3] != "in" || parts[len(parts)-1] == "" {
+		return Entry{}, fmt.Errorf("topic %s is not an inbound charge station topic", topic)
+	}
+
+	var msg transport.Message
+	err := json.Unmarshal([]byte(payload), &msg)
+	if err != nil {
+		return Entry{}, fmt.Errorf("unmarshalling message: %w", err)
+	}
+	return Entry{
+		OcppVersion:     transport.OcppVersion(parts[len(parts)-2]),
+		ChargeStationId: parts[len(parts)-1],
+		Message:         &msg,
+	}, nil
+}
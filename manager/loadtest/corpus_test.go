@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/loadtest"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"strings"
+	"testing"
+)
+
+func TestReadCorpus(t *testing.T) {
+	corpus, err := loadtest.ReadCorpus(strings.NewReader(`
+# captured with mosquitto_sub -v -t 'cs/in/#'
+cs/in/ocpp2.0.1/cs001 {"type":2,"action":"Heartbeat","id":"1","request":{}}
+
+cs/in/ocpp1.6/cs002 {"type":3,"action":"DataTransfer","id":"2","request":{"vendorId":"x"},"response":{"status":"Accepted"}}
+`))
+	require.NoError(t, err)
+
+	require.Len(t, corpus, 2)
+	assert.Equal(t, transport.OcppVersion201, corpus[0].OcppVersion)
+	assert.Equal(t, "cs001", corpus[0].ChargeStationId)
+	assert.Equal(t, transport.MessageTypeCall, corpus[0].Message.MessageType)
+	assert.Equal(t, "Heartbeat", corpus[0].Message.Action)
+	assert.Equal(t, transport.OcppVersion16, corpus[1].OcppVersion)
+	assert.Equal(t, "cs002", corpus[1].ChargeStationId)
+	assert.Equal(t, transport.MessageTypeCallResult, corpus[1].Message.MessageType)
+	assert.JSONEq(t, `{"status":"Accepted"}`, string(corpus[1].Message.ResponsePayload))
+}
+
+func TestReadCorpusRejectsOutboundTopic(t *testing.T) {
+	_, err := loadtest.ReadCorpus(strings.NewReader(`cs/out/ocpp2.0.1/cs001 {"type":3,"action":"Heartbeat","id":"1"}`))
+	assert.ErrorContains(t, err, "line 1: topic cs/out/ocpp2.0.1/cs001 is not an inbound charge station topic")
+}
+
+func TestReadCorpusRejectsInvalidMessage(t *testing.T) {
+	_, err := loadtest.ReadCorpus(strings.NewReader("cs/in/ocpp2.0.1/cs001 {\"type\":2,\n"))
+	assert.ErrorContains(t, err, "line 1: unmarshalling message")
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	"sync"
+	"time"
+)
+
+// NewRouters returns the routers for each OCPP version, configured as they are by
+// default but without the certificate services: Plug & Charge messages fail with an
+// internal error.
+func NewRouters(emitter transport.Emitter, engine store.Engine) map[transport.OcppVersion]transport.MessageHandler {
+	clk := clock.RealClock{}
+	heartbeatInterval := services.NewHeartbeatInterval(time.Hour)
+	registrationPolicy := handlers.RegistrationPolicy{Store: engine, RetryInterval: time.Minute}
+	tariffService := services.TariffEngine{
+		Store:         engine,
+		DefaultTariff: services.NewDefaultTariff("EUR", 0, 0.55, 0),
+		Location:      time.UTC,
+	}
+	schedulingStrategy := services.AsSoonAsPossibleSchedulingStrategy{Clock: clk}
+
+	return map[transport.OcppVersion]transport.MessageHandler{
+		transport.OcppVersion16: ocpp16.NewRouter(emitter, clk, engine,
+			nil, nil, nil,
+			heartbeatInterval, registrationPolicy,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+			false, schemas.OcppSchemas),
+		transport.OcppVersion201: ocpp201.NewRouter(emitter, clk, engine, tariffService,
+			nil, nil, nil,
+			heartbeatInterval, registrationPolicy,
+			nil, nil, nil, nil, schedulingStrategy, nil, nil, nil, nil,
+			schemas.OcppSchemas),
+		transport.OcppVersion21: ocpp21.NewRouter(emitter, clk, engine, tariffService,
+			nil, nil, nil,
+			heartbeatInterval, registrationPolicy,
+			nil, nil, nil, nil, schedulingStrategy, nil, nil, nil, nil,
+			schemas.OcppSchemas),
+	}
+}
+
+// Emitter discards the messages that the routers send to charge stations, counting
+// the call errors that answer the calls from the charge stations
+type Emitter struct {
+	mu     sync.Mutex
+	errors map[actionKey]int64
+}
+
+type actionKey struct {
+	ocppVersion transport.OcppVersion
+	action      string
+}
+
+func (e *Emitter) Emit(_ context.Context, ocppVersion transport.OcppVersion, _ string, message *transport.Message) error {
+	if message.MessageType != transport.MessageTypeCallError {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.errors == nil {
+		e.errors = make(map[actionKey]int64)
+	}
+	e.errors[actionKey{ocppVersion: ocppVersion, action: message.Action}]++
+	return nil
+}
+
+// Errors returns the number of call errors sent in answer to calls with the action
+func (e *Emitter) Errors(ocppVersion transport.OcppVersion, action string) int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.errors[actionKey{ocppVersion: ocppVersion, action: action}]
+}
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxSamples is the number of latencies kept for each action to estimate the
+// percentiles
+const maxSamples = 10000
+
+// Runner replays a corpus of messages to the routers and measures how long each
+// message takes to route
+type Runner struct {
+	// Handlers are the routers for each OCPP version in the corpus
+	Handlers map[transport.OcppVersion]transport.MessageHandler
+	// Emitter, if set, is the emitter used by the Handlers: the call errors that it
+	// counts are reported for each action
+	Emitter *Emitter
+	// Rate is the number of messages routed per second: if it is not set the messages
+	// are routed as fast as possible
+	Rate float64
+	// Concurrency is the number of messages routed at once
+	Concurrency int
+	// Duration is how long the corpus is replayed for, repeating it as necessary: if
+	// it is not set the corpus is replayed once
+	Duration time.Duration
+}
+
+// Report describes the routing performance of a run
+type Report struct {
+	Messages int64
+	Errors   int64
+	Elapsed  time.Duration
+	Actions  []ActionReport
+}
+
+// Throughput is the number of messages routed per second
+func (r *Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Messages) / r.Elapsed.Seconds()
+}
+
+// ActionReport describes the routing performance of the messages with an action and
+// message type. The percentiles are estimated from a sample of the latencies.
+type ActionReport struct {
+	OcppVersion transport.OcppVersion
+	Action      string
+	MessageType transport.MessageType
+	Messages    int64
+	Errors      int64
+	Mean        time.Duration
+	P50         time.Duration
+	P95         time.Duration
+	P99         time.Duration
+	Max         time.Duration
+}
+
+type latencyKey struct {
+	ocppVersion transport.OcppVersion
+	action      string
+	messageType transport.MessageType
+}
+
+// latencies records the latencies of the messages with an action, keeping a uniform
+// sample of them
+type latencies struct {
+	count   int64
+	total   time.Duration
+	max     time.Duration
+	samples []time.Duration
+}
+
+func (l *latencies) add(latency time.Duration, rnd *rand.Rand) {
+	l.count++
+	l.total += latency
+	if latency > l.max {
+		l.max = latency
+	}
+	if len(l.samples) < maxSamples {
+		l.samples = append(l.samples, latency)
+	} else if i := rnd.Int63n(l.count); i < maxSamples {
+		l.samples[i] = latency
+	}
+}
+
+func (l *latencies) percentile(p float64) time.Duration {
+	return l.samples[int(p*float64(len(l.samples)-1))]
+}
+
+// Run replays the corpus until it has been replayed once or, if a duration is set,
+// until the duration has passed. It stops early if the context is done.
+func (r *Runner) Run(ctx context.Context, corpus []Entry) (*Report, error) {
+	if len(corpus) == 0 {
+		return nil, errors.New("corpus is empty")
+	}
+	for _, entry := range corpus {
+		if r.Handlers[entry.OcppVersion] == nil {
+			return nil, fmt.Errorf("no router for ocpp version %s", entry.OcppVersion)
+		}
+	}
+	concurrency := r.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var mu sync.Mutex
+	results := make(map[latencyKey]*latencies)
+	// #nosec G404 - the sample does not need to be unpredictable
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	work := make(chan Entry)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range work {
+				start := time.Now()
+				r.Handlers[entry.OcppVersion].Handle(ctx, entry.ChargeStationId, entry.Message)
+				latency := time.Since(start)
+
+				key := latencyKey{ocppVersion: entry.OcppVersion, action: entry.Message.Action, messageType: entry.Message.MessageType}
+				mu.Lock()
+				l := results[key]
+				if l == nil {
+					l = &latencies{}
+					results[key] = l
+				}
+				l.add(latency, rnd)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	start := time.Now()
+	for i := 0; ctx.Err() == nil; i++ {
+		if r.Duration > 0 && time.Since(start) >= r.Duration {
+			break
+		}
+		if r.Duration <= 0 && i >= len(corpus) {
+			break
+		}
+		if r.Rate > 0 {
+			due := start.Add(time.Duration(float64(i) / r.Rate * float64(time.Second)))
+			if wait := time.Until(due); wait > 0 {
+				select {
+				case <-ctx.Done():
+					continue
+				case <-time.After(wait):
+				}
+			}
+		}
+		work <- corpus[i%len(corpus)]
+	}
+	close(work)
+	wg.Wait()
+
+	report := &Report{Elapsed: time.Since(start)}
+	for key, l := range results {
+		sort.Slice(l.samples, func(i, j int) bool { return l.samples[i] < l.samples[j] })
+		action := ActionReport{
+			OcppVersion: key.ocppVersion,
+			Action:      key.action,
+			MessageType: key.messageType,
+			Messages:    l.count,
+			Mean:        l.total / time.Duration(l.count),
+			P50:         l.percentile(0.5),
+			P95:         l.percentile(0.95),
+			P99:         l.percentile(0.99),
+			Max:         l.max,
+		}
+		if r.Emitter != nil && key.messageType == transport.MessageTypeCall {
+			action.Errors = r.Emitter.Errors(key.ocppVersion, key.action)
+		}
+		report.Messages += action.Messages
+		report.Errors += action.Errors
+		report.Actions = append(report.Actions, action)
+	}
+	sort.Slice(report.Actions, func(i, j int) bool {
+		a, b := report.Actions[i], report.Actions[j]
+		if a.OcppVersion != b.OcppVersion {
+			return a.OcppVersion < b.OcppVersion
+		}
+		if a.Action != b.Action {
+			return a.Action < b.Action
+		}
+		return a.MessageType < b.MessageType
+	})
+	return report, nil
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/loadtest"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func newCall(action, payload string) *transport.Message {
+	return &transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         action,
+		MessageId:      "1",
+		RequestPayload: json.RawMessage(payload),
+	}
+}
+
+func TestRunnerReportsLatencyAndErrorsByAction(t *testing.T) {
+	emitter := new(loadtest.Emitter)
+	runner := loadtest.Runner{
+		Handlers:    loadtest.NewRouters(emitter, inmemory.NewStore(clock.RealClock{})),
+		Emitter:     emitter,
+		Concurrency: 2,
+	}
+
+	report, err := runner.Run(context.Background(), []loadtest.Entry{
+		{OcppVersion: transport.OcppVersion201, ChargeStationId: "cs001", Message: newCall("Heartbeat", `{}`)},
+		{OcppVersion: transport.OcppVersion201, ChargeStationId: "cs001", Message: newCall("Heartbeat", `{}`)},
+		{OcppVersion: transport.OcppVersion201, ChargeStationId: "cs001", Message: newCall("StatusNotification", `{"evseId":"one"}`)},
+		{OcppVersion: transport.OcppVersion16, ChargeStationId: "cs002", Message: newCall("Heartbeat", `{}`)},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(4), report.Messages)
+	assert.Equal(t, int64(1), report.Errors)
+	require.Len(t, report.Actions, 3)
+
+	assert.Equal(t, transport.OcppVersion16, report.Actions[0].OcppVersion)
+	assert.Equal(t, "Heartbeat", report.Actions[0].Action)
+	assert.Equal(t, int64(1), report.Actions[0].Messages)
+
+	heartbeat := report.Actions[1]
+	assert.Equal(t, transport.OcppVersion201, heartbeat.OcppVersion)
+	assert.Equal(t, "Heartbeat", heartbeat.Action)
+	assert.Equal(t, int64(2), heartbeat.Messages)
+	assert.Equal(t, int64(0), heartbeat.Errors)
+	assert.Greater(t, heartbeat.Max, time.Duration(0))
+	assert.LessOrEqual(t, heartbeat.P50, heartbeat.Max)
+
+	statusNotification := report.Actions[2]
+	assert.Equal(t, "StatusNotification", statusNotification.Action)
+	assert.Equal(t, int64(1), statusNotification.Errors)
+}
+
+func TestRunnerRepeatsCorpusAtRateForDuration(t *testing.T) {
+	emitter := new(loadtest.Emitter)
+	runner := loadtest.Runner{
+		Handlers: loadtest.NewRouters(emitter, inmemory.NewStore(clock.RealClock{})),
+		Rate:     100,
+		Duration: 200 * time.Millisecond,
+	}
+
+	report, err := runner.Run(context.Background(), []loadtest.Entry{
+		{OcppVersion: transport.OcppVersion201, ChargeStationId: "cs001", Message: newCall("Heartbeat", `{}`)},
+	})
+	require.NoError(t, err)
+
+	assert.InDelta(t, 20, report.Messages, 5)
+	assert.InDelta(t, 100, report.Throughput(), 25)
+}
+
+func TestRunnerRejectsCorpusWithoutRouter(t *testing.T) {
+	runner := loadtest.Runner{
+		Handlers: map[transport.OcppVersion]transport.MessageHandler{},
+	}
+
+	_, err := runner.Run(context.Background(), []loadtest.Entry{
+		{OcppVersion: transport.OcppVersion201, ChargeStationId: "cs001", Message: newCall("Heartbeat", `{}`)},
+	})
+	assert.ErrorContains(t, err, "no router for ocpp version ocpp2.0.1")
+}
@@ -4,6 +4,7 @@ package ocpi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/thoughtworks/maeve-csms/manager/store"
@@ -13,43 +14,115 @@ import (
 
 var authzHeaderRegexp = regexp.MustCompile(`(?i)^Token (.*)$`)
 
+// receiverPathRegexp matches the receiver interfaces that identify the party that owns
+// the object in the path: the 2.1.1 tokens interface is the equivalent of the 2.2 tokens
+// receiver interface
+var receiverPathRegexp = regexp.MustCompile(`^/ocpi/(?:receiver/2\.2/[^/]+|2\.1\.1/tokens)/([^/]+)/([^/]+)(/|$)`)
+
+// NewTokenAuthenticationFunc returns an authentication function that checks the token in
+// the Authorization header has been registered. A token that was issued when credentials
+// were exchanged is also scoped to the parties that use it: the party that a request is
+// routed from and the party that owns the object being pushed must be one of them, unless
+// the token is used by a hub.
 func NewTokenAuthenticationFunc(engine store.Engine) openapi3filter.AuthenticationFunc {
 	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
-		authzHeader := input.RequestValidationInput.Request.Header.Get("Authorization")
-		matches := authzHeaderRegexp.FindStringSubmatch(authzHeader)
-		if len(matches) != 2 {
-			return input.NewError(nil)
-		}
-
-		reg, err := engine.GetRegistrationDetails(ctx, matches[1])
+		err := authenticate(ctx, engine, input.RequestValidationInput.Request)
 		if err != nil {
 			return input.NewError(err)
 		}
-		if reg == nil {
-			return input.NewError(fmt.Errorf("unknown token"))
-		}
-		if reg.Status != store.OcpiRegistrationStatusRegistered {
-			allowed := false
-			switch input.RequestValidationInput.Request.Method {
-			case http.MethodGet:
-				switch input.RequestValidationInput.Request.URL.Path {
-				case "/ocpi/versions":
-					allowed = true
-				case "/ocpi/2.2":
-					allowed = true
-				}
-			case http.MethodPost:
-				switch input.RequestValidationInput.Request.URL.Path {
-				case "/ocpi/2.2/credentials":
-					allowed = true
-				}
+		return nil
+	}
+}
+
+// NewTokenAuthenticationMiddleware returns middleware that authenticates requests in the
+// same way as NewTokenAuthenticationFunc for the interfaces that are not described by the
+// OpenAPI specification, such as the 2.1.1 interfaces
+func NewTokenAuthenticationMiddleware(engine store.Engine) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := authenticate(r.Context(), engine, r)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
 			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func authenticate(ctx context.Context, engine store.Engine, r *http.Request) error {
+	authzHeader := r.Header.Get("Authorization")
+	matches := authzHeaderRegexp.FindStringSubmatch(authzHeader)
+	if len(matches) != 2 {
+		return errors.New("no token")
+	}
 
-			if !allowed {
-				return input.NewError(fmt.Errorf("unregistered token"))
+	reg, err := engine.GetRegistrationDetails(ctx, matches[1])
+	if err != nil {
+		return err
+	}
+	if reg == nil {
+		return fmt.Errorf("unknown token")
+	}
+	if reg.Status != store.OcpiRegistrationStatusRegistered {
+		allowed := false
+		switch r.Method {
+		case http.MethodGet:
+			switch r.URL.Path {
+			case "/ocpi/versions":
+				allowed = true
+			case "/ocpi/2.1.1", "/ocpi/2.2", "/ocpi/2.2.1":
+				allowed = true
 			}
+		case http.MethodPost:
+			switch r.URL.Path {
+			case "/ocpi/2.1.1/credentials", "/ocpi/2.2/credentials":
+				allowed = true
+			}
+		}
+
+		if !allowed {
+			return fmt.Errorf("unregistered token")
 		}
+	}
+
+	return checkPartyScope(r, reg)
+}
 
+// checkPartyScope checks that the request only acts on behalf of the parties that use the
+// token. Tokens without parties, such as those registered through the manager API, are not
+// scoped.
+func checkPartyScope(r *http.Request, reg *store.OcpiRegistration) error {
+	if len(reg.Parties) == 0 {
 		return nil
 	}
+	for _, party := range reg.Parties {
+		if party.Role == string(CredentialsRoleRoleHUB) {
+			return nil
+		}
+	}
+
+	countryCode := r.Header.Get("OCPI-from-country-code")
+	partyId := r.Header.Get("OCPI-from-party-id")
+	if countryCode != "" || partyId != "" {
+		if !hasParty(reg, countryCode, partyId) {
+			return fmt.Errorf("token not valid for party %s/%s", countryCode, partyId)
+		}
+	}
+
+	matches := receiverPathRegexp.FindStringSubmatch(r.URL.Path)
+	if matches != nil && !hasParty(reg, matches[1], matches[2]) {
+		return fmt.Errorf("token not valid for party %s/%s", matches[1], matches[2])
+	}
+
+	return nil
+}
+
+func hasParty(reg *store.OcpiRegistration, countryCode, partyId string) bool {
+	for _, party := range reg.Parties {
+		if party.CountryCode == countryCode && party.PartyId == partyId {
+			return true
+		}
+	}
+	return false
 }
@@ -131,3 +131,96 @@ func TestAuthenticationWithUnknownToken(t *testing.T) {
 
 	assert.ErrorContains(t, err, "authorization failed: unknown token")
 }
+
+func TestAuthenticationScopesTokenToRegisteredParties(t *testing.T) {
+	token := "abcdef123456"
+
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetRegistrationDetails(context.Background(), token, &store.OcpiRegistration{
+		Status: store.OcpiRegistrationStatusRegistered,
+		Parties: []store.OcpiPartyRole{
+			{Role: "EMSP", CountryCode: "GB", PartyId: "EMS"},
+		},
+	})
+	require.NoError(t, err)
+
+	authFn := ocpi.NewTokenAuthenticationFunc(engine)
+
+	endpoints := []struct {
+		Name        string
+		Path        string
+		CountryCode string
+		PartyId     string
+		Error       string
+	}{
+		{Name: "sender without headers", Path: "/ocpi/sender/2.2/locations"},
+		{Name: "sender from party", Path: "/ocpi/sender/2.2/locations", CountryCode: "GB", PartyId: "EMS"},
+		{Name: "sender from other party", Path: "/ocpi/sender/2.2/locations", CountryCode: "NL", PartyId: "EMS",
+			Error: "authorization failed: token not valid for party NL/EMS"},
+		{Name: "receiver for party", Path: "/ocpi/receiver/2.2/tokens/GB/EMS/token001"},
+		{Name: "receiver for other party", Path: "/ocpi/receiver/2.2/tokens/GB/OTH/token001",
+			Error: "authorization failed: token not valid for party GB/OTH"},
+		{Name: "2.1.1 tokens for party", Path: "/ocpi/2.1.1/tokens/GB/EMS/token001"},
+		{Name: "2.1.1 tokens for other party", Path: "/ocpi/2.1.1/tokens/GB/OTH/token001",
+			Error: "authorization failed: token not valid for party GB/OTH"},
+	}
+
+	for _, endpoint := range endpoints {
+		t.Run(endpoint.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, endpoint.Path, nil)
+			req.Header.Add("Authorization", fmt.Sprintf("Token %s", token))
+			if endpoint.CountryCode != "" {
+				req.Header.Add("OCPI-from-country-code", endpoint.CountryCode)
+				req.Header.Add("OCPI-from-party-id", endpoint.PartyId)
+			}
+			err = authFn(context.Background(), &openapi3filter.AuthenticationInput{
+				RequestValidationInput: &openapi3filter.RequestValidationInput{
+					Request: req,
+				},
+				SecuritySchemeName: "token",
+				SecurityScheme: &openapi3.SecurityScheme{
+					Type:   "http",
+					Scheme: "bearer",
+				},
+			})
+			if endpoint.Error == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, endpoint.Error)
+			}
+		})
+	}
+}
+
+func TestAuthenticationDoesNotScopeHubToken(t *testing.T) {
+	token := "abcdef123456"
+
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetRegistrationDetails(context.Background(), token, &store.OcpiRegistration{
+		Status: store.OcpiRegistrationStatusRegistered,
+		Parties: []store.OcpiPartyRole{
+			{Role: "HUB", CountryCode: "DE", PartyId: "HUB"},
+		},
+	})
+	require.NoError(t, err)
+
+	authFn := ocpi.NewTokenAuthenticationFunc(engine)
+
+	req := httptest.NewRequest(http.MethodPut, "/ocpi/receiver/2.2/tokens/GB/EMS/token001", nil)
+	req.Header.Add("Authorization", fmt.Sprintf("Token %s", token))
+	req.Header.Add("OCPI-from-country-code", "GB")
+	req.Header.Add("OCPI-from-party-id", "EMS")
+
+	err = authFn(context.Background(), &openapi3filter.AuthenticationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request: req,
+		},
+		SecuritySchemeName: "token",
+		SecurityScheme: &openapi3.SecurityScheme{
+			Type:   "http",
+			Scheme: "bearer",
+		},
+	})
+
+	assert.NoError(t, err)
+}
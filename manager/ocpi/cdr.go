@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"net/http"
+	"time"
+)
+
+// GetCdrs returns the charge detail records that were last updated between dateFrom
+// (inclusive) and dateTo (exclusive), skipping the first offset records and returning at
+// most limit records. The total number of matching records is also returned so that the
+// caller can paginate.
+func (o *OCPI) GetCdrs(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]CDR, int, error) {
+	return listPage(ctx, o.store.ListChargeDetailRecords,
+		func(record *store.ChargeDetailRecord) string { return record.LastUpdated },
+		func(record *store.ChargeDetailRecord) CDR { return newCdr(o.countryCode, o.partyId, record) },
+		dateFrom, dateTo, offset, limit)
+}
+
+// transactionEnded creates the charge detail record for the transaction and sends it to
+// the eMSP that issued the token. A record is only created once: events replayed after
+// the transaction has ended do not change it.
+func (o *OCPI) transactionEnded(ctx context.Context, transaction *store.Transaction, evse evseLocation) error {
+	existing, err := o.store.LookupChargeDetailRecord(ctx, transaction.TransactionId)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+
+	record, err := o.newChargeDetailRecord(ctx, transaction, evse)
+	if err != nil {
+		return err
+	}
+	err = o.store.SetChargeDetailRecord(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	r, err := o.tokenRecipient(ctx, transaction.IdToken)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		// the record can still be pulled
+		return nil
+	}
+
+	cdrsUrl, err := o.getPartyReceiverEndpoint(ctx, r.OcpiParty, "cdrs")
+	if err != nil {
+		return err
+	}
+
+	var body any = newCdr(o.countryCode, o.partyId, record)
+	if uses211(r.OcpiParty) {
+		location, err := o.GetLocation(ctx, evse.locationId)
+		if err != nil {
+			return err
+		}
+		body = newCdr211(newCdr(o.countryCode, o.partyId, record), location)
+	}
+	return o.client.Push(ctx, r.OcpiParty, r.countryCode, r.partyId, http.MethodPost, cdrsUrl, body)
+}
+
+// tokenRecipient returns the recipient for the eMSP that issued the token, if it is known
+func (o *OCPI) tokenRecipient(ctx context.Context, idToken string) (*recipient, error) {
+	if idToken == "" {
+		return nil, nil
+	}
+	tok, err := o.store.LookupToken(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	return o.recipientFor(ctx, tok.CountryCode, tok.PartyId)
+}
+
+// newChargeDetailRecord builds the charge detail record for a completed transaction from
+// the transaction, the location of the charge station and the tariff
+func (o *OCPI) newChargeDetailRecord(ctx context.Context, transaction *store.Transaction, evse evseLocation) (*store.ChargeDetailRecord, error) {
+	contractId, err := o.lookupContractId(ctx, transaction.IdToken)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := transactionTimes(transaction)
+
+	record := &store.ChargeDetailRecord{
+		Id:              transaction.TransactionId,
+		ChargeStationId: transaction.ChargeStationId,
+		TransactionId:   transaction.TransactionId,
+		IdToken:         transaction.IdToken,
+		TokenType:       transaction.TokenType,
+		ContractId:      contractId,
+		LocationId:      evse.locationId,
+		EvseUid:         evse.evseUid,
+		ConnectorId:     evse.connectorId,
+		StartDateTime:   formatTime(start),
+		EndDateTime:     formatTime(end),
+		TotalEnergy:     transactionEnergy(transaction) / 1000,
+		TotalTime:       end.Sub(start).Hours(),
+		Currency:        currency,
+		LastUpdated:     formatTime(end),
+	}
+
+	loc, err := o.store.LookupLocation(ctx, evse.locationId)
+	if err != nil {
+		return nil, err
+	}
+	if loc != nil {
+		record.LocationName = loc.Name
+		record.Address = loc.Address
+		record.City = loc.City
+		record.PostalCode = loc.PostalCode
+		record.Country = loc.Country
+		record.Coordinates = loc.Coordinates
+		if loc.Evses != nil {
+			for _, locEvse := range *loc.Evses {
+				if locEvse.Uid != evse.evseUid {
+					continue
+				}
+				if locEvse.EvseId != nil {
+					record.EvseId = *locEvse.EvseId
+				}
+				for _, connector := range locEvse.Connectors {
+					if connector.Id == evse.connectorId {
+						record.ConnectorFormat = connector.Format
+						record.ConnectorPowerType = connector.PowerType
+						record.ConnectorStandard = connector.Standard
+					}
+				}
+			}
+		}
+	}
+
+	// the cost stored when the transaction ended is used if there is one: transactions
+	// that were not priced when they ended, e.g. OCPP 1.6 transactions, are priced now
+	cost := transaction.Cost
+	if cost == nil && o.tariffService != nil {
+		cost, err = services.CalculateCostBreakdown(o.tariffService, transaction)
+		if err != nil {
+			slog.Warn("unable to calculate cost of charge detail record", slog.String("transactionId", transaction.TransactionId), "err", err)
+		}
+	}
+	if cost != nil {
+		record.TotalCost = cost.TotalCost
+		if cost.Currency != "" {
+			record.Currency = cost.Currency
+		}
+	}
+
+	return record, nil
+}
+
+func newCdr(countryCode, partyId string, record *store.ChargeDetailRecord) CDR {
+	var name *string
+	if record.LocationName != "" {
+		locationName := record.LocationName
+		name = &locationName
+	}
+	sessionId := record.TransactionId
+
+	return CDR{
+		AuthMethod: CDRAuthMethodWHITELIST,
+		CdrLocation: CdrLocation{
+			Address:            record.Address,
+			City:               record.City,
+			ConnectorFormat:    CdrLocationConnectorFormat(record.ConnectorFormat),
+			ConnectorId:        record.ConnectorId,
+			ConnectorPowerType: CdrLocationConnectorPowerType(record.ConnectorPowerType),
+			ConnectorStandard:  CdrLocationConnectorStandard(record.ConnectorStandard),
+			Coordinates: GeoLocation{
+				Latitude:  record.Coordinates.Latitude,
+				Longitude: record.Coordinates.Longitude,
+			},
+			Country:    record.Country,
+			EvseId:     record.EvseId,
+			EvseUid:    record.EvseUid,
+			Id:         record.LocationId,
+			Name:       name,
+			PostalCode: record.PostalCode,
+		},
+		CdrToken: CdrToken{
+			ContractId: record.ContractId,
+			Type:       cdrTokenType(record.TokenType),
+			Uid:        record.IdToken,
+		},
+		ChargingPeriods: []ChargingPeriod{
+			{
+				Dimensions: []CdrDimension{
+					{Type: CdrDimensionTypeENERGY, Volume: float32(record.TotalEnergy)},
+					{Type: CdrDimensionTypeTIME, Volume: float32(record.TotalTime)},
+				},
+				StartDateTime: record.StartDateTime,
+			},
+		},
+		CountryCode:   countryCode,
+		Currency:      record.Currency,
+		EndDateTime:   record.EndDateTime,
+		Id:            record.Id,
+		LastUpdated:   record.LastUpdated,
+		PartyId:       partyId,
+		SessionId:     &sessionId,
+		StartDateTime: record.StartDateTime,
+		// the tariff services do not distinguish VAT
+		TotalCost:   Price{ExclVat: float32(record.TotalCost), InclVat: float32(record.TotalCost)},
+		TotalEnergy: float32(record.TotalEnergy),
+		TotalTime:   float32(record.TotalTime),
+	}
+}
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"net/http"
+	"time"
+)
+
+const (
+	// maxPushAttempts is the number of times a push is attempted before it is dropped
+	maxPushAttempts = 10
+	// pushRetryInterval is the time before a failed push is first retried: it doubles
+	// with each attempt, up to maxPushRetryInterval
+	pushRetryInterval    = 30 * time.Second
+	maxPushRetryInterval = time.Hour
+	// pushPageSize is the number of pushes read from the outbox at a time
+	pushPageSize = 50
+)
+
+// Client pushes objects to other parties. Each push is written to an outbox in the store
+// before it is sent and is only removed once it has been delivered, so a push that fails
+// is retried with exponential backoff, even if the manager restarts in the meantime.
+type Client struct {
+	store       store.OcpiStore
+	httpClient  *http.Client
+	clock       clock.PassiveClock
+	countryCode string
+	partyId     string
+}
+
+func NewClient(store store.OcpiStore, httpClient *http.Client, clock clock.PassiveClock, countryCode, partyId string) *Client {
+	return &Client{
+		store:       store,
+		httpClient:  httpClient,
+		clock:       clock,
+		countryCode: countryCode,
+		partyId:     partyId,
+	}
+}
+
+// Push sends the body to the url of the party, addressed to the party identified by
+// toCountryCode and toPartyId. The token of the party is read from the store each time the
+// push is attempted, so a push that is retried uses the current credentials. An error is
+// only returned if the push cannot be added to the outbox.
+func (c *Client) Push(ctx context.Context, party *store.OcpiParty, toCountryCode, toPartyId, method, url string, body any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	push := &store.OcpiPush{
+		Id:            pushId(party, method, url),
+		Method:        method,
+		Url:           url,
+		Body:          b,
+		Role:          party.Role,
+		CountryCode:   party.CountryCode,
+		PartyId:       party.PartyId,
+		ToCountryCode: toCountryCode,
+		ToPartyId:     toPartyId,
+		CorrelationId: correlationId(ctx),
+		SendAfter:     c.clock.Now(),
+	}
+	err = c.store.SetPendingPush(ctx, push)
+	if err != nil {
+		return err
+	}
+
+	return c.deliver(ctx, push)
+}
+
+// ProcessOutbox attempts to deliver the pushes in the outbox that are due to be retried
+func (c *Client) ProcessOutbox(ctx context.Context) error {
+	var previousId string
+	for {
+		pushes, err := c.store.ListPendingPushes(ctx, pushPageSize, previousId)
+		if err != nil {
+			return err
+		}
+		for _, push := range pushes {
+			if c.clock.Now().Before(push.SendAfter) {
+				continue
+			}
+			err = c.deliver(ctx, push)
+			if err != nil {
+				return err
+			}
+		}
+		if len(pushes) < pushPageSize {
+			return nil
+		}
+		previousId = pushes[len(pushes)-1].Id
+	}
+}
+
+// deliver attempts to send the push. The push is removed from the outbox once it has been
+// delivered or when it will never succeed, otherwise it is rescheduled.
+func (c *Client) deliver(ctx context.Context, push *store.OcpiPush) error {
+	ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer("manager").Start(ctx, "ocpi push",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("ocpi.push.method", push.Method),
+			attribute.String("ocpi.push.url", push.Url),
+			attribute.String("ocpi.push.to", fmt.Sprintf("%s:%s", push.ToCountryCode, push.ToPartyId)),
+			attribute.Int("ocpi.push.attempts", push.Attempts)))
+	defer span.End()
+
+	retry, err := c.send(ctx, push)
+	if err == nil {
+		return c.store.DeletePendingPush(ctx, push.Id)
+	}
+	span.RecordError(err)
+
+	push.Attempts++
+	if !retry || push.Attempts >= maxPushAttempts {
+		slog.Error("dropping ocpi push", slog.String("method", push.Method), slog.String("url", push.Url),
+			slog.Int("attempts", push.Attempts), "err", err)
+		return c.store.DeletePendingPush(ctx, push.Id)
+	}
+
+	push.SendAfter = c.clock.Now().Add(retryInterval(push.Attempts))
+	slog.Warn("ocpi push failed, will retry", slog.String("method", push.Method), slog.String("url", push.Url),
+		slog.Int("attempts", push.Attempts), slog.Time("sendAfter", push.SendAfter), "err", err)
+	return c.store.SetPendingPush(ctx, push)
+}
+
+// send makes the request for the push, reporting whether it is worth retrying if it fails
+func (c *Client) send(ctx context.Context, push *store.OcpiPush) (bool, error) {
+	party, err := c.store.GetPartyDetails(ctx, push.Role, push.CountryCode, push.PartyId)
+	if err != nil {
+		return true, err
+	}
+	if party == nil {
+		return false, fmt.Errorf("unknown party %s:%s", push.CountryCode, push.PartyId)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, push.Method, push.Url, bytes.NewReader(push.Body))
+	if err != nil {
+		return false, err
+	}
+	setHeaders(req, party.Token, c.countryCode, c.partyId, push.ToCountryCode, push.ToPartyId, push.CorrelationId)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return true, errs.Wrap(errs.ErrDependencyUnavailable, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+	return retry, errs.New(errs.ErrDependencyUnavailable, "status code: %d", resp.StatusCode)
+}
+
+// pushId identifies the push in the outbox. A PUT or PATCH replaces the object at the url,
+// so a later push of the same object replaces one that has not yet been delivered rather
+// than being overwritten by it when it is retried.
+func pushId(party *store.OcpiParty, method, url string) string {
+	if method != http.MethodPut && method != http.MethodPatch {
+		return uuid.New().String()
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%s:%s:%s", party.Role, party.CountryCode, party.PartyId, method, url)))
+	return hex.EncodeToString(sum[:])
+}
+
+func retryInterval(attempts int) time.Duration {
+	interval := pushRetryInterval
+	for i := 1; i < attempts && interval < maxPushRetryInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxPushRetryInterval {
+		interval = maxPushRetryInterval
+	}
+	return interval
+}
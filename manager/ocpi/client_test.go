@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"io"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type receivedPush struct {
+	method string
+	path   string
+	token  string
+	body   string
+}
+
+// newPushServer returns a server that records the pushes it receives and responds
+// with the next of the status codes, repeating the last one
+func newPushServer(t *testing.T, statuses ...int) (*httptest.Server, *[]receivedPush) {
+	var received []receivedPush
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = append(received, receivedPush{
+			method: r.Method,
+			path:   r.URL.Path,
+			token:  r.Header.Get("Authorization"),
+			body:   string(b),
+		})
+		status := statuses[len(statuses)-1]
+		if len(received) <= len(statuses) {
+			status = statuses[len(received)-1]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func setupClient(t *testing.T, now time.Time) (*ocpi.Client, store.Engine, *fakeclock.FakePassiveClock, *store.OcpiParty) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	party := &store.OcpiParty{
+		Role:        "EMSP",
+		CountryCode: "GB",
+		PartyId:     "TWE",
+		Token:       "token001",
+	}
+	require.NoError(t, engine.SetPartyDetails(context.Background(), party))
+	fakeClock := fakeclock.NewFakePassiveClock(now)
+	return ocpi.NewClient(engine, http.DefaultClient, fakeClock, "GB", "TWK"), engine, fakeClock, party
+}
+
+func listPendingPushes(t *testing.T, engine store.Engine) []*store.OcpiPush {
+	pushes, err := engine.ListPendingPushes(context.Background(), 10, "")
+	require.NoError(t, err)
+	return pushes
+}
+
+func TestClientPushDeliversImmediately(t *testing.T) {
+	server, received := newPushServer(t, http.StatusOK)
+	client, engine, _, party := setupClient(t, time.Now())
+
+	err := client.Push(context.Background(), party, "GB", "TWE", http.MethodPut,
+		server.URL+"/sessions/GB/TWK/s001", map[string]string{"id": "s001"})
+	require.NoError(t, err)
+
+	require.Len(t, *received, 1)
+	assert.Equal(t, receivedPush{
+		method: http.MethodPut,
+		path:   "/sessions/GB/TWK/s001",
+		token:  "Token token001",
+		body:   `{"id":"s001"}`,
+	}, (*received)[0])
+	assert.Empty(t, listPendingPushes(t, engine))
+}
+
+func TestClientRetriesFailedPushWithBackoff(t *testing.T) {
+	server, received := newPushServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+	now := time.Now()
+	client, engine, fakeClock, party := setupClient(t, now)
+	ctx := context.Background()
+
+	err := client.Push(ctx, party, "GB", "TWE", http.MethodPost, server.URL+"/cdrs", map[string]string{"id": "cdr001"})
+	require.NoError(t, err)
+
+	pushes := listPendingPushes(t, engine)
+	require.Len(t, pushes, 1)
+	assert.Equal(t, 1, pushes[0].Attempts)
+	assert.Equal(t, now.Add(30*time.Second).Unix(), pushes[0].SendAfter.Unix())
+
+	// not yet due
+	require.NoError(t, client.ProcessOutbox(ctx))
+	assert.Len(t, *received, 1)
+
+	fakeClock.SetTime(now.Add(30 * time.Second))
+	require.NoError(t, client.ProcessOutbox(ctx))
+	assert.Len(t, *received, 2)
+
+	pushes = listPendingPushes(t, engine)
+	require.Len(t, pushes, 1)
+	assert.Equal(t, 2, pushes[0].Attempts)
+	assert.Equal(t, now.Add(90*time.Second).Unix(), pushes[0].SendAfter.Unix())
+
+	// the credentials are rotated before the push is retried
+	party.Token = "token002"
+	require.NoError(t, engine.SetPartyDetails(ctx, party))
+
+	fakeClock.SetTime(now.Add(90 * time.Second))
+	require.NoError(t, client.ProcessOutbox(ctx))
+	require.Len(t, *received, 3)
+	assert.Equal(t, "Token token002", (*received)[2].token)
+	assert.Equal(t, `{"id":"cdr001"}`, (*received)[2].body)
+	assert.Empty(t, listPendingPushes(t, engine))
+}
+
+func TestClientDropsPushRejectedByParty(t *testing.T) {
+	server, received := newPushServer(t, http.StatusBadRequest)
+	client, engine, _, party := setupClient(t, time.Now())
+
+	err := client.Push(context.Background(), party, "GB", "TWE", http.MethodPost, server.URL+"/cdrs", map[string]string{"id": "cdr001"})
+	require.NoError(t, err)
+
+	assert.Len(t, *received, 1)
+	assert.Empty(t, listPendingPushes(t, engine))
+}
+
+func TestClientReplacesUndeliveredPutOfSameObject(t *testing.T) {
+	server, received := newPushServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+	now := time.Now()
+	client, engine, fakeClock, party := setupClient(t, now)
+	ctx := context.Background()
+
+	for _, status := range []string{"ACTIVE", "COMPLETED"} {
+		err := client.Push(ctx, party, "GB", "TWE", http.MethodPut,
+			server.URL+"/sessions/GB/TWK/s001", map[string]string{"status": status})
+		require.NoError(t, err)
+	}
+
+	pushes := listPendingPushes(t, engine)
+	require.Len(t, pushes, 1)
+	assert.Equal(t, `{"status":"COMPLETED"}`, string(pushes[0].Body))
+
+	fakeClock.SetTime(now.Add(time.Minute))
+	require.NoError(t, client.ProcessOutbox(ctx))
+	require.Len(t, *received, 3)
+	assert.Equal(t, `{"status":"COMPLETED"}`, (*received)[2].body)
+	assert.Empty(t, listPendingPushes(t, engine))
+}
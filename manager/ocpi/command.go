@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+)
+
+// commandTimeout is the number of seconds an eMSP is told to wait for the result of a
+// command
+const commandTimeout = 30
+
+// the OCPI command types
+const (
+	commandUnlockConnector = "UNLOCK_CONNECTOR"
+)
+
+// SetPendingCommand records that the command has been sent to the charge station so that
+// the result can be sent to the party that requested it
+func (o *OCPI) SetPendingCommand(ctx context.Context, command *store.OcpiCommand) error {
+	return o.store.SetPendingCommand(ctx, command)
+}
+
+// CommandResult sends the result of a command to the party that requested it. Results for
+// calls that were not requested through OCPI are ignored.
+func (o *OCPI) CommandResult(ctx context.Context, chargeStationId, action, reference, status string) error {
+	var commandType string
+	var result CommandResultResult
+	switch action {
+	case "UnlockConnector":
+		commandType = commandUnlockConnector
+		result = unlockConnectorResult(status)
+	default:
+		return nil
+	}
+
+	command, err := o.store.LookupPendingCommand(ctx, commandType, chargeStationId, reference)
+	if err != nil {
+		return err
+	}
+	if command == nil {
+		return nil
+	}
+
+	r, err := o.recipientFor(ctx, command.CountryCode, command.PartyId)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return fmt.Errorf("unknown party %s:%s for %s command", command.CountryCode, command.PartyId, commandType)
+	}
+
+	err = o.client.Push(ctx, r.OcpiParty, r.countryCode, r.partyId, http.MethodPost, command.ResponseUrl,
+		CommandResult{Result: result})
+	if err != nil {
+		return err
+	}
+
+	return o.store.DeletePendingCommand(ctx, command.Command, command.ChargeStationId, command.Reference)
+}
+
+// unlockConnectorResult maps the status of an OCPP 1.6 UnlockConnector response to the
+// OCPI command result
+func unlockConnectorResult(status string) CommandResultResult {
+	switch status {
+	case "Unlocked":
+		return CommandResultResultACCEPTED
+	case "NotSupported":
+		return CommandResultResultNOTSUPPORTED
+	default:
+		return CommandResultResultFAILED
+	}
+}
@@ -1,4 +1,6 @@
 // SPDX-License-Identifier: Apache-2.0
 
-// Package ocpi implements the OCPI API for interacting with eMSPs (MOs)
+// Package ocpi implements the OCPI API for interacting with eMSPs (MOs). Versions 2.2 and
+// 2.2.1 are implemented from the OpenAPI specification; eMSPs that still use 2.1.1 are
+// supported by converting objects to and from the 2.1.1 format.
 package ocpi
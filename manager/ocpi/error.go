@@ -30,6 +30,15 @@ func ErrInvalidRequest(err error) render.Renderer {
 	}
 }
 
+func ErrMethodNotAllowed(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusMethodNotAllowed,
+		StatusText:     http.StatusText(http.StatusMethodNotAllowed),
+		ErrorText:      err.Error(),
+	}
+}
+
 func ErrInternalError(err error) render.Renderer {
 	return &ErrResponse{
 		Err:            err,
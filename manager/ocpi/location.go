@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"time"
+)
+
+// GetLocations returns the locations that were last updated between dateFrom (inclusive)
+// and dateTo (exclusive), skipping the first offset locations and returning at most limit
+// locations. A zero dateFrom or dateTo is unbounded. The total number of matching
+// locations is also returned so that the caller can paginate.
+func (o *OCPI) GetLocations(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]Location, int, error) {
+	return listPage(ctx, o.store.ListLocations,
+		func(loc *store.Location) string { return loc.LastUpdated },
+		o.newLocation,
+		dateFrom, dateTo, offset, limit)
+}
+
+func (o *OCPI) GetLocation(ctx context.Context, locationId string) (*Location, error) {
+	loc, err := o.store.LookupLocation(ctx, locationId)
+	if err != nil {
+		return nil, err
+	}
+	if loc == nil {
+		return nil, nil
+	}
+	location := o.newLocation(loc)
+	return &location, nil
+}
+
+// ConnectorStatusChanged recalculates the status of the EVSEs that belong to the charge
+// station and pushes any EVSE whose status has changed to the eMSPs.
+func (o *OCPI) ConnectorStatusChanged(ctx context.Context, status *store.ConnectorStatus) error {
+	statuses, err := o.store.ListConnectorStatuses(ctx, status.ChargeStationId)
+	if err != nil {
+		return err
+	}
+	evseStatus := string(evseStatusFromConnectors(statuses))
+	lastUpdated := status.LastUpdated.UTC().Format(time.RFC3339)
+
+	locations, err := o.listAllLocations(ctx)
+	if err != nil {
+		return err
+	}
+
+	type evseRef struct {
+		locationId string
+		evseUid    string
+	}
+	var changed []evseRef
+	for _, loc := range locations {
+		if loc.Evses == nil {
+			continue
+		}
+		locationChanged := false
+		for i, evse := range *loc.Evses {
+			chargeStationId, err := extractChargeStationId(evse.Uid)
+			if err != nil || chargeStationId != status.ChargeStationId || evse.Status == evseStatus {
+				continue
+			}
+			(*loc.Evses)[i].Status = evseStatus
+			(*loc.Evses)[i].LastUpdated = lastUpdated
+			changed = append(changed, evseRef{locationId: loc.Id, evseUid: evse.Uid})
+			locationChanged = true
+		}
+		if locationChanged {
+			loc.LastUpdated = lastUpdated
+			err = o.store.SetLocation(ctx, loc)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	recipients, err := o.broadcastRecipients(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range recipients {
+		locationsUrl, err := o.getPartyReceiverUrl(ctx, r.OcpiParty, "locations")
+		if err != nil {
+			return err
+		}
+		for _, ref := range changed {
+			err = o.client.Push(ctx, r.OcpiParty, r.countryCode, r.partyId, http.MethodPatch,
+				fmt.Sprintf("%s/%s/%s", locationsUrl, ref.locationId, ref.evseUid), map[string]any{
+					"status":       EvseStatus(evseStatus),
+					"last_updated": lastUpdated,
+				})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o *OCPI) listAllLocations(ctx context.Context) ([]*store.Location, error) {
+	return listAll(ctx, o.store.ListLocations)
+}
+
+// evseStatusFromConnectors determines the status of an OCPI EVSE from the OCPP status of
+// its connectors: the EVSE takes the most useful status of any of its connectors, so an
+// EVSE is available if any connector is available. Connector 0 in OCPP 1.6 refers to the
+// charge station as a whole and is ignored.
+func evseStatusFromConnectors(statuses []*store.ConnectorStatus) EvseStatus {
+	priority := []EvseStatus{
+		EvseStatusCHARGING,
+		EvseStatusRESERVED,
+		EvseStatusAVAILABLE,
+		EvseStatusINOPERATIVE,
+		EvseStatusOUTOFORDER,
+	}
+
+	found := make(map[EvseStatus]bool)
+	for _, status := range statuses {
+		if status.ConnectorId == 0 {
+			continue
+		}
+		switch status.Status {
+		case "Charging", "Occupied", "Preparing", "SuspendedEV", "SuspendedEVSE", "Finishing":
+			found[EvseStatusCHARGING] = true
+		case "Reserved":
+			found[EvseStatusRESERVED] = true
+		case "Available":
+			found[EvseStatusAVAILABLE] = true
+		case "Unavailable":
+			found[EvseStatusINOPERATIVE] = true
+		case "Faulted":
+			found[EvseStatusOUTOFORDER] = true
+		}
+	}
+
+	for _, status := range priority {
+		if found[status] {
+			return status
+		}
+	}
+	return EvseStatusUNKNOWN
+}
+
+func (o *OCPI) newLocation(loc *store.Location) Location {
+	location := Location{
+		Address: loc.Address,
+		City:    loc.City,
+		Coordinates: GeoLocation{
+			Latitude:  loc.Coordinates.Latitude,
+			Longitude: loc.Coordinates.Longitude,
+		},
+		Country:     loc.Country,
+		CountryCode: o.countryCode,
+		Id:          loc.Id,
+		LastUpdated: loc.LastUpdated,
+		PartyId:     o.partyId,
+		Publish:     true,
+	}
+	if loc.Name != "" {
+		name := loc.Name
+		location.Name = &name
+	}
+	if loc.ParkingType != "" {
+		parkingType := LocationParkingType(loc.ParkingType)
+		location.ParkingType = &parkingType
+	}
+	if loc.PostalCode != "" {
+		postalCode := loc.PostalCode
+		location.PostalCode = &postalCode
+	}
+	if loc.Evses != nil {
+		evses := make([]Evse, len(*loc.Evses))
+		for i, evse := range *loc.Evses {
+			evses[i] = newEvse(evse)
+		}
+		location.Evses = &evses
+	}
+	return location
+}
+
+func newEvse(evse store.Evse) Evse {
+	connectors := make([]Connector, len(evse.Connectors))
+	for i, connector := range evse.Connectors {
+		connectors[i] = Connector{
+			Format:      ConnectorFormat(connector.Format),
+			Id:          connector.Id,
+			LastUpdated: connector.LastUpdated,
+			MaxAmperage: connector.MaxAmperage,
+			MaxVoltage:  connector.MaxVoltage,
+			PowerType:   ConnectorPowerType(connector.PowerType),
+			Standard:    ConnectorStandard(connector.Standard),
+		}
+	}
+	status := EvseStatus(evse.Status)
+	if status == "" {
+		status = EvseStatusUNKNOWN
+	}
+	return Evse{
+		Connectors:  connectors,
+		EvseId:      evse.EvseId,
+		LastUpdated: evse.LastUpdated,
+		Status:      status,
+		Uid:         evse.Uid,
+	}
+}
@@ -3,14 +3,15 @@
 package ocpi
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
 	"net/http"
+	"strings"
+	"time"
 )
 
 //go:generate oapi-codegen -config cfg.yaml ocpi22-spec.yaml
@@ -20,18 +21,39 @@ type Api interface {
 	RegisterNewParty(ctx context.Context, url, token string) error
 	GetVersions(ctx context.Context) ([]Version, error)
 	GetVersion(ctx context.Context) (VersionDetail, error)
-	SetCredentials(ctx context.Context, token string, credentials Credentials) error
+	GetVersion211(ctx context.Context) (VersionDetail211, error)
+	SetCredentials(ctx context.Context, token string, credentials Credentials) (Credentials, error)
+	SetCredentials211(ctx context.Context, token string, credentials Credentials211) (Credentials211, error)
+	UpdateCredentials(ctx context.Context, token string, credentials Credentials) (Credentials, error)
+	UpdateCredentials211(ctx context.Context, token string, credentials Credentials211) (Credentials211, error)
+	GetCredentials(ctx context.Context, token string) (Credentials, error)
+	DeleteCredentials(ctx context.Context, token string) error
 	SetToken(ctx context.Context, token Token) error
 	GetToken(ctx context.Context, countryCode string, partyID string, tokenUID string) (*Token, error)
 	PushLocation(ctx context.Context, location Location) error
+	GetLocations(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]Location, int, error)
+	GetLocation(ctx context.Context, locationId string) (*Location, error)
+	ConnectorStatusChanged(ctx context.Context, status *store.ConnectorStatus) error
+	GetSessions(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]Session, int, error)
+	GetCdrs(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]CDR, int, error)
+	TransactionChanged(ctx context.Context, transaction *store.Transaction) error
+	GetTariffs(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]Tariff, int, error)
+	GetTokens(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]Token, int, error)
+	PushTariff(ctx context.Context, tariff *store.Tariff) error
+	AuthorizeToken(ctx context.Context, idToken, tokenType string) (*store.Token, error)
+	SetPendingCommand(ctx context.Context, command *store.OcpiCommand) error
+	CommandResult(ctx context.Context, chargeStationId, action, reference, status string) error
+	ProcessOutbox(ctx context.Context) error
 }
 
 type OCPI struct {
-	store       store.Engine
-	httpClient  *http.Client
-	externalUrl string
-	countryCode string
-	partyId     string
+	store         store.Engine
+	httpClient    *http.Client
+	externalUrl   string
+	countryCode   string
+	partyId       string
+	tariffService services.TariffService
+	client        *Client
 }
 
 func NewOCPI(store store.Engine, httpClient *http.Client, countryCode, partyId string) *OCPI {
@@ -40,6 +62,7 @@ func NewOCPI(store store.Engine, httpClient *http.Client, countryCode, partyId s
 		httpClient:  httpClient,
 		countryCode: countryCode,
 		partyId:     partyId,
+		client:      NewClient(store, httpClient, clock.RealClock{}, countryCode, partyId),
 	}
 }
 
@@ -47,11 +70,32 @@ func (o *OCPI) SetExternalUrl(externalUrl string) {
 	o.externalUrl = externalUrl
 }
 
+// SetTariffService sets the service used to calculate the cost of the charge detail
+// records: records have no cost if it is not set
+func (o *OCPI) SetTariffService(tariffService services.TariffService) {
+	o.tariffService = tariffService
+}
+
+// ProcessOutbox retries the pushes to other parties that have not yet been delivered
+func (o *OCPI) ProcessOutbox(ctx context.Context) error {
+	return o.client.ProcessOutbox(ctx)
+}
+
+// GetVersions returns the versions supported by this CSMS: 2.2.1 has the same endpoints as
+// 2.2, which is still offered for parties that have not upgraded
 func (o *OCPI) GetVersions(context.Context) ([]Version, error) {
 	return []Version{
+		{
+			Url:     fmt.Sprintf("%s/ocpi/2.1.1", o.externalUrl),
+			Version: Version211,
+		},
 		{
 			Url:     fmt.Sprintf("%s/ocpi/2.2", o.externalUrl),
-			Version: "2.2",
+			Version: Version22,
+		},
+		{
+			Url:     fmt.Sprintf("%s/ocpi/2.2.1", o.externalUrl),
+			Version: Version221,
 		},
 	}, nil
 }
@@ -74,103 +118,64 @@ func (o *OCPI) GetVersion(context.Context) (VersionDetail, error) {
 				Role:       RECEIVER,
 				Url:        fmt.Sprintf("%s/ocpi/receiver/2.2/tokens/", o.externalUrl),
 			},
+			{
+				Identifier: "locations",
+				Role:       SENDER,
+				Url:        fmt.Sprintf("%s/ocpi/sender/2.2/locations", o.externalUrl),
+			},
+			{
+				Identifier: "sessions",
+				Role:       SENDER,
+				Url:        fmt.Sprintf("%s/ocpi/sender/2.2/sessions", o.externalUrl),
+			},
+			{
+				Identifier: "cdrs",
+				Role:       SENDER,
+				Url:        fmt.Sprintf("%s/ocpi/sender/2.2/cdrs", o.externalUrl),
+			},
+			{
+				Identifier: "tariffs",
+				Role:       SENDER,
+				Url:        fmt.Sprintf("%s/ocpi/sender/2.2/tariffs", o.externalUrl),
+			},
 		},
-		Version: "2.2",
+		Version: Version22,
 	}, nil
 }
 
-func (o *OCPI) SetCredentials(ctx context.Context, token string, credentials Credentials) error {
-	for _, role := range credentials.Roles {
-		err := o.store.SetPartyDetails(ctx, &store.OcpiParty{
-			Role:        string(role.Role),
-			CountryCode: role.CountryCode,
-			PartyId:     role.PartyId,
-			Url:         credentials.Url,
-			Token:       credentials.Token,
-		})
-		if err != nil {
-			return err
-		}
-	}
-
-	reg, err := o.store.GetRegistrationDetails(ctx, token)
-	if err != nil {
-		return err
-	}
-
-	if reg != nil && reg.Status == store.OcpiRegistrationStatusPending {
-		// register new party
-		err = o.RegisterNewParty(ctx, credentials.Url, credentials.Token)
-		if err != nil {
-			return err
-		}
-		// delete old token
-		err := o.store.DeleteRegistrationDetails(ctx, token)
-		if err != nil {
-			return err
-		}
-	}
-
-	// store new token
-	err = o.store.SetRegistrationDetails(ctx, credentials.Token, &store.OcpiRegistration{
-		Status: store.OcpiRegistrationStatusRegistered,
-	})
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (o *OCPI) GetToken(ctx context.Context, countryCode string, partyID string, tokenUID string) (*Token, error) {
-	tok, err := o.store.LookupToken(ctx, tokenUID)
-	if err != nil {
-		return nil, err
-	}
-	if tok.CountryCode != countryCode || tok.PartyId != partyID {
-		return nil, nil
-	}
-	return &Token{
-		ContractId:   tok.ContractId,
-		CountryCode:  tok.CountryCode,
-		GroupId:      tok.GroupId,
-		Issuer:       tok.Issuer,
-		Language:     tok.LanguageCode,
-		LastUpdated:  tok.LastUpdated,
-		PartyId:      tok.PartyId,
-		Type:         TokenType(tok.Type),
-		Uid:          tok.Uid,
-		Valid:        tok.Valid,
-		VisualNumber: tok.VisualNumber,
-		Whitelist:    TokenWhitelist(tok.CacheMode),
+// GetVersion211 returns the endpoints for version 2.1.1, which has no roles: the endpoints
+// are the CPO interfaces of the modules
+func (o *OCPI) GetVersion211(context.Context) (VersionDetail211, error) {
+	return VersionDetail211{
+		Endpoints: []Endpoint211{
+			{
+				Identifier: "credentials",
+				Url:        fmt.Sprintf("%s/ocpi/2.1.1/credentials", o.externalUrl),
+			},
+			{
+				Identifier: "tokens",
+				Url:        fmt.Sprintf("%s/ocpi/2.1.1/tokens", o.externalUrl),
+			},
+			{
+				Identifier: "sessions",
+				Url:        fmt.Sprintf("%s/ocpi/2.1.1/sessions", o.externalUrl),
+			},
+			{
+				Identifier: "cdrs",
+				Url:        fmt.Sprintf("%s/ocpi/2.1.1/cdrs", o.externalUrl),
+			},
+		},
+		Version: Version211,
 	}, nil
 }
 
-func (o *OCPI) SetToken(ctx context.Context, token Token) error {
-	tok := &store.Token{
-		CountryCode:  token.CountryCode,
-		PartyId:      token.PartyId,
-		Type:         string(token.Type),
-		Uid:          token.Uid,
-		ContractId:   token.ContractId,
-		VisualNumber: token.VisualNumber,
-		Issuer:       token.Issuer,
-		GroupId:      token.GroupId,
-		Valid:        token.Valid,
-		LanguageCode: token.Language,
-		CacheMode:    string(token.Whitelist),
-	}
-
-	return o.store.SetToken(ctx, tok)
-}
-
 func (o *OCPI) PushLocation(ctx context.Context, location Location) error {
-	parties, err := o.store.ListPartyDetailsForRole(ctx, "EMSP")
+	recipients, err := o.broadcastRecipients(ctx)
 	if err != nil {
 		return err
 	}
-	for _, party := range parties {
-		err = o.pushLocationToParty(ctx, party, location)
+	for _, r := range recipients {
+		err = o.pushLocationToParty(ctx, r, location)
 		if err != nil {
 			return err
 		}
@@ -179,29 +184,18 @@ func (o *OCPI) PushLocation(ctx context.Context, location Location) error {
 	return nil
 }
 
-func (o *OCPI) pushLocationToParty(ctx context.Context, party *store.OcpiParty, location Location) error {
-	// TODO: retrieve endpoints from store, not via OCPI exchange
-	versions, err := o.getVersions(ctx, party.Url, party.Token)
+func (o *OCPI) pushLocationToParty(ctx context.Context, r recipient, location Location) error {
+	locationsUrl, err := o.getPartyReceiverUrl(ctx, r.OcpiParty, "locations")
 	if err != nil {
 		return err
 	}
 
-	endpointUrl, err := getEndpointUrl(versions)
-	if err != nil {
-		return err
-	}
-
-	endpoints, err := o.getEndpoints(ctx, endpointUrl, party.Token)
-	if err != nil {
-		return err
+	var body any = location
+	if uses211(r.OcpiParty) {
+		body = newLocation211(location)
 	}
-
-	locationsUrl, err := o.getLocationsUrl(endpoints)
-	if err != nil {
-		return err
-	}
-
-	err = o.putLocation(ctx, locationsUrl, party.CountryCode, party.PartyId, party.Token, location)
+	err = o.client.Push(ctx, r.OcpiParty, r.countryCode, r.partyId, http.MethodPut,
+		fmt.Sprintf("%s/%s", locationsUrl, location.Id), body)
 	if err != nil {
 		return err
 	}
@@ -209,53 +203,69 @@ func (o *OCPI) pushLocationToParty(ctx context.Context, party *store.OcpiParty,
 	return nil
 }
 
-func (o *OCPI) getLocationsUrl(endpoints []Endpoint) (string, error) {
-	for _, endpoint := range endpoints {
-		if endpoint.Identifier == "locations" && endpoint.Role == RECEIVER {
-			return fmt.Sprintf("%s/%s/%s", endpoint.Url, o.countryCode, o.partyId), nil
-		}
+// getPartyReceiverUrl returns the URL of the party's receiver interface for the module
+// with objects owned by this party
+func (o *OCPI) getPartyReceiverUrl(ctx context.Context, party *store.OcpiParty, module string) (string, error) {
+	endpointUrl, err := o.getPartyReceiverEndpoint(ctx, party, module)
+	if err != nil {
+		return "", err
 	}
-	return "", errors.New("no locations endpoint for receiver found")
+	return fmt.Sprintf("%s/%s/%s", endpointUrl, o.countryCode, o.partyId), nil
 }
 
-func (o *OCPI) putLocation(ctx context.Context, url string, toCountryCode string, toPartyId string, token string, location Location) error {
-	b, err := json.Marshal(location)
-	if err != nil {
-		return err
-	}
+// getPartyReceiverEndpoint returns the URL of the party's receiver interface for the
+// module
+func (o *OCPI) getPartyReceiverEndpoint(ctx context.Context, party *store.OcpiParty, module string) (string, error) {
+	return o.getPartyEndpoint(ctx, party, module, RECEIVER)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/%s", url, location.Id), bytes.NewReader(b))
-	if err != nil {
-		return err
+// getPartyEndpoint returns the URL of the party's interface for the module in the given
+// role. The endpoints discovered when the credentials were exchanged are used if they are
+// known.
+func (o *OCPI) getPartyEndpoint(ctx context.Context, party *store.OcpiParty, module string, role EndpointRole) (string, error) {
+	if len(party.Endpoints) > 0 {
+		for _, endpoint := range party.Endpoints {
+			if endpoint.Identifier == module && endpoint.Role == string(role) {
+				return endpoint.Url, nil
+			}
+		}
+		return "", fmt.Errorf("no %s endpoint for %s found", module, strings.ToLower(string(role)))
 	}
-	o.setRequestHeaders(ctx, req, token, toCountryCode, toPartyId)
 
-	resp, err := o.httpClient.Do(req)
+	_, endpoints, err := o.discoverEndpoints(ctx, party.Url, party.Token, []string{partyVersion(party)})
 	if err != nil {
-		return err
+		return "", err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
 
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("status code: %d", resp.StatusCode)
+	for _, endpoint := range endpoints {
+		if endpoint.Identifier == module && endpoint.Role == role {
+			return endpoint.Url, nil
+		}
 	}
-
-	return nil
+	return "", fmt.Errorf("no %s endpoint for %s found", module, strings.ToLower(string(role)))
 }
 
 func (o *OCPI) setRequestHeaders(ctx context.Context, req *http.Request, token string, toCountryCode string, toPartyId string) {
+	setHeaders(req, token, o.countryCode, o.partyId, toCountryCode, toPartyId, correlationId(ctx))
+}
+
+func setHeaders(req *http.Request, token, fromCountryCode, fromPartyId, toCountryCode, toPartyId, correlationId string) {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Token %s", token))
 	req.Header.Set("X-Request-ID", uuid.New().String())
+	req.Header.Set("X-Correlation-ID", correlationId)
+	req.Header.Set("OCPI-from-country-code", fromCountryCode)
+	req.Header.Set("OCPI-from-party-id", fromPartyId)
+	req.Header.Set("OCPI-to-country-code", toCountryCode)
+	req.Header.Set("OCPI-to-party-id", toPartyId)
+}
+
+// correlationId returns the correlation id of the request being handled, or a new one if
+// there isn't one
+func correlationId(ctx context.Context) string {
 	value, ok := ctx.Value(ContextKeyCorrelationId).(string)
 	if !ok {
-		value = uuid.New().String()
+		return uuid.New().String()
 	}
-	req.Header.Set("X-Correlation-ID", value)
-	req.Header.Set("OCPI-from-country-code", o.countryCode)
-	req.Header.Set("OCPI-from-party-id", o.partyId)
-	req.Header.Set("OCPI-to-country-code", toCountryCode)
-	req.Header.Set("OCPI-to-party-id", toPartyId)
+	return value
 }
@@ -4,16 +4,20 @@ package ocpi_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"k8s.io/utils/clock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetVersions(t *testing.T) {
@@ -21,10 +25,18 @@ func TestGetVersions(t *testing.T) {
 	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
 
 	want := []ocpi.Version{
+		{
+			Version: "2.1.1",
+			Url:     "/ocpi/2.1.1",
+		},
 		{
 			Version: "2.2",
 			Url:     "/ocpi/2.2",
 		},
+		{
+			Version: "2.2.1",
+			Url:     "/ocpi/2.2.1",
+		},
 	}
 
 	got, err := ocpiApi.GetVersions(context.Background())
@@ -54,6 +66,26 @@ func TestGetVersionDetails(t *testing.T) {
 				Role:       ocpi.RECEIVER,
 				Url:        "/ocpi/receiver/2.2/tokens/",
 			},
+			{
+				Identifier: "locations",
+				Role:       ocpi.SENDER,
+				Url:        "/ocpi/sender/2.2/locations",
+			},
+			{
+				Identifier: "sessions",
+				Role:       ocpi.SENDER,
+				Url:        "/ocpi/sender/2.2/sessions",
+			},
+			{
+				Identifier: "cdrs",
+				Role:       ocpi.SENDER,
+				Url:        "/ocpi/sender/2.2/cdrs",
+			},
+			{
+				Identifier: "tariffs",
+				Role:       ocpi.SENDER,
+				Url:        "/ocpi/sender/2.2/tariffs",
+			},
 		},
 	}
 
@@ -121,6 +153,7 @@ func TestSetToken(t *testing.T) {
 		Issuer:      "Thoughtworks",
 		Valid:       true,
 		CacheMode:   "ALWAYS",
+		Version:     1,
 	}
 
 	got, err := engine.LookupToken(context.Background(), "DEADBEEF")
@@ -129,6 +162,170 @@ func TestSetToken(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestSetTokenNormalizesEmaidContractId(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+
+	err := ocpiApi.SetToken(context.Background(), ocpi.Token{
+		ContractId:  "gb-twk-012345678",
+		CountryCode: "GB",
+		Issuer:      "Thoughtworks",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "DEADBEEF",
+		Valid:       true,
+		Whitelist:   "ALWAYS",
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+	assert.Equal(t, "GBTWK012345678", got.ContractId)
+}
+
+func TestAuthorizeToken(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+
+	mux := http.NewServeMux()
+	emspServer := httptest.NewServer(mux)
+	defer emspServer.Close()
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data":[{"version":"2.2","url":"%s/ocpi/2.2"}], "status_code":1000}`, emspServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/2.2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data":{
+				"version":"2.2",
+				"endpoints":[{"identifier":"tokens","role":"SENDER","url":"%s/ocpi/sender/2.2/tokens"}]},
+				"status_code":1000}`,
+			emspServer.URL)))
+	})
+	authorizeHandler := func(allowed ocpi.AuthorizationInfoAllowed) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "RFID", r.URL.Query().Get("type"))
+			assert.Equal(t, "Token some-token-456", r.Header.Get("Authorization"))
+			assert.Equal(t, "GB", r.Header.Get("OCPI-to-country-code"))
+			assert.Equal(t, "TWE", r.Header.Get("OCPI-to-party-id"))
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(ocpi.OcpiResponseAuthorizationInfo{
+				StatusCode: ocpi.StatusSuccess,
+				Data: &ocpi.AuthorizationInfo{
+					Allowed: allowed,
+					Token: ocpi.Token{
+						ContractId:  "GBTWE012345678",
+						CountryCode: "GB",
+						Issuer:      "Thoughtworks",
+						PartyId:     "TWE",
+						Type:        ocpi.TokenTypeRFID,
+						Uid:         strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/ocpi/sender/2.2/tokens/"), "/authorize"),
+						Valid:       true,
+						Whitelist:   ocpi.ALLOWED,
+					},
+				},
+			})
+		}
+	}
+	mux.HandleFunc("/ocpi/sender/2.2/tokens/DEADBEEF/authorize", authorizeHandler(ocpi.AuthorizationInfoAllowedALLOWED))
+	mux.HandleFunc("/ocpi/sender/2.2/tokens/BADC0FFEE/authorize", authorizeHandler(ocpi.AuthorizationInfoAllowedBLOCKED))
+	mux.HandleFunc("/ocpi/sender/2.2/tokens/UNKNOWN/authorize", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"status_code":2004,"status_message":"Unknown token"}`))
+	})
+
+	_, err := ocpiApi.SetCredentials(context.Background(), "some-token-123", ocpi.Credentials{
+		Roles: []ocpi.CredentialsRole{
+			{
+				CountryCode: "GB",
+				PartyId:     "TWE",
+				Role:        ocpi.CredentialsRoleRoleEMSP,
+			},
+		},
+		Token: "some-token-456",
+		Url:   emspServer.URL + "/ocpi/versions",
+	})
+	require.NoError(t, err)
+
+	tok, err := ocpiApi.AuthorizeToken(context.Background(), "DEADBEEF", "ISO14443")
+	require.NoError(t, err)
+	require.NotNil(t, tok)
+	assert.Equal(t, "DEADBEEF", tok.Uid)
+	assert.Equal(t, "GBTWE012345678", tok.ContractId)
+	assert.True(t, tok.Valid)
+
+	tok, err = ocpiApi.AuthorizeToken(context.Background(), "BADC0FFEE", "")
+	require.NoError(t, err)
+	require.NotNil(t, tok)
+	assert.False(t, tok.Valid)
+
+	tok, err = ocpiApi.AuthorizeToken(context.Background(), "UNKNOWN", "ISO14443")
+	require.NoError(t, err)
+	assert.Nil(t, tok)
+
+	// real-time authorizations are not stored
+	stored, err := engine.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestCommandResult(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+
+	var results []ocpi.CommandResult
+	mux := http.NewServeMux()
+	emspServer := httptest.NewServer(mux)
+	defer emspServer.Close()
+	mux.HandleFunc("/ocpi/emsp/2.2/commands/UNLOCK_CONNECTOR/12345", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Token some-token-456", r.Header.Get("Authorization"))
+		var result ocpi.CommandResult
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&result))
+		results = append(results, result)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	err := engine.SetPartyDetails(context.Background(), &store.OcpiParty{
+		Role:        "EMSP",
+		CountryCode: "GB",
+		PartyId:     "TWE",
+		Url:         emspServer.URL + "/ocpi/versions",
+		Token:       "some-token-456",
+	})
+	require.NoError(t, err)
+
+	tests := map[string]ocpi.CommandResultResult{
+		"Unlocked":     ocpi.CommandResultResultACCEPTED,
+		"UnlockFailed": ocpi.CommandResultResultFAILED,
+		"NotSupported": ocpi.CommandResultResultNOTSUPPORTED,
+	}
+	for status, want := range tests {
+		t.Run(status, func(t *testing.T) {
+			results = nil
+			err := ocpiApi.SetPendingCommand(context.Background(), &store.OcpiCommand{
+				Command:         "UNLOCK_CONNECTOR",
+				ChargeStationId: "cs001",
+				Reference:       "1",
+				ResponseUrl:     emspServer.URL + "/ocpi/emsp/2.2/commands/UNLOCK_CONNECTOR/12345",
+				CountryCode:     "GB",
+				PartyId:         "TWE",
+			})
+			require.NoError(t, err)
+
+			err = ocpiApi.CommandResult(context.Background(), "cs001", "UnlockConnector", "1", status)
+			require.NoError(t, err)
+			assert.Equal(t, []ocpi.CommandResult{{Result: want}}, results)
+
+			// the result is only sent once
+			err = ocpiApi.CommandResult(context.Background(), "cs001", "UnlockConnector", "1", status)
+			require.NoError(t, err)
+			assert.Len(t, results, 1)
+		})
+	}
+}
+
 func TestPushLocation(t *testing.T) {
 	engine := inmemory.NewStore(clock.RealClock{})
 	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
@@ -158,7 +355,7 @@ func TestPushLocation(t *testing.T) {
 		assert.Equal(t, []byte(nil), got)
 		w.WriteHeader(http.StatusCreated)
 	})
-	err := ocpiApi.SetCredentials(context.Background(), "some-token-123", ocpi.Credentials{
+	_, err := ocpiApi.SetCredentials(context.Background(), "some-token-123", ocpi.Credentials{
 		Roles: []ocpi.CredentialsRole{
 			{
 				CountryCode: "GB",
@@ -176,3 +373,352 @@ func TestPushLocation(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestPushTariff(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+
+	var tariffs []ocpi.Tariff
+	mux := http.NewServeMux()
+	receiverServer := httptest.NewServer(mux)
+	defer receiverServer.Close()
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data":[{"version":"2.2","url":"%s/ocpi/2.2"}], "status_code":1000}`, receiverServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/2.2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data":{
+				"version":"2.2",
+				"endpoints":[{"identifier":"tariffs","role":"RECEIVER","url":"%s/ocpi/receiver/2.2/tariffs"}]},
+				"status_code":1000}`,
+			receiverServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/receiver/2.2/tariffs/GB/TWK/tariff001", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var tariff ocpi.Tariff
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&tariff))
+		tariffs = append(tariffs, tariff)
+		w.WriteHeader(http.StatusCreated)
+	})
+	_, err := ocpiApi.SetCredentials(context.Background(), "some-token-123", ocpi.Credentials{
+		Roles: []ocpi.CredentialsRole{
+			{
+				CountryCode: "GB",
+				PartyId:     "TWK",
+				Role:        ocpi.CredentialsRoleRoleEMSP,
+			},
+		},
+		Token: "some-token-456",
+		Url:   receiverServer.URL + "/ocpi/versions",
+	})
+	require.NoError(t, err)
+
+	maxKwh := 10.0
+	err = ocpiApi.PushTariff(context.Background(), &store.Tariff{
+		Id:       "tariff001",
+		Currency: "EUR",
+		Elements: []store.TariffElement{
+			{
+				PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.25, StepSize: 1}},
+				Restrictions: &store.TariffRestrictions{
+					MaxKwh:    &maxKwh,
+					DayOfWeek: []string{"MONDAY"},
+				},
+			},
+			{
+				PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.5, StepSize: 1}},
+			},
+		},
+		LastUpdated: "2023-06-01T00:00:00Z",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, tariffs, 1)
+	got := tariffs[0]
+	assert.Equal(t, "tariff001", got.Id)
+	assert.Equal(t, "GB", got.CountryCode)
+	assert.Equal(t, "TWK", got.PartyId)
+	assert.Equal(t, "EUR", got.Currency)
+	assert.Equal(t, "2023-06-01T00:00:00Z", got.LastUpdated)
+	require.Len(t, got.Elements, 2)
+	assert.Equal(t, []ocpi.PriceComponent{{Type: ocpi.PriceComponentTypeENERGY, Price: 0.25, StepSize: 1}}, got.Elements[0].PriceComponents)
+	require.NotNil(t, got.Elements[0].Restrictions)
+	assert.Equal(t, float32(10), *got.Elements[0].Restrictions.MaxKwh)
+	assert.Equal(t, &[]ocpi.TariffRestrictionsDayOfWeek{ocpi.MONDAY}, got.Elements[0].Restrictions.DayOfWeek)
+	assert.Nil(t, got.Elements[1].Restrictions)
+}
+
+func TestConnectorStatusChanged(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+
+	err := engine.SetLocation(context.Background(), &store.Location{
+		Id: "loc001",
+		Evses: &[]store.Evse{
+			{Uid: "BEBECEcs001", Status: "AVAILABLE", Connectors: []store.Connector{{Id: "1"}, {Id: "2"}}},
+			{Uid: "BEBECEcs002", Status: "AVAILABLE", Connectors: []store.Connector{{Id: "1"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	var patches []map[string]any
+	mux := http.NewServeMux()
+	receiverServer := httptest.NewServer(mux)
+	defer receiverServer.Close()
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data":[{"version":"2.2","url":"%s/ocpi/2.2"}], "status_code":1000}`, receiverServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/2.2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data":{
+				"version":"2.2",
+				"endpoints":[{"identifier":"locations","role":"RECEIVER","url":"%s/ocpi/receiver/2.2/locations"}]},
+				"status_code":1000}`,
+			receiverServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/receiver/2.2/locations/GB/TWK/loc001/BEBECEcs001", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		var patch map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+		patches = append(patches, patch)
+		w.WriteHeader(http.StatusOK)
+	})
+	_, err = ocpiApi.SetCredentials(context.Background(), "some-token-123", ocpi.Credentials{
+		Roles: []ocpi.CredentialsRole{
+			{
+				CountryCode: "GB",
+				PartyId:     "TWK",
+				Role:        ocpi.CredentialsRoleRoleEMSP,
+			},
+		},
+		Token: "some-token-456",
+		Url:   receiverServer.URL + "/ocpi/versions",
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	statuses := []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", ConnectorId: 0, Status: "Unavailable", LastUpdated: now},
+		{ChargeStationId: "cs001", ConnectorId: 1, Status: "Charging", LastUpdated: now},
+		{ChargeStationId: "cs001", ConnectorId: 2, Status: "Available", LastUpdated: now},
+	}
+	for _, status := range statuses {
+		require.NoError(t, engine.SetConnectorStatus(context.Background(), status))
+	}
+
+	err = ocpiApi.ConnectorStatusChanged(context.Background(), statuses[1])
+	require.NoError(t, err)
+	// an unchanged EVSE status is not pushed again
+	err = ocpiApi.ConnectorStatusChanged(context.Background(), statuses[2])
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]any{
+		{"status": "CHARGING", "last_updated": "2023-06-01T12:00:00Z"},
+	}, patches)
+
+	loc, err := ocpiApi.GetLocation(context.Background(), "loc001")
+	require.NoError(t, err)
+	require.NotNil(t, loc.Evses)
+	assert.Equal(t, ocpi.EvseStatusCHARGING, (*loc.Evses)[0].Status)
+	assert.Equal(t, ocpi.EvseStatusAVAILABLE, (*loc.Evses)[1].Status)
+	assert.Equal(t, "2023-06-01T12:00:00Z", loc.LastUpdated)
+}
+
+func TestTransactionChanged(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	ocpiApi.SetTariffService(services.BasicKwhTariffService{})
+
+	err := engine.SetLocation(context.Background(), &store.Location{
+		Id: "loc001",
+		Evses: &[]store.Evse{
+			{Uid: "BEBECEcs001", Status: "AVAILABLE", Connectors: []store.Connector{{Id: "2"}}},
+		},
+	})
+	require.NoError(t, err)
+	err = engine.SetToken(context.Background(), &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "DEADBEEF",
+		ContractId:  "GBTWKTWTW000018",
+		Valid:       true,
+	})
+	require.NoError(t, err)
+
+	var sessions []ocpi.Session
+	var cdrs []ocpi.CDR
+	mux := http.NewServeMux()
+	receiverServer := httptest.NewServer(mux)
+	defer receiverServer.Close()
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data":[{"version":"2.2","url":"%s/ocpi/2.2"}], "status_code":1000}`, receiverServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/2.2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data":{
+				"version":"2.2",
+				"endpoints":[
+					{"identifier":"sessions","role":"RECEIVER","url":"%s/ocpi/receiver/2.2/sessions"},
+					{"identifier":"cdrs","role":"RECEIVER","url":"%s/ocpi/receiver/2.2/cdrs"}
+				]},
+				"status_code":1000}`,
+			receiverServer.URL, receiverServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/receiver/2.2/cdrs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		var cdr ocpi.CDR
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&cdr))
+		cdrs = append(cdrs, cdr)
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/ocpi/receiver/2.2/sessions/GB/TWK/tx001", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var session ocpi.Session
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&session))
+		sessions = append(sessions, session)
+		w.WriteHeader(http.StatusOK)
+	})
+	_, err = ocpiApi.SetCredentials(context.Background(), "some-token-123", ocpi.Credentials{
+		Roles: []ocpi.CredentialsRole{
+			{
+				CountryCode: "GB",
+				PartyId:     "TWK",
+				Role:        ocpi.CredentialsRoleRoleEMSP,
+			},
+		},
+		Token: "some-token-456",
+		Url:   receiverServer.URL + "/ocpi/versions",
+	})
+	require.NoError(t, err)
+
+	energyRegister := "Energy.Active.Import.Register"
+	transaction := &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		MeterValues: []store.MeterValue{
+			{
+				Timestamp:     "2023-06-01T12:00:00Z",
+				SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: 1000}},
+			},
+			{
+				Timestamp:     "2023-06-01T12:30:00Z",
+				SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: 4500}},
+			},
+		},
+	}
+	err = ocpiApi.TransactionChanged(context.Background(), transaction)
+	require.NoError(t, err)
+
+	require.Empty(t, cdrs)
+
+	transactionEnd := "Transaction.End"
+	outlet := "Outlet"
+	transaction.MeterValues = append(transaction.MeterValues, store.MeterValue{
+		Timestamp: "2023-06-01T12:30:00Z",
+		SampledValues: []store.SampledValue{
+			{Context: &transactionEnd, Location: &outlet, Measurand: &energyRegister, Value: 3500},
+		},
+	})
+	transaction.EndedSeqNo = 2
+	err = ocpiApi.TransactionChanged(context.Background(), transaction)
+	require.NoError(t, err)
+	// the charge detail record is only sent once
+	err = ocpiApi.TransactionChanged(context.Background(), transaction)
+	require.NoError(t, err)
+
+	endDateTime := "2023-06-01T12:30:00Z"
+	want := ocpi.Session{
+		AuthMethod: ocpi.SessionAuthMethodWHITELIST,
+		CdrToken: ocpi.CdrToken{
+			ContractId: "GBTWKTWTW000018",
+			Type:       ocpi.CdrTokenTypeRFID,
+			Uid:        "DEADBEEF",
+		},
+		ConnectorId:   "2",
+		CountryCode:   "GB",
+		Currency:      "EUR",
+		EvseUid:       "BEBECEcs001",
+		Id:            "tx001",
+		Kwh:           3.5,
+		LastUpdated:   "2023-06-01T12:30:00Z",
+		LocationId:    "loc001",
+		PartyId:       "TWK",
+		StartDateTime: "2023-06-01T12:00:00Z",
+		Status:        ocpi.SessionStatusACTIVE,
+	}
+	require.Len(t, sessions, 3)
+	assert.Equal(t, want, sessions[0])
+
+	want.Status = ocpi.SessionStatusCOMPLETED
+	want.EndDateTime = &endDateTime
+	assert.Equal(t, want, sessions[1])
+	assert.Equal(t, want, sessions[2])
+
+	require.Len(t, cdrs, 1)
+	cdr := cdrs[0]
+	assert.Equal(t, "tx001", cdr.Id)
+	assert.Equal(t, "loc001", cdr.CdrLocation.Id)
+	assert.Equal(t, "BEBECEcs001", cdr.CdrLocation.EvseUid)
+	assert.Equal(t, "2", cdr.CdrLocation.ConnectorId)
+	assert.Equal(t, "GBTWKTWTW000018", cdr.CdrToken.ContractId)
+	assert.Equal(t, "2023-06-01T12:00:00Z", cdr.StartDateTime)
+	assert.Equal(t, "2023-06-01T12:30:00Z", cdr.EndDateTime)
+	assert.Equal(t, float32(3.5), cdr.TotalEnergy)
+	assert.Equal(t, float32(0.5), cdr.TotalTime)
+	assert.InDelta(t, 1.925, cdr.TotalCost.ExclVat, 0.0001)
+
+	stored, err := engine.LookupChargeDetailRecord(context.Background(), "tx001")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "cs001", stored.ChargeStationId)
+}
+
+func TestTransactionChangedUsesStoredCost(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	ocpiApi.SetTariffService(services.BasicKwhTariffService{})
+
+	err := engine.SetLocation(context.Background(), &store.Location{
+		Id: "loc001",
+		Evses: &[]store.Evse{
+			{Uid: "BEBECEcs001", Status: "AVAILABLE", Connectors: []store.Connector{{Id: "2"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	err = ocpiApi.TransactionChanged(context.Background(), &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		MeterValues: []store.MeterValue{
+			{Timestamp: "2023-06-01T12:00:00Z"},
+			{Timestamp: "2023-06-01T12:30:00Z"},
+		},
+		EndedSeqNo: 1,
+		Cost:       &store.CostBreakdown{Currency: "GBP", TotalCost: 4.2},
+	})
+	require.NoError(t, err)
+
+	stored, err := engine.LookupChargeDetailRecord(context.Background(), "tx001")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, 4.2, stored.TotalCost)
+	assert.Equal(t, "GBP", stored.Currency)
+}
+
+func TestTransactionChangedIgnoresChargeStationsWithoutLocation(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	// there are no parties, so any attempt to push would fail to find an endpoint
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+
+	err := ocpiApi.TransactionChanged(context.Background(), &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+	})
+	require.NoError(t, err)
+}
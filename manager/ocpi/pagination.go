@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// inDateRange reports whether the object was last updated between dateFrom (inclusive)
+// and dateTo (exclusive). A zero dateFrom or dateTo is unbounded.
+func inDateRange(lastUpdated string, dateFrom, dateTo time.Time) bool {
+	if dateFrom.IsZero() && dateTo.IsZero() {
+		return true
+	}
+	ts, err := time.Parse(time.RFC3339, lastUpdated)
+	if err != nil {
+		return false
+	}
+	if !dateFrom.IsZero() && ts.Before(dateFrom) {
+		return false
+	}
+	if !dateTo.IsZero() && !ts.Before(dateTo) {
+		return false
+	}
+	return true
+}
+
+// storePageSize is the number of objects read from the store at a time
+const storePageSize = 50
+
+// listAll reads every object from the store, a page at a time
+func listAll[S any](ctx context.Context, list func(ctx context.Context, offset, limit int) ([]S, error)) ([]S, error) {
+	var all []S
+	for {
+		page, err := list(ctx, len(all), storePageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < storePageSize {
+			return all, nil
+		}
+	}
+}
+
+// listPage reads every object from the store and returns the page of those that were
+// last updated between dateFrom (inclusive) and dateTo (exclusive), converted to their
+// OCPI representation, together with the total number of matching objects
+func listPage[S any, T any](ctx context.Context, list func(ctx context.Context, offset, limit int) ([]S, error),
+	lastUpdated func(S) string, convert func(S) T, dateFrom, dateTo time.Time, offset, limit int) ([]T, int, error) {
+	objects, err := listAll(ctx, list)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var matching []S
+	for _, object := range objects {
+		if inDateRange(lastUpdated(object), dateFrom, dateTo) {
+			matching = append(matching, object)
+		}
+	}
+
+	page := paginate(matching, offset, limit)
+	result := make([]T, len(page))
+	for i, object := range page {
+		result[i] = convert(object)
+	}
+	return result, len(matching), nil
+}
+
+// paginate returns at most limit items after skipping the first offset items
+func paginate[T any](items []T, offset, limit int) []T {
+	total := len(items)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	page := make([]T, 0, end-offset)
+	return append(page, items[offset:end]...)
+}
+
+// maxPageLimit is the maximum number of objects returned in a single page
+const maxPageLimit = 100
+
+// pageRequest holds the parameters that a party uses to page through the objects
+// owned by the CPO
+type pageRequest struct {
+	dateFrom time.Time
+	dateTo   time.Time
+	offset   int
+	limit    int
+}
+
+func newPageRequest(dateFrom, dateTo *string, offset, limit *int32) (pageRequest, error) {
+	page := pageRequest{limit: maxPageLimit}
+	var err error
+	if dateFrom != nil {
+		page.dateFrom, err = time.Parse(time.RFC3339, *dateFrom)
+		if err != nil {
+			return page, fmt.Errorf("invalid date_from: %w", err)
+		}
+	}
+	if dateTo != nil {
+		page.dateTo, err = time.Parse(time.RFC3339, *dateTo)
+		if err != nil {
+			return page, fmt.Errorf("invalid date_to: %w", err)
+		}
+	}
+	if offset != nil && *offset > 0 {
+		page.offset = int(*offset)
+	}
+	if limit != nil && *limit > 0 && *limit < maxPageLimit {
+		page.limit = int(*limit)
+	}
+	return page, nil
+}
+
+// setHeaders sets the OCPI pagination headers, including a link to the next page if
+// there are more objects to return
+func (p pageRequest) setHeaders(w http.ResponseWriter, r *http.Request, count, total int) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	w.Header().Set("X-Limit", strconv.Itoa(p.limit))
+	if p.offset+count < total {
+		next := *r.URL
+		query := next.Query()
+		query.Set("offset", strconv.Itoa(p.offset+count))
+		query.Set("limit", strconv.Itoa(p.limit))
+		next.RawQuery = query.Encode()
+		next.Host = r.Host
+		next.Scheme = "http"
+		if r.TLS != nil {
+			next.Scheme = "https"
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+}
@@ -15,25 +15,32 @@ import (
 	"net/http"
 )
 
+// ErrAlreadyRegistered is returned when a party that has already exchanged credentials
+// tries to register again: it should update its credentials instead
+var ErrAlreadyRegistered = errors.New("already registered")
+
+// ErrNotRegistered is returned when a party that has not exchanged credentials tries to
+// update or delete them
+var ErrNotRegistered = errors.New("not registered")
+
+// RegisterNewParty starts the credentials handshake with a party that has issued token
+// (CREDENTIALS_TOKEN_A) to be used with its versions endpoint at url. A new token is
+// generated for the party to use and exchanged for the token that this CSMS should use
+// when talking to the party. The most recent version supported by both parties is used.
 func (o *OCPI) RegisterNewParty(ctx context.Context, url, token string) error {
 	reg, err := o.store.GetRegistrationDetails(ctx, token)
 	if err != nil {
 		return err
 	}
 	if reg != nil && reg.Status == store.OcpiRegistrationStatusRegistered {
-		return errors.New("already registered")
+		return ErrAlreadyRegistered
 	}
 
-	versions, err := o.getVersions(ctx, url, token)
+	version, endpoints, err := o.discoverEndpoints(ctx, url, token, supportedVersions)
 	if err != nil {
 		return err
 	}
-
-	endpointUrl, err := getEndpointUrl(versions)
-	if err != nil {
-		return err
-	}
-	endpoints, err := o.getEndpoints(ctx, endpointUrl, token)
+	credentialsUrl, err := getCredentialsUrl(endpoints)
 	if err != nil {
 		return err
 	}
@@ -43,114 +50,298 @@ func (o *OCPI) RegisterNewParty(ctx context.Context, url, token string) error {
 		return err
 	}
 
+	// the party will use the new token to discover our endpoints before it responds
 	err = o.store.SetRegistrationDetails(ctx, newToken, &store.OcpiRegistration{
-		Status: store.OcpiRegistrationStatusRegistered,
+		Status: store.OcpiRegistrationStatusPending,
 	})
 	if err != nil {
 		return err
 	}
 
-	credentialsUrl, err := getCredentialsUrl(endpoints)
+	creds, err := o.postCredentials(ctx, version, credentialsUrl, token, newToken)
 	if err != nil {
+		_ = o.store.DeleteRegistrationDetails(ctx, newToken)
 		return err
 	}
 
-	err = o.postCredentials(ctx, credentialsUrl, token, newToken)
+	if creds.Url != url {
+		_, endpoints, err = o.discoverEndpoints(ctx, creds.Url, creds.Token, []string{version})
+		if err != nil {
+			_ = o.store.DeleteRegistrationDetails(ctx, newToken)
+			return err
+		}
+	}
+
+	return o.registerParty(ctx, newToken, version, *creds, endpoints)
+}
+
+// SetCredentials completes the credentials handshake started by a party that is using
+// token (CREDENTIALS_TOKEN_A). The party's endpoints are discovered using the token it
+// has provided and a new token is returned in the credentials for the party to use
+// from now on.
+func (o *OCPI) SetCredentials(ctx context.Context, token string, credentials Credentials) (Credentials, error) {
+	return o.setCredentials(ctx, token, credentials, versions22)
+}
+
+// SetCredentials211 completes the credentials handshake started by a party using version
+// 2.1.1
+func (o *OCPI) SetCredentials211(ctx context.Context, token string, credentials Credentials211) (Credentials211, error) {
+	creds, err := o.setCredentials(ctx, token, credentialsFrom211(credentials), []string{Version211})
 	if err != nil {
-		return err
+		return Credentials211{}, err
 	}
+	return newCredentials211(creds), nil
+}
 
-	return nil
+func (o *OCPI) setCredentials(ctx context.Context, token string, credentials Credentials, versions []string) (Credentials, error) {
+	reg, err := o.store.GetRegistrationDetails(ctx, token)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if reg != nil && reg.Status == store.OcpiRegistrationStatusRegistered {
+		return Credentials{}, ErrAlreadyRegistered
+	}
+
+	version, endpoints, err := o.discoverEndpoints(ctx, credentials.Url, credentials.Token, versions)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	newToken, err := generateRandomString()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	err = o.registerParty(ctx, newToken, version, credentials, endpoints)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	if reg != nil {
+		err = o.store.DeleteRegistrationDetails(ctx, token)
+		if err != nil {
+			return Credentials{}, err
+		}
+	}
+
+	return o.newCredentials(newToken), nil
 }
 
-func (o *OCPI) getVersions(ctx context.Context, url, token string) ([]Version, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// UpdateCredentials rotates the credentials of a registered party: the party's endpoints
+// are discovered again and a new token is returned to replace the token it is using.
+func (o *OCPI) UpdateCredentials(ctx context.Context, token string, credentials Credentials) (Credentials, error) {
+	return o.updateCredentials(ctx, token, credentials, versions22)
+}
+
+// UpdateCredentials211 rotates the credentials of a registered party using version 2.1.1
+func (o *OCPI) UpdateCredentials211(ctx context.Context, token string, credentials Credentials211) (Credentials211, error) {
+	creds, err := o.updateCredentials(ctx, token, credentialsFrom211(credentials), []string{Version211})
 	if err != nil {
-		return nil, err
+		return Credentials211{}, err
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Token %s", token))
+	return newCredentials211(creds), nil
+}
 
-	resp, err := o.httpClient.Do(req)
+func (o *OCPI) updateCredentials(ctx context.Context, token string, credentials Credentials, versions []string) (Credentials, error) {
+	reg, err := o.store.GetRegistrationDetails(ctx, token)
 	if err != nil {
-		return nil, err
+		return Credentials{}, err
+	}
+	if reg == nil || reg.Status != store.OcpiRegistrationStatusRegistered {
+		return Credentials{}, ErrNotRegistered
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+	version, endpoints, err := o.discoverEndpoints(ctx, credentials.Url, credentials.Token, versions)
+	if err != nil {
+		return Credentials{}, err
 	}
 
-	b, err := io.ReadAll(resp.Body)
+	newToken, err := generateRandomString()
 	if err != nil {
-		return nil, err
+		return Credentials{}, err
 	}
 
-	var versionList OcpiResponseListVersion
-	err = json.Unmarshal(b, &versionList)
+	err = o.deleteParties(ctx, reg)
 	if err != nil {
-		return nil, err
+		return Credentials{}, err
+	}
+	err = o.registerParty(ctx, newToken, version, credentials, endpoints)
+	if err != nil {
+		return Credentials{}, err
+	}
+	err = o.store.DeleteRegistrationDetails(ctx, token)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return o.newCredentials(newToken), nil
+}
+
+// GetCredentials returns the credentials that the party using token should use
+func (o *OCPI) GetCredentials(ctx context.Context, token string) (Credentials, error) {
+	reg, err := o.store.GetRegistrationDetails(ctx, token)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if reg == nil || reg.Status != store.OcpiRegistrationStatusRegistered {
+		return Credentials{}, ErrNotRegistered
+	}
+
+	return o.newCredentials(token), nil
+}
+
+// DeleteCredentials unregisters the party using token: the token can no longer be used
+// and the party's details are removed so nothing more is sent to the party.
+func (o *OCPI) DeleteCredentials(ctx context.Context, token string) error {
+	reg, err := o.store.GetRegistrationDetails(ctx, token)
+	if err != nil {
+		return err
+	}
+	if reg == nil || reg.Status != store.OcpiRegistrationStatusRegistered {
+		return ErrNotRegistered
 	}
-	if versionList.StatusCode != StatusSuccess {
-		return nil, fmt.Errorf("status code: %d", versionList.StatusCode)
+
+	err = o.deleteParties(ctx, reg)
+	if err != nil {
+		return err
 	}
-	return *versionList.Data, nil
+
+	return o.store.DeleteRegistrationDetails(ctx, token)
 }
 
-func getEndpointUrl(versions []Version) (string, error) {
-	var endpointUrl string
+// registerParty stores the details of each of the party's roles and records that the
+// party is registered to use token
+func (o *OCPI) registerParty(ctx context.Context, token, version string, credentials Credentials, endpoints []Endpoint) error {
+	storeEndpoints := make([]store.OcpiEndpoint, len(endpoints))
+	for i, endpoint := range endpoints {
+		storeEndpoints[i] = store.OcpiEndpoint{
+			Identifier: endpoint.Identifier,
+			Role:       string(endpoint.Role),
+			Url:        endpoint.Url,
+		}
+	}
 
-	for _, version := range versions {
-		if version.Version == "2.2" {
-			endpointUrl = version.Url
-			break
+	parties := make([]store.OcpiPartyRole, len(credentials.Roles))
+	for i, role := range credentials.Roles {
+		err := o.store.SetPartyDetails(ctx, &store.OcpiParty{
+			Role:        string(role.Role),
+			CountryCode: role.CountryCode,
+			PartyId:     role.PartyId,
+			Url:         credentials.Url,
+			Token:       credentials.Token,
+			Version:     version,
+			Endpoints:   storeEndpoints,
+		})
+		if err != nil {
+			return err
+		}
+		parties[i] = store.OcpiPartyRole{
+			Role:        string(role.Role),
+			CountryCode: role.CountryCode,
+			PartyId:     role.PartyId,
 		}
 	}
 
-	if endpointUrl == "" {
-		return "", fmt.Errorf("no version 2.2 endpoint found")
+	return o.store.SetRegistrationDetails(ctx, token, &store.OcpiRegistration{
+		Status:  store.OcpiRegistrationStatusRegistered,
+		Parties: parties,
+	})
+}
+
+func (o *OCPI) deleteParties(ctx context.Context, reg *store.OcpiRegistration) error {
+	for _, party := range reg.Parties {
+		err := o.store.DeletePartyDetails(ctx, party.Role, party.CountryCode, party.PartyId)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	return endpointUrl, nil
+// newCredentials returns the credentials of this CSMS for a party that uses token
+func (o *OCPI) newCredentials(token string) Credentials {
+	return Credentials{
+		Roles: []CredentialsRole{
+			{
+				CountryCode: o.countryCode,
+				PartyId:     o.partyId,
+				Role:        CredentialsRoleRoleCPO,
+			},
+		},
+		Token: token,
+		Url:   o.externalUrl + "/ocpi/versions",
+	}
 }
 
-func (o *OCPI) getEndpoints(ctx context.Context, url, token string) ([]Endpoint, error) {
+// discoverEndpoints agrees the most preferred of the acceptable versions with the party
+// with the versions endpoint at url and returns the party's endpoints for that version
+func (o *OCPI) discoverEndpoints(ctx context.Context, url, token string, acceptable []string) (string, []Endpoint, error) {
+	versions, err := getData[[]Version](ctx, o.httpClient, url, token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	version, err := negotiateVersion(versions, acceptable)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if version.Version == Version211 {
+		versionDetail, err := getData[VersionDetail211](ctx, o.httpClient, version.Url, token)
+		if err != nil {
+			return "", nil, err
+		}
+		return version.Version, endpointsFrom211(versionDetail.Endpoints), nil
+	}
+
+	versionDetail, err := getData[VersionDetail](ctx, o.httpClient, version.Url, token)
+	if err != nil {
+		return "", nil, err
+	}
+	return version.Version, versionDetail.Endpoints, nil
+}
+
+// getData returns the data of the OCPI response to a GET request to url
+func getData[T any](ctx context.Context, httpClient *http.Client, url, token string) (T, error) {
+	var data T
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, err
+		return data, err
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Token %s", token))
 
-	resp, err := o.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return data, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+		return data, fmt.Errorf("status code: %d", resp.StatusCode)
 	}
 
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return data, err
 	}
 
-	var versionDetail OcpiResponseVersionDetail
-	err = json.Unmarshal(b, &versionDetail)
+	var response ocpiResponse[T]
+	err = json.Unmarshal(b, &response)
 	if err != nil {
-		return nil, err
+		return data, err
 	}
-	if versionDetail.StatusCode != StatusSuccess {
-		return nil, fmt.Errorf("status code: %d", versionDetail.StatusCode)
+	if response.StatusCode != StatusSuccess {
+		return data, fmt.Errorf("status code: %d", response.StatusCode)
+	}
+	if response.Data == nil {
+		return data, errors.New("no data returned")
 	}
 
-	return versionDetail.Data.Endpoints, nil
+	return *response.Data, nil
 }
 
 func generateRandomString() (string, error) {
@@ -176,42 +367,65 @@ func getCredentialsUrl(endpoints []Endpoint) (string, error) {
 	return "", errors.New("no credentials endpoint for receiver found")
 }
 
-func (o *OCPI) postCredentials(ctx context.Context, url, token, newToken string) error {
-	creds := Credentials{
-		Roles: []CredentialsRole{
-			{
-				CountryCode: o.countryCode,
-				PartyId:     o.partyId,
-				Role:        "CPO",
-			},
-		},
-		Token: newToken,
-		Url:   o.externalUrl + "/ocpi/versions",
+// postCredentials sends the credentials of this CSMS to the party in the format of the
+// version agreed with the party and returns the party's credentials
+func (o *OCPI) postCredentials(ctx context.Context, version, url, token, newToken string) (*Credentials, error) {
+	var body any = o.newCredentials(newToken)
+	if version == Version211 {
+		body = newCredentials211(o.newCredentials(newToken))
 	}
-
-	b, err := json.Marshal(creds)
+	b, err := json.Marshal(body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Token %s", token))
 
 	resp, err := o.httpClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("status code: %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
 	}
 
-	return nil
+	b, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == Version211 {
+		creds, err := decodeCredentials[Credentials211](b)
+		if err != nil {
+			return nil, err
+		}
+		credentials := credentialsFrom211(*creds)
+		return &credentials, nil
+	}
+	return decodeCredentials[Credentials](b)
+}
+
+func decodeCredentials[T any](b []byte) (*T, error) {
+	var credentials ocpiResponse[T]
+	err := json.Unmarshal(b, &credentials)
+	if err != nil {
+		return nil, err
+	}
+	if credentials.StatusCode != StatusSuccess {
+		return nil, fmt.Errorf("status code: %d", credentials.StatusCode)
+	}
+	if credentials.Data == nil {
+		return nil, errors.New("no credentials returned")
+	}
+
+	return credentials.Data, nil
 }
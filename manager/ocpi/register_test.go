@@ -3,13 +3,16 @@
 package ocpi_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
 	"github.com/thoughtworks/maeve-csms/manager/server"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"io"
 	"k8s.io/utils/clock"
 	"net/http"
 	"net/http/httptest"
@@ -72,13 +75,154 @@ func TestRegistration(t *testing.T) {
 	assert.Nil(t, receiverTokenAReg)
 
 	// token status
-	receiverTokenBReg, err := receiverStore.GetRegistrationDetails(context.Background(), senderPartyDetails.Token)
+	receiverTokenCReg, err := receiverStore.GetRegistrationDetails(context.Background(), receiverPartyDetails.Token)
 	require.NoError(t, err)
-	require.NotNil(t, receiverTokenBReg)
-	assert.Equal(t, store.OcpiRegistrationStatusRegistered, receiverTokenBReg.Status)
+	require.NotNil(t, receiverTokenCReg)
+	assert.Equal(t, store.OcpiRegistrationStatusRegistered, receiverTokenCReg.Status)
+	assert.Equal(t, []store.OcpiPartyRole{{Role: "CPO", CountryCode: "GB", PartyId: "TWK"}}, receiverTokenCReg.Parties)
 
-	senderTokenCReg, err := senderStore.GetRegistrationDetails(context.Background(), receiverPartyDetails.Token)
+	senderTokenBReg, err := senderStore.GetRegistrationDetails(context.Background(), senderPartyDetails.Token)
 	require.NoError(t, err)
-	require.NotNil(t, senderTokenCReg)
-	assert.Equal(t, store.OcpiRegistrationStatusRegistered, senderTokenCReg.Status)
+	require.NotNil(t, senderTokenBReg)
+	assert.Equal(t, store.OcpiRegistrationStatusRegistered, senderTokenBReg.Status)
+	assert.Equal(t, []store.OcpiPartyRole{{Role: "CPO", CountryCode: "GB", PartyId: "TWS"}}, senderTokenBReg.Parties)
+
+	// endpoints are recorded so they do not need to be discovered again
+	assert.Contains(t, receiverPartyDetails.Endpoints, store.OcpiEndpoint{
+		Identifier: "credentials",
+		Role:       "RECEIVER",
+		Url:        receiverServer.URL + "/ocpi/2.2/credentials",
+	})
+}
+
+// registerParties completes the credentials handshake between two instances and returns
+// the receiving instance's server and store along with the token the sender uses to talk
+// to it
+func registerParties(t *testing.T) (*httptest.Server, store.Engine, string) {
+	tokenA := "abcdef123456"
+
+	senderStore := inmemory.NewStore(clock.RealClock{})
+	senderOcpiApi := ocpi.NewOCPI(senderStore, http.DefaultClient, "GB", "TWK")
+	senderServer := httptest.NewServer(server.NewOcpiHandler(senderStore, clock.RealClock{}, senderOcpiApi, nil))
+	senderOcpiApi.SetExternalUrl(senderServer.URL)
+	t.Cleanup(senderServer.Close)
+
+	receiverStore := inmemory.NewStore(clock.RealClock{})
+	err := receiverStore.SetRegistrationDetails(context.Background(), tokenA, &store.OcpiRegistration{
+		Status: store.OcpiRegistrationStatusPending,
+	})
+	require.NoError(t, err)
+	receiverOcpiApi := ocpi.NewOCPI(receiverStore, http.DefaultClient, "GB", "TWS")
+	receiverServer := httptest.NewServer(server.NewOcpiHandler(receiverStore, clock.RealClock{}, receiverOcpiApi, nil))
+	receiverOcpiApi.SetExternalUrl(receiverServer.URL)
+	t.Cleanup(receiverServer.Close)
+
+	err = senderOcpiApi.RegisterNewParty(context.Background(), receiverServer.URL+"/ocpi/versions", tokenA)
+	require.NoError(t, err)
+
+	receiverPartyDetails, err := senderStore.GetPartyDetails(context.Background(), "CPO", "GB", "TWS")
+	require.NoError(t, err)
+	require.NotNil(t, receiverPartyDetails)
+
+	return receiverServer, receiverStore, receiverPartyDetails.Token
+}
+
+func sendCredentialsRequest(t *testing.T, method, url, token string, creds *ocpi.Credentials) (*http.Response, *ocpi.OcpiResponseCredentials) {
+	var body io.Reader
+	if creds != nil {
+		b, err := json.Marshal(creds)
+		require.NoError(t, err)
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, url+"/ocpi/2.2/credentials", body)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Token "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "some-request-id")
+	req.Header.Set("X-Correlation-ID", "some-correlation-id")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var ocpiResp ocpi.OcpiResponseCredentials
+	_ = json.NewDecoder(resp.Body).Decode(&ocpiResp)
+	return resp, &ocpiResp
+}
+
+func TestRegistrationRejectsSecondPost(t *testing.T) {
+	receiverServer, receiverStore, token := registerParties(t)
+
+	senderPartyDetails, err := receiverStore.GetPartyDetails(context.Background(), "CPO", "GB", "TWK")
+	require.NoError(t, err)
+
+	resp, _ := sendCredentialsRequest(t, http.MethodPost, receiverServer.URL, token, &ocpi.Credentials{
+		Roles: []ocpi.CredentialsRole{{CountryCode: "GB", PartyId: "TWK", Role: ocpi.CredentialsRoleRoleCPO}},
+		Token: senderPartyDetails.Token,
+		Url:   senderPartyDetails.Url,
+	})
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestGetCredentials(t *testing.T) {
+	receiverServer, _, token := registerParties(t)
+
+	resp, creds := sendCredentialsRequest(t, http.MethodGet, receiverServer.URL, token, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, creds.Data)
+	assert.Equal(t, token, creds.Data.Token)
+	assert.Equal(t, receiverServer.URL+"/ocpi/versions", creds.Data.Url)
+	assert.Equal(t, []ocpi.CredentialsRole{{CountryCode: "GB", PartyId: "TWS", Role: ocpi.CredentialsRoleRoleCPO}}, creds.Data.Roles)
+
+	resp, _ = sendCredentialsRequest(t, http.MethodGet, receiverServer.URL, "unknown-token", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestUpdateCredentials(t *testing.T) {
+	receiverServer, receiverStore, token := registerParties(t)
+
+	senderPartyDetails, err := receiverStore.GetPartyDetails(context.Background(), "CPO", "GB", "TWK")
+	require.NoError(t, err)
+
+	resp, creds := sendCredentialsRequest(t, http.MethodPut, receiverServer.URL, token, &ocpi.Credentials{
+		Roles: []ocpi.CredentialsRole{{CountryCode: "GB", PartyId: "TWK", Role: ocpi.CredentialsRoleRoleCPO}},
+		Token: senderPartyDetails.Token,
+		Url:   senderPartyDetails.Url,
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, creds.Data)
+	assert.NotEqual(t, token, creds.Data.Token)
+	assert.Len(t, creds.Data.Token, 64)
+
+	oldReg, err := receiverStore.GetRegistrationDetails(context.Background(), token)
+	require.NoError(t, err)
+	assert.Nil(t, oldReg)
+
+	newReg, err := receiverStore.GetRegistrationDetails(context.Background(), creds.Data.Token)
+	require.NoError(t, err)
+	require.NotNil(t, newReg)
+	assert.Equal(t, store.OcpiRegistrationStatusRegistered, newReg.Status)
+
+	resp, _ = sendCredentialsRequest(t, http.MethodGet, receiverServer.URL, creds.Data.Token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestDeleteCredentials(t *testing.T) {
+	receiverServer, receiverStore, token := registerParties(t)
+
+	resp, _ := sendCredentialsRequest(t, http.MethodDelete, receiverServer.URL, token, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	reg, err := receiverStore.GetRegistrationDetails(context.Background(), token)
+	require.NoError(t, err)
+	assert.Nil(t, reg)
+
+	party, err := receiverStore.GetPartyDetails(context.Background(), "CPO", "GB", "TWK")
+	require.NoError(t, err)
+	assert.Nil(t, party)
+
+	resp, _ = sendCredentialsRequest(t, http.MethodGet, receiverServer.URL, token, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 }
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+)
+
+const (
+	ContextKeyRoute ContextKey = "route"
+)
+
+// Route identifies the parties that a request was sent between, as given by the OCPI
+// routing headers. When a request is routed through a hub these are the parties that
+// the hub is forwarding the request between, not the hub itself.
+type Route struct {
+	FromCountryCode string
+	FromPartyId     string
+	ToCountryCode   string
+	ToPartyId       string
+}
+
+// RoutingHeadersMiddleware records the routing headers of the request in the context
+// and returns them on the response, with the from and to parties swapped, so that a hub
+// can route the response back to the party that sent the request
+func RoutingHeadersMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := Route{
+			FromCountryCode: r.Header.Get("OCPI-from-country-code"),
+			FromPartyId:     r.Header.Get("OCPI-from-party-id"),
+			ToCountryCode:   r.Header.Get("OCPI-to-country-code"),
+			ToPartyId:       r.Header.Get("OCPI-to-party-id"),
+		}
+		if route.FromCountryCode != "" && route.FromPartyId != "" {
+			w.Header().Set("OCPI-to-country-code", route.FromCountryCode)
+			w.Header().Set("OCPI-to-party-id", route.FromPartyId)
+		}
+		if route.ToCountryCode != "" && route.ToPartyId != "" {
+			w.Header().Set("OCPI-from-country-code", route.ToCountryCode)
+			w.Header().Set("OCPI-from-party-id", route.ToPartyId)
+		}
+		r = r.WithContext(context.WithValue(r.Context(), ContextKeyRoute, route))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// recipient is a party that a request is sent to. The request is sent to the party's
+// URL using the party's token, but is addressed to the party identified by countryCode
+// and partyId: for a party that is reached through a hub the hub's details are used to
+// send the request, but it is addressed to the party itself.
+type recipient struct {
+	*store.OcpiParty
+	countryCode string
+	partyId     string
+}
+
+// broadcastRecipients returns the recipients of requests sent to all eMSPs: a request
+// addressed to a hub is broadcast by the hub to the eMSPs that are connected to it
+func (o *OCPI) broadcastRecipients(ctx context.Context) ([]recipient, error) {
+	var recipients []recipient
+	for _, role := range []string{"EMSP", "HUB"} {
+		parties, err := o.store.ListPartyDetailsForRole(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		for _, party := range parties {
+			recipients = append(recipients, recipient{
+				OcpiParty:   party,
+				countryCode: party.CountryCode,
+				partyId:     party.PartyId,
+			})
+		}
+	}
+	return recipients, nil
+}
+
+// recipientFor returns the recipient of requests sent to a specific eMSP: the eMSP itself
+// if credentials have been exchanged with it, otherwise through a hub. Nil is returned
+// if the eMSP cannot be reached.
+func (o *OCPI) recipientFor(ctx context.Context, countryCode, partyId string) (*recipient, error) {
+	party, err := o.store.GetPartyDetails(ctx, "EMSP", countryCode, partyId)
+	if err != nil {
+		return nil, err
+	}
+	if party == nil {
+		hubs, err := o.store.ListPartyDetailsForRole(ctx, "HUB")
+		if err != nil {
+			return nil, err
+		}
+		if len(hubs) == 0 {
+			return nil, nil
+		}
+		party = hubs[0]
+	}
+	return &recipient{
+		OcpiParty:   party,
+		countryCode: countryCode,
+		partyId:     partyId,
+	}, nil
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoutingHeadersMiddleware(t *testing.T) {
+	var got ocpi.Route
+	handler := ocpi.RoutingHeadersMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Context().Value(ocpi.ContextKeyRoute).(ocpi.Route)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/ocpi/sender/2.2/locations", nil)
+	req.Header.Set("OCPI-from-country-code", "NL")
+	req.Header.Set("OCPI-from-party-id", "EMS")
+	req.Header.Set("OCPI-to-country-code", "GB")
+	req.Header.Set("OCPI-to-party-id", "TWK")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, ocpi.Route{
+		FromCountryCode: "NL",
+		FromPartyId:     "EMS",
+		ToCountryCode:   "GB",
+		ToPartyId:       "TWK",
+	}, got)
+
+	resp := w.Result()
+	assert.Equal(t, "GB", resp.Header.Get("OCPI-from-country-code"))
+	assert.Equal(t, "TWK", resp.Header.Get("OCPI-from-party-id"))
+	assert.Equal(t, "NL", resp.Header.Get("OCPI-to-country-code"))
+	assert.Equal(t, "EMS", resp.Header.Get("OCPI-to-party-id"))
+}
+
+func TestRoutingHeadersMiddlewareWithoutHeaders(t *testing.T) {
+	handler := ocpi.RoutingHeadersMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/ocpi/versions", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	assert.Empty(t, resp.Header.Get("OCPI-from-party-id"))
+	assert.Empty(t, resp.Header.Get("OCPI-to-party-id"))
+}
+
+// newHubServer returns a server that acts as a hub, recording the routing headers of
+// the requests it receives for each path
+func newHubServer(t *testing.T) (*httptest.Server, map[string]ocpi.Route) {
+	routes := make(map[string]ocpi.Route)
+	recordRoute := func(status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Token hub-token", r.Header.Get("Authorization"))
+			routes[r.URL.Path] = ocpi.Route{
+				FromCountryCode: r.Header.Get("OCPI-from-country-code"),
+				FromPartyId:     r.Header.Get("OCPI-from-party-id"),
+				ToCountryCode:   r.Header.Get("OCPI-to-country-code"),
+				ToPartyId:       r.Header.Get("OCPI-to-party-id"),
+			}
+			w.WriteHeader(status)
+		}
+	}
+
+	mux := http.NewServeMux()
+	hubServer := httptest.NewServer(mux)
+	t.Cleanup(hubServer.Close)
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":[{"version":"2.2","url":"%s/ocpi/2.2"}], "status_code":1000}`, hubServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/2.2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{
+				"version":"2.2",
+				"endpoints":[{"identifier":"locations","role":"RECEIVER","url":"%s/ocpi/receiver/2.2/locations"}]},
+				"status_code":1000}`,
+			hubServer.URL)))
+	})
+	mux.HandleFunc("/ocpi/receiver/2.2/locations/", recordRoute(http.StatusCreated))
+	mux.HandleFunc("/ocpi/emsp/2.2/commands/", recordRoute(http.StatusOK))
+
+	return hubServer, routes
+}
+
+func TestPushLocationThroughHub(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	hubServer, routes := newHubServer(t)
+
+	err := engine.SetPartyDetails(context.Background(), &store.OcpiParty{
+		Role:        "HUB",
+		CountryCode: "DE",
+		PartyId:     "HUB",
+		Url:         hubServer.URL + "/ocpi/versions",
+		Token:       "hub-token",
+	})
+	require.NoError(t, err)
+
+	err = ocpiApi.PushLocation(context.Background(), ocpi.Location{Id: "loc001"})
+	require.NoError(t, err)
+
+	// a broadcast is addressed to the hub
+	assert.Equal(t, map[string]ocpi.Route{
+		"/ocpi/receiver/2.2/locations/GB/TWK/loc001": {
+			FromCountryCode: "GB",
+			FromPartyId:     "TWK",
+			ToCountryCode:   "DE",
+			ToPartyId:       "HUB",
+		},
+	}, routes)
+}
+
+func TestCommandResultThroughHub(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	hubServer, routes := newHubServer(t)
+
+	err := engine.SetPartyDetails(context.Background(), &store.OcpiParty{
+		Role:        "HUB",
+		CountryCode: "DE",
+		PartyId:     "HUB",
+		Url:         hubServer.URL + "/ocpi/versions",
+		Token:       "hub-token",
+	})
+	require.NoError(t, err)
+
+	err = ocpiApi.SetPendingCommand(context.Background(), &store.OcpiCommand{
+		Command:         "UNLOCK_CONNECTOR",
+		ChargeStationId: "cs001",
+		Reference:       "1",
+		ResponseUrl:     hubServer.URL + "/ocpi/emsp/2.2/commands/UNLOCK_CONNECTOR/12345",
+		CountryCode:     "NL",
+		PartyId:         "EMS",
+	})
+	require.NoError(t, err)
+
+	err = ocpiApi.CommandResult(context.Background(), "cs001", "UnlockConnector", "1", "Unlocked")
+	require.NoError(t, err)
+
+	// a request for a specific eMSP is addressed to the eMSP
+	assert.Equal(t, map[string]ocpi.Route{
+		"/ocpi/emsp/2.2/commands/UNLOCK_CONNECTOR/12345": {
+			FromCountryCode: "GB",
+			FromPartyId:     "TWK",
+			ToCountryCode:   "NL",
+			ToPartyId:       "EMS",
+		},
+	}, routes)
+}
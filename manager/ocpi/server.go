@@ -10,6 +10,7 @@ import (
 	"github.com/go-chi/render"
 	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"golang.org/x/exp/slog"
 	"k8s.io/utils/clock"
 	"net/http"
@@ -80,20 +81,105 @@ func (s *Server) PostCredentials(w http.ResponseWriter, r *http.Request, params
 		return
 	}
 
-	matches := authzHeaderRegexp.FindStringSubmatch(params.Authorization)
-	if len(matches) != 2 {
-		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid authorization header")))
+	token, err := tokenFromAuthorization(params.Authorization)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
 		return
 	}
 
-	err := s.ocpi.SetCredentials(r.Context(), matches[1], *creds)
+	newCreds, err := s.ocpi.SetCredentials(r.Context(), token, *creds)
 	if err != nil {
-		slog.Error("Error setting credentials", "err", err)
-		_ = render.Render(w, r, ErrInternalError(err))
+		s.renderCredentialsError(w, r, err)
+		return
+	}
+
+	s.renderCredentials(w, r, newCreds)
+}
+
+func (s *Server) PutCredentials(w http.ResponseWriter, r *http.Request, params PutCredentialsParams) {
+	creds := new(Credentials)
+	if err := render.Bind(r, creds); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	token, err := tokenFromAuthorization(params.Authorization)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	newCreds, err := s.ocpi.UpdateCredentials(r.Context(), token, *creds)
+	if err != nil {
+		s.renderCredentialsError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	s.renderCredentials(w, r, newCreds)
+}
+
+func (s *Server) GetCredentials(w http.ResponseWriter, r *http.Request, params GetCredentialsParams) {
+	token, err := tokenFromAuthorization(params.Authorization)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	creds, err := s.ocpi.GetCredentials(r.Context(), token)
+	if err != nil {
+		s.renderCredentialsError(w, r, err)
+		return
+	}
+
+	s.renderCredentials(w, r, creds)
+}
+
+func (s *Server) DeleteCredentials(w http.ResponseWriter, r *http.Request, params DeleteCredentialsParams) {
+	token, err := tokenFromAuthorization(params.Authorization)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	err = s.ocpi.DeleteCredentials(r.Context(), token)
+	if err != nil {
+		s.renderCredentialsError(w, r, err)
+		return
+	}
+
+	_ = render.Render(w, r, OcpiResponse{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+	})
+}
+
+func (s *Server) renderCredentials(w http.ResponseWriter, r *http.Request, creds Credentials) {
+	_ = render.Render(w, r, OcpiResponseCredentials{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          &creds,
+	})
+}
+
+func (s *Server) renderCredentialsError(w http.ResponseWriter, r *http.Request, err error) {
+	// the OCPI specification requires 405 when a party uses the wrong method for its
+	// registration state
+	if errors.Is(err, ErrAlreadyRegistered) || errors.Is(err, ErrNotRegistered) {
+		_ = render.Render(w, r, ErrMethodNotAllowed(err))
+		return
+	}
+	slog.Error("Error setting credentials", "err", err)
+	_ = render.Render(w, r, ErrInternalError(err))
+}
+
+func tokenFromAuthorization(authorization string) (string, error) {
+	matches := authzHeaderRegexp.FindStringSubmatch(authorization)
+	if len(matches) != 2 {
+		return "", fmt.Errorf("invalid authorization header")
+	}
+	return matches[1], nil
 }
 
 // TOKEN RECEIVER
@@ -104,6 +190,10 @@ func (s *Server) GetClientOwnedToken(w http.ResponseWriter, r *http.Request, cou
 		_ = render.Render(w, r, ErrInternalError(err))
 		return
 	}
+	if token == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
 
 	_ = render.Render(w, r, OcpiResponseToken{
 		StatusCode:    StatusSuccess,
@@ -135,11 +225,11 @@ func (s *Server) PutClientOwnedToken(w http.ResponseWriter, r *http.Request, cou
 
 	err := s.ocpi.SetToken(r.Context(), *tok)
 	if err != nil {
-		_ = render.Render(w, r, OcpiResponseListVersion{
-			StatusCode: StatusGenericServerFailure,
-			Timestamp:  s.clock.Now().Format(time.RFC3339),
-		})
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
 	}
+
+	s.renderTokenUpdated(w, r)
 }
 
 func (s *Server) PatchClientOwnedToken(w http.ResponseWriter, r *http.Request, countryCode string, partyID string, tokenUID string, params PatchClientOwnedTokenParams) {
@@ -186,8 +276,12 @@ func (s *Server) PatchClientOwnedToken(w http.ResponseWriter, r *http.Request, c
 		case "whitelist":
 			whitelist := v.(string)
 			tok.Whitelist = TokenWhitelist(whitelist)
+		case "last_updated":
+			lastUpdated := v.(string)
+			tok.LastUpdated = lastUpdated
 		default:
 			_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("unknown field %s", k)))
+			return
 		}
 	}
 
@@ -196,18 +290,16 @@ func (s *Server) PatchClientOwnedToken(w http.ResponseWriter, r *http.Request, c
 		_ = render.Render(w, r, ErrInternalError(err))
 		return
 	}
-}
 
-func (s *Server) DeleteCredentials(w http.ResponseWriter, r *http.Request, params DeleteCredentialsParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
-
-func (s *Server) GetCredentials(w http.ResponseWriter, r *http.Request, params GetCredentialsParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	s.renderTokenUpdated(w, r)
 }
 
-func (s *Server) PutCredentials(w http.ResponseWriter, r *http.Request, params PutCredentialsParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+func (s *Server) renderTokenUpdated(w http.ResponseWriter, r *http.Request) {
+	_ = render.Render(w, r, OcpiResponse{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+	})
 }
 
 func (s *Server) DeleteReceiverChargingProfile(w http.ResponseWriter, r *http.Request, sessionId string, params DeleteReceiverChargingProfileParams) {
@@ -303,7 +395,52 @@ func (s *Server) PostStopSession(w http.ResponseWriter, r *http.Request, params
 }
 
 func (s *Server) PostUnlockConnector(w http.ResponseWriter, r *http.Request, params PostUnlockConnectorParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	// TODO: as with start session, the following code supports OCPP 1.6 only
+	unlockConnector := new(UnlockConnector)
+	if err := render.Bind(r, unlockConnector); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	chargeStationId, err := extractChargeStationId(unlockConnector.EvseUid)
+	if err != nil {
+		slog.Error("error extracting charge station id", "err", err)
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	connectorId, err := strconv.Atoi(unlockConnector.ConnectorId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	// the result is sent to the response url once the charge station responds
+	err = s.ocpi.SetPendingCommand(r.Context(), &store.OcpiCommand{
+		Command:         commandUnlockConnector,
+		ChargeStationId: chargeStationId,
+		Reference:       strconv.Itoa(connectorId),
+		ResponseUrl:     unlockConnector.ResponseUrl,
+		CountryCode:     params.OCPIFromCountryCode,
+		PartyId:         params.OCPIFromPartyId,
+	})
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	commandResponse := CommandResponse{Result: CommandResponseResultACCEPTED, Timeout: commandTimeout}
+	err = s.v16CallMaker.Send(r.Context(), chargeStationId, &ocpp16.UnlockConnectorJson{
+		ConnectorId: connectorId,
+	})
+	if err != nil {
+		slog.Error("error sending mqtt message", "err", err)
+		commandResponse = CommandResponse{Result: CommandResponseResultREJECTED}
+	}
+	_ = render.Render(w, r, OcpiResponseCommandResponse{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          &commandResponse,
+	})
 }
 
 func (s *Server) GetClientOwnedLocation(w http.ResponseWriter, r *http.Request, countryCode string, partyID string, locationID string, params GetClientOwnedLocationParams) {
@@ -367,7 +504,25 @@ func (s *Server) PutClientOwnedTariff(w http.ResponseWriter, r *http.Request, co
 }
 
 func (s *Server) GetCdrsFromDataOwner(w http.ResponseWriter, r *http.Request, params GetCdrsFromDataOwnerParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	page, err := newPageRequest(params.DateFrom, params.DateTo, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	cdrs, total, err := s.ocpi.GetCdrs(r.Context(), page.dateFrom, page.dateTo, page.offset, page.limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	page.setHeaders(w, r, len(cdrs), total)
+	_ = render.Render(w, r, OcpiResponseCDRList{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          &cdrs,
+	})
 }
 
 func (s *Server) GetCdrPageFromDataOwner(w http.ResponseWriter, r *http.Request, uid string, params GetCdrPageFromDataOwnerParams) {
@@ -379,7 +534,25 @@ func (s *Server) PostAsyncResponse(w http.ResponseWriter, r *http.Request, comma
 }
 
 func (s *Server) GetLocationListFromDataOwner(w http.ResponseWriter, r *http.Request, params GetLocationListFromDataOwnerParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	page, err := newPageRequest(params.DateFrom, params.DateTo, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	locations, total, err := s.ocpi.GetLocations(r.Context(), page.dateFrom, page.dateTo, page.offset, page.limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	page.setHeaders(w, r, len(locations), total)
+	_ = render.Render(w, r, OcpiResponseLocationList{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          &locations,
+	})
 }
 
 func (s *Server) GetLocationPageFromDataOwner(w http.ResponseWriter, r *http.Request, uid string, params GetLocationPageFromDataOwnerParams) {
@@ -387,19 +560,104 @@ func (s *Server) GetLocationPageFromDataOwner(w http.ResponseWriter, r *http.Req
 }
 
 func (s *Server) GetLocationObjectFromDataOwner(w http.ResponseWriter, r *http.Request, locationID string, params GetLocationObjectFromDataOwnerParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	location, err := s.ocpi.GetLocation(r.Context(), locationID)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if location == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, OcpiResponseLocation{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          location,
+	})
 }
 
 func (s *Server) GetEvseObjectFromDataOwner(w http.ResponseWriter, r *http.Request, locationID string, evseUID string, params GetEvseObjectFromDataOwnerParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	evse, err := s.lookupEvse(r.Context(), locationID, evseUID)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if evse == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	_ = render.Render(w, r, OcpiResponseEvse{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          evse,
+	})
 }
 
 func (s *Server) GetConnectorObjectFromDataOwner(w http.ResponseWriter, r *http.Request, locationID string, evseUID string, connectorID string, params GetConnectorObjectFromDataOwnerParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	evse, err := s.lookupEvse(r.Context(), locationID, evseUID)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if evse != nil {
+		for _, connector := range evse.Connectors {
+			if connector.Id == connectorID {
+				connector := connector
+				_ = render.Render(w, r, OcpiResponseConnector{
+					StatusCode:    StatusSuccess,
+					StatusMessage: &StatusSuccessMessage,
+					Timestamp:     s.clock.Now().Format(time.RFC3339),
+					Data:          &connector,
+				})
+				return
+			}
+		}
+	}
+
+	_ = render.Render(w, r, ErrNotFound)
+}
+
+func (s *Server) lookupEvse(ctx context.Context, locationID string, evseUID string) (*Evse, error) {
+	location, err := s.ocpi.GetLocation(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil || location.Evses == nil {
+		return nil, nil
+	}
+	for _, evse := range *location.Evses {
+		if evse.Uid == evseUID {
+			evse := evse
+			return &evse, nil
+		}
+	}
+	return nil, nil
 }
 
 func (s *Server) GetSessionsFromDataOwner(w http.ResponseWriter, r *http.Request, params GetSessionsFromDataOwnerParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	page, err := newPageRequest(params.DateFrom, params.DateTo, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	sessions, total, err := s.ocpi.GetSessions(r.Context(), page.dateFrom, page.dateTo, page.offset, page.limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	page.setHeaders(w, r, len(sessions), total)
+	_ = render.Render(w, r, OcpiResponseSessionList{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          &sessions,
+	})
 }
 
 func (s *Server) GetSessionsPageFromDataOwner(w http.ResponseWriter, r *http.Request, uid string, params GetSessionsPageFromDataOwnerParams) {
@@ -411,7 +669,25 @@ func (s *Server) PutChargingPreferences(w http.ResponseWriter, r *http.Request,
 }
 
 func (s *Server) GetTariffsFromDataOwner(w http.ResponseWriter, r *http.Request, params GetTariffsFromDataOwnerParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	page, err := newPageRequest(params.DateFrom, params.DateTo, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	tariffs, total, err := s.ocpi.GetTariffs(r.Context(), page.dateFrom, page.dateTo, page.offset, page.limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	page.setHeaders(w, r, len(tariffs), total)
+	_ = render.Render(w, r, OcpiResponseTariffList{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          &tariffs,
+	})
 }
 
 func (s *Server) GetTariffsPageFromDataOwner(w http.ResponseWriter, r *http.Request, uid string, params GetTariffsPageFromDataOwnerParams) {
@@ -419,7 +695,25 @@ func (s *Server) GetTariffsPageFromDataOwner(w http.ResponseWriter, r *http.Requ
 }
 
 func (s *Server) GetTokensFromDataOwner(w http.ResponseWriter, r *http.Request, params GetTokensFromDataOwnerParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	page, err := newPageRequest(params.DateFrom, params.DateTo, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	tokens, total, err := s.ocpi.GetTokens(r.Context(), page.dateFrom, page.dateTo, page.offset, page.limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	page.setHeaders(w, r, len(tokens), total)
+	_ = render.Render(w, r, OcpiResponseTokenList{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          &tokens,
+	})
 }
 
 func (s *Server) GetTokensPageFromDataOwner(w http.ResponseWriter, r *http.Request, uid string, params GetTokensPageFromDataOwnerParams) {
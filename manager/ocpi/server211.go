@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Handler211 returns the handler for the 2.1.1 interfaces, which are served alongside the
+// 2.2 interfaces generated from the OpenAPI specification. The handler is mounted at
+// /ocpi/2.1.1 and requests must already have been authenticated.
+func Handler211(s *Server) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/", s.GetVersion211)
+	r.Route("/credentials", func(r chi.Router) {
+		r.Post("/", s.PostCredentials211)
+		r.Put("/", s.PutCredentials211)
+		r.Get("/", s.GetCredentials211)
+		r.Delete("/", s.DeleteCredentials211)
+	})
+	r.Route("/tokens/{country_code}/{party_id}/{token_uid}", func(r chi.Router) {
+		r.Get("/", s.GetToken211)
+		r.Put("/", s.PutToken211)
+		r.Patch("/", s.PatchToken211)
+	})
+	r.Get("/sessions", s.GetSessions211)
+	r.Get("/cdrs", s.GetCdrs211)
+	return r
+}
+
+// GetVersion221 returns the endpoints for version 2.2.1, which are those of version 2.2
+func (s *Server) GetVersion221(w http.ResponseWriter, r *http.Request) {
+	version, err := s.ocpi.GetVersion(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	version.Version = Version221
+	_ = render.Render(w, r, OcpiResponseVersionDetail{
+		StatusCode:    StatusSuccess,
+		Data:          &version,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		StatusMessage: &StatusSuccessMessage,
+	})
+}
+
+func (s *Server) GetVersion211(w http.ResponseWriter, r *http.Request) {
+	version, err := s.ocpi.GetVersion211(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	s.render211(w, r, version)
+}
+
+// CREDENTIALS
+
+func (s *Server) PostCredentials211(w http.ResponseWriter, r *http.Request) {
+	s.exchangeCredentials211(w, r, s.ocpi.SetCredentials211)
+}
+
+func (s *Server) PutCredentials211(w http.ResponseWriter, r *http.Request) {
+	s.exchangeCredentials211(w, r, s.ocpi.UpdateCredentials211)
+}
+
+func (s *Server) exchangeCredentials211(w http.ResponseWriter, r *http.Request,
+	exchange func(ctx context.Context, token string, credentials Credentials211) (Credentials211, error)) {
+	creds := new(Credentials211)
+	if err := render.Bind(r, creds); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	token, err := tokenFromAuthorization(r.Header.Get("Authorization"))
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	newCreds, err := exchange(r.Context(), token, *creds)
+	if err != nil {
+		s.renderCredentialsError(w, r, err)
+		return
+	}
+
+	s.render211(w, r, newCreds)
+}
+
+func (s *Server) GetCredentials211(w http.ResponseWriter, r *http.Request) {
+	token, err := tokenFromAuthorization(r.Header.Get("Authorization"))
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	creds, err := s.ocpi.GetCredentials(r.Context(), token)
+	if err != nil {
+		s.renderCredentialsError(w, r, err)
+		return
+	}
+
+	s.render211(w, r, newCredentials211(creds))
+}
+
+func (s *Server) DeleteCredentials211(w http.ResponseWriter, r *http.Request) {
+	s.DeleteCredentials(w, r, DeleteCredentialsParams{Authorization: r.Header.Get("Authorization")})
+}
+
+// TOKENS
+
+func (s *Server) GetToken211(w http.ResponseWriter, r *http.Request) {
+	token, err := s.ocpi.GetToken(r.Context(), chi.URLParam(r, "country_code"), chi.URLParam(r, "party_id"),
+		chi.URLParam(r, "token_uid"))
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if token == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	s.render211(w, r, newToken211(*token))
+}
+
+func (s *Server) PutToken211(w http.ResponseWriter, r *http.Request) {
+	tok := new(Token211)
+	if err := render.Bind(r, tok); err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	if tok.Uid != chi.URLParam(r, "token_uid") {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("token uid mismatch")))
+		return
+	}
+
+	err := s.ocpi.SetToken(r.Context(), tokenFrom211(chi.URLParam(r, "country_code"), chi.URLParam(r, "party_id"), *tok))
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	s.renderTokenUpdated(w, r)
+}
+
+func (s *Server) PatchToken211(w http.ResponseWriter, r *http.Request) {
+	var patch map[string]any
+	err := json.NewDecoder(r.Body).Decode(&patch)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	tok, err := s.ocpi.GetToken(r.Context(), chi.URLParam(r, "country_code"), chi.URLParam(r, "party_id"),
+		chi.URLParam(r, "token_uid"))
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if tok == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	for k, v := range patch {
+		switch k {
+		case "auth_id":
+			tok.ContractId = v.(string)
+		case "issuer":
+			tok.Issuer = v.(string)
+		case "language":
+			language := v.(string)
+			tok.Language = &language
+		case "type":
+			tok.Type = TokenType(v.(string))
+		case "valid":
+			tok.Valid = v.(bool)
+		case "visual_number":
+			visualNumber := v.(string)
+			tok.VisualNumber = &visualNumber
+		case "whitelist":
+			tok.Whitelist = TokenWhitelist(v.(string))
+		case "last_updated":
+			tok.LastUpdated = v.(string)
+		default:
+			_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("unknown field %s", k)))
+			return
+		}
+	}
+
+	err = s.ocpi.SetToken(r.Context(), *tok)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	s.renderTokenUpdated(w, r)
+}
+
+// SESSIONS AND CDRS
+
+func (s *Server) GetSessions211(w http.ResponseWriter, r *http.Request) {
+	page, err := newPageRequestFromQuery(r)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	sessions, total, err := s.ocpi.GetSessions(r.Context(), page.dateFrom, page.dateTo, page.offset, page.limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	sessions211 := make([]Session211, len(sessions))
+	for i, session := range sessions {
+		location, err := s.ocpi.GetLocation(r.Context(), session.LocationId)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		sessions211[i] = newSession211(session, location)
+	}
+
+	page.setHeaders(w, r, len(sessions), total)
+	s.render211(w, r, sessions211)
+}
+
+func (s *Server) GetCdrs211(w http.ResponseWriter, r *http.Request) {
+	page, err := newPageRequestFromQuery(r)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	cdrs, total, err := s.ocpi.GetCdrs(r.Context(), page.dateFrom, page.dateTo, page.offset, page.limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	cdrs211 := make([]CDR211, len(cdrs))
+	for i, cdr := range cdrs {
+		location, err := s.ocpi.GetLocation(r.Context(), cdr.CdrLocation.Id)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		cdrs211[i] = newCdr211(cdr, location)
+	}
+
+	page.setHeaders(w, r, len(cdrs), total)
+	s.render211(w, r, cdrs211)
+}
+
+func (s *Server) render211(w http.ResponseWriter, r *http.Request, data any) {
+	_ = render.Render(w, r, ocpiResponse[any]{
+		StatusCode:    StatusSuccess,
+		StatusMessage: &StatusSuccessMessage,
+		Timestamp:     s.clock.Now().Format(time.RFC3339),
+		Data:          &data,
+	})
+}
+
+// newPageRequestFromQuery reads the pagination parameters from the query: the 2.1.1
+// interfaces are not validated against the OpenAPI specification
+func newPageRequestFromQuery(r *http.Request) (pageRequest, error) {
+	query := r.URL.Query()
+
+	var dateFrom, dateTo *string
+	if query.Has("date_from") {
+		value := query.Get("date_from")
+		dateFrom = &value
+	}
+	if query.Has("date_to") {
+		value := query.Get("date_to")
+		dateTo = &value
+	}
+
+	var offset, limit *int32
+	for name, param := range map[string]**int32{"offset": &offset, "limit": &limit} {
+		if !query.Has(name) {
+			continue
+		}
+		value, err := strconv.ParseInt(query.Get(name), 10, 32)
+		if err != nil {
+			return pageRequest{}, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		v := int32(value)
+		*param = &v
+	}
+
+	return newPageRequest(dateFrom, dateTo, offset, limit)
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func new211Request(method, path, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Token 123")
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func decode211Response[T any](t *testing.T, resp *http.Response) T {
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var got struct {
+		Data       *T    `json:"data"`
+		StatusCode int32 `json:"status_code"`
+	}
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, ocpi.StatusSuccess, got.StatusCode)
+	require.NotNil(t, got.Data)
+	return *got.Data
+}
+
+func TestServerGetVersion211(t *testing.T) {
+	handler, _, _ := setupHandler(t)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, new211Request(http.MethodGet, "/ocpi/2.1.1", ""))
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	got := decode211Response[ocpi.VersionDetail211](t, resp)
+	assert.Equal(t, "2.1.1", got.Version)
+	assert.Contains(t, got.Endpoints, ocpi.Endpoint211{Identifier: "tokens", Url: "/ocpi/2.1.1/tokens"})
+}
+
+func TestServerPutAndGetToken211(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, new211Request(http.MethodPut, "/ocpi/2.1.1/tokens/NL/EMS/DEADBEEF", `{
+		"uid": "DEADBEEF",
+		"type": "RFID",
+		"auth_id": "NLEMSC00000001",
+		"issuer": "Example eMSP",
+		"valid": true,
+		"whitelist": "ALLOWED",
+		"last_updated": "2023-06-01T00:00:00Z"
+	}`))
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	tok, err := engine.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+	require.NotNil(t, tok)
+	assert.Equal(t, "NL", tok.CountryCode)
+	assert.Equal(t, "EMS", tok.PartyId)
+	assert.Equal(t, "NLEMSC00000001", tok.ContractId)
+	assert.Equal(t, "ALLOWED", tok.CacheMode)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, new211Request(http.MethodPatch, "/ocpi/2.1.1/tokens/NL/EMS/DEADBEEF", `{"valid": false}`))
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, new211Request(http.MethodGet, "/ocpi/2.1.1/tokens/NL/EMS/DEADBEEF", ""))
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	got := decode211Response[ocpi.Token211](t, resp)
+	// the store records when the token was last updated
+	assert.NotEmpty(t, got.LastUpdated)
+	got.LastUpdated = ""
+	assert.Equal(t, ocpi.Token211{
+		AuthId:    "NLEMSC00000001",
+		Issuer:    "Example eMSP",
+		Type:      ocpi.TokenTypeRFID,
+		Uid:       "DEADBEEF",
+		Valid:     false,
+		Whitelist: ocpi.ALLOWED,
+	}, got)
+}
+
+func TestServerPutToken211RejectsUidMismatch(t *testing.T) {
+	handler, _, _ := setupHandler(t)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, new211Request(http.MethodPut, "/ocpi/2.1.1/tokens/NL/EMS/DEADBEEF",
+		`{"uid": "CAFEBABE", "type": "RFID", "auth_id": "x", "issuer": "x", "valid": true, "whitelist": "ALLOWED"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestServerGetSessions211(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+	setLocations(t, engine, "loc001")
+
+	energyRegister := "Energy.Active.Import.Register"
+	err := engine.CreateTransaction(context.Background(), "cs000", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{
+			{
+				Timestamp:     "2023-06-01T00:00:00Z",
+				SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: 0}},
+			},
+		}, 0, false)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, new211Request(http.MethodGet, "/ocpi/2.1.1/sessions?date_from=2023-05-01T00:00:00Z&limit=10", ""))
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t, "10", resp.Header.Get("X-Limit"))
+
+	got := decode211Response[[]ocpi.Session211](t, resp)
+	require.Len(t, got, 1)
+	assert.Equal(t, "tx001", got[0].Id)
+	assert.Equal(t, "DEADBEEF", got[0].AuthId)
+	assert.Equal(t, "loc001", got[0].Location.Id)
+	require.Len(t, got[0].Location.Evses, 1)
+	assert.Equal(t, "BEBECEcs000", got[0].Location.Evses[0].Uid)
+}
+
+func TestServerGetSessions211WithInvalidLimit(t *testing.T) {
+	handler, _, _ := setupHandler(t)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, new211Request(http.MethodGet, "/ocpi/2.1.1/sessions?limit=many", ""))
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,6 +40,7 @@ func setupHandler(t *testing.T) (http.Handler, store.Engine, time.Time) {
 	require.NoError(t, err)
 
 	r := chi.NewRouter()
+	r.Mount("/ocpi/2.1.1", ocpi.Handler211(server))
 	r.Mount("/", ocpi.Handler(server))
 	return r, engine, now
 }
@@ -56,10 +58,18 @@ func TestServerGetVersions(t *testing.T) {
 
 	want := ocpi.OcpiResponseListVersion{
 		Data: &[]ocpi.Version{
+			{
+				Version: "2.1.1",
+				Url:     "/ocpi/2.1.1",
+			},
 			{
 				Version: "2.2",
 				Url:     "/ocpi/2.2",
 			},
+			{
+				Version: "2.2.1",
+				Url:     "/ocpi/2.2.1",
+			},
 		},
 		StatusCode:    ocpi.StatusSuccess,
 		StatusMessage: &ocpi.StatusSuccessMessage,
@@ -102,6 +112,26 @@ func TestServerGetVersion(t *testing.T) {
 					Url:        "/ocpi/receiver/2.2/tokens/",
 					Role:       ocpi.RECEIVER,
 				},
+				{
+					Identifier: "locations",
+					Url:        "/ocpi/sender/2.2/locations",
+					Role:       ocpi.SENDER,
+				},
+				{
+					Identifier: "sessions",
+					Url:        "/ocpi/sender/2.2/sessions",
+					Role:       ocpi.SENDER,
+				},
+				{
+					Identifier: "cdrs",
+					Url:        "/ocpi/sender/2.2/cdrs",
+					Role:       ocpi.SENDER,
+				},
+				{
+					Identifier: "tariffs",
+					Url:        "/ocpi/sender/2.2/tariffs",
+					Role:       ocpi.SENDER,
+				},
 			},
 			Version: "2.2",
 		},
@@ -211,6 +241,7 @@ func TestServerPutClientOwnedToken(t *testing.T) {
 		Issuer:      "Thoughtworks",
 		Valid:       true,
 		CacheMode:   "ALWAYS",
+		Version:     1,
 	}
 
 	got, err := engine.LookupToken(context.Background(), "DEADBEEF")
@@ -263,6 +294,7 @@ func TestServerPatchClientOwnedToken(t *testing.T) {
 		Issuer:      "TW",
 		Valid:       false,
 		CacheMode:   "NEVER",
+		Version:     2,
 	}
 
 	got, err := engine.LookupToken(context.Background(), "DEADBEEF")
@@ -331,6 +363,380 @@ func TestPostStartSession(t *testing.T) {
 	assert.Equal(t, ocpi.CommandResponseResultACCEPTED, ocpiResponseCommandResponse.Data.Result)
 }
 
+func TestPostUnlockConnector(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/ocpi/receiver/2.2/commands/UNLOCK_CONNECTOR",
+		strings.NewReader(`{
+			"response_url": "https://example.com/ocpi/receiver/2.2/commands/UNLOCK_CONNECTOR/12345",
+			"location_id": "loc001",
+			"evse_uid": "BEBECE041503001",
+			"connector_id": "2"
+		}`))
+	req.Header.Set("Authorization", "Token 123")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "123")
+	req.Header.Set("X-Correlation-ID", "123")
+	req.Header.Set("OCPI-from-country-code", "GB")
+	req.Header.Set("OCPI-from-party-id", "TWE")
+	req.Header.Set("OCPI-to-country-code", "GB")
+	req.Header.Set("OCPI-to-party-id", "TWK")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var ocpiResponseCommandResponse ocpi.OcpiResponseCommandResponse
+	err := json.NewDecoder(resp.Body).Decode(&ocpiResponseCommandResponse)
+	require.NoError(t, err)
+	assert.Equal(t, ocpi.StatusSuccess, ocpiResponseCommandResponse.StatusCode)
+	require.NotNil(t, ocpiResponseCommandResponse.Data)
+	assert.Equal(t, ocpi.CommandResponseResultACCEPTED, ocpiResponseCommandResponse.Data.Result)
+	assert.Equal(t, int32(30), ocpiResponseCommandResponse.Data.Timeout)
+
+	got, err := engine.LookupPendingCommand(context.Background(), "UNLOCK_CONNECTOR", "041503001", "2")
+	require.NoError(t, err)
+	assert.Equal(t, &store.OcpiCommand{
+		Command:         "UNLOCK_CONNECTOR",
+		ChargeStationId: "041503001",
+		Reference:       "2",
+		ResponseUrl:     "https://example.com/ocpi/receiver/2.2/commands/UNLOCK_CONNECTOR/12345",
+		CountryCode:     "GB",
+		PartyId:         "TWE",
+	}, got)
+}
+
+func setLocations(t *testing.T, engine store.Engine, ids ...string) {
+	for i, id := range ids {
+		err := engine.SetLocation(context.Background(), &store.Location{
+			Address: "F.Rooseveltlaan 3A",
+			City:    "Gent",
+			Coordinates: store.GeoLocation{
+				Latitude:  "51.047599",
+				Longitude: "3.729944",
+			},
+			Country: "BEL",
+			Evses: &[]store.Evse{
+				{
+					Connectors: []store.Connector{
+						{
+							Format:      "SOCKET",
+							Id:          "1",
+							MaxAmperage: 16,
+							MaxVoltage:  230,
+							PowerType:   "AC_1_PHASE",
+							Standard:    "IEC_62196_T2",
+							LastUpdated: "2023-06-01T00:00:00Z",
+						},
+					},
+					Status:      "AVAILABLE",
+					Uid:         fmt.Sprintf("BEBECEcs%03d", i),
+					LastUpdated: "2023-06-01T00:00:00Z",
+				},
+			},
+			Id:          id,
+			LastUpdated: fmt.Sprintf("2023-06-%02dT00:00:00Z", i+1),
+			Name:        "Gent Zuid",
+			ParkingType: "ON_STREET",
+			PostalCode:  "9000",
+		})
+		require.NoError(t, err)
+	}
+}
+
+func newSenderRequest(path string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Token 123")
+	req.Header.Set("X-Request-ID", "123")
+	req.Header.Set("X-Correlation-ID", "123")
+	req.Header.Set("OCPI-from-country-code", "GB")
+	req.Header.Set("OCPI-from-party-id", "TWK")
+	req.Header.Set("OCPI-to-country-code", "GB")
+	req.Header.Set("OCPI-to-party-id", "TWK")
+	return req
+}
+
+func TestServerGetLocationList(t *testing.T) {
+	handler, engine, now := setupHandler(t)
+	setLocations(t, engine, "loc001", "loc002", "loc003")
+
+	req := newSenderRequest("/ocpi/sender/2.2/locations?date_from=2023-06-02T00:00:00Z&limit=1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t, "1", resp.Header.Get("X-Limit"))
+	assert.Equal(t, `<http://example.com/ocpi/sender/2.2/locations?date_from=2023-06-02T00%3A00%3A00Z&limit=1&offset=1>; rel="next"`,
+		resp.Header.Get("Link"))
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var got ocpi.OcpiResponseLocationList
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, ocpi.StatusSuccess, got.StatusCode)
+	assert.Equal(t, now.Format(time.RFC3339), got.Timestamp)
+	require.NotNil(t, got.Data)
+	require.Len(t, *got.Data, 1)
+	loc := (*got.Data)[0]
+	assert.Equal(t, "loc002", loc.Id)
+	assert.Equal(t, "GB", loc.CountryCode)
+	assert.Equal(t, "TWK", loc.PartyId)
+	assert.True(t, loc.Publish)
+	require.NotNil(t, loc.Evses)
+	assert.Equal(t, ocpi.EvseStatusAVAILABLE, (*loc.Evses)[0].Status)
+
+	req = newSenderRequest("/ocpi/sender/2.2/locations?date_from=2023-06-02T00:00:00Z&limit=1&offset=1")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp = w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("Link"))
+	b, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+	require.NotNil(t, got.Data)
+	require.Len(t, *got.Data, 1)
+	assert.Equal(t, "loc003", (*got.Data)[0].Id)
+}
+
+func TestServerGetLocationObjects(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+	setLocations(t, engine, "loc001")
+
+	tests := map[string]struct {
+		path   string
+		status int
+		want   string
+	}{
+		"location": {
+			path:   "/ocpi/sender/2.2/locations/loc001",
+			status: http.StatusOK,
+			want:   `"id":"loc001"`,
+		},
+		"evse": {
+			path:   "/ocpi/sender/2.2/locations/loc001/BEBECEcs000",
+			status: http.StatusOK,
+			want:   `"uid":"BEBECEcs000"`,
+		},
+		"connector": {
+			path:   "/ocpi/sender/2.2/locations/loc001/BEBECEcs000/1",
+			status: http.StatusOK,
+			want:   `"standard":"IEC_62196_T2"`,
+		},
+		"unknown location": {
+			path:   "/ocpi/sender/2.2/locations/loc002",
+			status: http.StatusNotFound,
+		},
+		"unknown evse": {
+			path:   "/ocpi/sender/2.2/locations/loc001/BEBECEcs001",
+			status: http.StatusNotFound,
+		},
+		"unknown connector": {
+			path:   "/ocpi/sender/2.2/locations/loc001/BEBECEcs000/2",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newSenderRequest(tc.path))
+			resp := w.Result()
+			assert.Equal(t, tc.status, resp.StatusCode)
+			if tc.want != "" {
+				b, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Contains(t, string(b), tc.want)
+			}
+		})
+	}
+}
+
+func TestServerGetSessions(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+	setLocations(t, engine, "loc001")
+
+	energyRegister := "Energy.Active.Import.Register"
+	for i, transactionId := range []string{"tx001", "tx002", "tx003"} {
+		err := engine.CreateTransaction(context.Background(), "cs000", transactionId, "DEADBEEF", "ISO14443",
+			[]store.MeterValue{
+				{
+					Timestamp:     fmt.Sprintf("2023-06-%02dT00:00:00Z", i+1),
+					SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: 0}},
+				},
+			}, 0, false)
+		require.NoError(t, err)
+	}
+	// transactions on charge stations that are not part of a location are not sessions
+	err := engine.CreateTransaction(context.Background(), "cs999", "tx999", "DEADBEEF", "ISO14443", nil, 0, false)
+	require.NoError(t, err)
+
+	req := newSenderRequest("/ocpi/sender/2.2/sessions?date_from=2023-06-02T00:00:00Z&limit=1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t, "1", resp.Header.Get("X-Limit"))
+	assert.Contains(t, resp.Header.Get("Link"), "offset=1")
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var got ocpi.OcpiResponseSessionList
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	require.NotNil(t, got.Data)
+	require.Len(t, *got.Data, 1)
+	session := (*got.Data)[0]
+	assert.Equal(t, "tx002", session.Id)
+	assert.Equal(t, "loc001", session.LocationId)
+	assert.Equal(t, "BEBECEcs000", session.EvseUid)
+	assert.Equal(t, "1", session.ConnectorId)
+	assert.Equal(t, ocpi.SessionStatusACTIVE, session.Status)
+}
+
+func TestServerGetSessionsWithInvalidDate(t *testing.T) {
+	handler, _, _ := setupHandler(t)
+
+	req := newSenderRequest("/ocpi/sender/2.2/sessions?date_from=yesterday")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestServerGetCdrs(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+
+	for i, cdrId := range []string{"tx001", "tx002", "tx003"} {
+		err := engine.SetChargeDetailRecord(context.Background(), &store.ChargeDetailRecord{
+			Id:              cdrId,
+			ChargeStationId: "cs000",
+			TransactionId:   cdrId,
+			IdToken:         "DEADBEEF",
+			TokenType:       "ISO14443",
+			LocationId:      "loc001",
+			EvseUid:         "BEBECEcs000",
+			ConnectorId:     "1",
+			StartDateTime:   fmt.Sprintf("2023-06-%02dT00:00:00Z", i+1),
+			EndDateTime:     fmt.Sprintf("2023-06-%02dT01:00:00Z", i+1),
+			TotalEnergy:     10,
+			TotalTime:       1,
+			TotalCost:       5.5,
+			Currency:        "EUR",
+			LastUpdated:     fmt.Sprintf("2023-06-%02dT01:00:00Z", i+1),
+		})
+		require.NoError(t, err)
+	}
+
+	req := newSenderRequest("/ocpi/sender/2.2/cdrs?date_from=2023-06-02T00:00:00Z&limit=1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t, "1", resp.Header.Get("X-Limit"))
+	assert.Contains(t, resp.Header.Get("Link"), "offset=1")
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var got ocpi.OcpiResponseCDRList
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	require.NotNil(t, got.Data)
+	require.Len(t, *got.Data, 1)
+	cdr := (*got.Data)[0]
+	assert.Equal(t, "tx002", cdr.Id)
+	assert.Equal(t, "loc001", cdr.CdrLocation.Id)
+	assert.Equal(t, float32(10), cdr.TotalEnergy)
+	assert.Equal(t, float32(5.5), cdr.TotalCost.ExclVat)
+}
+
+func TestServerGetTariffs(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+
+	for i, tariffId := range []string{"tariff001", "tariff002", "tariff003"} {
+		err := engine.SetTariff(context.Background(), &store.Tariff{
+			Id:       tariffId,
+			Currency: "EUR",
+			Elements: []store.TariffElement{
+				{PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.5, StepSize: 1}}},
+			},
+			LastUpdated: fmt.Sprintf("2023-06-%02dT00:00:00Z", i+1),
+		})
+		require.NoError(t, err)
+	}
+
+	req := newSenderRequest("/ocpi/sender/2.2/tariffs?date_from=2023-06-02T00:00:00Z&limit=1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t, "1", resp.Header.Get("X-Limit"))
+	assert.Contains(t, resp.Header.Get("Link"), "offset=1")
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var got ocpi.OcpiResponseTariffList
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	require.NotNil(t, got.Data)
+	require.Len(t, *got.Data, 1)
+	tariff := (*got.Data)[0]
+	assert.Equal(t, "tariff002", tariff.Id)
+	assert.Equal(t, "GB", tariff.CountryCode)
+	assert.Equal(t, "TWK", tariff.PartyId)
+	require.Len(t, tariff.Elements, 1)
+	assert.Equal(t, float32(0.5), tariff.Elements[0].PriceComponents[0].Price)
+}
+
+func TestServerGetTokensAcrossStorePages(t *testing.T) {
+	handler, engine, _ := setupHandler(t)
+
+	// more tokens than are read from the store at a time
+	for i := 0; i < 60; i++ {
+		err := engine.SetToken(context.Background(), &store.Token{
+			CountryCode: "GB",
+			PartyId:     "TWK",
+			Type:        "RFID",
+			Uid:         fmt.Sprintf("token%03d", i),
+			ContractId:  fmt.Sprintf("GBTWK%09d", i),
+			Issuer:      "Thoughtworks",
+			Valid:       true,
+			CacheMode:   "ALWAYS",
+			LastUpdated: "2023-06-01T00:00:00Z",
+		})
+		require.NoError(t, err)
+	}
+
+	req := newSenderRequest("/ocpi/sender/2.2/tokens?offset=50&limit=5")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "60", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t, "5", resp.Header.Get("X-Limit"))
+	assert.Contains(t, resp.Header.Get("Link"), "offset=55")
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var got ocpi.OcpiResponseTokenList
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+
+	require.NotNil(t, got.Data)
+	require.Len(t, *got.Data, 5)
+	assert.Equal(t, "GBTWK000000050", (*got.Data)[0].ContractId)
+	assert.Equal(t, ocpi.ALWAYS, (*got.Data)[0].Whitelist)
+}
+
 func newNoopV16CallMaker() *handlers.OcppCallMaker {
 	emitter := transport.EmitterFunc(func(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
 		return nil
@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// currency is the currency reported for sessions and charge detail records: the tariff
+// services do not yet distinguish between currencies
+const currency = "EUR"
+
+// evseLocation identifies the OCPI location and EVSE that represent a charge station
+type evseLocation struct {
+	locationId  string
+	evseUid     string
+	connectorId string
+}
+
+// GetSessions returns the sessions that were last updated between dateFrom (inclusive)
+// and dateTo (exclusive), ordered by the time they were last updated, skipping the
+// first offset sessions and returning at most limit sessions. The total number of
+// matching sessions is also returned so that the caller can paginate.
+func (o *OCPI) GetSessions(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]Session, int, error) {
+	transactions, err := o.store.Transactions(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	evses, err := o.chargeStationEvses(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var matching []Session
+	for _, transaction := range transactions {
+		evse, ok := evses[transaction.ChargeStationId]
+		if !ok {
+			continue
+		}
+		session, err := o.newSession(ctx, transaction, evse)
+		if err != nil {
+			return nil, 0, err
+		}
+		if inDateRange(session.LastUpdated, dateFrom, dateTo) {
+			matching = append(matching, session)
+		}
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		if matching[i].LastUpdated != matching[j].LastUpdated {
+			return matching[i].LastUpdated < matching[j].LastUpdated
+		}
+		return matching[i].Id < matching[j].Id
+	})
+
+	return paginate(matching, offset, limit), len(matching), nil
+}
+
+// TransactionChanged pushes the session that represents the transaction to the eMSPs in
+// the format of the version agreed with each of them. Transactions on charge stations that are not part of a location are not published.
+func (o *OCPI) TransactionChanged(ctx context.Context, transaction *store.Transaction) error {
+	evses, err := o.chargeStationEvses(ctx)
+	if err != nil {
+		return err
+	}
+	evse, ok := evses[transaction.ChargeStationId]
+	if !ok {
+		return nil
+	}
+
+	session, err := o.newSession(ctx, transaction, evse)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := o.broadcastRecipients(ctx)
+	if err != nil {
+		return err
+	}
+	var session211 *Session211
+	for _, r := range recipients {
+		sessionsUrl, err := o.getPartyReceiverUrl(ctx, r.OcpiParty, "sessions")
+		if err != nil {
+			return err
+		}
+		var body any = session
+		if uses211(r.OcpiParty) {
+			if session211 == nil {
+				location, err := o.GetLocation(ctx, session.LocationId)
+				if err != nil {
+					return err
+				}
+				s := newSession211(session, location)
+				session211 = &s
+			}
+			body = session211
+		}
+		err = o.client.Push(ctx, r.OcpiParty, r.countryCode, r.partyId, http.MethodPut,
+			fmt.Sprintf("%s/%s", sessionsUrl, session.Id), body)
+		if err != nil {
+			return err
+		}
+	}
+
+	if transaction.EndedSeqNo != 0 {
+		return o.transactionEnded(ctx, transaction, evse)
+	}
+
+	return nil
+}
+
+// chargeStationEvses maps each charge station to the location and EVSE that represent it
+func (o *OCPI) chargeStationEvses(ctx context.Context) (map[string]evseLocation, error) {
+	locations, err := o.listAllLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	evses := make(map[string]evseLocation)
+	for _, loc := range locations {
+		if loc.Evses == nil {
+			continue
+		}
+		for _, evse := range *loc.Evses {
+			chargeStationId, err := extractChargeStationId(evse.Uid)
+			if err != nil {
+				continue
+			}
+			connectorId := "1"
+			if len(evse.Connectors) > 0 {
+				connectorId = evse.Connectors[0].Id
+			}
+			evses[chargeStationId] = evseLocation{
+				locationId:  loc.Id,
+				evseUid:     evse.Uid,
+				connectorId: connectorId,
+			}
+		}
+	}
+	return evses, nil
+}
+
+func (o *OCPI) newSession(ctx context.Context, transaction *store.Transaction, evse evseLocation) (Session, error) {
+	contractId, err := o.lookupContractId(ctx, transaction.IdToken)
+	if err != nil {
+		return Session{}, err
+	}
+
+	start, end := transactionTimes(transaction)
+
+	session := Session{
+		AuthMethod: SessionAuthMethodWHITELIST,
+		CdrToken: CdrToken{
+			ContractId: contractId,
+			Type:       cdrTokenType(transaction.TokenType),
+			Uid:        transaction.IdToken,
+		},
+		ConnectorId:   evse.connectorId,
+		CountryCode:   o.countryCode,
+		Currency:      currency,
+		EvseUid:       evse.evseUid,
+		Id:            transaction.TransactionId,
+		Kwh:           float32(transactionEnergy(transaction) / 1000),
+		LastUpdated:   formatTime(end),
+		LocationId:    evse.locationId,
+		PartyId:       o.partyId,
+		StartDateTime: formatTime(start),
+		Status:        SessionStatusACTIVE,
+	}
+	if transaction.EndedSeqNo != 0 {
+		endDateTime := formatTime(end)
+		session.EndDateTime = &endDateTime
+		session.Status = SessionStatusCOMPLETED
+	}
+
+	return session, nil
+}
+
+// lookupContractId returns the contract id of the token: the token itself is used if it
+// is not known
+func (o *OCPI) lookupContractId(ctx context.Context, idToken string) (string, error) {
+	if idToken == "" {
+		return "", nil
+	}
+	tok, err := o.store.LookupToken(ctx, idToken)
+	if err != nil {
+		return "", err
+	}
+	if tok == nil {
+		return idToken, nil
+	}
+	return tok.ContractId, nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// transactionTimes returns the earliest and latest times recorded against the transaction
+func transactionTimes(transaction *store.Transaction) (start, end time.Time) {
+	var timestamps []string
+	for _, meterValue := range transaction.MeterValues {
+		timestamps = append(timestamps, meterValue.Timestamp)
+	}
+	for _, chargingState := range transaction.ChargingStates {
+		timestamps = append(timestamps, chargingState.Timestamp)
+	}
+
+	for _, timestamp := range timestamps {
+		ts, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			continue
+		}
+		if start.IsZero() || ts.Before(start) {
+			start = ts
+		}
+		if end.IsZero() || ts.After(end) {
+			end = ts
+		}
+	}
+	return start, end
+}
+
+// transactionEnergy returns the energy delivered during the transaction in Wh. Once the
+// transaction has ended this is the outlet reading used for billing, before then it is
+// the increase in the energy register since the start of the transaction.
+func transactionEnergy(transaction *store.Transaction) float64 {
+	meterValues := make([]store.MeterValue, len(transaction.MeterValues))
+	copy(meterValues, transaction.MeterValues)
+	store.SortMeterValues(meterValues)
+
+	var first, last float64
+	found := false
+	for _, meterValue := range meterValues {
+		for _, sv := range meterValue.SampledValues {
+			if sv.Measurand == nil || *sv.Measurand != "Energy.Active.Import.Register" {
+				continue
+			}
+			if sv.Context != nil && *sv.Context == "Transaction.End" &&
+				sv.Location != nil && *sv.Location == "Outlet" {
+				return sv.Value
+			}
+			value := energyInWh(sv)
+			if !found {
+				first = value
+				found = true
+			}
+			last = value
+		}
+	}
+	return last - first
+}
+
+func energyInWh(sv store.SampledValue) float64 {
+	value := sv.Value
+	if sv.UnitOfMeasure != nil {
+		value *= math.Pow10(sv.UnitOfMeasure.Multipler)
+		if sv.UnitOfMeasure.Unit == "kWh" {
+			value *= 1000
+		}
+	}
+	return value
+}
+
+func cdrTokenType(tokenType string) CdrTokenType {
+	switch tokenType {
+	case "ISO14443", "ISO15693", "RFID":
+		return CdrTokenTypeRFID
+	case "eMAID", "Central", "APP_USER":
+		return CdrTokenTypeAPPUSER
+	case "AD_HOC_USER":
+		return CdrTokenTypeADHOCUSER
+	default:
+		return CdrTokenTypeOTHER
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"time"
+)
+
+// GetTariffs returns the tariffs that were last updated between dateFrom (inclusive)
+// and dateTo (exclusive), skipping the first offset tariffs and returning at most limit
+// tariffs. The total number of matching tariffs is also returned so that the caller can
+// paginate.
+func (o *OCPI) GetTariffs(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]Tariff, int, error) {
+	return listPage(ctx, o.store.ListTariffs,
+		func(tariff *store.Tariff) string { return tariff.LastUpdated },
+		func(tariff *store.Tariff) Tariff { return newTariff(o.countryCode, o.partyId, tariff) },
+		dateFrom, dateTo, offset, limit)
+}
+
+// PushTariff sends the tariff to the eMSPs. Tariffs are not sent to eMSPs using 2.1.1:
+// the 2.1.1 tariff can't express the restrictions this CSMS uses.
+func (o *OCPI) PushTariff(ctx context.Context, tariff *store.Tariff) error {
+	recipients, err := o.broadcastRecipients(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range recipients {
+		if uses211(r.OcpiParty) {
+			continue
+		}
+		tariffsUrl, err := o.getPartyReceiverUrl(ctx, r.OcpiParty, "tariffs")
+		if err != nil {
+			return err
+		}
+		err = o.client.Push(ctx, r.OcpiParty, r.countryCode, r.partyId, http.MethodPut,
+			fmt.Sprintf("%s/%s", tariffsUrl, tariff.Id), newTariff(o.countryCode, o.partyId, tariff))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newTariff(countryCode, partyId string, tariff *store.Tariff) Tariff {
+	elements := make([]TariffElement, len(tariff.Elements))
+	for i, element := range tariff.Elements {
+		components := make([]PriceComponent, len(element.PriceComponents))
+		for j, component := range element.PriceComponents {
+			components[j] = PriceComponent{
+				Price:    float32(component.Price),
+				StepSize: int32(component.StepSize),
+				Type:     PriceComponentType(component.Type),
+				Vat:      float32Ptr(component.Vat),
+			}
+		}
+		elements[i] = TariffElement{
+			PriceComponents: components,
+			Restrictions:    newTariffRestrictions(element.Restrictions),
+		}
+	}
+
+	result := Tariff{
+		CountryCode:   countryCode,
+		Currency:      tariff.Currency,
+		Elements:      elements,
+		EndDateTime:   stringPtr(tariff.EndDateTime),
+		Id:            tariff.Id,
+		LastUpdated:   tariff.LastUpdated,
+		PartyId:       partyId,
+		StartDateTime: stringPtr(tariff.StartDateTime),
+	}
+	if tariff.Type != "" {
+		tariffType := TariffType(tariff.Type)
+		result.Type = &tariffType
+	}
+	return result
+}
+
+func newTariffRestrictions(restrictions *store.TariffRestrictions) *TariffRestrictions {
+	if restrictions == nil {
+		return nil
+	}
+
+	result := &TariffRestrictions{
+		EndDate:     stringPtr(restrictions.EndDate),
+		EndTime:     stringPtr(restrictions.EndTime),
+		MaxDuration: int32Ptr(restrictions.MaxDuration),
+		MaxKwh:      float32Ptr(restrictions.MaxKwh),
+		MinDuration: int32Ptr(restrictions.MinDuration),
+		MinKwh:      float32Ptr(restrictions.MinKwh),
+		StartDate:   stringPtr(restrictions.StartDate),
+		StartTime:   stringPtr(restrictions.StartTime),
+	}
+	if len(restrictions.DayOfWeek) > 0 {
+		days := make([]TariffRestrictionsDayOfWeek, len(restrictions.DayOfWeek))
+		for i, day := range restrictions.DayOfWeek {
+			days[i] = TariffRestrictionsDayOfWeek(day)
+		}
+		result.DayOfWeek = &days
+	}
+	return result
+}
+
+func stringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func float32Ptr(f *float64) *float32 {
+	if f == nil {
+		return nil
+	}
+	v := float32(*f)
+	return &v
+}
+
+func int32Ptr(i *int) *int32 {
+	if i == nil {
+		return nil
+	}
+	v := int32(*i)
+	return &v
+}
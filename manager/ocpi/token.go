@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func (o *OCPI) GetToken(ctx context.Context, countryCode string, partyID string, tokenUID string) (*Token, error) {
+	tok, err := o.store.LookupToken(ctx, tokenUID)
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if tok.CountryCode != countryCode || tok.PartyId != partyID {
+		return nil, nil
+	}
+	token := newToken(tok)
+	return &token, nil
+}
+
+// GetTokens returns the tokens that were last updated between dateFrom (inclusive) and
+// dateTo (exclusive), skipping the first offset tokens and returning at most limit
+// tokens. The total number of matching tokens is also returned so that the caller can
+// paginate.
+func (o *OCPI) GetTokens(ctx context.Context, dateFrom, dateTo time.Time, offset, limit int) ([]Token, int, error) {
+	return listPage(ctx, o.store.ListTokens,
+		func(tok *store.Token) string { return tok.LastUpdated },
+		newToken,
+		dateFrom, dateTo, offset, limit)
+}
+
+func (o *OCPI) SetToken(ctx context.Context, token Token) error {
+	return store.ReplaceToken(ctx, o.store, newStoreToken(token))
+}
+
+// AuthorizeToken asks the eMSPs to authorize a token that has not been pushed to this
+// CSMS. The first eMSP that knows the token decides whether it is valid: nil is returned
+// if none of them know it. The result is not stored because an eMSP that requires
+// real-time authorization may give a different answer next time.
+func (o *OCPI) AuthorizeToken(ctx context.Context, idToken, tokenType string) (*store.Token, error) {
+	recipients, err := o.broadcastRecipients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range recipients {
+		tokensUrl, err := o.getPartyEndpoint(ctx, r.OcpiParty, "tokens", SENDER)
+		if err != nil {
+			slog.Warn("unable to authorize token with eMSP", slog.String("countryCode", r.countryCode),
+				slog.String("partyId", r.partyId), "err", err)
+			continue
+		}
+		authorizeType := authorizeTokenType(tokenType)
+		if uses211(r.OcpiParty) && authorizeType != RFID {
+			authorizeType = OTHER
+		}
+		info, err := o.postAuthorize(ctx, tokensUrl, r.countryCode, r.partyId, r.Token, idToken, authorizeType)
+		if err != nil {
+			slog.Warn("unable to authorize token with eMSP", slog.String("countryCode", r.countryCode),
+				slog.String("partyId", r.partyId), "err", err)
+			continue
+		}
+		if info == nil {
+			continue
+		}
+
+		if uses211(r.OcpiParty) {
+			return newAuthorizedToken211(r.countryCode, r.partyId, idToken, authorizeType, info.Allowed), nil
+		}
+
+		tok := newStoreToken(info.Token)
+		tok.Valid = tok.Valid && info.Allowed == AuthorizationInfoAllowedALLOWED
+		return tok, nil
+	}
+
+	return nil, nil
+}
+
+// postAuthorize requests real-time authorization of the token from the eMSP: nil is
+// returned if the eMSP does not know the token
+func (o *OCPI) postAuthorize(ctx context.Context, tokensUrl string, toCountryCode string, toPartyId string, token string, idToken string, tokenType PostRealTimeTokenAuthorizationParamsType) (*AuthorizationInfo, error) {
+	authorizeUrl := fmt.Sprintf("%s/%s/authorize?type=%s", tokensUrl, url.PathEscape(idToken), tokenType)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authorizeUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	o.setRequestHeaders(ctx, req, token, toCountryCode, toPartyId)
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, errs.Wrap(errs.ErrDependencyUnavailable, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errs.New(errs.ErrDependencyUnavailable, "status code: %d", resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var authorizationInfo OcpiResponseAuthorizationInfo
+	err = json.Unmarshal(b, &authorizationInfo)
+	if err != nil {
+		return nil, err
+	}
+	if authorizationInfo.StatusCode == StatusUnknownToken {
+		return nil, nil
+	}
+	if authorizationInfo.StatusCode != StatusSuccess {
+		return nil, fmt.Errorf("status code: %d", authorizationInfo.StatusCode)
+	}
+	if authorizationInfo.Data == nil {
+		return nil, fmt.Errorf("no authorization info returned")
+	}
+
+	return authorizationInfo.Data, nil
+}
+
+// newAuthorizedToken211 returns the token authorized by an eMSP using 2.1.1, which only
+// responds with whether the token is allowed: the id token is used as the contract id
+// and the token must not be cached as nothing else is known about it
+func newAuthorizedToken211(countryCode, partyId, idToken string, tokenType PostRealTimeTokenAuthorizationParamsType, allowed AuthorizationInfoAllowed) *store.Token {
+	return &store.Token{
+		CountryCode: countryCode,
+		PartyId:     partyId,
+		Type:        string(tokenType),
+		Uid:         idToken,
+		ContractId:  idToken,
+		Valid:       allowed == AuthorizationInfoAllowedALLOWED,
+		CacheMode:   string(NEVER),
+	}
+}
+
+func newToken(tok *store.Token) Token {
+	return Token{
+		ContractId:   tok.ContractId,
+		CountryCode:  tok.CountryCode,
+		GroupId:      tok.GroupId,
+		Issuer:       tok.Issuer,
+		Language:     tok.LanguageCode,
+		LastUpdated:  tok.LastUpdated,
+		PartyId:      tok.PartyId,
+		Type:         TokenType(tok.Type),
+		Uid:          tok.Uid,
+		Valid:        tok.Valid,
+		VisualNumber: tok.VisualNumber,
+		Whitelist:    TokenWhitelist(tok.CacheMode),
+	}
+}
+
+// newStoreToken returns the token to store: the separators are removed from the
+// contract id if it is an eMAID, so that it matches the eMAID reported by charge stations
+func newStoreToken(token Token) *store.Token {
+	contractId := token.ContractId
+	if emaid, err := ocpp.CompactEmaid(contractId); err == nil {
+		contractId = emaid
+	}
+	return &store.Token{
+		CountryCode:  token.CountryCode,
+		PartyId:      token.PartyId,
+		Type:         string(token.Type),
+		Uid:          token.Uid,
+		ContractId:   contractId,
+		VisualNumber: token.VisualNumber,
+		Issuer:       token.Issuer,
+		GroupId:      token.GroupId,
+		Valid:        token.Valid,
+		LanguageCode: token.Language,
+		CacheMode:    string(token.Whitelist),
+		LastUpdated:  token.LastUpdated,
+	}
+}
+
+// authorizeTokenType maps an OCPP token type to the OCPI token type: OCPP 1.6 id tags,
+// which have no type, are treated as RFID tokens
+func authorizeTokenType(tokenType string) PostRealTimeTokenAuthorizationParamsType {
+	switch tokenType {
+	case "", "ISO14443", "ISO15693", "RFID":
+		return RFID
+	case "eMAID", "Central", "APP_USER":
+		return APPUSER
+	case "AD_HOC_USER":
+		return ADHOCUSER
+	default:
+		return OTHER
+	}
+}
@@ -0,0 +1,389 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+// The OCPI 2.1.1 objects exchanged with parties that have not moved to 2.2. Objects are
+// held in the 2.2 format and converted when they are sent to or received from a 2.1.1
+// party: 2.1.1 has no roles and only provides for connections between a CPO and an eMSP.
+
+// ocpiResponse is the OCPI response envelope for data that has no generated response
+// type, such as the 2.1.1 objects
+type ocpiResponse[T any] struct {
+	Data          *T      `json:"data,omitempty"`
+	StatusCode    int32   `json:"status_code"`
+	StatusMessage *string `json:"status_message,omitempty"`
+	Timestamp     string  `json:"timestamp"`
+}
+
+type Endpoint211 struct {
+	Identifier string `json:"identifier"`
+	Url        string `json:"url"`
+}
+
+type VersionDetail211 struct {
+	Endpoints []Endpoint211 `json:"endpoints"`
+	Version   string        `json:"version"`
+}
+
+type Credentials211 struct {
+	BusinessDetails BusinessDetails `json:"business_details"`
+	CountryCode     string          `json:"country_code"`
+	PartyId         string          `json:"party_id"`
+	Token           string          `json:"token"`
+	Url             string          `json:"url"`
+}
+
+type Token211 struct {
+	AuthId       string         `json:"auth_id"`
+	Issuer       string         `json:"issuer"`
+	Language     *string        `json:"language,omitempty"`
+	LastUpdated  string         `json:"last_updated"`
+	Type         TokenType      `json:"type"`
+	Uid          string         `json:"uid"`
+	Valid        bool           `json:"valid"`
+	VisualNumber *string        `json:"visual_number,omitempty"`
+	Whitelist    TokenWhitelist `json:"whitelist"`
+}
+
+type Connector211 struct {
+	Amperage    int32              `json:"amperage"`
+	Format      ConnectorFormat    `json:"format"`
+	Id          string             `json:"id"`
+	LastUpdated string             `json:"last_updated"`
+	PowerType   ConnectorPowerType `json:"power_type"`
+	Standard    ConnectorStandard  `json:"standard"`
+	Voltage     int32              `json:"voltage"`
+}
+
+type Evse211 struct {
+	Connectors  []Connector211 `json:"connectors"`
+	EvseId      *string        `json:"evse_id,omitempty"`
+	LastUpdated string         `json:"last_updated"`
+	Status      EvseStatus     `json:"status"`
+	Uid         string         `json:"uid"`
+}
+
+type Location211 struct {
+	Address     string      `json:"address"`
+	City        string      `json:"city"`
+	Coordinates GeoLocation `json:"coordinates"`
+	Country     string      `json:"country"`
+	Evses       []Evse211   `json:"evses"`
+	Id          string      `json:"id"`
+	LastUpdated string      `json:"last_updated"`
+	Name        *string     `json:"name,omitempty"`
+	PostalCode  string      `json:"postal_code"`
+	Type        string      `json:"type"`
+}
+
+type ChargingPeriod211 struct {
+	Dimensions    []CdrDimension `json:"dimensions"`
+	StartDateTime string         `json:"start_date_time"`
+}
+
+type Session211 struct {
+	AuthId          string               `json:"auth_id"`
+	AuthMethod      SessionAuthMethod    `json:"auth_method"`
+	ChargingPeriods *[]ChargingPeriod211 `json:"charging_periods,omitempty"`
+	Currency        string               `json:"currency"`
+	EndDatetime     *string              `json:"end_datetime,omitempty"`
+	Id              string               `json:"id"`
+	Kwh             float32              `json:"kwh"`
+	LastUpdated     string               `json:"last_updated"`
+	Location        Location211          `json:"location"`
+	MeterId         *string              `json:"meter_id,omitempty"`
+	StartDatetime   string               `json:"start_datetime"`
+	Status          SessionStatus        `json:"status"`
+	TotalCost       *float32             `json:"total_cost,omitempty"`
+}
+
+type CDR211 struct {
+	AuthId           string              `json:"auth_id"`
+	AuthMethod       CDRAuthMethod       `json:"auth_method"`
+	ChargingPeriods  []ChargingPeriod211 `json:"charging_periods"`
+	Currency         string              `json:"currency"`
+	Id               string              `json:"id"`
+	LastUpdated      string              `json:"last_updated"`
+	Location         Location211         `json:"location"`
+	MeterId          *string             `json:"meter_id,omitempty"`
+	StartDateTime    string              `json:"start_date_time"`
+	StopDateTime     string              `json:"stop_date_time"`
+	TotalCost        float32             `json:"total_cost"`
+	TotalEnergy      float32             `json:"total_energy"`
+	TotalParkingTime *float32            `json:"total_parking_time,omitempty"`
+	TotalTime        float32             `json:"total_time"`
+}
+
+// endpointsFrom211 gives the 2.1.1 endpoints of an eMSP the roles of the equivalent 2.2
+// interfaces: a 2.1.1 eMSP provides the sender interface of the tokens module and the
+// receiver interfaces of the other modules
+func endpointsFrom211(endpoints []Endpoint211) []Endpoint {
+	result := make([]Endpoint, len(endpoints))
+	for i, endpoint := range endpoints {
+		role := RECEIVER
+		if endpoint.Identifier == "tokens" {
+			role = SENDER
+		}
+		result[i] = Endpoint{
+			Identifier: endpoint.Identifier,
+			Role:       role,
+			Url:        endpoint.Url,
+		}
+	}
+	return result
+}
+
+// newCredentials211 returns the credentials of the first role: a 2.1.1 party only has one
+func newCredentials211(credentials Credentials) Credentials211 {
+	creds := Credentials211{
+		Token: credentials.Token,
+		Url:   credentials.Url,
+	}
+	if len(credentials.Roles) > 0 {
+		creds.BusinessDetails = credentials.Roles[0].BusinessDetails
+		creds.CountryCode = credentials.Roles[0].CountryCode
+		creds.PartyId = credentials.Roles[0].PartyId
+	}
+	return creds
+}
+
+// credentialsFrom211 returns the credentials of a 2.1.1 party, which is always an eMSP
+func credentialsFrom211(credentials Credentials211) Credentials {
+	return Credentials{
+		Roles: []CredentialsRole{
+			{
+				BusinessDetails: credentials.BusinessDetails,
+				CountryCode:     credentials.CountryCode,
+				PartyId:         credentials.PartyId,
+				Role:            CredentialsRoleRoleEMSP,
+			},
+		},
+		Token: credentials.Token,
+		Url:   credentials.Url,
+	}
+}
+
+func newToken211(token Token) Token211 {
+	return Token211{
+		AuthId:       token.ContractId,
+		Issuer:       token.Issuer,
+		Language:     token.Language,
+		LastUpdated:  token.LastUpdated,
+		Type:         tokenType211(token.Type),
+		Uid:          token.Uid,
+		Valid:        token.Valid,
+		VisualNumber: token.VisualNumber,
+		Whitelist:    token.Whitelist,
+	}
+}
+
+// tokenFrom211 returns the token pushed by a 2.1.1 eMSP: the token does not include the
+// party that issued it, so this is taken from the URL it was pushed to
+func tokenFrom211(countryCode, partyId string, token Token211) Token {
+	return Token{
+		ContractId:   token.AuthId,
+		CountryCode:  countryCode,
+		Issuer:       token.Issuer,
+		Language:     token.Language,
+		LastUpdated:  token.LastUpdated,
+		PartyId:      partyId,
+		Type:         token.Type,
+		Uid:          token.Uid,
+		Valid:        token.Valid,
+		VisualNumber: token.VisualNumber,
+		Whitelist:    token.Whitelist,
+	}
+}
+
+// tokenType211 maps a token type to one of the two types known to 2.1.1
+func tokenType211(tokenType TokenType) TokenType {
+	if tokenType == TokenTypeRFID {
+		return TokenTypeRFID
+	}
+	return TokenTypeOTHER
+}
+
+func newLocation211(location Location) Location211 {
+	loc := Location211{
+		Address:     location.Address,
+		City:        location.City,
+		Coordinates: location.Coordinates,
+		Country:     location.Country,
+		Evses:       make([]Evse211, 0),
+		Id:          location.Id,
+		LastUpdated: location.LastUpdated,
+		Name:        location.Name,
+		Type:        locationType211(location.ParkingType),
+	}
+	if location.PostalCode != nil {
+		loc.PostalCode = *location.PostalCode
+	}
+	if location.Evses != nil {
+		for _, evse := range *location.Evses {
+			loc.Evses = append(loc.Evses, newEvse211(evse))
+		}
+	}
+	return loc
+}
+
+// sessionLocation211 returns the location of a session or charge detail record, which in
+// 2.1.1 only includes the EVSE and connector that were used
+func sessionLocation211(location Location, evseUid, connectorId string) Location211 {
+	loc := newLocation211(location)
+	evses := make([]Evse211, 0, 1)
+	for _, evse := range loc.Evses {
+		if evse.Uid != evseUid {
+			continue
+		}
+		connectors := make([]Connector211, 0, 1)
+		for _, connector := range evse.Connectors {
+			if connector.Id == connectorId {
+				connectors = append(connectors, connector)
+			}
+		}
+		evse.Connectors = connectors
+		evses = append(evses, evse)
+	}
+	loc.Evses = evses
+	return loc
+}
+
+func newEvse211(evse Evse) Evse211 {
+	connectors := make([]Connector211, len(evse.Connectors))
+	for i, connector := range evse.Connectors {
+		connectors[i] = Connector211{
+			Amperage:    connector.MaxAmperage,
+			Format:      connector.Format,
+			Id:          connector.Id,
+			LastUpdated: connector.LastUpdated,
+			PowerType:   connector.PowerType,
+			Standard:    connector.Standard,
+			Voltage:     connector.MaxVoltage,
+		}
+	}
+	return Evse211{
+		Connectors:  connectors,
+		EvseId:      evse.EvseId,
+		LastUpdated: evse.LastUpdated,
+		Status:      evse.Status,
+		Uid:         evse.Uid,
+	}
+}
+
+// locationType211 maps the parking type to the 2.1.1 location type
+func locationType211(parkingType *LocationParkingType) string {
+	if parkingType == nil {
+		return "UNKNOWN"
+	}
+	switch *parkingType {
+	case "ON_STREET", "PARKING_GARAGE", "UNDERGROUND_GARAGE", "PARKING_LOT":
+		return string(*parkingType)
+	default:
+		return "OTHER"
+	}
+}
+
+// newSession211 returns the session with the location it takes place at, or with just
+// the location id if the location is no longer known
+func newSession211(session Session, location *Location) Session211 {
+	s := Session211{
+		AuthId:        session.CdrToken.ContractId,
+		AuthMethod:    session.AuthMethod,
+		Currency:      session.Currency,
+		EndDatetime:   session.EndDateTime,
+		Id:            session.Id,
+		Kwh:           session.Kwh,
+		LastUpdated:   session.LastUpdated,
+		Location:      Location211{Id: session.LocationId, Evses: make([]Evse211, 0)},
+		MeterId:       session.MeterId,
+		StartDatetime: session.StartDateTime,
+		Status:        session.Status,
+	}
+	if s.AuthMethod == SessionAuthMethodCOMMAND {
+		s.AuthMethod = SessionAuthMethodAUTHREQUEST
+	}
+	if s.Status == SessionStatusRESERVATION {
+		s.Status = SessionStatusPENDING
+	}
+	if location != nil {
+		s.Location = sessionLocation211(*location, session.EvseUid, session.ConnectorId)
+	}
+	if session.ChargingPeriods != nil {
+		periods := newChargingPeriods211(*session.ChargingPeriods)
+		s.ChargingPeriods = &periods
+	}
+	if session.TotalCost != nil {
+		s.TotalCost = &session.TotalCost.InclVat
+	}
+	return s
+}
+
+// newCdr211 returns the charge detail record with the location it took place at, or
+// with the location recorded in the charge detail record if that is no longer known, in
+// which case the voltage and amperage of the connector are unknown
+func newCdr211(cdr CDR, location *Location) CDR211 {
+	c := CDR211{
+		AuthId:           cdr.CdrToken.ContractId,
+		AuthMethod:       cdr.AuthMethod,
+		ChargingPeriods:  newChargingPeriods211(cdr.ChargingPeriods),
+		Currency:         cdr.Currency,
+		Id:               cdr.Id,
+		LastUpdated:      cdr.LastUpdated,
+		MeterId:          cdr.MeterId,
+		StartDateTime:    cdr.StartDateTime,
+		StopDateTime:     cdr.EndDateTime,
+		TotalCost:        cdr.TotalCost.InclVat,
+		TotalEnergy:      cdr.TotalEnergy,
+		TotalParkingTime: cdr.TotalParkingTime,
+		TotalTime:        cdr.TotalTime,
+	}
+	if c.AuthMethod == CDRAuthMethodCOMMAND {
+		c.AuthMethod = CDRAuthMethodAUTHREQUEST
+	}
+
+	if location != nil {
+		c.Location = sessionLocation211(*location, cdr.CdrLocation.EvseUid, cdr.CdrLocation.ConnectorId)
+		return c
+	}
+	evseId := cdr.CdrLocation.EvseId
+	c.Location = Location211{
+		Address:     cdr.CdrLocation.Address,
+		City:        cdr.CdrLocation.City,
+		Coordinates: cdr.CdrLocation.Coordinates,
+		Country:     cdr.CdrLocation.Country,
+		Evses: []Evse211{
+			{
+				Connectors: []Connector211{
+					{
+						Format:      ConnectorFormat(cdr.CdrLocation.ConnectorFormat),
+						Id:          cdr.CdrLocation.ConnectorId,
+						LastUpdated: cdr.LastUpdated,
+						PowerType:   ConnectorPowerType(cdr.CdrLocation.ConnectorPowerType),
+						Standard:    ConnectorStandard(cdr.CdrLocation.ConnectorStandard),
+					},
+				},
+				EvseId:      &evseId,
+				LastUpdated: cdr.LastUpdated,
+				Status:      EvseStatusUNKNOWN,
+				Uid:         cdr.CdrLocation.EvseUid,
+			},
+		},
+		Id:          cdr.CdrLocation.Id,
+		LastUpdated: cdr.LastUpdated,
+		Name:        cdr.CdrLocation.Name,
+		PostalCode:  cdr.CdrLocation.PostalCode,
+		Type:        "UNKNOWN",
+	}
+	return c
+}
+
+func newChargingPeriods211(periods []ChargingPeriod) []ChargingPeriod211 {
+	result := make([]ChargingPeriod211, len(periods))
+	for i, period := range periods {
+		result[i] = ChargingPeriod211{
+			Dimensions:    period.Dimensions,
+			StartDateTime: period.StartDateTime,
+		}
+	}
+	return result
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"io"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// new211Emsp returns an eMSP that only supports 2.1.1 and records the bodies of the
+// requests it receives by path
+func new211Emsp(t *testing.T) (*httptest.Server, map[string][]string) {
+	received := make(map[string][]string)
+	mux := http.NewServeMux()
+	emsp := httptest.NewServer(mux)
+	t.Cleanup(emsp.Close)
+
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":[{"version":"2.1.1","url":"%s/ocpi/2.1.1"}],"status_code":1000}`, emsp.URL)
+	})
+	mux.HandleFunc("/ocpi/2.1.1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{
+				"version":"2.1.1",
+				"endpoints":[
+					{"identifier":"credentials","url":"%[1]s/ocpi/2.1.1/credentials"},
+					{"identifier":"tokens","url":"%[1]s/ocpi/2.1.1/tokens"},
+					{"identifier":"sessions","url":"%[1]s/ocpi/2.1.1/sessions"},
+					{"identifier":"cdrs","url":"%[1]s/ocpi/2.1.1/cdrs"}
+				]},
+				"status_code":1000}`, emsp.URL)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received[r.URL.Path] = append(received[r.URL.Path], string(b))
+		switch r.URL.Path {
+		case "/ocpi/2.1.1/credentials":
+			_, _ = fmt.Fprintf(w, `{"data":{
+					"url":"%s/ocpi/versions",
+					"token":"emsp-token",
+					"party_id":"EMS",
+					"country_code":"NL",
+					"business_details":{"name":"Example eMSP"}
+				},
+				"status_code":1000}`, emsp.URL)
+		case "/ocpi/2.1.1/tokens/DEADBEEF/authorize":
+			_, _ = fmt.Fprint(w, `{"data":{"allowed":"ALLOWED"},"status_code":1000}`)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	return emsp, received
+}
+
+func TestRegistrationWith211Party(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	ocpiApi.SetExternalUrl("https://csms.example.com")
+	emsp, received := new211Emsp(t)
+
+	err := ocpiApi.RegisterNewParty(context.Background(), emsp.URL+"/ocpi/versions", "token-a")
+	require.NoError(t, err)
+
+	// the credentials are sent in the 2.1.1 format
+	require.Len(t, received["/ocpi/2.1.1/credentials"], 1)
+	var sent map[string]any
+	require.NoError(t, json.Unmarshal([]byte(received["/ocpi/2.1.1/credentials"][0]), &sent))
+	assert.Equal(t, "GB", sent["country_code"])
+	assert.Equal(t, "TWK", sent["party_id"])
+	assert.Equal(t, "https://csms.example.com/ocpi/versions", sent["url"])
+	assert.NotContains(t, sent, "roles")
+
+	party, err := engine.GetPartyDetails(context.Background(), "EMSP", "NL", "EMS")
+	require.NoError(t, err)
+	require.NotNil(t, party)
+	assert.Equal(t, "2.1.1", party.Version)
+	assert.Equal(t, "emsp-token", party.Token)
+	assert.Contains(t, party.Endpoints, store.OcpiEndpoint{
+		Identifier: "tokens",
+		Role:       "SENDER",
+		Url:        emsp.URL + "/ocpi/2.1.1/tokens",
+	})
+	assert.Contains(t, party.Endpoints, store.OcpiEndpoint{
+		Identifier: "sessions",
+		Role:       "RECEIVER",
+		Url:        emsp.URL + "/ocpi/2.1.1/sessions",
+	})
+}
+
+func TestSetCredentials22RejectsParty211(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	emsp, _ := new211Emsp(t)
+
+	_, err := ocpiApi.SetCredentials(context.Background(), "token-a", ocpi.Credentials{
+		Token: "emsp-token",
+		Url:   emsp.URL + "/ocpi/versions",
+	})
+	assert.ErrorContains(t, err, "no version 2.2.1 or 2.2 endpoint found")
+}
+
+// register211Party registers a 2.1.1 eMSP that has started the credentials handshake
+func register211Party(t *testing.T, ocpiApi *ocpi.OCPI, emspUrl string) ocpi.Credentials211 {
+	creds, err := ocpiApi.SetCredentials211(context.Background(), "token-a", ocpi.Credentials211{
+		CountryCode: "NL",
+		PartyId:     "EMS",
+		Token:       "emsp-token",
+		Url:         emspUrl + "/ocpi/versions",
+	})
+	require.NoError(t, err)
+	return creds
+}
+
+func TestSetCredentials211(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	ocpiApi.SetExternalUrl("https://csms.example.com")
+	emsp, _ := new211Emsp(t)
+
+	creds := register211Party(t, ocpiApi, emsp.URL)
+	assert.Equal(t, "GB", creds.CountryCode)
+	assert.Equal(t, "TWK", creds.PartyId)
+	assert.Equal(t, "https://csms.example.com/ocpi/versions", creds.Url)
+	assert.Len(t, creds.Token, 64)
+
+	reg, err := engine.GetRegistrationDetails(context.Background(), creds.Token)
+	require.NoError(t, err)
+	require.NotNil(t, reg)
+	assert.Equal(t, []store.OcpiPartyRole{{Role: "EMSP", CountryCode: "NL", PartyId: "EMS"}}, reg.Parties)
+}
+
+func TestTransactionChangedPushes211Session(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	emsp, received := new211Emsp(t)
+
+	setLocations(t, engine, "loc001")
+	err := engine.SetToken(context.Background(), &store.Token{
+		CountryCode: "NL",
+		PartyId:     "EMS",
+		Type:        "RFID",
+		Uid:         "DEADBEEF",
+		ContractId:  "NLEMSC00000001",
+		Valid:       true,
+	})
+	require.NoError(t, err)
+	register211Party(t, ocpiApi, emsp.URL)
+
+	energyRegister := "Energy.Active.Import.Register"
+	err = ocpiApi.TransactionChanged(context.Background(), &store.Transaction{
+		ChargeStationId: "cs000",
+		TransactionId:   "tx001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		MeterValues: []store.MeterValue{
+			{
+				Timestamp:     "2023-06-01T12:00:00Z",
+				SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: 1000}},
+			},
+			{
+				Timestamp:     "2023-06-01T12:30:00Z",
+				SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: 4500}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	pushed := received["/ocpi/2.1.1/sessions/GB/TWK/tx001"]
+	require.Len(t, pushed, 1)
+	var session ocpi.Session211
+	require.NoError(t, json.Unmarshal([]byte(pushed[0]), &session))
+	assert.Equal(t, "tx001", session.Id)
+	assert.Equal(t, "NLEMSC00000001", session.AuthId)
+	assert.Equal(t, "2023-06-01T12:00:00Z", session.StartDatetime)
+	assert.Equal(t, float32(3.5), session.Kwh)
+	assert.Equal(t, ocpi.SessionStatusACTIVE, session.Status)
+	assert.Equal(t, "loc001", session.Location.Id)
+	require.Len(t, session.Location.Evses, 1)
+	assert.Equal(t, "BEBECEcs000", session.Location.Evses[0].Uid)
+	require.Len(t, session.Location.Evses[0].Connectors, 1)
+	assert.Equal(t, int32(230), session.Location.Evses[0].Connectors[0].Voltage)
+	assert.Equal(t, int32(16), session.Location.Evses[0].Connectors[0].Amperage)
+}
+
+func TestAuthorizeTokenWith211Party(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	ocpiApi := ocpi.NewOCPI(engine, http.DefaultClient, "GB", "TWK")
+	emsp, received := new211Emsp(t)
+	register211Party(t, ocpiApi, emsp.URL)
+
+	tok, err := ocpiApi.AuthorizeToken(context.Background(), "DEADBEEF", "ISO14443")
+	require.NoError(t, err)
+
+	assert.Len(t, received["/ocpi/2.1.1/tokens/DEADBEEF/authorize"], 1)
+	assert.Equal(t, &store.Token{
+		CountryCode: "NL",
+		PartyId:     "EMS",
+		Type:        "RFID",
+		Uid:         "DEADBEEF",
+		ContractId:  "DEADBEEF",
+		Valid:       true,
+		CacheMode:   "NEVER",
+	}, tok)
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpi
+
+import (
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"strings"
+)
+
+const (
+	Version211 = "2.1.1"
+	Version22  = "2.2"
+	Version221 = "2.2.1"
+)
+
+// versions22 are the versions that use the 2.2 interfaces, in order of preference: 2.2.1
+// only corrects the 2.2 specification so the same objects are exchanged for both
+var versions22 = []string{Version221, Version22}
+
+// supportedVersions are the versions that can be agreed with a party, in order of
+// preference
+var supportedVersions = []string{Version221, Version22, Version211}
+
+// negotiateVersion returns the most preferred of the acceptable versions that the party
+// offers
+func negotiateVersion(offered []Version, acceptable []string) (Version, error) {
+	for _, version := range acceptable {
+		for _, v := range offered {
+			if v.Version == version {
+				return v, nil
+			}
+		}
+	}
+	return Version{}, fmt.Errorf("no version %s endpoint found", strings.Join(acceptable, " or "))
+}
+
+// partyVersion returns the version agreed with the party
+func partyVersion(party *store.OcpiParty) string {
+	if party.Version == "" {
+		return Version22
+	}
+	return party.Version
+}
+
+// uses211 reports whether objects are exchanged with the party in the 2.1.1 format
+func uses211(party *store.OcpiParty) bool {
+	return partyVersion(party) == Version211
+}
@@ -6,31 +6,93 @@ import (
 	"strings"
 )
 
-var emaidPattern = regexp.MustCompile("([A-Za-z]{2})(-?)([A-Za-z0-9]{3})(-?)([A-Za-z0-9]{9})(-?)([A-Za-z0-9])?")
+// emaidPattern matches an eMAID in the ISO 15118 or eMI3 formats: a two letter country
+// code, a three character provider id, a nine character instance id and an optional
+// check digit. The parts may be separated by hyphens or, as in the OICP format used by
+// Hubject, by asterisks.
+var emaidPattern = regexp.MustCompile(`^([A-Za-z]{2})[-*]?([A-Za-z0-9]{3})[-*]?([A-Za-z0-9]{9})(?:[-*]?([A-Za-z0-9]))?$`)
 
+// Emaid is an e-mobility account identifier, which identifies the contract of an EV
+// driver with an e-mobility service provider
+type Emaid struct {
+	CountryCode string
+	ProviderId  string
+	InstanceId  string
+	CheckDigit  rune
+}
+
+// ParseEmaid parses an eMAID regardless of its case and separators. The check digit is
+// calculated if it is missing and must be correct if it is present.
+func ParseEmaid(emaid string) (*Emaid, error) {
+	parts := emaidPattern.FindStringSubmatch(strings.TrimSpace(emaid))
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("emaid %s is invalid", emaid)
+	}
+
+	e := &Emaid{
+		CountryCode: strings.ToUpper(parts[1]),
+		ProviderId:  strings.ToUpper(parts[2]),
+		InstanceId:  strings.ToUpper(parts[3]),
+	}
+	e.CheckDigit = calculateEmaidCheckDigit(e.WithoutCheckDigit())
+	if parts[4] != "" {
+		digit := rune(strings.ToUpper(parts[4])[0])
+		if digit != e.CheckDigit {
+			return nil, fmt.Errorf("emaid check digit %c expected value %c", digit, e.CheckDigit)
+		}
+	}
+	return e, nil
+}
+
+// String returns the normalized eMAID: upper case, without separators and with the
+// check digit
+func (e Emaid) String() string {
+	return fmt.Sprintf("%s%c", e.WithoutCheckDigit(), e.CheckDigit)
+}
+
+// WithoutCheckDigit returns the normalized eMAID without its check digit
+func (e Emaid) WithoutCheckDigit() string {
+	return e.CountryCode + e.ProviderId + e.InstanceId
+}
+
+// NormalizeEmaid returns the normalized form of the eMAID: see Emaid.String
 func NormalizeEmaid(emaid string) (string, error) {
-	norm, err := normalizeEmaid(emaid)
+	e, err := ParseEmaid(emaid)
 	if err != nil {
 		return "", err
 	}
-	digit := calculateEmaidCheckDigit(norm)
-	if len(norm) > 14 {
-		if digit != rune(norm[14]) {
-			return "", fmt.Errorf("emaid check digit %c expected value %c", norm[14], digit)
-		}
-		return norm, nil
-	}
+	return e.String(), nil
+}
 
-	return fmt.Sprintf("%s%c", norm, digit), nil
+// CompactEmaid removes the separators from the eMAID and converts it to upper case. The
+// check digit is only included if the eMAID has one.
+func CompactEmaid(emaid string) (string, error) {
+	e, err := ParseEmaid(emaid)
+	if err != nil {
+		return "", err
+	}
+	if len(strings.NewReplacer("-", "", "*", "").Replace(strings.TrimSpace(emaid))) > 14 {
+		return e.String(), nil
+	}
+	return e.WithoutCheckDigit(), nil
 }
 
-func normalizeEmaid(emaid string) (string, error) {
-	parts := emaidPattern.FindStringSubmatch(emaid)
-	if len(parts) > 0 {
-		str := fmt.Sprintf("%s%s%s%s", parts[1], parts[3], parts[5], parts[7])
-		return strings.ToUpper(str), nil
+// EmaidsEqual reports whether the two identifiers are the same eMAID, ignoring any
+// differences in case, separators or the presence of the check digit. Identifiers that
+// are not eMAIDs are compared exactly.
+func EmaidsEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	emaidA, err := ParseEmaid(a)
+	if err != nil {
+		return false
+	}
+	emaidB, err := ParseEmaid(b)
+	if err != nil {
+		return false
 	}
-	return "", fmt.Errorf("emaid %s is invalid", emaid)
+	return *emaidA == *emaidB
 }
 
 var reverseLookup = "0I9R3LCU6OFX----1JAS4MDV7PGY----2KBT5NEW8QHZ"
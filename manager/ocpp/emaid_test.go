@@ -38,11 +38,58 @@ func TestNormalizeEmaidWithoutCheckDigit(t *testing.T) {
 	}
 }
 
+func TestNormalizeEmaidWithAsteriskSeparators(t *testing.T) {
+	norm, err := ocpp.NormalizeEmaid("GB*TWK*012345678*V")
+	require.NoError(t, err)
+	assert.Equal(t, "GBTWK012345678V", norm)
+}
+
 func TestNormalizeInvalidEmaid(t *testing.T) {
 	_, err := ocpp.NormalizeEmaid("GB-TWK-01234567")
 	assert.ErrorContains(t, err, "emaid GB-TWK-01234567 is invalid")
 }
 
+func TestNormalizeEmaidWithinOtherText(t *testing.T) {
+	_, err := ocpp.NormalizeEmaid("XGBTWK012345678V")
+	assert.ErrorContains(t, err, "is invalid")
+}
+
+func TestNormalizeEmaidWithWrongCheckDigit(t *testing.T) {
+	_, err := ocpp.NormalizeEmaid("GBTWK012345678A")
+	assert.ErrorContains(t, err, "emaid check digit A expected value V")
+}
+
+func TestParseEmaid(t *testing.T) {
+	emaid, err := ocpp.ParseEmaid(" gb-twk-012345678 ")
+	require.NoError(t, err)
+	assert.Equal(t, &ocpp.Emaid{
+		CountryCode: "GB",
+		ProviderId:  "TWK",
+		InstanceId:  "012345678",
+		CheckDigit:  'V',
+	}, emaid)
+	assert.Equal(t, "GBTWK012345678V", emaid.String())
+	assert.Equal(t, "GBTWK012345678", emaid.WithoutCheckDigit())
+}
+
+func TestCompactEmaid(t *testing.T) {
+	compact, err := ocpp.CompactEmaid("gb-twk-012345678")
+	require.NoError(t, err)
+	assert.Equal(t, "GBTWK012345678", compact)
+
+	compact, err = ocpp.CompactEmaid("GB*TWK*012345678*V")
+	require.NoError(t, err)
+	assert.Equal(t, "GBTWK012345678V", compact)
+}
+
+func TestEmaidsEqual(t *testing.T) {
+	assert.True(t, ocpp.EmaidsEqual("GBTWK012345678V", "GB-TWK-012345678-V"))
+	assert.True(t, ocpp.EmaidsEqual("gb*twk*012345678", "GBTWK012345678V"))
+	assert.True(t, ocpp.EmaidsEqual("MYEMAID", "MYEMAID"))
+	assert.False(t, ocpp.EmaidsEqual("GBTWK012345678V", "GBTWK012345679"))
+	assert.False(t, ocpp.EmaidsEqual("MYEMAID", "myemaid"))
+}
+
 var alpha = "ABCDEFGHIJKLMNOPQRSTUVWXZY"
 var alphaNumeric = "0123456789" + alpha
 
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+type ClearCacheJson map[string]interface{}
+
+func (*ClearCacheJson) IsRequest() {}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+type UnlockConnectorResponseJsonStatus string
+
+type UnlockConnectorResponseJson struct {
+	// Status corresponds to the JSON schema field "status".
+	Status UnlockConnectorResponseJsonStatus `json:"status" yaml:"status" mapstructure:"status"`
+}
+
+const UnlockConnectorResponseJsonStatusNotSupported UnlockConnectorResponseJsonStatus = "NotSupported"
+const UnlockConnectorResponseJsonStatusUnlockFailed UnlockConnectorResponseJsonStatus = "UnlockFailed"
+const UnlockConnectorResponseJsonStatusUnlocked UnlockConnectorResponseJsonStatus = "Unlocked"
+
+func (*UnlockConnectorResponseJson) IsResponse() {}
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp16
+
+type UpdateFirmwareResponseJson map[string]interface{}
+
+func (*UpdateFirmwareResponseJson) IsResponse() {}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+// Source of the charging limit.
+type ChargingLimitSourceEnumType string
+
+const ChargingLimitSourceEnumTypeCSO ChargingLimitSourceEnumType = "CSO"
+const ChargingLimitSourceEnumTypeEMS ChargingLimitSourceEnumType = "EMS"
+const ChargingLimitSourceEnumTypeOther ChargingLimitSourceEnumType = "Other"
+const ChargingLimitSourceEnumTypeSO ChargingLimitSourceEnumType = "SO"
+
+type ClearedChargingLimitRequestJson struct {
+	// ChargingLimitSource corresponds to the JSON schema field "chargingLimitSource".
+	ChargingLimitSource ChargingLimitSourceEnumType `json:"chargingLimitSource" yaml:"chargingLimitSource" mapstructure:"chargingLimitSource"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// EVSE Identifier.
+	//
+	EvseId *int `json:"evseId,omitempty" yaml:"evseId,omitempty" mapstructure:"evseId,omitempty"`
+}
+
+func (*ClearedChargingLimitRequestJson) IsRequest() {}
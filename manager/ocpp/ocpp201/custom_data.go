@@ -2,10 +2,47 @@
 
 package ocpp201
 
+import "encoding/json"
+
 // This class does not get 'AdditionalProperties = false' in the schema generation,
 // so it can be extended with arbitrary JSON properties to allow adding custom
 // data.
 type CustomDataType struct {
 	// VendorId corresponds to the JSON schema field "vendorId".
 	VendorId string `json:"vendorId" yaml:"vendorId" mapstructure:"vendorId"`
+
+	// Properties holds the vendor specific properties other than "vendorId".
+	Properties map[string]json.RawMessage `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+func (c CustomDataType) MarshalJSON() ([]byte, error) {
+	props := make(map[string]json.RawMessage, len(c.Properties)+1)
+	for k, v := range c.Properties {
+		props[k] = v
+	}
+	vendorId, err := json.Marshal(c.VendorId)
+	if err != nil {
+		return nil, err
+	}
+	props["vendorId"] = vendorId
+	return json.Marshal(props)
+}
+
+func (c *CustomDataType) UnmarshalJSON(b []byte) error {
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(b, &props); err != nil {
+		return err
+	}
+	c.VendorId = ""
+	if vendorId, ok := props["vendorId"]; ok {
+		if err := json.Unmarshal(vendorId, &c.VendorId); err != nil {
+			return err
+		}
+		delete(props, "vendorId")
+	}
+	c.Properties = nil
+	if len(props) > 0 {
+		c.Properties = props
+	}
+	return nil
 }
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201_test
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"testing"
+)
+
+func TestCustomDataTypeRoundTripsVendorProperties(t *testing.T) {
+	in := `{"vendorId":"org.example","chunk":1,"nested":{"a":"b"}}`
+
+	var customData ocpp201.CustomDataType
+	err := json.Unmarshal([]byte(in), &customData)
+	require.NoError(t, err)
+
+	assert.Equal(t, "org.example", customData.VendorId)
+	assert.Equal(t, json.RawMessage(`1`), customData.Properties["chunk"])
+
+	out, err := json.Marshal(customData)
+	require.NoError(t, err)
+	assert.JSONEq(t, in, string(out))
+}
+
+func TestCustomDataTypeWithoutVendorProperties(t *testing.T) {
+	var customData ocpp201.CustomDataType
+	err := json.Unmarshal([]byte(`{"vendorId":"org.example"}`), &customData)
+	require.NoError(t, err)
+
+	assert.Equal(t, ocpp201.CustomDataType{VendorId: "org.example"}, customData)
+}
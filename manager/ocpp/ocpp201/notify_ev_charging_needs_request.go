@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+// AC_ Charging_ Parameters
+// urn:x-oca:ocpp:uid:2:233250
+// EV AC charging parameters.
+type ACChargingParametersType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// AC_ Charging_ Parameters. Energy_ Amount. Energy_ Amount
+	// urn:x-oca:ocpp:uid:1:569211
+	// Amount of energy requested (in Wh). This includes energy required for
+	// preconditioning.
+	//
+	EnergyAmount int `json:"energyAmount" yaml:"energyAmount" mapstructure:"energyAmount"`
+
+	// AC_ Charging_ Parameters. EV_ Max. Current
+	// urn:x-oca:ocpp:uid:1:569213
+	// Maximum current (amps) supported by the electric vehicle (per phase).
+	// Includes cable capacity.
+	//
+	EvMaxCurrent int `json:"evMaxCurrent" yaml:"evMaxCurrent" mapstructure:"evMaxCurrent"`
+
+	// AC_ Charging_ Parameters. EV_ Max. Voltage
+	// urn:x-oca:ocpp:uid:1:569214
+	// Maximum voltage supported by the electric vehicle
+	//
+	EvMaxVoltage int `json:"evMaxVoltage" yaml:"evMaxVoltage" mapstructure:"evMaxVoltage"`
+
+	// AC_ Charging_ Parameters. EV_ Min. Current
+	// urn:x-oca:ocpp:uid:1:569212
+	// Minimum current (amps) supported by the electric vehicle (per phase).
+	//
+	EvMinCurrent int `json:"evMinCurrent" yaml:"evMinCurrent" mapstructure:"evMinCurrent"`
+}
+
+// Charging_ Needs
+// urn:x-oca:ocpp:uid:2:233249
+type ChargingNeedsType struct {
+	// AcChargingParameters corresponds to the JSON schema field "acChargingParameters".
+	AcChargingParameters *ACChargingParametersType `json:"acChargingParameters,omitempty" yaml:"acChargingParameters,omitempty" mapstructure:"acChargingParameters,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// DcChargingParameters corresponds to the JSON schema field "dcChargingParameters".
+	DcChargingParameters *DCChargingParametersType `json:"dcChargingParameters,omitempty" yaml:"dcChargingParameters,omitempty" mapstructure:"dcChargingParameters,omitempty"`
+
+	// Charging_ Needs. Departure_ Time. Date_ Time
+	// urn:x-oca:ocpp:uid:1:569223
+	// Estimated departure time of the EV.
+	//
+	DepartureTime *string `json:"departureTime,omitempty" yaml:"departureTime,omitempty" mapstructure:"departureTime,omitempty"`
+
+	// RequestedEnergyTransfer corresponds to the JSON schema field "requestedEnergyTransfer".
+	RequestedEnergyTransfer EnergyTransferModeEnumType `json:"requestedEnergyTransfer" yaml:"requestedEnergyTransfer" mapstructure:"requestedEnergyTransfer"`
+}
+
+// DC_ Charging_ Parameters
+// urn:x-oca:ocpp:uid:2:233251
+// EV DC charging parameters
+type DCChargingParametersType struct {
+	// DC_ Charging_ Parameters. Bulk_ SOC. Percentage
+	// urn:x-oca:ocpp:uid:1:569222
+	// Percentage of SoC at which the EV considers a fast charging process to end.
+	// (possible values: 0 - 100)
+	//
+	BulkSoC *int `json:"bulkSoC,omitempty" yaml:"bulkSoC,omitempty" mapstructure:"bulkSoC,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// DC_ Charging_ Parameters. Energy_ Amount. Energy_ Amount
+	// urn:x-oca:ocpp:uid:1:569217
+	// Amount of energy requested (in Wh). This inludes energy required for
+	// preconditioning.
+	//
+	EnergyAmount *int `json:"energyAmount,omitempty" yaml:"energyAmount,omitempty" mapstructure:"energyAmount,omitempty"`
+
+	// DC_ Charging_ Parameters. EV_ Energy_ Capacity. Numeric
+	// urn:x-oca:ocpp:uid:1:569220
+	// Capacity of the electric vehicle battery (in Wh)
+	//
+	EvEnergyCapacity *int `json:"evEnergyCapacity,omitempty" yaml:"evEnergyCapacity,omitempty" mapstructure:"evEnergyCapacity,omitempty"`
+
+	// DC_ Charging_ Parameters. EV_ Max. Current
+	// urn:x-oca:ocpp:uid:1:569215
+	// Maximum current (amps) supported by the electric vehicle. Includes cable
+	// capacity.
+	//
+	EvMaxCurrent int `json:"evMaxCurrent" yaml:"evMaxCurrent" mapstructure:"evMaxCurrent"`
+
+	// DC_ Charging_ Parameters. EV_ Max. Power
+	// urn:x-oca:ocpp:uid:1:569218
+	// Maximum power (in W) supported by the electric vehicle. Required for DC
+	// charging.
+	//
+	EvMaxPower *int `json:"evMaxPower,omitempty" yaml:"evMaxPower,omitempty" mapstructure:"evMaxPower,omitempty"`
+
+	// DC_ Charging_ Parameters. EV_ Max. Voltage
+	// urn:x-oca:ocpp:uid:1:569216
+	// Maximum voltage supported by the electric vehicle
+	//
+	EvMaxVoltage int `json:"evMaxVoltage" yaml:"evMaxVoltage" mapstructure:"evMaxVoltage"`
+
+	// DC_ Charging_ Parameters. Full_ SOC. Percentage
+	// urn:x-oca:ocpp:uid:1:569221
+	// Percentage of SoC at which the EV considers the battery fully charged.
+	// (possible values: 0 - 100)
+	//
+	FullSoC *int `json:"fullSoC,omitempty" yaml:"fullSoC,omitempty" mapstructure:"fullSoC,omitempty"`
+
+	// DC_ Charging_ Parameters. State_ Of_ Charge. Numeric
+	// urn:x-oca:ocpp:uid:1:569219
+	// Energy available in the battery (in percent of the battery capacity)
+	//
+	StateOfCharge *int `json:"stateOfCharge,omitempty" yaml:"stateOfCharge,omitempty" mapstructure:"stateOfCharge,omitempty"`
+}
+
+// Charging_ Needs. Requested. Energy_ Transfer_ Mode_ Code
+// urn:x-oca:ocpp:uid:1:569209
+// Mode of energy transfer requested by the EV.
+type EnergyTransferModeEnumType string
+
+const EnergyTransferModeEnumTypeACSinglePhase EnergyTransferModeEnumType = "AC_single_phase"
+const EnergyTransferModeEnumTypeACThreePhase EnergyTransferModeEnumType = "AC_three_phase"
+const EnergyTransferModeEnumTypeACTwoPhase EnergyTransferModeEnumType = "AC_two_phase"
+const EnergyTransferModeEnumTypeDC EnergyTransferModeEnumType = "DC"
+
+type NotifyEVChargingNeedsRequestJson struct {
+	// ChargingNeeds corresponds to the JSON schema field "chargingNeeds".
+	ChargingNeeds ChargingNeedsType `json:"chargingNeeds" yaml:"chargingNeeds" mapstructure:"chargingNeeds"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Defines the EVSE and connector to which the EV is connected. EvseId may not
+	// be 0.
+	//
+	EvseId int `json:"evseId" yaml:"evseId" mapstructure:"evseId"`
+
+	// Contains the maximum schedule tuples the car supports per schedule.
+	//
+	MaxScheduleTuples *int `json:"maxScheduleTuples,omitempty" yaml:"maxScheduleTuples,omitempty" mapstructure:"maxScheduleTuples,omitempty"`
+}
+
+func (*NotifyEVChargingNeedsRequestJson) IsRequest() {}
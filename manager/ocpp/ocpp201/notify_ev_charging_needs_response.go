@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+type NotifyEVChargingNeedsResponseJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status NotifyEVChargingNeedsStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// StatusInfo corresponds to the JSON schema field "statusInfo".
+	StatusInfo *StatusInfoType `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+}
+
+func (*NotifyEVChargingNeedsResponseJson) IsResponse() {}
+
+// Returns whether the CSMS has been able to process the message successfully.
+// It does not imply that the evChargingNeeds can be met with the current
+// charging profile.
+type NotifyEVChargingNeedsStatusEnumType string
+
+const NotifyEVChargingNeedsStatusEnumTypeAccepted NotifyEVChargingNeedsStatusEnumType = "Accepted"
+const NotifyEVChargingNeedsStatusEnumTypeProcessing NotifyEVChargingNeedsStatusEnumType = "Processing"
+const NotifyEVChargingNeedsStatusEnumTypeRejected NotifyEVChargingNeedsStatusEnumType = "Rejected"
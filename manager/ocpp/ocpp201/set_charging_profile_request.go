@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+type SetChargingProfileRequestJson struct {
+	// ChargingProfile corresponds to the JSON schema field "chargingProfile".
+	ChargingProfile ChargingProfileType `json:"chargingProfile" yaml:"chargingProfile" mapstructure:"chargingProfile"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// For TxDefaultProfile an evseId=0 applies the profile to each individual
+	// evse. For ChargingStationMaxProfile and ChargingStationExternalConstraints
+	// an evseId=0 contains an overal limit for the whole Charging Station.
+	//
+	EvseId int `json:"evseId" yaml:"evseId" mapstructure:"evseId"`
+}
+
+func (*SetChargingProfileRequestJson) IsRequest() {}
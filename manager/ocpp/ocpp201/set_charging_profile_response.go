@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp201
+
+// Returns whether the Charging Station has been able to process the message
+// successfully. This does not guarantee the schedule will be followed to the
+// letter. There might be other constraints the Charging Station may need to
+// take into account.
+type ChargingProfileStatusEnumType string
+
+const ChargingProfileStatusEnumTypeAccepted ChargingProfileStatusEnumType = "Accepted"
+const ChargingProfileStatusEnumTypeRejected ChargingProfileStatusEnumType = "Rejected"
+
+type SetChargingProfileResponseJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status ChargingProfileStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// StatusInfo corresponds to the JSON schema field "statusInfo".
+	StatusInfo *StatusInfoType `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+}
+
+func (*SetChargingProfileResponseJson) IsResponse() {}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type BatterySwapRequestJson struct {
+	// BatteryData corresponds to the JSON schema field "batteryData".
+	BatteryData []BatteryDataType `json:"batteryData" yaml:"batteryData" mapstructure:"batteryData"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// EventType corresponds to the JSON schema field "eventType".
+	EventType BatterySwapEventEnumType `json:"eventType" yaml:"eventType" mapstructure:"eventType"`
+
+	// IdToken corresponds to the JSON schema field "idToken".
+	IdToken IdTokenType `json:"idToken" yaml:"idToken" mapstructure:"idToken"`
+
+	// RequestId to correlate BatteryIn/Out events and optional
+	// RequestBatterySwapRequest.
+	//
+	RequestId int `json:"requestId" yaml:"requestId" mapstructure:"requestId"`
+}
+
+func (*BatterySwapRequestJson) IsRequest() {}
+
+type AdditionalInfoType struct {
+	// This field specifies the additional IdToken.
+	//
+	AdditionalIdToken string `json:"additionalIdToken" yaml:"additionalIdToken" mapstructure:"additionalIdToken"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// _additionalInfo_ can be used to send extra information to CSMS in addition to
+	// the regular authorization with _IdToken_. _AdditionalInfo_ contains one or more
+	// custom _types_, which need to be agreed upon by all parties involved. When the
+	// _type_ is not supported, the CSMS/Charging Station MAY ignore the
+	// _additionalInfo_.
+	//
+	Type string `json:"type" yaml:"type" mapstructure:"type"`
+}
+
+type BatteryDataType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Slot number where battery is inserted or removed.
+	EvseId int `json:"evseId" yaml:"evseId" mapstructure:"evseId"`
+
+	// Production date of battery.
+	//
+	ProductionDate *string `json:"productionDate,omitempty" yaml:"productionDate,omitempty" mapstructure:"productionDate,omitempty"`
+
+	// Serial number of battery.
+	SerialNumber string `json:"serialNumber" yaml:"serialNumber" mapstructure:"serialNumber"`
+
+	// State of charge
+	SoC float64 `json:"soC" yaml:"soC" mapstructure:"soC"`
+
+	// State of health
+	//
+	SoH float64 `json:"soH" yaml:"soH" mapstructure:"soH"`
+
+	// Vendor-specific info from battery in undefined format.
+	VendorInfo *string `json:"vendorInfo,omitempty" yaml:"vendorInfo,omitempty" mapstructure:"vendorInfo,omitempty"`
+}
+
+type BatterySwapEventEnumType string
+
+const BatterySwapEventEnumTypeBatteryIn BatterySwapEventEnumType = "BatteryIn"
+const BatterySwapEventEnumTypeBatteryOut BatterySwapEventEnumType = "BatteryOut"
+const BatterySwapEventEnumTypeBatteryOutTimeout BatterySwapEventEnumType = "BatteryOutTimeout"
+
+// Contains a case insensitive identifier to use for the authorization and the type
+// of authorization to support multiple forms of identifiers.
+type IdTokenType struct {
+	// AdditionalInfo corresponds to the JSON schema field "additionalInfo".
+	AdditionalInfo []AdditionalInfoType `json:"additionalInfo,omitempty" yaml:"additionalInfo,omitempty" mapstructure:"additionalInfo,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// IdToken is case insensitive. Might hold the hidden id of an RFID tag, but can
+	// for example also contain a UUID.
+	//
+	IdToken string `json:"idToken" yaml:"idToken" mapstructure:"idToken"`
+
+	// Enumeration of possible idToken types. Values defined in Appendix as
+	// IdTokenEnumStringType.
+	//
+	Type string `json:"type" yaml:"type" mapstructure:"type"`
+}
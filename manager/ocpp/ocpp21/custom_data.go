@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+
+// CustomDataType is unchanged from OCPP 2.0.1
+type CustomDataType = ocpp201.CustomDataType
@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ocpp21 contains types that represent the OCPP 2.1 protocol messages that
+// are not part of OCPP 2.0.1. OCPP 2.1 is still a draft: only the messages needed to
+// pilot bidirectional charging and battery swapping are included, and they may change
+// before the specification is final. The files follow the same conventions as the
+// ocpp201 package.
+package ocpp21
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package oicp
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"strings"
+)
+
+// AuthorizeToken asks Hubject to authorize a token that is not known to the CSMS. Nil is
+// returned if Hubject does not know the token. The Hubject session is recorded so that it
+// can be stopped when the transaction ends.
+func (o *OICP) AuthorizeToken(ctx context.Context, idToken, tokenType string) (*store.Token, error) {
+	var authorization Authorization
+	err := o.post(ctx, o.operatorUrl("charging", "v21", "authorize/start"), AuthorizeStartRequest{
+		OperatorID:     o.operatorId,
+		Identification: newIdentification(idToken, tokenType),
+	}, &authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	if authorization.AuthorizationStatus != AuthorizationStatusAuthorized {
+		slog.Info("token not authorized by hubject", slog.String("idToken", idToken),
+			slog.String("statusCode", describeStatusCode(authorization.StatusCode)))
+		return nil, nil
+	}
+
+	if authorization.SessionID != nil {
+		err = o.store.SetOicpSession(ctx, &store.OicpSession{
+			IdToken:   idToken,
+			SessionId: *authorization.SessionID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tok := &store.Token{
+		Type:       tokenType,
+		Uid:        idToken,
+		ContractId: idToken,
+		Valid:      true,
+		// the provider decides each time the token is used
+		CacheMode: store.CacheModeNever,
+	}
+	if authorization.ProviderID != nil {
+		tok.Issuer = *authorization.ProviderID
+		// provider ids are of the form DE*ICE
+		countryCode, partyId, found := strings.Cut(*authorization.ProviderID, "*")
+		if found {
+			tok.CountryCode = countryCode
+			tok.PartyId = partyId
+		}
+	}
+	return tok, nil
+}
+
+// TransactionChanged stops the Hubject session of a transaction that has ended. Nothing
+// is sent for transactions that were not authorized through Hubject.
+func (o *OICP) TransactionChanged(ctx context.Context, transaction *store.Transaction) error {
+	if transaction.EndedSeqNo == 0 || transaction.IdToken == "" {
+		return nil
+	}
+
+	session, err := o.store.LookupOicpSession(ctx, transaction.IdToken)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return nil
+	}
+
+	req := AuthorizeStopRequest{
+		SessionID:           session.SessionId,
+		CPOPartnerSessionID: &transaction.TransactionId,
+		OperatorID:          o.operatorId,
+		Identification:      newIdentification(transaction.IdToken, transaction.TokenType),
+	}
+	evseIds, err := o.chargeStationEvseIds(ctx, transaction.ChargeStationId)
+	if err != nil {
+		return err
+	}
+	if len(evseIds) > 0 {
+		req.EvseID = &evseIds[0]
+	}
+
+	var authorization Authorization
+	err = o.post(ctx, o.operatorUrl("charging", "v21", "authorize/stop"), req, &authorization)
+	if err != nil {
+		return err
+	}
+	if authorization.AuthorizationStatus != AuthorizationStatusAuthorized {
+		slog.Warn("hubject did not authorize stop of session", slog.String("sessionId", session.SessionId),
+			slog.String("transactionId", transaction.TransactionId),
+			slog.String("statusCode", describeStatusCode(authorization.StatusCode)))
+	}
+
+	return o.store.DeleteOicpSession(ctx, transaction.IdToken)
+}
+
+func newIdentification(idToken, tokenType string) Identification {
+	if tokenType == "eMAID" {
+		return Identification{
+			PlugAndChargeIdentification: &PlugAndChargeIdentification{EvcoID: idToken},
+		}
+	}
+	return Identification{
+		RFIDMifareFamilyIdentification: &RFIDMifareFamilyIdentification{UID: idToken},
+	}
+}
@@ -0,0 +1,5 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oicp implements the CPO side of OICP 2.3 for operators that are connected
+// directly to the Hubject roaming network
+package oicp
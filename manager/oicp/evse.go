@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package oicp
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"regexp"
+)
+
+// locationPageSize is the number of locations read from the store at a time
+const locationPageSize = 50
+
+// evseUidRegexp extracts the charge station id from the uid of an EVSE: EVSEs are
+// linked to charge stations in the same way as for OCPI
+var evseUidRegexp = regexp.MustCompile(`^[a-zA-Z]{5}E([a-zA-Z0-9]+)?$`)
+
+// PushEvseData replaces the EVSE data held by Hubject with the EVSEs of the locations in
+// the store. EVSEs without an EVSE id can't be identified in OICP, so are not included.
+func (o *OICP) PushEvseData(ctx context.Context) error {
+	locations, err := o.listAllLocations(ctx)
+	if err != nil {
+		return err
+	}
+
+	records := make([]EvseDataRecord, 0)
+	for _, loc := range locations {
+		if loc.Evses == nil {
+			continue
+		}
+		for _, evse := range *loc.Evses {
+			if evse.EvseId == nil {
+				continue
+			}
+			records = append(records, o.newEvseDataRecord(loc, evse))
+		}
+	}
+
+	return o.pushAcknowledged(ctx, o.operatorUrl("evsepush", "v23", "data-records"), PushEvseDataRequest{
+		ActionType: ActionTypeFullLoad,
+		OperatorEvseData: OperatorEvseData{
+			OperatorID:     o.operatorId,
+			OperatorName:   o.operatorName,
+			EvseDataRecord: records,
+		},
+	})
+}
+
+// ConnectorStatusChanged pushes the status of the EVSEs that belong to the charge
+// station to Hubject
+func (o *OICP) ConnectorStatusChanged(ctx context.Context, status *store.ConnectorStatus) error {
+	evseIds, err := o.chargeStationEvseIds(ctx, status.ChargeStationId)
+	if err != nil {
+		return err
+	}
+	if len(evseIds) == 0 {
+		return nil
+	}
+
+	statuses, err := o.store.ListConnectorStatuses(ctx, status.ChargeStationId)
+	if err != nil {
+		return err
+	}
+	evseStatus := evseStatusFromConnectors(statuses)
+
+	records := make([]EvseStatusRecord, len(evseIds))
+	for i, evseId := range evseIds {
+		records[i] = EvseStatusRecord{
+			EvseID:     evseId,
+			EvseStatus: evseStatus,
+		}
+	}
+
+	return o.pushAcknowledged(ctx, o.operatorUrl("evsepush", "v21", "status-records"), PushEvseStatusRequest{
+		ActionType: ActionTypeUpdate,
+		OperatorEvseStatus: OperatorEvseStatus{
+			OperatorID:       o.operatorId,
+			OperatorName:     o.operatorName,
+			EvseStatusRecord: records,
+		},
+	})
+}
+
+// chargeStationEvseIds returns the EVSE ids of the EVSEs that belong to the charge station
+func (o *OICP) chargeStationEvseIds(ctx context.Context, chargeStationId string) ([]string, error) {
+	locations, err := o.listAllLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var evseIds []string
+	for _, loc := range locations {
+		if loc.Evses == nil {
+			continue
+		}
+		for _, evse := range *loc.Evses {
+			match := evseUidRegexp.FindStringSubmatch(evse.Uid)
+			if match == nil || match[1] != chargeStationId || evse.EvseId == nil {
+				continue
+			}
+			evseIds = append(evseIds, *evse.EvseId)
+		}
+	}
+	return evseIds, nil
+}
+
+func (o *OICP) listAllLocations(ctx context.Context) ([]*store.Location, error) {
+	var locations []*store.Location
+	for {
+		page, err := o.store.ListLocations(ctx, len(locations), locationPageSize)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, page...)
+		if len(page) < locationPageSize {
+			return locations, nil
+		}
+	}
+}
+
+func (o *OICP) newEvseDataRecord(loc *store.Location, evse store.Evse) EvseDataRecord {
+	chargingStationId := evse.Uid
+	if match := evseUidRegexp.FindStringSubmatch(evse.Uid); match != nil {
+		chargingStationId = match[1]
+	}
+
+	plugs := make([]string, 0)
+	facilities := make([]ChargingFacility, 0)
+	for _, connector := range evse.Connectors {
+		if plug := plugType(connector); plug != "" {
+			plugs = append(plugs, plug)
+		}
+		facilities = append(facilities, newChargingFacility(connector))
+	}
+
+	return EvseDataRecord{
+		EvseID:               *evse.EvseId,
+		ChargingStationID:    chargingStationId,
+		ChargingStationNames: []InfoText{{Lang: "en", Value: loc.Name}},
+		Address: Address{
+			Country:    loc.Country,
+			City:       loc.City,
+			Street:     loc.Address,
+			PostalCode: loc.PostalCode,
+		},
+		GeoCoordinates: GeoCoordinates{
+			DecimalDegree: DecimalDegree{
+				Latitude:  loc.Coordinates.Latitude,
+				Longitude: loc.Coordinates.Longitude,
+			},
+		},
+		Plugs:                          plugs,
+		ChargingFacilities:             facilities,
+		CalibrationLawDataAvailability: "Not Available",
+		AuthenticationModes:            []string{"NFC RFID Classic", "REMOTE"},
+		PaymentOptions:                 []string{"Contract"},
+		ValueAddedServices:             []string{"None"},
+		Accessibility:                  "Unspecified",
+		HotlinePhoneNumber:             o.hotlinePhoneNumber,
+		IsOpen24Hours:                  true,
+		IsHubjectCompatible:            true,
+		DynamicInfoAvailable:           "true",
+	}
+}
+
+// plugType returns the OICP plug type of the OCPI connector, or an empty string if
+// there is no equivalent
+func plugType(connector store.Connector) string {
+	switch connector.Standard {
+	case "IEC_62196_T1":
+		return "Type 1 Connector (Cable Attached)"
+	case "IEC_62196_T1_COMBO":
+		return "CCS Combo 1 Plug (Cable Attached)"
+	case "IEC_62196_T2":
+		if connector.Format == "CABLE" {
+			return "Type 2 Connector (Cable Attached)"
+		}
+		return "Type 2 Outlet"
+	case "IEC_62196_T2_COMBO":
+		return "CCS Combo 2 Plug (Cable Attached)"
+	case "CHADEMO":
+		return "CHAdeMO"
+	case "DOMESTIC_F":
+		return "Type F Schuko"
+	case "TESLA_S":
+		return "Tesla Connector"
+	default:
+		return ""
+	}
+}
+
+func newChargingFacility(connector store.Connector) ChargingFacility {
+	facility := ChargingFacility{
+		PowerType: connector.PowerType,
+	}
+	if connector.MaxVoltage > 0 {
+		facility.Voltage = &connector.MaxVoltage
+	}
+	if connector.MaxAmperage > 0 {
+		facility.Amperage = &connector.MaxAmperage
+	}
+	// power in kW: OCPI gives the voltage between a phase and neutral
+	power := int64(connector.MaxVoltage) * int64(connector.MaxAmperage)
+	if connector.PowerType == "AC_3_PHASE" {
+		power *= 3
+	}
+	facility.Power = int32(power / 1000)
+	return facility
+}
+
+// evseStatusFromConnectors returns the status of an EVSE from the statuses of its
+// connectors, using the same priority as the OCPI integration
+func evseStatusFromConnectors(statuses []*store.ConnectorStatus) EvseStatus {
+	priority := []EvseStatus{
+		EvseStatusOccupied,
+		EvseStatusReserved,
+		EvseStatusAvailable,
+		EvseStatusOutOfService,
+	}
+
+	found := make(map[EvseStatus]bool)
+	for _, status := range statuses {
+		if status.ConnectorId == 0 {
+			continue
+		}
+		switch status.Status {
+		case "Charging", "Occupied", "Preparing", "SuspendedEV", "SuspendedEVSE", "Finishing":
+			found[EvseStatusOccupied] = true
+		case "Reserved":
+			found[EvseStatusReserved] = true
+		case "Available":
+			found[EvseStatusAvailable] = true
+		case "Unavailable", "Faulted":
+			found[EvseStatusOutOfService] = true
+		}
+	}
+
+	for _, status := range priority {
+		if found[status] {
+			return status
+		}
+	}
+	return EvseStatusUnknown
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package oicp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"net/url"
+)
+
+// Api is the OICP integration: it is informed of the same events as the OCPI integration
+// and asks Hubject to authorize tokens that are not known to the CSMS
+type Api interface {
+	AuthorizeToken(ctx context.Context, idToken, tokenType string) (*store.Token, error)
+	TransactionChanged(ctx context.Context, transaction *store.Transaction) error
+	ConnectorStatusChanged(ctx context.Context, status *store.ConnectorStatus) error
+	PushEvseData(ctx context.Context) error
+}
+
+type OICP struct {
+	store              store.Engine
+	httpClient         *http.Client
+	tokenService       services.HttpTokenService
+	url                string
+	operatorId         string
+	operatorName       string
+	hotlinePhoneNumber string
+}
+
+func NewOICP(store store.Engine, httpClient *http.Client, url, operatorId, operatorName, hotlinePhoneNumber string) *OICP {
+	return &OICP{
+		store:              store,
+		httpClient:         httpClient,
+		url:                url,
+		operatorId:         operatorId,
+		operatorName:       operatorName,
+		hotlinePhoneNumber: hotlinePhoneNumber,
+	}
+}
+
+// SetHttpTokenService sets the service that provides the bearer token sent to Hubject:
+// no token is sent if it is not set, e.g. when Hubject authenticates the client
+// certificate instead
+func (o *OICP) SetHttpTokenService(tokenService services.HttpTokenService) {
+	o.tokenService = tokenService
+}
+
+// operatorUrl returns the URL of the Hubject service for this operator
+func (o *OICP) operatorUrl(service, version, operation string) string {
+	return fmt.Sprintf("%s/api/oicp/%s/%s/operators/%s/%s", o.url, service, version, url.PathEscape(o.operatorId), operation)
+}
+
+// post sends the request to Hubject and decodes the response into result
+func (o *OICP) post(ctx context.Context, url string, request any, result any) error {
+	b, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.tokenService != nil {
+		token, err := o.tokenService.GetToken(ctx, false)
+		if err != nil {
+			return fmt.Errorf("getting hubject token: %w", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return errs.Wrap(errs.ErrDependencyUnavailable, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return errs.New(errs.ErrDependencyUnavailable, "status code: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// pushAcknowledged sends a push request to Hubject and checks that it was accepted
+func (o *OICP) pushAcknowledged(ctx context.Context, url string, request any) error {
+	var ack Acknowledgement
+	err := o.post(ctx, url, request, &ack)
+	if err != nil {
+		return err
+	}
+	if !ack.Result || ack.StatusCode.Code != StatusCodeSuccess {
+		return fmt.Errorf("push rejected: %s", describeStatusCode(ack.StatusCode))
+	}
+	return nil
+}
+
+func describeStatusCode(statusCode StatusCode) string {
+	if statusCode.Description != nil {
+		return fmt.Sprintf("%s %s", statusCode.Code, *statusCode.Description)
+	}
+	return statusCode.Code
+}
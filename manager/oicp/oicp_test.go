@@ -0,0 +1,305 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package oicp_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/oicp"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"io"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type receivedRequest struct {
+	path  string
+	token string
+	body  string
+}
+
+// newHubjectServer returns a server that records the requests it receives and responds
+// with the response registered for the path
+func newHubjectServer(t *testing.T, responses map[string]any) (*httptest.Server, *[]receivedRequest) {
+	var received []receivedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = append(received, receivedRequest{
+			path:  r.URL.Path,
+			token: r.Header.Get("Authorization"),
+			body:  string(b),
+		})
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func setupOicp(t *testing.T, url string) (*oicp.OICP, store.Engine) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	api := oicp.NewOICP(engine, http.DefaultClient, url, "DE*TWK", "Thoughtworks", "+4930123456")
+	api.SetHttpTokenService(services.NewFixedHttpTokenService("hubject-token"))
+	return api, engine
+}
+
+func setupLocation(t *testing.T, engine store.Engine) {
+	evseId := "DE*TWK*E0001"
+	err := engine.SetLocation(context.Background(), &store.Location{
+		Id:      "loc001",
+		Name:    "Gasometer Schöneberg",
+		Address: "Torgauer Straße 12-15",
+		City:    "Berlin",
+		Country: "DEU",
+		Coordinates: store.GeoLocation{
+			Latitude:  "52.4848",
+			Longitude: "13.3574",
+		},
+		PostalCode: "10829",
+		Evses: &[]store.Evse{
+			{
+				Uid:    "DETWKEcs001",
+				EvseId: &evseId,
+				Connectors: []store.Connector{
+					{
+						Id:          "1",
+						Format:      "SOCKET",
+						Standard:    "IEC_62196_T2",
+						PowerType:   "AC_3_PHASE",
+						MaxVoltage:  230,
+						MaxAmperage: 32,
+					},
+				},
+			},
+			{
+				// no EVSE id, so not published
+				Uid: "DETWKEcs002",
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestAuthorizeTokenAuthorizedByHubject(t *testing.T) {
+	sessionId := "b2688855-7f00-0002-6d8e-48d498008a6b"
+	providerId := "DE*ICE"
+	server, received := newHubjectServer(t, map[string]any{
+		"/api/oicp/charging/v21/operators/DE*TWK/authorize/start": oicp.Authorization{
+			SessionID:           &sessionId,
+			ProviderID:          &providerId,
+			AuthorizationStatus: oicp.AuthorizationStatusAuthorized,
+			StatusCode:          oicp.StatusCode{Code: oicp.StatusCodeSuccess},
+		},
+	})
+	api, engine := setupOicp(t, server.URL)
+	ctx := context.Background()
+
+	tok, err := api.AuthorizeToken(ctx, "DEADBEEF", "RFID")
+	require.NoError(t, err)
+
+	assert.Equal(t, &store.Token{
+		CountryCode: "DE",
+		PartyId:     "ICE",
+		Type:        "RFID",
+		Uid:         "DEADBEEF",
+		ContractId:  "DEADBEEF",
+		Issuer:      "DE*ICE",
+		Valid:       true,
+		CacheMode:   store.CacheModeNever,
+	}, tok)
+
+	require.Len(t, *received, 1)
+	assert.Equal(t, "Bearer hubject-token", (*received)[0].token)
+	assert.JSONEq(t, `{"OperatorID":"DE*TWK","Identification":{"RFIDMifareFamilyIdentification":{"UID":"DEADBEEF"}}}`,
+		(*received)[0].body)
+
+	session, err := engine.LookupOicpSession(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.Equal(t, &store.OicpSession{IdToken: "DEADBEEF", SessionId: sessionId}, session)
+}
+
+func TestAuthorizeTokenNotAuthorizedByHubject(t *testing.T) {
+	description := "No positive authorization response"
+	server, _ := newHubjectServer(t, map[string]any{
+		"/api/oicp/charging/v21/operators/DE*TWK/authorize/start": oicp.Authorization{
+			AuthorizationStatus: oicp.AuthorizationStatusNotAuthorized,
+			StatusCode:          oicp.StatusCode{Code: "320", Description: &description},
+		},
+	})
+	api, engine := setupOicp(t, server.URL)
+
+	tok, err := api.AuthorizeToken(context.Background(), "EMP77TWTW99999", "eMAID")
+	require.NoError(t, err)
+	assert.Nil(t, tok)
+
+	session, err := engine.LookupOicpSession(context.Background(), "EMP77TWTW99999")
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestAuthorizeTokenReturnsErrorWhenHubjectFails(t *testing.T) {
+	server, _ := newHubjectServer(t, map[string]any{})
+	api, _ := setupOicp(t, server.URL)
+
+	_, err := api.AuthorizeToken(context.Background(), "DEADBEEF", "RFID")
+	assert.Error(t, err)
+}
+
+func TestTransactionChangedStopsHubjectSession(t *testing.T) {
+	server, received := newHubjectServer(t, map[string]any{
+		"/api/oicp/charging/v21/operators/DE*TWK/authorize/stop": oicp.Authorization{
+			AuthorizationStatus: oicp.AuthorizationStatusAuthorized,
+			StatusCode:          oicp.StatusCode{Code: oicp.StatusCodeSuccess},
+		},
+	})
+	api, engine := setupOicp(t, server.URL)
+	setupLocation(t, engine)
+	ctx := context.Background()
+
+	err := engine.SetOicpSession(ctx, &store.OicpSession{IdToken: "DEADBEEF", SessionId: "s001"})
+	require.NoError(t, err)
+
+	// transactions that are still in progress are not stopped
+	transaction := &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "RFID",
+	}
+	require.NoError(t, api.TransactionChanged(ctx, transaction))
+	assert.Empty(t, *received)
+
+	transaction.EndedSeqNo = 1
+	require.NoError(t, api.TransactionChanged(ctx, transaction))
+
+	require.Len(t, *received, 1)
+	assert.JSONEq(t, `{
+		"SessionID": "s001",
+		"CPOPartnerSessionID": "tx001",
+		"OperatorID": "DE*TWK",
+		"EvseID": "DE*TWK*E0001",
+		"Identification": {"RFIDMifareFamilyIdentification": {"UID": "DEADBEEF"}}
+	}`, (*received)[0].body)
+
+	session, err := engine.LookupOicpSession(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestTransactionChangedIgnoresTransactionsNotAuthorizedByHubject(t *testing.T) {
+	server, received := newHubjectServer(t, map[string]any{})
+	api, _ := setupOicp(t, server.URL)
+
+	err := api.TransactionChanged(context.Background(), &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "RFID",
+		EndedSeqNo:      1,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, *received)
+}
+
+func TestPushEvseData(t *testing.T) {
+	server, received := newHubjectServer(t, map[string]any{
+		"/api/oicp/evsepush/v23/operators/DE*TWK/data-records": oicp.Acknowledgement{
+			Result:     true,
+			StatusCode: oicp.StatusCode{Code: oicp.StatusCodeSuccess},
+		},
+	})
+	api, engine := setupOicp(t, server.URL)
+	setupLocation(t, engine)
+
+	err := api.PushEvseData(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, *received, 1)
+	var req oicp.PushEvseDataRequest
+	require.NoError(t, json.Unmarshal([]byte((*received)[0].body), &req))
+
+	voltage, amperage := int32(230), int32(32)
+	assert.Equal(t, oicp.ActionTypeFullLoad, req.ActionType)
+	assert.Equal(t, "DE*TWK", req.OperatorEvseData.OperatorID)
+	require.Len(t, req.OperatorEvseData.EvseDataRecord, 1)
+	record := req.OperatorEvseData.EvseDataRecord[0]
+	assert.Equal(t, "DE*TWK*E0001", record.EvseID)
+	assert.Equal(t, "cs001", record.ChargingStationID)
+	assert.Equal(t, oicp.Address{
+		Country:    "DEU",
+		City:       "Berlin",
+		Street:     "Torgauer Straße 12-15",
+		PostalCode: "10829",
+	}, record.Address)
+	assert.Equal(t, "52.4848", record.GeoCoordinates.DecimalDegree.Latitude)
+	assert.Equal(t, []string{"Type 2 Outlet"}, record.Plugs)
+	assert.Equal(t, []oicp.ChargingFacility{
+		{PowerType: "AC_3_PHASE", Voltage: &voltage, Amperage: &amperage, Power: 22},
+	}, record.ChargingFacilities)
+	assert.Equal(t, "+4930123456", record.HotlinePhoneNumber)
+}
+
+func TestPushEvseDataReturnsErrorWhenRejected(t *testing.T) {
+	server, _ := newHubjectServer(t, map[string]any{
+		"/api/oicp/evsepush/v23/operators/DE*TWK/data-records": oicp.Acknowledgement{
+			Result:     false,
+			StatusCode: oicp.StatusCode{Code: "019"},
+		},
+	})
+	api, engine := setupOicp(t, server.URL)
+	setupLocation(t, engine)
+
+	err := api.PushEvseData(context.Background())
+	assert.ErrorContains(t, err, "push rejected: 019")
+}
+
+func TestConnectorStatusChangedPushesEvseStatus(t *testing.T) {
+	server, received := newHubjectServer(t, map[string]any{
+		"/api/oicp/evsepush/v21/operators/DE*TWK/status-records": oicp.Acknowledgement{
+			Result:     true,
+			StatusCode: oicp.StatusCode{Code: oicp.StatusCodeSuccess},
+		},
+	})
+	api, engine := setupOicp(t, server.URL)
+	setupLocation(t, engine)
+	ctx := context.Background()
+
+	status := &store.ConnectorStatus{
+		ChargeStationId: "cs001",
+		ConnectorId:     1,
+		Status:          "Charging",
+		LastUpdated:     time.Now(),
+	}
+	require.NoError(t, engine.SetConnectorStatus(ctx, status))
+
+	err := api.ConnectorStatusChanged(ctx, status)
+	require.NoError(t, err)
+
+	require.Len(t, *received, 1)
+	assert.JSONEq(t, `{
+		"ActionType": "update",
+		"OperatorEvseStatus": {
+			"OperatorID": "DE*TWK",
+			"OperatorName": "Thoughtworks",
+			"EvseStatusRecord": [{"EvseID": "DE*TWK*E0001", "EvseStatus": "Occupied"}]
+		}
+	}`, (*received)[0].body)
+
+	// charge stations without a published EVSE are not pushed
+	err = api.ConnectorStatusChanged(ctx, &store.ConnectorStatus{ChargeStationId: "cs002", ConnectorId: 1, Status: "Available"})
+	require.NoError(t, err)
+	assert.Len(t, *received, 1)
+}
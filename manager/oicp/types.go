@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package oicp
+
+// The types in this file are the subset of the OICP 2.3 CPO messages used by the
+// manager. Optional fields that are not used are omitted.
+
+type StatusCode struct {
+	Code           string  `json:"Code"`
+	Description    *string `json:"Description,omitempty"`
+	AdditionalInfo *string `json:"AdditionalInfo,omitempty"`
+}
+
+// StatusCodeSuccess is the status code returned by Hubject when a request succeeds
+const StatusCodeSuccess = "000"
+
+type Acknowledgement struct {
+	Result     bool       `json:"Result"`
+	StatusCode StatusCode `json:"StatusCode"`
+}
+
+type RFIDMifareFamilyIdentification struct {
+	UID string `json:"UID"`
+}
+
+type PlugAndChargeIdentification struct {
+	EvcoID string `json:"EvcoID"`
+}
+
+type Identification struct {
+	RFIDMifareFamilyIdentification *RFIDMifareFamilyIdentification `json:"RFIDMifareFamilyIdentification,omitempty"`
+	PlugAndChargeIdentification    *PlugAndChargeIdentification    `json:"PlugAndChargeIdentification,omitempty"`
+}
+
+type AuthorizeStartRequest struct {
+	OperatorID     string         `json:"OperatorID"`
+	EvseID         *string        `json:"EvseID,omitempty"`
+	Identification Identification `json:"Identification"`
+}
+
+type AuthorizeStopRequest struct {
+	SessionID           string         `json:"SessionID"`
+	CPOPartnerSessionID *string        `json:"CPOPartnerSessionID,omitempty"`
+	OperatorID          string         `json:"OperatorID"`
+	EvseID              *string        `json:"EvseID,omitempty"`
+	Identification      Identification `json:"Identification"`
+}
+
+type AuthorizationStatus string
+
+const (
+	AuthorizationStatusAuthorized    AuthorizationStatus = "Authorized"
+	AuthorizationStatusNotAuthorized AuthorizationStatus = "NotAuthorized"
+)
+
+// Authorization is the response to both an authorize start and an authorize stop request
+type Authorization struct {
+	SessionID           *string             `json:"SessionID,omitempty"`
+	ProviderID          *string             `json:"ProviderID,omitempty"`
+	AuthorizationStatus AuthorizationStatus `json:"AuthorizationStatus"`
+	StatusCode          StatusCode          `json:"StatusCode"`
+}
+
+type ActionType string
+
+const (
+	ActionTypeFullLoad ActionType = "fullLoad"
+	ActionTypeUpdate   ActionType = "update"
+)
+
+type Address struct {
+	Country    string `json:"Country"`
+	City       string `json:"City"`
+	Street     string `json:"Street"`
+	PostalCode string `json:"PostalCode"`
+}
+
+type DecimalDegree struct {
+	Latitude  string `json:"Latitude"`
+	Longitude string `json:"Longitude"`
+}
+
+type GeoCoordinates struct {
+	DecimalDegree DecimalDegree `json:"DecimalDegree"`
+}
+
+type InfoText struct {
+	Lang  string `json:"lang"`
+	Value string `json:"value"`
+}
+
+type ChargingFacility struct {
+	PowerType string `json:"PowerType"`
+	Voltage   *int32 `json:"Voltage,omitempty"`
+	Amperage  *int32 `json:"Amperage,omitempty"`
+	Power     int32  `json:"Power"`
+}
+
+type EvseDataRecord struct {
+	EvseID                         string             `json:"EvseID"`
+	ChargingStationID              string             `json:"ChargingStationID"`
+	ChargingStationNames           []InfoText         `json:"ChargingStationNames"`
+	Address                        Address            `json:"Address"`
+	GeoCoordinates                 GeoCoordinates     `json:"GeoCoordinates"`
+	Plugs                          []string           `json:"Plugs"`
+	ChargingFacilities             []ChargingFacility `json:"ChargingFacilities"`
+	RenewableEnergy                bool               `json:"RenewableEnergy"`
+	CalibrationLawDataAvailability string             `json:"CalibrationLawDataAvailability"`
+	AuthenticationModes            []string           `json:"AuthenticationModes"`
+	PaymentOptions                 []string           `json:"PaymentOptions"`
+	ValueAddedServices             []string           `json:"ValueAddedServices"`
+	Accessibility                  string             `json:"Accessibility"`
+	HotlinePhoneNumber             string             `json:"HotlinePhoneNumber"`
+	IsOpen24Hours                  bool               `json:"IsOpen24Hours"`
+	IsHubjectCompatible            bool               `json:"IsHubjectCompatible"`
+	DynamicInfoAvailable           string             `json:"DynamicInfoAvailable"`
+}
+
+type OperatorEvseData struct {
+	OperatorID     string           `json:"OperatorID"`
+	OperatorName   string           `json:"OperatorName"`
+	EvseDataRecord []EvseDataRecord `json:"EvseDataRecord"`
+}
+
+type PushEvseDataRequest struct {
+	ActionType       ActionType       `json:"ActionType"`
+	OperatorEvseData OperatorEvseData `json:"OperatorEvseData"`
+}
+
+type EvseStatus string
+
+const (
+	EvseStatusAvailable    EvseStatus = "Available"
+	EvseStatusReserved     EvseStatus = "Reserved"
+	EvseStatusOccupied     EvseStatus = "Occupied"
+	EvseStatusOutOfService EvseStatus = "OutOfService"
+	EvseStatusUnknown      EvseStatus = "Unknown"
+)
+
+type EvseStatusRecord struct {
+	EvseID     string     `json:"EvseID"`
+	EvseStatus EvseStatus `json:"EvseStatus"`
+}
+
+type OperatorEvseStatus struct {
+	OperatorID       string             `json:"OperatorID"`
+	OperatorName     string             `json:"OperatorName"`
+	EvseStatusRecord []EvseStatusRecord `json:"EvseStatusRecord"`
+}
+
+type PushEvseStatusRequest struct {
+	ActionType         ActionType         `json:"ActionType"`
+	OperatorEvseStatus OperatorEvseStatus `json:"OperatorEvseStatus"`
+}
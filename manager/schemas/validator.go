@@ -11,6 +11,8 @@ import (
 	"io"
 	"io/fs"
 	"net/url"
+	"reflect"
+	"sync"
 )
 
 type FSLoader struct {
@@ -28,13 +30,72 @@ func (f FSLoader) Load(u string) (io.ReadCloser, error) {
 	return nil, nil
 }
 
-func Validate(data json.RawMessage, schemaFs fs.FS, schemaFile string) error {
+type cacheKey struct {
+	schemaFs   fs.FS
+	schemaFile string
+}
+
+var (
+	// compileMu serializes compilation: the fs loader is registered globally, so
+	// only one schema fs can be in use at a time
+	compileMu sync.Mutex
+	cacheMu   sync.RWMutex
+	cache     = make(map[cacheKey]*jsonschema.Schema)
+)
+
+// Compile returns the compiled schema held in the file. Schemas are compiled once
+// and cached by fs and path, so the contents of the fs must not change. An fs that
+// cannot be used as a map key, such as fstest.MapFS, is compiled on every call.
+func Compile(schemaFs fs.FS, schemaFile string) (*jsonschema.Schema, error) {
+	if t := reflect.TypeOf(schemaFs); t == nil || !t.Comparable() {
+		return compile(schemaFs, schemaFile)
+	}
+
+	key := cacheKey{schemaFs: schemaFs, schemaFile: schemaFile}
+	cacheMu.RLock()
+	schema, ok := cache[key]
+	cacheMu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	schema, err := compile(schemaFs, schemaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheMu.Lock()
+	cache[key] = schema
+	cacheMu.Unlock()
+	return schema, nil
+}
+
+func compile(schemaFs fs.FS, schemaFile string) (*jsonschema.Schema, error) {
+	compileMu.Lock()
+	defer compileMu.Unlock()
+
 	compiler := jsonschema.NewCompiler()
 	compiler.Draft = jsonschema.Draft6
 	loader.Register("fs", FSLoader{
 		FS: schemaFs,
 	})
-	schema, err := compiler.Compile(fmt.Sprintf("fs:///%s", schemaFile))
+	return compiler.Compile(fmt.Sprintf("fs:///%s", schemaFile))
+}
+
+// Precompile adds the schemas to the cache so that the first messages validated
+// against them are not delayed by compilation
+func Precompile(schemaFs fs.FS, schemaFiles ...string) error {
+	for _, schemaFile := range schemaFiles {
+		_, err := Compile(schemaFs, schemaFile)
+		if err != nil {
+			return fmt.Errorf("compiling %s: %w", schemaFile, err)
+		}
+	}
+	return nil
+}
+
+func Validate(data json.RawMessage, schemaFs fs.FS, schemaFile string) error {
+	schema, err := Compile(schemaFs, schemaFile)
 	if err != nil {
 		return err
 	}
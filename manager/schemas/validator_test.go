@@ -11,6 +11,7 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/schemas"
 	"testing"
+	"testing/fstest"
 )
 
 func TestValidateRequest(t *testing.T) {
@@ -46,3 +47,38 @@ func TestValidateInvalidRequest(t *testing.T) {
 	var valError *jsonschema.ValidationError
 	assert.ErrorAs(t, err, &valError)
 }
+
+func TestCompileCachesSchema(t *testing.T) {
+	first, err := schemas.Compile(schemas.OcppSchemas, "ocpp201/HeartbeatRequest.json")
+	require.NoError(t, err)
+	second, err := schemas.Compile(schemas.OcppSchemas, "ocpp201/HeartbeatRequest.json")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+}
+
+func TestCompileDoesNotCacheUncomparableFS(t *testing.T) {
+	schemaFs := fstest.MapFS{
+		"test.json": &fstest.MapFile{Data: []byte(`{"type":"object"}`)},
+	}
+
+	first, err := schemas.Compile(schemaFs, "test.json")
+	require.NoError(t, err)
+	second, err := schemas.Compile(schemaFs, "test.json")
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+}
+
+func TestPrecompileReportsMissingSchema(t *testing.T) {
+	err := schemas.Precompile(schemas.OcppSchemas, "ocpp201/HeartbeatRequest.json", "ocpp201/Unknown.json")
+	assert.ErrorContains(t, err, "ocpp201/Unknown.json")
+}
+
+func BenchmarkValidate(b *testing.B) {
+	data := []byte(`{"chargingStation":{"model":"SuperTest","vendorName":"TW"},"reason":"PowerUp"}`)
+	for i := 0; i < b.N; i++ {
+		err := schemas.Validate(data, schemas.OcppSchemas, "ocpp201/BootNotificationRequest.json")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,6 +3,9 @@
 package server
 
 import (
+	"encoding/json"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/rs/cors"
 	"github.com/thoughtworks/maeve-csms/manager/adminui"
 	"github.com/thoughtworks/maeve-csms/manager/api"
@@ -10,7 +13,9 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
 	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"github.com/unrolled/secure"
+	"golang.org/x/exp/slog"
 	"k8s.io/utils/clock"
 	"net/http"
 	"os"
@@ -22,8 +27,15 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/templates"
 )
 
-func NewApiHandler(settings config.ApiSettings, engine store.Engine, ocpi ocpi.Api, csCertProvider services.ChargeStationCertificateProvider) http.Handler {
-	apiServer, err := api.NewServer(engine, clock.RealClock{}, ocpi)
+// NewApiHandler returns the handler for the API server. The API is served at /api/v1
+// and, for existing clients, at /api/v0. The /readyz endpoint reports that the manager
+// is unavailable if the engine or any of the transports is unhealthy, or once the
+// manager has been drained through the /drain endpoint. The /reload endpoint reloads
+// the configuration and the /root-certificates/refresh endpoint refreshes the root
+// certificates used to validate contract certificates. When there is an authenticator, callers must have the role
+// required by the route group.
+func NewApiHandler(settings config.ApiSettings, engine store.Engine, ocpi ocpi.Api, csCertProvider services.ChargeStationCertificateProvider, transports ...transport.HealthReporter) http.Handler {
+	apiServer, err := api.NewServer(engine, clock.RealClock{}, ocpi, api.WithActionFlags(settings.ActionFlags), api.WithEventStream(settings.EventStream), api.WithReceipts(settings.Receipts))
 	if err != nil {
 		panic(err)
 	}
@@ -45,27 +57,86 @@ func NewApiHandler(settings config.ApiSettings, engine store.Engine, ocpi ocpi.A
 	logger := middleware.RequestLogger(logFormatter{})
 
 	r.Use(middleware.Recoverer, secureMiddleware.Handler, cors.Default().Handler, api.ValidationMiddleware)
+
+	// the probes, metrics and API description are used by infrastructure and are not authenticated
+	if settings.Drain != nil {
+		transports = append(transports, settings.Drain)
+	}
 	r.Get("/health", health)
-	r.Get("/transactions", transactions(engine))
+	r.Get("/readyz", readyz(engine, transports))
 	r.Handle("/metrics", promhttp.Handler())
-	r.Get("/api/openapi.json", getApiSwaggerJson)
-	r.With(logger).Mount("/api/v0", api.Handler(apiServer))
-	r.With(logger).Mount("/adminui", adminui.NewServer(settings.Host, settings.WsPort, settings.WssPort, settings.OrgName, engine, csCertProvider))
+	r.Get("/api/openapi.json", getApiSwaggerJson("/api/v1"))
+
+	// v0 is the unversioned API that existing clients use: it is the same as v1
+	r.Mount("/api/v1", apiRouter(apiServer, settings.Authenticator, logger, "/api/v1"))
+	r.With(deprecated("/api/v1")).Mount("/api/v0", apiRouter(apiServer, settings.Authenticator, logger, "/api/v0"))
+
+	// the admin UI can be read by any caller and changed by operators
+	r.Group(func(r chi.Router) {
+		r.Use(api.Authorize(settings.Authenticator, api.RoleReadOnly, api.RoleOperator))
+		r.With(api.RejectTenants).Get("/transactions", transactions(engine))
+		r.With(logger, api.RejectTenants).Mount("/adminui", adminui.NewServer(settings.Host, settings.WsPort, settings.WssPort, settings.OrgName, engine, csCertProvider))
+	})
+
+	// managing the manager itself is reserved for admins that are not scoped to a tenant
+	r.Group(func(r chi.Router) {
+		r.Use(api.Authorize(settings.Authenticator, api.RoleAdmin, api.RoleAdmin), api.RejectTenants)
+		if settings.Drain != nil {
+			r.Post("/drain", drain(settings.Drain))
+		}
+		if settings.Reloader != nil {
+			r.Post("/reload", reload(settings.Reloader))
+		}
+		if settings.RootCertificates != nil {
+			r.Post("/root-certificates/refresh", refreshRootCertificates(settings.RootCertificates))
+		}
+	})
 	return r
 }
 
-func getApiSwaggerJson(w http.ResponseWriter, r *http.Request) {
-	swagger, err := api.GetSwagger()
-	if err != nil {
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		return
+// apiRouter returns the router for a version of the API that is mounted at basePath.
+// The API description is not authenticated. The API can be read by any caller and
+// changed by operators: callers are scoped to their tenant.
+func apiRouter(apiServer api.ServerInterface, authenticator api.Authenticator, logger func(http.Handler) http.Handler, basePath string) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/openapi.json", getApiSwaggerJson(basePath))
+	r.Group(func(r chi.Router) {
+		r.Use(api.Authorize(authenticator, api.RoleReadOnly, api.RoleOperator), logger)
+		api.HandlerFromMux(apiServer, r)
+	})
+	return r
+}
+
+// deprecated marks the responses from a version of the API that has been replaced by
+// the version at successor
+func deprecated(successor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+			next.ServeHTTP(w, r)
+		})
 	}
-	json, err := swagger.MarshalJSON()
-	if err != nil {
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		return
+}
+
+// getApiSwaggerJson serves the API description with the server that is mounted at
+// basePath, so that clients generated from it call the same version of the API
+func getApiSwaggerJson(basePath string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		swagger, err := api.GetSwagger()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		swagger.Servers = openapi3.Servers{{URL: basePath}}
+		json, err := swagger.MarshalJSON()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(json)
 	}
-	_, _ = w.Write(json)
 }
 
 func health(w http.ResponseWriter, r *http.Request) {
@@ -73,6 +144,81 @@ func health(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write([]byte(`{"status":"OK"}`))
 }
 
+func readyz(engine store.Engine, transports []transport.HealthReporter) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if reporter, ok := engine.(store.HealthReporter); ok {
+			err = reporter.Healthy()
+		}
+		for _, reporter := range transports {
+			if err == nil {
+				err = reporter.Healthy()
+			}
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"Unavailable"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}
+}
+
+// drain stops the manager receiving messages ahead of it being shut down, responding
+// once the messages that have already been received have been handled
+func drain(d *transport.Drain) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := d.Start(r.Context())
+		if err != nil {
+			slog.Error("draining", "err", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"status":"Failed"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"Drained"}`))
+	}
+}
+
+// reload applies the settings that can be changed while the manager is running from
+// the configuration, responding with the settings that were changed
+func reload(reloader *config.Reloader) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		changes, err := reloader.Reload()
+		if err != nil {
+			slog.Error("reloading configuration", "err", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]any{"status": "Failed", "error": err.Error()})
+			return
+		}
+		if changes == nil {
+			changes = []config.Change{}
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "Reloaded", "changes": changes})
+	}
+}
+
+// refreshRootCertificates retrieves the root certificates used to validate contract
+// certificates, so that new root certificates are trusted without waiting for the
+// hourly refresh, responding with the number of root certificates retrieved
+func refreshRootCertificates(refresher services.RootCertificateRefresher) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		count, err := refresher.RefreshRootCertificates(r.Context())
+		if err != nil {
+			slog.Error("refreshing root certificates", "err", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]any{"status": "Failed", "error": err.Error()})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "Refreshed", "certificates": count})
+	}
+}
+
 func transactions(transactionStore store.Engine) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ts, err := transactionStore.Transactions(r.Context())
@@ -3,16 +3,22 @@
 package server_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/api"
 	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"io"
 	"k8s.io/utils/clock"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/thoughtworks/maeve-csms/manager/server"
 )
@@ -36,6 +42,174 @@ func TestHealthHandler(t *testing.T) {
 	}
 }
 
+type unhealthyEngine struct {
+	store.Engine
+}
+
+func (unhealthyEngine) Healthy() error {
+	return errors.New("storage unavailable")
+}
+
+func TestReadyzHandler(t *testing.T) {
+	handler := server.NewApiHandler(config.ApiSettings{}, inmemory.NewStore(clock.RealClock{}), nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReadyzHandlerWithUnhealthyStore(t *testing.T) {
+	engine := unhealthyEngine{Engine: inmemory.NewStore(clock.RealClock{})}
+	handler := server.NewApiHandler(config.ApiSettings{}, engine, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"status":"Unavailable"}`, w.Body.String())
+}
+
+type unhealthyTransport struct{}
+
+func (unhealthyTransport) Healthy() error {
+	return errors.New("not connected")
+}
+
+func TestReadyzHandlerWithUnhealthyTransport(t *testing.T) {
+	handler := server.NewApiHandler(config.ApiSettings{}, inmemory.NewStore(clock.RealClock{}), nil, nil, unhealthyTransport{})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"status":"Unavailable"}`, w.Body.String())
+}
+
+func TestDrainHandler(t *testing.T) {
+	drain := transport.NewDrain(time.Second)
+	handler := server.NewApiHandler(config.ApiSettings{Drain: drain}, inmemory.NewStore(clock.RealClock{}), nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/drain", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"Drained"}`, w.Body.String())
+	assert.True(t, drain.Draining())
+
+	req = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"status":"Unavailable"}`, w.Body.String())
+}
+
+func TestReloadHandler(t *testing.T) {
+	reloader := config.NewReloader(&config.DefaultConfig)
+	handler := server.NewApiHandler(config.ApiSettings{Reloader: reloader}, inmemory.NewStore(clock.RealClock{}), nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/reload", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"status":"Failed","error":"no configuration to reload"}`, w.Body.String())
+
+	reloader.SetLoader(func() (*config.BaseConfig, error) {
+		return &config.DefaultConfig, nil
+	})
+	req = httptest.NewRequest(http.MethodPost, "/reload", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"Reloaded","changes":[]}`, w.Body.String())
+}
+
+type fakeRootCertificateRefresher struct {
+	count int
+	err   error
+}
+
+func (f fakeRootCertificateRefresher) RefreshRootCertificates(context.Context) (int, error) {
+	return f.count, f.err
+}
+
+func TestRefreshRootCertificatesHandler(t *testing.T) {
+	handler := server.NewApiHandler(config.ApiSettings{RootCertificates: fakeRootCertificateRefresher{count: 3}},
+		inmemory.NewStore(clock.RealClock{}), nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/root-certificates/refresh", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"Refreshed","certificates":3}`, w.Body.String())
+}
+
+func TestRefreshRootCertificatesHandlerWhenRefreshFails(t *testing.T) {
+	handler := server.NewApiHandler(config.ApiSettings{RootCertificates: fakeRootCertificateRefresher{err: errors.New("unavailable")}},
+		inmemory.NewStore(clock.RealClock{}), nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/root-certificates/refresh", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"status":"Failed","error":"unavailable"}`, w.Body.String())
+}
+
+func TestApiHandlerAuthorizesRouteGroups(t *testing.T) {
+	apiKeys := api.NewApiKeyAuthenticator()
+	apiKeys.Add("reader-key", api.Principal{Name: "reader", Role: api.RoleReadOnly})
+	apiKeys.Add("operator-key", api.Principal{Name: "operator", Role: api.RoleOperator})
+	apiKeys.Add("admin-key", api.Principal{Name: "admin", Role: api.RoleAdmin})
+	settings := config.ApiSettings{
+		Drain:            transport.NewDrain(time.Second),
+		Authenticator:    apiKeys,
+		RootCertificates: fakeRootCertificateRefresher{},
+	}
+	handler := server.NewApiHandler(settings, inmemory.NewStore(clock.RealClock{}), nil, nil)
+
+	// the drain comes last as the manager is not ready once it has been drained
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+		want   int
+	}{
+		{"health is public", http.MethodGet, "/health", "", http.StatusOK},
+		{"readyz is public", http.MethodGet, "/readyz", "", http.StatusOK},
+		{"api description is public", http.MethodGet, "/api/v1/openapi.json", "", http.StatusOK},
+		{"api needs credentials", http.MethodGet, "/api/v1/token/unknown", "", http.StatusUnauthorized},
+		{"reader reads the api", http.MethodGet, "/api/v1/token/unknown", "reader-key", http.StatusNotFound},
+		{"reader cannot change the api", http.MethodPost, "/api/v1/token", "reader-key", http.StatusForbidden},
+		{"v0 api needs credentials", http.MethodGet, "/api/v0/token/unknown", "", http.StatusUnauthorized},
+		{"reader reads the v0 api", http.MethodGet, "/api/v0/token/unknown", "reader-key", http.StatusNotFound},
+		{"operator cannot refresh root certificates", http.MethodPost, "/root-certificates/refresh", "operator-key", http.StatusForbidden},
+		{"admin refreshes root certificates", http.MethodPost, "/root-certificates/refresh", "admin-key", http.StatusOK},
+		{"operator cannot drain", http.MethodPost, "/drain", "operator-key", http.StatusForbidden},
+		{"admin drains", http.MethodPost, "/drain", "admin-key", http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.key != "" {
+				req.Header.Set(api.ApiKeyHeader, tc.key)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tc.want, w.Code)
+		})
+	}
+}
+
 func TestMetricsHandler(t *testing.T) {
 	handler := server.NewApiHandler(config.ApiSettings{}, inmemory.NewStore(clock.RealClock{}), nil, nil)
 
@@ -86,3 +260,34 @@ func TestSwaggerHandler(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, jsonData["info"].(map[string]any)["title"], "MaEVe CSMS")
 }
+
+func TestApiVersions(t *testing.T) {
+	handler := server.NewApiHandler(config.ApiSettings{}, inmemory.NewStore(clock.RealClock{}), nil, nil)
+
+	for _, basePath := range []string{"/api/v1", "/api/v0"} {
+		req := httptest.NewRequest(http.MethodGet, basePath+"/openapi.json", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var swagger struct {
+			Servers []struct {
+				Url string `json:"url"`
+			} `json:"servers"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &swagger))
+		require.Len(t, swagger.Servers, 1)
+		assert.Equal(t, basePath, swagger.Servers[0].Url)
+
+		req = httptest.NewRequest(http.MethodGet, basePath+"/token", nil)
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		if basePath == "/api/v0" {
+			assert.Equal(t, "true", w.Header().Get("Deprecation"))
+			assert.Equal(t, `</api/v1>; rel="successor-version"`, w.Header().Get("Link"))
+		} else {
+			assert.Empty(t, w.Header().Get("Deprecation"))
+		}
+	}
+}
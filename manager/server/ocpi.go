@@ -46,8 +46,13 @@ func NewOcpiHandler(engine store.Engine, clock clock.PassiveClock, ocpiApi ocpi.
 		panic(err)
 	}
 	swagger.Servers = nil
-	r.Use(middleware.Recoverer, secureMiddleware.Handler, cors.Default().Handler, logger)
+	r.Use(middleware.Recoverer, secureMiddleware.Handler, cors.Default().Handler, logger,
+		ocpi.CorrelationIDMiddleware, ocpi.RoutingHeadersMiddleware)
 	r.Get("/openapi.json", getOcpiSwaggerJson)
+	// the 2.1.1 and 2.2.1 interfaces are not described by the OpenAPI specification
+	authenticated := r.With(ocpi.NewTokenAuthenticationMiddleware(engine))
+	authenticated.Get("/ocpi/2.2.1", ocpiServer.GetVersion221)
+	authenticated.Mount("/ocpi/2.1.1", ocpi.Handler211(ocpiServer))
 	r.With(oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapimiddleware.Options{
 		Options: openapi3filter.Options{
 			AuthenticationFunc: ocpi.NewTokenAuthenticationFunc(engine),
@@ -80,7 +80,7 @@ func TestAPIRequestWithValidToken(t *testing.T) {
 		t.Errorf("status code: want %d, got %d", http.StatusOK, res.StatusCode)
 	}
 
-	assert.JSONEq(t, `{"status_code":1000,"status_message":"Success","timestamp":"2023-06-15T15:05:00Z","data":[{"url":"/ocpi/2.2","version":"2.2"}]}`, string(b))
+	assert.JSONEq(t, `{"status_code":1000,"status_message":"Success","timestamp":"2023-06-15T15:05:00Z","data":[{"url":"/ocpi/2.1.1","version":"2.1.1"},{"url":"/ocpi/2.2","version":"2.2"},{"url":"/ocpi/2.2.1","version":"2.2.1"}]}`, string(b))
 }
 
 func TestAPIRequestWithInvalidToken(t *testing.T) {
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"math/big"
+	"time"
+)
+
+var certificateRenewals = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_charge_station_certificate_renewals_total",
+	Help: "The number of charge station certificate renewals triggered by the CSMS by certificate type",
+}, []string{"type"})
+
+// ExpiringCertificate is a certificate, signed by the CSMS for a charge station, that
+// is about to expire or has expired
+type ExpiringCertificate struct {
+	ChargeStationId string
+	CertificateType store.CertificateType
+	CertificateId   string
+	SerialNumber    string
+	NotAfter        time.Time
+}
+
+// CertificateExpiryNotifier is informed about charge station certificates that are
+// about to expire or have expired.
+type CertificateExpiryNotifier interface {
+	CertificateExpiring(ctx context.Context, certificate *ExpiringCertificate) error
+	CertificateExpired(ctx context.Context, certificate *ExpiringCertificate) error
+}
+
+// LogCertificateExpiryNotifier records expiring certificates in the log so that they
+// can be picked up by log based monitoring.
+type LogCertificateExpiryNotifier struct{}
+
+func (LogCertificateExpiryNotifier) CertificateExpiring(_ context.Context, certificate *ExpiringCertificate) error {
+	slog.Warn("charge station certificate expiring",
+		slog.String("chargeStationId", certificate.ChargeStationId),
+		slog.String("certificateType", string(certificate.CertificateType)),
+		slog.String("serialNumber", certificate.SerialNumber),
+		slog.Time("notAfter", certificate.NotAfter))
+	return nil
+}
+
+func (LogCertificateExpiryNotifier) CertificateExpired(_ context.Context, certificate *ExpiringCertificate) error {
+	slog.Error("charge station certificate expired",
+		slog.String("chargeStationId", certificate.ChargeStationId),
+		slog.String("certificateType", string(certificate.CertificateType)),
+		slog.String("serialNumber", certificate.SerialNumber),
+		slog.Time("notAfter", certificate.NotAfter))
+	return nil
+}
+
+// renewalTriggers are the messages that ask a charge station to renew a certificate
+// of each type that the CSMS signs
+var renewalTriggers = []struct {
+	certificateType store.CertificateType
+	triggerMessage  store.TriggerMessage
+}{
+	{store.CertificateTypeChargeStation, store.TriggerMessageSignChargingStationCertificate},
+	{store.CertificateTypeEVCC, store.TriggerMessageSignV2GCertificate},
+}
+
+// CertificateExpiryService tracks the expiry of the certificates that the charge
+// stations have installed following a CertificateSigned request. The notifier is told
+// about certificates that expire within AlertBefore and the charge station is
+// triggered to renew certificates that expire within RenewBefore.
+//
+// Only the latest certificate of each type is considered: a certificate is ignored if
+// the charge station has since reported (via GetInstalledCertificateIds) an inventory
+// of certificates of the same type that does not include it.
+type CertificateExpiryService struct {
+	Clock       clock.PassiveClock
+	Store       store.Engine
+	Notifier    CertificateExpiryNotifier
+	AlertBefore time.Duration
+	RenewBefore time.Duration
+}
+
+// CheckChargeStation checks the certificates installed by the charge station. It
+// returns the number of renewals that were triggered.
+func (c *CertificateExpiryService) CheckChargeStation(ctx context.Context, certificates *store.ChargeStationInstallCertificates) (int, error) {
+	csId := certificates.ChargeStationId
+
+	inventory, err := c.Store.LookupChargeStationInstalledCertificates(ctx, csId)
+	if err != nil {
+		return 0, fmt.Errorf("lookup charge station installed certificates: %w", err)
+	}
+
+	renewals := 0
+	for _, renewal := range renewalTriggers {
+		certificateType := renewal.certificateType
+		latest, renewing := latestCertificate(certificates, inventory, certificateType)
+		if latest == nil {
+			continue
+		}
+
+		now := c.Clock.Now()
+		if now.After(latest.NotAfter.Add(-c.AlertBefore)) && c.Notifier != nil {
+			var err error
+			if now.After(latest.NotAfter) {
+				err = c.Notifier.CertificateExpired(ctx, latest)
+			} else {
+				err = c.Notifier.CertificateExpiring(ctx, latest)
+			}
+			if err != nil {
+				return renewals, err
+			}
+		}
+
+		if renewing || now.Before(latest.NotAfter.Add(-c.RenewBefore)) {
+			continue
+		}
+
+		triggered, err := c.triggerRenewal(ctx, csId, renewal.triggerMessage)
+		if err != nil {
+			return renewals, err
+		}
+		if triggered {
+			certificateRenewals.WithLabelValues(string(certificateType)).Inc()
+			trace.SpanFromContext(ctx).AddEvent("certificate renewal triggered", trace.WithAttributes(
+				attribute.String("certificateType", string(certificateType)),
+				attribute.String("serialNumber", latest.SerialNumber)))
+			renewals++
+		}
+	}
+
+	return renewals, nil
+}
+
+// triggerRenewal queues the trigger message for the charge station unless another
+// trigger message is already queued
+func (c *CertificateExpiryService) triggerRenewal(ctx context.Context, csId string, triggerMessage store.TriggerMessage) (bool, error) {
+	existing, err := c.Store.LookupChargeStationTriggerMessage(ctx, csId)
+	if err != nil {
+		return false, fmt.Errorf("lookup charge station trigger message: %w", err)
+	}
+	if existing != nil {
+		return false, nil
+	}
+
+	err = c.Store.SetChargeStationTriggerMessage(ctx, csId, &store.ChargeStationTriggerMessage{
+		TriggerMessage: triggerMessage,
+		TriggerStatus:  store.TriggerStatusPending,
+		SendAfter:      c.Clock.Now(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("set charge station trigger message: %w", err)
+	}
+	return true, nil
+}
+
+// latestCertificate returns the accepted certificate of the given type that expires
+// last and whether a certificate of that type is waiting to be installed
+func latestCertificate(certificates *store.ChargeStationInstallCertificates, inventory *store.ChargeStationInstalledCertificates,
+	certificateType store.CertificateType) (*ExpiringCertificate, bool) {
+	var latest *ExpiringCertificate
+	renewing := false
+	for _, cert := range certificates.Certificates {
+		if cert.CertificateType != certificateType {
+			continue
+		}
+		if cert.CertificateInstallationStatus == store.CertificateInstallationPending {
+			renewing = true
+			continue
+		}
+		if cert.CertificateInstallationStatus != store.CertificateInstallationAccepted {
+			continue
+		}
+
+		chain, err := ParseCertificates([]byte(cert.CertificateData))
+		if err != nil || len(chain) == 0 {
+			slog.Warn("unable to check expiry of charge station certificate", "chargeStationId", certificates.ChargeStationId,
+				"certificateId", cert.CertificateId, "error", err)
+			continue
+		}
+		leaf := chain[0]
+		if !isInstalled(inventory, certificateType, leaf) {
+			continue
+		}
+		if latest == nil || leaf.NotAfter.After(latest.NotAfter) {
+			latest = &ExpiringCertificate{
+				ChargeStationId: certificates.ChargeStationId,
+				CertificateType: certificateType,
+				CertificateId:   cert.CertificateId,
+				SerialNumber:    leaf.SerialNumber.Text(16),
+				NotAfter:        leaf.NotAfter,
+			}
+		}
+	}
+	return latest, renewing
+}
+
+// isInstalled returns false if the charge station has reported an inventory of
+// certificates of the given type that does not include the certificate
+func isInstalled(inventory *store.ChargeStationInstalledCertificates, certificateType store.CertificateType, cert *x509.Certificate) bool {
+	if inventory == nil {
+		return true
+	}
+	reported := false
+	for _, installed := range inventory.Certificates {
+		if installed.CertificateType != certificateType {
+			continue
+		}
+		reported = true
+		serialNumber, ok := new(big.Int).SetString(installed.SerialNumber, 16)
+		if ok && serialNumber.Cmp(cert.SerialNumber) == 0 {
+			return true
+		}
+	}
+	return !reported
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	clockTest "k8s.io/utils/clock/testing"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type recordingCertificateExpiryNotifier struct {
+	expiring []*services.ExpiringCertificate
+	expired  []*services.ExpiringCertificate
+}
+
+func (r *recordingCertificateExpiryNotifier) CertificateExpiring(_ context.Context, certificate *services.ExpiringCertificate) error {
+	r.expiring = append(r.expiring, certificate)
+	return nil
+}
+
+func (r *recordingCertificateExpiryNotifier) CertificateExpired(_ context.Context, certificate *services.ExpiringCertificate) error {
+	r.expired = append(r.expired, certificate)
+	return nil
+}
+
+func createExpiringCertificate(t *testing.T, serialNumber int64, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serialNumber),
+		Subject:      pkix.Name{CommonName: "cs001"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}))
+}
+
+func setupCertificateExpiryService(t *testing.T, now time.Time, certificates ...*store.ChargeStationInstallCertificate) (*services.CertificateExpiryService, store.Engine, *recordingCertificateExpiryNotifier) {
+	clock := clockTest.NewFakePassiveClock(now)
+	engine := inmemory.NewStore(clock)
+	err := engine.UpdateChargeStationInstallCertificates(context.Background(), "cs001", &store.ChargeStationInstallCertificates{
+		Certificates: certificates,
+	})
+	require.NoError(t, err)
+
+	notifier := new(recordingCertificateExpiryNotifier)
+	return &services.CertificateExpiryService{
+		Clock:       clock,
+		Store:       engine,
+		Notifier:    notifier,
+		RenewBefore: 30 * 24 * time.Hour,
+		AlertBefore: 14 * 24 * time.Hour,
+	}, engine, notifier
+}
+
+func checkChargeStation(t *testing.T, expiry *services.CertificateExpiryService, engine store.Engine) int {
+	certificates, err := engine.LookupChargeStationInstallCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	renewals, err := expiry.CheckChargeStation(context.Background(), certificates)
+	require.NoError(t, err)
+	return renewals
+}
+
+func TestCertificateExpiryServiceDoesNothingForValidCertificates(t *testing.T) {
+	now := time.Now()
+	expiry, engine, notifier := setupCertificateExpiryService(t, now, &store.ChargeStationInstallCertificate{
+		CertificateType:               store.CertificateTypeChargeStation,
+		CertificateId:                 "cert001",
+		CertificateData:               createExpiringCertificate(t, 1, now.Add(90*24*time.Hour)),
+		CertificateInstallationStatus: store.CertificateInstallationAccepted,
+	})
+
+	assert.Equal(t, 0, checkChargeStation(t, expiry, engine))
+	assert.Empty(t, notifier.expiring)
+
+	trigger, err := engine.LookupChargeStationTriggerMessage(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, trigger)
+}
+
+func TestCertificateExpiryServiceTriggersRenewal(t *testing.T) {
+	now := time.Now()
+	expiry, engine, notifier := setupCertificateExpiryService(t, now, &store.ChargeStationInstallCertificate{
+		CertificateType:               store.CertificateTypeChargeStation,
+		CertificateId:                 "cert001",
+		CertificateData:               createExpiringCertificate(t, 1, now.Add(20*24*time.Hour)),
+		CertificateInstallationStatus: store.CertificateInstallationAccepted,
+	})
+
+	assert.Equal(t, 1, checkChargeStation(t, expiry, engine))
+	assert.Empty(t, notifier.expiring)
+
+	trigger, err := engine.LookupChargeStationTriggerMessage(context.Background(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, trigger)
+	assert.Equal(t, store.TriggerMessageSignChargingStationCertificate, trigger.TriggerMessage)
+	assert.Equal(t, store.TriggerStatusPending, trigger.TriggerStatus)
+
+	// the renewal is not triggered again while the trigger message is queued
+	assert.Equal(t, 0, checkChargeStation(t, expiry, engine))
+}
+
+func TestCertificateExpiryServiceAlertsForExpiringCertificates(t *testing.T) {
+	now := time.Now()
+	expiry, engine, notifier := setupCertificateExpiryService(t, now,
+		&store.ChargeStationInstallCertificate{
+			CertificateType:               store.CertificateTypeChargeStation,
+			CertificateId:                 "cert001",
+			CertificateData:               createExpiringCertificate(t, 1, now.Add(7*24*time.Hour)),
+			CertificateInstallationStatus: store.CertificateInstallationAccepted,
+		},
+		&store.ChargeStationInstallCertificate{
+			CertificateType:               store.CertificateTypeEVCC,
+			CertificateId:                 "cert002",
+			CertificateData:               createExpiringCertificate(t, 2, now.Add(-time.Hour)),
+			CertificateInstallationStatus: store.CertificateInstallationAccepted,
+		})
+
+	// only one trigger message can be queued for the charge station at a time
+	assert.Equal(t, 1, checkChargeStation(t, expiry, engine))
+
+	require.Len(t, notifier.expiring, 1)
+	assert.Equal(t, "cert001", notifier.expiring[0].CertificateId)
+	assert.Equal(t, "1", notifier.expiring[0].SerialNumber)
+	require.Len(t, notifier.expired, 1)
+	assert.Equal(t, "cert002", notifier.expired[0].CertificateId)
+	assert.Equal(t, store.CertificateTypeEVCC, notifier.expired[0].CertificateType)
+}
+
+func TestCertificateExpiryServiceOnlyConsidersTheLatestCertificate(t *testing.T) {
+	now := time.Now()
+	expiry, engine, notifier := setupCertificateExpiryService(t, now,
+		&store.ChargeStationInstallCertificate{
+			CertificateType:               store.CertificateTypeChargeStation,
+			CertificateId:                 "cert001",
+			CertificateData:               createExpiringCertificate(t, 1, now.Add(7*24*time.Hour)),
+			CertificateInstallationStatus: store.CertificateInstallationAccepted,
+		},
+		&store.ChargeStationInstallCertificate{
+			CertificateType:               store.CertificateTypeChargeStation,
+			CertificateId:                 "cert002",
+			CertificateData:               createExpiringCertificate(t, 2, now.Add(365*24*time.Hour)),
+			CertificateInstallationStatus: store.CertificateInstallationAccepted,
+		})
+
+	assert.Equal(t, 0, checkChargeStation(t, expiry, engine))
+	assert.Empty(t, notifier.expiring)
+}
+
+func TestCertificateExpiryServiceDoesNotTriggerRenewalWhileRenewing(t *testing.T) {
+	now := time.Now()
+	expiry, engine, _ := setupCertificateExpiryService(t, now,
+		&store.ChargeStationInstallCertificate{
+			CertificateType:               store.CertificateTypeChargeStation,
+			CertificateId:                 "cert001",
+			CertificateData:               createExpiringCertificate(t, 1, now.Add(20*24*time.Hour)),
+			CertificateInstallationStatus: store.CertificateInstallationAccepted,
+		},
+		&store.ChargeStationInstallCertificate{
+			CertificateType:               store.CertificateTypeChargeStation,
+			CertificateId:                 "cert002",
+			CertificateData:               createExpiringCertificate(t, 2, now.Add(365*24*time.Hour)),
+			CertificateInstallationStatus: store.CertificateInstallationPending,
+		})
+
+	assert.Equal(t, 0, checkChargeStation(t, expiry, engine))
+}
+
+func TestCertificateExpiryServiceIgnoresCertificatesThatAreNoLongerInstalled(t *testing.T) {
+	now := time.Now()
+	expiry, engine, notifier := setupCertificateExpiryService(t, now, &store.ChargeStationInstallCertificate{
+		CertificateType:               store.CertificateTypeEVCC,
+		CertificateId:                 "cert001",
+		CertificateData:               createExpiringCertificate(t, 1, now.Add(7*24*time.Hour)),
+		CertificateInstallationStatus: store.CertificateInstallationAccepted,
+	})
+	err := engine.SetChargeStationInstalledCertificates(context.Background(), "cs001", &store.ChargeStationInstalledCertificates{
+		ChargeStationId: "cs001",
+		Certificates: []*store.ChargeStationInstalledCertificate{
+			{
+				CertificateType: store.CertificateTypeEVCC,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "issuer-name-hash",
+				IssuerKeyHash:   "issuer-key-hash",
+				SerialNumber:    "0a",
+				Status:          store.InstalledCertificateStatusInstalled,
+			},
+		},
+		RefreshStatus: store.InstalledCertificatesRefreshAccepted,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, checkChargeStation(t, expiry, engine))
+	assert.Empty(t, notifier.expiring)
+}
@@ -9,14 +9,36 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"golang.org/x/crypto/ocsp"
 	"golang.org/x/exp/slog"
 	"io"
+	"k8s.io/utils/clock"
 	"math/big"
 	"net/http"
+	"time"
+)
+
+var (
+	ocspCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_ocsp_cache_lookups_total",
+		Help: "The number of OCSP response cache lookups by result (hit or miss)",
+	}, []string{"result"})
+	ocspResponderFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "manager_ocsp_responder_failures_total",
+		Help: "The number of calls to OCSP responders that failed or returned a response that could not be parsed",
+	})
+	ocspFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_ocsp_fallbacks_total",
+		Help: "The number of certificates whose OCSP responders were unavailable, by the fallback policy applied",
+	}, []string{"policy"})
 )
 
 // OCSPError is an error returned by the OCSP server in response to a check
@@ -50,15 +72,63 @@ func (v ValidationError) Error() string {
 	}
 }
 
+// OcspFallbackPolicy decides how a certificate is validated when none of its OCSP
+// responders can be reached
+type OcspFallbackPolicy string
+
+const (
+	// OcspFallbackReject fails the validation
+	OcspFallbackReject OcspFallbackPolicy = "reject"
+	// OcspFallbackAcceptWithWarning accepts the certificate, logging a warning, but no
+	// OCSP response is available for the charge station
+	OcspFallbackAcceptWithWarning OcspFallbackPolicy = "accept_with_warning"
+	// OcspFallbackUseCachedStatus uses the last cached response for the certificate,
+	// even if it is stale, and fails the validation if there is none
+	OcspFallbackUseCachedStatus OcspFallbackPolicy = "use_cached_status"
+)
+
+// errOCSPCheckWaived is returned by performOCSPCheck when the OCSP responders are
+// unavailable and the certificate is accepted by the OcspFallbackAcceptWithWarning
+// policy
+var errOCSPCheckWaived = errors.New("ocsp check waived")
+
 type CertificateValidationService interface {
 	ValidatePEMCertificateChain(ctx context.Context, pemChain []byte, eMAID string) (*string, error)
 	ValidateHashedCertificateChain(ctx context.Context, ocspRequestData []ocpp201.OCSPRequestDataType) (*string, error)
 }
 
+// OnlineCertificateValidationService validates certificates against the root
+// certificates and checks their revocation status with the OCSP responders that they
+// identify. If OcspResponseStore is set, the OCSP responses for good certificates are
+// cached until the responders' next update: a cached response is returned for the
+// charge station to pass on to the EV as if it had come from the responder.
 type OnlineCertificateValidationService struct {
 	RootCertificateProvider RootCertificateProviderService
 	MaxOCSPAttempts         int
 	HttpClient              *http.Client
+	OcspResponseStore       store.OcspResponseStore
+	// Clock is used to check whether a cached response is stale: the real clock is used
+	// if it is not set
+	Clock clock.PassiveClock
+	// FallbackPolicy applies when a certificate's OCSP responders are unavailable: the
+	// validation fails if it is not set
+	FallbackPolicy OcspFallbackPolicy
+	// RevalidateBefore is how long before their next update the cached responses are
+	// revalidated by RevalidateOCSPResponses
+	RevalidateBefore time.Duration
+}
+
+// OcspRevalidator refreshes the cached OCSP responses before they become stale so that
+// they are available if the responders become unavailable
+type OcspRevalidator interface {
+	// RevalidateOCSPResponses returns the number of responses that were refreshed
+	RevalidateOCSPResponses(ctx context.Context) (int, error)
+}
+
+// RefreshRootCertificates refreshes the root certificates if the
+// RootCertificateProvider caches them
+func (o *OnlineCertificateValidationService) RefreshRootCertificates(ctx context.Context) (int, error) {
+	return refreshRootCertificates(ctx, o.RootCertificateProvider)
 }
 
 func (o *OnlineCertificateValidationService) ValidatePEMCertificateChain(ctx context.Context, pemChain []byte, eMAID string) (*string, error) {
@@ -71,7 +141,7 @@ func (o *OnlineCertificateValidationService) ValidatePEMCertificateChain(ctx con
 		return nil, fmt.Errorf("empty certificate chain")
 	}
 
-	err = o.validateEMAID(certificateChain[0], eMAID)
+	err = validateEMAID(certificateChain[0], eMAID)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +151,7 @@ func (o *OnlineCertificateValidationService) ValidatePEMCertificateChain(ctx con
 		return nil, err
 	}
 
-	err = o.validatePEMCertificateChain(certificateChain, rootCerts)
+	err = validatePEMCertificateChain(certificateChain, rootCerts)
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +176,10 @@ func (o *OnlineCertificateValidationService) ValidateHashedCertificateChain(ctx
 		}
 		var ocspResp *string
 		ocspResp, err = o.performOCSPCheck(ctx, []string{requestData.ResponderURL}, ocspRequest, nil, o.MaxOCSPAttempts)
+		if errors.Is(err, errOCSPCheckWaived) {
+			err = nil
+			continue
+		}
 		if err != nil {
 			return ocspResp, err
 		}
@@ -117,7 +191,7 @@ func (o *OnlineCertificateValidationService) ValidateHashedCertificateChain(ctx
 	return ocspResponse, err
 }
 
-func (o *OnlineCertificateValidationService) validatePEMCertificateChain(certificateChain, rootCertificates []*x509.Certificate) error {
+func validatePEMCertificateChain(certificateChain, rootCertificates []*x509.Certificate) error {
 	if len(certificateChain) < 1 {
 		return errors.New("no certificates in chain")
 	}
@@ -151,8 +225,8 @@ func (o *OnlineCertificateValidationService) validatePEMCertificateChain(certifi
 	return nil
 }
 
-func (o *OnlineCertificateValidationService) validateEMAID(certificate *x509.Certificate, eMAID string) error {
-	if certificate.Subject.CommonName != eMAID {
+func validateEMAID(certificate *x509.Certificate, eMAID string) error {
+	if !ocpp.EmaidsEqual(certificate.Subject.CommonName, eMAID) {
 		return fmt.Errorf("leaf certificate CN: %s, not %s: %w", certificate.Subject.CommonName, eMAID, ValidationErrorWrongEmaid)
 	}
 
@@ -164,6 +238,9 @@ func (o *OnlineCertificateValidationService) validatePEMCertificateChainOCSPStat
 		return nil, fmt.Errorf("no certificates in chain: %w", ValidationErrorCertChain)
 	}
 
+	// a certificate whose responders are unavailable may be accepted without a response
+	waived := false
+
 	// validate each certificate with issuer
 	for i := 1; i < len(certificateChain); i++ {
 		if len(certificateChain[i-1].OCSPServer) > 0 {
@@ -172,10 +249,16 @@ func (o *OnlineCertificateValidationService) validatePEMCertificateChainOCSPStat
 			if err != nil {
 				return
 			}
-			ocspResponse, err = o.performOCSPCheck(ctx, certificateChain[i-1].OCSPServer, ocspRequest, certificateChain[i], maxRetries)
+			var ocspResp *string
+			ocspResp, err = o.performOCSPCheck(ctx, certificateChain[i-1].OCSPServer, ocspRequest, certificateChain[i], maxRetries)
+			if errors.Is(err, errOCSPCheckWaived) {
+				waived, err = true, nil
+				continue
+			}
 			if err != nil {
-				return
+				return ocspResp, err
 			}
+			ocspResponse = ocspResp
 		}
 	}
 
@@ -189,13 +272,18 @@ func (o *OnlineCertificateValidationService) validatePEMCertificateChainOCSPStat
 				if err != nil {
 					return
 				}
-				return o.performOCSPCheck(ctx, subjectCert.OCSPServer, ocspRequest, rootCert, maxRetries)
+				var ocspResp *string
+				ocspResp, err = o.performOCSPCheck(ctx, subjectCert.OCSPServer, ocspRequest, rootCert, maxRetries)
+				if errors.Is(err, errOCSPCheckWaived) {
+					return ocspResponse, nil
+				}
+				return ocspResp, err
 			}
 		}
 	}
 
-	if ocspResponse != nil {
-		return
+	if ocspResponse != nil || waived {
+		return ocspResponse, nil
 	}
 
 	return nil, fmt.Errorf("no OCSP response available: %w", ValidationErrorCertChain)
@@ -220,20 +308,222 @@ func (o *OnlineCertificateValidationService) performOCSPCheck(ctx context.Contex
 		return nil, nil
 	}
 
+	cacheKey := ocspResponseKey(ocspRequest)
+	if o.OcspResponseStore != nil && cacheKey != "" {
+		if ocspResponse := o.cachedOCSPCheck(ctx, cacheKey, issuerCert); ocspResponse != nil {
+			ocspCacheLookups.WithLabelValues("hit").Inc()
+			return ocspResponse, nil
+		}
+		ocspCacheLookups.WithLabelValues("miss").Inc()
+	}
+
+	// the responders are only unavailable if none of them gave an answer that could be
+	// parsed
+	answered := false
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		responderUrl := ocspResponderUrls[attempt%ocspResponderUrlCount]
-		ocspResponse, err := o.attemptOCSPCheck(ctx, responderUrl, ocspRequest, issuerCert)
+		ocspResponse, parsedResponse, err := o.attemptOCSPCheck(ctx, responderUrl, ocspRequest, issuerCert)
 		if err == nil {
+			if cacheKey != "" {
+				o.cacheOCSPResponse(ctx, cacheKey, ocspResponse, parsedResponse, ocspRequest, ocspResponderUrls, issuerCert)
+			}
 			return ocspResponse, nil
 		}
 		var ocspError *OCSPError
 		if errors.As(err, &ocspError) {
 			return ocspResponse, fmt.Errorf("ocsp check status: %d: %w", ocspError, ValidationErrorCertRevoked)
 		}
+		answered = answered || parsedResponse != nil
+		ocspResponderFailures.Inc()
 		slog.Warn("ocsp check", slog.Int("attempt", attempt), slog.Int("maxAttempts", maxAttempts), "error", err)
 	}
 
-	return nil, fmt.Errorf("failed to perform ocsp check after %d attempts", maxAttempts)
+	err := fmt.Errorf("failed to perform ocsp check after %d attempts", maxAttempts)
+	if answered {
+		return nil, err
+	}
+	return o.applyFallbackPolicy(ctx, cacheKey, issuerCert, err)
+}
+
+// applyFallbackPolicy validates a certificate whose OCSP responders are unavailable:
+// err is returned when the certificate is rejected
+func (o *OnlineCertificateValidationService) applyFallbackPolicy(ctx context.Context, cacheKey string, issuerCert *x509.Certificate, err error) (*string, error) {
+	switch o.FallbackPolicy {
+	case OcspFallbackAcceptWithWarning:
+		ocspFallbacks.WithLabelValues(string(OcspFallbackAcceptWithWarning)).Inc()
+		slog.Warn("accepting certificate without ocsp check", "key", cacheKey, "error", err)
+		return nil, errOCSPCheckWaived
+	case OcspFallbackUseCachedStatus:
+		ocspFallbacks.WithLabelValues(string(OcspFallbackUseCachedStatus)).Inc()
+		if o.OcspResponseStore == nil || cacheKey == "" {
+			return nil, err
+		}
+		cached, lookupErr := o.OcspResponseStore.LookupOcspResponse(ctx, cacheKey)
+		if lookupErr != nil || cached == nil {
+			return nil, err
+		}
+		respBytes, decodeErr := base64.StdEncoding.DecodeString(cached.Response)
+		if decodeErr != nil {
+			return nil, err
+		}
+		ocspResponse, parsedResponse, checkErr := checkOCSPResponse(respBytes, issuerCert)
+		if parsedResponse == nil {
+			return nil, err
+		}
+		if checkErr != nil {
+			return nil, fmt.Errorf("cached ocsp check status: %d: %w", parsedResponse.Status, ValidationErrorCertRevoked)
+		}
+		slog.Warn("using cached ocsp response", "key", cacheKey, "nextUpdate", cached.NextUpdate, "error", err)
+		return ocspResponse, nil
+	default:
+		ocspFallbacks.WithLabelValues(string(OcspFallbackReject)).Inc()
+		return nil, err
+	}
+}
+
+// ocspResponseKey returns the key of the cached response for the certificate in the
+// OCSP request: serial numbers are only unique to an issuer so the key includes the
+// hash of the issuer's public key. It returns an empty key if the request cannot be
+// parsed.
+func ocspResponseKey(ocspRequest []byte) string {
+	req, err := ocsp.ParseRequest(ocspRequest)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x:%x", req.IssuerKeyHash, req.SerialNumber)
+}
+
+func (o *OnlineCertificateValidationService) now() time.Time {
+	if o.Clock == nil {
+		return time.Now()
+	}
+	return o.Clock.Now()
+}
+
+// cachedOCSPCheck returns the cached OCSP response for the certificate if the
+// certificate was good when the response was produced and the response is not stale:
+// otherwise it returns nil
+func (o *OnlineCertificateValidationService) cachedOCSPCheck(ctx context.Context, key string, issuerCert *x509.Certificate) *string {
+	cached, err := o.OcspResponseStore.LookupOcspResponse(ctx, key)
+	if err != nil {
+		slog.Warn("lookup cached ocsp response", "key", key, "error", err)
+		return nil
+	}
+	if cached == nil || !o.now().Before(cached.NextUpdate) {
+		return nil
+	}
+	respBytes, err := base64.StdEncoding.DecodeString(cached.Response)
+	if err != nil {
+		return nil
+	}
+	ocspResponse, _, err := checkOCSPResponse(respBytes, issuerCert)
+	if err != nil {
+		return nil
+	}
+	return ocspResponse
+}
+
+// cacheOCSPResponse caches a response that reports the certificate is good until the
+// responder's next update, along with the request so that it can be revalidated: a
+// response without a next update is not cached as newer information is always
+// available
+func (o *OnlineCertificateValidationService) cacheOCSPResponse(ctx context.Context, key string, ocspResponse *string, parsedResponse *ocsp.Response,
+	ocspRequest []byte, ocspResponderUrls []string, issuerCert *x509.Certificate) {
+	if o.OcspResponseStore == nil || parsedResponse.NextUpdate.IsZero() || !o.now().Before(parsedResponse.NextUpdate) {
+		return
+	}
+	response := &store.OcspResponse{
+		Key:           key,
+		Response:      *ocspResponse,
+		NextUpdate:    parsedResponse.NextUpdate,
+		Request:       base64.StdEncoding.EncodeToString(ocspRequest),
+		ResponderUrls: ocspResponderUrls,
+	}
+	if issuerCert != nil {
+		response.Issuer = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: issuerCert.Raw}))
+	}
+	err := o.OcspResponseStore.SetOcspResponse(ctx, response)
+	if err != nil {
+		slog.Warn("cache ocsp response", "key", key, "error", err)
+	}
+}
+
+// RevalidateOCSPResponses asks the responders for a new response for each cached
+// response that reports a good certificate and becomes stale within RevalidateBefore.
+// A response that reports the certificate is no longer good replaces the cached
+// response, so it is not used by the OcspFallbackUseCachedStatus policy.
+func (o *OnlineCertificateValidationService) RevalidateOCSPResponses(ctx context.Context) (int, error) {
+	if o.OcspResponseStore == nil {
+		return 0, nil
+	}
+
+	revalidated := 0
+	previousKey := ""
+	for {
+		responses, err := o.OcspResponseStore.ListOcspResponses(ctx, 50, previousKey)
+		if err != nil {
+			return revalidated, fmt.Errorf("list ocsp responses: %w", err)
+		}
+		if len(responses) == 0 {
+			return revalidated, nil
+		}
+		for _, cached := range responses {
+			if o.revalidateOCSPResponse(ctx, cached) {
+				revalidated++
+			}
+		}
+		previousKey = responses[len(responses)-1].Key
+	}
+}
+
+func (o *OnlineCertificateValidationService) revalidateOCSPResponse(ctx context.Context, cached *store.OcspResponse) bool {
+	if cached.Request == "" || len(cached.ResponderUrls) == 0 || o.now().Add(o.RevalidateBefore).Before(cached.NextUpdate) {
+		return false
+	}
+
+	var issuerCert *x509.Certificate
+	if cached.Issuer != "" {
+		issuerCerts, err := ParseCertificates([]byte(cached.Issuer))
+		if err != nil || len(issuerCerts) != 1 {
+			slog.Warn("parse cached ocsp response issuer", "key", cached.Key, "error", err)
+			return false
+		}
+		issuerCert = issuerCerts[0]
+	}
+
+	respBytes, err := base64.StdEncoding.DecodeString(cached.Response)
+	if err != nil {
+		return false
+	}
+	if _, _, err = checkOCSPResponse(respBytes, issuerCert); err != nil {
+		// the certificate is no longer good, so its status will not change
+		return false
+	}
+
+	ocspRequest, err := base64.StdEncoding.DecodeString(cached.Request)
+	if err != nil {
+		return false
+	}
+
+	for attempt := 1; attempt <= o.MaxOCSPAttempts; attempt++ {
+		responderUrl := cached.ResponderUrls[attempt%len(cached.ResponderUrls)]
+		ocspResponse, parsedResponse, err := o.attemptOCSPCheck(ctx, responderUrl, ocspRequest, issuerCert)
+		if parsedResponse == nil {
+			ocspResponderFailures.Inc()
+			slog.Warn("revalidate ocsp response", "key", cached.Key, slog.Int("attempt", attempt), "error", err)
+			continue
+		}
+
+		revalidated := *cached
+		revalidated.Response = *ocspResponse
+		revalidated.NextUpdate = parsedResponse.NextUpdate
+		if err = o.OcspResponseStore.SetOcspResponse(ctx, &revalidated); err != nil {
+			slog.Warn("cache ocsp response", "key", cached.Key, "error", err)
+			return false
+		}
+		return true
+	}
+	return false
 }
 
 func (o *OnlineCertificateValidationService) createOCSPRequestFromCertificate(subjectCert, issuerCert *x509.Certificate) ([]byte, error) {
@@ -267,11 +557,13 @@ func (o *OnlineCertificateValidationService) createOCSPRequestFromHashData(issue
 	return req.Marshal()
 }
 
-func (o *OnlineCertificateValidationService) attemptOCSPCheck(ctx context.Context, ocspResponderUrl string, ocspRequest []byte, issuerCert *x509.Certificate) (*string, error) {
+// attemptOCSPCheck calls the OCSP responder: the parsed response is returned if the
+// responder's response can be parsed, whatever the certificate's status
+func (o *OnlineCertificateValidationService) attemptOCSPCheck(ctx context.Context, ocspResponderUrl string, ocspRequest []byte, issuerCert *x509.Certificate) (*string, *ocsp.Response, error) {
 	//#nosec G107 - need to use OCSP URL specified in the certificate
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ocspResponderUrl, bytes.NewReader(ocspRequest))
 	if err != nil {
-		return nil, fmt.Errorf("new request: %w", err)
+		return nil, nil, fmt.Errorf("new request: %w", err)
 	}
 
 	req.Header.Add("Content-Type", "application/ocsp-request")
@@ -279,28 +571,32 @@ func (o *OnlineCertificateValidationService) attemptOCSPCheck(ctx context.Contex
 
 	resp, err := o.HttpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("post %s: %w", ocspResponderUrl, err)
+		return nil, nil, fmt.Errorf("post %s: %w", ocspResponderUrl, err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("post %s status %s", ocspResponderUrl, resp.Status)
+		return nil, nil, fmt.Errorf("post %s status %s", ocspResponderUrl, resp.Status)
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 	respBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading body: %w", err)
+		return nil, nil, fmt.Errorf("reading body: %w", err)
 	}
+	return checkOCSPResponse(respBytes, issuerCert)
+}
+
+func checkOCSPResponse(respBytes []byte, issuerCert *x509.Certificate) (*string, *ocsp.Response, error) {
 	base64Response := base64.StdEncoding.EncodeToString(respBytes)
 	ocspResp, err := ocsp.ParseResponse(respBytes, issuerCert)
 	if err != nil {
-		return &base64Response, fmt.Errorf("parsing ocsp response: %w", err)
+		return &base64Response, nil, fmt.Errorf("parsing ocsp response: %w", err)
 	}
 	if ocspResp.Status != ocsp.Good {
-		return &base64Response, OCSPError(ocspResp.Status)
+		return &base64Response, ocspResp, OCSPError(ocspResp.Status)
 	}
 
-	return &base64Response, nil
+	return &base64Response, ocspResp, nil
 }
 
 func ParseCertificates(pemData []byte) ([]*x509.Certificate, error) {
@@ -19,8 +19,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
 	"golang.org/x/crypto/ocsp"
 	"io"
+	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/strings/slices"
 	"math"
 	"math/big"
@@ -35,9 +38,20 @@ type OCSPResponder struct {
 	Certificates         []*x509.Certificate
 	Keys                 []*ecdsa.PrivateKey
 	RevokedSerialNumbers []string
+	// NextUpdate is how long after the response is produced that the status of a good
+	// certificate will next be updated
+	NextUpdate time.Duration
+	Requests   int
+	// Unavailable makes the responder respond with a 503 Service Unavailable
+	Unavailable bool
 }
 
 func (o *OCSPResponder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.Requests++
+	if o.Unavailable {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
 	reqBytes, err := io.ReadAll(r.Body)
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -99,7 +113,7 @@ func (o *OCSPResponder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			Status:       ocsp.Good,
 			SerialNumber: ocspReq.SerialNumber,
 			ThisUpdate:   now,
-			NextUpdate:   now,
+			NextUpdate:   now.Add(o.NextUpdate),
 		}
 	}
 
@@ -518,6 +532,303 @@ func TestValidatingHashedCertificateChainWithNoOCSPResponderUrlOnLeaf(t *testing
 	require.Nil(t, ocspResp)
 }
 
+func newCachingCertificateValidationService(rootCACerts []*x509.Certificate, clock *fakeclock.FakePassiveClock) services.OnlineCertificateValidationService {
+	return services.OnlineCertificateValidationService{
+		RootCertificateProvider: services.X509RootCertificateProviderService{Certificates: rootCACerts},
+		MaxOCSPAttempts:         3,
+		HttpClient:              http.DefaultClient,
+		OcspResponseStore:       inmemory.NewStore(clock),
+		Clock:                   clock,
+	}
+}
+
+func pemCertificateChain(certs ...*x509.Certificate) []byte {
+	var pemChain []byte
+	for _, cert := range certs {
+		pemChain = append(pemChain, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: cert.Raw,
+		})...)
+	}
+	return pemChain
+}
+
+func TestValidatingPEMCertificateChainUsesCachedOCSPResponses(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:          t,
+		NextUpdate: time.Hour,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	validationService := newCachingCertificateValidationService(rootCACerts, clock)
+	pemChain := pemCertificateChain(leafCert, intCACert)
+
+	hits := testutil.MetricValue(t, "manager_ocsp_cache_lookups_total", map[string]string{"result": "hit"})
+
+	ocspResp, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	require.NoError(t, err)
+	validateOCSPResponse(t, ocspResp)
+	assert.Equal(t, 2, ocspResponder.Requests)
+
+	cachedOcspResp, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	require.NoError(t, err)
+	assert.Equal(t, *ocspResp, *cachedOcspResp)
+	assert.Equal(t, 2, ocspResponder.Requests)
+	assert.Equal(t, hits+2, testutil.MetricValue(t, "manager_ocsp_cache_lookups_total", map[string]string{"result": "hit"}))
+
+	// the cached responses are stale after the responder's next update
+	clock.SetTime(clock.Now().Add(time.Hour))
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	require.NoError(t, err)
+	assert.Equal(t, 4, ocspResponder.Requests)
+}
+
+func TestValidatingPEMCertificateChainDoesNotCacheOCSPResponsesWithoutNextUpdate(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T: t,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	validationService := newCachingCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+	pemChain := pemCertificateChain(leafCert, intCACert)
+
+	for i := 0; i < 2; i++ {
+		_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 4, ocspResponder.Requests)
+}
+
+func TestValidatingPEMCertificateChainDoesNotCacheRevokedCertificates(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:          t,
+		NextUpdate: time.Hour,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	ocspResponder.RevokedSerialNumbers = []string{intCACert.SerialNumber.Text(16)}
+	validationService := newCachingCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+	pemChain := pemCertificateChain(leafCert, intCACert)
+
+	for i := 0; i < 2; i++ {
+		_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+		require.Error(t, err)
+	}
+	// the leaf certificate's response is cached, the intermediate's never is
+	assert.Equal(t, 7, ocspResponder.Requests)
+}
+
+func TestValidatingHashedCertificateChainUsesCachedOCSPResponses(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:          t,
+		NextUpdate: time.Hour,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	validationService := newCachingCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+
+	intCAPublicKeyBytes, err := getPublicKeyBytes(intCACert.RawSubjectPublicKeyInfo)
+	require.NoError(t, err)
+
+	requestData := []ocpp201.OCSPRequestDataType{
+		{
+			HashAlgorithm:  "SHA256",
+			IssuerNameHash: hashBytes(leafCert.RawIssuer),
+			IssuerKeyHash:  hashBytes(intCAPublicKeyBytes),
+			ResponderURL:   leafCert.OCSPServer[0],
+			SerialNumber:   leafCert.SerialNumber.Text(16),
+		},
+	}
+	for i := 0; i < 2; i++ {
+		ocspResp, err := validationService.ValidateHashedCertificateChain(context.TODO(), requestData)
+		require.NoError(t, err)
+		validateOCSPResponse(t, ocspResp)
+	}
+	assert.Equal(t, 1, ocspResponder.Requests)
+}
+
+func TestValidatingPEMCertificateChainWithUnavailableOCSPResponderIsRejectedByDefault(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:           t,
+		Unavailable: true,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	validationService := newCachingCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	assert.ErrorContains(t, err, "failed to perform ocsp check after 3 attempts")
+}
+
+func TestValidatingPEMCertificateChainWithUnavailableOCSPResponderIsAcceptedWithWarning(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:           t,
+		Unavailable: true,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	validationService := newCachingCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+	validationService.FallbackPolicy = services.OcspFallbackAcceptWithWarning
+
+	accepted := testutil.MetricValue(t, "manager_ocsp_fallbacks_total", map[string]string{"policy": "accept_with_warning"})
+
+	ocspResp, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	require.NoError(t, err)
+	assert.Nil(t, ocspResp)
+	assert.Equal(t, accepted+2, testutil.MetricValue(t, "manager_ocsp_fallbacks_total", map[string]string{"policy": "accept_with_warning"}))
+}
+
+func TestValidatingHashedCertificateChainWithUnavailableOCSPResponderIsAcceptedWithWarning(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:           t,
+		Unavailable: true,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	validationService := newCachingCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+	validationService.FallbackPolicy = services.OcspFallbackAcceptWithWarning
+
+	intCAPublicKeyBytes, err := getPublicKeyBytes(intCACert.RawSubjectPublicKeyInfo)
+	require.NoError(t, err)
+
+	ocspResp, err := validationService.ValidateHashedCertificateChain(context.TODO(), []ocpp201.OCSPRequestDataType{
+		{
+			HashAlgorithm:  "SHA256",
+			IssuerNameHash: hashBytes(leafCert.RawIssuer),
+			IssuerKeyHash:  hashBytes(intCAPublicKeyBytes),
+			ResponderURL:   leafCert.OCSPServer[0],
+			SerialNumber:   leafCert.SerialNumber.Text(16),
+		},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, ocspResp)
+}
+
+func TestValidatingPEMCertificateChainWithUnavailableOCSPResponderUsesCachedStatus(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:          t,
+		NextUpdate: time.Hour,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	validationService := newCachingCertificateValidationService(rootCACerts, clock)
+	validationService.FallbackPolicy = services.OcspFallbackUseCachedStatus
+	pemChain := pemCertificateChain(leafCert, intCACert)
+
+	ocspResp, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	require.NoError(t, err)
+
+	// the cached responses are stale but are still used while the responder is unavailable
+	ocspResponder.Unavailable = true
+	clock.SetTime(clock.Now().Add(2 * time.Hour))
+	cachedOcspResp, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	require.NoError(t, err)
+	assert.Equal(t, *ocspResp, *cachedOcspResp)
+}
+
+func TestValidatingPEMCertificateChainWithUnavailableOCSPResponderAndNoCachedStatusIsRejected(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:           t,
+		Unavailable: true,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	validationService := newCachingCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+	validationService.FallbackPolicy = services.OcspFallbackUseCachedStatus
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	assert.ErrorContains(t, err, "failed to perform ocsp check after 3 attempts")
+}
+
+func TestRevalidateOCSPResponses(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:          t,
+		NextUpdate: time.Hour,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	validationService := newCachingCertificateValidationService(rootCACerts, clock)
+	validationService.RevalidateBefore = 15 * time.Minute
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	require.NoError(t, err)
+	require.Equal(t, 2, ocspResponder.Requests)
+
+	// the cached responses are not due to be revalidated
+	revalidated, err := validationService.RevalidateOCSPResponses(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 0, revalidated)
+
+	clock.SetTime(clock.Now().Add(50 * time.Minute))
+	revalidated, err = validationService.RevalidateOCSPResponses(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 2, revalidated)
+	assert.Equal(t, 4, ocspResponder.Requests)
+}
+
+func TestRevalidateOCSPResponsesReplacesResponseForRevokedCertificate(t *testing.T) {
+	ocspResponder := &OCSPResponder{
+		T:          t,
+		NextUpdate: time.Hour,
+	}
+
+	server := httptest.NewServer(ocspResponder)
+	defer server.Close()
+
+	rootCACerts, intCACert, leafCert := setupOCSPResponder(t, server.URL, ocspResponder)
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	validationService := newCachingCertificateValidationService(rootCACerts, clock)
+	validationService.FallbackPolicy = services.OcspFallbackUseCachedStatus
+	pemChain := pemCertificateChain(leafCert, intCACert)
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	require.NoError(t, err)
+
+	ocspResponder.RevokedSerialNumbers = []string{intCACert.SerialNumber.Text(16)}
+	clock.SetTime(clock.Now().Add(time.Hour))
+	revalidated, err := validationService.RevalidateOCSPResponses(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 2, revalidated)
+
+	// the cached status of the intermediate certificate is now revoked
+	ocspResponder.Unavailable = true
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pemChain, "MYEMAID")
+	assert.ErrorIs(t, err, services.ValidationErrorCertRevoked)
+}
+
 func getPublicKeyBytes(rawSubjectPublicKeyInfo []byte) ([]byte, error) {
 	var publicKeyInfo struct {
 		Algorithm pkix.AlgorithmIdentifier
@@ -13,6 +13,7 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.mozilla.org/pkcs7"
 	"go.opentelemetry.io/otel/codes"
@@ -43,20 +44,41 @@ const (
 	ISO15118V20 ISOVersion = "ISO15118-20"
 )
 
-// The OpcpChargeStationCertificateProvider issues certificates using the CPO CA
+// ISOVersionOfSchema returns the ISO 15118 version identified by the schema version
+// that a charge station sends in a Get15118EVCertificate request: the ISO 15118-20
+// schema namespaces are of the form urn:iso:std:iso:15118:-20:CommonMessages. Any
+// other schema is assumed to be ISO 15118-2.
+func ISOVersionOfSchema(schemaVersion string) ISOVersion {
+	if strings.Contains(schemaVersion, ":15118:-20:") {
+		return ISO15118V20
+	}
+	return ISO15118V2
+}
+
+// The OpcpChargeStationCertificateProvider issues certificates using the CPO CA. The
+// certificates are issued for the ISO 15118 version that the charge station last used
+// to request a contract certificate, if RuntimeDetailsStore is set and the version is
+// known, otherwise for ISOVersion.
 type OpcpChargeStationCertificateProvider struct {
-	BaseURL          string
-	HttpTokenService HttpTokenService
-	ISOVersion       ISOVersion
-	HttpClient       *http.Client
+	BaseURL             string
+	HttpTokenService    HttpTokenService
+	ISOVersion          ISOVersion
+	HttpClient          *http.Client
+	RuntimeDetailsStore store.ChargeStationRuntimeDetailsStore
 }
 
+// HttpError is the status of a failed request to a service that the manager depends
+// on: it is an errs.ErrDependencyUnavailable
 type HttpError int
 
 func (h HttpError) Error() string {
 	return fmt.Sprintf("http status: %d", h)
 }
 
+func (h HttpError) Is(target error) bool {
+	return target == errs.ErrDependencyUnavailable
+}
+
 func (h OpcpChargeStationCertificateProvider) ProvideCertificate(ctx context.Context, typ CertificateType, pemEncodedCSR string, csId string) (string, error) {
 	if typ == CertificateTypeV2G {
 		csr, err := convertCSR(pemEncodedCSR)
@@ -64,12 +86,17 @@ func (h OpcpChargeStationCertificateProvider) ProvideCertificate(ctx context.Con
 			return "", err
 		}
 
-		cert, err := h.requestCertificateWithRetry(ctx, csr)
+		isoVersion, err := h.isoVersion(ctx, csId)
+		if err != nil {
+			return "", err
+		}
+
+		cert, err := h.requestCertificateWithRetry(ctx, csr, isoVersion)
 		if err != nil {
 			return "", fmt.Errorf("requesting certificate: %w", err)
 		}
 
-		chain, err := h.requestChainWithRetry(ctx)
+		chain, err := h.requestChainWithRetry(ctx, isoVersion)
 		if err != nil {
 			return "", fmt.Errorf("requesting ca certificates: %w", err)
 		}
@@ -80,15 +107,31 @@ func (h OpcpChargeStationCertificateProvider) ProvideCertificate(ctx context.Con
 	}
 }
 
-func (h OpcpChargeStationCertificateProvider) requestCertificateWithRetry(ctx context.Context, csr []byte) ([]byte, error) {
+func (h OpcpChargeStationCertificateProvider) isoVersion(ctx context.Context, csId string) (ISOVersion, error) {
+	if h.RuntimeDetailsStore != nil {
+		details, err := h.RuntimeDetailsStore.LookupChargeStationRuntimeDetails(ctx, csId)
+		if err != nil {
+			return "", fmt.Errorf("lookup charge station runtime details: %w", err)
+		}
+		if details != nil && details.Iso15118Version != "" {
+			return ISOVersion(details.Iso15118Version), nil
+		}
+	}
+	if h.ISOVersion == "" {
+		return ISO15118V2, nil
+	}
+	return h.ISOVersion, nil
+}
+
+func (h OpcpChargeStationCertificateProvider) requestCertificateWithRetry(ctx context.Context, csr []byte, isoVersion ISOVersion) ([]byte, error) {
 	span := trace.SpanFromContext(ctx)
 	newCtx, span := span.TracerProvider().Tracer("manager").Start(ctx, "sign_certificate")
 	defer span.End()
 
-	certBytes, err := h.requestCertificate(newCtx, csr, false)
+	certBytes, err := h.requestCertificate(newCtx, csr, isoVersion, false)
 	if err != nil {
 		span.SetAttributes(semconv.HTTPResendCount(1))
-		certBytes, err = h.requestCertificate(newCtx, csr, true)
+		certBytes, err = h.requestCertificate(newCtx, csr, isoVersion, true)
 		if err != nil {
 			span.SetStatus(codes.Error, "retries exhausted")
 			span.RecordError(err)
@@ -97,8 +140,8 @@ func (h OpcpChargeStationCertificateProvider) requestCertificateWithRetry(ctx co
 	return certBytes, err
 }
 
-func (h OpcpChargeStationCertificateProvider) requestCertificate(ctx context.Context, csr []byte, isRetry bool) ([]byte, error) {
-	enrollUrl := fmt.Sprintf("%s/cpo/simpleenroll/%s", h.BaseURL, h.ISOVersion)
+func (h OpcpChargeStationCertificateProvider) requestCertificate(ctx context.Context, csr []byte, isoVersion ISOVersion, isRetry bool) ([]byte, error) {
+	enrollUrl := fmt.Sprintf("%s/cpo/simpleenroll/%s", h.BaseURL, isoVersion)
 	req, err := http.NewRequestWithContext(ctx, "POST", enrollUrl, bytes.NewReader(csr))
 	if err != nil {
 		return nil, err
@@ -128,20 +171,20 @@ func (h OpcpChargeStationCertificateProvider) requestCertificate(ctx context.Con
 	return nil, HttpError(resp.StatusCode)
 }
 
-func (h OpcpChargeStationCertificateProvider) requestChainWithRetry(ctx context.Context) ([]byte, error) {
+func (h OpcpChargeStationCertificateProvider) requestChainWithRetry(ctx context.Context, isoVersion ISOVersion) ([]byte, error) {
 	span := trace.SpanFromContext(ctx)
 	newCtx, span := span.TracerProvider().Tracer("manager").Start(ctx, "get_ca_certificates")
 	defer span.End()
 
-	chainBytes, err := h.requestChain(newCtx, false)
+	chainBytes, err := h.requestChain(newCtx, isoVersion, false)
 	if err != nil {
-		chainBytes, err = h.requestChain(newCtx, true)
+		chainBytes, err = h.requestChain(newCtx, isoVersion, true)
 	}
 	return chainBytes, err
 }
 
-func (h OpcpChargeStationCertificateProvider) requestChain(ctx context.Context, isRetry bool) ([]byte, error) {
-	caUrl := fmt.Sprintf("%s/cpo/cacerts/%s", h.BaseURL, h.ISOVersion)
+func (h OpcpChargeStationCertificateProvider) requestChain(ctx context.Context, isoVersion ISOVersion, isRetry bool) ([]byte, error) {
+	caUrl := fmt.Sprintf("%s/cpo/cacerts/%s", h.BaseURL, isoVersion)
 	req, err := http.NewRequestWithContext(ctx, "GET", caUrl, nil)
 	if err != nil {
 		return nil, err
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"go.mozilla.org/pkcs7"
 	"io"
@@ -29,10 +30,11 @@ import (
 )
 
 type opcpHttpHandler struct {
-	caCert  *x509.Certificate
-	caKey   *ecdsa.PrivateKey
-	intCert *x509.Certificate
-	intKey  *ecdsa.PrivateKey
+	caCert     *x509.Certificate
+	caKey      *ecdsa.PrivateKey
+	intCert    *x509.Certificate
+	intKey     *ecdsa.PrivateKey
+	isoVersion services.ISOVersion
 }
 
 func newOPCPHttpHandler(t *testing.T) opcpHttpHandler {
@@ -40,15 +42,16 @@ func newOPCPHttpHandler(t *testing.T) opcpHttpHandler {
 	intCert, intKey := createIntermediateCACertificate(t, "int", "", caCert, caKey)
 
 	return opcpHttpHandler{
-		caCert:  caCert,
-		caKey:   caKey,
-		intCert: intCert,
-		intKey:  intKey,
+		caCert:     caCert,
+		caKey:      caKey,
+		intCert:    intCert,
+		intKey:     intKey,
+		isoVersion: services.ISO15118V2,
 	}
 }
 
 func (h opcpHttpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if r.URL.String() == "/cpo/simpleenroll/ISO15118-2" {
+	if r.URL.String() == fmt.Sprintf("/cpo/simpleenroll/%s", h.isoVersion) {
 		if r.Header.Get("authorization") != "Bearer TestToken" {
 			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
@@ -96,7 +99,7 @@ func (h opcpHttpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write(enc)
 
 		return
-	} else if r.URL.String() == "/cpo/cacerts/ISO15118-2" {
+	} else if r.URL.String() == fmt.Sprintf("/cpo/cacerts/%s", h.isoVersion) {
 		if r.Header.Get("authorization") != "Bearer TestToken" {
 			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
@@ -160,6 +163,45 @@ func TestOPCPChargeStationCertificateProvider(t *testing.T) {
 	require.Nil(t, block)
 }
 
+func TestOPCPChargeStationCertificateProviderUsesChargeStationIsoVersion(t *testing.T) {
+	opcp := newOPCPHttpHandler(t)
+	opcp.isoVersion = services.ISO15118V20
+
+	server := httptest.NewServer(opcp)
+	defer server.Close()
+
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetChargeStationRuntimeDetails(context.TODO(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion:     "2.0.1",
+		Iso15118Version: "ISO15118-20",
+	})
+	require.NoError(t, err)
+
+	provider := services.OpcpChargeStationCertificateProvider{
+		BaseURL:             server.URL,
+		HttpTokenService:    services.NewFixedHttpTokenService("TestToken"),
+		ISOVersion:          services.ISO15118V2,
+		HttpClient:          http.DefaultClient,
+		RuntimeDetailsStore: engine,
+	}
+
+	csr := createCertificateSigningRequest(t)
+
+	pemChain, err := provider.ProvideCertificate(context.TODO(), services.CertificateTypeV2G, string(csr), "cs001")
+	require.NoError(t, err)
+	assert.NotEmpty(t, pemChain)
+
+	// a charge station whose version is not known uses the configured version
+	_, err = provider.ProvideCertificate(context.TODO(), services.CertificateTypeV2G, string(csr), "cs002")
+	assert.ErrorContains(t, err, "http status: 404")
+}
+
+func TestISOVersionOfSchema(t *testing.T) {
+	assert.Equal(t, services.ISO15118V2, services.ISOVersionOfSchema("urn:iso:15118:2:2013:MsgDef"))
+	assert.Equal(t, services.ISO15118V20, services.ISOVersionOfSchema("urn:iso:std:iso:15118:-20:CommonMessages"))
+	assert.Equal(t, services.ISO15118V2, services.ISOVersionOfSchema(""))
+}
+
 //	func TestOPCPChargeStationCertificateProviderWithWrongId(t *testing.T) {
 //		opcp := newOPCPHttpHandler(t)
 //
@@ -16,10 +16,16 @@ import (
 	"net/http"
 )
 
-const XsdMsgDefinition = "urn:iso:15118:2:2013:MsgDef"
+const (
+	XsdMsgDefinition    = "urn:iso:15118:2:2013:MsgDef"
+	XsdMsgDefinitionV20 = "urn:iso:std:iso:15118:-20:CommonMessages"
+)
 
 type ContractCertificateProvider interface {
-	ProvideCertificate(ctx context.Context, exiRequest string) (EvCertificate15118Response, error)
+	// ProvideCertificate returns the EXI encoded CertificateInstallationRes for the EXI
+	// encoded CertificateInstallationReq: both are encoded with the schema of the ISO
+	// 15118 version
+	ProvideCertificate(ctx context.Context, exiRequest string, isoVersion ISOVersion) (EvCertificate15118Response, error)
 }
 
 type OpcpContractCertificateProvider struct {
@@ -52,16 +58,21 @@ type SignedContractDataResponse struct {
 	XsdMsgDefNamespace string      `json:"xsdMsgDefNamespace"`
 }
 
-func (h OpcpContractCertificateProvider) ProvideCertificate(ctx context.Context, exiRequest string) (EvCertificate15118Response, error) {
+func (h OpcpContractCertificateProvider) ProvideCertificate(ctx context.Context, exiRequest string, isoVersion ISOVersion) (EvCertificate15118Response, error) {
 	client := h.HttpClient
 	if client == nil {
 		client = http.DefaultClient
 	}
 
+	xsdMsgDefNamespace := XsdMsgDefinition
+	if isoVersion == ISO15118V20 {
+		xsdMsgDefNamespace = XsdMsgDefinitionV20
+	}
+
 	requestUrl := fmt.Sprintf("%s/v1/ccp/signedContractData", h.BaseURL)
 	requestBody := SignedContractDataRequest{
 		CertificateInstallationReq: exiRequest,
-		XsdMsgDefNamespace:         XsdMsgDefinition,
+		XsdMsgDefNamespace:         xsdMsgDefNamespace,
 	}
 	marshalledBody, err := json.Marshal(requestBody)
 	if err != nil {
@@ -181,7 +192,7 @@ func withRetries(ctx context.Context, action retryFunc, attempts int) (*http.Res
 
 type DefaultContractCertificateProvider struct{}
 
-func (d DefaultContractCertificateProvider) ProvideCertificate(context.Context, string) (EvCertificate15118Response, error) {
+func (d DefaultContractCertificateProvider) ProvideCertificate(context.Context, string, ISOVersion) (EvCertificate15118Response, error) {
 	return EvCertificate15118Response{
 		Status: ocpp201.Iso15118EVCertificateStatusEnumTypeFailed,
 	}, errors.New("not implemented")
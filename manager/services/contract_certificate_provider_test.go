@@ -21,7 +21,8 @@ const dummyExiResponse = "dummy exi response"
 const maxRetryAttempts = 3
 
 type otherHubjectHttpHandler struct {
-	flakyCount int
+	flakyCount         int
+	xsdMsgDefNamespace string
 }
 
 func newOtherHubjectHttpHandler() otherHubjectHttpHandler {
@@ -60,6 +61,8 @@ func (h *otherHubjectHttpHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	h.xsdMsgDefNamespace = request.XsdMsgDefNamespace
+
 	if request.CertificateInstallationReq == "invalid" {
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
@@ -97,11 +100,31 @@ func TestContractCertificateProvider(t *testing.T) {
 		HttpTokenService: services.NewFixedHttpTokenService("TestToken"),
 	}
 
-	response, err := provider.ProvideCertificate(context.Background(), "valid")
+	response, err := provider.ProvideCertificate(context.Background(), "valid", services.ISO15118V2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted, response.Status)
+	assert.Equal(t, dummyExiResponse, response.CertificateInstallationRes)
+	assert.Equal(t, services.XsdMsgDefinition, hubject.xsdMsgDefNamespace)
+}
+
+func TestContractCertificateProviderWithIso15118V20(t *testing.T) {
+	hubject := newOtherHubjectHttpHandler()
+
+	server := httptest.NewServer(&hubject)
+	defer server.Close()
+
+	provider := services.OpcpContractCertificateProvider{
+		BaseURL:          server.URL,
+		HttpTokenService: services.NewFixedHttpTokenService("TestToken"),
+	}
+
+	response, err := provider.ProvideCertificate(context.Background(), "valid", services.ISO15118V20)
 
 	assert.NoError(t, err)
 	assert.Equal(t, ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted, response.Status)
 	assert.Equal(t, dummyExiResponse, response.CertificateInstallationRes)
+	assert.Equal(t, services.XsdMsgDefinitionV20, hubject.xsdMsgDefNamespace)
 }
 
 func TestContractCertificateProviderWithFlakyResponses(t *testing.T) {
@@ -115,7 +138,7 @@ func TestContractCertificateProviderWithFlakyResponses(t *testing.T) {
 		HttpTokenService: services.NewFixedHttpTokenService("TestToken"),
 	}
 
-	response, err := provider.ProvideCertificate(context.Background(), "flaky")
+	response, err := provider.ProvideCertificate(context.Background(), "flaky", services.ISO15118V2)
 
 	assert.NoError(t, err)
 	assert.Equal(t, ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted, response.Status)
@@ -144,7 +167,7 @@ func TestContractCertificateProviderFailureCases(t *testing.T) {
 				HttpTokenService: services.NewFixedHttpTokenService(tc.token),
 			}
 
-			response, err := provider.ProvideCertificate(context.Background(), tc.exiRequest)
+			response, err := provider.ProvideCertificate(context.Background(), tc.exiRequest, services.ISO15118V2)
 
 			assert.Error(t, err)
 			assert.Equal(t, ocpp201.Iso15118EVCertificateStatusEnumTypeFailed, response.Status)
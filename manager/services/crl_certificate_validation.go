@@ -0,0 +1,321 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"golang.org/x/exp/slog"
+	"io"
+	"k8s.io/utils/clock"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var crlDownloads = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_crl_downloads_total",
+	Help: "The number of CRL downloads by result (success or failure)",
+}, []string{"result"})
+
+// CrlCertificateValidationService validates certificates against the root
+// certificates and checks their revocation status with the certificate revocation
+// lists (CRLs) published at their CRL distribution points. Each CRL is cached until
+// its next update, or for MaxCrlAge if that is sooner.
+//
+// CRLs do not provide the OCSP responses that the charge station passes on to the EV,
+// so if Ocsp is set the certificates are also validated by it once they have passed
+// the CRL check and its OCSP responses are returned.
+//
+// Hashed certificate chains do not identify the CRL distribution points, so they are
+// checked against the cached CRLs of their issuers: a certificate whose issuer has no
+// cached CRL is rejected unless Ocsp is set.
+type CrlCertificateValidationService struct {
+	RootCertificateProvider RootCertificateProviderService
+	HttpClient              *http.Client
+	// MaxCrlAge is the longest that a CRL is cached: if it is not set, CRLs without a
+	// next update are downloaded each time they are used
+	MaxCrlAge time.Duration
+	// Clock is used to check whether a cached CRL has expired: the real clock is used if
+	// it is not set
+	Clock clock.PassiveClock
+	// Ocsp, if set, checks the OCSP status of certificates that are not revoked
+	Ocsp CertificateValidationService
+
+	mu   sync.Mutex
+	crls map[string]*cachedCrl
+}
+
+// cachedCrl is a CRL along with the certificate that signed it
+type cachedCrl struct {
+	crl     *x509.RevocationList
+	issuer  *x509.Certificate
+	expires time.Time
+}
+
+func (c *CrlCertificateValidationService) ValidatePEMCertificateChain(ctx context.Context, pemChain []byte, eMAID string) (*string, error) {
+	certificateChain, err := ParseCertificates(pemChain)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(certificateChain) == 0 {
+		return nil, fmt.Errorf("empty certificate chain")
+	}
+
+	err = validateEMAID(certificateChain[0], eMAID)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCerts, err := c.RootCertificateProvider.ProvideCertificates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validatePEMCertificateChain(certificateChain, rootCerts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, cert := range certificateChain {
+		if len(cert.CRLDistributionPoints) == 0 {
+			continue
+		}
+		issuer := issuerOf(i, certificateChain, rootCerts)
+		if issuer == nil {
+			return nil, fmt.Errorf("no issuer for certificate %s: %w", cert.Subject, ValidationErrorCertChain)
+		}
+		err = c.checkRevocation(ctx, cert, issuer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Ocsp != nil {
+		return c.Ocsp.ValidatePEMCertificateChain(ctx, pemChain, eMAID)
+	}
+
+	return nil, nil
+}
+
+func (c *CrlCertificateValidationService) ValidateHashedCertificateChain(ctx context.Context, ocspRequestData []ocpp201.OCSPRequestDataType) (*string, error) {
+	for _, requestData := range ocspRequestData {
+		serial, ok := new(big.Int).SetString(requestData.SerialNumber, 16)
+		if !ok {
+			return nil, fmt.Errorf("unable to parse serial number %s as base 16 string", requestData.SerialNumber)
+		}
+
+		cached := c.cachedCrlForIssuer(requestData)
+		if cached == nil {
+			if c.Ocsp != nil {
+				continue
+			}
+			return nil, fmt.Errorf("no crl cached for issuer of certificate %s: %w", requestData.SerialNumber, ValidationErrorCertChain)
+		}
+		if isRevoked(cached.crl, serial) {
+			return nil, fmt.Errorf("certificate %s in crl: %w", requestData.SerialNumber, ValidationErrorCertRevoked)
+		}
+	}
+
+	if c.Ocsp != nil {
+		return c.Ocsp.ValidateHashedCertificateChain(ctx, ocspRequestData)
+	}
+
+	return nil, nil
+}
+
+// RevalidateOCSPResponses revalidates the cached OCSP responses of Ocsp, if it caches
+// them
+func (c *CrlCertificateValidationService) RevalidateOCSPResponses(ctx context.Context) (int, error) {
+	if revalidator, ok := c.Ocsp.(OcspRevalidator); ok {
+		return revalidator.RevalidateOCSPResponses(ctx)
+	}
+	return 0, nil
+}
+
+// RefreshRootCertificates refreshes the root certificates if the
+// RootCertificateProvider caches them, along with those of Ocsp
+func (c *CrlCertificateValidationService) RefreshRootCertificates(ctx context.Context) (int, error) {
+	count, err := refreshRootCertificates(ctx, c.RootCertificateProvider)
+	if refresher, ok := c.Ocsp.(RootCertificateRefresher); ok {
+		ocspCount, ocspErr := refresher.RefreshRootCertificates(ctx)
+		count += ocspCount
+		err = errors.Join(err, ocspErr)
+	}
+	return count, err
+}
+
+// issuerOf returns the certificate that issued the i'th certificate in the chain: the
+// next certificate in the chain, or the root certificate that issued the last
+// certificate
+func issuerOf(i int, certificateChain, rootCertificates []*x509.Certificate) *x509.Certificate {
+	if i+1 < len(certificateChain) {
+		return certificateChain[i+1]
+	}
+	subjectCert := certificateChain[i]
+	for _, rootCert := range rootCertificates {
+		if bytes.Equal(subjectCert.AuthorityKeyId, rootCert.SubjectKeyId) {
+			return rootCert
+		}
+	}
+	return nil
+}
+
+// checkRevocation checks the certificate against the CRL from the first of its
+// distribution points that provides one signed by the issuer
+func (c *CrlCertificateValidationService) checkRevocation(ctx context.Context, cert, issuer *x509.Certificate) error {
+	var errs []error
+	for _, url := range cert.CRLDistributionPoints {
+		crl, err := c.lookupCrl(ctx, url, issuer)
+		if err != nil {
+			slog.Warn("crl check", "url", url, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		if isRevoked(crl, cert.SerialNumber) {
+			return fmt.Errorf("certificate %s in crl %s: %w", cert.Subject, url, ValidationErrorCertRevoked)
+		}
+		return nil
+	}
+	return fmt.Errorf("no crl available for certificate %s: %w", cert.Subject, errors.Join(errs...))
+}
+
+func (c *CrlCertificateValidationService) now() time.Time {
+	if c.Clock == nil {
+		return time.Now()
+	}
+	return c.Clock.Now()
+}
+
+// lookupCrl returns the CRL published at the url, downloading it if it is not cached
+// or the cached CRL has expired
+func (c *CrlCertificateValidationService) lookupCrl(ctx context.Context, url string, issuer *x509.Certificate) (*x509.RevocationList, error) {
+	c.mu.Lock()
+	cached := c.crls[url]
+	c.mu.Unlock()
+
+	now := c.now()
+	if cached == nil || !now.Before(cached.expires) {
+		crl, err := c.downloadCrl(ctx, url)
+		if err != nil {
+			crlDownloads.WithLabelValues("failure").Inc()
+			return nil, err
+		}
+		crlDownloads.WithLabelValues("success").Inc()
+
+		cached = &cachedCrl{crl: crl, issuer: issuer, expires: crl.NextUpdate}
+		if c.MaxCrlAge > 0 && (cached.expires.IsZero() || now.Add(c.MaxCrlAge).Before(cached.expires)) {
+			cached.expires = now.Add(c.MaxCrlAge)
+		}
+	}
+
+	if err := cached.crl.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("crl %s not signed by %s: %w", url, issuer.Subject, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.crls == nil {
+		c.crls = make(map[string]*cachedCrl)
+	}
+	cached.issuer = issuer
+	c.crls[url] = cached
+
+	return cached.crl, nil
+}
+
+func (c *CrlCertificateValidationService) downloadCrl(ctx context.Context, url string) (*x509.RevocationList, error) {
+	//#nosec G107 - need to use CRL distribution point specified in the certificate
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", url, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s status %s", url, resp.Status)
+	}
+	crlBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading body: %w", err)
+	}
+
+	// CRLs are usually DER encoded, but some distribution points publish PEM
+	if block, _ := pem.Decode(crlBytes); block != nil && block.Type == "X509 CRL" {
+		crlBytes = block.Bytes
+	}
+
+	crl, err := x509.ParseRevocationList(crlBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing crl from %s: %w", url, err)
+	}
+	return crl, nil
+}
+
+// cachedCrlForIssuer returns the cached CRL, whether or not it has expired, that was
+// signed by the issuer identified by the hashes in the request data
+func (c *CrlCertificateValidationService) cachedCrlForIssuer(requestData ocpp201.OCSPRequestDataType) *cachedCrl {
+	hash := hashAlgorithm(string(requestData.HashAlgorithm))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, cached := range c.crls {
+		nameHash := hash.New()
+		nameHash.Write(cached.issuer.RawSubject)
+		if !strings.EqualFold(hex.EncodeToString(nameHash.Sum(nil)), requestData.IssuerNameHash) {
+			continue
+		}
+		publicKey, err := subjectPublicKey(cached.issuer)
+		if err != nil {
+			continue
+		}
+		keyHash := hash.New()
+		keyHash.Write(publicKey)
+		if strings.EqualFold(hex.EncodeToString(keyHash.Sum(nil)), requestData.IssuerKeyHash) {
+			return cached
+		}
+	}
+	return nil
+}
+
+// subjectPublicKey returns the bytes of the certificate's public key, which are hashed
+// to identify the issuer in an OCSP request
+func subjectPublicKey(cert *x509.Certificate) ([]byte, error) {
+	var publicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(cert.RawSubjectPublicKeyInfo, &publicKeyInfo); err != nil {
+		return nil, err
+	}
+	return publicKeyInfo.PublicKey.RightAlign(), nil
+}
+
+func isRevoked(crl *x509.RevocationList, serialNumber *big.Int) bool {
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(serialNumber) == 0 {
+			return true
+		}
+	}
+	return false
+}
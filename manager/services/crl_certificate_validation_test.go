@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	fakeclock "k8s.io/utils/clock/testing"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// crlPublisher publishes a CRL for the root CA at /root.crl and for the intermediate CA
+// at /int.crl
+type crlPublisher struct {
+	t           *testing.T
+	rootCert    *x509.Certificate
+	rootKey     *ecdsa.PrivateKey
+	intCert     *x509.Certificate
+	intKey      *ecdsa.PrivateKey
+	nextUpdate  time.Duration
+	revoked     []*big.Int
+	unavailable atomic.Bool
+	downloads   atomic.Int32
+}
+
+func (p *crlPublisher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.downloads.Add(1)
+	if p.unavailable.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	var issuerCert *x509.Certificate
+	var issuerKey *ecdsa.PrivateKey
+	switch r.URL.Path {
+	case "/root.crl":
+		issuerCert, issuerKey = p.rootCert, p.rootKey
+	case "/int.crl":
+		issuerCert, issuerKey = p.intCert, p.intKey
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now(),
+		NextUpdate: time.Now().Add(p.nextUpdate),
+	}
+	for _, serialNumber := range p.revoked {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   serialNumber,
+			RevocationTime: time.Now(),
+		})
+	}
+	crlBytes, err := x509.CreateRevocationList(rand.Reader, template, issuerCert, issuerKey)
+	require.NoError(p.t, err)
+
+	w.Header().Set("Content-Type", "application/pkix-crl")
+	_, _ = w.Write(crlBytes)
+}
+
+func setupCrlPublisher(t *testing.T) (*crlPublisher, []*x509.Certificate, *x509.Certificate, *x509.Certificate) {
+	publisher := &crlPublisher{t: t, nextUpdate: time.Hour}
+	server := httptest.NewServer(publisher)
+	t.Cleanup(server.Close)
+
+	rootCert, rootKey := createRootCACertificate(t, "ca1")
+	intCert, intKey := createCrlCertificate(t, "int1", server.URL+"/root.crl", true, rootCert, rootKey)
+	leafCert, _ := createCrlCertificate(t, "MYEMAID", server.URL+"/int.crl", false, intCert, intKey)
+
+	publisher.rootCert, publisher.rootKey = rootCert, rootKey
+	publisher.intCert, publisher.intKey = intCert, intKey
+
+	return publisher, []*x509.Certificate{rootCert}, intCert, leafCert
+}
+
+func createCrlCertificate(t *testing.T, commonName, crlUrl string, isCA bool, caCert *x509.Certificate, caKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	keyPair, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{"Thoughtworks"},
+		},
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Minute),
+		CRLDistributionPoints: []string{crlUrl},
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, &template, caCert, &keyPair.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+
+	return cert, keyPair
+}
+
+func newCrlCertificateValidationService(rootCACerts []*x509.Certificate, clock *fakeclock.FakePassiveClock) *services.CrlCertificateValidationService {
+	return &services.CrlCertificateValidationService{
+		RootCertificateProvider: services.X509RootCertificateProviderService{Certificates: rootCACerts},
+		HttpClient:              http.DefaultClient,
+		Clock:                   clock,
+	}
+}
+
+func hashedCertificateData(t *testing.T, cert, issuerCert *x509.Certificate) ocpp201.OCSPRequestDataType {
+	issuerPublicKeyBytes, err := getPublicKeyBytes(issuerCert.RawSubjectPublicKeyInfo)
+	require.NoError(t, err)
+
+	return ocpp201.OCSPRequestDataType{
+		HashAlgorithm:  "SHA256",
+		IssuerNameHash: hashBytes(cert.RawIssuer),
+		IssuerKeyHash:  hashBytes(issuerPublicKeyBytes),
+		SerialNumber:   cert.SerialNumber.Text(16),
+	}
+}
+
+// stubCertificateValidationService returns the same OCSP response for every chain
+type stubCertificateValidationService struct {
+	ocspResponse string
+}
+
+func (s stubCertificateValidationService) ValidatePEMCertificateChain(context.Context, []byte, string) (*string, error) {
+	return &s.ocspResponse, nil
+}
+
+func (s stubCertificateValidationService) ValidateHashedCertificateChain(context.Context, []ocpp201.OCSPRequestDataType) (*string, error) {
+	return &s.ocspResponse, nil
+}
+
+func TestCrlValidatingPEMCertificateChain(t *testing.T) {
+	publisher, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	validationService := newCrlCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+
+	ocspResp, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	require.NoError(t, err)
+	assert.Nil(t, ocspResp)
+	assert.Equal(t, int32(2), publisher.downloads.Load())
+
+	// the CRLs are cached until their next update
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), publisher.downloads.Load())
+}
+
+func TestCrlValidatingPEMCertificateChainWithRevokedCertificate(t *testing.T) {
+	publisher, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	publisher.revoked = []*big.Int{leafCert.SerialNumber}
+	validationService := newCrlCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	assert.ErrorIs(t, err, services.ValidationErrorCertRevoked)
+}
+
+func TestCrlValidatingPEMCertificateChainWithRevokedIntermediateCertificate(t *testing.T) {
+	publisher, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	publisher.revoked = []*big.Int{intCACert.SerialNumber}
+	validationService := newCrlCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	assert.ErrorIs(t, err, services.ValidationErrorCertRevoked)
+}
+
+func TestCrlValidatingPEMCertificateChainWithUnavailableCrl(t *testing.T) {
+	publisher, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	publisher.unavailable.Store(true)
+	validationService := newCrlCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	assert.ErrorContains(t, err, "no crl available for certificate")
+}
+
+func TestCrlValidatingPEMCertificateChainWithWrongEmaid(t *testing.T) {
+	_, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	validationService := newCrlCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "OTHEREMAID")
+	assert.ErrorIs(t, err, services.ValidationErrorWrongEmaid)
+}
+
+func TestCrlValidatingPEMCertificateChainDownloadsCrlAfterMaxCrlAge(t *testing.T) {
+	publisher, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	validationService := newCrlCertificateValidationService(rootCACerts, clock)
+	validationService.MaxCrlAge = 10 * time.Minute
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	require.NoError(t, err)
+
+	publisher.revoked = []*big.Int{leafCert.SerialNumber}
+	clock.SetTime(clock.Now().Add(10 * time.Minute))
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	assert.ErrorIs(t, err, services.ValidationErrorCertRevoked)
+}
+
+func TestCrlValidatingPEMCertificateChainReturnsOcspResponse(t *testing.T) {
+	_, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	validationService := newCrlCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+	validationService.Ocsp = stubCertificateValidationService{ocspResponse: "b2NzcA=="}
+
+	ocspResp, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	require.NoError(t, err)
+	require.NotNil(t, ocspResp)
+	assert.Equal(t, "b2NzcA==", *ocspResp)
+}
+
+func TestCrlValidatingHashedCertificateChain(t *testing.T) {
+	publisher, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	validationService := newCrlCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+
+	// the CRLs are cached by validating the PEM chain
+	publisher.revoked = []*big.Int{big.NewInt(1)}
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	require.NoError(t, err)
+
+	ocspResp, err := validationService.ValidateHashedCertificateChain(context.TODO(), []ocpp201.OCSPRequestDataType{
+		hashedCertificateData(t, leafCert, intCACert),
+		hashedCertificateData(t, intCACert, rootCACerts[0]),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, ocspResp)
+}
+
+func TestCrlValidatingHashedCertificateChainWithRevokedCertificate(t *testing.T) {
+	publisher, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	validationService := newCrlCertificateValidationService(rootCACerts, clock)
+	validationService.MaxCrlAge = time.Minute
+
+	_, err := validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	require.NoError(t, err)
+
+	revokedCert, _ := createCrlCertificate(t, "OTHEREMAID", intCACert.CRLDistributionPoints[0], false, intCACert, publisher.intKey)
+	publisher.revoked = []*big.Int{revokedCert.SerialNumber}
+	clock.SetTime(clock.Now().Add(time.Minute))
+	_, err = validationService.ValidatePEMCertificateChain(context.TODO(), pemCertificateChain(leafCert, intCACert), "MYEMAID")
+	require.NoError(t, err)
+
+	_, err = validationService.ValidateHashedCertificateChain(context.TODO(), []ocpp201.OCSPRequestDataType{
+		hashedCertificateData(t, revokedCert, intCACert),
+	})
+	assert.ErrorIs(t, err, services.ValidationErrorCertRevoked)
+}
+
+func TestCrlValidatingHashedCertificateChainWithoutCachedCrl(t *testing.T) {
+	_, rootCACerts, intCACert, leafCert := setupCrlPublisher(t)
+	validationService := newCrlCertificateValidationService(rootCACerts, fakeclock.NewFakePassiveClock(time.Now()))
+
+	_, err := validationService.ValidateHashedCertificateChain(context.TODO(), []ocpp201.OCSPRequestDataType{
+		hashedCertificateData(t, leafCert, intCACert),
+	})
+	assert.ErrorIs(t, err, services.ValidationErrorCertChain)
+
+	validationService.Ocsp = stubCertificateValidationService{ocspResponse: "b2NzcA=="}
+	ocspResp, err := validationService.ValidateHashedCertificateChain(context.TODO(), []ocpp201.OCSPRequestDataType{
+		hashedCertificateData(t, leafCert, intCACert),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ocspResp)
+	assert.Equal(t, "b2NzcA==", *ocspResp)
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// DriverNotifier sends notifications about a reservation to the driver that holds it,
+// e.g. by email, by SMS or through the operator's app. A notifier that cannot reach the
+// driver, because the reservation does not have the contact details it needs, sends
+// nothing.
+type DriverNotifier interface {
+	NotifyDriver(ctx context.Context, notification store.ReservationNotification, reservation *store.Reservation) error
+}
+
+// DriverNotifiers sends each notification with all of the notifiers
+type DriverNotifiers []DriverNotifier
+
+func (d DriverNotifiers) NotifyDriver(ctx context.Context, notification store.ReservationNotification, reservation *store.Reservation) error {
+	var errs []error
+	for _, notifier := range d {
+		if err := notifier.NotifyDriver(ctx, notification, reservation); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// TenantDriverNotifier sends each notification with the notifier of the tenant that made
+// the reservation, or with the Default notifier if the tenant does not have one. Nothing
+// is sent if neither is set.
+type TenantDriverNotifier struct {
+	Tenants map[string]DriverNotifier
+	Default DriverNotifier
+}
+
+func (t TenantDriverNotifier) NotifyDriver(ctx context.Context, notification store.ReservationNotification, reservation *store.Reservation) error {
+	notifier, ok := t.Tenants[reservation.TenantId]
+	if !ok {
+		notifier = t.Default
+	}
+	if notifier == nil {
+		return nil
+	}
+	return notifier.NotifyDriver(ctx, notification, reservation)
+}
+
+// ReservationNotificationMessage returns the subject and text of the message that tells
+// the driver about the reservation
+func ReservationNotificationMessage(notification store.ReservationNotification, reservation *store.Reservation) (string, string) {
+	expiry := reservation.ExpiryDate.UTC().Format(time.RFC3339)
+	switch notification {
+	case store.ReservationNotificationConfirmed:
+		return "Your reservation is confirmed",
+			fmt.Sprintf("Your reservation %d at charge station %s is confirmed until %s.",
+				reservation.ReservationId, reservation.ChargeStationId, expiry)
+	case store.ReservationNotificationExpiring:
+		return "Your reservation expires soon",
+			fmt.Sprintf("Your reservation %d at charge station %s expires at %s.",
+				reservation.ReservationId, reservation.ChargeStationId, expiry)
+	default:
+		return "Your reservation has been cancelled",
+			fmt.Sprintf("Your reservation %d at charge station %s has been cancelled.",
+				reservation.ReservationId, reservation.ChargeStationId)
+	}
+}
+
+// DriverNotificationPolicy decides which notifications are due for a reservation:
+//   - Confirmed once the charge station has accepted the reservation
+//   - Expiring once the reservation expires within ReminderBefore, unless it has been used
+//   - Cancelled once the reservation has been cancelled, including as a no-show
+//
+// Notifications are only sent for reservations that have not expired, so that drivers
+// are not told about reservations that finished before the policy was enabled.
+type DriverNotificationPolicy struct {
+	Notifier DriverNotifier
+	// ReminderBefore is how long before a reservation expires that the driver is
+	// reminded: no reminder is sent if it is zero
+	ReminderBefore time.Duration
+}
+
+// Notify sends the notifications that are due for the reservation and have not been
+// sent before, recording them on the reservation. A notification that fails is logged
+// and sent again the next time. It reports whether the reservation was changed: the
+// caller writes the reservation.
+func (p *DriverNotificationPolicy) Notify(ctx context.Context, reservation *store.Reservation, now time.Time) bool {
+	if !reservation.ExpiryDate.After(now) {
+		return false
+	}
+
+	var due []store.ReservationNotification
+	switch reservation.Status {
+	case store.ReservationStatusAccepted:
+		due = append(due, store.ReservationNotificationConfirmed)
+		if p.ReminderBefore > 0 && !now.Before(reservation.ExpiryDate.Add(-p.ReminderBefore)) {
+			due = append(due, store.ReservationNotificationExpiring)
+		}
+	case store.ReservationStatusCancelled:
+		due = append(due, store.ReservationNotificationCancelled)
+	}
+
+	changed := false
+	for _, notification := range due {
+		if reservation.HasNotified(notification) {
+			continue
+		}
+		err := p.Notifier.NotifyDriver(ctx, notification, reservation)
+		if err != nil {
+			slog.Warn("unable to notify driver",
+				slog.Int("reservationId", reservation.ReservationId),
+				slog.String("notification", string(notification)),
+				"err", err)
+			continue
+		}
+		reservation.Notified = append(reservation.Notified, notification)
+		changed = true
+	}
+	return changed
+}
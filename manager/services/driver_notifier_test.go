@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+type recordingDriverNotifier struct {
+	notifications []store.ReservationNotification
+	err           error
+}
+
+func (r *recordingDriverNotifier) NotifyDriver(_ context.Context, notification store.ReservationNotification, _ *store.Reservation) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.notifications = append(r.notifications, notification)
+	return nil
+}
+
+func TestDriverNotificationPolicySendsEachNotificationOnce(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	notifier := &recordingDriverNotifier{}
+	policy := &services.DriverNotificationPolicy{Notifier: notifier, ReminderBefore: 15 * time.Minute}
+
+	reservation := &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		ExpiryDate:      now.Add(time.Hour),
+		Status:          store.ReservationStatusPending,
+	}
+	assert.False(t, policy.Notify(context.Background(), reservation, now))
+
+	reservation.Status = store.ReservationStatusAccepted
+	assert.True(t, policy.Notify(context.Background(), reservation, now))
+	assert.False(t, policy.Notify(context.Background(), reservation, now))
+	assert.True(t, policy.Notify(context.Background(), reservation, now.Add(50*time.Minute)))
+
+	reservation.Status = store.ReservationStatusCancelled
+	assert.True(t, policy.Notify(context.Background(), reservation, now.Add(50*time.Minute)))
+
+	want := []store.ReservationNotification{
+		store.ReservationNotificationConfirmed,
+		store.ReservationNotificationExpiring,
+		store.ReservationNotificationCancelled,
+	}
+	assert.Equal(t, want, notifier.notifications)
+	assert.Equal(t, want, reservation.Notified)
+}
+
+func TestDriverNotificationPolicyRetriesFailedNotifications(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	notifier := &recordingDriverNotifier{err: errors.New("unavailable")}
+	policy := &services.DriverNotificationPolicy{Notifier: notifier}
+
+	reservation := &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		ExpiryDate:      now.Add(time.Hour),
+		Status:          store.ReservationStatusAccepted,
+	}
+	assert.False(t, policy.Notify(context.Background(), reservation, now))
+	assert.Empty(t, reservation.Notified)
+
+	notifier.err = nil
+	assert.True(t, policy.Notify(context.Background(), reservation, now))
+	assert.Equal(t, []store.ReservationNotification{store.ReservationNotificationConfirmed}, reservation.Notified)
+}
+
+func TestDriverNotificationPolicyIgnoresExpiredReservations(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	notifier := &recordingDriverNotifier{}
+	policy := &services.DriverNotificationPolicy{Notifier: notifier}
+
+	reservation := &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		ExpiryDate:      now,
+		Status:          store.ReservationStatusCancelled,
+	}
+	assert.False(t, policy.Notify(context.Background(), reservation, now))
+	assert.Empty(t, notifier.notifications)
+}
+
+func TestTenantDriverNotifierUsesTheTenantsNotifier(t *testing.T) {
+	acme := &recordingDriverNotifier{}
+	fallback := &recordingDriverNotifier{}
+	notifier := services.TenantDriverNotifier{
+		Tenants: map[string]services.DriverNotifier{"acme": acme},
+		Default: fallback,
+	}
+
+	assert.NoError(t, notifier.NotifyDriver(context.Background(), store.ReservationNotificationConfirmed, &store.Reservation{TenantId: "acme"}))
+	assert.NoError(t, notifier.NotifyDriver(context.Background(), store.ReservationNotificationCancelled, &store.Reservation{TenantId: "other"}))
+
+	assert.Equal(t, []store.ReservationNotification{store.ReservationNotificationConfirmed}, acme.notifications)
+	assert.Equal(t, []store.ReservationNotification{store.ReservationNotificationCancelled}, fallback.notifications)
+
+	notifier.Default = nil
+	assert.NoError(t, notifier.NotifyDriver(context.Background(), store.ReservationNotificationCancelled, &store.Reservation{TenantId: "other"}))
+	assert.Len(t, fallback.notifications, 1)
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"strconv"
+	"time"
+)
+
+var contractCertificateProviderRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_contract_certificate_provider_requests_total",
+	Help: "The number of requests made to each provider of a fallback contract certificate provider by result (accepted, failed, error or skipped)",
+}, []string{"provider", "result"})
+
+// FallbackContractCertificateProvider asks its providers, in priority order, for the
+// contract certificate until one of them accepts the request. A provider that returns
+// an error failureThreshold times in a row is considered unhealthy and is skipped for
+// the retryAfter duration, after which a single request is made to check whether it
+// has recovered.
+type FallbackContractCertificateProvider struct {
+	providers []*fallbackContractCertificateProvider
+}
+
+type fallbackContractCertificateProvider struct {
+	ContractCertificateProvider
+	breaker *circuitBreaker
+}
+
+func NewFallbackContractCertificateProvider(providers []ContractCertificateProvider, failureThreshold int, retryAfter time.Duration, clock clock.PassiveClock) *FallbackContractCertificateProvider {
+	fallback := new(FallbackContractCertificateProvider)
+	for _, provider := range providers {
+		fallback.providers = append(fallback.providers, &fallbackContractCertificateProvider{
+			ContractCertificateProvider: provider,
+			breaker: &circuitBreaker{
+				threshold: failureThreshold,
+				openFor:   retryAfter,
+				clock:     clock,
+			},
+		})
+	}
+	return fallback
+}
+
+func (f *FallbackContractCertificateProvider) ProvideCertificate(ctx context.Context, exiRequest string, isoVersion ISOVersion) (EvCertificate15118Response, error) {
+	span := trace.SpanFromContext(ctx)
+
+	var failed *EvCertificate15118Response
+	var errs []error
+	for index, provider := range f.providers {
+		label := strconv.Itoa(index)
+		if !provider.breaker.allow() {
+			contractCertificateProviderRequests.WithLabelValues(label, "skipped").Inc()
+			continue
+		}
+
+		resp, err := provider.ProvideCertificate(ctx, exiRequest, isoVersion)
+		if err != nil {
+			provider.breaker.failure()
+			contractCertificateProviderRequests.WithLabelValues(label, "error").Inc()
+			slog.Warn("contract certificate provider failed", "provider", index, "error", err)
+			errs = append(errs, fmt.Errorf("provider %d: %w", index, err))
+			continue
+		}
+		provider.breaker.success()
+
+		if resp.Status == ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted {
+			contractCertificateProviderRequests.WithLabelValues(label, "accepted").Inc()
+			span.SetAttributes(attribute.Int("contract_cert_provider.provider", index))
+			return resp, nil
+		}
+		// the provider could not provide the certificate, but another provider may
+		contractCertificateProviderRequests.WithLabelValues(label, "failed").Inc()
+		if failed == nil {
+			failed = &resp
+		}
+	}
+
+	if failed != nil {
+		return *failed, nil
+	}
+
+	err := errors.Join(errs...)
+	if err == nil {
+		err = errors.New("no healthy contract certificate provider")
+	}
+	return EvCertificate15118Response{
+		Status: ocpp201.Iso15118EVCertificateStatusEnumTypeFailed,
+	}, err
+}
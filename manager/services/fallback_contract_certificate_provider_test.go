@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type fakeContractCertificateProvider struct {
+	response services.EvCertificate15118Response
+	err      error
+	calls    int
+}
+
+func (f *fakeContractCertificateProvider) ProvideCertificate(context.Context, string, services.ISOVersion) (services.EvCertificate15118Response, error) {
+	f.calls++
+	return f.response, f.err
+}
+
+func acceptingContractCertificateProvider(res string) *fakeContractCertificateProvider {
+	return &fakeContractCertificateProvider{
+		response: services.EvCertificate15118Response{
+			Status:                     ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted,
+			CertificateInstallationRes: res,
+		},
+	}
+}
+
+func failingContractCertificateProvider() *fakeContractCertificateProvider {
+	return &fakeContractCertificateProvider{
+		response: services.EvCertificate15118Response{
+			Status: ocpp201.Iso15118EVCertificateStatusEnumTypeFailed,
+		},
+		err: errors.New("unavailable"),
+	}
+}
+
+func TestFallbackContractCertificateProviderUsesThePrimaryProvider(t *testing.T) {
+	primary := acceptingContractCertificateProvider("primary")
+	secondary := acceptingContractCertificateProvider("secondary")
+	provider := services.NewFallbackContractCertificateProvider([]services.ContractCertificateProvider{primary, secondary},
+		3, time.Minute, clockTest.NewFakePassiveClock(time.Now()))
+
+	resp, err := provider.ProvideCertificate(context.Background(), "exi", services.ISO15118V2)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", resp.CertificateInstallationRes)
+	assert.Equal(t, 0, secondary.calls)
+}
+
+func TestFallbackContractCertificateProviderFallsBackWhenThePrimaryFails(t *testing.T) {
+	primary := failingContractCertificateProvider()
+	secondary := acceptingContractCertificateProvider("secondary")
+	provider := services.NewFallbackContractCertificateProvider([]services.ContractCertificateProvider{primary, secondary},
+		3, time.Minute, clockTest.NewFakePassiveClock(time.Now()))
+
+	resp, err := provider.ProvideCertificate(context.Background(), "exi", services.ISO15118V2)
+	require.NoError(t, err)
+	assert.Equal(t, ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted, resp.Status)
+	assert.Equal(t, "secondary", resp.CertificateInstallationRes)
+}
+
+func TestFallbackContractCertificateProviderFallsBackWhenThePrimaryCannotProvideTheCertificate(t *testing.T) {
+	primary := &fakeContractCertificateProvider{
+		response: services.EvCertificate15118Response{
+			Status: ocpp201.Iso15118EVCertificateStatusEnumTypeFailed,
+		},
+	}
+	secondary := acceptingContractCertificateProvider("secondary")
+	provider := services.NewFallbackContractCertificateProvider([]services.ContractCertificateProvider{primary, secondary},
+		3, time.Minute, clockTest.NewFakePassiveClock(time.Now()))
+
+	resp, err := provider.ProvideCertificate(context.Background(), "exi", services.ISO15118V2)
+	require.NoError(t, err)
+	assert.Equal(t, "secondary", resp.CertificateInstallationRes)
+}
+
+func TestFallbackContractCertificateProviderSkipsAnUnhealthyProvider(t *testing.T) {
+	primary := failingContractCertificateProvider()
+	secondary := acceptingContractCertificateProvider("secondary")
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	provider := services.NewFallbackContractCertificateProvider([]services.ContractCertificateProvider{primary, secondary},
+		2, time.Minute, clock)
+
+	for i := 0; i < 4; i++ {
+		_, err := provider.ProvideCertificate(context.Background(), "exi", services.ISO15118V2)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, primary.calls)
+	assert.Equal(t, 4, secondary.calls)
+
+	// once the primary has been skipped for a minute it is tried again
+	primary.response = services.EvCertificate15118Response{
+		Status:                     ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted,
+		CertificateInstallationRes: "primary",
+	}
+	primary.err = nil
+	clock.SetTime(clock.Now().Add(time.Minute))
+
+	resp, err := provider.ProvideCertificate(context.Background(), "exi", services.ISO15118V2)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", resp.CertificateInstallationRes)
+	assert.Equal(t, 3, primary.calls)
+	assert.Equal(t, 4, secondary.calls)
+}
+
+func TestFallbackContractCertificateProviderWhenAllProvidersFail(t *testing.T) {
+	provider := services.NewFallbackContractCertificateProvider([]services.ContractCertificateProvider{
+		failingContractCertificateProvider(),
+		failingContractCertificateProvider(),
+	}, 1, time.Minute, clockTest.NewFakePassiveClock(time.Now()))
+
+	resp, err := provider.ProvideCertificate(context.Background(), "exi", services.ISO15118V2)
+	assert.ErrorContains(t, err, "provider 0: unavailable")
+	assert.ErrorContains(t, err, "provider 1: unavailable")
+	assert.Equal(t, ocpp201.Iso15118EVCertificateStatusEnumTypeFailed, resp.Status)
+
+	_, err = provider.ProvideCertificate(context.Background(), "exi", services.ISO15118V2)
+	assert.ErrorContains(t, err, "no healthy contract certificate provider")
+}
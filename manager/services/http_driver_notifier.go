@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DriverNotificationRequest is the body of the request that the WebhookDriverNotifier
+// POSTs for each notification
+type DriverNotificationRequest struct {
+	Notification    string     `json:"notification"`
+	ReservationId   int        `json:"reservationId"`
+	ChargeStationId string     `json:"chargeStationId"`
+	EvseId          *int       `json:"evseId,omitempty"`
+	IdToken         string     `json:"idToken"`
+	TokenType       string     `json:"tokenType"`
+	StartDate       *time.Time `json:"startDate,omitempty"`
+	ExpiryDate      time.Time  `json:"expiryDate"`
+	Status          string     `json:"status"`
+	TenantId        string     `json:"tenantId,omitempty"`
+	Email           string     `json:"email,omitempty"`
+	Phone           string     `json:"phone,omitempty"`
+}
+
+// WebhookDriverNotifier POSTs a DriverNotificationRequest to a webhook, e.g. the backend
+// of the operator's app so that it can push the notification to the driver
+type WebhookDriverNotifier struct {
+	Url        string
+	HttpClient *http.Client
+	// TokenService may be nil if the webhook does not require authentication
+	TokenService HttpTokenService
+}
+
+func (w WebhookDriverNotifier) NotifyDriver(ctx context.Context, notification store.ReservationNotification, reservation *store.Reservation) error {
+	req := DriverNotificationRequest{
+		Notification:    string(notification),
+		ReservationId:   reservation.ReservationId,
+		ChargeStationId: reservation.ChargeStationId,
+		EvseId:          reservation.EvseId,
+		IdToken:         reservation.IdToken,
+		TokenType:       reservation.TokenType,
+		ExpiryDate:      reservation.ExpiryDate.UTC(),
+		Status:          string(reservation.Status),
+		TenantId:        reservation.TenantId,
+	}
+	if !reservation.StartDate.IsZero() {
+		startDate := reservation.StartDate.UTC()
+		req.StartDate = &startDate
+	}
+	if reservation.Contact != nil {
+		req.Email = reservation.Contact.Email
+		req.Phone = reservation.Contact.Phone
+	}
+	return postNotification(ctx, w.HttpClient, w.TokenService, w.Url, req)
+}
+
+// SmsRequest is the body of the request that the SmsDriverNotifier POSTs to the SMS
+// gateway for each notification
+type SmsRequest struct {
+	From    string `json:"from,omitempty"`
+	To      string `json:"to"`
+	Message string `json:"message"`
+}
+
+// SmsDriverNotifier sends notifications to the driver's phone number through an SMS
+// gateway
+type SmsDriverNotifier struct {
+	Url        string
+	From       string
+	HttpClient *http.Client
+	// TokenService may be nil if the gateway does not require authentication
+	TokenService HttpTokenService
+}
+
+func (s SmsDriverNotifier) NotifyDriver(ctx context.Context, notification store.ReservationNotification, reservation *store.Reservation) error {
+	if reservation.Contact == nil || reservation.Contact.Phone == "" {
+		return nil
+	}
+	_, text := ReservationNotificationMessage(notification, reservation)
+	return postNotification(ctx, s.HttpClient, s.TokenService, s.Url, SmsRequest{
+		From:    s.From,
+		To:      reservation.Contact.Phone,
+		Message: text,
+	})
+}
+
+func postNotification(ctx context.Context, httpClient *http.Client, tokenService HttpTokenService, url string, notification any) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if tokenService != nil {
+		token, err := tokenService.GetToken(ctx, false)
+		if err != nil {
+			return fmt.Errorf("failed to get token: %w", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errs.New(errs.ErrDependencyUnavailable, "failed to execute request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return errs.New(errs.ErrDependencyUnavailable, "unexpected status code: %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
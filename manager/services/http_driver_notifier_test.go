@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func notificationServer(t *testing.T, status int, body any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer TOKEN", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(body))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWebhookDriverNotifier(t *testing.T) {
+	var req services.DriverNotificationRequest
+	server := notificationServer(t, http.StatusAccepted, &req)
+	expiry := time.Date(2026, 10, 15, 13, 0, 0, 0, time.UTC)
+	evseId := 2
+
+	notifier := services.WebhookDriverNotifier{
+		Url:          server.URL,
+		HttpClient:   http.DefaultClient,
+		TokenService: services.NewFixedHttpTokenService("TOKEN"),
+	}
+	err := notifier.NotifyDriver(context.Background(), store.ReservationNotificationConfirmed, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		EvseId:          &evseId,
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ExpiryDate:      expiry,
+		Status:          store.ReservationStatusAccepted,
+		TenantId:        "acme",
+		Contact:         &store.ReservationContact{Email: "driver@example.com"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, services.DriverNotificationRequest{
+		Notification:    "Confirmed",
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		EvseId:          &evseId,
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ExpiryDate:      expiry,
+		Status:          "Accepted",
+		TenantId:        "acme",
+		Email:           "driver@example.com",
+	}, req)
+}
+
+func TestWebhookDriverNotifierFailsOnErrorStatus(t *testing.T) {
+	var req services.DriverNotificationRequest
+	server := notificationServer(t, http.StatusInternalServerError, &req)
+
+	notifier := services.WebhookDriverNotifier{
+		Url:          server.URL,
+		HttpClient:   http.DefaultClient,
+		TokenService: services.NewFixedHttpTokenService("TOKEN"),
+	}
+	err := notifier.NotifyDriver(context.Background(), store.ReservationNotificationCancelled, &store.Reservation{ReservationId: 1})
+	assert.ErrorContains(t, err, "unexpected status code: 500")
+}
+
+func TestSmsDriverNotifier(t *testing.T) {
+	var req services.SmsRequest
+	server := notificationServer(t, http.StatusOK, &req)
+
+	notifier := services.SmsDriverNotifier{
+		Url:          server.URL,
+		From:         "ACME",
+		HttpClient:   http.DefaultClient,
+		TokenService: services.NewFixedHttpTokenService("TOKEN"),
+	}
+	err := notifier.NotifyDriver(context.Background(), store.ReservationNotificationExpiring, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		ExpiryDate:      time.Date(2026, 10, 15, 13, 0, 0, 0, time.UTC),
+		Contact:         &store.ReservationContact{Phone: "+447700900123"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, services.SmsRequest{
+		From:    "ACME",
+		To:      "+447700900123",
+		Message: "Your reservation 1 at charge station cs001 expires at 2026-10-15T13:00:00Z.",
+	}, req)
+}
+
+func TestSmsDriverNotifierSkipsReservationsWithoutAPhoneNumber(t *testing.T) {
+	notifier := services.SmsDriverNotifier{
+		Url:        "http://localhost:0",
+		HttpClient: http.DefaultClient,
+	}
+	err := notifier.NotifyDriver(context.Background(), store.ReservationNotificationExpiring, &store.Reservation{
+		ReservationId: 1,
+		Contact:       &store.ReservationContact{Email: "driver@example.com"},
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// PricingRequest is the body of the request that the HttpTariffService POSTs to the
+// pricing service for each transaction
+type PricingRequest struct {
+	ChargeStationId string    `json:"chargeStationId"`
+	TransactionId   string    `json:"transactionId"`
+	IdToken         string    `json:"idToken"`
+	TokenType       string    `json:"tokenType"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	EnergyKwh       float64   `json:"energyKwh"`
+	ChargingHours   float64   `json:"chargingHours"`
+	ParkingHours    float64   `json:"parkingHours"`
+}
+
+// PricingResponse is the cost of a transaction returned by the pricing service.
+// TariffId identifies the tariff that the pricing service applied: if it is set the
+// tariff must be registered with the CSMS so that it can be used while the pricing
+// service is unavailable.
+type PricingResponse struct {
+	TariffId    string  `json:"tariffId,omitempty"`
+	Currency    string  `json:"currency,omitempty"`
+	SessionFee  float64 `json:"sessionFee"`
+	EnergyCost  float64 `json:"energyCost"`
+	TimeCost    float64 `json:"timeCost"`
+	ParkingCost float64 `json:"parkingCost"`
+	TotalCost   float64 `json:"totalCost"`
+}
+
+// HttpTariffService asks an external pricing service for the cost of each transaction.
+//
+// When the pricing service cannot be reached, or responds with an error, the
+// transaction is priced by the Fallback tariff engine with the last tariff that the
+// pricing service applied to the charge station, otherwise with the tariff that the
+// Fallback would use. After FailureThreshold consecutive failures the pricing service
+// is not called for the OpenFor duration, after which a single call is made to check
+// whether it has recovered.
+type HttpTariffService struct {
+	url          string
+	httpClient   *http.Client
+	tokenService HttpTokenService
+	timeout      time.Duration
+	fallback     TariffEngine
+	breaker      *circuitBreaker
+
+	sync.Mutex
+	// tariffIds holds the id of the last tariff applied by the pricing service to each
+	// charge station
+	tariffIds map[string]string
+}
+
+// NewHttpTariffService returns an HttpTariffService that POSTs a PricingRequest to the
+// url: tokenService may be nil if the pricing service does not require authentication
+func NewHttpTariffService(url string, httpClient *http.Client, tokenService HttpTokenService, fallback TariffEngine,
+	timeout time.Duration, failureThreshold int, openFor time.Duration, clock clock.PassiveClock) *HttpTariffService {
+	return &HttpTariffService{
+		url:          url,
+		httpClient:   httpClient,
+		tokenService: tokenService,
+		timeout:      timeout,
+		fallback:     fallback,
+		breaker: &circuitBreaker{
+			threshold: failureThreshold,
+			openFor:   openFor,
+			clock:     clock,
+		},
+		tariffIds: make(map[string]string),
+	}
+}
+
+func (h *HttpTariffService) CalculateCost(transaction *store.Transaction) (float64, error) {
+	breakdown, err := h.CalculateCostBreakdown(transaction)
+	if err != nil {
+		return 0, err
+	}
+	return breakdown.TotalCost, nil
+}
+
+func (h *HttpTariffService) CalculateCostBreakdown(transaction *store.Transaction) (*store.CostBreakdown, error) {
+	if transaction == nil {
+		return nil, errors.New("no transaction provided")
+	}
+
+	tx, err := summariseTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.breaker.allow() {
+		ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+		defer cancel()
+
+		pricing, err := h.price(ctx, transaction, tx)
+		if err == nil {
+			h.breaker.success()
+			if pricing.TariffId != "" {
+				h.Lock()
+				h.tariffIds[transaction.ChargeStationId] = pricing.TariffId
+				h.Unlock()
+			}
+			return &store.CostBreakdown{
+				TariffId:    pricing.TariffId,
+				Currency:    pricing.Currency,
+				SessionFee:  pricing.SessionFee,
+				EnergyCost:  pricing.EnergyCost,
+				TimeCost:    pricing.TimeCost,
+				ParkingCost: pricing.ParkingCost,
+				TotalCost:   pricing.TotalCost,
+			}, nil
+		}
+		h.breaker.failure()
+		slog.Warn("pricing service failed: using fallback tariff", "chargeStationId", transaction.ChargeStationId,
+			"transactionId", transaction.TransactionId, "error", err)
+	}
+
+	return h.calculateFallbackCost(transaction)
+}
+
+func (h *HttpTariffService) price(ctx context.Context, transaction *store.Transaction, tx tariffedTransaction) (*PricingResponse, error) {
+	body, err := json.Marshal(PricingRequest{
+		ChargeStationId: transaction.ChargeStationId,
+		TransactionId:   transaction.TransactionId,
+		IdToken:         transaction.IdToken,
+		TokenType:       transaction.TokenType,
+		StartTime:       tx.start.UTC(),
+		EndTime:         tx.end.UTC(),
+		EnergyKwh:       tx.energyWh / 1000,
+		ChargingHours:   tx.charging.Hours(),
+		ParkingHours:    tx.parking.Hours(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pricing request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if h.tokenService != nil {
+		token, err := h.tokenService.GetToken(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token: %w", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, errs.New(errs.ErrDependencyUnavailable, "failed to execute request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errs.New(errs.ErrDependencyUnavailable, "unexpected status code: %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var pricing PricingResponse
+	if err := json.Unmarshal(respBody, &pricing); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+	return &pricing, nil
+}
+
+// calculateFallbackCost prices the transaction with the last tariff that the pricing
+// service applied to the charge station, if it is still registered, otherwise with the
+// fallback tariff engine's tariff
+func (h *HttpTariffService) calculateFallbackCost(transaction *store.Transaction) (*store.CostBreakdown, error) {
+	h.Lock()
+	tariffId := h.tariffIds[transaction.ChargeStationId]
+	h.Unlock()
+
+	if tariffId != "" {
+		tariff, err := h.fallback.Store.LookupTariff(context.Background(), tariffId)
+		if err != nil {
+			return nil, fmt.Errorf("lookup tariff %s: %w", tariffId, err)
+		}
+		if tariff != nil {
+			return h.fallback.itemise(transaction, tariffId, tariff)
+		}
+	}
+
+	return h.fallback.CalculateCostBreakdown(transaction)
+}
+
+// circuitBreaker stops calls to a service that keeps failing: after threshold
+// consecutive failures the circuit opens and calls are refused for openFor, after
+// which one call is allowed through to test whether the service has recovered
+type circuitBreaker struct {
+	sync.Mutex
+	threshold int
+	openFor   time.Duration
+	clock     clock.PassiveClock
+	failures  int
+	openUntil time.Time
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	now := b.clock.Now()
+	if now.Before(b.openUntil) {
+		return false
+	}
+	// the other calls are refused until the trial call has completed
+	b.openUntil = now.Add(b.openFor)
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.Lock()
+	defer b.Unlock()
+	b.failures = 0
+}
+
+func (b *circuitBreaker) failure() {
+	b.Lock()
+	defer b.Unlock()
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.clock.Now().Add(b.openFor)
+	}
+}
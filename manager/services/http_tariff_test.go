@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+type pricingServer struct {
+	*httptest.Server
+	calls    atomic.Int32
+	failing  atomic.Bool
+	requests chan services.PricingRequest
+}
+
+func newPricingServer(t *testing.T, response services.PricingResponse) *pricingServer {
+	server := &pricingServer{requests: make(chan services.PricingRequest, 10)}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.calls.Add(1)
+		if server.failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var req services.PricingRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		server.requests <- req
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newHttpTariffService(t *testing.T, url string, clock *fakeclock.FakePassiveClock) (*services.HttpTariffService, store.Engine) {
+	fallback, engine := newTariffEngine(t, timeOfUseTariff("tou"))
+	return services.NewHttpTariffService(url, http.DefaultClient, services.NewFixedHttpTokenService("secret"), fallback,
+		time.Second, 2, time.Minute, clock), engine
+}
+
+func TestHttpTariffServiceUsesTheCostFromThePricingService(t *testing.T) {
+	server := newPricingServer(t, services.PricingResponse{
+		TariffId:   "tou",
+		Currency:   "GBP",
+		EnergyCost: 4,
+		TimeCost:   2,
+		TotalCost:  6,
+	})
+	tariffService, _ := newHttpTariffService(t, server.URL, fakeclock.NewFakePassiveClock(time.Now()))
+
+	breakdown, err := tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+
+	assert.Equal(t, &store.CostBreakdown{
+		TariffId:   "tou",
+		Currency:   "GBP",
+		EnergyCost: 4,
+		TimeCost:   2,
+		TotalCost:  6,
+	}, breakdown)
+
+	req := <-server.requests
+	assert.Equal(t, "cs001", req.ChargeStationId)
+	assert.Equal(t, "tx001", req.TransactionId)
+	assert.Equal(t, time.Date(2023, 6, 1, 16, 0, 0, 0, time.UTC), req.StartTime)
+	assert.Equal(t, time.Date(2023, 6, 1, 18, 30, 0, 0, time.UTC), req.EndTime)
+	assert.InDelta(t, 20.0, req.EnergyKwh, 0.0001)
+	assert.InDelta(t, 2.0, req.ChargingHours, 0.0001)
+	assert.InDelta(t, 0.5, req.ParkingHours, 0.0001)
+
+	cost, err := tariffService.CalculateCost(newTimeOfUseTransaction())
+	require.NoError(t, err)
+	assert.Equal(t, 6.0, cost)
+}
+
+func TestHttpTariffServiceFallsBackToTheLastTariffApplied(t *testing.T) {
+	server := newPricingServer(t, services.PricingResponse{TariffId: "tou", Currency: "GBP", TotalCost: 6})
+	tariffService, _ := newHttpTariffService(t, server.URL, fakeclock.NewFakePassiveClock(time.Now()))
+
+	_, err := tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+
+	server.failing.Store(true)
+	breakdown, err := tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+
+	assert.Equal(t, "tou", breakdown.TariffId)
+	assert.InDelta(t, 13.4, breakdown.TotalCost, 0.0001)
+	assert.Len(t, breakdown.Periods, 2)
+}
+
+func TestHttpTariffServiceFallsBackToTheDefaultTariff(t *testing.T) {
+	server := newPricingServer(t, services.PricingResponse{})
+	server.failing.Store(true)
+	tariffService, _ := newHttpTariffService(t, server.URL, fakeclock.NewFakePassiveClock(time.Now()))
+
+	transaction := newTimeOfUseTransaction()
+	breakdown, err := tariffService.CalculateCostBreakdown(transaction)
+	require.NoError(t, err)
+
+	assert.Empty(t, breakdown.TariffId)
+	assert.Equal(t, "EUR", breakdown.Currency)
+	assert.InDelta(t, 11.0, breakdown.TotalCost, 0.0001)
+}
+
+func TestHttpTariffServiceStopsCallingAFailingPricingService(t *testing.T) {
+	server := newPricingServer(t, services.PricingResponse{TotalCost: 6})
+	server.failing.Store(true)
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	tariffService, _ := newHttpTariffService(t, server.URL, clock)
+
+	for i := 0; i < 4; i++ {
+		_, err := tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), server.calls.Load())
+
+	// once the circuit has been open for a minute a trial call is made
+	server.failing.Store(false)
+	clock.SetTime(clock.Now().Add(time.Minute))
+	breakdown, err := tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+	assert.Equal(t, 6.0, breakdown.TotalCost)
+	assert.Equal(t, int32(3), server.calls.Load())
+
+	_, err = tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), server.calls.Load())
+}
+
+func TestHttpTariffServiceReopensTheCircuitWhenTheTrialCallFails(t *testing.T) {
+	server := newPricingServer(t, services.PricingResponse{TotalCost: 6})
+	server.failing.Store(true)
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	tariffService, _ := newHttpTariffService(t, server.URL, clock)
+
+	for i := 0; i < 2; i++ {
+		_, err := tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+		require.NoError(t, err)
+	}
+	clock.SetTime(clock.Now().Add(time.Minute))
+	_, err := tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), server.calls.Load())
+
+	server.failing.Store(false)
+	breakdown, err := tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+	assert.InDelta(t, 11.0, breakdown.TotalCost, 0.0001)
+	assert.Equal(t, int32(3), server.calls.Load())
+}
+
+func TestHttpTariffServiceFallsBackWhenThePricingServiceIsUnreachable(t *testing.T) {
+	server := newPricingServer(t, services.PricingResponse{})
+	server.Close()
+	tariffService, engine := newHttpTariffService(t, server.URL, fakeclock.NewFakePassiveClock(time.Now()))
+	require.NoError(t, engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{TariffId: "tou"}))
+
+	breakdown, err := tariffService.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+	assert.Equal(t, "tou", breakdown.TariffId)
+}
@@ -6,16 +6,26 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/exp/slog"
 	"io"
 	"k8s.io/utils/clock"
 	"net/http"
 	"os"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 )
 
+var httpTokenRefreshFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_http_token_refresh_failures_total",
+	Help: "The number of failed attempts to obtain an HTTP bearer token by type of token service",
+}, []string{"type"})
+
 type HttpTokenService interface {
 	GetToken(ctx context.Context, refresh bool) (string, error)
 }
@@ -76,6 +86,36 @@ func (f *FixedHttpTokenService) GetToken(_ context.Context, _ bool) (string, err
 	return f.token, nil
 }
 
+// SourceHttpTokenService reads the token from a LocalSource each time it is asked
+// for one, so a token that is rotated in the source is used without restarting the
+// manager. It is usually wrapped in a CachingHttpTokenService to avoid reading the
+// source for every request.
+type SourceHttpTokenService struct {
+	source LocalSource
+}
+
+func NewSourceHttpTokenService(source LocalSource) *SourceHttpTokenService {
+	return &SourceHttpTokenService{
+		source: source,
+	}
+}
+
+func (s *SourceHttpTokenService) GetToken(ctx context.Context, _ bool) (string, error) {
+	data, err := s.source.GetData(ctx)
+	if err != nil {
+		httpTokenRefreshFailures.WithLabelValues("source").Inc()
+		slog.Error("failed to read http token", "error", err)
+		return "", fmt.Errorf("reading token: %w", err)
+	}
+	token := strings.TrimSpace(data)
+	if token == "" {
+		httpTokenRefreshFailures.WithLabelValues("source").Inc()
+		slog.Error("failed to read http token", "error", "token is empty")
+		return "", errors.New("token is empty")
+	}
+	return token, nil
+}
+
 type HubjectTestHttpTokenService struct {
 	url    string
 	client *http.Client
@@ -128,26 +168,40 @@ func (h *HubjectTestHttpTokenService) GetToken(ctx context.Context, _ bool) (str
 	return matches[1], nil
 }
 
+// OAuth2HttpTokenService obtains a token using the OAuth2 client credentials grant and
+// caches it until it expires. The token is refreshed refreshBefore its expiry: if the
+// refresh fails the cached token is used until it expires. The client secret is read
+// from its LocalSource for each token request so that it can be rotated without
+// restarting the manager.
 type OAuth2HttpTokenService struct {
 	sync.Mutex
 
-	url          string
-	clientId     string
-	clientSecret string
-	client       *http.Client
-	clock        clock.PassiveClock
+	url           string
+	clientId      string
+	clientSecret  LocalSource
+	refreshBefore time.Duration
+	client        *http.Client
+	clock         clock.PassiveClock
 
-	cachedValue  string
-	cachedExpiry time.Time
+	cachedValue   string
+	cachedExpiry  time.Time
+	cachedRefresh time.Time
 }
 
 func NewOAuth2HttpTokenService(url, clientId, clientSecret string, httpClient *http.Client, clk clock.PassiveClock) *OAuth2HttpTokenService {
+	return NewRotatingOAuth2HttpTokenService(url, clientId, StringSource{Data: clientSecret}, 0, httpClient, clk)
+}
+
+// NewRotatingOAuth2HttpTokenService returns an OAuth2HttpTokenService that reads the
+// client secret from a LocalSource and refreshes the token refreshBefore it expires
+func NewRotatingOAuth2HttpTokenService(url, clientId string, clientSecret LocalSource, refreshBefore time.Duration, httpClient *http.Client, clk clock.PassiveClock) *OAuth2HttpTokenService {
 	return &OAuth2HttpTokenService{
-		url:          url,
-		client:       httpClient,
-		clientId:     clientId,
-		clientSecret: clientSecret,
-		clock:        clk,
+		url:           url,
+		client:        httpClient,
+		clientId:      clientId,
+		clientSecret:  clientSecret,
+		refreshBefore: refreshBefore,
+		clock:         clk,
 	}
 }
 
@@ -167,46 +221,77 @@ func (o *OAuth2HttpTokenService) GetToken(ctx context.Context, refresh bool) (st
 	o.Lock()
 	defer o.Unlock()
 
-	if refresh || o.clock.Now().After(o.cachedExpiry) {
-		// create the http request
-		body := OAuth2TokenRequest{
-			GrantType:    "client_credentials",
-			ClientId:     o.clientId,
-			ClientSecret: o.clientSecret,
-		}
-		b, err := json.Marshal(body)
-		if err != nil {
-			return "", err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, bytes.NewReader(b))
-		if err != nil {
-			return "", err
-		}
-		req.Header.Set("Content-Type", "application/json")
+	now := o.clock.Now()
+	if !refresh && o.cachedValue != "" && now.Before(o.cachedRefresh) {
+		return o.cachedValue, nil
+	}
 
-		// execute the request
-		resp, err := o.client.Do(req)
-		if err != nil {
-			return "", err
+	token, expiresIn, err := o.requestToken(ctx)
+	if err != nil {
+		httpTokenRefreshFailures.WithLabelValues("oauth2").Inc()
+		if !refresh && o.cachedValue != "" && now.Before(o.cachedExpiry) {
+			slog.Warn("failed to refresh oauth2 token: using cached token until it expires", "url", o.url,
+				"expiry", o.cachedExpiry, "error", err)
+			return o.cachedValue, nil
 		}
-		defer func() {
-			_ = resp.Body.Close()
-		}()
+		slog.Error("failed to obtain oauth2 token", "url", o.url, "error", err)
+		return "", err
+	}
 
-		// parse the response
-		if resp.StatusCode != http.StatusOK {
-			return "", fmt.Errorf("http request %s %s %w", http.MethodPost, o.url, HttpError(resp.StatusCode))
-		}
+	// short-lived tokens are refreshed half way through their life rather than
+	// refreshBefore their expiry
+	refreshBefore := o.refreshBefore
+	if refreshBefore > expiresIn/2 {
+		refreshBefore = expiresIn / 2
+	}
+	o.cachedValue = token
+	o.cachedExpiry = now.Add(expiresIn)
+	o.cachedRefresh = o.cachedExpiry.Add(-refreshBefore)
 
-		// decode the response
-		var tokenResponse OAuth2TokenResponse
-		if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
-			return "", err
-		}
+	return o.cachedValue, nil
+}
 
-		o.cachedValue = tokenResponse.AccessToken
-		o.cachedExpiry = o.clock.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+func (o *OAuth2HttpTokenService) requestToken(ctx context.Context) (string, time.Duration, error) {
+	clientSecret, err := o.clientSecret.GetData(ctx)
+	if err != nil {
+		return "", 0, fmt.Errorf("reading client secret: %w", err)
 	}
 
-	return o.cachedValue, nil
+	// create the http request
+	body := OAuth2TokenRequest{
+		GrantType:    "client_credentials",
+		ClientId:     o.clientId,
+		ClientSecret: strings.TrimSpace(clientSecret),
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return "", 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, bytes.NewReader(b))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// execute the request
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// parse the response
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("http request %s %s %w", http.MethodPost, o.url, HttpError(resp.StatusCode))
+	}
+
+	// decode the response
+	var tokenResponse OAuth2TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", 0, err
+	}
+
+	return tokenResponse.AccessToken, time.Duration(tokenResponse.ExpiresIn) * time.Second, nil
 }
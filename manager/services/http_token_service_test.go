@@ -10,9 +10,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/services"
 	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -44,6 +46,32 @@ func TestFixedTokenService(t *testing.T) {
 	assert.Equal(t, "test", token)
 }
 
+func TestSourceTokenService(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("test1\n"), 0600))
+
+	svc := services.NewCachingHttpTokenService(services.NewSourceHttpTokenService(services.FileSource{FileName: tokenFile}),
+		time.Minute, clock.RealClock{})
+	token, err := svc.GetToken(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, "test1", token)
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("test2\n"), 0600))
+	token, err = svc.GetToken(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, "test1", token)
+
+	token, err = svc.GetToken(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, "test2", token)
+}
+
+func TestSourceTokenServiceWithEmptyToken(t *testing.T) {
+	svc := services.NewSourceHttpTokenService(services.StringSource{Data: "\n"})
+	_, err := svc.GetToken(context.Background(), false)
+	assert.Error(t, err)
+}
+
 type CountingTokenService struct {
 	Count int
 	Token string
@@ -119,13 +147,101 @@ func TestOAuth2HttpTokenServiceWithCachedCredential(t *testing.T) {
 	assert.Equal(t, 1, handler.CallCount)
 }
 
+func TestOAuth2HttpTokenServiceRefreshesBeforeExpiry(t *testing.T) {
+	handler := &oauth2HttpHandler{
+		ClientId:     "client_id",
+		ClientSecret: "client_secret",
+	}
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	clk := clockTest.NewFakePassiveClock(time.Now())
+	svc := services.NewRotatingOAuth2HttpTokenService(srv.URL, "client_id", services.StringSource{Data: "client_secret"},
+		2*time.Second, http.DefaultClient, clk)
+	_, err := svc.GetToken(context.Background(), false)
+	require.NoError(t, err)
+
+	clk.SetTime(clk.Now().Add(7 * time.Second))
+	_, err = svc.GetToken(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, handler.CallCount)
+
+	clk.SetTime(clk.Now().Add(time.Second))
+	_, err = svc.GetToken(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, handler.CallCount)
+}
+
+func TestOAuth2HttpTokenServiceUsesCachedTokenWhenRefreshFails(t *testing.T) {
+	handler := &oauth2HttpHandler{
+		ClientId:     "client_id",
+		ClientSecret: "client_secret",
+	}
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	clk := clockTest.NewFakePassiveClock(time.Now())
+	svc := services.NewRotatingOAuth2HttpTokenService(srv.URL, "client_id", services.StringSource{Data: "client_secret"},
+		2*time.Second, http.DefaultClient, clk)
+	_, err := svc.GetToken(context.Background(), false)
+	require.NoError(t, err)
+
+	handler.Unavailable = true
+	clk.SetTime(clk.Now().Add(9 * time.Second))
+	token, err := svc.GetToken(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, "test", token)
+
+	_, err = svc.GetToken(context.Background(), true)
+	assert.Error(t, err)
+
+	clk.SetTime(clk.Now().Add(2 * time.Second))
+	_, err = svc.GetToken(context.Background(), false)
+	assert.Error(t, err)
+}
+
+func TestOAuth2HttpTokenServiceReadsRotatedClientSecret(t *testing.T) {
+	handler := &oauth2HttpHandler{
+		ClientId:     "client_id",
+		ClientSecret: "client_secret",
+	}
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "client_secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("client_secret\n"), 0600))
+
+	svc := services.NewRotatingOAuth2HttpTokenService(srv.URL, "client_id", services.FileSource{FileName: secretFile},
+		time.Second, http.DefaultClient, clock.RealClock{})
+	_, err := svc.GetToken(context.Background(), false)
+	require.NoError(t, err)
+
+	handler.ClientSecret = "rotated_secret"
+	_, err = svc.GetToken(context.Background(), true)
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(secretFile, []byte("rotated_secret\n"), 0600))
+	token, err := svc.GetToken(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, "test", token)
+	assert.Equal(t, 2, handler.CallCount)
+}
+
 type oauth2HttpHandler struct {
 	ClientId     string
 	ClientSecret string
 	CallCount    int
+	Unavailable  bool
 }
 
 func (o *oauth2HttpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if o.Unavailable {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
 	var tokenReq services.OAuth2TokenRequest
 	err := json.NewDecoder(r.Body).Decode(&tokenReq)
 	if err != nil {
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"sync/atomic"
+	"time"
+)
+
+// HeartbeatInterval is the interval at which charge stations are asked to send a
+// Heartbeat. It is shared by the BootNotification handlers and the LivenessService
+// so that it can be changed while the manager is running.
+type HeartbeatInterval struct {
+	interval atomic.Int64
+}
+
+func NewHeartbeatInterval(interval time.Duration) *HeartbeatInterval {
+	h := new(HeartbeatInterval)
+	h.Set(interval)
+	return h
+}
+
+// Get returns the current interval
+func (h *HeartbeatInterval) Get() time.Duration {
+	return time.Duration(h.interval.Load())
+}
+
+// Set changes the interval
+func (h *HeartbeatInterval) Set(interval time.Duration) {
+	h.interval.Store(int64(interval))
+}
+
+// LivenessNotifier is informed when the CSMS considers a charge station to have
+// gone offline or to have come back online.
+type LivenessNotifier interface {
+	ChargeStationOffline(ctx context.Context, liveness *store.ChargeStationLiveness) error
+	ChargeStationOnline(ctx context.Context, liveness *store.ChargeStationLiveness) error
+}
+
+// LogLivenessNotifier records liveness changes in the log so that they can be
+// picked up by log based monitoring.
+type LogLivenessNotifier struct{}
+
+func (LogLivenessNotifier) ChargeStationOffline(_ context.Context, liveness *store.ChargeStationLiveness) error {
+	slog.Warn("charge station offline",
+		slog.String("chargeStationId", liveness.ChargeStationId),
+		slog.Time("lastSeen", liveness.LastSeen))
+	return nil
+}
+
+func (LogLivenessNotifier) ChargeStationOnline(_ context.Context, liveness *store.ChargeStationLiveness) error {
+	slog.Info("charge station online",
+		slog.String("chargeStationId", liveness.ChargeStationId),
+		slog.Time("lastSeen", liveness.LastSeen))
+	return nil
+}
+
+// LivenessService tracks when each charge station was last heard from. A charge
+// station is marked offline once it has not sent any message for MissedHeartbeats
+// heartbeat intervals and is marked online again when the next message arrives.
+type LivenessService struct {
+	Clock             clock.PassiveClock
+	Store             store.ChargeStationLivenessStore
+	Notifier          LivenessNotifier
+	HeartbeatInterval *HeartbeatInterval
+	MissedHeartbeats  int
+}
+
+// MessageReceived records that the charge station has sent a message with the
+// given action.
+func (l *LivenessService) MessageReceived(ctx context.Context, chargeStationId string, action string) error {
+	liveness, err := l.Store.LookupChargeStationLiveness(ctx, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("lookup charge station liveness: %w", err)
+	}
+	if liveness == nil {
+		liveness = &store.ChargeStationLiveness{
+			ChargeStationId: chargeStationId,
+		}
+	}
+
+	wasOffline := liveness.Offline
+	liveness.LastSeen = l.Clock.Now()
+	if action == "Heartbeat" {
+		liveness.LastHeartbeat = liveness.LastSeen
+	}
+	liveness.Offline = false
+
+	err = l.Store.SetChargeStationLiveness(ctx, chargeStationId, liveness)
+	if err != nil {
+		return fmt.Errorf("set charge station liveness: %w", err)
+	}
+
+	if wasOffline {
+		trace.SpanFromContext(ctx).AddEvent("charge station online")
+		if l.Notifier != nil {
+			return l.Notifier.ChargeStationOnline(ctx, liveness)
+		}
+	}
+	return nil
+}
+
+// CheckOffline marks the charge station as offline if it has missed too many
+// heartbeat intervals. It returns true if the charge station has just gone offline.
+func (l *LivenessService) CheckOffline(ctx context.Context, liveness *store.ChargeStationLiveness) (bool, error) {
+	if liveness.Offline || l.Clock.Now().Before(liveness.LastSeen.Add(l.offlineAfter())) {
+		return false, nil
+	}
+
+	liveness.Offline = true
+	err := l.Store.SetChargeStationLiveness(ctx, liveness.ChargeStationId, liveness)
+	if err != nil {
+		return false, fmt.Errorf("set charge station liveness: %w", err)
+	}
+
+	trace.SpanFromContext(ctx).AddEvent("charge station offline",
+		trace.WithAttributes(attribute.String("chargeStationId", liveness.ChargeStationId)))
+	if l.Notifier != nil {
+		err = l.Notifier.ChargeStationOffline(ctx, liveness)
+		if err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+func (l *LivenessService) offlineAfter() time.Duration {
+	missed := l.MissedHeartbeats
+	if missed <= 0 {
+		missed = 3
+	}
+	return time.Duration(missed) * l.HeartbeatInterval.Get()
+}
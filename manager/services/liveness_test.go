@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type recordingLivenessNotifier struct {
+	offline []string
+	online  []string
+}
+
+func (r *recordingLivenessNotifier) ChargeStationOffline(_ context.Context, liveness *store.ChargeStationLiveness) error {
+	r.offline = append(r.offline, liveness.ChargeStationId)
+	return nil
+}
+
+func (r *recordingLivenessNotifier) ChargeStationOnline(_ context.Context, liveness *store.ChargeStationLiveness) error {
+	r.online = append(r.online, liveness.ChargeStationId)
+	return nil
+}
+
+func TestLivenessServiceRecordsMessages(t *testing.T) {
+	now := time.Now().UTC()
+	fakeClock := clockTest.NewFakePassiveClock(now)
+	engine := inmemory.NewStore(clock.RealClock{})
+	notifier := &recordingLivenessNotifier{}
+
+	liveness := &services.LivenessService{
+		Clock:             fakeClock,
+		Store:             engine,
+		Notifier:          notifier,
+		HeartbeatInterval: services.NewHeartbeatInterval(time.Minute),
+	}
+
+	err := liveness.MessageReceived(context.Background(), "cs001", "Heartbeat")
+	require.NoError(t, err)
+
+	fakeClock.SetTime(now.Add(10 * time.Second))
+	err = liveness.MessageReceived(context.Background(), "cs001", "StatusNotification")
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLiveness(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationLiveness{
+		ChargeStationId: "cs001",
+		LastSeen:        now.Add(10 * time.Second),
+		LastHeartbeat:   now,
+	}, got)
+	assert.Empty(t, notifier.online)
+}
+
+func TestLivenessServiceMarksChargeStationOffline(t *testing.T) {
+	now := time.Now().UTC()
+	fakeClock := clockTest.NewFakePassiveClock(now)
+	engine := inmemory.NewStore(clock.RealClock{})
+	notifier := &recordingLivenessNotifier{}
+
+	liveness := &services.LivenessService{
+		Clock:             fakeClock,
+		Store:             engine,
+		Notifier:          notifier,
+		HeartbeatInterval: services.NewHeartbeatInterval(time.Minute),
+		MissedHeartbeats:  2,
+	}
+
+	err := liveness.MessageReceived(context.Background(), "cs001", "Heartbeat")
+	require.NoError(t, err)
+
+	fakeClock.SetTime(now.Add(90 * time.Second))
+	cs, err := engine.LookupChargeStationLiveness(context.Background(), "cs001")
+	require.NoError(t, err)
+	offline, err := liveness.CheckOffline(context.Background(), cs)
+	require.NoError(t, err)
+	assert.False(t, offline)
+
+	fakeClock.SetTime(now.Add(2 * time.Minute))
+	offline, err = liveness.CheckOffline(context.Background(), cs)
+	require.NoError(t, err)
+	assert.True(t, offline)
+
+	// already offline
+	offline, err = liveness.CheckOffline(context.Background(), cs)
+	require.NoError(t, err)
+	assert.False(t, offline)
+
+	cs, err = engine.LookupChargeStationLiveness(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.True(t, cs.Offline)
+	assert.Equal(t, []string{"cs001"}, notifier.offline)
+
+	err = liveness.MessageReceived(context.Background(), "cs001", "BootNotification")
+	require.NoError(t, err)
+
+	cs, err = engine.LookupChargeStationLiveness(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.False(t, cs.Offline)
+	assert.Equal(t, []string{"cs001"}, notifier.online)
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+	"math"
+	"sort"
+	"time"
+)
+
+// ChargingNeeds describes the energy requested by an EV, as reported in an OCPP 2.0.1
+// NotifyEVChargingNeeds message.
+type ChargingNeeds struct {
+	EnergyAmount  float64   // the energy requested in Wh: zero if not known
+	MaxPower      float64   // the maximum power that the EV can accept in W
+	DepartureTime time.Time // the estimated departure time: zero if not known
+}
+
+// ChargingSchedulePeriod is a period of a charging schedule that starts at Start and
+// lasts until the start of the next period (or the end of the schedule).
+type ChargingSchedulePeriod struct {
+	Start time.Time
+	Limit float64 // the power limit in W
+}
+
+// SchedulingStrategy determines how the energy needed by an EV is delivered over
+// the time that it is connected to the EVSE.
+type SchedulingStrategy interface {
+	Schedule(ctx context.Context, chargeStationId string, evseId int, needs ChargingNeeds) ([]ChargingSchedulePeriod, error)
+}
+
+// AsSoonAsPossibleSchedulingStrategy charges the EV at its maximum power.
+type AsSoonAsPossibleSchedulingStrategy struct {
+	Clock clock.PassiveClock
+}
+
+func (a AsSoonAsPossibleSchedulingStrategy) Schedule(_ context.Context, _ string, _ int, needs ChargingNeeds) ([]ChargingSchedulePeriod, error) {
+	return []ChargingSchedulePeriod{{Start: a.Clock.Now(), Limit: needs.MaxPower}}, nil
+}
+
+// CarbonIntensityPeriod is the forecast carbon intensity of grid electricity for the
+// period [Start, End). Any measure can be used, e.g. gCO2/kWh or the percentage of
+// non-renewable generation, provided that a lower value is better.
+type CarbonIntensityPeriod struct {
+	Start     time.Time
+	End       time.Time
+	Intensity float64
+}
+
+// CarbonIntensitySignal provides a forecast of the carbon intensity of the electricity
+// supplied to a charge station.
+type CarbonIntensitySignal interface {
+	Forecast(ctx context.Context, chargeStationId string, from, to time.Time) ([]CarbonIntensityPeriod, error)
+}
+
+// DailyCarbonIntensitySignal is a CarbonIntensitySignal that repeats the same hourly
+// carbon intensity every day.
+type DailyCarbonIntensitySignal struct {
+	HourlyIntensity [24]float64
+	Location        *time.Location // defaults to UTC
+}
+
+func (d DailyCarbonIntensitySignal) Forecast(_ context.Context, _ string, from, to time.Time) ([]CarbonIntensityPeriod, error) {
+	loc := d.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var periods []CarbonIntensityPeriod
+	local := from.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, loc)
+	for start.Before(to) {
+		end := start.Add(time.Hour)
+		periods = append(periods, CarbonIntensityPeriod{
+			Start:     start,
+			End:       end,
+			Intensity: d.HourlyIntensity[start.Hour()],
+		})
+		start = end
+	}
+	return periods, nil
+}
+
+// LowCarbonSchedulingStrategy delivers the energy needed by an EV during the periods
+// with the lowest forecast carbon intensity before the EV departs. The EV is charged
+// as soon as possible if the departure time or energy amount is not known, if the
+// energy cannot be delivered in time or if the EVSE has been reserved for another
+// driver (so that the EVSE is released as early as possible).
+type LowCarbonSchedulingStrategy struct {
+	Clock        clock.PassiveClock
+	Signal       CarbonIntensitySignal
+	Reservations store.ReservationStore
+}
+
+func (l LowCarbonSchedulingStrategy) Schedule(ctx context.Context, chargeStationId string, evseId int, needs ChargingNeeds) ([]ChargingSchedulePeriod, error) {
+	now := l.Clock.Now()
+	asap := []ChargingSchedulePeriod{{Start: now, Limit: needs.MaxPower}}
+
+	if needs.EnergyAmount <= 0 || needs.MaxPower <= 0 || !needs.DepartureTime.After(now) {
+		return asap, nil
+	}
+	if needs.EnergyAmount >= needs.MaxPower*needs.DepartureTime.Sub(now).Hours() {
+		return asap, nil
+	}
+
+	if l.Reservations != nil {
+		reserved, err := l.evseReserved(ctx, chargeStationId, evseId)
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return asap, nil
+		}
+	}
+
+	forecast, err := l.Signal.Forecast(ctx, chargeStationId, now, needs.DepartureTime)
+	if err != nil {
+		return nil, fmt.Errorf("forecasting carbon intensity: %w", err)
+	}
+
+	windows := coverWindow(forecast, now, needs.DepartureTime)
+
+	// allocate the energy to the cleanest windows first, preferring earlier windows
+	// when the intensity is the same
+	order := make([]int, len(windows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return windows[order[i]].Intensity < windows[order[j]].Intensity
+	})
+
+	limits := make([]float64, len(windows))
+	remaining := needs.EnergyAmount
+	for _, i := range order {
+		if remaining <= 0 {
+			break
+		}
+		hours := windows[i].End.Sub(windows[i].Start).Hours()
+		energy := math.Min(remaining, needs.MaxPower*hours)
+		limits[i] = energy / hours
+		remaining -= energy
+	}
+
+	var schedule []ChargingSchedulePeriod
+	for i, window := range windows {
+		if len(schedule) > 0 && schedule[len(schedule)-1].Limit == limits[i] {
+			continue
+		}
+		schedule = append(schedule, ChargingSchedulePeriod{Start: window.Start, Limit: limits[i]})
+	}
+	return schedule, nil
+}
+
+// coverWindow clips the forecast to [from, to) and fills any gaps with periods of
+// unknown, and therefore least preferred, intensity.
+func coverWindow(forecast []CarbonIntensityPeriod, from, to time.Time) []CarbonIntensityPeriod {
+	sort.Slice(forecast, func(i, j int) bool {
+		return forecast[i].Start.Before(forecast[j].Start)
+	})
+
+	var windows []CarbonIntensityPeriod
+	next := from
+	for _, period := range forecast {
+		if !period.End.After(next) || !period.Start.Before(to) {
+			continue
+		}
+		if period.Start.After(next) {
+			windows = append(windows, CarbonIntensityPeriod{Start: next, End: period.Start, Intensity: math.Inf(1)})
+			next = period.Start
+		}
+		end := period.End
+		if end.After(to) {
+			end = to
+		}
+		windows = append(windows, CarbonIntensityPeriod{Start: next, End: end, Intensity: period.Intensity})
+		next = end
+	}
+	if next.Before(to) {
+		windows = append(windows, CarbonIntensityPeriod{Start: next, End: to, Intensity: math.Inf(1)})
+	}
+	return windows
+}
+
+func (l LowCarbonSchedulingStrategy) evseReserved(ctx context.Context, chargeStationId string, evseId int) (bool, error) {
+	now := l.Clock.Now()
+	previousReservationId := 0
+	for {
+		reservations, err := l.Reservations.ListReservations(ctx, 50, previousReservationId)
+		if err != nil {
+			return false, fmt.Errorf("listing reservations: %w", err)
+		}
+		if len(reservations) == 0 {
+			return false, nil
+		}
+		for _, reservation := range reservations {
+			if reservation.ChargeStationId == chargeStationId &&
+				(reservation.EvseId == nil || *reservation.EvseId == evseId) &&
+				reservation.Active(now) {
+				return true, nil
+			}
+		}
+		previousReservationId = reservations[len(reservations)-1].ReservationId
+	}
+}
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestAsSoonAsPossibleSchedulingStrategy(t *testing.T) {
+	now := time.Date(2023, 6, 1, 18, 0, 0, 0, time.UTC)
+	strategy := services.AsSoonAsPossibleSchedulingStrategy{Clock: clockTest.NewFakePassiveClock(now)}
+
+	schedule, err := strategy.Schedule(context.Background(), "cs001", 1, services.ChargingNeeds{
+		EnergyAmount:  20000,
+		MaxPower:      7400,
+		DepartureTime: now.Add(12 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []services.ChargingSchedulePeriod{{Start: now, Limit: 7400}}, schedule)
+}
+
+func TestDailyCarbonIntensitySignal(t *testing.T) {
+	var hourly [24]float64
+	for i := range hourly {
+		hourly[i] = float64(i)
+	}
+	signal := services.DailyCarbonIntensitySignal{HourlyIntensity: hourly}
+
+	from := time.Date(2023, 6, 1, 22, 30, 0, 0, time.UTC)
+	forecast, err := signal.Forecast(context.Background(), "cs001", from, from.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, []services.CarbonIntensityPeriod{
+		{Start: time.Date(2023, 6, 1, 22, 0, 0, 0, time.UTC), End: time.Date(2023, 6, 1, 23, 0, 0, 0, time.UTC), Intensity: 22},
+		{Start: time.Date(2023, 6, 1, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC), Intensity: 23},
+		{Start: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2023, 6, 2, 1, 0, 0, 0, time.UTC), Intensity: 0},
+	}, forecast)
+}
+
+func overnightSignal() services.CarbonIntensitySignal {
+	var hourly [24]float64
+	for i := range hourly {
+		hourly[i] = 300
+	}
+	// windy night
+	hourly[1] = 100
+	hourly[2] = 50
+	hourly[3] = 100
+	return services.DailyCarbonIntensitySignal{HourlyIntensity: hourly}
+}
+
+func TestLowCarbonSchedulingStrategyPrefersLowCarbonPeriods(t *testing.T) {
+	now := time.Date(2023, 6, 1, 22, 0, 0, 0, time.UTC)
+	strategy := services.LowCarbonSchedulingStrategy{
+		Clock:        clockTest.NewFakePassiveClock(now),
+		Signal:       overnightSignal(),
+		Reservations: inmemory.NewStore(clock.RealClock{}),
+	}
+
+	schedule, err := strategy.Schedule(context.Background(), "cs001", 1, services.ChargingNeeds{
+		EnergyAmount:  15000,
+		MaxPower:      10000,
+		DepartureTime: now.Add(8 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []services.ChargingSchedulePeriod{
+		{Start: now, Limit: 0},
+		{Start: time.Date(2023, 6, 2, 1, 0, 0, 0, time.UTC), Limit: 5000},
+		{Start: time.Date(2023, 6, 2, 2, 0, 0, 0, time.UTC), Limit: 10000},
+		{Start: time.Date(2023, 6, 2, 3, 0, 0, 0, time.UTC), Limit: 0},
+	}, schedule)
+}
+
+func TestLowCarbonSchedulingStrategyHonoursDepartureTime(t *testing.T) {
+	now := time.Date(2023, 6, 1, 22, 0, 0, 0, time.UTC)
+	strategy := services.LowCarbonSchedulingStrategy{
+		Clock:  clockTest.NewFakePassiveClock(now),
+		Signal: overnightSignal(),
+	}
+
+	// the EV departs before the cleanest period
+	schedule, err := strategy.Schedule(context.Background(), "cs001", 1, services.ChargingNeeds{
+		EnergyAmount:  5000,
+		MaxPower:      10000,
+		DepartureTime: time.Date(2023, 6, 2, 1, 30, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []services.ChargingSchedulePeriod{
+		{Start: now, Limit: 0},
+		{Start: time.Date(2023, 6, 2, 1, 0, 0, 0, time.UTC), Limit: 10000},
+	}, schedule)
+}
+
+func TestLowCarbonSchedulingStrategyChargesAsSoonAsPossible(t *testing.T) {
+	now := time.Date(2023, 6, 1, 22, 0, 0, 0, time.UTC)
+	evseId := 1
+
+	tests := map[string]struct {
+		needs        services.ChargingNeeds
+		reservations []*store.Reservation
+	}{
+		"no departure time": {
+			needs: services.ChargingNeeds{EnergyAmount: 15000, MaxPower: 10000},
+		},
+		"no energy amount": {
+			needs: services.ChargingNeeds{MaxPower: 10000, DepartureTime: now.Add(8 * time.Hour)},
+		},
+		"insufficient time": {
+			needs: services.ChargingNeeds{EnergyAmount: 50000, MaxPower: 10000, DepartureTime: now.Add(4 * time.Hour)},
+		},
+		"reserved evse": {
+			needs: services.ChargingNeeds{EnergyAmount: 15000, MaxPower: 10000, DepartureTime: now.Add(8 * time.Hour)},
+			reservations: []*store.Reservation{
+				{
+					ReservationId:   1,
+					ChargeStationId: "cs001",
+					EvseId:          &evseId,
+					ExpiryDate:      now.Add(6 * time.Hour),
+					Status:          store.ReservationStatusAccepted,
+				},
+			},
+		},
+		"reserved charge station": {
+			needs: services.ChargingNeeds{EnergyAmount: 15000, MaxPower: 10000, DepartureTime: now.Add(8 * time.Hour)},
+			reservations: []*store.Reservation{
+				{
+					ReservationId:   1,
+					ChargeStationId: "cs001",
+					ExpiryDate:      now.Add(6 * time.Hour),
+					Status:          store.ReservationStatusAccepted,
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			engine := inmemory.NewStore(clock.RealClock{})
+			for _, reservation := range tc.reservations {
+				require.NoError(t, engine.SetReservation(context.Background(), reservation))
+			}
+
+			strategy := services.LowCarbonSchedulingStrategy{
+				Clock:        clockTest.NewFakePassiveClock(now),
+				Signal:       overnightSignal(),
+				Reservations: engine,
+			}
+
+			schedule, err := strategy.Schedule(context.Background(), "cs001", evseId, tc.needs)
+			require.NoError(t, err)
+
+			assert.Equal(t, []services.ChargingSchedulePeriod{{Start: now, Limit: 10000}}, schedule)
+		})
+	}
+}
+
+func TestLowCarbonSchedulingStrategyIgnoresOtherReservations(t *testing.T) {
+	now := time.Date(2023, 6, 1, 22, 0, 0, 0, time.UTC)
+	otherEvseId := 2
+	engine := inmemory.NewStore(clock.RealClock{})
+	reservations := []*store.Reservation{
+		{ReservationId: 1, ChargeStationId: "cs002", ExpiryDate: now.Add(time.Hour), Status: store.ReservationStatusAccepted},
+		{ReservationId: 2, ChargeStationId: "cs001", EvseId: &otherEvseId, ExpiryDate: now.Add(time.Hour), Status: store.ReservationStatusAccepted},
+		{ReservationId: 3, ChargeStationId: "cs001", ExpiryDate: now.Add(-time.Hour), Status: store.ReservationStatusAccepted},
+		{ReservationId: 4, ChargeStationId: "cs001", ExpiryDate: now.Add(time.Hour), Status: store.ReservationStatusCancelled},
+	}
+	for _, reservation := range reservations {
+		require.NoError(t, engine.SetReservation(context.Background(), reservation))
+	}
+
+	strategy := services.LowCarbonSchedulingStrategy{
+		Clock:        clockTest.NewFakePassiveClock(now),
+		Signal:       overnightSignal(),
+		Reservations: engine,
+	}
+
+	schedule, err := strategy.Schedule(context.Background(), "cs001", 1, services.ChargingNeeds{
+		EnergyAmount:  10000,
+		MaxPower:      10000,
+		DepartureTime: now.Add(8 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []services.ChargingSchedulePeriod{
+		{Start: now, Limit: 0},
+		{Start: time.Date(2023, 6, 2, 2, 0, 0, 0, time.UTC), Limit: 10000},
+		{Start: time.Date(2023, 6, 2, 3, 0, 0, 0, time.UTC), Limit: 0},
+	}, schedule)
+}
@@ -6,9 +6,13 @@ import (
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
 )
 
 type LocalSource interface {
@@ -39,6 +43,19 @@ func (f FileSource) GetData(_ context.Context) (string, error) {
 	return string(data), nil
 }
 
+// EnvSource reads the data from an environment variable each time it is requested
+type EnvSource struct {
+	Variable string
+}
+
+func (e EnvSource) GetData(_ context.Context) (string, error) {
+	data, ok := os.LookupEnv(e.Variable)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", e.Variable)
+	}
+	return data, nil
+}
+
 type GoogleSecretSource struct {
 	SecretName string
 }
@@ -62,3 +79,62 @@ func (g GoogleSecretSource) GetData(ctx context.Context) (string, error) {
 
 	return string(resp.GetPayload().GetData()), nil
 }
+
+// VaultSecretSource reads a key of a secret from a HashiCorp Vault KV secrets engine.
+// The Path is the API path of the secret without the /v1 prefix, e.g.
+// "secret/data/csms/opcp" for a KV version 2 engine mounted at "secret". Vault is
+// authenticated with the token held in the TokenEnvVar environment variable, which
+// is read on each request so that it can be rotated.
+type VaultSecretSource struct {
+	Address     string
+	Path        string
+	Key         string
+	TokenEnvVar string
+	HttpClient  *http.Client
+}
+
+func (v VaultSecretSource) GetData(ctx context.Context) (string, error) {
+	secretUrl, err := url.JoinPath(v.Address, "v1", v.Path)
+	if err != nil {
+		return "", fmt.Errorf("vault secret url: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretUrl, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating vault request: %v", err)
+	}
+	req.Header.Set("X-Vault-Token", os.Getenv(v.TokenEnvVar))
+
+	httpClient := v.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("reading vault secret %s: %v", v.Path, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("reading vault secret %s: status %s", v.Path, resp.Status)
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&secret)
+	if err != nil {
+		return "", fmt.Errorf("decoding vault secret %s: %v", v.Path, err)
+	}
+
+	// a KV version 2 engine nests the secret's keys inside a data object alongside its metadata
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]any); ok && strings.Contains(v.Path, "/data/") {
+		data = nested
+	}
+	value, ok := data[v.Key].(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no string key %s", v.Path, v.Key)
+	}
+	return value, nil
+}
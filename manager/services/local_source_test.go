@@ -6,6 +6,8 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 )
@@ -30,6 +32,107 @@ func TestFileSource(t *testing.T) {
 	assert.Contains(t, data, "-----BEGIN CERTIFICATE-----")
 }
 
+func TestEnvSource(t *testing.T) {
+	t.Setenv("TEST_ENV_SOURCE", "hello world")
+	source := EnvSource{
+		Variable: "TEST_ENV_SOURCE",
+	}
+
+	data, err := source.GetData(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", data)
+}
+
+func TestEnvSourceWhenVariableIsNotSet(t *testing.T) {
+	source := EnvSource{
+		Variable: "TEST_ENV_SOURCE_NOT_SET",
+	}
+
+	_, err := source.GetData(context.TODO())
+	assert.ErrorContains(t, err, "TEST_ENV_SOURCE_NOT_SET is not set")
+}
+
+func newVaultServer(t *testing.T, path, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVaultSecretSourceWithKVVersion2(t *testing.T) {
+	t.Setenv("TEST_VAULT_TOKEN", "vault-token")
+	server := newVaultServer(t, "/v1/secret/data/csms/opcp",
+		`{"data":{"data":{"token":"opcp-token"},"metadata":{"version":3}}}`)
+
+	source := VaultSecretSource{
+		Address:     server.URL,
+		Path:        "secret/data/csms/opcp",
+		Key:         "token",
+		TokenEnvVar: "TEST_VAULT_TOKEN",
+		HttpClient:  server.Client(),
+	}
+
+	data, err := source.GetData(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "opcp-token", data)
+}
+
+func TestVaultSecretSourceWithKVVersion1(t *testing.T) {
+	t.Setenv("TEST_VAULT_TOKEN", "vault-token")
+	server := newVaultServer(t, "/v1/kv/csms/opcp", `{"data":{"token":"opcp-token"}}`)
+
+	source := VaultSecretSource{
+		Address:     server.URL,
+		Path:        "kv/csms/opcp",
+		Key:         "token",
+		TokenEnvVar: "TEST_VAULT_TOKEN",
+	}
+
+	data, err := source.GetData(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "opcp-token", data)
+}
+
+func TestVaultSecretSourceWithMissingKey(t *testing.T) {
+	t.Setenv("TEST_VAULT_TOKEN", "vault-token")
+	server := newVaultServer(t, "/v1/kv/csms/opcp", `{"data":{"token":"opcp-token"}}`)
+
+	source := VaultSecretSource{
+		Address:     server.URL,
+		Path:        "kv/csms/opcp",
+		Key:         "password",
+		TokenEnvVar: "TEST_VAULT_TOKEN",
+	}
+
+	_, err := source.GetData(context.TODO())
+	assert.ErrorContains(t, err, "has no string key password")
+}
+
+func TestVaultSecretSourceWhenForbidden(t *testing.T) {
+	t.Setenv("TEST_VAULT_TOKEN", "wrong-token")
+	server := newVaultServer(t, "/v1/kv/csms/opcp", `{"data":{"token":"opcp-token"}}`)
+
+	source := VaultSecretSource{
+		Address:     server.URL,
+		Path:        "kv/csms/opcp",
+		Key:         "token",
+		TokenEnvVar: "TEST_VAULT_TOKEN",
+	}
+
+	_, err := source.GetData(context.TODO())
+	assert.ErrorContains(t, err, "403 Forbidden")
+}
+
 func TestGoogleCloudSecretSource(t *testing.T) {
 	secretName := os.Getenv("TEST_GOOGLE_CLOUD_SECRET_NAME")
 	if secretName == "" {
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// OcpiTariffService calculates the cost of a transaction from an OCPI tariff held in
+// the store. For each type of price component the first tariff element whose
+// restrictions are met is used, as described by the OCPI 2.2 tariffs module.
+//
+// Restrictions are evaluated once for the whole transaction, using the local time at
+// which it started and its totals, rather than splitting the transaction into
+// separate charging periods.
+type OcpiTariffService struct {
+	Store    store.TariffStore
+	TariffId string
+	// Location is the time zone used for time of day, date and day of week restrictions:
+	// UTC is used if it is not set
+	Location *time.Location
+}
+
+// tariffedTransaction is a summary of a transaction used to price it
+type tariffedTransaction struct {
+	start    time.Time
+	end      time.Time
+	energyWh float64
+	charging time.Duration
+	parking  time.Duration
+}
+
+func (o OcpiTariffService) CalculateCost(transaction *store.Transaction) (float64, error) {
+	if transaction == nil {
+		return 0, errors.New("no transaction provided")
+	}
+
+	tariff, err := o.Store.LookupTariff(context.Background(), o.TariffId)
+	if err != nil {
+		return 0, fmt.Errorf("lookup tariff %s: %w", o.TariffId, err)
+	}
+	if tariff == nil {
+		return 0, fmt.Errorf("unknown tariff: %s", o.TariffId)
+	}
+
+	tx, err := summariseTransaction(transaction)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tariffActiveAt(tariff, tx.start); err != nil {
+		return 0, err
+	}
+
+	location := o.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	var cost float64
+	for _, componentType := range []string{"FLAT", "ENERGY", "TIME", "PARKING_TIME"} {
+		component := findPriceComponent(tariff, componentType, tx.start, tx, location)
+		if component == nil {
+			continue
+		}
+		switch componentType {
+		case "FLAT":
+			cost += component.Price
+		case "ENERGY":
+			cost += component.Price * roundUpToStep(tx.energyWh, component.StepSize) / 1000
+		case "TIME":
+			cost += component.Price * roundUpToStep(tx.charging.Seconds(), component.StepSize) / 3600
+		case "PARKING_TIME":
+			cost += component.Price * roundUpToStep(tx.parking.Seconds(), component.StepSize) / 3600
+		}
+	}
+
+	return cost, nil
+}
+
+func summariseTransaction(transaction *store.Transaction) (tariffedTransaction, error) {
+	var tx tariffedTransaction
+
+	var timestamps []string
+	for _, meterValue := range transaction.MeterValues {
+		timestamps = append(timestamps, meterValue.Timestamp)
+	}
+	for _, chargingState := range transaction.ChargingStates {
+		timestamps = append(timestamps, chargingState.Timestamp)
+	}
+	for _, timestamp := range timestamps {
+		// timestamps that cannot be parsed are left out, as they are when the charging
+		// states are timed
+		ts, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			continue
+		}
+		if tx.start.IsZero() || ts.Before(tx.start) {
+			tx.start = ts
+		}
+		if tx.end.IsZero() || ts.After(tx.end) {
+			tx.end = ts
+		}
+	}
+	if tx.start.IsZero() {
+		return tx, errors.New("no meter values or charging states found in transaction")
+	}
+
+	tx.energyWh, _ = findMostRecentOutletEnergyReading(transaction)
+
+	charging, idle := chargingStateDurations(transaction)
+	if len(transaction.ChargingStates) < 2 {
+		// without charging state changes the whole transaction is treated as charging
+		charging = tx.end.Sub(tx.start)
+	}
+	tx.charging = charging
+	tx.parking = idle
+
+	return tx, nil
+}
+
+func tariffActiveAt(tariff *store.Tariff, t time.Time) error {
+	if tariff.StartDateTime != "" {
+		start, err := time.Parse(time.RFC3339, tariff.StartDateTime)
+		if err != nil {
+			return fmt.Errorf("parsing tariff start date time %s: %w", tariff.StartDateTime, err)
+		}
+		if t.Before(start) {
+			return fmt.Errorf("tariff %s is not active until %s", tariff.Id, tariff.StartDateTime)
+		}
+	}
+	if tariff.EndDateTime != "" {
+		end, err := time.Parse(time.RFC3339, tariff.EndDateTime)
+		if err != nil {
+			return fmt.Errorf("parsing tariff end date time %s: %w", tariff.EndDateTime, err)
+		}
+		if !t.Before(end) {
+			return fmt.Errorf("tariff %s expired at %s", tariff.Id, tariff.EndDateTime)
+		}
+	}
+	return nil
+}
+
+// findPriceComponent returns the price component of the given type from the first
+// element that has one and whose restrictions are met by the transaction at the given
+// time
+func findPriceComponent(tariff *store.Tariff, componentType string, at time.Time, tx tariffedTransaction, location *time.Location) *store.PriceComponent {
+	for _, element := range tariff.Elements {
+		if !restrictionsMet(element.Restrictions, at, tx, location) {
+			continue
+		}
+		for i, component := range element.PriceComponents {
+			if component.Type == componentType {
+				return &element.PriceComponents[i]
+			}
+		}
+	}
+	return nil
+}
+
+// restrictionsMet reports whether the restrictions are met: time of day, date and day of
+// week restrictions are evaluated at the given time, and energy and duration
+// restrictions against the whole transaction
+func restrictionsMet(restrictions *store.TariffRestrictions, at time.Time, tx tariffedTransaction, location *time.Location) bool {
+	if restrictions == nil {
+		return true
+	}
+
+	local := at.In(location)
+	timeOfDay := local.Format("15:04")
+	if restrictions.StartTime != "" && restrictions.EndTime != "" && restrictions.EndTime < restrictions.StartTime {
+		// the restriction spans midnight
+		if timeOfDay < restrictions.StartTime && timeOfDay >= restrictions.EndTime {
+			return false
+		}
+	} else {
+		if restrictions.StartTime != "" && timeOfDay < restrictions.StartTime {
+			return false
+		}
+		if restrictions.EndTime != "" && timeOfDay >= restrictions.EndTime {
+			return false
+		}
+	}
+
+	date := local.Format("2006-01-02")
+	if restrictions.StartDate != "" && date < restrictions.StartDate {
+		return false
+	}
+	if restrictions.EndDate != "" && date >= restrictions.EndDate {
+		return false
+	}
+
+	if len(restrictions.DayOfWeek) > 0 {
+		found := false
+		for _, day := range restrictions.DayOfWeek {
+			if day == strings.ToUpper(local.Weekday().String()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	kwh := tx.energyWh / 1000
+	if restrictions.MinKwh != nil && kwh < *restrictions.MinKwh {
+		return false
+	}
+	if restrictions.MaxKwh != nil && kwh >= *restrictions.MaxKwh {
+		return false
+	}
+
+	duration := tx.end.Sub(tx.start).Seconds()
+	if restrictions.MinDuration != nil && duration < float64(*restrictions.MinDuration) {
+		return false
+	}
+	if restrictions.MaxDuration != nil && duration >= float64(*restrictions.MaxDuration) {
+		return false
+	}
+
+	return true
+}
+
+// roundUpToStep rounds the value up to a whole number of steps
+func roundUpToStep(value float64, step int) float64 {
+	if step <= 0 {
+		return value
+	}
+	return math.Ceil(value/float64(step)) * float64(step)
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+)
+
+func newOcpiTariffTransaction(start string, wh float64) *store.Transaction {
+	startTime, _ := time.Parse(time.RFC3339, start)
+	return &store.Transaction{
+		MeterValues: []store.MeterValue{
+			{
+				Timestamp: startTime.Add(90 * time.Minute).Format(time.RFC3339),
+				SampledValues: []store.SampledValue{
+					{
+						Context:   makePtr("Transaction.End"),
+						Measurand: makePtr("Energy.Active.Import.Register"),
+						Location:  makePtr("Outlet"),
+						Value:     wh,
+					},
+				},
+			},
+		},
+		ChargingStates: []store.ChargingStateChange{
+			{State: "Charging", Timestamp: start},
+			{State: "SuspendedEV", Timestamp: startTime.Add(time.Hour).Format(time.RFC3339)},
+			{State: "Idle", Timestamp: startTime.Add(90 * time.Minute).Format(time.RFC3339)},
+		},
+	}
+}
+
+func newOcpiTariffService(t *testing.T, tariff *store.Tariff) services.OcpiTariffService {
+	engine := inmemory.NewStore(clock.RealClock{})
+	require.NoError(t, engine.SetTariff(context.Background(), tariff))
+	return services.OcpiTariffService{
+		Store:    engine,
+		TariffId: tariff.Id,
+	}
+}
+
+func TestOcpiTariffServiceCalculatesCostOfEachDimension(t *testing.T) {
+	tariffService := newOcpiTariffService(t, &store.Tariff{
+		Id:       "tariff001",
+		Currency: "EUR",
+		Elements: []store.TariffElement{
+			{
+				PriceComponents: []store.PriceComponent{
+					{Type: "FLAT", Price: 1},
+					{Type: "ENERGY", Price: 0.5, StepSize: 1000},
+					{Type: "TIME", Price: 2, StepSize: 60},
+					{Type: "PARKING_TIME", Price: 3, StepSize: 900},
+				},
+			},
+		},
+	})
+
+	cost, err := tariffService.CalculateCost(newOcpiTariffTransaction("2023-06-05T12:00:00Z", 10500))
+	require.NoError(t, err)
+	// 1 flat + 11 kWh (rounded up) + 1 hour charging + 0.5 hours parking
+	assert.InDelta(t, 1+5.5+2+1.5, cost, 0.0001)
+}
+
+func TestOcpiTariffServiceUsesFirstElementWhoseRestrictionsAreMet(t *testing.T) {
+	tariffService := newOcpiTariffService(t, &store.Tariff{
+		Id:       "tariff001",
+		Currency: "EUR",
+		Elements: []store.TariffElement{
+			{
+				PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.2, StepSize: 1}},
+				Restrictions: &store.TariffRestrictions{
+					StartTime: "22:00",
+					EndTime:   "06:00",
+				},
+			},
+			{
+				PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.4, StepSize: 1}},
+				Restrictions: &store.TariffRestrictions{
+					DayOfWeek: []string{"SATURDAY", "SUNDAY"},
+				},
+			},
+			{
+				PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.5, StepSize: 1}},
+			},
+		},
+	})
+
+	tests := map[string]struct {
+		start string
+		want  float64
+	}{
+		"overnight":       {start: "2023-06-05T23:00:00Z", want: 2},
+		"early morning":   {start: "2023-06-10T05:30:00Z", want: 2},
+		"weekend daytime": {start: "2023-06-10T12:00:00Z", want: 4},
+		"weekday daytime": {start: "2023-06-05T12:00:00Z", want: 5},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cost, err := tariffService.CalculateCost(newOcpiTariffTransaction(tc.start, 10000))
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, cost, 0.0001)
+		})
+	}
+}
+
+func TestOcpiTariffServiceAppliesEnergyRestrictions(t *testing.T) {
+	minKwh := 20.0
+	tariffService := newOcpiTariffService(t, &store.Tariff{
+		Id:       "tariff001",
+		Currency: "EUR",
+		Elements: []store.TariffElement{
+			{
+				PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.3, StepSize: 1}},
+				Restrictions:    &store.TariffRestrictions{MinKwh: &minKwh},
+			},
+			{
+				PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.5, StepSize: 1}},
+			},
+		},
+	})
+
+	cost, err := tariffService.CalculateCost(newOcpiTariffTransaction("2023-06-05T12:00:00Z", 10000))
+	require.NoError(t, err)
+	assert.InDelta(t, 5, cost, 0.0001)
+
+	cost, err = tariffService.CalculateCost(newOcpiTariffTransaction("2023-06-05T12:00:00Z", 30000))
+	require.NoError(t, err)
+	assert.InDelta(t, 9, cost, 0.0001)
+}
+
+func TestOcpiTariffServiceErrorsWhenTariffNotActive(t *testing.T) {
+	tariffService := newOcpiTariffService(t, &store.Tariff{
+		Id:            "tariff001",
+		Currency:      "EUR",
+		StartDateTime: "2023-07-01T00:00:00Z",
+	})
+
+	_, err := tariffService.CalculateCost(newOcpiTariffTransaction("2023-06-05T12:00:00Z", 10000))
+	assert.ErrorContains(t, err, "tariff tariff001 is not active until 2023-07-01T00:00:00Z")
+}
+
+func TestOcpiTariffServiceErrorsWithUnknownTariff(t *testing.T) {
+	tariffService := services.OcpiTariffService{
+		Store:    inmemory.NewStore(clock.RealClock{}),
+		TariffId: "unknown",
+	}
+
+	_, err := tariffService.CalculateCost(newOcpiTariffTransaction("2023-06-05T12:00:00Z", 10000))
+	assert.ErrorContains(t, err, "unknown tariff: unknown")
+}
+
+func TestOcpiTariffServiceErrorsWithNilTransaction(t *testing.T) {
+	tariffService := services.OcpiTariffService{}
+	_, err := tariffService.CalculateCost(nil)
+	assert.ErrorContains(t, err, "no transaction provided")
+}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/templates"
+	"golang.org/x/exp/slog"
+	"html/template"
+	"io"
+	"math"
+	"time"
+)
+
+// ErrTransactionNotEnded is returned when a receipt is requested for a transaction that
+// the charge station has not ended
+var ErrTransactionNotEnded = errs.New(errs.ErrConflict, "transaction has not ended")
+
+// DefaultReceiptTemplate renders a receipt as an HTML page
+var DefaultReceiptTemplate = template.Must(template.New("receipt").Parse(templates.Receipt))
+
+type ReceiptLineType string
+
+var (
+	ReceiptLineTypeFlat        ReceiptLineType = "FLAT"
+	ReceiptLineTypeEnergy      ReceiptLineType = "ENERGY"
+	ReceiptLineTypeTime        ReceiptLineType = "TIME"
+	ReceiptLineTypeParkingTime ReceiptLineType = "PARKING_TIME"
+	// ReceiptLineTypeTotal charges for the whole session when the tariff service does not
+	// itemise the cost
+	ReceiptLineTypeTotal ReceiptLineType = "TOTAL"
+)
+
+// ReceiptLine is a charge on a receipt. Amounts are in the receipt's currency, rounded
+// to two decimal places, and the tax rate is a percentage.
+type ReceiptLine struct {
+	Type        ReceiptLineType
+	Description string
+	// Quantity is the energy in kWh or the time in hours that is charged for: it is
+	// zero, and Unit is empty, for a fee
+	Quantity  float64
+	Unit      string
+	NetAmount float64
+	TaxRate   float64
+	TaxAmount float64
+}
+
+// Receipt is the driver's record of a charging session and what it cost
+type Receipt struct {
+	Seller          string
+	ChargeStationId string
+	TransactionId   string
+	IdToken         string
+	StartTime       time.Time
+	EndTime         time.Time
+	Duration        time.Duration
+	EnergyKwh       float64
+	// TariffId identifies the stored tariff that priced the session: it is empty if the
+	// tariff is not held in the store
+	TariffId string
+	Currency string
+	Lines    []ReceiptLine
+	NetTotal float64
+	TaxTotal float64
+	Total    float64
+}
+
+// ReceiptSender sends a receipt to the driver's email address: html is the receipt
+// rendered with the receipt template
+type ReceiptSender interface {
+	SendReceipt(ctx context.Context, email string, receipt *Receipt, html string) error
+}
+
+// ReceiptService produces the receipts of transactions that have ended. The cost that
+// was stored when the transaction ended is used if there is one, otherwise the
+// transaction is priced by the TariffService. Each line is taxed at the VAT of the
+// tariff's price component, or at TaxRate if the tariff does not give one.
+type ReceiptService struct {
+	Tariffs       store.TariffStore
+	TariffService TariffService
+	// TaxRate is a percentage, e.g. 20 for 20% VAT
+	TaxRate float64
+	// Seller is the name of the operator shown on the receipt
+	Seller string
+	// Template renders the HTML receipt: DefaultReceiptTemplate is used if it is nil
+	Template *template.Template
+	// Sender emails receipts: receipts cannot be emailed if it is nil
+	Sender ReceiptSender
+}
+
+func (r *ReceiptService) NewReceipt(ctx context.Context, transaction *store.Transaction) (*Receipt, error) {
+	if transaction.Status() != store.TransactionStatusEnded {
+		return nil, ErrTransactionNotEnded
+	}
+
+	receipt := &Receipt{
+		Seller:          r.Seller,
+		ChargeStationId: transaction.ChargeStationId,
+		TransactionId:   transaction.TransactionId,
+		IdToken:         transaction.IdToken,
+	}
+	receipt.StartTime, receipt.EndTime = sessionTimes(transaction)
+	receipt.Duration = receipt.EndTime.Sub(receipt.StartTime)
+	if _, wh, ok := transaction.EndOutletEnergy(); ok {
+		receipt.EnergyKwh = wh / 1000
+	}
+
+	cost := transaction.Cost
+	if cost == nil && r.TariffService != nil {
+		var err error
+		cost, err = CalculateCostBreakdown(r.TariffService, transaction)
+		if err != nil {
+			slog.Warn("unable to calculate cost of receipt", slog.String("transactionId", transaction.TransactionId), "err", err)
+		}
+	}
+	if cost == nil {
+		return receipt, nil
+	}
+	receipt.TariffId = cost.TariffId
+	receipt.Currency = cost.Currency
+
+	var tariff *store.Tariff
+	if cost.TariffId != "" && r.Tariffs != nil {
+		var err error
+		tariff, err = r.Tariffs.LookupTariff(ctx, cost.TariffId)
+		if err != nil {
+			return nil, fmt.Errorf("lookup tariff %s: %w", cost.TariffId, err)
+		}
+	}
+
+	var chargingHours, parkingHours float64
+	for _, period := range cost.Periods {
+		chargingHours += period.ChargingHours
+		parkingHours += period.ParkingHours
+	}
+	itemised := []ReceiptLine{
+		{Type: ReceiptLineTypeFlat, Description: "Session fee", NetAmount: cost.SessionFee},
+		{Type: ReceiptLineTypeEnergy, Description: "Energy", Quantity: receipt.EnergyKwh, Unit: "kWh", NetAmount: cost.EnergyCost},
+		{Type: ReceiptLineTypeTime, Description: "Charging time", Quantity: chargingHours, Unit: "h", NetAmount: cost.TimeCost},
+		{Type: ReceiptLineTypeParkingTime, Description: "Parking time", Quantity: parkingHours, Unit: "h", NetAmount: cost.ParkingCost},
+	}
+	for _, line := range itemised {
+		if line.NetAmount != 0 {
+			receipt.Lines = append(receipt.Lines, line)
+		}
+	}
+	if len(receipt.Lines) == 0 && cost.TotalCost != 0 {
+		receipt.Lines = append(receipt.Lines, ReceiptLine{Type: ReceiptLineTypeTotal, Description: "Charging session", NetAmount: cost.TotalCost})
+	}
+
+	for i := range receipt.Lines {
+		line := &receipt.Lines[i]
+		line.NetAmount = roundAmount(line.NetAmount)
+		line.TaxRate = r.taxRate(tariff, line.Type)
+		line.TaxAmount = roundAmount(line.NetAmount * line.TaxRate / 100)
+		receipt.NetTotal += line.NetAmount
+		receipt.TaxTotal += line.TaxAmount
+	}
+	receipt.NetTotal = roundAmount(receipt.NetTotal)
+	receipt.TaxTotal = roundAmount(receipt.TaxTotal)
+	receipt.Total = roundAmount(receipt.NetTotal + receipt.TaxTotal)
+
+	return receipt, nil
+}
+
+// RenderHtml writes the receipt as an HTML page
+func (r *ReceiptService) RenderHtml(w io.Writer, receipt *Receipt) error {
+	tmpl := r.Template
+	if tmpl == nil {
+		tmpl = DefaultReceiptTemplate
+	}
+	return tmpl.Execute(w, receipt)
+}
+
+// Email sends the receipt to the email address: a failure to send it is an
+// errs.ErrDependencyUnavailable
+func (r *ReceiptService) Email(ctx context.Context, email string, receipt *Receipt) error {
+	if r.Sender == nil {
+		return errors.New("no receipt sender")
+	}
+	var html bytes.Buffer
+	if err := r.RenderHtml(&html, receipt); err != nil {
+		return fmt.Errorf("rendering receipt: %w", err)
+	}
+	if err := r.Sender.SendReceipt(ctx, email, receipt, html.String()); err != nil {
+		return errs.Wrap(errs.ErrDependencyUnavailable, err)
+	}
+	return nil
+}
+
+// taxRate returns the VAT of the first of the tariff's price components of the type,
+// or the service's TaxRate if there is none
+func (r *ReceiptService) taxRate(tariff *store.Tariff, lineType ReceiptLineType) float64 {
+	if tariff != nil {
+		for _, element := range tariff.Elements {
+			for _, component := range element.PriceComponents {
+				if component.Type == string(lineType) && component.Vat != nil {
+					return *component.Vat
+				}
+			}
+		}
+	}
+	return r.TaxRate
+}
+
+// sessionTimes returns the earliest and latest times recorded by the transaction's meter
+// values and charging states
+func sessionTimes(transaction *store.Transaction) (start, end time.Time) {
+	var timestamps []string
+	for _, meterValue := range transaction.MeterValues {
+		timestamps = append(timestamps, meterValue.Timestamp)
+	}
+	for _, chargingState := range transaction.ChargingStates {
+		timestamps = append(timestamps, chargingState.Timestamp)
+	}
+	for _, timestamp := range timestamps {
+		ts, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			continue
+		}
+		if start.IsZero() || ts.Before(start) {
+			start = ts
+		}
+		if end.IsZero() || ts.After(end) {
+			end = ts
+		}
+	}
+	return start.UTC(), end.UTC()
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func endedTransaction(cost *store.CostBreakdown) *store.Transaction {
+	return &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "1234",
+		IdToken:         "SOMERFID",
+		TokenType:       "ISO14443",
+		MeterValues: []store.MeterValue{
+			{Timestamp: "2023-05-05T12:00:00Z"},
+			{
+				Timestamp: "2023-05-05T13:30:00Z",
+				SampledValues: []store.SampledValue{
+					{
+						Context:   makePtr("Transaction.End"),
+						Measurand: makePtr("Energy.Active.Import.Register"),
+						Location:  makePtr("Outlet"),
+						Value:     10000,
+					},
+				},
+			},
+		},
+		EndedSeqNo: 1,
+		Cost:       cost,
+	}
+}
+
+func TestReceiptServiceItemisesTheCost(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetTariff(ctx, &store.Tariff{
+		Id:       "tariff001",
+		Currency: "GBP",
+		Elements: []store.TariffElement{
+			{PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.3, Vat: makePtr(5.0)}}},
+			{PriceComponents: []store.PriceComponent{{Type: "PARKING_TIME", Price: 1.5}}},
+		},
+	})
+	require.NoError(t, err)
+
+	receipts := &services.ReceiptService{Tariffs: engine, TaxRate: 20, Seller: "Thoughtworks"}
+	receipt, err := receipts.NewReceipt(ctx, endedTransaction(&store.CostBreakdown{
+		TariffId:    "tariff001",
+		Currency:    "GBP",
+		EnergyCost:  3.003,
+		ParkingCost: 0.75,
+		TotalCost:   3.753,
+		Periods:     []store.CostPeriod{{ChargingHours: 1, ParkingHours: 0.5}},
+	}))
+	require.NoError(t, err)
+
+	want := &services.Receipt{
+		Seller:          "Thoughtworks",
+		ChargeStationId: "cs001",
+		TransactionId:   "1234",
+		IdToken:         "SOMERFID",
+		StartTime:       time.Date(2023, 5, 5, 12, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2023, 5, 5, 13, 30, 0, 0, time.UTC),
+		Duration:        90 * time.Minute,
+		EnergyKwh:       10,
+		TariffId:        "tariff001",
+		Currency:        "GBP",
+		Lines: []services.ReceiptLine{
+			{Type: services.ReceiptLineTypeEnergy, Description: "Energy", Quantity: 10, Unit: "kWh", NetAmount: 3, TaxRate: 5, TaxAmount: 0.15},
+			{Type: services.ReceiptLineTypeParkingTime, Description: "Parking time", Quantity: 0.5, Unit: "h", NetAmount: 0.75, TaxRate: 20, TaxAmount: 0.15},
+		},
+		NetTotal: 3.75,
+		TaxTotal: 0.3,
+		Total:    4.05,
+	}
+	assert.Equal(t, want, receipt)
+}
+
+func TestReceiptServicePricesATransactionWithoutACost(t *testing.T) {
+	receipts := &services.ReceiptService{TariffService: services.BasicKwhTariffService{}, TaxRate: 20}
+	receipt, err := receipts.NewReceipt(context.Background(), endedTransaction(nil))
+	require.NoError(t, err)
+
+	want := []services.ReceiptLine{
+		{Type: services.ReceiptLineTypeTotal, Description: "Charging session", NetAmount: 5.5, TaxRate: 20, TaxAmount: 1.1},
+	}
+	assert.Equal(t, want, receipt.Lines)
+	assert.Equal(t, 6.6, receipt.Total)
+}
+
+func TestReceiptServiceRejectsATransactionThatHasNotEnded(t *testing.T) {
+	transaction := endedTransaction(nil)
+	transaction.EndedSeqNo = 0
+
+	receipts := &services.ReceiptService{}
+	_, err := receipts.NewReceipt(context.Background(), transaction)
+	assert.ErrorIs(t, err, services.ErrTransactionNotEnded)
+	assert.ErrorIs(t, err, errs.ErrConflict)
+}
+
+type fakeReceiptSender struct {
+	email   string
+	receipt *services.Receipt
+	html    string
+	err     error
+}
+
+func (f *fakeReceiptSender) SendReceipt(_ context.Context, email string, receipt *services.Receipt, html string) error {
+	f.email, f.receipt, f.html = email, receipt, html
+	return f.err
+}
+
+func TestReceiptServiceRendersAndEmailsTheReceipt(t *testing.T) {
+	ctx := context.Background()
+	sender := &fakeReceiptSender{}
+	receipts := &services.ReceiptService{TaxRate: 20, Seller: "Thoughtworks", Sender: sender}
+	receipt, err := receipts.NewReceipt(ctx, endedTransaction(&store.CostBreakdown{Currency: "EUR", SessionFee: 1}))
+	require.NoError(t, err)
+
+	var html bytes.Buffer
+	err = receipts.RenderHtml(&html, receipt)
+	require.NoError(t, err)
+	assert.Contains(t, html.String(), "Thoughtworks")
+	assert.Contains(t, html.String(), "Session fee")
+	assert.Contains(t, html.String(), "1.20")
+
+	err = receipts.Email(ctx, "driver@example.com", receipt)
+	require.NoError(t, err)
+	assert.Equal(t, "driver@example.com", sender.email)
+	assert.Equal(t, receipt, sender.receipt)
+	assert.Equal(t, html.String(), sender.html)
+
+	sender.err = errors.New("connection refused")
+	err = receipts.Email(ctx, "driver@example.com", receipt)
+	assert.ErrorIs(t, err, errs.ErrDependencyUnavailable)
+}
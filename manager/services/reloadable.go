@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"sync"
+)
+
+// reloadable holds a service that can be replaced while the manager is running
+type reloadable[T any] struct {
+	mu      sync.RWMutex
+	service T
+}
+
+// Get returns the service that calls are delegated to
+func (r *reloadable[T]) Get() T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.service
+}
+
+// Set replaces the service that calls are delegated to
+func (r *reloadable[T]) Set(service T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.service = service
+}
+
+// ReloadableTariffService is a TariffService that delegates to a TariffService that
+// can be replaced when the configuration is reloaded
+type ReloadableTariffService struct {
+	reloadable[TariffService]
+}
+
+func NewReloadableTariffService(service TariffService) *ReloadableTariffService {
+	r := new(ReloadableTariffService)
+	r.Set(service)
+	return r
+}
+
+func (r *ReloadableTariffService) CalculateCost(transaction *store.Transaction) (float64, error) {
+	return r.Get().CalculateCost(transaction)
+}
+
+func (r *ReloadableTariffService) CalculateCostBreakdown(transaction *store.Transaction) (*store.CostBreakdown, error) {
+	return CalculateCostBreakdown(r.Get(), transaction)
+}
+
+// NoShowFee prices the reservation if the current service is a NoShowFeeCalculator:
+// otherwise no fee is charged
+func (r *ReloadableTariffService) NoShowFee(ctx context.Context, reservation *store.Reservation) (*store.CostBreakdown, error) {
+	if calculator, ok := r.Get().(NoShowFeeCalculator); ok {
+		return calculator.NoShowFee(ctx, reservation)
+	}
+	return nil, nil
+}
+
+// ReloadableCertificateValidationService is a CertificateValidationService that
+// delegates to a CertificateValidationService that can be replaced when the
+// configuration is reloaded
+type ReloadableCertificateValidationService struct {
+	reloadable[CertificateValidationService]
+}
+
+func NewReloadableCertificateValidationService(service CertificateValidationService) *ReloadableCertificateValidationService {
+	r := new(ReloadableCertificateValidationService)
+	r.Set(service)
+	return r
+}
+
+func (r *ReloadableCertificateValidationService) ValidatePEMCertificateChain(ctx context.Context, pemChain []byte, eMAID string) (*string, error) {
+	return r.Get().ValidatePEMCertificateChain(ctx, pemChain, eMAID)
+}
+
+func (r *ReloadableCertificateValidationService) ValidateHashedCertificateChain(ctx context.Context, ocspRequestData []ocpp201.OCSPRequestDataType) (*string, error) {
+	return r.Get().ValidateHashedCertificateChain(ctx, ocspRequestData)
+}
+
+// RevalidateOCSPResponses revalidates the cached OCSP responses if the current
+// service is an OcspRevalidator
+func (r *ReloadableCertificateValidationService) RevalidateOCSPResponses(ctx context.Context) (int, error) {
+	if revalidator, ok := r.Get().(OcspRevalidator); ok {
+		return revalidator.RevalidateOCSPResponses(ctx)
+	}
+	return 0, nil
+}
+
+// RefreshRootCertificates refreshes the root certificates if the current service is a
+// RootCertificateRefresher
+func (r *ReloadableCertificateValidationService) RefreshRootCertificates(ctx context.Context) (int, error) {
+	if refresher, ok := r.Get().(RootCertificateRefresher); ok {
+		return refresher.RefreshRootCertificates(ctx)
+	}
+	return 0, nil
+}
+
+// ReloadableContractCertificateProvider is a ContractCertificateProvider that
+// delegates to a ContractCertificateProvider that can be replaced when the
+// configuration is reloaded
+type ReloadableContractCertificateProvider struct {
+	reloadable[ContractCertificateProvider]
+}
+
+func NewReloadableContractCertificateProvider(provider ContractCertificateProvider) *ReloadableContractCertificateProvider {
+	r := new(ReloadableContractCertificateProvider)
+	r.Set(provider)
+	return r
+}
+
+func (r *ReloadableContractCertificateProvider) ProvideCertificate(ctx context.Context, exiRequest string, isoVersion ISOVersion) (EvCertificate15118Response, error) {
+	return r.Get().ProvideCertificate(ctx, exiRequest, isoVersion)
+}
+
+// ReloadableChargeStationCertificateProvider is a ChargeStationCertificateProvider
+// that delegates to a ChargeStationCertificateProvider that can be replaced when the
+// configuration is reloaded
+type ReloadableChargeStationCertificateProvider struct {
+	reloadable[ChargeStationCertificateProvider]
+}
+
+func NewReloadableChargeStationCertificateProvider(provider ChargeStationCertificateProvider) *ReloadableChargeStationCertificateProvider {
+	r := new(ReloadableChargeStationCertificateProvider)
+	r.Set(provider)
+	return r
+}
+
+func (r *ReloadableChargeStationCertificateProvider) ProvideCertificate(ctx context.Context, typ CertificateType, pemEncodedCSR string, csId string) (string, error) {
+	return r.Get().ProvideCertificate(ctx, typ, pemEncodedCSR, csId)
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestReloadableTariffServiceDelegatesToReplacement(t *testing.T) {
+	transaction := &store.Transaction{
+		MeterValues: []store.MeterValue{
+			{
+				Timestamp: time.Now().Format(time.RFC3339),
+				SampledValues: []store.SampledValue{
+					{
+						Context:   makePtr("Transaction.End"),
+						Measurand: makePtr("Energy.Active.Import.Register"),
+						Location:  makePtr("Outlet"),
+						Value:     1000,
+					},
+				},
+			},
+		},
+	}
+	tariffService := services.NewReloadableTariffService(services.BasicKwhTariffService{})
+
+	cost, err := tariffService.CalculateCost(transaction)
+	require.NoError(t, err)
+	assert.Equal(t, 0.55, cost)
+
+	tariffService.Set(services.TimeBasedTariffService{PricePerKwh: 0.25})
+
+	cost, err = tariffService.CalculateCost(transaction)
+	require.NoError(t, err)
+	assert.Equal(t, 0.25, cost)
+}
+
+func TestReloadableTariffServiceItemisesCostWhenReplacementCan(t *testing.T) {
+	transaction := newTimeOfUseTransaction()
+	tariffService := services.NewReloadableTariffService(services.BasicKwhTariffService{})
+
+	breakdown, err := tariffService.CalculateCostBreakdown(transaction)
+	require.NoError(t, err)
+	assert.Empty(t, breakdown.Periods)
+
+	tariffEngine, _ := newTariffEngine(t)
+	tariffService.Set(tariffEngine)
+
+	breakdown, err = tariffService.CalculateCostBreakdown(transaction)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", breakdown.Currency)
+	assert.Len(t, breakdown.Periods, 1)
+}
+
+func TestHeartbeatIntervalCanBeChanged(t *testing.T) {
+	interval := services.NewHeartbeatInterval(5 * time.Minute)
+	assert.Equal(t, 5*time.Minute, interval.Get())
+
+	interval.Set(time.Minute)
+	assert.Equal(t, time.Minute, interval.Get())
+}
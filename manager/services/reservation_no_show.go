@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+var reservationNoShows = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "manager_reservation_no_shows_total",
+	Help: "The number of reservations released because they were not used",
+})
+
+// NoShowFeeCalculator prices a reservation that was released because it was not used
+type NoShowFeeCalculator interface {
+	// NoShowFee returns the fee for the reservation: it is nil if no fee is charged
+	NoShowFee(ctx context.Context, reservation *store.Reservation) (*store.CostBreakdown, error)
+}
+
+// ReservationNoShowPolicy releases reservations that no transaction has used within the
+// GracePeriod of the reservation starting, so that the EVSE can be used by others.
+type ReservationNoShowPolicy struct {
+	GracePeriod time.Duration
+	// Fees prices the reservations that are released: no fee is charged if it is nil
+	Fees NoShowFeeCalculator
+}
+
+// Release marks the reservation as a no-show, charging the no-show fee, and moves it to
+// CancelPending so that it is cancelled at the charge station. It reports whether the
+// reservation was released: the caller writes the reservation. The reservation is
+// released without a fee if the fee cannot be calculated.
+func (p *ReservationNoShowPolicy) Release(ctx context.Context, reservation *store.Reservation, now time.Time) bool {
+	if !reservation.Unused(now, p.GracePeriod) {
+		return false
+	}
+
+	reservation.Status = store.ReservationStatusCancelPending
+	reservation.SendAfter = time.Time{}
+	reservation.NoShow = true
+	if p.Fees != nil {
+		fee, err := p.Fees.NoShowFee(ctx, reservation)
+		if err != nil {
+			slog.Warn("unable to calculate no-show fee",
+				slog.Int("reservationId", reservation.ReservationId),
+				slog.String("chargeStationId", reservation.ChargeStationId),
+				"err", err)
+		} else {
+			reservation.NoShowFee = fee
+		}
+	}
+	reservationNoShows.Inc()
+	return true
+}
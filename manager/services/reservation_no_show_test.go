@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+type fixedNoShowFees struct {
+	fee *store.CostBreakdown
+	err error
+}
+
+func (f fixedNoShowFees) NoShowFee(context.Context, *store.Reservation) (*store.CostBreakdown, error) {
+	return f.fee, f.err
+}
+
+func TestReservationNoShowPolicyReleasesUnusedReservations(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	fee := &store.CostBreakdown{Currency: "GBP", SessionFee: 5, TotalCost: 5}
+	policy := &services.ReservationNoShowPolicy{
+		GracePeriod: 15 * time.Minute,
+		Fees:        fixedNoShowFees{fee: fee},
+	}
+
+	reservation := &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		StartDate:       now.Add(-10 * time.Minute),
+		ExpiryDate:      now.Add(time.Hour),
+		Status:          store.ReservationStatusAccepted,
+	}
+	assert.False(t, policy.Release(context.Background(), reservation, now))
+	assert.Equal(t, store.ReservationStatusAccepted, reservation.Status)
+
+	reservation.StartDate = now.Add(-20 * time.Minute)
+	assert.True(t, policy.Release(context.Background(), reservation, now))
+	assert.Equal(t, store.ReservationStatusCancelPending, reservation.Status)
+	assert.True(t, reservation.NoShow)
+	assert.Equal(t, fee, reservation.NoShowFee)
+}
+
+func TestReservationNoShowPolicyIgnoresUsedReservations(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	policy := &services.ReservationNoShowPolicy{GracePeriod: 15 * time.Minute}
+
+	reservation := &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		StartDate:       now.Add(-20 * time.Minute),
+		ExpiryDate:      now.Add(time.Hour),
+		Status:          store.ReservationStatusUsed,
+	}
+	assert.False(t, policy.Release(context.Background(), reservation, now))
+	assert.False(t, reservation.NoShow)
+}
+
+func TestReservationNoShowPolicyReleasesWithoutAFeeWhenTheFeeFails(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	policy := &services.ReservationNoShowPolicy{
+		GracePeriod: 15 * time.Minute,
+		Fees:        fixedNoShowFees{err: errors.New("no tariff")},
+	}
+
+	reservation := &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		StartDate:       now.Add(-20 * time.Minute),
+		ExpiryDate:      now.Add(time.Hour),
+		Status:          store.ReservationStatusAccepted,
+	}
+	assert.True(t, policy.Release(context.Background(), reservation, now))
+	assert.True(t, reservation.NoShow)
+	assert.Nil(t, reservation.NoShowFee)
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+)
+
+// ReservationNotifier informs the driver that holds a reservation about
+// changes that the CSMS makes to it.
+type ReservationNotifier interface {
+	// ReservationTransferred is called once a reservation has been moved: the
+	// reservation contains the new charge station and EVSE.
+	ReservationTransferred(ctx context.Context, reservation *store.Reservation, previousChargeStationId string, previousEvseId *int) error
+	// ReservationTransferFailed is called when the target of a transfer would not
+	// accept the reservation: the original reservation remains in place.
+	ReservationTransferFailed(ctx context.Context, reservation *store.Reservation) error
+}
+
+// LogReservationNotifier records notifications in the log. It is used when no
+// channel to the driver is available.
+type LogReservationNotifier struct{}
+
+func (LogReservationNotifier) ReservationTransferred(_ context.Context, reservation *store.Reservation, previousChargeStationId string, previousEvseId *int) error {
+	slog.Info("reservation transferred",
+		slog.Int("reservationId", reservation.ReservationId),
+		slog.String("idToken", reservation.IdToken),
+		slog.String("previousChargeStationId", previousChargeStationId),
+		slog.Any("previousEvseId", previousEvseId),
+		slog.String("chargeStationId", reservation.ChargeStationId),
+		slog.Any("evseId", reservation.EvseId))
+	return nil
+}
+
+func (LogReservationNotifier) ReservationTransferFailed(_ context.Context, reservation *store.Reservation) error {
+	slog.Info("reservation transfer failed",
+		slog.Int("reservationId", reservation.ReservationId),
+		slog.String("idToken", reservation.IdToken),
+		slog.String("chargeStationId", reservation.ChargeStationId),
+		slog.Any("evseId", reservation.EvseId))
+	return nil
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+// ErrPaymentDeclined is returned by a PaymentAuthorization when a hold cannot be placed
+// on the driver's payment method: the reservation is rejected
+var ErrPaymentDeclined = errors.New("payment declined")
+
+// PaymentAuthorization places holds on the driver's payment method, e.g. with an
+// external payment service provider, so that reservations can be paid for. Capture and
+// Release may be called more than once for the same hold when manager instances race,
+// so they should be idempotent.
+type PaymentAuthorization interface {
+	// Authorize places a hold for the reservation before it is made at the charge
+	// station: it returns the identifier of the hold
+	Authorize(ctx context.Context, reservation *store.Reservation) (string, error)
+	// Capture charges the amount against the reservation's hold
+	Capture(ctx context.Context, reservation *store.Reservation, amount *store.CostBreakdown) error
+	// Release removes the reservation's hold without charging
+	Release(ctx context.Context, reservation *store.Reservation) error
+}
+
+// AuthorizeReservationPayment places the hold for the reservation unless one has
+// already been placed: the caller writes the reservation.
+func AuthorizeReservationPayment(ctx context.Context, payments PaymentAuthorization, reservation *store.Reservation) error {
+	if reservation.Payment != nil {
+		return nil
+	}
+	authorizationId, err := payments.Authorize(ctx, reservation)
+	if err != nil {
+		return err
+	}
+	reservation.Payment = &store.ReservationPayment{
+		AuthorizationId: authorizationId,
+		Status:          store.ReservationPaymentStatusAuthorized,
+	}
+	return nil
+}
+
+// SettleReservationPayment settles the reservation's hold once the reservation has
+// finished:
+//   - a reservation used by a transaction is charged the cost of the transaction once
+//     it has ended and been priced
+//   - a no-show is charged the no-show fee
+//   - a reservation that is cancelled, rejected or expires unused is not charged
+//
+// The hold is released if there is nothing to charge. It reports whether the reservation
+// was changed: the caller writes the reservation.
+func SettleReservationPayment(ctx context.Context, payments PaymentAuthorization, transactions store.TransactionStore, reservation *store.Reservation, now time.Time) (bool, error) {
+	if reservation.Payment == nil || reservation.Payment.Status != store.ReservationPaymentStatusAuthorized {
+		return false, nil
+	}
+
+	var amount *store.CostBreakdown
+	switch {
+	case reservation.NoShow:
+		amount = reservation.NoShowFee
+	case reservation.Status == store.ReservationStatusUsed:
+		transaction, err := transactions.FindTransaction(ctx, reservation.ChargeStationId, reservation.TransactionId)
+		if err != nil {
+			return false, err
+		}
+		if transaction == nil || transaction.Status() != store.TransactionStatusEnded || transaction.Cost == nil {
+			return false, nil
+		}
+		amount = transaction.Cost
+	case reservation.Status == store.ReservationStatusCancelled, reservation.Status == store.ReservationStatusRejected:
+	case (reservation.Status == store.ReservationStatusPending || reservation.Status == store.ReservationStatusAccepted) &&
+		!reservation.ExpiryDate.After(now):
+	default:
+		return false, nil
+	}
+
+	if amount != nil && amount.TotalCost > 0 {
+		err := payments.Capture(ctx, reservation, amount)
+		if err != nil {
+			return false, err
+		}
+		reservation.Payment.Status = store.ReservationPaymentStatusCaptured
+		return true, nil
+	}
+
+	err := payments.Release(ctx, reservation)
+	if err != nil {
+		return false, err
+	}
+	reservation.Payment.Status = store.ReservationPaymentStatusReleased
+	return true, nil
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+type recordingPaymentAuthorization struct {
+	captured *store.CostBreakdown
+	released bool
+}
+
+func (r *recordingPaymentAuthorization) Authorize(context.Context, *store.Reservation) (string, error) {
+	return "auth-1", nil
+}
+
+func (r *recordingPaymentAuthorization) Capture(_ context.Context, _ *store.Reservation, amount *store.CostBreakdown) error {
+	r.captured = amount
+	return nil
+}
+
+func (r *recordingPaymentAuthorization) Release(context.Context, *store.Reservation) error {
+	r.released = true
+	return nil
+}
+
+func authorizedReservation(status store.ReservationStatus, expiryDate time.Time) *store.Reservation {
+	return &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		ExpiryDate:      expiryDate,
+		Status:          status,
+		Payment: &store.ReservationPayment{
+			AuthorizationId: "auth-1",
+			Status:          store.ReservationPaymentStatusAuthorized,
+		},
+	}
+}
+
+func TestAuthorizeReservationPaymentPlacesOneHold(t *testing.T) {
+	payments := &recordingPaymentAuthorization{}
+	reservation := &store.Reservation{ReservationId: 1, Status: store.ReservationStatusPending}
+
+	require.NoError(t, services.AuthorizeReservationPayment(context.Background(), payments, reservation))
+	assert.Equal(t, &store.ReservationPayment{
+		AuthorizationId: "auth-1",
+		Status:          store.ReservationPaymentStatusAuthorized,
+	}, reservation.Payment)
+
+	reservation.Payment.AuthorizationId = "auth-0"
+	require.NoError(t, services.AuthorizeReservationPayment(context.Background(), payments, reservation))
+	assert.Equal(t, "auth-0", reservation.Payment.AuthorizationId)
+}
+
+func TestSettleReservationPaymentCapturesTheTransactionCost(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	payments := &recordingPaymentAuthorization{}
+
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 0, false))
+	reservation := authorizedReservation(store.ReservationStatusUsed, now.Add(time.Hour))
+	reservation.TransactionId = "tx001"
+
+	settled, err := services.SettleReservationPayment(ctx, payments, engine, reservation, now)
+	require.NoError(t, err)
+	assert.False(t, settled)
+
+	cost := &store.CostBreakdown{Currency: "EUR", EnergyCost: 12.5, TotalCost: 12.5}
+	require.NoError(t, engine.EndTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 1))
+	require.NoError(t, engine.SetTransactionCost(ctx, "cs001", "tx001", cost))
+
+	settled, err = services.SettleReservationPayment(ctx, payments, engine, reservation, now)
+	require.NoError(t, err)
+	assert.True(t, settled)
+	assert.Equal(t, cost, payments.captured)
+	assert.Equal(t, store.ReservationPaymentStatusCaptured, reservation.Payment.Status)
+
+	settled, err = services.SettleReservationPayment(ctx, payments, engine, reservation, now)
+	require.NoError(t, err)
+	assert.False(t, settled)
+}
+
+func TestSettleReservationPaymentReleasesUnchargedReservations(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		reservation *store.Reservation
+		settled     bool
+	}{
+		"active":    {authorizedReservation(store.ReservationStatusAccepted, now.Add(time.Hour)), false},
+		"expired":   {authorizedReservation(store.ReservationStatusAccepted, now), true},
+		"cancelled": {authorizedReservation(store.ReservationStatusCancelled, now.Add(time.Hour)), true},
+		"rejected":  {authorizedReservation(store.ReservationStatusRejected, now.Add(time.Hour)), true},
+		"no-show without fee": {func() *store.Reservation {
+			reservation := authorizedReservation(store.ReservationStatusCancelPending, now.Add(time.Hour))
+			reservation.NoShow = true
+			return reservation
+		}(), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			payments := &recordingPaymentAuthorization{}
+			settled, err := services.SettleReservationPayment(ctx, payments, engine, tc.reservation, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.settled, settled)
+			assert.Equal(t, tc.settled, payments.released)
+			assert.Nil(t, payments.captured)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// RetentionService applies the data retention policy: meter values are purged once
+// they are older than MeterValues and ended transactions are anonymized once they
+// started more than Transactions ago. A zero duration keeps the data indefinitely.
+type RetentionService struct {
+	Clock        clock.PassiveClock
+	Store        store.RetentionStore
+	MeterValues  time.Duration
+	Transactions time.Duration
+}
+
+// RetentionResult reports the number of records changed by applying the policy
+type RetentionResult struct {
+	PurgedMeterValues      int
+	AnonymizedTransactions int
+}
+
+// Apply removes or anonymizes the data that the policy no longer allows to be kept
+func (r *RetentionService) Apply(ctx context.Context) (*RetentionResult, error) {
+	result := new(RetentionResult)
+	now := r.Clock.Now()
+
+	if r.MeterValues > 0 {
+		purged, err := r.Store.PurgeMeterValues(ctx, now.Add(-r.MeterValues))
+		result.PurgedMeterValues = purged
+		if err != nil {
+			return result, fmt.Errorf("purge meter values: %w", err)
+		}
+	}
+
+	if r.Transactions > 0 {
+		anonymized, err := r.Store.AnonymizeTransactions(ctx, now.Add(-r.Transactions))
+		result.AnonymizedTransactions = anonymized
+		if err != nil {
+			return result, fmt.Errorf("anonymize transactions: %w", err)
+		}
+	}
+
+	slog.Info("applied retention policy",
+		slog.Int("purgedMeterValues", result.PurgedMeterValues),
+		slog.Int("anonymizedTransactions", result.AnonymizedTransactions))
+	return result, nil
+}
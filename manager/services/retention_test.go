@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestRetentionServicePurgesMeterValuesAndAnonymizesTransactions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.AddMeterValues(ctx, "cs001", 1, []store.MeterValue{
+		{Timestamp: now.Add(-100 * 24 * time.Hour).Format(time.RFC3339)},
+		{Timestamp: now.Add(-24 * time.Hour).Format(time.RFC3339)},
+	})
+	require.NoError(t, err)
+
+	oldStart := []store.MeterValue{{Timestamp: now.Add(-3 * 365 * 24 * time.Hour).Format(time.RFC3339)}}
+	err = engine.CreateTransaction(ctx, "cs001", "old", "SOMERFID", "ISO14443", oldStart, 0, false)
+	require.NoError(t, err)
+	err = engine.EndTransaction(ctx, "cs001", "old", "SOMERFID", "ISO14443", nil, 1)
+	require.NoError(t, err)
+	// an old transaction that has not ended is still needed to authorize its end
+	err = engine.CreateTransaction(ctx, "cs001", "active", "SOMERFID", "ISO14443", oldStart, 0, false)
+	require.NoError(t, err)
+	recentStart := []store.MeterValue{{Timestamp: now.Add(-time.Hour).Format(time.RFC3339)}}
+	err = engine.CreateTransaction(ctx, "cs001", "recent", "SOMERFID", "ISO14443", recentStart, 0, false)
+	require.NoError(t, err)
+	err = engine.EndTransaction(ctx, "cs001", "recent", "SOMERFID", "ISO14443", nil, 1)
+	require.NoError(t, err)
+
+	retention := &services.RetentionService{
+		Clock:        clockTest.NewFakePassiveClock(now),
+		Store:        engine,
+		MeterValues:  90 * 24 * time.Hour,
+		Transactions: 2 * 365 * 24 * time.Hour,
+	}
+
+	got, err := retention.Apply(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &services.RetentionResult{PurgedMeterValues: 1, AnonymizedTransactions: 1}, got)
+
+	meterValues, err := engine.LookupMeterValues(ctx, "cs001", 1)
+	require.NoError(t, err)
+	assert.Len(t, meterValues.MeterValues, 1)
+
+	for transactionId, want := range map[string]string{
+		"old":    "",
+		"active": "SOMERFID",
+		"recent": "SOMERFID",
+	} {
+		transaction, err := engine.FindTransaction(ctx, "cs001", transactionId)
+		require.NoError(t, err)
+		assert.Equal(t, want, transaction.IdToken, transactionId)
+	}
+
+	got, err = retention.Apply(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &services.RetentionResult{}, got)
+}
+
+func TestRetentionServiceKeepsDataWithoutAPolicy(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.AddMeterValues(ctx, "cs001", 1, []store.MeterValue{
+		{Timestamp: now.Add(-1000 * 24 * time.Hour).Format(time.RFC3339)},
+	})
+	require.NoError(t, err)
+
+	retention := &services.RetentionService{
+		Clock: clockTest.NewFakePassiveClock(now),
+		Store: engine,
+	}
+
+	got, err := retention.Apply(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &services.RetentionResult{}, got)
+}
@@ -8,7 +8,10 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.opentelemetry.io/otel/codes"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
@@ -20,10 +23,22 @@ import (
 	"time"
 )
 
+var rootCertificateRefreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_root_certificate_refreshes_total",
+	Help: "The number of times the cached root certificates were refreshed by result (success or failure)",
+}, []string{"result"})
+
 type RootCertificateProviderService interface {
 	ProvideCertificates(ctx context.Context) ([]*x509.Certificate, error)
 }
 
+// RootCertificateRefresher retrieves the root certificates again so that new root
+// certificates are trusted without restarting the manager
+type RootCertificateRefresher interface {
+	// RefreshRootCertificates returns the number of root certificates that were retrieved
+	RefreshRootCertificates(ctx context.Context) (int, error)
+}
+
 type CompositeRootCertificateProviderService struct {
 	Providers []RootCertificateProviderService
 }
@@ -42,6 +57,31 @@ func (c CompositeRootCertificateProviderService) ProvideCertificates(ctx context
 	return certs, nil
 }
 
+// RefreshRootCertificates refreshes each of the providers that caches its root
+// certificates
+func (c CompositeRootCertificateProviderService) RefreshRootCertificates(ctx context.Context) (int, error) {
+	return refreshRootCertificates(ctx, c.Providers...)
+}
+
+// refreshRootCertificates refreshes the providers that are RootCertificateRefreshers,
+// continuing with the others if one fails
+func refreshRootCertificates(ctx context.Context, providers ...RootCertificateProviderService) (int, error) {
+	count := 0
+	var errs []error
+	for _, provider := range providers {
+		if refresher, ok := provider.(RootCertificateRefresher); ok {
+			refreshed, err := refresher.RefreshRootCertificates(ctx)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			count += refreshed
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// FileRootCertificateProviderService reads the root certificates from the files each
+// time they are needed, so it does not need to be refreshed
 type FileRootCertificateProviderService struct {
 	FilePaths []string
 }
@@ -130,6 +170,25 @@ func (c *CachingRootCertificateProviderService) ProvideCertificates(ctx context.
 	return c.certs, nil
 }
 
+// RefreshRootCertificates retrieves the certificates from the delegate and, if that
+// succeeds, swaps them for the cached certificates. The cached certificates continue
+// to be used while the certificates are retrieved and if the retrieval fails.
+func (c *CachingRootCertificateProviderService) RefreshRootCertificates(ctx context.Context) (int, error) {
+	certs, err := c.delegate.ProvideCertificates(ctx)
+	if err != nil {
+		rootCertificateRefreshes.WithLabelValues("failure").Inc()
+		return 0, fmt.Errorf("refreshing root certificates: %w", err)
+	}
+	rootCertificateRefreshes.WithLabelValues("success").Inc()
+
+	c.Lock()
+	defer c.Unlock()
+	c.certs = certs
+	c.expiry = c.clock.Now().Add(c.ttl)
+
+	return len(certs), nil
+}
+
 type OpcpRootCertificateReturnType struct {
 	RootCertificateCollection OpcpRootCertificateCollectionType `json:"RootCertificateCollection"`
 }
@@ -7,6 +7,7 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/services"
@@ -110,10 +111,14 @@ func TestOpcpMoRootCertificateProviderServiceRetrieveCertificates(t *testing.T)
 type CountingRootCertificateProviderService struct {
 	Count        int
 	Certificates []*x509.Certificate
+	Err          error
 }
 
 func (c *CountingRootCertificateProviderService) ProvideCertificates(context.Context) ([]*x509.Certificate, error) {
 	c.Count++
+	if c.Err != nil {
+		return nil, c.Err
+	}
 	return c.Certificates, nil
 }
 
@@ -142,6 +147,69 @@ func TestCachingRootCertificateProviderService(t *testing.T) {
 	assert.Equal(t, "V2G Root CA QA G1", certs[0].Issuer.CommonName)
 }
 
+func TestCachingRootCertificateProviderServiceRefresh(t *testing.T) {
+	rootCA, _ := createRootCACertificate(t, "V2G Root CA QA G1")
+	svc := &CountingRootCertificateProviderService{
+		Certificates: []*x509.Certificate{rootCA},
+	}
+	cachingService := services.NewCachingRootCertificateProviderService(svc, time.Hour, &clock.RealClock{})
+
+	_, err := cachingService.ProvideCertificates(context.TODO())
+	require.NoError(t, err)
+
+	newRootCA, _ := createRootCACertificate(t, "V2G Root CA QA G2")
+	svc.Certificates = []*x509.Certificate{rootCA, newRootCA}
+	count, err := cachingService.RefreshRootCertificates(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	certs, err := cachingService.ProvideCertificates(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 2, svc.Count)
+	require.Len(t, certs, 2)
+	assert.Equal(t, "V2G Root CA QA G2", certs[1].Issuer.CommonName)
+}
+
+func TestCachingRootCertificateProviderServiceRefreshFailureKeepsTheCachedCertificates(t *testing.T) {
+	rootCA, _ := createRootCACertificate(t, "V2G Root CA QA G1")
+	svc := &CountingRootCertificateProviderService{
+		Certificates: []*x509.Certificate{rootCA},
+	}
+	cachingService := services.NewCachingRootCertificateProviderService(svc, time.Hour, &clock.RealClock{})
+
+	_, err := cachingService.ProvideCertificates(context.TODO())
+	require.NoError(t, err)
+
+	svc.Err = errors.New("unavailable")
+	_, err = cachingService.RefreshRootCertificates(context.TODO())
+	assert.ErrorContains(t, err, "unavailable")
+
+	certs, err := cachingService.ProvideCertificates(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	assert.Equal(t, "V2G Root CA QA G1", certs[0].Issuer.CommonName)
+}
+
+func TestCompositeRootCertificateProviderServiceRefreshesCachingProviders(t *testing.T) {
+	rootCA, _ := createRootCACertificate(t, "V2G Root CA QA G1")
+	svc := &CountingRootCertificateProviderService{
+		Certificates: []*x509.Certificate{rootCA},
+	}
+	compositeService := services.CompositeRootCertificateProviderService{
+		Providers: []services.RootCertificateProviderService{
+			services.FileRootCertificateProviderService{
+				FilePaths: []string{"testdata/root_ca.pem"},
+			},
+			services.NewCachingRootCertificateProviderService(svc, time.Hour, &clock.RealClock{}),
+		},
+	}
+
+	count, err := compositeService.RefreshRootCertificates(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 1, svc.Count)
+}
+
 func TestCompositeRootCertificateProviderService(t *testing.T) {
 	fileRetrievalService := services.FileRootCertificateProviderService{
 		FilePaths: []string{"testdata/root_ca.pem"},
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"net/mail"
+	"net/smtp"
+)
+
+// SmtpDriverNotifier emails notifications to the driver's email address through an SMTP
+// server
+type SmtpDriverNotifier struct {
+	// Addr is the host:port of the SMTP server
+	Addr string
+	From string
+	// Auth authenticates with the SMTP server: it is nil if the server does not
+	// require authentication
+	Auth smtp.Auth
+}
+
+func (s SmtpDriverNotifier) NotifyDriver(_ context.Context, notification store.ReservationNotification, reservation *store.Reservation) error {
+	if reservation.Contact == nil || reservation.Contact.Email == "" {
+		return nil
+	}
+	to, err := mail.ParseAddress(reservation.Contact.Email)
+	if err != nil {
+		return fmt.Errorf("invalid email address: %w", err)
+	}
+
+	subject, text := ReservationNotificationMessage(notification, reservation)
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		s.From, to.Address, subject, text)
+	err = smtp.SendMail(s.Addr, s.Auth, s.From, []string{to.Address}, []byte(msg))
+	if err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+	return nil
+}
+
+// SendReceipt emails the HTML receipt to the email address
+func (s SmtpDriverNotifier) SendReceipt(_ context.Context, email string, receipt *Receipt, html string) error {
+	to, err := mail.ParseAddress(email)
+	if err != nil {
+		return fmt.Errorf("invalid email address: %w", err)
+	}
+
+	subject := fmt.Sprintf("Your receipt for charging session %s", receipt.TransactionId)
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s\r\n",
+		s.From, to.Address, subject, html)
+	err = smtp.SendMail(s.Addr, s.Auth, s.From, []string{to.Address}, []byte(msg))
+	if err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+	return nil
+}
@@ -15,6 +15,25 @@ type TariffService interface {
 	CalculateCost(transaction *store.Transaction) (float64, error)
 }
 
+// CostBreakdownService is implemented by tariff services that can itemise the cost of
+// a transaction
+type CostBreakdownService interface {
+	CalculateCostBreakdown(transaction *store.Transaction) (*store.CostBreakdown, error)
+}
+
+// CalculateCostBreakdown itemises the cost of the transaction if the tariff service is a
+// CostBreakdownService, otherwise the breakdown only holds the total cost
+func CalculateCostBreakdown(tariffService TariffService, transaction *store.Transaction) (*store.CostBreakdown, error) {
+	if breakdownService, ok := tariffService.(CostBreakdownService); ok {
+		return breakdownService.CalculateCostBreakdown(transaction)
+	}
+	cost, err := tariffService.CalculateCost(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &store.CostBreakdown{TotalCost: cost}, nil
+}
+
 type BasicKwhTariffService struct{}
 
 func (BasicKwhTariffService) CalculateCost(transaction *store.Transaction) (float64, error) {
@@ -64,3 +83,80 @@ func findMostRecentOutletEnergyReading(transaction *store.Transaction) (float64,
 
 	return totalWh, found
 }
+
+// TimeBasedTariffService calculates the cost of a transaction from the energy
+// delivered, the time spent charging and the time the EV occupied the charge
+// station without charging. The charging state changes recorded against the
+// transaction are used to determine how long was spent in each state.
+type TimeBasedTariffService struct {
+	PricePerKwh          float64
+	PricePerChargingHour float64
+	IdleFeePerMinute     float64
+	// IdleGracePeriod is the amount of idle time that is not charged for
+	IdleGracePeriod time.Duration
+}
+
+func (t TimeBasedTariffService) CalculateCost(transaction *store.Transaction) (float64, error) {
+	if transaction == nil {
+		return 0, errors.New("no transaction provided")
+	}
+
+	var cost float64
+	Wh, found := findMostRecentOutletEnergyReading(transaction)
+	if found {
+		cost += t.PricePerKwh * Wh / 1000
+	}
+
+	charging, idle := chargingStateDurations(transaction)
+	if !found && charging == 0 && idle == 0 {
+		return 0, errors.New("no output energy reading or charging states found in transaction")
+	}
+
+	cost += t.PricePerChargingHour * charging.Hours()
+	if idle > t.IdleGracePeriod {
+		cost += t.IdleFeePerMinute * (idle - t.IdleGracePeriod).Minutes()
+	}
+
+	return cost, nil
+}
+
+// chargingStateDurations returns the total time spent charging and the total
+// time spent idle (connected but not drawing power). Each state lasts until
+// the next recorded state change: the final state has no duration.
+func chargingStateDurations(transaction *store.Transaction) (charging, idle time.Duration) {
+	states := timedChargingStates(transaction.ChargingStates)
+	for i := 0; i < len(states)-1; i++ {
+		from, to := states[i].at, states[i+1].at
+		if to.Before(from) {
+			continue
+		}
+		switch states[i].state {
+		case "Charging":
+			charging += to.Sub(from)
+		case "SuspendedEV", "EVConnected", "Idle":
+			idle += to.Sub(from)
+		}
+	}
+	return charging, idle
+}
+
+// timedChargingState is a charging state change with its parsed timestamp
+type timedChargingState struct {
+	state string
+	at    time.Time
+}
+
+// timedChargingStates returns the charging state changes whose timestamps can be
+// parsed: a change with an unparseable timestamp cannot be placed on the timeline, so
+// the previous state is taken to last until the next change that can be
+func timedChargingStates(states []store.ChargingStateChange) []timedChargingState {
+	timed := make([]timedChargingState, 0, len(states))
+	for _, state := range states {
+		at, err := time.Parse(time.RFC3339, state.Timestamp)
+		if err != nil {
+			continue
+		}
+		timed = append(timed, timedChargingState{state: state.State, at: at})
+	}
+	return timed
+}
@@ -0,0 +1,374 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slices"
+)
+
+// TariffEngineStore holds the charge stations and the tariffs that are assigned to them
+type TariffEngineStore interface {
+	store.ChargeStationStore
+	store.TariffStore
+}
+
+// TariffEngine itemises the cost of a transaction using an OCPI tariff. The tariff is
+// the one assigned to the charge station, otherwise the one identified by
+// DefaultTariffId, otherwise DefaultTariff.
+//
+// The transaction is split into periods at midnight and at the start and end times of
+// the tariff's elements so that each time-of-use band is priced separately. The energy
+// in a period is interpolated from the outlet energy register readings and the time
+// spent charging and parking is taken from the charging states. The session fee is
+// taken from the elements that apply when the transaction starts.
+type TariffEngine struct {
+	Store           TariffEngineStore
+	DefaultTariffId string
+	// DefaultTariff is used for charge stations without a tariff when there is no
+	// DefaultTariffId: it is not held in the store
+	DefaultTariff *store.Tariff
+	// Location is the time zone of the tariff restrictions: UTC is used if it is not set
+	Location *time.Location
+}
+
+// NewDefaultTariff returns a tariff with a session fee, a price per kWh and a price per
+// minute of charging that can be used as a TariffEngine's DefaultTariff
+func NewDefaultTariff(currency string, sessionFee, pricePerKwh, pricePerMinute float64) *store.Tariff {
+	components := []store.PriceComponent{{Type: "ENERGY", Price: pricePerKwh}}
+	if sessionFee != 0 {
+		components = append(components, store.PriceComponent{Type: "FLAT", Price: sessionFee})
+	}
+	if pricePerMinute != 0 {
+		components = append(components, store.PriceComponent{Type: "TIME", Price: pricePerMinute * 60})
+	}
+	return &store.Tariff{
+		Currency: currency,
+		Type:     "REGULAR",
+		Elements: []store.TariffElement{{PriceComponents: components}},
+	}
+}
+
+func (e TariffEngine) CalculateCost(transaction *store.Transaction) (float64, error) {
+	breakdown, err := e.CalculateCostBreakdown(transaction)
+	if err != nil {
+		return 0, err
+	}
+	return breakdown.TotalCost, nil
+}
+
+// NoShowFee prices a reservation that was not used with the session fee of the tariff
+// elements that apply when the reservation started: no fee is charged if there is none
+func (e TariffEngine) NoShowFee(ctx context.Context, reservation *store.Reservation) (*store.CostBreakdown, error) {
+	tariffId, tariff, err := e.findTariff(ctx, reservation.ChargeStationId)
+	if err != nil {
+		return nil, err
+	}
+	start := reservation.StartDate
+	if err = tariffActiveAt(tariff, start); err != nil {
+		return nil, err
+	}
+
+	location := e.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	component := findPriceComponent(tariff, "FLAT", start, tariffedTransaction{start: start, end: start}, location)
+	if component == nil || component.Price == 0 {
+		return nil, nil
+	}
+	return &store.CostBreakdown{
+		TariffId:   tariffId,
+		Currency:   tariff.Currency,
+		SessionFee: component.Price,
+		TotalCost:  component.Price,
+	}, nil
+}
+
+// pricedDimension accumulates the quantity of a dimension across the periods so that
+// the quantity billed in the last period can be rounded up to the step size
+type pricedDimension struct {
+	quantity   float64
+	component  *store.PriceComponent
+	lastPeriod int
+}
+
+func (e TariffEngine) CalculateCostBreakdown(transaction *store.Transaction) (*store.CostBreakdown, error) {
+	if transaction == nil {
+		return nil, errors.New("no transaction provided")
+	}
+
+	tariffId, tariff, err := e.findTariff(context.Background(), transaction.ChargeStationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.itemise(transaction, tariffId, tariff)
+}
+
+// itemise prices the transaction with the tariff: tariffId is reported in the breakdown
+func (e TariffEngine) itemise(transaction *store.Transaction, tariffId string, tariff *store.Tariff) (*store.CostBreakdown, error) {
+	tx, err := summariseTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	if err = tariffActiveAt(tariff, tx.start); err != nil {
+		return nil, err
+	}
+
+	location := e.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	readings := outletEnergyReadings(transaction, tx.start)
+	intervals := chargingIntervals(transaction, tx)
+
+	breakdown := &store.CostBreakdown{
+		TariffId: tariffId,
+		Currency: tariff.Currency,
+	}
+	if component := findPriceComponent(tariff, "FLAT", tx.start, tx, location); component != nil {
+		breakdown.SessionFee = component.Price
+	}
+
+	energy := &pricedDimension{}
+	charging := &pricedDimension{}
+	parking := &pricedDimension{}
+
+	boundaries := periodBoundaries(tariff, tx.start, tx.end, location)
+	for i := 0; i < len(boundaries)-1; i++ {
+		from, to := boundaries[i], boundaries[i+1]
+		period := store.CostPeriod{
+			StartTime: from.UTC().Format(time.RFC3339),
+			EndTime:   to.UTC().Format(time.RFC3339),
+			EnergyKwh: (energyAt(readings, to) - energyAt(readings, from)) / 1000,
+		}
+		for _, interval := range intervals {
+			overlap := overlapOf(interval.from, interval.to, from, to).Hours()
+			if interval.parking {
+				period.ParkingHours += overlap
+			} else {
+				period.ChargingHours += overlap
+			}
+		}
+
+		index := len(breakdown.Periods)
+		if component := findPriceComponent(tariff, "ENERGY", from, tx, location); component != nil && period.EnergyKwh > 0 {
+			period.EnergyPrice = component.Price
+			energy.add(period.EnergyKwh*1000, component, index)
+		}
+		if component := findPriceComponent(tariff, "TIME", from, tx, location); component != nil && period.ChargingHours > 0 {
+			period.TimePrice = component.Price
+			charging.add(period.ChargingHours*3600, component, index)
+		}
+		if component := findPriceComponent(tariff, "PARKING_TIME", from, tx, location); component != nil && period.ParkingHours > 0 {
+			period.ParkingPrice = component.Price
+			parking.add(period.ParkingHours*3600, component, index)
+		}
+		period.Cost = period.EnergyPrice*period.EnergyKwh + period.TimePrice*period.ChargingHours +
+			period.ParkingPrice*period.ParkingHours
+
+		breakdown.EnergyCost += period.EnergyPrice * period.EnergyKwh
+		breakdown.TimeCost += period.TimePrice * period.ChargingHours
+		breakdown.ParkingCost += period.ParkingPrice * period.ParkingHours
+		breakdown.Periods = append(breakdown.Periods, period)
+	}
+
+	// the quantity of each dimension is rounded up to the step size in the last period
+	// that the dimension is billed in, as described by the OCPI 2.2 tariffs module
+	breakdown.EnergyCost += energy.roundUp(breakdown.Periods, 1000)
+	breakdown.TimeCost += charging.roundUp(breakdown.Periods, 3600)
+	breakdown.ParkingCost += parking.roundUp(breakdown.Periods, 3600)
+
+	breakdown.TotalCost = breakdown.SessionFee + breakdown.EnergyCost + breakdown.TimeCost + breakdown.ParkingCost
+
+	return breakdown, nil
+}
+
+// findTariff returns the tariff for the charge station and its id: the id is empty for
+// the DefaultTariff
+func (e TariffEngine) findTariff(ctx context.Context, chargeStationId string) (string, *store.Tariff, error) {
+	tariffId := e.DefaultTariffId
+	chargeStation, err := e.Store.LookupChargeStation(ctx, chargeStationId)
+	if err != nil {
+		return "", nil, fmt.Errorf("lookup charge station %s: %w", chargeStationId, err)
+	}
+	if chargeStation != nil && chargeStation.TariffId != "" {
+		tariffId = chargeStation.TariffId
+	}
+
+	if tariffId == "" {
+		if e.DefaultTariff == nil {
+			return "", nil, fmt.Errorf("no tariff for charge station %s", chargeStationId)
+		}
+		return "", e.DefaultTariff, nil
+	}
+
+	tariff, err := e.Store.LookupTariff(ctx, tariffId)
+	if err != nil {
+		return "", nil, fmt.Errorf("lookup tariff %s: %w", tariffId, err)
+	}
+	if tariff == nil {
+		return "", nil, fmt.Errorf("unknown tariff: %s", tariffId)
+	}
+	return tariffId, tariff, nil
+}
+
+func (d *pricedDimension) add(quantity float64, component *store.PriceComponent, period int) {
+	d.quantity += quantity
+	d.component = component
+	d.lastPeriod = period
+}
+
+// roundUp adds the cost of rounding the quantity up to the step size to the last period
+// that the dimension was billed in and returns it: unit is the number of step units
+// (Wh or seconds) that the price is quoted for
+func (d *pricedDimension) roundUp(periods []store.CostPeriod, unit float64) float64 {
+	if d.component == nil || d.component.StepSize <= 0 {
+		return 0
+	}
+	cost := d.component.Price * (roundUpToStep(d.quantity, d.component.StepSize) - d.quantity) / unit
+	periods[d.lastPeriod].Cost += cost
+	return cost
+}
+
+// energyReading is the value of the outlet energy register at a point in time
+type energyReading struct {
+	at time.Time
+	wh float64
+}
+
+// outletEnergyReadings returns the outlet energy register readings in time order. The
+// register is taken to be zero when the transaction starts, which is the assumption
+// made when the energy is read from the Transaction.End reading.
+func outletEnergyReadings(transaction *store.Transaction, start time.Time) []energyReading {
+	readings := []energyReading{{at: start}}
+	for _, mv := range transaction.MeterValues {
+		ts, err := time.Parse(time.RFC3339, mv.Timestamp)
+		if err != nil {
+			continue
+		}
+		for _, sv := range mv.SampledValues {
+			if sv.Measurand != nil && *sv.Measurand == "Energy.Active.Import.Register" &&
+				sv.Location != nil && *sv.Location == "Outlet" {
+				readings = append(readings, energyReading{at: ts, wh: sv.Value})
+			}
+		}
+	}
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].at.Before(readings[j].at)
+	})
+	return readings
+}
+
+// energyAt interpolates the energy register at the given time
+func energyAt(readings []energyReading, t time.Time) float64 {
+	if !t.After(readings[0].at) {
+		return readings[0].wh
+	}
+	for i := 1; i < len(readings); i++ {
+		if t.After(readings[i].at) {
+			continue
+		}
+		prev, next := readings[i-1], readings[i]
+		span := next.at.Sub(prev.at)
+		if span == 0 {
+			return next.wh
+		}
+		return prev.wh + (next.wh-prev.wh)*float64(t.Sub(prev.at))/float64(span)
+	}
+	return readings[len(readings)-1].wh
+}
+
+// chargingInterval is a period of time that was spent charging or parking
+type chargingInterval struct {
+	from    time.Time
+	to      time.Time
+	parking bool
+}
+
+// chargingIntervals returns the intervals spent charging and parking in the same way as
+// chargingStateDurations: without charging state changes the whole transaction is
+// treated as charging
+func chargingIntervals(transaction *store.Transaction, tx tariffedTransaction) []chargingInterval {
+	if len(transaction.ChargingStates) < 2 {
+		return []chargingInterval{{from: tx.start, to: tx.end}}
+	}
+
+	states := timedChargingStates(transaction.ChargingStates)
+	var intervals []chargingInterval
+	for i := 0; i < len(states)-1; i++ {
+		from, to := states[i].at, states[i+1].at
+		switch states[i].state {
+		case "Charging":
+			intervals = append(intervals, chargingInterval{from: from, to: to})
+		case "SuspendedEV", "EVConnected", "Idle":
+			intervals = append(intervals, chargingInterval{from: from, to: to, parking: true})
+		}
+	}
+	return intervals
+}
+
+// overlapOf returns how long the interval [from, to) overlaps the period [start, end)
+func overlapOf(from, to, start, end time.Time) time.Duration {
+	if from.Before(start) {
+		from = start
+	}
+	if to.After(end) {
+		to = end
+	}
+	if !to.After(from) {
+		return 0
+	}
+	return to.Sub(from)
+}
+
+// periodBoundaries returns the times between start and end, inclusive, at which the
+// transaction is split: midnight and the start and end times of the tariff's elements
+func periodBoundaries(tariff *store.Tariff, start, end time.Time, location *time.Location) []time.Time {
+	timesOfDay := []string{"00:00"}
+	for _, element := range tariff.Elements {
+		if element.Restrictions == nil {
+			continue
+		}
+		for _, timeOfDay := range []string{element.Restrictions.StartTime, element.Restrictions.EndTime} {
+			if timeOfDay != "" && !slices.Contains(timesOfDay, timeOfDay) {
+				timesOfDay = append(timesOfDay, timeOfDay)
+			}
+		}
+	}
+
+	boundaries := []time.Time{start}
+	localStart := start.In(location)
+	day := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, location)
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		for _, timeOfDay := range timesOfDay {
+			clock, err := time.Parse("15:04", timeOfDay)
+			if err != nil {
+				continue
+			}
+			boundary := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, location)
+			if boundary.After(start) && boundary.Before(end) {
+				boundaries = append(boundaries, boundary)
+			}
+		}
+	}
+	if end.After(start) {
+		boundaries = append(boundaries, end)
+	}
+
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+	return slices.CompactFunc(boundaries, func(a, b time.Time) bool {
+		return a.Equal(b)
+	})
+}
@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+)
+
+func outletEnergy(timestamp, context string, wh float64) store.MeterValue {
+	return store.MeterValue{
+		Timestamp: timestamp,
+		SampledValues: []store.SampledValue{
+			{
+				Context:   makePtr(context),
+				Measurand: makePtr("Energy.Active.Import.Register"),
+				Location:  makePtr("Outlet"),
+				Value:     wh,
+			},
+		},
+	}
+}
+
+// newTimeOfUseTransaction charges at a constant rate from 16:00 to 18:00 then parks
+// until 18:30
+func newTimeOfUseTransaction() *store.Transaction {
+	return &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		MeterValues: []store.MeterValue{
+			outletEnergy("2023-06-01T16:00:00Z", "Transaction.Begin", 0),
+			outletEnergy("2023-06-01T17:30:00Z", "Sample.Periodic", 15000),
+			outletEnergy("2023-06-01T18:30:00Z", "Transaction.End", 20000),
+		},
+		ChargingStates: []store.ChargingStateChange{
+			{State: "Charging", Timestamp: "2023-06-01T16:00:00Z"},
+			{State: "SuspendedEV", Timestamp: "2023-06-01T18:00:00Z"},
+			{State: "Idle", Timestamp: "2023-06-01T18:30:00Z"},
+		},
+	}
+}
+
+func timeOfUseTariff(id string) *store.Tariff {
+	return &store.Tariff{
+		Id:       id,
+		Currency: "GBP",
+		Elements: []store.TariffElement{
+			{
+				PriceComponents: []store.PriceComponent{
+					{Type: "ENERGY", Price: 0.5},
+					{Type: "PARKING_TIME", Price: 6},
+				},
+				Restrictions: &store.TariffRestrictions{StartTime: "17:00", EndTime: "21:00"},
+			},
+			{
+				PriceComponents: []store.PriceComponent{
+					{Type: "FLAT", Price: 1},
+					{Type: "ENERGY", Price: 0.2},
+					{Type: "TIME", Price: 1.2},
+					{Type: "PARKING_TIME", Price: 3},
+				},
+			},
+		},
+	}
+}
+
+func newTariffEngine(t *testing.T, tariffs ...*store.Tariff) (services.TariffEngine, store.Engine) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	for _, tariff := range tariffs {
+		require.NoError(t, engine.SetTariff(context.Background(), tariff))
+	}
+	return services.TariffEngine{
+		Store:         engine,
+		DefaultTariff: services.NewDefaultTariff("EUR", 0, 0.55, 0),
+	}, engine
+}
+
+func TestTariffEnginePricesEachTimeOfUseBand(t *testing.T) {
+	tariffEngine, _ := newTariffEngine(t, timeOfUseTariff("tou"))
+	tariffEngine.DefaultTariffId = "tou"
+
+	breakdown, err := tariffEngine.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+
+	assert.Equal(t, "tou", breakdown.TariffId)
+	assert.Equal(t, "GBP", breakdown.Currency)
+	assert.Equal(t, 1.0, breakdown.SessionFee)
+	// 10 kWh at 0.2 before 17:00 and 10 kWh at 0.5 after
+	assert.InDelta(t, 7.0, breakdown.EnergyCost, 0.0001)
+	// the band does not price charging time, so it is priced by the next element
+	assert.InDelta(t, 2.4, breakdown.TimeCost, 0.0001)
+	assert.InDelta(t, 3.0, breakdown.ParkingCost, 0.0001)
+	assert.InDelta(t, 13.4, breakdown.TotalCost, 0.0001)
+
+	require.Len(t, breakdown.Periods, 2)
+	assert.Equal(t, "2023-06-01T16:00:00Z", breakdown.Periods[0].StartTime)
+	assert.Equal(t, "2023-06-01T17:00:00Z", breakdown.Periods[0].EndTime)
+	assert.InDelta(t, 10.0, breakdown.Periods[0].EnergyKwh, 0.0001)
+	assert.InDelta(t, 1.0, breakdown.Periods[0].ChargingHours, 0.0001)
+	assert.Equal(t, 0.2, breakdown.Periods[0].EnergyPrice)
+	assert.InDelta(t, 3.2, breakdown.Periods[0].Cost, 0.0001)
+	assert.Equal(t, "2023-06-01T17:00:00Z", breakdown.Periods[1].StartTime)
+	assert.Equal(t, "2023-06-01T18:30:00Z", breakdown.Periods[1].EndTime)
+	assert.InDelta(t, 0.5, breakdown.Periods[1].ParkingHours, 0.0001)
+	assert.Equal(t, 6.0, breakdown.Periods[1].ParkingPrice)
+	assert.InDelta(t, 9.2, breakdown.Periods[1].Cost, 0.0001)
+
+	cost, err := tariffEngine.CalculateCost(newTimeOfUseTransaction())
+	require.NoError(t, err)
+	assert.InDelta(t, 13.4, cost, 0.0001)
+}
+
+func TestTariffEngineUsesTheTariffAssignedToTheChargeStation(t *testing.T) {
+	flat := &store.Tariff{
+		Id:       "flat",
+		Currency: "EUR",
+		Elements: []store.TariffElement{{PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.3}}}},
+	}
+	tariffEngine, engine := newTariffEngine(t, timeOfUseTariff("tou"), flat)
+	tariffEngine.DefaultTariffId = "tou"
+	require.NoError(t, engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{TariffId: "flat"}))
+
+	breakdown, err := tariffEngine.CalculateCostBreakdown(newTimeOfUseTransaction())
+	require.NoError(t, err)
+
+	assert.Equal(t, "flat", breakdown.TariffId)
+	assert.Equal(t, "EUR", breakdown.Currency)
+	assert.InDelta(t, 6.0, breakdown.TotalCost, 0.0001)
+}
+
+func TestTariffEngineUsesTheDefaultTariff(t *testing.T) {
+	tariffEngine, _ := newTariffEngine(t)
+
+	transaction := newTimeOfUseTransaction()
+	breakdown, err := tariffEngine.CalculateCostBreakdown(transaction)
+	require.NoError(t, err)
+
+	assert.Empty(t, breakdown.TariffId)
+	assert.Equal(t, "EUR", breakdown.Currency)
+	basicCost, err := services.BasicKwhTariffService{}.CalculateCost(transaction)
+	require.NoError(t, err)
+	assert.InDelta(t, basicCost, breakdown.TotalCost, 0.0001)
+	assert.InDelta(t, basicCost, breakdown.EnergyCost, 0.0001)
+}
+
+func TestTariffEngineRoundsUpToTheStepSizeInTheLastPeriod(t *testing.T) {
+	tariffEngine, _ := newTariffEngine(t, &store.Tariff{
+		Id:       "stepped",
+		Currency: "EUR",
+		Elements: []store.TariffElement{
+			{
+				PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 1, StepSize: 1000}},
+				Restrictions:    &store.TariffRestrictions{StartTime: "17:00"},
+			},
+			{
+				PriceComponents: []store.PriceComponent{{Type: "ENERGY", Price: 0.5, StepSize: 1000}},
+			},
+		},
+	})
+	tariffEngine.DefaultTariffId = "stepped"
+
+	transaction := &store.Transaction{
+		ChargeStationId: "cs001",
+		MeterValues: []store.MeterValue{
+			outletEnergy("2023-06-01T16:00:00Z", "Transaction.Begin", 0),
+			outletEnergy("2023-06-01T18:00:00Z", "Transaction.End", 2500),
+		},
+	}
+	breakdown, err := tariffEngine.CalculateCostBreakdown(transaction)
+	require.NoError(t, err)
+
+	// 1.25 kWh at 0.5 then 1.25 kWh at 1, with the remaining 0.5 kWh billed at 1
+	require.Len(t, breakdown.Periods, 2)
+	assert.InDelta(t, 0.625, breakdown.Periods[0].Cost, 0.0001)
+	assert.InDelta(t, 1.75, breakdown.Periods[1].Cost, 0.0001)
+	assert.InDelta(t, 2.375, breakdown.TotalCost, 0.0001)
+}
+
+func TestTariffEngineSplitsTransactionsAtMidnight(t *testing.T) {
+	tariffEngine, _ := newTariffEngine(t)
+
+	transaction := &store.Transaction{
+		ChargeStationId: "cs001",
+		MeterValues: []store.MeterValue{
+			outletEnergy("2023-06-01T23:00:00Z", "Transaction.Begin", 0),
+			outletEnergy("2023-06-02T01:00:00Z", "Transaction.End", 2000),
+		},
+	}
+	breakdown, err := tariffEngine.CalculateCostBreakdown(transaction)
+	require.NoError(t, err)
+
+	require.Len(t, breakdown.Periods, 2)
+	assert.Equal(t, "2023-06-02T00:00:00Z", breakdown.Periods[1].StartTime)
+	assert.InDelta(t, 1.0, breakdown.Periods[1].EnergyKwh, 0.0001)
+	assert.InDelta(t, 1.1, breakdown.TotalCost, 0.0001)
+}
+
+func TestTariffEngineErrorsWithUnknownTariff(t *testing.T) {
+	tariffEngine, engine := newTariffEngine(t)
+	require.NoError(t, engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{TariffId: "unknown"}))
+
+	_, err := tariffEngine.CalculateCostBreakdown(newTimeOfUseTransaction())
+	assert.ErrorContains(t, err, "unknown tariff: unknown")
+}
+
+func TestTariffEngineErrorsWithoutATariff(t *testing.T) {
+	tariffEngine, _ := newTariffEngine(t)
+	tariffEngine.DefaultTariff = nil
+
+	_, err := tariffEngine.CalculateCostBreakdown(newTimeOfUseTransaction())
+	assert.ErrorContains(t, err, "no tariff for charge station cs001")
+}
+
+func TestTariffEngineErrorsWithNilTransaction(t *testing.T) {
+	tariffEngine, _ := newTariffEngine(t)
+
+	_, err := tariffEngine.CalculateCostBreakdown(nil)
+	assert.Error(t, err)
+}
+
+func TestCalculateCostBreakdownOfServiceThatCannotItemiseCosts(t *testing.T) {
+	breakdown, err := services.CalculateCostBreakdown(services.BasicKwhTariffService{}, newTimeOfUseTransaction())
+	require.NoError(t, err)
+
+	assert.Equal(t, &store.CostBreakdown{TotalCost: 11}, breakdown)
+}
+
+func TestTariffEngineChargesTheSessionFeeForNoShows(t *testing.T) {
+	tariffEngine, engine := newTariffEngine(t, timeOfUseTariff("tou"))
+	require.NoError(t, engine.SetChargeStation(context.Background(), "cs001", &store.ChargeStation{TariffId: "tou"}))
+
+	fee, err := tariffEngine.NoShowFee(context.Background(), &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		StartDate:       time.Date(2023, 6, 1, 18, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &store.CostBreakdown{
+		TariffId:   "tou",
+		Currency:   "GBP",
+		SessionFee: 1,
+		TotalCost:  1,
+	}, fee)
+
+	// the default tariff has no session fee
+	fee, err = tariffEngine.NoShowFee(context.Background(), &store.Reservation{
+		ReservationId:   2,
+		ChargeStationId: "cs002",
+		StartDate:       time.Date(2023, 6, 1, 18, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, fee)
+}
@@ -54,3 +54,81 @@ func TestBasicKwhTariffServiceErrorsWhenNoKwhReading(t *testing.T) {
 	var zero float64
 	assert.Equal(t, zero, cost)
 }
+
+func TestTimeBasedTariffServiceCalculatesEnergyChargingAndIdleCost(t *testing.T) {
+	transaction := &store.Transaction{
+		MeterValues: []store.MeterValue{
+			{
+				Timestamp: "2023-05-05T13:30:00Z",
+				SampledValues: []store.SampledValue{
+					{
+						Context:   makePtr("Transaction.End"),
+						Measurand: makePtr("Energy.Active.Import.Register"),
+						Location:  makePtr("Outlet"),
+						Value:     10000,
+					},
+				},
+			},
+		},
+		ChargingStates: []store.ChargingStateChange{
+			{State: "Charging", Timestamp: "2023-05-05T12:00:00Z"},
+			{State: "SuspendedEV", Timestamp: "2023-05-05T13:00:00Z"},
+			{State: "Idle", Timestamp: "2023-05-05T13:30:00Z"},
+		},
+	}
+	tariffService := services.TimeBasedTariffService{
+		PricePerKwh:          0.5,
+		PricePerChargingHour: 1,
+		IdleFeePerMinute:     0.1,
+		IdleGracePeriod:      10 * time.Minute,
+	}
+	cost, err := tariffService.CalculateCost(transaction)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5+1+2, cost, 0.0001)
+}
+
+func TestTimeBasedTariffServiceDoesNotChargeIdleTimeWithinGracePeriod(t *testing.T) {
+	transaction := &store.Transaction{
+		ChargingStates: []store.ChargingStateChange{
+			{State: "Charging", Timestamp: "2023-05-05T12:00:00Z"},
+			{State: "EVConnected", Timestamp: "2023-05-05T12:30:00Z"},
+			{State: "Idle", Timestamp: "2023-05-05T12:35:00Z"},
+		},
+	}
+	tariffService := services.TimeBasedTariffService{
+		PricePerChargingHour: 2,
+		IdleFeePerMinute:     0.1,
+		IdleGracePeriod:      10 * time.Minute,
+	}
+	cost, err := tariffService.CalculateCost(transaction)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, cost, 0.0001)
+}
+
+func TestTimeBasedTariffServiceIgnoresUnparseableChargingStateTimestamps(t *testing.T) {
+	transaction := &store.Transaction{
+		ChargingStates: []store.ChargingStateChange{
+			{State: "Charging", Timestamp: "2023-05-05T12:00:00Z"},
+			{State: "SuspendedEV", Timestamp: "not a time"},
+			{State: "Idle", Timestamp: "2023-05-05T12:30:00Z"},
+		},
+	}
+	tariffService := services.TimeBasedTariffService{
+		PricePerChargingHour: 2,
+	}
+	cost, err := tariffService.CalculateCost(transaction)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, cost, 0.0001)
+}
+
+func TestTimeBasedTariffServiceErrorsWithNilTransaction(t *testing.T) {
+	tariffService := services.TimeBasedTariffService{}
+	_, err := tariffService.CalculateCost(nil)
+	assert.ErrorContains(t, err, "no transaction provided")
+}
+
+func TestTimeBasedTariffServiceErrorsWhenNoReadingsOrStates(t *testing.T) {
+	tariffService := services.TimeBasedTariffService{}
+	_, err := tariffService.CalculateCost(&store.Transaction{})
+	assert.ErrorContains(t, err, "no output energy reading or charging states found in transaction")
+}
@@ -4,11 +4,14 @@ package services
 
 import (
 	"context"
+	"errors"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/utils/clock"
+	"strings"
 	"time"
 )
 
@@ -16,9 +19,98 @@ type TokenAuthService interface {
 	Authorize(ctx context.Context, token ocpp201.IdTokenType) ocpp201.IdTokenInfoType
 }
 
+// RemoteTokenAuthorizer authorizes tokens that are not held in the token store with the
+// party that issued them. A nil token is returned if the token is not known.
+type RemoteTokenAuthorizer interface {
+	AuthorizeToken(ctx context.Context, idToken, tokenType string) (*store.Token, error)
+}
+
+// RemoteTokenAuthorizers asks each of the authorizers in turn until one of them knows the
+// token. An error is only returned if none of them know the token and one of them failed.
+type RemoteTokenAuthorizers []RemoteTokenAuthorizer
+
+func (r RemoteTokenAuthorizers) AuthorizeToken(ctx context.Context, idToken, tokenType string) (*store.Token, error) {
+	var errs []error
+	for _, authorizer := range r {
+		tok, err := authorizer.AuthorizeToken(ctx, idToken, tokenType)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if tok != nil {
+			return tok, nil
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// LookupToken looks up the token in the store. If it is not found and the id token is an
+// eMAID, it is looked up again by the normalized eMAID, with and without its check
+// digit, so that the token is found however the eMAID is formatted.
+func LookupToken(ctx context.Context, tokenStore store.TokenStore, idToken string) (*store.Token, error) {
+	uids, _ := tokenUids(idToken, "")
+	return lookupToken(ctx, tokenStore, uids)
+}
+
+// LookupIdToken looks up the token for an OCPP 2.0.1 id token in the store. The token is
+// looked up by the id token as it was presented, then by its canonical form for the
+// type of id token (see tokenUids) and finally by the hash of its canonical form, so
+// that tokens can be registered without the CSMS holding their id tokens.
+func LookupIdToken(ctx context.Context, tokenStore store.TokenStore, idToken ocpp201.IdTokenType) (*store.Token, error) {
+	uids, canonical := tokenUids(idToken.IdToken, idToken.Type)
+	return lookupToken(ctx, tokenStore, append(uids, store.HashTokenUid(canonical)))
+}
+
+// lookupToken returns the token with the first of the uids that is in the store
+func lookupToken(ctx context.Context, tokenStore store.TokenStore, uids []string) (*store.Token, error) {
+	for _, uid := range uids {
+		tok, err := tokenStore.LookupToken(ctx, uid)
+		if err != nil || tok != nil {
+			return tok, err
+		}
+	}
+	return nil, nil
+}
+
+// tokenUids returns the uids that a token for the id token may be stored under along
+// with the canonical form of the id token:
+//   - an eMAID is upper case without separators, with its check digit (it may also be
+//     stored without its check digit)
+//   - an ISO14443 or ISO15693 UID is upper case hex
+//   - a MAC address is upper case hex without separators
+//   - other id tokens, e.g. a KeyCode, are used as they are
+//
+// OCPP 1.6 id tags, which have no type, are treated as eMAIDs if they are valid eMAIDs.
+func tokenUids(idToken string, tokenType ocpp201.IdTokenEnumType) ([]string, string) {
+	canonical := idToken
+	var alternatives []string
+
+	switch tokenType {
+	case "", ocpp201.IdTokenEnumTypeEMAID:
+		if emaid, err := ocpp.ParseEmaid(idToken); err == nil {
+			canonical = emaid.String()
+			alternatives = []string{emaid.WithoutCheckDigit()}
+		}
+	case ocpp201.IdTokenEnumTypeISO14443, ocpp201.IdTokenEnumTypeISO15693:
+		canonical = strings.ToUpper(idToken)
+	case ocpp201.IdTokenEnumTypeMacAddress:
+		canonical = strings.ToUpper(strings.NewReplacer(":", "", "-", "", ".", "").Replace(idToken))
+	}
+
+	uids := []string{idToken}
+	for _, uid := range append([]string{canonical}, alternatives...) {
+		if uid != idToken {
+			uids = append(uids, uid)
+		}
+	}
+	return uids, canonical
+}
+
 type OcppTokenAuthService struct {
 	TokenStore store.TokenStore
 	Clock      clock.PassiveClock
+	// RemoteTokenAuthorizer (if any) is asked about tokens that are not in the TokenStore
+	RemoteTokenAuthorizer RemoteTokenAuthorizer
 }
 
 func (o *OcppTokenAuthService) Authorize(ctx context.Context, token ocpp201.IdTokenType) ocpp201.IdTokenInfoType {
@@ -44,7 +136,13 @@ func (o *OcppTokenAuthService) Authorize(ctx context.Context, token ocpp201.IdTo
 			Status: ocpp201.AuthorizationStatusEnumTypeInvalid,
 		}
 	default:
-		foundToken, err := o.TokenStore.LookupToken(ctx, token.IdToken)
+		var foundToken *store.Token
+		var err error
+		foundToken, err = LookupIdToken(ctx, o.TokenStore, token)
+		if err == nil && foundToken == nil && o.RemoteTokenAuthorizer != nil {
+			span.SetAttributes(attribute.Bool("token_auth.remote", true))
+			foundToken, err = o.RemoteTokenAuthorizer.AuthorizeToken(ctx, token.IdToken, string(token.Type))
+		}
 		if err != nil {
 			span.RecordError(err)
 			tokenInfo = &ocpp201.IdTokenInfoType{
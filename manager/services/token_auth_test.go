@@ -252,6 +252,138 @@ func TestOcppTokenAuthServiceReturnsAcceptedIfTokenIsValid(t *testing.T) {
 	})
 }
 
+func TestOcppTokenAuthServiceFindsEmaidTokenRegardlessOfFormatting(t *testing.T) {
+	now := time.Now()
+	clock := fakeclock.NewFakePassiveClock(now)
+	tokenStore := inmemory.NewStore(clock)
+
+	err := tokenStore.SetToken(context.Background(), &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "OTHER",
+		Uid:         "GBTWK012345678V",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   "ALWAYS",
+	})
+	require.NoError(t, err)
+
+	tokenAuthService := services.OcppTokenAuthService{
+		TokenStore: tokenStore,
+		Clock:      clock,
+	}
+
+	for _, idToken := range []string{"GBTWK012345678V", "gb-twk-012345678-v", "GB*TWK*012345678", "GBTWK012345678"} {
+		t.Run(idToken, func(t *testing.T) {
+			tokenInfo := tokenAuthService.Authorize(context.Background(), ocpp201.IdTokenType{
+				Type:    ocpp201.IdTokenEnumTypeEMAID,
+				IdToken: idToken,
+			})
+			assert.Equal(t, ocpp201.AuthorizationStatusEnumTypeAccepted, tokenInfo.Status)
+		})
+	}
+}
+
+func TestLookupTokenFindsTokenStoredWithoutCheckDigit(t *testing.T) {
+	tokenStore := inmemory.NewStore(fakeclock.NewFakePassiveClock(time.Now()))
+	err := tokenStore.SetToken(context.Background(), &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "OTHER",
+		Uid:         "GBTWK012345678",
+		ContractId:  "GBTWK012345678V",
+		Valid:       true,
+		CacheMode:   "ALWAYS",
+	})
+	require.NoError(t, err)
+
+	tok, err := services.LookupToken(context.Background(), tokenStore, "GB-TWK-012345678-V")
+	require.NoError(t, err)
+	require.NotNil(t, tok)
+	assert.Equal(t, "GBTWK012345678", tok.Uid)
+
+	tok, err = services.LookupToken(context.Background(), tokenStore, "DEADBEEF")
+	require.NoError(t, err)
+	assert.Nil(t, tok)
+}
+
+func TestOcppTokenAuthServiceFindsTokensByCanonicalIdToken(t *testing.T) {
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	tokenStore := inmemory.NewStore(clock)
+	for _, uid := range []string{"DEADBEEF", "E0040150A1B2C3D4", "0A1B2C3D4E5F", "1234"} {
+		err := tokenStore.SetToken(context.Background(), &store.Token{
+			CountryCode: "GB",
+			PartyId:     "TWK",
+			Type:        "RFID",
+			Uid:         uid,
+			ContractId:  "GBTWK012345678V",
+			Valid:       true,
+			CacheMode:   "ALWAYS",
+		})
+		require.NoError(t, err)
+	}
+
+	tokenAuthService := services.OcppTokenAuthService{
+		TokenStore: tokenStore,
+		Clock:      clock,
+	}
+
+	tests := []ocpp201.IdTokenType{
+		{Type: ocpp201.IdTokenEnumTypeISO14443, IdToken: "deadbeef"},
+		{Type: ocpp201.IdTokenEnumTypeISO15693, IdToken: "e0040150a1b2c3d4"},
+		{Type: ocpp201.IdTokenEnumTypeMacAddress, IdToken: "0a:1b:2c:3d:4e:5f"},
+		{Type: ocpp201.IdTokenEnumTypeMacAddress, IdToken: "0A-1B-2C-3D-4E-5F"},
+		{Type: ocpp201.IdTokenEnumTypeKeyCode, IdToken: "1234"},
+	}
+	for _, idToken := range tests {
+		t.Run(string(idToken.Type)+"/"+idToken.IdToken, func(t *testing.T) {
+			tokenInfo := tokenAuthService.Authorize(context.Background(), idToken)
+			assert.Equal(t, ocpp201.AuthorizationStatusEnumTypeAccepted, tokenInfo.Status)
+		})
+	}
+
+	// a key code is case-sensitive
+	tokenInfo := tokenAuthService.Authorize(context.Background(), ocpp201.IdTokenType{
+		Type:    ocpp201.IdTokenEnumTypeKeyCode,
+		IdToken: "deadbeef",
+	})
+	assert.Equal(t, ocpp201.AuthorizationStatusEnumTypeUnknown, tokenInfo.Status)
+}
+
+func TestOcppTokenAuthServiceFindsTokensByHashedIdToken(t *testing.T) {
+	clock := fakeclock.NewFakePassiveClock(time.Now())
+	tokenStore := inmemory.NewStore(clock)
+	for _, idToken := range []string{"DEADBEEF", "GBTWK012345678V"} {
+		err := tokenStore.SetToken(context.Background(), &store.Token{
+			CountryCode: "GB",
+			PartyId:     "TWK",
+			Type:        "RFID",
+			Uid:         store.HashTokenUid(idToken),
+			ContractId:  "GBTWK012345678V",
+			Valid:       true,
+			CacheMode:   "ALWAYS",
+		})
+		require.NoError(t, err)
+	}
+
+	tokenAuthService := services.OcppTokenAuthService{
+		TokenStore: tokenStore,
+		Clock:      clock,
+	}
+
+	tests := []ocpp201.IdTokenType{
+		{Type: ocpp201.IdTokenEnumTypeISO14443, IdToken: "deadbeef"},
+		{Type: ocpp201.IdTokenEnumTypeEMAID, IdToken: "GB-TWK-012345678"},
+	}
+	for _, idToken := range tests {
+		t.Run(string(idToken.Type)+"/"+idToken.IdToken, func(t *testing.T) {
+			tokenInfo := tokenAuthService.Authorize(context.Background(), idToken)
+			assert.Equal(t, ocpp201.AuthorizationStatusEnumTypeAccepted, tokenInfo.Status)
+		})
+	}
+}
+
 func TestOcppTokenAuthServiceIncludesGroupIdIfConfigured(t *testing.T) {
 	now := time.Now()
 	clock := fakeclock.NewFakePassiveClock(now)
@@ -353,3 +485,103 @@ func TestOcppTokenAuthServiceSetsExpiryTimeToNowIfCacheModeIsNever(t *testing.T)
 		"token_auth.status": "Accepted",
 	})
 }
+
+type fakeRemoteTokenAuthorizer struct {
+	tokens    map[string]*store.Token
+	requested []string
+}
+
+func (f *fakeRemoteTokenAuthorizer) AuthorizeToken(_ context.Context, idToken, tokenType string) (*store.Token, error) {
+	f.requested = append(f.requested, tokenType+":"+idToken)
+	return f.tokens[idToken], nil
+}
+
+func TestOcppTokenAuthServiceAuthorizesUnknownTokenRemotely(t *testing.T) {
+	now := time.Now()
+	clock := fakeclock.NewFakePassiveClock(now)
+	tokenStore := inmemory.NewStore(clock)
+
+	remoteAuthorizer := &fakeRemoteTokenAuthorizer{
+		tokens: map[string]*store.Token{
+			"DEADBEEF":  {Uid: "DEADBEEF", Valid: true, CacheMode: "NEVER"},
+			"BADC0FFEE": {Uid: "BADC0FFEE", Valid: false},
+		},
+	}
+
+	tokenAuthService := services.OcppTokenAuthService{
+		TokenStore:            tokenStore,
+		Clock:                 clock,
+		RemoteTokenAuthorizer: remoteAuthorizer,
+	}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		tokenInfo := tokenAuthService.Authorize(ctx, ocpp201.IdTokenType{
+			Type:    ocpp201.IdTokenEnumTypeISO14443,
+			IdToken: "DEADBEEF",
+		})
+
+		expiryTime := now.Format(time.RFC3339)
+		assert.Equal(t, ocpp201.IdTokenInfoType{
+			Status:              ocpp201.AuthorizationStatusEnumTypeAccepted,
+			CacheExpiryDateTime: &expiryTime,
+		}, tokenInfo)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"token_auth.type":   "ISO14443",
+		"token_auth.id":     "DEADBEEF",
+		"token_auth.remote": true,
+		"token_auth.status": "Accepted",
+	})
+
+	tokenInfo := tokenAuthService.Authorize(ctx, ocpp201.IdTokenType{
+		Type:    ocpp201.IdTokenEnumTypeISO14443,
+		IdToken: "BADC0FFEE",
+	})
+	assert.Equal(t, ocpp201.AuthorizationStatusEnumTypeInvalid, tokenInfo.Status)
+
+	tokenInfo = tokenAuthService.Authorize(ctx, ocpp201.IdTokenType{
+		Type:    ocpp201.IdTokenEnumTypeEMAID,
+		IdToken: "UNKNOWN",
+	})
+	assert.Equal(t, ocpp201.AuthorizationStatusEnumTypeUnknown, tokenInfo.Status)
+
+	assert.Equal(t, []string{"ISO14443:DEADBEEF", "ISO14443:BADC0FFEE", "eMAID:UNKNOWN"}, remoteAuthorizer.requested)
+}
+
+func TestRemoteTokenAuthorizersAsksEachAuthorizerUntilTokenIsFound(t *testing.T) {
+	first := &fakeRemoteTokenAuthorizer{
+		tokens: map[string]*store.Token{
+			"DEADBEEF": {Uid: "DEADBEEF", Valid: true},
+		},
+	}
+	second := &fakeRemoteTokenAuthorizer{
+		tokens: map[string]*store.Token{
+			"DEADBEEF":  {Uid: "DEADBEEF", Valid: false},
+			"BADC0FFEE": {Uid: "BADC0FFEE", Valid: true},
+		},
+	}
+	authorizers := services.RemoteTokenAuthorizers{first, second}
+
+	tok, err := authorizers.AuthorizeToken(context.Background(), "DEADBEEF", "ISO14443")
+	require.NoError(t, err)
+	assert.True(t, tok.Valid)
+
+	tok, err = authorizers.AuthorizeToken(context.Background(), "BADC0FFEE", "ISO14443")
+	require.NoError(t, err)
+	assert.Equal(t, "BADC0FFEE", tok.Uid)
+
+	tok, err = authorizers.AuthorizeToken(context.Background(), "UNKNOWN", "ISO14443")
+	require.NoError(t, err)
+	assert.Nil(t, tok)
+
+	assert.Equal(t, []string{"ISO14443:DEADBEEF", "ISO14443:BADC0FFEE", "ISO14443:UNKNOWN"}, first.requested)
+	assert.Equal(t, []string{"ISO14443:BADC0FFEE", "ISO14443:UNKNOWN"}, second.requested)
+}
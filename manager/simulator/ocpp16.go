@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"strconv"
+	"time"
+)
+
+// ocpp16Protocol simulates an OCPP 1.6 charge point
+type ocpp16Protocol struct{}
+
+// ocpp16ReserveNow is the part of a 1.6 ReserveNow request that the simulator uses
+type ocpp16ReserveNow struct {
+	ConnectorId   int    `json:"connectorId"`
+	ExpiryDate    string `json:"expiryDate"`
+	ReservationId int    `json:"reservationId"`
+}
+
+// ocpp16CancelReservation is a 1.6 CancelReservation request
+type ocpp16CancelReservation struct {
+	ReservationId int `json:"reservationId"`
+}
+
+// ocpp16Status is a 1.6 response that only has a status
+type ocpp16Status struct {
+	Status string `json:"status"`
+}
+
+func (ocpp16Protocol) boot(ctx context.Context, s *Station) (bool, time.Duration, error) {
+	var resp ocpp16.BootNotificationResponseJson
+	err := s.call(ctx, "BootNotification", &ocpp16.BootNotificationJson{
+		ChargePointVendor: vendor,
+		ChargePointModel:  model,
+	}, &resp)
+	if err != nil {
+		return false, 0, err
+	}
+	return resp.Status == ocpp16.BootNotificationResponseJsonStatusAccepted, time.Duration(resp.Interval) * time.Second, nil
+}
+
+func (ocpp16Protocol) heartbeat(ctx context.Context, s *Station) error {
+	var resp ocpp16.HeartbeatResponseJson
+	return s.call(ctx, "Heartbeat", &ocpp16.HeartbeatJson{}, &resp)
+}
+
+func (ocpp16Protocol) status(ctx context.Context, s *Station, status connectorStatus) error {
+	var connectorStatus ocpp16.StatusNotificationJsonStatus
+	switch status {
+	case connectorOccupied:
+		connectorStatus = ocpp16.StatusNotificationJsonStatusCharging
+	case connectorReserved:
+		connectorStatus = ocpp16.StatusNotificationJsonStatusReserved
+	default:
+		connectorStatus = ocpp16.StatusNotificationJsonStatusAvailable
+	}
+	timestamp := now()
+	var resp ocpp16.StatusNotificationResponseJson
+	return s.call(ctx, "StatusNotification", &ocpp16.StatusNotificationJson{
+		ConnectorId: 1,
+		ErrorCode:   ocpp16.StatusNotificationJsonErrorCodeNoError,
+		Status:      connectorStatus,
+		Timestamp:   &timestamp,
+	}, &resp)
+}
+
+func (ocpp16Protocol) authorize(ctx context.Context, s *Station) (bool, error) {
+	var resp ocpp16.AuthorizeResponseJson
+	err := s.call(ctx, "Authorize", &ocpp16.AuthorizeJson{IdTag: s.cfg.IdToken}, &resp)
+	if err != nil {
+		return false, err
+	}
+	return resp.IdTagInfo.Status == ocpp16.AuthorizeResponseJsonIdTagInfoStatusAccepted, nil
+}
+
+func (ocpp16Protocol) startTransaction(ctx context.Context, s *Station, reservationId *int) (*transaction, error) {
+	var resp ocpp16.StartTransactionResponseJson
+	err := s.call(ctx, "StartTransaction", &ocpp16.StartTransactionJson{
+		ConnectorId:   1,
+		IdTag:         s.cfg.IdToken,
+		MeterStart:    s.meter,
+		ReservationId: reservationId,
+		Timestamp:     now(),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction{id: strconv.Itoa(resp.TransactionId)}, nil
+}
+
+func (ocpp16Protocol) meterValues(ctx context.Context, s *Station, tx *transaction) error {
+	transactionId, err := strconv.Atoi(tx.id)
+	if err != nil {
+		return err
+	}
+	measurand := ocpp16.MeterValuesJsonMeterValueElemSampledValueElemMeasurandEnergyActiveImportRegister
+	unit := ocpp16.MeterValuesJsonMeterValueElemSampledValueElemUnitWh
+	var resp ocpp16.MeterValuesResponseJson
+	return s.call(ctx, "MeterValues", &ocpp16.MeterValuesJson{
+		ConnectorId:   1,
+		TransactionId: &transactionId,
+		MeterValue: []ocpp16.MeterValuesJsonMeterValueElem{
+			{
+				Timestamp: now(),
+				SampledValue: []ocpp16.MeterValuesJsonMeterValueElemSampledValueElem{
+					{
+						Measurand: &measurand,
+						Unit:      &unit,
+						Value:     strconv.Itoa(s.meter),
+					},
+				},
+			},
+		},
+	}, &resp)
+}
+
+func (ocpp16Protocol) stopTransaction(ctx context.Context, s *Station, tx *transaction, remote bool) error {
+	transactionId, err := strconv.Atoi(tx.id)
+	if err != nil {
+		return err
+	}
+	reason := ocpp16.StopTransactionJsonReasonLocal
+	if remote {
+		reason = ocpp16.StopTransactionJsonReasonRemote
+	}
+	var resp ocpp16.StopTransactionResponseJson
+	return s.call(ctx, "StopTransaction", &ocpp16.StopTransactionJson{
+		IdTag:         &s.cfg.IdToken,
+		MeterStop:     s.meter,
+		Reason:        &reason,
+		Timestamp:     now(),
+		TransactionId: transactionId,
+	}, &resp)
+}
+
+func (ocpp16Protocol) handleCall(ctx context.Context, s *Station, action string, payload json.RawMessage) (any, error) {
+	switch action {
+	case "ReserveNow":
+		var req ocpp16ReserveNow
+		err := json.Unmarshal(payload, &req)
+		if err != nil {
+			return nil, err
+		}
+		expiry, err := time.Parse(time.RFC3339, req.ExpiryDate)
+		if err != nil {
+			return nil, err
+		}
+		if req.ConnectorId > 1 {
+			return &ocpp16Status{Status: "Rejected"}, nil
+		}
+		if !s.reserve(req.ReservationId, expiry) {
+			return &ocpp16Status{Status: "Occupied"}, nil
+		}
+		s.sendStatus(ctx, connectorReserved)
+		return &ocpp16Status{Status: "Accepted"}, nil
+	case "CancelReservation":
+		var req ocpp16CancelReservation
+		err := json.Unmarshal(payload, &req)
+		if err != nil {
+			return nil, err
+		}
+		if !s.cancelReservation(req.ReservationId) {
+			return &ocpp16Status{Status: "Rejected"}, nil
+		}
+		s.sendStatus(ctx, connectorAvailable)
+		return &ocpp16Status{Status: "Accepted"}, nil
+	case "RemoteStartTransaction":
+		if s.currentTransaction() != nil {
+			return &ocpp16Status{Status: "Rejected"}, nil
+		}
+		s.requestStart()
+		return &ocpp16Status{Status: "Accepted"}, nil
+	case "RemoteStopTransaction":
+		if s.currentTransaction() == nil {
+			return &ocpp16Status{Status: "Rejected"}, nil
+		}
+		s.requestStop()
+		return &ocpp16Status{Status: "Accepted"}, nil
+	case "GetConfiguration":
+		return map[string]any{"configurationKey": []any{}}, nil
+	case "ChangeAvailability", "ChangeConfiguration", "ClearCache", "ClearChargingProfile", "Reset",
+		"SetChargingProfile", "TriggerMessage", "UnlockConnector":
+		return &ocpp16Status{Status: "Accepted"}, nil
+	default:
+		return nil, callError{code: "NotImplemented", description: action + " is not simulated"}
+	}
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"time"
+)
+
+// ocpp201Protocol simulates an OCPP 2.0.1 charging station with a single EVSE
+type ocpp201Protocol struct{}
+
+// ocpp201Status is a 2.0.1 response that only has a status
+type ocpp201Status struct {
+	Status string `json:"status"`
+}
+
+func (ocpp201Protocol) boot(ctx context.Context, s *Station) (bool, time.Duration, error) {
+	var resp ocpp201.BootNotificationResponseJson
+	err := s.call(ctx, "BootNotification", &ocpp201.BootNotificationRequestJson{
+		ChargingStation: ocpp201.ChargingStationType{
+			VendorName: vendor,
+			Model:      model,
+		},
+		Reason: ocpp201.BootReasonEnumTypePowerUp,
+	}, &resp)
+	if err != nil {
+		return false, 0, err
+	}
+	return resp.Status == ocpp201.RegistrationStatusEnumTypeAccepted, time.Duration(resp.Interval) * time.Second, nil
+}
+
+func (ocpp201Protocol) heartbeat(ctx context.Context, s *Station) error {
+	var resp ocpp201.HeartbeatResponseJson
+	return s.call(ctx, "Heartbeat", &ocpp201.HeartbeatRequestJson{}, &resp)
+}
+
+func (ocpp201Protocol) status(ctx context.Context, s *Station, status connectorStatus) error {
+	var connectorStatus ocpp201.ConnectorStatusEnumType
+	switch status {
+	case connectorOccupied:
+		connectorStatus = ocpp201.ConnectorStatusEnumTypeOccupied
+	case connectorReserved:
+		connectorStatus = ocpp201.ConnectorStatusEnumTypeReserved
+	default:
+		connectorStatus = ocpp201.ConnectorStatusEnumTypeAvailable
+	}
+	var resp ocpp201.StatusNotificationResponseJson
+	return s.call(ctx, "StatusNotification", &ocpp201.StatusNotificationRequestJson{
+		ConnectorId:     1,
+		ConnectorStatus: connectorStatus,
+		EvseId:          1,
+		Timestamp:       now(),
+	}, &resp)
+}
+
+func (ocpp201Protocol) idToken(s *Station) *ocpp201.IdTokenType {
+	return &ocpp201.IdTokenType{
+		IdToken: s.cfg.IdToken,
+		Type:    ocpp201.IdTokenEnumType(s.cfg.IdTokenType),
+	}
+}
+
+func (p ocpp201Protocol) authorize(ctx context.Context, s *Station) (bool, error) {
+	var resp ocpp201.AuthorizeResponseJson
+	err := s.call(ctx, "Authorize", &ocpp201.AuthorizeRequestJson{IdToken: *p.idToken(s)}, &resp)
+	if err != nil {
+		return false, err
+	}
+	return resp.IdTokenInfo.Status == ocpp201.AuthorizationStatusEnumTypeAccepted, nil
+}
+
+func (p ocpp201Protocol) startTransaction(ctx context.Context, s *Station, reservationId *int) (*transaction, error) {
+	tx := &transaction{id: uuid.Must(uuid.NewV7()).String()}
+	connectorId := 1
+	chargingState := ocpp201.ChargingStateEnumTypeCharging
+	err := p.transactionEvent(ctx, s, tx, &ocpp201.TransactionEventRequestJson{
+		EventType:     ocpp201.TransactionEventEnumTypeStarted,
+		Evse:          &ocpp201.EVSEType{Id: 1, ConnectorId: &connectorId},
+		IdToken:       p.idToken(s),
+		ReservationId: reservationId,
+		TransactionInfo: ocpp201.TransactionType{
+			ChargingState: &chargingState,
+		},
+		TriggerReason: ocpp201.TriggerReasonEnumTypeAuthorized,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (p ocpp201Protocol) meterValues(ctx context.Context, s *Station, tx *transaction) error {
+	return p.transactionEvent(ctx, s, tx, &ocpp201.TransactionEventRequestJson{
+		EventType:     ocpp201.TransactionEventEnumTypeUpdated,
+		TriggerReason: ocpp201.TriggerReasonEnumTypeMeterValuePeriodic,
+	})
+}
+
+func (p ocpp201Protocol) stopTransaction(ctx context.Context, s *Station, tx *transaction, remote bool) error {
+	stoppedReason, triggerReason := ocpp201.ReasonEnumTypeLocal, ocpp201.TriggerReasonEnumTypeStopAuthorized
+	if remote {
+		stoppedReason, triggerReason = ocpp201.ReasonEnumTypeRemote, ocpp201.TriggerReasonEnumTypeRemoteStop
+	}
+	return p.transactionEvent(ctx, s, tx, &ocpp201.TransactionEventRequestJson{
+		EventType: ocpp201.TransactionEventEnumTypeEnded,
+		IdToken:   p.idToken(s),
+		TransactionInfo: ocpp201.TransactionType{
+			StoppedReason: &stoppedReason,
+		},
+		TriggerReason: triggerReason,
+	})
+}
+
+// transactionEvent completes the TransactionEvent for the transaction with its
+// sequence number and the current meter reading, and sends it
+func (ocpp201Protocol) transactionEvent(ctx context.Context, s *Station, tx *transaction, req *ocpp201.TransactionEventRequestJson) error {
+	measurand := ocpp201.MeasurandEnumTypeEnergyActiveImportRegister
+	timestamp := now()
+	req.SeqNo = tx.seqNo
+	req.Timestamp = timestamp
+	req.TransactionInfo.TransactionId = tx.id
+	req.MeterValue = []ocpp201.MeterValueType{
+		{
+			Timestamp: timestamp,
+			SampledValue: []ocpp201.SampledValueType{
+				{
+					Measurand:     &measurand,
+					UnitOfMeasure: &ocpp201.UnitOfMeasureType{Unit: "Wh"},
+					Value:         float64(s.meter),
+				},
+			},
+		},
+	}
+	tx.seqNo++
+
+	var resp ocpp201.TransactionEventResponseJson
+	return s.call(ctx, "TransactionEvent", req, &resp)
+}
+
+func (ocpp201Protocol) handleCall(ctx context.Context, s *Station, action string, payload json.RawMessage) (any, error) {
+	switch action {
+	case "ReserveNow":
+		var req ocpp201.ReserveNowRequestJson
+		err := json.Unmarshal(payload, &req)
+		if err != nil {
+			return nil, err
+		}
+		expiry, err := time.Parse(time.RFC3339, req.ExpiryDateTime)
+		if err != nil {
+			return nil, err
+		}
+		if req.EvseId != nil && *req.EvseId > 1 {
+			return &ocpp201.ReserveNowResponseJson{Status: ocpp201.ReserveNowStatusEnumTypeRejected}, nil
+		}
+		if !s.reserve(req.Id, expiry) {
+			return &ocpp201.ReserveNowResponseJson{Status: ocpp201.ReserveNowStatusEnumTypeOccupied}, nil
+		}
+		s.sendStatus(ctx, connectorReserved)
+		return &ocpp201.ReserveNowResponseJson{Status: ocpp201.ReserveNowStatusEnumTypeAccepted}, nil
+	case "CancelReservation":
+		var req ocpp201.CancelReservationRequestJson
+		err := json.Unmarshal(payload, &req)
+		if err != nil {
+			return nil, err
+		}
+		if !s.cancelReservation(req.ReservationId) {
+			return &ocpp201.CancelReservationResponseJson{Status: ocpp201.CancelReservationStatusEnumTypeRejected}, nil
+		}
+		s.sendStatus(ctx, connectorAvailable)
+		return &ocpp201.CancelReservationResponseJson{Status: ocpp201.CancelReservationStatusEnumTypeAccepted}, nil
+	case "RequestStartTransaction":
+		if s.currentTransaction() != nil {
+			return &ocpp201.RequestStartTransactionResponseJson{Status: ocpp201.RequestStartStopStatusEnumTypeRejected}, nil
+		}
+		s.requestStart()
+		return &ocpp201.RequestStartTransactionResponseJson{Status: ocpp201.RequestStartStopStatusEnumTypeAccepted}, nil
+	case "RequestStopTransaction":
+		var req ocpp201.RequestStopTransactionRequestJson
+		err := json.Unmarshal(payload, &req)
+		if err != nil {
+			return nil, err
+		}
+		tx := s.currentTransaction()
+		if tx == nil || tx.id != req.TransactionId {
+			return &ocpp201.RequestStopTransactionResponseJson{Status: ocpp201.RequestStartStopStatusEnumTypeRejected}, nil
+		}
+		s.requestStop()
+		return &ocpp201.RequestStopTransactionResponseJson{Status: ocpp201.RequestStartStopStatusEnumTypeAccepted}, nil
+	case "SetVariables":
+		var req ocpp201.SetVariablesRequestJson
+		err := json.Unmarshal(payload, &req)
+		if err != nil {
+			return nil, err
+		}
+		resp := &ocpp201.SetVariablesResponseJson{SetVariableResult: []ocpp201.SetVariableResultType{}}
+		for _, data := range req.SetVariableData {
+			resp.SetVariableResult = append(resp.SetVariableResult, ocpp201.SetVariableResultType{
+				AttributeStatus: ocpp201.SetVariableStatusEnumTypeAccepted,
+				AttributeType:   data.AttributeType,
+				Component:       data.Component,
+				Variable:        data.Variable,
+			})
+		}
+		return resp, nil
+	case "ChangeAvailability", "ClearCache", "ClearChargingProfile", "Reset", "SetChargingProfile",
+		"TriggerMessage", "UnlockConnector", "CertificateSigned", "InstallCertificate", "DeleteCertificate":
+		return &ocpp201Status{Status: "Accepted"}, nil
+	default:
+		return nil, callError{code: "NotImplemented", description: action + " is not simulated"}
+	}
+}
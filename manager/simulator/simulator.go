@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package simulator simulates charge stations that connect to the gateway, for load
+// testing and demonstrating the CSMS.
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	vendor = "MaEVe"
+	model  = "Simulator"
+)
+
+// Config describes how the simulated charge stations connect to the gateway and
+// behave
+type Config struct {
+	// Url is the gateway's websocket URL, e.g. ws://localhost/ws, to which the charge
+	// station id is appended
+	Url         string
+	OcppVersion transport.OcppVersion
+	// Password is used for basic authentication with the gateway if it is set
+	Password string
+	// IdToken authorizes the simulated transactions and IdTokenType is its OCPP 2.0.1
+	// type
+	IdToken     string
+	IdTokenType string
+	// TransactionInterval is the mean time between transactions: the times are
+	// exponentially distributed
+	TransactionInterval time.Duration
+	// TransactionDuration is the mean duration of a transaction: the durations are
+	// uniformly distributed between half and one and a half times it
+	TransactionDuration time.Duration
+	MeterValueInterval  time.Duration
+	CallTimeout         time.Duration
+	// ReconnectDelay is how long a charge station waits to reconnect after it is
+	// disconnected
+	ReconnectDelay time.Duration
+}
+
+// DefaultConfig is the default simulator configuration
+var DefaultConfig = Config{
+	Url:                 "ws://localhost/ws",
+	OcppVersion:         transport.OcppVersion201,
+	IdToken:             "SIMULATOR",
+	IdTokenType:         "ISO14443",
+	TransactionInterval: 5 * time.Minute,
+	TransactionDuration: 2 * time.Minute,
+	MeterValueInterval:  30 * time.Second,
+	CallTimeout:         30 * time.Second,
+	ReconnectDelay:      10 * time.Second,
+}
+
+// Stats counts what the simulated charge stations have done
+type Stats struct {
+	Connected     atomic.Int64
+	Booted        atomic.Int64
+	Disconnects   atomic.Int64
+	Calls         atomic.Int64
+	CallErrors    atomic.Int64
+	CallsReceived atomic.Int64
+	Transactions  atomic.Int64
+	Unauthorized  atomic.Int64
+	Reservations  atomic.Int64
+}
+
+func (s *Stats) String() string {
+	return fmt.Sprintf("connected=%d booted=%d disconnects=%d calls=%d callErrors=%d callsReceived=%d transactions=%d unauthorized=%d reservations=%d",
+		s.Connected.Load(), s.Booted.Load(), s.Disconnects.Load(), s.Calls.Load(), s.CallErrors.Load(),
+		s.CallsReceived.Load(), s.Transactions.Load(), s.Unauthorized.Load(), s.Reservations.Load())
+}
+
+// Simulator runs a number of simulated charge stations, with ids made from a prefix
+// and a sequence number starting at 1
+type Simulator struct {
+	Config Config
+	Count  int
+	Prefix string
+	// RampUp is the time over which the charge stations are started
+	RampUp time.Duration
+	Stats  Stats
+}
+
+// Run runs the charge stations until the context is done: a charge station that is
+// disconnected reconnects after the reconnect delay
+func (sim *Simulator) Run(ctx context.Context) error {
+	var stations []*Station
+	for i := 1; i <= sim.Count; i++ {
+		station, err := newStation(fmt.Sprintf("%s%d", sim.Prefix, i), &sim.Config, &sim.Stats, time.Now().UnixNano()+int64(i))
+		if err != nil {
+			return err
+		}
+		stations = append(stations, station)
+	}
+
+	var wg sync.WaitGroup
+	for i, station := range stations {
+		if i > 0 && sim.RampUp > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(sim.RampUp / time.Duration(len(stations))):
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(station *Station) {
+			defer wg.Done()
+			sim.runStation(ctx, station)
+		}(station)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (sim *Simulator) runStation(ctx context.Context, station *Station) {
+	for {
+		err := station.Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		sim.Stats.Disconnects.Add(1)
+		slog.Warn("simulated charge station disconnected", "chargeStationId", station.id, "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(sim.Config.ReconnectDelay):
+		}
+	}
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
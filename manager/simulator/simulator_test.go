@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/simulator"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"net/http"
+	"net/http/httptest"
+	"nhooyr.io/websocket"
+	"strings"
+	"testing"
+	"time"
+)
+
+type receivedCall struct {
+	chargeStationId string
+	action          string
+	payload         map[string]any
+}
+
+// fakeCsms accepts connections from simulated charge stations, answers their calls and
+// can make calls to them
+type fakeCsms struct {
+	t       *testing.T
+	calls   chan receivedCall
+	results chan []json.RawMessage
+	conns   chan *websocket.Conn
+}
+
+func newFakeCsms(t *testing.T) (*fakeCsms, *httptest.Server) {
+	csms := &fakeCsms{
+		t:       t,
+		calls:   make(chan receivedCall, 100),
+		results: make(chan []json.RawMessage, 10),
+		conns:   make(chan *websocket.Conn, 1),
+	}
+	server := httptest.NewServer(csms)
+	t.Cleanup(server.Close)
+	return csms, server
+}
+
+func (f *fakeCsms) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		Subprotocols: []string{string(transport.OcppVersion16), string(transport.OcppVersion201)},
+	})
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "")
+	}()
+	f.conns <- conn
+	chargeStationId := strings.TrimPrefix(r.URL.Path, "/ws/")
+
+	for {
+		_, data, err := conn.Read(r.Context())
+		if err != nil {
+			return
+		}
+		var frame []json.RawMessage
+		if json.Unmarshal(data, &frame) != nil {
+			continue
+		}
+		var messageType transport.MessageType
+		_ = json.Unmarshal(frame[0], &messageType)
+		if messageType != transport.MessageTypeCall {
+			f.results <- frame
+			continue
+		}
+
+		var id, action string
+		_ = json.Unmarshal(frame[1], &id)
+		_ = json.Unmarshal(frame[2], &action)
+		var payload map[string]any
+		_ = json.Unmarshal(frame[3], &payload)
+		f.calls <- receivedCall{chargeStationId: chargeStationId, action: action, payload: payload}
+
+		response := map[string]any{}
+		switch action {
+		case "BootNotification":
+			response = map[string]any{"currentTime": time.Now().Format(time.RFC3339), "interval": 60, "status": "Accepted"}
+		case "Authorize":
+			response = map[string]any{
+				"idTagInfo":   map[string]any{"status": "Accepted"},
+				"idTokenInfo": map[string]any{"status": "Accepted"},
+			}
+		case "StartTransaction":
+			response = map[string]any{"idTagInfo": map[string]any{"status": "Accepted"}, "transactionId": 42}
+		}
+		msg, _ := json.Marshal([]any{transport.MessageTypeCallResult, id, response})
+		_ = conn.Write(r.Context(), websocket.MessageText, msg)
+	}
+}
+
+// expectCall waits for the charge station to make a call with the action
+func (f *fakeCsms) expectCall(action string) receivedCall {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case call := <-f.calls:
+			if call.action == action {
+				return call
+			}
+		case <-timeout:
+			f.t.Fatalf("timed out waiting for %s", action)
+		}
+	}
+}
+
+// makeCall makes a call to the charge station and returns its response
+func (f *fakeCsms) makeCall(conn *websocket.Conn, action string, payload any) []json.RawMessage {
+	msg, err := json.Marshal([]any{transport.MessageTypeCall, "csms-1", action, payload})
+	require.NoError(f.t, err)
+	require.NoError(f.t, conn.Write(context.Background(), websocket.MessageText, msg))
+	select {
+	case result := <-f.results:
+		return result
+	case <-time.After(5 * time.Second):
+		f.t.Fatalf("timed out waiting for %s result", action)
+		return nil
+	}
+}
+
+func runSimulator(t *testing.T, server *httptest.Server, ocppVersion transport.OcppVersion, transactionDuration time.Duration) *simulator.Simulator {
+	cfg := simulator.DefaultConfig
+	cfg.Url = "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+	cfg.OcppVersion = ocppVersion
+	cfg.TransactionInterval = time.Hour
+	cfg.TransactionDuration = transactionDuration
+	cfg.MeterValueInterval = 50 * time.Millisecond
+	cfg.CallTimeout = 5 * time.Second
+
+	sim := &simulator.Simulator{Config: cfg, Count: 1, Prefix: "cs"}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = sim.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return sim
+}
+
+func TestSimulatorOcpp16ReservedTransaction(t *testing.T) {
+	csms, server := newFakeCsms(t)
+	sim := runSimulator(t, server, transport.OcppVersion16, 200*time.Millisecond)
+
+	boot := csms.expectCall("BootNotification")
+	assert.Equal(t, "cs1", boot.chargeStationId)
+	status := csms.expectCall("StatusNotification")
+	assert.Equal(t, "Available", status.payload["status"])
+	conn := <-csms.conns
+
+	result := csms.makeCall(conn, "ReserveNow", map[string]any{
+		"connectorId":   1,
+		"expiryDate":    time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"idTag":         "SIMULATOR",
+		"reservationId": 7,
+	})
+	assert.JSONEq(t, `{"status":"Accepted"}`, string(result[2]))
+	status = csms.expectCall("StatusNotification")
+	assert.Equal(t, "Reserved", status.payload["status"])
+
+	result = csms.makeCall(conn, "RemoteStartTransaction", map[string]any{"idTag": "SIMULATOR"})
+	assert.JSONEq(t, `{"status":"Accepted"}`, string(result[2]))
+
+	csms.expectCall("Authorize")
+	start := csms.expectCall("StartTransaction")
+	assert.Equal(t, float64(7), start.payload["reservationId"])
+	csms.expectCall("MeterValues")
+	stop := csms.expectCall("StopTransaction")
+	assert.Equal(t, float64(42), stop.payload["transactionId"])
+	assert.Equal(t, "Local", stop.payload["reason"])
+	status = csms.expectCall("StatusNotification")
+	assert.Equal(t, "Available", status.payload["status"])
+
+	assert.Equal(t, int64(1), sim.Stats.Transactions.Load())
+	assert.Equal(t, int64(1), sim.Stats.Reservations.Load())
+}
+
+func TestSimulatorOcpp201Transaction(t *testing.T) {
+	csms, server := newFakeCsms(t)
+	runSimulator(t, server, transport.OcppVersion201, time.Hour)
+
+	csms.expectCall("BootNotification")
+	status := csms.expectCall("StatusNotification")
+	assert.Equal(t, "Available", status.payload["connectorStatus"])
+	conn := <-csms.conns
+
+	result := csms.makeCall(conn, "RequestStartTransaction", map[string]any{
+		"idToken":       map[string]any{"idToken": "SIMULATOR", "type": "ISO14443"},
+		"remoteStartId": 1,
+	})
+	assert.JSONEq(t, `{"status":"Accepted"}`, string(result[2]))
+
+	csms.expectCall("Authorize")
+	started := csms.expectCall("TransactionEvent")
+	assert.Equal(t, "Started", started.payload["eventType"])
+	assert.Equal(t, float64(0), started.payload["seqNo"])
+	transactionId := started.payload["transactionInfo"].(map[string]any)["transactionId"]
+
+	result = csms.makeCall(conn, "RequestStopTransaction", map[string]any{"transactionId": transactionId})
+	assert.JSONEq(t, `{"status":"Accepted"}`, string(result[2]))
+
+	ended := csms.expectCall("TransactionEvent")
+	for ended.payload["eventType"] == "Updated" {
+		ended = csms.expectCall("TransactionEvent")
+	}
+	assert.Equal(t, "Ended", ended.payload["eventType"])
+	assert.Equal(t, transactionId, ended.payload["transactionInfo"].(map[string]any)["transactionId"])
+	assert.Equal(t, "Remote", ended.payload["transactionInfo"].(map[string]any)["stoppedReason"])
+}
+
+func TestSimulatorRespondsToSetVariables(t *testing.T) {
+	csms, server := newFakeCsms(t)
+	runSimulator(t, server, transport.OcppVersion201, time.Hour)
+
+	csms.expectCall("StatusNotification")
+	conn := <-csms.conns
+
+	result := csms.makeCall(conn, "SetVariables", map[string]any{
+		"setVariableData": []any{
+			map[string]any{
+				"attributeValue": "60",
+				"component":      map[string]any{"name": "OCPPCommCtrlr"},
+				"variable":       map[string]any{"name": "HeartbeatInterval"},
+			},
+		},
+	})
+	assert.JSONEq(t, `{"setVariableResult":[{"attributeStatus":"Accepted","component":{"name":"OCPPCommCtrlr"},"variable":{"name":"HeartbeatInterval"}}]}`, string(result[2]))
+}
+
+func TestSimulatorRejectsUnsimulatedCalls(t *testing.T) {
+	csms, server := newFakeCsms(t)
+	runSimulator(t, server, transport.OcppVersion16, time.Hour)
+
+	csms.expectCall("StatusNotification")
+	conn := <-csms.conns
+
+	result := csms.makeCall(conn, "UpdateFirmware", map[string]any{})
+	var messageType transport.MessageType
+	require.NoError(t, json.Unmarshal(result[0], &messageType))
+	assert.Equal(t, transport.MessageTypeCallError, messageType)
+	assert.JSONEq(t, `"NotImplemented"`, string(result[2]))
+}
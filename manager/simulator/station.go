@@ -0,0 +1,494 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+	"math/rand"
+	"net/http"
+	"nhooyr.io/websocket"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// connectorStatus is the status of the simulated connector, which each protocol
+// reports in its own terms
+type connectorStatus int
+
+const (
+	connectorAvailable connectorStatus = iota
+	connectorOccupied
+	connectorReserved
+)
+
+// transaction is a transaction in progress on the simulated connector
+type transaction struct {
+	id    string
+	seqNo int
+}
+
+// reservation is a reservation of the simulated connector made by the CSMS
+type reservation struct {
+	id     int
+	expiry time.Time
+}
+
+// callError is returned by a protocol's handleCall to answer a call from the CSMS
+// with a CallError
+type callError struct {
+	code        string
+	description string
+}
+
+func (c callError) Error() string {
+	return fmt.Sprintf("%s: %s", c.code, c.description)
+}
+
+// protocol builds the messages that a simulated charge station exchanges with the
+// CSMS in a specific OCPP version
+type protocol interface {
+	// boot sends the BootNotification: it returns whether the charge station was
+	// accepted and the heartbeat (or retry) interval
+	boot(ctx context.Context, s *Station) (bool, time.Duration, error)
+	heartbeat(ctx context.Context, s *Station) error
+	status(ctx context.Context, s *Station, status connectorStatus) error
+	authorize(ctx context.Context, s *Station) (bool, error)
+	startTransaction(ctx context.Context, s *Station, reservationId *int) (*transaction, error)
+	meterValues(ctx context.Context, s *Station, tx *transaction) error
+	// stopTransaction stops the transaction locally or, if remote is set, because the
+	// CSMS asked for it to be stopped
+	stopTransaction(ctx context.Context, s *Station, tx *transaction, remote bool) error
+	// handleCall answers a call made by the CSMS
+	handleCall(ctx context.Context, s *Station, action string, payload json.RawMessage) (any, error)
+}
+
+// Station is a simulated charge station with a single connector. It boots, sends
+// heartbeats and makes transactions at random intervals, and accepts reservations and
+// remote start and stop requests from the CSMS.
+type Station struct {
+	id    string
+	cfg   *Config
+	stats *Stats
+	proto protocol
+	conn  *websocket.Conn
+	rand  *rand.Rand
+
+	// a charge station waits for the response to one call before making another
+	callMu  sync.Mutex
+	nextId  atomic.Int64
+	mu      sync.Mutex
+	pending map[string]chan callResult
+
+	reservation *reservation
+	transaction *transaction
+	remoteStart chan struct{}
+	remoteStop  chan struct{}
+	meter       int
+}
+
+type callResult struct {
+	payload json.RawMessage
+	err     error
+}
+
+func newStation(id string, cfg *Config, stats *Stats, seed int64) (*Station, error) {
+	var proto protocol
+	switch cfg.OcppVersion {
+	case transport.OcppVersion16:
+		proto = ocpp16Protocol{}
+	case transport.OcppVersion201:
+		proto = ocpp201Protocol{}
+	default:
+		return nil, fmt.Errorf("unsupported ocpp version: %s", cfg.OcppVersion)
+	}
+
+	return &Station{
+		id:    id,
+		cfg:   cfg,
+		stats: stats,
+		proto: proto,
+		// #nosec G404 - the simulated behaviour does not need to be unpredictable
+		rand:        rand.New(rand.NewSource(seed)),
+		pending:     make(map[string]chan callResult),
+		remoteStart: make(chan struct{}, 1),
+		remoteStop:  make(chan struct{}, 1),
+	}, nil
+}
+
+// Run connects the charge station to the gateway and simulates it until the context
+// is done or the connection fails
+func (s *Station) Run(ctx context.Context) error {
+	header := http.Header{}
+	if s.cfg.Password != "" {
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(s.id+":"+s.cfg.Password)))
+	}
+	conn, _, err := websocket.Dial(ctx, s.cfg.Url+"/"+s.id, &websocket.DialOptions{
+		HTTPHeader:   header,
+		Subprotocols: []string{string(s.cfg.OcppVersion)},
+	})
+	if err != nil {
+		return fmt.Errorf("connecting %s: %w", s.id, err)
+	}
+	s.conn = conn
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "")
+	}()
+	s.stats.Connected.Add(1)
+	defer s.stats.Connected.Add(-1)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	go func() {
+		cancel(s.read(ctx))
+	}()
+
+	interval, err := s.boot(ctx)
+	if err != nil {
+		return s.result(ctx, err)
+	}
+	s.stats.Booted.Add(1)
+	defer s.stats.Booted.Add(-1)
+
+	err = s.proto.status(ctx, s, connectorAvailable)
+	if err != nil {
+		return s.result(ctx, err)
+	}
+
+	go func() {
+		cancel(s.heartbeat(ctx, interval))
+	}()
+
+	return s.result(ctx, s.transact(ctx))
+}
+
+// result returns the reason that the charge station stopped: nil if the context was
+// done, otherwise the error that stopped it
+func (s *Station) result(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); cause != nil && !errors.Is(cause, context.Canceled) {
+		return cause
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// boot sends BootNotifications until the charge station is accepted and returns the
+// heartbeat interval
+func (s *Station) boot(ctx context.Context) (time.Duration, error) {
+	for {
+		accepted, interval, err := s.proto.boot(ctx, s)
+		if err != nil {
+			return 0, err
+		}
+		if interval <= 0 {
+			interval = time.Minute
+		}
+		if accepted {
+			return interval, nil
+		}
+		slog.Info("simulated charge station not accepted", "chargeStationId", s.id, "retryIn", interval)
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (s *Station) heartbeat(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			err := s.proto.heartbeat(ctx, s)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// transact makes transactions at random intervals, or when the CSMS asks for one to
+// be started, until the context is done
+func (s *Station) transact(ctx context.Context) error {
+	for {
+		wait := time.Duration(s.rand.ExpFloat64() * float64(s.cfg.TransactionInterval))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		case <-s.remoteStart:
+		}
+
+		err := s.runTransaction(ctx)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Station) runTransaction(ctx context.Context) error {
+	authorized, err := s.proto.authorize(ctx, s)
+	if err != nil {
+		return err
+	}
+	if !authorized {
+		s.stats.Unauthorized.Add(1)
+		return nil
+	}
+
+	err = s.proto.status(ctx, s, connectorOccupied)
+	if err != nil {
+		return err
+	}
+	tx, err := s.proto.startTransaction(ctx, s, s.takeReservation())
+	if err != nil {
+		return err
+	}
+	s.setTransaction(tx)
+	defer s.setTransaction(nil)
+	s.stats.Transactions.Add(1)
+
+	// the duration is uniformly distributed around the configured duration
+	duration := s.cfg.TransactionDuration/2 + time.Duration(s.rand.Int63n(int64(s.cfg.TransactionDuration)+1))
+	end := time.NewTimer(duration)
+	defer end.Stop()
+	ticker := time.NewTicker(s.cfg.MeterValueInterval)
+	defer ticker.Stop()
+
+	remote := false
+	for charging := true; charging; {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-end.C:
+			charging = false
+		case <-s.remoteStop:
+			charging, remote = false, true
+		case <-ticker.C:
+			s.meter += 100 + s.rand.Intn(1000)
+			err = s.proto.meterValues(ctx, s, tx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = s.proto.stopTransaction(ctx, s, tx, remote)
+	if err != nil {
+		return err
+	}
+	return s.proto.status(ctx, s, connectorAvailable)
+}
+
+// reserve reserves the connector unless it is in use or already reserved
+func (s *Station) reserve(id int, expiry time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.transaction != nil || (s.reservation != nil && s.reservation.expiry.After(time.Now())) {
+		return false
+	}
+	s.reservation = &reservation{id: id, expiry: expiry}
+	s.stats.Reservations.Add(1)
+	return true
+}
+
+// cancelReservation cancels the reservation with the id, if the connector has it
+func (s *Station) cancelReservation(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reservation == nil || s.reservation.id != id {
+		return false
+	}
+	s.reservation = nil
+	return true
+}
+
+// takeReservation returns the id of the connector's reservation, if it has one that
+// has not expired, so that the next transaction fulfils it
+func (s *Station) takeReservation() *int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.reservation
+	s.reservation = nil
+	if r == nil || r.expiry.Before(time.Now()) {
+		return nil
+	}
+	return &r.id
+}
+
+func (s *Station) setTransaction(tx *transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transaction = tx
+}
+
+// currentTransaction returns the transaction in progress, if any
+func (s *Station) currentTransaction() *transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transaction
+}
+
+// requestStart asks for a transaction to be started as soon as the connector is free
+func (s *Station) requestStart() {
+	select {
+	case s.remoteStart <- struct{}{}:
+	default:
+	}
+}
+
+// requestStop asks for the transaction in progress to be stopped
+func (s *Station) requestStop() {
+	select {
+	case s.remoteStop <- struct{}{}:
+	default:
+	}
+}
+
+// sendStatus reports the connector status without holding up the caller, so that it
+// can be used while a call from the CSMS is being answered
+func (s *Station) sendStatus(ctx context.Context, status connectorStatus) {
+	go func() {
+		err := s.proto.status(ctx, s, status)
+		if err != nil && ctx.Err() == nil {
+			slog.Warn("sending status notification", "chargeStationId", s.id, "error", err)
+		}
+	}()
+}
+
+// call makes an OCPP call to the CSMS and decodes the result into the response
+func (s *Station) call(ctx context.Context, action string, request, response any) error {
+	s.callMu.Lock()
+	defer s.callMu.Unlock()
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("marshalling %s request: %w", action, err)
+	}
+	id := strconv.FormatInt(s.nextId.Add(1), 10)
+	msg, err := json.Marshal([]any{transport.MessageTypeCall, id, action, json.RawMessage(payload)})
+	if err != nil {
+		return fmt.Errorf("marshalling %s call: %w", action, err)
+	}
+
+	result := make(chan callResult, 1)
+	s.mu.Lock()
+	s.pending[id] = result
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+	}()
+
+	err = s.conn.Write(ctx, websocket.MessageText, msg)
+	if err != nil {
+		return fmt.Errorf("sending %s: %w", action, err)
+	}
+	s.stats.Calls.Add(1)
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.CallTimeout)
+	defer cancel()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for %s result: %w", action, ctx.Err())
+	case res := <-result:
+		if res.err != nil {
+			s.stats.CallErrors.Add(1)
+			return fmt.Errorf("%s: %w", action, res.err)
+		}
+		err = json.Unmarshal(res.payload, response)
+		if err != nil {
+			return fmt.Errorf("unmarshalling %s result: %w", action, err)
+		}
+		return nil
+	}
+}
+
+// read receives messages from the CSMS until the connection fails: call results are
+// passed to the waiting call and calls are answered
+func (s *Station) read(ctx context.Context) error {
+	for {
+		_, data, err := s.conn.Read(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("reading from %s: %w", s.id, err)
+		}
+
+		var frame []json.RawMessage
+		var messageType transport.MessageType
+		var id string
+		if json.Unmarshal(data, &frame) != nil || len(frame) < 3 ||
+			json.Unmarshal(frame[0], &messageType) != nil || json.Unmarshal(frame[1], &id) != nil {
+			slog.Warn("simulated charge station received invalid message", "chargeStationId", s.id, "message", string(data))
+			continue
+		}
+
+		switch messageType {
+		case transport.MessageTypeCall:
+			var action string
+			if len(frame) < 4 || json.Unmarshal(frame[2], &action) != nil {
+				slog.Warn("simulated charge station received invalid call", "chargeStationId", s.id, "message", string(data))
+				continue
+			}
+			err = s.answer(ctx, id, action, frame[3])
+			if err != nil {
+				return err
+			}
+		case transport.MessageTypeCallResult:
+			s.deliver(id, callResult{payload: frame[2]})
+		case transport.MessageTypeCallError:
+			var code, description string
+			_ = json.Unmarshal(frame[2], &code)
+			if len(frame) > 3 {
+				_ = json.Unmarshal(frame[3], &description)
+			}
+			s.deliver(id, callResult{err: callError{code: code, description: description}})
+		}
+	}
+}
+
+func (s *Station) deliver(id string, result callResult) {
+	s.mu.Lock()
+	pending := s.pending[id]
+	s.mu.Unlock()
+	if pending != nil {
+		pending <- result
+	}
+}
+
+// answer responds to a call from the CSMS with a CallResult or a CallError
+func (s *Station) answer(ctx context.Context, id, action string, payload json.RawMessage) error {
+	s.stats.CallsReceived.Add(1)
+	response, err := s.proto.handleCall(ctx, s, action, payload)
+
+	var msg []byte
+	var cerr callError
+	switch {
+	case errors.As(err, &cerr):
+		msg, err = json.Marshal([]any{transport.MessageTypeCallError, id, cerr.code, cerr.description, struct{}{}})
+	case err != nil:
+		msg, err = json.Marshal([]any{transport.MessageTypeCallError, id, "FormationViolation", err.Error(), struct{}{}})
+	default:
+		msg, err = json.Marshal([]any{transport.MessageTypeCallResult, id, response})
+	}
+	if err != nil {
+		return fmt.Errorf("marshalling %s response: %w", action, err)
+	}
+	return s.conn.Write(ctx, websocket.MessageText, msg)
+}
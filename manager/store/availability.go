@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+type AvailabilityStatus string
+
+var (
+	AvailabilityStatusFree        AvailabilityStatus = "Free"
+	AvailabilityStatusReserved    AvailabilityStatus = "Reserved"
+	AvailabilityStatusCharging    AvailabilityStatus = "Charging"
+	AvailabilityStatusUnavailable AvailabilityStatus = "Unavailable"
+)
+
+// availabilityPriority orders the statuses for when windows overlap: an EVSE that is
+// unavailable cannot be used even if it is reserved
+var availabilityPriority = map[AvailabilityStatus]int{
+	AvailabilityStatusFree:        0,
+	AvailabilityStatusReserved:    1,
+	AvailabilityStatusCharging:    2,
+	AvailabilityStatusUnavailable: 3,
+}
+
+// AvailabilityWindow is a period during which an EVSE has the same status
+type AvailabilityWindow struct {
+	Start  time.Time
+	End    time.Time
+	Status AvailabilityStatus
+}
+
+// EvseAvailability is the calendar of an EVSE: its windows cover the requested period
+// in order. The connectors of an OCPP 1.6 charge station are used independently, so
+// each has its own calendar with EvseId 0 and its ConnectorId: ConnectorId is 0 for
+// an OCPP 2.0.1 EVSE.
+type EvseAvailability struct {
+	EvseId      int
+	ConnectorId int
+	Windows     []AvailabilityWindow
+}
+
+// AvailabilityStore holds the state that the availability of a charge station is
+// computed from
+type AvailabilityStore interface {
+	EvseStore
+	ConnectorStatusStore
+	ReservationStore
+}
+
+// ChargeStationAvailability returns the calendar of each of the charge station's EVSEs
+// from the later of from and now until to, ordered by EVSE and connector:
+//   - a reservation that is accepted, or is waiting to be sent, makes the EVSE
+//     Reserved from the start of its booked time slot until it expires: a
+//     reservation without an EVSE applies to every EVSE
+//   - an EVSE whose connector is in use is Charging until the departure time of the
+//     EV, or until to if the EV did not give one
+//   - an EVSE whose connectors are all Unavailable or Faulted is Unavailable until to
+func ChargeStationAvailability(ctx context.Context, engine AvailabilityStore, chargeStationId string, from, to, now time.Time) ([]*EvseAvailability, error) {
+	if from.Before(now) {
+		from = now
+	}
+
+	type unit struct {
+		evseId, connectorId int
+	}
+	windows := make(map[unit][]AvailabilityWindow)
+	departures := make(map[int]time.Time)
+	addUnit := func(u unit) {
+		if _, ok := windows[u]; !ok {
+			windows[u] = nil
+		}
+	}
+
+	evses, err := engine.ListEvses(ctx, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+	for _, evse := range evses {
+		departures[evse.EvseId] = evse.DepartureTime
+		if evse.EvseId != 0 {
+			addUnit(unit{evse.EvseId, 0})
+			continue
+		}
+		for _, connector := range evse.Connectors {
+			addUnit(unit{0, connector.ConnectorId})
+		}
+	}
+
+	statuses, err := engine.ListConnectorStatuses(ctx, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+	// an OCPP 2.0.1 EVSE is unavailable if all of its connectors are
+	usable := make(map[unit]bool)
+	stationUnavailable := false
+	for _, status := range statuses {
+		u := unit{status.EvseId, 0}
+		if status.EvseId == 0 {
+			if status.ConnectorId == 0 {
+				// connector 0 is the OCPP 1.6 charge station as a whole
+				stationUnavailable = connectorUnavailable(status.Status)
+				continue
+			}
+			u.connectorId = status.ConnectorId
+		}
+		addUnit(u)
+		switch {
+		case connectorInUse(status.Status):
+			end := to
+			if departure := departures[status.EvseId]; departure.After(from) && departure.Before(to) {
+				end = departure
+			}
+			windows[u] = append(windows[u], AvailabilityWindow{Start: from, End: end, Status: AvailabilityStatusCharging})
+			usable[u] = true
+		case !connectorUnavailable(status.Status):
+			usable[u] = true
+		default:
+			if _, ok := usable[u]; !ok {
+				usable[u] = false
+			}
+		}
+	}
+	for u := range windows {
+		if ok, reported := usable[u]; stationUnavailable || (reported && !ok) {
+			windows[u] = append(windows[u], AvailabilityWindow{Start: from, End: to, Status: AvailabilityStatusUnavailable})
+		}
+	}
+
+	previousReservationId := 0
+	for {
+		page, err := engine.ListReservations(ctx, scanPageSize, previousReservationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, reservation := range page {
+			if reservation.ChargeStationId != chargeStationId || !reservation.ExpiryDate.After(from) ||
+				(reservation.Status != ReservationStatusAccepted && reservation.Status != ReservationStatusPending) {
+				continue
+			}
+			window := AvailabilityWindow{Start: reservation.Start(from), End: reservation.ExpiryDate, Status: AvailabilityStatusReserved}
+			if !window.Start.Before(to) {
+				continue
+			}
+			if window.End.After(to) {
+				window.End = to
+			}
+			if reservation.EvseId != nil {
+				addUnit(unit{*reservation.EvseId, 0})
+			}
+			for u := range windows {
+				if reservation.EvseId == nil || *reservation.EvseId == u.evseId {
+					windows[u] = append(windows[u], window)
+				}
+			}
+		}
+		if len(page) < scanPageSize {
+			break
+		}
+		previousReservationId = page[len(page)-1].ReservationId
+	}
+
+	availability := make([]*EvseAvailability, 0, len(windows))
+	for u, unitWindows := range windows {
+		availability = append(availability, &EvseAvailability{
+			EvseId:      u.evseId,
+			ConnectorId: u.connectorId,
+			Windows:     mergeAvailabilityWindows(unitWindows, from, to),
+		})
+	}
+	sort.Slice(availability, func(i, j int) bool {
+		if availability[i].EvseId != availability[j].EvseId {
+			return availability[i].EvseId < availability[j].EvseId
+		}
+		return availability[i].ConnectorId < availability[j].ConnectorId
+	})
+	return availability, nil
+}
+
+// mergeAvailabilityWindows returns windows that cover from to to in order, taking the
+// status with the highest priority where the windows overlap and Free where there is
+// no window
+func mergeAvailabilityWindows(windows []AvailabilityWindow, from, to time.Time) []AvailabilityWindow {
+	boundaries := []time.Time{from, to}
+	for _, window := range windows {
+		boundaries = append(boundaries, window.Start, window.End)
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	var merged []AvailabilityWindow
+	for i := 0; i < len(boundaries)-1; i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		if !start.Before(end) || start.Before(from) || end.After(to) {
+			continue
+		}
+		status := AvailabilityStatusFree
+		for _, window := range windows {
+			if !window.Start.After(start) && !window.End.Before(end) &&
+				availabilityPriority[window.Status] > availabilityPriority[status] {
+				status = window.Status
+			}
+		}
+		if n := len(merged); n > 0 && merged[n-1].Status == status {
+			merged[n-1].End = end
+			continue
+		}
+		merged = append(merged, AvailabilityWindow{Start: start, End: end, Status: status})
+	}
+	return merged
+}
+
+// connectorInUse reports whether the OCPP 1.6 or 2.0.1 connector status means that an
+// EV is plugged in
+func connectorInUse(status string) bool {
+	switch status {
+	case "Occupied", "Preparing", "Charging", "SuspendedEV", "SuspendedEVSE", "Finishing":
+		return true
+	}
+	return false
+}
+
+func connectorUnavailable(status string) bool {
+	return status == "Unavailable" || status == ConnectorStatusFaulted
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestChargeStationAvailability(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	to := now.Add(24 * time.Hour)
+
+	require.NoError(t, engine.SetEvse(ctx, &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		Connectors:      []store.ChargeStationConnector{{ConnectorId: 1}},
+	}))
+	require.NoError(t, engine.SetEvse(ctx, &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          2,
+		Connectors:      []store.ChargeStationConnector{{ConnectorId: 1}},
+		DepartureTime:   now.Add(2 * time.Hour),
+	}))
+	require.NoError(t, engine.SetEvse(ctx, &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          3,
+		Connectors:      []store.ChargeStationConnector{{ConnectorId: 1}},
+	}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Available"}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", EvseId: 2, ConnectorId: 1, Status: "Occupied"}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", EvseId: 3, ConnectorId: 1, Status: "Faulted"}))
+
+	evseId := 1
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		EvseId:          &evseId,
+		ExpiryDate:      now.Add(4 * time.Hour),
+		Status:          store.ReservationStatusAccepted,
+	}))
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   2,
+		ChargeStationId: "cs001",
+		EvseId:          &evseId,
+		ExpiryDate:      now.Add(8 * time.Hour),
+		Status:          store.ReservationStatusCancelled,
+	}))
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   3,
+		ChargeStationId: "cs002",
+		ExpiryDate:      now.Add(8 * time.Hour),
+		Status:          store.ReservationStatusAccepted,
+	}))
+
+	availability, err := store.ChargeStationAvailability(ctx, engine, "cs001", now.Add(-time.Hour), to, now)
+	require.NoError(t, err)
+
+	want := []*store.EvseAvailability{
+		{
+			EvseId: 1,
+			Windows: []store.AvailabilityWindow{
+				{Start: now, End: now.Add(4 * time.Hour), Status: store.AvailabilityStatusReserved},
+				{Start: now.Add(4 * time.Hour), End: to, Status: store.AvailabilityStatusFree},
+			},
+		},
+		{
+			EvseId: 2,
+			Windows: []store.AvailabilityWindow{
+				{Start: now, End: now.Add(2 * time.Hour), Status: store.AvailabilityStatusCharging},
+				{Start: now.Add(2 * time.Hour), End: to, Status: store.AvailabilityStatusFree},
+			},
+		},
+		{
+			EvseId: 3,
+			Windows: []store.AvailabilityWindow{
+				{Start: now, End: to, Status: store.AvailabilityStatusUnavailable},
+			},
+		},
+	}
+	assert.Equal(t, want, availability)
+}
+
+func TestChargeStationAvailabilityForOcpp16Connectors(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	to := now.Add(6 * time.Hour)
+
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", ConnectorId: 1, Status: "Charging"}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", ConnectorId: 2, Status: "Available"}))
+
+	availability, err := store.ChargeStationAvailability(ctx, engine, "cs001", now, to, now)
+	require.NoError(t, err)
+
+	want := []*store.EvseAvailability{
+		{
+			ConnectorId: 1,
+			Windows:     []store.AvailabilityWindow{{Start: now, End: to, Status: store.AvailabilityStatusCharging}},
+		},
+		{
+			ConnectorId: 2,
+			Windows:     []store.AvailabilityWindow{{Start: now, End: to, Status: store.AvailabilityStatusFree}},
+		},
+	}
+	assert.Equal(t, want, availability)
+
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", ConnectorId: 0, Status: "Unavailable"}))
+
+	availability, err = store.ChargeStationAvailability(ctx, engine, "cs001", now, to, now)
+	require.NoError(t, err)
+	require.Len(t, availability, 2)
+	for _, evse := range availability {
+		assert.Equal(t, []store.AvailabilityWindow{{Start: now, End: to, Status: store.AvailabilityStatusUnavailable}}, evse.Windows)
+	}
+}
+
+func TestChargeStationAvailabilityOfBookedTimeSlots(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	to := now.Add(24 * time.Hour)
+
+	require.NoError(t, engine.SetEvse(ctx, &store.ChargeStationEvse{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		Connectors:      []store.ChargeStationConnector{{ConnectorId: 1}},
+	}))
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		StartDate:       now.Add(2 * time.Hour),
+		ExpiryDate:      now.Add(3 * time.Hour),
+		Status:          store.ReservationStatusPending,
+	}))
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   2,
+		ChargeStationId: "cs001",
+		StartDate:       to.Add(time.Hour),
+		ExpiryDate:      to.Add(2 * time.Hour),
+		Status:          store.ReservationStatusPending,
+	}))
+
+	availability, err := store.ChargeStationAvailability(ctx, engine, "cs001", now, to, now)
+	require.NoError(t, err)
+
+	require.Len(t, availability, 1)
+	assert.Equal(t, []store.AvailabilityWindow{
+		{Start: now, End: now.Add(2 * time.Hour), Status: store.AvailabilityStatusFree},
+		{Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Status: store.AvailabilityStatusReserved},
+		{Start: now.Add(3 * time.Hour), End: to, Status: store.AvailabilityStatusFree},
+	}, availability[0].Windows)
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"math"
+	"time"
+)
+
+type BulkCommandAction string
+
+var (
+	BulkCommandActionReset               BulkCommandAction = "Reset"
+	BulkCommandActionChangeConfiguration BulkCommandAction = "ChangeConfiguration"
+	BulkCommandActionUpdateFirmware      BulkCommandAction = "UpdateFirmware"
+	BulkCommandActionClearCache          BulkCommandAction = "ClearCache"
+)
+
+type BulkCommandStatus string
+
+var (
+	// BulkCommandStatusRunning means the command is being sent to the charge stations
+	BulkCommandStatusRunning BulkCommandStatus = "Running"
+	// BulkCommandStatusPaused means no more charge stations are sent the command until
+	// the bulk command is resumed: the calls that have been sent are still tracked
+	BulkCommandStatusPaused BulkCommandStatus = "Paused"
+	// BulkCommandStatusCompleted means every charge station has a final result
+	BulkCommandStatusCompleted BulkCommandStatus = "Completed"
+)
+
+type BulkCommandResultStatus string
+
+var (
+	// BulkCommandResultStatusPending means the command has not been sent to the charge
+	// station
+	BulkCommandResultStatusPending BulkCommandResultStatus = "Pending"
+	// BulkCommandResultStatusSent means the command was sent to the charge station,
+	// which has not yet responded
+	BulkCommandResultStatusSent BulkCommandResultStatus = "Sent"
+	// BulkCommandResultStatusAccepted means the charge station accepted the command
+	BulkCommandResultStatusAccepted BulkCommandResultStatus = "Accepted"
+	// BulkCommandResultStatusFailed means the command could not be sent, the charge
+	// station rejected it or the charge station did not respond
+	BulkCommandResultStatusFailed BulkCommandResultStatus = "Failed"
+)
+
+// BulkCommandSelector selects the charge stations that a bulk command is sent to: a
+// field that is not set matches all charge stations.
+type BulkCommandSelector struct {
+	// SiteId selects the charge stations assigned to the site or one of its sub-sites
+	SiteId          string
+	FirmwareVersion string
+	Vendor          string
+	Model           string
+	// Tags selects the charge stations that have all the tags
+	Tags []string
+}
+
+// Matches reports whether the charge station is selected, other than by its site: the
+// site is matched by SelectBulkCommandChargeStations, which looks up the sub-sites
+func (s *BulkCommandSelector) Matches(chargeStation *ChargeStation) bool {
+	if s.FirmwareVersion != "" && chargeStation.FirmwareVersion != s.FirmwareVersion {
+		return false
+	}
+	if s.Vendor != "" && chargeStation.Vendor != s.Vendor {
+		return false
+	}
+	if s.Model != "" && chargeStation.Model != s.Model {
+		return false
+	}
+	return chargeStation.HasTags(s.Tags)
+}
+
+// BulkCommandResult tracks the command sent to one of the selected charge stations
+type BulkCommandResult struct {
+	ChargeStationId string
+	Status          BulkCommandResultStatus
+	// Result is the status returned by the charge station, or the reason that the
+	// command failed
+	Result      string
+	SentAt      time.Time
+	CompletedAt time.Time
+}
+
+// BulkCommand is a command that is sent to each of the charge stations selected when it
+// was created. The charge stations are sent the command a few at a time, and the result
+// of each is recorded so that the bulk command carries on where it left off after a
+// restart.
+type BulkCommand struct {
+	// Id is time ordered
+	Id       string
+	Action   BulkCommandAction
+	Selector BulkCommandSelector
+	// ResetType is Immediate or OnIdle for a Reset: Immediate is sent to OCPP 1.6
+	// charge stations as a Hard reset and OnIdle as a Soft reset
+	ResetType string
+	// Key and Value are the configuration to change for a ChangeConfiguration: the key
+	// is sent to OCPP 2.0.1 charge stations as a component/variable name
+	Key   string
+	Value string
+	// FirmwareLocation and FirmwareRetrieveDate are the firmware to install for an
+	// UpdateFirmware: a zero FirmwareRetrieveDate means the firmware is retrieved as
+	// soon as the charge station is sent the command
+	FirmwareLocation     string
+	FirmwareRetrieveDate time.Time
+	// MaxInFlight is the maximum number of charge stations that have been sent the
+	// command and not yet responded
+	MaxInFlight int
+	Status      BulkCommandStatus
+	Results     []*BulkCommandResult
+	TenantId    string
+	CreatedBy   string
+	CreatedAt   time.Time
+	// Version is incremented each time the bulk command is written
+	Version int
+}
+
+// NewBulkCommandId returns an id for a bulk command that sorts after the ids of the
+// bulk commands that were created before it
+func NewBulkCommandId() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// CountResults returns the number of charge stations whose result has the status
+func (b *BulkCommand) CountResults(status BulkCommandResultStatus) int {
+	count := 0
+	for _, result := range b.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+type BulkCommandStore interface {
+	// SetBulkCommand writes the bulk command if its Version matches the stored version
+	// (0 for a new bulk command) and increments the Version: otherwise it returns
+	// ErrVersionConflict
+	SetBulkCommand(ctx context.Context, bulkCommand *BulkCommand) error
+	LookupBulkCommand(ctx context.Context, id string) (*BulkCommand, error)
+	// ListBulkCommands returns the page of bulk commands ordered by id that follow the
+	// bulk command with previousId
+	ListBulkCommands(ctx context.Context, pageSize int, previousId string) ([]*BulkCommand, error)
+}
+
+// SelectBulkCommandChargeStations returns the ids of the charge stations that belong to
+// the tenant and are selected by the selector. Every charge station is selected from an
+// empty tenant.
+func SelectBulkCommandChargeStations(ctx context.Context, engine Engine, selector *BulkCommandSelector, tenantId string) ([]string, error) {
+	group, err := NewChargeStationGroup(ctx, engine, tenantId, selector.SiteId, nil)
+	if err != nil {
+		return nil, err
+	}
+	chargeStations, err := listTenant(ctx, engine.ListChargeStations, func(chargeStation *ChargeStation) bool {
+		return group.Matches(chargeStation) && selector.Matches(chargeStation)
+	}, 0, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(chargeStations))
+	for i, chargeStation := range chargeStations {
+		ids[i] = chargeStation.ChargeStationId
+	}
+	return ids, nil
+}
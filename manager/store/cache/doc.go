@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache provides a store.Engine that wraps another store.Engine, caching
+// token lookups so that authorizing a charge does not need a round trip to the
+// underlying storage. Tokens are held in an in-process LRU cache and, optionally, in
+// a second cache that is shared between manager instances.
+package cache
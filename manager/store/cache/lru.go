@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"container/list"
+	"k8s.io/utils/clock"
+	"sync"
+	"time"
+)
+
+type lruEntry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+// LRU is a fixed size cache that discards the least recently used entry when it is
+// full. Entries also expire once they have been in the cache for longer than the TTL.
+// It is safe for concurrent use.
+type LRU[V any] struct {
+	sync.Mutex
+	clock   clock.PassiveClock
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func NewLRU[V any](clock clock.PassiveClock, size int, ttl time.Duration) *LRU[V] {
+	return &LRU[V]{
+		clock:   clock,
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (c *LRU[V]) Get(key string) (V, bool) {
+	c.Lock()
+	defer c.Unlock()
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*lruEntry[V])
+	if !c.clock.Now().Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *LRU[V]) Put(key string, value V) {
+	c.Lock()
+	defer c.Unlock()
+	expires := c.clock.Now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry[V])
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{
+		key:     key,
+		value:   value,
+		expires: expires,
+	})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[V]).key)
+	}
+}
+
+func (c *LRU[V]) Remove(key string) {
+	c.Lock()
+	defer c.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+func (c *LRU[V]) Clear() {
+	c.Lock()
+	defer c.Unlock()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
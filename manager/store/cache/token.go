@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+var tokenCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_token_cache_lookups_total",
+	Help: "The number of token lookups by cache tier and result (hit or miss)",
+}, []string{"tier", "result"})
+
+// SharedTokenCache is a second tier cache that is shared between manager instances:
+// a token cached by one instance is available to the others.
+type SharedTokenCache interface {
+	LookupCachedToken(ctx context.Context, tokenUid string) (*store.Token, error)
+	SetCachedToken(ctx context.Context, token *store.Token, ttl time.Duration) error
+	DeleteCachedToken(ctx context.Context, tokenUid string) error
+}
+
+// Store is a store.Engine that caches the tokens returned by LookupToken. Tokens are
+// invalidated when they are set through the Store: changes made through another
+// manager instance are only seen once the cached token expires, unless Invalidate
+// is called.
+type Store struct {
+	store.Engine
+	local  *LRU[*store.Token]
+	shared SharedTokenCache
+	ttl    time.Duration
+}
+
+type Opt func(s *Store)
+
+// WithSharedCache adds a second tier cache that is consulted when a token is not in
+// the in-process cache
+func WithSharedCache(shared SharedTokenCache) Opt {
+	return func(s *Store) {
+		s.shared = shared
+	}
+}
+
+// NewStore caches up to size tokens in-process, each for at most ttl
+func NewStore(engine store.Engine, clock clock.PassiveClock, size int, ttl time.Duration, opts ...Opt) *Store {
+	s := &Store{
+		Engine: engine,
+		local:  NewLRU[*store.Token](clock, size, ttl),
+		ttl:    ttl,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Store) LookupToken(ctx context.Context, tokenUid string) (*store.Token, error) {
+	if tok, ok := s.local.Get(tokenUid); ok {
+		tokenCacheLookups.WithLabelValues("local", "hit").Inc()
+		return cloneToken(tok), nil
+	}
+	tokenCacheLookups.WithLabelValues("local", "miss").Inc()
+
+	if s.shared != nil {
+		tok, err := s.shared.LookupCachedToken(ctx, tokenUid)
+		if err != nil {
+			slog.Warn("lookup token in shared cache", "tokenUid", tokenUid, "err", err)
+		}
+		if tok != nil {
+			tokenCacheLookups.WithLabelValues("shared", "hit").Inc()
+			s.local.Put(tokenUid, tok)
+			return cloneToken(tok), nil
+		}
+		tokenCacheLookups.WithLabelValues("shared", "miss").Inc()
+	}
+
+	tok, err := s.Engine.LookupToken(ctx, tokenUid)
+	if err != nil || tok == nil {
+		return tok, err
+	}
+
+	s.local.Put(tokenUid, cloneToken(tok))
+	if s.shared != nil {
+		if err := s.shared.SetCachedToken(ctx, tok, s.ttl); err != nil {
+			slog.Warn("set token in shared cache", "tokenUid", tokenUid, "err", err)
+		}
+	}
+	return tok, nil
+}
+
+// SetToken invalidates the cached token whether or not it is written: if the token was
+// changed through another manager instance the write fails with a version conflict, and
+// the next lookup reads the changed token
+func (s *Store) SetToken(ctx context.Context, token *store.Token) error {
+	err := s.Engine.SetToken(ctx, token)
+	if invalidateErr := s.Invalidate(ctx, token.Uid); err == nil {
+		err = invalidateErr
+	}
+	return err
+}
+
+// Invalidate removes the token from both cache tiers so that the next lookup reads it
+// from the underlying engine
+func (s *Store) Invalidate(ctx context.Context, tokenUid string) error {
+	s.local.Remove(tokenUid)
+	if s.shared != nil {
+		return s.shared.DeleteCachedToken(ctx, tokenUid)
+	}
+	return nil
+}
+
+// InvalidateLocal empties the in-process cache. It is intended to be called when
+// tokens may have been changed without going through the Store, e.g. after a bulk
+// import.
+func (s *Store) InvalidateLocal() {
+	s.local.Clear()
+}
+
+func (s *Store) WriteTransactionBatch(ctx context.Context, batch *store.TransactionBatch) error {
+	return store.WriteTransactionBatch(ctx, s.Engine, batch)
+}
+
+func (s *Store) FleetStats(ctx context.Context, since, now time.Time) (*store.FleetStats, error) {
+	return store.ComputeFleetStats(ctx, s.Engine, since, now)
+}
+
+func (s *Store) ListTenantChargeStations(ctx context.Context, tenantId string, offset, limit int) ([]*store.ChargeStation, error) {
+	return store.ListTenantChargeStations(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) ListTenantTokens(ctx context.Context, tenantId string, offset, limit int) ([]*store.Token, error) {
+	return store.ListTenantTokens(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) ListTenantSites(ctx context.Context, tenantId string, offset, limit int) ([]*store.Site, error) {
+	return store.ListTenantSites(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) ListTenantTags(ctx context.Context, tenantId string, offset, limit int) ([]*store.Tag, error) {
+	return store.ListTenantTags(ctx, s.Engine, tenantId, offset, limit)
+}
+
+// IsTransient uses the underlying engine's classification of its errors
+func (s *Store) IsTransient(err error) bool {
+	return store.IsTransient(s.Engine, err)
+}
+
+// Healthy reports the health of the underlying engine
+func (s *Store) Healthy() error {
+	if reporter, ok := s.Engine.(store.HealthReporter); ok {
+		return reporter.Healthy()
+	}
+	return nil
+}
+
+// Close closes the underlying engine
+func (s *Store) Close() error {
+	return store.Close(s.Engine)
+}
+
+func cloneToken(tok *store.Token) *store.Token {
+	clone := *tok
+	return &clone
+}
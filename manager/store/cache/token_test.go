@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/cache"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type countingEngine struct {
+	store.Engine
+	lookups int
+}
+
+func (c *countingEngine) LookupToken(ctx context.Context, tokenUid string) (*store.Token, error) {
+	c.lookups++
+	return c.Engine.LookupToken(ctx, tokenUid)
+}
+
+type sharedCache struct {
+	tokens map[string]*store.Token
+}
+
+func (s *sharedCache) LookupCachedToken(_ context.Context, tokenUid string) (*store.Token, error) {
+	return s.tokens[tokenUid], nil
+}
+
+func (s *sharedCache) SetCachedToken(_ context.Context, token *store.Token, _ time.Duration) error {
+	s.tokens[token.Uid] = token
+	return nil
+}
+
+func (s *sharedCache) DeleteCachedToken(_ context.Context, tokenUid string) error {
+	delete(s.tokens, tokenUid)
+	return nil
+}
+
+func newToken(uid string) *store.Token {
+	return &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         uid,
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   store.CacheModeAllowed,
+	}
+}
+
+func newEngine(t *testing.T, uids ...string) *countingEngine {
+	engine := &countingEngine{Engine: inmemory.NewStore(clock.RealClock{})}
+	for _, uid := range uids {
+		require.NoError(t, engine.SetToken(context.Background(), newToken(uid)))
+	}
+	return engine
+}
+
+func TestLookupTokenIsCached(t *testing.T) {
+	ctx := context.Background()
+	engine := newEngine(t, "DEADBEEF")
+	cached := cache.NewStore(engine, clock.RealClock{}, 10, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		got, err := cached.LookupToken(ctx, "DEADBEEF")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, "DEADBEEF", got.Uid)
+	}
+
+	assert.Equal(t, 1, engine.lookups)
+}
+
+func TestLookupTokenDoesNotCacheUnknownTokens(t *testing.T) {
+	ctx := context.Background()
+	engine := newEngine(t)
+	cached := cache.NewStore(engine, clock.RealClock{}, 10, time.Minute)
+
+	got, err := cached.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, engine.SetToken(ctx, newToken("DEADBEEF")))
+
+	got, err = cached.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestCachedTokenExpires(t *testing.T) {
+	ctx := context.Background()
+	engine := newEngine(t, "DEADBEEF")
+	clk := clockTest.NewFakePassiveClock(time.Now())
+	cached := cache.NewStore(engine, clk, 10, time.Minute)
+
+	_, err := cached.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+
+	clk.SetTime(clk.Now().Add(time.Minute))
+
+	_, err = cached.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, engine.lookups)
+}
+
+func TestLeastRecentlyUsedTokenIsEvicted(t *testing.T) {
+	ctx := context.Background()
+	var uids []string
+	for i := 0; i < 3; i++ {
+		uids = append(uids, fmt.Sprintf("%08X", i))
+	}
+	engine := newEngine(t, uids...)
+	cached := cache.NewStore(engine, clock.RealClock{}, 2, time.Minute)
+
+	for _, uid := range uids {
+		_, err := cached.LookupToken(ctx, uid)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, engine.lookups)
+
+	// the first token was evicted to make room for the third
+	_, err := cached.LookupToken(ctx, uids[2])
+	require.NoError(t, err)
+	assert.Equal(t, 3, engine.lookups)
+	_, err = cached.LookupToken(ctx, uids[0])
+	require.NoError(t, err)
+	assert.Equal(t, 4, engine.lookups)
+}
+
+func TestSetTokenInvalidatesCachedToken(t *testing.T) {
+	ctx := context.Background()
+	engine := newEngine(t, "DEADBEEF")
+	shared := &sharedCache{tokens: make(map[string]*store.Token)}
+	cached := cache.NewStore(engine, clock.RealClock{}, 10, time.Minute, cache.WithSharedCache(shared))
+
+	tok, err := cached.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	require.Contains(t, shared.tokens, "DEADBEEF")
+
+	tok.Valid = false
+	require.NoError(t, cached.SetToken(ctx, tok))
+	assert.NotContains(t, shared.tokens, "DEADBEEF")
+
+	got, err := cached.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.False(t, got.Valid)
+	assert.Equal(t, 2, engine.lookups)
+}
+
+func TestLookupTokenUsesSharedCache(t *testing.T) {
+	ctx := context.Background()
+	engine := newEngine(t)
+	shared := &sharedCache{tokens: map[string]*store.Token{
+		"DEADBEEF": newToken("DEADBEEF"),
+	}}
+	cached := cache.NewStore(engine, clock.RealClock{}, 10, time.Minute, cache.WithSharedCache(shared))
+
+	got, err := cached.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.Equal(t, newToken("DEADBEEF"), got)
+	assert.Equal(t, 0, engine.lookups)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"golang.org/x/exp/slices"
+	"time"
+)
+
+// ChargeDetailRecord is the final, billable record of a completed transaction. The
+// location details are copied from the location at the time the record is created so
+// that later changes to the location do not alter the record.
+type ChargeDetailRecord struct {
+	Id                 string
+	ChargeStationId    string
+	TransactionId      string
+	IdToken            string
+	TokenType          string
+	ContractId         string
+	LocationId         string
+	LocationName       string
+	Address            string
+	City               string
+	PostalCode         string
+	Country            string
+	Coordinates        GeoLocation
+	EvseUid            string
+	EvseId             string
+	ConnectorId        string
+	ConnectorFormat    string
+	ConnectorPowerType string
+	ConnectorStandard  string
+	StartDateTime      string
+	EndDateTime        string
+	TotalEnergy        float64 // kWh
+	TotalTime          float64 // hours
+	TotalCost          float64
+	Currency           string
+	LastUpdated        string
+}
+
+// ChargeDetailRecordFilter selects charge detail records: a field that is not set
+// matches all records.
+type ChargeDetailRecordFilter struct {
+	// EndedFrom and EndedBefore bound the record's EndDateTime: records whose end date
+	// time cannot be parsed never match a filter that sets either of them
+	EndedFrom   *time.Time
+	EndedBefore *time.Time
+	// ChargeStationIds, if not nil, restricts the records to those of the charge
+	// stations, e.g. the charge stations that belong to a tenant
+	ChargeStationIds []string
+}
+
+// Matches reports whether the record is selected by the filter
+func (f *ChargeDetailRecordFilter) Matches(cdr *ChargeDetailRecord) bool {
+	if f == nil {
+		return true
+	}
+	if f.ChargeStationIds != nil && !slices.Contains(f.ChargeStationIds, cdr.ChargeStationId) {
+		return false
+	}
+	if f.EndedFrom != nil || f.EndedBefore != nil {
+		endTime, err := time.Parse(time.RFC3339, cdr.EndDateTime)
+		if err != nil {
+			return false
+		}
+		if f.EndedFrom != nil && endTime.Before(*f.EndedFrom) {
+			return false
+		}
+		if f.EndedBefore != nil && !endTime.Before(*f.EndedBefore) {
+			return false
+		}
+	}
+	return true
+}
+
+type ChargeDetailRecordStore interface {
+	SetChargeDetailRecord(ctx context.Context, cdr *ChargeDetailRecord) error
+	LookupChargeDetailRecord(ctx context.Context, cdrId string) (*ChargeDetailRecord, error)
+	// ListChargeDetailRecords returns the records ordered by id
+	ListChargeDetailRecords(ctx context.Context, offset int, limit int) ([]*ChargeDetailRecord, error)
+	// QueryChargeDetailRecords returns up to pageSize of the records selected by the
+	// filter that follow previousCdrId: the first page follows "". The records are
+	// ordered by id, unless the filter selects more charge stations than the engine
+	// can compare in one query, when they are ordered by id within each group of
+	// charge stations.
+	QueryChargeDetailRecords(ctx context.Context, filter *ChargeDetailRecordFilter, pageSize int, previousCdrId string) ([]*ChargeDetailRecord, error)
+}
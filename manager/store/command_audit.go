@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
+	"time"
+)
+
+type CommandAuditStatus string
+
+var (
+	// CommandAuditStatusRequested means the command was accepted by the API and will be
+	// sent to the charge station by the CSMS
+	CommandAuditStatusRequested CommandAuditStatus = "Requested"
+	// CommandAuditStatusSent means the call was sent to the charge station, which has
+	// not yet responded
+	CommandAuditStatusSent CommandAuditStatus = "Sent"
+	// CommandAuditStatusNotSent means the call could not be sent to the charge station
+	CommandAuditStatusNotSent CommandAuditStatus = "NotSent"
+	// CommandAuditStatusCompleted means the charge station responded with a CallResult
+	CommandAuditStatusCompleted CommandAuditStatus = "Completed"
+	// CommandAuditStatusFailed means the charge station responded with a CallError
+	CommandAuditStatusFailed CommandAuditStatus = "Failed"
+)
+
+// CommandAuditRecord records a command initiated by the CSMS: either a request made
+// through the API, such as a reservation or a configuration change, or a call sent to
+// a charge station with the charge station's response.
+type CommandAuditRecord struct {
+	// Id is time ordered: for a call it is the OCPP message id
+	Id              string
+	ChargeStationId string
+	// Command is the API operation for a request or the OCPP action for a call
+	Command     string
+	OcppVersion string
+	Request     string
+	RequestedBy string
+	RequestedAt time.Time
+	Status      CommandAuditStatus
+	// Result holds the CallResult payload, or the error that the call failed with
+	Result      string
+	CompletedAt time.Time
+}
+
+// NewCommandAuditId returns an id for a command audit record that sorts after the ids
+// of the records that were created before it
+func NewCommandAuditId() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// CommandAuditFilter selects command audit records: a field that is not set matches
+// all records.
+type CommandAuditFilter struct {
+	ChargeStationId string
+	Command         string
+	RequestedFrom   *time.Time
+	RequestedBefore *time.Time
+	// ChargeStationIds, if not nil, restricts the records to those for the charge
+	// stations, e.g. the charge stations that belong to a tenant
+	ChargeStationIds []string
+}
+
+// Matches reports whether the record is selected by the filter
+func (f *CommandAuditFilter) Matches(record *CommandAuditRecord) bool {
+	if f == nil {
+		return true
+	}
+	if f.ChargeStationId != "" && record.ChargeStationId != f.ChargeStationId {
+		return false
+	}
+	if f.Command != "" && record.Command != f.Command {
+		return false
+	}
+	if f.ChargeStationIds != nil && !slices.Contains(f.ChargeStationIds, record.ChargeStationId) {
+		return false
+	}
+	if f.RequestedFrom != nil && record.RequestedAt.Before(*f.RequestedFrom) {
+		return false
+	}
+	if f.RequestedBefore != nil && !record.RequestedAt.Before(*f.RequestedBefore) {
+		return false
+	}
+	return true
+}
+
+type CommandAuditStore interface {
+	SetCommandAuditRecord(ctx context.Context, record *CommandAuditRecord) error
+	LookupCommandAuditRecord(ctx context.Context, id string) (*CommandAuditRecord, error)
+	// QueryCommandAuditRecords returns the page of records selected by the filter,
+	// ordered by id, starting at offset
+	QueryCommandAuditRecords(ctx context.Context, filter *CommandAuditFilter, offset, limit int) ([]*CommandAuditRecord, error)
+}
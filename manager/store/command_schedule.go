@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"time"
+)
+
+// CommandSchedule creates a bulk command at a time, or each time that a cron expression
+// matches, e.g. to soft reset a site's charge stations every night. A schedule is run by
+// the manager instance that advances its NextRunAt, so it runs once however many
+// instances there are.
+type CommandSchedule struct {
+	// Id is time ordered
+	Id   string
+	Name string
+	// Command is the bulk command that is created each time the schedule runs: its
+	// charge stations are selected when it is created
+	Command BulkCommand
+	// RunAt is the time of a schedule that runs once: it is zero for a schedule with a
+	// Cron expression
+	RunAt time.Time
+	// Cron is the cron expression of a repeating schedule, see ParseCron, which is
+	// evaluated in the IANA TimeZone or in UTC when TimeZone is empty
+	Cron     string
+	TimeZone string
+	// NextRunAt is zero when the schedule will not run again
+	NextRunAt time.Time
+	LastRunAt time.Time
+	// LastBulkCommandId is the bulk command created by the last run, or empty if it did
+	// not create one: LastError says why not
+	LastBulkCommandId string
+	LastError         string
+	TenantId          string
+	CreatedBy         string
+	CreatedAt         time.Time
+	// Version is incremented each time the schedule is written
+	Version int
+}
+
+// NewCommandScheduleId returns an id for a command schedule that sorts after the ids of
+// the schedules that were created before it
+func NewCommandScheduleId() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// NextRun returns the first time after the time that the schedule runs, or the zero
+// time if it does not run again. Runs that were missed, e.g. while no manager instance
+// was running, are not caught up.
+func (s *CommandSchedule) NextRun(after time.Time) (time.Time, error) {
+	if s.Cron == "" {
+		if s.RunAt.After(after) {
+			return s.RunAt, nil
+		}
+		return time.Time{}, nil
+	}
+	cron, err := ParseCron(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("time zone: %w", err)
+	}
+	next := cron.Next(after.In(loc))
+	if next.IsZero() {
+		return next, nil
+	}
+	return next.UTC(), nil
+}
+
+// Due reports whether the schedule should have run by now
+func (s *CommandSchedule) Due(now time.Time) bool {
+	return !s.NextRunAt.IsZero() && !s.NextRunAt.After(now)
+}
+
+// NewBulkCommand returns the bulk command for a run of the schedule that sends the
+// command to the charge stations
+func (s *CommandSchedule) NewBulkCommand(id string, chargeStationIds []string, now time.Time) *BulkCommand {
+	bulkCommand := s.Command
+	bulkCommand.Id = id
+	bulkCommand.Selector.Tags = append([]string(nil), s.Command.Selector.Tags...)
+	bulkCommand.Status = BulkCommandStatusRunning
+	bulkCommand.Results = make([]*BulkCommandResult, len(chargeStationIds))
+	for i, csId := range chargeStationIds {
+		bulkCommand.Results[i] = &BulkCommandResult{
+			ChargeStationId: csId,
+			Status:          BulkCommandResultStatusPending,
+		}
+	}
+	bulkCommand.TenantId = s.TenantId
+	bulkCommand.CreatedBy = s.CreatedBy
+	bulkCommand.CreatedAt = now
+	bulkCommand.Version = 0
+	return &bulkCommand
+}
+
+type CommandScheduleStore interface {
+	// SetCommandSchedule writes the schedule if its Version matches the stored version
+	// (0 for a new schedule) and increments the Version: otherwise it returns
+	// ErrVersionConflict
+	SetCommandSchedule(ctx context.Context, schedule *CommandSchedule) error
+	LookupCommandSchedule(ctx context.Context, id string) (*CommandSchedule, error)
+	// ListCommandSchedules returns the page of schedules ordered by id that follow the
+	// schedule with previousId
+	ListCommandSchedules(ctx context.Context, pageSize int, previousId string) ([]*CommandSchedule, error)
+	DeleteCommandSchedule(ctx context.Context, id string) error
+}
+
+// ListTenantCommandSchedules returns the page of the tenant's schedules, ordered by id,
+// starting at offset. Every schedule belongs to an empty tenant.
+func ListTenantCommandSchedules(ctx context.Context, engine CommandScheduleStore, tenantId string, offset, limit int) ([]*CommandSchedule, error) {
+	var schedules []*CommandSchedule
+	var previousId string
+	for len(schedules) < offset+limit {
+		page, err := engine.ListCommandSchedules(ctx, tenantPageSize, previousId)
+		if err != nil {
+			return nil, err
+		}
+		for _, schedule := range page {
+			if tenantId == "" || schedule.TenantId == tenantId {
+				schedules = append(schedules, schedule)
+			}
+		}
+		if len(page) < tenantPageSize {
+			break
+		}
+		previousId = page[len(page)-1].Id
+	}
+	if offset >= len(schedules) {
+		return nil, nil
+	}
+	return schedules[offset:int(math.Min(float64(offset+limit), float64(len(schedules))))], nil
+}
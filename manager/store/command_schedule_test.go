@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestCommandScheduleNextRun(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	once := &store.CommandSchedule{RunAt: now.Add(time.Hour)}
+	next, err := once.NextRun(now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), next)
+	next, err = once.NextRun(now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, next.IsZero())
+
+	nightly := &store.CommandSchedule{Cron: "0 2 * * *", TimeZone: "Europe/Paris"}
+	next, err = nightly.NextRun(now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 16, 1, 0, 0, 0, time.UTC), next)
+
+	invalid := &store.CommandSchedule{Cron: "0 2 * * *", TimeZone: "Nowhere/Special"}
+	_, err = invalid.NextRun(now)
+	assert.Error(t, err)
+}
+
+func TestCommandScheduleNewBulkCommand(t *testing.T) {
+	now := time.Now().UTC()
+	schedule := &store.CommandSchedule{
+		Id: store.NewCommandScheduleId(),
+		Command: store.BulkCommand{
+			Action:      store.BulkCommandActionReset,
+			Selector:    store.BulkCommandSelector{Tags: []string{"dc"}},
+			ResetType:   "OnIdle",
+			MaxInFlight: 5,
+		},
+		TenantId:  "a",
+		CreatedBy: "operator",
+	}
+
+	bulkCommand := schedule.NewBulkCommand("bc001", []string{"cs001", "cs002"}, now)
+
+	assert.Equal(t, &store.BulkCommand{
+		Id:          "bc001",
+		Action:      store.BulkCommandActionReset,
+		Selector:    store.BulkCommandSelector{Tags: []string{"dc"}},
+		ResetType:   "OnIdle",
+		MaxInFlight: 5,
+		Status:      store.BulkCommandStatusRunning,
+		Results: []*store.BulkCommandResult{
+			{ChargeStationId: "cs001", Status: store.BulkCommandResultStatusPending},
+			{ChargeStationId: "cs002", Status: store.BulkCommandResultStatusPending},
+		},
+		TenantId:  "a",
+		CreatedBy: "operator",
+		CreatedAt: now,
+	}, bulkCommand)
+
+	// the schedule's command is not shared with the bulk command
+	bulkCommand.Selector.Tags[0] = "ac"
+	assert.Equal(t, []string{"dc"}, schedule.Command.Selector.Tags)
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	after := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC) // a Friday
+
+	tests := map[string]struct {
+		expr string
+		want time.Time
+	}{
+		"every minute":          {"* * * * *", time.Date(2024, 3, 15, 10, 31, 0, 0, time.UTC)},
+		"every quarter hour":    {"*/15 * * * *", time.Date(2024, 3, 15, 10, 45, 0, 0, time.UTC)},
+		"nightly":               {"0 2 * * *", time.Date(2024, 3, 16, 2, 0, 0, 0, time.UTC)},
+		"weekends":              {"0 8 * * 6,0", time.Date(2024, 3, 16, 8, 0, 0, 0, time.UTC)},
+		"sunday as 7":           {"0 8 * * 7", time.Date(2024, 3, 17, 8, 0, 0, 0, time.UTC)},
+		"weekdays":              {"30 10 * * 1-5", time.Date(2024, 3, 18, 10, 30, 0, 0, time.UTC)},
+		"first of the month":    {"0 0 1 * *", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		"leap day":              {"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		"day of month or week":  {"0 0 20 * 1", time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC)},
+		"never":                 {"0 0 31 2 *", time.Time{}},
+		"hours in a range step": {"0 8-18/4 * * *", time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cron, err := store.ParseCron(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, cron.Next(after))
+		})
+	}
+}
+
+func TestCronNextInTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	cron, err := store.ParseCron("30 1 * * *")
+	require.NoError(t, err)
+
+	// 01:30 does not exist on the day the clocks go forward
+	got := cron.Next(time.Date(2024, 3, 30, 12, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2024, 4, 1, 1, 30, 0, 0, loc), got)
+}
+
+func TestParseCronRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"*/0 * * * *", "5/10 * * * *", "10-5 * * * *", "a * * * *"} {
+		_, err := store.ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -4,6 +4,7 @@ package store
 
 import (
 	"context"
+	"sort"
 	"time"
 )
 
@@ -26,6 +27,49 @@ type ChargeStationAuthStore interface {
 	LookupChargeStationAuth(ctx context.Context, chargeStationId string) (*ChargeStationAuth, error)
 }
 
+// ChargeStation is the registry entry for a charge station. The operator maintains
+// the site, tags and coordinates: the other details are recorded when the charge station
+// is registered and each time it sends a BootNotification.
+type ChargeStation struct {
+	ChargeStationId string
+	SecurityProfile SecurityProfile
+	// OcppVersion, Vendor, Model, SerialNumber and FirmwareVersion are empty until
+	// the charge station has sent a BootNotification
+	OcppVersion     string
+	Vendor          string
+	Model           string
+	SerialNumber    string
+	FirmwareVersion string
+	SiteId          string
+	Coordinates     *GeoLocation
+	// Tags are the names of the tags assigned to the charge station, in order
+	Tags []string
+	// LastBoot is the time that the last BootNotification was received
+	LastBoot time.Time
+	// TenantId identifies the operator that the charge station belongs to when the
+	// manager serves several operators: it is empty for a charge station that is not
+	// scoped to an operator
+	TenantId string
+	// TariffId identifies the tariff used to price the charge station's transactions:
+	// the default tariff is used if it is empty
+	TariffId string
+	// Version is incremented each time the charge station is written
+	Version int
+}
+
+type ChargeStationStore interface {
+	// SetChargeStation writes the charge station if its Version matches the stored
+	// version (0 for a new charge station) and increments the Version: otherwise it
+	// returns ErrVersionConflict
+	SetChargeStation(ctx context.Context, chargeStationId string, chargeStation *ChargeStation) error
+	LookupChargeStation(ctx context.Context, chargeStationId string) (*ChargeStation, error)
+	ListChargeStations(ctx context.Context, offset int, limit int) ([]*ChargeStation, error)
+	// ListChargeStationsNearby returns the charge stations whose coordinates are within
+	// the radius in m of the point, nearest first
+	ListChargeStationsNearby(ctx context.Context, latitude, longitude, radius float64) ([]*ChargeStation, error)
+	DeleteChargeStation(ctx context.Context, chargeStationId string) error
+}
+
 type ChargeStationSettingStatus string
 
 var (
@@ -55,12 +99,19 @@ type ChargeStationSettingsStore interface {
 }
 
 type ChargeStationRuntimeDetails struct {
-	OcppVersion string
+	// ChargeStationId is only populated when listing runtime details
+	ChargeStationId string
+	OcppVersion     string
+	// Iso15118Version is the ISO 15118 version (ISO15118-2 or ISO15118-20) that the
+	// charge station used in its last Get15118EVCertificate request: it is empty until
+	// the charge station has made one
+	Iso15118Version string
 }
 
 type ChargeStationRuntimeDetailsStore interface {
 	SetChargeStationRuntimeDetails(ctx context.Context, chargeStationId string, details *ChargeStationRuntimeDetails) error
 	LookupChargeStationRuntimeDetails(ctx context.Context, chargeStationId string) (*ChargeStationRuntimeDetails, error)
+	ListChargeStationRuntimeDetails(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationRuntimeDetails, error)
 }
 
 type CertificateType string
@@ -101,6 +152,46 @@ type ChargeStationInstallCertificatesStore interface {
 	ListChargeStationInstallCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationInstallCertificates, error)
 }
 
+type InstalledCertificateStatus string
+
+var (
+	InstalledCertificateStatusInstalled     InstalledCertificateStatus = "Installed"
+	InstalledCertificateStatusDeletePending InstalledCertificateStatus = "DeletePending"
+	InstalledCertificateStatusDeleteFailed  InstalledCertificateStatus = "DeleteFailed"
+)
+
+type ChargeStationInstalledCertificate struct {
+	CertificateType CertificateType
+	HashAlgorithm   string
+	IssuerNameHash  string
+	IssuerKeyHash   string
+	SerialNumber    string
+	Status          InstalledCertificateStatus
+	SendAfter       time.Time
+}
+
+type InstalledCertificatesRefreshStatus string
+
+var (
+	InstalledCertificatesRefreshPending  InstalledCertificatesRefreshStatus = "Pending"
+	InstalledCertificatesRefreshAccepted InstalledCertificatesRefreshStatus = "Accepted"
+)
+
+// ChargeStationInstalledCertificates is the inventory of certificates that a charge
+// station has reported as installed (via GetInstalledCertificateIds).
+type ChargeStationInstalledCertificates struct {
+	ChargeStationId string
+	Certificates    []*ChargeStationInstalledCertificate
+	RefreshStatus   InstalledCertificatesRefreshStatus
+	SendAfter       time.Time
+}
+
+type ChargeStationInstalledCertificatesStore interface {
+	SetChargeStationInstalledCertificates(ctx context.Context, chargeStationId string, certificates *ChargeStationInstalledCertificates) error
+	LookupChargeStationInstalledCertificates(ctx context.Context, chargeStationId string) (*ChargeStationInstalledCertificates, error)
+	ListChargeStationInstalledCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationInstalledCertificates, error)
+}
+
 type TriggerStatus string
 
 var (
@@ -138,3 +229,95 @@ type ChargeStationTriggerMessageStore interface {
 	LookupChargeStationTriggerMessage(ctx context.Context, chargeStationId string) (*ChargeStationTriggerMessage, error)
 	ListChargeStationTriggerMessages(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationTriggerMessage, error)
 }
+
+type ProvisioningStatus string
+
+var (
+	ProvisioningStatusPending   ProvisioningStatus = "Pending"
+	ProvisioningStatusCompleted ProvisioningStatus = "Completed"
+	ProvisioningStatusFailed    ProvisioningStatus = "Failed"
+)
+
+// ChargeStationProvisioning records the progress of a charge station through the
+// provisioning script that is run before the charge station is accepted.
+type ChargeStationProvisioning struct {
+	ChargeStationId string
+	Status          ProvisioningStatus
+	// Step is the index of the next step of the script to run
+	Step      int
+	SendAfter time.Time
+}
+
+type ChargeStationProvisioningStore interface {
+	SetChargeStationProvisioning(ctx context.Context, chargeStationId string, provisioning *ChargeStationProvisioning) error
+	LookupChargeStationProvisioning(ctx context.Context, chargeStationId string) (*ChargeStationProvisioning, error)
+	ListChargeStationProvisioning(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationProvisioning, error)
+}
+
+type RegistrationStatus string
+
+var (
+	RegistrationStatusAccepted RegistrationStatus = "Accepted"
+	RegistrationStatusPending  RegistrationStatus = "Pending"
+	RegistrationStatusRejected RegistrationStatus = "Rejected"
+)
+
+// ChargeStationRegistration records whether a charge station is allowed to
+// complete a BootNotification.
+type ChargeStationRegistration struct {
+	ChargeStationId string
+	Status          RegistrationStatus
+}
+
+type ChargeStationRegistrationStore interface {
+	SetChargeStationRegistration(ctx context.Context, chargeStationId string, registration *ChargeStationRegistration) error
+	LookupChargeStationRegistration(ctx context.Context, chargeStationId string) (*ChargeStationRegistration, error)
+}
+
+// ChargeStationLiveness records when the CSMS last received a message from a
+// charge station and whether the charge station is considered to be offline.
+type ChargeStationLiveness struct {
+	ChargeStationId string
+	// LastSeen is the time that any message was last received
+	LastSeen time.Time
+	// LastHeartbeat is the time that a Heartbeat was last received: it is zero
+	// if the charge station has not sent a Heartbeat
+	LastHeartbeat time.Time
+	Offline       bool
+}
+
+type ChargeStationLivenessStore interface {
+	SetChargeStationLiveness(ctx context.Context, chargeStationId string, liveness *ChargeStationLiveness) error
+	LookupChargeStationLiveness(ctx context.Context, chargeStationId string) (*ChargeStationLiveness, error)
+	ListChargeStationLiveness(ctx context.Context, pageSize int, previousChargeStationId string) ([]*ChargeStationLiveness, error)
+}
+
+// ConnectorStatus is the status of a connector as last reported by the charge
+// station in a StatusNotification.
+type ConnectorStatus struct {
+	ChargeStationId string
+	// EvseId is 0 for OCPP 1.6 charge stations, which identify connectors
+	// uniquely within the charge station
+	EvseId      int
+	ConnectorId int
+	// Status is the OCPP connector status, e.g. Available or Charging
+	Status      string
+	LastUpdated time.Time
+}
+
+type ConnectorStatusStore interface {
+	SetConnectorStatus(ctx context.Context, status *ConnectorStatus) error
+	// ListConnectorStatuses returns the statuses of the charge station's connectors ordered
+	// by EVSE and then connector
+	ListConnectorStatuses(ctx context.Context, chargeStationId string) ([]*ConnectorStatus, error)
+}
+
+// SortConnectorStatuses orders connector statuses by EVSE and then connector.
+func SortConnectorStatuses(statuses []*ConnectorStatus) {
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].EvseId != statuses[j].EvseId {
+			return statuses[i].EvseId < statuses[j].EvseId
+		}
+		return statuses[i].ConnectorId < statuses[j].ConnectorId
+	})
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetBulkCommand(ctx context.Context, bulkCommand *store.BulkCommand) error {
+	err := update(ctx, s, entityKey("BulkCommand", bulkCommand.Id), func(existing *store.BulkCommand) (*store.BulkCommand, error) {
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if bulkCommand.Version != version {
+			return nil, store.ErrVersionConflict
+		}
+		clone := *bulkCommand
+		clone.Version++
+		return &clone, nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting bulk command %s: %w", bulkCommand.Id, err)
+	}
+	bulkCommand.Version++
+	return nil
+}
+
+func (s *Store) LookupBulkCommand(ctx context.Context, id string) (*store.BulkCommand, error) {
+	bulkCommand, err := get[store.BulkCommand](ctx, s, entityKey("BulkCommand", id))
+	if err != nil {
+		return nil, fmt.Errorf("lookup bulk command %s: %w", id, err)
+	}
+	return bulkCommand, nil
+}
+
+func (s *Store) ListBulkCommands(ctx context.Context, pageSize int, previousId string) ([]*store.BulkCommand, error) {
+	bulkCommands, err := list[store.BulkCommand](ctx, s, "BulkCommand", previousId, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list bulk commands: %w", err)
+	}
+	return bulkCommands, nil
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetChargeDetailRecord(ctx context.Context, cdr *store.ChargeDetailRecord) error {
+	err := s.put(ctx, entityKey("ChargeDetailRecord", cdr.Id), cdr)
+	if err != nil {
+		return fmt.Errorf("setting charge detail record %s: %w", cdr.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeDetailRecord(ctx context.Context, cdrId string) (*store.ChargeDetailRecord, error) {
+	cdr, err := get[store.ChargeDetailRecord](ctx, s, entityKey("ChargeDetailRecord", cdrId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge detail record %s: %w", cdrId, err)
+	}
+	return cdr, nil
+}
+
+func (s *Store) ListChargeDetailRecords(ctx context.Context, offset int, limit int) ([]*store.ChargeDetailRecord, error) {
+	cdrs, err := page[store.ChargeDetailRecord](ctx, s, "ChargeDetailRecord", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list charge detail records: %w", err)
+	}
+	return cdrs, nil
+}
+
+// QueryChargeDetailRecords reads the records a page at a time, following the sort key,
+// until a page of the records selected by the filter has been read
+func (s *Store) QueryChargeDetailRecords(ctx context.Context, filter *store.ChargeDetailRecordFilter, pageSize int, previousCdrId string) ([]*store.ChargeDetailRecord, error) {
+	input := s.keyCondition(listIndex, "type", "ChargeDetailRecord", "lk", previousCdrId, "")
+	if filter != nil {
+		if filter.ChargeStationIds != nil && len(filter.ChargeStationIds) == 0 {
+			return make([]*store.ChargeDetailRecord, 0), nil
+		}
+		filterChargeStations(input, filter.ChargeStationIds)
+	}
+	cdrs, err := selected(ctx, s, input, filter.Matches, 0, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("query charge detail records: %w", err)
+	}
+	return cdrs, nil
+}
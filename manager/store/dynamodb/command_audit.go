@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetCommandAuditRecord(ctx context.Context, record *store.CommandAuditRecord) error {
+	err := s.put(ctx, entityKey("CommandAuditRecord", record.Id), record)
+	if err != nil {
+		return fmt.Errorf("setting command audit record %s: %w", record.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupCommandAuditRecord(ctx context.Context, id string) (*store.CommandAuditRecord, error) {
+	record, err := get[store.CommandAuditRecord](ctx, s, entityKey("CommandAuditRecord", id))
+	if err != nil {
+		return nil, fmt.Errorf("lookup command audit record %s: %w", id, err)
+	}
+	return record, nil
+}
+
+func (s *Store) QueryCommandAuditRecords(ctx context.Context, filter *store.CommandAuditFilter, offset, limit int) ([]*store.CommandAuditRecord, error) {
+	input := s.keyCondition(listIndex, "type", "CommandAuditRecord", "lk", "", "")
+	if filter != nil {
+		chargeStationIds := filter.ChargeStationIds
+		if filter.ChargeStationId != "" {
+			chargeStationIds = []string{filter.ChargeStationId}
+		}
+		if chargeStationIds != nil && len(chargeStationIds) == 0 {
+			return make([]*store.CommandAuditRecord, 0), nil
+		}
+		filterChargeStations(input, chargeStationIds)
+	}
+	records, err := selected(ctx, s, input, filter.Matches, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query command audit records: %w", err)
+	}
+	return records, nil
+}
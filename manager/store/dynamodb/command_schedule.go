@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetCommandSchedule(ctx context.Context, schedule *store.CommandSchedule) error {
+	err := update(ctx, s, entityKey("CommandSchedule", schedule.Id), func(existing *store.CommandSchedule) (*store.CommandSchedule, error) {
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if schedule.Version != version {
+			return nil, store.ErrVersionConflict
+		}
+		clone := *schedule
+		clone.Version++
+		return &clone, nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting command schedule %s: %w", schedule.Id, err)
+	}
+	schedule.Version++
+	return nil
+}
+
+func (s *Store) LookupCommandSchedule(ctx context.Context, id string) (*store.CommandSchedule, error) {
+	schedule, err := get[store.CommandSchedule](ctx, s, entityKey("CommandSchedule", id))
+	if err != nil {
+		return nil, fmt.Errorf("lookup command schedule %s: %w", id, err)
+	}
+	return schedule, nil
+}
+
+func (s *Store) ListCommandSchedules(ctx context.Context, pageSize int, previousId string) ([]*store.CommandSchedule, error) {
+	schedules, err := list[store.CommandSchedule](ctx, s, "CommandSchedule", previousId, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list command schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+func (s *Store) DeleteCommandSchedule(ctx context.Context, id string) error {
+	err := s.delete(ctx, entityKey("CommandSchedule", id))
+	if err != nil {
+		return fmt.Errorf("deleting command schedule %s: %w", id, err)
+	}
+	return nil
+}
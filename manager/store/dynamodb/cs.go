@@ -0,0 +1,397 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"strconv"
+)
+
+func (s *Store) SetChargeStationAuth(ctx context.Context, chargeStationId string, auth *store.ChargeStationAuth) error {
+	err := s.put(ctx, entityKey("ChargeStation", chargeStationId), auth)
+	if err != nil {
+		return fmt.Errorf("setting charge station auth %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationAuth(ctx context.Context, chargeStationId string) (*store.ChargeStationAuth, error) {
+	auth, err := get[store.ChargeStationAuth](ctx, s, entityKey("ChargeStation", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station auth %s: %w", chargeStationId, err)
+	}
+	return auth, nil
+}
+
+func (s *Store) SetChargeStation(ctx context.Context, chargeStationId string, chargeStation *store.ChargeStation) error {
+	err := update(ctx, s, entityKey("ChargeStationDetails", chargeStationId), func(existing *store.ChargeStation) (*store.ChargeStation, error) {
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if chargeStation.Version != version {
+			return nil, store.ErrVersionConflict
+		}
+		clone := *chargeStation
+		clone.ChargeStationId = chargeStationId
+		clone.Version++
+		return &clone, nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station %s: %w", chargeStationId, err)
+	}
+	chargeStation.Version++
+	return nil
+}
+
+func (s *Store) LookupChargeStation(ctx context.Context, chargeStationId string) (*store.ChargeStation, error) {
+	chargeStation, err := get[store.ChargeStation](ctx, s, entityKey("ChargeStationDetails", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station %s: %w", chargeStationId, err)
+	}
+	return chargeStation, nil
+}
+
+func (s *Store) ListChargeStations(ctx context.Context, offset int, limit int) ([]*store.ChargeStation, error) {
+	chargeStations, err := page[store.ChargeStation](ctx, s, "ChargeStationDetails", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list charge stations: %w", err)
+	}
+	return chargeStations, nil
+}
+
+func (s *Store) ListTenantChargeStations(ctx context.Context, tenantId string, offset, limit int) ([]*store.ChargeStation, error) {
+	chargeStations, err := tenantPage[store.ChargeStation](ctx, s, "ChargeStationDetails", tenantId, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list charge stations of tenant %s: %w", tenantId, err)
+	}
+	return chargeStations, nil
+}
+
+// ListChargeStationsNearby reads the whole registry: the table has no index that
+// can select charge stations by location
+func (s *Store) ListChargeStationsNearby(ctx context.Context, latitude, longitude, radius float64) ([]*store.ChargeStation, error) {
+	chargeStations, err := list[store.ChargeStation](ctx, s, "ChargeStationDetails", "", "", 0)
+	if err != nil {
+		return nil, fmt.Errorf("list charge stations nearby: %w", err)
+	}
+	return store.ChargeStationsNearby(chargeStations, latitude, longitude, radius), nil
+}
+
+func (s *Store) DeleteChargeStation(ctx context.Context, chargeStationId string) error {
+	err := s.delete(ctx, entityKey("ChargeStationDetails", chargeStationId))
+	if err != nil {
+		return fmt.Errorf("deleting charge station %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) UpdateChargeStationSettings(ctx context.Context, chargeStationId string, settings *store.ChargeStationSettings) error {
+	err := update(ctx, s, entityKey("ChargeStationSettings", chargeStationId), func(set *store.ChargeStationSettings) (*store.ChargeStationSettings, error) {
+		if set == nil || set.Settings == nil {
+			set = &store.ChargeStationSettings{
+				ChargeStationId: chargeStationId,
+				Settings:        make(map[string]*store.ChargeStationSetting, len(settings.Settings)),
+			}
+		}
+		for k, v := range settings.Settings {
+			set.Settings[k] = v
+		}
+		return set, nil
+	})
+	if err != nil {
+		return fmt.Errorf("updating charge station settings %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationSettings(ctx context.Context, chargeStationId string) (*store.ChargeStationSettings, error) {
+	settings, err := get[store.ChargeStationSettings](ctx, s, entityKey("ChargeStationSettings", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station settings %s: %w", chargeStationId, err)
+	}
+	return settings, nil
+}
+
+func (s *Store) ListChargeStationSettings(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationSettings, error) {
+	settings, err := list[store.ChargeStationSettings](ctx, s, "ChargeStationSettings", previousChargeStationId, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *Store) DeleteChargeStationSettings(ctx context.Context, chargeStationId string) error {
+	err := s.delete(ctx, entityKey("ChargeStationSettings", chargeStationId))
+	if err != nil {
+		return fmt.Errorf("deleting charge station settings %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) SetChargeStationRuntimeDetails(ctx context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
+	err := s.put(ctx, entityKey("ChargeStationRuntimeDetails", chargeStationId), &store.ChargeStationRuntimeDetails{
+		ChargeStationId: chargeStationId,
+		OcppVersion:     details.OcppVersion,
+		Iso15118Version: details.Iso15118Version,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station runtime details %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationRuntimeDetails(ctx context.Context, chargeStationId string) (*store.ChargeStationRuntimeDetails, error) {
+	details, err := get[store.ChargeStationRuntimeDetails](ctx, s, entityKey("ChargeStationRuntimeDetails", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station runtime details %s: %w", chargeStationId, err)
+	}
+	return details, nil
+}
+
+func (s *Store) ListChargeStationRuntimeDetails(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationRuntimeDetails, error) {
+	details, err := list[store.ChargeStationRuntimeDetails](ctx, s, "ChargeStationRuntimeDetails", previousChargeStationId, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station runtime details: %w", err)
+	}
+	return details, nil
+}
+
+func (s *Store) UpdateChargeStationInstallCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationInstallCertificates) error {
+	err := update(ctx, s, entityKey("ChargeStationInstallCertificates", chargeStationId), func(certs *store.ChargeStationInstallCertificates) (*store.ChargeStationInstallCertificates, error) {
+		if certs == nil {
+			certs = &store.ChargeStationInstallCertificates{
+				ChargeStationId: chargeStationId,
+			}
+		}
+		for _, v := range certificates.Certificates {
+			matched := false
+			for _, c := range certs.Certificates {
+				if v.CertificateId == c.CertificateId {
+					c.CertificateData = v.CertificateData
+					c.CertificateInstallationStatus = v.CertificateInstallationStatus
+					c.CertificateType = v.CertificateType
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				certs.Certificates = append(certs.Certificates, v)
+			}
+		}
+		return certs, nil
+	})
+	if err != nil {
+		return fmt.Errorf("updating charge station install certificates %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationInstallCertificates(ctx context.Context, chargeStationId string) (*store.ChargeStationInstallCertificates, error) {
+	certs, err := get[store.ChargeStationInstallCertificates](ctx, s, entityKey("ChargeStationInstallCertificates", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station install certificates %s: %w", chargeStationId, err)
+	}
+	return certs, nil
+}
+
+func (s *Store) ListChargeStationInstallCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationInstallCertificates, error) {
+	certs, err := list[store.ChargeStationInstallCertificates](ctx, s, "ChargeStationInstallCertificates", previousChargeStationId, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station install certificates: %w", err)
+	}
+	return certs, nil
+}
+
+func (s *Store) SetChargeStationInstalledCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationInstalledCertificates) error {
+	err := s.put(ctx, entityKey("ChargeStationInstalledCertificates", chargeStationId), &store.ChargeStationInstalledCertificates{
+		ChargeStationId: chargeStationId,
+		Certificates:    certificates.Certificates,
+		RefreshStatus:   certificates.RefreshStatus,
+		SendAfter:       certificates.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station installed certificates %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationInstalledCertificates(ctx context.Context, chargeStationId string) (*store.ChargeStationInstalledCertificates, error) {
+	certs, err := get[store.ChargeStationInstalledCertificates](ctx, s, entityKey("ChargeStationInstalledCertificates", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station installed certificates %s: %w", chargeStationId, err)
+	}
+	return certs, nil
+}
+
+func (s *Store) ListChargeStationInstalledCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationInstalledCertificates, error) {
+	certs, err := list[store.ChargeStationInstalledCertificates](ctx, s, "ChargeStationInstalledCertificates", previousChargeStationId, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station installed certificates: %w", err)
+	}
+	return certs, nil
+}
+
+func (s *Store) SetChargeStationTriggerMessage(ctx context.Context, chargeStationId string, triggerMessage *store.ChargeStationTriggerMessage) error {
+	err := s.put(ctx, entityKey("ChargeStationTriggerMessage", chargeStationId), &store.ChargeStationTriggerMessage{
+		ChargeStationId: chargeStationId,
+		TriggerMessage:  triggerMessage.TriggerMessage,
+		TriggerStatus:   triggerMessage.TriggerStatus,
+		SendAfter:       triggerMessage.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station trigger message %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) DeleteChargeStationTriggerMessage(ctx context.Context, chargeStationId string) error {
+	err := s.delete(ctx, entityKey("ChargeStationTriggerMessage", chargeStationId))
+	if err != nil {
+		return fmt.Errorf("deleting charge station trigger message %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationTriggerMessage(ctx context.Context, chargeStationId string) (*store.ChargeStationTriggerMessage, error) {
+	triggerMessage, err := get[store.ChargeStationTriggerMessage](ctx, s, entityKey("ChargeStationTriggerMessage", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station trigger message %s: %w", chargeStationId, err)
+	}
+	return triggerMessage, nil
+}
+
+func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationTriggerMessage, error) {
+	triggerMessages, err := list[store.ChargeStationTriggerMessage](ctx, s, "ChargeStationTriggerMessage", previousChargeStationId, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station trigger messages: %w", err)
+	}
+	return triggerMessages, nil
+}
+
+func (s *Store) SetChargeStationProvisioning(ctx context.Context, chargeStationId string, provisioning *store.ChargeStationProvisioning) error {
+	err := s.put(ctx, entityKey("ChargeStationProvisioning", chargeStationId), &store.ChargeStationProvisioning{
+		ChargeStationId: chargeStationId,
+		Status:          provisioning.Status,
+		Step:            provisioning.Step,
+		SendAfter:       provisioning.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station provisioning %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationProvisioning(ctx context.Context, chargeStationId string) (*store.ChargeStationProvisioning, error) {
+	provisioning, err := get[store.ChargeStationProvisioning](ctx, s, entityKey("ChargeStationProvisioning", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station provisioning %s: %w", chargeStationId, err)
+	}
+	return provisioning, nil
+}
+
+func (s *Store) ListChargeStationProvisioning(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationProvisioning, error) {
+	provisioning, err := list[store.ChargeStationProvisioning](ctx, s, "ChargeStationProvisioning", previousChargeStationId, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station provisioning: %w", err)
+	}
+	return provisioning, nil
+}
+
+func (s *Store) SetChargeStationRegistration(ctx context.Context, chargeStationId string, registration *store.ChargeStationRegistration) error {
+	err := s.put(ctx, entityKey("ChargeStationRegistration", chargeStationId), &store.ChargeStationRegistration{
+		ChargeStationId: chargeStationId,
+		Status:          registration.Status,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station registration %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationRegistration(ctx context.Context, chargeStationId string) (*store.ChargeStationRegistration, error) {
+	registration, err := get[store.ChargeStationRegistration](ctx, s, entityKey("ChargeStationRegistration", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station registration %s: %w", chargeStationId, err)
+	}
+	return registration, nil
+}
+
+func (s *Store) SetChargeStationLiveness(ctx context.Context, chargeStationId string, liveness *store.ChargeStationLiveness) error {
+	err := s.put(ctx, entityKey("ChargeStationLiveness", chargeStationId), &store.ChargeStationLiveness{
+		ChargeStationId: chargeStationId,
+		LastSeen:        liveness.LastSeen,
+		LastHeartbeat:   liveness.LastHeartbeat,
+		Offline:         liveness.Offline,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station liveness %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationLiveness(ctx context.Context, chargeStationId string) (*store.ChargeStationLiveness, error) {
+	liveness, err := get[store.ChargeStationLiveness](ctx, s, entityKey("ChargeStationLiveness", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station liveness %s: %w", chargeStationId, err)
+	}
+	return liveness, nil
+}
+
+func (s *Store) ListChargeStationLiveness(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationLiveness, error) {
+	liveness, err := list[store.ChargeStationLiveness](ctx, s, "ChargeStationLiveness", previousChargeStationId, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station liveness: %w", err)
+	}
+	return liveness, nil
+}
+
+func (s *Store) SetConnectorStatus(ctx context.Context, status *store.ConnectorStatus) error {
+	// connector statuses are also listed so that faulted connectors can be counted
+	err := s.put(ctx, itemKey{
+		Pk:      fmt.Sprintf("ConnectorStatus#%s", status.ChargeStationId),
+		Sk:      fmt.Sprintf("%d:%d", status.EvseId, status.ConnectorId),
+		Type:    "ConnectorStatus",
+		ListKey: fmt.Sprintf("%s:%d:%d", status.ChargeStationId, status.EvseId, status.ConnectorId),
+	}, status)
+	if err != nil {
+		return fmt.Errorf("setting connector status %s: %w", status.ChargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) ListConnectorStatuses(ctx context.Context, chargeStationId string) ([]*store.ConnectorStatus, error) {
+	statuses, err := query[store.ConnectorStatus](ctx, s, fmt.Sprintf("ConnectorStatus#%s", chargeStationId), "", "", 0)
+	if err != nil {
+		return nil, fmt.Errorf("list connector statuses %s: %w", chargeStationId, err)
+	}
+	store.SortConnectorStatuses(statuses)
+	return statuses, nil
+}
+
+func (s *Store) SetEvse(ctx context.Context, evse *store.ChargeStationEvse) error {
+	err := s.put(ctx, itemKey{Pk: fmt.Sprintf("Evse#%s", evse.ChargeStationId), Sk: strconv.Itoa(evse.EvseId)}, evse)
+	if err != nil {
+		return fmt.Errorf("setting evse %s/%d: %w", evse.ChargeStationId, evse.EvseId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupEvse(ctx context.Context, chargeStationId string, evseId int) (*store.ChargeStationEvse, error) {
+	evse, err := get[store.ChargeStationEvse](ctx, s, itemKey{Pk: fmt.Sprintf("Evse#%s", chargeStationId), Sk: strconv.Itoa(evseId)})
+	if err != nil {
+		return nil, fmt.Errorf("lookup evse %s/%d: %w", chargeStationId, evseId, err)
+	}
+	return evse, nil
+}
+
+func (s *Store) ListEvses(ctx context.Context, chargeStationId string) ([]*store.ChargeStationEvse, error) {
+	evses, err := query[store.ChargeStationEvse](ctx, s, fmt.Sprintf("Evse#%s", chargeStationId), "", "", 0)
+	if err != nil {
+		return nil, fmt.Errorf("list evses %s: %w", chargeStationId, err)
+	}
+	store.SortEvses(evses)
+	return evses, nil
+}
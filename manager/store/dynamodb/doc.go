@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dynamodb provides an implementation of store.Engine using Amazon DynamoDB.
+// Everything is kept in a single table. Most records have a partition of their own,
+// whose partition key, pk, is the kind of record followed by its id (e.g. Token#<uid>),
+// so that no partition holds every record of a kind. Records that belong to a charge
+// station, such as its transactions and connector statuses, share a partition per
+// charge station, in which the sort key, sk, identifies the record. Records are listed
+// through the list global secondary index, whose partition key, type, is the kind of
+// record and whose sort key, lk, is its id.
+package dynamodb
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func exiResponseChunksKey(chargeStationId, exiRequestHash string) string {
+	return fmt.Sprintf("%s:%s", chargeStationId, exiRequestHash)
+}
+
+func (s *Store) SetExiResponseChunks(ctx context.Context, chunks *store.ExiResponseChunks) error {
+	key := exiResponseChunksKey(chunks.ChargeStationId, chunks.ExiRequestHash)
+	err := s.put(ctx, entityKey("ExiResponseChunks", key), chunks)
+	if err != nil {
+		return fmt.Errorf("setting exi response chunks %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) (*store.ExiResponseChunks, error) {
+	key := exiResponseChunksKey(chargeStationId, exiRequestHash)
+	chunks, err := get[store.ExiResponseChunks](ctx, s, entityKey("ExiResponseChunks", key))
+	if err != nil {
+		return nil, fmt.Errorf("lookup exi response chunks %s: %w", key, err)
+	}
+	return chunks, nil
+}
+
+func (s *Store) DeleteExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) error {
+	key := exiResponseChunksKey(chargeStationId, exiRequestHash)
+	err := s.delete(ctx, entityKey("ExiResponseChunks", key))
+	if err != nil {
+		return fmt.Errorf("deleting exi response chunks %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetLease(ctx context.Context, lease *store.Lease) error {
+	err := update(ctx, s, entityKey("Lease", lease.Name), func(existing *store.Lease) (*store.Lease, error) {
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if lease.Version != version {
+			return nil, store.ErrVersionConflict
+		}
+		clone := *lease
+		clone.Version++
+		return &clone, nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting lease %s: %w", lease.Name, err)
+	}
+	lease.Version++
+	return nil
+}
+
+func (s *Store) LookupLease(ctx context.Context, name string) (*store.Lease, error) {
+	lease, err := get[store.Lease](ctx, s, entityKey("Lease", name))
+	if err != nil {
+		return nil, fmt.Errorf("lookup lease %s: %w", name, err)
+	}
+	return lease, nil
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetLocation(ctx context.Context, location *store.Location) error {
+	// record when the location was stored, as firestore does
+	loc := *location
+	loc.LastUpdated = s.clock.Now().UTC().Format("2006-01-02T15:04:05Z")
+	err := s.put(ctx, entityKey("Location", location.Id), &loc)
+	if err != nil {
+		return fmt.Errorf("setting location %s: %w", location.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupLocation(ctx context.Context, locationId string) (*store.Location, error) {
+	location, err := get[store.Location](ctx, s, entityKey("Location", locationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup location %s: %w", locationId, err)
+	}
+	return location, nil
+}
+
+func (s *Store) ListLocations(ctx context.Context, offset int, limit int) ([]*store.Location, error) {
+	locations, err := page[store.Location](ctx, s, "Location", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list locations: %w", err)
+	}
+	return locations, nil
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package dynamodb_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/thoughtworks/maeve-csms/manager/store/dynamodb"
+	"k8s.io/utils/clock"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+var endpoint string
+
+func setup() func() {
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "amazon/dynamodb-local:2.2.1",
+		ExposedPorts: []string{"8000/tcp"},
+		Cmd:          []string{"-jar", "DynamoDBLocal.jar", "-inMemory"},
+		WaitingFor:   wait.ForExposedPort(),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		log.Println("Failed to wait for started container")
+		log.Fatal(err)
+	}
+
+	endpoint, err = container.PortEndpoint(ctx, "8000/tcp", "http")
+	if err != nil {
+		log.Println("Container did not expose endpoint")
+		log.Fatal(err)
+	}
+
+	// DynamoDB local accepts any credentials
+	_ = os.Setenv("AWS_ACCESS_KEY_ID", "test")
+	_ = os.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	_ = os.Setenv("AWS_REGION", "eu-west-2")
+
+	return func() {
+		if err := container.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err.Error())
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
+	teardown := setup()
+	exitVal := m.Run()
+	teardown()
+
+	os.Exit(exitVal)
+}
+
+// newStore returns a store for a new table named after the test
+func newStore(t *testing.T, clk clock.PassiveClock) *dynamodb.Store {
+	ctx := context.Background()
+	table := strings.ReplaceAll(t.Name(), "/", "_")
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	require.NoError(t, err)
+	client := awsdynamodb.NewFromConfig(cfg, func(o *awsdynamodb.Options) {
+		o.BaseEndpoint = aws.String(endpoint)
+	})
+	_, err = client.CreateTable(ctx, &awsdynamodb.CreateTableInput{
+		TableName: aws.String(table),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("pk"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("sk"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("type"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("lk"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("pk"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("sk"), KeyType: types.KeyTypeRange},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{{
+			IndexName: aws.String("list"),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("type"), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String("lk"), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		}},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	require.NoError(t, err, fmt.Sprintf("creating table %s", table))
+
+	engine, err := dynamodb.NewStore(ctx, table, endpoint, clk)
+	require.NoError(t, err)
+	return engine
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetOcspResponse(ctx context.Context, response *store.OcspResponse) error {
+	err := s.put(ctx, entityKey("OcspResponse", response.Key), response)
+	if err != nil {
+		return fmt.Errorf("setting ocsp response %s: %w", response.Key, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupOcspResponse(ctx context.Context, key string) (*store.OcspResponse, error) {
+	response, err := get[store.OcspResponse](ctx, s, entityKey("OcspResponse", key))
+	if err != nil {
+		return nil, fmt.Errorf("lookup ocsp response %s: %w", key, err)
+	}
+	return response, nil
+}
+
+func (s *Store) ListOcspResponses(ctx context.Context, pageSize int, previousKey string) ([]*store.OcspResponse, error) {
+	responses, err := list[store.OcspResponse](ctx, s, "OcspResponse", previousKey, "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list ocsp responses: %w", err)
+	}
+	return responses, nil
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetOicpSession(ctx context.Context, session *store.OicpSession) error {
+	err := s.put(ctx, entityKey("OicpSession", session.IdToken), session)
+	if err != nil {
+		return fmt.Errorf("setting oicp session %s: %w", session.IdToken, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupOicpSession(ctx context.Context, idToken string) (*store.OicpSession, error) {
+	session, err := get[store.OicpSession](ctx, s, entityKey("OicpSession", idToken))
+	if err != nil {
+		return nil, fmt.Errorf("lookup oicp session %s: %w", idToken, err)
+	}
+	return session, nil
+}
+
+func (s *Store) DeleteOicpSession(ctx context.Context, idToken string) error {
+	err := s.delete(ctx, entityKey("OicpSession", idToken))
+	if err != nil {
+		return fmt.Errorf("deleting oicp session %s: %w", idToken, err)
+	}
+	return nil
+}
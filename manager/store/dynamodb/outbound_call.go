@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetOutboundCallQueue(ctx context.Context, queue *store.OutboundCallQueue) error {
+	err := update(ctx, s, entityKey("OutboundCallQueues", queue.ChargeStationId), func(existing *store.OutboundCallQueue) (*store.OutboundCallQueue, error) {
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if queue.Version != version {
+			return nil, store.ErrVersionConflict
+		}
+		clone := *queue
+		clone.Version++
+		return &clone, nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting outbound call queue %s: %w", queue.ChargeStationId, err)
+	}
+	queue.Version++
+	return nil
+}
+
+func (s *Store) LookupOutboundCallQueue(ctx context.Context, chargeStationId string) (*store.OutboundCallQueue, error) {
+	queue, err := get[store.OutboundCallQueue](ctx, s, entityKey("OutboundCallQueues", chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup outbound call queue %s: %w", chargeStationId, err)
+	}
+	return queue, nil
+}
+
+func (s *Store) DeleteOutboundCallQueue(ctx context.Context, chargeStationId string) error {
+	err := s.delete(ctx, entityKey("OutboundCallQueues", chargeStationId))
+	if err != nil {
+		return fmt.Errorf("deleting outbound call queue %s: %w", chargeStationId, err)
+	}
+	return nil
+}
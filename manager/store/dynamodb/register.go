@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+)
+
+func init() {
+	store.Register("dynamodb", func(ctx context.Context, options map[string]any) (store.Engine, error) {
+		table, err := store.RequiredStringOption(options, "table")
+		if err != nil {
+			return nil, err
+		}
+		endpoint, err := store.StringOption(options, "endpoint")
+		if err != nil {
+			return nil, err
+		}
+		return NewStore(ctx, table, endpoint, clock.RealClock{})
+	})
+}
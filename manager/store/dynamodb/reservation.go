@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"strconv"
+	"time"
+)
+
+// reservationKey is zero-padded so that reservations are listed in numeric order
+func reservationKey(reservationId int) string {
+	return fmt.Sprintf("%019d", reservationId)
+}
+
+// acceptedUntil is the expiry date, in Unix milliseconds, of an accepted reservation: it
+// is stored alongside the reservation so that accepted reservations can be counted by
+// whether they have expired
+func acceptedUntil(reservation *store.Reservation) map[string]types.AttributeValue {
+	if reservation.Status != store.ReservationStatusAccepted {
+		return nil
+	}
+	return map[string]types.AttributeValue{
+		"acceptedUntil": &types.AttributeValueMemberN{Value: strconv.FormatInt(reservation.ExpiryDate.UnixMilli(), 10)},
+	}
+}
+
+func (s *Store) SetReservation(ctx context.Context, reservation *store.Reservation) error {
+	err := updateWithAttributes(ctx, s, entityKey("Reservation", reservationKey(reservation.ReservationId)), func(existing *store.Reservation) (*store.Reservation, error) {
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if reservation.Version != version {
+			return nil, store.ErrVersionConflict
+		}
+		clone := *reservation
+		clone.Version++
+		return &clone, nil
+	}, acceptedUntil)
+	if err != nil {
+		return fmt.Errorf("setting reservation %d: %w", reservation.ReservationId, err)
+	}
+	reservation.Version++
+	return nil
+}
+
+func (s *Store) LookupReservation(ctx context.Context, reservationId int) (*store.Reservation, error) {
+	reservation, err := get[store.Reservation](ctx, s, entityKey("Reservation", reservationKey(reservationId)))
+	if err != nil {
+		return nil, fmt.Errorf("lookup reservation %d: %w", reservationId, err)
+	}
+	return reservation, nil
+}
+
+func (s *Store) ListReservations(ctx context.Context, pageSize int, previousReservationId int) ([]*store.Reservation, error) {
+	reservations, err := list[store.Reservation](ctx, s, "Reservation", reservationKey(previousReservationId), "", pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+func newOwner() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// reservationLockKey gives each lock a partition of its own: locks are never listed
+func reservationLockKey(reservationId int) itemKey {
+	return itemKey{Pk: "ReservationLock#" + reservationKey(reservationId), Sk: "ReservationLock"}
+}
+
+type reservationLock struct {
+	Pk        string `dynamodbav:"pk"`
+	Sk        string `dynamodbav:"sk"`
+	Owner     string `dynamodbav:"owner"`
+	ExpiresAt int64  `dynamodbav:"expiresAt"`
+}
+
+func (s *Store) LockReservation(ctx context.Context, reservationId int, ttl time.Duration) (bool, error) {
+	now := s.clock.Now()
+	lockKey := reservationLockKey(reservationId)
+	item, err := attributevalue.MarshalMap(&reservationLock{
+		Pk:        lockKey.Pk,
+		Sk:        lockKey.Sk,
+		Owner:     s.owner,
+		ExpiresAt: now.Add(ttl).UnixMilli(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("locking reservation %d: %w", reservationId, err)
+	}
+	// a lock that has expired can be taken over by any manager instance
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.table),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(pk) OR expiresAt < :now"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(now.UnixMilli(), 10)},
+		},
+	})
+	var held *types.ConditionalCheckFailedException
+	if errors.As(err, &held) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("locking reservation %d: %w", reservationId, err)
+	}
+	return true, nil
+}
+
+func (s *Store) UnlockReservation(ctx context.Context, reservationId int) error {
+	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:           aws.String(s.table),
+		Key:                 reservationLockKey(reservationId).key(),
+		ConditionExpression: aws.String("#owner = :owner"),
+		ExpressionAttributeNames: map[string]string{
+			"#owner": "owner",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":owner": &types.AttributeValueMemberS{Value: s.owner},
+		},
+	})
+	// the lock has expired and been taken by another manager instance
+	var notHeld *types.ConditionalCheckFailedException
+	if errors.As(err, &notHeld) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unlocking reservation %d: %w", reservationId, err)
+	}
+	return nil
+}
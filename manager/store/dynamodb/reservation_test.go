@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package dynamodb_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/dynamodb"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupReservation(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+
+	evseId := 1
+	want := &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		EvseId:          &evseId,
+		IdToken:         "SOMERFID",
+		TokenType:       "ISO14443",
+		ExpiryDate:      time.Now().Add(time.Hour).UTC(),
+		Status:          store.ReservationStatusPending,
+		SendAfter:       time.Now().UTC(),
+	}
+
+	err := engine.SetReservation(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupReservation(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestListReservationsInNumericOrder(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+
+	for _, id := range []int{10, 2, 1} {
+		require.NoError(t, engine.SetReservation(ctx, &store.Reservation{ReservationId: id}))
+	}
+
+	got, err := engine.ListReservations(ctx, 10, 1)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, 2, got[0].ReservationId)
+	assert.Equal(t, 10, got[1].ReservationId)
+}
+
+func TestLockReservation(t *testing.T) {
+	ctx := context.Background()
+	clk := clockTest.NewFakePassiveClock(time.Now())
+	engine := newStore(t, clk)
+	// a second manager instance using the same table
+	other, err := dynamodb.NewStore(ctx, t.Name(), endpoint, clk)
+	require.NoError(t, err)
+
+	locked, err := engine.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	locked, err = other.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	// only the owner can release the lock
+	require.NoError(t, other.UnlockReservation(ctx, 1))
+	locked, err = other.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	require.NoError(t, engine.UnlockReservation(ctx, 1))
+	locked, err = other.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, locked)
+}
+
+func TestLockReservationAfterExpiry(t *testing.T) {
+	ctx := context.Background()
+	clk := clockTest.NewFakePassiveClock(time.Now())
+	engine := newStore(t, clk)
+	other, err := dynamodb.NewStore(ctx, t.Name(), endpoint, clk)
+	require.NoError(t, err)
+
+	locked, err := engine.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	clk.SetTime(clk.Now().Add(2 * time.Minute))
+
+	locked, err = other.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, locked)
+}
+
+func TestSetReservationWithStaleVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+
+	err := engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusPending,
+	})
+	require.NoError(t, err)
+
+	first, err := engine.LookupReservation(ctx, 1)
+	require.NoError(t, err)
+	second, err := engine.LookupReservation(ctx, 1)
+	require.NoError(t, err)
+
+	first.Status = store.ReservationStatusAccepted
+	err = engine.SetReservation(ctx, first)
+	require.NoError(t, err)
+
+	second.Status = store.ReservationStatusRejected
+	err = engine.SetReservation(ctx, second)
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+	got, err := engine.LookupReservation(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusAccepted, got.Status)
+	assert.Equal(t, 2, got.Version)
+}
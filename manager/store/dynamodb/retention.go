@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+// PurgeMeterValues rewrites each EVSE's meter values with a conditional write, so
+// meter values added concurrently are not lost
+func (s *Store) PurgeMeterValues(ctx context.Context, before time.Time) (int, error) {
+	all, err := list[store.EvseMeterValues](ctx, s, "MeterValues", "", "", 0)
+	if err != nil {
+		return 0, fmt.Errorf("list meter values: %w", err)
+	}
+
+	purged := 0
+	for _, evseMeterValues := range all {
+		if _, count := store.RetainMeterValues(evseMeterValues.MeterValues, before); count == 0 {
+			continue
+		}
+		key := meterValuesKey(evseMeterValues.ChargeStationId, evseMeterValues.EvseId)
+		var count int
+		err = update(ctx, s, entityKey("MeterValues", key), func(evseMeterValues *store.EvseMeterValues) (*store.EvseMeterValues, error) {
+			if evseMeterValues != nil {
+				evseMeterValues.MeterValues, count = store.RetainMeterValues(evseMeterValues.MeterValues, before)
+			}
+			return evseMeterValues, nil
+		})
+		if err != nil {
+			return purged, fmt.Errorf("purging meter values %s: %w", key, err)
+		}
+		purged += count
+	}
+	return purged, nil
+}
+
+// AnonymizeTransactions selects the transactions to anonymize by the start time held in
+// their records, so their meter values are not read, and rewrites each record with a
+// conditional write
+func (s *Store) AnonymizeTransactions(ctx context.Context, before time.Time) (int, error) {
+	candidates, err := s.transactionRecords(ctx, &store.TransactionFilter{
+		StartedBefore: &before,
+		Status:        store.TransactionStatusEnded,
+	}, "", 0)
+	if err != nil {
+		return 0, fmt.Errorf("list transactions: %w", err)
+	}
+
+	anonymized := 0
+	for _, candidate := range candidates {
+		if candidate.Data.IdToken == "" && candidate.Data.TokenType == "" {
+			continue
+		}
+		key := transactionKey(candidate.Data.ChargeStationId, candidate.Data.TransactionId)
+		changed, err := s.anonymizeTransaction(ctx, candidate.Data.ChargeStationId, candidate.Data.TransactionId, before)
+		if err != nil {
+			return anonymized, fmt.Errorf("anonymizing transaction %s: %w", key, err)
+		}
+		if changed {
+			anonymized++
+		}
+	}
+	return anonymized, nil
+}
+
+func (s *Store) anonymizeTransaction(ctx context.Context, chargeStationId, transactionId string, before time.Time) (bool, error) {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		rec, err := s.lookupTransaction(ctx, chargeStationId, transactionId)
+		if err != nil {
+			return false, err
+		}
+		if rec == nil || !rec.matches(&store.TransactionFilter{StartedBefore: &before, Status: store.TransactionStatusEnded}) ||
+			(rec.Data.IdToken == "" && rec.Data.TokenType == "") {
+			return false, nil
+		}
+		version := rec.Version
+		rec.Data.IdToken = ""
+		rec.Data.TokenType = ""
+		rec.Data.Version++
+		rec.Version++
+		ok, err := s.writeTransaction(ctx, rec, true, version, nil)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, fmt.Errorf("updated concurrently %d times", maxUpdateAttempts)
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"strconv"
+	"time"
+)
+
+// FleetStats counts the records in the list index with filter expressions, so DynamoDB
+// returns the counts rather than the records, and sums the energy delivered from the
+// single attribute that is projected from each completed transaction. The connector
+// statuses, transactions and reservations are selected by attributes that are written
+// alongside them: records written before the attributes were added are not counted
+// until they are next written.
+func (s *Store) FleetStats(ctx context.Context, since, now time.Time) (*store.FleetStats, error) {
+	stats := &store.FleetStats{}
+
+	chargeStations, err := s.count(ctx, "ChargeStationLiveness", "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("counting charge stations: %w", err)
+	}
+	stats.ChargeStationsOffline, err = s.count(ctx, "ChargeStationLiveness", "#data.Offline = :offline",
+		map[string]string{"#data": "data"},
+		map[string]types.AttributeValue{":offline": &types.AttributeValueMemberBOOL{Value: true}})
+	if err != nil {
+		return nil, fmt.Errorf("counting offline charge stations: %w", err)
+	}
+	stats.ChargeStationsOnline = chargeStations - stats.ChargeStationsOffline
+
+	stats.FaultedConnectors, err = s.count(ctx, "ConnectorStatus", "#data.#status = :faulted",
+		map[string]string{"#data": "data", "#status": "Status"},
+		map[string]types.AttributeValue{":faulted": &types.AttributeValueMemberS{Value: store.ConnectorStatusFaulted}})
+	if err != nil {
+		return nil, fmt.Errorf("counting faulted connectors: %w", err)
+	}
+
+	stats.ActiveTransactions, err = s.count(ctx, "Transaction", "#data.EndedSeqNo = :zero",
+		map[string]string{"#data": "data"},
+		map[string]types.AttributeValue{":zero": &types.AttributeValueMemberN{Value: "0"}})
+	if err != nil {
+		return nil, fmt.Errorf("counting active transactions: %w", err)
+	}
+	stats.CompletedTransactions, stats.EnergyDeliveredWh, err = s.completedTransactions(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("counting completed transactions: %w", err)
+	}
+
+	nowMillis := &types.AttributeValueMemberN{Value: strconv.FormatInt(now.UnixMilli(), 10)}
+	stats.ActiveReservations, err = s.count(ctx, "Reservation", "acceptedUntil > :now", nil,
+		map[string]types.AttributeValue{":now": nowMillis})
+	if err != nil {
+		return nil, fmt.Errorf("counting active reservations: %w", err)
+	}
+	stats.ExpiredReservations, err = s.count(ctx, "Reservation", "acceptedUntil <= :now", nil,
+		map[string]types.AttributeValue{":now": nowMillis})
+	if err != nil {
+		return nil, fmt.Errorf("counting expired reservations: %w", err)
+	}
+
+	return stats, nil
+}
+
+// completedTransactions returns the number of transactions that ended at or after since
+// and the energy that they delivered
+func (s *Store) completedTransactions(ctx context.Context, since time.Time) (int, float64, error) {
+	input := s.keyCondition(listIndex, "type", "Transaction", "lk", "", "")
+	input.FilterExpression = aws.String("endTime >= :since AND #data.EndedSeqNo <> :zero")
+	input.ProjectionExpression = aws.String("energyWh")
+	input.ExpressionAttributeNames["#data"] = "data"
+	input.ExpressionAttributeValues[":since"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(since.UnixMilli(), 10)}
+	input.ExpressionAttributeValues[":zero"] = &types.AttributeValueMemberN{Value: "0"}
+
+	var completed int
+	var energy float64
+	err := s.items(ctx, input, func(item map[string]types.AttributeValue) (bool, error) {
+		var transaction struct {
+			EnergyWh float64 `dynamodbav:"energyWh"`
+		}
+		if err := attributevalue.UnmarshalMap(item, &transaction); err != nil {
+			return false, err
+		}
+		completed++
+		energy += transaction.EnergyWh
+		return true, nil
+	})
+	return completed, energy, err
+}
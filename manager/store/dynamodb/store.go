@@ -0,0 +1,429 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"k8s.io/utils/clock"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxUpdateAttempts bounds the number of times a conditional write is retried when the
+// record is changed by another writer between being read and written
+const maxUpdateAttempts = 5
+
+// Store is an implementation of the store.Engine interface that persists data in a
+// DynamoDB table. The table must have a string partition key named pk and a string
+// sort key named sk, and a global secondary index named list that projects all
+// attributes and has a string partition key named type and a string sort key named lk.
+type Store struct {
+	client *dynamodb.Client
+	table  string
+	clock  clock.PassiveClock
+	// owner identifies the locks held by this Store
+	owner string
+}
+
+// NewStore creates a store for the table using the standard AWS SDK configuration
+// (environment, shared config files or instance roles). The endpoint overrides the
+// DynamoDB endpoint, e.g. to use DynamoDB local: it is ignored if empty.
+func NewStore(ctx context.Context, table, endpoint string, clock clock.PassiveClock) (*Store, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load aws config: %w", err)
+	}
+
+	client := dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+
+	return &Store{
+		client: client,
+		table:  table,
+		clock:  clock,
+		owner:  newOwner(),
+	}, nil
+}
+
+// Healthy returns an error if the table cannot be reached
+func (s *Store) Healthy() error {
+	_, err := s.client.DescribeTable(context.Background(), &dynamodb.DescribeTableInput{
+		TableName: aws.String(s.table),
+	})
+	return err
+}
+
+// IsTransient reports whether err is one that the AWS SDK retries, e.g. throttling, a
+// server error or a connection error, which is still likely to succeed if the
+// operation is retried once the SDK has given up. DynamoDB returns a
+// TransactionConflictException when a transaction conflicts with another one.
+func (s *Store) IsTransient(err error) bool {
+	if retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary {
+		return true
+	}
+	var conflict *types.TransactionConflictException
+	return errors.As(err, &conflict)
+}
+
+// listIndex is the global secondary index used to list the items of a type
+const listIndex = "list"
+
+// record is the item stored in the table: the stored value is held in data. Items that
+// are listed by type also have a type and a list key, which place them in the list
+// index: items without them are left out of it.
+type record[T any] struct {
+	Pk      string `dynamodbav:"pk"`
+	Sk      string `dynamodbav:"sk"`
+	Type    string `dynamodbav:"type,omitempty"`
+	ListKey string `dynamodbav:"lk,omitempty"`
+	Data    *T     `dynamodbav:"data"`
+	Version int64  `dynamodbav:"version"`
+}
+
+// itemKey locates an item in the table and, if Type is set, in the list index
+type itemKey struct {
+	Pk      string
+	Sk      string
+	Type    string
+	ListKey string
+}
+
+// entityKey is the key of an item that has a partition of its own, so that no single
+// partition holds every item of the type, and that is listed by id
+func entityKey(typ, id string) itemKey {
+	return itemKey{Pk: typ + "#" + id, Sk: typ, Type: typ, ListKey: id}
+}
+
+func (k itemKey) key() map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"pk": &types.AttributeValueMemberS{Value: k.Pk},
+		"sk": &types.AttributeValueMemberS{Value: k.Sk},
+	}
+}
+
+func newRecord[T any](k itemKey, value *T, version int64) *record[T] {
+	return &record[T]{Pk: k.Pk, Sk: k.Sk, Type: k.Type, ListKey: k.ListKey, Data: value, Version: version}
+}
+
+func (s *Store) put(ctx context.Context, k itemKey, value any) error {
+	item, err := attributevalue.MarshalMap(newRecord(k, &value, 0))
+	if err != nil {
+		return err
+	}
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.table),
+		Item:      item,
+	})
+	return err
+}
+
+// lookup returns the record or nil if there is none: consistent reads are used when the
+// record is about to be updated
+func lookup[T any](ctx context.Context, s *Store, k itemKey, consistent bool) (*record[T], error) {
+	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(s.table),
+		Key:            k.key(),
+		ConsistentRead: aws.Bool(consistent),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.Item == nil {
+		return nil, nil
+	}
+	var rec record[T]
+	if err = attributevalue.UnmarshalMap(out.Item, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// get returns the value or nil if there is none
+func get[T any](ctx context.Context, s *Store, k itemKey) (*T, error) {
+	rec, err := lookup[T](ctx, s, k, false)
+	if err != nil || rec == nil {
+		return nil, err
+	}
+	return rec.Data, nil
+}
+
+func (s *Store) delete(ctx context.Context, k itemKey) error {
+	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(s.table),
+		Key:       k.key(),
+	})
+	return err
+}
+
+// update performs an optimistic read-modify-write of a record: the write is conditional
+// on the record's version not having changed since it was read, and the update is
+// retried if it has. The value passed to fn is nil if there is no record.
+func update[T any](ctx context.Context, s *Store, k itemKey, fn func(value *T) (*T, error)) error {
+	return updateWithAttributes(ctx, s, k, fn, nil)
+}
+
+// updateWithAttributes performs an update that also stores the attributes returned by
+// attributes for the updated value alongside the record, so that records can be selected
+// by them in filter expressions
+func updateWithAttributes[T any](ctx context.Context, s *Store, k itemKey, fn func(value *T) (*T, error),
+	attributes func(value *T) map[string]types.AttributeValue) error {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		rec, err := lookup[T](ctx, s, k, true)
+		if err != nil {
+			return err
+		}
+
+		var value *T
+		var version int64
+		if rec != nil {
+			value = rec.Data
+			version = rec.Version
+		}
+		value, err = fn(value)
+		if err != nil {
+			return err
+		}
+
+		item, err := attributevalue.MarshalMap(newRecord(k, value, version+1))
+		if err != nil {
+			return err
+		}
+		if attributes != nil {
+			for name, attribute := range attributes(value) {
+				item[name] = attribute
+			}
+		}
+		input := &dynamodb.PutItemInput{
+			TableName: aws.String(s.table),
+			Item:      item,
+		}
+		input.ConditionExpression, input.ExpressionAttributeValues = versionCondition(rec != nil, version)
+
+		_, err = s.client.PutItem(ctx, input)
+		var conflict *types.ConditionalCheckFailedException
+		if errors.As(err, &conflict) {
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("%s/%s updated concurrently %d times", k.Pk, k.Sk, maxUpdateAttempts)
+}
+
+// versionCondition is the condition for writing an item that was read at the version,
+// or that did not exist when it was read
+func versionCondition(exists bool, version int64) (*string, map[string]types.AttributeValue) {
+	if !exists {
+		return aws.String("attribute_not_exists(pk)"), nil
+	}
+	return aws.String("version = :version"), map[string]types.AttributeValue{
+		":version": &types.AttributeValueMemberN{Value: strconv.FormatInt(version, 10)},
+	}
+}
+
+// keyCondition selects the items in the partition of the index (the table if index is
+// empty) whose sort key follows after, if after is not empty, or starts with prefix,
+// if prefix is not empty
+func (s *Store) keyCondition(index, partitionKey, partition, sortKey, after, prefix string) *dynamodb.QueryInput {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(s.table),
+		KeyConditionExpression: aws.String("#pk = :pk"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": partitionKey,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: partition},
+		},
+	}
+	if index != "" {
+		input.IndexName = aws.String(index)
+	}
+	switch {
+	case after != "":
+		input.KeyConditionExpression = aws.String("#pk = :pk AND #sk > :sk")
+		input.ExpressionAttributeNames["#sk"] = sortKey
+		input.ExpressionAttributeValues[":sk"] = &types.AttributeValueMemberS{Value: after}
+	case prefix != "":
+		input.KeyConditionExpression = aws.String("#pk = :pk AND begins_with(#sk, :sk)")
+		input.ExpressionAttributeNames["#sk"] = sortKey
+		input.ExpressionAttributeValues[":sk"] = &types.AttributeValueMemberS{Value: prefix}
+	}
+	return input
+}
+
+// items calls fn with each item returned by the query, a page at a time, until fn
+// returns false or there are no more items
+func (s *Store) items(ctx context.Context, input *dynamodb.QueryInput, fn func(item map[string]types.AttributeValue) (bool, error)) error {
+	for {
+		out, err := s.client.Query(ctx, input)
+		if err != nil {
+			return err
+		}
+		for _, item := range out.Items {
+			more, err := fn(item)
+			if err != nil || !more {
+				return err
+			}
+		}
+		if out.LastEvaluatedKey == nil {
+			return nil
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// values returns up to max values (all values if max is 0) returned by the query
+func values[T any](ctx context.Context, s *Store, input *dynamodb.QueryInput, max int) ([]*T, error) {
+	if max > 0 && max <= math.MaxInt32 && input.FilterExpression == nil {
+		// the limit bounds the items read, before any filter is applied
+		input.Limit = aws.Int32(int32(max))
+	}
+	values := make([]*T, 0)
+	err := s.items(ctx, input, func(item map[string]types.AttributeValue) (bool, error) {
+		var rec record[T]
+		if err := attributevalue.UnmarshalMap(item, &rec); err != nil {
+			return false, err
+		}
+		values = append(values, rec.Data)
+		return max == 0 || len(values) < max, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// count returns the number of items of the type in the list index that are selected by
+// the filter expression, or all of them if it is empty. DynamoDB counts the items
+// without returning them.
+func (s *Store) count(ctx context.Context, typ, filter string, names map[string]string, values map[string]types.AttributeValue) (int, error) {
+	input := s.keyCondition(listIndex, "type", typ, "lk", "", "")
+	input.Select = types.SelectCount
+	if filter != "" {
+		input.FilterExpression = aws.String(filter)
+	}
+	for name, value := range names {
+		input.ExpressionAttributeNames[name] = value
+	}
+	for name, value := range values {
+		input.ExpressionAttributeValues[name] = value
+	}
+
+	count := 0
+	for {
+		out, err := s.client.Query(ctx, input)
+		if err != nil {
+			return 0, err
+		}
+		count += int(out.Count)
+		if out.LastEvaluatedKey == nil {
+			return count, nil
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// query returns up to max values (all values if max is 0) from the partition ordered by
+// sort key. If after is not empty only values whose sort key follows it are returned
+// and if prefix is not empty only values whose sort key starts with it.
+func query[T any](ctx context.Context, s *Store, pk, after, prefix string, max int) ([]*T, error) {
+	return values[T](ctx, s, s.keyCondition("", "pk", pk, "sk", after, prefix), max)
+}
+
+// list returns up to max values (all values if max is 0) of the type ordered by list
+// key, in the same way as query. The list index is eventually consistent, so values
+// written very recently may be missing.
+func list[T any](ctx context.Context, s *Store, typ, after, prefix string, max int) ([]*T, error) {
+	return values[T](ctx, s, s.keyCondition(listIndex, "type", typ, "lk", after, prefix), max)
+}
+
+// page returns the values of the type ordered by list key starting at offset
+func page[T any](ctx context.Context, s *Store, typ string, offset, limit int) ([]*T, error) {
+	if limit <= 0 {
+		return make([]*T, 0), nil
+	}
+	max := offset + limit
+	if limit > math.MaxInt-offset {
+		max = 0
+	}
+	values, err := list[T](ctx, s, typ, "", "", max)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= len(values) {
+		return make([]*T, 0), nil
+	}
+	return values[offset:], nil
+}
+
+// selected returns the page of values returned by the query that match, or of all of
+// them if matches is nil, starting at offset. The query may select the items with a
+// filter expression, in which case DynamoDB reads the other items but does not return
+// them.
+func selected[T any](ctx context.Context, s *Store, input *dynamodb.QueryInput, matches func(*T) bool, offset, limit int) ([]*T, error) {
+	values := make([]*T, 0)
+	if limit <= 0 {
+		return values, nil
+	}
+	matched := 0
+	err := s.items(ctx, input, func(item map[string]types.AttributeValue) (bool, error) {
+		var rec record[T]
+		if err := attributevalue.UnmarshalMap(item, &rec); err != nil {
+			return false, err
+		}
+		if matches != nil && !matches(rec.Data) {
+			return true, nil
+		}
+		if matched >= offset {
+			values = append(values, rec.Data)
+		}
+		matched++
+		return len(values) < limit, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// tenantPage returns the page of the tenant's values of the type ordered by list key
+// starting at offset
+func tenantPage[T any](ctx context.Context, s *Store, typ, tenantId string, offset, limit int) ([]*T, error) {
+	input := s.keyCondition(listIndex, "type", typ, "lk", "", "")
+	input.FilterExpression = aws.String("#data.TenantId = :tenant")
+	input.ExpressionAttributeNames["#data"] = "data"
+	input.ExpressionAttributeValues[":tenant"] = &types.AttributeValueMemberS{Value: tenantId}
+	return selected[T](ctx, s, input, nil, offset, limit)
+}
+
+// maxInValues is the largest number of values that DynamoDB compares an attribute with
+// in an IN condition
+const maxInValues = 100
+
+// filterChargeStations adds a filter expression to the query that selects the items
+// whose data is for one of the charge stations. Longer lists than DynamoDB accepts in a
+// condition are left to the caller to apply to the values that are returned.
+func filterChargeStations(input *dynamodb.QueryInput, chargeStationIds []string) {
+	if len(chargeStationIds) == 0 || len(chargeStationIds) > maxInValues {
+		return
+	}
+	operands := make([]string, len(chargeStationIds))
+	for i, chargeStationId := range chargeStationIds {
+		operand := fmt.Sprintf(":cs%d", i)
+		operands[i] = operand
+		input.ExpressionAttributeValues[operand] = &types.AttributeValueMemberS{Value: chargeStationId}
+	}
+	input.FilterExpression = aws.String(fmt.Sprintf("#data.ChargeStationId IN (%s)", strings.Join(operands, ", ")))
+	input.ExpressionAttributeNames["#data"] = "data"
+}
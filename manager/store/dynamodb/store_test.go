@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package dynamodb_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestUpdateChargeStationSettingsMergesSettings(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+	now := time.Now().UTC()
+
+	err := engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"a": {Value: "1", Status: store.ChargeStationSettingStatusPending, SendAfter: now},
+			"b": {Value: "2", Status: store.ChargeStationSettingStatusPending, SendAfter: now},
+		},
+	})
+	require.NoError(t, err)
+	err = engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"b": {Value: "3", Status: store.ChargeStationSettingStatusAccepted, SendAfter: now},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationSettings{
+		ChargeStationId: "cs001",
+		Settings: map[string]*store.ChargeStationSetting{
+			"a": {Value: "1", Status: store.ChargeStationSettingStatusPending, SendAfter: now},
+			"b": {Value: "3", Status: store.ChargeStationSettingStatusAccepted, SendAfter: now},
+		},
+	}, got)
+}
+
+func TestListChargeStationRuntimeDetailsPages(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+
+	for i := 0; i < 5; i++ {
+		err := engine.SetChargeStationRuntimeDetails(ctx, fmt.Sprintf("cs%03d", i), &store.ChargeStationRuntimeDetails{
+			OcppVersion: "2.0.1",
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationRuntimeDetails(ctx, 2, "cs001")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs002", got[0].ChargeStationId)
+	assert.Equal(t, "cs003", got[1].ChargeStationId)
+}
+
+func TestSetAndListConnectorStatuses(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+	now := time.Now().UTC()
+
+	for _, status := range []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 2, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now},
+		{ChargeStationId: "cs002", EvseId: 1, ConnectorId: 1, Status: "Available", LastUpdated: now},
+	} {
+		require.NoError(t, engine.SetConnectorStatus(ctx, status))
+	}
+
+	got, err := engine.ListConnectorStatuses(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 2, ConnectorId: 1, Status: "Available", LastUpdated: now},
+	}, got)
+}
+
+func TestSetTokenRecordsLastUpdated(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	engine := newStore(t, clockTest.NewFakePassiveClock(now))
+
+	require.NoError(t, engine.SetToken(ctx, &store.Token{Uid: "DEADBEEF", Valid: true}))
+
+	got, err := engine.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "2023-06-01T12:00:00Z", got.LastUpdated)
+
+	missing, err := engine.LookupToken(ctx, "CAFEBABE")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestListPartyDetailsForRole(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+
+	for _, party := range []*store.OcpiParty{
+		{Role: "EMSP", CountryCode: "NL", PartyId: "EMS"},
+		{Role: "EMSP", CountryCode: "GB", PartyId: "EMS"},
+		{Role: "CPO", CountryCode: "GB", PartyId: "TWK"},
+	} {
+		require.NoError(t, engine.SetPartyDetails(ctx, party))
+	}
+
+	got, err := engine.ListPartyDetailsForRole(ctx, "EMSP")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "GB", got[0].CountryCode)
+	assert.Equal(t, "NL", got[1].CountryCode)
+}
+
+func TestListTenantChargeStations(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+
+	for i := 0; i < 5; i++ {
+		tenantId := "tenant-a"
+		if i == 2 {
+			tenantId = "tenant-b"
+		}
+		err := engine.SetChargeStation(ctx, fmt.Sprintf("cs%03d", i), &store.ChargeStation{TenantId: tenantId})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListTenantChargeStations(ctx, "tenant-a", 1, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs001", got[0].ChargeStationId)
+	assert.Equal(t, "cs003", got[1].ChargeStationId)
+
+	ids, err := store.TenantChargeStationIds(ctx, engine, "tenant-b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cs002"}, ids)
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+func (s *Store) SetToken(ctx context.Context, token *store.Token) error {
+	lastUpdated := s.clock.Now().UTC().Format(time.RFC3339)
+	err := update(ctx, s, entityKey("Token", token.Uid), func(existing *store.Token) (*store.Token, error) {
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if token.Version != version {
+			return nil, store.ErrVersionConflict
+		}
+		clone := *token
+		clone.LastUpdated = lastUpdated
+		clone.Version++
+		return &clone, nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting token: %s: %w", token.Uid, err)
+	}
+	token.LastUpdated = lastUpdated
+	token.Version++
+	return nil
+}
+
+func (s *Store) LookupToken(ctx context.Context, tokenUid string) (*store.Token, error) {
+	token, err := get[store.Token](ctx, s, entityKey("Token", tokenUid))
+	if err != nil {
+		return nil, fmt.Errorf("lookup token %s: %w", tokenUid, err)
+	}
+	return token, nil
+}
+
+func (s *Store) ListTokens(ctx context.Context, offset int, limit int) ([]*store.Token, error) {
+	tokens, err := page[store.Token](ctx, s, "Token", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+func (s *Store) ListTenantTokens(ctx context.Context, tenantId string, offset, limit int) ([]*store.Token, error) {
+	tokens, err := tenantPage[store.Token](ctx, s, "Token", tenantId, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens of tenant %s: %w", tenantId, err)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,520 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// meterValuesPerItem bounds the number of meter values held in a single meter value
+// item so that items stay well below DynamoDB's 400KB limit
+const meterValuesPerItem = 100
+
+func transactionKey(chargeStationId, transactionId string) string {
+	return fmt.Sprintf("%s:%s", chargeStationId, transactionId)
+}
+
+// transactionPartition holds the transactions of a charge station: each transaction is
+// an item whose sort key is the transaction id followed by #, and which is followed in
+// the partition by the items holding its meter values
+func transactionPartition(chargeStationId string) string {
+	return "Transaction#" + chargeStationId
+}
+
+func transactionItemKey(chargeStationId, transactionId string) itemKey {
+	return itemKey{
+		Pk:      transactionPartition(chargeStationId),
+		Sk:      transactionId + "#",
+		Type:    "Transaction",
+		ListKey: transactionKey(chargeStationId, transactionId),
+	}
+}
+
+func meterValueItemKey(chargeStationId, transactionId string, seq int) itemKey {
+	return itemKey{
+		Pk: transactionPartition(chargeStationId),
+		Sk: fmt.Sprintf("%s#mv#%010d", transactionId, seq),
+	}
+}
+
+// transactionRecord is the item stored for a transaction. The transaction's meter values
+// are not held in data, which would grow with every meter value the charge station
+// reports, but in meter value items: each write that adds meter values adds an item
+// numbered from 0. Items numbered below MeterValuesFrom were replaced by a later write.
+// StartTime is the transaction's start time, kept so that transactions can be selected
+// without reading their meter values, and EndTime, in Unix milliseconds, and EnergyWh
+// are the time and value of the outlet energy reading taken when the transaction ended,
+// kept so that the energy delivered can be aggregated.
+type transactionRecord struct {
+	Pk              string             `dynamodbav:"pk"`
+	Sk              string             `dynamodbav:"sk"`
+	Type            string             `dynamodbav:"type"`
+	ListKey         string             `dynamodbav:"lk"`
+	Data            *store.Transaction `dynamodbav:"data"`
+	StartTime       *time.Time         `dynamodbav:"startTime,omitempty"`
+	EndTime         *int64             `dynamodbav:"endTime,omitempty"`
+	EnergyWh        float64            `dynamodbav:"energyWh,omitempty"`
+	MeterValueItems int                `dynamodbav:"mvItems"`
+	MeterValuesFrom int                `dynamodbav:"mvFrom"`
+	Version         int64              `dynamodbav:"version"`
+}
+
+type meterValueRecord struct {
+	Pk   string             `dynamodbav:"pk"`
+	Sk   string             `dynamodbav:"sk"`
+	Seq  int                `dynamodbav:"seq"`
+	Data []store.MeterValue `dynamodbav:"data"`
+}
+
+// matches applies the filter to the transaction using the stored start time in place
+// of the meter values that the record does not hold
+func (r *transactionRecord) matches(filter *store.TransactionFilter) bool {
+	if filter == nil {
+		return true
+	}
+	unbounded := *filter
+	unbounded.StartedFrom, unbounded.StartedBefore = nil, nil
+	if !unbounded.Matches(r.Data) {
+		return false
+	}
+	if filter.StartedFrom != nil || filter.StartedBefore != nil {
+		if r.StartTime == nil {
+			return false
+		}
+		if filter.StartedFrom != nil && r.StartTime.Before(*filter.StartedFrom) {
+			return false
+		}
+		if filter.StartedBefore != nil && !r.StartTime.Before(*filter.StartedBefore) {
+			return false
+		}
+	}
+	return true
+}
+
+// addMeterValues records the meter values in the transaction record and returns the
+// items that hold them
+func (r *transactionRecord) addMeterValues(meterValues []store.MeterValue) []*meterValueRecord {
+	var items []*meterValueRecord
+	for len(meterValues) > 0 {
+		n := min(len(meterValues), meterValuesPerItem)
+		k := meterValueItemKey(r.Data.ChargeStationId, r.Data.TransactionId, r.MeterValueItems)
+		items = append(items, &meterValueRecord{Pk: k.Pk, Sk: k.Sk, Seq: r.MeterValueItems, Data: meterValues[:n]})
+		r.MeterValueItems++
+		meterValues = meterValues[n:]
+	}
+	return items
+}
+
+// startTime sets the record's start time to the transaction's start time, which is the
+// earliest of the meter values' timestamps
+func (r *transactionRecord) startTime(meterValues []store.MeterValue) {
+	for _, meterValue := range meterValues {
+		ts, err := time.Parse(time.RFC3339, meterValue.Timestamp)
+		if err != nil {
+			continue
+		}
+		if r.StartTime == nil || ts.Before(*r.StartTime) {
+			ts := ts.UTC()
+			r.StartTime = &ts
+		}
+	}
+}
+
+// endReading sets the record's end time and energy from the meter values if they hold
+// an earlier outlet energy reading taken when the transaction ended
+func (r *transactionRecord) endReading(meterValues []store.MeterValue) {
+	endTime, energy, ok := (&store.Transaction{MeterValues: meterValues}).EndOutletEnergy()
+	if ok && (r.EndTime == nil || endTime.UnixMilli() < *r.EndTime) {
+		millis := endTime.UnixMilli()
+		r.EndTime = &millis
+		r.EnergyWh = energy
+	}
+}
+
+// lookupTransaction returns the transaction record, without the transaction's meter
+// values, or nil if there is none
+func (s *Store) lookupTransaction(ctx context.Context, chargeStationId, transactionId string) (*transactionRecord, error) {
+	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(s.table),
+		Key:            transactionItemKey(chargeStationId, transactionId).key(),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil || out.Item == nil {
+		return nil, err
+	}
+	var rec transactionRecord
+	if err = attributevalue.UnmarshalMap(out.Item, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// writeTransaction writes the transaction record, which was read at version (or did not
+// exist if existing is false), and the meter value items in a single DynamoDB
+// transaction. It returns false if the record was changed by another writer since it was
+// read.
+func (s *Store) writeTransaction(ctx context.Context, rec *transactionRecord, existing bool, version int64, meterValues []*meterValueRecord) (bool, error) {
+	item, err := attributevalue.MarshalMap(rec)
+	if err != nil {
+		return false, err
+	}
+	put := &types.Put{
+		TableName: aws.String(s.table),
+		Item:      item,
+	}
+	put.ConditionExpression, put.ExpressionAttributeValues = versionCondition(existing, version)
+
+	if len(meterValues) == 0 {
+		_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName:                 put.TableName,
+			Item:                      put.Item,
+			ConditionExpression:       put.ConditionExpression,
+			ExpressionAttributeValues: put.ExpressionAttributeValues,
+		})
+		var conflict *types.ConditionalCheckFailedException
+		if errors.As(err, &conflict) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	items := []types.TransactWriteItem{{Put: put}}
+	for _, meterValue := range meterValues {
+		item, err := attributevalue.MarshalMap(meterValue)
+		if err != nil {
+			return false, err
+		}
+		items = append(items, types.TransactWriteItem{Put: &types.Put{
+			TableName: aws.String(s.table),
+			Item:      item,
+		}})
+	}
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	})
+	var canceled *types.TransactionCanceledException
+	if errors.As(err, &canceled) && len(canceled.CancellationReasons) > 0 &&
+		aws.ToString(canceled.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// updateTransaction applies the batch to the transaction record with an optimistic
+// read-modify-write, in the same way as update, adding an item for the batch's meter
+// values rather than reading and rewriting the transaction's existing meter values
+func (s *Store) updateTransaction(ctx context.Context, batch *store.TransactionBatch) error {
+	header := *batch
+	header.MeterValues = nil
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		rec, err := s.lookupTransaction(ctx, batch.ChargeStationId, batch.TransactionId)
+		if err != nil {
+			return err
+		}
+		existing := rec != nil
+		var version int64
+		if existing {
+			version = rec.Version
+		} else {
+			k := transactionItemKey(batch.ChargeStationId, batch.TransactionId)
+			rec = &transactionRecord{Pk: k.Pk, Sk: k.Sk, Type: k.Type, ListKey: k.ListKey}
+		}
+
+		rec.Data, err = store.ApplyTransactionBatch(rec.Data, &header)
+		if err != nil {
+			return err
+		}
+		rec.Version = version + 1
+		rec.startTime(batch.MeterValues)
+		rec.endReading(batch.MeterValues)
+		items := rec.addMeterValues(batch.MeterValues)
+
+		ok, err := s.writeTransaction(ctx, rec, existing, version, items)
+		if err != nil || ok {
+			return err
+		}
+	}
+	return fmt.Errorf("transaction %s/%s updated concurrently %d times", batch.ChargeStationId, batch.TransactionId, maxUpdateAttempts)
+}
+
+// transactions returns up to max transactions (all transactions if max is 0) selected
+// by the filter from the items returned by the query, which must return each
+// transaction's record followed by its meter value items, as a query of a charge
+// station's partition does
+func (s *Store) transactions(ctx context.Context, input *dynamodb.QueryInput, filter *store.TransactionFilter, max int) ([]*store.Transaction, error) {
+	transactions := make([]*store.Transaction, 0)
+	var current *transactionRecord
+	err := s.items(ctx, input, func(item map[string]types.AttributeValue) (bool, error) {
+		if _, ok := item["type"]; ok {
+			if max > 0 && len(transactions) == max {
+				return false, nil
+			}
+			var rec transactionRecord
+			if err := attributevalue.UnmarshalMap(item, &rec); err != nil {
+				return false, err
+			}
+			current = nil
+			if rec.matches(filter) {
+				current = &rec
+				transactions = append(transactions, rec.Data)
+			}
+			return true, nil
+		}
+		var meterValues meterValueRecord
+		if err := attributevalue.UnmarshalMap(item, &meterValues); err != nil {
+			return false, err
+		}
+		if current != nil && strings.HasPrefix(meterValues.Sk, current.Sk) &&
+			meterValues.Seq >= current.MeterValuesFrom && meterValues.Seq < current.MeterValueItems {
+			current.Data.MeterValues = append(current.Data.MeterValues, meterValues.Data...)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, transaction := range transactions {
+		store.SortMeterValues(transaction.MeterValues)
+	}
+	return transactions, nil
+}
+
+// stationTransactions returns up to max transactions (all transactions if max is 0)
+// recorded by the charge station and selected by the filter, in transaction id order,
+// that follow the transaction with the id after if it is not empty
+func (s *Store) stationTransactions(ctx context.Context, chargeStationId string, filter *store.TransactionFilter, after string, max int) ([]*store.Transaction, error) {
+	// every item of a transaction has a sort key that starts with its id and #, so all
+	// of them sort before its id followed by $
+	if after != "" {
+		after += "$"
+	}
+	input := s.keyCondition("", "pk", transactionPartition(chargeStationId), "sk", after, "")
+	return s.transactions(ctx, input, filter, max)
+}
+
+// listedTransactions returns up to max transactions (all transactions if max is 0)
+// selected by the filter from the list index, in charge station and transaction id
+// order, that follow the transaction with the list key after if it is not empty. The
+// meter values of each selected transaction are read from its charge station's partition.
+func (s *Store) listedTransactions(ctx context.Context, filter *store.TransactionFilter, after string, max int) ([]*store.Transaction, error) {
+	selected, err := s.transactionRecords(ctx, filter, after, max)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*store.Transaction, 0, len(selected))
+	for _, rec := range selected {
+		transaction, err := s.transaction(ctx, rec.Data.ChargeStationId, rec.Data.TransactionId, false)
+		if err != nil {
+			return nil, err
+		}
+		if transaction != nil {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions, nil
+}
+
+// transactionRecords returns up to max transaction records (all records if max is 0)
+// selected by the filter from the list index that follow the list key after if it is
+// not empty
+func (s *Store) transactionRecords(ctx context.Context, filter *store.TransactionFilter, after string, max int) ([]*transactionRecord, error) {
+	records := make([]*transactionRecord, 0)
+	input := s.keyCondition(listIndex, "type", "Transaction", "lk", after, "")
+	err := s.items(ctx, input, func(item map[string]types.AttributeValue) (bool, error) {
+		var rec transactionRecord
+		if err := attributevalue.UnmarshalMap(item, &rec); err != nil {
+			return false, err
+		}
+		if rec.matches(filter) {
+			records = append(records, &rec)
+		}
+		return max == 0 || len(records) < max, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// transaction returns the transaction with its meter values or nil if there is none
+func (s *Store) transaction(ctx context.Context, chargeStationId, transactionId string, consistent bool) (*store.Transaction, error) {
+	input := s.keyCondition("", "pk", transactionPartition(chargeStationId), "sk", "", transactionId+"#")
+	input.ConsistentRead = aws.Bool(consistent)
+	transactions, err := s.transactions(ctx, input, nil, 1)
+	if err != nil || len(transactions) == 0 {
+		return nil, err
+	}
+	return transactions[0], nil
+}
+
+// queryTransactions reads the partitions of the charge stations selected by the filter,
+// in charge station order, or the list index if the filter does not select charge
+// stations, starting after the previous transaction if it is not nil
+func (s *Store) queryTransactions(ctx context.Context, filter *store.TransactionFilter, max int, previous *store.Transaction) ([]*store.Transaction, error) {
+	var chargeStationIds []string
+	switch {
+	case filter != nil && filter.ChargeStationId != "":
+		chargeStationIds = []string{filter.ChargeStationId}
+	case filter != nil && filter.ChargeStationIds != nil:
+		chargeStationIds = slices.Clone(filter.ChargeStationIds)
+		sort.Strings(chargeStationIds)
+	default:
+		var after string
+		if previous != nil {
+			after = transactionKey(previous.ChargeStationId, previous.TransactionId)
+		}
+		return s.listedTransactions(ctx, filter, after, max)
+	}
+
+	transactions := make([]*store.Transaction, 0)
+	for _, chargeStationId := range chargeStationIds {
+		var after string
+		if previous != nil {
+			if chargeStationId < previous.ChargeStationId {
+				continue
+			}
+			if chargeStationId == previous.ChargeStationId {
+				after = previous.TransactionId
+			}
+		}
+		remaining := 0
+		if max > 0 {
+			remaining = max - len(transactions)
+		}
+		found, err := s.stationTransactions(ctx, chargeStationId, filter, after, remaining)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, found...)
+		if max > 0 && len(transactions) >= max {
+			break
+		}
+	}
+	return transactions, nil
+}
+
+func (s *Store) Transactions(ctx context.Context) ([]*store.Transaction, error) {
+	transactions, err := s.listedTransactions(ctx, nil, "", 0)
+	if err != nil {
+		return nil, fmt.Errorf("list transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+func (s *Store) QueryTransactions(ctx context.Context, filter *store.TransactionFilter, offset, limit int) ([]*store.Transaction, error) {
+	if limit <= 0 {
+		return make([]*store.Transaction, 0), nil
+	}
+	transactions, err := s.queryTransactions(ctx, filter, offset+limit, nil)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	if offset >= len(transactions) {
+		return make([]*store.Transaction, 0), nil
+	}
+	return transactions[offset:], nil
+}
+
+// QueryTransactionsAfter reads only the partitions of the charge stations selected by
+// the filter, if it selects any, and otherwise reads the list index. Transactions are
+// ordered by charge station and then by transaction id.
+func (s *Store) QueryTransactionsAfter(ctx context.Context, filter *store.TransactionFilter, pageSize int, previous *store.Transaction) ([]*store.Transaction, error) {
+	if pageSize <= 0 {
+		return make([]*store.Transaction, 0), nil
+	}
+	transactions, err := s.queryTransactions(ctx, filter, pageSize, previous)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+func (s *Store) FindTransaction(ctx context.Context, chargeStationId, transactionId string) (*store.Transaction, error) {
+	transaction, err := s.transaction(ctx, chargeStationId, transactionId, false)
+	if err != nil {
+		return nil, fmt.Errorf("lookup transaction %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return transaction, nil
+}
+
+func (s *Store) CreateTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int, offline bool) error {
+	err := s.updateTransaction(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationStart,
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     meterValues,
+		SeqNo:           seqNo,
+		Offline:         offline,
+	})
+	if err != nil {
+		return fmt.Errorf("creating transaction %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return nil
+}
+
+func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValues []store.MeterValue) error {
+	err := s.updateTransaction(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationUpdate,
+		MeterValues:     meterValues,
+	})
+	if err != nil {
+		return fmt.Errorf("updating transaction %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return nil
+}
+
+func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int) error {
+	err := s.updateTransaction(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationEnd,
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     meterValues,
+		SeqNo:           seqNo,
+	})
+	if err != nil {
+		return fmt.Errorf("ending transaction %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return nil
+}
+
+func (s *Store) MarkTransactionRecoveredFromOffline(ctx context.Context, chargeStationId, transactionId string) error {
+	return s.updateTransaction(ctx, &store.TransactionBatch{
+		ChargeStationId:      chargeStationId,
+		TransactionId:        transactionId,
+		RecoveredFromOffline: true,
+	})
+}
+
+func (s *Store) AddTransactionChargingState(ctx context.Context, chargeStationId, transactionId string, chargingState store.ChargingStateChange) error {
+	return s.updateTransaction(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		ChargingState:   &chargingState,
+	})
+}
+
+func (s *Store) SetTransactionCost(ctx context.Context, chargeStationId, transactionId string, cost *store.CostBreakdown) error {
+	return s.updateTransaction(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Cost:            cost,
+	})
+}
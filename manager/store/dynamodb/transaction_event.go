@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// transactionEventPartition holds the events of a transaction
+func transactionEventPartition(chargeStationId, transactionId string) string {
+	return "TransactionEvent#" + transactionKey(chargeStationId, transactionId)
+}
+
+// transactionEventKey orders the events of a transaction by the time they were
+// recorded: the random suffix keeps events recorded at the same time apart
+func transactionEventKey(event *store.TransactionEvent) itemKey {
+	return itemKey{
+		Pk: transactionEventPartition(event.Batch.ChargeStationId, event.Batch.TransactionId),
+		Sk: fmt.Sprintf("%s#%s", event.RecordedAt.UTC().Format("20060102T150405.000000000Z"), newOwner()),
+	}
+}
+
+func (s *Store) AppendTransactionEvent(ctx context.Context, event *store.TransactionEvent) error {
+	err := s.put(ctx, transactionEventKey(event), event)
+	if err != nil {
+		return fmt.Errorf("appending transaction event %s/%s: %w", event.Batch.ChargeStationId, event.Batch.TransactionId, err)
+	}
+	return nil
+}
+
+func (s *Store) ListTransactionEvents(ctx context.Context, chargeStationId, transactionId string) ([]*store.TransactionEvent, error) {
+	events, err := query[store.TransactionEvent](ctx, s, transactionEventPartition(chargeStationId, transactionId), "", "", 0)
+	if err != nil {
+		return nil, fmt.Errorf("list transaction events %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return events, nil
+}
+
+// ReplaceTransaction writes the transaction's meter values to new meter value items
+// that replace the existing ones, which are then deleted
+func (s *Store) ReplaceTransaction(ctx context.Context, transaction *store.Transaction) error {
+	replaced, err := s.replaceTransaction(ctx, transaction)
+	if err != nil {
+		return fmt.Errorf("replacing transaction %s/%s: %w", transaction.ChargeStationId, transaction.TransactionId, err)
+	}
+	// items that are not deleted are ignored, as they are numbered below the record's
+	// MeterValuesFrom
+	for _, k := range replaced {
+		_ = s.delete(ctx, k)
+	}
+	return nil
+}
+
+// replaceTransaction returns the keys of the meter value items that were replaced
+func (s *Store) replaceTransaction(ctx context.Context, transaction *store.Transaction) ([]itemKey, error) {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		rec, err := s.lookupTransaction(ctx, transaction.ChargeStationId, transaction.TransactionId)
+		if err != nil {
+			return nil, err
+		}
+		existing := rec != nil
+		var version int64
+		clone := *transaction
+		clone.MeterValues = nil
+		clone.Version = 1
+		if existing {
+			version = rec.Version
+			clone.Version = rec.Data.Version + 1
+		} else {
+			k := transactionItemKey(transaction.ChargeStationId, transaction.TransactionId)
+			rec = &transactionRecord{Pk: k.Pk, Sk: k.Sk, Type: k.Type, ListKey: k.ListKey}
+		}
+
+		var replaced []itemKey
+		for seq := rec.MeterValuesFrom; seq < rec.MeterValueItems; seq++ {
+			replaced = append(replaced, meterValueItemKey(transaction.ChargeStationId, transaction.TransactionId, seq))
+		}
+		rec.Data = &clone
+		rec.Version = version + 1
+		rec.MeterValuesFrom = rec.MeterValueItems
+		rec.StartTime, rec.EndTime, rec.EnergyWh = nil, nil, 0
+		rec.startTime(transaction.MeterValues)
+		rec.endReading(transaction.MeterValues)
+		items := rec.addMeterValues(transaction.MeterValues)
+
+		ok, err := s.writeTransaction(ctx, rec, existing, version, items)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return replaced, nil
+		}
+	}
+	return nil, fmt.Errorf("updated concurrently %d times", maxUpdateAttempts)
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package dynamodb_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTransactionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+	energyRegister := "Energy.Active.Import.Register"
+	meterValue := func(timestamp string, value float64) store.MeterValue {
+		return store.MeterValue{
+			Timestamp:     timestamp,
+			SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: value}},
+		}
+	}
+
+	err := engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{meterValue("2023-06-01T12:00:00Z", 0)}, 0, false)
+	require.NoError(t, err)
+	err = engine.UpdateTransaction(ctx, "cs001", "tx001",
+		[]store.MeterValue{meterValue("2023-06-01T12:30:00Z", 500)})
+	require.NoError(t, err)
+	err = engine.EndTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{meterValue("2023-06-01T12:15:00Z", 250)}, 2)
+	require.NoError(t, err)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		MeterValues: []store.MeterValue{
+			meterValue("2023-06-01T12:00:00Z", 0),
+			meterValue("2023-06-01T12:15:00Z", 250),
+			meterValue("2023-06-01T12:30:00Z", 500),
+		},
+		EndedSeqNo:        2,
+		UpdatedSeqNoCount: 1,
+		Version:           3,
+	}, got)
+
+	transactions, err := engine.Transactions(ctx)
+	require.NoError(t, err)
+	assert.Len(t, transactions, 1)
+}
+
+func TestConcurrentTransactionUpdatesAreNotLost(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 0, false))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, engine.UpdateTransaction(ctx, "cs001", "tx001", nil))
+		}()
+	}
+	wg.Wait()
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.UpdatedSeqNoCount)
+}
+
+func TestMarkTransactionRecoveredFromOfflineThatDoesNotExist(t *testing.T) {
+	engine := newStore(t, clock.RealClock{})
+
+	err := engine.MarkTransactionRecoveredFromOffline(context.Background(), "cs001", "tx001")
+	assert.ErrorContains(t, err, "transaction cs001/tx001 not found")
+
+	got, err := engine.FindTransaction(context.Background(), "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestQueryTransactionsAfterByChargeStation(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+	for _, cs := range []string{"cs002", "cs001", "cs003"} {
+		for _, tx := range []string{"tx001", "tx002"} {
+			require.NoError(t, engine.CreateTransaction(ctx, cs, tx, "DEADBEEF", "ISO14443",
+				[]store.MeterValue{{Timestamp: "2023-06-01T12:00:00Z"}}, 0, false))
+			require.NoError(t, engine.UpdateTransaction(ctx, cs, tx,
+				[]store.MeterValue{{Timestamp: "2023-06-01T12:30:00Z"}}))
+		}
+	}
+
+	filter := &store.TransactionFilter{ChargeStationIds: []string{"cs003", "cs001"}}
+	var got []string
+	var previous *store.Transaction
+	for {
+		page, err := engine.QueryTransactionsAfter(ctx, filter, 3, previous)
+		require.NoError(t, err)
+		for _, transaction := range page {
+			assert.Len(t, transaction.MeterValues, 2)
+			got = append(got, transaction.ChargeStationId+"/"+transaction.TransactionId)
+		}
+		if len(page) < 3 {
+			break
+		}
+		previous = page[len(page)-1]
+	}
+	assert.Equal(t, []string{"cs001/tx001", "cs001/tx002", "cs003/tx001", "cs003/tx002"}, got)
+}
+
+func TestReplaceTransactionReplacesMeterValues(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t, clock.RealClock{})
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{{Timestamp: "2023-06-01T12:00:00Z"}}, 0, false))
+	require.NoError(t, engine.UpdateTransaction(ctx, "cs001", "tx001",
+		[]store.MeterValue{{Timestamp: "2023-06-01T12:30:00Z"}}))
+
+	var meterValues []store.MeterValue
+	for i := 0; i < 250; i++ {
+		meterValues = append(meterValues, store.MeterValue{
+			Timestamp: time.Date(2023, 6, 1, 11, 0, i, 0, time.UTC).Format(time.RFC3339),
+		})
+	}
+	err := engine.ReplaceTransaction(ctx, &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		MeterValues:     meterValues,
+		EndedSeqNo:      1,
+	})
+	require.NoError(t, err)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, meterValues, got.MeterValues)
+	assert.Equal(t, 3, got.Version)
+	startTime, ok := got.StartTime()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2023, 6, 1, 11, 0, 0, 0, time.UTC), startTime)
+}
@@ -2,15 +2,81 @@
 
 package store
 
+import (
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"io"
+)
+
 type Engine interface {
+	ChargeStationStore
 	ChargeStationAuthStore
 	ChargeStationSettingsStore
 	ChargeStationRuntimeDetailsStore
 	ChargeStationInstallCertificatesStore
+	ChargeStationInstalledCertificatesStore
 	ChargeStationTriggerMessageStore
+	ChargeStationProvisioningStore
+	ChargeStationRegistrationStore
+	ChargeStationLivenessStore
+	ConnectorStatusStore
+	EvseStore
 	TokenStore
 	TransactionStore
+	MeterValueStore
 	CertificateStore
 	OcpiStore
+	OicpStore
 	LocationStore
+	ChargeDetailRecordStore
+	TariffStore
+	ReservationStore
+	ExiResponseStore
+	OcspResponseStore
+	OutboundCallQueueStore
+	CommandAuditStore
+	BulkCommandStore
+	CommandScheduleStore
+	LeaseStore
+	SiteStore
+	TagStore
+	RetentionStore
+}
+
+// ErrVersionConflict is returned when a versioned record is written with a version
+// that no longer matches the stored record: another writer has changed it since it
+// was read. The caller should read the record again and reapply its change. It is an
+// errs.ErrConflict.
+var ErrVersionConflict = errs.New(errs.ErrConflict, "version conflict")
+
+// HealthReporter is implemented by engines that can report whether the
+// underlying storage is currently reachable.
+type HealthReporter interface {
+	Healthy() error
+}
+
+// TransientErrorClassifier is implemented by engines that can tell which of their
+// errors are transient: the operation that failed is likely to succeed if it is
+// retried, e.g. because the storage was busy, throttled the request or could not be
+// reached.
+type TransientErrorClassifier interface {
+	IsTransient(err error) bool
+}
+
+// IsTransient reports whether the engine classifies err as transient. It is false for
+// engines that are not TransientErrorClassifiers. It is used by engines that wrap
+// another engine.
+func IsTransient(engine Engine, err error) bool {
+	if classifier, ok := engine.(TransientErrorClassifier); ok {
+		return classifier.IsTransient(err)
+	}
+	return false
+}
+
+// Close releases the resources, such as connections, that are held by an engine that
+// implements io.Closer. It is used by engines that wrap another engine.
+func Close(engine Engine) error {
+	if closer, ok := engine.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
 }
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package eventlog provides a store.Engine that wraps another store.Engine, recording
+// each change to a transaction in an append-only log before it is applied. The log
+// can be used to audit how a transaction was built and to rebuild the transaction
+// from its events after a handler bug wrote the wrong values.
+package eventlog
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package eventlog
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// Store is a store.Engine that appends an event to the log for each change made to a
+// transaction, then applies the change to the underlying engine. An event is recorded
+// even if the change cannot be applied, so that a replay can repair the transaction.
+type Store struct {
+	store.Engine
+	log   store.TransactionEventStore
+	clock clock.PassiveClock
+}
+
+// NewStore records the events in log, which is usually the engine that is wrapped by
+// engine: it is passed separately so that the log is reachable through wrappers that
+// do not expose it
+func NewStore(engine store.Engine, log store.TransactionEventStore, clock clock.PassiveClock) *Store {
+	return &Store{
+		Engine: engine,
+		log:    log,
+		clock:  clock,
+	}
+}
+
+func (s *Store) CreateTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int, offline bool) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationStart,
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     meterValues,
+		SeqNo:           seqNo,
+		Offline:         offline,
+	})
+}
+
+func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValues []store.MeterValue) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationUpdate,
+		MeterValues:     meterValues,
+	})
+}
+
+func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationEnd,
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     meterValues,
+		SeqNo:           seqNo,
+	})
+}
+
+func (s *Store) MarkTransactionRecoveredFromOffline(ctx context.Context, chargeStationId, transactionId string) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId:      chargeStationId,
+		TransactionId:        transactionId,
+		RecoveredFromOffline: true,
+	})
+}
+
+func (s *Store) AddTransactionChargingState(ctx context.Context, chargeStationId, transactionId string, chargingState store.ChargingStateChange) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		ChargingState:   &chargingState,
+	})
+}
+
+func (s *Store) SetTransactionCost(ctx context.Context, chargeStationId, transactionId string, cost *store.CostBreakdown) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Cost:            cost,
+	})
+}
+
+func (s *Store) WriteTransactionBatch(ctx context.Context, batch *store.TransactionBatch) error {
+	err := s.log.AppendTransactionEvent(ctx, &store.TransactionEvent{
+		RecordedAt: s.clock.Now().UTC(),
+		Batch:      *batch,
+	})
+	if err != nil {
+		return err
+	}
+	return store.WriteTransactionBatch(ctx, s.Engine, batch)
+}
+
+func (s *Store) FleetStats(ctx context.Context, since, now time.Time) (*store.FleetStats, error) {
+	return store.ComputeFleetStats(ctx, s.Engine, since, now)
+}
+
+func (s *Store) ListTenantChargeStations(ctx context.Context, tenantId string, offset, limit int) ([]*store.ChargeStation, error) {
+	return store.ListTenantChargeStations(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) ListTenantTokens(ctx context.Context, tenantId string, offset, limit int) ([]*store.Token, error) {
+	return store.ListTenantTokens(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) ListTenantSites(ctx context.Context, tenantId string, offset, limit int) ([]*store.Site, error) {
+	return store.ListTenantSites(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) ListTenantTags(ctx context.Context, tenantId string, offset, limit int) ([]*store.Tag, error) {
+	return store.ListTenantTags(ctx, s.Engine, tenantId, offset, limit)
+}
+
+func (s *Store) AppendTransactionEvent(ctx context.Context, event *store.TransactionEvent) error {
+	return s.log.AppendTransactionEvent(ctx, event)
+}
+
+func (s *Store) ListTransactionEvents(ctx context.Context, chargeStationId, transactionId string) ([]*store.TransactionEvent, error) {
+	return s.log.ListTransactionEvents(ctx, chargeStationId, transactionId)
+}
+
+func (s *Store) ReplaceTransaction(ctx context.Context, transaction *store.Transaction) error {
+	return s.log.ReplaceTransaction(ctx, transaction)
+}
+
+// Replay rebuilds the transaction from its events, replacing the stored transaction.
+// It returns the rebuilt transaction or nil if there are no events for the transaction,
+// in which case the stored transaction is left unchanged. Transactions that started
+// before the log was enabled must not be replayed as their earlier changes are lost.
+func (s *Store) Replay(ctx context.Context, chargeStationId, transactionId string) (*store.Transaction, error) {
+	events, err := s.log.ListTransactionEvents(ctx, chargeStationId, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	transaction := store.ProjectTransaction(events)
+	if transaction == nil {
+		return nil, nil
+	}
+	if err = s.log.ReplaceTransaction(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("replaying transaction %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return transaction, nil
+}
+
+// LockReservation always acquires the lock if the underlying engine cannot hold locks
+func (s *Store) LockReservation(ctx context.Context, reservationId int, ttl time.Duration) (bool, error) {
+	if locker, ok := s.Engine.(store.ReservationLockStore); ok {
+		return locker.LockReservation(ctx, reservationId, ttl)
+	}
+	return true, nil
+}
+
+func (s *Store) UnlockReservation(ctx context.Context, reservationId int) error {
+	if locker, ok := s.Engine.(store.ReservationLockStore); ok {
+		return locker.UnlockReservation(ctx, reservationId)
+	}
+	return nil
+}
+
+// Healthy reports the health of the underlying engine
+func (s *Store) Healthy() error {
+	if reporter, ok := s.Engine.(store.HealthReporter); ok {
+		return reporter.Healthy()
+	}
+	return nil
+}
+
+// Close closes the underlying engine
+func (s *Store) Close() error {
+	return store.Close(s.Engine)
+}
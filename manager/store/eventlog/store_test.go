@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package eventlog_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/eventlog"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func meterValue(timestamp string, value float64) store.MeterValue {
+	energyRegister := "Energy.Active.Import.Register"
+	return store.MeterValue{
+		Timestamp:     timestamp,
+		SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: value}},
+	}
+}
+
+func TestStoreRecordsTransactionEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	engine := inmemory.NewStore(clock.RealClock{})
+	s := eventlog.NewStore(engine, engine, clockTest.NewFakePassiveClock(now))
+
+	err := s.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{meterValue("2023-06-01T12:00:00Z", 0)}, 0, false)
+	require.NoError(t, err)
+	err = s.UpdateTransaction(ctx, "cs001", "tx001",
+		[]store.MeterValue{meterValue("2023-06-01T12:30:00Z", 500)})
+	require.NoError(t, err)
+	err = s.EndTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{meterValue("2023-06-01T13:00:00Z", 1000)}, 2)
+	require.NoError(t, err)
+
+	events, err := s.ListTransactionEvents(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, now, events[0].RecordedAt)
+	assert.Equal(t, store.TransactionOperationStart, events[0].Batch.Operation)
+	assert.Equal(t, store.TransactionOperationUpdate, events[1].Batch.Operation)
+	assert.Equal(t, store.TransactionOperationEnd, events[2].Batch.Operation)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, 2, got.EndedSeqNo)
+	assert.Len(t, got.MeterValues, 3)
+}
+
+func TestStoreReplayRebuildsTransaction(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	s := eventlog.NewStore(engine, engine, clock.RealClock{})
+
+	err := s.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{meterValue("2023-06-01T12:00:00Z", 0)}, 0, false)
+	require.NoError(t, err)
+	err = s.EndTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{meterValue("2023-06-01T13:00:00Z", 1000)}, 1)
+	require.NoError(t, err)
+
+	want, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+
+	// simulate a handler bug that overwrote the meter values
+	corrupted := *want
+	corrupted.MeterValues = []store.MeterValue{meterValue("2023-06-01T13:00:00Z", 5)}
+	err = engine.ReplaceTransaction(ctx, &corrupted)
+	require.NoError(t, err)
+
+	replayed, err := s.Replay(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	require.NotNil(t, replayed)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, want.MeterValues, got.MeterValues)
+	assert.Equal(t, want.EndedSeqNo, got.EndedSeqNo)
+	assert.Equal(t, want.Version+2, got.Version)
+}
+
+func TestStoreReplayWithoutEvents(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	s := eventlog.NewStore(engine, engine, clock.RealClock{})
+
+	err := engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{meterValue("2023-06-01T12:00:00Z", 0)}, 0, false)
+	require.NoError(t, err)
+
+	replayed, err := s.Replay(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Nil(t, replayed)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, "DEADBEEF", got.IdToken)
+}
+
+func TestProjectTransactionSkipsEventsThatCannotBeApplied(t *testing.T) {
+	events := []*store.TransactionEvent{
+		{
+			Batch: store.TransactionBatch{
+				ChargeStationId:      "cs001",
+				TransactionId:        "tx001",
+				RecoveredFromOffline: true,
+			},
+		},
+		{
+			Batch: store.TransactionBatch{
+				ChargeStationId: "cs001",
+				TransactionId:   "tx001",
+				Operation:       store.TransactionOperationStart,
+				IdToken:         "DEADBEEF",
+				TokenType:       "ISO14443",
+				MeterValues:     []store.MeterValue{meterValue("2023-06-01T12:00:00Z", 0)},
+			},
+		},
+	}
+
+	got := store.ProjectTransaction(events)
+	require.NotNil(t, got)
+	assert.Equal(t, "DEADBEEF", got.IdToken)
+	assert.False(t, got.RecoveredFromOffline)
+	assert.Len(t, events[1].Batch.MeterValues, 1)
+	assert.Nil(t, store.ProjectTransaction(nil))
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// ChargeStationEvse is an EVSE of a charge station and the connectors that it has.
+// The EVSEs and connectors are discovered from the messages that the charge station
+// sends and their metadata can be set through the API.
+type ChargeStationEvse struct {
+	ChargeStationId string
+	// EvseId is 0 for OCPP 1.6 charge stations, which identify connectors uniquely
+	// within the charge station
+	EvseId int
+	// Connectors are ordered by id
+	Connectors []ChargeStationConnector
+	// DepartureTime is when the EV that is charging at the EVSE is expected to leave,
+	// as given in its charging needs: it is zero when it is not known
+	DepartureTime time.Time
+	LastUpdated   time.Time
+}
+
+// ChargeStationConnector is a connector of an EVSE
+type ChargeStationConnector struct {
+	ConnectorId int
+	// ConnectorType is the OCPP 2.0.1 connector type, e.g. cType2 or cCCS2: it is
+	// empty if it is not known
+	ConnectorType string
+	// MaxPower is the maximum power that the connector can deliver in W: it is 0 if
+	// it is not known
+	MaxPower float64
+	// PhysicalLabel is the label on the connector that a driver sees, e.g. "A" or "2"
+	PhysicalLabel string
+}
+
+// Connector returns the connector with the id, or nil if the EVSE does not have it
+func (e *ChargeStationEvse) Connector(connectorId int) *ChargeStationConnector {
+	for i := range e.Connectors {
+		if e.Connectors[i].ConnectorId == connectorId {
+			return &e.Connectors[i]
+		}
+	}
+	return nil
+}
+
+// AddConnector returns the connector with the id, adding it to the EVSE if the EVSE
+// does not have it
+func (e *ChargeStationEvse) AddConnector(connectorId int) *ChargeStationConnector {
+	if connector := e.Connector(connectorId); connector != nil {
+		return connector
+	}
+	e.Connectors = append(e.Connectors, ChargeStationConnector{ConnectorId: connectorId})
+	sort.Slice(e.Connectors, func(i, j int) bool {
+		return e.Connectors[i].ConnectorId < e.Connectors[j].ConnectorId
+	})
+	return e.Connector(connectorId)
+}
+
+type EvseStore interface {
+	SetEvse(ctx context.Context, evse *ChargeStationEvse) error
+	// LookupEvse returns nil if the charge station does not have the EVSE
+	LookupEvse(ctx context.Context, chargeStationId string, evseId int) (*ChargeStationEvse, error)
+	// ListEvses returns the charge station's EVSEs ordered by id
+	ListEvses(ctx context.Context, chargeStationId string) ([]*ChargeStationEvse, error)
+}
+
+// SortEvses orders EVSEs by id.
+func SortEvses(evses []*ChargeStationEvse) {
+	sort.Slice(evses, func(i, j int) bool {
+		return evses[i].EvseId < evses[j].EvseId
+	})
+}
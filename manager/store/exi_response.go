@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// ExiResponseChunks holds an EXI response that is too large to be returned to the
+// charge station in a single message. The charge station retrieves the chunks one
+// at a time by repeating the request.
+type ExiResponseChunks struct {
+	ChargeStationId string
+	ExiRequestHash  string
+	Chunks          []string
+	ExpiresAt       time.Time
+}
+
+type ExiResponseStore interface {
+	SetExiResponseChunks(ctx context.Context, chunks *ExiResponseChunks) error
+	LookupExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) (*ExiResponseChunks, error)
+	DeleteExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) error
+}
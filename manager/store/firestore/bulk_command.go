@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type bulkCommandSelector struct {
+	SiteId          string   `firestore:"site"`
+	FirmwareVersion string   `firestore:"fw"`
+	Vendor          string   `firestore:"vendor"`
+	Model           string   `firestore:"model"`
+	Tags            []string `firestore:"tags"`
+}
+
+type bulkCommandResult struct {
+	ChargeStationId string    `firestore:"cs"`
+	Status          string    `firestore:"s"`
+	Result          string    `firestore:"res"`
+	SentAt          time.Time `firestore:"sent"`
+	CompletedAt     time.Time `firestore:"done"`
+}
+
+type bulkCommand struct {
+	Id                   string               `firestore:"id"`
+	Action               string               `firestore:"a"`
+	Selector             bulkCommandSelector  `firestore:"sel"`
+	ResetType            string               `firestore:"rt"`
+	Key                  string               `firestore:"k"`
+	Value                string               `firestore:"v"`
+	FirmwareLocation     string               `firestore:"fl"`
+	FirmwareRetrieveDate time.Time            `firestore:"frd"`
+	MaxInFlight          int                  `firestore:"max"`
+	Status               string               `firestore:"s"`
+	Results              []*bulkCommandResult `firestore:"r"`
+	TenantId             string               `firestore:"tn"`
+	CreatedBy            string               `firestore:"by"`
+	CreatedAt            time.Time            `firestore:"at"`
+	Version              int                  `firestore:"ver"`
+}
+
+func mapBulkCommand(data *bulkCommand) *store.BulkCommand {
+	results := make([]*store.BulkCommandResult, len(data.Results))
+	for i, result := range data.Results {
+		results[i] = &store.BulkCommandResult{
+			ChargeStationId: result.ChargeStationId,
+			Status:          store.BulkCommandResultStatus(result.Status),
+			Result:          result.Result,
+			SentAt:          result.SentAt,
+			CompletedAt:     result.CompletedAt,
+		}
+	}
+	return &store.BulkCommand{
+		Id:     data.Id,
+		Action: store.BulkCommandAction(data.Action),
+		Selector: store.BulkCommandSelector{
+			SiteId:          data.Selector.SiteId,
+			FirmwareVersion: data.Selector.FirmwareVersion,
+			Vendor:          data.Selector.Vendor,
+			Model:           data.Selector.Model,
+			Tags:            data.Selector.Tags,
+		},
+		ResetType:            data.ResetType,
+		Key:                  data.Key,
+		Value:                data.Value,
+		FirmwareLocation:     data.FirmwareLocation,
+		FirmwareRetrieveDate: data.FirmwareRetrieveDate,
+		MaxInFlight:          data.MaxInFlight,
+		Status:               store.BulkCommandStatus(data.Status),
+		Results:              results,
+		TenantId:             data.TenantId,
+		CreatedBy:            data.CreatedBy,
+		CreatedAt:            data.CreatedAt,
+		Version:              data.Version,
+	}
+}
+
+func newBulkCommand(b *store.BulkCommand) *bulkCommand {
+	results := make([]*bulkCommandResult, len(b.Results))
+	for i, result := range b.Results {
+		results[i] = &bulkCommandResult{
+			ChargeStationId: result.ChargeStationId,
+			Status:          string(result.Status),
+			Result:          result.Result,
+			SentAt:          result.SentAt,
+			CompletedAt:     result.CompletedAt,
+		}
+	}
+	return &bulkCommand{
+		Id:     b.Id,
+		Action: string(b.Action),
+		Selector: bulkCommandSelector{
+			SiteId:          b.Selector.SiteId,
+			FirmwareVersion: b.Selector.FirmwareVersion,
+			Vendor:          b.Selector.Vendor,
+			Model:           b.Selector.Model,
+			Tags:            b.Selector.Tags,
+		},
+		ResetType:            b.ResetType,
+		Key:                  b.Key,
+		Value:                b.Value,
+		FirmwareLocation:     b.FirmwareLocation,
+		FirmwareRetrieveDate: b.FirmwareRetrieveDate,
+		MaxInFlight:          b.MaxInFlight,
+		Status:               string(b.Status),
+		Results:              results,
+		TenantId:             b.TenantId,
+		CreatedBy:            b.CreatedBy,
+		CreatedAt:            b.CreatedAt,
+		Version:              b.Version,
+	}
+}
+
+func (s *Store) SetBulkCommand(ctx context.Context, b *store.BulkCommand) error {
+	bulkCommandRef := s.client.Doc(fmt.Sprintf("BulkCommand/%s", b.Id))
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var version int
+		snap, err := tx.Get(bulkCommandRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var data bulkCommand
+			if err = snap.DataTo(&data); err != nil {
+				return err
+			}
+			version = data.Version
+		}
+		if b.Version != version {
+			return store.ErrVersionConflict
+		}
+		data := newBulkCommand(b)
+		data.Version++
+		return tx.Set(bulkCommandRef, data)
+	})
+	if err != nil {
+		return fmt.Errorf("setting bulk command %s: %w", b.Id, err)
+	}
+	b.Version++
+	return nil
+}
+
+func (s *Store) LookupBulkCommand(ctx context.Context, id string) (*store.BulkCommand, error) {
+	bulkCommandRef := s.client.Doc(fmt.Sprintf("BulkCommand/%s", id))
+	snap, err := bulkCommandRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup bulk command %s: %w", id, err)
+	}
+	var data bulkCommand
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map bulk command %s: %w", id, err)
+	}
+	return mapBulkCommand(&data), nil
+}
+
+func (s *Store) ListBulkCommands(ctx context.Context, pageSize int, previousId string) ([]*store.BulkCommand, error) {
+	var bulkCommands []*store.BulkCommand
+	snaps, err := s.client.Collection("BulkCommand").OrderBy("id", firestore.Asc).
+		StartAfter(previousId).Limit(pageSize).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list bulk commands: %w", err)
+	}
+	for _, snap := range snaps {
+		var data bulkCommand
+		if err = snap.DataTo(&data); err != nil {
+			return nil, fmt.Errorf("map bulk command: %w", err)
+		}
+		bulkCommands = append(bulkCommands, mapBulkCommand(&data))
+	}
+	return bulkCommands, nil
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupBulkCommand(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	want := &store.BulkCommand{
+		Id:                   store.NewBulkCommandId(),
+		Action:               store.BulkCommandActionUpdateFirmware,
+		Selector:             store.BulkCommandSelector{SiteId: "site001", Vendor: "acme"},
+		FirmwareLocation:     "https://firmware.example.com/cs-2.1.0.bin",
+		FirmwareRetrieveDate: time.Now().UTC().Truncate(time.Millisecond),
+		MaxInFlight:          5,
+		Status:               store.BulkCommandStatusRunning,
+		Results: []*store.BulkCommandResult{
+			{
+				ChargeStationId: "cs001",
+				Status:          store.BulkCommandResultStatusAccepted,
+				Result:          "Accepted",
+				SentAt:          time.Now().UTC().Truncate(time.Millisecond),
+				CompletedAt:     time.Now().UTC().Truncate(time.Millisecond),
+			},
+		},
+		TenantId:  "tenant001",
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	err = engine.SetBulkCommand(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupBulkCommand(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	stale := *got
+	stale.Version = 0
+	err = engine.SetBulkCommand(ctx, &stale)
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+}
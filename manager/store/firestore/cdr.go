@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Store) SetChargeDetailRecord(ctx context.Context, cdr *store.ChargeDetailRecord) error {
+	cdrRef := s.client.Doc(fmt.Sprintf("ChargeDetailRecord/%s", cdr.Id))
+	_, err := cdrRef.Set(ctx, cdr)
+	if err != nil {
+		return fmt.Errorf("setting charge detail record %s: %w", cdr.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeDetailRecord(ctx context.Context, cdrId string) (*store.ChargeDetailRecord, error) {
+	cdrRef := s.client.Doc(fmt.Sprintf("ChargeDetailRecord/%s", cdrId))
+	snap, err := cdrRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge detail record %s: %w", cdrId, err)
+	}
+	var cdr store.ChargeDetailRecord
+	if err = snap.DataTo(&cdr); err != nil {
+		return nil, fmt.Errorf("lookup charge detail record %s: %w", cdrId, err)
+	}
+	return &cdr, nil
+}
+
+func (s *Store) ListChargeDetailRecords(ctx context.Context, offset int, limit int) ([]*store.ChargeDetailRecord, error) {
+	var cdrs []*store.ChargeDetailRecord
+	iter := s.client.Collection("ChargeDetailRecord").OrderBy("Id", firestore.Asc).Offset(offset).Limit(limit).Documents(ctx)
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("next charge detail record: %w", err)
+		}
+		var cdr store.ChargeDetailRecord
+		if err = snap.DataTo(&cdr); err != nil {
+			return nil, fmt.Errorf("map charge detail record: %w", err)
+		}
+		cdrs = append(cdrs, &cdr)
+	}
+	if cdrs == nil {
+		cdrs = make([]*store.ChargeDetailRecord, 0)
+	}
+	return cdrs, nil
+}
+
+// QueryChargeDetailRecords selects the charge stations in the query, reading them in
+// chunks of up to maxInValues in charge station order, and applies the remaining filters
+// to the records as they are read: the end date times are strings, which do not order
+// as times
+func (s *Store) QueryChargeDetailRecords(ctx context.Context, filter *store.ChargeDetailRecordFilter, pageSize int, previousCdrId string) ([]*store.ChargeDetailRecord, error) {
+	var chargeStationIds []string
+	if filter != nil {
+		chargeStationIds = filter.ChargeStationIds
+	}
+	previousChargeStationId := ""
+	if previousCdrId != "" && chargeStationIds != nil {
+		previous, err := s.LookupChargeDetailRecord(ctx, previousCdrId)
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil {
+			previousChargeStationId = previous.ChargeStationId
+		}
+	}
+
+	cdrs := make([]*store.ChargeDetailRecord, 0)
+	for _, chunk := range chargeStationChunks(chargeStationIds) {
+		after := previousCdrId
+		if previousCdrId != "" && chunk != nil {
+			if chunk[len(chunk)-1] < previousChargeStationId {
+				// the chunk was read by an earlier page
+				continue
+			}
+			if chunk[0] > previousChargeStationId {
+				after = ""
+			}
+		}
+		page, err := s.queryChargeDetailRecords(ctx, filter, chunk, pageSize-len(cdrs), after)
+		if err != nil {
+			return nil, err
+		}
+		cdrs = append(cdrs, page...)
+		if len(cdrs) >= pageSize {
+			break
+		}
+	}
+	return cdrs, nil
+}
+
+// queryChargeDetailRecords returns up to pageSize records of the charge stations, or of
+// any charge station if chargeStationIds is nil, ordered by id after previousCdrId
+func (s *Store) queryChargeDetailRecords(ctx context.Context, filter *store.ChargeDetailRecordFilter, chargeStationIds []string, pageSize int, previousCdrId string) ([]*store.ChargeDetailRecord, error) {
+	q := s.client.Collection("ChargeDetailRecord").Query
+	if chargeStationIds != nil {
+		q = q.Where("ChargeStationId", "in", chargeStationIds)
+	}
+	q = q.OrderBy("Id", firestore.Asc)
+	if previousCdrId != "" {
+		q = q.StartAfter(previousCdrId)
+	}
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	cdrs := make([]*store.ChargeDetailRecord, 0)
+	for len(cdrs) < pageSize {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("next charge detail record: %w", err)
+		}
+		var cdr store.ChargeDetailRecord
+		if err = snap.DataTo(&cdr); err != nil {
+			return nil, fmt.Errorf("map charge detail record: %w", err)
+		}
+		if filter.Matches(&cdr) {
+			cdrs = append(cdrs, &cdr)
+		}
+	}
+	return cdrs, nil
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestSetAndLookupChargeDetailRecord(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	want := &store.ChargeDetailRecord{
+		Id:              "cdr001",
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		LocationId:      "loc001",
+		Coordinates: store.GeoLocation{
+			Latitude:  "51.047599",
+			Longitude: "3.729944",
+		},
+		TotalEnergy: 12.5,
+		TotalCost:   6.875,
+		Currency:    "EUR",
+		LastUpdated: "2023-06-01T12:00:00Z",
+	}
+	err = engine.SetChargeDetailRecord(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeDetailRecord(ctx, "cdr001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = engine.LookupChargeDetailRecord(ctx, "cdr002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeDetailRecords(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	for i := 4; i >= 0; i-- {
+		err := engine.SetChargeDetailRecord(ctx, &store.ChargeDetailRecord{Id: fmt.Sprintf("cdr%03d", i)})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeDetailRecords(ctx, 1, 3)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, cdr := range got {
+		ids = append(ids, cdr.Id)
+	}
+	assert.Equal(t, []string{"cdr001", "cdr002", "cdr003"}, ids)
+}
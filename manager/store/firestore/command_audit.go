@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type commandAuditRecord struct {
+	Id              string    `firestore:"id"`
+	ChargeStationId string    `firestore:"cs"`
+	Command         string    `firestore:"c"`
+	OcppVersion     string    `firestore:"v"`
+	Request         string    `firestore:"req"`
+	RequestedBy     string    `firestore:"by"`
+	RequestedAt     time.Time `firestore:"at"`
+	Status          string    `firestore:"s"`
+	Result          string    `firestore:"res"`
+	CompletedAt     time.Time `firestore:"done"`
+}
+
+func (s *Store) SetCommandAuditRecord(ctx context.Context, record *store.CommandAuditRecord) error {
+	recordRef := s.client.Doc(fmt.Sprintf("CommandAuditRecord/%s", record.Id))
+	_, err := recordRef.Set(ctx, &commandAuditRecord{
+		Id:              record.Id,
+		ChargeStationId: record.ChargeStationId,
+		Command:         record.Command,
+		OcppVersion:     record.OcppVersion,
+		Request:         record.Request,
+		RequestedBy:     record.RequestedBy,
+		RequestedAt:     record.RequestedAt,
+		Status:          string(record.Status),
+		Result:          record.Result,
+		CompletedAt:     record.CompletedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting command audit record %s: %w", record.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupCommandAuditRecord(ctx context.Context, id string) (*store.CommandAuditRecord, error) {
+	recordRef := s.client.Doc(fmt.Sprintf("CommandAuditRecord/%s", id))
+	snap, err := recordRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup command audit record %s: %w", id, err)
+	}
+	var data commandAuditRecord
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map command audit record %s: %w", id, err)
+	}
+	return newCommandAuditRecord(&data), nil
+}
+
+func (s *Store) QueryCommandAuditRecords(ctx context.Context, filter *store.CommandAuditFilter, offset, limit int) ([]*store.CommandAuditRecord, error) {
+	// only the charge stations can be filtered by firestore while ordering by document
+	// id: the remaining filters are applied to the documents as they are read
+	var chargeStationIds []string
+	if filter != nil {
+		chargeStationIds = filter.ChargeStationIds
+	}
+	records := make([]*store.CommandAuditRecord, 0)
+	matched := 0
+	for _, chunk := range chargeStationChunks(chargeStationIds) {
+		q := s.client.Collection("CommandAuditRecord").Query
+		if filter != nil && filter.ChargeStationId != "" {
+			q = q.Where("cs", "==", filter.ChargeStationId)
+		} else if chunk != nil {
+			q = q.Where("cs", "in", chunk)
+		}
+		err := func() error {
+			iter := q.OrderBy(firestore.DocumentID, firestore.Asc).Documents(ctx)
+			defer iter.Stop()
+			for len(records) < limit {
+				snap, err := iter.Next()
+				if err == iterator.Done {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("next command audit record: %w", err)
+				}
+				var data commandAuditRecord
+				if err = snap.DataTo(&data); err != nil {
+					return fmt.Errorf("map command audit record %s: %w", snap.Ref.ID, err)
+				}
+				record := newCommandAuditRecord(&data)
+				if !filter.Matches(record) {
+					continue
+				}
+				if matched >= offset {
+					records = append(records, record)
+				}
+				matched++
+			}
+			return nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+		if len(records) >= limit {
+			break
+		}
+	}
+	return records, nil
+}
+
+func newCommandAuditRecord(data *commandAuditRecord) *store.CommandAuditRecord {
+	return &store.CommandAuditRecord{
+		Id:              data.Id,
+		ChargeStationId: data.ChargeStationId,
+		Command:         data.Command,
+		OcppVersion:     data.OcppVersion,
+		Request:         data.Request,
+		RequestedBy:     data.RequestedBy,
+		RequestedAt:     data.RequestedAt,
+		Status:          store.CommandAuditStatus(data.Status),
+		Result:          data.Result,
+		CompletedAt:     data.CompletedAt,
+	}
+}
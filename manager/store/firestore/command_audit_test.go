@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetAndQueryCommandAuditRecords(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	var want []*store.CommandAuditRecord
+	for _, chargeStationId := range []string{"cs001", "cs002", "cs001"} {
+		record := &store.CommandAuditRecord{
+			Id:              store.NewCommandAuditId(),
+			ChargeStationId: chargeStationId,
+			Command:         "ReserveNow",
+			OcppVersion:     "ocpp1.6",
+			Request:         `{"reservationId":1}`,
+			RequestedBy:     "csms",
+			RequestedAt:     now,
+			Status:          store.CommandAuditStatusCompleted,
+			Result:          `{"status":"Accepted"}`,
+			CompletedAt:     now,
+		}
+		require.NoError(t, engine.SetCommandAuditRecord(ctx, record))
+		want = append(want, record)
+	}
+
+	got, err := engine.LookupCommandAuditRecord(ctx, want[1].Id)
+	require.NoError(t, err)
+	assert.Equal(t, want[1], got)
+
+	records, err := engine.QueryCommandAuditRecords(ctx, &store.CommandAuditFilter{ChargeStationId: "cs001"}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.CommandAuditRecord{want[2]}, records)
+}
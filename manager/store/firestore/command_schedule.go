@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type commandSchedule struct {
+	Id                string       `firestore:"id"`
+	Name              string       `firestore:"name"`
+	Command           *bulkCommand `firestore:"cmd"`
+	RunAt             time.Time    `firestore:"at"`
+	Cron              string       `firestore:"cron"`
+	TimeZone          string       `firestore:"tz"`
+	NextRunAt         time.Time    `firestore:"next"`
+	LastRunAt         time.Time    `firestore:"last"`
+	LastBulkCommandId string       `firestore:"lastId"`
+	LastError         string       `firestore:"lastErr"`
+	TenantId          string       `firestore:"tn"`
+	CreatedBy         string       `firestore:"by"`
+	CreatedAt         time.Time    `firestore:"created"`
+	Version           int          `firestore:"ver"`
+}
+
+func mapCommandSchedule(data *commandSchedule) *store.CommandSchedule {
+	schedule := &store.CommandSchedule{
+		Id:                data.Id,
+		Name:              data.Name,
+		RunAt:             data.RunAt,
+		Cron:              data.Cron,
+		TimeZone:          data.TimeZone,
+		NextRunAt:         data.NextRunAt,
+		LastRunAt:         data.LastRunAt,
+		LastBulkCommandId: data.LastBulkCommandId,
+		LastError:         data.LastError,
+		TenantId:          data.TenantId,
+		CreatedBy:         data.CreatedBy,
+		CreatedAt:         data.CreatedAt,
+		Version:           data.Version,
+	}
+	if data.Command != nil {
+		schedule.Command = *mapBulkCommand(data.Command)
+	}
+	return schedule
+}
+
+func newCommandSchedule(s *store.CommandSchedule) *commandSchedule {
+	return &commandSchedule{
+		Id:                s.Id,
+		Name:              s.Name,
+		Command:           newBulkCommand(&s.Command),
+		RunAt:             s.RunAt,
+		Cron:              s.Cron,
+		TimeZone:          s.TimeZone,
+		NextRunAt:         s.NextRunAt,
+		LastRunAt:         s.LastRunAt,
+		LastBulkCommandId: s.LastBulkCommandId,
+		LastError:         s.LastError,
+		TenantId:          s.TenantId,
+		CreatedBy:         s.CreatedBy,
+		CreatedAt:         s.CreatedAt,
+		Version:           s.Version,
+	}
+}
+
+func (s *Store) SetCommandSchedule(ctx context.Context, schedule *store.CommandSchedule) error {
+	scheduleRef := s.client.Doc(fmt.Sprintf("CommandSchedule/%s", schedule.Id))
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var version int
+		snap, err := tx.Get(scheduleRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var data commandSchedule
+			if err = snap.DataTo(&data); err != nil {
+				return err
+			}
+			version = data.Version
+		}
+		if schedule.Version != version {
+			return store.ErrVersionConflict
+		}
+		data := newCommandSchedule(schedule)
+		data.Version++
+		return tx.Set(scheduleRef, data)
+	})
+	if err != nil {
+		return fmt.Errorf("setting command schedule %s: %w", schedule.Id, err)
+	}
+	schedule.Version++
+	return nil
+}
+
+func (s *Store) LookupCommandSchedule(ctx context.Context, id string) (*store.CommandSchedule, error) {
+	scheduleRef := s.client.Doc(fmt.Sprintf("CommandSchedule/%s", id))
+	snap, err := scheduleRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup command schedule %s: %w", id, err)
+	}
+	var data commandSchedule
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map command schedule %s: %w", id, err)
+	}
+	return mapCommandSchedule(&data), nil
+}
+
+func (s *Store) ListCommandSchedules(ctx context.Context, pageSize int, previousId string) ([]*store.CommandSchedule, error) {
+	var schedules []*store.CommandSchedule
+	snaps, err := s.client.Collection("CommandSchedule").OrderBy("id", firestore.Asc).
+		StartAfter(previousId).Limit(pageSize).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list command schedules: %w", err)
+	}
+	for _, snap := range snaps {
+		var data commandSchedule
+		if err = snap.DataTo(&data); err != nil {
+			return nil, fmt.Errorf("map command schedule: %w", err)
+		}
+		schedules = append(schedules, mapCommandSchedule(&data))
+	}
+	return schedules, nil
+}
+
+func (s *Store) DeleteCommandSchedule(ctx context.Context, id string) error {
+	scheduleRef := s.client.Doc(fmt.Sprintf("CommandSchedule/%s", id))
+	_, err := scheduleRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting command schedule %s: %w", id, err)
+	}
+	return nil
+}
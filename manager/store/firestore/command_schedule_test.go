@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetLookupAndDeleteCommandSchedule(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	want := &store.CommandSchedule{
+		Id:   store.NewCommandScheduleId(),
+		Name: "nightly reset",
+		Command: store.BulkCommand{
+			Action:      store.BulkCommandActionReset,
+			Selector:    store.BulkCommandSelector{SiteId: "site001", Tags: []string{"dc"}},
+			ResetType:   "OnIdle",
+			MaxInFlight: 10,
+		},
+		Cron:      "0 2 * * *",
+		TimeZone:  "Europe/London",
+		NextRunAt: time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond),
+		TenantId:  "a",
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	require.NoError(t, engine.SetCommandSchedule(ctx, want))
+	assert.Equal(t, 1, want.Version)
+
+	got, err := engine.LookupCommandSchedule(ctx, want.Id)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.Command.Action, got.Command.Action)
+	assert.Equal(t, want.Command.Selector, got.Command.Selector)
+	assert.Equal(t, want.Command.ResetType, got.Command.ResetType)
+	assert.Equal(t, want.Cron, got.Cron)
+	assert.Equal(t, want.TimeZone, got.TimeZone)
+	assert.True(t, want.NextRunAt.Equal(got.NextRunAt))
+	assert.Equal(t, want.TenantId, got.TenantId)
+	assert.Equal(t, 1, got.Version)
+
+	stale := *want
+	stale.Version = 0
+	assert.ErrorIs(t, engine.SetCommandSchedule(ctx, &stale), store.ErrVersionConflict)
+
+	schedules, err := engine.ListCommandSchedules(ctx, 10, "")
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, want.Id, schedules[0].Id)
+
+	require.NoError(t, engine.DeleteCommandSchedule(ctx, want.Id))
+	got, err = engine.LookupCommandSchedule(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
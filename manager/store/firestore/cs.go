@@ -51,6 +51,173 @@ func (s *Store) LookupChargeStationAuth(ctx context.Context, chargeStationId str
 	}, nil
 }
 
+type chargeStationDetails struct {
+	SecurityProfile int                `firestore:"prof"`
+	OcppVersion     string             `firestore:"v"`
+	Vendor          string             `firestore:"vnd"`
+	Model           string             `firestore:"mdl"`
+	SerialNumber    string             `firestore:"ser"`
+	FirmwareVersion string             `firestore:"fw"`
+	SiteId          string             `firestore:"site"`
+	Tags            []string           `firestore:"tags"`
+	Coordinates     *store.GeoLocation `firestore:"geo"`
+	// Geohash indexes the charge station by its coordinates: it is empty if the
+	// charge station has no coordinates
+	Geohash  string    `firestore:"gh"`
+	LastBoot time.Time `firestore:"boot"`
+	TenantId string    `firestore:"tenant"`
+	TariffId string    `firestore:"tariff"`
+	Version  int       `firestore:"ver"`
+}
+
+func mapChargeStationDetails(chargeStationId string, data *chargeStationDetails) *store.ChargeStation {
+	return &store.ChargeStation{
+		ChargeStationId: chargeStationId,
+		SecurityProfile: store.SecurityProfile(data.SecurityProfile),
+		OcppVersion:     data.OcppVersion,
+		Vendor:          data.Vendor,
+		Model:           data.Model,
+		SerialNumber:    data.SerialNumber,
+		FirmwareVersion: data.FirmwareVersion,
+		SiteId:          data.SiteId,
+		Tags:            data.Tags,
+		Coordinates:     data.Coordinates,
+		LastBoot:        data.LastBoot,
+		TenantId:        data.TenantId,
+		TariffId:        data.TariffId,
+		Version:         data.Version,
+	}
+}
+
+// SetChargeStation stores the registry entry in the ChargeStationDetails collection:
+// the ChargeStation collection holds the charge station's auth details
+func (s *Store) SetChargeStation(ctx context.Context, chargeStationId string, chargeStation *store.ChargeStation) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDetails/%s", chargeStationId))
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var version int
+		snap, err := tx.Get(csRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var csData chargeStationDetails
+			if err = snap.DataTo(&csData); err != nil {
+				return err
+			}
+			version = csData.Version
+		}
+		if chargeStation.Version != version {
+			return store.ErrVersionConflict
+		}
+		return tx.Set(csRef, &chargeStationDetails{
+			SecurityProfile: int(chargeStation.SecurityProfile),
+			OcppVersion:     chargeStation.OcppVersion,
+			Vendor:          chargeStation.Vendor,
+			Model:           chargeStation.Model,
+			SerialNumber:    chargeStation.SerialNumber,
+			FirmwareVersion: chargeStation.FirmwareVersion,
+			SiteId:          chargeStation.SiteId,
+			Tags:            chargeStation.Tags,
+			Coordinates:     chargeStation.Coordinates,
+			Geohash:         store.ChargeStationGeohash(chargeStation),
+			LastBoot:        chargeStation.LastBoot,
+			TenantId:        chargeStation.TenantId,
+			TariffId:        chargeStation.TariffId,
+			Version:         version + 1,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station %s: %w", chargeStationId, err)
+	}
+	chargeStation.Version++
+	return nil
+}
+
+func (s *Store) LookupChargeStation(ctx context.Context, chargeStationId string) (*store.ChargeStation, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDetails/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station %s: %w", chargeStationId, err)
+	}
+	var csData chargeStationDetails
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map charge station %s: %w", chargeStationId, err)
+	}
+	return mapChargeStationDetails(chargeStationId, &csData), nil
+}
+
+func (s *Store) ListChargeStations(ctx context.Context, offset int, limit int) ([]*store.ChargeStation, error) {
+	chargeStations, err := listChargeStations(ctx, s.client.Collection("ChargeStationDetails").Query, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list charge stations: %w", err)
+	}
+	return chargeStations, nil
+}
+
+// ListTenantChargeStations selects the tenant's charge stations with an equality filter
+func (s *Store) ListTenantChargeStations(ctx context.Context, tenantId string, offset, limit int) ([]*store.ChargeStation, error) {
+	chargeStations, err := listChargeStations(ctx,
+		s.client.Collection("ChargeStationDetails").Where("tenant", "==", tenantId), offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list charge stations for tenant %s: %w", tenantId, err)
+	}
+	return chargeStations, nil
+}
+
+func listChargeStations(ctx context.Context, q firestore.Query, offset, limit int) ([]*store.ChargeStation, error) {
+	snaps, err := q.OrderBy(firestore.DocumentID, firestore.Asc).Offset(offset).Limit(limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	chargeStations := make([]*store.ChargeStation, 0, len(snaps))
+	for _, snap := range snaps {
+		var csData chargeStationDetails
+		if err = snap.DataTo(&csData); err != nil {
+			return nil, fmt.Errorf("map charge station %s: %w", snap.Ref.ID, err)
+		}
+		chargeStations = append(chargeStations, mapChargeStationDetails(snap.Ref.ID, &csData))
+	}
+	return chargeStations, nil
+}
+
+// ListChargeStationsNearby queries the charge stations whose geohash is in one of the
+// cells that cover the circle and then selects those within the circle itself
+func (s *Store) ListChargeStationsNearby(ctx context.Context, latitude, longitude, radius float64) ([]*store.ChargeStation, error) {
+	cells := store.GeohashCells(latitude, longitude, radius)
+	if cells == nil {
+		// every charge station with coordinates
+		cells = []string{""}
+	}
+	var chargeStations []*store.ChargeStation
+	for _, cell := range cells {
+		snaps, err := s.client.Collection("ChargeStationDetails").
+			Where("gh", ">=", cell).Where("gh", "<", cell+"~").Documents(ctx).GetAll()
+		if err != nil {
+			return nil, fmt.Errorf("list charge stations nearby: %w", err)
+		}
+		for _, snap := range snaps {
+			var csData chargeStationDetails
+			if err = snap.DataTo(&csData); err != nil {
+				return nil, fmt.Errorf("map charge station %s: %w", snap.Ref.ID, err)
+			}
+			chargeStations = append(chargeStations, mapChargeStationDetails(snap.Ref.ID, &csData))
+		}
+	}
+	return store.ChargeStationsNearby(chargeStations, latitude, longitude, radius), nil
+}
+
+func (s *Store) DeleteChargeStation(ctx context.Context, chargeStationId string) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDetails/%s", chargeStationId))
+	_, err := csRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting charge station %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
 type chargeStationSetting struct {
 	Value     string    `firestore:"v"`
 	Status    string    `firestore:"s"`
@@ -229,14 +396,186 @@ func (s *Store) ListChargeStationInstallCertificates(ctx context.Context, pageSi
 	return installCerts, nil
 }
 
+type chargeStationInstalledCertificate struct {
+	Type           string    `firestore:"t"`
+	HashAlgorithm  string    `firestore:"a"`
+	IssuerNameHash string    `firestore:"n"`
+	IssuerKeyHash  string    `firestore:"k"`
+	SerialNumber   string    `firestore:"sn"`
+	Status         string    `firestore:"s"`
+	SendAfter      time.Time `firestore:"u"`
+}
+
+type chargeStationInstalledCertificates struct {
+	Certificates  []*chargeStationInstalledCertificate `firestore:"c"`
+	RefreshStatus string                               `firestore:"r"`
+	SendAfter     time.Time                            `firestore:"u"`
+}
+
+func mapChargeStationInstalledCertificates(chargeStationId string, csData *chargeStationInstalledCertificates) *store.ChargeStationInstalledCertificates {
+	var certs []*store.ChargeStationInstalledCertificate
+	for _, c := range csData.Certificates {
+		certs = append(certs, &store.ChargeStationInstalledCertificate{
+			CertificateType: store.CertificateType(c.Type),
+			HashAlgorithm:   c.HashAlgorithm,
+			IssuerNameHash:  c.IssuerNameHash,
+			IssuerKeyHash:   c.IssuerKeyHash,
+			SerialNumber:    c.SerialNumber,
+			Status:          store.InstalledCertificateStatus(c.Status),
+			SendAfter:       c.SendAfter,
+		})
+	}
+	return &store.ChargeStationInstalledCertificates{
+		ChargeStationId: chargeStationId,
+		Certificates:    certs,
+		RefreshStatus:   store.InstalledCertificatesRefreshStatus(csData.RefreshStatus),
+		SendAfter:       csData.SendAfter,
+	}
+}
+
+func (s *Store) SetChargeStationInstalledCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationInstalledCertificates) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationInstalledCertificates/%s", chargeStationId))
+	var certs []*chargeStationInstalledCertificate
+	for _, c := range certificates.Certificates {
+		certs = append(certs, &chargeStationInstalledCertificate{
+			Type:           string(c.CertificateType),
+			HashAlgorithm:  c.HashAlgorithm,
+			IssuerNameHash: c.IssuerNameHash,
+			IssuerKeyHash:  c.IssuerKeyHash,
+			SerialNumber:   c.SerialNumber,
+			Status:         string(c.Status),
+			SendAfter:      c.SendAfter,
+		})
+	}
+	_, err := csRef.Set(ctx, &chargeStationInstalledCertificates{
+		Certificates:  certs,
+		RefreshStatus: string(certificates.RefreshStatus),
+		SendAfter:     certificates.SendAfter,
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationInstalledCertificates(ctx context.Context, chargeStationId string) (*store.ChargeStationInstalledCertificates, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationInstalledCertificates/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station installed certificates %s: %w", chargeStationId, err)
+	}
+	var csData chargeStationInstalledCertificates
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map charge station installed certificates %s: %w", chargeStationId, err)
+	}
+	return mapChargeStationInstalledCertificates(chargeStationId, &csData), nil
+}
+
+func (s *Store) ListChargeStationInstalledCertificates(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationInstalledCertificates, error) {
+	var installedCerts []*store.ChargeStationInstalledCertificates
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationInstalledCertificates").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationInstalledCertificates").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station installed certificates: %w", err)
+	}
+	for _, snap := range snaps {
+		var csData chargeStationInstalledCertificates
+		if err = snap.DataTo(&csData); err != nil {
+			return nil, fmt.Errorf("map charge station installed certificates: %w", err)
+		}
+		installedCerts = append(installedCerts, mapChargeStationInstalledCertificates(snap.Ref.ID, &csData))
+	}
+	return installedCerts, nil
+}
+
+type chargeStationProvisioning struct {
+	Status    string    `firestore:"s"`
+	Step      int       `firestore:"n"`
+	SendAfter time.Time `firestore:"u"`
+}
+
+func mapChargeStationProvisioning(chargeStationId string, data *chargeStationProvisioning) *store.ChargeStationProvisioning {
+	return &store.ChargeStationProvisioning{
+		ChargeStationId: chargeStationId,
+		Status:          store.ProvisioningStatus(data.Status),
+		Step:            data.Step,
+		SendAfter:       data.SendAfter,
+	}
+}
+
+func (s *Store) SetChargeStationProvisioning(ctx context.Context, chargeStationId string, provisioning *store.ChargeStationProvisioning) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationProvisioning/%s", chargeStationId))
+	_, err := csRef.Set(ctx, &chargeStationProvisioning{
+		Status:    string(provisioning.Status),
+		Step:      provisioning.Step,
+		SendAfter: provisioning.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station provisioning %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationProvisioning(ctx context.Context, chargeStationId string) (*store.ChargeStationProvisioning, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationProvisioning/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station provisioning %s: %w", chargeStationId, err)
+	}
+	var csData chargeStationProvisioning
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map charge station provisioning %s: %w", chargeStationId, err)
+	}
+	return mapChargeStationProvisioning(chargeStationId, &csData), nil
+}
+
+func (s *Store) ListChargeStationProvisioning(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationProvisioning, error) {
+	var provisioning []*store.ChargeStationProvisioning
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationProvisioning").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationProvisioning").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station provisioning: %w", err)
+	}
+	for _, snap := range snaps {
+		var csData chargeStationProvisioning
+		if err = snap.DataTo(&csData); err != nil {
+			return nil, fmt.Errorf("map charge station provisioning: %w", err)
+		}
+		provisioning = append(provisioning, mapChargeStationProvisioning(snap.Ref.ID, &csData))
+	}
+	return provisioning, nil
+}
+
 type chargeStationRuntimeDetails struct {
-	OcppVersion string `firestore:"v"`
+	OcppVersion     string `firestore:"v"`
+	Iso15118Version string `firestore:"i"`
 }
 
 func (s *Store) SetChargeStationRuntimeDetails(ctx context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
 	csRef := s.client.Doc(fmt.Sprintf("ChargeStationRuntimeDetails/%s", chargeStationId))
 	_, err := csRef.Set(ctx, &chargeStationRuntimeDetails{
-		OcppVersion: details.OcppVersion,
+		OcppVersion:     details.OcppVersion,
+		Iso15118Version: details.Iso15118Version,
 	})
 	if err != nil {
 		return err
@@ -258,10 +597,39 @@ func (s *Store) LookupChargeStationRuntimeDetails(ctx context.Context, chargeSta
 		return nil, fmt.Errorf("map charge station runtime details %s: %w", chargeStationId, err)
 	}
 	return &store.ChargeStationRuntimeDetails{
-		OcppVersion: csData.OcppVersion,
+		OcppVersion:     csData.OcppVersion,
+		Iso15118Version: csData.Iso15118Version,
 	}, nil
 }
 
+func (s *Store) ListChargeStationRuntimeDetails(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationRuntimeDetails, error) {
+	var runtimeDetails []*store.ChargeStationRuntimeDetails
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationRuntimeDetails").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationRuntimeDetails").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station runtime details: %w", err)
+	}
+	for _, snap := range snaps {
+		var csData chargeStationRuntimeDetails
+		if err = snap.DataTo(&csData); err != nil {
+			return nil, fmt.Errorf("map charge station runtime details: %w", err)
+		}
+		runtimeDetails = append(runtimeDetails, &store.ChargeStationRuntimeDetails{
+			ChargeStationId: snap.Ref.ID,
+			OcppVersion:     csData.OcppVersion,
+			Iso15118Version: csData.Iso15118Version,
+		})
+	}
+	return runtimeDetails, nil
+}
+
 type chargeStationTriggerMessage struct {
 	Type      string    `firestore:"t"`
 	Status    string    `firestore:"s"`
@@ -339,3 +707,291 @@ func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize i
 	}
 	return triggerMessages, nil
 }
+
+type chargeStationRegistration struct {
+	Status string `firestore:"s"`
+}
+
+func (s *Store) SetChargeStationRegistration(ctx context.Context, chargeStationId string, registration *store.ChargeStationRegistration) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationRegistration/%s", chargeStationId))
+	_, err := csRef.Set(ctx, &chargeStationRegistration{
+		Status: string(registration.Status),
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station registration %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationRegistration(ctx context.Context, chargeStationId string) (*store.ChargeStationRegistration, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationRegistration/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station registration %s: %w", chargeStationId, err)
+	}
+	var csData chargeStationRegistration
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map charge station registration %s: %w", chargeStationId, err)
+	}
+	return &store.ChargeStationRegistration{
+		ChargeStationId: chargeStationId,
+		Status:          store.RegistrationStatus(csData.Status),
+	}, nil
+}
+
+type chargeStationLiveness struct {
+	LastSeen      time.Time `firestore:"l"`
+	LastHeartbeat time.Time `firestore:"h"`
+	Offline       bool      `firestore:"o"`
+}
+
+func mapChargeStationLiveness(chargeStationId string, data *chargeStationLiveness) *store.ChargeStationLiveness {
+	return &store.ChargeStationLiveness{
+		ChargeStationId: chargeStationId,
+		LastSeen:        data.LastSeen,
+		LastHeartbeat:   data.LastHeartbeat,
+		Offline:         data.Offline,
+	}
+}
+
+func (s *Store) SetChargeStationLiveness(ctx context.Context, chargeStationId string, liveness *store.ChargeStationLiveness) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationLiveness/%s", chargeStationId))
+	_, err := csRef.Set(ctx, &chargeStationLiveness{
+		LastSeen:      liveness.LastSeen,
+		LastHeartbeat: liveness.LastHeartbeat,
+		Offline:       liveness.Offline,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station liveness %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationLiveness(ctx context.Context, chargeStationId string) (*store.ChargeStationLiveness, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationLiveness/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station liveness %s: %w", chargeStationId, err)
+	}
+	var csData chargeStationLiveness
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map charge station liveness %s: %w", chargeStationId, err)
+	}
+	return mapChargeStationLiveness(chargeStationId, &csData), nil
+}
+
+func (s *Store) ListChargeStationLiveness(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationLiveness, error) {
+	var liveness []*store.ChargeStationLiveness
+	var docIt *firestore.DocumentIterator
+	if previousCsId == "" {
+		docIt = s.client.Collection("ChargeStationLiveness").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("ChargeStationLiveness").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousCsId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list charge station liveness: %w", err)
+	}
+	for _, snap := range snaps {
+		var csData chargeStationLiveness
+		if err = snap.DataTo(&csData); err != nil {
+			return nil, fmt.Errorf("map charge station liveness: %w", err)
+		}
+		liveness = append(liveness, mapChargeStationLiveness(snap.Ref.ID, &csData))
+	}
+	return liveness, nil
+}
+
+type connectorStatus struct {
+	EvseId      int       `firestore:"e"`
+	ConnectorId int       `firestore:"c"`
+	Status      string    `firestore:"s"`
+	LastUpdated time.Time `firestore:"u"`
+}
+
+type connectorStatuses struct {
+	Connectors []connectorStatus `firestore:"c"`
+	// Faulted counts the connectors whose status is Faulted, so that they can be summed
+	// by an aggregation query
+	Faulted int `firestore:"f"`
+}
+
+func (s *Store) SetConnectorStatus(ctx context.Context, status *store.ConnectorStatus) error {
+	statuses, err := s.ListConnectorStatuses(ctx, status.ChargeStationId)
+	if err != nil {
+		return err
+	}
+
+	var data connectorStatuses
+	for _, existing := range statuses {
+		if existing.EvseId == status.EvseId && existing.ConnectorId == status.ConnectorId {
+			continue
+		}
+		data.Connectors = append(data.Connectors, connectorStatus{
+			EvseId:      existing.EvseId,
+			ConnectorId: existing.ConnectorId,
+			Status:      existing.Status,
+			LastUpdated: existing.LastUpdated,
+		})
+	}
+	data.Connectors = append(data.Connectors, connectorStatus{
+		EvseId:      status.EvseId,
+		ConnectorId: status.ConnectorId,
+		Status:      status.Status,
+		LastUpdated: status.LastUpdated,
+	})
+
+	for _, connector := range data.Connectors {
+		if connector.Status == store.ConnectorStatusFaulted {
+			data.Faulted++
+		}
+	}
+
+	csRef := s.client.Doc(fmt.Sprintf("ConnectorStatus/%s", status.ChargeStationId))
+	_, err = csRef.Set(ctx, &data)
+	if err != nil {
+		return fmt.Errorf("setting connector status %s: %w", status.ChargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) ListConnectorStatuses(ctx context.Context, chargeStationId string) ([]*store.ConnectorStatus, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ConnectorStatus/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup connector status %s: %w", chargeStationId, err)
+	}
+	var data connectorStatuses
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map connector status %s: %w", chargeStationId, err)
+	}
+	var statuses []*store.ConnectorStatus
+	for _, connector := range data.Connectors {
+		statuses = append(statuses, &store.ConnectorStatus{
+			ChargeStationId: chargeStationId,
+			EvseId:          connector.EvseId,
+			ConnectorId:     connector.ConnectorId,
+			Status:          connector.Status,
+			LastUpdated:     connector.LastUpdated,
+		})
+	}
+	store.SortConnectorStatuses(statuses)
+	return statuses, nil
+}
+
+type evseConnector struct {
+	ConnectorId   int     `firestore:"c"`
+	ConnectorType string  `firestore:"t"`
+	MaxPower      float64 `firestore:"p"`
+	PhysicalLabel string  `firestore:"l"`
+}
+
+type evse struct {
+	EvseId        int             `firestore:"e"`
+	Connectors    []evseConnector `firestore:"c"`
+	DepartureTime time.Time       `firestore:"d"`
+	LastUpdated   time.Time       `firestore:"u"`
+}
+
+type evses struct {
+	Evses []evse `firestore:"e"`
+}
+
+// SetEvse stores the EVSE in a document holding all the charge station's EVSEs
+func (s *Store) SetEvse(ctx context.Context, cse *store.ChargeStationEvse) error {
+	existing, err := s.ListEvses(ctx, cse.ChargeStationId)
+	if err != nil {
+		return err
+	}
+
+	var data evses
+	for _, e := range existing {
+		if e.EvseId != cse.EvseId {
+			data.Evses = append(data.Evses, toFirestoreEvse(e))
+		}
+	}
+	data.Evses = append(data.Evses, toFirestoreEvse(cse))
+
+	csRef := s.client.Doc(fmt.Sprintf("Evse/%s", cse.ChargeStationId))
+	_, err = csRef.Set(ctx, &data)
+	if err != nil {
+		return fmt.Errorf("setting evse %s/%d: %w", cse.ChargeStationId, cse.EvseId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupEvse(ctx context.Context, chargeStationId string, evseId int) (*store.ChargeStationEvse, error) {
+	all, err := s.ListEvses(ctx, chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range all {
+		if e.EvseId == evseId {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Store) ListEvses(ctx context.Context, chargeStationId string) ([]*store.ChargeStationEvse, error) {
+	csRef := s.client.Doc(fmt.Sprintf("Evse/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list evses %s: %w", chargeStationId, err)
+	}
+	var data evses
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map evses %s: %w", chargeStationId, err)
+	}
+	var result []*store.ChargeStationEvse
+	for _, e := range data.Evses {
+		cse := &store.ChargeStationEvse{
+			ChargeStationId: chargeStationId,
+			EvseId:          e.EvseId,
+			DepartureTime:   e.DepartureTime,
+			LastUpdated:     e.LastUpdated,
+		}
+		for _, connector := range e.Connectors {
+			cse.Connectors = append(cse.Connectors, store.ChargeStationConnector{
+				ConnectorId:   connector.ConnectorId,
+				ConnectorType: connector.ConnectorType,
+				MaxPower:      connector.MaxPower,
+				PhysicalLabel: connector.PhysicalLabel,
+			})
+		}
+		result = append(result, cse)
+	}
+	store.SortEvses(result)
+	return result, nil
+}
+
+func toFirestoreEvse(cse *store.ChargeStationEvse) evse {
+	e := evse{
+		EvseId:        cse.EvseId,
+		DepartureTime: cse.DepartureTime,
+		LastUpdated:   cse.LastUpdated,
+	}
+	for _, connector := range cse.Connectors {
+		e.Connectors = append(e.Connectors, evseConnector{
+			ConnectorId:   connector.ConnectorId,
+			ConnectorType: connector.ConnectorType,
+			MaxPower:      connector.MaxPower,
+			PhysicalLabel: connector.PhysicalLabel,
+		})
+	}
+	return e
+}
@@ -289,6 +289,100 @@ func TestListChargeStationInstallCertificates(t *testing.T) {
 	assert.Len(t, csIds, 25)
 }
 
+func TestSetAndLookupChargeStationInstalledCertificates(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	now := time.Now()
+	installedCertsStore, err := firestore.NewStore(ctx, "myproject", clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+
+	want := &store.ChargeStationInstalledCertificates{
+		ChargeStationId: "cs001",
+		Certificates: []*store.ChargeStationInstalledCertificate{
+			{
+				CertificateType: store.CertificateTypeV2G,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "name-hash",
+				IssuerKeyHash:   "key-hash",
+				SerialNumber:    "0123",
+				Status:          store.InstalledCertificateStatusDeletePending,
+				SendAfter:       now.UTC(),
+			},
+		},
+		RefreshStatus: store.InstalledCertificatesRefreshAccepted,
+		SendAfter:     now.UTC(),
+	}
+
+	err = installedCertsStore.SetChargeStationInstalledCertificates(ctx, "cs001", want)
+	require.NoError(t, err)
+
+	got, err := installedCertsStore.LookupChargeStationInstalledCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	page, err := installedCertsStore.ListChargeStationInstalledCertificates(ctx, 10, "")
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, want, page[0])
+}
+
+func TestLookupChargeStationInstalledCertificatesWithUnregisteredChargeStation(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	installedCertsStore, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	got, err := installedCertsStore.LookupChargeStationInstalledCertificates(ctx, "not-created")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetAndLookupChargeStationProvisioning(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	now := time.Now()
+	provisioningStore, err := firestore.NewStore(ctx, "myproject", clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+
+	want := &store.ChargeStationProvisioning{
+		ChargeStationId: "cs001",
+		Status:          store.ProvisioningStatusPending,
+		Step:            2,
+		SendAfter:       now.UTC(),
+	}
+
+	err = provisioningStore.SetChargeStationProvisioning(ctx, "cs001", want)
+	require.NoError(t, err)
+
+	got, err := provisioningStore.LookupChargeStationProvisioning(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	page, err := provisioningStore.ListChargeStationProvisioning(ctx, 10, "")
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, want, page[0])
+}
+
+func TestLookupChargeStationProvisioningWithUnregisteredChargeStation(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	provisioningStore, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	got, err := provisioningStore.LookupChargeStationProvisioning(ctx, "not-created")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
 func TestSetAndLookupChargeStationRuntimeDetails(t *testing.T) {
 	defer cleanupAllCollections(t, "myproject")
 
@@ -323,6 +417,32 @@ func TestLookupChargeStationRuntimeDetailsWithUnregisteredChargeStation(t *testi
 	assert.Nil(t, got)
 }
 
+func TestListChargeStationRuntimeDetails(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	detailsStore, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	for i := 0; i < 15; i++ {
+		csId := fmt.Sprintf("cs%03d", i)
+		err := detailsStore.SetChargeStationRuntimeDetails(ctx, csId, &store.ChargeStationRuntimeDetails{
+			OcppVersion: "1.6",
+		})
+		require.NoError(t, err)
+	}
+
+	page1, err := detailsStore.ListChargeStationRuntimeDetails(ctx, 10, "")
+	require.NoError(t, err)
+	require.Len(t, page1, 10)
+	assert.Equal(t, &store.ChargeStationRuntimeDetails{ChargeStationId: "cs000", OcppVersion: "1.6"}, page1[0])
+
+	page2, err := detailsStore.ListChargeStationRuntimeDetails(ctx, 10, page1[len(page1)-1].ChargeStationId)
+	require.NoError(t, err)
+	require.Len(t, page2, 5)
+}
+
 func TestListChargeStationTriggerMessages(t *testing.T) {
 	defer cleanupAllCollections(t, "myproject")
 
@@ -342,3 +462,204 @@ func TestListChargeStationTriggerMessages(t *testing.T) {
 
 	t.Logf("%+v", got)
 }
+
+func TestListChargeStationsNearby(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	chargeStationStore, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	for id, coordinates := range map[string]*store.GeoLocation{
+		"cs001": {Latitude: "51.5080", Longitude: "-0.1281"},
+		"cs002": {Latitude: "51.5074", Longitude: "-0.1278"},
+		"cs003": {Latitude: "48.8566", Longitude: "2.3522"},
+		"cs004": nil,
+	} {
+		err = chargeStationStore.SetChargeStation(ctx, id, &store.ChargeStation{Coordinates: coordinates})
+		require.NoError(t, err)
+	}
+
+	got, err := chargeStationStore.ListChargeStationsNearby(ctx, 51.5074, -0.1278, 1000)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs002", got[0].ChargeStationId)
+	assert.Equal(t, "cs001", got[1].ChargeStationId)
+
+	got, err = chargeStationStore.ListChargeStationsNearby(ctx, 51.5074, -0.1278, 1_000_000)
+	require.NoError(t, err)
+	assert.Len(t, got, 3)
+}
+
+func TestSetLookupAndDeleteChargeStation(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	now := time.Now()
+	chargeStationStore, err := firestore.NewStore(ctx, "myproject", clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+
+	want := &store.ChargeStation{
+		ChargeStationId: "cs001",
+		SecurityProfile: store.TLSWithBasicAuth,
+		OcppVersion:     "1.6",
+		Vendor:          "Acme",
+		Model:           "Charger 1",
+		SerialNumber:    "123456",
+		FirmwareVersion: "1.0.0",
+		SiteId:          "site001",
+		Coordinates: &store.GeoLocation{
+			Latitude:  "51.5072",
+			Longitude: "-0.1276",
+		},
+		LastBoot: now.UTC(),
+	}
+
+	err = chargeStationStore.SetChargeStation(ctx, "cs001", want)
+	require.NoError(t, err)
+
+	got, err := chargeStationStore.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	page, err := chargeStationStore.ListChargeStations(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, want, page[0])
+
+	err = chargeStationStore.DeleteChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+
+	got, err = chargeStationStore.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetAndLookupChargeStationRegistration(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	registrationStore, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	want := &store.ChargeStationRegistration{
+		ChargeStationId: "cs001",
+		Status:          store.RegistrationStatusRejected,
+	}
+
+	err = registrationStore.SetChargeStationRegistration(ctx, "cs001", want)
+	require.NoError(t, err)
+
+	got, err := registrationStore.LookupChargeStationRegistration(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = registrationStore.LookupChargeStationRegistration(ctx, "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetAndLookupChargeStationLiveness(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	now := time.Now()
+	livenessStore, err := firestore.NewStore(ctx, "myproject", clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+
+	want := &store.ChargeStationLiveness{
+		ChargeStationId: "cs001",
+		LastSeen:        now.UTC(),
+		LastHeartbeat:   now.Add(-time.Minute).UTC(),
+		Offline:         true,
+	}
+
+	err = livenessStore.SetChargeStationLiveness(ctx, "cs001", want)
+	require.NoError(t, err)
+
+	got, err := livenessStore.LookupChargeStationLiveness(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	page, err := livenessStore.ListChargeStationLiveness(ctx, 10, "")
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, want, page[0])
+
+	got, err = livenessStore.LookupChargeStationLiveness(ctx, "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetAndListConnectorStatuses(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	statusStore, err := firestore.NewStore(ctx, "myproject", clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+
+	statuses := []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 2, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now.Add(time.Minute)},
+		{ChargeStationId: "cs002", EvseId: 1, ConnectorId: 1, Status: "Faulted", LastUpdated: now},
+	}
+	for _, status := range statuses {
+		err = statusStore.SetConnectorStatus(ctx, status)
+		require.NoError(t, err)
+	}
+
+	got, err := statusStore.ListConnectorStatuses(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ConnectorStatus{statuses[2], statuses[0]}, got)
+
+	got, err = statusStore.ListConnectorStatuses(ctx, "cs003")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestSetLookupAndListEvses(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	evseStore, err := firestore.NewStore(ctx, "myproject", clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+
+	evses := []*store.ChargeStationEvse{
+		{ChargeStationId: "cs001", EvseId: 2, Connectors: []store.ChargeStationConnector{{ConnectorId: 1}}, LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, Connectors: []store.ChargeStationConnector{{ConnectorId: 1}}, LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, Connectors: []store.ChargeStationConnector{
+			{ConnectorId: 1, ConnectorType: "cType2", MaxPower: 22000, PhysicalLabel: "A"},
+			{ConnectorId: 2, ConnectorType: "cCCS2"},
+		}, LastUpdated: now.Add(time.Minute)},
+		{ChargeStationId: "cs002", EvseId: 1, LastUpdated: now},
+	}
+	for _, evse := range evses {
+		err = evseStore.SetEvse(ctx, evse)
+		require.NoError(t, err)
+	}
+
+	got, err := evseStore.ListEvses(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationEvse{evses[2], evses[0]}, got)
+
+	evse, err := evseStore.LookupEvse(ctx, "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, evses[2], evse)
+
+	evse, err = evseStore.LookupEvse(ctx, "cs001", 3)
+	require.NoError(t, err)
+	assert.Nil(t, evse)
+
+	got, err = evseStore.ListEvses(ctx, "cs003")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type exiResponseChunks struct {
+	ChargeStationId string    `firestore:"cs"`
+	ExiRequestHash  string    `firestore:"h"`
+	Chunks          []string  `firestore:"c"`
+	ExpiresAt       time.Time `firestore:"x"`
+}
+
+func (s *Store) SetExiResponseChunks(ctx context.Context, chunks *store.ExiResponseChunks) error {
+	chunksRef := s.client.Doc(fmt.Sprintf("ExiResponseChunks/%s:%s", chunks.ChargeStationId, chunks.ExiRequestHash))
+	_, err := chunksRef.Set(ctx, &exiResponseChunks{
+		ChargeStationId: chunks.ChargeStationId,
+		ExiRequestHash:  chunks.ExiRequestHash,
+		Chunks:          chunks.Chunks,
+		ExpiresAt:       chunks.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("setting exi response chunks %s:%s: %w", chunks.ChargeStationId, chunks.ExiRequestHash, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) (*store.ExiResponseChunks, error) {
+	chunksRef := s.client.Doc(fmt.Sprintf("ExiResponseChunks/%s:%s", chargeStationId, exiRequestHash))
+	snap, err := chunksRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup exi response chunks %s:%s: %w", chargeStationId, exiRequestHash, err)
+	}
+	var data exiResponseChunks
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map exi response chunks %s:%s: %w", chargeStationId, exiRequestHash, err)
+	}
+	return &store.ExiResponseChunks{
+		ChargeStationId: data.ChargeStationId,
+		ExiRequestHash:  data.ExiRequestHash,
+		Chunks:          data.Chunks,
+		ExpiresAt:       data.ExpiresAt,
+	}, nil
+}
+
+func (s *Store) DeleteExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) error {
+	chunksRef := s.client.Doc(fmt.Sprintf("ExiResponseChunks/%s:%s", chargeStationId, exiRequestHash))
+	_, err := chunksRef.Delete(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		return fmt.Errorf("delete exi response chunks %s:%s: %w", chargeStationId, exiRequestHash, err)
+	}
+	return nil
+}
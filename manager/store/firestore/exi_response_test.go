@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupExiResponseChunks(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	want := &store.ExiResponseChunks{
+		ChargeStationId: "cs001",
+		ExiRequestHash:  "request-hash",
+		Chunks:          []string{"first", "second"},
+		ExpiresAt:       time.Now().Add(5 * time.Minute).UTC().Truncate(time.Millisecond),
+	}
+
+	err = engine.SetExiResponseChunks(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupExiResponseChunks(ctx, "cs001", "request-hash")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestLookupExiResponseChunksThatDoesNotExist(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	got, err := engine.LookupExiResponseChunks(ctx, "cs001", "request-hash")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestDeleteExiResponseChunks(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	err = engine.SetExiResponseChunks(ctx, &store.ExiResponseChunks{
+		ChargeStationId: "cs001",
+		ExiRequestHash:  "request-hash",
+		Chunks:          []string{"first", "second"},
+		ExpiresAt:       time.Now().Add(5 * time.Minute).UTC(),
+	})
+	require.NoError(t, err)
+
+	err = engine.DeleteExiResponseChunks(ctx, "cs001", "request-hash")
+	require.NoError(t, err)
+
+	got, err := engine.LookupExiResponseChunks(ctx, "cs001", "request-hash")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
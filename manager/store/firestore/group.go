@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type site struct {
+	Name         string `firestore:"name"`
+	ParentSiteId string `firestore:"parent"`
+	TenantId     string `firestore:"tenant"`
+}
+
+type tag struct {
+	Description string `firestore:"desc"`
+	TenantId    string `firestore:"tenant"`
+}
+
+func (s *Store) SetSite(ctx context.Context, st *store.Site) error {
+	siteRef := s.client.Doc(fmt.Sprintf("Site/%s", st.Id))
+	_, err := siteRef.Set(ctx, &site{
+		Name:         st.Name,
+		ParentSiteId: st.ParentSiteId,
+		TenantId:     st.TenantId,
+	})
+	if err != nil {
+		return fmt.Errorf("setting site %s: %w", st.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupSite(ctx context.Context, siteId string) (*store.Site, error) {
+	siteRef := s.client.Doc(fmt.Sprintf("Site/%s", siteId))
+	snap, err := siteRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup site %s: %w", siteId, err)
+	}
+	var data site
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map site %s: %w", siteId, err)
+	}
+	return mapSite(siteId, &data), nil
+}
+
+func (s *Store) ListSites(ctx context.Context, offset int, limit int) ([]*store.Site, error) {
+	sites, err := listSites(ctx, s.client.Collection("Site").Query, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list sites: %w", err)
+	}
+	return sites, nil
+}
+
+// ListTenantSites selects the tenant's sites with an equality filter
+func (s *Store) ListTenantSites(ctx context.Context, tenantId string, offset, limit int) ([]*store.Site, error) {
+	sites, err := listSites(ctx, s.client.Collection("Site").Where("tenant", "==", tenantId), offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list sites for tenant %s: %w", tenantId, err)
+	}
+	return sites, nil
+}
+
+func listSites(ctx context.Context, q firestore.Query, offset, limit int) ([]*store.Site, error) {
+	snaps, err := q.OrderBy(firestore.DocumentID, firestore.Asc).Offset(offset).Limit(limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	sites := make([]*store.Site, 0, len(snaps))
+	for _, snap := range snaps {
+		var data site
+		if err = snap.DataTo(&data); err != nil {
+			return nil, fmt.Errorf("map site %s: %w", snap.Ref.ID, err)
+		}
+		sites = append(sites, mapSite(snap.Ref.ID, &data))
+	}
+	return sites, nil
+}
+
+func (s *Store) DeleteSite(ctx context.Context, siteId string) error {
+	siteRef := s.client.Doc(fmt.Sprintf("Site/%s", siteId))
+	_, err := siteRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting site %s: %w", siteId, err)
+	}
+	return nil
+}
+
+func mapSite(siteId string, data *site) *store.Site {
+	return &store.Site{
+		Id:           siteId,
+		Name:         data.Name,
+		ParentSiteId: data.ParentSiteId,
+		TenantId:     data.TenantId,
+	}
+}
+
+func (s *Store) SetTag(ctx context.Context, t *store.Tag) error {
+	tagRef := s.client.Doc(fmt.Sprintf("Tag/%s", t.Name))
+	_, err := tagRef.Set(ctx, &tag{
+		Description: t.Description,
+		TenantId:    t.TenantId,
+	})
+	if err != nil {
+		return fmt.Errorf("setting tag %s: %w", t.Name, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupTag(ctx context.Context, name string) (*store.Tag, error) {
+	tagRef := s.client.Doc(fmt.Sprintf("Tag/%s", name))
+	snap, err := tagRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup tag %s: %w", name, err)
+	}
+	var data tag
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map tag %s: %w", name, err)
+	}
+	return mapTag(name, &data), nil
+}
+
+func (s *Store) ListTags(ctx context.Context, offset int, limit int) ([]*store.Tag, error) {
+	tags, err := listTags(ctx, s.client.Collection("Tag").Query, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	return tags, nil
+}
+
+// ListTenantTags selects the tenant's tags with an equality filter
+func (s *Store) ListTenantTags(ctx context.Context, tenantId string, offset, limit int) ([]*store.Tag, error) {
+	tags, err := listTags(ctx, s.client.Collection("Tag").Where("tenant", "==", tenantId), offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tags for tenant %s: %w", tenantId, err)
+	}
+	return tags, nil
+}
+
+func listTags(ctx context.Context, q firestore.Query, offset, limit int) ([]*store.Tag, error) {
+	snaps, err := q.OrderBy(firestore.DocumentID, firestore.Asc).Offset(offset).Limit(limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]*store.Tag, 0, len(snaps))
+	for _, snap := range snaps {
+		var data tag
+		if err = snap.DataTo(&data); err != nil {
+			return nil, fmt.Errorf("map tag %s: %w", snap.Ref.ID, err)
+		}
+		tags = append(tags, mapTag(snap.Ref.ID, &data))
+	}
+	return tags, nil
+}
+
+func (s *Store) DeleteTag(ctx context.Context, name string) error {
+	tagRef := s.client.Doc(fmt.Sprintf("Tag/%s", name))
+	_, err := tagRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting tag %s: %w", name, err)
+	}
+	return nil
+}
+
+func mapTag(name string, data *tag) *store.Tag {
+	return &store.Tag{
+		Name:        name,
+		Description: data.Description,
+		TenantId:    data.TenantId,
+	}
+}
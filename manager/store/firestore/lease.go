@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type lease struct {
+	Holder    string    `firestore:"holder"`
+	ExpiresAt time.Time `firestore:"exp"`
+	Version   int       `firestore:"ver"`
+}
+
+func (s *Store) SetLease(ctx context.Context, l *store.Lease) error {
+	leaseRef := s.client.Doc(fmt.Sprintf("Lease/%s", l.Name))
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var version int
+		snap, err := tx.Get(leaseRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var data lease
+			if err = snap.DataTo(&data); err != nil {
+				return err
+			}
+			version = data.Version
+		}
+		if l.Version != version {
+			return store.ErrVersionConflict
+		}
+		return tx.Set(leaseRef, &lease{
+			Holder:    l.Holder,
+			ExpiresAt: l.ExpiresAt,
+			Version:   l.Version + 1,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("setting lease %s: %w", l.Name, err)
+	}
+	l.Version++
+	return nil
+}
+
+func (s *Store) LookupLease(ctx context.Context, name string) (*store.Lease, error) {
+	leaseRef := s.client.Doc(fmt.Sprintf("Lease/%s", name))
+	snap, err := leaseRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup lease %s: %w", name, err)
+	}
+	var data lease
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map lease %s: %w", name, err)
+	}
+	return &store.Lease{
+		Name:      name,
+		Holder:    data.Holder,
+		ExpiresAt: data.ExpiresAt,
+		Version:   data.Version,
+	}, nil
+}
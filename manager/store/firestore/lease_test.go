@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupLease(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	want := &store.Lease{
+		Name:      "sync",
+		Holder:    "manager-1",
+		ExpiresAt: time.Now().UTC().Add(30 * time.Second).Truncate(time.Millisecond),
+	}
+	require.NoError(t, engine.SetLease(ctx, want))
+	assert.Equal(t, 1, want.Version)
+
+	got, err := engine.LookupLease(ctx, "sync")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, want.Holder, got.Holder)
+	assert.True(t, want.ExpiresAt.Equal(got.ExpiresAt))
+	assert.Equal(t, 1, got.Version)
+
+	stale := *want
+	stale.Version = 0
+	assert.ErrorIs(t, engine.SetLease(ctx, &stale), store.ErrVersionConflict)
+
+	got, err = engine.LookupLease(ctx, "retention")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -75,15 +75,34 @@ func TestMain(m *testing.M) {
 
 func cleanupAllCollections(t *testing.T, gcloudProject string) {
 	cleanupCollection(t, gcloudProject, "Certificate")
+	cleanupCollection(t, gcloudProject, "ChargeDetailRecord")
 	cleanupCollection(t, gcloudProject, "ChargeStation")
+	cleanupCollection(t, gcloudProject, "ChargeStationDetails")
 	cleanupCollection(t, gcloudProject, "ChargeStationSettings")
 	cleanupCollection(t, gcloudProject, "ChargeStationInstallCertificates")
+	cleanupCollection(t, gcloudProject, "ChargeStationInstalledCertificates")
+	cleanupCollection(t, gcloudProject, "ChargeStationLiveness")
+	cleanupCollection(t, gcloudProject, "ChargeStationProvisioning")
+	cleanupCollection(t, gcloudProject, "ChargeStationRegistration")
 	cleanupCollection(t, gcloudProject, "ChargeStationRuntimeDetails")
+	cleanupCollection(t, gcloudProject, "CommandAuditRecord")
+	cleanupCollection(t, gcloudProject, "ConnectorStatus")
+	cleanupCollection(t, gcloudProject, "Evse")
+	cleanupCollection(t, gcloudProject, "ExiResponseChunks")
+	cleanupCollection(t, gcloudProject, "OutboundCallQueues")
+	cleanupCollection(t, gcloudProject, "Lease")
 	cleanupCollection(t, gcloudProject, "Location")
+	cleanupCollection(t, gcloudProject, "MeterValues")
+	cleanupCollection(t, gcloudProject, "OcpiCommand")
 	cleanupCollection(t, gcloudProject, "OcpiParty")
+	cleanupCollection(t, gcloudProject, "OcpiPush")
 	cleanupCollection(t, gcloudProject, "OcpiRegistration")
+	cleanupCollection(t, gcloudProject, "OicpSession")
+	cleanupCollection(t, gcloudProject, "Reservation")
+	cleanupCollection(t, gcloudProject, "Tariff")
 	cleanupCollection(t, gcloudProject, "Token")
 	cleanupCollection(t, gcloudProject, "Transaction")
+	cleanupCollection(t, gcloudProject, "TransactionEvent")
 }
 
 func cleanupCollection(t *testing.T, gcloudProject, collection string) {
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type evseMeterValues struct {
+	ChargeStationId string             `firestore:"cs"`
+	EvseId          int                `firestore:"e"`
+	MeterValues     []store.MeterValue `firestore:"m"`
+}
+
+func (s *Store) AddMeterValues(ctx context.Context, chargeStationId string, evseId int, meterValues []store.MeterValue) error {
+	existing, err := s.LookupMeterValues(ctx, chargeStationId, evseId)
+	if err != nil {
+		return err
+	}
+
+	data := &evseMeterValues{
+		ChargeStationId: chargeStationId,
+		EvseId:          evseId,
+	}
+	if existing != nil {
+		data.MeterValues = existing.MeterValues
+	}
+	data.MeterValues = append(data.MeterValues, meterValues...)
+	store.SortMeterValues(data.MeterValues)
+
+	meterValuesRef := s.client.Doc(fmt.Sprintf("MeterValues/%s:%d", chargeStationId, evseId))
+	_, err = meterValuesRef.Set(ctx, data)
+	if err != nil {
+		return fmt.Errorf("setting meter values %s:%d: %w", chargeStationId, evseId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupMeterValues(ctx context.Context, chargeStationId string, evseId int) (*store.EvseMeterValues, error) {
+	meterValuesRef := s.client.Doc(fmt.Sprintf("MeterValues/%s:%d", chargeStationId, evseId))
+	snap, err := meterValuesRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup meter values %s:%d: %w", chargeStationId, evseId, err)
+	}
+	var data evseMeterValues
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map meter values %s:%d: %w", chargeStationId, evseId, err)
+	}
+	return &store.EvseMeterValues{
+		ChargeStationId: data.ChargeStationId,
+		EvseId:          data.EvseId,
+		MeterValues:     data.MeterValues,
+	}, nil
+}
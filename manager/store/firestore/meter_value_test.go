@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func meterValue(timestamp string, value float64) store.MeterValue {
+	measurand := "Energy.Active.Import.Register"
+	return store.MeterValue{
+		Timestamp: timestamp,
+		SampledValues: []store.SampledValue{
+			{
+				Measurand: &measurand,
+				Value:     value,
+			},
+		},
+	}
+}
+
+func TestAddAndLookupMeterValues(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	err = engine.AddMeterValues(ctx, "cs001", 1, []store.MeterValue{meterValue("2023-06-15T15:10:00Z", 200)})
+	require.NoError(t, err)
+	err = engine.AddMeterValues(ctx, "cs001", 1, []store.MeterValue{meterValue("2023-06-15T15:05:00Z", 100)})
+	require.NoError(t, err)
+	err = engine.AddMeterValues(ctx, "cs001", 2, []store.MeterValue{meterValue("2023-06-15T15:05:00Z", 50)})
+	require.NoError(t, err)
+
+	got, err := engine.LookupMeterValues(ctx, "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, &store.EvseMeterValues{
+		ChargeStationId: "cs001",
+		EvseId:          1,
+		MeterValues: []store.MeterValue{
+			meterValue("2023-06-15T15:05:00Z", 100),
+			meterValue("2023-06-15T15:10:00Z", 200),
+		},
+	}, got)
+}
+
+func TestLookupMeterValuesThatDoNotExist(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	got, err := engine.LookupMeterValues(ctx, "cs001", 1)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
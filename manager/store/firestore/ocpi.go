@@ -3,6 +3,7 @@
 package firestore
 
 import (
+	"cloud.google.com/go/firestore"
 	"context"
 	"errors"
 	"fmt"
@@ -74,6 +75,15 @@ func (s *Store) GetPartyDetails(ctx context.Context, role, countryCode, partyId
 	return &registration, nil
 }
 
+func (s *Store) DeletePartyDetails(ctx context.Context, role, countryCode, partyId string) error {
+	partyRef := s.client.Doc(fmt.Sprintf("OcpiParty/%s/Id/%s:%s", role, countryCode, partyId))
+	_, err := partyRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("delete party details %s/%s:%s: %w", role, countryCode, partyId, err)
+	}
+	return nil
+}
+
 func (s *Store) ListPartyDetailsForRole(context context.Context, role string) ([]*store.OcpiParty, error) {
 	var parties []*store.OcpiParty
 	iter := s.client.Collection(fmt.Sprintf("OcpiParty/%s/Id", role)).Documents(context)
@@ -96,3 +106,80 @@ func (s *Store) ListPartyDetailsForRole(context context.Context, role string) ([
 	}
 	return parties, nil
 }
+
+func (s *Store) SetPendingCommand(ctx context.Context, command *store.OcpiCommand) error {
+	commandRef := s.client.Doc(fmt.Sprintf("OcpiCommand/%s:%s:%s", command.Command, command.ChargeStationId, command.Reference))
+	_, err := commandRef.Set(ctx, command)
+	if err != nil {
+		return fmt.Errorf("setting pending command %s:%s:%s: %w", command.Command, command.ChargeStationId, command.Reference, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupPendingCommand(ctx context.Context, command, chargeStationId, reference string) (*store.OcpiCommand, error) {
+	commandRef := s.client.Doc(fmt.Sprintf("OcpiCommand/%s:%s:%s", command, chargeStationId, reference))
+	snap, err := commandRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup pending command %s:%s:%s: %w", command, chargeStationId, reference, err)
+	}
+	var pending store.OcpiCommand
+	err = snap.DataTo(&pending)
+	if err != nil {
+		return nil, fmt.Errorf("map pending command %s:%s:%s: %w", command, chargeStationId, reference, err)
+	}
+	return &pending, nil
+}
+
+func (s *Store) DeletePendingCommand(ctx context.Context, command, chargeStationId, reference string) error {
+	commandRef := s.client.Doc(fmt.Sprintf("OcpiCommand/%s:%s:%s", command, chargeStationId, reference))
+	_, err := commandRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("delete pending command %s:%s:%s: %w", command, chargeStationId, reference, err)
+	}
+	return nil
+}
+
+func (s *Store) SetPendingPush(ctx context.Context, push *store.OcpiPush) error {
+	pushRef := s.client.Doc(fmt.Sprintf("OcpiPush/%s", push.Id))
+	_, err := pushRef.Set(ctx, push)
+	if err != nil {
+		return fmt.Errorf("setting pending push %s: %w", push.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) ListPendingPushes(ctx context.Context, pageSize int, previousId string) ([]*store.OcpiPush, error) {
+	var docIt *firestore.DocumentIterator
+	if previousId == "" {
+		docIt = s.client.Collection("OcpiPush").OrderBy(firestore.DocumentID, firestore.Asc).
+			Limit(pageSize).Documents(ctx)
+	} else {
+		docIt = s.client.Collection("OcpiPush").OrderBy(firestore.DocumentID, firestore.Asc).
+			StartAfter(previousId).Limit(pageSize).Documents(ctx)
+	}
+	snaps, err := docIt.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list pending pushes: %w", err)
+	}
+	pushes := make([]*store.OcpiPush, 0, len(snaps))
+	for _, snap := range snaps {
+		var push store.OcpiPush
+		if err = snap.DataTo(&push); err != nil {
+			return nil, fmt.Errorf("map pending push %s: %w", snap.Ref.ID, err)
+		}
+		pushes = append(pushes, &push)
+	}
+	return pushes, nil
+}
+
+func (s *Store) DeletePendingPush(ctx context.Context, id string) error {
+	pushRef := s.client.Doc(fmt.Sprintf("OcpiPush/%s", id))
+	_, err := pushRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("delete pending push %s: %w", id, err)
+	}
+	return nil
+}
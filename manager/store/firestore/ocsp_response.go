@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type ocspResponse struct {
+	Key           string    `firestore:"k"`
+	Response      string    `firestore:"r"`
+	NextUpdate    time.Time `firestore:"n"`
+	Request       string    `firestore:"q"`
+	ResponderUrls []string  `firestore:"u"`
+	Issuer        string    `firestore:"i"`
+}
+
+func (s *Store) SetOcspResponse(ctx context.Context, response *store.OcspResponse) error {
+	responseRef := s.client.Doc(fmt.Sprintf("OcspResponse/%s", response.Key))
+	_, err := responseRef.Set(ctx, &ocspResponse{
+		Key:           response.Key,
+		Response:      response.Response,
+		NextUpdate:    response.NextUpdate,
+		Request:       response.Request,
+		ResponderUrls: response.ResponderUrls,
+		Issuer:        response.Issuer,
+	})
+	if err != nil {
+		return fmt.Errorf("setting ocsp response %s: %w", response.Key, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupOcspResponse(ctx context.Context, key string) (*store.OcspResponse, error) {
+	responseRef := s.client.Doc(fmt.Sprintf("OcspResponse/%s", key))
+	snap, err := responseRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup ocsp response %s: %w", key, err)
+	}
+	var data ocspResponse
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map ocsp response %s: %w", key, err)
+	}
+	return mapOcspResponse(&data), nil
+}
+
+func (s *Store) ListOcspResponses(ctx context.Context, pageSize int, previousKey string) ([]*store.OcspResponse, error) {
+	query := s.client.Collection("OcspResponse").OrderBy(firestore.DocumentID, firestore.Asc)
+	if previousKey != "" {
+		query = query.StartAfter(previousKey)
+	}
+	snaps, err := query.Limit(pageSize).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list ocsp responses: %w", err)
+	}
+	var responses []*store.OcspResponse
+	for _, snap := range snaps {
+		var data ocspResponse
+		if err = snap.DataTo(&data); err != nil {
+			return nil, fmt.Errorf("map ocsp response %s: %w", snap.Ref.ID, err)
+		}
+		responses = append(responses, mapOcspResponse(&data))
+	}
+	return responses, nil
+}
+
+func mapOcspResponse(data *ocspResponse) *store.OcspResponse {
+	return &store.OcspResponse{
+		Key:           data.Key,
+		Response:      data.Response,
+		NextUpdate:    data.NextUpdate,
+		Request:       data.Request,
+		ResponderUrls: data.ResponderUrls,
+		Issuer:        data.Issuer,
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Store) SetOicpSession(ctx context.Context, session *store.OicpSession) error {
+	sessionRef := s.client.Doc(fmt.Sprintf("OicpSession/%s", session.IdToken))
+	_, err := sessionRef.Set(ctx, session)
+	if err != nil {
+		return fmt.Errorf("setting oicp session %s: %w", session.IdToken, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupOicpSession(ctx context.Context, idToken string) (*store.OicpSession, error) {
+	sessionRef := s.client.Doc(fmt.Sprintf("OicpSession/%s", idToken))
+	snap, err := sessionRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup oicp session %s: %w", idToken, err)
+	}
+	var session store.OicpSession
+	err = snap.DataTo(&session)
+	if err != nil {
+		return nil, fmt.Errorf("map oicp session %s: %w", idToken, err)
+	}
+	return &session, nil
+}
+
+func (s *Store) DeleteOicpSession(ctx context.Context, idToken string) error {
+	sessionRef := s.client.Doc(fmt.Sprintf("OicpSession/%s", idToken))
+	_, err := sessionRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("delete oicp session %s: %w", idToken, err)
+	}
+	return nil
+}
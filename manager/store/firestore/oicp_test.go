@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestSetLookupAndDeleteOicpSession(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	err = engine.SetOicpSession(ctx, &store.OicpSession{
+		IdToken:   "DEHBJC123456",
+		SessionId: "b2688855-7f00-0002-6d8e-48d883f6abb6",
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupOicpSession(ctx, "DEHBJC123456")
+	require.NoError(t, err)
+	assert.Equal(t, &store.OicpSession{
+		IdToken:   "DEHBJC123456",
+		SessionId: "b2688855-7f00-0002-6d8e-48d883f6abb6",
+	}, got)
+
+	got, err = engine.LookupOicpSession(ctx, "DEHBJC654321")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	err = engine.DeleteOicpSession(ctx, "DEHBJC123456")
+	require.NoError(t, err)
+
+	got, err = engine.LookupOicpSession(ctx, "DEHBJC123456")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type outboundCall struct {
+	OcppVersion    string `firestore:"v"`
+	MessageId      string `firestore:"id"`
+	Action         string `firestore:"a"`
+	RequestPayload string `firestore:"p"`
+	State          string `firestore:"s"`
+}
+
+type outboundCallQueue struct {
+	ChargeStationId   string          `firestore:"cs"`
+	InFlight          *outboundCall   `firestore:"f"`
+	InFlightExpiresAt time.Time       `firestore:"x"`
+	Queued            []*outboundCall `firestore:"q"`
+	Version           int             `firestore:"ver"`
+}
+
+func toFirestoreOutboundCall(call *store.OutboundCall) *outboundCall {
+	if call == nil {
+		return nil
+	}
+	return &outboundCall{
+		OcppVersion:    call.OcppVersion,
+		MessageId:      call.MessageId,
+		Action:         call.Action,
+		RequestPayload: call.RequestPayload,
+		State:          call.State,
+	}
+}
+
+func fromFirestoreOutboundCall(call *outboundCall) *store.OutboundCall {
+	if call == nil {
+		return nil
+	}
+	return &store.OutboundCall{
+		OcppVersion:    call.OcppVersion,
+		MessageId:      call.MessageId,
+		Action:         call.Action,
+		RequestPayload: call.RequestPayload,
+		State:          call.State,
+	}
+}
+
+func (s *Store) SetOutboundCallQueue(ctx context.Context, queue *store.OutboundCallQueue) error {
+	queueRef := s.client.Doc(fmt.Sprintf("OutboundCallQueues/%s", queue.ChargeStationId))
+	data := &outboundCallQueue{
+		ChargeStationId:   queue.ChargeStationId,
+		InFlight:          toFirestoreOutboundCall(queue.InFlight),
+		InFlightExpiresAt: queue.InFlightExpiresAt,
+		Version:           queue.Version + 1,
+	}
+	for _, call := range queue.Queued {
+		data.Queued = append(data.Queued, toFirestoreOutboundCall(call))
+	}
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var version int
+		snap, err := tx.Get(queueRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var existing outboundCallQueue
+			if err = snap.DataTo(&existing); err != nil {
+				return err
+			}
+			version = existing.Version
+		}
+		if queue.Version != version {
+			return store.ErrVersionConflict
+		}
+		return tx.Set(queueRef, data)
+	})
+	if err != nil {
+		return fmt.Errorf("setting outbound call queue %s: %w", queue.ChargeStationId, err)
+	}
+	queue.Version++
+	return nil
+}
+
+func (s *Store) LookupOutboundCallQueue(ctx context.Context, chargeStationId string) (*store.OutboundCallQueue, error) {
+	queueRef := s.client.Doc(fmt.Sprintf("OutboundCallQueues/%s", chargeStationId))
+	snap, err := queueRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup outbound call queue %s: %w", chargeStationId, err)
+	}
+	var data outboundCallQueue
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map outbound call queue %s: %w", chargeStationId, err)
+	}
+	queue := &store.OutboundCallQueue{
+		ChargeStationId:   data.ChargeStationId,
+		InFlight:          fromFirestoreOutboundCall(data.InFlight),
+		InFlightExpiresAt: data.InFlightExpiresAt,
+		Version:           data.Version,
+	}
+	for _, call := range data.Queued {
+		queue.Queued = append(queue.Queued, fromFirestoreOutboundCall(call))
+	}
+	return queue, nil
+}
+
+func (s *Store) DeleteOutboundCallQueue(ctx context.Context, chargeStationId string) error {
+	queueRef := s.client.Doc(fmt.Sprintf("OutboundCallQueues/%s", chargeStationId))
+	_, err := queueRef.Delete(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		return fmt.Errorf("delete outbound call queue %s: %w", chargeStationId, err)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+)
+
+func init() {
+	store.Register("firestore", func(ctx context.Context, options map[string]any) (store.Engine, error) {
+		projectId, err := store.RequiredStringOption(options, "project_id")
+		if err != nil {
+			return nil, err
+		}
+		return NewStore(ctx, projectId, clock.RealClock{})
+	})
+}
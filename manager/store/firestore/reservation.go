@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type reservationTransfer struct {
+	ChargeStationId string `firestore:"cs"`
+	EvseId          *int   `firestore:"e"`
+	Status          string `firestore:"s"`
+}
+
+type reservationPayment struct {
+	AuthorizationId string `firestore:"a"`
+	Status          string `firestore:"s"`
+}
+
+type reservationContact struct {
+	Email string `firestore:"e"`
+	Phone string `firestore:"p"`
+}
+
+type reservation struct {
+	ReservationId   int                  `firestore:"id"`
+	ChargeStationId string               `firestore:"cs"`
+	EvseId          *int                 `firestore:"e"`
+	IdToken         string               `firestore:"t"`
+	TokenType       string               `firestore:"tt"`
+	ExpiryDate      time.Time            `firestore:"x"`
+	Status          string               `firestore:"s"`
+	Transfer        *reservationTransfer `firestore:"m"`
+	SendAfter       time.Time            `firestore:"u"`
+	StartDate       time.Time            `firestore:"sd"`
+	NoShow          bool                 `firestore:"ns"`
+	NoShowFee       *store.CostBreakdown `firestore:"nf"`
+	Payment         *reservationPayment  `firestore:"p"`
+	TransactionId   string               `firestore:"tx"`
+	Contact         *reservationContact  `firestore:"c"`
+	Notified        []string             `firestore:"n"`
+	TenantId        string               `firestore:"tn"`
+	Version         int                  `firestore:"ver"`
+}
+
+func mapReservation(data *reservation) *store.Reservation {
+	var transfer *store.ReservationTransfer
+	if data.Transfer != nil {
+		transfer = &store.ReservationTransfer{
+			ChargeStationId: data.Transfer.ChargeStationId,
+			EvseId:          data.Transfer.EvseId,
+			Status:          store.ReservationTransferStatus(data.Transfer.Status),
+		}
+	}
+	var payment *store.ReservationPayment
+	if data.Payment != nil {
+		payment = &store.ReservationPayment{
+			AuthorizationId: data.Payment.AuthorizationId,
+			Status:          store.ReservationPaymentStatus(data.Payment.Status),
+		}
+	}
+	var contact *store.ReservationContact
+	if data.Contact != nil {
+		contact = &store.ReservationContact{
+			Email: data.Contact.Email,
+			Phone: data.Contact.Phone,
+		}
+	}
+	var notified []store.ReservationNotification
+	for _, notification := range data.Notified {
+		notified = append(notified, store.ReservationNotification(notification))
+	}
+	return &store.Reservation{
+		ReservationId:   data.ReservationId,
+		ChargeStationId: data.ChargeStationId,
+		EvseId:          data.EvseId,
+		IdToken:         data.IdToken,
+		TokenType:       data.TokenType,
+		ExpiryDate:      data.ExpiryDate,
+		Status:          store.ReservationStatus(data.Status),
+		Transfer:        transfer,
+		SendAfter:       data.SendAfter,
+		StartDate:       data.StartDate,
+		NoShow:          data.NoShow,
+		NoShowFee:       data.NoShowFee,
+		Payment:         payment,
+		TransactionId:   data.TransactionId,
+		Contact:         contact,
+		Notified:        notified,
+		TenantId:        data.TenantId,
+		Version:         data.Version,
+	}
+}
+
+func newReservation(r *store.Reservation) *reservation {
+	var transfer *reservationTransfer
+	if r.Transfer != nil {
+		transfer = &reservationTransfer{
+			ChargeStationId: r.Transfer.ChargeStationId,
+			EvseId:          r.Transfer.EvseId,
+			Status:          string(r.Transfer.Status),
+		}
+	}
+	var payment *reservationPayment
+	if r.Payment != nil {
+		payment = &reservationPayment{
+			AuthorizationId: r.Payment.AuthorizationId,
+			Status:          string(r.Payment.Status),
+		}
+	}
+	var contact *reservationContact
+	if r.Contact != nil {
+		contact = &reservationContact{
+			Email: r.Contact.Email,
+			Phone: r.Contact.Phone,
+		}
+	}
+	var notified []string
+	for _, notification := range r.Notified {
+		notified = append(notified, string(notification))
+	}
+	return &reservation{
+		ReservationId:   r.ReservationId,
+		ChargeStationId: r.ChargeStationId,
+		EvseId:          r.EvseId,
+		IdToken:         r.IdToken,
+		TokenType:       r.TokenType,
+		ExpiryDate:      r.ExpiryDate,
+		Status:          string(r.Status),
+		Transfer:        transfer,
+		SendAfter:       r.SendAfter,
+		StartDate:       r.StartDate,
+		NoShow:          r.NoShow,
+		NoShowFee:       r.NoShowFee,
+		Payment:         payment,
+		TransactionId:   r.TransactionId,
+		Contact:         contact,
+		Notified:        notified,
+		TenantId:        r.TenantId,
+		Version:         r.Version,
+	}
+}
+
+func (s *Store) SetReservation(ctx context.Context, r *store.Reservation) error {
+	reservationRef := s.client.Doc(fmt.Sprintf("Reservation/%d", r.ReservationId))
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var version int
+		snap, err := tx.Get(reservationRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var data reservation
+			if err = snap.DataTo(&data); err != nil {
+				return err
+			}
+			version = data.Version
+		}
+		if r.Version != version {
+			return store.ErrVersionConflict
+		}
+		data := newReservation(r)
+		data.Version++
+		return tx.Set(reservationRef, data)
+	})
+	if err != nil {
+		return fmt.Errorf("setting reservation %d: %w", r.ReservationId, err)
+	}
+	r.Version++
+	return nil
+}
+
+func (s *Store) LookupReservation(ctx context.Context, reservationId int) (*store.Reservation, error) {
+	reservationRef := s.client.Doc(fmt.Sprintf("Reservation/%d", reservationId))
+	snap, err := reservationRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup reservation %d: %w", reservationId, err)
+	}
+	var data reservation
+	if err = snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map reservation %d: %w", reservationId, err)
+	}
+	return mapReservation(&data), nil
+}
+
+func (s *Store) ListReservations(ctx context.Context, pageSize int, previousReservationId int) ([]*store.Reservation, error) {
+	var reservations []*store.Reservation
+	snaps, err := s.client.Collection("Reservation").OrderBy("id", firestore.Asc).
+		StartAfter(previousReservationId).Limit(pageSize).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list reservations: %w", err)
+	}
+	for _, snap := range snaps {
+		var data reservation
+		if err = snap.DataTo(&data); err != nil {
+			return nil, fmt.Errorf("map reservation: %w", err)
+		}
+		reservations = append(reservations, mapReservation(&data))
+	}
+	return reservations, nil
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupReservation(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	evseId := 1
+	targetEvseId := 2
+	want := &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		EvseId:          &evseId,
+		IdToken:         "SOMERFID",
+		TokenType:       "ISO14443",
+		ExpiryDate:      time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond),
+		Status:          store.ReservationStatusAccepted,
+		Transfer: &store.ReservationTransfer{
+			ChargeStationId: "cs002",
+			EvseId:          &targetEvseId,
+			Status:          store.ReservationTransferStatusPending,
+		},
+		SendAfter: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	err = engine.SetReservation(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupReservation(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestLookupReservationThatDoesNotExist(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	got, err := engine.LookupReservation(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListReservations(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	for i := 1; i <= 25; i++ {
+		err := engine.SetReservation(ctx, &store.Reservation{
+			ReservationId:   i,
+			ChargeStationId: "cs001",
+			Status:          store.ReservationStatusAccepted,
+		})
+		require.NoError(t, err)
+	}
+
+	page1, err := engine.ListReservations(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, page1, 10)
+	assert.Equal(t, 1, page1[0].ReservationId)
+
+	page2, err := engine.ListReservations(ctx, 10, page1[9].ReservationId)
+	require.NoError(t, err)
+	require.Len(t, page2, 10)
+	assert.Equal(t, 11, page2[0].ReservationId)
+
+	page3, err := engine.ListReservations(ctx, 10, page2[9].ReservationId)
+	require.NoError(t, err)
+	require.Len(t, page3, 5)
+	assert.Equal(t, 25, page3[4].ReservationId)
+}
+
+func TestSetReservationWithStaleVersionConflicts(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	err = engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusPending,
+	})
+	require.NoError(t, err)
+
+	first, err := engine.LookupReservation(ctx, 1)
+	require.NoError(t, err)
+	second, err := engine.LookupReservation(ctx, 1)
+	require.NoError(t, err)
+
+	first.Status = store.ReservationStatusAccepted
+	err = engine.SetReservation(ctx, first)
+	require.NoError(t, err)
+
+	second.Status = store.ReservationStatusRejected
+	err = engine.SetReservation(ctx, second)
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+	got, err := engine.LookupReservation(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusAccepted, got.Status)
+	assert.Equal(t, 2, got.Version)
+}
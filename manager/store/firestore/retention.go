@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// PurgeMeterValues only updates documents that have not changed since they were read:
+// a document that is updated concurrently is purged by the next run
+func (s *Store) PurgeMeterValues(ctx context.Context, before time.Time) (int, error) {
+	iter := s.client.Collection("MeterValues").Documents(ctx)
+	defer iter.Stop()
+
+	purged := 0
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return purged, fmt.Errorf("next meter values: %w", err)
+		}
+		var data evseMeterValues
+		if err = snap.DataTo(&data); err != nil {
+			return purged, fmt.Errorf("map meter values %s: %w", snap.Ref.ID, err)
+		}
+		retained, count := store.RetainMeterValues(data.MeterValues, before)
+		if count == 0 {
+			continue
+		}
+		_, err = snap.Ref.Update(ctx, []firestore.Update{{Path: "m", Value: retained}},
+			firestore.LastUpdateTime(snap.UpdateTime))
+		if status.Code(err) == codes.FailedPrecondition {
+			continue
+		}
+		if err != nil {
+			return purged, fmt.Errorf("purging meter values %s: %w", snap.Ref.ID, err)
+		}
+		purged += count
+	}
+	return purged, nil
+}
+
+// AnonymizeTransactions only updates documents that have not changed since they were
+// read: a transaction that is updated concurrently is anonymized by the next run
+func (s *Store) AnonymizeTransactions(ctx context.Context, before time.Time) (int, error) {
+	iter := s.client.Collection("Transaction").Documents(ctx)
+	defer iter.Stop()
+
+	anonymized := 0
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return anonymized, fmt.Errorf("next transaction: %w", err)
+		}
+		var transaction store.Transaction
+		if err = snap.DataTo(&transaction); err != nil {
+			return anonymized, fmt.Errorf("map transaction %s: %w", snap.Ref.ID, err)
+		}
+		if !transaction.Anonymize(before) {
+			continue
+		}
+		_, err = snap.Ref.Update(ctx, []firestore.Update{
+			{Path: "idToken", Value: transaction.IdToken},
+			{Path: "tokenType", Value: transaction.TokenType},
+			{Path: "version", Value: transaction.Version + 1},
+		}, firestore.LastUpdateTime(snap.UpdateTime))
+		if status.Code(err) == codes.FailedPrecondition {
+			continue
+		}
+		if err != nil {
+			return anonymized, fmt.Errorf("anonymizing transaction %s: %w", snap.Ref.ID, err)
+		}
+		anonymized++
+	}
+	return anonymized, nil
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestPurgeMeterValues(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	err = engine.AddMeterValues(ctx, "cs001", 1, []store.MeterValue{
+		{Timestamp: "2023-01-01T00:00:00Z"},
+		{Timestamp: "2023-06-01T00:00:00Z"},
+	})
+	require.NoError(t, err)
+
+	purged, err := engine.PurgeMeterValues(ctx, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	got, err := engine.LookupMeterValues(ctx, "cs001", 1)
+	require.NoError(t, err)
+	require.Len(t, got.MeterValues, 1)
+	assert.Equal(t, "2023-06-01T00:00:00Z", got.MeterValues[0].Timestamp)
+}
+
+func TestAnonymizeTransactions(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	err = engine.CreateTransaction(ctx, "cs001", "1234", idToken, tokenType,
+		[]store.MeterValue{{Timestamp: "2021-01-01T00:00:00Z"}}, 0, false)
+	require.NoError(t, err)
+	err = engine.EndTransaction(ctx, "cs001", "1234", idToken, tokenType, nil, 1)
+	require.NoError(t, err)
+
+	anonymized, err := engine.AnonymizeTransactions(ctx, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, anonymized)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "1234")
+	require.NoError(t, err)
+	assert.Empty(t, got.IdToken)
+	assert.Empty(t, got.TokenType)
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+// FleetStats uses firestore aggregation queries, so the documents are counted and
+// summed by firestore rather than read. The connector statuses and transactions are
+// aggregated by fields that are written alongside them: documents written before the
+// fields were added are not counted until they are next written.
+func (s *Store) FleetStats(ctx context.Context, since, now time.Time) (*store.FleetStats, error) {
+	stats := &store.FleetStats{}
+
+	liveness := s.client.Collection("ChargeStationLiveness").Query
+	chargeStations, err := count(ctx, liveness)
+	if err != nil {
+		return nil, fmt.Errorf("counting charge stations: %w", err)
+	}
+	stats.ChargeStationsOffline, err = count(ctx, liveness.Where("o", "==", true))
+	if err != nil {
+		return nil, fmt.Errorf("counting offline charge stations: %w", err)
+	}
+	stats.ChargeStationsOnline = chargeStations - stats.ChargeStationsOffline
+
+	result, err := s.client.Collection("ConnectorStatus").NewAggregationQuery().WithSum("f", "faulted").Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting faulted connectors: %w", err)
+	}
+	stats.FaultedConnectors = int(aggregate(result, "faulted"))
+
+	transactions := s.client.Collection("Transaction").Query
+	stats.ActiveTransactions, err = count(ctx, transactions.Where("endedSeqNo", "==", 0))
+	if err != nil {
+		return nil, fmt.Errorf("counting active transactions: %w", err)
+	}
+	completed := transactions.Where("endTime", ">=", since)
+	result, err = completed.NewAggregationQuery().
+		WithCount("completed").
+		WithSum("energyWh", "energy").
+		Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting completed transactions: %w", err)
+	}
+	stats.CompletedTransactions = int(aggregate(result, "completed"))
+	stats.EnergyDeliveredWh = aggregate(result, "energy")
+
+	accepted := s.client.Collection("Reservation").Where("s", "==", string(store.ReservationStatusAccepted))
+	stats.ActiveReservations, err = count(ctx, accepted.Where("x", ">", now))
+	if err != nil {
+		return nil, fmt.Errorf("counting active reservations: %w", err)
+	}
+	stats.ExpiredReservations, err = count(ctx, accepted.Where("x", "<=", now))
+	if err != nil {
+		return nil, fmt.Errorf("counting expired reservations: %w", err)
+	}
+
+	return stats, nil
+}
+
+// count returns the number of documents selected by the query
+func count(ctx context.Context, q firestore.Query) (int, error) {
+	result, err := q.NewAggregationQuery().WithCount("count").Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int(aggregate(result, "count")), nil
+}
+
+// aggregate returns the value of the aggregation with the alias: sums are integers if
+// every summed value is an integer, otherwise doubles
+func aggregate(result firestore.AggregationResult, alias string) float64 {
+	value, ok := result[alias].(*pb.Value)
+	if !ok {
+		return 0
+	}
+	if _, ok := value.GetValueType().(*pb.Value_DoubleValue); ok {
+		return value.GetDoubleValue()
+	}
+	return float64(value.GetIntegerValue())
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestFleetStats(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	since := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := since.Add(12 * time.Hour)
+	transactionEnd := "Transaction.End"
+	outlet := "Outlet"
+	energyRegister := "Energy.Active.Import.Register"
+	endMeterValues := func(ts time.Time, wh float64) []store.MeterValue {
+		return []store.MeterValue{{
+			Timestamp: ts.Format(time.RFC3339),
+			SampledValues: []store.SampledValue{
+				{Context: &transactionEnd, Location: &outlet, Measurand: &energyRegister, Value: wh},
+			},
+		}}
+	}
+
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs001", &store.ChargeStationLiveness{LastSeen: now}))
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs002", &store.ChargeStationLiveness{LastSeen: since, Offline: true}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", ConnectorId: 1, Status: "Faulted"}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", ConnectorId: 2, Status: "Available"}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs002", ConnectorId: 1, Status: "Faulted"}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs002", ConnectorId: 1, Status: "Available"}))
+
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 0, false))
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx002", "DEADBEEF", "ISO14443", nil, 0, false))
+	require.NoError(t, engine.EndTransaction(ctx, "cs001", "tx002", "DEADBEEF", "ISO14443", endMeterValues(now, 1500), 1))
+	require.NoError(t, engine.CreateTransaction(ctx, "cs002", "tx003", "DEADBEEF", "ISO14443", nil, 0, false))
+	require.NoError(t, engine.EndTransaction(ctx, "cs002", "tx003", "DEADBEEF", "ISO14443", endMeterValues(since.Add(-time.Hour), 2000), 1))
+
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{ReservationId: 1, ChargeStationId: "cs001",
+		Status: store.ReservationStatusAccepted, ExpiryDate: now.Add(30 * time.Minute)}))
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{ReservationId: 2, ChargeStationId: "cs001",
+		Status: store.ReservationStatusAccepted, ExpiryDate: now.Add(-time.Minute)}))
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{ReservationId: 3, ChargeStationId: "cs002",
+		Status: store.ReservationStatusCancelled, ExpiryDate: now.Add(time.Hour)}))
+
+	require.Implements(t, (*store.FleetStatsStore)(nil), engine)
+	stats, err := store.ComputeFleetStats(ctx, engine, since, now)
+	require.NoError(t, err)
+	assert.Equal(t, &store.FleetStats{
+		ChargeStationsOnline:  1,
+		ChargeStationsOffline: 1,
+		ActiveTransactions:    1,
+		CompletedTransactions: 1,
+		EnergyDeliveredWh:     1500,
+		ActiveReservations:    1,
+		ExpiredReservations:   1,
+		FaultedConnectors:     1,
+	}, stats)
+}
@@ -26,3 +26,8 @@ func NewStore(ctx context.Context, gcloudProject string, clock clock.PassiveCloc
 		clock:  clock,
 	}, nil
 }
+
+// Close closes the firestore client
+func (s *Store) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Store) SetTariff(ctx context.Context, tariff *store.Tariff) error {
+	tariffRef := s.client.Doc(fmt.Sprintf("Tariff/%s", tariff.Id))
+	_, err := tariffRef.Set(ctx, tariff)
+	if err != nil {
+		return fmt.Errorf("setting tariff %s: %w", tariff.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupTariff(ctx context.Context, tariffId string) (*store.Tariff, error) {
+	tariffRef := s.client.Doc(fmt.Sprintf("Tariff/%s", tariffId))
+	snap, err := tariffRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup tariff %s: %w", tariffId, err)
+	}
+	var tariff store.Tariff
+	if err = snap.DataTo(&tariff); err != nil {
+		return nil, fmt.Errorf("lookup tariff %s: %w", tariffId, err)
+	}
+	return &tariff, nil
+}
+
+func (s *Store) ListTariffs(ctx context.Context, offset int, limit int) ([]*store.Tariff, error) {
+	var tariffs []*store.Tariff
+	iter := s.client.Collection("Tariff").OrderBy("Id", firestore.Asc).Offset(offset).Limit(limit).Documents(ctx)
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("next tariff: %w", err)
+		}
+		var tariff store.Tariff
+		if err = snap.DataTo(&tariff); err != nil {
+			return nil, fmt.Errorf("map tariff: %w", err)
+		}
+		tariffs = append(tariffs, &tariff)
+	}
+	if tariffs == nil {
+		tariffs = make([]*store.Tariff, 0)
+	}
+	return tariffs, nil
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestSetAndLookupTariff(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	want := &store.Tariff{
+		Id:       "tariff001",
+		Currency: "EUR",
+		Elements: []store.TariffElement{
+			{
+				PriceComponents: []store.PriceComponent{
+					{Type: "ENERGY", Price: 0.35, StepSize: 1},
+				},
+				Restrictions: &store.TariffRestrictions{
+					StartTime: "08:00",
+					EndTime:   "20:00",
+					DayOfWeek: []string{"MONDAY", "TUESDAY"},
+				},
+			},
+			{
+				PriceComponents: []store.PriceComponent{
+					{Type: "ENERGY", Price: 0.25, StepSize: 1},
+				},
+			},
+		},
+		LastUpdated: "2023-06-01T12:00:00Z",
+	}
+	err = engine.SetTariff(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupTariff(ctx, "tariff001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = engine.LookupTariff(ctx, "tariff002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListTariffs(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	for i := 4; i >= 0; i-- {
+		err := engine.SetTariff(ctx, &store.Tariff{Id: fmt.Sprintf("tariff%03d", i)})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListTariffs(ctx, 1, 3)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, tariff := range got {
+		ids = append(ids, tariff.Id)
+	}
+	assert.Equal(t, []string{"tariff001", "tariff002", "tariff003"}, ids)
+}
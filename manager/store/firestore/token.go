@@ -25,28 +25,48 @@ type token struct {
 	Valid        bool    `firestore:"valid"`
 	LanguageCode *string `firestore:"lang"`
 	CacheMode    string  `firestore:"cache"`
+	TenantId     string  `firestore:"tenant"`
+	Version      int     `firestore:"ver"`
 }
 
 func (s *Store) SetToken(ctx context.Context, tok *store.Token) error {
 	tokenRef := s.client.Doc(fmt.Sprintf("Token/%s", tok.Uid))
-	tokenData := &token{
-		CountryCode:  tok.CountryCode,
-		PartyId:      tok.PartyId,
-		Type:         tok.Type,
-		Uid:          tok.Uid,
-		ContractId:   tok.ContractId,
-		VisualNumber: tok.VisualNumber,
-		Issuer:       tok.Issuer,
-		GroupId:      tok.GroupId,
-		Valid:        tok.Valid,
-		LanguageCode: tok.LanguageCode,
-		CacheMode:    tok.CacheMode,
-	}
-	_, err := tokenRef.Set(ctx, tokenData)
-
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var version int
+		snap, err := tx.Get(tokenRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var existing token
+			if err = snap.DataTo(&existing); err != nil {
+				return err
+			}
+			version = existing.Version
+		}
+		if tok.Version != version {
+			return store.ErrVersionConflict
+		}
+		return tx.Set(tokenRef, &token{
+			CountryCode:  tok.CountryCode,
+			PartyId:      tok.PartyId,
+			Type:         tok.Type,
+			Uid:          tok.Uid,
+			ContractId:   tok.ContractId,
+			VisualNumber: tok.VisualNumber,
+			Issuer:       tok.Issuer,
+			GroupId:      tok.GroupId,
+			Valid:        tok.Valid,
+			LanguageCode: tok.LanguageCode,
+			CacheMode:    tok.CacheMode,
+			TenantId:     tok.TenantId,
+			Version:      version + 1,
+		})
+	})
 	if err != nil {
 		return fmt.Errorf("setting token: %s: %w", tok.Uid, err)
 	}
+	tok.Version++
 	return nil
 }
 
@@ -80,12 +100,24 @@ func newToken(snap *firestore.DocumentSnapshot, tokenUid string) (*store.Token,
 		LanguageCode: tok.LanguageCode,
 		CacheMode:    tok.CacheMode,
 		LastUpdated:  snap.UpdateTime.Format(time.RFC3339),
+		TenantId:     tok.TenantId,
+		Version:      tok.Version,
 	}, nil
 }
 
 func (s *Store) ListTokens(context context.Context, offset int, limit int) ([]*store.Token, error) {
+	return listTokens(context, s.client.Collection("Token").Query, offset, limit)
+}
+
+// ListTenantTokens selects the tenant's tokens with an equality filter, using the
+// composite index defined in firestore.indexes.json
+func (s *Store) ListTenantTokens(ctx context.Context, tenantId string, offset, limit int) ([]*store.Token, error) {
+	return listTokens(ctx, s.client.Collection("Token").Where("tenant", "==", tenantId), offset, limit)
+}
+
+func listTokens(ctx context.Context, q firestore.Query, offset, limit int) ([]*store.Token, error) {
 	var tokens []*store.Token
-	iter := s.client.Collection("Token").OrderBy("uid", firestore.Asc).Offset(offset).Limit(limit).Documents(context)
+	iter := q.OrderBy("uid", firestore.Asc).Offset(offset).Limit(limit).Documents(ctx)
 	for {
 		doc, err := iter.Next()
 		if err == iterator.Done {
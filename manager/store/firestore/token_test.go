@@ -93,6 +93,33 @@ func TestLookupTokenThatDoesNotExist(t *testing.T) {
 	require.Nil(t, got)
 }
 
+func TestSetTokenWithStaleVersionConflicts(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+
+	tokenStore, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	require.NoError(t, tokenStore.SetToken(ctx, &store.Token{Uid: "12345678", Valid: true}))
+
+	first, err := tokenStore.LookupToken(ctx, "12345678")
+	require.NoError(t, err)
+	second, err := tokenStore.LookupToken(ctx, "12345678")
+	require.NoError(t, err)
+
+	first.CacheMode = store.CacheModeNever
+	require.NoError(t, tokenStore.SetToken(ctx, first))
+
+	second.Valid = false
+	err = tokenStore.SetToken(ctx, second)
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+	got, err := tokenStore.LookupToken(ctx, "12345678")
+	require.NoError(t, err)
+	assert.True(t, got.Valid)
+	assert.Equal(t, 2, got.Version)
+}
+
 func TestListTokensWithNoMatches(t *testing.T) {
 	defer cleanupAllCollections(t, "myproject")
 
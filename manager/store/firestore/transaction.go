@@ -5,39 +5,28 @@ package firestore
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
+	"cloud.google.com/go/firestore"
 	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slices"
+	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 func (s *Store) CreateTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValue []store.MeterValue, seqNo int, offline bool) error {
-	transaction, err := s.FindTransaction(ctx, chargeStationId, transactionId)
-	if err != nil {
-		return fmt.Errorf("getting transaction: %w", err)
-	}
-
-	if transaction != nil {
-		transaction.IdToken = idToken
-		transaction.TokenType = tokenType
-		transaction.MeterValues = append(transaction.MeterValues, meterValue...)
-		transaction.StartSeqNo = seqNo
-		transaction.Offline = offline
-	} else {
-		transaction = &store.Transaction{
-			ChargeStationId:   chargeStationId,
-			TransactionId:     transactionId,
-			IdToken:           idToken,
-			TokenType:         tokenType,
-			MeterValues:       meterValue,
-			StartSeqNo:        seqNo,
-			EndedSeqNo:        0,
-			UpdatedSeqNoCount: 0,
-			Offline:           offline,
-		}
-	}
-
-	return s.updateTransaction(ctx, chargeStationId, transactionId, transaction)
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationStart,
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     meterValue,
+		SeqNo:           seqNo,
+		Offline:         offline,
+	})
 }
 
 func (s *Store) FindTransaction(ctx context.Context, chargeStationId, transactionId string) (*store.Transaction, error) {
@@ -76,59 +65,312 @@ func (s *Store) Transactions(ctx context.Context) ([]*store.Transaction, error)
 	return transactions, nil
 }
 
-func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValue []store.MeterValue) error {
-	transaction, err := s.FindTransaction(ctx, chargeStationId, transactionId)
-	if err != nil {
-		return fmt.Errorf("getting transaction: %w", err)
+// QueryTransactions applies the equality filters and the charge stations in the query
+// and the remaining filters to the documents as they are read
+func (s *Store) QueryTransactions(ctx context.Context, filter *store.TransactionFilter, offset, limit int) ([]*store.Transaction, error) {
+	if filter == nil {
+		filter = &store.TransactionFilter{}
 	}
-
-	if transaction == nil {
-		transaction = &store.Transaction{
-			ChargeStationId:   chargeStationId,
-			TransactionId:     transactionId,
-			MeterValues:       meterValue,
-			UpdatedSeqNoCount: 1,
+	transactions := make([]*store.Transaction, 0)
+	matched := 0
+	for _, chargeStationIds := range chargeStationChunks(filter.ChargeStationIds) {
+		q := s.client.Collection("Transaction").Query
+		if filter.ChargeStationId != "" {
+			q = q.Where("chargeStationId", "==", filter.ChargeStationId)
+		}
+		if filter.IdToken != "" {
+			q = q.Where("idToken", "==", filter.IdToken)
+		}
+		if chargeStationIds != nil && filter.ChargeStationId == "" {
+			q = q.Where("chargeStationId", "in", chargeStationIds)
+		}
+		err := func() error {
+			iter := q.OrderBy(firestore.DocumentID, firestore.Asc).Documents(ctx)
+			defer iter.Stop()
+			for len(transactions) < limit {
+				snap, err := iter.Next()
+				if err == iterator.Done {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("next transaction: %w", err)
+				}
+				var transaction store.Transaction
+				if err = snap.DataTo(&transaction); err != nil {
+					return fmt.Errorf("map transaction %s: %w", snap.Ref.ID, err)
+				}
+				if !filter.Matches(&transaction) {
+					continue
+				}
+				if matched >= offset {
+					transactions = append(transactions, &transaction)
+				}
+				matched++
+			}
+			return nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+		if len(transactions) >= limit {
+			break
 		}
-	} else {
-		transaction.MeterValues = append(transaction.MeterValues, meterValue...)
-		transaction.UpdatedSeqNoCount++
 	}
+	return transactions, nil
+}
 
-	return s.updateTransaction(ctx, chargeStationId, transactionId, transaction)
+// QueryTransactionsAfter applies the equality, charge station and start time filters in
+// the query, using the composite indexes defined in firestore.indexes.json, and the
+// status filter to the documents as they are read. Charge stations are read in chunks
+// of up to maxInValues, in charge station order, and within a chunk transactions are
+// ordered by document id, or by start time and document id if the filter bounds the
+// start time.
+func (s *Store) QueryTransactionsAfter(ctx context.Context, filter *store.TransactionFilter, pageSize int, previous *store.Transaction) ([]*store.Transaction, error) {
+	if filter == nil {
+		filter = &store.TransactionFilter{}
+	}
+	transactions := make([]*store.Transaction, 0)
+	for _, chargeStationIds := range chargeStationChunks(filter.ChargeStationIds) {
+		after := previous
+		if previous != nil && chargeStationIds != nil {
+			if chargeStationIds[len(chargeStationIds)-1] < previous.ChargeStationId {
+				// the chunk was read by an earlier page
+				continue
+			}
+			if chargeStationIds[0] > previous.ChargeStationId {
+				after = nil
+			}
+		}
+		page, err := s.queryTransactionsAfter(ctx, filter, chargeStationIds, pageSize-len(transactions), after)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, page...)
+		if len(transactions) >= pageSize {
+			break
+		}
+	}
+	return transactions, nil
 }
 
-func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValue []store.MeterValue, seqNo int) error {
-	transaction, err := s.FindTransaction(ctx, chargeStationId, transactionId)
-	if err != nil {
-		return fmt.Errorf("getting transaction: %w", err)
+// queryTransactionsAfter returns up to pageSize transactions recorded by the charge
+// stations, or by any charge station if chargeStationIds is nil, that follow previous
+func (s *Store) queryTransactionsAfter(ctx context.Context, filter *store.TransactionFilter, chargeStationIds []string, pageSize int, previous *store.Transaction) ([]*store.Transaction, error) {
+	q := s.client.Collection("Transaction").Query
+	if filter.ChargeStationId != "" {
+		q = q.Where("chargeStationId", "==", filter.ChargeStationId)
+	}
+	if filter.IdToken != "" {
+		q = q.Where("idToken", "==", filter.IdToken)
+	}
+	if chargeStationIds != nil && filter.ChargeStationId == "" {
+		q = q.Where("chargeStationId", "in", chargeStationIds)
+	}
+	byStartTime := filter.StartedFrom != nil || filter.StartedBefore != nil
+	if filter.StartedFrom != nil {
+		q = q.Where("startTime", ">=", *filter.StartedFrom)
+	}
+	if filter.StartedBefore != nil {
+		q = q.Where("startTime", "<", *filter.StartedBefore)
 	}
+	if byStartTime {
+		q = q.OrderBy("startTime", firestore.Asc)
+	}
+	q = q.OrderBy(firestore.DocumentID, firestore.Asc)
+	if previous != nil {
+		previousId := transactionDocumentId(previous.ChargeStationId, previous.TransactionId)
+		if byStartTime {
+			startTime, _ := previous.StartTime()
+			q = q.StartAfter(startTime, previousId)
+		} else {
+			q = q.StartAfter(previousId)
+		}
+	}
+	iter := q.Documents(ctx)
+	defer iter.Stop()
 
-	if transaction == nil {
-		transaction = &store.Transaction{
-			ChargeStationId: chargeStationId,
-			TransactionId:   transactionId,
-			IdToken:         idToken,
-			TokenType:       tokenType,
-			MeterValues:     meterValue,
-			EndedSeqNo:      seqNo,
+	transactions := make([]*store.Transaction, 0)
+	for len(transactions) < pageSize {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("next transaction: %w", err)
+		}
+		var transaction store.Transaction
+		if err = snap.DataTo(&transaction); err != nil {
+			return nil, fmt.Errorf("map transaction %s: %w", snap.Ref.ID, err)
+		}
+		if filter.Matches(&transaction) {
+			transactions = append(transactions, &transaction)
 		}
-	} else {
-		transaction.MeterValues = append(transaction.MeterValues, meterValue...)
-		transaction.EndedSeqNo = seqNo
 	}
+	return transactions, nil
+}
 
-	return s.updateTransaction(ctx, chargeStationId, transactionId, transaction)
+func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValue []store.MeterValue) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationUpdate,
+		MeterValues:     meterValue,
+	})
 }
 
-func (s *Store) updateTransaction(ctx context.Context, chargeStationId, transactionId string, transaction *store.Transaction) error {
-	transactionRef := s.client.Doc(getPath(chargeStationId, transactionId))
-	_, err := transactionRef.Set(ctx, transaction)
+func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValue []store.MeterValue, seqNo int) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Operation:       store.TransactionOperationEnd,
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     meterValue,
+		SeqNo:           seqNo,
+	})
+}
+
+func (s *Store) MarkTransactionRecoveredFromOffline(ctx context.Context, chargeStationId, transactionId string) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId:      chargeStationId,
+		TransactionId:        transactionId,
+		RecoveredFromOffline: true,
+	})
+}
+
+func (s *Store) AddTransactionChargingState(ctx context.Context, chargeStationId, transactionId string, chargingState store.ChargingStateChange) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		ChargingState:   &chargingState,
+	})
+}
+
+func (s *Store) SetTransactionCost(ctx context.Context, chargeStationId, transactionId string, cost *store.CostBreakdown) error {
+	return s.WriteTransactionBatch(ctx, &store.TransactionBatch{
+		ChargeStationId: chargeStationId,
+		TransactionId:   transactionId,
+		Cost:            cost,
+	})
+}
+
+// WriteTransactionBatch reads and writes the transaction and the consumed reservation
+// in a single firestore transaction, which is retried if either document is changed
+// concurrently
+func (s *Store) WriteTransactionBatch(ctx context.Context, batch *store.TransactionBatch) error {
+	transactionRef := s.client.Doc(getPath(batch.ChargeStationId, batch.TransactionId))
+	var reservationRef *firestore.DocumentRef
+	if batch.ReservationId != nil {
+		reservationRef = s.client.Doc(fmt.Sprintf("Reservation/%d", *batch.ReservationId))
+	}
+
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// all reads must be made before the first write
+		var transaction *store.Transaction
+		snap, err := tx.Get(transactionRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("lookup transaction %s/%s: %w", batch.ChargeStationId, batch.TransactionId, err)
+		}
+		if err == nil {
+			transaction = new(store.Transaction)
+			if err = snap.DataTo(transaction); err != nil {
+				return fmt.Errorf("map transaction %s/%s: %w", batch.ChargeStationId, batch.TransactionId, err)
+			}
+		}
+
+		var consumed *store.Reservation
+		if reservationRef != nil {
+			snap, err := tx.Get(reservationRef)
+			if err != nil && status.Code(err) != codes.NotFound {
+				return fmt.Errorf("lookup reservation %d: %w", *batch.ReservationId, err)
+			}
+			if err == nil {
+				var data reservation
+				if err = snap.DataTo(&data); err != nil {
+					return fmt.Errorf("map reservation %d: %w", *batch.ReservationId, err)
+				}
+				consumed = mapReservation(&data)
+				if consumed.Consume(batch.ChargeStationId, batch.TransactionId) {
+					consumed.Version++
+				} else {
+					consumed = nil
+				}
+			}
+		}
+
+		transaction, err = store.ApplyTransactionBatch(transaction, batch)
+		if err != nil {
+			return err
+		}
+		if err = tx.Set(transactionRef, newTransactionDocument(transaction)); err != nil {
+			return fmt.Errorf("setting transaction %s/%s: %w", batch.ChargeStationId, batch.TransactionId, err)
+		}
+		if consumed != nil {
+			if err = tx.Set(reservationRef, newReservation(consumed)); err != nil {
+				return fmt.Errorf("setting reservation %d: %w", consumed.ReservationId, err)
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("setting transaction %s/%s: %w", chargeStationId, transactionId, err)
+		return fmt.Errorf("writing transaction batch %s/%s: %w", batch.ChargeStationId, batch.TransactionId, err)
 	}
 	return nil
 }
 
+// maxInValues is the largest number of values that firestore compares a field with in
+// an "in" filter
+const maxInValues = 30
+
+// chargeStationChunks splits the charge station ids into chunks that can each be
+// compared with an "in" filter. The chunks hold the ids in order, so reading the chunks
+// in turn reads the records in charge station order. A nil list is a single nil chunk,
+// which selects every charge station, and an empty list has no chunks.
+func chargeStationChunks(chargeStationIds []string) [][]string {
+	if chargeStationIds == nil {
+		return [][]string{nil}
+	}
+	sorted := slices.Clone(chargeStationIds)
+	sort.Strings(sorted)
+	var chunks [][]string
+	for len(sorted) > 0 {
+		n := min(len(sorted), maxInValues)
+		chunks = append(chunks, sorted[:n])
+		sorted = sorted[n:]
+	}
+	return chunks
+}
+
+// transactionDocument is the document stored for a transaction. It holds fields derived
+// from the transaction so that transactions can be selected and aggregated by them:
+// startTime is the transaction's StartTime, and endTime and energyWh are the time and
+// outlet energy of the reading taken when an ended transaction ended. Transactions
+// written before the fields were added have none of them until they are next written.
+type transactionDocument struct {
+	store.Transaction
+	StartTime *time.Time `firestore:"startTime"`
+	EndTime   *time.Time `firestore:"endTime"`
+	EnergyWh  float64    `firestore:"energyWh"`
+}
+
+func newTransactionDocument(transaction *store.Transaction) *transactionDocument {
+	doc := &transactionDocument{Transaction: *transaction}
+	if startTime, ok := transaction.StartTime(); ok {
+		doc.StartTime = &startTime
+	}
+	if transaction.Status() == store.TransactionStatusEnded {
+		if endTime, energy, ok := transaction.EndOutletEnergy(); ok {
+			doc.EndTime = &endTime
+			doc.EnergyWh = energy
+		}
+	}
+	return doc
+}
+
+func transactionDocumentId(chargeStationId, transactionId string) string {
+	return fmt.Sprintf("%s-%s", chargeStationId, transactionId)
+}
+
 func getPath(chargeStationId, transactionId string) string {
-	return fmt.Sprintf("Transaction/%s-%s", chargeStationId, transactionId)
+	return "Transaction/" + transactionDocumentId(chargeStationId, transactionId)
 }
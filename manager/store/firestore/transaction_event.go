@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type transactionEvent struct {
+	ChargeStationId string                 `firestore:"chargeStationId"`
+	TransactionId   string                 `firestore:"transactionId"`
+	RecordedAt      time.Time              `firestore:"recordedAt"`
+	Batch           store.TransactionBatch `firestore:"batch"`
+}
+
+func (s *Store) AppendTransactionEvent(ctx context.Context, event *store.TransactionEvent) error {
+	_, err := s.client.Collection("TransactionEvent").NewDoc().Create(ctx, &transactionEvent{
+		ChargeStationId: event.Batch.ChargeStationId,
+		TransactionId:   event.Batch.TransactionId,
+		RecordedAt:      event.RecordedAt,
+		Batch:           event.Batch,
+	})
+	if err != nil {
+		return fmt.Errorf("appending transaction event %s/%s: %w", event.Batch.ChargeStationId, event.Batch.TransactionId, err)
+	}
+	return nil
+}
+
+// ListTransactionEvents uses the composite index on the TransactionEvent collection
+// defined in firestore.indexes.json
+func (s *Store) ListTransactionEvents(ctx context.Context, chargeStationId, transactionId string) ([]*store.TransactionEvent, error) {
+	snaps, err := s.client.Collection("TransactionEvent").
+		Where("chargeStationId", "==", chargeStationId).
+		Where("transactionId", "==", transactionId).
+		OrderBy("recordedAt", firestore.Asc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list transaction events %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	events := make([]*store.TransactionEvent, 0, len(snaps))
+	for _, snap := range snaps {
+		var data transactionEvent
+		if err = snap.DataTo(&data); err != nil {
+			return nil, fmt.Errorf("map transaction event %s: %w", snap.Ref.ID, err)
+		}
+		events = append(events, &store.TransactionEvent{
+			RecordedAt: data.RecordedAt,
+			Batch:      data.Batch,
+		})
+	}
+	return events, nil
+}
+
+func (s *Store) ReplaceTransaction(ctx context.Context, transaction *store.Transaction) error {
+	transactionRef := s.client.Doc(getPath(transaction.ChargeStationId, transaction.TransactionId))
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		clone := *transaction
+		clone.Version = 1
+		snap, err := tx.Get(transactionRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var existing store.Transaction
+			if err = snap.DataTo(&existing); err != nil {
+				return err
+			}
+			clone.Version = existing.Version + 1
+		}
+		return tx.Set(transactionRef, newTransactionDocument(&clone))
+	})
+	if err != nil {
+		return fmt.Errorf("replacing transaction %s/%s: %w", transaction.ChargeStationId, transaction.TransactionId, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestTransactionEventsAreListedInOrder(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+	log := engine.(store.TransactionEventStore)
+
+	recordedAt := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	operations := []store.TransactionOperation{store.TransactionOperationEnd, store.TransactionOperationStart, store.TransactionOperationUpdate}
+	offsets := []time.Duration{2 * time.Minute, 0, time.Minute}
+	for i, operation := range operations {
+		err = log.AppendTransactionEvent(ctx, &store.TransactionEvent{
+			RecordedAt: recordedAt.Add(offsets[i]),
+			Batch: store.TransactionBatch{
+				ChargeStationId: "cs001",
+				TransactionId:   "tx001",
+				Operation:       operation,
+				MeterValues:     NewMeterValues(100),
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	events, err := log.ListTransactionEvents(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, recordedAt, events[0].RecordedAt.UTC())
+	assert.Equal(t, store.TransactionOperationStart, events[0].Batch.Operation)
+	assert.Equal(t, store.TransactionOperationUpdate, events[1].Batch.Operation)
+	assert.Equal(t, store.TransactionOperationEnd, events[2].Batch.Operation)
+	assert.Equal(t, NewMeterValues(100), events[0].Batch.MeterValues)
+}
+
+func TestReplaceTransaction(t *testing.T) {
+	defer cleanupAllCollections(t, "myproject")
+
+	ctx := context.Background()
+	engine, err := firestore.NewStore(ctx, "myproject", clock.RealClock{})
+	require.NoError(t, err)
+
+	err = engine.CreateTransaction(ctx, "cs001", "tx001", idToken, tokenType, NewMeterValues(100), 0, false)
+	require.NoError(t, err)
+
+	err = engine.(store.TransactionEventStore).ReplaceTransaction(ctx, &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		IdToken:         idToken,
+		TokenType:       tokenType,
+		MeterValues:     NewMeterValues(200),
+		EndedSeqNo:      1,
+	})
+	require.NoError(t, err)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, NewMeterValues(200), got.MeterValues)
+	assert.Equal(t, 1, got.EndedSeqNo)
+	assert.Equal(t, 2, got.Version)
+}
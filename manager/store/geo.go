@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// earthRadius is the mean radius of the earth in m
+const earthRadius = 6371008.8
+
+// maxGeohashPrecision is the precision of the geohashes that are stored by the engines
+// that index charge stations by geohash: a cell is about 5m across
+const maxGeohashPrecision = 9
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Parse returns the latitude and longitude in degrees
+func (g GeoLocation) Parse() (latitude, longitude float64, err error) {
+	latitude, err = strconv.ParseFloat(g.Latitude, 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return 0, 0, fmt.Errorf("invalid latitude: %q", g.Latitude)
+	}
+	longitude, err = strconv.ParseFloat(g.Longitude, 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return 0, 0, fmt.Errorf("invalid longitude: %q", g.Longitude)
+	}
+	return latitude, longitude, nil
+}
+
+// Distance returns the great-circle distance in m between two points given in degrees
+func Distance(latitude1, longitude1, latitude2, longitude2 float64) float64 {
+	phi1 := latitude1 * math.Pi / 180
+	phi2 := latitude2 * math.Pi / 180
+	dPhi := phi2 - phi1
+	dLambda := (longitude2 - longitude1) * math.Pi / 180
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox returns the bounds in degrees of the box that contains the circle with
+// the radius in m around the point. The minimum longitude is greater than the maximum
+// longitude when the box crosses the antimeridian: the box covers every longitude when
+// the circle contains a pole.
+func BoundingBox(latitude, longitude, radius float64) (minLatitude, minLongitude, maxLatitude, maxLongitude float64) {
+	dLatitude := radius / earthRadius * 180 / math.Pi
+	minLatitude = latitude - dLatitude
+	maxLatitude = latitude + dLatitude
+	if minLatitude <= -90 || maxLatitude >= 90 {
+		return math.Max(minLatitude, -90), -180, math.Min(maxLatitude, 90), 180
+	}
+	dLongitude := dLatitude / math.Cos(math.Max(math.Abs(minLatitude), math.Abs(maxLatitude))*math.Pi/180)
+	if dLongitude >= 180 {
+		return minLatitude, -180, maxLatitude, 180
+	}
+	return minLatitude, normaliseLongitude(longitude - dLongitude), maxLatitude, normaliseLongitude(longitude + dLongitude)
+}
+
+func normaliseLongitude(longitude float64) float64 {
+	if longitude < -180 {
+		return longitude + 360
+	}
+	if longitude > 180 {
+		return longitude - 360
+	}
+	return longitude
+}
+
+// Geohash returns the geohash of the point with the precision (number of characters)
+func Geohash(latitude, longitude float64, precision int) string {
+	minLatitude, maxLatitude := -90.0, 90.0
+	minLongitude, maxLongitude := -180.0, 180.0
+	hash := make([]byte, 0, precision)
+	bits, ch := 0, 0
+	even := true
+	for len(hash) < precision {
+		if even {
+			mid := (minLongitude + maxLongitude) / 2
+			if longitude >= mid {
+				ch = ch<<1 | 1
+				minLongitude = mid
+			} else {
+				ch = ch << 1
+				maxLongitude = mid
+			}
+		} else {
+			mid := (minLatitude + maxLatitude) / 2
+			if latitude >= mid {
+				ch = ch<<1 | 1
+				minLatitude = mid
+			} else {
+				ch = ch << 1
+				maxLatitude = mid
+			}
+		}
+		even = !even
+		bits++
+		if bits == 5 {
+			hash = append(hash, geohashAlphabet[ch])
+			bits, ch = 0, 0
+		}
+	}
+	return string(hash)
+}
+
+// GeohashCells returns the geohash prefixes of the cells that cover the circle with the
+// radius in m around the point: a point within the circle has a geohash that starts
+// with one of them. The cells are no smaller than the radius, so there are at most 9.
+// It returns nil when the circle is too large to be covered by cells of a single
+// character.
+func GeohashCells(latitude, longitude, radius float64) []string {
+	minLatitude, minLongitude, maxLatitude, maxLongitude := BoundingBox(latitude, longitude, radius)
+	maxCos := math.Cos(math.Max(math.Abs(minLatitude), math.Abs(maxLatitude)) * math.Pi / 180)
+
+	precision := 0
+	var cellHeight, cellWidth float64
+	for p := maxGeohashPrecision; p >= 1; p-- {
+		cellHeight = 180 / math.Pow(2, math.Floor(float64(5*p)/2))
+		cellWidth = 360 / math.Pow(2, math.Ceil(float64(5*p)/2))
+		metresPerDegree := earthRadius * math.Pi / 180
+		if cellHeight*metresPerDegree >= radius && cellWidth*metresPerDegree*maxCos >= radius {
+			precision = p
+			break
+		}
+	}
+	if precision == 0 {
+		return nil
+	}
+
+	// sampling the box at intervals no larger than a cell visits every cell that it
+	// overlaps
+	if maxLongitude < minLongitude {
+		maxLongitude += 360
+	}
+	seen := make(map[string]bool)
+	var cells []string
+	for lat := minLatitude; ; lat += cellHeight {
+		lat = math.Min(lat, maxLatitude)
+		for lng := minLongitude; ; lng += cellWidth {
+			lng = math.Min(lng, maxLongitude)
+			cell := Geohash(lat, normaliseLongitude(lng), precision)
+			if !seen[cell] {
+				seen[cell] = true
+				cells = append(cells, cell)
+			}
+			if lng == maxLongitude {
+				break
+			}
+		}
+		if lat == maxLatitude {
+			break
+		}
+	}
+	sort.Strings(cells)
+	return cells
+}
+
+// ChargeStationsNearby returns the charge stations whose coordinates are within the
+// radius in m of the point, nearest first. The engines use it to filter the candidates
+// that they select with a coarser query.
+func ChargeStationsNearby(chargeStations []*ChargeStation, latitude, longitude, radius float64) []*ChargeStation {
+	type candidate struct {
+		chargeStation *ChargeStation
+		distance      float64
+	}
+	var candidates []candidate
+	for _, chargeStation := range chargeStations {
+		if chargeStation.Coordinates == nil {
+			continue
+		}
+		lat, lng, err := chargeStation.Coordinates.Parse()
+		if err != nil {
+			continue
+		}
+		if distance := Distance(latitude, longitude, lat, lng); distance <= radius {
+			candidates = append(candidates, candidate{chargeStation, distance})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].chargeStation.ChargeStationId < candidates[j].chargeStation.ChargeStationId
+	})
+	nearby := make([]*ChargeStation, len(candidates))
+	for i, c := range candidates {
+		nearby[i] = c.chargeStation
+	}
+	return nearby
+}
+
+// ChargeStationGeohash returns the geohash that the engines index the charge station
+// by, or an empty string if it does not have valid coordinates
+func ChargeStationGeohash(chargeStation *ChargeStation) string {
+	if chargeStation.Coordinates == nil {
+		return ""
+	}
+	lat, lng, err := chargeStation.Coordinates.Parse()
+	if err != nil {
+		return ""
+	}
+	return Geohash(lat, lng, maxGeohashPrecision)
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"strings"
+	"testing"
+)
+
+func TestGeohash(t *testing.T) {
+	assert.Equal(t, "ezs42", store.Geohash(42.6, -5.6, 5))
+	assert.Equal(t, "u4pruydqq", store.Geohash(57.64911, 10.40744, 9))
+}
+
+func TestDistance(t *testing.T) {
+	// London to Paris
+	assert.InDelta(t, 343_500, store.Distance(51.5074, -0.1278, 48.8566, 2.3522), 1000)
+	assert.Equal(t, 0.0, store.Distance(51.5074, -0.1278, 51.5074, -0.1278))
+}
+
+func TestGeoLocationParse(t *testing.T) {
+	lat, lng, err := store.GeoLocation{Latitude: "51.5074", Longitude: "-0.1278"}.Parse()
+	require.NoError(t, err)
+	assert.Equal(t, 51.5074, lat)
+	assert.Equal(t, -0.1278, lng)
+
+	_, _, err = store.GeoLocation{Latitude: "91", Longitude: "0"}.Parse()
+	assert.Error(t, err)
+
+	_, _, err = store.GeoLocation{Latitude: "0", Longitude: "east"}.Parse()
+	assert.Error(t, err)
+}
+
+func TestBoundingBoxCrossingTheAntimeridian(t *testing.T) {
+	minLat, minLng, maxLat, maxLng := store.BoundingBox(0, 179.99, 10_000)
+	assert.InDelta(t, -0.09, minLat, 0.001)
+	assert.InDelta(t, 0.09, maxLat, 0.001)
+	assert.Greater(t, minLng, maxLng)
+}
+
+func TestGeohashCellsCoverTheCircle(t *testing.T) {
+	latitude, longitude, radius := 51.5007, -0.1246, 2_000.0
+	cells := store.GeohashCells(latitude, longitude, radius)
+	require.NotEmpty(t, cells)
+	assert.LessOrEqual(t, len(cells), 9)
+
+	// points on the edge of the circle are in one of the cells
+	minLat, minLng, maxLat, maxLng := store.BoundingBox(latitude, longitude, radius)
+	for _, point := range [][2]float64{
+		{minLat, longitude}, {maxLat, longitude}, {latitude, minLng}, {latitude, maxLng}, {latitude, longitude},
+	} {
+		hash := store.Geohash(point[0], point[1], 9)
+		covered := false
+		for _, cell := range cells {
+			covered = covered || strings.HasPrefix(hash, cell)
+		}
+		assert.True(t, covered, "%v is not covered", point)
+	}
+}
+
+func TestGeohashCellsForALargeCircle(t *testing.T) {
+	assert.Nil(t, store.GeohashCells(0, 0, 10_000_000))
+}
+
+func TestChargeStationsNearby(t *testing.T) {
+	chargeStations := []*store.ChargeStation{
+		{ChargeStationId: "far", Coordinates: &store.GeoLocation{Latitude: "48.8566", Longitude: "2.3522"}},
+		{ChargeStationId: "near", Coordinates: &store.GeoLocation{Latitude: "51.5080", Longitude: "-0.1281"}},
+		{ChargeStationId: "nearest", Coordinates: &store.GeoLocation{Latitude: "51.5074", Longitude: "-0.1278"}},
+		{ChargeStationId: "unknown"},
+	}
+
+	got := store.ChargeStationsNearby(chargeStations, 51.5074, -0.1278, 1000)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "nearest", got[0].ChargeStationId)
+	assert.Equal(t, "near", got[1].ChargeStationId)
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"golang.org/x/exp/slices"
+	"math"
+)
+
+// Site is a place where charge stations are installed. Sites form a hierarchy, e.g. a
+// region that contains car parks: a charge station assigned to a site is also in each
+// of the site's ancestors.
+type Site struct {
+	Id   string
+	Name string
+	// ParentSiteId is empty for a site at the top of the hierarchy
+	ParentSiteId string
+	// TenantId identifies the operator that the site belongs to: it is empty for a site
+	// that is not scoped to an operator
+	TenantId string
+}
+
+type SiteStore interface {
+	SetSite(ctx context.Context, site *Site) error
+	LookupSite(ctx context.Context, siteId string) (*Site, error)
+	// ListSites returns the sites ordered by id
+	ListSites(ctx context.Context, offset int, limit int) ([]*Site, error)
+	DeleteSite(ctx context.Context, siteId string) error
+}
+
+// Tag labels a group of charge stations, e.g. the charge stations that share a
+// supply or the DC chargers: a charge station can have many tags.
+type Tag struct {
+	Name        string
+	Description string
+	// TenantId identifies the operator that the tag belongs to: it is empty for a tag
+	// that is not scoped to an operator
+	TenantId string
+}
+
+type TagStore interface {
+	SetTag(ctx context.Context, tag *Tag) error
+	LookupTag(ctx context.Context, name string) (*Tag, error)
+	// ListTags returns the tags ordered by name
+	ListTags(ctx context.Context, offset int, limit int) ([]*Tag, error)
+	DeleteTag(ctx context.Context, name string) error
+}
+
+// HasTags reports whether the charge station has all the tags
+func (c *ChargeStation) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(c.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListAllSites returns every site, reading the sites a page at a time
+func ListAllSites(ctx context.Context, engine SiteStore) ([]*Site, error) {
+	return listTenant(ctx, engine.ListSites, func(*Site) bool { return true }, 0, math.MaxInt)
+}
+
+// SiteIds returns the id of the site followed by the ids of all of its sub-sites. A
+// site that is not registered has no sub-sites.
+func SiteIds(ctx context.Context, engine SiteStore, siteId string) ([]string, error) {
+	sites, err := ListAllSites(ctx, engine)
+	if err != nil {
+		return nil, err
+	}
+	return SiteTree(sites, siteId), nil
+}
+
+// SiteTree returns the id of the site followed by the ids of the sites below it in the
+// hierarchy
+func SiteTree(sites []*Site, siteId string) []string {
+	children := make(map[string][]string)
+	for _, site := range sites {
+		if site.ParentSiteId != "" {
+			children[site.ParentSiteId] = append(children[site.ParentSiteId], site.Id)
+		}
+	}
+	ids := []string{siteId}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			// a cycle can only be made by writing the sites directly to the engine
+			if !slices.Contains(ids, child) {
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+// SiteAncestors returns the id of the site followed by the ids of the sites above it in
+// the hierarchy
+func SiteAncestors(sites []*Site, siteId string) []string {
+	parents := make(map[string]string)
+	for _, site := range sites {
+		parents[site.Id] = site.ParentSiteId
+	}
+	ids := []string{siteId}
+	for parent := parents[siteId]; parent != "" && !slices.Contains(ids, parent); parent = parents[parent] {
+		ids = append(ids, parent)
+	}
+	return ids
+}
+
+// ChargeStationGroup selects the charge stations that belong to a tenant, are assigned to
+// one of a set of sites and have all of a set of tags. A field that is not set matches
+// all charge stations.
+type ChargeStationGroup struct {
+	TenantId string
+	// SiteIds is usually a site and its sub-sites, see SiteIds
+	SiteIds []string
+	Tags    []string
+}
+
+// NewChargeStationGroup returns the group of the tenant's charge stations that are
+// assigned to the site, or one of its sub-sites, and have all the tags
+func NewChargeStationGroup(ctx context.Context, engine SiteStore, tenantId, siteId string, tags []string) (*ChargeStationGroup, error) {
+	group := &ChargeStationGroup{
+		TenantId: tenantId,
+		Tags:     tags,
+	}
+	if siteId != "" {
+		siteIds, err := SiteIds(ctx, engine, siteId)
+		if err != nil {
+			return nil, err
+		}
+		group.SiteIds = siteIds
+	}
+	return group, nil
+}
+
+// Matches reports whether the charge station is in the group
+func (g *ChargeStationGroup) Matches(chargeStation *ChargeStation) bool {
+	if g.TenantId != "" && chargeStation.TenantId != g.TenantId {
+		return false
+	}
+	if g.SiteIds != nil && !slices.Contains(g.SiteIds, chargeStation.SiteId) {
+		return false
+	}
+	return chargeStation.HasTags(g.Tags)
+}
+
+// ListGroupChargeStations returns the page of charge stations in the group, ordered by
+// charge station id, starting at offset. The registry is read a page at a time in the
+// same way as ListTenantChargeStations.
+func ListGroupChargeStations(ctx context.Context, engine ChargeStationStore, group *ChargeStationGroup, offset, limit int) ([]*ChargeStation, error) {
+	return listTenant(ctx, engine.ListChargeStations, group.Matches, offset, limit)
+}
+
+// GroupChargeStationIds returns the ids of all the charge stations in the group
+func GroupChargeStationIds(ctx context.Context, engine ChargeStationStore, group *ChargeStationGroup) ([]string, error) {
+	chargeStations, err := ListGroupChargeStations(ctx, engine, group, 0, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(chargeStations))
+	for i, chargeStation := range chargeStations {
+		ids[i] = chargeStation.ChargeStationId
+	}
+	return ids, nil
+}
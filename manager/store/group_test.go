@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestSiteTree(t *testing.T) {
+	sites := []*store.Site{
+		{Id: "uk"},
+		{Id: "london", ParentSiteId: "uk"},
+		{Id: "car-park-1", ParentSiteId: "london"},
+		{Id: "car-park-2", ParentSiteId: "london"},
+		{Id: "manchester", ParentSiteId: "uk"},
+	}
+
+	assert.Equal(t, []string{"london", "car-park-1", "car-park-2"}, store.SiteTree(sites, "london"))
+	assert.Equal(t, []string{"car-park-1", "london", "uk"}, store.SiteAncestors(sites, "car-park-1"))
+	// a site that is not registered only contains itself
+	assert.Equal(t, []string{"unknown"}, store.SiteTree(sites, "unknown"))
+	assert.Equal(t, []string{"unknown"}, store.SiteAncestors(sites, "unknown"))
+}
+
+func TestGroupChargeStationIds(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	require.NoError(t, engine.SetSite(ctx, &store.Site{Id: "london"}))
+	require.NoError(t, engine.SetSite(ctx, &store.Site{Id: "car-park-1", ParentSiteId: "london"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{SiteId: "london", Tags: []string{"dc"}}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{SiteId: "car-park-1", Tags: []string{"ac"}}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs003", &store.ChargeStation{SiteId: "car-park-1", Tags: []string{"dc"}, TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs004", &store.ChargeStation{SiteId: "manchester", Tags: []string{"dc"}}))
+
+	group, err := store.NewChargeStationGroup(ctx, engine, "", "london", nil)
+	require.NoError(t, err)
+	ids, err := store.GroupChargeStationIds(ctx, engine, group)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cs001", "cs002", "cs003"}, ids)
+
+	group, err = store.NewChargeStationGroup(ctx, engine, "", "london", []string{"dc"})
+	require.NoError(t, err)
+	ids, err = store.GroupChargeStationIds(ctx, engine, group)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cs001", "cs003"}, ids)
+
+	group, err = store.NewChargeStationGroup(ctx, engine, "a", "", []string{"dc"})
+	require.NoError(t, err)
+	ids, err = store.GroupChargeStationIds(ctx, engine, group)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cs003"}, ids)
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupBulkCommand(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	want := &store.BulkCommand{
+		Id:          store.NewBulkCommandId(),
+		Action:      store.BulkCommandActionReset,
+		Selector:    store.BulkCommandSelector{SiteId: "site001"},
+		ResetType:   "Immediate",
+		MaxInFlight: 10,
+		Status:      store.BulkCommandStatusRunning,
+		Results: []*store.BulkCommandResult{
+			{ChargeStationId: "cs001", Status: store.BulkCommandResultStatusPending},
+		},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := engine.SetBulkCommand(ctx, want)
+	require.NoError(t, err)
+	assert.Equal(t, 1, want.Version)
+
+	got, err := engine.LookupBulkCommand(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// the results are copied, so they are only changed when the bulk command is set
+	got.Results[0].Status = store.BulkCommandResultStatusSent
+	got, err = engine.LookupBulkCommand(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Equal(t, store.BulkCommandResultStatusPending, got.Results[0].Status)
+}
+
+func TestSetBulkCommandWithStaleVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	bulkCommand := &store.BulkCommand{
+		Id:     store.NewBulkCommandId(),
+		Action: store.BulkCommandActionClearCache,
+		Status: store.BulkCommandStatusRunning,
+	}
+	require.NoError(t, engine.SetBulkCommand(ctx, bulkCommand))
+
+	stale := *bulkCommand
+	stale.Version = 0
+	err := engine.SetBulkCommand(ctx, &stale)
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+}
+
+func TestListBulkCommands(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		bulkCommand := &store.BulkCommand{
+			Id:     store.NewBulkCommandId(),
+			Action: store.BulkCommandActionClearCache,
+		}
+		require.NoError(t, engine.SetBulkCommand(ctx, bulkCommand))
+		ids = append(ids, bulkCommand.Id)
+	}
+
+	got, err := engine.ListBulkCommands(ctx, 10, ids[0])
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, ids[1], got[0].Id)
+	assert.Equal(t, ids[2], got[1].Id)
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupChargeDetailRecord(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	want := &store.ChargeDetailRecord{
+		Id:              "cdr001",
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		LocationId:      "loc001",
+		TotalEnergy:     12.5,
+		TotalCost:       6.875,
+		Currency:        "EUR",
+		LastUpdated:     "2023-06-01T12:00:00Z",
+	}
+	err := engine.SetChargeDetailRecord(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeDetailRecord(ctx, "cdr001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = engine.LookupChargeDetailRecord(ctx, "cdr002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeDetailRecords(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for i := 4; i >= 0; i-- {
+		err := engine.SetChargeDetailRecord(ctx, &store.ChargeDetailRecord{Id: fmt.Sprintf("cdr%03d", i)})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeDetailRecords(ctx, 1, 3)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, cdr := range got {
+		ids = append(ids, cdr.Id)
+	}
+	assert.Equal(t, []string{"cdr001", "cdr002", "cdr003"}, ids)
+}
+
+func TestQueryChargeDetailRecords(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for i := 0; i < 6; i++ {
+		err := engine.SetChargeDetailRecord(ctx, &store.ChargeDetailRecord{
+			Id:              fmt.Sprintf("cdr%03d", i),
+			ChargeStationId: fmt.Sprintf("cs%03d", i%2),
+			EndDateTime:     fmt.Sprintf("2023-06-0%dT12:00:00Z", i+1),
+		})
+		require.NoError(t, err)
+	}
+
+	from := time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2023, 6, 6, 0, 0, 0, 0, time.UTC)
+	filter := &store.ChargeDetailRecordFilter{EndedFrom: &from, EndedBefore: &before}
+
+	ids := func(cdrs []*store.ChargeDetailRecord) []string {
+		var ids []string
+		for _, cdr := range cdrs {
+			ids = append(ids, cdr.Id)
+		}
+		return ids
+	}
+
+	got, err := engine.QueryChargeDetailRecords(ctx, filter, 3, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cdr001", "cdr002", "cdr003"}, ids(got))
+	got, err = engine.QueryChargeDetailRecords(ctx, filter, 3, "cdr003")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cdr004"}, ids(got))
+
+	filter.ChargeStationIds = []string{"cs001"}
+	got, err = engine.QueryChargeDetailRecords(ctx, filter, 3, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cdr001", "cdr003"}, ids(got))
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupCommandAuditRecord(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	want := &store.CommandAuditRecord{
+		Id:              store.NewCommandAuditId(),
+		ChargeStationId: "cs001",
+		Command:         "createReservation",
+		Request:         `{"id":1}`,
+		RequestedBy:     "operator",
+		RequestedAt:     time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		Status:          store.CommandAuditStatusRequested,
+	}
+	err := engine.SetCommandAuditRecord(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupCommandAuditRecord(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = engine.LookupCommandAuditRecord(ctx, store.NewCommandAuditId())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestQueryCommandAuditRecords(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var ids []string
+	for i, chargeStationId := range []string{"cs001", "cs002", "cs001", "cs001"} {
+		record := &store.CommandAuditRecord{
+			Id:              store.NewCommandAuditId(),
+			ChargeStationId: chargeStationId,
+			Command:         "Reset",
+			RequestedAt:     start.Add(time.Duration(i) * time.Minute),
+			Status:          store.CommandAuditStatusSent,
+		}
+		require.NoError(t, engine.SetCommandAuditRecord(ctx, record))
+		ids = append(ids, record.Id)
+	}
+
+	queried := func(filter *store.CommandAuditFilter, offset, limit int) []string {
+		records, err := engine.QueryCommandAuditRecords(ctx, filter, offset, limit)
+		require.NoError(t, err)
+		got := make([]string, 0)
+		for _, record := range records {
+			got = append(got, record.Id)
+		}
+		return got
+	}
+
+	assert.Equal(t, ids, queried(nil, 0, 10))
+	assert.Equal(t, []string{ids[0], ids[2], ids[3]}, queried(&store.CommandAuditFilter{ChargeStationId: "cs001"}, 0, 10))
+	assert.Equal(t, []string{ids[2]}, queried(&store.CommandAuditFilter{ChargeStationId: "cs001"}, 1, 1))
+	from := start.Add(time.Minute)
+	before := start.Add(3 * time.Minute)
+	assert.Equal(t, []string{ids[1], ids[2]}, queried(&store.CommandAuditFilter{RequestedFrom: &from, RequestedBefore: &before}, 0, 10))
+	assert.Empty(t, queried(&store.CommandAuditFilter{Command: "ChangeConfiguration"}, 0, 10))
+	assert.Equal(t, []string{ids[1]}, queried(&store.CommandAuditFilter{ChargeStationIds: []string{"cs002"}}, 0, 10))
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetLookupAndDeleteCommandSchedule(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	want := &store.CommandSchedule{
+		Id:   store.NewCommandScheduleId(),
+		Name: "nightly reset",
+		Command: store.BulkCommand{
+			Action:      store.BulkCommandActionReset,
+			Selector:    store.BulkCommandSelector{Tags: []string{"dc"}},
+			ResetType:   "OnIdle",
+			MaxInFlight: 10,
+		},
+		Cron:      "0 2 * * *",
+		NextRunAt: time.Now().UTC().Add(time.Hour),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	require.NoError(t, engine.SetCommandSchedule(ctx, want))
+	assert.Equal(t, 1, want.Version)
+
+	got, err := engine.LookupCommandSchedule(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// the command is copied, so it is only changed when the schedule is set
+	got.Command.Selector.Tags[0] = "ac"
+	got, err = engine.LookupCommandSchedule(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dc"}, got.Command.Selector.Tags)
+
+	stale := *want
+	stale.Version = 0
+	assert.ErrorIs(t, engine.SetCommandSchedule(ctx, &stale), store.ErrVersionConflict)
+
+	require.NoError(t, engine.DeleteCommandSchedule(ctx, want.Id))
+	got, err = engine.LookupCommandSchedule(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListCommandSchedules(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		schedule := &store.CommandSchedule{
+			Id:       store.NewCommandScheduleId(),
+			Command:  store.BulkCommand{Action: store.BulkCommandActionClearCache},
+			TenantId: []string{"a", "b", "a"}[i],
+		}
+		require.NoError(t, engine.SetCommandSchedule(ctx, schedule))
+		ids = append(ids, schedule.Id)
+	}
+
+	got, err := engine.ListCommandSchedules(ctx, 10, ids[0])
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, ids[1], got[0].Id)
+	assert.Equal(t, ids[2], got[1].Id)
+
+	got, err = store.ListTenantCommandSchedules(ctx, engine, "a", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, ids[2], got[0].Id)
+}
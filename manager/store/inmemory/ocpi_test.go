@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestDeletePartyDetails(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for _, partyId := range []string{"TWK", "TWS"} {
+		err := engine.SetPartyDetails(ctx, &store.OcpiParty{
+			Role:        "EMSP",
+			CountryCode: "GB",
+			PartyId:     partyId,
+			Url:         "https://example.com/ocpi/versions",
+			Token:       "abcdef123456",
+		})
+		require.NoError(t, err)
+	}
+
+	err := engine.DeletePartyDetails(ctx, "EMSP", "GB", "TWK")
+	require.NoError(t, err)
+
+	got, err := engine.GetPartyDetails(ctx, "EMSP", "GB", "TWK")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	parties, err := engine.ListPartyDetailsForRole(ctx, "EMSP")
+	require.NoError(t, err)
+	require.Len(t, parties, 1)
+	assert.Equal(t, "TWS", parties[0].PartyId)
+}
+
+func TestSetLookupAndDeletePendingCommand(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetPendingCommand(ctx, &store.OcpiCommand{
+		Command:         "UNLOCK_CONNECTOR",
+		ChargeStationId: "cs001",
+		Reference:       "1",
+		ResponseUrl:     "https://example.com/ocpi/emsp/2.2/commands/UNLOCK_CONNECTOR/1234",
+		CountryCode:     "GB",
+		PartyId:         "TWE",
+	})
+	require.NoError(t, err)
+
+	want := &store.OcpiCommand{
+		Command:         "UNLOCK_CONNECTOR",
+		ChargeStationId: "cs001",
+		Reference:       "1",
+		ResponseUrl:     "https://example.com/ocpi/emsp/2.2/commands/UNLOCK_CONNECTOR/1234",
+		CountryCode:     "GB",
+		PartyId:         "TWE",
+	}
+
+	got, err := engine.LookupPendingCommand(ctx, "UNLOCK_CONNECTOR", "cs001", "1")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = engine.LookupPendingCommand(ctx, "UNLOCK_CONNECTOR", "cs001", "2")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	err = engine.DeletePendingCommand(ctx, "UNLOCK_CONNECTOR", "cs001", "1")
+	require.NoError(t, err)
+
+	got, err = engine.LookupPendingCommand(ctx, "UNLOCK_CONNECTOR", "cs001", "1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetListAndDeletePendingPushes(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	sendAfter := time.Now().UTC().Truncate(time.Millisecond)
+	for _, id := range []string{"push002", "push001", "push003"} {
+		err := engine.SetPendingPush(ctx, &store.OcpiPush{
+			Id:            id,
+			Method:        "PUT",
+			Url:           "https://example.com/ocpi/emsp/2.2/sessions/GB/TWK/" + id,
+			Body:          []byte(`{"id":"` + id + `"}`),
+			Role:          "EMSP",
+			CountryCode:   "GB",
+			PartyId:       "TWE",
+			ToCountryCode: "GB",
+			ToPartyId:     "TWE",
+			CorrelationId: "1234",
+			Attempts:      1,
+			SendAfter:     sendAfter,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListPendingPushes(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, &store.OcpiPush{
+		Id:            "push001",
+		Method:        "PUT",
+		Url:           "https://example.com/ocpi/emsp/2.2/sessions/GB/TWK/push001",
+		Body:          []byte(`{"id":"push001"}`),
+		Role:          "EMSP",
+		CountryCode:   "GB",
+		PartyId:       "TWE",
+		ToCountryCode: "GB",
+		ToPartyId:     "TWE",
+		CorrelationId: "1234",
+		Attempts:      1,
+		SendAfter:     sendAfter,
+	}, got[0])
+	assert.Equal(t, "push002", got[1].Id)
+
+	got, err = engine.ListPendingPushes(ctx, 2, "push002")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "push003", got[0].Id)
+
+	err = engine.DeletePendingPush(ctx, "push001")
+	require.NoError(t, err)
+
+	got, err = engine.ListPendingPushes(ctx, 10, "")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "push002", got[0].Id)
+}
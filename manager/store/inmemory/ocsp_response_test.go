@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupOcspResponse(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	want := &store.OcspResponse{
+		Key:        "0a1b:1234",
+		Response:   "b2NzcA==",
+		NextUpdate: time.Now().Add(time.Hour).UTC(),
+	}
+
+	err := engine.SetOcspResponse(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupOcspResponse(ctx, "0a1b:1234")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestLookupOcspResponseThatDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	got, err := engine.LookupOcspResponse(ctx, "0a1b:1234")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListOcspResponses(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for _, key := range []string{"0a1b:3", "0a1b:1", "0a1b:2"} {
+		err := engine.SetOcspResponse(ctx, &store.OcspResponse{
+			Key:        key,
+			Response:   "b2NzcA==",
+			NextUpdate: time.Now().Add(-time.Hour).UTC(),
+		})
+		require.NoError(t, err)
+	}
+
+	page, err := engine.ListOcspResponses(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "0a1b:1", page[0].Key)
+	assert.Equal(t, "0a1b:2", page[1].Key)
+
+	page, err = engine.ListOcspResponses(ctx, 2, page[1].Key)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "0a1b:3", page[0].Key)
+}
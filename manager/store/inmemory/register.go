@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+)
+
+func init() {
+	store.Register("in_memory", func(context.Context, map[string]any) (store.Engine, error) {
+		return NewStore(clock.RealClock{}), nil
+	})
+}
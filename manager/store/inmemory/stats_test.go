@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestFleetStatsMatchesTheAggregatedRecords(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	since := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := since.Add(12 * time.Hour)
+
+	transactionEnd := "Transaction.End"
+	outlet := "Outlet"
+	energyRegister := "Energy.Active.Import.Register"
+	// more charge stations than fit in a page of the liveness records
+	for i := 0; i < 150; i++ {
+		csId := fmt.Sprintf("cs%03d", i)
+		require.NoError(t, engine.SetChargeStationLiveness(ctx, csId, &store.ChargeStationLiveness{Offline: i%3 == 0}))
+		status := "Available"
+		if i%10 == 0 {
+			status = "Faulted"
+		}
+		require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: csId, ConnectorId: 1, Status: status}))
+		require.NoError(t, engine.CreateTransaction(ctx, csId, "tx001", "DEADBEEF", "ISO14443", nil, 0, false))
+		if i%2 == 0 {
+			require.NoError(t, engine.EndTransaction(ctx, csId, "tx001", "DEADBEEF", "ISO14443", []store.MeterValue{
+				{
+					Timestamp: now.Add(-time.Duration(i) * time.Hour).Format(time.RFC3339),
+					SampledValues: []store.SampledValue{
+						{Context: &transactionEnd, Location: &outlet, Measurand: &energyRegister, Value: 100},
+					},
+				},
+			}, 1))
+		}
+		require.NoError(t, engine.SetReservation(ctx, &store.Reservation{ReservationId: i + 1, ChargeStationId: csId,
+			Status: store.ReservationStatusAccepted, ExpiryDate: now.Add(time.Duration(i-100) * time.Minute)}))
+	}
+
+	stats, err := engine.FleetStats(ctx, since, now)
+	require.NoError(t, err)
+	assert.Equal(t, &store.FleetStats{
+		ChargeStationsOnline:  100,
+		ChargeStationsOffline: 50,
+		ActiveTransactions:    75,
+		CompletedTransactions: 7,
+		EnergyDeliveredWh:     700,
+		ActiveReservations:    49,
+		ExpiredReservations:   101,
+		FaultedConnectors:     15,
+	}, stats)
+
+	aggregated, err := store.AggregateFleetStats(ctx, engine, nil, since, now)
+	require.NoError(t, err)
+	assert.Equal(t, stats, aggregated)
+}
+
+func TestAggregateFleetStatsForChargeStations(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Now()
+
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs001", &store.ChargeStationLiveness{}))
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs002", &store.ChargeStationLiveness{}))
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 0, false))
+	require.NoError(t, engine.CreateTransaction(ctx, "cs002", "tx002", "DEADBEEF", "ISO14443", nil, 0, false))
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{ReservationId: 1, ChargeStationId: "cs002",
+		Status: store.ReservationStatusAccepted, ExpiryDate: now.Add(time.Hour)}))
+
+	stats, err := store.AggregateFleetStats(ctx, engine, []string{"cs001"}, now, now)
+	require.NoError(t, err)
+	assert.Equal(t, &store.FleetStats{ChargeStationsOnline: 1, ActiveTransactions: 1}, stats)
+}
@@ -17,6 +17,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/thoughtworks/maeve-csms/manager/errs"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 )
 
@@ -25,34 +26,82 @@ import (
 // instances. It is primarily provided to support unit testing.
 type Store struct {
 	sync.Mutex
-	clock                            clock.PassiveClock
-	chargeStationAuth                map[string]*store.ChargeStationAuth
-	chargeStationSettings            map[string]*store.ChargeStationSettings
-	chargeStationInstallCertificates map[string]*store.ChargeStationInstallCertificates
-	chargeStationRuntimeDetails      map[string]*store.ChargeStationRuntimeDetails
-	chargeStationTriggerMessage      map[string]*store.ChargeStationTriggerMessage
-	tokens                           map[string]*store.Token
-	transactions                     map[string]*store.Transaction
-	certificates                     map[string]string
-	registrations                    map[string]*store.OcpiRegistration
-	partyDetails                     map[string]*store.OcpiParty
-	locations                        map[string]*store.Location
+	clock                              clock.PassiveClock
+	chargeStationAuth                  map[string]*store.ChargeStationAuth
+	chargeStations                     map[string]*store.ChargeStation
+	chargeStationSettings              map[string]*store.ChargeStationSettings
+	chargeStationInstallCertificates   map[string]*store.ChargeStationInstallCertificates
+	chargeStationInstalledCertificates map[string]*store.ChargeStationInstalledCertificates
+	chargeStationRuntimeDetails        map[string]*store.ChargeStationRuntimeDetails
+	chargeStationTriggerMessage        map[string]*store.ChargeStationTriggerMessage
+	chargeStationProvisioning          map[string]*store.ChargeStationProvisioning
+	chargeStationRegistration          map[string]*store.ChargeStationRegistration
+	chargeStationLiveness              map[string]*store.ChargeStationLiveness
+	connectorStatuses                  map[string]map[string]*store.ConnectorStatus
+	evses                              map[string]map[int]*store.ChargeStationEvse
+	tokens                             map[string]*store.Token
+	transactions                       map[string]*store.Transaction
+	transactionEvents                  map[string][]*store.TransactionEvent
+	meterValues                        map[string]*store.EvseMeterValues
+	certificates                       map[string]string
+	registrations                      map[string]*store.OcpiRegistration
+	partyDetails                       map[string]*store.OcpiParty
+	pendingCommands                    map[string]*store.OcpiCommand
+	pendingPushes                      map[string]*store.OcpiPush
+	oicpSessions                       map[string]*store.OicpSession
+	locations                          map[string]*store.Location
+	chargeDetailRecords                map[string]*store.ChargeDetailRecord
+	tariffs                            map[string]*store.Tariff
+	reservations                       map[int]*store.Reservation
+	exiResponseChunks                  map[string]*store.ExiResponseChunks
+	ocspResponses                      map[string]*store.OcspResponse
+	outboundCallQueues                 map[string]*store.OutboundCallQueue
+	commandAuditRecords                map[string]*store.CommandAuditRecord
+	bulkCommands                       map[string]*store.BulkCommand
+	sites                              map[string]*store.Site
+	tags                               map[string]*store.Tag
+	commandSchedules                   map[string]*store.CommandSchedule
+	leases                             map[string]*store.Lease
 }
 
 func NewStore(clock clock.PassiveClock) *Store {
 	return &Store{
-		clock:                            clock,
-		chargeStationAuth:                make(map[string]*store.ChargeStationAuth),
-		chargeStationSettings:            make(map[string]*store.ChargeStationSettings),
-		chargeStationInstallCertificates: make(map[string]*store.ChargeStationInstallCertificates),
-		chargeStationRuntimeDetails:      make(map[string]*store.ChargeStationRuntimeDetails),
-		chargeStationTriggerMessage:      make(map[string]*store.ChargeStationTriggerMessage),
-		tokens:                           make(map[string]*store.Token),
-		transactions:                     make(map[string]*store.Transaction),
-		certificates:                     make(map[string]string),
-		registrations:                    make(map[string]*store.OcpiRegistration),
-		partyDetails:                     make(map[string]*store.OcpiParty),
-		locations:                        make(map[string]*store.Location),
+		clock:                              clock,
+		chargeStationAuth:                  make(map[string]*store.ChargeStationAuth),
+		chargeStations:                     make(map[string]*store.ChargeStation),
+		chargeStationSettings:              make(map[string]*store.ChargeStationSettings),
+		chargeStationInstallCertificates:   make(map[string]*store.ChargeStationInstallCertificates),
+		chargeStationInstalledCertificates: make(map[string]*store.ChargeStationInstalledCertificates),
+		chargeStationRuntimeDetails:        make(map[string]*store.ChargeStationRuntimeDetails),
+		chargeStationTriggerMessage:        make(map[string]*store.ChargeStationTriggerMessage),
+		chargeStationProvisioning:          make(map[string]*store.ChargeStationProvisioning),
+		chargeStationRegistration:          make(map[string]*store.ChargeStationRegistration),
+		chargeStationLiveness:              make(map[string]*store.ChargeStationLiveness),
+		connectorStatuses:                  make(map[string]map[string]*store.ConnectorStatus),
+		evses:                              make(map[string]map[int]*store.ChargeStationEvse),
+		tokens:                             make(map[string]*store.Token),
+		transactions:                       make(map[string]*store.Transaction),
+		transactionEvents:                  make(map[string][]*store.TransactionEvent),
+		meterValues:                        make(map[string]*store.EvseMeterValues),
+		certificates:                       make(map[string]string),
+		registrations:                      make(map[string]*store.OcpiRegistration),
+		partyDetails:                       make(map[string]*store.OcpiParty),
+		pendingCommands:                    make(map[string]*store.OcpiCommand),
+		pendingPushes:                      make(map[string]*store.OcpiPush),
+		oicpSessions:                       make(map[string]*store.OicpSession),
+		locations:                          make(map[string]*store.Location),
+		chargeDetailRecords:                make(map[string]*store.ChargeDetailRecord),
+		tariffs:                            make(map[string]*store.Tariff),
+		reservations:                       make(map[int]*store.Reservation),
+		exiResponseChunks:                  make(map[string]*store.ExiResponseChunks),
+		ocspResponses:                      make(map[string]*store.OcspResponse),
+		outboundCallQueues:                 make(map[string]*store.OutboundCallQueue),
+		commandAuditRecords:                make(map[string]*store.CommandAuditRecord),
+		bulkCommands:                       make(map[string]*store.BulkCommand),
+		sites:                              make(map[string]*store.Site),
+		tags:                               make(map[string]*store.Tag),
+		commandSchedules:                   make(map[string]*store.CommandSchedule),
+		leases:                             make(map[string]*store.Lease),
 	}
 }
 
@@ -69,6 +118,75 @@ func (s *Store) LookupChargeStationAuth(_ context.Context, chargeStationId strin
 	return s.chargeStationAuth[chargeStationId], nil
 }
 
+func cloneChargeStation(chargeStation *store.ChargeStation) *store.ChargeStation {
+	clone := *chargeStation
+	if chargeStation.Coordinates != nil {
+		coordinates := *chargeStation.Coordinates
+		clone.Coordinates = &coordinates
+	}
+	clone.Tags = slices.Clone(chargeStation.Tags)
+	return &clone
+}
+
+func (s *Store) SetChargeStation(_ context.Context, chargeStationId string, chargeStation *store.ChargeStation) error {
+	s.Lock()
+	defer s.Unlock()
+	var version int
+	if existing := s.chargeStations[chargeStationId]; existing != nil {
+		version = existing.Version
+	}
+	if chargeStation.Version != version {
+		return store.ErrVersionConflict
+	}
+	chargeStation.Version++
+	clone := cloneChargeStation(chargeStation)
+	clone.ChargeStationId = chargeStationId
+	s.chargeStations[chargeStationId] = clone
+	return nil
+}
+
+func (s *Store) LookupChargeStation(_ context.Context, chargeStationId string) (*store.ChargeStation, error) {
+	s.Lock()
+	defer s.Unlock()
+	chargeStation := s.chargeStations[chargeStationId]
+	if chargeStation == nil {
+		return nil, nil
+	}
+	return cloneChargeStation(chargeStation), nil
+}
+
+func (s *Store) ListChargeStations(_ context.Context, offset int, limit int) ([]*store.ChargeStation, error) {
+	s.Lock()
+	defer s.Unlock()
+	keys := maps.Keys(s.chargeStations)
+	sort.Strings(keys)
+
+	chargeStations := make([]*store.ChargeStation, 0)
+	for i, key := range keys {
+		if i >= offset && i < offset+limit {
+			chargeStations = append(chargeStations, cloneChargeStation(s.chargeStations[key]))
+		}
+	}
+	return chargeStations, nil
+}
+
+func (s *Store) ListChargeStationsNearby(_ context.Context, latitude, longitude, radius float64) ([]*store.ChargeStation, error) {
+	s.Lock()
+	defer s.Unlock()
+	chargeStations := make([]*store.ChargeStation, 0, len(s.chargeStations))
+	for _, chargeStation := range s.chargeStations {
+		chargeStations = append(chargeStations, cloneChargeStation(chargeStation))
+	}
+	return store.ChargeStationsNearby(chargeStations, latitude, longitude, radius), nil
+}
+
+func (s *Store) DeleteChargeStation(_ context.Context, chargeStationId string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.chargeStations, chargeStationId)
+	return nil
+}
+
 func (s *Store) UpdateChargeStationSettings(_ context.Context, chargeStationId string, settings *store.ChargeStationSettings) error {
 	s.Lock()
 	defer s.Unlock()
@@ -183,6 +301,223 @@ func (s *Store) ListChargeStationInstallCertificates(_ context.Context, pageSize
 	return installCertificates, nil
 }
 
+func (s *Store) SetChargeStationInstalledCertificates(_ context.Context, chargeStationId string, certificates *store.ChargeStationInstalledCertificates) error {
+	s.Lock()
+	defer s.Unlock()
+	s.chargeStationInstalledCertificates[chargeStationId] = &store.ChargeStationInstalledCertificates{
+		ChargeStationId: chargeStationId,
+		Certificates:    slices.Clone(certificates.Certificates),
+		RefreshStatus:   certificates.RefreshStatus,
+		SendAfter:       certificates.SendAfter,
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationInstalledCertificates(_ context.Context, chargeStationId string) (*store.ChargeStationInstalledCertificates, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.chargeStationInstalledCertificates[chargeStationId], nil
+}
+
+func (s *Store) ListChargeStationInstalledCertificates(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationInstalledCertificates, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.chargeStationInstalledCertificates)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var installedCertificates []*store.ChargeStationInstalledCertificates
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		installedCertificates = append(installedCertificates, s.chargeStationInstalledCertificates[k])
+	}
+	return installedCertificates, nil
+}
+
+func (s *Store) SetChargeStationProvisioning(_ context.Context, chargeStationId string, provisioning *store.ChargeStationProvisioning) error {
+	s.Lock()
+	defer s.Unlock()
+	s.chargeStationProvisioning[chargeStationId] = &store.ChargeStationProvisioning{
+		ChargeStationId: chargeStationId,
+		Status:          provisioning.Status,
+		Step:            provisioning.Step,
+		SendAfter:       provisioning.SendAfter,
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationProvisioning(_ context.Context, chargeStationId string) (*store.ChargeStationProvisioning, error) {
+	s.Lock()
+	defer s.Unlock()
+	provisioning := s.chargeStationProvisioning[chargeStationId]
+	if provisioning == nil {
+		return nil, nil
+	}
+	clone := *provisioning
+	return &clone, nil
+}
+
+func (s *Store) ListChargeStationProvisioning(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationProvisioning, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.chargeStationProvisioning)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var provisioning []*store.ChargeStationProvisioning
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		clone := *s.chargeStationProvisioning[k]
+		provisioning = append(provisioning, &clone)
+	}
+	return provisioning, nil
+}
+
+func (s *Store) SetChargeStationRegistration(_ context.Context, chargeStationId string, registration *store.ChargeStationRegistration) error {
+	s.Lock()
+	defer s.Unlock()
+	s.chargeStationRegistration[chargeStationId] = &store.ChargeStationRegistration{
+		ChargeStationId: chargeStationId,
+		Status:          registration.Status,
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationRegistration(_ context.Context, chargeStationId string) (*store.ChargeStationRegistration, error) {
+	s.Lock()
+	defer s.Unlock()
+	registration := s.chargeStationRegistration[chargeStationId]
+	if registration == nil {
+		return nil, nil
+	}
+	clone := *registration
+	return &clone, nil
+}
+
+func (s *Store) SetChargeStationLiveness(_ context.Context, chargeStationId string, liveness *store.ChargeStationLiveness) error {
+	s.Lock()
+	defer s.Unlock()
+	s.chargeStationLiveness[chargeStationId] = &store.ChargeStationLiveness{
+		ChargeStationId: chargeStationId,
+		LastSeen:        liveness.LastSeen,
+		LastHeartbeat:   liveness.LastHeartbeat,
+		Offline:         liveness.Offline,
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationLiveness(_ context.Context, chargeStationId string) (*store.ChargeStationLiveness, error) {
+	s.Lock()
+	defer s.Unlock()
+	liveness := s.chargeStationLiveness[chargeStationId]
+	if liveness == nil {
+		return nil, nil
+	}
+	clone := *liveness
+	return &clone, nil
+}
+
+func (s *Store) ListChargeStationLiveness(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationLiveness, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.chargeStationLiveness)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var liveness []*store.ChargeStationLiveness
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		clone := *s.chargeStationLiveness[k]
+		liveness = append(liveness, &clone)
+	}
+	return liveness, nil
+}
+
+func (s *Store) SetConnectorStatus(_ context.Context, status *store.ConnectorStatus) error {
+	s.Lock()
+	defer s.Unlock()
+	statuses := s.connectorStatuses[status.ChargeStationId]
+	if statuses == nil {
+		statuses = make(map[string]*store.ConnectorStatus)
+		s.connectorStatuses[status.ChargeStationId] = statuses
+	}
+	clone := *status
+	statuses[fmt.Sprintf("%d:%d", status.EvseId, status.ConnectorId)] = &clone
+	return nil
+}
+
+func (s *Store) ListConnectorStatuses(_ context.Context, chargeStationId string) ([]*store.ConnectorStatus, error) {
+	s.Lock()
+	defer s.Unlock()
+	var statuses []*store.ConnectorStatus
+	for _, status := range s.connectorStatuses[chargeStationId] {
+		clone := *status
+		statuses = append(statuses, &clone)
+	}
+	store.SortConnectorStatuses(statuses)
+	return statuses, nil
+}
+
+func cloneEvse(evse *store.ChargeStationEvse) *store.ChargeStationEvse {
+	clone := *evse
+	clone.Connectors = append([]store.ChargeStationConnector(nil), evse.Connectors...)
+	return &clone
+}
+
+func (s *Store) SetEvse(_ context.Context, evse *store.ChargeStationEvse) error {
+	s.Lock()
+	defer s.Unlock()
+	evses := s.evses[evse.ChargeStationId]
+	if evses == nil {
+		evses = make(map[int]*store.ChargeStationEvse)
+		s.evses[evse.ChargeStationId] = evses
+	}
+	evses[evse.EvseId] = cloneEvse(evse)
+	return nil
+}
+
+func (s *Store) LookupEvse(_ context.Context, chargeStationId string, evseId int) (*store.ChargeStationEvse, error) {
+	s.Lock()
+	defer s.Unlock()
+	evse := s.evses[chargeStationId][evseId]
+	if evse == nil {
+		return nil, nil
+	}
+	return cloneEvse(evse), nil
+}
+
+func (s *Store) ListEvses(_ context.Context, chargeStationId string) ([]*store.ChargeStationEvse, error) {
+	s.Lock()
+	defer s.Unlock()
+	var evses []*store.ChargeStationEvse
+	for _, evse := range s.evses[chargeStationId] {
+		evses = append(evses, cloneEvse(evse))
+	}
+	store.SortEvses(evses)
+	return evses, nil
+}
+
 func (s *Store) SetChargeStationRuntimeDetails(_ context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
 	s.Lock()
 	defer s.Unlock()
@@ -196,6 +531,32 @@ func (s *Store) LookupChargeStationRuntimeDetails(_ context.Context, chargeStati
 	return s.chargeStationRuntimeDetails[chargeStationId], nil
 }
 
+func (s *Store) ListChargeStationRuntimeDetails(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationRuntimeDetails, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.chargeStationRuntimeDetails)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousChargeStationId)
+	if !found {
+		i = 0
+	} else {
+		i++
+	}
+
+	var runtimeDetails []*store.ChargeStationRuntimeDetails
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		runtimeDetails = append(runtimeDetails, &store.ChargeStationRuntimeDetails{
+			ChargeStationId: k,
+			OcppVersion:     s.chargeStationRuntimeDetails[k].OcppVersion,
+			Iso15118Version: s.chargeStationRuntimeDetails[k].Iso15118Version,
+		})
+	}
+	return runtimeDetails, nil
+}
+
 func (s *Store) SetChargeStationTriggerMessage(ctx context.Context, chargeStationId string, triggerMessage *store.ChargeStationTriggerMessage) error {
 	s.Lock()
 	defer s.Unlock()
@@ -238,33 +599,48 @@ func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize i
 	return triggerMessages, nil
 }
 
+func cloneToken(token *store.Token) *store.Token {
+	clone := *token
+	return &clone
+}
+
 func (s *Store) SetToken(_ context.Context, token *store.Token) error {
 	s.Lock()
 	defer s.Unlock()
+	var version int
+	if existing := s.tokens[token.Uid]; existing != nil {
+		version = existing.Version
+	}
+	if token.Version != version {
+		return store.ErrVersionConflict
+	}
 	token.LastUpdated = time.Now().UTC().Format(time.RFC3339)
-	s.tokens[token.Uid] = token
+	token.Version++
+	s.tokens[token.Uid] = cloneToken(token)
 	return nil
 }
 
 func (s *Store) LookupToken(_ context.Context, tokenUid string) (*store.Token, error) {
 	s.Lock()
 	defer s.Unlock()
-	return s.tokens[tokenUid], nil
+	token := s.tokens[tokenUid]
+	if token == nil {
+		return nil, nil
+	}
+	return cloneToken(token), nil
 }
 
 func (s *Store) ListTokens(_ context.Context, offset int, limit int) ([]*store.Token, error) {
 	s.Lock()
 	defer s.Unlock()
-	var tokens []*store.Token
-	count := 0
-	for _, token := range s.tokens {
-		if count >= offset && count < offset+limit {
-			tokens = append(tokens, token)
+	keys := maps.Keys(s.tokens)
+	sort.Strings(keys)
+
+	tokens := make([]*store.Token, 0)
+	for i, key := range keys {
+		if i >= offset && i < offset+limit {
+			tokens = append(tokens, cloneToken(s.tokens[key]))
 		}
-		count++
-	}
-	if tokens == nil {
-		tokens = make([]*store.Token, 0)
 	}
 	return tokens, nil
 }
@@ -296,6 +672,51 @@ func (s *Store) Transactions(_ context.Context) ([]*store.Transaction, error) {
 	return transactions, nil
 }
 
+func (s *Store) QueryTransactions(_ context.Context, filter *store.TransactionFilter, offset, limit int) ([]*store.Transaction, error) {
+	s.Lock()
+	defer s.Unlock()
+	keys := maps.Keys(s.transactions)
+	sort.Strings(keys)
+
+	transactions := make([]*store.Transaction, 0)
+	matched := 0
+	for _, key := range keys {
+		transaction := s.transactions[key]
+		if !filter.Matches(transaction) {
+			continue
+		}
+		if matched >= offset && matched < offset+limit {
+			transactions = append(transactions, transaction)
+		}
+		matched++
+	}
+	return transactions, nil
+}
+
+func (s *Store) QueryTransactionsAfter(_ context.Context, filter *store.TransactionFilter, pageSize int, previous *store.Transaction) ([]*store.Transaction, error) {
+	s.Lock()
+	defer s.Unlock()
+	keys := maps.Keys(s.transactions)
+	sort.Strings(keys)
+
+	var after string
+	if previous != nil {
+		after = transactionKey(previous.ChargeStationId, previous.TransactionId)
+	}
+	transactions := make([]*store.Transaction, 0)
+	for _, key := range keys {
+		if len(transactions) >= pageSize {
+			break
+		}
+		transaction := s.transactions[key]
+		if key <= after || !filter.Matches(transaction) {
+			continue
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
 func (s *Store) FindTransaction(_ context.Context, chargeStationId, transactionId string) (*store.Transaction, error) {
 	s.Lock()
 	defer s.Unlock()
@@ -310,8 +731,10 @@ func (s *Store) CreateTransaction(_ context.Context, chargeStationId, transactio
 		transaction.IdToken = idToken
 		transaction.TokenType = tokenType
 		transaction.MeterValues = append(transaction.MeterValues, meterValues...)
+		store.SortMeterValues(transaction.MeterValues)
 		transaction.StartSeqNo = seqNo
 		transaction.Offline = offline
+		transaction.Version++
 	} else {
 		transaction = &store.Transaction{
 			ChargeStationId:   chargeStationId,
@@ -323,6 +746,7 @@ func (s *Store) CreateTransaction(_ context.Context, chargeStationId, transactio
 			EndedSeqNo:        0,
 			UpdatedSeqNoCount: 0,
 			Offline:           offline,
+			Version:           1,
 		}
 		s.updateTransaction(transaction)
 	}
@@ -339,11 +763,14 @@ func (s *Store) UpdateTransaction(_ context.Context, chargeStationId, transactio
 			TransactionId:     transactionId,
 			MeterValues:       meterValues,
 			UpdatedSeqNoCount: 1,
+			Version:           1,
 		}
 		s.updateTransaction(transaction)
 	} else {
 		transaction.MeterValues = append(transaction.MeterValues, meterValues...)
+		store.SortMeterValues(transaction.MeterValues)
 		transaction.UpdatedSeqNoCount++
+		transaction.Version++
 	}
 	return nil
 }
@@ -361,12 +788,84 @@ func (s *Store) EndTransaction(_ context.Context, chargeStationId, transactionId
 			TokenType:       tokenType,
 			MeterValues:     meterValues,
 			EndedSeqNo:      seqNo,
+			Version:         1,
 		}
 		s.updateTransaction(transaction)
 	} else {
 		transaction.MeterValues = append(transaction.MeterValues, meterValues...)
+		store.SortMeterValues(transaction.MeterValues)
 		transaction.EndedSeqNo = seqNo
+		transaction.Version++
+	}
+	return nil
+}
+
+func (s *Store) MarkTransactionRecoveredFromOffline(_ context.Context, chargeStationId, transactionId string) error {
+	s.Lock()
+	defer s.Unlock()
+	transaction := s.getTransaction(chargeStationId, transactionId)
+	if transaction == nil {
+		return errs.New(errs.ErrNotFound, "transaction %s/%s not found", chargeStationId, transactionId)
+	}
+	transaction.RecoveredFromOffline = true
+	transaction.Version++
+	return nil
+}
+
+func (s *Store) AddTransactionChargingState(_ context.Context, chargeStationId, transactionId string, chargingState store.ChargingStateChange) error {
+	s.Lock()
+	defer s.Unlock()
+	transaction := s.getTransaction(chargeStationId, transactionId)
+	if transaction == nil {
+		return errs.New(errs.ErrNotFound, "transaction %s/%s not found", chargeStationId, transactionId)
+	}
+	transaction.ChargingStates = append(transaction.ChargingStates, chargingState)
+	store.SortChargingStates(transaction.ChargingStates)
+	transaction.Version++
+	return nil
+}
+
+func (s *Store) SetTransactionCost(_ context.Context, chargeStationId, transactionId string, cost *store.CostBreakdown) error {
+	s.Lock()
+	defer s.Unlock()
+	transaction := s.getTransaction(chargeStationId, transactionId)
+	if transaction == nil {
+		return errs.New(errs.ErrNotFound, "transaction %s/%s not found", chargeStationId, transactionId)
+	}
+	transaction.Cost = cost
+	transaction.Version++
+	return nil
+}
+
+func (s *Store) AppendTransactionEvent(_ context.Context, event *store.TransactionEvent) error {
+	s.Lock()
+	defer s.Unlock()
+	key := transactionKey(event.Batch.ChargeStationId, event.Batch.TransactionId)
+	clone := *event
+	s.transactionEvents[key] = append(s.transactionEvents[key], &clone)
+	return nil
+}
+
+func (s *Store) ListTransactionEvents(_ context.Context, chargeStationId, transactionId string) ([]*store.TransactionEvent, error) {
+	s.Lock()
+	defer s.Unlock()
+	events := make([]*store.TransactionEvent, 0)
+	for _, event := range s.transactionEvents[transactionKey(chargeStationId, transactionId)] {
+		clone := *event
+		events = append(events, &clone)
+	}
+	return events, nil
+}
+
+func (s *Store) ReplaceTransaction(_ context.Context, transaction *store.Transaction) error {
+	s.Lock()
+	defer s.Unlock()
+	clone := *transaction
+	clone.Version = 1
+	if existing := s.getTransaction(transaction.ChargeStationId, transaction.TransactionId); existing != nil {
+		clone.Version = existing.Version + 1
 	}
+	s.updateTransaction(&clone)
 	return nil
 }
 
@@ -466,6 +965,17 @@ func (s *Store) GetPartyDetails(_ context.Context, role, countryCode, partyId st
 	return s.partyDetails[recordId], nil
 }
 
+func (s *Store) DeletePartyDetails(_ context.Context, role, countryCode, partyId string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	recordId := fmt.Sprintf("%s:%s:%s", role, countryCode, partyId)
+
+	delete(s.partyDetails, recordId)
+
+	return nil
+}
+
 func (s *Store) ListPartyDetailsForRole(_ context.Context, role string) ([]*store.OcpiParty, error) {
 	s.Lock()
 	defer s.Unlock()
@@ -481,16 +991,124 @@ func (s *Store) ListPartyDetailsForRole(_ context.Context, role string) ([]*stor
 	return parties, nil
 }
 
-func (s *Store) SetLocation(_ context.Context, location *store.Location) error {
+func (s *Store) SetPendingCommand(_ context.Context, command *store.OcpiCommand) error {
 	s.Lock()
 	defer s.Unlock()
 
-	s.locations[location.Id] = location
+	recordId := fmt.Sprintf("%s:%s:%s", command.Command, command.ChargeStationId, command.Reference)
+
+	commandCopy := *command
+	s.pendingCommands[recordId] = &commandCopy
 
 	return nil
 }
 
-func (s *Store) LookupLocation(_ context.Context, locationId string) (*store.Location, error) {
+func (s *Store) LookupPendingCommand(_ context.Context, command, chargeStationId, reference string) (*store.OcpiCommand, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	recordId := fmt.Sprintf("%s:%s:%s", command, chargeStationId, reference)
+
+	pending, ok := s.pendingCommands[recordId]
+	if !ok {
+		return nil, nil
+	}
+	pendingCopy := *pending
+	return &pendingCopy, nil
+}
+
+func (s *Store) DeletePendingCommand(_ context.Context, command, chargeStationId, reference string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	recordId := fmt.Sprintf("%s:%s:%s", command, chargeStationId, reference)
+
+	delete(s.pendingCommands, recordId)
+
+	return nil
+}
+
+func (s *Store) SetPendingPush(_ context.Context, push *store.OcpiPush) error {
+	s.Lock()
+	defer s.Unlock()
+
+	pushCopy := *push
+	s.pendingPushes[push.Id] = &pushCopy
+
+	return nil
+}
+
+func (s *Store) ListPendingPushes(_ context.Context, pageSize int, previousId string) ([]*store.OcpiPush, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	ids := maps.Keys(s.pendingPushes)
+	sort.Strings(ids)
+
+	pushes := make([]*store.OcpiPush, 0)
+	for _, id := range ids {
+		if id <= previousId {
+			continue
+		}
+		if len(pushes) >= pageSize {
+			break
+		}
+		pushCopy := *s.pendingPushes[id]
+		pushes = append(pushes, &pushCopy)
+	}
+	return pushes, nil
+}
+
+func (s *Store) DeletePendingPush(_ context.Context, id string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.pendingPushes, id)
+
+	return nil
+}
+
+func (s *Store) SetOicpSession(_ context.Context, session *store.OicpSession) error {
+	s.Lock()
+	defer s.Unlock()
+
+	sessionCopy := *session
+	s.oicpSessions[session.IdToken] = &sessionCopy
+
+	return nil
+}
+
+func (s *Store) LookupOicpSession(_ context.Context, idToken string) (*store.OicpSession, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	session, ok := s.oicpSessions[idToken]
+	if !ok {
+		return nil, nil
+	}
+	sessionCopy := *session
+	return &sessionCopy, nil
+}
+
+func (s *Store) DeleteOicpSession(_ context.Context, idToken string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.oicpSessions, idToken)
+
+	return nil
+}
+
+func (s *Store) SetLocation(_ context.Context, location *store.Location) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.locations[location.Id] = location
+
+	return nil
+}
+
+func (s *Store) LookupLocation(_ context.Context, locationId string) (*store.Location, error) {
 	s.Lock()
 	defer s.Unlock()
 
@@ -500,16 +1118,702 @@ func (s *Store) LookupLocation(_ context.Context, locationId string) (*store.Loc
 func (s *Store) ListLocations(_ context.Context, offset int, limit int) ([]*store.Location, error) {
 	s.Lock()
 	defer s.Unlock()
+	// pages must be stable so order by id, as firestore does
+	keys := maps.Keys(s.locations)
+	sort.Strings(keys)
+
 	var locations []*store.Location
-	count := 0
-	for _, location := range s.locations {
-		if count >= offset && count < offset+limit {
-			locations = append(locations, location)
+	for i, key := range keys {
+		if i >= offset && i < offset+limit {
+			locations = append(locations, s.locations[key])
 		}
-		count++
 	}
 	if locations == nil {
 		locations = make([]*store.Location, 0)
 	}
 	return locations, nil
 }
+
+func (s *Store) SetChargeDetailRecord(_ context.Context, cdr *store.ChargeDetailRecord) error {
+	s.Lock()
+	defer s.Unlock()
+	clone := *cdr
+	s.chargeDetailRecords[cdr.Id] = &clone
+	return nil
+}
+
+func (s *Store) LookupChargeDetailRecord(_ context.Context, cdrId string) (*store.ChargeDetailRecord, error) {
+	s.Lock()
+	defer s.Unlock()
+	cdr, ok := s.chargeDetailRecords[cdrId]
+	if !ok {
+		return nil, nil
+	}
+	clone := *cdr
+	return &clone, nil
+}
+
+func (s *Store) ListChargeDetailRecords(_ context.Context, offset int, limit int) ([]*store.ChargeDetailRecord, error) {
+	s.Lock()
+	defer s.Unlock()
+	keys := maps.Keys(s.chargeDetailRecords)
+	sort.Strings(keys)
+
+	cdrs := make([]*store.ChargeDetailRecord, 0)
+	for i, key := range keys {
+		if i >= offset && i < offset+limit {
+			clone := *s.chargeDetailRecords[key]
+			cdrs = append(cdrs, &clone)
+		}
+	}
+	return cdrs, nil
+}
+
+func (s *Store) QueryChargeDetailRecords(_ context.Context, filter *store.ChargeDetailRecordFilter, pageSize int, previousCdrId string) ([]*store.ChargeDetailRecord, error) {
+	s.Lock()
+	defer s.Unlock()
+	keys := maps.Keys(s.chargeDetailRecords)
+	sort.Strings(keys)
+
+	cdrs := make([]*store.ChargeDetailRecord, 0)
+	for _, key := range keys {
+		if len(cdrs) >= pageSize {
+			break
+		}
+		cdr := s.chargeDetailRecords[key]
+		if key <= previousCdrId || !filter.Matches(cdr) {
+			continue
+		}
+		clone := *cdr
+		cdrs = append(cdrs, &clone)
+	}
+	return cdrs, nil
+}
+
+func (s *Store) SetTariff(_ context.Context, tariff *store.Tariff) error {
+	s.Lock()
+	defer s.Unlock()
+	clone := *tariff
+	s.tariffs[tariff.Id] = &clone
+	return nil
+}
+
+func (s *Store) LookupTariff(_ context.Context, tariffId string) (*store.Tariff, error) {
+	s.Lock()
+	defer s.Unlock()
+	tariff, ok := s.tariffs[tariffId]
+	if !ok {
+		return nil, nil
+	}
+	clone := *tariff
+	return &clone, nil
+}
+
+func (s *Store) ListTariffs(_ context.Context, offset int, limit int) ([]*store.Tariff, error) {
+	s.Lock()
+	defer s.Unlock()
+	keys := maps.Keys(s.tariffs)
+	sort.Strings(keys)
+
+	tariffs := make([]*store.Tariff, 0)
+	for i, key := range keys {
+		if i >= offset && i < offset+limit {
+			clone := *s.tariffs[key]
+			tariffs = append(tariffs, &clone)
+		}
+	}
+	return tariffs, nil
+}
+
+func (s *Store) SetReservation(_ context.Context, reservation *store.Reservation) error {
+	s.Lock()
+	defer s.Unlock()
+	var version int
+	if existing := s.reservations[reservation.ReservationId]; existing != nil {
+		version = existing.Version
+	}
+	if reservation.Version != version {
+		return store.ErrVersionConflict
+	}
+	reservation.Version++
+	s.reservations[reservation.ReservationId] = cloneReservation(reservation)
+	return nil
+}
+
+func (s *Store) LookupReservation(_ context.Context, reservationId int) (*store.Reservation, error) {
+	s.Lock()
+	defer s.Unlock()
+	reservation := s.reservations[reservationId]
+	if reservation == nil {
+		return nil, nil
+	}
+	return cloneReservation(reservation), nil
+}
+
+func cloneReservation(reservation *store.Reservation) *store.Reservation {
+	clone := *reservation
+	if reservation.EvseId != nil {
+		evseId := *reservation.EvseId
+		clone.EvseId = &evseId
+	}
+	if reservation.Transfer != nil {
+		transfer := *reservation.Transfer
+		clone.Transfer = &transfer
+	}
+	if reservation.Payment != nil {
+		payment := *reservation.Payment
+		clone.Payment = &payment
+	}
+	if reservation.Contact != nil {
+		contact := *reservation.Contact
+		clone.Contact = &contact
+	}
+	clone.Notified = slices.Clone(reservation.Notified)
+	return &clone
+}
+
+func (s *Store) ListReservations(_ context.Context, pageSize int, previousReservationId int) ([]*store.Reservation, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.reservations)
+	sort.Ints(keys)
+
+	i, found := slices.BinarySearch(keys, previousReservationId)
+	if found {
+		i++
+	}
+
+	var reservations []*store.Reservation
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		reservations = append(reservations, cloneReservation(s.reservations[k]))
+	}
+	return reservations, nil
+}
+
+func meterValuesKey(chargeStationId string, evseId int) string {
+	return fmt.Sprintf("%s:%d", chargeStationId, evseId)
+}
+
+func (s *Store) AddMeterValues(_ context.Context, chargeStationId string, evseId int, meterValues []store.MeterValue) error {
+	s.Lock()
+	defer s.Unlock()
+	key := meterValuesKey(chargeStationId, evseId)
+	evseMeterValues := s.meterValues[key]
+	if evseMeterValues == nil {
+		evseMeterValues = &store.EvseMeterValues{
+			ChargeStationId: chargeStationId,
+			EvseId:          evseId,
+		}
+		s.meterValues[key] = evseMeterValues
+	}
+	evseMeterValues.MeterValues = append(evseMeterValues.MeterValues, meterValues...)
+	store.SortMeterValues(evseMeterValues.MeterValues)
+	return nil
+}
+
+func (s *Store) LookupMeterValues(_ context.Context, chargeStationId string, evseId int) (*store.EvseMeterValues, error) {
+	s.Lock()
+	defer s.Unlock()
+	evseMeterValues := s.meterValues[meterValuesKey(chargeStationId, evseId)]
+	if evseMeterValues == nil {
+		return nil, nil
+	}
+	clone := *evseMeterValues
+	clone.MeterValues = slices.Clone(evseMeterValues.MeterValues)
+	return &clone, nil
+}
+
+func (s *Store) PurgeMeterValues(_ context.Context, before time.Time) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+	purged := 0
+	for _, evseMeterValues := range s.meterValues {
+		var count int
+		evseMeterValues.MeterValues, count = store.RetainMeterValues(evseMeterValues.MeterValues, before)
+		purged += count
+	}
+	return purged, nil
+}
+
+func (s *Store) AnonymizeTransactions(_ context.Context, before time.Time) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+	anonymized := 0
+	for _, transaction := range s.transactions {
+		if transaction.Anonymize(before) {
+			transaction.Version++
+			anonymized++
+		}
+	}
+	return anonymized, nil
+}
+
+func (s *Store) FleetStats(_ context.Context, since, now time.Time) (*store.FleetStats, error) {
+	s.Lock()
+	defer s.Unlock()
+	stats := &store.FleetStats{}
+	for _, liveness := range s.chargeStationLiveness {
+		if liveness.Offline {
+			stats.ChargeStationsOffline++
+		} else {
+			stats.ChargeStationsOnline++
+		}
+	}
+	for _, statuses := range s.connectorStatuses {
+		for _, status := range statuses {
+			if status.Status == store.ConnectorStatusFaulted {
+				stats.FaultedConnectors++
+			}
+		}
+	}
+	for _, transaction := range s.transactions {
+		stats.AddTransaction(transaction, since)
+	}
+	for _, reservation := range s.reservations {
+		stats.AddReservation(reservation, now)
+	}
+	return stats, nil
+}
+
+func exiResponseChunksKey(chargeStationId, exiRequestHash string) string {
+	return fmt.Sprintf("%s:%s", chargeStationId, exiRequestHash)
+}
+
+func (s *Store) SetExiResponseChunks(_ context.Context, chunks *store.ExiResponseChunks) error {
+	s.Lock()
+	defer s.Unlock()
+	s.exiResponseChunks[exiResponseChunksKey(chunks.ChargeStationId, chunks.ExiRequestHash)] = chunks
+	return nil
+}
+
+func (s *Store) LookupExiResponseChunks(_ context.Context, chargeStationId, exiRequestHash string) (*store.ExiResponseChunks, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.exiResponseChunks[exiResponseChunksKey(chargeStationId, exiRequestHash)], nil
+}
+
+func (s *Store) DeleteExiResponseChunks(_ context.Context, chargeStationId, exiRequestHash string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.exiResponseChunks, exiResponseChunksKey(chargeStationId, exiRequestHash))
+	return nil
+}
+
+func (s *Store) SetOcspResponse(_ context.Context, response *store.OcspResponse) error {
+	s.Lock()
+	defer s.Unlock()
+	s.ocspResponses[response.Key] = response
+	return nil
+}
+
+func (s *Store) LookupOcspResponse(_ context.Context, key string) (*store.OcspResponse, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.ocspResponses[key], nil
+}
+
+func (s *Store) ListOcspResponses(_ context.Context, pageSize int, previousKey string) ([]*store.OcspResponse, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.ocspResponses)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousKey)
+	if found {
+		i++
+	}
+
+	var responses []*store.OcspResponse
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		responses = append(responses, s.ocspResponses[k])
+	}
+	return responses, nil
+}
+
+func (s *Store) SetOutboundCallQueue(_ context.Context, queue *store.OutboundCallQueue) error {
+	s.Lock()
+	defer s.Unlock()
+	var version int
+	if existing := s.outboundCallQueues[queue.ChargeStationId]; existing != nil {
+		version = existing.Version
+	}
+	if queue.Version != version {
+		return store.ErrVersionConflict
+	}
+	queue.Version++
+	s.outboundCallQueues[queue.ChargeStationId] = cloneOutboundCallQueue(queue)
+	return nil
+}
+
+func (s *Store) LookupOutboundCallQueue(_ context.Context, chargeStationId string) (*store.OutboundCallQueue, error) {
+	s.Lock()
+	defer s.Unlock()
+	return cloneOutboundCallQueue(s.outboundCallQueues[chargeStationId]), nil
+}
+
+func (s *Store) DeleteOutboundCallQueue(_ context.Context, chargeStationId string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.outboundCallQueues, chargeStationId)
+	return nil
+}
+
+// cloneOutboundCallQueue copies the queue so that changes made by the caller are only
+// stored when the queue is set
+func cloneOutboundCallQueue(queue *store.OutboundCallQueue) *store.OutboundCallQueue {
+	if queue == nil {
+		return nil
+	}
+	clone := *queue
+	if queue.InFlight != nil {
+		inFlight := *queue.InFlight
+		clone.InFlight = &inFlight
+	}
+	clone.Queued = make([]*store.OutboundCall, len(queue.Queued))
+	for i, call := range queue.Queued {
+		queued := *call
+		clone.Queued[i] = &queued
+	}
+	return &clone
+}
+
+func (s *Store) SetCommandAuditRecord(_ context.Context, record *store.CommandAuditRecord) error {
+	s.Lock()
+	defer s.Unlock()
+	clone := *record
+	s.commandAuditRecords[record.Id] = &clone
+	return nil
+}
+
+func (s *Store) LookupCommandAuditRecord(_ context.Context, id string) (*store.CommandAuditRecord, error) {
+	s.Lock()
+	defer s.Unlock()
+	record, ok := s.commandAuditRecords[id]
+	if !ok {
+		return nil, nil
+	}
+	clone := *record
+	return &clone, nil
+}
+
+func (s *Store) QueryCommandAuditRecords(_ context.Context, filter *store.CommandAuditFilter, offset, limit int) ([]*store.CommandAuditRecord, error) {
+	s.Lock()
+	defer s.Unlock()
+	keys := maps.Keys(s.commandAuditRecords)
+	sort.Strings(keys)
+
+	records := make([]*store.CommandAuditRecord, 0)
+	matched := 0
+	for _, key := range keys {
+		record := s.commandAuditRecords[key]
+		if !filter.Matches(record) {
+			continue
+		}
+		if matched >= offset && matched < offset+limit {
+			clone := *record
+			records = append(records, &clone)
+		}
+		matched++
+	}
+	return records, nil
+}
+
+func (s *Store) SetBulkCommand(_ context.Context, bulkCommand *store.BulkCommand) error {
+	s.Lock()
+	defer s.Unlock()
+	var version int
+	if existing := s.bulkCommands[bulkCommand.Id]; existing != nil {
+		version = existing.Version
+	}
+	if bulkCommand.Version != version {
+		return store.ErrVersionConflict
+	}
+	bulkCommand.Version++
+	s.bulkCommands[bulkCommand.Id] = cloneBulkCommand(bulkCommand)
+	return nil
+}
+
+func (s *Store) LookupBulkCommand(_ context.Context, id string) (*store.BulkCommand, error) {
+	s.Lock()
+	defer s.Unlock()
+	bulkCommand := s.bulkCommands[id]
+	if bulkCommand == nil {
+		return nil, nil
+	}
+	return cloneBulkCommand(bulkCommand), nil
+}
+
+func (s *Store) ListBulkCommands(_ context.Context, pageSize int, previousId string) ([]*store.BulkCommand, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.bulkCommands)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousId)
+	if found {
+		i++
+	}
+
+	var bulkCommands []*store.BulkCommand
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		bulkCommands = append(bulkCommands, cloneBulkCommand(s.bulkCommands[k]))
+	}
+	return bulkCommands, nil
+}
+
+func cloneBulkCommand(bulkCommand *store.BulkCommand) *store.BulkCommand {
+	clone := *bulkCommand
+	clone.Selector.Tags = slices.Clone(bulkCommand.Selector.Tags)
+	clone.Results = make([]*store.BulkCommandResult, len(bulkCommand.Results))
+	for i, result := range bulkCommand.Results {
+		resultClone := *result
+		clone.Results[i] = &resultClone
+	}
+	return &clone
+}
+
+func (s *Store) SetSite(_ context.Context, site *store.Site) error {
+	s.Lock()
+	defer s.Unlock()
+	clone := *site
+	s.sites[site.Id] = &clone
+	return nil
+}
+
+func (s *Store) LookupSite(_ context.Context, siteId string) (*store.Site, error) {
+	s.Lock()
+	defer s.Unlock()
+	site, ok := s.sites[siteId]
+	if !ok {
+		return nil, nil
+	}
+	clone := *site
+	return &clone, nil
+}
+
+func (s *Store) ListSites(_ context.Context, offset int, limit int) ([]*store.Site, error) {
+	s.Lock()
+	defer s.Unlock()
+	keys := maps.Keys(s.sites)
+	sort.Strings(keys)
+
+	sites := make([]*store.Site, 0)
+	for i, key := range keys {
+		if i >= offset && i < offset+limit {
+			clone := *s.sites[key]
+			sites = append(sites, &clone)
+		}
+	}
+	return sites, nil
+}
+
+func (s *Store) DeleteSite(_ context.Context, siteId string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.sites, siteId)
+	return nil
+}
+
+func (s *Store) SetTag(_ context.Context, tag *store.Tag) error {
+	s.Lock()
+	defer s.Unlock()
+	clone := *tag
+	s.tags[tag.Name] = &clone
+	return nil
+}
+
+func (s *Store) LookupTag(_ context.Context, name string) (*store.Tag, error) {
+	s.Lock()
+	defer s.Unlock()
+	tag, ok := s.tags[name]
+	if !ok {
+		return nil, nil
+	}
+	clone := *tag
+	return &clone, nil
+}
+
+func (s *Store) ListTags(_ context.Context, offset int, limit int) ([]*store.Tag, error) {
+	s.Lock()
+	defer s.Unlock()
+	keys := maps.Keys(s.tags)
+	sort.Strings(keys)
+
+	tags := make([]*store.Tag, 0)
+	for i, key := range keys {
+		if i >= offset && i < offset+limit {
+			clone := *s.tags[key]
+			tags = append(tags, &clone)
+		}
+	}
+	return tags, nil
+}
+
+func (s *Store) ListTenantChargeStations(_ context.Context, tenantId string, offset, limit int) ([]*store.ChargeStation, error) {
+	s.Lock()
+	defer s.Unlock()
+	return tenantPage(s.chargeStations, func(chargeStation *store.ChargeStation) *store.ChargeStation {
+		if chargeStation.TenantId != tenantId {
+			return nil
+		}
+		return cloneChargeStation(chargeStation)
+	}, offset, limit), nil
+}
+
+func (s *Store) ListTenantTokens(_ context.Context, tenantId string, offset, limit int) ([]*store.Token, error) {
+	s.Lock()
+	defer s.Unlock()
+	return tenantPage(s.tokens, func(token *store.Token) *store.Token {
+		if token.TenantId != tenantId {
+			return nil
+		}
+		return cloneToken(token)
+	}, offset, limit), nil
+}
+
+func (s *Store) ListTenantSites(_ context.Context, tenantId string, offset, limit int) ([]*store.Site, error) {
+	s.Lock()
+	defer s.Unlock()
+	return tenantPage(s.sites, func(site *store.Site) *store.Site {
+		if site.TenantId != tenantId {
+			return nil
+		}
+		clone := *site
+		return &clone
+	}, offset, limit), nil
+}
+
+func (s *Store) ListTenantTags(_ context.Context, tenantId string, offset, limit int) ([]*store.Tag, error) {
+	s.Lock()
+	defer s.Unlock()
+	return tenantPage(s.tags, func(tag *store.Tag) *store.Tag {
+		if tag.TenantId != tenantId {
+			return nil
+		}
+		clone := *tag
+		return &clone
+	}, offset, limit), nil
+}
+
+// tenantPage returns the page of values, ordered by key, starting at offset that are
+// selected by the function, which returns a copy of each value in the tenant and nil
+// for the others
+func tenantPage[T any](values map[string]*T, selected func(*T) *T, offset, limit int) []*T {
+	keys := maps.Keys(values)
+	sort.Strings(keys)
+
+	page := make([]*T, 0)
+	matched := 0
+	for _, key := range keys {
+		if len(page) >= limit {
+			break
+		}
+		value := selected(values[key])
+		if value == nil {
+			continue
+		}
+		if matched >= offset {
+			page = append(page, value)
+		}
+		matched++
+	}
+	return page
+}
+
+func (s *Store) DeleteTag(_ context.Context, name string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.tags, name)
+	return nil
+}
+
+func (s *Store) SetCommandSchedule(_ context.Context, schedule *store.CommandSchedule) error {
+	s.Lock()
+	defer s.Unlock()
+	var version int
+	if existing := s.commandSchedules[schedule.Id]; existing != nil {
+		version = existing.Version
+	}
+	if schedule.Version != version {
+		return store.ErrVersionConflict
+	}
+	schedule.Version++
+	s.commandSchedules[schedule.Id] = cloneCommandSchedule(schedule)
+	return nil
+}
+
+func (s *Store) LookupCommandSchedule(_ context.Context, id string) (*store.CommandSchedule, error) {
+	s.Lock()
+	defer s.Unlock()
+	schedule := s.commandSchedules[id]
+	if schedule == nil {
+		return nil, nil
+	}
+	return cloneCommandSchedule(schedule), nil
+}
+
+func (s *Store) ListCommandSchedules(_ context.Context, pageSize int, previousId string) ([]*store.CommandSchedule, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := maps.Keys(s.commandSchedules)
+	sort.Strings(keys)
+
+	i, found := slices.BinarySearch(keys, previousId)
+	if found {
+		i++
+	}
+
+	var schedules []*store.CommandSchedule
+	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
+	for _, k := range keys[i:max] {
+		schedules = append(schedules, cloneCommandSchedule(s.commandSchedules[k]))
+	}
+	return schedules, nil
+}
+
+func (s *Store) DeleteCommandSchedule(_ context.Context, id string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.commandSchedules, id)
+	return nil
+}
+
+func cloneCommandSchedule(schedule *store.CommandSchedule) *store.CommandSchedule {
+	clone := *schedule
+	clone.Command.Selector.Tags = slices.Clone(schedule.Command.Selector.Tags)
+	return &clone
+}
+
+func (s *Store) SetLease(_ context.Context, lease *store.Lease) error {
+	s.Lock()
+	defer s.Unlock()
+	var version int
+	if existing := s.leases[lease.Name]; existing != nil {
+		version = existing.Version
+	}
+	if lease.Version != version {
+		return store.ErrVersionConflict
+	}
+	lease.Version++
+	clone := *lease
+	s.leases[lease.Name] = &clone
+	return nil
+}
+
+func (s *Store) LookupLease(_ context.Context, name string) (*store.Lease, error) {
+	s.Lock()
+	defer s.Unlock()
+	lease := s.leases[name]
+	if lease == nil {
+		return nil, nil
+	}
+	clone := *lease
+	return &clone, nil
+}
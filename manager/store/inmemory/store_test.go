@@ -15,6 +15,84 @@ import (
 	"time"
 )
 
+func TestSetLookupAndDeleteChargeStation(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	want := &store.ChargeStation{
+		ChargeStationId: "cs001",
+		SecurityProfile: store.TLSWithClientSideCertificates,
+		OcppVersion:     "2.0.1",
+		Vendor:          "Acme",
+		Model:           "Charger 1",
+		SiteId:          "site001",
+		Coordinates: &store.GeoLocation{
+			Latitude:  "51.5072",
+			Longitude: "-0.1276",
+		},
+		LastBoot: time.Now(),
+	}
+
+	err := engine.SetChargeStation(context.Background(), "cs001", want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	err = engine.DeleteChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+
+	got, err = engine.LookupChargeStation(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeStations(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for i := 0; i < 25; i++ {
+		err := engine.SetChargeStation(context.Background(), fmt.Sprintf("cs%03d", i), &store.ChargeStation{
+			OcppVersion: "1.6",
+		})
+		require.NoError(t, err)
+	}
+
+	page1, err := engine.ListChargeStations(context.Background(), 0, 20)
+	require.NoError(t, err)
+	require.Len(t, page1, 20)
+	assert.Equal(t, "cs000", page1[0].ChargeStationId)
+
+	page2, err := engine.ListChargeStations(context.Background(), 20, 20)
+	require.NoError(t, err)
+	require.Len(t, page2, 5)
+	assert.Equal(t, "cs020", page2[0].ChargeStationId)
+}
+
+func TestListChargeStationsNearby(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for id, coordinates := range map[string]*store.GeoLocation{
+		"cs001": {Latitude: "51.5080", Longitude: "-0.1281"},
+		"cs002": {Latitude: "51.5074", Longitude: "-0.1278"},
+		"cs003": {Latitude: "48.8566", Longitude: "2.3522"},
+		"cs004": nil,
+	} {
+		err := engine.SetChargeStation(ctx, id, &store.ChargeStation{Coordinates: coordinates})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationsNearby(ctx, 51.5074, -0.1278, 1000)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs002", got[0].ChargeStationId)
+	assert.Equal(t, "cs001", got[1].ChargeStationId)
+
+	got, err = engine.ListChargeStationsNearby(ctx, 0, 0, 1000)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
 func TestUpdateChargeStationSettingsWithNewSettings(t *testing.T) {
 	now := time.Now()
 	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(now))
@@ -117,6 +195,29 @@ func TestListChargeStationSettingsReturnsDataInPages(t *testing.T) {
 	assert.Len(t, csIds, 25)
 }
 
+func TestListChargeStationRuntimeDetails(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for i := 0; i < 15; i++ {
+		csId := fmt.Sprintf("cs%03d", i)
+		err := engine.SetChargeStationRuntimeDetails(context.Background(), csId, &store.ChargeStationRuntimeDetails{
+			OcppVersion: "2.0.1",
+		})
+		require.NoError(t, err)
+	}
+
+	page1, err := engine.ListChargeStationRuntimeDetails(context.Background(), 10, "")
+	require.NoError(t, err)
+	require.Len(t, page1, 10)
+	assert.Equal(t, "cs000", page1[0].ChargeStationId)
+	assert.Equal(t, "2.0.1", page1[0].OcppVersion)
+
+	page2, err := engine.ListChargeStationRuntimeDetails(context.Background(), 10, page1[len(page1)-1].ChargeStationId)
+	require.NoError(t, err)
+	require.Len(t, page2, 5)
+	assert.Equal(t, "cs010", page2[0].ChargeStationId)
+}
+
 func TestUpdateChargeStationInstallCertificates(t *testing.T) {
 	now := time.Now()
 	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(now))
@@ -142,6 +243,204 @@ func TestUpdateChargeStationInstallCertificates(t *testing.T) {
 	assert.Equal(t, time.Time{}, got.Certificates[0].SendAfter)
 }
 
+func TestSetAndLookupChargeStationInstalledCertificates(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	want := &store.ChargeStationInstalledCertificates{
+		ChargeStationId: "cs001",
+		Certificates: []*store.ChargeStationInstalledCertificate{
+			{
+				CertificateType: store.CertificateTypeV2G,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "name-hash",
+				IssuerKeyHash:   "key-hash",
+				SerialNumber:    "0123",
+				Status:          store.InstalledCertificateStatusInstalled,
+			},
+		},
+		RefreshStatus: store.InstalledCertificatesRefreshAccepted,
+	}
+
+	err := engine.SetChargeStationInstalledCertificates(context.Background(), "cs001", want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationInstalledCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	err = engine.SetChargeStationInstalledCertificates(context.Background(), "cs001", &store.ChargeStationInstalledCertificates{
+		RefreshStatus: store.InstalledCertificatesRefreshPending,
+	})
+	require.NoError(t, err)
+
+	got, err = engine.LookupChargeStationInstalledCertificates(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Empty(t, got.Certificates)
+	assert.Equal(t, store.InstalledCertificatesRefreshPending, got.RefreshStatus)
+}
+
+func TestSetAndLookupChargeStationProvisioning(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	want := &store.ChargeStationProvisioning{
+		ChargeStationId: "cs001",
+		Status:          store.ProvisioningStatusPending,
+		Step:            2,
+		SendAfter:       time.Now(),
+	}
+
+	err := engine.SetChargeStationProvisioning(context.Background(), "cs001", want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationProvisioning(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = engine.LookupChargeStationProvisioning(context.Background(), "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeStationProvisioning(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for i := 0; i < 25; i++ {
+		err := engine.SetChargeStationProvisioning(context.Background(), fmt.Sprintf("cs%03d", i), &store.ChargeStationProvisioning{
+			Status: store.ProvisioningStatusPending,
+		})
+		require.NoError(t, err)
+	}
+
+	page1, err := engine.ListChargeStationProvisioning(context.Background(), 20, "")
+	require.NoError(t, err)
+	require.Len(t, page1, 20)
+	assert.Equal(t, "cs000", page1[0].ChargeStationId)
+
+	page2, err := engine.ListChargeStationProvisioning(context.Background(), 20, page1[19].ChargeStationId)
+	require.NoError(t, err)
+	require.Len(t, page2, 5)
+	assert.Equal(t, "cs020", page2[0].ChargeStationId)
+}
+
+func TestSetAndLookupChargeStationRegistration(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	want := &store.ChargeStationRegistration{
+		ChargeStationId: "cs001",
+		Status:          store.RegistrationStatusPending,
+	}
+
+	err := engine.SetChargeStationRegistration(context.Background(), "cs001", want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationRegistration(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = engine.LookupChargeStationRegistration(context.Background(), "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetAndLookupChargeStationLiveness(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	now := time.Now()
+	want := &store.ChargeStationLiveness{
+		ChargeStationId: "cs001",
+		LastSeen:        now,
+		LastHeartbeat:   now.Add(-time.Minute),
+	}
+
+	err := engine.SetChargeStationLiveness(context.Background(), "cs001", want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLiveness(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = engine.LookupChargeStationLiveness(context.Background(), "cs002")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListChargeStationLiveness(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	for i := 0; i < 25; i++ {
+		err := engine.SetChargeStationLiveness(context.Background(), fmt.Sprintf("cs%03d", i), &store.ChargeStationLiveness{
+			LastSeen: time.Now(),
+		})
+		require.NoError(t, err)
+	}
+
+	page1, err := engine.ListChargeStationLiveness(context.Background(), 20, "")
+	require.NoError(t, err)
+	require.Len(t, page1, 20)
+	assert.Equal(t, "cs000", page1[0].ChargeStationId)
+
+	page2, err := engine.ListChargeStationLiveness(context.Background(), 20, page1[19].ChargeStationId)
+	require.NoError(t, err)
+	require.Len(t, page2, 5)
+	assert.Equal(t, "cs020", page2[0].ChargeStationId)
+}
+
+func TestSetAndListConnectorStatuses(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	now := time.Now()
+	statuses := []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 2, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now.Add(time.Minute)},
+		{ChargeStationId: "cs002", EvseId: 1, ConnectorId: 1, Status: "Faulted", LastUpdated: now},
+	}
+	for _, status := range statuses {
+		err := engine.SetConnectorStatus(context.Background(), status)
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListConnectorStatuses(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ConnectorStatus{statuses[2], statuses[0]}, got)
+
+	got, err = engine.ListConnectorStatuses(context.Background(), "cs003")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestSetLookupAndListEvses(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	now := time.Now()
+	evses := []*store.ChargeStationEvse{
+		{ChargeStationId: "cs001", EvseId: 2, Connectors: []store.ChargeStationConnector{{ConnectorId: 1}}, LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, Connectors: []store.ChargeStationConnector{{ConnectorId: 1}}, LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, Connectors: []store.ChargeStationConnector{
+			{ConnectorId: 1, ConnectorType: "cType2", MaxPower: 22000, PhysicalLabel: "A"},
+		}, LastUpdated: now.Add(time.Minute)},
+		{ChargeStationId: "cs002", EvseId: 1, LastUpdated: now},
+	}
+	for _, evse := range evses {
+		err := engine.SetEvse(context.Background(), evse)
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListEvses(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationEvse{evses[2], evses[0]}, got)
+
+	// the store holds a copy of the EVSE
+	got[0].Connectors[0].PhysicalLabel = "B"
+	evse, err := engine.LookupEvse(context.Background(), "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, evses[2], evse)
+
+	evse, err = engine.LookupEvse(context.Background(), "cs001", 3)
+	require.NoError(t, err)
+	assert.Nil(t, evse)
+}
+
 func TestUpdateChargeStationCertificateWithExistingCertificate(t *testing.T) {
 	engine := inmemory.NewStore(clock.RealClock{})
 
@@ -214,3 +513,19 @@ func TestUpdateChargeStationCertificateWithNewCertificate(t *testing.T) {
 	assert.Equal(t, "evcc-pem-data", got.Certificates[1].CertificateData)
 	assert.Equal(t, store.CertificateInstallationPending, got.Certificates[1].CertificateInstallationStatus)
 }
+
+func TestSetChargeStationWithStaleVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{SiteId: "site001"})
+	require.NoError(t, err)
+
+	err = engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{SiteId: "site002"})
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+	got, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "site001", got.SiteId)
+	assert.Equal(t, 1, got.Version)
+}
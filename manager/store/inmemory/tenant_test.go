@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory_test
+
+// Test for the tenant queries in the store package
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestListTenantChargeStations(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	// more charge stations than fit in a page of the registry
+	for i := 0; i < 250; i++ {
+		tenant := "tenant-a"
+		if i%2 == 1 {
+			tenant = "tenant-b"
+		}
+		err := engine.SetChargeStation(ctx, fmt.Sprintf("cs%03d", i), &store.ChargeStation{TenantId: tenant})
+		require.NoError(t, err)
+	}
+
+	chargeStations, err := store.ListTenantChargeStations(ctx, engine, "tenant-b", 60, 10)
+	require.NoError(t, err)
+	require.Len(t, chargeStations, 10)
+	assert.Equal(t, "cs121", chargeStations[0].ChargeStationId)
+	assert.Equal(t, "cs139", chargeStations[9].ChargeStationId)
+
+	ids, err := store.TenantChargeStationIds(ctx, engine, "tenant-a")
+	require.NoError(t, err)
+	assert.Len(t, ids, 125)
+	assert.Equal(t, "cs000", ids[0])
+
+	ids, err = store.TenantChargeStationIds(ctx, engine, "tenant-c")
+	require.NoError(t, err)
+	assert.NotNil(t, ids)
+	assert.Empty(t, ids)
+}
+
+// chargeStationRegistry hides the engine's TenantStore methods, so the charge stations
+// are selected by reading the registry
+type chargeStationRegistry struct {
+	store.ChargeStationStore
+}
+
+func TestListTenantChargeStationsFromRegistry(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	for i := 0; i < 250; i++ {
+		tenant := "tenant-a"
+		if i%2 == 1 {
+			tenant = "tenant-b"
+		}
+		err := engine.SetChargeStation(ctx, fmt.Sprintf("cs%03d", i), &store.ChargeStation{TenantId: tenant})
+		require.NoError(t, err)
+	}
+
+	chargeStations, err := store.ListTenantChargeStations(ctx, chargeStationRegistry{engine}, "tenant-b", 60, 10)
+	require.NoError(t, err)
+	require.Len(t, chargeStations, 10)
+	assert.Equal(t, "cs121", chargeStations[0].ChargeStationId)
+	assert.Equal(t, "cs139", chargeStations[9].ChargeStationId)
+}
+
+func TestListTenantTokens(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	require.NoError(t, engine.SetToken(ctx, &store.Token{Uid: "AAA", TenantId: "tenant-a"}))
+	require.NoError(t, engine.SetToken(ctx, &store.Token{Uid: "BBB"}))
+	require.NoError(t, engine.SetToken(ctx, &store.Token{Uid: "CCC", TenantId: "tenant-a"}))
+
+	tokens, err := store.ListTenantTokens(ctx, engine, "tenant-a", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "AAA", tokens[0].Uid)
+	assert.Equal(t, "CCC", tokens[1].Uid)
+}
+
+func TestQueryTransactionsForChargeStations(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	for _, csId := range []string{"cs001", "cs002", "cs003"} {
+		err := engine.CreateTransaction(ctx, csId, "tx-"+csId, "TOKEN", "ISO14443", nil, 0, false)
+		require.NoError(t, err)
+	}
+
+	transactions, err := engine.QueryTransactions(ctx, &store.TransactionFilter{
+		ChargeStationIds: []string{"cs001", "cs003"},
+	}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+	assert.Equal(t, "cs001", transactions[0].ChargeStationId)
+	assert.Equal(t, "cs003", transactions[1].ChargeStationId)
+
+	transactions, err = engine.QueryTransactions(ctx, &store.TransactionFilter{
+		ChargeStationIds: []string{},
+	}, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, transactions)
+}
@@ -67,6 +67,7 @@ func TestCreateAndFindTransaction(t *testing.T) {
 		TokenType:       tokenType,
 		MeterValues:     meterValues,
 		StartSeqNo:      0,
+		Version:         1,
 	}
 
 	assert.Equal(t, want, got)
@@ -97,6 +98,7 @@ func TestCreateTransactionWithExistingTransaction(t *testing.T) {
 		TokenType:       tokenType,
 		MeterValues:     append(meterValues1, meterValues2...),
 		StartSeqNo:      0,
+		Version:         2,
 	}
 
 	assert.Equal(t, want, got)
@@ -127,6 +129,7 @@ func TestTransactionStoreUpdateCreatedTransaction(t *testing.T) {
 		TokenType:         tokenType,
 		MeterValues:       append(meterValues1, meterValues2...),
 		UpdatedSeqNoCount: 1,
+		Version:           2,
 	}
 
 	assert.Equal(t, want, got)
@@ -162,6 +165,7 @@ func TestTransactionStoreEndTransaction(t *testing.T) {
 		EndedSeqNo:        2,
 		UpdatedSeqNoCount: 1,
 		Offline:           false,
+		Version:           3,
 	}
 
 	assert.Equal(t, want, got)
@@ -189,7 +193,185 @@ func TestTransactionStoreEndNonExistingTransaction(t *testing.T) {
 		EndedSeqNo:        2,
 		UpdatedSeqNoCount: 0,
 		Offline:           false,
+		Version:           1,
 	}
 
 	assert.Equal(t, want, got)
 }
+
+func TestTransactionStoreOrdersMeterValuesReceivedOutOfOrder(t *testing.T) {
+	ctx := context.Background()
+
+	transactionStore := inmemory.NewStore(clock.RealClock{})
+
+	now := time.Now()
+	meterValues1 := NewMeterValues(100)
+	meterValues1[0].Timestamp = now.Add(-2 * time.Minute).Format(time.RFC3339)
+	meterValues2 := NewMeterValues(200)
+	meterValues2[0].Timestamp = now.Add(-time.Minute).Format(time.RFC3339)
+	meterValues3 := NewMeterValues(300)
+	meterValues3[0].Timestamp = now.Format(time.RFC3339)
+
+	err := transactionStore.EndTransaction(ctx, "cs006", "1234", idToken, tokenType, meterValues3, 2)
+	assert.NoError(t, err)
+	err = transactionStore.UpdateTransaction(ctx, "cs006", "1234", meterValues2)
+	assert.NoError(t, err)
+	err = transactionStore.CreateTransaction(ctx, "cs006", "1234", idToken, tokenType, meterValues1, 0, true)
+	assert.NoError(t, err)
+
+	got, err := transactionStore.FindTransaction(ctx, "cs006", "1234")
+	assert.NoError(t, err)
+
+	assert.Equal(t, append(meterValues1, append(meterValues2, meterValues3...)...), got.MeterValues)
+	assert.Equal(t, 2, got.EndedSeqNo)
+	assert.True(t, got.Offline)
+}
+
+func TestTransactionStoreMarkTransactionRecoveredFromOffline(t *testing.T) {
+	ctx := context.Background()
+
+	transactionStore := inmemory.NewStore(clock.RealClock{})
+
+	err := transactionStore.CreateTransaction(ctx, "cs007", "1234", idToken, tokenType, NewMeterValues(100), 0, true)
+	assert.NoError(t, err)
+
+	err = transactionStore.MarkTransactionRecoveredFromOffline(ctx, "cs007", "1234")
+	assert.NoError(t, err)
+
+	got, err := transactionStore.FindTransaction(ctx, "cs007", "1234")
+	assert.NoError(t, err)
+	assert.True(t, got.RecoveredFromOffline)
+}
+
+func TestTransactionStoreMarkNonExistingTransactionRecoveredFromOffline(t *testing.T) {
+	ctx := context.Background()
+
+	transactionStore := inmemory.NewStore(clock.RealClock{})
+
+	err := transactionStore.MarkTransactionRecoveredFromOffline(ctx, "cs008", "1234")
+	assert.Error(t, err)
+}
+
+func TestTransactionStoreAddTransactionChargingState(t *testing.T) {
+	ctx := context.Background()
+
+	transactionStore := inmemory.NewStore(clock.RealClock{})
+
+	err := transactionStore.CreateTransaction(ctx, "cs009", "1234", idToken, tokenType, NewMeterValues(100), 0, false)
+	assert.NoError(t, err)
+
+	err = transactionStore.AddTransactionChargingState(ctx, "cs009", "1234", store.ChargingStateChange{State: "SuspendedEV", Timestamp: "2023-05-05T13:00:00Z"})
+	assert.NoError(t, err)
+	err = transactionStore.AddTransactionChargingState(ctx, "cs009", "1234", store.ChargingStateChange{State: "Charging", Timestamp: "2023-05-05T12:00:00Z"})
+	assert.NoError(t, err)
+
+	got, err := transactionStore.FindTransaction(ctx, "cs009", "1234")
+	assert.NoError(t, err)
+
+	want := []store.ChargingStateChange{
+		{State: "Charging", Timestamp: "2023-05-05T12:00:00Z"},
+		{State: "SuspendedEV", Timestamp: "2023-05-05T13:00:00Z"},
+	}
+	assert.Equal(t, want, got.ChargingStates)
+}
+
+func TestTransactionStoreSetTransactionCost(t *testing.T) {
+	ctx := context.Background()
+
+	transactionStore := inmemory.NewStore(clock.RealClock{})
+
+	err := transactionStore.CreateTransaction(ctx, "cs010", "1234", idToken, tokenType, NewMeterValues(100), 0, false)
+	assert.NoError(t, err)
+
+	cost := &store.CostBreakdown{
+		TariffId:   "tariff001",
+		Currency:   "GBP",
+		EnergyCost: 2.5,
+		TotalCost:  2.5,
+		Periods: []store.CostPeriod{{
+			StartTime:   "2023-05-05T12:00:00Z",
+			EndTime:     "2023-05-05T13:00:00Z",
+			EnergyKwh:   10,
+			EnergyPrice: 0.25,
+			Cost:        2.5,
+		}},
+	}
+	err = transactionStore.SetTransactionCost(ctx, "cs010", "1234", cost)
+	assert.NoError(t, err)
+
+	got, err := transactionStore.FindTransaction(ctx, "cs010", "1234")
+	assert.NoError(t, err)
+	assert.Equal(t, cost, got.Cost)
+	assert.Equal(t, 2, got.Version)
+
+	err = transactionStore.SetTransactionCost(ctx, "cs010", "5678", cost)
+	assert.Error(t, err)
+}
+
+func TestTransactionStoreQueryTransactions(t *testing.T) {
+	ctx := context.Background()
+
+	transactionStore := inmemory.NewStore(clock.RealClock{})
+
+	meterValuesAt := func(timestamp string) []store.MeterValue {
+		return []store.MeterValue{{Timestamp: timestamp}}
+	}
+	err := transactionStore.CreateTransaction(ctx, "cs001", "1234", idToken, tokenType, meterValuesAt("2023-06-01T12:00:00Z"), 0, false)
+	assert.NoError(t, err)
+	err = transactionStore.EndTransaction(ctx, "cs001", "1234", idToken, tokenType, nil, 1)
+	assert.NoError(t, err)
+	err = transactionStore.CreateTransaction(ctx, "cs001", "1235", idToken, tokenType, meterValuesAt("2023-06-02T12:00:00Z"), 0, false)
+	assert.NoError(t, err)
+	err = transactionStore.CreateTransaction(ctx, "cs001", "1236", "OTHERRFID", tokenType, meterValuesAt("2023-06-01T12:00:00Z"), 0, false)
+	assert.NoError(t, err)
+	err = transactionStore.CreateTransaction(ctx, "cs002", "1237", idToken, tokenType, meterValuesAt("2023-06-01T12:00:00Z"), 0, false)
+	assert.NoError(t, err)
+
+	transactionIds := func(transactions []*store.Transaction) []string {
+		ids := make([]string, len(transactions))
+		for i, transaction := range transactions {
+			ids[i] = transaction.TransactionId
+		}
+		return ids
+	}
+
+	got, err := transactionStore.QueryTransactions(ctx, &store.TransactionFilter{
+		ChargeStationId: "cs001",
+		IdToken:         idToken,
+	}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1234", "1235"}, transactionIds(got))
+
+	got, err = transactionStore.QueryTransactions(ctx, &store.TransactionFilter{
+		StartedFrom:   makePtr(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)),
+		StartedBefore: makePtr(time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)),
+		Status:        store.TransactionStatusActive,
+	}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1236", "1237"}, transactionIds(got))
+
+	got, err = transactionStore.QueryTransactions(ctx, nil, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1235", "1236"}, transactionIds(got))
+
+	got, err = transactionStore.QueryTransactionsAfter(ctx, &store.TransactionFilter{
+		ChargeStationIds: []string{"cs001", "cs002"},
+		StartedFrom:      makePtr(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)),
+		StartedBefore:    makePtr(time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)),
+	}, 2, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1234", "1236"}, transactionIds(got))
+	got, err = transactionStore.QueryTransactionsAfter(ctx, &store.TransactionFilter{
+		ChargeStationIds: []string{"cs001", "cs002"},
+		StartedFrom:      makePtr(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)),
+		StartedBefore:    makePtr(time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)),
+	}, 2, got[1])
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1237"}, transactionIds(got))
+
+	got, err = transactionStore.QueryTransactionsAfter(ctx, &store.TransactionFilter{
+		ChargeStationIds: []string{},
+	}, 2, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
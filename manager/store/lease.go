@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Lease gives one manager instance, the Holder, ownership of a named job until the lease
+// expires. The holder renews the lease before it expires to keep it: when it stops, e.g.
+// because the instance has stopped, another instance acquires the lease once it has
+// expired. The clocks of the manager instances should agree to well within a lease's
+// duration.
+type Lease struct {
+	Name      string
+	Holder    string
+	ExpiresAt time.Time
+	// Version is incremented each time the lease is written
+	Version int
+}
+
+type LeaseStore interface {
+	// SetLease writes the lease if its Version matches the stored version (0 for a new
+	// lease) and increments the Version: otherwise it returns ErrVersionConflict
+	SetLease(ctx context.Context, lease *Lease) error
+	LookupLease(ctx context.Context, name string) (*Lease, error)
+}
+
+// AcquireLease acquires the named lease for the holder, or renews it if the holder
+// already has it, so that it expires after ttl. It returns the lease, or nil if another
+// holder has a lease that has not expired or acquired the lease at the same time.
+func AcquireLease(ctx context.Context, engine LeaseStore, name, holder string, now time.Time, ttl time.Duration) (*Lease, error) {
+	lease, err := engine.LookupLease(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if lease == nil {
+		lease = &Lease{Name: name}
+	} else if lease.Holder != holder && lease.ExpiresAt.After(now) {
+		return nil, nil
+	}
+
+	lease.Holder = holder
+	lease.ExpiresAt = now.Add(ttl)
+	err = engine.SetLease(ctx, lease)
+	if errors.Is(err, ErrVersionConflict) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+// ReleaseLease expires the named lease if the holder has it, so that another holder can
+// acquire it without waiting for it to expire
+func ReleaseLease(ctx context.Context, engine LeaseStore, name, holder string, now time.Time) error {
+	lease, err := engine.LookupLease(ctx, name)
+	if err != nil {
+		return err
+	}
+	if lease == nil || lease.Holder != holder || !lease.ExpiresAt.After(now) {
+		return nil
+	}
+	lease.ExpiresAt = now
+	err = engine.SetLease(ctx, lease)
+	if errors.Is(err, ErrVersionConflict) {
+		return nil
+	}
+	return err
+}
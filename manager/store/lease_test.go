@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestAcquireLease(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	lease, err := store.AcquireLease(ctx, engine, "sync", "manager-1", now, 30*time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, "manager-1", lease.Holder)
+	assert.Equal(t, now.Add(30*time.Second), lease.ExpiresAt)
+
+	// another holder cannot acquire the lease until it has expired
+	lease, err = store.AcquireLease(ctx, engine, "sync", "manager-2", now.Add(10*time.Second), 30*time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, lease)
+
+	// the holder renews the lease
+	lease, err = store.AcquireLease(ctx, engine, "sync", "manager-1", now.Add(10*time.Second), 30*time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, now.Add(40*time.Second), lease.ExpiresAt)
+
+	lease, err = store.AcquireLease(ctx, engine, "sync", "manager-2", now.Add(40*time.Second), 30*time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, "manager-2", lease.Holder)
+
+	// the leases are independent
+	lease, err = store.AcquireLease(ctx, engine, "retention", "manager-1", now.Add(40*time.Second), 30*time.Second)
+	require.NoError(t, err)
+	assert.NotNil(t, lease)
+}
+
+func TestReleaseLease(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	_, err := store.AcquireLease(ctx, engine, "sync", "manager-1", now, 30*time.Second)
+	require.NoError(t, err)
+
+	// only the holder releases the lease
+	require.NoError(t, store.ReleaseLease(ctx, engine, "sync", "manager-2", now))
+	lease, err := store.AcquireLease(ctx, engine, "sync", "manager-2", now.Add(time.Second), 30*time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, lease)
+
+	require.NoError(t, store.ReleaseLease(ctx, engine, "sync", "manager-1", now.Add(time.Second)))
+	lease, err = store.AcquireLease(ctx, engine, "sync", "manager-2", now.Add(time.Second), 30*time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, "manager-2", lease.Holder)
+}
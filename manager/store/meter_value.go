@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import "context"
+
+// EvseMeterValues holds the meter values that a charge station has reported for an
+// EVSE outside of a transaction. An EvseId of 0 refers to the main energy meter of
+// the charge station.
+type EvseMeterValues struct {
+	ChargeStationId string
+	EvseId          int
+	MeterValues     []MeterValue
+}
+
+type MeterValueStore interface {
+	AddMeterValues(ctx context.Context, chargeStationId string, evseId int, meterValues []MeterValue) error
+	LookupMeterValues(ctx context.Context, chargeStationId string, evseId int) (*EvseMeterValues, error)
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Checkpoint records the progress of a migration for each kind of entity
+type Checkpoint struct {
+	// Positions holds the offset or id of the last entity copied for each kind of
+	// entity that is in progress
+	Positions map[string]string `json:"positions"`
+	// Counts holds the number of entities copied for each kind of entity
+	Counts map[string]int `json:"counts"`
+	// Completed holds the kinds of entity that have been copied
+	Completed map[string]bool `json:"completed"`
+}
+
+func NewCheckpoint() *Checkpoint {
+	return &Checkpoint{
+		Positions: make(map[string]string),
+		Counts:    make(map[string]int),
+		Completed: make(map[string]bool),
+	}
+}
+
+// LoadCheckpoint reads a checkpoint from a file: a new checkpoint is returned if the
+// file does not exist
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewCheckpoint(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint %s: %w", path, err)
+	}
+	checkpoint := NewCheckpoint()
+	if err = json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("parse checkpoint %s: %w", path, err)
+	}
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint to a file, replacing the file atomically so that an
+// interrupted write does not lose the previous checkpoint
+func (c *Checkpoint) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write checkpoint %s: %w", tmp, err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("replace checkpoint %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package migrate copies the durable data held by one store.Engine to another, e.g.
+// to promote an environment or to move to a different storage implementation.
+// Entities are copied a page at a time and the progress is recorded in a Checkpoint
+// so that an interrupted migration can be resumed.
+package migrate
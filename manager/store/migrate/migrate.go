@@ -0,0 +1,381 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"sort"
+	"strconv"
+)
+
+// ocpiRoles are the roles that OCPI parties can take
+var ocpiRoles = []string{"CPO", "EMSP", "HUB", "NAP", "NSP", "OTHER", "SCSP"}
+
+// Migrator copies the durable data from one engine to another. Runtime state (connector
+// status, liveness, pending OCPI commands and EXI response chunks) is not copied as it
+// is rebuilt by the charge stations. Cached certificates, EVSE meter values, OCPI
+// registrations and OICP sessions are not copied because they cannot be listed.
+// Leases are not copied as they are acquired again by the manager instances.
+// Versions are not copied: each record is written over the version held by the target.
+type Migrator struct {
+	From       store.Engine
+	To         store.Engine
+	Checkpoint *Checkpoint
+	// Save is called after each page of entities has been copied
+	Save     func(checkpoint *Checkpoint) error
+	PageSize int
+
+	// sortedTransactions holds the transactions to copy: the engines can only list
+	// every transaction, so they are read once and copied a page at a time
+	sortedTransactions []*store.Transaction
+}
+
+// page copies the page of entities that follow position: it returns the position
+// of the last entity copied, the number of entities copied and whether all the
+// entities have been copied
+type page func(ctx context.Context, position string, pageSize int) (string, int, bool, error)
+
+type kind struct {
+	name string
+	page page
+}
+
+func (m *Migrator) kinds() []kind {
+	return []kind{
+		{"charge_stations", byOffset(m.From.ListChargeStations, func(ctx context.Context, cs *store.ChargeStation) error {
+			// the target is overwritten, so the write is made at the target's version
+			existing, err := m.To.LookupChargeStation(ctx, cs.ChargeStationId)
+			if err != nil {
+				return err
+			}
+			cs.Version = 0
+			if existing != nil {
+				cs.Version = existing.Version
+			}
+			if err = m.To.SetChargeStation(ctx, cs.ChargeStationId, cs); err != nil {
+				return err
+			}
+			return m.copyChargeStationAuth(ctx, cs.ChargeStationId)
+		})},
+		{"charge_station_runtime_details", byId(m.From.ListChargeStationRuntimeDetails,
+			func(details *store.ChargeStationRuntimeDetails) string { return details.ChargeStationId },
+			func(ctx context.Context, details *store.ChargeStationRuntimeDetails) error {
+				// charge stations that booted before the registry existed are only known
+				// by their runtime details
+				if err := m.To.SetChargeStationRuntimeDetails(ctx, details.ChargeStationId, details); err != nil {
+					return err
+				}
+				return m.copyChargeStationAuth(ctx, details.ChargeStationId)
+			})},
+		{"charge_station_settings", byId(m.From.ListChargeStationSettings,
+			func(settings *store.ChargeStationSettings) string { return settings.ChargeStationId },
+			func(ctx context.Context, settings *store.ChargeStationSettings) error {
+				return m.To.UpdateChargeStationSettings(ctx, settings.ChargeStationId, settings)
+			})},
+		{"charge_station_install_certificates", byId(m.From.ListChargeStationInstallCertificates,
+			func(certificates *store.ChargeStationInstallCertificates) string { return certificates.ChargeStationId },
+			func(ctx context.Context, certificates *store.ChargeStationInstallCertificates) error {
+				return m.To.UpdateChargeStationInstallCertificates(ctx, certificates.ChargeStationId, certificates)
+			})},
+		{"charge_station_installed_certificates", byId(m.From.ListChargeStationInstalledCertificates,
+			func(certificates *store.ChargeStationInstalledCertificates) string {
+				return certificates.ChargeStationId
+			},
+			func(ctx context.Context, certificates *store.ChargeStationInstalledCertificates) error {
+				return m.To.SetChargeStationInstalledCertificates(ctx, certificates.ChargeStationId, certificates)
+			})},
+		{"charge_station_trigger_messages", byId(m.From.ListChargeStationTriggerMessages,
+			func(trigger *store.ChargeStationTriggerMessage) string { return trigger.ChargeStationId },
+			func(ctx context.Context, trigger *store.ChargeStationTriggerMessage) error {
+				return m.To.SetChargeStationTriggerMessage(ctx, trigger.ChargeStationId, trigger)
+			})},
+		{"charge_station_provisioning", byId(m.From.ListChargeStationProvisioning,
+			func(provisioning *store.ChargeStationProvisioning) string { return provisioning.ChargeStationId },
+			func(ctx context.Context, provisioning *store.ChargeStationProvisioning) error {
+				return m.To.SetChargeStationProvisioning(ctx, provisioning.ChargeStationId, provisioning)
+			})},
+		{"tokens", byOffset(m.From.ListTokens, func(ctx context.Context, token *store.Token) error {
+			return store.ReplaceToken(ctx, m.To, token)
+		})},
+		{"transactions", m.transactions},
+		{"reservations", byId(
+			func(ctx context.Context, pageSize int, previousId string) ([]*store.Reservation, error) {
+				var previousReservationId int
+				if previousId != "" {
+					var err error
+					previousReservationId, err = strconv.Atoi(previousId)
+					if err != nil {
+						return nil, fmt.Errorf("invalid reservation position %s: %w", previousId, err)
+					}
+				}
+				return m.From.ListReservations(ctx, pageSize, previousReservationId)
+			},
+			func(reservation *store.Reservation) string { return strconv.Itoa(reservation.ReservationId) },
+			func(ctx context.Context, reservation *store.Reservation) error {
+				existing, err := m.To.LookupReservation(ctx, reservation.ReservationId)
+				if err != nil {
+					return err
+				}
+				reservation.Version = 0
+				if existing != nil {
+					reservation.Version = existing.Version
+				}
+				return m.To.SetReservation(ctx, reservation)
+			})},
+		{"tariffs", byOffset(m.From.ListTariffs, m.To.SetTariff)},
+		{"sites", byOffset(m.From.ListSites, m.To.SetSite)},
+		{"tags", byOffset(m.From.ListTags, m.To.SetTag)},
+		{"locations", byOffset(m.From.ListLocations, m.To.SetLocation)},
+		{"charge_detail_records", byOffset(m.From.ListChargeDetailRecords, m.To.SetChargeDetailRecord)},
+		{"ocpi_parties", m.ocpiParties},
+		{"ocpi_pending_pushes", byId(m.From.ListPendingPushes,
+			func(push *store.OcpiPush) string { return push.Id },
+			m.To.SetPendingPush)},
+		{"command_audit_records", byOffset(
+			func(ctx context.Context, offset, limit int) ([]*store.CommandAuditRecord, error) {
+				return m.From.QueryCommandAuditRecords(ctx, nil, offset, limit)
+			},
+			m.To.SetCommandAuditRecord)},
+		{"bulk_commands", byId(m.From.ListBulkCommands,
+			func(bulkCommand *store.BulkCommand) string { return bulkCommand.Id },
+			func(ctx context.Context, bulkCommand *store.BulkCommand) error {
+				existing, err := m.To.LookupBulkCommand(ctx, bulkCommand.Id)
+				if err != nil {
+					return err
+				}
+				bulkCommand.Version = 0
+				if existing != nil {
+					bulkCommand.Version = existing.Version
+				}
+				return m.To.SetBulkCommand(ctx, bulkCommand)
+			})},
+		{"command_schedules", byId(m.From.ListCommandSchedules,
+			func(schedule *store.CommandSchedule) string { return schedule.Id },
+			func(ctx context.Context, schedule *store.CommandSchedule) error {
+				existing, err := m.To.LookupCommandSchedule(ctx, schedule.Id)
+				if err != nil {
+					return err
+				}
+				schedule.Version = 0
+				if existing != nil {
+					schedule.Version = existing.Version
+				}
+				return m.To.SetCommandSchedule(ctx, schedule)
+			})},
+	}
+}
+
+// Run copies each kind of entity in turn, skipping those that the checkpoint records
+// as completed and resuming the one in progress
+func (m *Migrator) Run(ctx context.Context) error {
+	if m.Checkpoint == nil {
+		m.Checkpoint = NewCheckpoint()
+	}
+	pageSize := m.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	for _, k := range m.kinds() {
+		if m.Checkpoint.Completed[k.name] {
+			continue
+		}
+		for {
+			// the progress made before an error is saved too, as the replay of a
+			// transaction cannot be repeated without duplicating its meter values
+			position, count, done, err := k.page(ctx, m.Checkpoint.Positions[k.name], pageSize)
+			m.Checkpoint.Counts[k.name] += count
+			if done {
+				m.Checkpoint.Completed[k.name] = true
+				delete(m.Checkpoint.Positions, k.name)
+			} else if position != "" {
+				m.Checkpoint.Positions[k.name] = position
+			}
+			if m.Save != nil {
+				if saveErr := m.Save(m.Checkpoint); saveErr != nil {
+					return saveErr
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("migrate %s: %w", k.name, err)
+			}
+			if done {
+				slog.Info("migrated store entities", "kind", k.name, "count", m.Checkpoint.Counts[k.name])
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) copyChargeStationAuth(ctx context.Context, chargeStationId string) error {
+	auth, err := m.From.LookupChargeStationAuth(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	if auth != nil {
+		if err = m.To.SetChargeStationAuth(ctx, chargeStationId, auth); err != nil {
+			return err
+		}
+	}
+	registration, err := m.From.LookupChargeStationRegistration(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	if registration != nil {
+		if err = m.To.SetChargeStationRegistration(ctx, chargeStationId, registration); err != nil {
+			return err
+		}
+	}
+	// the EVSEs hold connector metadata set through the API, so they are copied with
+	// the charge station
+	evses, err := m.From.ListEvses(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	for _, evse := range evses {
+		if err = m.To.SetEvse(ctx, evse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transactions copies the transactions in key order: a transaction can only be written
+// through the operations that change it, so they are replayed to build the same
+// transaction in the target
+func (m *Migrator) transactions(ctx context.Context, position string, pageSize int) (string, int, bool, error) {
+	key := func(transaction *store.Transaction) string {
+		return transaction.ChargeStationId + ":" + transaction.TransactionId
+	}
+	if m.sortedTransactions == nil {
+		transactions, err := m.From.Transactions(ctx)
+		if err != nil {
+			return "", 0, false, err
+		}
+		sort.Slice(transactions, func(i, j int) bool {
+			return key(transactions[i]) < key(transactions[j])
+		})
+		m.sortedTransactions = transactions
+	}
+	transactions := m.sortedTransactions
+	start := sort.Search(len(transactions), func(i int) bool {
+		return key(transactions[i]) > position
+	})
+
+	count := 0
+	for _, transaction := range transactions[start:] {
+		if count == pageSize {
+			return position, count, false, nil
+		}
+		if err := m.replayTransaction(ctx, transaction); err != nil {
+			return position, count, false, fmt.Errorf("transaction %s: %w", key(transaction), err)
+		}
+		position = key(transaction)
+		count++
+	}
+	return position, count, true, nil
+}
+
+func (m *Migrator) replayTransaction(ctx context.Context, transaction *store.Transaction) error {
+	err := m.To.CreateTransaction(ctx, transaction.ChargeStationId, transaction.TransactionId,
+		transaction.IdToken, transaction.TokenType, transaction.MeterValues, transaction.StartSeqNo, transaction.Offline)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < transaction.UpdatedSeqNoCount; i++ {
+		err = m.To.UpdateTransaction(ctx, transaction.ChargeStationId, transaction.TransactionId, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if transaction.EndedSeqNo != 0 {
+		err = m.To.EndTransaction(ctx, transaction.ChargeStationId, transaction.TransactionId,
+			transaction.IdToken, transaction.TokenType, nil, transaction.EndedSeqNo)
+		if err != nil {
+			return err
+		}
+	}
+	if transaction.RecoveredFromOffline {
+		err = m.To.MarkTransactionRecoveredFromOffline(ctx, transaction.ChargeStationId, transaction.TransactionId)
+		if err != nil {
+			return err
+		}
+	}
+	for _, chargingState := range transaction.ChargingStates {
+		err = m.To.AddTransactionChargingState(ctx, transaction.ChargeStationId, transaction.TransactionId, chargingState)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ocpiParties copies the parties for each role: there are few parties so they are
+// copied in a single page
+func (m *Migrator) ocpiParties(ctx context.Context, _ string, _ int) (string, int, bool, error) {
+	count := 0
+	for _, role := range ocpiRoles {
+		parties, err := m.From.ListPartyDetailsForRole(ctx, role)
+		if err != nil {
+			return "", count, false, err
+		}
+		for _, party := range parties {
+			if err = m.To.SetPartyDetails(ctx, party); err != nil {
+				return "", count, false, err
+			}
+			count++
+		}
+	}
+	return "", count, true, nil
+}
+
+// byOffset copies the entities returned by a list operation that pages by offset
+func byOffset[T any](list func(ctx context.Context, offset, limit int) ([]*T, error),
+	set func(ctx context.Context, value *T) error) page {
+	return func(ctx context.Context, position string, pageSize int) (string, int, bool, error) {
+		var offset int
+		if position != "" {
+			var err error
+			offset, err = strconv.Atoi(position)
+			if err != nil {
+				return "", 0, false, fmt.Errorf("invalid offset %s: %w", position, err)
+			}
+		}
+		values, err := list(ctx, offset, pageSize)
+		if err != nil {
+			return position, 0, false, err
+		}
+		for _, value := range values {
+			if err = set(ctx, value); err != nil {
+				return position, 0, false, err
+			}
+		}
+		return strconv.Itoa(offset + len(values)), len(values), len(values) < pageSize, nil
+	}
+}
+
+// byId copies the entities returned by a list operation that pages by the id of the
+// previous entity
+func byId[T any](list func(ctx context.Context, pageSize int, previousId string) ([]*T, error),
+	id func(value *T) string,
+	set func(ctx context.Context, value *T) error) page {
+	return func(ctx context.Context, position string, pageSize int) (string, int, bool, error) {
+		values, err := list(ctx, pageSize, position)
+		if err != nil {
+			return position, 0, false, err
+		}
+		for _, value := range values {
+			if err = set(ctx, value); err != nil {
+				return position, 0, false, err
+			}
+		}
+		if len(values) > 0 {
+			position = id(values[len(values)-1])
+		}
+		return position, len(values), len(values) < pageSize, nil
+	}
+}
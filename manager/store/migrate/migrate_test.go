@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/store/migrate"
+	"github.com/thoughtworks/maeve-csms/manager/store/sqlite"
+	"k8s.io/utils/clock"
+	"path/filepath"
+	"testing"
+)
+
+func newTarget(t *testing.T) *sqlite.Store {
+	engine, err := sqlite.NewStore(context.Background(), filepath.Join(t.TempDir(), "manager.db"), clock.RealClock{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = engine.Close()
+	})
+	return engine
+}
+
+func TestMigratorCopiesEntities(t *testing.T) {
+	ctx := context.Background()
+	from := inmemory.NewStore(clock.RealClock{})
+	to := newTarget(t)
+
+	err := from.SetChargeStation(ctx, "cs001", &store.ChargeStation{
+		ChargeStationId: "cs001",
+		SecurityProfile: store.TLSWithClientSideCertificates,
+		Vendor:          "Acme",
+	})
+	require.NoError(t, err)
+	err = from.SetChargeStationAuth(ctx, "cs001", &store.ChargeStationAuth{
+		SecurityProfile: store.TLSWithClientSideCertificates,
+	})
+	require.NoError(t, err)
+	// cs002 booted before it was recorded in the registry
+	err = from.SetChargeStationRuntimeDetails(ctx, "cs002", &store.ChargeStationRuntimeDetails{OcppVersion: "2.0.1"})
+	require.NoError(t, err)
+	err = from.SetChargeStationAuth(ctx, "cs002", &store.ChargeStationAuth{
+		SecurityProfile:      store.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "DEADBEEF",
+	})
+	require.NoError(t, err)
+	for _, uid := range []string{"DEADBEEF", "CAFEBABE", "FEEDFACE"} {
+		err = from.SetToken(ctx, &store.Token{Uid: uid, Type: "RFID", Valid: true})
+		require.NoError(t, err)
+	}
+	err = from.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{{Timestamp: "2023-06-01T12:00:00Z"}}, 0, false)
+	require.NoError(t, err)
+	err = from.UpdateTransaction(ctx, "cs001", "tx001", []store.MeterValue{{Timestamp: "2023-06-01T12:30:00Z"}})
+	require.NoError(t, err)
+	err = from.EndTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{{Timestamp: "2023-06-01T13:00:00Z"}}, 2)
+	require.NoError(t, err)
+	err = from.AddTransactionChargingState(ctx, "cs001", "tx001", store.ChargingStateChange{
+		State:     "Charging",
+		Timestamp: "2023-06-01T12:00:00Z",
+	})
+	require.NoError(t, err)
+	err = from.SetReservation(ctx, &store.Reservation{
+		ReservationId:   10,
+		ChargeStationId: "cs001",
+		Status:          store.ReservationStatusAccepted,
+	})
+	require.NoError(t, err)
+
+	var saved int
+	migrator := &migrate.Migrator{
+		From: from,
+		To:   to,
+		Save: func(*migrate.Checkpoint) error {
+			saved++
+			return nil
+		},
+		PageSize: 2,
+	}
+	err = migrator.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, migrator.Checkpoint.Counts["tokens"])
+	assert.True(t, migrator.Checkpoint.Completed["ocpi_pending_pushes"])
+	assert.Empty(t, migrator.Checkpoint.Positions)
+	assert.Greater(t, saved, len(migrator.Checkpoint.Completed))
+
+	cs, err := to.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", cs.Vendor)
+	for _, csId := range []string{"cs001", "cs002"} {
+		want, err := from.LookupChargeStationAuth(ctx, csId)
+		require.NoError(t, err)
+		got, err := to.LookupChargeStationAuth(ctx, csId)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, csId)
+	}
+	tokens, err := to.ListTokens(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, tokens, 3)
+	want, err := from.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	got, err := to.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	reservation, err := to.LookupReservation(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusAccepted, reservation.Status)
+}
+
+func TestMigratorResumesFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	from := inmemory.NewStore(clock.RealClock{})
+	to := newTarget(t)
+
+	for _, uid := range []string{"AAAA", "BBBB", "CCCC"} {
+		err := from.SetToken(ctx, &store.Token{Uid: uid, Type: "RFID", Valid: true})
+		require.NoError(t, err)
+	}
+	err := from.SetTariff(ctx, &store.Tariff{Id: "t001", Currency: "EUR"})
+	require.NoError(t, err)
+
+	// the first two tokens were copied before the migration was interrupted
+	checkpoint := migrate.NewCheckpoint()
+	checkpoint.Positions["tokens"] = "2"
+	checkpoint.Counts["tokens"] = 2
+	checkpoint.Completed["tariffs"] = true
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	err = checkpoint.Save(path)
+	require.NoError(t, err)
+	checkpoint, err = migrate.LoadCheckpoint(path)
+	require.NoError(t, err)
+
+	migrator := &migrate.Migrator{
+		From:       from,
+		To:         to,
+		Checkpoint: checkpoint,
+		Save: func(checkpoint *migrate.Checkpoint) error {
+			return checkpoint.Save(path)
+		},
+	}
+	err = migrator.Run(ctx)
+	require.NoError(t, err)
+
+	tokens, err := to.ListTokens(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "CCCC", tokens[0].Uid)
+	tariff, err := to.LookupTariff(ctx, "t001")
+	require.NoError(t, err)
+	assert.Nil(t, tariff)
+
+	saved, err := migrate.LoadCheckpoint(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, saved.Counts["tokens"])
+	assert.True(t, saved.Completed["tokens"])
+}
+
+func TestLoadCheckpointThatDoesNotExist(t *testing.T) {
+	checkpoint, err := migrate.LoadCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"))
+	require.NoError(t, err)
+	assert.Equal(t, migrate.NewCheckpoint(), checkpoint)
+}
@@ -4,6 +4,7 @@ package store
 
 import (
 	"context"
+	"time"
 )
 
 type OcpiRegistrationStatusType string
@@ -15,6 +16,21 @@ var (
 
 type OcpiRegistration struct {
 	Status OcpiRegistrationStatusType
+	// Parties identifies the party details held for the party that uses the token: it is
+	// only known once credentials have been exchanged with the party
+	Parties []OcpiPartyRole
+}
+
+type OcpiPartyRole struct {
+	Role        string
+	CountryCode string
+	PartyId     string
+}
+
+type OcpiEndpoint struct {
+	Identifier string
+	Role       string
+	Url        string
 }
 
 type OcpiParty struct {
@@ -23,6 +39,46 @@ type OcpiParty struct {
 	Role        string
 	Url         string
 	Token       string
+	// Version is the OCPI version agreed with the party when the credentials were
+	// exchanged: parties registered before versions were negotiated use 2.2
+	Version string
+	// Endpoints are the endpoints of the party for that version, as discovered when the
+	// credentials were exchanged
+	Endpoints []OcpiEndpoint
+}
+
+// OcpiCommand is a command requested by a party that is waiting for the charge station to
+// respond: the result of the command is sent to the ResponseUrl
+type OcpiCommand struct {
+	Command         string
+	ChargeStationId string
+	// Reference distinguishes commands of the same type sent to the charge station, e.g.
+	// the connector to unlock
+	Reference   string
+	ResponseUrl string
+	// CountryCode and PartyId identify the party that requested the command
+	CountryCode string
+	PartyId     string
+}
+
+// OcpiPush is a request to a party that is held in the outbox until it has been
+// delivered, so that it is not lost if the manager restarts
+type OcpiPush struct {
+	Id     string
+	Method string
+	Url    string
+	Body   []byte
+	// Role, CountryCode and PartyId identify the party whose credentials are used to send
+	// the request: this is the hub for a request that is routed through a hub
+	Role        string
+	CountryCode string
+	PartyId     string
+	// ToCountryCode and ToPartyId identify the party that the request is addressed to
+	ToCountryCode string
+	ToPartyId     string
+	CorrelationId string
+	Attempts      int
+	SendAfter     time.Time
 }
 
 type OcpiStore interface {
@@ -33,4 +89,13 @@ type OcpiStore interface {
 	SetPartyDetails(ctx context.Context, partyDetails *OcpiParty) error
 	GetPartyDetails(ctx context.Context, role, countryCode, partyId string) (*OcpiParty, error)
 	ListPartyDetailsForRole(ctx context.Context, role string) ([]*OcpiParty, error)
+	DeletePartyDetails(ctx context.Context, role, countryCode, partyId string) error
+
+	SetPendingCommand(ctx context.Context, command *OcpiCommand) error
+	LookupPendingCommand(ctx context.Context, command, chargeStationId, reference string) (*OcpiCommand, error)
+	DeletePendingCommand(ctx context.Context, command, chargeStationId, reference string) error
+
+	SetPendingPush(ctx context.Context, push *OcpiPush) error
+	ListPendingPushes(ctx context.Context, pageSize int, previousId string) ([]*OcpiPush, error)
+	DeletePendingPush(ctx context.Context, id string) error
 }
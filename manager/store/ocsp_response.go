@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// OcspResponse is a response from an OCSP responder for a certificate. It is cached
+// until the responder's next update so that the certificate's status can be checked,
+// and the response returned to the charge station, without calling the responder. A
+// stale response is kept so that it can be used while the responder is unavailable.
+type OcspResponse struct {
+	// Key identifies the certificate by the hash of its issuer's public key and its
+	// serial number
+	Key string
+	// Response is the base64 encoded DER response
+	Response   string
+	NextUpdate time.Time
+	// Request is the base64 encoded DER request that is sent to the ResponderUrls to
+	// revalidate the response
+	Request       string
+	ResponderUrls []string
+	// Issuer is the PEM encoded certificate of the certificate's issuer, if it is known,
+	// which is used to verify the response
+	Issuer string
+}
+
+type OcspResponseStore interface {
+	SetOcspResponse(ctx context.Context, response *OcspResponse) error
+	// LookupOcspResponse returns nil if no response is cached: the response returned
+	// may be past its NextUpdate
+	LookupOcspResponse(ctx context.Context, key string) (*OcspResponse, error)
+	// ListOcspResponses returns up to pageSize responses, ordered by key, that follow
+	// the response with previousKey
+	ListOcspResponses(ctx context.Context, pageSize int, previousKey string) ([]*OcspResponse, error)
+}
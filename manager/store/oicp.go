@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import "context"
+
+// OicpSession links a token that was authorized through Hubject to the session that
+// Hubject created for it, so that the session can be stopped when the transaction ends
+type OicpSession struct {
+	IdToken   string
+	SessionId string
+}
+
+type OicpStore interface {
+	SetOicpSession(ctx context.Context, session *OicpSession) error
+	LookupOicpSession(ctx context.Context, idToken string) (*OicpSession, error)
+	DeleteOicpSession(ctx context.Context, idToken string) error
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// OutboundCall is a call from the CSMS to a charge station
+type OutboundCall struct {
+	OcppVersion    string
+	MessageId      string
+	Action         string
+	RequestPayload string
+	State          string
+}
+
+// OutboundCallQueue holds the calls from the CSMS to a charge station: OCPP allows
+// only one call to be outstanding at a time, so the others wait until the charge
+// station has responded to the call in flight or the call has timed out.
+type OutboundCallQueue struct {
+	ChargeStationId   string
+	InFlight          *OutboundCall
+	InFlightExpiresAt time.Time
+	Queued            []*OutboundCall
+	// Version is incremented each time the queue is written
+	Version int
+}
+
+// OutboundCallQueueStore holds a queue for each charge station. The queue is shared
+// by the manager instances, each of which may add calls to it or release the call in
+// flight.
+type OutboundCallQueueStore interface {
+	// SetOutboundCallQueue writes the queue if its Version matches the stored version
+	// (0 for a new queue) and increments the Version: otherwise it returns
+	// ErrVersionConflict
+	SetOutboundCallQueue(ctx context.Context, queue *OutboundCallQueue) error
+	LookupOutboundCallQueue(ctx context.Context, chargeStationId string) (*OutboundCallQueue, error)
+	DeleteOutboundCallQueue(ctx context.Context, chargeStationId string) error
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func callResponseKey(chargeStationId, messageId, action string) string {
+	return fmt.Sprintf("CallResponse:%s:%s:%s", chargeStationId, messageId, action)
+}
+
+// LookupCallResponse returns nil if no response is held for the call. The responses
+// are held on behalf of a handlers.CallResponses so that every manager instance
+// recognises a retransmitted call.
+func (s *Store) LookupCallResponse(ctx context.Context, chargeStationId, messageId, action string) (json.RawMessage, error) {
+	response, err := get[json.RawMessage](ctx, s, callResponseKey(chargeStationId, messageId, action))
+	if err != nil {
+		return nil, fmt.Errorf("lookup response to %s call %s from %s: %w", action, messageId, chargeStationId, err)
+	}
+	if response == nil {
+		return nil, nil
+	}
+	return *response, nil
+}
+
+func (s *Store) SetCallResponse(ctx context.Context, chargeStationId, messageId, action string, response json.RawMessage, ttl time.Duration) error {
+	err := s.setWithTTL(ctx, callResponseKey(chargeStationId, messageId, action), response, ttl)
+	if err != nil {
+		return fmt.Errorf("setting response to %s call %s from %s: %w", action, messageId, chargeStationId, err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func connectorStatusKey(chargeStationId string) string {
+	return fmt.Sprintf("ConnectorStatus:%s", chargeStationId)
+}
+
+// SetConnectorStatus stores the connector's status in a hash holding all the charge
+// station's connectors: the hash expires if none of them is updated within the TTL
+func (s *Store) SetConnectorStatus(ctx context.Context, status *store.ConnectorStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("setting connector status %s: %w", status.ChargeStationId, err)
+	}
+	key := connectorStatusKey(status.ChargeStationId)
+	_, err = s.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, key, fmt.Sprintf("%d:%d", status.EvseId, status.ConnectorId), data)
+		pipe.PExpire(ctx, key, s.ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting connector status %s: %w", status.ChargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) ListConnectorStatuses(ctx context.Context, chargeStationId string) ([]*store.ConnectorStatus, error) {
+	values, err := s.client.HGetAll(ctx, connectorStatusKey(chargeStationId)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list connector statuses %s: %w", chargeStationId, err)
+	}
+	var statuses []*store.ConnectorStatus
+	for _, data := range values {
+		status := new(store.ConnectorStatus)
+		if err = json.Unmarshal([]byte(data), status); err != nil {
+			return nil, fmt.Errorf("map connector status %s: %w", chargeStationId, err)
+		}
+		statuses = append(statuses, status)
+	}
+	store.SortConnectorStatuses(statuses)
+	return statuses, nil
+}
+
+// livenessIndexKey is a sorted set of the charge stations with liveness: all members have
+// the same score so that they can be paged through in lexicographic order
+const livenessIndexKey = "ChargeStationLiveness"
+
+func livenessKey(chargeStationId string) string {
+	return fmt.Sprintf("ChargeStationLiveness:%s", chargeStationId)
+}
+
+func (s *Store) SetChargeStationLiveness(ctx context.Context, chargeStationId string, liveness *store.ChargeStationLiveness) error {
+	data, err := json.Marshal(&store.ChargeStationLiveness{
+		ChargeStationId: chargeStationId,
+		LastSeen:        liveness.LastSeen,
+		LastHeartbeat:   liveness.LastHeartbeat,
+		Offline:         liveness.Offline,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station liveness %s: %w", chargeStationId, err)
+	}
+	_, err = s.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Set(ctx, livenessKey(chargeStationId), data, s.ttl)
+		pipe.ZAdd(ctx, livenessIndexKey, goredis.Z{Member: chargeStationId})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station liveness %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationLiveness(ctx context.Context, chargeStationId string) (*store.ChargeStationLiveness, error) {
+	liveness, err := get[store.ChargeStationLiveness](ctx, s, livenessKey(chargeStationId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station liveness %s: %w", chargeStationId, err)
+	}
+	return liveness, nil
+}
+
+// ListChargeStationLiveness pages through the liveness index, removing the charge
+// stations whose liveness has expired
+func (s *Store) ListChargeStationLiveness(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationLiveness, error) {
+	liveness := make([]*store.ChargeStationLiveness, 0)
+	for len(liveness) < pageSize {
+		min := "-"
+		if previousChargeStationId != "" {
+			min = "(" + previousChargeStationId
+		}
+		ids, err := s.client.ZRangeByLex(ctx, livenessIndexKey, &goredis.ZRangeBy{
+			Min:   min,
+			Max:   "+",
+			Count: int64(pageSize - len(liveness)),
+		}).Result()
+		if err != nil {
+			return nil, fmt.Errorf("list charge station liveness: %w", err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		keys := make([]string, len(ids))
+		for i, id := range ids {
+			keys[i] = livenessKey(id)
+		}
+		values, err := s.client.MGet(ctx, keys...).Result()
+		if err != nil {
+			return nil, fmt.Errorf("list charge station liveness: %w", err)
+		}
+
+		var expired []any
+		for i, value := range values {
+			data, ok := value.(string)
+			if !ok {
+				expired = append(expired, ids[i])
+				continue
+			}
+			cs := new(store.ChargeStationLiveness)
+			if err = json.Unmarshal([]byte(data), cs); err != nil {
+				return nil, fmt.Errorf("map charge station liveness %s: %w", ids[i], err)
+			}
+			liveness = append(liveness, cs)
+		}
+		if len(expired) > 0 {
+			if err = s.client.ZRem(ctx, livenessIndexKey, expired...).Err(); err != nil {
+				return nil, fmt.Errorf("list charge station liveness: %w", err)
+			}
+		}
+		previousChargeStationId = ids[len(ids)-1]
+	}
+	return liveness, nil
+}
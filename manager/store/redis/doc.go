@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis provides a Redis implementation of the stores that hold high-churn
+// runtime state: connector status, charge station liveness, pending OCPI commands, EXI
+// response chunks and reservation locks. Entries expire so that abandoned state does not
+// accumulate. The Store can be used on its own wherever those stores are needed, or
+// layered in front of a durable store.Engine with NewLayeredStore. It can also be used as the
+// shared tier of the token cache provided by the cache package.
+package redis
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func exiResponseChunksKey(chargeStationId, exiRequestHash string) string {
+	return fmt.Sprintf("ExiResponseChunks:%s:%s", chargeStationId, exiRequestHash)
+}
+
+// SetExiResponseChunks stores the chunks until they expire: chunks without an expiry use
+// the Store's TTL and chunks that have already expired are not stored
+func (s *Store) SetExiResponseChunks(ctx context.Context, chunks *store.ExiResponseChunks) error {
+	key := exiResponseChunksKey(chunks.ChargeStationId, chunks.ExiRequestHash)
+	ttl := s.ttl
+	if !chunks.ExpiresAt.IsZero() {
+		ttl = chunks.ExpiresAt.Sub(s.clock.Now())
+		if ttl <= 0 {
+			return s.DeleteExiResponseChunks(ctx, chunks.ChargeStationId, chunks.ExiRequestHash)
+		}
+	}
+	err := s.setWithTTL(ctx, key, chunks, ttl)
+	if err != nil {
+		return fmt.Errorf("setting exi response chunks %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) (*store.ExiResponseChunks, error) {
+	key := exiResponseChunksKey(chargeStationId, exiRequestHash)
+	chunks, err := get[store.ExiResponseChunks](ctx, s, key)
+	if err != nil {
+		return nil, fmt.Errorf("lookup exi response chunks %s: %w", key, err)
+	}
+	return chunks, nil
+}
+
+func (s *Store) DeleteExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) error {
+	key := exiResponseChunksKey(chargeStationId, exiRequestHash)
+	err := s.client.Del(ctx, key).Err()
+	if err != nil {
+		return fmt.Errorf("deleting exi response chunks %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+// Layered is a store.Engine that keeps the transient runtime state in Redis and
+// delegates everything else to a durable engine. The durable engine never sees the
+// runtime state, so it is lost if Redis is flushed.
+type Layered struct {
+	store.Engine
+	transient *Store
+}
+
+func NewLayeredStore(durable store.Engine, transient *Store) *Layered {
+	return &Layered{
+		Engine:    durable,
+		transient: transient,
+	}
+}
+
+func (l *Layered) SetConnectorStatus(ctx context.Context, status *store.ConnectorStatus) error {
+	return l.transient.SetConnectorStatus(ctx, status)
+}
+
+func (l *Layered) ListConnectorStatuses(ctx context.Context, chargeStationId string) ([]*store.ConnectorStatus, error) {
+	return l.transient.ListConnectorStatuses(ctx, chargeStationId)
+}
+
+func (l *Layered) SetChargeStationLiveness(ctx context.Context, chargeStationId string, liveness *store.ChargeStationLiveness) error {
+	return l.transient.SetChargeStationLiveness(ctx, chargeStationId, liveness)
+}
+
+func (l *Layered) LookupChargeStationLiveness(ctx context.Context, chargeStationId string) (*store.ChargeStationLiveness, error) {
+	return l.transient.LookupChargeStationLiveness(ctx, chargeStationId)
+}
+
+func (l *Layered) ListChargeStationLiveness(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationLiveness, error) {
+	return l.transient.ListChargeStationLiveness(ctx, pageSize, previousChargeStationId)
+}
+
+func (l *Layered) SetPendingCommand(ctx context.Context, command *store.OcpiCommand) error {
+	return l.transient.SetPendingCommand(ctx, command)
+}
+
+func (l *Layered) LookupPendingCommand(ctx context.Context, command, chargeStationId, reference string) (*store.OcpiCommand, error) {
+	return l.transient.LookupPendingCommand(ctx, command, chargeStationId, reference)
+}
+
+func (l *Layered) DeletePendingCommand(ctx context.Context, command, chargeStationId, reference string) error {
+	return l.transient.DeletePendingCommand(ctx, command, chargeStationId, reference)
+}
+
+func (l *Layered) SetExiResponseChunks(ctx context.Context, chunks *store.ExiResponseChunks) error {
+	return l.transient.SetExiResponseChunks(ctx, chunks)
+}
+
+func (l *Layered) LookupExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) (*store.ExiResponseChunks, error) {
+	return l.transient.LookupExiResponseChunks(ctx, chargeStationId, exiRequestHash)
+}
+
+func (l *Layered) DeleteExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) error {
+	return l.transient.DeleteExiResponseChunks(ctx, chargeStationId, exiRequestHash)
+}
+
+func (l *Layered) SetOutboundCallQueue(ctx context.Context, queue *store.OutboundCallQueue) error {
+	return l.transient.SetOutboundCallQueue(ctx, queue)
+}
+
+func (l *Layered) LookupOutboundCallQueue(ctx context.Context, chargeStationId string) (*store.OutboundCallQueue, error) {
+	return l.transient.LookupOutboundCallQueue(ctx, chargeStationId)
+}
+
+func (l *Layered) DeleteOutboundCallQueue(ctx context.Context, chargeStationId string) error {
+	return l.transient.DeleteOutboundCallQueue(ctx, chargeStationId)
+}
+
+func (l *Layered) LockReservation(ctx context.Context, reservationId int, ttl time.Duration) (bool, error) {
+	return l.transient.LockReservation(ctx, reservationId, ttl)
+}
+
+func (l *Layered) UnlockReservation(ctx context.Context, reservationId int) error {
+	return l.transient.UnlockReservation(ctx, reservationId)
+}
+
+func (l *Layered) WriteTransactionBatch(ctx context.Context, batch *store.TransactionBatch) error {
+	return store.WriteTransactionBatch(ctx, l.Engine, batch)
+}
+
+// ListTenantChargeStations uses the durable engine's tenant selection if it is a
+// store.TenantStore
+func (l *Layered) ListTenantChargeStations(ctx context.Context, tenantId string, offset, limit int) ([]*store.ChargeStation, error) {
+	return store.ListTenantChargeStations(ctx, l.Engine, tenantId, offset, limit)
+}
+
+func (l *Layered) ListTenantTokens(ctx context.Context, tenantId string, offset, limit int) ([]*store.Token, error) {
+	return store.ListTenantTokens(ctx, l.Engine, tenantId, offset, limit)
+}
+
+func (l *Layered) ListTenantSites(ctx context.Context, tenantId string, offset, limit int) ([]*store.Site, error) {
+	return store.ListTenantSites(ctx, l.Engine, tenantId, offset, limit)
+}
+
+func (l *Layered) ListTenantTags(ctx context.Context, tenantId string, offset, limit int) ([]*store.Tag, error) {
+	return store.ListTenantTags(ctx, l.Engine, tenantId, offset, limit)
+}
+
+// IsTransient reports whether either Redis or the durable engine classifies err as
+// transient
+func (l *Layered) IsTransient(err error) bool {
+	return l.transient.IsTransient(err) || store.IsTransient(l.Engine, err)
+}
+
+// Healthy returns an error if either Redis or the durable engine is unhealthy
+func (l *Layered) Healthy() error {
+	if err := l.transient.Healthy(); err != nil {
+		return err
+	}
+	if reporter, ok := l.Engine.(store.HealthReporter); ok {
+		return reporter.Healthy()
+	}
+	return nil
+}
+
+// Close closes the connection to Redis and the durable engine
+func (l *Layered) Close() error {
+	return errors.Join(l.transient.Close(), store.Close(l.Engine))
+}
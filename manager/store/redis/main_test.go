@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"log"
+	"os"
+	"testing"
+)
+
+var endpoint string
+
+func setup() func() {
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "redis:7",
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("Ready to accept connections"),
+			wait.ForExposedPort(),
+		),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		log.Println("Failed to wait for started container")
+		log.Fatal(err)
+	}
+
+	endpoint, err = container.Endpoint(ctx, "")
+	if err != nil {
+		log.Println("Container did not expose endpoint")
+		log.Fatal(err)
+	}
+
+	return func() {
+		if err := container.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err.Error())
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
+	teardown := setup()
+	exitVal := m.Run()
+	teardown()
+
+	os.Exit(exitVal)
+}
+
+// newClient returns a client for an empty database
+func newClient(t *testing.T) *goredis.Client {
+	client := goredis.NewClient(&goredis.Options{Addr: endpoint})
+	require.NoError(t, client.FlushDB(context.Background()).Err())
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
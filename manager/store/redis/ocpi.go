@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func pendingCommandKey(command, chargeStationId, reference string) string {
+	return fmt.Sprintf("OcpiCommand:%s:%s:%s", command, chargeStationId, reference)
+}
+
+func (s *Store) SetPendingCommand(ctx context.Context, command *store.OcpiCommand) error {
+	key := pendingCommandKey(command.Command, command.ChargeStationId, command.Reference)
+	err := s.setWithTTL(ctx, key, command, s.ttl)
+	if err != nil {
+		return fmt.Errorf("setting pending command %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupPendingCommand(ctx context.Context, command, chargeStationId, reference string) (*store.OcpiCommand, error) {
+	key := pendingCommandKey(command, chargeStationId, reference)
+	pending, err := get[store.OcpiCommand](ctx, s, key)
+	if err != nil {
+		return nil, fmt.Errorf("lookup pending command %s: %w", key, err)
+	}
+	return pending, nil
+}
+
+func (s *Store) DeletePendingCommand(ctx context.Context, command, chargeStationId, reference string) error {
+	key := pendingCommandKey(command, chargeStationId, reference)
+	err := s.client.Del(ctx, key).Err()
+	if err != nil {
+		return fmt.Errorf("deleting pending command %s: %w", key, err)
+	}
+	return nil
+}
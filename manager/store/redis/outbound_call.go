@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func outboundCallQueueKey(chargeStationId string) string {
+	return fmt.Sprintf("OutboundCallQueue:%s", chargeStationId)
+}
+
+// SetOutboundCallQueue watches the queue's key so that the queue is only written if
+// it has not been changed since its version was checked
+func (s *Store) SetOutboundCallQueue(ctx context.Context, queue *store.OutboundCallQueue) error {
+	key := outboundCallQueueKey(queue.ChargeStationId)
+	clone := *queue
+	clone.Version++
+	data, err := json.Marshal(&clone)
+	if err != nil {
+		return fmt.Errorf("setting outbound call queue %s: %w", key, err)
+	}
+	err = s.client.Watch(ctx, func(tx *goredis.Tx) error {
+		var version int
+		existing, err := tx.Get(ctx, key).Bytes()
+		if err != nil && !errors.Is(err, goredis.Nil) {
+			return err
+		}
+		if err == nil {
+			var stored store.OutboundCallQueue
+			if err = json.Unmarshal(existing, &stored); err != nil {
+				return err
+			}
+			version = stored.Version
+		}
+		if queue.Version != version {
+			return store.ErrVersionConflict
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Set(ctx, key, data, s.ttl)
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, goredis.TxFailedErr) {
+		err = store.ErrVersionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("setting outbound call queue %s: %w", key, err)
+	}
+	queue.Version++
+	return nil
+}
+
+func (s *Store) LookupOutboundCallQueue(ctx context.Context, chargeStationId string) (*store.OutboundCallQueue, error) {
+	key := outboundCallQueueKey(chargeStationId)
+	queue, err := get[store.OutboundCallQueue](ctx, s, key)
+	if err != nil {
+		return nil, fmt.Errorf("lookup outbound call queue %s: %w", key, err)
+	}
+	return queue, nil
+}
+
+func (s *Store) DeleteOutboundCallQueue(ctx context.Context, chargeStationId string) error {
+	key := outboundCallQueueKey(chargeStationId)
+	err := s.client.Del(ctx, key).Err()
+	if err != nil {
+		return fmt.Errorf("deleting outbound call queue %s: %w", key, err)
+	}
+	return nil
+}
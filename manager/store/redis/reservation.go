@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	goredis "github.com/redis/go-redis/v9"
+	"time"
+)
+
+func reservationLockKey(reservationId int) string {
+	return fmt.Sprintf("ReservationLock:%d", reservationId)
+}
+
+// unlockScript only deletes the lock if it is still held by the owner
+var unlockScript = goredis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+func (s *Store) LockReservation(ctx context.Context, reservationId int, ttl time.Duration) (bool, error) {
+	locked, err := s.client.SetNX(ctx, reservationLockKey(reservationId), s.owner, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("locking reservation %d: %w", reservationId, err)
+	}
+	return locked, nil
+}
+
+func (s *Store) UnlockReservation(ctx context.Context, reservationId int) error {
+	err := unlockScript.Run(ctx, s.client, []string{reservationLockKey(reservationId)}, s.owner).Err()
+	if err != nil {
+		return fmt.Errorf("unlocking reservation %d: %w", reservationId, err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	goredis "github.com/redis/go-redis/v9"
+	"io"
+	"k8s.io/utils/clock"
+	"net"
+	"strings"
+	"time"
+)
+
+// Store keeps transient runtime state in Redis. Every entry expires after the Store's
+// TTL unless it is updated, with the exception of EXI response chunks which expire at
+// their own deadline.
+type Store struct {
+	client goredis.UniversalClient
+	clock  clock.PassiveClock
+	ttl    time.Duration
+	// owner identifies the locks held by this Store
+	owner string
+}
+
+type Opt func(s *Store)
+
+// WithTTL sets how long entries are kept after they were last updated
+func WithTTL(ttl time.Duration) Opt {
+	return func(s *Store) {
+		s.ttl = ttl
+	}
+}
+
+func NewStore(client goredis.UniversalClient, clock clock.PassiveClock, opts ...Opt) *Store {
+	s := &Store{
+		client: client,
+		clock:  clock,
+		ttl:    24 * time.Hour,
+		owner:  newOwner(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func newOwner() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Healthy returns an error if Redis cannot be reached
+func (s *Store) Healthy() error {
+	return s.client.Ping(context.Background()).Err()
+}
+
+// IsTransient reports whether err is a network error or one that Redis returns while it
+// is loading its data set or failing over, which are likely to succeed if retried
+func (s *Store) IsTransient(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var redisErr goredis.Error
+	if errors.As(err, &redisErr) {
+		for _, prefix := range []string{"LOADING ", "READONLY ", "CLUSTERDOWN ", "TRYAGAIN ", "MASTERDOWN "} {
+			if strings.HasPrefix(redisErr.Error(), prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Close closes the connection to Redis
+func (s *Store) Close() error {
+	return s.client.Close()
+}
+
+// setWithTTL stores the value as JSON under key, expiring after ttl
+func (s *Store) setWithTTL(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, key, data, ttl).Err()
+}
+
+// get returns nil if there is no value for the key
+func get[T any](ctx context.Context, s *Store, key string) (*T, error) {
+	data, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	value := new(T)
+	if err = json.Unmarshal(data, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
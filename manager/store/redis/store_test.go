@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/store/redis"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+func TestSetAndListConnectorStatuses(t *testing.T) {
+	ctx := context.Background()
+	engine := redis.NewStore(newClient(t), clock.RealClock{})
+	now := time.Now().UTC()
+
+	for _, status := range []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 2, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now},
+	} {
+		require.NoError(t, engine.SetConnectorStatus(ctx, status))
+	}
+
+	got, err := engine.ListConnectorStatuses(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 2, ConnectorId: 1, Status: "Available", LastUpdated: now},
+	}, got)
+}
+
+func TestChargeStationLivenessExpires(t *testing.T) {
+	ctx := context.Background()
+	engine := redis.NewStore(newClient(t), clock.RealClock{}, redis.WithTTL(100*time.Millisecond))
+
+	err := engine.SetChargeStationLiveness(ctx, "cs001", &store.ChargeStationLiveness{
+		LastSeen: time.Now().UTC(),
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationLiveness(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "cs001", got.ChargeStationId)
+
+	time.Sleep(200 * time.Millisecond)
+
+	got, err = engine.LookupChargeStationLiveness(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	liveness, err := engine.ListChargeStationLiveness(ctx, 10, "")
+	require.NoError(t, err)
+	assert.Empty(t, liveness)
+}
+
+func TestListChargeStationLivenessIsPaged(t *testing.T) {
+	ctx := context.Background()
+	engine := redis.NewStore(newClient(t), clock.RealClock{})
+
+	for i := 0; i < 5; i++ {
+		err := engine.SetChargeStationLiveness(ctx, fmt.Sprintf("cs%03d", i), &store.ChargeStationLiveness{
+			LastSeen: time.Now().UTC(),
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationLiveness(ctx, 3, "")
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, "cs002", got[2].ChargeStationId)
+
+	got, err = engine.ListChargeStationLiveness(ctx, 3, "cs002")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs003", got[0].ChargeStationId)
+	assert.Equal(t, "cs004", got[1].ChargeStationId)
+}
+
+func TestSetAndDeletePendingCommand(t *testing.T) {
+	ctx := context.Background()
+	engine := redis.NewStore(newClient(t), clock.RealClock{})
+
+	want := &store.OcpiCommand{
+		Command:         "UNLOCK_CONNECTOR",
+		ChargeStationId: "cs001",
+		Reference:       "1:1",
+		ResponseUrl:     "https://emsp.example.com/commands/1",
+	}
+	require.NoError(t, engine.SetPendingCommand(ctx, want))
+
+	got, err := engine.LookupPendingCommand(ctx, "UNLOCK_CONNECTOR", "cs001", "1:1")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	require.NoError(t, engine.DeletePendingCommand(ctx, "UNLOCK_CONNECTOR", "cs001", "1:1"))
+
+	got, err = engine.LookupPendingCommand(ctx, "UNLOCK_CONNECTOR", "cs001", "1:1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestExpiredExiResponseChunksAreNotStored(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	engine := redis.NewStore(newClient(t), clockTest.NewFakePassiveClock(now))
+
+	err := engine.SetExiResponseChunks(ctx, &store.ExiResponseChunks{
+		ChargeStationId: "cs001",
+		ExiRequestHash:  "hash",
+		Chunks:          []string{"a", "b"},
+		ExpiresAt:       now.Add(-time.Second),
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupExiResponseChunks(ctx, "cs001", "hash")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestReservationLockIsOnlyHeldByOneStore(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+	first := redis.NewStore(client, clock.RealClock{})
+	second := redis.NewStore(client, clock.RealClock{})
+
+	locked, err := first.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	locked, err = second.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	// only the owner can release the lock
+	require.NoError(t, second.UnlockReservation(ctx, 1))
+	locked, err = second.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	require.NoError(t, first.UnlockReservation(ctx, 1))
+	locked, err = second.LockReservation(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, locked)
+}
+
+func TestLayeredStoreKeepsRuntimeStateOutOfTheDurableEngine(t *testing.T) {
+	ctx := context.Background()
+	durable := inmemory.NewStore(clock.RealClock{})
+	engine := redis.NewLayeredStore(durable, redis.NewStore(newClient(t), clock.RealClock{}))
+
+	err := engine.SetChargeStationLiveness(ctx, "cs001", &store.ChargeStationLiveness{LastSeen: time.Now().UTC()})
+	require.NoError(t, err)
+	err = engine.SetToken(ctx, &store.Token{Uid: "DEADBEEF"})
+	require.NoError(t, err)
+
+	liveness, err := durable.LookupChargeStationLiveness(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, liveness)
+	liveness, err = engine.LookupChargeStationLiveness(ctx, "cs001")
+	require.NoError(t, err)
+	assert.NotNil(t, liveness)
+
+	token, err := durable.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.NotNil(t, token)
+}
+
+func TestSetLookupAndDeleteCachedToken(t *testing.T) {
+	ctx := context.Background()
+	engine := redis.NewStore(newClient(t), clock.RealClock{})
+
+	want := &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "DEADBEEF",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   store.CacheModeAllowed,
+	}
+
+	err := engine.SetCachedToken(ctx, want, time.Minute)
+	require.NoError(t, err)
+
+	got, err := engine.LookupCachedToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	err = engine.DeleteCachedToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+
+	got, err = engine.LookupCachedToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetAndLookupCallResponse(t *testing.T) {
+	ctx := context.Background()
+	engine := redis.NewStore(newClient(t), clock.RealClock{})
+
+	err := engine.SetCallResponse(ctx, "cs001", "msg-1", "Heartbeat", []byte(`{"currentTime":"2023-06-15T15:05:00Z"}`), time.Minute)
+	require.NoError(t, err)
+
+	got, err := engine.LookupCallResponse(ctx, "cs001", "msg-1", "Heartbeat")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"currentTime":"2023-06-15T15:05:00Z"}`, string(got))
+
+	got, err = engine.LookupCallResponse(ctx, "cs002", "msg-1", "Heartbeat")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+func cachedTokenKey(tokenUid string) string {
+	return fmt.Sprintf("TokenCache:%s", tokenUid)
+}
+
+// LookupCachedToken returns nil if the token is not cached. The Store is not the
+// source of truth for tokens: it only caches them on behalf of a cache.Store.
+func (s *Store) LookupCachedToken(ctx context.Context, tokenUid string) (*store.Token, error) {
+	tok, err := get[store.Token](ctx, s, cachedTokenKey(tokenUid))
+	if err != nil {
+		return nil, fmt.Errorf("lookup cached token %s: %w", tokenUid, err)
+	}
+	return tok, nil
+}
+
+func (s *Store) SetCachedToken(ctx context.Context, token *store.Token, ttl time.Duration) error {
+	err := s.setWithTTL(ctx, cachedTokenKey(token.Uid), token, ttl)
+	if err != nil {
+		return fmt.Errorf("setting cached token %s: %w", token.Uid, err)
+	}
+	return nil
+}
+
+func (s *Store) DeleteCachedToken(ctx context.Context, tokenUid string) error {
+	err := s.client.Del(ctx, cachedTokenKey(tokenUid)).Err()
+	if err != nil {
+		return fmt.Errorf("deleting cached token %s: %w", tokenUid, err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Factory creates an Engine. The options are the engine specific configuration: their
+// keys and values are defined by the engine.
+type Factory func(ctx context.Context, options map[string]any) (Engine, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]Factory)
+)
+
+// Register makes an engine available by name. It is intended to be called from the init
+// function of the package implementing the engine, so that a custom engine can be compiled
+// into the manager by importing its package. Register panics if it is called twice with
+// the same name or if factory is nil.
+func Register(name string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	if factory == nil {
+		panic("store: register factory is nil for " + name)
+	}
+	if _, dup := factories[name]; dup {
+		panic("store: register called twice for " + name)
+	}
+	factories[name] = factory
+}
+
+// Engines returns the sorted names of the registered engines
+func Engines() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open creates an Engine using the factory registered with the name
+func Open(ctx context.Context, name string, options map[string]any) (Engine, error) {
+	factoriesMu.RLock()
+	factory, ok := factories[name]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown storage type: %s", name)
+	}
+	engine, err := factory(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("create %s storage: %w", name, err)
+	}
+	return engine, nil
+}
+
+// StringOption returns the string value of an option or an empty string if the option is
+// not set
+func StringOption(options map[string]any, key string) (string, error) {
+	value, ok := options[key]
+	if !ok || value == nil {
+		return "", nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("option %s must be a string, not %T", key, value)
+	}
+	return s, nil
+}
+
+// RequiredStringOption returns the string value of an option, which must be set
+func RequiredStringOption(options map[string]any, key string) (string, error) {
+	s, err := StringOption(options, key)
+	if err != nil {
+		return "", err
+	}
+	if s == "" {
+		return "", fmt.Errorf("option %s is required", key)
+	}
+	return s, nil
+}
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"golang.org/x/exp/slices"
+	"time"
+)
+
+type ReservationStatus string
+
+var (
+	ReservationStatusPending       ReservationStatus = "Pending"
+	ReservationStatusAccepted      ReservationStatus = "Accepted"
+	ReservationStatusRejected      ReservationStatus = "Rejected"
+	ReservationStatusCancelPending ReservationStatus = "CancelPending"
+	ReservationStatusCancelled     ReservationStatus = "Cancelled"
+	// ReservationStatusUsed means the reservation was consumed by starting a transaction
+	ReservationStatusUsed ReservationStatus = "Used"
+)
+
+type ReservationTransferStatus string
+
+var (
+	// ReservationTransferStatusPending means the reservation has not yet been made at the target
+	ReservationTransferStatusPending ReservationTransferStatus = "Pending"
+	// ReservationTransferStatusAccepted means the target has accepted the reservation and
+	// the reservation at the original charge station is being cancelled
+	ReservationTransferStatusAccepted ReservationTransferStatus = "Accepted"
+	// ReservationTransferStatusRejected means the target rejected the reservation: the
+	// reservation at the original charge station is unaffected
+	ReservationTransferStatusRejected ReservationTransferStatus = "Rejected"
+)
+
+// ReservationTransfer describes a request to move a reservation to a different
+// EVSE or charge station.
+type ReservationTransfer struct {
+	ChargeStationId string
+	EvseId          *int
+	Status          ReservationTransferStatus
+}
+
+type ReservationPaymentStatus string
+
+var (
+	// ReservationPaymentStatusAuthorized means a hold has been placed on the driver's
+	// payment method and has not yet been settled
+	ReservationPaymentStatusAuthorized ReservationPaymentStatus = "Authorized"
+	ReservationPaymentStatusCaptured   ReservationPaymentStatus = "Captured"
+	ReservationPaymentStatusReleased   ReservationPaymentStatus = "Released"
+)
+
+// ReservationPayment describes the hold placed on the driver's payment method for a
+// paid reservation.
+type ReservationPayment struct {
+	// AuthorizationId identifies the hold with the payment service provider
+	AuthorizationId string
+	Status          ReservationPaymentStatus
+}
+
+// ReservationContact is how the driver that holds a reservation can be notified about it
+type ReservationContact struct {
+	Email string
+	Phone string
+}
+
+type ReservationNotification string
+
+var (
+	// ReservationNotificationConfirmed tells the driver that the charge station has
+	// accepted the reservation
+	ReservationNotificationConfirmed ReservationNotification = "Confirmed"
+	// ReservationNotificationExpiring reminds the driver that the reservation expires soon
+	ReservationNotificationExpiring ReservationNotification = "Expiring"
+	// ReservationNotificationCancelled tells the driver that the reservation was cancelled
+	ReservationNotificationCancelled ReservationNotification = "Cancelled"
+)
+
+// ReservationLeadTime is how long before a booked time slot starts that the
+// reservation is made at the charge station
+const ReservationLeadTime = 5 * time.Minute
+
+type Reservation struct {
+	ReservationId   int
+	ChargeStationId string
+	EvseId          *int
+	IdToken         string
+	TokenType       string
+	ExpiryDate      time.Time
+	Status          ReservationStatus
+	Transfer        *ReservationTransfer
+	SendAfter       time.Time
+	// StartDate is when the reservation starts to hold the EVSE: for a booked time slot
+	// the reservation is only made at the charge station ReservationLeadTime before it
+	StartDate time.Time
+	// NoShow is set when the reservation is cancelled because no transaction used it
+	NoShow bool
+	// NoShowFee is the fee charged for a no-show: it is nil if no fee is charged
+	NoShowFee *CostBreakdown
+	// Payment is the hold for a paid reservation: it is nil if no hold has been placed
+	Payment *ReservationPayment
+	// TransactionId identifies the transaction that used the reservation
+	TransactionId string
+	// Contact is how the driver is notified about the reservation: it is nil if the
+	// driver did not give one
+	Contact *ReservationContact
+	// Notified lists the notifications that have been sent to the driver
+	Notified []ReservationNotification
+	// TenantId identifies the operator that made the reservation
+	TenantId string
+	// Version is incremented each time the reservation is written
+	Version int
+}
+
+// Consume marks an accepted reservation held at the charge station as used by a
+// transaction: it reports whether the reservation was changed
+func (r *Reservation) Consume(chargeStationId, transactionId string) bool {
+	if r.Status != ReservationStatusAccepted || r.ChargeStationId != chargeStationId {
+		return false
+	}
+	r.Status = ReservationStatusUsed
+	r.TransactionId = transactionId
+	return true
+}
+
+// HasNotified reports whether the notification has been sent to the driver
+func (r *Reservation) HasNotified(notification ReservationNotification) bool {
+	return slices.Contains(r.Notified, notification)
+}
+
+// Active reports whether the reservation is held at the charge station: it has been
+// accepted and has not expired
+func (r *Reservation) Active(now time.Time) bool {
+	return r.Status == ReservationStatusAccepted && r.ExpiryDate.After(now)
+}
+
+// Expired reports whether the reservation was accepted but expired without being used
+func (r *Reservation) Expired(now time.Time) bool {
+	return r.Status == ReservationStatusAccepted && !r.ExpiryDate.After(now)
+}
+
+// Start returns the time from which the reservation holds the EVSE: the start of the
+// booked time slot, or now if the slot has started
+func (r *Reservation) Start(now time.Time) time.Time {
+	if r.StartDate.After(now) {
+		return r.StartDate
+	}
+	return now
+}
+
+// Scheduled reports whether the reservation is for a booked time slot and has not yet
+// been sent to the charge station
+func (r *Reservation) Scheduled(now time.Time) bool {
+	return r.Status == ReservationStatusPending && !r.StartDate.IsZero() &&
+		!now.After(r.StartDate.Add(-ReservationLeadTime))
+}
+
+// Unused reports whether the reservation started more than noShowAfter before now
+// without a transaction using it: it is still held at the charge station and should
+// be cancelled so that the EVSE can be used by others
+func (r *Reservation) Unused(now time.Time, noShowAfter time.Duration) bool {
+	return r.Status == ReservationStatusAccepted && r.Transfer == nil && !r.StartDate.IsZero() &&
+		now.After(r.StartDate.Add(noShowAfter))
+}
+
+// Overlaps reports whether the reservations can hold the same EVSE at the same time.
+// The reservations must be on the same charge station: a reservation without an EVSE
+// may use any of them.
+func (r *Reservation) Overlaps(other *Reservation, now time.Time) bool {
+	if r.ChargeStationId != other.ChargeStationId {
+		return false
+	}
+	if r.EvseId != nil && other.EvseId != nil && *r.EvseId != *other.EvseId {
+		return false
+	}
+	return r.Start(now).Before(other.ExpiryDate) && other.Start(now).Before(r.ExpiryDate)
+}
+
+// OverlappingReservation returns a reservation that is waiting to be sent or is held
+// at the charge station and overlaps the reservation, or nil if there is none
+func OverlappingReservation(ctx context.Context, engine ReservationStore, reservation *Reservation, now time.Time) (*Reservation, error) {
+	previousReservationId := 0
+	for {
+		page, err := engine.ListReservations(ctx, scanPageSize, previousReservationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range page {
+			if other.ReservationId == reservation.ReservationId || !other.ExpiryDate.After(now) ||
+				(other.Status != ReservationStatusPending && other.Status != ReservationStatusAccepted) {
+				continue
+			}
+			if reservation.Overlaps(other, now) {
+				return other, nil
+			}
+		}
+		if len(page) < scanPageSize {
+			return nil, nil
+		}
+		previousReservationId = page[len(page)-1].ReservationId
+	}
+}
+
+type ReservationStore interface {
+	// SetReservation writes the reservation if its Version matches the stored version
+	// (0 for a new reservation) and increments the Version: otherwise it returns
+	// ErrVersionConflict
+	SetReservation(ctx context.Context, reservation *Reservation) error
+	LookupReservation(ctx context.Context, reservationId int) (*Reservation, error)
+	ListReservations(ctx context.Context, pageSize int, previousReservationId int) ([]*Reservation, error)
+}
+
+// ReservationLockStore is implemented by engines that can hold a short-lived lock on a
+// reservation, so that only one manager instance sends a request for it at a time. A
+// lock that is not released expires after its ttl.
+type ReservationLockStore interface {
+	// LockReservation reports whether the lock was acquired: it is false if the lock is
+	// held by another manager instance
+	LockReservation(ctx context.Context, reservationId int, ttl time.Duration) (bool, error)
+	UnlockReservation(ctx context.Context, reservationId int) error
+}
@@ -0,0 +1,6 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package resilient provides a store.Engine that wraps another store.Engine,
+// retrying operations that fail with transient errors and tracking the health
+// of the underlying storage.
+package resilient
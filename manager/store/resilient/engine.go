@@ -0,0 +1,761 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package resilient
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+func (s *Store) SetChargeStationAuth(ctx context.Context, chargeStationId string, auth *store.ChargeStationAuth) error {
+	return s.do(ctx, "set charge station auth", func(ctx context.Context) error {
+		return s.engine.SetChargeStationAuth(ctx, chargeStationId, auth)
+	})
+}
+
+func (s *Store) LookupChargeStationAuth(ctx context.Context, chargeStationId string) (*store.ChargeStationAuth, error) {
+	return get(ctx, s, "lookup charge station auth", func(ctx context.Context) (*store.ChargeStationAuth, error) {
+		return s.engine.LookupChargeStationAuth(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) SetChargeStation(ctx context.Context, chargeStationId string, chargeStation *store.ChargeStation) error {
+	return s.do(ctx, "set charge station", func(ctx context.Context) error {
+		return s.engine.SetChargeStation(ctx, chargeStationId, chargeStation)
+	})
+}
+
+func (s *Store) LookupChargeStation(ctx context.Context, chargeStationId string) (*store.ChargeStation, error) {
+	return get(ctx, s, "lookup charge station", func(ctx context.Context) (*store.ChargeStation, error) {
+		return s.engine.LookupChargeStation(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) ListChargeStations(ctx context.Context, offset int, limit int) ([]*store.ChargeStation, error) {
+	return get(ctx, s, "list charge stations", func(ctx context.Context) ([]*store.ChargeStation, error) {
+		return s.engine.ListChargeStations(ctx, offset, limit)
+	})
+}
+
+func (s *Store) ListChargeStationsNearby(ctx context.Context, latitude, longitude, radius float64) ([]*store.ChargeStation, error) {
+	return get(ctx, s, "list charge stations nearby", func(ctx context.Context) ([]*store.ChargeStation, error) {
+		return s.engine.ListChargeStationsNearby(ctx, latitude, longitude, radius)
+	})
+}
+
+func (s *Store) DeleteChargeStation(ctx context.Context, chargeStationId string) error {
+	return s.do(ctx, "delete charge station", func(ctx context.Context) error {
+		return s.engine.DeleteChargeStation(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) UpdateChargeStationSettings(ctx context.Context, chargeStationId string, settings *store.ChargeStationSettings) error {
+	return s.do(ctx, "update charge station settings", func(ctx context.Context) error {
+		return s.engine.UpdateChargeStationSettings(ctx, chargeStationId, settings)
+	})
+}
+
+func (s *Store) LookupChargeStationSettings(ctx context.Context, chargeStationId string) (*store.ChargeStationSettings, error) {
+	return get(ctx, s, "lookup charge station settings", func(ctx context.Context) (*store.ChargeStationSettings, error) {
+		return s.engine.LookupChargeStationSettings(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) ListChargeStationSettings(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationSettings, error) {
+	return get(ctx, s, "list charge station settings", func(ctx context.Context) ([]*store.ChargeStationSettings, error) {
+		return s.engine.ListChargeStationSettings(ctx, pageSize, previousChargeStationId)
+	})
+}
+
+func (s *Store) DeleteChargeStationSettings(ctx context.Context, chargeStationId string) error {
+	return s.do(ctx, "delete charge station settings", func(ctx context.Context) error {
+		return s.engine.DeleteChargeStationSettings(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) SetChargeStationRuntimeDetails(ctx context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
+	return s.do(ctx, "set charge station runtime details", func(ctx context.Context) error {
+		return s.engine.SetChargeStationRuntimeDetails(ctx, chargeStationId, details)
+	})
+}
+
+func (s *Store) LookupChargeStationRuntimeDetails(ctx context.Context, chargeStationId string) (*store.ChargeStationRuntimeDetails, error) {
+	return get(ctx, s, "lookup charge station runtime details", func(ctx context.Context) (*store.ChargeStationRuntimeDetails, error) {
+		return s.engine.LookupChargeStationRuntimeDetails(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) ListChargeStationRuntimeDetails(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationRuntimeDetails, error) {
+	return get(ctx, s, "list charge station runtime details", func(ctx context.Context) ([]*store.ChargeStationRuntimeDetails, error) {
+		return s.engine.ListChargeStationRuntimeDetails(ctx, pageSize, previousChargeStationId)
+	})
+}
+
+func (s *Store) UpdateChargeStationInstallCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationInstallCertificates) error {
+	return s.do(ctx, "update charge station install certificates", func(ctx context.Context) error {
+		return s.engine.UpdateChargeStationInstallCertificates(ctx, chargeStationId, certificates)
+	})
+}
+
+func (s *Store) LookupChargeStationInstallCertificates(ctx context.Context, chargeStationId string) (*store.ChargeStationInstallCertificates, error) {
+	return get(ctx, s, "lookup charge station install certificates", func(ctx context.Context) (*store.ChargeStationInstallCertificates, error) {
+		return s.engine.LookupChargeStationInstallCertificates(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) ListChargeStationInstallCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationInstallCertificates, error) {
+	return get(ctx, s, "list charge station install certificates", func(ctx context.Context) ([]*store.ChargeStationInstallCertificates, error) {
+		return s.engine.ListChargeStationInstallCertificates(ctx, pageSize, previousChargeStationId)
+	})
+}
+
+func (s *Store) SetChargeStationInstalledCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationInstalledCertificates) error {
+	return s.do(ctx, "set charge station installed certificates", func(ctx context.Context) error {
+		return s.engine.SetChargeStationInstalledCertificates(ctx, chargeStationId, certificates)
+	})
+}
+
+func (s *Store) LookupChargeStationInstalledCertificates(ctx context.Context, chargeStationId string) (*store.ChargeStationInstalledCertificates, error) {
+	return get(ctx, s, "lookup charge station installed certificates", func(ctx context.Context) (*store.ChargeStationInstalledCertificates, error) {
+		return s.engine.LookupChargeStationInstalledCertificates(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) ListChargeStationInstalledCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationInstalledCertificates, error) {
+	return get(ctx, s, "list charge station installed certificates", func(ctx context.Context) ([]*store.ChargeStationInstalledCertificates, error) {
+		return s.engine.ListChargeStationInstalledCertificates(ctx, pageSize, previousChargeStationId)
+	})
+}
+
+func (s *Store) SetChargeStationProvisioning(ctx context.Context, chargeStationId string, provisioning *store.ChargeStationProvisioning) error {
+	return s.do(ctx, "set charge station provisioning", func(ctx context.Context) error {
+		return s.engine.SetChargeStationProvisioning(ctx, chargeStationId, provisioning)
+	})
+}
+
+func (s *Store) LookupChargeStationProvisioning(ctx context.Context, chargeStationId string) (*store.ChargeStationProvisioning, error) {
+	return get(ctx, s, "lookup charge station provisioning", func(ctx context.Context) (*store.ChargeStationProvisioning, error) {
+		return s.engine.LookupChargeStationProvisioning(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) ListChargeStationProvisioning(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationProvisioning, error) {
+	return get(ctx, s, "list charge station provisioning", func(ctx context.Context) ([]*store.ChargeStationProvisioning, error) {
+		return s.engine.ListChargeStationProvisioning(ctx, pageSize, previousChargeStationId)
+	})
+}
+
+func (s *Store) SetChargeStationRegistration(ctx context.Context, chargeStationId string, registration *store.ChargeStationRegistration) error {
+	return s.do(ctx, "set charge station registration", func(ctx context.Context) error {
+		return s.engine.SetChargeStationRegistration(ctx, chargeStationId, registration)
+	})
+}
+
+func (s *Store) LookupChargeStationRegistration(ctx context.Context, chargeStationId string) (*store.ChargeStationRegistration, error) {
+	return get(ctx, s, "lookup charge station registration", func(ctx context.Context) (*store.ChargeStationRegistration, error) {
+		return s.engine.LookupChargeStationRegistration(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) SetChargeStationLiveness(ctx context.Context, chargeStationId string, liveness *store.ChargeStationLiveness) error {
+	return s.do(ctx, "set charge station liveness", func(ctx context.Context) error {
+		return s.engine.SetChargeStationLiveness(ctx, chargeStationId, liveness)
+	})
+}
+
+func (s *Store) LookupChargeStationLiveness(ctx context.Context, chargeStationId string) (*store.ChargeStationLiveness, error) {
+	return get(ctx, s, "lookup charge station liveness", func(ctx context.Context) (*store.ChargeStationLiveness, error) {
+		return s.engine.LookupChargeStationLiveness(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) ListChargeStationLiveness(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationLiveness, error) {
+	return get(ctx, s, "list charge station liveness", func(ctx context.Context) ([]*store.ChargeStationLiveness, error) {
+		return s.engine.ListChargeStationLiveness(ctx, pageSize, previousChargeStationId)
+	})
+}
+
+func (s *Store) SetConnectorStatus(ctx context.Context, status *store.ConnectorStatus) error {
+	return s.do(ctx, "set connector status", func(ctx context.Context) error {
+		return s.engine.SetConnectorStatus(ctx, status)
+	})
+}
+
+func (s *Store) ListConnectorStatuses(ctx context.Context, chargeStationId string) ([]*store.ConnectorStatus, error) {
+	return get(ctx, s, "list connector statuses", func(ctx context.Context) ([]*store.ConnectorStatus, error) {
+		return s.engine.ListConnectorStatuses(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) SetEvse(ctx context.Context, evse *store.ChargeStationEvse) error {
+	return s.do(ctx, "set evse", func(ctx context.Context) error {
+		return s.engine.SetEvse(ctx, evse)
+	})
+}
+
+func (s *Store) LookupEvse(ctx context.Context, chargeStationId string, evseId int) (*store.ChargeStationEvse, error) {
+	return get(ctx, s, "lookup evse", func(ctx context.Context) (*store.ChargeStationEvse, error) {
+		return s.engine.LookupEvse(ctx, chargeStationId, evseId)
+	})
+}
+
+func (s *Store) ListEvses(ctx context.Context, chargeStationId string) ([]*store.ChargeStationEvse, error) {
+	return get(ctx, s, "list evses", func(ctx context.Context) ([]*store.ChargeStationEvse, error) {
+		return s.engine.ListEvses(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) SetChargeStationTriggerMessage(ctx context.Context, chargeStationId string, triggerMessage *store.ChargeStationTriggerMessage) error {
+	return s.do(ctx, "set charge station trigger message", func(ctx context.Context) error {
+		return s.engine.SetChargeStationTriggerMessage(ctx, chargeStationId, triggerMessage)
+	})
+}
+
+func (s *Store) DeleteChargeStationTriggerMessage(ctx context.Context, chargeStationId string) error {
+	return s.do(ctx, "delete charge station trigger message", func(ctx context.Context) error {
+		return s.engine.DeleteChargeStationTriggerMessage(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) LookupChargeStationTriggerMessage(ctx context.Context, chargeStationId string) (*store.ChargeStationTriggerMessage, error) {
+	return get(ctx, s, "lookup charge station trigger message", func(ctx context.Context) (*store.ChargeStationTriggerMessage, error) {
+		return s.engine.LookupChargeStationTriggerMessage(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationTriggerMessage, error) {
+	return get(ctx, s, "list charge station trigger messages", func(ctx context.Context) ([]*store.ChargeStationTriggerMessage, error) {
+		return s.engine.ListChargeStationTriggerMessages(ctx, pageSize, previousChargeStationId)
+	})
+}
+
+func (s *Store) SetToken(ctx context.Context, token *store.Token) error {
+	return s.do(ctx, "set token", func(ctx context.Context) error {
+		return s.engine.SetToken(ctx, token)
+	})
+}
+
+func (s *Store) LookupToken(ctx context.Context, tokenUid string) (*store.Token, error) {
+	return get(ctx, s, "lookup token", func(ctx context.Context) (*store.Token, error) {
+		return s.engine.LookupToken(ctx, tokenUid)
+	})
+}
+
+func (s *Store) ListTokens(ctx context.Context, offset int, limit int) ([]*store.Token, error) {
+	return get(ctx, s, "list tokens", func(ctx context.Context) ([]*store.Token, error) {
+		return s.engine.ListTokens(ctx, offset, limit)
+	})
+}
+
+func (s *Store) Transactions(ctx context.Context) ([]*store.Transaction, error) {
+	return get(ctx, s, "transactions", func(ctx context.Context) ([]*store.Transaction, error) {
+		return s.engine.Transactions(ctx)
+	})
+}
+
+func (s *Store) QueryTransactions(ctx context.Context, filter *store.TransactionFilter, offset, limit int) ([]*store.Transaction, error) {
+	return get(ctx, s, "query transactions", func(ctx context.Context) ([]*store.Transaction, error) {
+		return s.engine.QueryTransactions(ctx, filter, offset, limit)
+	})
+}
+
+func (s *Store) QueryTransactionsAfter(ctx context.Context, filter *store.TransactionFilter, pageSize int, previous *store.Transaction) ([]*store.Transaction, error) {
+	return get(ctx, s, "query transactions", func(ctx context.Context) ([]*store.Transaction, error) {
+		return s.engine.QueryTransactionsAfter(ctx, filter, pageSize, previous)
+	})
+}
+
+func (s *Store) FindTransaction(ctx context.Context, chargeStationId, transactionId string) (*store.Transaction, error) {
+	return get(ctx, s, "find transaction", func(ctx context.Context) (*store.Transaction, error) {
+		return s.engine.FindTransaction(ctx, chargeStationId, transactionId)
+	})
+}
+
+func (s *Store) CreateTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValue []store.MeterValue, seqNo int, offline bool) error {
+	return s.do(ctx, "create transaction", func(ctx context.Context) error {
+		return s.engine.CreateTransaction(ctx, chargeStationId, transactionId, idToken, tokenType, meterValue, seqNo, offline)
+	})
+}
+
+func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValue []store.MeterValue) error {
+	return s.do(ctx, "update transaction", func(ctx context.Context) error {
+		return s.engine.UpdateTransaction(ctx, chargeStationId, transactionId, meterValue)
+	})
+}
+
+func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValue []store.MeterValue, seqNo int) error {
+	return s.do(ctx, "end transaction", func(ctx context.Context) error {
+		return s.engine.EndTransaction(ctx, chargeStationId, transactionId, idToken, tokenType, meterValue, seqNo)
+	})
+}
+
+func (s *Store) MarkTransactionRecoveredFromOffline(ctx context.Context, chargeStationId, transactionId string) error {
+	return s.do(ctx, "mark transaction recovered from offline", func(ctx context.Context) error {
+		return s.engine.MarkTransactionRecoveredFromOffline(ctx, chargeStationId, transactionId)
+	})
+}
+
+func (s *Store) AddTransactionChargingState(ctx context.Context, chargeStationId, transactionId string, chargingState store.ChargingStateChange) error {
+	return s.do(ctx, "add transaction charging state", func(ctx context.Context) error {
+		return s.engine.AddTransactionChargingState(ctx, chargeStationId, transactionId, chargingState)
+	})
+}
+
+func (s *Store) SetTransactionCost(ctx context.Context, chargeStationId, transactionId string, cost *store.CostBreakdown) error {
+	return s.do(ctx, "set transaction cost", func(ctx context.Context) error {
+		return s.engine.SetTransactionCost(ctx, chargeStationId, transactionId, cost)
+	})
+}
+
+// WriteTransactionBatch retries the whole batch: it is only atomic if the underlying
+// engine is a store.TransactionBatchStore
+func (s *Store) WriteTransactionBatch(ctx context.Context, batch *store.TransactionBatch) error {
+	return s.do(ctx, "write transaction batch", func(ctx context.Context) error {
+		return store.WriteTransactionBatch(ctx, s.engine, batch)
+	})
+}
+
+// FleetStats uses the underlying engine's aggregation if it is a store.FleetStatsStore
+func (s *Store) FleetStats(ctx context.Context, since, now time.Time) (*store.FleetStats, error) {
+	return get(ctx, s, "fleet stats", func(ctx context.Context) (*store.FleetStats, error) {
+		return store.ComputeFleetStats(ctx, s.engine, since, now)
+	})
+}
+
+// ListTenantChargeStations uses the underlying engine's tenant selection if it is a
+// store.TenantStore
+func (s *Store) ListTenantChargeStations(ctx context.Context, tenantId string, offset, limit int) ([]*store.ChargeStation, error) {
+	return get(ctx, s, "list tenant charge stations", func(ctx context.Context) ([]*store.ChargeStation, error) {
+		return store.ListTenantChargeStations(ctx, s.engine, tenantId, offset, limit)
+	})
+}
+
+func (s *Store) ListTenantTokens(ctx context.Context, tenantId string, offset, limit int) ([]*store.Token, error) {
+	return get(ctx, s, "list tenant tokens", func(ctx context.Context) ([]*store.Token, error) {
+		return store.ListTenantTokens(ctx, s.engine, tenantId, offset, limit)
+	})
+}
+
+func (s *Store) ListTenantSites(ctx context.Context, tenantId string, offset, limit int) ([]*store.Site, error) {
+	return get(ctx, s, "list tenant sites", func(ctx context.Context) ([]*store.Site, error) {
+		return store.ListTenantSites(ctx, s.engine, tenantId, offset, limit)
+	})
+}
+
+func (s *Store) ListTenantTags(ctx context.Context, tenantId string, offset, limit int) ([]*store.Tag, error) {
+	return get(ctx, s, "list tenant tags", func(ctx context.Context) ([]*store.Tag, error) {
+		return store.ListTenantTags(ctx, s.engine, tenantId, offset, limit)
+	})
+}
+
+func (s *Store) AddMeterValues(ctx context.Context, chargeStationId string, evseId int, meterValues []store.MeterValue) error {
+	return s.do(ctx, "add meter values", func(ctx context.Context) error {
+		return s.engine.AddMeterValues(ctx, chargeStationId, evseId, meterValues)
+	})
+}
+
+func (s *Store) LookupMeterValues(ctx context.Context, chargeStationId string, evseId int) (*store.EvseMeterValues, error) {
+	return get(ctx, s, "lookup meter values", func(ctx context.Context) (*store.EvseMeterValues, error) {
+		return s.engine.LookupMeterValues(ctx, chargeStationId, evseId)
+	})
+}
+
+func (s *Store) PurgeMeterValues(ctx context.Context, before time.Time) (int, error) {
+	return get(ctx, s, "purge meter values", func(ctx context.Context) (int, error) {
+		return s.engine.PurgeMeterValues(ctx, before)
+	})
+}
+
+func (s *Store) AnonymizeTransactions(ctx context.Context, before time.Time) (int, error) {
+	return get(ctx, s, "anonymize transactions", func(ctx context.Context) (int, error) {
+		return s.engine.AnonymizeTransactions(ctx, before)
+	})
+}
+
+func (s *Store) SetCertificate(ctx context.Context, pemCertificate string) error {
+	return s.do(ctx, "set certificate", func(ctx context.Context) error {
+		return s.engine.SetCertificate(ctx, pemCertificate)
+	})
+}
+
+func (s *Store) LookupCertificate(ctx context.Context, certificateHash string) (string, error) {
+	return get(ctx, s, "lookup certificate", func(ctx context.Context) (string, error) {
+		return s.engine.LookupCertificate(ctx, certificateHash)
+	})
+}
+
+func (s *Store) DeleteCertificate(ctx context.Context, certificateHash string) error {
+	return s.do(ctx, "delete certificate", func(ctx context.Context) error {
+		return s.engine.DeleteCertificate(ctx, certificateHash)
+	})
+}
+
+func (s *Store) SetRegistrationDetails(ctx context.Context, token string, registration *store.OcpiRegistration) error {
+	return s.do(ctx, "set registration details", func(ctx context.Context) error {
+		return s.engine.SetRegistrationDetails(ctx, token, registration)
+	})
+}
+
+func (s *Store) GetRegistrationDetails(ctx context.Context, token string) (*store.OcpiRegistration, error) {
+	return get(ctx, s, "get registration details", func(ctx context.Context) (*store.OcpiRegistration, error) {
+		return s.engine.GetRegistrationDetails(ctx, token)
+	})
+}
+
+func (s *Store) DeleteRegistrationDetails(ctx context.Context, token string) error {
+	return s.do(ctx, "delete registration details", func(ctx context.Context) error {
+		return s.engine.DeleteRegistrationDetails(ctx, token)
+	})
+}
+
+func (s *Store) SetPartyDetails(ctx context.Context, partyDetails *store.OcpiParty) error {
+	return s.do(ctx, "set party details", func(ctx context.Context) error {
+		return s.engine.SetPartyDetails(ctx, partyDetails)
+	})
+}
+
+func (s *Store) GetPartyDetails(ctx context.Context, role, countryCode, partyId string) (*store.OcpiParty, error) {
+	return get(ctx, s, "get party details", func(ctx context.Context) (*store.OcpiParty, error) {
+		return s.engine.GetPartyDetails(ctx, role, countryCode, partyId)
+	})
+}
+
+func (s *Store) ListPartyDetailsForRole(ctx context.Context, role string) ([]*store.OcpiParty, error) {
+	return get(ctx, s, "list party details for role", func(ctx context.Context) ([]*store.OcpiParty, error) {
+		return s.engine.ListPartyDetailsForRole(ctx, role)
+	})
+}
+
+func (s *Store) DeletePartyDetails(ctx context.Context, role, countryCode, partyId string) error {
+	return s.do(ctx, "delete party details", func(ctx context.Context) error {
+		return s.engine.DeletePartyDetails(ctx, role, countryCode, partyId)
+	})
+}
+
+func (s *Store) SetPendingCommand(ctx context.Context, command *store.OcpiCommand) error {
+	return s.do(ctx, "set pending command", func(ctx context.Context) error {
+		return s.engine.SetPendingCommand(ctx, command)
+	})
+}
+
+func (s *Store) LookupPendingCommand(ctx context.Context, command, chargeStationId, reference string) (*store.OcpiCommand, error) {
+	return get(ctx, s, "lookup pending command", func(ctx context.Context) (*store.OcpiCommand, error) {
+		return s.engine.LookupPendingCommand(ctx, command, chargeStationId, reference)
+	})
+}
+
+func (s *Store) DeletePendingCommand(ctx context.Context, command, chargeStationId, reference string) error {
+	return s.do(ctx, "delete pending command", func(ctx context.Context) error {
+		return s.engine.DeletePendingCommand(ctx, command, chargeStationId, reference)
+	})
+}
+
+func (s *Store) SetPendingPush(ctx context.Context, push *store.OcpiPush) error {
+	return s.do(ctx, "set pending push", func(ctx context.Context) error {
+		return s.engine.SetPendingPush(ctx, push)
+	})
+}
+
+func (s *Store) ListPendingPushes(ctx context.Context, pageSize int, previousId string) ([]*store.OcpiPush, error) {
+	return get(ctx, s, "list pending pushes", func(ctx context.Context) ([]*store.OcpiPush, error) {
+		return s.engine.ListPendingPushes(ctx, pageSize, previousId)
+	})
+}
+
+func (s *Store) DeletePendingPush(ctx context.Context, id string) error {
+	return s.do(ctx, "delete pending push", func(ctx context.Context) error {
+		return s.engine.DeletePendingPush(ctx, id)
+	})
+}
+
+func (s *Store) SetOicpSession(ctx context.Context, session *store.OicpSession) error {
+	return s.do(ctx, "set oicp session", func(ctx context.Context) error {
+		return s.engine.SetOicpSession(ctx, session)
+	})
+}
+
+func (s *Store) LookupOicpSession(ctx context.Context, idToken string) (*store.OicpSession, error) {
+	return get(ctx, s, "lookup oicp session", func(ctx context.Context) (*store.OicpSession, error) {
+		return s.engine.LookupOicpSession(ctx, idToken)
+	})
+}
+
+func (s *Store) DeleteOicpSession(ctx context.Context, idToken string) error {
+	return s.do(ctx, "delete oicp session", func(ctx context.Context) error {
+		return s.engine.DeleteOicpSession(ctx, idToken)
+	})
+}
+
+func (s *Store) SetLocation(ctx context.Context, location *store.Location) error {
+	return s.do(ctx, "set location", func(ctx context.Context) error {
+		return s.engine.SetLocation(ctx, location)
+	})
+}
+
+func (s *Store) LookupLocation(ctx context.Context, locationId string) (*store.Location, error) {
+	return get(ctx, s, "lookup location", func(ctx context.Context) (*store.Location, error) {
+		return s.engine.LookupLocation(ctx, locationId)
+	})
+}
+
+func (s *Store) ListLocations(ctx context.Context, offset int, limit int) ([]*store.Location, error) {
+	return get(ctx, s, "list locations", func(ctx context.Context) ([]*store.Location, error) {
+		return s.engine.ListLocations(ctx, offset, limit)
+	})
+}
+
+func (s *Store) SetChargeDetailRecord(ctx context.Context, cdr *store.ChargeDetailRecord) error {
+	return s.do(ctx, "set charge detail record", func(ctx context.Context) error {
+		return s.engine.SetChargeDetailRecord(ctx, cdr)
+	})
+}
+
+func (s *Store) LookupChargeDetailRecord(ctx context.Context, cdrId string) (*store.ChargeDetailRecord, error) {
+	return get(ctx, s, "lookup charge detail record", func(ctx context.Context) (*store.ChargeDetailRecord, error) {
+		return s.engine.LookupChargeDetailRecord(ctx, cdrId)
+	})
+}
+
+func (s *Store) ListChargeDetailRecords(ctx context.Context, offset int, limit int) ([]*store.ChargeDetailRecord, error) {
+	return get(ctx, s, "list charge detail records", func(ctx context.Context) ([]*store.ChargeDetailRecord, error) {
+		return s.engine.ListChargeDetailRecords(ctx, offset, limit)
+	})
+}
+
+func (s *Store) QueryChargeDetailRecords(ctx context.Context, filter *store.ChargeDetailRecordFilter, pageSize int, previousCdrId string) ([]*store.ChargeDetailRecord, error) {
+	return get(ctx, s, "query charge detail records", func(ctx context.Context) ([]*store.ChargeDetailRecord, error) {
+		return s.engine.QueryChargeDetailRecords(ctx, filter, pageSize, previousCdrId)
+	})
+}
+
+func (s *Store) SetTariff(ctx context.Context, tariff *store.Tariff) error {
+	return s.do(ctx, "set tariff", func(ctx context.Context) error {
+		return s.engine.SetTariff(ctx, tariff)
+	})
+}
+
+func (s *Store) LookupTariff(ctx context.Context, tariffId string) (*store.Tariff, error) {
+	return get(ctx, s, "lookup tariff", func(ctx context.Context) (*store.Tariff, error) {
+		return s.engine.LookupTariff(ctx, tariffId)
+	})
+}
+
+func (s *Store) ListTariffs(ctx context.Context, offset int, limit int) ([]*store.Tariff, error) {
+	return get(ctx, s, "list tariffs", func(ctx context.Context) ([]*store.Tariff, error) {
+		return s.engine.ListTariffs(ctx, offset, limit)
+	})
+}
+
+func (s *Store) SetReservation(ctx context.Context, reservation *store.Reservation) error {
+	return s.do(ctx, "set reservation", func(ctx context.Context) error {
+		return s.engine.SetReservation(ctx, reservation)
+	})
+}
+
+func (s *Store) LookupReservation(ctx context.Context, reservationId int) (*store.Reservation, error) {
+	return get(ctx, s, "lookup reservation", func(ctx context.Context) (*store.Reservation, error) {
+		return s.engine.LookupReservation(ctx, reservationId)
+	})
+}
+
+func (s *Store) ListReservations(ctx context.Context, pageSize int, previousReservationId int) ([]*store.Reservation, error) {
+	return get(ctx, s, "list reservations", func(ctx context.Context) ([]*store.Reservation, error) {
+		return s.engine.ListReservations(ctx, pageSize, previousReservationId)
+	})
+}
+
+// LockReservation always acquires the lock if the underlying engine cannot hold locks
+func (s *Store) LockReservation(ctx context.Context, reservationId int, ttl time.Duration) (bool, error) {
+	locker, ok := s.engine.(store.ReservationLockStore)
+	if !ok {
+		return true, nil
+	}
+	return get(ctx, s, "lock reservation", func(ctx context.Context) (bool, error) {
+		return locker.LockReservation(ctx, reservationId, ttl)
+	})
+}
+
+func (s *Store) UnlockReservation(ctx context.Context, reservationId int) error {
+	locker, ok := s.engine.(store.ReservationLockStore)
+	if !ok {
+		return nil
+	}
+	return s.do(ctx, "unlock reservation", func(ctx context.Context) error {
+		return locker.UnlockReservation(ctx, reservationId)
+	})
+}
+
+func (s *Store) SetExiResponseChunks(ctx context.Context, chunks *store.ExiResponseChunks) error {
+	return s.do(ctx, "set exi response chunks", func(ctx context.Context) error {
+		return s.engine.SetExiResponseChunks(ctx, chunks)
+	})
+}
+
+func (s *Store) LookupExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) (*store.ExiResponseChunks, error) {
+	return get(ctx, s, "lookup exi response chunks", func(ctx context.Context) (*store.ExiResponseChunks, error) {
+		return s.engine.LookupExiResponseChunks(ctx, chargeStationId, exiRequestHash)
+	})
+}
+
+func (s *Store) DeleteExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) error {
+	return s.do(ctx, "delete exi response chunks", func(ctx context.Context) error {
+		return s.engine.DeleteExiResponseChunks(ctx, chargeStationId, exiRequestHash)
+	})
+}
+
+func (s *Store) SetOcspResponse(ctx context.Context, response *store.OcspResponse) error {
+	return s.do(ctx, "set ocsp response", func(ctx context.Context) error {
+		return s.engine.SetOcspResponse(ctx, response)
+	})
+}
+
+func (s *Store) LookupOcspResponse(ctx context.Context, key string) (*store.OcspResponse, error) {
+	return get(ctx, s, "lookup ocsp response", func(ctx context.Context) (*store.OcspResponse, error) {
+		return s.engine.LookupOcspResponse(ctx, key)
+	})
+}
+
+func (s *Store) ListOcspResponses(ctx context.Context, pageSize int, previousKey string) ([]*store.OcspResponse, error) {
+	return get(ctx, s, "list ocsp responses", func(ctx context.Context) ([]*store.OcspResponse, error) {
+		return s.engine.ListOcspResponses(ctx, pageSize, previousKey)
+	})
+}
+
+func (s *Store) SetOutboundCallQueue(ctx context.Context, queue *store.OutboundCallQueue) error {
+	return s.do(ctx, "set outbound call queue", func(ctx context.Context) error {
+		return s.engine.SetOutboundCallQueue(ctx, queue)
+	})
+}
+
+func (s *Store) LookupOutboundCallQueue(ctx context.Context, chargeStationId string) (*store.OutboundCallQueue, error) {
+	return get(ctx, s, "lookup outbound call queue", func(ctx context.Context) (*store.OutboundCallQueue, error) {
+		return s.engine.LookupOutboundCallQueue(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) DeleteOutboundCallQueue(ctx context.Context, chargeStationId string) error {
+	return s.do(ctx, "delete outbound call queue", func(ctx context.Context) error {
+		return s.engine.DeleteOutboundCallQueue(ctx, chargeStationId)
+	})
+}
+
+func (s *Store) SetCommandAuditRecord(ctx context.Context, record *store.CommandAuditRecord) error {
+	return s.do(ctx, "set command audit record", func(ctx context.Context) error {
+		return s.engine.SetCommandAuditRecord(ctx, record)
+	})
+}
+
+func (s *Store) LookupCommandAuditRecord(ctx context.Context, id string) (*store.CommandAuditRecord, error) {
+	return get(ctx, s, "lookup command audit record", func(ctx context.Context) (*store.CommandAuditRecord, error) {
+		return s.engine.LookupCommandAuditRecord(ctx, id)
+	})
+}
+
+func (s *Store) QueryCommandAuditRecords(ctx context.Context, filter *store.CommandAuditFilter, offset, limit int) ([]*store.CommandAuditRecord, error) {
+	return get(ctx, s, "query command audit records", func(ctx context.Context) ([]*store.CommandAuditRecord, error) {
+		return s.engine.QueryCommandAuditRecords(ctx, filter, offset, limit)
+	})
+}
+
+func (s *Store) SetBulkCommand(ctx context.Context, bulkCommand *store.BulkCommand) error {
+	return s.do(ctx, "set bulk command", func(ctx context.Context) error {
+		return s.engine.SetBulkCommand(ctx, bulkCommand)
+	})
+}
+
+func (s *Store) LookupBulkCommand(ctx context.Context, id string) (*store.BulkCommand, error) {
+	return get(ctx, s, "lookup bulk command", func(ctx context.Context) (*store.BulkCommand, error) {
+		return s.engine.LookupBulkCommand(ctx, id)
+	})
+}
+
+func (s *Store) ListBulkCommands(ctx context.Context, pageSize int, previousId string) ([]*store.BulkCommand, error) {
+	return get(ctx, s, "list bulk commands", func(ctx context.Context) ([]*store.BulkCommand, error) {
+		return s.engine.ListBulkCommands(ctx, pageSize, previousId)
+	})
+}
+
+func (s *Store) SetSite(ctx context.Context, site *store.Site) error {
+	return s.do(ctx, "set site", func(ctx context.Context) error {
+		return s.engine.SetSite(ctx, site)
+	})
+}
+
+func (s *Store) LookupSite(ctx context.Context, siteId string) (*store.Site, error) {
+	return get(ctx, s, "lookup site", func(ctx context.Context) (*store.Site, error) {
+		return s.engine.LookupSite(ctx, siteId)
+	})
+}
+
+func (s *Store) ListSites(ctx context.Context, offset int, limit int) ([]*store.Site, error) {
+	return get(ctx, s, "list sites", func(ctx context.Context) ([]*store.Site, error) {
+		return s.engine.ListSites(ctx, offset, limit)
+	})
+}
+
+func (s *Store) DeleteSite(ctx context.Context, siteId string) error {
+	return s.do(ctx, "delete site", func(ctx context.Context) error {
+		return s.engine.DeleteSite(ctx, siteId)
+	})
+}
+
+func (s *Store) SetTag(ctx context.Context, tag *store.Tag) error {
+	return s.do(ctx, "set tag", func(ctx context.Context) error {
+		return s.engine.SetTag(ctx, tag)
+	})
+}
+
+func (s *Store) LookupTag(ctx context.Context, name string) (*store.Tag, error) {
+	return get(ctx, s, "lookup tag", func(ctx context.Context) (*store.Tag, error) {
+		return s.engine.LookupTag(ctx, name)
+	})
+}
+
+func (s *Store) ListTags(ctx context.Context, offset int, limit int) ([]*store.Tag, error) {
+	return get(ctx, s, "list tags", func(ctx context.Context) ([]*store.Tag, error) {
+		return s.engine.ListTags(ctx, offset, limit)
+	})
+}
+
+func (s *Store) DeleteTag(ctx context.Context, name string) error {
+	return s.do(ctx, "delete tag", func(ctx context.Context) error {
+		return s.engine.DeleteTag(ctx, name)
+	})
+}
+
+func (s *Store) SetCommandSchedule(ctx context.Context, schedule *store.CommandSchedule) error {
+	return s.do(ctx, "set command schedule", func(ctx context.Context) error {
+		return s.engine.SetCommandSchedule(ctx, schedule)
+	})
+}
+
+func (s *Store) LookupCommandSchedule(ctx context.Context, id string) (*store.CommandSchedule, error) {
+	return get(ctx, s, "lookup command schedule", func(ctx context.Context) (*store.CommandSchedule, error) {
+		return s.engine.LookupCommandSchedule(ctx, id)
+	})
+}
+
+func (s *Store) ListCommandSchedules(ctx context.Context, pageSize int, previousId string) ([]*store.CommandSchedule, error) {
+	return get(ctx, s, "list command schedules", func(ctx context.Context) ([]*store.CommandSchedule, error) {
+		return s.engine.ListCommandSchedules(ctx, pageSize, previousId)
+	})
+}
+
+func (s *Store) DeleteCommandSchedule(ctx context.Context, id string) error {
+	return s.do(ctx, "delete command schedule", func(ctx context.Context) error {
+		return s.engine.DeleteCommandSchedule(ctx, id)
+	})
+}
+
+func (s *Store) SetLease(ctx context.Context, lease *store.Lease) error {
+	return s.do(ctx, "set lease", func(ctx context.Context) error {
+		return s.engine.SetLease(ctx, lease)
+	})
+}
+
+func (s *Store) LookupLease(ctx context.Context, name string) (*store.Lease, error) {
+	return get(ctx, s, "lookup lease", func(ctx context.Context) (*store.Lease, error) {
+		return s.engine.LookupLease(ctx, name)
+	})
+}
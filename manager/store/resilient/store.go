@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package resilient
+
+import (
+	"context"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/clock"
+	"sync"
+	"time"
+)
+
+var storeOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "manager_store_operation_duration_seconds",
+	Help:    "The time taken by store operations, including any retries, by operation and outcome",
+	Buckets: prometheus.DefBuckets,
+}, []string{"operation", "outcome"})
+
+// Store is a store.Engine that delegates to another store.Engine. Operations
+// that fail with a transient error are retried with exponential backoff up to
+// a bounded number of attempts. Retries stop as soon as the context is done or
+// the context deadline would expire before the next attempt.
+type Store struct {
+	sync.Mutex
+	engine              store.Engine
+	clock               clock.Clock
+	maxAttempts         int
+	initialBackoff      time.Duration
+	maxBackoff          time.Duration
+	unhealthyAfter      int
+	consecutiveFailures int
+	lastErr             error
+}
+
+type Opt func(s *Store)
+
+// WithMaxAttempts sets the maximum number of attempts made for each operation,
+// including the first.
+func WithMaxAttempts(maxAttempts int) Opt {
+	return func(s *Store) {
+		s.maxAttempts = maxAttempts
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the upper bound of the
+// delay between retries: the delay doubles after each attempt.
+func WithBackoff(initialBackoff, maxBackoff time.Duration) Opt {
+	return func(s *Store) {
+		s.initialBackoff = initialBackoff
+		s.maxBackoff = maxBackoff
+	}
+}
+
+// WithUnhealthyAfter sets the number of consecutive operations that must fail
+// with a transient error before the store reports itself as unhealthy.
+func WithUnhealthyAfter(unhealthyAfter int) Opt {
+	return func(s *Store) {
+		s.unhealthyAfter = unhealthyAfter
+	}
+}
+
+func NewStore(engine store.Engine, clock clock.Clock, opts ...Opt) *Store {
+	s := &Store{
+		engine: engine,
+		clock:  clock,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	ensureDefaults(s)
+	return s
+}
+
+func ensureDefaults(s *Store) {
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = 3
+	}
+	if s.initialBackoff <= 0 {
+		s.initialBackoff = 100 * time.Millisecond
+	}
+	if s.maxBackoff <= 0 {
+		s.maxBackoff = time.Second
+	}
+	if s.maxBackoff < s.initialBackoff {
+		s.maxBackoff = s.initialBackoff
+	}
+	if s.unhealthyAfter <= 0 {
+		s.unhealthyAfter = 3
+	}
+}
+
+// Healthy returns an error if the most recent operations against the
+// underlying store have all failed with transient errors.
+func (s *Store) Healthy() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.consecutiveFailures >= s.unhealthyAfter {
+		return fmt.Errorf("storage unavailable after %d failed operations: %w", s.consecutiveFailures, s.lastErr)
+	}
+	return nil
+}
+
+// Close closes the underlying engine
+func (s *Store) Close() error {
+	return store.Close(s.engine)
+}
+
+// IsTransient reports whether err is likely to succeed if the operation is
+// retried, based on its gRPC status code. It is used for the errors of engines that
+// do not classify their own errors, and of engines such as firestore that return
+// gRPC errors.
+func IsTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransient reports whether err is transient, either by its gRPC status code or
+// because the underlying engine classifies it as transient
+func (s *Store) isTransient(err error) bool {
+	return IsTransient(err) || store.IsTransient(s.engine, err)
+}
+
+func (s *Store) recordResult(err error) {
+	s.Lock()
+	defer s.Unlock()
+	if err != nil && s.isTransient(err) {
+		s.consecutiveFailures++
+		s.lastErr = err
+	} else {
+		s.consecutiveFailures = 0
+		s.lastErr = nil
+	}
+}
+
+func (s *Store) do(ctx context.Context, op string, fn func(ctx context.Context) error) (err error) {
+	start := s.clock.Now()
+	defer func() {
+		outcome := "ok"
+		if err != nil {
+			outcome = "error"
+		}
+		storeOperationDuration.WithLabelValues(op, outcome).Observe(s.clock.Since(start).Seconds())
+	}()
+
+	backoff := s.initialBackoff
+	for attempt := 1; ; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err == nil {
+				return ctxErr
+			}
+			s.recordResult(err)
+			return err
+		}
+
+		err = fn(ctx)
+		if err == nil || !s.isTransient(err) {
+			s.recordResult(err)
+			return err
+		}
+
+		if attempt >= s.maxAttempts {
+			s.recordResult(err)
+			return fmt.Errorf("%s failed after %d attempts: %w", op, attempt, err)
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			s.recordResult(err)
+			return fmt.Errorf("%s: deadline too close to retry: %w", op, err)
+		}
+
+		slog.Warn("store operation", slog.String("op", op), slog.Int("attempt", attempt), slog.Int("maxAttempts", s.maxAttempts), "error", err)
+
+		select {
+		case <-ctx.Done():
+			s.recordResult(err)
+			return err
+		case <-s.clock.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+func get[T any](ctx context.Context, s *Store, op string, fn func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := s.do(ctx, op, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
+		return err
+	})
+	return result, err
+}
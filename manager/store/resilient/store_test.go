@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package resilient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/store/resilient"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+type flakyEngine struct {
+	store.Engine
+	errs  []error
+	calls int
+}
+
+func (f *flakyEngine) LookupToken(ctx context.Context, tokenUid string) (*store.Token, error) {
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	return f.Engine.LookupToken(ctx, tokenUid)
+}
+
+func newFlakyEngine(t *testing.T, errs ...error) *flakyEngine {
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetToken(context.Background(), &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "DEADBEEF",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   "ALWAYS",
+	})
+	require.NoError(t, err)
+	return &flakyEngine{Engine: engine, errs: errs}
+}
+
+func TestStoreRetriesTransientErrors(t *testing.T) {
+	engine := newFlakyEngine(t,
+		status.Error(codes.Unavailable, "unavailable"),
+		status.Error(codes.Aborted, "aborted"))
+	s := resilient.NewStore(engine, clock.RealClock{}, resilient.WithBackoff(time.Millisecond, 2*time.Millisecond))
+
+	got, err := s.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+
+	assert.Equal(t, "DEADBEEF", got.Uid)
+	assert.Equal(t, 3, engine.calls)
+	assert.NoError(t, s.Healthy())
+}
+
+func TestStoreDoesNotRetryPermanentErrors(t *testing.T) {
+	engine := newFlakyEngine(t, errors.New("broken"))
+	s := resilient.NewStore(engine, clock.RealClock{}, resilient.WithBackoff(time.Millisecond, 2*time.Millisecond))
+
+	_, err := s.LookupToken(context.Background(), "DEADBEEF")
+	assert.EqualError(t, err, "broken")
+	assert.Equal(t, 1, engine.calls)
+	assert.NoError(t, s.Healthy())
+}
+
+// classifyingEngine is a flaky engine that classifies its own errors
+type classifyingEngine struct {
+	*flakyEngine
+	transient error
+}
+
+func (c *classifyingEngine) IsTransient(err error) bool {
+	return errors.Is(err, c.transient)
+}
+
+func TestStoreRetriesErrorsTheEngineClassifiesAsTransient(t *testing.T) {
+	busy := errors.New("database is locked (5) (SQLITE_BUSY)")
+	engine := &classifyingEngine{
+		flakyEngine: newFlakyEngine(t, fmt.Errorf("lookup token: %w", busy)),
+		transient:   busy,
+	}
+	s := resilient.NewStore(engine, clock.RealClock{}, resilient.WithBackoff(time.Millisecond, 2*time.Millisecond))
+
+	got, err := s.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+
+	assert.Equal(t, "DEADBEEF", got.Uid)
+	assert.Equal(t, 2, engine.calls)
+}
+
+func TestStoreGivesUpAfterMaxAttempts(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	engine := newFlakyEngine(t, unavailable, unavailable, unavailable, unavailable)
+	s := resilient.NewStore(engine, clock.RealClock{},
+		resilient.WithMaxAttempts(2),
+		resilient.WithBackoff(time.Millisecond, 2*time.Millisecond))
+
+	_, err := s.LookupToken(context.Background(), "DEADBEEF")
+	assert.ErrorIs(t, err, unavailable)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 2, engine.calls)
+}
+
+func TestStoreFailsFastWhenDeadlineTooClose(t *testing.T) {
+	engine := newFlakyEngine(t, status.Error(codes.Unavailable, "unavailable"))
+	s := resilient.NewStore(engine, clock.RealClock{}, resilient.WithBackoff(time.Minute, time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := s.LookupToken(ctx, "DEADBEEF")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, engine.calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestStoreDoesNotCallEngineWhenContextDone(t *testing.T) {
+	engine := newFlakyEngine(t)
+	s := resilient.NewStore(engine, clock.RealClock{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.LookupToken(ctx, "DEADBEEF")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, engine.calls)
+}
+
+func TestStoreReportsUnhealthyAfterConsecutiveFailures(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	engine := newFlakyEngine(t, unavailable, unavailable)
+	s := resilient.NewStore(engine, clock.RealClock{},
+		resilient.WithMaxAttempts(1),
+		resilient.WithUnhealthyAfter(2))
+
+	_, err := s.LookupToken(context.Background(), "DEADBEEF")
+	assert.Error(t, err)
+	assert.NoError(t, s.Healthy())
+
+	_, err = s.LookupToken(context.Background(), "DEADBEEF")
+	assert.Error(t, err)
+	assert.ErrorIs(t, s.Healthy(), unavailable)
+
+	_, err = s.LookupToken(context.Background(), "DEADBEEF")
+	assert.NoError(t, err)
+	assert.NoError(t, s.Healthy())
+}
+
+func TestStoreLocksReservationsWhenEngineCannotLock(t *testing.T) {
+	s := resilient.NewStore(inmemory.NewStore(clock.RealClock{}), clock.RealClock{})
+
+	locked, err := s.LockReservation(context.Background(), 1, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, locked)
+	assert.NoError(t, s.UnlockReservation(context.Background(), 1))
+}
+
+func TestStoreRecordsOperationDuration(t *testing.T) {
+	engine := newFlakyEngine(t, status.Error(codes.PermissionDenied, "denied"))
+	s := resilient.NewStore(engine, clock.RealClock{})
+	okLabels := map[string]string{"operation": "lookup token", "outcome": "ok"}
+	errorLabels := map[string]string{"operation": "lookup token", "outcome": "error"}
+	okBefore := testutil.MetricValue(t, "manager_store_operation_duration_seconds", okLabels)
+	errorBefore := testutil.MetricValue(t, "manager_store_operation_duration_seconds", errorLabels)
+
+	_, err := s.LookupToken(context.Background(), "DEADBEEF")
+	require.Error(t, err)
+	_, err = s.LookupToken(context.Background(), "DEADBEEF")
+	require.NoError(t, err)
+
+	assert.Equal(t, okBefore+1, testutil.MetricValue(t, "manager_store_operation_duration_seconds", okLabels))
+	assert.Equal(t, errorBefore+1, testutil.MetricValue(t, "manager_store_operation_duration_seconds", errorLabels))
+}
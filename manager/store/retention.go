@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// RetentionStore removes or anonymizes data once it is no longer needed, so that
+// personal data and storage costs are not kept indefinitely.
+type RetentionStore interface {
+	// PurgeMeterValues removes the EVSE meter values with a timestamp before the cutoff
+	// and returns the number of meter values that were removed. The meter values of
+	// transactions are kept so that their cost can still be calculated.
+	PurgeMeterValues(ctx context.Context, before time.Time) (int, error)
+	// AnonymizeTransactions removes the token from ended transactions that started
+	// before the cutoff and returns the number of transactions that were changed
+	AnonymizeTransactions(ctx context.Context, before time.Time) (int, error)
+}
+
+// RetainMeterValues returns the meter values with a timestamp at or after the cutoff
+// and the number of meter values that were removed. Meter values whose timestamp
+// cannot be parsed are kept.
+func RetainMeterValues(meterValues []MeterValue, before time.Time) ([]MeterValue, int) {
+	retained := make([]MeterValue, 0, len(meterValues))
+	for _, meterValue := range meterValues {
+		ts, err := time.Parse(time.RFC3339, meterValue.Timestamp)
+		if err == nil && ts.Before(before) {
+			continue
+		}
+		retained = append(retained, meterValue)
+	}
+	return retained, len(meterValues) - len(retained)
+}
+
+// Anonymize removes the token from the transaction if it has ended and started
+// before the cutoff: it reports whether the transaction was changed
+func (t *Transaction) Anonymize(before time.Time) bool {
+	if t.Status() != TransactionStatusEnded || (t.IdToken == "" && t.TokenType == "") {
+		return false
+	}
+	startTime, ok := t.StartTime()
+	if !ok || !startTime.Before(before) {
+		return false
+	}
+	t.IdToken = ""
+	t.TokenType = ""
+	return true
+}
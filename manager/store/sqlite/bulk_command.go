@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetBulkCommand(ctx context.Context, bulkCommand *store.BulkCommand) error {
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		existing, err := get[store.BulkCommand](ctx, tx, "bulk_commands", bulkCommand.Id)
+		if err != nil {
+			return err
+		}
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if bulkCommand.Version != version {
+			return store.ErrVersionConflict
+		}
+		clone := *bulkCommand
+		clone.Version++
+		return put(ctx, tx, "bulk_commands", bulkCommand.Id, &clone)
+	})
+	if err != nil {
+		return fmt.Errorf("setting bulk command %s: %w", bulkCommand.Id, err)
+	}
+	bulkCommand.Version++
+	return nil
+}
+
+func (s *Store) LookupBulkCommand(ctx context.Context, id string) (*store.BulkCommand, error) {
+	bulkCommand, err := get[store.BulkCommand](ctx, s.db, "bulk_commands", id)
+	if err != nil {
+		return nil, fmt.Errorf("lookup bulk command %s: %w", id, err)
+	}
+	return bulkCommand, nil
+}
+
+func (s *Store) ListBulkCommands(ctx context.Context, pageSize int, previousId string) ([]*store.BulkCommand, error) {
+	bulkCommands, err := listAfter[store.BulkCommand](ctx, s.db, "bulk_commands", pageSize, previousId)
+	if err != nil {
+		return nil, fmt.Errorf("list bulk commands: %w", err)
+	}
+	return bulkCommands, nil
+}
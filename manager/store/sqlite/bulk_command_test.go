@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupBulkCommand(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	want := &store.BulkCommand{
+		Id:       store.NewBulkCommandId(),
+		Action:   store.BulkCommandActionChangeConfiguration,
+		Selector: store.BulkCommandSelector{FirmwareVersion: "1.2.3"},
+		Key:      "HeartbeatInterval",
+		Value:    "300",
+		Status:   store.BulkCommandStatusRunning,
+		Results: []*store.BulkCommandResult{
+			{ChargeStationId: "cs001", Status: store.BulkCommandResultStatusPending},
+			{ChargeStationId: "cs002", Status: store.BulkCommandResultStatusSent, SentAt: time.Now().UTC()},
+		},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := engine.SetBulkCommand(ctx, want)
+	require.NoError(t, err)
+
+	got, err := engine.LookupBulkCommand(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestSetBulkCommandWithStaleVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	bulkCommand := &store.BulkCommand{
+		Id:     store.NewBulkCommandId(),
+		Action: store.BulkCommandActionClearCache,
+	}
+	require.NoError(t, engine.SetBulkCommand(ctx, bulkCommand))
+
+	stale := *bulkCommand
+	stale.Version = 0
+	err := engine.SetBulkCommand(ctx, &stale)
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+}
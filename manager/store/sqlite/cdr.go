@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"strings"
+	"time"
+)
+
+func (s *Store) SetChargeDetailRecord(ctx context.Context, cdr *store.ChargeDetailRecord) error {
+	err := put(ctx, s.db, "charge_detail_records", cdr.Id, cdr)
+	if err != nil {
+		return fmt.Errorf("setting charge detail record %s: %w", cdr.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeDetailRecord(ctx context.Context, cdrId string) (*store.ChargeDetailRecord, error) {
+	cdr, err := get[store.ChargeDetailRecord](ctx, s.db, "charge_detail_records", cdrId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge detail record %s: %w", cdrId, err)
+	}
+	return cdr, nil
+}
+
+func (s *Store) ListChargeDetailRecords(ctx context.Context, offset int, limit int) ([]*store.ChargeDetailRecord, error) {
+	cdrs, err := listPage[store.ChargeDetailRecord](ctx, s.db, "charge_detail_records", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list charge detail records: %w", err)
+	}
+	return cdrs, nil
+}
+
+// QueryChargeDetailRecords applies the filter in the query: the end date times are
+// compared as times rather than as text
+func (s *Store) QueryChargeDetailRecords(ctx context.Context, filter *store.ChargeDetailRecordFilter, pageSize int, previousCdrId string) ([]*store.ChargeDetailRecord, error) {
+	conditions := []string{"id > ?"}
+	args := []any{previousCdrId}
+	if filter != nil && filter.EndedFrom != nil {
+		conditions = append(conditions, "julianday(json_extract(data, '$.EndDateTime')) >= julianday(?)")
+		args = append(args, filter.EndedFrom.Format(time.RFC3339Nano))
+	}
+	if filter != nil && filter.EndedBefore != nil {
+		conditions = append(conditions, "julianday(json_extract(data, '$.EndDateTime')) < julianday(?)")
+		args = append(args, filter.EndedBefore.Format(time.RFC3339Nano))
+	}
+	if filter != nil && filter.ChargeStationIds != nil {
+		ids, err := json.Marshal(filter.ChargeStationIds)
+		if err != nil {
+			return nil, fmt.Errorf("query charge detail records: %w", err)
+		}
+		conditions = append(conditions, "json_extract(data, '$.ChargeStationId') IN (SELECT value FROM json_each(?))")
+		args = append(args, string(ids))
+	}
+	q := "SELECT data FROM charge_detail_records WHERE " + strings.Join(conditions, " AND ") + " ORDER BY id LIMIT ?"
+	cdrs, err := query[store.ChargeDetailRecord](ctx, s.db, q, append(args, pageSize)...)
+	if err != nil {
+		return nil, fmt.Errorf("query charge detail records: %w", err)
+	}
+	return cdrs, nil
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestQueryChargeDetailRecords(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	cest := time.FixedZone("CEST", 2*60*60)
+	for i := 0; i < 6; i++ {
+		// the end date times are compared as times rather than as text
+		endDateTime := time.Date(2023, 6, i+1, 12, 0, 0, 0, time.UTC).In(cest)
+		err := engine.SetChargeDetailRecord(ctx, &store.ChargeDetailRecord{
+			Id:              fmt.Sprintf("cdr%03d", i),
+			ChargeStationId: fmt.Sprintf("cs%03d", i%2),
+			EndDateTime:     endDateTime.Format(time.RFC3339),
+		})
+		require.NoError(t, err)
+	}
+
+	from := time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2023, 6, 6, 0, 0, 0, 0, time.UTC)
+	filter := &store.ChargeDetailRecordFilter{EndedFrom: &from, EndedBefore: &before}
+
+	ids := func(cdrs []*store.ChargeDetailRecord) []string {
+		var ids []string
+		for _, cdr := range cdrs {
+			ids = append(ids, cdr.Id)
+		}
+		return ids
+	}
+
+	got, err := engine.QueryChargeDetailRecords(ctx, filter, 3, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cdr001", "cdr002", "cdr003"}, ids(got))
+	got, err = engine.QueryChargeDetailRecords(ctx, filter, 3, "cdr003")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cdr004"}, ids(got))
+
+	filter.ChargeStationIds = []string{"cs001"}
+	got, err = engine.QueryChargeDetailRecords(ctx, filter, 3, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cdr001", "cdr003"}, ids(got))
+
+	got, err = engine.QueryChargeDetailRecords(ctx, nil, 10, "")
+	require.NoError(t, err)
+	assert.Len(t, got, 6)
+}
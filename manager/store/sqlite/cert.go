@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+)
+
+type certificate struct {
+	PemData string
+}
+
+func (s *Store) SetCertificate(ctx context.Context, pemCertificate string) error {
+	b64Hash, err := getPEMCertificateHash(pemCertificate)
+	if err != nil {
+		return err
+	}
+	err = put(ctx, s.db, "certificates", b64Hash, &certificate{PemData: pemCertificate})
+	if err != nil {
+		return fmt.Errorf("setting certificate %s: %w", b64Hash, err)
+	}
+	return nil
+}
+
+func getPEMCertificateHash(pemCertificate string) (string, error) {
+	block, _ := pem.Decode([]byte(pemCertificate))
+	if block == nil {
+		return "", fmt.Errorf("pem block not found")
+	}
+	if block.Type != "CERTIFICATE" {
+		return "", fmt.Errorf("pem block does not contain certificate, but %s", block.Type)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
+
+func (s *Store) LookupCertificate(ctx context.Context, certificateHash string) (string, error) {
+	cert, err := get[certificate](ctx, s.db, "certificates", certificateHash)
+	if err != nil {
+		return "", fmt.Errorf("lookup certificate %s: %w", certificateHash, err)
+	}
+	if cert == nil {
+		return "", nil
+	}
+	return cert.PemData, nil
+}
+
+func (s *Store) DeleteCertificate(ctx context.Context, certificateHash string) error {
+	err := remove(ctx, s.db, "certificates", certificateHash)
+	if err != nil {
+		return fmt.Errorf("deleting certificate %s: %w", certificateHash, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"strings"
+)
+
+func (s *Store) SetCommandAuditRecord(ctx context.Context, record *store.CommandAuditRecord) error {
+	err := put(ctx, s.db, "command_audit_records", record.Id, record)
+	if err != nil {
+		return fmt.Errorf("setting command audit record %s: %w", record.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupCommandAuditRecord(ctx context.Context, id string) (*store.CommandAuditRecord, error) {
+	record, err := get[store.CommandAuditRecord](ctx, s.db, "command_audit_records", id)
+	if err != nil {
+		return nil, fmt.Errorf("lookup command audit record %s: %w", id, err)
+	}
+	return record, nil
+}
+
+func (s *Store) QueryCommandAuditRecords(ctx context.Context, filter *store.CommandAuditFilter, offset, limit int) ([]*store.CommandAuditRecord, error) {
+	q := "SELECT data FROM command_audit_records"
+	var conditions []string
+	var args []any
+	if filter != nil && filter.ChargeStationId != "" {
+		conditions = append(conditions, "json_extract(data, '$.ChargeStationId') = ?")
+		args = append(args, filter.ChargeStationId)
+	}
+	if filter != nil && filter.ChargeStationIds != nil {
+		ids, err := json.Marshal(filter.ChargeStationIds)
+		if err != nil {
+			return nil, fmt.Errorf("query command audit records: %w", err)
+		}
+		conditions = append(conditions, "json_extract(data, '$.ChargeStationId') IN (SELECT value FROM json_each(?))")
+		args = append(args, string(ids))
+	}
+	if len(conditions) > 0 {
+		q += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	candidates, err := query[store.CommandAuditRecord](ctx, s.db, q+" ORDER BY id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("query command audit records: %w", err)
+	}
+
+	records := make([]*store.CommandAuditRecord, 0)
+	matched := 0
+	for _, record := range candidates {
+		if len(records) >= limit {
+			break
+		}
+		if !filter.Matches(record) {
+			continue
+		}
+		if matched >= offset {
+			records = append(records, record)
+		}
+		matched++
+	}
+	return records, nil
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/sqlite"
+	clockTest "k8s.io/utils/clock/testing"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestQueryCommandAuditRecords(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	engine, err := sqlite.NewStore(ctx, filepath.Join(t.TempDir(), "manager.db"), clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	var want []*store.CommandAuditRecord
+	for _, chargeStationId := range []string{"cs001", "cs002", "cs001"} {
+		record := &store.CommandAuditRecord{
+			Id:              store.NewCommandAuditId(),
+			ChargeStationId: chargeStationId,
+			Command:         "ChangeConfiguration",
+			OcppVersion:     "ocpp1.6",
+			Request:         `{"key":"HeartbeatInterval","value":"60"}`,
+			RequestedBy:     "csms",
+			RequestedAt:     now,
+			Status:          store.CommandAuditStatusSent,
+		}
+		require.NoError(t, engine.SetCommandAuditRecord(ctx, record))
+		want = append(want, record)
+	}
+	want[0].Status = store.CommandAuditStatusCompleted
+	want[0].Result = `{"status":"Accepted"}`
+	want[0].CompletedAt = now.Add(time.Second)
+	require.NoError(t, engine.SetCommandAuditRecord(ctx, want[0]))
+
+	got, err := engine.LookupCommandAuditRecord(ctx, want[0].Id)
+	require.NoError(t, err)
+	assert.Equal(t, want[0], got)
+
+	records, err := engine.QueryCommandAuditRecords(ctx, &store.CommandAuditFilter{ChargeStationId: "cs001"}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.CommandAuditRecord{want[0], want[2]}, records)
+
+	records, err = engine.QueryCommandAuditRecords(ctx, nil, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.CommandAuditRecord{want[1]}, records)
+
+	records, err = engine.QueryCommandAuditRecords(ctx, &store.CommandAuditFilter{ChargeStationIds: []string{"cs002", "cs003"}}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.CommandAuditRecord{want[1]}, records)
+
+	records, err = engine.QueryCommandAuditRecords(ctx, &store.CommandAuditFilter{ChargeStationIds: []string{}}, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
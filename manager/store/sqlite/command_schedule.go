@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetCommandSchedule(ctx context.Context, schedule *store.CommandSchedule) error {
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		existing, err := get[store.CommandSchedule](ctx, tx, "command_schedules", schedule.Id)
+		if err != nil {
+			return err
+		}
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if schedule.Version != version {
+			return store.ErrVersionConflict
+		}
+		clone := *schedule
+		clone.Version++
+		return put(ctx, tx, "command_schedules", schedule.Id, &clone)
+	})
+	if err != nil {
+		return fmt.Errorf("setting command schedule %s: %w", schedule.Id, err)
+	}
+	schedule.Version++
+	return nil
+}
+
+func (s *Store) LookupCommandSchedule(ctx context.Context, id string) (*store.CommandSchedule, error) {
+	schedule, err := get[store.CommandSchedule](ctx, s.db, "command_schedules", id)
+	if err != nil {
+		return nil, fmt.Errorf("lookup command schedule %s: %w", id, err)
+	}
+	return schedule, nil
+}
+
+func (s *Store) ListCommandSchedules(ctx context.Context, pageSize int, previousId string) ([]*store.CommandSchedule, error) {
+	schedules, err := listAfter[store.CommandSchedule](ctx, s.db, "command_schedules", pageSize, previousId)
+	if err != nil {
+		return nil, fmt.Errorf("list command schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+func (s *Store) DeleteCommandSchedule(ctx context.Context, id string) error {
+	err := remove(ctx, s.db, "command_schedules", id)
+	if err != nil {
+		return fmt.Errorf("deleting command schedule %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestSetLookupListAndDeleteCommandSchedules(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	want := &store.CommandSchedule{
+		Id:   store.NewCommandScheduleId(),
+		Name: "weekend configuration",
+		Command: store.BulkCommand{
+			Action:      store.BulkCommandActionChangeConfiguration,
+			Selector:    store.BulkCommandSelector{SiteId: "site001"},
+			Key:         "TariffMessage",
+			Value:       "Weekend rates apply",
+			MaxInFlight: 10,
+		},
+		Cron:      "0 0 * * 6",
+		TimeZone:  "Europe/London",
+		NextRunAt: time.Now().UTC().Add(time.Hour),
+		TenantId:  "a",
+		CreatedAt: time.Now().UTC(),
+	}
+	require.NoError(t, engine.SetCommandSchedule(ctx, want))
+	assert.Equal(t, 1, want.Version)
+
+	got, err := engine.LookupCommandSchedule(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	stale := *want
+	stale.Version = 0
+	assert.ErrorIs(t, engine.SetCommandSchedule(ctx, &stale), store.ErrVersionConflict)
+
+	other := &store.CommandSchedule{Id: store.NewCommandScheduleId(), RunAt: time.Now().UTC()}
+	require.NoError(t, engine.SetCommandSchedule(ctx, other))
+	schedules, err := engine.ListCommandSchedules(ctx, 10, want.Id)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, other.Id, schedules[0].Id)
+
+	require.NoError(t, engine.DeleteCommandSchedule(ctx, want.Id))
+	got, err = engine.LookupCommandSchedule(ctx, want.Id)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
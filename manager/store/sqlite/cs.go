@@ -0,0 +1,433 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetChargeStationAuth(ctx context.Context, chargeStationId string, auth *store.ChargeStationAuth) error {
+	err := put(ctx, s.db, "charge_station_auth", chargeStationId, auth)
+	if err != nil {
+		return fmt.Errorf("setting charge station auth %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationAuth(ctx context.Context, chargeStationId string) (*store.ChargeStationAuth, error) {
+	auth, err := get[store.ChargeStationAuth](ctx, s.db, "charge_station_auth", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station auth %s: %w", chargeStationId, err)
+	}
+	return auth, nil
+}
+
+func (s *Store) SetChargeStation(ctx context.Context, chargeStationId string, chargeStation *store.ChargeStation) error {
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		existing, err := get[store.ChargeStation](ctx, tx, "charge_stations", chargeStationId)
+		if err != nil {
+			return err
+		}
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if chargeStation.Version != version {
+			return store.ErrVersionConflict
+		}
+		clone := *chargeStation
+		clone.ChargeStationId = chargeStationId
+		clone.Version++
+		return put(ctx, tx, "charge_stations", chargeStationId, &clone)
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station %s: %w", chargeStationId, err)
+	}
+	chargeStation.Version++
+	return nil
+}
+
+func (s *Store) LookupChargeStation(ctx context.Context, chargeStationId string) (*store.ChargeStation, error) {
+	chargeStation, err := get[store.ChargeStation](ctx, s.db, "charge_stations", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station %s: %w", chargeStationId, err)
+	}
+	return chargeStation, nil
+}
+
+func (s *Store) ListChargeStations(ctx context.Context, offset int, limit int) ([]*store.ChargeStation, error) {
+	chargeStations, err := listPage[store.ChargeStation](ctx, s.db, "charge_stations", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list charge stations: %w", err)
+	}
+	return chargeStations, nil
+}
+
+func (s *Store) ListTenantChargeStations(ctx context.Context, tenantId string, offset, limit int) ([]*store.ChargeStation, error) {
+	chargeStations, err := listTenantPage[store.ChargeStation](ctx, s.db, "charge_stations", tenantId, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list charge stations of tenant %s: %w", tenantId, err)
+	}
+	return chargeStations, nil
+}
+
+// ListChargeStationsNearby selects the charge stations within the bounding box of the
+// circle and then those within the circle itself
+func (s *Store) ListChargeStationsNearby(ctx context.Context, latitude, longitude, radius float64) ([]*store.ChargeStation, error) {
+	minLatitude, minLongitude, maxLatitude, maxLongitude := store.BoundingBox(latitude, longitude, radius)
+	longitudeCondition := "BETWEEN ? AND ?"
+	if minLongitude > maxLongitude {
+		// the box crosses the antimeridian
+		longitudeCondition = ">= ? OR CAST(json_extract(data, '$.Coordinates.Longitude') AS REAL) <= ?"
+	}
+	chargeStations, err := query[store.ChargeStation](ctx, s.db,
+		`SELECT data FROM charge_stations
+		WHERE CAST(json_extract(data, '$.Coordinates.Latitude') AS REAL) BETWEEN ? AND ?
+		AND (CAST(json_extract(data, '$.Coordinates.Longitude') AS REAL) `+longitudeCondition+`)`,
+		minLatitude, maxLatitude, minLongitude, maxLongitude)
+	if err != nil {
+		return nil, fmt.Errorf("list charge stations nearby: %w", err)
+	}
+	return store.ChargeStationsNearby(chargeStations, latitude, longitude, radius), nil
+}
+
+func (s *Store) DeleteChargeStation(ctx context.Context, chargeStationId string) error {
+	err := remove(ctx, s.db, "charge_stations", chargeStationId)
+	if err != nil {
+		return fmt.Errorf("deleting charge station %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) UpdateChargeStationSettings(ctx context.Context, chargeStationId string, settings *store.ChargeStationSettings) error {
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		set, err := get[store.ChargeStationSettings](ctx, tx, "charge_station_settings", chargeStationId)
+		if err != nil {
+			return err
+		}
+		if set == nil {
+			set = &store.ChargeStationSettings{
+				ChargeStationId: chargeStationId,
+				Settings:        make(map[string]*store.ChargeStationSetting, len(settings.Settings)),
+			}
+		}
+		for k, v := range settings.Settings {
+			set.Settings[k] = v
+		}
+		return put(ctx, tx, "charge_station_settings", chargeStationId, set)
+	})
+	if err != nil {
+		return fmt.Errorf("updating charge station settings %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationSettings(ctx context.Context, chargeStationId string) (*store.ChargeStationSettings, error) {
+	settings, err := get[store.ChargeStationSettings](ctx, s.db, "charge_station_settings", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station settings %s: %w", chargeStationId, err)
+	}
+	return settings, nil
+}
+
+func (s *Store) ListChargeStationSettings(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationSettings, error) {
+	settings, err := listAfter[store.ChargeStationSettings](ctx, s.db, "charge_station_settings", pageSize, previousChargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *Store) DeleteChargeStationSettings(ctx context.Context, chargeStationId string) error {
+	err := remove(ctx, s.db, "charge_station_settings", chargeStationId)
+	if err != nil {
+		return fmt.Errorf("deleting charge station settings %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) SetChargeStationRuntimeDetails(ctx context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
+	err := put(ctx, s.db, "charge_station_runtime_details", chargeStationId, &store.ChargeStationRuntimeDetails{
+		ChargeStationId: chargeStationId,
+		OcppVersion:     details.OcppVersion,
+		Iso15118Version: details.Iso15118Version,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station runtime details %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationRuntimeDetails(ctx context.Context, chargeStationId string) (*store.ChargeStationRuntimeDetails, error) {
+	details, err := get[store.ChargeStationRuntimeDetails](ctx, s.db, "charge_station_runtime_details", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station runtime details %s: %w", chargeStationId, err)
+	}
+	return details, nil
+}
+
+func (s *Store) ListChargeStationRuntimeDetails(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationRuntimeDetails, error) {
+	details, err := listAfter[store.ChargeStationRuntimeDetails](ctx, s.db, "charge_station_runtime_details", pageSize, previousChargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station runtime details: %w", err)
+	}
+	return details, nil
+}
+
+func (s *Store) UpdateChargeStationInstallCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationInstallCertificates) error {
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		certs, err := get[store.ChargeStationInstallCertificates](ctx, tx, "charge_station_install_certificates", chargeStationId)
+		if err != nil {
+			return err
+		}
+		if certs == nil {
+			certs = &store.ChargeStationInstallCertificates{
+				ChargeStationId: chargeStationId,
+			}
+		}
+		for _, v := range certificates.Certificates {
+			matched := false
+			for _, c := range certs.Certificates {
+				if v.CertificateId == c.CertificateId {
+					c.CertificateData = v.CertificateData
+					c.CertificateInstallationStatus = v.CertificateInstallationStatus
+					c.CertificateType = v.CertificateType
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				certs.Certificates = append(certs.Certificates, v)
+			}
+		}
+		return put(ctx, tx, "charge_station_install_certificates", chargeStationId, certs)
+	})
+	if err != nil {
+		return fmt.Errorf("updating charge station install certificates %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationInstallCertificates(ctx context.Context, chargeStationId string) (*store.ChargeStationInstallCertificates, error) {
+	certs, err := get[store.ChargeStationInstallCertificates](ctx, s.db, "charge_station_install_certificates", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station install certificates %s: %w", chargeStationId, err)
+	}
+	return certs, nil
+}
+
+func (s *Store) ListChargeStationInstallCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationInstallCertificates, error) {
+	certs, err := listAfter[store.ChargeStationInstallCertificates](ctx, s.db, "charge_station_install_certificates", pageSize, previousChargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station install certificates: %w", err)
+	}
+	return certs, nil
+}
+
+func (s *Store) SetChargeStationInstalledCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationInstalledCertificates) error {
+	err := put(ctx, s.db, "charge_station_installed_certificates", chargeStationId, &store.ChargeStationInstalledCertificates{
+		ChargeStationId: chargeStationId,
+		Certificates:    certificates.Certificates,
+		RefreshStatus:   certificates.RefreshStatus,
+		SendAfter:       certificates.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station installed certificates %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationInstalledCertificates(ctx context.Context, chargeStationId string) (*store.ChargeStationInstalledCertificates, error) {
+	certs, err := get[store.ChargeStationInstalledCertificates](ctx, s.db, "charge_station_installed_certificates", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station installed certificates %s: %w", chargeStationId, err)
+	}
+	return certs, nil
+}
+
+func (s *Store) ListChargeStationInstalledCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationInstalledCertificates, error) {
+	certs, err := listAfter[store.ChargeStationInstalledCertificates](ctx, s.db, "charge_station_installed_certificates", pageSize, previousChargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station installed certificates: %w", err)
+	}
+	return certs, nil
+}
+
+func (s *Store) SetChargeStationTriggerMessage(ctx context.Context, chargeStationId string, triggerMessage *store.ChargeStationTriggerMessage) error {
+	err := put(ctx, s.db, "charge_station_trigger_messages", chargeStationId, &store.ChargeStationTriggerMessage{
+		ChargeStationId: chargeStationId,
+		TriggerMessage:  triggerMessage.TriggerMessage,
+		TriggerStatus:   triggerMessage.TriggerStatus,
+		SendAfter:       triggerMessage.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station trigger message %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) DeleteChargeStationTriggerMessage(ctx context.Context, chargeStationId string) error {
+	err := remove(ctx, s.db, "charge_station_trigger_messages", chargeStationId)
+	if err != nil {
+		return fmt.Errorf("deleting charge station trigger message %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationTriggerMessage(ctx context.Context, chargeStationId string) (*store.ChargeStationTriggerMessage, error) {
+	triggerMessage, err := get[store.ChargeStationTriggerMessage](ctx, s.db, "charge_station_trigger_messages", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station trigger message %s: %w", chargeStationId, err)
+	}
+	return triggerMessage, nil
+}
+
+func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationTriggerMessage, error) {
+	triggerMessages, err := listAfter[store.ChargeStationTriggerMessage](ctx, s.db, "charge_station_trigger_messages", pageSize, previousChargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station trigger messages: %w", err)
+	}
+	return triggerMessages, nil
+}
+
+func (s *Store) SetChargeStationProvisioning(ctx context.Context, chargeStationId string, provisioning *store.ChargeStationProvisioning) error {
+	err := put(ctx, s.db, "charge_station_provisioning", chargeStationId, &store.ChargeStationProvisioning{
+		ChargeStationId: chargeStationId,
+		Status:          provisioning.Status,
+		Step:            provisioning.Step,
+		SendAfter:       provisioning.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station provisioning %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationProvisioning(ctx context.Context, chargeStationId string) (*store.ChargeStationProvisioning, error) {
+	provisioning, err := get[store.ChargeStationProvisioning](ctx, s.db, "charge_station_provisioning", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station provisioning %s: %w", chargeStationId, err)
+	}
+	return provisioning, nil
+}
+
+func (s *Store) ListChargeStationProvisioning(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationProvisioning, error) {
+	provisioning, err := listAfter[store.ChargeStationProvisioning](ctx, s.db, "charge_station_provisioning", pageSize, previousChargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station provisioning: %w", err)
+	}
+	return provisioning, nil
+}
+
+func (s *Store) SetChargeStationRegistration(ctx context.Context, chargeStationId string, registration *store.ChargeStationRegistration) error {
+	err := put(ctx, s.db, "charge_station_registrations", chargeStationId, &store.ChargeStationRegistration{
+		ChargeStationId: chargeStationId,
+		Status:          registration.Status,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station registration %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationRegistration(ctx context.Context, chargeStationId string) (*store.ChargeStationRegistration, error) {
+	registration, err := get[store.ChargeStationRegistration](ctx, s.db, "charge_station_registrations", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station registration %s: %w", chargeStationId, err)
+	}
+	return registration, nil
+}
+
+func (s *Store) SetChargeStationLiveness(ctx context.Context, chargeStationId string, liveness *store.ChargeStationLiveness) error {
+	err := put(ctx, s.db, "charge_station_liveness", chargeStationId, &store.ChargeStationLiveness{
+		ChargeStationId: chargeStationId,
+		LastSeen:        liveness.LastSeen,
+		LastHeartbeat:   liveness.LastHeartbeat,
+		Offline:         liveness.Offline,
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station liveness %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationLiveness(ctx context.Context, chargeStationId string) (*store.ChargeStationLiveness, error) {
+	liveness, err := get[store.ChargeStationLiveness](ctx, s.db, "charge_station_liveness", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup charge station liveness %s: %w", chargeStationId, err)
+	}
+	return liveness, nil
+}
+
+func (s *Store) ListChargeStationLiveness(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationLiveness, error) {
+	liveness, err := listAfter[store.ChargeStationLiveness](ctx, s.db, "charge_station_liveness", pageSize, previousChargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station liveness: %w", err)
+	}
+	return liveness, nil
+}
+
+func (s *Store) SetConnectorStatus(ctx context.Context, status *store.ConnectorStatus) error {
+	data, err := marshal(status)
+	if err != nil {
+		return fmt.Errorf("setting connector status %s: %w", status.ChargeStationId, err)
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO connector_statuses (charge_station_id, evse_id, connector_id, data)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (charge_station_id, evse_id, connector_id) DO UPDATE SET data = excluded.data`,
+		status.ChargeStationId, status.EvseId, status.ConnectorId, data)
+	if err != nil {
+		return fmt.Errorf("setting connector status %s: %w", status.ChargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) ListConnectorStatuses(ctx context.Context, chargeStationId string) ([]*store.ConnectorStatus, error) {
+	statuses, err := query[store.ConnectorStatus](ctx, s.db,
+		"SELECT data FROM connector_statuses WHERE charge_station_id = ?", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list connector statuses %s: %w", chargeStationId, err)
+	}
+	store.SortConnectorStatuses(statuses)
+	return statuses, nil
+}
+
+func (s *Store) SetEvse(ctx context.Context, evse *store.ChargeStationEvse) error {
+	data, err := marshal(evse)
+	if err != nil {
+		return fmt.Errorf("setting evse %s/%d: %w", evse.ChargeStationId, evse.EvseId, err)
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO evses (charge_station_id, evse_id, data)
+		VALUES (?, ?, ?)
+		ON CONFLICT (charge_station_id, evse_id) DO UPDATE SET data = excluded.data`,
+		evse.ChargeStationId, evse.EvseId, data)
+	if err != nil {
+		return fmt.Errorf("setting evse %s/%d: %w", evse.ChargeStationId, evse.EvseId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupEvse(ctx context.Context, chargeStationId string, evseId int) (*store.ChargeStationEvse, error) {
+	evses, err := query[store.ChargeStationEvse](ctx, s.db,
+		"SELECT data FROM evses WHERE charge_station_id = ? AND evse_id = ?", chargeStationId, evseId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup evse %s/%d: %w", chargeStationId, evseId, err)
+	}
+	if len(evses) == 0 {
+		return nil, nil
+	}
+	return evses[0], nil
+}
+
+func (s *Store) ListEvses(ctx context.Context, chargeStationId string) ([]*store.ChargeStationEvse, error) {
+	evses, err := query[store.ChargeStationEvse](ctx, s.db,
+		"SELECT data FROM evses WHERE charge_station_id = ?", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list evses %s: %w", chargeStationId, err)
+	}
+	store.SortEvses(evses)
+	return evses, nil
+}
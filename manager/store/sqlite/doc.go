@@ -0,0 +1,6 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sqlite provides an implementation of store.Engine using an SQLite database
+// file. It is intended for single-node deployments, such as small sites and edge
+// gateways, that need durable local persistence without any external services.
+package sqlite
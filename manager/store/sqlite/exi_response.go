@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func exiResponseChunksKey(chargeStationId, exiRequestHash string) string {
+	return fmt.Sprintf("%s:%s", chargeStationId, exiRequestHash)
+}
+
+func (s *Store) SetExiResponseChunks(ctx context.Context, chunks *store.ExiResponseChunks) error {
+	key := exiResponseChunksKey(chunks.ChargeStationId, chunks.ExiRequestHash)
+	err := put(ctx, s.db, "exi_response_chunks", key, chunks)
+	if err != nil {
+		return fmt.Errorf("setting exi response chunks %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) (*store.ExiResponseChunks, error) {
+	key := exiResponseChunksKey(chargeStationId, exiRequestHash)
+	chunks, err := get[store.ExiResponseChunks](ctx, s.db, "exi_response_chunks", key)
+	if err != nil {
+		return nil, fmt.Errorf("lookup exi response chunks %s: %w", key, err)
+	}
+	return chunks, nil
+}
+
+func (s *Store) DeleteExiResponseChunks(ctx context.Context, chargeStationId, exiRequestHash string) error {
+	key := exiResponseChunksKey(chargeStationId, exiRequestHash)
+	err := remove(ctx, s.db, "exi_response_chunks", key)
+	if err != nil {
+		return fmt.Errorf("deleting exi response chunks %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetSite(ctx context.Context, site *store.Site) error {
+	err := put(ctx, s.db, "sites", site.Id, site)
+	if err != nil {
+		return fmt.Errorf("setting site %s: %w", site.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupSite(ctx context.Context, siteId string) (*store.Site, error) {
+	site, err := get[store.Site](ctx, s.db, "sites", siteId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup site %s: %w", siteId, err)
+	}
+	return site, nil
+}
+
+func (s *Store) ListSites(ctx context.Context, offset int, limit int) ([]*store.Site, error) {
+	sites, err := listPage[store.Site](ctx, s.db, "sites", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list sites: %w", err)
+	}
+	return sites, nil
+}
+
+func (s *Store) ListTenantSites(ctx context.Context, tenantId string, offset, limit int) ([]*store.Site, error) {
+	sites, err := listTenantPage[store.Site](ctx, s.db, "sites", tenantId, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list sites of tenant %s: %w", tenantId, err)
+	}
+	return sites, nil
+}
+
+func (s *Store) DeleteSite(ctx context.Context, siteId string) error {
+	err := remove(ctx, s.db, "sites", siteId)
+	if err != nil {
+		return fmt.Errorf("deleting site %s: %w", siteId, err)
+	}
+	return nil
+}
+
+func (s *Store) SetTag(ctx context.Context, tag *store.Tag) error {
+	err := put(ctx, s.db, "tags", tag.Name, tag)
+	if err != nil {
+		return fmt.Errorf("setting tag %s: %w", tag.Name, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupTag(ctx context.Context, name string) (*store.Tag, error) {
+	tag, err := get[store.Tag](ctx, s.db, "tags", name)
+	if err != nil {
+		return nil, fmt.Errorf("lookup tag %s: %w", name, err)
+	}
+	return tag, nil
+}
+
+func (s *Store) ListTags(ctx context.Context, offset int, limit int) ([]*store.Tag, error) {
+	tags, err := listPage[store.Tag](ctx, s.db, "tags", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	return tags, nil
+}
+
+func (s *Store) ListTenantTags(ctx context.Context, tenantId string, offset, limit int) ([]*store.Tag, error) {
+	tags, err := listTenantPage[store.Tag](ctx, s.db, "tags", tenantId, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tags of tenant %s: %w", tenantId, err)
+	}
+	return tags, nil
+}
+
+func (s *Store) DeleteTag(ctx context.Context, name string) error {
+	err := remove(ctx, s.db, "tags", name)
+	if err != nil {
+		return fmt.Errorf("deleting tag %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+)
+
+func TestSetLookupListAndDeleteSites(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	parent := &store.Site{Id: "london", Name: "London", TenantId: "a"}
+	child := &store.Site{Id: "car-park-1", Name: "Car park 1", ParentSiteId: "london", TenantId: "a"}
+	require.NoError(t, engine.SetSite(ctx, parent))
+	require.NoError(t, engine.SetSite(ctx, child))
+
+	got, err := engine.LookupSite(ctx, "car-park-1")
+	require.NoError(t, err)
+	assert.Equal(t, child, got)
+
+	sites, err := engine.ListSites(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.Site{child, parent}, sites)
+
+	require.NoError(t, engine.DeleteSite(ctx, "car-park-1"))
+	got, err = engine.LookupSite(ctx, "car-park-1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetLookupListAndDeleteTags(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	want := &store.Tag{Name: "dc", Description: "DC chargers"}
+	require.NoError(t, engine.SetTag(ctx, want))
+
+	got, err := engine.LookupTag(ctx, "dc")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	tags, err := engine.ListTags(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.Tag{want}, tags)
+
+	require.NoError(t, engine.DeleteTag(ctx, "dc"))
+	got, err = engine.LookupTag(ctx, "dc")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
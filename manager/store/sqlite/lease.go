@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetLease(ctx context.Context, lease *store.Lease) error {
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		existing, err := get[store.Lease](ctx, tx, "leases", lease.Name)
+		if err != nil {
+			return err
+		}
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if lease.Version != version {
+			return store.ErrVersionConflict
+		}
+		clone := *lease
+		clone.Version++
+		return put(ctx, tx, "leases", lease.Name, &clone)
+	})
+	if err != nil {
+		return fmt.Errorf("setting lease %s: %w", lease.Name, err)
+	}
+	lease.Version++
+	return nil
+}
+
+func (s *Store) LookupLease(ctx context.Context, name string) (*store.Lease, error) {
+	lease, err := get[store.Lease](ctx, s.db, "leases", name)
+	if err != nil {
+		return nil, fmt.Errorf("lookup lease %s: %w", name, err)
+	}
+	return lease, nil
+}
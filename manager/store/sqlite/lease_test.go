@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestSetAndLookupLease(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	want := &store.Lease{
+		Name:      "sync",
+		Holder:    "manager-1",
+		ExpiresAt: time.Now().UTC().Add(30 * time.Second),
+	}
+	require.NoError(t, engine.SetLease(ctx, want))
+	assert.Equal(t, 1, want.Version)
+
+	got, err := engine.LookupLease(ctx, "sync")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	stale := *want
+	stale.Version = 0
+	assert.ErrorIs(t, engine.SetLease(ctx, &stale), store.ErrVersionConflict)
+
+	got, err = engine.LookupLease(ctx, "retention")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func meterValuesKey(chargeStationId string, evseId int) string {
+	return fmt.Sprintf("%s:%d", chargeStationId, evseId)
+}
+
+func (s *Store) AddMeterValues(ctx context.Context, chargeStationId string, evseId int, meterValues []store.MeterValue) error {
+	key := meterValuesKey(chargeStationId, evseId)
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		evseMeterValues, err := get[store.EvseMeterValues](ctx, tx, "meter_values", key)
+		if err != nil {
+			return err
+		}
+		if evseMeterValues == nil {
+			evseMeterValues = &store.EvseMeterValues{
+				ChargeStationId: chargeStationId,
+				EvseId:          evseId,
+			}
+		}
+		evseMeterValues.MeterValues = append(evseMeterValues.MeterValues, meterValues...)
+		store.SortMeterValues(evseMeterValues.MeterValues)
+		return put(ctx, tx, "meter_values", key, evseMeterValues)
+	})
+	if err != nil {
+		return fmt.Errorf("adding meter values %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupMeterValues(ctx context.Context, chargeStationId string, evseId int) (*store.EvseMeterValues, error) {
+	key := meterValuesKey(chargeStationId, evseId)
+	evseMeterValues, err := get[store.EvseMeterValues](ctx, s.db, "meter_values", key)
+	if err != nil {
+		return nil, fmt.Errorf("lookup meter values %s: %w", key, err)
+	}
+	return evseMeterValues, nil
+}
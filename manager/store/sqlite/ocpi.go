@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetRegistrationDetails(ctx context.Context, token string, registration *store.OcpiRegistration) error {
+	err := put(ctx, s.db, "ocpi_registrations", token, registration)
+	if err != nil {
+		return fmt.Errorf("setting ocpi registration: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) GetRegistrationDetails(ctx context.Context, token string) (*store.OcpiRegistration, error) {
+	registration, err := get[store.OcpiRegistration](ctx, s.db, "ocpi_registrations", token)
+	if err != nil {
+		return nil, fmt.Errorf("lookup ocpi registration: %w", err)
+	}
+	return registration, nil
+}
+
+func (s *Store) DeleteRegistrationDetails(ctx context.Context, token string) error {
+	err := remove(ctx, s.db, "ocpi_registrations", token)
+	if err != nil {
+		return fmt.Errorf("deleting ocpi registration: %w", err)
+	}
+	return nil
+}
+
+func partyKey(role, countryCode, partyId string) string {
+	return fmt.Sprintf("%s:%s:%s", role, countryCode, partyId)
+}
+
+func (s *Store) SetPartyDetails(ctx context.Context, partyDetails *store.OcpiParty) error {
+	key := partyKey(partyDetails.Role, partyDetails.CountryCode, partyDetails.PartyId)
+	err := put(ctx, s.db, "ocpi_parties", key, partyDetails)
+	if err != nil {
+		return fmt.Errorf("setting ocpi party %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) GetPartyDetails(ctx context.Context, role, countryCode, partyId string) (*store.OcpiParty, error) {
+	key := partyKey(role, countryCode, partyId)
+	party, err := get[store.OcpiParty](ctx, s.db, "ocpi_parties", key)
+	if err != nil {
+		return nil, fmt.Errorf("lookup ocpi party %s: %w", key, err)
+	}
+	return party, nil
+}
+
+func (s *Store) DeletePartyDetails(ctx context.Context, role, countryCode, partyId string) error {
+	key := partyKey(role, countryCode, partyId)
+	err := remove(ctx, s.db, "ocpi_parties", key)
+	if err != nil {
+		return fmt.Errorf("deleting ocpi party %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) ListPartyDetailsForRole(ctx context.Context, role string) ([]*store.OcpiParty, error) {
+	prefix := role + ":"
+	parties, err := query[store.OcpiParty](ctx, s.db,
+		"SELECT data FROM ocpi_parties WHERE substr(id, 1, length(?)) = ? ORDER BY id", prefix, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list ocpi parties for role %s: %w", role, err)
+	}
+	return parties, nil
+}
+
+func pendingCommandKey(command, chargeStationId, reference string) string {
+	return fmt.Sprintf("%s:%s:%s", command, chargeStationId, reference)
+}
+
+func (s *Store) SetPendingCommand(ctx context.Context, command *store.OcpiCommand) error {
+	key := pendingCommandKey(command.Command, command.ChargeStationId, command.Reference)
+	err := put(ctx, s.db, "ocpi_pending_commands", key, command)
+	if err != nil {
+		return fmt.Errorf("setting pending command %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupPendingCommand(ctx context.Context, command, chargeStationId, reference string) (*store.OcpiCommand, error) {
+	key := pendingCommandKey(command, chargeStationId, reference)
+	pending, err := get[store.OcpiCommand](ctx, s.db, "ocpi_pending_commands", key)
+	if err != nil {
+		return nil, fmt.Errorf("lookup pending command %s: %w", key, err)
+	}
+	return pending, nil
+}
+
+func (s *Store) DeletePendingCommand(ctx context.Context, command, chargeStationId, reference string) error {
+	key := pendingCommandKey(command, chargeStationId, reference)
+	err := remove(ctx, s.db, "ocpi_pending_commands", key)
+	if err != nil {
+		return fmt.Errorf("deleting pending command %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) SetPendingPush(ctx context.Context, push *store.OcpiPush) error {
+	err := put(ctx, s.db, "ocpi_pending_pushes", push.Id, push)
+	if err != nil {
+		return fmt.Errorf("setting pending push %s: %w", push.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) ListPendingPushes(ctx context.Context, pageSize int, previousId string) ([]*store.OcpiPush, error) {
+	pushes, err := listAfter[store.OcpiPush](ctx, s.db, "ocpi_pending_pushes", pageSize, previousId)
+	if err != nil {
+		return nil, fmt.Errorf("list pending pushes: %w", err)
+	}
+	return pushes, nil
+}
+
+func (s *Store) DeletePendingPush(ctx context.Context, id string) error {
+	err := remove(ctx, s.db, "ocpi_pending_pushes", id)
+	if err != nil {
+		return fmt.Errorf("deleting pending push %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+)
+
+func TestListPartyDetailsForRole(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	for _, party := range []*store.OcpiParty{
+		{Role: "EMSP", CountryCode: "NL", PartyId: "EMS", Url: "https://emsp.example.com/ocpi/versions", Token: "a"},
+		{Role: "CPO", CountryCode: "GB", PartyId: "CPO", Url: "https://cpo.example.com/ocpi/versions", Token: "b"},
+		{Role: "EMSP", CountryCode: "BE", PartyId: "EMS", Url: "https://emsp.example.be/ocpi/versions", Token: "c"},
+	} {
+		require.NoError(t, engine.SetPartyDetails(ctx, party))
+	}
+
+	got, err := engine.ListPartyDetailsForRole(ctx, "EMSP")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "BE", got[0].CountryCode)
+	assert.Equal(t, "NL", got[1].CountryCode)
+
+	require.NoError(t, engine.DeletePartyDetails(ctx, "EMSP", "NL", "EMS"))
+	party, err := engine.GetPartyDetails(ctx, "EMSP", "NL", "EMS")
+	require.NoError(t, err)
+	assert.Nil(t, party)
+}
+
+func TestListPendingPushesIsPaged(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	for _, id := range []string{"push002", "push001", "push003"} {
+		require.NoError(t, engine.SetPendingPush(ctx, &store.OcpiPush{Id: id}))
+	}
+
+	got, err := engine.ListPendingPushes(ctx, 2, "push001")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "push002", got[0].Id)
+	assert.Equal(t, "push003", got[1].Id)
+}
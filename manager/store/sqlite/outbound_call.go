@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetOutboundCallQueue(ctx context.Context, queue *store.OutboundCallQueue) error {
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		existing, err := get[store.OutboundCallQueue](ctx, tx, "outbound_call_queues", queue.ChargeStationId)
+		if err != nil {
+			return err
+		}
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if queue.Version != version {
+			return store.ErrVersionConflict
+		}
+		clone := *queue
+		clone.Version++
+		return put(ctx, tx, "outbound_call_queues", queue.ChargeStationId, &clone)
+	})
+	if err != nil {
+		return fmt.Errorf("setting outbound call queue %s: %w", queue.ChargeStationId, err)
+	}
+	queue.Version++
+	return nil
+}
+
+func (s *Store) LookupOutboundCallQueue(ctx context.Context, chargeStationId string) (*store.OutboundCallQueue, error) {
+	queue, err := get[store.OutboundCallQueue](ctx, s.db, "outbound_call_queues", chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup outbound call queue %s: %w", chargeStationId, err)
+	}
+	return queue, nil
+}
+
+func (s *Store) DeleteOutboundCallQueue(ctx context.Context, chargeStationId string) error {
+	err := remove(ctx, s.db, "outbound_call_queues", chargeStationId)
+	if err != nil {
+		return fmt.Errorf("deleting outbound call queue %s: %w", chargeStationId, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/sqlite"
+	clockTest "k8s.io/utils/clock/testing"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOutboundCallQueueSurvivesReopeningTheStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "manager.db")
+	engine, err := sqlite.NewStore(ctx, path, clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+
+	want := &store.OutboundCallQueue{
+		ChargeStationId: "cs001",
+		InFlight: &store.OutboundCall{
+			OcppVersion:    "ocpp2.0.1",
+			MessageId:      "1",
+			Action:         "TriggerMessage",
+			RequestPayload: `{"requestedMessage":"Heartbeat"}`,
+		},
+		InFlightExpiresAt: now.Add(30 * time.Second),
+		Queued: []*store.OutboundCall{
+			{
+				OcppVersion:    "ocpp2.0.1",
+				MessageId:      "2",
+				Action:         "Reset",
+				RequestPayload: `{"type":"Immediate"}`,
+				State:          `{"requestedBy":"test"}`,
+			},
+		},
+	}
+	err = engine.SetOutboundCallQueue(ctx, want)
+	require.NoError(t, err)
+	require.NoError(t, engine.Close())
+
+	engine, err = sqlite.NewStore(ctx, path, clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	got, err := engine.LookupOutboundCallQueue(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	err = engine.DeleteOutboundCallQueue(ctx, "cs001")
+	require.NoError(t, err)
+	got, err = engine.LookupOutboundCallQueue(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetOutboundCallQueueWithStaleVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	engine, err := sqlite.NewStore(ctx, filepath.Join(t.TempDir(), "manager.db"), clockTest.NewFakePassiveClock(time.Now()))
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	queue := &store.OutboundCallQueue{ChargeStationId: "cs001"}
+	require.NoError(t, engine.SetOutboundCallQueue(ctx, queue))
+	assert.Equal(t, 1, queue.Version)
+
+	stale := &store.OutboundCallQueue{ChargeStationId: "cs001", Queued: []*store.OutboundCall{{MessageId: "1"}}}
+	err = engine.SetOutboundCallQueue(ctx, stale)
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+	queue.Queued = []*store.OutboundCall{{MessageId: "2"}}
+	require.NoError(t, engine.SetOutboundCallQueue(ctx, queue))
+	got, err := engine.LookupOutboundCallQueue(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Version)
+	assert.Equal(t, "2", got.Queued[0].MessageId)
+}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+)
+
+func init() {
+	store.Register("sqlite", func(ctx context.Context, options map[string]any) (store.Engine, error) {
+		path, err := store.RequiredStringOption(options, "path")
+		if err != nil {
+			return nil, err
+		}
+		return NewStore(ctx, path, clock.RealClock{})
+	})
+}
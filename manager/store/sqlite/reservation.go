@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetReservation(ctx context.Context, reservation *store.Reservation) error {
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		existing, err := get[store.Reservation](ctx, tx, "reservations", reservation.ReservationId)
+		if err != nil {
+			return err
+		}
+		var version int
+		if existing != nil {
+			version = existing.Version
+		}
+		if reservation.Version != version {
+			return store.ErrVersionConflict
+		}
+		clone := *reservation
+		clone.Version++
+		return put(ctx, tx, "reservations", reservation.ReservationId, &clone)
+	})
+	if err != nil {
+		return fmt.Errorf("setting reservation %d: %w", reservation.ReservationId, err)
+	}
+	reservation.Version++
+	return nil
+}
+
+func (s *Store) LookupReservation(ctx context.Context, reservationId int) (*store.Reservation, error) {
+	reservation, err := get[store.Reservation](ctx, s.db, "reservations", reservationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup reservation %d: %w", reservationId, err)
+	}
+	return reservation, nil
+}
+
+func (s *Store) ListReservations(ctx context.Context, pageSize int, previousReservationId int) ([]*store.Reservation, error) {
+	reservations, err := listAfter[store.Reservation](ctx, s.db, "reservations", pageSize, previousReservationId)
+	if err != nil {
+		return nil, fmt.Errorf("list reservations: %w", err)
+	}
+	return reservations, nil
+}
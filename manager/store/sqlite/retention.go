@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+func (s *Store) PurgeMeterValues(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		all, err := query[store.EvseMeterValues](ctx, tx, "SELECT data FROM meter_values")
+		if err != nil {
+			return err
+		}
+		for _, evseMeterValues := range all {
+			var count int
+			evseMeterValues.MeterValues, count = store.RetainMeterValues(evseMeterValues.MeterValues, before)
+			if count == 0 {
+				continue
+			}
+			key := meterValuesKey(evseMeterValues.ChargeStationId, evseMeterValues.EvseId)
+			if err = put(ctx, tx, "meter_values", key, evseMeterValues); err != nil {
+				return err
+			}
+			purged += count
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("purging meter values: %w", err)
+	}
+	return purged, nil
+}
+
+func (s *Store) AnonymizeTransactions(ctx context.Context, before time.Time) (int, error) {
+	anonymized := 0
+	err := s.inTransaction(ctx, func(tx *sql.Tx) error {
+		// transactions that have already been anonymized have no token
+		transactions, err := query[store.Transaction](ctx, tx,
+			"SELECT data FROM transactions WHERE json_extract(data, '$.IdToken') != '' OR json_extract(data, '$.TokenType') != ''")
+		if err != nil {
+			return err
+		}
+		for _, transaction := range transactions {
+			if !transaction.Anonymize(before) {
+				continue
+			}
+			transaction.Version++
+			key := transactionKey(transaction.ChargeStationId, transaction.TransactionId)
+			if err = put(ctx, tx, "transactions", key, transaction); err != nil {
+				return err
+			}
+			anonymized++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("anonymizing transactions: %w", err)
+	}
+	return anonymized, nil
+}
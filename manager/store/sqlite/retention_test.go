@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestPurgeMeterValues(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	err := engine.AddMeterValues(ctx, "cs001", 1, []store.MeterValue{
+		{Timestamp: "2023-01-01T00:00:00Z"},
+		{Timestamp: "2023-06-01T00:00:00Z"},
+	})
+	require.NoError(t, err)
+
+	purged, err := engine.PurgeMeterValues(ctx, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	got, err := engine.LookupMeterValues(ctx, "cs001", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []store.MeterValue{{Timestamp: "2023-06-01T00:00:00Z"}}, got.MeterValues)
+}
+
+func TestAnonymizeTransactions(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	err := engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{{Timestamp: "2021-01-01T00:00:00Z"}}, 0, false)
+	require.NoError(t, err)
+	err = engine.EndTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 1)
+	require.NoError(t, err)
+
+	before := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	anonymized, err := engine.AnonymizeTransactions(ctx, before)
+	require.NoError(t, err)
+	assert.Equal(t, 1, anonymized)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Empty(t, got.IdToken)
+	assert.Empty(t, got.TokenType)
+	assert.Len(t, got.MeterValues, 1)
+
+	anonymized, err = engine.AnonymizeTransactions(ctx, before)
+	require.NoError(t, err)
+	assert.Equal(t, 0, anonymized)
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"time"
+)
+
+func (s *Store) FleetStats(ctx context.Context, since, now time.Time) (*store.FleetStats, error) {
+	stats := &store.FleetStats{}
+
+	var chargeStations int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*), COALESCE(SUM(json_extract(data, '$.Offline')), 0) FROM charge_station_liveness").
+		Scan(&chargeStations, &stats.ChargeStationsOffline)
+	if err != nil {
+		return nil, fmt.Errorf("counting charge stations: %w", err)
+	}
+	stats.ChargeStationsOnline = chargeStations - stats.ChargeStationsOffline
+
+	err = s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM connector_statuses WHERE json_extract(data, '$.Status') = ?",
+		store.ConnectorStatusFaulted).Scan(&stats.FaultedConnectors)
+	if err != nil {
+		return nil, fmt.Errorf("counting faulted connectors: %w", err)
+	}
+
+	err = s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM transactions WHERE json_extract(data, '$.EndedSeqNo') = 0").
+		Scan(&stats.ActiveTransactions)
+	if err != nil {
+		return nil, fmt.Errorf("counting active transactions: %w", err)
+	}
+
+	// the energy is read from the meter values, so only the ended transactions that
+	// have a meter value in the period are read
+	ended, err := query[store.Transaction](ctx, s.db, `SELECT data FROM transactions
+		WHERE json_extract(data, '$.EndedSeqNo') != 0 AND EXISTS (
+			SELECT 1 FROM json_each(data, '$.MeterValues') AS mv
+			WHERE julianday(json_extract(mv.value, '$.Timestamp')) >= julianday(?))`,
+		since.Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, fmt.Errorf("listing ended transactions: %w", err)
+	}
+	for _, transaction := range ended {
+		stats.AddTransaction(transaction, since)
+	}
+
+	err = s.db.QueryRowContext(ctx, `SELECT
+			COALESCE(SUM(julianday(json_extract(data, '$.ExpiryDate')) > julianday(?)), 0),
+			COALESCE(SUM(julianday(json_extract(data, '$.ExpiryDate')) <= julianday(?)), 0)
+		FROM reservations WHERE json_extract(data, '$.Status') = ?`,
+		now.Format(time.RFC3339Nano), now.Format(time.RFC3339Nano), store.ReservationStatusAccepted).
+		Scan(&stats.ActiveReservations, &stats.ExpiredReservations)
+	if err != nil {
+		return nil, fmt.Errorf("counting reservations: %w", err)
+	}
+
+	return stats, nil
+}
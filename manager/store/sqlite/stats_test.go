@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func endMeterValues(ts time.Time, wh float64) []store.MeterValue {
+	transactionEnd := "Transaction.End"
+	outlet := "Outlet"
+	energyRegister := "Energy.Active.Import.Register"
+	return []store.MeterValue{
+		{
+			Timestamp: ts.Format(time.RFC3339),
+			SampledValues: []store.SampledValue{
+				{Context: &transactionEnd, Location: &outlet, Measurand: &energyRegister, Value: wh},
+			},
+		},
+	}
+}
+
+func TestFleetStats(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+	since := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := since.Add(12 * time.Hour)
+
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs001", &store.ChargeStationLiveness{LastSeen: now}))
+	require.NoError(t, engine.SetChargeStationLiveness(ctx, "cs002", &store.ChargeStationLiveness{LastSeen: since, Offline: true}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", ConnectorId: 1, Status: "Faulted"}))
+	require.NoError(t, engine.SetConnectorStatus(ctx, &store.ConnectorStatus{ChargeStationId: "cs001", ConnectorId: 2, Status: "Available"}))
+
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 0, false))
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx002", "DEADBEEF", "ISO14443", nil, 0, false))
+	require.NoError(t, engine.EndTransaction(ctx, "cs001", "tx002", "DEADBEEF", "ISO14443", endMeterValues(now, 1500), 1))
+	require.NoError(t, engine.CreateTransaction(ctx, "cs002", "tx003", "DEADBEEF", "ISO14443", nil, 0, false))
+	require.NoError(t, engine.EndTransaction(ctx, "cs002", "tx003", "DEADBEEF", "ISO14443", endMeterValues(since.Add(-time.Hour), 2000), 1))
+
+	// the expiry date is compared as a time rather than as text
+	cest := time.FixedZone("CEST", 2*60*60)
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{ReservationId: 1, ChargeStationId: "cs001",
+		Status: store.ReservationStatusAccepted, ExpiryDate: now.Add(30 * time.Minute)}))
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{ReservationId: 2, ChargeStationId: "cs001",
+		Status: store.ReservationStatusAccepted, ExpiryDate: now.Add(-time.Minute).In(cest)}))
+	require.NoError(t, engine.SetReservation(ctx, &store.Reservation{ReservationId: 3, ChargeStationId: "cs002",
+		Status: store.ReservationStatusCancelled, ExpiryDate: now.Add(time.Hour)}))
+
+	stats, err := engine.FleetStats(ctx, since, now)
+	require.NoError(t, err)
+	assert.Equal(t, &store.FleetStats{
+		ChargeStationsOnline:  1,
+		ChargeStationsOffline: 1,
+		ActiveTransactions:    1,
+		CompletedTransactions: 1,
+		EnergyDeliveredWh:     1500,
+		ActiveReservations:    1,
+		ExpiredReservations:   1,
+		FaultedConnectors:     1,
+	}, stats)
+}
@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"k8s.io/utils/clock"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// documentTables hold one JSON document per row, keyed by id
+var documentTables = []string{
+	"charge_station_auth",
+	"charge_stations",
+	"charge_station_settings",
+	"charge_station_runtime_details",
+	"charge_station_install_certificates",
+	"charge_station_installed_certificates",
+	"charge_station_trigger_messages",
+	"charge_station_provisioning",
+	"charge_station_registrations",
+	"charge_station_liveness",
+	"tokens",
+	"transactions",
+	"meter_values",
+	"certificates",
+	"ocpi_registrations",
+	"ocpi_parties",
+	"ocpi_pending_commands",
+	"ocpi_pending_pushes",
+	"oicp_sessions",
+	"locations",
+	"charge_detail_records",
+	"tariffs",
+	"exi_response_chunks",
+	"ocsp_responses",
+	"outbound_call_queues",
+	"command_audit_records",
+	"bulk_commands",
+	"command_schedules",
+	"leases",
+	"sites",
+	"tags",
+}
+
+func schema() []string {
+	var statements []string
+	for _, table := range documentTables {
+		statements = append(statements, fmt.Sprintf(
+			"CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, data TEXT NOT NULL)", table))
+	}
+	return append(statements,
+		// reservations are listed in numeric order
+		"CREATE TABLE IF NOT EXISTS reservations (id INTEGER PRIMARY KEY, data TEXT NOT NULL)",
+		// transaction events are listed in the order they were appended
+		`CREATE TABLE IF NOT EXISTS transaction_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			transaction_id TEXT NOT NULL,
+			data TEXT NOT NULL
+		)`,
+		"CREATE INDEX IF NOT EXISTS transaction_events_transaction_id ON transaction_events (transaction_id, id)",
+		`CREATE TABLE IF NOT EXISTS connector_statuses (
+			charge_station_id TEXT NOT NULL,
+			evse_id INTEGER NOT NULL,
+			connector_id INTEGER NOT NULL,
+			data TEXT NOT NULL,
+			PRIMARY KEY (charge_station_id, evse_id, connector_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS evses (
+			charge_station_id TEXT NOT NULL,
+			evse_id INTEGER NOT NULL,
+			data TEXT NOT NULL,
+			PRIMARY KEY (charge_station_id, evse_id)
+		)`,
+		// charge stations are searched by the latitude of their coordinates
+		"CREATE INDEX IF NOT EXISTS charge_stations_latitude ON charge_stations (CAST(json_extract(data, '$.Coordinates.Latitude') AS REAL))",
+		// the registry is listed by tenant
+		"CREATE INDEX IF NOT EXISTS charge_stations_tenant ON charge_stations (json_extract(data, '$.TenantId'), id)",
+		"CREATE INDEX IF NOT EXISTS tokens_tenant ON tokens (json_extract(data, '$.TenantId'), id)",
+		"CREATE INDEX IF NOT EXISTS sites_tenant ON sites (json_extract(data, '$.TenantId'), id)",
+		"CREATE INDEX IF NOT EXISTS tags_tenant ON tags (json_extract(data, '$.TenantId'), id)",
+	)
+}
+
+// Store is an implementation of the store.Engine interface that persists data in a
+// local SQLite database. The database file must not be shared between manager
+// instances, so it can only be used when running a single manager.
+type Store struct {
+	db    *sql.DB
+	clock clock.PassiveClock
+}
+
+// NewStore opens the SQLite database at path, creating it and its tables if they
+// do not exist
+func NewStore(ctx context.Context, path string, clock clock.PassiveClock) (*Store, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database %s: %w", path, err)
+	}
+	// sqlite only allows a single writer: serializing access through one connection
+	// avoids busy errors between the store's own transactions
+	db.SetMaxOpenConns(1)
+
+	for _, statement := range schema() {
+		if _, err = db.ExecContext(ctx, statement); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("create sqlite schema in %s: %w", path, err)
+		}
+	}
+
+	return &Store{
+		db:    db,
+		clock: clock,
+	}, nil
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// IsTransient reports whether err is a busy or locked error, returned when another
+// process holds a lock on the database for longer than the busy timeout
+func (s *Store) IsTransient(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	// the primary result code is held in the low byte of an extended result code
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	default:
+		return false
+	}
+}
+
+// Healthy returns an error if the database cannot be reached
+func (s *Store) Healthy() error {
+	return s.db.Ping()
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// inTransaction runs fn in a transaction which is committed if fn succeeds: fn must
+// only access the database through tx
+func (s *Store) inTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func marshal(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func put(ctx context.Context, q querier, table string, id any, value any) error {
+	data, err := marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = q.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (id, data) VALUES (?, ?) ON CONFLICT (id) DO UPDATE SET data = excluded.data", table),
+		id, data)
+	return err
+}
+
+// get returns nil if there is no document with the id
+func get[T any](ctx context.Context, q querier, table string, id any) (*T, error) {
+	var data string
+	err := q.QueryRowContext(ctx, fmt.Sprintf("SELECT data FROM %s WHERE id = ?", table), id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	value := new(T)
+	if err = json.Unmarshal([]byte(data), value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func remove(ctx context.Context, q querier, table string, id any) error {
+	_, err := q.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = ?", table), id)
+	return err
+}
+
+// query returns the documents selected by a query for the data column
+func query[T any](ctx context.Context, q querier, query string, args ...any) ([]*T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	values := make([]*T, 0)
+	for rows.Next() {
+		var data string
+		if err = rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		value := new(T)
+		if err = json.Unmarshal([]byte(data), value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// listAfter returns up to pageSize documents ordered by id that follow previousId
+func listAfter[T any](ctx context.Context, q querier, table string, pageSize int, previousId any) ([]*T, error) {
+	return query[T](ctx, q, fmt.Sprintf("SELECT data FROM %s WHERE id > ? ORDER BY id LIMIT ?", table),
+		previousId, pageSize)
+}
+
+// listPage returns the page of documents ordered by id starting at offset
+func listPage[T any](ctx context.Context, q querier, table string, offset, limit int) ([]*T, error) {
+	return query[T](ctx, q, fmt.Sprintf("SELECT data FROM %s ORDER BY id LIMIT ? OFFSET ?", table),
+		limit, offset)
+}
+
+// listTenantPage returns the page of the tenant's documents ordered by id starting at
+// offset
+func listTenantPage[T any](ctx context.Context, q querier, table, tenantId string, offset, limit int) ([]*T, error) {
+	return query[T](ctx, q, fmt.Sprintf("SELECT data FROM %s WHERE json_extract(data, '$.TenantId') = ? ORDER BY id LIMIT ? OFFSET ?", table),
+		tenantId, limit, offset)
+}
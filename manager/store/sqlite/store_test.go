@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/sqlite"
+	"k8s.io/utils/clock"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newStore(t *testing.T) *sqlite.Store {
+	engine, err := sqlite.NewStore(context.Background(), filepath.Join(t.TempDir(), "manager.db"), clock.RealClock{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = engine.Close()
+	})
+	return engine
+}
+
+func TestDataIsPersistedWhenTheStoreIsReopened(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "manager.db")
+
+	engine, err := sqlite.NewStore(ctx, path, clock.RealClock{})
+	require.NoError(t, err)
+	want := &store.ChargeStationAuth{
+		SecurityProfile:      store.TLSWithBasicAuth,
+		Base64SHA256Password: "DEADBEEF",
+	}
+	err = engine.SetChargeStationAuth(ctx, "cs001", want)
+	require.NoError(t, err)
+	require.NoError(t, engine.Close())
+
+	engine, err = sqlite.NewStore(ctx, path, clock.RealClock{})
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	got, err := engine.LookupChargeStationAuth(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.NoError(t, engine.Healthy())
+}
+
+func TestLookupChargeStationAuthThatDoesNotExist(t *testing.T) {
+	engine := newStore(t)
+
+	got, err := engine.LookupChargeStationAuth(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestUpdateChargeStationSettingsMergesSettings(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+	now := time.Now().UTC()
+
+	err := engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"foo": {Value: "bar", Status: store.ChargeStationSettingStatusPending, SendAfter: now},
+			"baz": {Value: "qux", Status: store.ChargeStationSettingStatusPending, SendAfter: now},
+		},
+	})
+	require.NoError(t, err)
+
+	err = engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"baz": {Value: "qux", Status: store.ChargeStationSettingStatusAccepted, SendAfter: now},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationSettings{
+		ChargeStationId: "cs001",
+		Settings: map[string]*store.ChargeStationSetting{
+			"foo": {Value: "bar", Status: store.ChargeStationSettingStatusPending, SendAfter: now},
+			"baz": {Value: "qux", Status: store.ChargeStationSettingStatusAccepted, SendAfter: now},
+		},
+	}, got)
+
+	err = engine.DeleteChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+
+	got, err = engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestUpdateChargeStationInstallCertificatesMergesCertificates(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	err := engine.UpdateChargeStationInstallCertificates(ctx, "cs001", &store.ChargeStationInstallCertificates{
+		Certificates: []*store.ChargeStationInstallCertificate{
+			{CertificateId: "v2g", CertificateType: store.CertificateTypeV2G, CertificateData: "v2g-1",
+				CertificateInstallationStatus: store.CertificateInstallationPending},
+		},
+	})
+	require.NoError(t, err)
+
+	err = engine.UpdateChargeStationInstallCertificates(ctx, "cs001", &store.ChargeStationInstallCertificates{
+		Certificates: []*store.ChargeStationInstallCertificate{
+			{CertificateId: "v2g", CertificateType: store.CertificateTypeV2G, CertificateData: "v2g-2",
+				CertificateInstallationStatus: store.CertificateInstallationAccepted},
+			{CertificateId: "mo", CertificateType: store.CertificateTypeMO, CertificateData: "mo-1",
+				CertificateInstallationStatus: store.CertificateInstallationPending},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err := engine.LookupChargeStationInstallCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationInstallCertificates{
+		ChargeStationId: "cs001",
+		Certificates: []*store.ChargeStationInstallCertificate{
+			{CertificateId: "v2g", CertificateType: store.CertificateTypeV2G, CertificateData: "v2g-2",
+				CertificateInstallationStatus: store.CertificateInstallationAccepted},
+			{CertificateId: "mo", CertificateType: store.CertificateTypeMO, CertificateData: "mo-1",
+				CertificateInstallationStatus: store.CertificateInstallationPending},
+		},
+	}, got)
+}
+
+func TestListChargeStationProvisioningIsPaged(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	for _, csId := range []string{"cs003", "cs001", "cs002"} {
+		err := engine.SetChargeStationProvisioning(ctx, csId, &store.ChargeStationProvisioning{
+			Status: store.ProvisioningStatusPending,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationProvisioning(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs001", got[0].ChargeStationId)
+	assert.Equal(t, "cs002", got[1].ChargeStationId)
+
+	got, err = engine.ListChargeStationProvisioning(ctx, 2, "cs002")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs003", got[0].ChargeStationId)
+}
+
+func TestListChargeStationsIsPaged(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	for _, csId := range []string{"cs003", "cs001", "cs002"} {
+		err := engine.SetChargeStation(ctx, csId, &store.ChargeStation{
+			SiteId: "site001",
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStations(ctx, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs001", got[0].ChargeStationId)
+	assert.Equal(t, "cs002", got[1].ChargeStationId)
+
+	got, err = engine.ListChargeStations(ctx, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs003", got[0].ChargeStationId)
+
+	err = engine.DeleteChargeStation(ctx, "cs003")
+	require.NoError(t, err)
+
+	cs, err := engine.LookupChargeStation(ctx, "cs003")
+	require.NoError(t, err)
+	assert.Nil(t, cs)
+}
+
+func TestListTenantChargeStationsIsPaged(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	for _, csId := range []string{"cs004", "cs003", "cs002", "cs001"} {
+		tenantId := "tenant-a"
+		if csId == "cs002" {
+			tenantId = "tenant-b"
+		}
+		err := engine.SetChargeStation(ctx, csId, &store.ChargeStation{TenantId: tenantId})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListTenantChargeStations(ctx, "tenant-a", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs003", got[0].ChargeStationId)
+	assert.Equal(t, "cs004", got[1].ChargeStationId)
+
+	ids, err := store.TenantChargeStationIds(ctx, engine, "tenant-b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cs002"}, ids)
+}
+
+func TestSetConnectorStatusReplacesTheConnectorsStatus(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+	now := time.Now().UTC()
+
+	for _, status := range []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 2, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Available", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now},
+		{ChargeStationId: "cs002", EvseId: 1, ConnectorId: 1, Status: "Faulted", LastUpdated: now},
+	} {
+		require.NoError(t, engine.SetConnectorStatus(ctx, status))
+	}
+
+	got, err := engine.ListConnectorStatuses(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ConnectorStatus{
+		{ChargeStationId: "cs001", EvseId: 1, ConnectorId: 1, Status: "Occupied", LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 2, ConnectorId: 1, Status: "Available", LastUpdated: now},
+	}, got)
+}
+
+func TestSetEvseReplacesTheEvse(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+	now := time.Now().UTC()
+
+	for _, evse := range []*store.ChargeStationEvse{
+		{ChargeStationId: "cs001", EvseId: 2, Connectors: []store.ChargeStationConnector{{ConnectorId: 1}}, LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, Connectors: []store.ChargeStationConnector{{ConnectorId: 1}}, LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 1, Connectors: []store.ChargeStationConnector{
+			{ConnectorId: 1, ConnectorType: "cType2", MaxPower: 22000, PhysicalLabel: "A"},
+		}, LastUpdated: now},
+		{ChargeStationId: "cs002", EvseId: 1, LastUpdated: now},
+	} {
+		require.NoError(t, engine.SetEvse(ctx, evse))
+	}
+
+	got, err := engine.ListEvses(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.ChargeStationEvse{
+		{ChargeStationId: "cs001", EvseId: 1, Connectors: []store.ChargeStationConnector{
+			{ConnectorId: 1, ConnectorType: "cType2", MaxPower: 22000, PhysicalLabel: "A"},
+		}, LastUpdated: now},
+		{ChargeStationId: "cs001", EvseId: 2, Connectors: []store.ChargeStationConnector{{ConnectorId: 1}}, LastUpdated: now},
+	}, got)
+
+	evse, err := engine.LookupEvse(ctx, "cs001", 3)
+	require.NoError(t, err)
+	assert.Nil(t, evse)
+}
+
+func TestListChargeStationsNearby(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	for id, coordinates := range map[string]*store.GeoLocation{
+		"cs001": {Latitude: "51.5080", Longitude: "-0.1281"},
+		"cs002": {Latitude: "51.5074", Longitude: "-0.1278"},
+		"cs003": {Latitude: "48.8566", Longitude: "2.3522"},
+		"cs004": nil,
+	} {
+		err := engine.SetChargeStation(ctx, id, &store.ChargeStation{Coordinates: coordinates})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.ListChargeStationsNearby(ctx, 51.5074, -0.1278, 1000)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cs002", got[0].ChargeStationId)
+	assert.Equal(t, "cs001", got[1].ChargeStationId)
+
+	got, err = engine.ListChargeStationsNearby(ctx, 0, 0, 1000)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestListChargeStationsNearbyAcrossTheAntimeridian(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	err := engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{
+		Coordinates: &store.GeoLocation{Latitude: "0", Longitude: "-179.999"},
+	})
+	require.NoError(t, err)
+
+	got, err := engine.ListChargeStationsNearby(ctx, 0, 179.999, 1000)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "cs001", got[0].ChargeStationId)
+}
+
+func TestSetChargeStationWithStaleVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	err := engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{SiteId: "site001"})
+	require.NoError(t, err)
+
+	err = engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{SiteId: "site002"})
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+	got, err := engine.LookupChargeStation(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "site001", got.SiteId)
+	assert.Equal(t, 1, got.Version)
+}
+
+func TestIsTransientWhenTheDatabaseIsLocked(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "manager.db")
+	engine, err := sqlite.NewStore(ctx, path, clock.RealClock{})
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	// another process holds the write lock
+	locker, err := sql.Open("sqlite", "file:"+path)
+	require.NoError(t, err)
+	defer func() {
+		_ = locker.Close()
+	}()
+	conn, err := locker.Conn(ctx)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, err = conn.ExecContext(ctx, "BEGIN EXCLUSIVE")
+	require.NoError(t, err)
+
+	writer, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(0)")
+	require.NoError(t, err)
+	defer func() {
+		_ = writer.Close()
+	}()
+	_, err = writer.ExecContext(ctx, "INSERT INTO tags (id, data) VALUES ('dc', '{}')")
+	require.Error(t, err)
+
+	assert.True(t, engine.IsTransient(fmt.Errorf("setting tag dc: %w", err)))
+	assert.False(t, engine.IsTransient(errors.New("broken")))
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetTariff(ctx context.Context, tariff *store.Tariff) error {
+	err := put(ctx, s.db, "tariffs", tariff.Id, tariff)
+	if err != nil {
+		return fmt.Errorf("setting tariff %s: %w", tariff.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupTariff(ctx context.Context, tariffId string) (*store.Tariff, error) {
+	tariff, err := get[store.Tariff](ctx, s.db, "tariffs", tariffId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup tariff %s: %w", tariffId, err)
+	}
+	return tariff, nil
+}
+
+func (s *Store) ListTariffs(ctx context.Context, offset int, limit int) ([]*store.Tariff, error) {
+	tariffs, err := listPage[store.Tariff](ctx, s.db, "tariffs", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tariffs: %w", err)
+	}
+	return tariffs, nil
+}
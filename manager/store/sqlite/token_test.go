@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/sqlite"
+	clockTest "k8s.io/utils/clock/testing"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSetAndListTokens(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	engine, err := sqlite.NewStore(ctx, filepath.Join(t.TempDir(), "manager.db"), clockTest.NewFakePassiveClock(now))
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	for _, uid := range []string{"CCCC", "AAAA", "BBBB"} {
+		err = engine.SetToken(ctx, &store.Token{
+			CountryCode: "GB",
+			PartyId:     "TWK",
+			Type:        "RFID",
+			Uid:         uid,
+			ContractId:  "GBTWK" + uid,
+			Valid:       true,
+			CacheMode:   "ALWAYS",
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := engine.LookupToken(ctx, "AAAA")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "GBTWKAAAA", got.ContractId)
+	assert.Equal(t, "2023-06-01T12:00:00Z", got.LastUpdated)
+
+	tokens, err := engine.ListTokens(ctx, 1, 5)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "BBBB", tokens[0].Uid)
+	assert.Equal(t, "CCCC", tokens[1].Uid)
+}
+
+func TestSetTokenWithStaleVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	engine, err := sqlite.NewStore(ctx, filepath.Join(t.TempDir(), "manager.db"), clockTest.NewFakePassiveClock(time.Now()))
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+	require.NoError(t, engine.SetToken(ctx, &store.Token{Uid: "DEADBEEF", Valid: true}))
+
+	first, err := engine.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	second, err := engine.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+
+	first.CacheMode = store.CacheModeNever
+	require.NoError(t, engine.SetToken(ctx, first))
+
+	second.Valid = false
+	err = engine.SetToken(ctx, second)
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+	got, err := engine.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.True(t, got.Valid)
+	assert.Equal(t, 2, got.Version)
+}
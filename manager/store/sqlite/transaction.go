@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"strings"
+	"time"
+)
+
+func transactionKey(chargeStationId, transactionId string) string {
+	return fmt.Sprintf("%s:%s", chargeStationId, transactionId)
+}
+
+// updateTransaction stores the transaction returned by update, which is passed the
+// existing transaction or nil if there is none, with the next version
+func (s *Store) updateTransaction(ctx context.Context, chargeStationId, transactionId string,
+	update func(transaction *store.Transaction) (*store.Transaction, error)) error {
+	key := transactionKey(chargeStationId, transactionId)
+	return s.inTransaction(ctx, func(tx *sql.Tx) error {
+		transaction, err := get[store.Transaction](ctx, tx, "transactions", key)
+		if err != nil {
+			return err
+		}
+		transaction, err = update(transaction)
+		if err != nil {
+			return err
+		}
+		transaction.Version++
+		return put(ctx, tx, "transactions", key, transaction)
+	})
+}
+
+func (s *Store) Transactions(ctx context.Context) ([]*store.Transaction, error) {
+	transactions, err := query[store.Transaction](ctx, s.db, "SELECT data FROM transactions ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("list transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// transactionConditions returns the SQL conditions, and their arguments, that select the
+// transactions matched by the filter: start times are compared as times rather than as text
+func transactionConditions(filter *store.TransactionFilter) ([]string, []any, error) {
+	var conditions []string
+	var args []any
+	if filter == nil {
+		return conditions, args, nil
+	}
+	if filter.ChargeStationId != "" {
+		conditions = append(conditions, "json_extract(data, '$.ChargeStationId') = ?")
+		args = append(args, filter.ChargeStationId)
+	}
+	if filter.IdToken != "" {
+		conditions = append(conditions, "json_extract(data, '$.IdToken') = ?")
+		args = append(args, filter.IdToken)
+	}
+	if filter.ChargeStationIds != nil {
+		ids, err := json.Marshal(filter.ChargeStationIds)
+		if err != nil {
+			return nil, nil, err
+		}
+		conditions = append(conditions, "json_extract(data, '$.ChargeStationId') IN (SELECT value FROM json_each(?))")
+		args = append(args, string(ids))
+	}
+	switch filter.Status {
+	case store.TransactionStatusActive:
+		conditions = append(conditions, "json_extract(data, '$.EndedSeqNo') = 0")
+	case store.TransactionStatusEnded:
+		conditions = append(conditions, "json_extract(data, '$.EndedSeqNo') != 0")
+	}
+	if filter.StartedFrom != nil {
+		conditions = append(conditions, "julianday(json_extract(data, '$.MeterValues[0].Timestamp')) >= julianday(?)")
+		args = append(args, filter.StartedFrom.Format(time.RFC3339Nano))
+	}
+	if filter.StartedBefore != nil {
+		conditions = append(conditions, "julianday(json_extract(data, '$.MeterValues[0].Timestamp')) < julianday(?)")
+		args = append(args, filter.StartedBefore.Format(time.RFC3339Nano))
+	}
+	return conditions, args, nil
+}
+
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+func (s *Store) QueryTransactions(ctx context.Context, filter *store.TransactionFilter, offset, limit int) ([]*store.Transaction, error) {
+	conditions, args, err := transactionConditions(filter)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	q := "SELECT data FROM transactions" + where(conditions) + " ORDER BY id LIMIT ? OFFSET ?"
+	transactions, err := query[store.Transaction](ctx, s.db, q, append(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+func (s *Store) QueryTransactionsAfter(ctx context.Context, filter *store.TransactionFilter, pageSize int, previous *store.Transaction) ([]*store.Transaction, error) {
+	conditions, args, err := transactionConditions(filter)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	if previous != nil {
+		conditions = append(conditions, "id > ?")
+		args = append(args, transactionKey(previous.ChargeStationId, previous.TransactionId))
+	}
+	q := "SELECT data FROM transactions" + where(conditions) + " ORDER BY id LIMIT ?"
+	transactions, err := query[store.Transaction](ctx, s.db, q, append(args, pageSize)...)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+func (s *Store) FindTransaction(ctx context.Context, chargeStationId, transactionId string) (*store.Transaction, error) {
+	transaction, err := get[store.Transaction](ctx, s.db, "transactions", transactionKey(chargeStationId, transactionId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup transaction %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return transaction, nil
+}
+
+func (s *Store) CreateTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int, offline bool) error {
+	err := s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction) (*store.Transaction, error) {
+		if transaction == nil {
+			return &store.Transaction{
+				ChargeStationId: chargeStationId,
+				TransactionId:   transactionId,
+				IdToken:         idToken,
+				TokenType:       tokenType,
+				MeterValues:     meterValues,
+				StartSeqNo:      seqNo,
+				Offline:         offline,
+			}, nil
+		}
+		transaction.IdToken = idToken
+		transaction.TokenType = tokenType
+		transaction.MeterValues = append(transaction.MeterValues, meterValues...)
+		store.SortMeterValues(transaction.MeterValues)
+		transaction.StartSeqNo = seqNo
+		transaction.Offline = offline
+		return transaction, nil
+	})
+	if err != nil {
+		return fmt.Errorf("creating transaction %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return nil
+}
+
+func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValues []store.MeterValue) error {
+	err := s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction) (*store.Transaction, error) {
+		if transaction == nil {
+			return &store.Transaction{
+				ChargeStationId:   chargeStationId,
+				TransactionId:     transactionId,
+				MeterValues:       meterValues,
+				UpdatedSeqNoCount: 1,
+			}, nil
+		}
+		transaction.MeterValues = append(transaction.MeterValues, meterValues...)
+		store.SortMeterValues(transaction.MeterValues)
+		transaction.UpdatedSeqNoCount++
+		return transaction, nil
+	})
+	if err != nil {
+		return fmt.Errorf("updating transaction %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return nil
+}
+
+func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int) error {
+	err := s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction) (*store.Transaction, error) {
+		if transaction == nil {
+			return &store.Transaction{
+				ChargeStationId: chargeStationId,
+				TransactionId:   transactionId,
+				IdToken:         idToken,
+				TokenType:       tokenType,
+				MeterValues:     meterValues,
+				EndedSeqNo:      seqNo,
+			}, nil
+		}
+		transaction.MeterValues = append(transaction.MeterValues, meterValues...)
+		store.SortMeterValues(transaction.MeterValues)
+		transaction.EndedSeqNo = seqNo
+		return transaction, nil
+	})
+	if err != nil {
+		return fmt.Errorf("ending transaction %s/%s: %w", chargeStationId, transactionId, err)
+	}
+	return nil
+}
+
+func (s *Store) MarkTransactionRecoveredFromOffline(ctx context.Context, chargeStationId, transactionId string) error {
+	return s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction) (*store.Transaction, error) {
+		if transaction == nil {
+			return nil, errs.New(errs.ErrNotFound, "transaction %s/%s not found", chargeStationId, transactionId)
+		}
+		transaction.RecoveredFromOffline = true
+		return transaction, nil
+	})
+}
+
+func (s *Store) AddTransactionChargingState(ctx context.Context, chargeStationId, transactionId string, chargingState store.ChargingStateChange) error {
+	return s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction) (*store.Transaction, error) {
+		if transaction == nil {
+			return nil, errs.New(errs.ErrNotFound, "transaction %s/%s not found", chargeStationId, transactionId)
+		}
+		transaction.ChargingStates = append(transaction.ChargingStates, chargingState)
+		store.SortChargingStates(transaction.ChargingStates)
+		return transaction, nil
+	})
+}
+
+func (s *Store) SetTransactionCost(ctx context.Context, chargeStationId, transactionId string, cost *store.CostBreakdown) error {
+	return s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction) (*store.Transaction, error) {
+		if transaction == nil {
+			return nil, errs.New(errs.ErrNotFound, "transaction %s/%s not found", chargeStationId, transactionId)
+		}
+		transaction.Cost = cost
+		return transaction, nil
+	})
+}
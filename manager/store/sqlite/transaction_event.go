@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) AppendTransactionEvent(ctx context.Context, event *store.TransactionEvent) error {
+	key := transactionKey(event.Batch.ChargeStationId, event.Batch.TransactionId)
+	data, err := marshal(event)
+	if err == nil {
+		_, err = s.db.ExecContext(ctx, "INSERT INTO transaction_events (transaction_id, data) VALUES (?, ?)", key, data)
+	}
+	if err != nil {
+		return fmt.Errorf("appending transaction event %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) ListTransactionEvents(ctx context.Context, chargeStationId, transactionId string) ([]*store.TransactionEvent, error) {
+	key := transactionKey(chargeStationId, transactionId)
+	events, err := query[store.TransactionEvent](ctx, s.db,
+		"SELECT data FROM transaction_events WHERE transaction_id = ? ORDER BY id", key)
+	if err != nil {
+		return nil, fmt.Errorf("list transaction events %s: %w", key, err)
+	}
+	return events, nil
+}
+
+func (s *Store) ReplaceTransaction(ctx context.Context, transaction *store.Transaction) error {
+	err := s.updateTransaction(ctx, transaction.ChargeStationId, transaction.TransactionId, func(existing *store.Transaction) (*store.Transaction, error) {
+		clone := *transaction
+		clone.Version = 0
+		if existing != nil {
+			clone.Version = existing.Version
+		}
+		return &clone, nil
+	})
+	if err != nil {
+		return fmt.Errorf("replacing transaction %s/%s: %w", transaction.ChargeStationId, transaction.TransactionId, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestTransactionEventsAreListedInOrder(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+	recordedAt := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, operation := range []store.TransactionOperation{store.TransactionOperationStart, store.TransactionOperationUpdate, store.TransactionOperationEnd} {
+		err := engine.AppendTransactionEvent(ctx, &store.TransactionEvent{
+			RecordedAt: recordedAt.Add(time.Duration(i) * time.Minute),
+			Batch: store.TransactionBatch{
+				ChargeStationId: "cs001",
+				TransactionId:   "tx001",
+				Operation:       operation,
+				SeqNo:           i,
+			},
+		})
+		require.NoError(t, err)
+	}
+	err := engine.AppendTransactionEvent(ctx, &store.TransactionEvent{
+		RecordedAt: recordedAt,
+		Batch: store.TransactionBatch{
+			ChargeStationId: "cs001",
+			TransactionId:   "tx002",
+			Operation:       store.TransactionOperationStart,
+		},
+	})
+	require.NoError(t, err)
+
+	events, err := engine.ListTransactionEvents(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, recordedAt, events[0].RecordedAt)
+	assert.Equal(t, store.TransactionOperationStart, events[0].Batch.Operation)
+	assert.Equal(t, store.TransactionOperationUpdate, events[1].Batch.Operation)
+	assert.Equal(t, store.TransactionOperationEnd, events[2].Batch.Operation)
+	assert.Equal(t, 2, events[2].Batch.SeqNo)
+
+	events, err = engine.ListTransactionEvents(ctx, "cs002", "tx001")
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestReplaceTransactionContinuesVersion(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	err := engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 0, false)
+	require.NoError(t, err)
+
+	err = engine.ReplaceTransaction(ctx, &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		IdToken:         "CAFEBABE",
+		TokenType:       "ISO14443",
+		EndedSeqNo:      1,
+		Version:         7,
+	})
+	require.NoError(t, err)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, "CAFEBABE", got.IdToken)
+	assert.Equal(t, 1, got.EndedSeqNo)
+	assert.Equal(t, 2, got.Version)
+}
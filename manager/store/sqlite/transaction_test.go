@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+	"time"
+)
+
+func TestTransactionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+	energyRegister := "Energy.Active.Import.Register"
+	meterValue := func(timestamp string, value float64) store.MeterValue {
+		return store.MeterValue{
+			Timestamp:     timestamp,
+			SampledValues: []store.SampledValue{{Measurand: &energyRegister, Value: value}},
+		}
+	}
+
+	err := engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{meterValue("2023-06-01T12:00:00Z", 0)}, 0, false)
+	require.NoError(t, err)
+	err = engine.UpdateTransaction(ctx, "cs001", "tx001",
+		[]store.MeterValue{meterValue("2023-06-01T12:30:00Z", 500)})
+	require.NoError(t, err)
+	err = engine.EndTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443",
+		[]store.MeterValue{meterValue("2023-06-01T12:15:00Z", 250)}, 2)
+	require.NoError(t, err)
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.Transaction{
+		ChargeStationId: "cs001",
+		TransactionId:   "tx001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		MeterValues: []store.MeterValue{
+			meterValue("2023-06-01T12:00:00Z", 0),
+			meterValue("2023-06-01T12:15:00Z", 250),
+			meterValue("2023-06-01T12:30:00Z", 500),
+		},
+		EndedSeqNo:        2,
+		UpdatedSeqNoCount: 1,
+		Version:           3,
+	}, got)
+
+	transactions, err := engine.Transactions(ctx)
+	require.NoError(t, err)
+	assert.Len(t, transactions, 1)
+}
+
+func TestMarkTransactionRecoveredFromOfflineThatDoesNotExist(t *testing.T) {
+	engine := newStore(t)
+
+	err := engine.MarkTransactionRecoveredFromOffline(context.Background(), "cs001", "tx001")
+	assert.ErrorContains(t, err, "transaction cs001/tx001 not found")
+
+	got, err := engine.FindTransaction(context.Background(), "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSetTransactionCost(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 0, false))
+
+	cost := &store.CostBreakdown{
+		TariffId:   "tariff001",
+		Currency:   "GBP",
+		SessionFee: 1,
+		EnergyCost: 2.5,
+		TotalCost:  3.5,
+		Periods: []store.CostPeriod{{
+			StartTime:   "2023-06-01T12:00:00Z",
+			EndTime:     "2023-06-01T13:00:00Z",
+			EnergyKwh:   10,
+			EnergyPrice: 0.25,
+			Cost:        2.5,
+		}},
+	}
+	require.NoError(t, engine.SetTransactionCost(ctx, "cs001", "tx001", cost))
+
+	got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+	require.NoError(t, err)
+	assert.Equal(t, cost, got.Cost)
+	assert.Equal(t, 2, got.Version)
+
+	err = engine.SetTransactionCost(ctx, "cs001", "tx002", cost)
+	assert.ErrorContains(t, err, "transaction cs001/tx002 not found")
+}
+
+func TestQueryTransactions(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	for _, tx := range []struct{ chargeStationId, transactionId, idToken string }{
+		{"cs001", "tx001", "DEADBEEF"},
+		{"cs001", "tx002", "CAFEBABE"},
+		{"cs001", "tx003", "DEADBEEF"},
+		{"cs002", "tx004", "DEADBEEF"},
+	} {
+		err := engine.CreateTransaction(ctx, tx.chargeStationId, tx.transactionId, tx.idToken, "ISO14443", nil, 0, false)
+		require.NoError(t, err)
+	}
+	require.NoError(t, engine.EndTransaction(ctx, "cs001", "tx001", "DEADBEEF", "ISO14443", nil, 1))
+
+	got, err := engine.QueryTransactions(ctx, &store.TransactionFilter{
+		ChargeStationId: "cs001",
+		IdToken:         "DEADBEEF",
+		Status:          store.TransactionStatusActive,
+	}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "tx003", got[0].TransactionId)
+
+	got, err = engine.QueryTransactions(ctx, nil, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "tx003", got[0].TransactionId)
+	assert.Equal(t, "tx004", got[1].TransactionId)
+}
+
+func TestQueryTransactionsAfter(t *testing.T) {
+	ctx := context.Background()
+	engine := newStore(t)
+
+	for i, startTime := range []string{
+		"2023-06-01T12:00:00Z",
+		"2023-06-02T01:00:00+02:00",
+		"2023-06-02T12:00:00Z",
+		"not a time",
+		"2023-06-01T18:00:00Z",
+	} {
+		err := engine.CreateTransaction(ctx, fmt.Sprintf("cs%03d", i%2), fmt.Sprintf("tx%03d", i), "DEADBEEF", "ISO14443",
+			[]store.MeterValue{{Timestamp: startTime}}, 0, false)
+		require.NoError(t, err)
+	}
+	require.NoError(t, engine.EndTransaction(ctx, "cs000", "tx000", "DEADBEEF", "ISO14443", nil, 1))
+
+	transactionIds := func(transactions []*store.Transaction) []string {
+		ids := make([]string, len(transactions))
+		for i, transaction := range transactions {
+			ids[i] = transaction.TransactionId
+		}
+		return ids
+	}
+
+	from := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)
+	filter := &store.TransactionFilter{StartedFrom: &from, StartedBefore: &before}
+	got, err := engine.QueryTransactionsAfter(ctx, filter, 2, nil)
+	require.NoError(t, err)
+	// tx001 started at 23:00 UTC
+	assert.Equal(t, []string{"tx000", "tx004"}, transactionIds(got))
+	got, err = engine.QueryTransactionsAfter(ctx, filter, 2, got[1])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx001"}, transactionIds(got))
+	got, err = engine.QueryTransactionsAfter(ctx, filter, 2, got[0])
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = engine.QueryTransactionsAfter(ctx, &store.TransactionFilter{
+		ChargeStationIds: []string{"cs001"},
+		Status:           store.TransactionStatusActive,
+	}, 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx001", "tx003"}, transactionIds(got))
+
+	got, err = engine.QueryTransactionsAfter(ctx, &store.TransactionFilter{Status: store.TransactionStatusEnded}, 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx000"}, transactionIds(got))
+}
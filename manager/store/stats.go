@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"golang.org/x/exp/slices"
+	"time"
+)
+
+// scanPageSize is the number of records read at a time by the functions that scan
+// every record, such as when the FleetStats are aggregated
+const scanPageSize = 100
+
+// ConnectorStatusFaulted is the status reported by OCPP 1.6 and 2.0.1 charge stations
+// for a connector that has an error
+const ConnectorStatusFaulted = "Faulted"
+
+// FleetStats are aggregates over the charge stations, transactions and reservations
+// held by an engine, e.g. to power an operator dashboard.
+type FleetStats struct {
+	// ChargeStationsOnline and ChargeStationsOffline count the charge stations that
+	// have connected to the CSMS by their liveness
+	ChargeStationsOnline  int
+	ChargeStationsOffline int
+	ActiveTransactions    int
+	// CompletedTransactions and EnergyDeliveredWh cover the transactions that ended at
+	// or after the start of the period
+	CompletedTransactions int
+	EnergyDeliveredWh     float64
+	// ActiveReservations counts the accepted reservations that have not expired and
+	// ExpiredReservations those that expired without being used
+	ActiveReservations  int
+	ExpiredReservations int
+	// FaultedConnectors counts the connectors whose last reported status is Faulted
+	FaultedConnectors int
+}
+
+// FleetStatsStore is implemented by engines that can aggregate the FleetStats in the
+// underlying storage rather than by reading every record.
+type FleetStatsStore interface {
+	FleetStats(ctx context.Context, since, now time.Time) (*FleetStats, error)
+}
+
+// ComputeFleetStats returns the FleetStats for the period starting at since with the
+// engine's own aggregation if the engine is a FleetStatsStore, otherwise by reading the
+// records a page at a time
+func ComputeFleetStats(ctx context.Context, engine Engine, since, now time.Time) (*FleetStats, error) {
+	if aggregator, ok := engine.(FleetStatsStore); ok {
+		return aggregator.FleetStats(ctx, since, now)
+	}
+	return AggregateFleetStats(ctx, engine, nil, since, now)
+}
+
+// AggregateFleetStats returns the FleetStats for the period starting at since by
+// reading the records a page at a time. If chargeStationIds is not nil, only the
+// charge stations in the list, and their transactions and reservations, are counted.
+func AggregateFleetStats(ctx context.Context, engine Engine, chargeStationIds []string, since, now time.Time) (*FleetStats, error) {
+	included := func(chargeStationId string) bool {
+		return chargeStationIds == nil || slices.Contains(chargeStationIds, chargeStationId)
+	}
+	stats := &FleetStats{}
+
+	previousChargeStationId := ""
+	for {
+		page, err := engine.ListChargeStationLiveness(ctx, scanPageSize, previousChargeStationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, liveness := range page {
+			if !included(liveness.ChargeStationId) {
+				continue
+			}
+			if liveness.Offline {
+				stats.ChargeStationsOffline++
+			} else {
+				stats.ChargeStationsOnline++
+			}
+			statuses, err := engine.ListConnectorStatuses(ctx, liveness.ChargeStationId)
+			if err != nil {
+				return nil, err
+			}
+			for _, status := range statuses {
+				if status.Status == ConnectorStatusFaulted {
+					stats.FaultedConnectors++
+				}
+			}
+		}
+		if len(page) < scanPageSize {
+			break
+		}
+		previousChargeStationId = page[len(page)-1].ChargeStationId
+	}
+
+	filter := &TransactionFilter{ChargeStationIds: chargeStationIds}
+	var previousTransaction *Transaction
+	for {
+		page, err := engine.QueryTransactionsAfter(ctx, filter, scanPageSize, previousTransaction)
+		if err != nil {
+			return nil, err
+		}
+		for _, transaction := range page {
+			stats.AddTransaction(transaction, since)
+		}
+		if len(page) < scanPageSize {
+			break
+		}
+		previousTransaction = page[len(page)-1]
+	}
+
+	previousReservationId := 0
+	for {
+		page, err := engine.ListReservations(ctx, scanPageSize, previousReservationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, reservation := range page {
+			if included(reservation.ChargeStationId) {
+				stats.AddReservation(reservation, now)
+			}
+		}
+		if len(page) < scanPageSize {
+			break
+		}
+		previousReservationId = page[len(page)-1].ReservationId
+	}
+
+	return stats, nil
+}
+
+// AddReservation counts the reservation in the stats if it is active or has expired
+func (s *FleetStats) AddReservation(reservation *Reservation, now time.Time) {
+	switch {
+	case reservation.Active(now):
+		s.ActiveReservations++
+	case reservation.Expired(now):
+		s.ExpiredReservations++
+	}
+}
+
+// AddTransaction counts the transaction in the stats: an ended transaction is only
+// counted if it ended at or after since
+func (s *FleetStats) AddTransaction(transaction *Transaction, since time.Time) {
+	if transaction.Status() == TransactionStatusActive {
+		s.ActiveTransactions++
+		return
+	}
+	endedAt, energy, ok := transaction.EndOutletEnergy()
+	if ok && !endedAt.Before(since) {
+		s.CompletedTransactions++
+		s.EnergyDeliveredWh += energy
+	}
+}
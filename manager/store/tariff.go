@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import "context"
+
+// PriceComponent is the price of one dimension of a charging session. Energy is priced
+// per kWh, time and parking time per hour and a flat fee per session. The step size is
+// the billing increment in Wh for energy and in seconds for time and parking time.
+type PriceComponent struct {
+	Type     string // ENERGY, FLAT, PARKING_TIME or TIME
+	Price    float64
+	Vat      *float64
+	StepSize int
+}
+
+// TariffRestrictions limits when a tariff element applies. Times are local times in the
+// format HH:MM, dates are in the format YYYY-MM-DD and durations are in seconds.
+type TariffRestrictions struct {
+	StartTime   string
+	EndTime     string
+	StartDate   string
+	EndDate     string
+	MinKwh      *float64
+	MaxKwh      *float64
+	MinDuration *int
+	MaxDuration *int
+	DayOfWeek   []string
+}
+
+type TariffElement struct {
+	PriceComponents []PriceComponent
+	Restrictions    *TariffRestrictions
+}
+
+type Tariff struct {
+	Id            string
+	Currency      string
+	Type          string
+	Elements      []TariffElement
+	StartDateTime string
+	EndDateTime   string
+	LastUpdated   string
+}
+
+type TariffStore interface {
+	SetTariff(ctx context.Context, tariff *Tariff) error
+	LookupTariff(ctx context.Context, tariffId string) (*Tariff, error)
+	// ListTariffs returns the tariffs ordered by id
+	ListTariffs(ctx context.Context, offset int, limit int) ([]*Tariff, error)
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"math"
+)
+
+// tenantPageSize is the number of records read at a time when selecting the records
+// that belong to a tenant from an engine that is not a TenantStore
+const tenantPageSize = 100
+
+// TenantStore is implemented by engines that can select the records that belong to a
+// tenant in the underlying storage, with a filter or an index, rather than by reading
+// every record. Each method returns the page of the tenant's records, in the same order
+// as the engine's list method, starting at offset.
+type TenantStore interface {
+	ListTenantChargeStations(ctx context.Context, tenantId string, offset, limit int) ([]*ChargeStation, error)
+	ListTenantTokens(ctx context.Context, tenantId string, offset, limit int) ([]*Token, error)
+	ListTenantSites(ctx context.Context, tenantId string, offset, limit int) ([]*Site, error)
+	ListTenantTags(ctx context.Context, tenantId string, offset, limit int) ([]*Tag, error)
+}
+
+// ListTenantChargeStations returns the page of charge stations that belong to the
+// tenant, ordered by charge station id, starting at offset. The engine selects them if
+// it is a TenantStore, otherwise the registry is read a page at a time.
+func ListTenantChargeStations(ctx context.Context, engine ChargeStationStore, tenantId string, offset, limit int) ([]*ChargeStation, error) {
+	if tenants, ok := engine.(TenantStore); ok {
+		return tenants.ListTenantChargeStations(ctx, tenantId, offset, limit)
+	}
+	return listTenant(ctx, engine.ListChargeStations, func(chargeStation *ChargeStation) bool {
+		return chargeStation.TenantId == tenantId
+	}, offset, limit)
+}
+
+// TenantChargeStationIds returns the ids of all the charge stations that belong to the
+// tenant, e.g. to select the tenant's transactions with a TransactionFilter
+func TenantChargeStationIds(ctx context.Context, engine ChargeStationStore, tenantId string) ([]string, error) {
+	chargeStations, err := ListTenantChargeStations(ctx, engine, tenantId, 0, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(chargeStations))
+	for i, chargeStation := range chargeStations {
+		ids[i] = chargeStation.ChargeStationId
+	}
+	return ids, nil
+}
+
+// ListTenantTokens returns the page of tokens that belong to the tenant, ordered by
+// uid, starting at offset, in the same way as ListTenantChargeStations
+func ListTenantTokens(ctx context.Context, engine TokenStore, tenantId string, offset, limit int) ([]*Token, error) {
+	if tenants, ok := engine.(TenantStore); ok {
+		return tenants.ListTenantTokens(ctx, tenantId, offset, limit)
+	}
+	return listTenant(ctx, engine.ListTokens, func(token *Token) bool {
+		return token.TenantId == tenantId
+	}, offset, limit)
+}
+
+// ListTenantSites returns the page of sites that belong to the tenant, ordered by id,
+// starting at offset
+func ListTenantSites(ctx context.Context, engine SiteStore, tenantId string, offset, limit int) ([]*Site, error) {
+	if tenants, ok := engine.(TenantStore); ok {
+		return tenants.ListTenantSites(ctx, tenantId, offset, limit)
+	}
+	return listTenant(ctx, engine.ListSites, func(site *Site) bool {
+		return site.TenantId == tenantId
+	}, offset, limit)
+}
+
+// ListTenantTags returns the page of tags that belong to the tenant, ordered by name,
+// starting at offset
+func ListTenantTags(ctx context.Context, engine TagStore, tenantId string, offset, limit int) ([]*Tag, error) {
+	if tenants, ok := engine.(TenantStore); ok {
+		return tenants.ListTenantTags(ctx, tenantId, offset, limit)
+	}
+	return listTenant(ctx, engine.ListTags, func(tag *Tag) bool {
+		return tag.TenantId == tenantId
+	}, offset, limit)
+}
+
+func listTenant[T any](ctx context.Context, list func(ctx context.Context, offset, limit int) ([]*T, error), inTenant func(*T) bool, offset, limit int) ([]*T, error) {
+	selected := make([]*T, 0)
+	matched := 0
+	for page := 0; len(selected) < limit; page += tenantPageSize {
+		items, err := list(ctx, page, tenantPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if len(selected) >= limit {
+				break
+			}
+			if !inTenant(item) {
+				continue
+			}
+			if matched >= offset {
+				selected = append(selected, item)
+			}
+			matched++
+		}
+		if len(items) < tenantPageSize {
+			break
+		}
+	}
+	return selected, nil
+}
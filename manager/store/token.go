@@ -2,7 +2,12 @@
 
 package store
 
-import "context"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
 
 const (
 	CacheModeAlways         = "ALWAYS"
@@ -24,10 +29,55 @@ type Token struct {
 	LanguageCode *string
 	CacheMode    string
 	LastUpdated  string
+	// TenantId identifies the operator that registered the token through the API: it
+	// is empty for tokens that are shared by all operators, e.g. those pushed by eMSPs
+	TenantId string
+	// Version is incremented each time the token is written
+	Version int
+}
+
+// HashedTokenUidPrefix identifies the uid of a token that is registered by the hash of
+// its id token, so that the id token itself is not held by the CSMS
+const HashedTokenUidPrefix = "sha256:"
+
+// HashTokenUid returns the uid under which a token is registered by the hash of its id
+// token: the prefix followed by the hex encoded SHA-256 hash of the id token
+func HashTokenUid(idToken string) string {
+	hash := sha256.Sum256([]byte(idToken))
+	return HashedTokenUidPrefix + hex.EncodeToString(hash[:])
 }
 
 type TokenStore interface {
+	// SetToken writes the token if its Version matches the stored version (0 for a new
+	// token) and increments the Version: otherwise it returns ErrVersionConflict
 	SetToken(ctx context.Context, token *Token) error
 	LookupToken(ctx context.Context, tokenUid string) (*Token, error)
 	ListTokens(context context.Context, offset int, limit int) ([]*Token, error)
 }
+
+// maxReplaceTokenAttempts is the number of times a token is read and written before
+// ReplaceToken gives up because the token keeps changing
+const maxReplaceTokenAttempts = 3
+
+// ReplaceToken writes the token whatever the version of the stored token. It is for
+// writers that replace a token rather than change it, e.g. an eMSP pushing the token:
+// the write is retried if the token is changed concurrently.
+func ReplaceToken(ctx context.Context, tokens TokenStore, token *Token) error {
+	var err error
+	for attempt := 0; attempt < maxReplaceTokenAttempts; attempt++ {
+		var existing *Token
+		existing, err = tokens.LookupToken(ctx, token.Uid)
+		if err != nil {
+			return err
+		}
+		token.Version = 0
+		if existing != nil {
+			token.Version = existing.Version
+		}
+		err = tokens.SetToken(ctx, token)
+		if !errors.Is(err, ErrVersionConflict) {
+			return err
+		}
+	}
+	return err
+}
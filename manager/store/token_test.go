@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+	"testing"
+)
+
+func TestSetTokenWithStaleVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+	require.NoError(t, engine.SetToken(ctx, &store.Token{Uid: "DEADBEEF", Valid: true}))
+
+	first, err := engine.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	second, err := engine.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+
+	first.CacheMode = store.CacheModeNever
+	require.NoError(t, engine.SetToken(ctx, first))
+	assert.Equal(t, 2, first.Version)
+
+	second.Valid = false
+	err = engine.SetToken(ctx, second)
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+	got, err := engine.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.True(t, got.Valid)
+	assert.Equal(t, store.CacheModeNever, got.CacheMode)
+}
+
+func TestReplaceToken(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	require.NoError(t, store.ReplaceToken(ctx, engine, &store.Token{Uid: "DEADBEEF", Valid: true}))
+	// the token is replaced without reading it first
+	require.NoError(t, store.ReplaceToken(ctx, engine, &store.Token{Uid: "DEADBEEF", Valid: false}))
+
+	got, err := engine.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	assert.False(t, got.Valid)
+	assert.Equal(t, 2, got.Version)
+}
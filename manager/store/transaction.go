@@ -2,18 +2,69 @@
 
 package store
 
-import "context"
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/errs"
+	"golang.org/x/exp/slices"
+	"sort"
+	"time"
+)
 
 type Transaction struct {
-	ChargeStationId   string       `firestore:"chargeStationId"`
-	TransactionId     string       `firestore:"transactionId"`
-	IdToken           string       `firestore:"idToken"`
-	TokenType         string       `firestore:"tokenType"`
-	MeterValues       []MeterValue `firestore:"meterValues"`
-	StartSeqNo        int          `firestore:"startSeqNo"`
-	EndedSeqNo        int          `firestore:"endedSeqNo"`
-	UpdatedSeqNoCount int          `firestore:"updatedSeqNoCount"`
-	Offline           bool         `firestore:"offline"`
+	ChargeStationId      string                `firestore:"chargeStationId"`
+	TransactionId        string                `firestore:"transactionId"`
+	IdToken              string                `firestore:"idToken"`
+	TokenType            string                `firestore:"tokenType"`
+	MeterValues          []MeterValue          `firestore:"meterValues"`
+	StartSeqNo           int                   `firestore:"startSeqNo"`
+	EndedSeqNo           int                   `firestore:"endedSeqNo"`
+	UpdatedSeqNoCount    int                   `firestore:"updatedSeqNoCount"`
+	Offline              bool                  `firestore:"offline"`
+	RecoveredFromOffline bool                  `firestore:"recoveredFromOffline"`
+	ChargingStates       []ChargingStateChange `firestore:"chargingStates"`
+	// Cost is the itemised cost of the transaction: it is nil until the transaction
+	// has been priced
+	Cost *CostBreakdown `firestore:"cost"`
+	// Version is incremented each time the transaction is written
+	Version int `firestore:"version"`
+}
+
+// ChargingStateChange records the charging state reported by a charge station
+// (e.g. Charging, SuspendedEV, Idle) and the time from which it applies.
+type ChargingStateChange struct {
+	State     string `firestore:"state"`
+	Timestamp string `firestore:"timestamp"`
+}
+
+// CostBreakdown itemises the cost of a transaction by the dimensions of the tariff
+// that priced it. Costs are in the tariff's currency and exclude VAT.
+type CostBreakdown struct {
+	// TariffId identifies the stored tariff that priced the transaction: it is empty
+	// if the tariff is not held in the store
+	TariffId    string  `firestore:"tariffId"`
+	Currency    string  `firestore:"currency"`
+	SessionFee  float64 `firestore:"sessionFee"`
+	EnergyCost  float64 `firestore:"energyCost"`
+	TimeCost    float64 `firestore:"timeCost"`
+	ParkingCost float64 `firestore:"parkingCost"`
+	TotalCost   float64 `firestore:"totalCost"`
+	// Periods split the transaction where the price of energy, charging time or
+	// parking time changes, e.g. at the boundary of a time-of-use band
+	Periods []CostPeriod `firestore:"periods"`
+}
+
+// CostPeriod is a part of a transaction during which prices do not change. Prices are
+// per kWh for energy and per hour for charging and parking time.
+type CostPeriod struct {
+	StartTime     string  `firestore:"startTime"`
+	EndTime       string  `firestore:"endTime"`
+	EnergyKwh     float64 `firestore:"energyKwh"`
+	ChargingHours float64 `firestore:"chargingHours"`
+	ParkingHours  float64 `firestore:"parkingHours"`
+	EnergyPrice   float64 `firestore:"energyPrice"`
+	TimePrice     float64 `firestore:"timePrice"`
+	ParkingPrice  float64 `firestore:"parkingPrice"`
+	Cost          float64 `firestore:"cost"`
 }
 
 type MeterValue struct {
@@ -35,10 +86,318 @@ type UnitOfMeasure struct {
 	Multipler int    `firestore:"multipler"`
 }
 
+type TransactionStatus string
+
+var (
+	// TransactionStatusActive means the charge station has not reported the end of the transaction
+	TransactionStatusActive TransactionStatus = "Active"
+	TransactionStatusEnded  TransactionStatus = "Ended"
+)
+
+// Status returns whether the transaction is active or has ended
+func (t *Transaction) Status() TransactionStatus {
+	if t.EndedSeqNo != 0 {
+		return TransactionStatusEnded
+	}
+	return TransactionStatusActive
+}
+
+// StartTime returns the timestamp of the transaction's first meter value. It returns
+// false if there are no meter values or the timestamp cannot be parsed.
+func (t *Transaction) StartTime() (time.Time, bool) {
+	if len(t.MeterValues) == 0 {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(time.RFC3339, t.MeterValues[0].Timestamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ts, true
+}
+
+// EndOutletEnergy returns the timestamp and value of the outlet energy reading recorded
+// when the transaction ended: the same reading that is used for billing. It returns false
+// if there is no such reading or its timestamp cannot be parsed.
+func (t *Transaction) EndOutletEnergy() (time.Time, float64, bool) {
+	for _, mv := range t.MeterValues {
+		for _, sv := range mv.SampledValues {
+			if sv.Context != nil && *sv.Context == "Transaction.End" &&
+				sv.Measurand != nil && *sv.Measurand == "Energy.Active.Import.Register" &&
+				sv.Location != nil && *sv.Location == "Outlet" {
+				ts, err := time.Parse(time.RFC3339, mv.Timestamp)
+				if err != nil {
+					return time.Time{}, 0, false
+				}
+				return ts, sv.Value, true
+			}
+		}
+	}
+	return time.Time{}, 0, false
+}
+
+// TransactionFilter selects transactions: a field that is not set matches all
+// transactions.
+type TransactionFilter struct {
+	ChargeStationId string
+	IdToken         string
+	// StartedFrom and StartedBefore bound the transaction's StartTime: transactions
+	// without a start time never match a filter that sets either of them
+	StartedFrom   *time.Time
+	StartedBefore *time.Time
+	Status        TransactionStatus
+	// ChargeStationIds, if not nil, restricts the transactions to those recorded by the
+	// charge stations, e.g. the charge stations that belong to a tenant
+	ChargeStationIds []string
+}
+
+// Matches reports whether the transaction is selected by the filter
+func (f *TransactionFilter) Matches(transaction *Transaction) bool {
+	if f == nil {
+		return true
+	}
+	if f.ChargeStationId != "" && transaction.ChargeStationId != f.ChargeStationId {
+		return false
+	}
+	if f.IdToken != "" && transaction.IdToken != f.IdToken {
+		return false
+	}
+	if f.ChargeStationIds != nil && !slices.Contains(f.ChargeStationIds, transaction.ChargeStationId) {
+		return false
+	}
+	if f.Status != "" && transaction.Status() != f.Status {
+		return false
+	}
+	if f.StartedFrom != nil || f.StartedBefore != nil {
+		startTime, ok := transaction.StartTime()
+		if !ok {
+			return false
+		}
+		if f.StartedFrom != nil && startTime.Before(*f.StartedFrom) {
+			return false
+		}
+		if f.StartedBefore != nil && !startTime.Before(*f.StartedBefore) {
+			return false
+		}
+	}
+	return true
+}
+
 type TransactionStore interface {
 	Transactions(ctx context.Context) ([]*Transaction, error)
+	// QueryTransactions returns the page of transactions selected by the filter, ordered
+	// by charge station and transaction id, starting at offset
+	QueryTransactions(ctx context.Context, filter *TransactionFilter, offset, limit int) ([]*Transaction, error)
+	// QueryTransactionsAfter returns up to pageSize transactions selected by the filter
+	// that follow previous, the last transaction of the previous page (nil for the first
+	// page), in an order defined by the engine. Unlike QueryTransactions, reading every
+	// page reads each transaction once.
+	QueryTransactionsAfter(ctx context.Context, filter *TransactionFilter, pageSize int, previous *Transaction) ([]*Transaction, error)
 	FindTransaction(ctx context.Context, chargeStationId, transactionId string) (*Transaction, error)
 	CreateTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValue []MeterValue, seqNo int, offline bool) error
 	UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValue []MeterValue) error
 	EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValue []MeterValue, seqNo int) error
+	MarkTransactionRecoveredFromOffline(ctx context.Context, chargeStationId, transactionId string) error
+	AddTransactionChargingState(ctx context.Context, chargeStationId, transactionId string, chargingState ChargingStateChange) error
+	// SetTransactionCost replaces the cost breakdown of an existing transaction
+	SetTransactionCost(ctx context.Context, chargeStationId, transactionId string, cost *CostBreakdown) error
+}
+
+type TransactionOperation string
+
+var (
+	TransactionOperationStart  TransactionOperation = "Start"
+	TransactionOperationUpdate TransactionOperation = "Update"
+	TransactionOperationEnd    TransactionOperation = "End"
+)
+
+// TransactionBatch groups the changes made to a transaction, and the reservation it
+// consumes, when a transaction event is received.
+type TransactionBatch struct {
+	ChargeStationId string
+	TransactionId   string
+	// Operation, if set, starts, updates or ends the transaction in the same way as
+	// CreateTransaction, UpdateTransaction and EndTransaction: otherwise the
+	// transaction must already exist
+	Operation            TransactionOperation
+	IdToken              string
+	TokenType            string
+	MeterValues          []MeterValue
+	SeqNo                int
+	Offline              bool
+	RecoveredFromOffline bool
+	ChargingState        *ChargingStateChange
+	// ReservationId, if set, identifies a reservation that is consumed by the transaction
+	ReservationId *int
+	// Cost, if set, replaces the transaction's cost breakdown
+	Cost *CostBreakdown
+}
+
+// TransactionBatchStore is implemented by engines that can write all the changes in a
+// TransactionBatch atomically.
+type TransactionBatchStore interface {
+	WriteTransactionBatch(ctx context.Context, batch *TransactionBatch) error
+}
+
+// WriteTransactionBatch writes the batch atomically if the engine is a TransactionBatchStore,
+// otherwise the changes are written one at a time
+func WriteTransactionBatch(ctx context.Context, engine Engine, batch *TransactionBatch) error {
+	if batcher, ok := engine.(TransactionBatchStore); ok {
+		return batcher.WriteTransactionBatch(ctx, batch)
+	}
+
+	var err error
+	switch batch.Operation {
+	case TransactionOperationStart:
+		err = engine.CreateTransaction(ctx, batch.ChargeStationId, batch.TransactionId, batch.IdToken, batch.TokenType,
+			batch.MeterValues, batch.SeqNo, batch.Offline)
+	case TransactionOperationUpdate:
+		err = engine.UpdateTransaction(ctx, batch.ChargeStationId, batch.TransactionId, batch.MeterValues)
+	case TransactionOperationEnd:
+		err = engine.EndTransaction(ctx, batch.ChargeStationId, batch.TransactionId, batch.IdToken, batch.TokenType,
+			batch.MeterValues, batch.SeqNo)
+	}
+	if err != nil {
+		return err
+	}
+
+	if batch.RecoveredFromOffline {
+		err = engine.MarkTransactionRecoveredFromOffline(ctx, batch.ChargeStationId, batch.TransactionId)
+		if err != nil {
+			return err
+		}
+	}
+
+	if batch.ChargingState != nil {
+		err = engine.AddTransactionChargingState(ctx, batch.ChargeStationId, batch.TransactionId, *batch.ChargingState)
+		if err != nil {
+			return err
+		}
+	}
+
+	if batch.Cost != nil {
+		err = engine.SetTransactionCost(ctx, batch.ChargeStationId, batch.TransactionId, batch.Cost)
+		if err != nil {
+			return err
+		}
+	}
+
+	if batch.ReservationId != nil {
+		reservation, err := engine.LookupReservation(ctx, *batch.ReservationId)
+		if err != nil {
+			return err
+		}
+		if reservation != nil && reservation.Consume(batch.ChargeStationId, batch.TransactionId) {
+			return engine.SetReservation(ctx, reservation)
+		}
+	}
+
+	return nil
+}
+
+// ApplyTransactionBatch returns the transaction with the changes in the batch applied
+// and its version incremented: the transaction is nil if it does not exist yet. It is
+// used by engines that write a batch as a single read-modify-write.
+func ApplyTransactionBatch(transaction *Transaction, batch *TransactionBatch) (*Transaction, error) {
+	switch batch.Operation {
+	case TransactionOperationStart:
+		if transaction != nil {
+			transaction.IdToken = batch.IdToken
+			transaction.TokenType = batch.TokenType
+			transaction.MeterValues = append(transaction.MeterValues, batch.MeterValues...)
+			SortMeterValues(transaction.MeterValues)
+			transaction.StartSeqNo = batch.SeqNo
+			transaction.Offline = batch.Offline
+		} else {
+			transaction = &Transaction{
+				ChargeStationId:   batch.ChargeStationId,
+				TransactionId:     batch.TransactionId,
+				IdToken:           batch.IdToken,
+				TokenType:         batch.TokenType,
+				MeterValues:       batch.MeterValues,
+				StartSeqNo:        batch.SeqNo,
+				EndedSeqNo:        0,
+				UpdatedSeqNoCount: 0,
+				Offline:           batch.Offline,
+			}
+		}
+	case TransactionOperationUpdate:
+		if transaction == nil {
+			transaction = &Transaction{
+				ChargeStationId:   batch.ChargeStationId,
+				TransactionId:     batch.TransactionId,
+				MeterValues:       batch.MeterValues,
+				UpdatedSeqNoCount: 1,
+			}
+		} else {
+			transaction.MeterValues = append(transaction.MeterValues, batch.MeterValues...)
+			SortMeterValues(transaction.MeterValues)
+			transaction.UpdatedSeqNoCount++
+		}
+	case TransactionOperationEnd:
+		if transaction == nil {
+			transaction = &Transaction{
+				ChargeStationId: batch.ChargeStationId,
+				TransactionId:   batch.TransactionId,
+				IdToken:         batch.IdToken,
+				TokenType:       batch.TokenType,
+				MeterValues:     batch.MeterValues,
+				EndedSeqNo:      batch.SeqNo,
+			}
+		} else {
+			transaction.MeterValues = append(transaction.MeterValues, batch.MeterValues...)
+			SortMeterValues(transaction.MeterValues)
+			transaction.EndedSeqNo = batch.SeqNo
+		}
+	}
+
+	if transaction == nil {
+		return nil, errs.New(errs.ErrNotFound, "transaction %s/%s not found", batch.ChargeStationId, batch.TransactionId)
+	}
+	if batch.RecoveredFromOffline {
+		transaction.RecoveredFromOffline = true
+	}
+	if batch.ChargingState != nil {
+		transaction.ChargingStates = append(transaction.ChargingStates, *batch.ChargingState)
+		SortChargingStates(transaction.ChargingStates)
+	}
+	if batch.Cost != nil {
+		transaction.Cost = batch.Cost
+	}
+	transaction.Version++
+
+	return transaction, nil
+}
+
+// SortChargingStates orders charging state changes by timestamp in the same way
+// as SortMeterValues.
+func SortChargingStates(chargingStates []ChargingStateChange) {
+	sort.SliceStable(chargingStates, func(i, j int) bool {
+		return timestampBefore(chargingStates[i].Timestamp, chargingStates[j].Timestamp)
+	})
+}
+
+// SortMeterValues orders meter values by timestamp so that values received out of
+// order (e.g. replayed after a charge station reconnects) form a chronological
+// timeline. Values with timestamps that cannot be parsed are placed after the others,
+// keeping their relative order.
+func SortMeterValues(meterValues []MeterValue) {
+	sort.SliceStable(meterValues, func(i, j int) bool {
+		return timestampBefore(meterValues[i].Timestamp, meterValues[j].Timestamp)
+	})
+}
+
+// timestampBefore orders RFC 3339 timestamps chronologically, with the timestamps that
+// cannot be parsed after all the others and equal to each other, so that it is a strict
+// weak ordering
+func timestampBefore(timestamp1, timestamp2 string) bool {
+	ts1, err1 := time.Parse(time.RFC3339, timestamp1)
+	ts2, err2 := time.Parse(time.RFC3339, timestamp2)
+	switch {
+	case err1 != nil:
+		return false
+	case err2 != nil:
+		return true
+	default:
+		return ts1.Before(ts2)
+	}
 }
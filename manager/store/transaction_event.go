@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// TransactionEvent is an immutable record of a change to a transaction, as it was
+// received from the charge station. The events recorded for a transaction can be
+// projected to rebuild it, e.g. after a handler bug wrote the wrong values.
+type TransactionEvent struct {
+	// RecordedAt is the time that the event was appended to the log
+	RecordedAt time.Time
+	Batch      TransactionBatch
+}
+
+// TransactionEventStore is implemented by engines that can keep an append-only log of
+// the events for each transaction.
+type TransactionEventStore interface {
+	AppendTransactionEvent(ctx context.Context, event *TransactionEvent) error
+	// ListTransactionEvents returns the events for the transaction in the order that
+	// they were recorded
+	ListTransactionEvents(ctx context.Context, chargeStationId, transactionId string) ([]*TransactionEvent, error)
+	// ReplaceTransaction overwrites the transaction with one projected from its events:
+	// the version continues from that of the stored transaction
+	ReplaceTransaction(ctx context.Context, transaction *Transaction) error
+}
+
+// ProjectTransaction builds the transaction from its events: it returns nil if none
+// of the events could be applied. An event that could not be applied when it was
+// received, e.g. marking a transaction that did not exist yet, is skipped in the
+// same way.
+func ProjectTransaction(events []*TransactionEvent) *Transaction {
+	var transaction *Transaction
+	for _, event := range events {
+		batch := event.Batch
+		batch.MeterValues = append([]MeterValue(nil), batch.MeterValues...)
+		projected, err := ApplyTransactionBatch(transaction, &batch)
+		if err != nil {
+			continue
+		}
+		transaction = projected
+	}
+	return transaction
+}
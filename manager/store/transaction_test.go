@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"testing"
+)
+
+func TestSortMeterValuesPlacesUnparseableTimestampsLast(t *testing.T) {
+	meterValues := []store.MeterValue{
+		{Timestamp: "not a time"},
+		{Timestamp: "2023-05-05T12:30:00Z"},
+		{Timestamp: ""},
+		{Timestamp: "2023-05-05T12:00:00Z"},
+	}
+
+	store.SortMeterValues(meterValues)
+
+	var timestamps []string
+	for _, meterValue := range meterValues {
+		timestamps = append(timestamps, meterValue.Timestamp)
+	}
+	assert.Equal(t, []string{"2023-05-05T12:00:00Z", "2023-05-05T12:30:00Z", "not a time", ""}, timestamps)
+}
+
+func TestSortChargingStatesPlacesUnparseableTimestampsLast(t *testing.T) {
+	chargingStates := []store.ChargingStateChange{
+		{State: "Idle", Timestamp: "2023-05-05T13:00:00Z"},
+		{State: "Unknown", Timestamp: "yesterday"},
+		{State: "Charging", Timestamp: "2023-05-05T12:00:00Z"},
+	}
+
+	store.SortChargingStates(chargingStates)
+
+	assert.Equal(t, []store.ChargingStateChange{
+		{State: "Charging", Timestamp: "2023-05-05T12:00:00Z"},
+		{State: "Idle", Timestamp: "2023-05-05T13:00:00Z"},
+		{State: "Unknown", Timestamp: "yesterday"},
+	}, chargingStates)
+}
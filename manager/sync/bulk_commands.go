@@ -0,0 +1,322 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slices"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// SyncBulkCommands sends each running bulk command to the charge stations that it
+// selected, at most MaxInFlight charge stations at a time. The response of each charge
+// station is read from the command audit log: a charge station that has not responded
+// within responseTimeout has failed. A charge station is marked as sent before the
+// call is made, so a bulk command that is being sent by another manager instance is
+// skipped and a bulk command carries on where it left off after a restart.
+func SyncBulkCommands(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.Engine,
+	clock clock.PassiveClock,
+	v16CallMaker,
+	v201CallMaker handlers.CallMaker,
+	runEvery,
+	responseTimeout time.Duration) {
+	var previousId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync bulk commands")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync bulk commands", trace.WithSpanKind(trace.SpanKindInternal),
+					trace.WithAttributes(attribute.String("sync.bulk_commands.previous", previousId)))
+				defer span.End()
+				bulkCommands, err := engine.ListBulkCommands(ctx, 50, previousId)
+				if err != nil {
+					span.RecordError(err)
+					return
+				}
+				if len(bulkCommands) > 0 {
+					previousId = bulkCommands[len(bulkCommands)-1].Id
+				} else {
+					previousId = ""
+				}
+				span.SetAttributes(attribute.Int("sync.bulk_commands.count", len(bulkCommands)))
+				for _, bulkCommand := range bulkCommands {
+					if bulkCommand.Status == store.BulkCommandStatusCompleted {
+						continue
+					}
+					err := syncBulkCommand(ctx, tracer, engine, clock, v16CallMaker, v201CallMaker, bulkCommand, responseTimeout)
+					if err != nil {
+						span.RecordError(err)
+					}
+				}
+			}()
+		}
+	}
+}
+
+func syncBulkCommand(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.Engine,
+	clock clock.PassiveClock,
+	v16CallMaker,
+	v201CallMaker handlers.CallMaker,
+	bulkCommand *store.BulkCommand,
+	responseTimeout time.Duration) error {
+	ctx, span := tracer.Start(ctx, "sync bulk command", trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attribute.String("sync.bulk_command.id", bulkCommand.Id),
+			attribute.String("sync.bulk_command.action", string(bulkCommand.Action)),
+			attribute.String("sync.bulk_command.status", string(bulkCommand.Status)),
+		))
+	defer span.End()
+
+	changed := false
+	for _, result := range bulkCommand.Results {
+		if result.Status != store.BulkCommandResultStatusSent {
+			continue
+		}
+		completed, err := completeBulkCommandResult(ctx, engine, clock.Now(), bulkCommand.Action, result, responseTimeout)
+		if err != nil {
+			return err
+		}
+		changed = changed || completed
+	}
+
+	type bulkCall struct {
+		result    *store.BulkCommandResult
+		callMaker handlers.CallMaker
+		request   ocpp.Request
+	}
+	var calls []bulkCall
+	if bulkCommand.Status == store.BulkCommandStatusRunning {
+		inFlight := bulkCommand.CountResults(store.BulkCommandResultStatusSent)
+		for _, result := range bulkCommand.Results {
+			if inFlight >= bulkCommand.MaxInFlight {
+				break
+			}
+			if result.Status != store.BulkCommandResultStatusPending {
+				continue
+			}
+			changed = true
+			details, err := engine.LookupChargeStationRuntimeDetails(ctx, result.ChargeStationId)
+			if err != nil {
+				return err
+			}
+			if details == nil {
+				failBulkCommandResult(result, clock.Now(), "charge station has not connected")
+				continue
+			}
+			callMaker, request, err := bulkCommandCall(bulkCommand, details.OcppVersion, v16CallMaker, v201CallMaker, clock.Now())
+			if err != nil {
+				failBulkCommandResult(result, clock.Now(), err.Error())
+				continue
+			}
+			result.Status = store.BulkCommandResultStatusSent
+			result.SentAt = clock.Now()
+			calls = append(calls, bulkCall{result: result, callMaker: callMaker, request: request})
+			inFlight++
+		}
+	}
+
+	if bulkCommand.CountResults(store.BulkCommandResultStatusPending) == 0 &&
+		bulkCommand.CountResults(store.BulkCommandResultStatusSent) == 0 {
+		bulkCommand.Status = store.BulkCommandStatusCompleted
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	// the charge stations are marked as sent before the calls are made, so that a bulk
+	// command changed by another manager instance is not sent twice
+	err := engine.SetBulkCommand(ctx, bulkCommand)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.Int("sync.bulk_command.sent", len(calls)))
+
+	failed := false
+	for _, call := range calls {
+		err := call.callMaker.Send(ctx, call.result.ChargeStationId, call.request)
+		if err != nil {
+			slog.Error("send bulk command", "bulkCommandId", bulkCommand.Id,
+				"chargeStationId", call.result.ChargeStationId, "err", err)
+			failBulkCommandResult(call.result, clock.Now(), err.Error())
+			failed = true
+		}
+	}
+	if failed {
+		return engine.SetBulkCommand(ctx, bulkCommand)
+	}
+	return nil
+}
+
+// bulkCommandCall returns the request for the bulk command in the charge station's OCPP
+// version and the call maker that sends it
+func bulkCommandCall(bulkCommand *store.BulkCommand, ocppVersion string, v16CallMaker, v201CallMaker handlers.CallMaker, now time.Time) (handlers.CallMaker, ocpp.Request, error) {
+	retrieveDate := bulkCommand.FirmwareRetrieveDate
+	if retrieveDate.IsZero() {
+		retrieveDate = now
+	}
+
+	if ocppVersion == "1.6" {
+		switch bulkCommand.Action {
+		case store.BulkCommandActionReset:
+			resetType := ocpp16.ResetJsonTypeHard
+			if bulkCommand.ResetType == string(ocpp201.ResetEnumTypeOnIdle) {
+				resetType = ocpp16.ResetJsonTypeSoft
+			}
+			return v16CallMaker, &ocpp16.ResetJson{Type: resetType}, nil
+		case store.BulkCommandActionChangeConfiguration:
+			return v16CallMaker, &ocpp16.ChangeConfigurationJson{
+				Key:   bulkCommand.Key,
+				Value: bulkCommand.Value,
+			}, nil
+		case store.BulkCommandActionUpdateFirmware:
+			return v16CallMaker, &ocpp16.UpdateFirmwareJson{
+				Location:     bulkCommand.FirmwareLocation,
+				RetrieveDate: retrieveDate.UTC().Format(time.RFC3339),
+			}, nil
+		case store.BulkCommandActionClearCache:
+			return v16CallMaker, &ocpp16.ClearCacheJson{}, nil
+		}
+		return nil, nil, fmt.Errorf("unknown bulk command action: %s", bulkCommand.Action)
+	}
+
+	switch bulkCommand.Action {
+	case store.BulkCommandActionReset:
+		resetType := ocpp201.ResetEnumTypeImmediate
+		if bulkCommand.ResetType == string(ocpp201.ResetEnumTypeOnIdle) {
+			resetType = ocpp201.ResetEnumTypeOnIdle
+		}
+		return v201CallMaker, &ocpp201.ResetRequestJson{Type: resetType}, nil
+	case store.BulkCommandActionChangeConfiguration:
+		var variable ocpp201.SetVariableDataType
+		err := parseOcpp201Name(bulkCommand.Key, &variable)
+		if err != nil {
+			return nil, nil, err
+		}
+		variable.AttributeValue = bulkCommand.Value
+		return v201CallMaker, &ocpp201.SetVariablesRequestJson{
+			SetVariableData: []ocpp201.SetVariableDataType{variable},
+		}, nil
+	case store.BulkCommandActionUpdateFirmware:
+		// the request id is shared by the charge stations so that their firmware status
+		// notifications can be matched to the bulk command
+		return v201CallMaker, &ocpp201.UpdateFirmwareRequestJson{
+			RequestId: int(bulkCommand.CreatedAt.Unix()),
+			Firmware: ocpp201.FirmwareType{
+				Location:         bulkCommand.FirmwareLocation,
+				RetrieveDateTime: retrieveDate.UTC().Format(time.RFC3339),
+			},
+		}, nil
+	case store.BulkCommandActionClearCache:
+		return v201CallMaker, &ocpp201.ClearCacheRequestJson{}, nil
+	}
+	return nil, nil, fmt.Errorf("unknown bulk command action: %s", bulkCommand.Action)
+}
+
+// bulkCommandOcppActions maps each bulk command action to the OCPP actions of the calls
+// that are sent for it
+var bulkCommandOcppActions = map[store.BulkCommandAction][]string{
+	store.BulkCommandActionReset:               {"Reset"},
+	store.BulkCommandActionChangeConfiguration: {"ChangeConfiguration", "SetVariables"},
+	store.BulkCommandActionUpdateFirmware:      {"UpdateFirmware"},
+	store.BulkCommandActionClearCache:          {"ClearCache"},
+}
+
+// bulkCommandRejections are the statuses returned by charge stations that mean the
+// command was not carried out
+var bulkCommandRejections = []string{
+	"Rejected",
+	"NotSupported",
+	"InvalidCertificate",
+	"RevokedCertificate",
+	"UnknownComponent",
+	"UnknownVariable",
+}
+
+// completeBulkCommandResult records the outcome of the call sent to the charge station
+// from the first call with the bulk command's action in the command audit log since the
+// call was sent. It reports whether the result was changed.
+func completeBulkCommandResult(ctx context.Context, engine store.CommandAuditStore, now time.Time, action store.BulkCommandAction, result *store.BulkCommandResult, responseTimeout time.Duration) (bool, error) {
+	records, err := engine.QueryCommandAuditRecords(ctx, &store.CommandAuditFilter{
+		ChargeStationId: result.ChargeStationId,
+		RequestedFrom:   &result.SentAt,
+	}, 0, 20)
+	if err != nil {
+		return false, err
+	}
+
+	var record *store.CommandAuditRecord
+	for _, candidate := range records {
+		if candidate.RequestedBy == transport.CommandAuditRequester &&
+			slices.Contains(bulkCommandOcppActions[action], candidate.Command) {
+			record = candidate
+			break
+		}
+	}
+
+	if record == nil || record.Status == store.CommandAuditStatusSent {
+		if now.Before(result.SentAt.Add(responseTimeout)) {
+			return false, nil
+		}
+		failBulkCommandResult(result, now, "no response from charge station")
+		return true, nil
+	}
+
+	if record.Status != store.CommandAuditStatusCompleted {
+		failBulkCommandResult(result, now, record.Result)
+		return true, nil
+	}
+
+	status := bulkCommandResponseStatus(record.Result)
+	result.Result = status
+	result.CompletedAt = now
+	if slices.Contains(bulkCommandRejections, status) {
+		result.Status = store.BulkCommandResultStatusFailed
+	} else {
+		result.Status = store.BulkCommandResultStatusAccepted
+	}
+	return true, nil
+}
+
+// bulkCommandResponseStatus returns the status from the charge station's response. The
+// response to an OCPP 1.6 UpdateFirmware has no status.
+func bulkCommandResponseStatus(payload string) string {
+	var response struct {
+		Status            string `json:"status"`
+		SetVariableResult []struct {
+			AttributeStatus string `json:"attributeStatus"`
+		} `json:"setVariableResult"`
+	}
+	if err := json.Unmarshal([]byte(payload), &response); err != nil {
+		return ""
+	}
+	if len(response.SetVariableResult) > 0 {
+		return response.SetVariableResult[0].AttributeStatus
+	}
+	return response.Status
+}
+
+func failBulkCommandResult(result *store.BulkCommandResult, now time.Time, reason string) {
+	result.Status = store.BulkCommandResultStatusFailed
+	result.Result = reason
+	result.CompletedAt = now
+}
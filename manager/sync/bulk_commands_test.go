@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+// respondWith records the call and the charge station's response in the command audit
+// log, as the transport.CommandAuditor does
+func respondWith(action, response string) updateFn {
+	return func(ctx context.Context, engine store.Engine, chargeStationId string, req ocpp.Request) error {
+		return engine.SetCommandAuditRecord(ctx, &store.CommandAuditRecord{
+			Id:              store.NewCommandAuditId(),
+			ChargeStationId: chargeStationId,
+			Command:         action,
+			RequestedBy:     transport.CommandAuditRequester,
+			RequestedAt:     time.Now(),
+			Status:          store.CommandAuditStatusCompleted,
+			Result:          response,
+			CompletedAt:     time.Now(),
+		})
+	}
+}
+
+func TestSyncBulkCommands(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	for csId, version := range map[string]string{"cs001": "2.0.1", "cs002": "1.6"} {
+		err := engine.SetChargeStationRuntimeDetails(ctx, csId, &store.ChargeStationRuntimeDetails{
+			OcppVersion: version,
+		})
+		require.NoError(t, err)
+	}
+
+	bulkCommand := &store.BulkCommand{
+		Id:          store.NewBulkCommandId(),
+		Action:      store.BulkCommandActionReset,
+		ResetType:   "OnIdle",
+		MaxInFlight: 1,
+		Status:      store.BulkCommandStatusRunning,
+		Results: []*store.BulkCommandResult{
+			{ChargeStationId: "cs001", Status: store.BulkCommandResultStatusPending},
+			{ChargeStationId: "cs002", Status: store.BulkCommandResultStatusPending},
+			{ChargeStationId: "cs003", Status: store.BulkCommandResultStatusPending},
+		},
+	}
+	require.NoError(t, engine.SetBulkCommand(ctx, bulkCommand))
+
+	v16CallMaker := &mockCallMaker{
+		engine:   engine,
+		updateFn: respondWith("Reset", `{"status":"Rejected"}`),
+	}
+	v201CallMaker := &mockCallMaker{
+		engine:   engine,
+		updateFn: respondWith("Reset", `{"status":"Accepted"}`),
+	}
+
+	sync.SyncBulkCommands(ctx, tracer, engine, clock.RealClock{}, v16CallMaker, v201CallMaker, 50*time.Millisecond, time.Minute)
+
+	require.Len(t, v201CallMaker.callEvents, 1)
+	assert.Equal(t, "cs001", v201CallMaker.callEvents[0].chargeStationId)
+	assert.Equal(t, &ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeOnIdle}, v201CallMaker.callEvents[0].request)
+	require.Len(t, v16CallMaker.callEvents, 1)
+	assert.Equal(t, "cs002", v16CallMaker.callEvents[0].chargeStationId)
+	assert.Equal(t, &ocpp16.ResetJson{Type: ocpp16.ResetJsonTypeSoft}, v16CallMaker.callEvents[0].request)
+
+	got, err := engine.LookupBulkCommand(context.Background(), bulkCommand.Id)
+	require.NoError(t, err)
+	assert.Equal(t, store.BulkCommandStatusCompleted, got.Status)
+	assert.Equal(t, store.BulkCommandResultStatusAccepted, got.Results[0].Status)
+	assert.Equal(t, "Accepted", got.Results[0].Result)
+	assert.Equal(t, store.BulkCommandResultStatusFailed, got.Results[1].Status)
+	assert.Equal(t, "Rejected", got.Results[1].Result)
+	assert.Equal(t, store.BulkCommandResultStatusFailed, got.Results[2].Status)
+	assert.Equal(t, "charge station has not connected", got.Results[2].Result)
+}
+
+func TestSyncBulkCommandsLimitsChargeStationsInFlight(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	bulkCommand := &store.BulkCommand{
+		Id:          store.NewBulkCommandId(),
+		Action:      store.BulkCommandActionChangeConfiguration,
+		Key:         "OCPPCommCtrlr/HeartbeatInterval",
+		Value:       "300",
+		MaxInFlight: 2,
+		Status:      store.BulkCommandStatusRunning,
+	}
+	for _, csId := range []string{"cs001", "cs002", "cs003"} {
+		err := engine.SetChargeStationRuntimeDetails(ctx, csId, &store.ChargeStationRuntimeDetails{
+			OcppVersion: "2.0.1",
+		})
+		require.NoError(t, err)
+		bulkCommand.Results = append(bulkCommand.Results, &store.BulkCommandResult{
+			ChargeStationId: csId,
+			Status:          store.BulkCommandResultStatusPending,
+		})
+	}
+	require.NoError(t, engine.SetBulkCommand(ctx, bulkCommand))
+
+	// the charge stations do not respond
+	v201CallMaker := &mockCallMaker{engine: engine}
+
+	sync.SyncBulkCommands(ctx, tracer, engine, clock.RealClock{}, &mockCallMaker{}, v201CallMaker, 50*time.Millisecond, time.Minute)
+
+	require.Len(t, v201CallMaker.callEvents, 2)
+	assert.Equal(t, &ocpp201.SetVariablesRequestJson{
+		SetVariableData: []ocpp201.SetVariableDataType{
+			{
+				AttributeValue: "300",
+				Component:      ocpp201.ComponentType{Name: "OCPPCommCtrlr"},
+				Variable:       ocpp201.VariableType{Name: "HeartbeatInterval"},
+			},
+		},
+	}, v201CallMaker.callEvents[0].request)
+
+	got, err := engine.LookupBulkCommand(context.Background(), bulkCommand.Id)
+	require.NoError(t, err)
+	assert.Equal(t, store.BulkCommandStatusRunning, got.Status)
+	assert.Equal(t, store.BulkCommandResultStatusSent, got.Results[0].Status)
+	assert.Equal(t, store.BulkCommandResultStatusSent, got.Results[1].Status)
+	assert.Equal(t, store.BulkCommandResultStatusPending, got.Results[2].Status)
+}
+
+func TestSyncBulkCommandsFailsChargeStationsThatDoNotRespond(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	sentAt := time.Now().Add(-10 * time.Minute)
+	bulkCommand := &store.BulkCommand{
+		Id:          store.NewBulkCommandId(),
+		Action:      store.BulkCommandActionClearCache,
+		MaxInFlight: 1,
+		Status:      store.BulkCommandStatusPaused,
+		Results: []*store.BulkCommandResult{
+			{ChargeStationId: "cs001", Status: store.BulkCommandResultStatusSent, SentAt: sentAt},
+			{ChargeStationId: "cs002", Status: store.BulkCommandResultStatusPending},
+		},
+	}
+	require.NoError(t, engine.SetBulkCommand(ctx, bulkCommand))
+
+	v201CallMaker := &mockCallMaker{engine: engine}
+
+	sync.SyncBulkCommands(ctx, tracer, engine, clock.RealClock{}, &mockCallMaker{}, v201CallMaker, 50*time.Millisecond, 5*time.Minute)
+
+	// a paused bulk command does not send the command to more charge stations
+	assert.Empty(t, v201CallMaker.callEvents)
+
+	got, err := engine.LookupBulkCommand(context.Background(), bulkCommand.Id)
+	require.NoError(t, err)
+	assert.Equal(t, store.BulkCommandStatusPaused, got.Status)
+	assert.Equal(t, store.BulkCommandResultStatusFailed, got.Results[0].Status)
+	assert.Equal(t, "no response from charge station", got.Results[0].Result)
+	assert.Equal(t, store.BulkCommandResultStatusPending, got.Results[1].Status)
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// SyncCertificateExpiry periodically checks the expiry of the certificates installed
+// by every charge station, raising alerts and triggering renewals as required.
+func SyncCertificateExpiry(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.Engine,
+	expiry *services.CertificateExpiryService,
+	runEvery time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync certificate expiry")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync certificate expiry", trace.WithSpanKind(trace.SpanKindInternal))
+				defer span.End()
+				var previousChargeStationId string
+				checked, renewals := 0, 0
+				for {
+					certificates, err := engine.ListChargeStationInstallCertificates(ctx, 50, previousChargeStationId)
+					if err != nil {
+						span.RecordError(err)
+						break
+					}
+					for _, cs := range certificates {
+						triggered, err := expiry.CheckChargeStation(ctx, cs)
+						if err != nil {
+							span.RecordError(err)
+						}
+						renewals += triggered
+					}
+					checked += len(certificates)
+					if len(certificates) < 50 {
+						break
+					}
+					previousChargeStationId = certificates[len(certificates)-1].ChargeStationId
+				}
+				span.SetAttributes(
+					attribute.Int("sync.certificate_expiry.count", checked),
+					attribute.Int("sync.certificate_expiry.renewals", renewals))
+			}()
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestSyncCertificateExpiry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for csId, notAfter := range map[string]time.Time{
+		"cs001": now.Add(90 * 24 * time.Hour),
+		"cs002": now.Add(7 * 24 * time.Hour),
+	} {
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: csId},
+			NotBefore:    now.Add(-time.Hour),
+			NotAfter:     notAfter,
+		}
+		certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+		err = engine.UpdateChargeStationInstallCertificates(ctx, csId, &store.ChargeStationInstallCertificates{
+			Certificates: []*store.ChargeStationInstallCertificate{
+				{
+					CertificateType:               store.CertificateTypeChargeStation,
+					CertificateId:                 csId,
+					CertificateData:               string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})),
+					CertificateInstallationStatus: store.CertificateInstallationAccepted,
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	sync.SyncCertificateExpiry(ctx, tracer, engine, &services.CertificateExpiryService{
+		Clock:       clock.RealClock{},
+		Store:       engine,
+		RenewBefore: 30 * 24 * time.Hour,
+		AlertBefore: 14 * 24 * time.Hour,
+	}, 100*time.Millisecond)
+
+	trigger, err := engine.LookupChargeStationTriggerMessage(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Nil(t, trigger)
+
+	trigger, err = engine.LookupChargeStationTriggerMessage(context.Background(), "cs002")
+	require.NoError(t, err)
+	require.NotNil(t, trigger)
+	assert.Equal(t, store.TriggerMessageSignChargingStationCertificate, trigger.TriggerMessage)
+}
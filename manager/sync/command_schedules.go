@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// SyncCommandSchedules creates a bulk command for each command schedule that is due.
+// The schedule's next run is written before the bulk command is created, so a schedule
+// that is run by another manager instance at the same time is skipped when its version
+// conflicts. A run that fails after the schedule has been written is not retried.
+func SyncCommandSchedules(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.Engine,
+	clock clock.PassiveClock,
+	runEvery time.Duration) {
+	var previousId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync command schedules")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync command schedules", trace.WithSpanKind(trace.SpanKindInternal),
+					trace.WithAttributes(attribute.String("sync.command_schedules.previous", previousId)))
+				defer span.End()
+				schedules, err := engine.ListCommandSchedules(ctx, 50, previousId)
+				if err != nil {
+					span.RecordError(err)
+					return
+				}
+				if len(schedules) > 0 {
+					previousId = schedules[len(schedules)-1].Id
+				} else {
+					previousId = ""
+				}
+				span.SetAttributes(attribute.Int("sync.command_schedules.count", len(schedules)))
+				run := 0
+				for _, schedule := range schedules {
+					if !schedule.Due(clock.Now()) {
+						continue
+					}
+					ran, err := runCommandSchedule(ctx, engine, clock.Now().UTC(), schedule)
+					if err != nil {
+						span.RecordError(err)
+					}
+					if ran {
+						run++
+					}
+				}
+				span.SetAttributes(attribute.Int("sync.command_schedules.run", run))
+			}()
+		}
+	}
+}
+
+// runCommandSchedule creates the bulk command for the schedule and advances it to its
+// next run. It reports whether this manager instance ran the schedule.
+func runCommandSchedule(ctx context.Context, engine store.Engine, now time.Time, schedule *store.CommandSchedule) (bool, error) {
+	chargeStationIds, err := store.SelectBulkCommandChargeStations(ctx, engine, &schedule.Command.Selector, schedule.TenantId)
+	if err != nil {
+		return false, err
+	}
+
+	bulkCommandId := store.NewBulkCommandId()
+	schedule.LastRunAt = now
+	schedule.LastBulkCommandId = bulkCommandId
+	schedule.LastError = ""
+	if len(chargeStationIds) == 0 {
+		schedule.LastBulkCommandId = ""
+		schedule.LastError = "no charge stations selected"
+	}
+	schedule.NextRunAt, err = schedule.NextRun(now)
+	if err != nil {
+		// the schedule cannot run again: it was validated when it was created
+		schedule.LastError = err.Error()
+	}
+
+	err = engine.SetCommandSchedule(ctx, schedule)
+	if errors.Is(err, store.ErrVersionConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if schedule.LastBulkCommandId == "" {
+		return true, nil
+	}
+
+	slog.Info("running command schedule", "scheduleId", schedule.Id, "bulkCommandId", bulkCommandId,
+		"chargeStations", len(chargeStationIds))
+	return true, engine.SetBulkCommand(ctx, schedule.NewBulkCommand(bulkCommandId, chargeStationIds, now))
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	gosync "sync"
+	"testing"
+	"time"
+)
+
+func TestSyncCommandSchedules(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{TenantId: "a", Tags: []string{"dc"}}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{TenantId: "a"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs003", &store.ChargeStation{TenantId: "b", Tags: []string{"dc"}}))
+
+	nightly := &store.CommandSchedule{
+		Id: store.NewCommandScheduleId(),
+		Command: store.BulkCommand{
+			Action:      store.BulkCommandActionReset,
+			Selector:    store.BulkCommandSelector{Tags: []string{"dc"}},
+			ResetType:   "OnIdle",
+			MaxInFlight: 10,
+		},
+		Cron:      "0 2 * * *",
+		NextRunAt: time.Now().Add(-time.Minute),
+		TenantId:  "a",
+		CreatedBy: "operator",
+	}
+	require.NoError(t, engine.SetCommandSchedule(ctx, nightly))
+	once := &store.CommandSchedule{
+		Id:        store.NewCommandScheduleId(),
+		Command:   store.BulkCommand{Action: store.BulkCommandActionClearCache, MaxInFlight: 10},
+		RunAt:     time.Now().Add(-time.Minute),
+		NextRunAt: time.Now().Add(-time.Minute),
+		TenantId:  "c",
+	}
+	require.NoError(t, engine.SetCommandSchedule(ctx, once))
+
+	// two manager instances run the schedules at the same time
+	var wg gosync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sync.SyncCommandSchedules(ctx, tracer, engine, clock.RealClock{}, 20*time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	bulkCommands, err := engine.ListBulkCommands(context.Background(), 10, "")
+	require.NoError(t, err)
+	require.Len(t, bulkCommands, 1)
+	assert.Equal(t, store.BulkCommandActionReset, bulkCommands[0].Action)
+	assert.Equal(t, "a", bulkCommands[0].TenantId)
+	assert.Equal(t, "operator", bulkCommands[0].CreatedBy)
+	require.Len(t, bulkCommands[0].Results, 1)
+	assert.Equal(t, "cs001", bulkCommands[0].Results[0].ChargeStationId)
+
+	got, err := engine.LookupCommandSchedule(context.Background(), nightly.Id)
+	require.NoError(t, err)
+	assert.Equal(t, bulkCommands[0].Id, got.LastBulkCommandId)
+	assert.Equal(t, 2, got.NextRunAt.Hour())
+	assert.True(t, got.NextRunAt.After(time.Now()))
+
+	// the schedule that runs once selected no charge stations and does not run again
+	got, err = engine.LookupCommandSchedule(context.Background(), once.Id)
+	require.NoError(t, err)
+	assert.Empty(t, got.LastBulkCommandId)
+	assert.Equal(t, "no charge stations selected", got.LastError)
+	assert.True(t, got.NextRunAt.IsZero())
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"math"
+	"time"
+)
+
+var groupChargeStations = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "manager_group_charge_stations",
+	Help: "The number of charge stations in each site, including its sub-sites, and with each tag",
+}, []string{"group_type", "group"})
+
+// SyncGroupMetrics periodically counts the charge stations in each site and tag.
+func SyncGroupMetrics(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.Engine,
+	runEvery time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync group metrics")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync group metrics", trace.WithSpanKind(trace.SpanKindInternal))
+				defer span.End()
+				count, err := updateGroupMetrics(ctx, engine)
+				if err != nil {
+					span.RecordError(err)
+				}
+				span.SetAttributes(attribute.Int("sync.group_metrics.count", count))
+			}()
+		}
+	}
+}
+
+// updateGroupMetrics sets the gauges from the registry and returns the number of charge
+// stations that were counted. Groups that have been deleted are removed from the gauge.
+func updateGroupMetrics(ctx context.Context, engine store.Engine) (int, error) {
+	sites, err := store.ListAllSites(ctx, engine)
+	if err != nil {
+		return 0, err
+	}
+	chargeStations, err := store.ListGroupChargeStations(ctx, engine, &store.ChargeStationGroup{}, 0, math.MaxInt)
+	if err != nil {
+		return 0, err
+	}
+
+	siteCounts := make(map[string]int)
+	tagCounts := make(map[string]int)
+	for _, chargeStation := range chargeStations {
+		if chargeStation.SiteId != "" {
+			// a charge station is also in each of the sites above its own
+			for _, siteId := range store.SiteAncestors(sites, chargeStation.SiteId) {
+				siteCounts[siteId]++
+			}
+		}
+		for _, tag := range chargeStation.Tags {
+			tagCounts[tag]++
+		}
+	}
+
+	groupChargeStations.Reset()
+	for siteId, count := range siteCounts {
+		groupChargeStations.WithLabelValues("site", siteId).Set(float64(count))
+	}
+	for tag, count := range tagCounts {
+		groupChargeStations.WithLabelValues("tag", tag).Set(float64(count))
+	}
+	return len(chargeStations), nil
+}
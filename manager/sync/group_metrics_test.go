@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSyncGroupMetrics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	require.NoError(t, engine.SetSite(ctx, &store.Site{Id: "region"}))
+	require.NoError(t, engine.SetSite(ctx, &store.Site{Id: "car-park", ParentSiteId: "region"}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs001", &store.ChargeStation{SiteId: "car-park", Tags: []string{"dc"}}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs002", &store.ChargeStation{SiteId: "region", Tags: []string{"dc"}}))
+	require.NoError(t, engine.SetChargeStation(ctx, "cs003", &store.ChargeStation{}))
+
+	sync.SyncGroupMetrics(ctx, tracer, engine, 100*time.Millisecond)
+
+	site := func(siteId string) float64 {
+		return testutil.MetricValue(t, "manager_group_charge_stations", map[string]string{"group_type": "site", "group": siteId})
+	}
+	assert.Equal(t, 2.0, site("region"))
+	assert.Equal(t, 1.0, site("car-park"))
+	assert.Equal(t, 2.0, testutil.MetricValue(t, "manager_group_charge_stations", map[string]string{"group_type": "tag", "group": "dc"}))
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// SyncInstalledCertificates sends GetInstalledCertificateIds requests for charge stations
+// that have a pending inventory refresh and DeleteCertificate requests for any installed
+// certificate that has been marked for deletion. Only OCPP 2.0.1 charge stations are
+// supported.
+func SyncInstalledCertificates(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.Engine,
+	clock clock.PassiveClock,
+	v201CallMaker handlers.CallMaker,
+	runEvery,
+	retryAfter time.Duration) {
+	var previousChargeStationId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync installed certificates")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync installed certificates", trace.WithSpanKind(trace.SpanKindInternal),
+					trace.WithAttributes(attribute.String("sync.installed_certificates.previous", previousChargeStationId)))
+				defer span.End()
+				inventories, err := engine.ListChargeStationInstalledCertificates(ctx, 50, previousChargeStationId)
+				if err != nil {
+					span.RecordError(err)
+					return
+				}
+				if len(inventories) > 0 {
+					previousChargeStationId = inventories[len(inventories)-1].ChargeStationId
+				} else {
+					previousChargeStationId = ""
+				}
+				span.SetAttributes(attribute.Int("sync.installed_certificates.count", len(inventories)))
+				for _, inventory := range inventories {
+					if !hasPendingInstalledCertificatesWork(clock, inventory) {
+						continue
+					}
+					func() {
+						ctx, span := tracer.Start(ctx, "sync installed certificate", trace.WithSpanKind(trace.SpanKindInternal),
+							trace.WithAttributes(
+								attribute.String("chargeStationId", inventory.ChargeStationId),
+								attribute.String("sync.installed_certificates.refresh", string(inventory.RefreshStatus)),
+							))
+						defer span.End()
+						details, err := engine.LookupChargeStationRuntimeDetails(ctx, inventory.ChargeStationId)
+						if err != nil {
+							span.RecordError(err)
+							return
+						}
+						if details == nil {
+							span.RecordError(fmt.Errorf("no runtime details for charge station"))
+							return
+						}
+						span.SetAttributes(attribute.String("sync.installed_certificates.ocpp_version", details.OcppVersion))
+						if details.OcppVersion == "1.6" {
+							span.RecordError(fmt.Errorf("installed certificate management not supported for OCPP 1.6"))
+							return
+						}
+
+						csId := inventory.ChargeStationId
+						var requests []ocpp.Request
+						if inventory.RefreshStatus == store.InstalledCertificatesRefreshPending && clock.Now().After(inventory.SendAfter) {
+							inventory.SendAfter = clock.Now().Add(retryAfter)
+							requests = append(requests, &ocpp201.GetInstalledCertificateIdsRequestJson{})
+						}
+						for _, cert := range inventory.Certificates {
+							if cert.Status == store.InstalledCertificateStatusDeletePending && clock.Now().After(cert.SendAfter) {
+								cert.SendAfter = clock.Now().Add(retryAfter)
+								requests = append(requests, &ocpp201.DeleteCertificateRequestJson{
+									CertificateHashData: ocpp201.CertificateHashDataType{
+										HashAlgorithm:  ocpp201.HashAlgorithmEnumType(cert.HashAlgorithm),
+										IssuerNameHash: cert.IssuerNameHash,
+										IssuerKeyHash:  cert.IssuerKeyHash,
+										SerialNumber:   cert.SerialNumber,
+									},
+								})
+							}
+						}
+
+						err = engine.SetChargeStationInstalledCertificates(ctx, csId, inventory)
+						if err != nil {
+							span.RecordError(err)
+							return
+						}
+
+						for _, req := range requests {
+							err = v201CallMaker.Send(ctx, csId, req)
+							if err != nil {
+								span.RecordError(err)
+							}
+						}
+					}()
+				}
+			}()
+		}
+	}
+}
+
+func hasPendingInstalledCertificatesWork(clock clock.PassiveClock, inventory *store.ChargeStationInstalledCertificates) bool {
+	if inventory.RefreshStatus == store.InstalledCertificatesRefreshPending && clock.Now().After(inventory.SendAfter) {
+		return true
+	}
+	for _, cert := range inventory.Certificates {
+		if cert.Status == store.InstalledCertificateStatusDeletePending && clock.Now().After(cert.SendAfter) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSyncInstalledCertificates(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationRuntimeDetails(ctx, "cs002", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+	})
+	require.NoError(t, err)
+
+	err = engine.SetChargeStationInstalledCertificates(ctx, "cs001", &store.ChargeStationInstalledCertificates{
+		Certificates: []*store.ChargeStationInstalledCertificate{
+			{
+				CertificateType: store.CertificateTypeMO,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "name-hash",
+				IssuerKeyHash:   "key-hash",
+				SerialNumber:    "0123",
+				Status:          store.InstalledCertificateStatusDeletePending,
+			},
+			{
+				CertificateType: store.CertificateTypeV2G,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "other-name-hash",
+				IssuerKeyHash:   "other-key-hash",
+				SerialNumber:    "4567",
+				Status:          store.InstalledCertificateStatusInstalled,
+			},
+		},
+		RefreshStatus: store.InstalledCertificatesRefreshPending,
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationInstalledCertificates(ctx, "cs002", &store.ChargeStationInstalledCertificates{
+		RefreshStatus: store.InstalledCertificatesRefreshPending,
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+
+	sync.SyncInstalledCertificates(ctx, tracer, engine, clock.RealClock{}, v201CallMaker, 100*time.Millisecond, 1*time.Second)
+
+	require.Len(t, v201CallMaker.callEvents, 2)
+	assert.Equal(t, "cs001", v201CallMaker.callEvents[0].chargeStationId)
+	assert.IsType(t, &ocpp201.GetInstalledCertificateIdsRequestJson{}, v201CallMaker.callEvents[0].request)
+	assert.Equal(t, "cs001", v201CallMaker.callEvents[1].chargeStationId)
+	assert.Equal(t, &ocpp201.DeleteCertificateRequestJson{
+		CertificateHashData: ocpp201.CertificateHashDataType{
+			HashAlgorithm:  ocpp201.HashAlgorithmEnumTypeSHA256,
+			IssuerNameHash: "name-hash",
+			IssuerKeyHash:  "key-hash",
+			SerialNumber:   "0123",
+		},
+	}, v201CallMaker.callEvents[1].request)
+}
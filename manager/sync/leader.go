@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"os"
+	"time"
+)
+
+var leaseHeld = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "manager_lease_held",
+	Help: "Whether this manager instance holds each lease and runs its jobs",
+}, []string{"lease"})
+
+// Lead runs the jobs on the manager instance that holds the named lease, so that the
+// jobs run on one instance at a time however many instances there are. The lease is
+// acquired or renewed every third of ttl. The jobs are started, with a context that is
+// cancelled when the instance loses the lease, each time that the instance acquires it.
+// An instance that cannot reach the store keeps running the jobs until its lease has
+// expired, after which another instance can acquire it. Lead returns when ctx is done,
+// after stopping the jobs and releasing the lease.
+func Lead(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.LeaseStore,
+	clock clock.PassiveClock,
+	name, holder string,
+	ttl time.Duration,
+	jobs func(ctx context.Context)) {
+	var cancel context.CancelFunc
+	var expiresAt time.Time
+	stop := func() {
+		slog.Info("lost lease", "lease", name, "holder", holder)
+		cancel()
+		cancel = nil
+		leaseHeld.WithLabelValues(name).Set(0)
+	}
+	defer func() {
+		if cancel == nil {
+			return
+		}
+		stop()
+		// ctx is done, so the lease is released with a context of its own
+		releaseCtx, releaseCancel := context.WithTimeout(context.Background(), ttl/3)
+		defer releaseCancel()
+		if err := store.ReleaseLease(releaseCtx, engine, name, holder, clock.Now()); err != nil {
+			slog.Warn("failed to release lease", "lease", name, "err", err)
+		}
+	}()
+
+	for {
+		func() {
+			spanCtx, span := tracer.Start(ctx, "sync lease", trace.WithSpanKind(trace.SpanKindInternal),
+				trace.WithAttributes(attribute.String("sync.lease.name", name), attribute.String("sync.lease.holder", holder)))
+			defer span.End()
+			lease, err := store.AcquireLease(spanCtx, engine, name, holder, clock.Now(), ttl)
+			switch {
+			case err != nil:
+				span.RecordError(err)
+				if cancel != nil && !clock.Now().Before(expiresAt) {
+					stop()
+				}
+			case lease == nil:
+				if cancel != nil {
+					stop()
+				}
+			default:
+				expiresAt = lease.ExpiresAt
+				if cancel == nil {
+					slog.Info("acquired lease", "lease", name, "holder", holder)
+					var leadCtx context.Context
+					leadCtx, cancel = context.WithCancel(ctx)
+					leaseHeld.WithLabelValues(name).Set(1)
+					jobs(leadCtx)
+				}
+			}
+			span.SetAttributes(attribute.Bool("sync.lease.held", cancel != nil))
+		}()
+
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync lease", "lease", name)
+			return
+		case <-time.After(ttl / 3):
+		}
+	}
+}
+
+// NewLeaseHolder returns a name for this manager instance that is different to the name
+// of every other instance
+func NewLeaseHolder() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "manager"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString())
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	gosync "sync"
+	"testing"
+	"time"
+)
+
+func TestLead(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	var mu gosync.Mutex
+	var leaders []string
+	running := 0
+	jobs := func(holder string) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			mu.Lock()
+			defer mu.Unlock()
+			leaders = append(leaders, holder)
+			running++
+			go func() {
+				<-ctx.Done()
+				mu.Lock()
+				defer mu.Unlock()
+				running--
+			}()
+		}
+	}
+	lead := func(ctx context.Context, holder string) chan struct{} {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			sync.Lead(ctx, tracer, engine, clock.RealClock{}, "sync", holder, 150*time.Millisecond, jobs(holder))
+		}()
+		return done
+	}
+	leadersAre := func(want ...string) func() bool {
+		return func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return assert.ObjectsAreEqual(want, leaders) && running == 1
+		}
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	done1 := lead(ctx1, "manager-1")
+	require.Eventually(t, leadersAre("manager-1"), time.Second, 10*time.Millisecond)
+
+	// the second instance does not run the jobs while the first renews the lease
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	done2 := lead(ctx2, "manager-2")
+	time.Sleep(400 * time.Millisecond)
+	assert.True(t, leadersAre("manager-1")())
+
+	// the first instance releases the lease when it stops, and the jobs move to the second
+	cancel1()
+	<-done1
+	require.Eventually(t, leadersAre("manager-1", "manager-2"), time.Second, 10*time.Millisecond)
+
+	cancel2()
+	<-done2
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return running == 0
+	}, time.Second, 10*time.Millisecond)
+}
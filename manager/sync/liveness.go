@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// SyncLiveness marks charge stations that have stopped sending messages as offline.
+func SyncLiveness(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.Engine,
+	liveness *services.LivenessService,
+	runEvery time.Duration) {
+	var previousChargeStationId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync liveness")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync liveness", trace.WithSpanKind(trace.SpanKindInternal),
+					trace.WithAttributes(attribute.String("sync.liveness.previous", previousChargeStationId)))
+				defer span.End()
+				chargeStations, err := engine.ListChargeStationLiveness(ctx, 50, previousChargeStationId)
+				if err != nil {
+					span.RecordError(err)
+					return
+				}
+				if len(chargeStations) > 0 {
+					previousChargeStationId = chargeStations[len(chargeStations)-1].ChargeStationId
+				} else {
+					previousChargeStationId = ""
+				}
+				span.SetAttributes(attribute.Int("sync.liveness.count", len(chargeStations)))
+				offline := 0
+				for _, cs := range chargeStations {
+					wentOffline, err := liveness.CheckOffline(ctx, cs)
+					if err != nil {
+						span.RecordError(err)
+					}
+					if wentOffline {
+						offline++
+					}
+				}
+				span.SetAttributes(attribute.Int("sync.liveness.offline", offline))
+			}()
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSyncLiveness(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	now := time.Now()
+	for csId, liveness := range map[string]*store.ChargeStationLiveness{
+		"cs001": {LastSeen: now},
+		"cs002": {LastSeen: now.Add(-16 * time.Minute)},
+		"cs003": {LastSeen: now.Add(-time.Hour), Offline: true},
+	} {
+		err := engine.SetChargeStationLiveness(ctx, csId, liveness)
+		require.NoError(t, err)
+	}
+
+	sync.SyncLiveness(ctx, tracer, engine, &services.LivenessService{
+		Clock:             clock.RealClock{},
+		Store:             engine,
+		HeartbeatInterval: services.NewHeartbeatInterval(5 * time.Minute),
+		MissedHeartbeats:  3,
+	}, 100*time.Millisecond)
+
+	for csId, want := range map[string]bool{
+		"cs001": false,
+		"cs002": true,
+		"cs003": true,
+	} {
+		liveness, err := engine.LookupChargeStationLiveness(context.Background(), csId)
+		require.NoError(t, err)
+		assert.Equal(t, want, liveness.Offline, csId)
+	}
+}
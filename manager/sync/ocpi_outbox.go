@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// OcpiOutbox holds the pushes to OCPI parties that have not yet been delivered
+type OcpiOutbox interface {
+	ProcessOutbox(ctx context.Context) error
+}
+
+// SyncOcpiOutbox retries the pushes to OCPI parties that failed when they were first sent.
+func SyncOcpiOutbox(ctx context.Context,
+	tracer trace.Tracer,
+	outbox OcpiOutbox,
+	runEvery time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync ocpi outbox")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync ocpi outbox", trace.WithSpanKind(trace.SpanKindInternal))
+				defer span.End()
+				err := outbox.ProcessOutbox(ctx)
+				if err != nil {
+					span.RecordError(err)
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"testing"
+	"time"
+)
+
+type countingOutbox struct {
+	count int
+}
+
+func (c *countingOutbox) ProcessOutbox(context.Context) error {
+	c.count++
+	return nil
+}
+
+func TestSyncOcpiOutbox(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	tracer, _ := testutil.GetTracer()
+
+	outbox := &countingOutbox{}
+	sync.SyncOcpiOutbox(ctx, tracer, outbox, 100*time.Millisecond)
+
+	assert.Greater(t, outbox.count, 0)
+}
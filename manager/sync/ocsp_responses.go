@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// SyncOcspResponses periodically revalidates the cached OCSP responses so that they are
+// still fresh if the OCSP responders become unavailable.
+func SyncOcspResponses(ctx context.Context,
+	tracer trace.Tracer,
+	revalidator services.OcspRevalidator,
+	runEvery time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync ocsp responses")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync ocsp responses", trace.WithSpanKind(trace.SpanKindInternal))
+				defer span.End()
+				revalidated, err := revalidator.RevalidateOCSPResponses(ctx)
+				if err != nil {
+					span.RecordError(err)
+				}
+				span.SetAttributes(attribute.Int("sync.ocsp.revalidated", revalidated))
+			}()
+		}
+	}
+}
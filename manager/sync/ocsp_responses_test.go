@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"testing"
+	"time"
+)
+
+type recordingOcspRevalidator struct {
+	calls int
+}
+
+func (r *recordingOcspRevalidator) RevalidateOCSPResponses(context.Context) (int, error) {
+	r.calls++
+	return 0, nil
+}
+
+func TestSyncOcspResponses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	tracer, _ := testutil.GetTracer()
+
+	revalidator := &recordingOcspRevalidator{}
+	sync.SyncOcspResponses(ctx, tracer, revalidator, 100*time.Millisecond)
+
+	assert.GreaterOrEqual(t, revalidator.calls, 3)
+}
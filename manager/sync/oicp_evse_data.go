@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// OicpEvseDataPusher sends the EVSE data of the locations in the store to Hubject
+type OicpEvseDataPusher interface {
+	PushEvseData(ctx context.Context) error
+}
+
+// SyncOicpEvseData pushes the EVSE data to Hubject when the manager starts and then
+// periodically, so that Hubject sees locations that have been added or changed.
+func SyncOicpEvseData(ctx context.Context,
+	tracer trace.Tracer,
+	pusher OicpEvseDataPusher,
+	runEvery time.Duration) {
+	push := func() {
+		ctx, span := tracer.Start(ctx, "sync oicp evse data", trace.WithSpanKind(trace.SpanKindInternal))
+		defer span.End()
+		err := pusher.PushEvseData(ctx)
+		if err != nil {
+			span.RecordError(err)
+			slog.Warn("unable to push evse data to hubject", "err", err)
+		}
+	}
+
+	push()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync oicp evse data")
+			return
+		case <-time.After(runEvery):
+			push()
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"testing"
+	"time"
+)
+
+type countingEvseDataPusher struct {
+	count int
+}
+
+func (c *countingEvseDataPusher) PushEvseData(context.Context) error {
+	c.count++
+	return nil
+}
+
+func TestSyncOicpEvseDataPushesOnStartAndPeriodically(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	tracer, _ := testutil.GetTracer()
+
+	pusher := &countingEvseDataPusher{}
+	sync.SyncOicpEvseData(ctx, tracer, pusher, 100*time.Millisecond)
+
+	assert.Greater(t, pusher.count, 1)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// SyncProvisioning sends the current step of the provisioning script to each OCPP 1.6
+// charge station that is being provisioned. Subsequent steps are sent as soon as the
+// charge station responds to the previous step: this loop starts the script after the
+// charge station has booted and resends a step if no response has been received
+// within the script's retry interval.
+func SyncProvisioning(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.Engine,
+	clock clock.PassiveClock,
+	v16CallMaker handlers.CallMaker,
+	script *ocpp16.ProvisioningScript,
+	runEvery time.Duration) {
+	var previousChargeStationId string
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync provisioning")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync provisioning", trace.WithSpanKind(trace.SpanKindInternal),
+					trace.WithAttributes(attribute.String("sync.provisioning.previous", previousChargeStationId)))
+				defer span.End()
+				provisioning, err := engine.ListChargeStationProvisioning(ctx, 50, previousChargeStationId)
+				if err != nil {
+					span.RecordError(err)
+					return
+				}
+				if len(provisioning) > 0 {
+					previousChargeStationId = provisioning[len(provisioning)-1].ChargeStationId
+				} else {
+					previousChargeStationId = ""
+				}
+				span.SetAttributes(attribute.Int("sync.provisioning.count", len(provisioning)))
+				for _, p := range provisioning {
+					if p.Status != store.ProvisioningStatusPending || !clock.Now().After(p.SendAfter) {
+						continue
+					}
+					func() {
+						ctx, span := tracer.Start(ctx, "sync provisioning step", trace.WithSpanKind(trace.SpanKindInternal),
+							trace.WithAttributes(
+								attribute.String("chargeStationId", p.ChargeStationId),
+								attribute.Int("sync.provisioning.step", p.Step),
+							))
+						defer span.End()
+
+						if p.Step >= len(script.Steps) {
+							// the script has been shortened since provisioning started
+							p.Status = store.ProvisioningStatusCompleted
+							err := engine.SetChargeStationProvisioning(ctx, p.ChargeStationId, p)
+							if err != nil {
+								span.RecordError(err)
+							}
+							return
+						}
+
+						p.SendAfter = clock.Now().Add(script.RetryAfter)
+						err := engine.SetChargeStationProvisioning(ctx, p.ChargeStationId, p)
+						if err != nil {
+							span.RecordError(err)
+							return
+						}
+
+						err = v16CallMaker.Send(ctx, p.ChargeStationId, script.Steps[p.Step].Request())
+						if err != nil {
+							span.RecordError(err)
+						}
+					}()
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	ocpp16types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSyncProvisioning(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	script := &ocpp16.ProvisioningScript{
+		Steps: []ocpp16.ProvisioningStep{
+			{ChangeConfiguration: &ocpp16types.ChangeConfigurationJson{Key: "MeterValueSampleInterval", Value: "60"}},
+			{TriggerMessage: &ocpp16types.TriggerMessageJson{
+				RequestedMessage: ocpp16types.TriggerMessageJsonRequestedMessageStatusNotification,
+			}},
+		},
+		RetryAfter: time.Second,
+	}
+
+	for csId, provisioning := range map[string]*store.ChargeStationProvisioning{
+		"cs001": {Status: store.ProvisioningStatusPending},
+		"cs002": {Status: store.ProvisioningStatusPending, Step: 1},
+		"cs003": {Status: store.ProvisioningStatusCompleted},
+		"cs004": {Status: store.ProvisioningStatusPending, SendAfter: time.Now().Add(time.Hour)},
+		"cs005": {Status: store.ProvisioningStatusPending, Step: 5},
+	} {
+		err := engine.SetChargeStationProvisioning(ctx, csId, provisioning)
+		require.NoError(t, err)
+	}
+
+	v16CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+
+	sync.SyncProvisioning(ctx, tracer, engine, clock.RealClock{}, v16CallMaker, script, 100*time.Millisecond)
+
+	require.Len(t, v16CallMaker.callEvents, 2)
+	assert.Equal(t, "cs001", v16CallMaker.callEvents[0].chargeStationId)
+	assert.Equal(t, script.Steps[0].ChangeConfiguration, v16CallMaker.callEvents[0].request)
+	assert.Equal(t, "cs002", v16CallMaker.callEvents[1].chargeStationId)
+	assert.Equal(t, script.Steps[1].TriggerMessage, v16CallMaker.callEvents[1].request)
+
+	provisioning, err := engine.LookupChargeStationProvisioning(context.Background(), "cs005")
+	require.NoError(t, err)
+	assert.Equal(t, store.ProvisioningStatusCompleted, provisioning.Status)
+}
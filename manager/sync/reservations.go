@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"time"
+)
+
+// SyncReservations sends ReserveNow and CancelReservation requests for reservations
+// that are waiting on a charge station. A reservation transfer is performed as a
+// ReserveNow to the target followed, once accepted, by a CancelReservation to the
+// original charge station. A reservation for a booked time slot is only sent shortly
+// before the slot starts. Reservations that are not used are released by the noShow
+// policy, unless it is nil. If payments is not nil then a hold is placed for each
+// reservation before it is sent and is settled once the reservation has finished. The
+// notifications policy, unless it is nil, tells drivers about their reservations. Only
+// OCPP 2.0.1 charge stations are supported. If the engine can lock reservations then a
+// request is only sent by the instance holding the lock.
+func SyncReservations(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.Engine,
+	clock clock.PassiveClock,
+	v201CallMaker handlers.CallMaker,
+	noShow *services.ReservationNoShowPolicy,
+	payments services.PaymentAuthorization,
+	notifications *services.DriverNotificationPolicy,
+	runEvery,
+	retryAfter time.Duration) {
+	var previousReservationId int
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync reservations")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync reservations", trace.WithSpanKind(trace.SpanKindInternal),
+					trace.WithAttributes(attribute.Int("sync.reservations.previous", previousReservationId)))
+				defer span.End()
+				reservations, err := engine.ListReservations(ctx, 50, previousReservationId)
+				if err != nil {
+					span.RecordError(err)
+					return
+				}
+				if len(reservations) > 0 {
+					previousReservationId = reservations[len(reservations)-1].ReservationId
+				} else {
+					previousReservationId = 0
+				}
+				span.SetAttributes(attribute.Int("sync.reservations.count", len(reservations)))
+				for _, reservation := range reservations {
+					changed := noShow != nil && noShow.Release(ctx, reservation, clock.Now())
+					if payments != nil {
+						settled, err := services.SettleReservationPayment(ctx, payments, engine, reservation, clock.Now())
+						if err != nil {
+							span.RecordError(err)
+						}
+						changed = changed || settled
+					}
+					if notifications != nil && notifications.Notify(ctx, reservation, clock.Now()) {
+						changed = true
+					}
+					if changed {
+						err = engine.SetReservation(ctx, reservation)
+						if err != nil {
+							span.RecordError(err)
+							continue
+						}
+					}
+					csId, action, req := reservationCall(reservation)
+					if req == nil || !clock.Now().After(reservation.SendAfter) {
+						continue
+					}
+					func() {
+						ctx, span := tracer.Start(ctx, "sync reservation", trace.WithSpanKind(trace.SpanKindInternal),
+							trace.WithAttributes(
+								attribute.String("chargeStationId", csId),
+								attribute.Int("sync.reservation.id", reservation.ReservationId),
+								attribute.String("sync.reservation.status", string(reservation.Status)),
+								attribute.String("sync.reservation.action", action),
+							))
+						defer span.End()
+						details, err := engine.LookupChargeStationRuntimeDetails(ctx, csId)
+						if err != nil {
+							span.RecordError(err)
+							return
+						}
+						if details == nil {
+							span.RecordError(fmt.Errorf("no runtime details for charge station"))
+							return
+						}
+						span.SetAttributes(attribute.String("sync.reservation.ocpp_version", details.OcppVersion))
+						if details.OcppVersion == "1.6" {
+							span.RecordError(fmt.Errorf("reservations not supported for OCPP 1.6"))
+							return
+						}
+
+						// the lock is held until the request may be retried so that other
+						// manager instances do not send it too
+						if locker, ok := engine.(store.ReservationLockStore); ok {
+							locked, err := locker.LockReservation(ctx, reservation.ReservationId, retryAfter)
+							if err != nil {
+								span.RecordError(err)
+								return
+							}
+							if !locked {
+								span.SetAttributes(attribute.Bool("sync.reservation.locked", true))
+								return
+							}
+						}
+
+						reservation.SendAfter = clock.Now().Add(retryAfter)
+						send := true
+						if payments != nil && reservation.Status == store.ReservationStatusPending && reservation.Transfer == nil {
+							err = services.AuthorizeReservationPayment(ctx, payments, reservation)
+							if err != nil {
+								span.RecordError(err)
+								if errors.Is(err, services.ErrPaymentDeclined) {
+									reservation.Status = store.ReservationStatusRejected
+								}
+								send = false
+							}
+						}
+						err = engine.SetReservation(ctx, reservation)
+						if err != nil {
+							span.RecordError(err)
+							return
+						}
+						if !send {
+							return
+						}
+
+						err = v201CallMaker.Send(ctx, csId, req)
+						if err != nil {
+							span.RecordError(err)
+						}
+					}()
+				}
+			}()
+		}
+	}
+}
+
+// reservationCall determines the charge station and request needed to progress the
+// reservation: it returns a nil request if there is nothing to send.
+func reservationCall(reservation *store.Reservation) (string, string, ocpp.Request) {
+	if reservation.Transfer != nil {
+		switch reservation.Transfer.Status {
+		case store.ReservationTransferStatusPending:
+			return reservation.Transfer.ChargeStationId, "ReserveNow",
+				reserveNowRequest(reservation, reservation.Transfer.EvseId)
+		case store.ReservationTransferStatusAccepted:
+			return reservation.ChargeStationId, "CancelReservation", &ocpp201.CancelReservationRequestJson{
+				ReservationId: reservation.ReservationId,
+			}
+		}
+	}
+
+	switch reservation.Status {
+	case store.ReservationStatusPending:
+		return reservation.ChargeStationId, "ReserveNow", reserveNowRequest(reservation, reservation.EvseId)
+	case store.ReservationStatusCancelPending:
+		return reservation.ChargeStationId, "CancelReservation", &ocpp201.CancelReservationRequestJson{
+			ReservationId: reservation.ReservationId,
+		}
+	}
+
+	return "", "", nil
+}
+
+func reserveNowRequest(reservation *store.Reservation, evseId *int) *ocpp201.ReserveNowRequestJson {
+	return &ocpp201.ReserveNowRequestJson{
+		Id:             reservation.ReservationId,
+		EvseId:         evseId,
+		ExpiryDateTime: reservation.ExpiryDate.UTC().Format(time.RFC3339),
+		IdToken: ocpp201.IdTokenType{
+			IdToken: reservation.IdToken,
+			Type:    ocpp201.IdTokenEnumType(reservation.TokenType),
+		},
+	}
+}
@@ -0,0 +1,398 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSyncReservations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	for csId, version := range map[string]string{"cs001": "2.0.1", "cs002": "1.6", "cs003": "2.0.1"} {
+		err := engine.SetChargeStationRuntimeDetails(ctx, csId, &store.ChargeStationRuntimeDetails{
+			OcppVersion: version,
+		})
+		require.NoError(t, err)
+	}
+
+	evseId := 1
+	targetEvseId := 2
+	expiry := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	reservations := []*store.Reservation{
+		{ReservationId: 1, ChargeStationId: "cs001", EvseId: &evseId, Status: store.ReservationStatusPending},
+		{ReservationId: 2, ChargeStationId: "cs001", Status: store.ReservationStatusCancelPending},
+		{ReservationId: 3, ChargeStationId: "cs001", Status: store.ReservationStatusAccepted,
+			Transfer: &store.ReservationTransfer{
+				ChargeStationId: "cs003",
+				EvseId:          &targetEvseId,
+				Status:          store.ReservationTransferStatusPending,
+			}},
+		{ReservationId: 4, ChargeStationId: "cs001", Status: store.ReservationStatusAccepted,
+			Transfer: &store.ReservationTransfer{
+				ChargeStationId: "cs003",
+				Status:          store.ReservationTransferStatusAccepted,
+			}},
+		{ReservationId: 5, ChargeStationId: "cs002", Status: store.ReservationStatusPending},
+		{ReservationId: 6, ChargeStationId: "cs001", Status: store.ReservationStatusAccepted},
+	}
+	for _, reservation := range reservations {
+		reservation.IdToken = "DEADBEEF"
+		reservation.TokenType = "ISO14443"
+		reservation.ExpiryDate = expiry
+		err := engine.SetReservation(ctx, reservation)
+		require.NoError(t, err)
+	}
+
+	v201CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+
+	sync.SyncReservations(ctx, tracer, engine, clock.RealClock{}, v201CallMaker, &services.ReservationNoShowPolicy{GracePeriod: 15 * time.Minute}, nil, nil, 100*time.Millisecond, 1*time.Second)
+
+	idToken := ocpp201.IdTokenType{
+		IdToken: "DEADBEEF",
+		Type:    ocpp201.IdTokenEnumTypeISO14443,
+	}
+	require.Len(t, v201CallMaker.callEvents, 4)
+	assert.Equal(t, "cs001", v201CallMaker.callEvents[0].chargeStationId)
+	assert.Equal(t, &ocpp201.ReserveNowRequestJson{
+		Id:             1,
+		EvseId:         &evseId,
+		ExpiryDateTime: "2026-10-14T12:00:00Z",
+		IdToken:        idToken,
+	}, v201CallMaker.callEvents[0].request)
+	assert.Equal(t, "cs001", v201CallMaker.callEvents[1].chargeStationId)
+	assert.Equal(t, &ocpp201.CancelReservationRequestJson{ReservationId: 2}, v201CallMaker.callEvents[1].request)
+	assert.Equal(t, "cs003", v201CallMaker.callEvents[2].chargeStationId)
+	assert.Equal(t, &ocpp201.ReserveNowRequestJson{
+		Id:             3,
+		EvseId:         &targetEvseId,
+		ExpiryDateTime: "2026-10-14T12:00:00Z",
+		IdToken:        idToken,
+	}, v201CallMaker.callEvents[2].request)
+	assert.Equal(t, "cs001", v201CallMaker.callEvents[3].chargeStationId)
+	assert.Equal(t, &ocpp201.CancelReservationRequestJson{ReservationId: 4}, v201CallMaker.callEvents[3].request)
+}
+
+type lockedReservationsEngine struct {
+	store.Engine
+	locked map[int]bool
+}
+
+func (e lockedReservationsEngine) LockReservation(_ context.Context, reservationId int, _ time.Duration) (bool, error) {
+	return !e.locked[reservationId], nil
+}
+
+func (e lockedReservationsEngine) UnlockReservation(context.Context, int) error {
+	return nil
+}
+
+func TestSyncReservationsSkipsReservationsLockedByAnotherInstance(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	for _, reservationId := range []int{1, 2} {
+		err = engine.SetReservation(ctx, &store.Reservation{
+			ReservationId:   reservationId,
+			ChargeStationId: "cs001",
+			IdToken:         "DEADBEEF",
+			TokenType:       "ISO14443",
+			Status:          store.ReservationStatusCancelPending,
+		})
+		require.NoError(t, err)
+	}
+
+	v201CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+
+	sync.SyncReservations(ctx, tracer, lockedReservationsEngine{Engine: engine, locked: map[int]bool{1: true}},
+		clock.RealClock{}, v201CallMaker, nil, nil, nil, 100*time.Millisecond, 1*time.Second)
+
+	require.Len(t, v201CallMaker.callEvents, 1)
+	assert.Equal(t, &ocpp201.CancelReservationRequestJson{ReservationId: 2}, v201CallMaker.callEvents[0].request)
+}
+
+func TestSyncReservationsSendsBookingsShortlyBeforeTheyStart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	now := time.Now()
+	for reservationId, startDate := range map[int]time.Time{1: now.Add(time.Hour), 2: now.Add(time.Minute)} {
+		err = engine.SetReservation(ctx, &store.Reservation{
+			ReservationId:   reservationId,
+			ChargeStationId: "cs001",
+			IdToken:         "DEADBEEF",
+			TokenType:       "ISO14443",
+			StartDate:       startDate,
+			ExpiryDate:      startDate.Add(time.Hour),
+			Status:          store.ReservationStatusPending,
+			SendAfter:       startDate.Add(-store.ReservationLeadTime),
+		})
+		require.NoError(t, err)
+	}
+
+	v201CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+
+	sync.SyncReservations(ctx, tracer, engine, clock.RealClock{}, v201CallMaker, &services.ReservationNoShowPolicy{GracePeriod: 15 * time.Minute}, nil, nil, 100*time.Millisecond, 1*time.Second)
+
+	require.Len(t, v201CallMaker.callEvents, 1)
+	req, ok := v201CallMaker.callEvents[0].request.(*ocpp201.ReserveNowRequestJson)
+	require.True(t, ok)
+	assert.Equal(t, 2, req.Id)
+}
+
+func TestSyncReservationsCancelsUnusedBookings(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	now := time.Now()
+	for reservationId, startDate := range map[int]time.Time{1: now.Add(-20 * time.Minute), 2: now.Add(-10 * time.Minute)} {
+		err = engine.SetReservation(ctx, &store.Reservation{
+			ReservationId:   reservationId,
+			ChargeStationId: "cs001",
+			IdToken:         "DEADBEEF",
+			TokenType:       "ISO14443",
+			StartDate:       startDate,
+			ExpiryDate:      startDate.Add(time.Hour),
+			Status:          store.ReservationStatusAccepted,
+		})
+		require.NoError(t, err)
+	}
+
+	v201CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+
+	sync.SyncReservations(ctx, tracer, engine, clock.RealClock{}, v201CallMaker, &services.ReservationNoShowPolicy{GracePeriod: 15 * time.Minute}, nil, nil, 100*time.Millisecond, 1*time.Second)
+
+	require.Len(t, v201CallMaker.callEvents, 1)
+	assert.Equal(t, &ocpp201.CancelReservationRequestJson{ReservationId: 1}, v201CallMaker.callEvents[0].request)
+
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusCancelPending, reservation.Status)
+	assert.True(t, reservation.NoShow)
+	reservation, err = engine.LookupReservation(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusAccepted, reservation.Status)
+}
+
+type mockPaymentAuthorization struct {
+	declined bool
+	captured map[int]*store.CostBreakdown
+	released []int
+}
+
+func (m *mockPaymentAuthorization) Authorize(_ context.Context, reservation *store.Reservation) (string, error) {
+	if m.declined {
+		return "", services.ErrPaymentDeclined
+	}
+	return fmt.Sprintf("auth-%d", reservation.ReservationId), nil
+}
+
+func (m *mockPaymentAuthorization) Capture(_ context.Context, reservation *store.Reservation, amount *store.CostBreakdown) error {
+	m.captured[reservation.ReservationId] = amount
+	return nil
+}
+
+func (m *mockPaymentAuthorization) Release(_ context.Context, reservation *store.Reservation) error {
+	m.released = append(m.released, reservation.ReservationId)
+	return nil
+}
+
+func TestSyncReservationsAuthorizesPaymentBeforeReserving(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	err = engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ExpiryDate:      time.Now().Add(time.Hour),
+		Status:          store.ReservationStatusPending,
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+	payments := &mockPaymentAuthorization{}
+
+	sync.SyncReservations(ctx, tracer, engine, clock.RealClock{}, v201CallMaker, nil, payments, nil, 100*time.Millisecond, 1*time.Second)
+
+	require.Len(t, v201CallMaker.callEvents, 1)
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, &store.ReservationPayment{
+		AuthorizationId: "auth-1",
+		Status:          store.ReservationPaymentStatusAuthorized,
+	}, reservation.Payment)
+}
+
+func TestSyncReservationsRejectsReservationsWhenPaymentIsDeclined(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	err = engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ExpiryDate:      time.Now().Add(time.Hour),
+		Status:          store.ReservationStatusPending,
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+	payments := &mockPaymentAuthorization{declined: true}
+
+	sync.SyncReservations(ctx, tracer, engine, clock.RealClock{}, v201CallMaker, nil, payments, nil, 100*time.Millisecond, 1*time.Second)
+
+	assert.Empty(t, v201CallMaker.callEvents)
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, store.ReservationStatusRejected, reservation.Status)
+	assert.Nil(t, reservation.Payment)
+}
+
+func TestSyncReservationsChargesNoShowFees(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+	startDate := time.Now().Add(-20 * time.Minute)
+	err = engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		StartDate:       startDate,
+		ExpiryDate:      startDate.Add(time.Hour),
+		Status:          store.ReservationStatusAccepted,
+		Payment: &store.ReservationPayment{
+			AuthorizationId: "auth-1",
+			Status:          store.ReservationPaymentStatusAuthorized,
+		},
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+	payments := &mockPaymentAuthorization{captured: make(map[int]*store.CostBreakdown)}
+	fee := &store.CostBreakdown{Currency: "EUR", SessionFee: 5, TotalCost: 5}
+
+	sync.SyncReservations(ctx, tracer, engine, clock.RealClock{}, v201CallMaker,
+		&services.ReservationNoShowPolicy{GracePeriod: 15 * time.Minute, Fees: fixedNoShowFee{fee}}, payments, nil, 100*time.Millisecond, 1*time.Second)
+
+	assert.Equal(t, map[int]*store.CostBreakdown{1: fee}, payments.captured)
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.True(t, reservation.NoShow)
+	assert.Equal(t, store.ReservationPaymentStatusCaptured, reservation.Payment.Status)
+}
+
+type fixedNoShowFee struct {
+	fee *store.CostBreakdown
+}
+
+func (f fixedNoShowFee) NoShowFee(context.Context, *store.Reservation) (*store.CostBreakdown, error) {
+	return f.fee, nil
+}
+
+type recordingDriverNotifier struct {
+	notifications []store.ReservationNotification
+}
+
+func (r *recordingDriverNotifier) NotifyDriver(_ context.Context, notification store.ReservationNotification, _ *store.Reservation) error {
+	r.notifications = append(r.notifications, notification)
+	return nil
+}
+
+func TestSyncReservationsNotifiesDrivers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	err := engine.SetReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		IdToken:         "DEADBEEF",
+		TokenType:       "ISO14443",
+		ExpiryDate:      time.Now().Add(10 * time.Minute),
+		Status:          store.ReservationStatusAccepted,
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{
+		engine: engine,
+	}
+	notifier := &recordingDriverNotifier{}
+
+	sync.SyncReservations(ctx, tracer, engine, clock.RealClock{}, v201CallMaker, nil, nil,
+		&services.DriverNotificationPolicy{Notifier: notifier, ReminderBefore: 15 * time.Minute}, 100*time.Millisecond, 1*time.Second)
+
+	want := []store.ReservationNotification{store.ReservationNotificationConfirmed, store.ReservationNotificationExpiring}
+	assert.Equal(t, want, notifier.notifications)
+	reservation, err := engine.LookupReservation(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, want, reservation.Notified)
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// SyncRetention periodically purges and anonymizes data according to the retention policy.
+func SyncRetention(ctx context.Context,
+	tracer trace.Tracer,
+	retention *services.RetentionService,
+	runEvery time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync retention")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync retention", trace.WithSpanKind(trace.SpanKindInternal))
+				defer span.End()
+				result, err := retention.Apply(ctx)
+				if err != nil {
+					span.RecordError(err)
+				}
+				span.SetAttributes(
+					attribute.Int("sync.retention.purged_meter_values", result.PurgedMeterValues),
+					attribute.Int("sync.retention.anonymized_transactions", result.AnonymizedTransactions))
+			}()
+		}
+	}
+}
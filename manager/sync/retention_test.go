@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"k8s.io/utils/clock"
+	"testing"
+	"time"
+)
+
+func TestSyncRetention(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+	tracer, _ := testutil.GetTracer()
+
+	now := time.Now()
+	err := engine.AddMeterValues(ctx, "cs001", 1, []store.MeterValue{
+		{Timestamp: now.Add(-48 * time.Hour).Format(time.RFC3339)},
+		{Timestamp: now.Format(time.RFC3339)},
+	})
+	require.NoError(t, err)
+
+	sync.SyncRetention(ctx, tracer, &services.RetentionService{
+		Clock:       clock.RealClock{},
+		Store:       engine,
+		MeterValues: 24 * time.Hour,
+	}, 100*time.Millisecond)
+
+	meterValues, err := engine.LookupMeterValues(context.Background(), "cs001", 1)
+	require.NoError(t, err)
+	assert.Len(t, meterValues.MeterValues, 1)
+}
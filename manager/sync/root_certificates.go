@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// SyncRootCertificates periodically refreshes the root certificates used to validate
+// contract certificates so that new root certificates are trusted without restarting
+// the manager.
+func SyncRootCertificates(ctx context.Context,
+	tracer trace.Tracer,
+	refresher services.RootCertificateRefresher,
+	runEvery time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync root certificates")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync root certificates", trace.WithSpanKind(trace.SpanKindInternal))
+				defer span.End()
+				refreshed, err := refresher.RefreshRootCertificates(ctx)
+				if err != nil {
+					slog.Warn("refreshing root certificates", "err", err)
+					span.RecordError(err)
+				}
+				span.SetAttributes(attribute.Int("sync.root_certificates.refreshed", refreshed))
+			}()
+		}
+	}
+}
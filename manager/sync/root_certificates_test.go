@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"testing"
+	"time"
+)
+
+type recordingRootCertificateRefresher struct {
+	calls int
+}
+
+func (r *recordingRootCertificateRefresher) RefreshRootCertificates(context.Context) (int, error) {
+	r.calls++
+	return 1, nil
+}
+
+func TestSyncRootCertificates(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	tracer, _ := testutil.GetTracer()
+
+	refresher := &recordingRootCertificateRefresher{}
+	sync.SyncRootCertificates(ctx, tracer, refresher, 100*time.Millisecond)
+
+	assert.GreaterOrEqual(t, refresher.calls, 3)
+}
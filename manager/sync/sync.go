@@ -6,6 +6,7 @@ import (
 	"context"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/services"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"go.opentelemetry.io/otel/trace"
@@ -13,32 +14,143 @@ import (
 	"time"
 )
 
-func Sync(storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tracer, emitter transport.Emitter) {
+// leaseTtl is how long the manager instance that runs the jobs holds the lease: another
+// instance takes over the jobs within leaseTtl of the instance stopping
+const leaseTtl = 30 * time.Second
+
+// Sync starts the background jobs until ctx is done. The returned channel is closed
+// once the jobs have been stopped and this instance has released the lease to run them,
+// so that another instance can take them over straight away.
+func Sync(ctx context.Context, storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tracer, emitter transport.Emitter, provisioningScript *ocpp16.ProvisioningScript, liveness *services.LivenessService, ocpiOutbox OcpiOutbox, oicpEvseData OicpEvseDataPusher, retention *services.RetentionService, ocspRevalidator services.OcspRevalidator, rootCertificates services.RootCertificateRefresher, certificateExpiry *services.CertificateExpiryService, reservationNoShow *services.ReservationNoShowPolicy, reservationPayments services.PaymentAuthorization, driverNotifications *services.DriverNotificationPolicy) <-chan struct{} {
 	v16SyncCallMaker := ocpp16.NewCallMaker(emitter)
 	dataTransferCallMaker := ocpp16.NewDataTransferCallMaker(emitter)
 	v201SyncCallMaker := ocpp201.NewCallMaker(emitter)
 
-	go SyncSettings(context.Background(),
-		storageEngine,
-		clock,
-		v16SyncCallMaker,
-		v201SyncCallMaker,
-		1*time.Minute,
-		2*time.Minute)
-	go SyncCertificates(context.Background(),
-		storageEngine,
-		clock,
-		dataTransferCallMaker,
-		v201SyncCallMaker,
-		1*time.Minute,
-		2*time.Minute)
-	go SyncTriggers(context.Background(),
+	jobs := func(ctx context.Context) {
+		go SyncSettings(ctx,
+			storageEngine,
+			clock,
+			v16SyncCallMaker,
+			v201SyncCallMaker,
+			1*time.Minute,
+			2*time.Minute)
+		go SyncCertificates(ctx,
+			storageEngine,
+			clock,
+			dataTransferCallMaker,
+			v201SyncCallMaker,
+			1*time.Minute,
+			2*time.Minute)
+		go SyncTriggers(ctx,
+			tracer,
+			storageEngine,
+			clock,
+			v16SyncCallMaker,
+			dataTransferCallMaker,
+			v201SyncCallMaker,
+			1*time.Minute,
+			2*time.Minute)
+		go SyncInstalledCertificates(ctx,
+			tracer,
+			storageEngine,
+			clock,
+			v201SyncCallMaker,
+			1*time.Minute,
+			2*time.Minute)
+		go SyncReservations(ctx,
+			tracer,
+			storageEngine,
+			clock,
+			v201SyncCallMaker,
+			reservationNoShow,
+			reservationPayments,
+			driverNotifications,
+			1*time.Minute,
+			2*time.Minute)
+		go SyncBulkCommands(ctx,
+			tracer,
+			storageEngine,
+			clock,
+			v16SyncCallMaker,
+			v201SyncCallMaker,
+			10*time.Second,
+			5*time.Minute)
+		go SyncCommandSchedules(ctx,
+			tracer,
+			storageEngine,
+			clock,
+			30*time.Second)
+		if provisioningScript != nil {
+			go SyncProvisioning(ctx,
+				tracer,
+				storageEngine,
+				clock,
+				v16SyncCallMaker,
+				provisioningScript,
+				1*time.Minute)
+		}
+		if liveness != nil {
+			go SyncLiveness(ctx,
+				tracer,
+				storageEngine,
+				liveness,
+				1*time.Minute)
+		}
+		if ocpiOutbox != nil {
+			go SyncOcpiOutbox(ctx,
+				tracer,
+				ocpiOutbox,
+				1*time.Minute)
+		}
+		if oicpEvseData != nil {
+			go SyncOicpEvseData(ctx,
+				tracer,
+				oicpEvseData,
+				1*time.Hour)
+		}
+		if retention != nil {
+			go SyncRetention(ctx,
+				tracer,
+				retention,
+				1*time.Hour)
+		}
+		if ocspRevalidator != nil {
+			go SyncOcspResponses(ctx,
+				tracer,
+				ocspRevalidator,
+				5*time.Minute)
+		}
+		if rootCertificates != nil {
+			go SyncRootCertificates(ctx,
+				tracer,
+				rootCertificates,
+				1*time.Hour)
+		}
+		if certificateExpiry != nil {
+			go SyncCertificateExpiry(ctx,
+				tracer,
+				storageEngine,
+				certificateExpiry,
+				1*time.Hour)
+		}
+	}
+	// the jobs are run by one manager instance at a time
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Lead(ctx,
+			tracer,
+			storageEngine,
+			clock,
+			"sync",
+			NewLeaseHolder(),
+			leaseTtl,
+			jobs)
+	}()
+	// every manager instance reports the metrics
+	go SyncGroupMetrics(ctx,
 		tracer,
 		storageEngine,
-		clock,
-		v16SyncCallMaker,
-		dataTransferCallMaker,
-		v201SyncCallMaker,
-		1*time.Minute,
-		2*time.Minute)
+		1*time.Minute)
+	return done
 }
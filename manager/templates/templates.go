@@ -6,3 +6,6 @@ import _ "embed"
 
 //go:embed transactions.html
 var Transactions string
+
+//go:embed receipt.html
+var Receipt string
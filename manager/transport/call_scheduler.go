@@ -0,0 +1,304 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+	"sync"
+	"time"
+)
+
+var (
+	outboundCallsQueued = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_outbound_calls_queued_total",
+		Help: "The number of calls to charge stations that had to wait for an earlier call to complete, by action",
+	}, []string{"action"})
+	outboundCallsTimedOut = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_outbound_calls_timed_out_total",
+		Help: "The number of calls to charge stations that were not answered before the call timeout, by action",
+	}, []string{"action"})
+)
+
+// CallScheduler is an Emitter that sends a charge station one call at a time, as OCPP
+// requires. A call that is emitted while another call to the charge station is in
+// flight is queued until the charge station responds with a CallResult or CallError,
+// or the call in flight times out. CallResults and CallErrors are emitted straight
+// away.
+//
+// The queues are held in the store, which is read each time a queue is needed, so that
+// they survive a restart and are shared by all the manager instances: a call is only
+// sent once the calls made by any instance have completed. A queue is written with a
+// version check and the change is retried if another instance changed the queue at
+// the same time. A call is put in flight in the store before it is sent, so a call
+// is not lost if the queue cannot be stored: either the call is not sent and Emit
+// returns the error, or the call stays in flight until it times out.
+//
+// The charge station's responses are seen by wrapping the MessageHandlers with
+// Handler. Timeouts are noticed when the next message is received from, or emitted
+// to, the charge station.
+type CallScheduler struct {
+	emitter Emitter
+	store   store.OutboundCallQueueStore
+	clock   clock.PassiveClock
+	timeout time.Duration
+
+	mu sync.Mutex
+	// stations serialises the changes this instance makes to each charge station's
+	// queue, so that the instance does not conflict with itself
+	stations map[string]*sync.Mutex
+}
+
+// maxQueueUpdateAttempts is the number of times a queue is read and written before
+// the CallScheduler gives up because other instances keep changing it
+const maxQueueUpdateAttempts = 5
+
+type CallSchedulerOpt func(*CallScheduler)
+
+// WithCallSchedulerClock sets the clock used to expire calls in flight
+func WithCallSchedulerClock(clock clock.PassiveClock) CallSchedulerOpt {
+	return func(s *CallScheduler) {
+		s.clock = clock
+	}
+}
+
+// WithCallSchedulerTimeout sets how long to wait for the response to a call
+// before the next call is sent, defaults to 30s
+func WithCallSchedulerTimeout(timeout time.Duration) CallSchedulerOpt {
+	return func(s *CallScheduler) {
+		s.timeout = timeout
+	}
+}
+
+func NewCallScheduler(emitter Emitter, engine store.OutboundCallQueueStore, opts ...CallSchedulerOpt) *CallScheduler {
+	s := &CallScheduler{
+		emitter:  emitter,
+		store:    engine,
+		clock:    clock.RealClock{},
+		timeout:  30 * time.Second,
+		stations: make(map[string]*sync.Mutex),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *CallScheduler) Emit(ctx context.Context, ocppVersion OcppVersion, chargeStationId string, message *Message) error {
+	if message.MessageType != MessageTypeCall {
+		return s.emitter.Emit(ctx, ocppVersion, chargeStationId, message)
+	}
+
+	station := s.station(chargeStationId)
+	station.Lock()
+	defer station.Unlock()
+
+	call := &store.OutboundCall{
+		OcppVersion:    string(ocppVersion),
+		MessageId:      message.MessageId,
+		Action:         message.Action,
+		RequestPayload: string(message.RequestPayload),
+		State:          string(message.State),
+	}
+
+	var timedOut, next *store.OutboundCall
+	err := s.update(ctx, chargeStationId, func(queue *store.OutboundCallQueue) bool {
+		timedOut = s.expire(queue)
+		queue.Queued = append(queue.Queued, call)
+		next = nil
+		if queue.InFlight == nil {
+			// the earlier queued calls, if any, go first
+			next = s.takeNext(queue)
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("queueing call %s to %s: %w", message.MessageId, chargeStationId, err)
+	}
+	reportTimeout(chargeStationId, timedOut)
+
+	switch {
+	case next == nil:
+		outboundCallsQueued.WithLabelValues(message.Action).Inc()
+	case next.MessageId == call.MessageId:
+		err = s.emitter.Emit(ctx, ocppVersion, chargeStationId, message)
+		if err != nil {
+			s.sendQueued(ctx, chargeStationId, s.drop(ctx, chargeStationId, call))
+			return err
+		}
+	default:
+		s.sendQueued(ctx, chargeStationId, next)
+	}
+	return nil
+}
+
+// Handler returns a MessageHandler that passes each message to next and then, if the
+// message completes the call in flight or the call in flight has timed out, sends the
+// next queued call to the charge station.
+func (s *CallScheduler) Handler(next MessageHandler) MessageHandler {
+	return MessageHandlerFunc(func(ctx context.Context, chargeStationId string, message *Message) {
+		next.Handle(ctx, chargeStationId, message)
+		s.release(ctx, chargeStationId, message)
+	})
+}
+
+func (s *CallScheduler) release(ctx context.Context, chargeStationId string, message *Message) {
+	station := s.station(chargeStationId)
+	station.Lock()
+	defer station.Unlock()
+
+	var timedOut, next *store.OutboundCall
+	err := s.update(ctx, chargeStationId, func(queue *store.OutboundCallQueue) bool {
+		timedOut, next = nil, nil
+		if queue.InFlight == nil {
+			return false
+		}
+		if message.MessageType != MessageTypeCall && message.MessageId == queue.InFlight.MessageId {
+			queue.InFlight = nil
+		} else if timedOut = s.expire(queue); timedOut == nil {
+			return false
+		}
+		next = s.takeNext(queue)
+		return true
+	})
+	if err != nil {
+		slog.Error("storing outbound call queue", "chargeStationId", chargeStationId, "error", err)
+		return
+	}
+	reportTimeout(chargeStationId, timedOut)
+	s.sendQueued(ctx, chargeStationId, next)
+}
+
+// update reads the charge station's queue from the store and passes it to fn, which
+// changes the queue and reports whether it has changed. A changed queue is written
+// back: if another instance has changed the queue since it was read, fn is called
+// again with the queue as it is now.
+func (s *CallScheduler) update(ctx context.Context, chargeStationId string, fn func(queue *store.OutboundCallQueue) bool) error {
+	var err error
+	for attempt := 0; attempt < maxQueueUpdateAttempts; attempt++ {
+		var queue *store.OutboundCallQueue
+		queue, err = s.store.LookupOutboundCallQueue(ctx, chargeStationId)
+		if err != nil {
+			return fmt.Errorf("lookup outbound call queue for %s: %w", chargeStationId, err)
+		}
+		if queue == nil {
+			queue = &store.OutboundCallQueue{ChargeStationId: chargeStationId}
+		}
+		if !fn(queue) {
+			return nil
+		}
+		err = s.store.SetOutboundCallQueue(ctx, queue)
+		if !errors.Is(err, store.ErrVersionConflict) {
+			return err
+		}
+	}
+	return err
+}
+
+// takeNext puts the first queued call in flight and returns it, or returns nil if
+// no calls are queued
+func (s *CallScheduler) takeNext(queue *store.OutboundCallQueue) *store.OutboundCall {
+	if len(queue.Queued) == 0 {
+		return nil
+	}
+	queue.InFlight = queue.Queued[0]
+	queue.InFlightExpiresAt = s.clock.Now().Add(s.timeout)
+	queue.Queued = queue.Queued[1:]
+	return queue.InFlight
+}
+
+// sendQueued sends the call, which has been put in flight, to the charge station. If
+// the call cannot be sent it is dropped and the next queued call is sent instead.
+func (s *CallScheduler) sendQueued(ctx context.Context, chargeStationId string, call *store.OutboundCall) {
+	for call != nil {
+		err := s.emitter.Emit(ctx, OcppVersion(call.OcppVersion), chargeStationId, &Message{
+			MessageType:    MessageTypeCall,
+			Action:         call.Action,
+			MessageId:      call.MessageId,
+			RequestPayload: []byte(call.RequestPayload),
+			State:          stateOf(call),
+		})
+		if err == nil {
+			return
+		}
+		slog.Error("sending queued call", "chargeStationId", chargeStationId,
+			"action", call.Action, "messageId", call.MessageId, "error", err)
+		call = s.drop(ctx, chargeStationId, call)
+	}
+}
+
+// drop takes the call, which could not be sent, out of flight and returns the next
+// queued call, which has been put in flight in its place. It returns nil if there
+// is no queued call or the queue could not be stored, in which case the call stays
+// in flight until it times out.
+func (s *CallScheduler) drop(ctx context.Context, chargeStationId string, call *store.OutboundCall) *store.OutboundCall {
+	var next *store.OutboundCall
+	err := s.update(ctx, chargeStationId, func(queue *store.OutboundCallQueue) bool {
+		next = nil
+		if queue.InFlight == nil || queue.InFlight.MessageId != call.MessageId {
+			return false
+		}
+		queue.InFlight = nil
+		next = s.takeNext(queue)
+		return true
+	})
+	if err != nil {
+		slog.Error("storing outbound call queue", "chargeStationId", chargeStationId, "error", err)
+		return nil
+	}
+	return next
+}
+
+// expire takes the call in flight out of flight if it has timed out, returning the
+// call that timed out or nil
+func (s *CallScheduler) expire(queue *store.OutboundCallQueue) *store.OutboundCall {
+	if queue.InFlight == nil || s.clock.Now().Before(queue.InFlightExpiresAt) {
+		return nil
+	}
+	timedOut := queue.InFlight
+	queue.InFlight = nil
+	return timedOut
+}
+
+// reportTimeout records a call that timed out once the queue that it was taken out
+// of has been stored
+func reportTimeout(chargeStationId string, call *store.OutboundCall) {
+	if call == nil {
+		return
+	}
+	slog.Warn("call timed out", "chargeStationId", chargeStationId,
+		"action", call.Action, "messageId", call.MessageId)
+	outboundCallsTimedOut.WithLabelValues(call.Action).Inc()
+}
+
+func (s *CallScheduler) station(chargeStationId string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	station, ok := s.stations[chargeStationId]
+	if !ok {
+		station = new(sync.Mutex)
+		s.stations[chargeStationId] = station
+	}
+	return station
+}
+
+// Healthy reports the health of the underlying emitter
+func (s *CallScheduler) Healthy() error {
+	if reporter, ok := s.emitter.(HealthReporter); ok {
+		return reporter.Healthy()
+	}
+	return nil
+}
+
+func stateOf(call *store.OutboundCall) []byte {
+	if call.State == "" {
+		return nil
+	}
+	return []byte(call.State)
+}
@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type recordingEmitter struct {
+	messages []*transport.Message
+}
+
+func (r *recordingEmitter) Emit(_ context.Context, _ transport.OcppVersion, _ string, message *transport.Message) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func (r *recordingEmitter) ids() []string {
+	var ids []string
+	for _, msg := range r.messages {
+		ids = append(ids, msg.MessageId)
+	}
+	return ids
+}
+
+func call(id string) *transport.Message {
+	return &transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "TriggerMessage",
+		MessageId:      id,
+		RequestPayload: json.RawMessage(`{"requestedMessage":"Heartbeat"}`),
+		State:          json.RawMessage(`{"requestedBy":"test"}`),
+	}
+}
+
+func TestCallSchedulerSendsOneCallAtATime(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	emitter := &recordingEmitter{}
+	scheduler := transport.NewCallScheduler(emitter, inmemory.NewStore(clock), transport.WithCallSchedulerClock(clock))
+	handler := scheduler.Handler(transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {}))
+
+	for _, id := range []string{"1", "2", "3"} {
+		err := scheduler.Emit(ctx, transport.OcppVersion201, "cs001", call(id))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"1"}, emitter.ids())
+
+	handler.Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "1"})
+	assert.Equal(t, []string{"1", "2"}, emitter.ids())
+	assert.JSONEq(t, `{"requestedMessage":"Heartbeat"}`, string(emitter.messages[1].RequestPayload))
+	assert.JSONEq(t, `{"requestedBy":"test"}`, string(emitter.messages[1].State))
+
+	handler.Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCallError, MessageId: "2"})
+	assert.Equal(t, []string{"1", "2", "3"}, emitter.ids())
+}
+
+func TestCallSchedulerSendsCallsToDifferentChargeStationsInParallel(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	emitter := &recordingEmitter{}
+	scheduler := transport.NewCallScheduler(emitter, inmemory.NewStore(clock), transport.WithCallSchedulerClock(clock))
+
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion201, "cs001", call("1")))
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion201, "cs002", call("2")))
+
+	assert.Equal(t, []string{"1", "2"}, emitter.ids())
+}
+
+func TestCallSchedulerDoesNotQueueResponses(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	emitter := &recordingEmitter{}
+	scheduler := transport.NewCallScheduler(emitter, inmemory.NewStore(clock), transport.WithCallSchedulerClock(clock))
+
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion201, "cs001", call("1")))
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion201, "cs001", &transport.Message{
+		MessageType: transport.MessageTypeCallResult,
+		MessageId:   "2",
+	}))
+
+	assert.Equal(t, []string{"1", "2"}, emitter.ids())
+}
+
+func TestCallSchedulerSendsNextCallWhenCallTimesOut(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	emitter := &recordingEmitter{}
+	scheduler := transport.NewCallScheduler(emitter, inmemory.NewStore(clock),
+		transport.WithCallSchedulerClock(clock),
+		transport.WithCallSchedulerTimeout(10*time.Second))
+	handler := scheduler.Handler(transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {}))
+
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion201, "cs001", call("1")))
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion201, "cs001", call("2")))
+
+	heartbeat := &transport.Message{MessageType: transport.MessageTypeCall, Action: "Heartbeat", MessageId: "1"}
+	handler.Handle(ctx, "cs001", heartbeat)
+	assert.Equal(t, []string{"1"}, emitter.ids(), "a call from the charge station does not complete the call in flight")
+
+	clock.SetTime(clock.Now().Add(11 * time.Second))
+	handler.Handle(ctx, "cs001", heartbeat)
+	assert.Equal(t, []string{"1", "2"}, emitter.ids())
+}
+
+func TestCallSchedulerRestoresQueueFromStore(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	engine := inmemory.NewStore(clock)
+	emitter := &recordingEmitter{}
+	scheduler := transport.NewCallScheduler(emitter, engine, transport.WithCallSchedulerClock(clock))
+
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion16, "cs001", call("1")))
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion16, "cs001", call("2")))
+
+	// a new scheduler, as after a restart
+	emitter = &recordingEmitter{}
+	scheduler = transport.NewCallScheduler(emitter, engine, transport.WithCallSchedulerClock(clock))
+	handler := scheduler.Handler(transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {}))
+
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion16, "cs001", call("3")))
+	assert.Empty(t, emitter.ids())
+
+	handler.Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "1"})
+	handler.Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "2"})
+	assert.Equal(t, []string{"2", "3"}, emitter.ids())
+
+	handler.Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "3"})
+	queue, err := engine.LookupOutboundCallQueue(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, queue)
+	assert.Nil(t, queue.InFlight)
+	assert.Empty(t, queue.Queued)
+}
+
+type failingQueueStore struct {
+	store.OutboundCallQueueStore
+	fail bool
+}
+
+func (f *failingQueueStore) SetOutboundCallQueue(ctx context.Context, queue *store.OutboundCallQueue) error {
+	if f.fail {
+		return errors.New("store unavailable")
+	}
+	return f.OutboundCallQueueStore.SetOutboundCallQueue(ctx, queue)
+}
+
+func TestCallSchedulerDoesNotSendCallThatCouldNotBeQueued(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	engine := &failingQueueStore{OutboundCallQueueStore: inmemory.NewStore(clock), fail: true}
+	emitter := &recordingEmitter{}
+	scheduler := transport.NewCallScheduler(emitter, engine, transport.WithCallSchedulerClock(clock))
+
+	err := scheduler.Emit(ctx, transport.OcppVersion201, "cs001", call("1"))
+	assert.Error(t, err)
+	assert.Empty(t, emitter.ids())
+}
+
+func TestCallSchedulerSendsNextCallAfterTimeoutWhenReleaseCouldNotBeStored(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	engine := &failingQueueStore{OutboundCallQueueStore: inmemory.NewStore(clock)}
+	emitter := &recordingEmitter{}
+	scheduler := transport.NewCallScheduler(emitter, engine,
+		transport.WithCallSchedulerClock(clock),
+		transport.WithCallSchedulerTimeout(10*time.Second))
+	handler := scheduler.Handler(transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {}))
+
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion201, "cs001", call("1")))
+	require.NoError(t, scheduler.Emit(ctx, transport.OcppVersion201, "cs001", call("2")))
+
+	engine.fail = true
+	handler.Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "1"})
+	assert.Equal(t, []string{"1"}, emitter.ids())
+
+	engine.fail = false
+	clock.SetTime(clock.Now().Add(11 * time.Second))
+	handler.Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCall, Action: "Heartbeat", MessageId: "a"})
+	assert.Equal(t, []string{"1", "2"}, emitter.ids())
+}
+
+func TestCallSchedulersSharingAStoreSendOneCallAtATime(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	engine := inmemory.NewStore(clock)
+	noop := transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {})
+
+	firstEmitter, secondEmitter := &recordingEmitter{}, &recordingEmitter{}
+	first := transport.NewCallScheduler(firstEmitter, engine, transport.WithCallSchedulerClock(clock))
+	second := transport.NewCallScheduler(secondEmitter, engine, transport.WithCallSchedulerClock(clock))
+
+	require.NoError(t, first.Emit(ctx, transport.OcppVersion201, "cs001", call("1")))
+	require.NoError(t, second.Emit(ctx, transport.OcppVersion201, "cs001", call("2")))
+	require.NoError(t, first.Emit(ctx, transport.OcppVersion201, "cs001", call("3")))
+	assert.Equal(t, []string{"1"}, firstEmitter.ids())
+	assert.Empty(t, secondEmitter.ids())
+
+	// the charge station is connected to the second instance when it responds
+	second.Handler(noop).Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "1"})
+	assert.Equal(t, []string{"2"}, secondEmitter.ids())
+
+	first.Handler(noop).Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "2"})
+	assert.Equal(t, []string{"1", "3"}, firstEmitter.ids())
+}
+
+// racingQueueStore lets another scheduler change the queue between the first read and
+// write of a queue
+type racingQueueStore struct {
+	store.OutboundCallQueueStore
+	race func()
+}
+
+func (r *racingQueueStore) SetOutboundCallQueue(ctx context.Context, queue *store.OutboundCallQueue) error {
+	if race := r.race; race != nil {
+		r.race = nil
+		race()
+	}
+	return r.OutboundCallQueueStore.SetOutboundCallQueue(ctx, queue)
+}
+
+func TestCallSchedulerRetriesWhenQueueIsChangedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	engine := &racingQueueStore{OutboundCallQueueStore: inmemory.NewStore(clock)}
+
+	firstEmitter, secondEmitter := &recordingEmitter{}, &recordingEmitter{}
+	first := transport.NewCallScheduler(firstEmitter, engine, transport.WithCallSchedulerClock(clock))
+	second := transport.NewCallScheduler(secondEmitter, engine, transport.WithCallSchedulerClock(clock))
+
+	engine.race = func() {
+		require.NoError(t, second.Emit(ctx, transport.OcppVersion201, "cs001", call("1")))
+	}
+	require.NoError(t, first.Emit(ctx, transport.OcppVersion201, "cs001", call("2")))
+
+	assert.Equal(t, []string{"1"}, secondEmitter.ids())
+	assert.Empty(t, firstEmitter.ids())
+
+	queue, err := engine.LookupOutboundCallQueue(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, queue.InFlight)
+	assert.Equal(t, "1", queue.InFlight.MessageId)
+	require.Len(t, queue.Queued, 1)
+	assert.Equal(t, "2", queue.Queued[0].MessageId)
+}
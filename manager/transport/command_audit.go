@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// CommandAuditRequester identifies the CSMS as the sender of the calls in the command
+// audit log: the operator that asked for a command is recorded when the API accepts it
+const CommandAuditRequester = "csms"
+
+// CommandAuditor is an Emitter that records each call sent to a charge station in the
+// command audit log. A call that cannot be recorded is not sent. The charge station's
+// responses are recorded by wrapping the MessageHandlers with Handler.
+type CommandAuditor struct {
+	emitter Emitter
+	store   store.CommandAuditStore
+	clock   clock.PassiveClock
+}
+
+func NewCommandAuditor(emitter Emitter, engine store.CommandAuditStore, clock clock.PassiveClock) *CommandAuditor {
+	return &CommandAuditor{
+		emitter: emitter,
+		store:   engine,
+		clock:   clock,
+	}
+}
+
+func (a *CommandAuditor) Emit(ctx context.Context, ocppVersion OcppVersion, chargeStationId string, message *Message) error {
+	if message.MessageType != MessageTypeCall {
+		return a.emitter.Emit(ctx, ocppVersion, chargeStationId, message)
+	}
+
+	record := &store.CommandAuditRecord{
+		Id:              message.MessageId,
+		ChargeStationId: chargeStationId,
+		Command:         message.Action,
+		OcppVersion:     string(ocppVersion),
+		Request:         string(message.RequestPayload),
+		RequestedBy:     CommandAuditRequester,
+		RequestedAt:     a.clock.Now(),
+		Status:          store.CommandAuditStatusSent,
+	}
+	err := a.store.SetCommandAuditRecord(ctx, record)
+	if err != nil {
+		return fmt.Errorf("auditing %s call to %s: %w", message.Action, chargeStationId, err)
+	}
+
+	err = a.emitter.Emit(ctx, ocppVersion, chargeStationId, message)
+	if err != nil {
+		record.Status = store.CommandAuditStatusNotSent
+		record.Result = err.Error()
+		record.CompletedAt = a.clock.Now()
+		a.save(ctx, record)
+	}
+	return err
+}
+
+// Handler returns a MessageHandler that records the responses to the audited calls
+// once they have been handled by next
+func (a *CommandAuditor) Handler(next MessageHandler) MessageHandler {
+	return MessageHandlerFunc(func(ctx context.Context, chargeStationId string, message *Message) {
+		next.Handle(ctx, chargeStationId, message)
+		if message.MessageType != MessageTypeCall {
+			a.complete(ctx, chargeStationId, message)
+		}
+	})
+}
+
+func (a *CommandAuditor) complete(ctx context.Context, chargeStationId string, message *Message) {
+	record, err := a.store.LookupCommandAuditRecord(ctx, message.MessageId)
+	if err != nil {
+		slog.Error("lookup command audit record", "chargeStationId", chargeStationId, "messageId", message.MessageId, "error", err)
+		return
+	}
+	if record == nil || record.ChargeStationId != chargeStationId {
+		return
+	}
+
+	if message.MessageType == MessageTypeCallError {
+		record.Status = store.CommandAuditStatusFailed
+		record.Result = fmt.Sprintf("%s: %s", message.ErrorCode, message.ErrorDescription)
+	} else {
+		record.Status = store.CommandAuditStatusCompleted
+		record.Result = string(message.ResponsePayload)
+	}
+	record.CompletedAt = a.clock.Now()
+	a.save(ctx, record)
+}
+
+// save stores the outcome of a call: the call has already been sent, so a failure is
+// only logged
+func (a *CommandAuditor) save(ctx context.Context, record *store.CommandAuditRecord) {
+	err := a.store.SetCommandAuditRecord(ctx, record)
+	if err != nil {
+		slog.Error("setting command audit record", "chargeStationId", record.ChargeStationId, "messageId", record.Id, "error", err)
+	}
+}
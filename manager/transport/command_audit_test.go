@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	clockTest "k8s.io/utils/clock/testing"
+	"testing"
+	"time"
+)
+
+type failingEmitter struct{}
+
+func (failingEmitter) Emit(context.Context, transport.OcppVersion, string, *transport.Message) error {
+	return errors.New("not connected")
+}
+
+type failingAuditStore struct {
+	store.CommandAuditStore
+}
+
+func (failingAuditStore) SetCommandAuditRecord(context.Context, *store.CommandAuditRecord) error {
+	return errors.New("failed")
+}
+
+func TestCommandAuditorRecordsCallsAndResponses(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := clockTest.NewFakePassiveClock(now)
+	engine := inmemory.NewStore(clock)
+	emitter := &recordingEmitter{}
+	auditor := transport.NewCommandAuditor(emitter, engine, clock)
+	handler := auditor.Handler(transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {}))
+
+	require.NoError(t, auditor.Emit(ctx, transport.OcppVersion201, "cs001", call("1")))
+	require.NoError(t, auditor.Emit(ctx, transport.OcppVersion201, "cs001", call("2")))
+	assert.Equal(t, []string{"1", "2"}, emitter.ids())
+
+	record, err := engine.LookupCommandAuditRecord(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, &store.CommandAuditRecord{
+		Id:              "1",
+		ChargeStationId: "cs001",
+		Command:         "TriggerMessage",
+		OcppVersion:     "ocpp2.0.1",
+		Request:         `{"requestedMessage":"Heartbeat"}`,
+		RequestedBy:     transport.CommandAuditRequester,
+		RequestedAt:     now,
+		Status:          store.CommandAuditStatusSent,
+	}, record)
+
+	clock.SetTime(now.Add(time.Second))
+	handler.Handle(ctx, "cs001", &transport.Message{
+		MessageType:     transport.MessageTypeCallResult,
+		MessageId:       "1",
+		ResponsePayload: json.RawMessage(`{"status":"Accepted"}`),
+	})
+	handler.Handle(ctx, "cs001", &transport.Message{
+		MessageType:      transport.MessageTypeCallError,
+		MessageId:        "2",
+		ErrorCode:        transport.ErrorNotImplemented,
+		ErrorDescription: "unknown action",
+	})
+
+	record, err = engine.LookupCommandAuditRecord(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandAuditStatusCompleted, record.Status)
+	assert.Equal(t, `{"status":"Accepted"}`, record.Result)
+	assert.Equal(t, now.Add(time.Second), record.CompletedAt)
+
+	record, err = engine.LookupCommandAuditRecord(ctx, "2")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandAuditStatusFailed, record.Status)
+	assert.Equal(t, "NotImplemented: unknown action", record.Result)
+}
+
+func TestCommandAuditorIgnoresResponsesToCallsThatWereNotAudited(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	engine := inmemory.NewStore(clock)
+	auditor := transport.NewCommandAuditor(&recordingEmitter{}, engine, clock)
+	handled := false
+	handler := auditor.Handler(transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {
+		handled = true
+	}))
+
+	require.NoError(t, auditor.Emit(ctx, transport.OcppVersion16, "cs001", call("1")))
+	handler.Handle(ctx, "cs002", &transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "1"})
+	handler.Handle(ctx, "cs001", &transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "2"})
+	assert.True(t, handled)
+
+	record, err := engine.LookupCommandAuditRecord(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandAuditStatusSent, record.Status)
+	record, err = engine.LookupCommandAuditRecord(ctx, "2")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func TestCommandAuditorRecordsCallsThatCannotBeSent(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+	engine := inmemory.NewStore(clock)
+	auditor := transport.NewCommandAuditor(failingEmitter{}, engine, clock)
+
+	err := auditor.Emit(ctx, transport.OcppVersion16, "cs001", call("1"))
+	assert.Error(t, err)
+
+	record, err := engine.LookupCommandAuditRecord(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandAuditStatusNotSent, record.Status)
+	assert.Equal(t, "not connected", record.Result)
+}
+
+func TestCommandAuditorDoesNotSendCallsThatCannotBeAudited(t *testing.T) {
+	ctx := context.Background()
+	emitter := &recordingEmitter{}
+	auditor := transport.NewCommandAuditor(emitter, failingAuditStore{}, clockTest.NewFakePassiveClock(time.Now()))
+
+	err := auditor.Emit(ctx, transport.OcppVersion16, "cs001", call("1"))
+	assert.Error(t, err)
+	require.NoError(t, auditor.Emit(ctx, transport.OcppVersion16, "cs001",
+		&transport.Message{MessageType: transport.MessageTypeCallResult, MessageId: "2"}))
+	assert.Equal(t, []string{"2"}, emitter.ids())
+}
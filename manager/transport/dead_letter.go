@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+// DeadLetter is a message received from a charge station that could not be
+// routed, together with the reason that it failed.
+type DeadLetter struct {
+	OcppVersion     OcppVersion `json:"ocpp_version"`
+	ChargeStationId string      `json:"charge_station_id"`
+	Message         *Message    `json:"message"`
+	ErrorCode       ErrorCode   `json:"error_code"`
+	Error           string      `json:"error"`
+	// Failures is the number of times that the message has failed to be routed, if
+	// the router counts them
+	Failures  int       `json:"failures,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DeadLetterEmitter records messages that could not be routed so that they
+// can be inspected and replayed once the cause has been fixed.
+type DeadLetterEmitter interface {
+	EmitDeadLetter(ctx context.Context, letter *DeadLetter) error
+}
+
+// DeadLetterQueue holds the dead letters until they are replayed or removed.
+type DeadLetterQueue interface {
+	DeadLetterEmitter
+	// ListDeadLetters returns the dead letters that have not been replayed or removed
+	ListDeadLetters(ctx context.Context) ([]*DeadLetter, error)
+	// ReplayDeadLetter sends the message to be routed again, as if it had been
+	// received from the charge station, and removes the dead letter
+	ReplayDeadLetter(ctx context.Context, letter *DeadLetter) error
+	RemoveDeadLetter(ctx context.Context, letter *DeadLetter) error
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/exp/slog"
+	"sync"
+	"time"
+)
+
+// ErrDraining is the health reported by a manager that has been drained
+var ErrDraining = errors.New("draining")
+
+// Drain stops the manager from taking on new work so that it can be shut down
+// without losing messages, e.g. during a rolling deploy. Draining disconnects the
+// listener's connections, which stops new messages being received and waits for the
+// messages that have already been received to be handled. Once drained the manager
+// reports that it is not ready.
+type Drain struct {
+	timeout time.Duration
+	once    sync.Once
+	err     error
+
+	mu          sync.Mutex
+	draining    bool
+	connections []Connection
+}
+
+// NewDrain returns a Drain that waits up to timeout for the messages that have been
+// received to be handled
+func NewDrain(timeout time.Duration) *Drain {
+	return &Drain{
+		timeout: timeout,
+	}
+}
+
+// Add registers a connection that is disconnected when the manager is drained: a
+// connection that is added once the manager is draining is disconnected straight away
+func (d *Drain) Add(connection Connection) {
+	d.mu.Lock()
+	if !d.draining {
+		d.connections = append(d.connections, connection)
+		d.mu.Unlock()
+		return
+	}
+	d.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+	if err := connection.Disconnect(ctx); err != nil {
+		slog.Warn("disconnecting while draining", "err", err)
+	}
+}
+
+// Start drains the manager. It returns once the connections have been disconnected
+// or the timeout has passed. Draining a manager that is already draining waits for
+// the first drain to finish and returns its result.
+func (d *Drain) Start(ctx context.Context) error {
+	d.once.Do(func() {
+		d.mu.Lock()
+		d.draining = true
+		connections := d.connections
+		d.connections = nil
+		d.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+
+		var errs []error
+		for _, connection := range connections {
+			if err := connection.Disconnect(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			d.err = fmt.Errorf("draining: %w", errors.Join(errs...))
+		}
+	})
+	return d.err
+}
+
+// Draining reports whether the manager has been drained
+func (d *Drain) Draining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// Healthy reports ErrDraining once the manager has been drained, so that it is no
+// longer sent work
+func (d *Drain) Healthy() error {
+	if d.Draining() {
+		return ErrDraining
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"testing"
+	"time"
+)
+
+type fakeConnection struct {
+	disconnected int
+	err          error
+}
+
+func (f *fakeConnection) Disconnect(context.Context) error {
+	f.disconnected++
+	return f.err
+}
+
+func TestDrainDisconnectsConnections(t *testing.T) {
+	drain := transport.NewDrain(time.Second)
+	first, second := &fakeConnection{}, &fakeConnection{}
+	drain.Add(first)
+	drain.Add(second)
+
+	assert.NoError(t, drain.Healthy())
+	assert.False(t, drain.Draining())
+
+	assert.NoError(t, drain.Start(context.Background()))
+	assert.True(t, drain.Draining())
+	assert.ErrorIs(t, drain.Healthy(), transport.ErrDraining)
+	assert.Equal(t, 1, first.disconnected)
+	assert.Equal(t, 1, second.disconnected)
+
+	// draining again has no effect
+	assert.NoError(t, drain.Start(context.Background()))
+	assert.Equal(t, 1, first.disconnected)
+}
+
+func TestDrainReportsDisconnectErrors(t *testing.T) {
+	drain := transport.NewDrain(time.Second)
+	failing := &fakeConnection{err: errors.New("broker unavailable")}
+	other := &fakeConnection{}
+	drain.Add(failing)
+	drain.Add(other)
+
+	err := drain.Start(context.Background())
+	assert.ErrorIs(t, err, failing.err)
+	assert.Equal(t, 1, other.disconnected)
+	assert.ErrorIs(t, drain.Start(context.Background()), failing.err)
+}
+
+func TestDrainDisconnectsConnectionAddedWhileDraining(t *testing.T) {
+	drain := transport.NewDrain(time.Second)
+	assert.NoError(t, drain.Start(context.Background()))
+
+	late := &fakeConnection{}
+	drain.Add(late)
+	assert.Equal(t, 1, late.disconnected)
+}
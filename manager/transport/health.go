@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+// HealthReporter is implemented by listeners and emitters that can report whether
+// they are currently connected to the broker.
+type HealthReporter interface {
+	Healthy() error
+}
@@ -27,6 +27,14 @@ const (
 	MessageTypeCallError
 )
 
+// MessageOrigin identifies the protocol that a message was received with when the
+// gateway has converted it from something other than OCPP-J
+type MessageOrigin string
+
+const (
+	MessageOriginSoap MessageOrigin = "soap" // OCPP 1.6 SOAP
+)
+
 type Message struct {
 	MessageType      MessageType     `json:"type"`
 	Action           string          `json:"action"`
@@ -36,6 +44,7 @@ type Message struct {
 	ErrorCode        ErrorCode       `json:"error_code,omitempty"`
 	ErrorDescription string          `json:"error_description,omitempty"`
 	State            json.RawMessage `json:"state,omitempty"`
+	Origin           MessageOrigin   `json:"origin,omitempty"`
 }
 
 func NewErrorMessage(action, messageId string, code ErrorCode, err error) *Message {
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	server "github.com/mochi-co/mqtt/v2"
+	"github.com/mochi-co/mqtt/v2/hooks/auth"
+	"github.com/mochi-co/mqtt/v2/listeners"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/mqtt"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type testCertificates struct {
+	roots  *x509.CertPool
+	server tls.Certificate
+	client tls.Certificate
+}
+
+func issueCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (tls.Certificate, *x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, cert, key
+}
+
+func newTestCertificates(t *testing.T) *testCertificates {
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := time.Now().Add(time.Hour)
+
+	_, ca, caKey := issueCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	serverCert, _, _ := issueCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+
+	clientCert, _, _ := issueCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "manager"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	return &testCertificates{
+		roots:  roots,
+		server: serverCert,
+		client: clientCert,
+	}
+}
+
+// newTlsBroker starts a broker that requires a client certificate and a username
+// and password
+func newTlsBroker(t *testing.T, certs *testCertificates) *url.URL {
+	broker := server.New(nil)
+	err := broker.AddHook(new(auth.Hook), &auth.Options{
+		Ledger: &auth.Ledger{
+			Auth: auth.AuthRules{
+				{Username: "manager", Password: "secret", Allow: true},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	tcp := listeners.NewTCP("tls1", addr, &listeners.Config{
+		TLSConfig: &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{certs.server},
+			ClientCAs:    certs.roots,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		},
+	})
+	err = broker.AddListener(tcp)
+	require.NoError(t, err)
+	err = broker.Serve()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = broker.Close()
+	})
+
+	u, err := url.Parse(fmt.Sprintf("mqtts://%s", tcp.Address()))
+	require.NoError(t, err)
+	return u
+}
+
+func TestListenerConnectsToBrokerWithClientCertificate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	certs := newTestCertificates(t)
+	brokerUrl := newTlsBroker(t, certs)
+
+	listener := mqtt.NewListener(
+		mqtt.WithMqttBrokerUrl[mqtt.Listener](brokerUrl),
+		mqtt.WithMqttTlsConfig[mqtt.Listener](&tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			RootCAs:      certs.roots,
+			Certificates: []tls.Certificate{certs.client},
+		}),
+		mqtt.WithMqttCredentials[mqtt.Listener]("manager", "secret"))
+
+	conn, err := listener.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {}))
+	require.NoError(t, err)
+	err = conn.Disconnect(ctx)
+	assert.NoError(t, err)
+}
+
+func TestListenerDoesNotConnectWithoutClientCertificate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	certs := newTestCertificates(t)
+	brokerUrl := newTlsBroker(t, certs)
+
+	listener := mqtt.NewListener(
+		mqtt.WithMqttBrokerUrl[mqtt.Listener](brokerUrl),
+		mqtt.WithMqttConnectSettings[mqtt.Listener](500*time.Millisecond, 100*time.Millisecond, 10*time.Second),
+		mqtt.WithMqttTlsConfig[mqtt.Listener](&tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    certs.roots,
+		}),
+		mqtt.WithMqttCredentials[mqtt.Listener]("manager", "secret"))
+
+	_, err := listener.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {}))
+	assert.Error(t, err)
+}
+
+func TestEmitterConnectsToBrokerWithClientCertificate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	certs := newTestCertificates(t)
+	brokerUrl := newTlsBroker(t, certs)
+
+	emitter := mqtt.NewEmitter(
+		mqtt.WithMqttBrokerUrl[mqtt.Emitter](brokerUrl),
+		mqtt.WithMqttTlsConfig[mqtt.Emitter](&tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			RootCAs:      certs.roots,
+			Certificates: []tls.Certificate{certs.client},
+		}),
+		mqtt.WithMqttCredentials[mqtt.Emitter]("manager", "secret"))
+
+	err := emitter.Emit(ctx, transport.OcppVersion201, "cs001", &transport.Message{
+		MessageType: transport.MessageTypeCall,
+		Action:      "Test",
+		MessageId:   "my-message-id",
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// listIdleTimeout is how long ListDeadLetters waits for another retained message
+// before deciding that it has received them all
+const listIdleTimeout = 500 * time.Millisecond
+
+// DeadLetterQueue is an implementation of transport.DeadLetterQueue that keeps
+// each dead letter as a retained message on the broker.
+//
+// Dead letters are published on the topic <topic>/<ocpp-version>/<cs-id>/<message-id>
+// so that they are kept until they are removed. A dead letter is replayed by
+// publishing its message on <prefix>/in/<ocpp-version>/<cs-id>, where it will be
+// received by the listener as if it had been sent by the charge station.
+//
+// The queue uses the same connection settings as the Emitter.
+type DeadLetterQueue struct {
+	emitter *Emitter
+	topic   string
+}
+
+func NewDeadLetterQueue(topic string, opts ...Opt[Emitter]) *DeadLetterQueue {
+	e := new(Emitter)
+	for _, opt := range opts {
+		opt(e)
+	}
+	ensureEmitterDefaults(e)
+	return &DeadLetterQueue{
+		emitter: e,
+		topic:   topic,
+	}
+}
+
+func (q *DeadLetterQueue) letterTopic(letter *transport.DeadLetter) string {
+	return fmt.Sprintf("%s/%s/%s/%s", q.topic, letter.OcppVersion, letter.ChargeStationId, url.QueryEscape(letter.Message.MessageId))
+}
+
+func (q *DeadLetterQueue) EmitDeadLetter(ctx context.Context, letter *transport.DeadLetter) error {
+	payload, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("marshalling dead letter: %v", err)
+	}
+	return q.publish(ctx, q.letterTopic(letter), payload, true)
+}
+
+func (q *DeadLetterQueue) ListDeadLetters(ctx context.Context) ([]*transport.DeadLetter, error) {
+	var mu sync.Mutex
+	var letters []*transport.DeadLetter
+	receivedCh := make(chan struct{}, 1)
+
+	readyCh := make(chan error, 1)
+	filter := fmt.Sprintf("%s/#", q.topic)
+	cfg := autopaho.ClientConfig{
+		BrokerUrls:        q.emitter.mqttBrokerUrls,
+		KeepAlive:         q.emitter.mqttKeepAliveInterval,
+		ConnectRetryDelay: q.emitter.mqttConnectRetryDelay,
+		ConnectTimeout:    q.emitter.mqttConnectTimeout,
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
+			_, err := manager.Subscribe(ctx, &paho.Subscribe{
+				Subscriptions: map[string]paho.SubscribeOptions{
+					filter: {QoS: 1},
+				},
+			})
+			select {
+			case readyCh <- err:
+			default:
+			}
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: fmt.Sprintf("%s-%s", "manager-dead-letters", randSeq(5)),
+			Router: paho.NewSingleHandlerRouter(func(mqttMsg *paho.Publish) {
+				// a retained message with an empty payload is a dead letter that has been removed
+				if len(mqttMsg.Payload) == 0 {
+					return
+				}
+				var letter transport.DeadLetter
+				err := json.Unmarshal(mqttMsg.Payload, &letter)
+				if err != nil {
+					slog.Warn("unable to unmarshal dead letter", "topic", mqttMsg.Topic, "err", err)
+					return
+				}
+				mu.Lock()
+				letters = append(letters, &letter)
+				mu.Unlock()
+				select {
+				case receivedCh <- struct{}{}:
+				default:
+				}
+			}),
+		},
+	}
+	q.emitter.applyCredentials(&cfg)
+
+	connCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, err := autopaho.NewConnection(connCtx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Disconnect(context.Background())
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("subscribing to %s: %v", filter, ctx.Err())
+	case err := <-readyCh:
+		if err != nil {
+			return nil, fmt.Errorf("subscribing to %s: %v", filter, err)
+		}
+	}
+
+	// the broker sends the retained messages straight after the subscription
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-receivedCh:
+		case <-time.After(listIdleTimeout):
+			mu.Lock()
+			defer mu.Unlock()
+			return letters, nil
+		}
+	}
+}
+
+func (q *DeadLetterQueue) ReplayDeadLetter(ctx context.Context, letter *transport.DeadLetter) error {
+	payload, err := json.Marshal(letter.Message)
+	if err != nil {
+		return fmt.Errorf("marshalling message: %v", err)
+	}
+	topic := fmt.Sprintf("%s/in/%s/%s", q.emitter.mqttPrefix, letter.OcppVersion, letter.ChargeStationId)
+	err = q.publish(ctx, topic, payload, false)
+	if err != nil {
+		return err
+	}
+	return q.RemoveDeadLetter(ctx, letter)
+}
+
+func (q *DeadLetterQueue) RemoveDeadLetter(ctx context.Context, letter *transport.DeadLetter) error {
+	return q.publish(ctx, q.letterTopic(letter), nil, true)
+}
+
+func (q *DeadLetterQueue) publish(ctx context.Context, topic string, payload []byte, retain bool) error {
+	props := new(paho.PublishProperties)
+	err := injectTraceContext(ctx, props)
+	if err != nil {
+		return err
+	}
+	err = q.emitter.ensureConnection(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to MQTT: %v", err)
+	}
+	_, err = q.emitter.conn.Publish(ctx, &paho.Publish{
+		QoS:        1,
+		Topic:      topic,
+		Payload:    payload,
+		Retain:     retain,
+		Properties: props,
+	})
+	if err != nil {
+		return fmt.Errorf("publishing to %s: %v", topic, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/mqtt"
+	"testing"
+	"time"
+)
+
+func newDeadLetter(messageId string) *transport.DeadLetter {
+	return &transport.DeadLetter{
+		OcppVersion:     transport.OcppVersion201,
+		ChargeStationId: "cs001",
+		Message: &transport.Message{
+			MessageType:    transport.MessageTypeCall,
+			Action:         "Unknown",
+			MessageId:      messageId,
+			RequestPayload: json.RawMessage(`{"someKey":"someValue"}`),
+		},
+		ErrorCode: transport.ErrorNotImplemented,
+		Error:     "routing request: unknown action Unknown",
+		Timestamp: time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestDeadLetterQueueListsEmittedDeadLetters(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, clientUrl := mqtt.NewBroker(t)
+	defer func() {
+		_ = broker.Close()
+	}()
+	err := broker.Serve()
+	require.NoError(t, err)
+
+	queue := mqtt.NewDeadLetterQueue("dead-letters", mqtt.WithMqttBrokerUrl[mqtt.Emitter](clientUrl))
+
+	err = queue.EmitDeadLetter(ctx, newDeadLetter("msg/1"))
+	require.NoError(t, err)
+	err = queue.EmitDeadLetter(ctx, newDeadLetter("msg/2"))
+	require.NoError(t, err)
+
+	letters, err := queue.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	ids := []string{letters[0].Message.MessageId, letters[1].Message.MessageId}
+	assert.ElementsMatch(t, []string{"msg/1", "msg/2"}, ids)
+	assert.Equal(t, newDeadLetter(letters[0].Message.MessageId), letters[0])
+
+	err = queue.RemoveDeadLetter(ctx, letters[0])
+	require.NoError(t, err)
+
+	remaining, err := queue.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, letters[1].Message.MessageId, remaining[0].Message.MessageId)
+}
+
+func TestDeadLetterQueueReplaysMessageToListener(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, clientUrl := mqtt.NewBroker(t)
+	defer func() {
+		_ = broker.Close()
+	}()
+	err := broker.Serve()
+	require.NoError(t, err)
+
+	receivedMsgCh := make(chan *transport.Message, 1)
+	handler := func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		assert.Equal(t, "cs001", chargeStationId)
+		receivedMsgCh <- msg
+	}
+
+	listener := mqtt.NewListener(mqtt.WithMqttBrokerUrl[mqtt.Listener](clientUrl))
+	conn, err := listener.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(handler))
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		assert.NoError(t, err)
+	}()
+
+	queue := mqtt.NewDeadLetterQueue("dead-letters", mqtt.WithMqttBrokerUrl[mqtt.Emitter](clientUrl))
+	letter := newDeadLetter("msg001")
+	err = queue.EmitDeadLetter(ctx, letter)
+	require.NoError(t, err)
+
+	err = queue.ReplayDeadLetter(ctx, letter)
+	require.NoError(t, err)
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for replayed message")
+	case msg := <-receivedMsgCh:
+		assert.Equal(t, letter.Message, msg)
+	}
+
+	letters, err := queue.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+}
@@ -9,14 +9,13 @@ import (
 	"github.com/eclipse/paho.golang/autopaho"
 	"github.com/eclipse/paho.golang/paho"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -28,12 +27,15 @@ import (
 // configured, the ocpp-version and cs-id are provided to the Emit
 // function. If not configured the default prefix is `cs`.
 //
-// The Emitter defaults to connecting to a broker on 127.0.0.1:1883.
+// The Emitter defaults to connecting to a broker on 127.0.0.1:1883. The
+// connection is made when the first message is emitted and is re-established,
+// with an exponential backoff, if it is lost.
 type Emitter struct {
 	sync.Mutex
 	connectionDetails
-	tracer trace.Tracer
-	conn   *autopaho.ConnectionManager
+	tracer  trace.Tracer
+	conn    *autopaho.ConnectionManager
+	monitor atomic.Pointer[connectionMonitor]
 }
 
 func NewEmitter(opts ...Opt[Emitter]) transport.Emitter {
@@ -65,12 +67,10 @@ func (e *Emitter) Emit(ctx context.Context, ocppVersion transport.OcppVersion, c
 		))
 	defer span.End()
 
-	correlationMap := make(map[string]string)
-	otel.GetTextMapPropagator().Inject(newCtx, propagation.MapCarrier(correlationMap))
-
-	correlationData, err := json.Marshal(correlationMap)
+	props := new(paho.PublishProperties)
+	err = injectTraceContext(newCtx, props)
 	if err != nil {
-		return fmt.Errorf("marshalling correlation map: %v", err)
+		return err
 	}
 
 	err = e.ensureConnection(ctx)
@@ -79,11 +79,9 @@ func (e *Emitter) Emit(ctx context.Context, ocppVersion transport.OcppVersion, c
 	}
 
 	_, err = e.conn.Publish(newCtx, &paho.Publish{
-		Topic:   topic,
-		Payload: payload,
-		Properties: &paho.PublishProperties{
-			CorrelationData: correlationData,
-		},
+		Topic:      topic,
+		Payload:    payload,
+		Properties: props,
 	})
 	if err != nil {
 		return fmt.Errorf("publishing to %s: %v", topic, err)
@@ -119,6 +117,9 @@ func ensureEmitterDefaults(e *Emitter) {
 	if e.mqttConnectRetryDelay == 0 {
 		e.mqttConnectRetryDelay = 1 * time.Second
 	}
+	if e.mqttMaxRetryDelay == 0 {
+		e.mqttMaxRetryDelay = 30 * time.Second
+	}
 	if e.mqttKeepAliveInterval == 0 {
 		e.mqttKeepAliveInterval = 10
 	}
@@ -131,14 +132,26 @@ func (e *Emitter) ensureConnection(ctx context.Context) error {
 	e.Lock()
 	defer e.Unlock()
 	if e.conn == nil {
-		conn, err := autopaho.NewConnection(context.Background(), autopaho.ClientConfig{
+		monitor := newConnectionMonitor("emitter", e.mqttConnectRetryDelay, e.mqttMaxRetryDelay)
+		cfg := autopaho.ClientConfig{
 			BrokerUrls:        e.mqttBrokerUrls,
 			KeepAlive:         e.mqttKeepAliveInterval,
 			ConnectRetryDelay: e.mqttConnectRetryDelay,
+			ConnectTimeout:    e.mqttConnectTimeout,
+			OnConnectError: func(err error) {
+				monitor.connectFailed(context.Background(), err)
+			},
+			OnConnectionUp: func(*autopaho.ConnectionManager, *paho.Connack) {
+				monitor.connected()
+			},
 			ClientConfig: paho.ClientConfig{
 				ClientID: fmt.Sprintf("%s-%s", "manager-emit", randSeq(5)),
 			},
-		})
+		}
+		e.applyCredentials(&cfg)
+		monitor.watch(&cfg.ClientConfig)
+		e.monitor.Store(monitor)
+		conn, err := autopaho.NewConnection(context.Background(), cfg)
 		if err != nil {
 			return err
 		}
@@ -151,3 +164,13 @@ func (e *Emitter) ensureConnection(ctx context.Context) error {
 	}
 	return nil
 }
+
+// Healthy reports an error if the connection to the broker is down. The emitter is
+// healthy before its first message as it has not tried to connect.
+func (e *Emitter) Healthy() error {
+	monitor := e.monitor.Load()
+	if monitor == nil {
+		return nil
+	}
+	return monitor.healthy()
+}
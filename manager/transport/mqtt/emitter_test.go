@@ -171,6 +171,7 @@ func TestEmitterAddsCorrelationData(t *testing.T) {
 		err := json.Unmarshal(publish.Properties.CorrelationData, &correlationMap)
 		require.NoError(t, err)
 		assert.NotEmpty(t, correlationMap["traceparent"])
+		assert.Equal(t, correlationMap["traceparent"], publish.Properties.User.Get("traceparent"))
 
 		rcvdCh <- struct{}{}
 	}))
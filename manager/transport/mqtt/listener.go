@@ -8,10 +8,8 @@ import (
 	"github.com/eclipse/paho.golang/autopaho"
 	"github.com/eclipse/paho.golang/paho"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
@@ -19,13 +17,25 @@ import (
 	"math/rand"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
+// Listener is an implementation of transport.Listener that uses MQTT as the
+// transport. Each connection reconnects to the broker when it is lost, with an
+// exponential backoff, and subscribes to its topic again once it is connected.
+//
+// The messages received on a connection are handled by a transport.WorkerPool,
+// so messages from the same charge station are handled in order while messages
+// from different charge stations are handled in parallel.
 type Listener struct {
 	connectionDetails
-	mqttGroup string
-	tracer    trace.Tracer
+	mqttGroup       string
+	tracer          trace.Tracer
+	workers         int
+	workerQueueSize int
+	mu              sync.Mutex
+	monitors        []*connectionMonitor
 }
 
 func NewListener(opts ...Opt[Listener]) *Listener {
@@ -57,12 +67,21 @@ func ensureListenerDefaults(l *Listener) {
 	if l.mqttConnectRetryDelay == 0 {
 		l.mqttConnectRetryDelay = 1 * time.Second
 	}
+	if l.mqttMaxRetryDelay == 0 {
+		l.mqttMaxRetryDelay = 30 * time.Second
+	}
 	if l.mqttKeepAliveInterval == 0 {
 		l.mqttKeepAliveInterval = 10
 	}
 	if l.tracer == nil {
 		l.tracer = noop.NewTracerProvider().Tracer("")
 	}
+	if l.workers == 0 {
+		l.workers = 16
+	}
+	if l.workerQueueSize == 0 {
+		l.workerQueueSize = 100
+	}
 }
 
 func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId *string, handler transport.MessageHandler) (transport.Connection, error) {
@@ -74,6 +93,7 @@ func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersio
 	clientId := fmt.Sprintf("%s-%s", l.mqttGroup, randSeq(5))
 
 	readyCh := make(chan struct{})
+	var readyOnce sync.Once
 
 	var topic string
 	if chargeStationId != nil {
@@ -82,36 +102,54 @@ func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersio
 		topic = fmt.Sprintf("$share/%s/%s/in/%s/#", l.mqttGroup, l.mqttPrefix, ocppVersion)
 	}
 
-	conn := new(connection)
+	connCtx, connCancel := context.WithCancel(context.Background())
+	monitor := newConnectionMonitor("listener", l.mqttConnectRetryDelay, l.mqttMaxRetryDelay)
+	// the receive span ends once the message is queued: the worker handles the message
+	// in a process span of its own, a child of the receive span
+	processSpanName := fmt.Sprintf("%s/in/%s/# process", l.mqttPrefix, ocppVersion)
+	pool := transport.NewWorkerPool(string(ocppVersion), transport.MessageHandlerFunc(func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		ctx, span := l.tracer.Start(ctx, processSpanName,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystem("mqtt"),
+				semconv.MessagingOperationKey.String("process"),
+				attribute.String("csId", chargeStationId),
+			))
+		defer span.End()
+		handler.Handle(ctx, chargeStationId, msg)
+	}), l.workers, l.workerQueueSize)
+	conn := &connection{
+		cancel:  connCancel,
+		monitor: monitor,
+		pool:    pool,
+	}
 	mqttRouter := paho.NewStandardRouter()
-	conn.mqttConn, err = autopaho.NewConnection(context.Background(), autopaho.ClientConfig{
+	cfg := autopaho.ClientConfig{
 		BrokerUrls:        l.mqttBrokerUrls,
 		KeepAlive:         l.mqttKeepAliveInterval,
 		ConnectRetryDelay: l.mqttConnectRetryDelay,
+		ConnectTimeout:    l.mqttConnectTimeout,
+		OnConnectError: func(err error) {
+			monitor.connectFailed(connCtx, err)
+		},
 		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
-			_, err := manager.Subscribe(ctx, &paho.Subscribe{
+			// called on every (re)connection: the subscription is made again as the
+			// broker may not have kept the session
+			subscribeCtx, cancel := context.WithTimeout(connCtx, l.mqttConnectTimeout)
+			defer cancel()
+			_, err := manager.Subscribe(subscribeCtx, &paho.Subscribe{
 				Subscriptions: map[string]paho.SubscribeOptions{
 					topic: {},
 				},
 			})
 			if err != nil {
-				slog.Error("failed to subscribe to topic", "topic", topic)
+				slog.Error("failed to subscribe to topic", "topic", topic, "err", err)
 				return
 			}
 			mqttRouter.UnregisterHandler(topic)
 			mqttRouter.RegisterHandler(topic, func(mqttMsg *paho.Publish) {
-				ctx := context.Background()
-
-				// extract trace id
-				if mqttMsg.Properties != nil && mqttMsg.Properties.CorrelationData != nil {
-					correlationMap := make(map[string]string)
-					err := json.Unmarshal(mqttMsg.Properties.CorrelationData, &correlationMap)
-					if err != nil {
-						slog.Warn("failed to unmarshal correlation data", "error", err)
-					} else {
-						ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(correlationMap))
-					}
-				}
+				// continue the trace started by the gateway
+				ctx := extractTraceContext(context.Background(), mqttMsg.Properties)
 
 				// create span
 				newCtx, span := l.tracer.Start(ctx,
@@ -123,7 +161,6 @@ func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersio
 						semconv.MessagingMessagePayloadSizeBytes(len(mqttMsg.Payload)),
 						semconv.MessagingOperationKey.String("receive"),
 					))
-				defer span.End()
 
 				// determine charge station id
 				topicParts := strings.Split(mqttMsg.Topic, "/")
@@ -136,6 +173,7 @@ func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersio
 					span.RecordError(err)
 					span.SetStatus(codes.Error, "unable to unmarshal message")
 					slog.Warn("unable to unmarshal message", "err", err)
+					span.End()
 					return
 				}
 
@@ -154,41 +192,77 @@ func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersio
 						attribute.String(fmt.Sprintf("%s.description", msg.MessageType), msg.ErrorDescription))
 				}
 
-				// execute the handler
-				handler.Handle(newCtx, chargeStationId, &msg)
+				// the message has been received: queue it for the handler, which blocks while
+				// the worker's queue is full
+				span.End()
+				pool.Handle(newCtx, chargeStationId, &msg)
+			})
+			monitor.connected()
+			readyOnce.Do(func() {
+				close(readyCh)
 			})
-			readyCh <- struct{}{}
 		},
 		ClientConfig: paho.ClientConfig{
 			ClientID: clientId,
 			Router:   mqttRouter,
 		},
-	})
+	}
+	l.applyCredentials(&cfg)
+	monitor.watch(&cfg.ClientConfig)
+	conn.mqttConn, err = autopaho.NewConnection(connCtx, cfg)
 	if err != nil {
+		connCancel()
+		pool.Close()
 		return nil, err
 	}
 
 	select {
 	case <-ctx.Done():
+		_ = conn.Disconnect(context.Background())
 		return nil, errors.New("timeout waiting for mqtt connectionDetails setup")
 	case <-readyCh:
+		l.mu.Lock()
+		l.monitors = append(l.monitors, monitor)
+		l.mu.Unlock()
 		return conn, nil
 	}
 }
 
+// Healthy reports an error if any of the listener's connections to the broker
+// is down, including while it is waiting to reconnect
+func (l *Listener) Healthy() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, monitor := range l.monitors {
+		if err := monitor.healthy(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type connection struct {
 	mqttConn *autopaho.ConnectionManager
+	cancel   context.CancelFunc
+	monitor  *connectionMonitor
+	pool     *transport.WorkerPool
 }
 
+// Disconnect stops receiving messages and then waits, until the context is done, for
+// the messages that have already been received to be handled
 func (c *connection) Disconnect(ctx context.Context) error {
+	c.monitor.close()
+	// stops any backoff in progress so that the connection manager can exit
+	c.cancel()
 	if c.mqttConn != nil {
 		err := c.mqttConn.Disconnect(ctx)
 		if err != nil {
+			_ = c.pool.Drain(ctx)
 			return err
 		}
 		c.mqttConn = nil
 	}
-	return nil
+	return c.pool.Drain(ctx)
 }
 
 var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
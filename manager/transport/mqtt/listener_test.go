@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"strings"
 	"testing"
 	"time"
@@ -191,6 +192,66 @@ func TestListenerAddsTraceInformation(t *testing.T) {
 	}
 }
 
+func TestListenerHandlesMessageInProcessSpan(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tracer, exporter := testutil.GetTracer()
+
+	// start the broker
+	broker, clientUrl := mqtt.NewBroker(t)
+	defer func() {
+		err := broker.Close()
+		assert.NoError(t, err)
+	}()
+	err := broker.Serve()
+	require.NoError(t, err)
+
+	// setup the handler
+	receivedMsgCh := make(chan trace.SpanContext, 1)
+	handler := func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		receivedMsgCh <- trace.SpanContextFromContext(ctx)
+	}
+
+	// connect the listener to the broker
+	listener := mqtt.NewListener(
+		mqtt.WithMqttBrokerUrl[mqtt.Listener](clientUrl),
+		mqtt.WithOtelTracer[mqtt.Listener](tracer))
+	conn, err := listener.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(handler))
+	require.NoError(t, err)
+	defer func() {
+		if conn != nil {
+			err := conn.Disconnect(ctx)
+			require.NoError(t, err)
+		}
+	}()
+
+	publishMessage(t, ctx, broker, transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "Test",
+		MessageId:      "my-message-id",
+		RequestPayload: json.RawMessage(`{"someKey":"someValue"}`),
+	})
+
+	var handlerSpan trace.SpanContext
+	select {
+	case <-ctx.Done():
+		require.Fail(t, "timeout waiting for test to complete")
+	case handlerSpan = <-receivedMsgCh:
+	}
+
+	// the receive span has ended before the message is handled and the process span
+	// ends once it has been handled
+	require.Eventually(t, func() bool {
+		return len(exporter.GetSpans()) == 2
+	}, time.Second, 10*time.Millisecond)
+	receive, process := exporter.GetSpans()[0], exporter.GetSpans()[1]
+	assert.Equal(t, "cs/in/ocpp2.0.1/# receive", receive.Name)
+	assert.Equal(t, "cs/in/ocpp2.0.1/# process", process.Name)
+	assert.Equal(t, handlerSpan.SpanID(), process.SpanContext.SpanID())
+	assert.Equal(t, receive.SpanContext.SpanID(), process.Parent.SpanID())
+}
+
 func publishMessage(t *testing.T, ctx context.Context, broker *server.Server, msg transport.Message) {
 	msgBytes, err := json.Marshal(msg)
 	require.NoError(t, err)
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/exp/slog"
+	"sync"
+	"time"
+)
+
+var (
+	mqttDisconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_mqtt_disconnects_total",
+		Help: "The number of times an established connection to the MQTT broker was lost, by client (listener or emitter)",
+	}, []string{"client"})
+	mqttConnectErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_mqtt_connect_errors_total",
+		Help: "The number of failed attempts to connect to the MQTT broker, by client (listener or emitter)",
+	}, []string{"client"})
+)
+
+// ErrNotConnected is reported by Healthy when a connection to the broker is down
+var ErrNotConnected = errors.New("not connected to the mqtt broker")
+
+// connectionMonitor tracks whether a connection to the broker is up and spaces out
+// reconnection attempts with an exponential backoff. autopaho waits a fixed delay
+// between attempts, so the monitor extends that delay when a connection attempt fails.
+type connectionMonitor struct {
+	sync.Mutex
+	client       string
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	up           bool
+	closed       bool
+	failures     int
+}
+
+func newConnectionMonitor(client string, initialDelay, maxDelay time.Duration) *connectionMonitor {
+	return &connectionMonitor{
+		client:       client,
+		initialDelay: initialDelay,
+		maxDelay:     maxDelay,
+	}
+}
+
+func (m *connectionMonitor) connected() {
+	m.Lock()
+	defer m.Unlock()
+	if m.failures > 0 {
+		slog.Info("connected to mqtt broker", "client", m.client, "failedAttempts", m.failures)
+	}
+	m.up = true
+	m.failures = 0
+}
+
+func (m *connectionMonitor) disconnected(err error) {
+	m.Lock()
+	defer m.Unlock()
+	if !m.up || m.closed {
+		return
+	}
+	m.up = false
+	mqttDisconnects.WithLabelValues(m.client).Inc()
+	slog.Warn("lost connection to mqtt broker", "client", m.client, "err", err)
+}
+
+// connectFailed is called by autopaho after each failed connection attempt: it blocks
+// until the backoff delay, less the fixed delay that autopaho will add, has passed or
+// the context is cancelled
+func (m *connectionMonitor) connectFailed(ctx context.Context, err error) {
+	m.Lock()
+	m.failures++
+	delay := m.retryDelay(m.failures)
+	m.Unlock()
+
+	mqttConnectErrors.WithLabelValues(m.client).Inc()
+	slog.Warn("failed to connect to mqtt broker", "client", m.client, "err", err, "retryIn", delay)
+
+	select {
+	case <-time.After(delay - m.initialDelay):
+	case <-ctx.Done():
+	}
+}
+
+func (m *connectionMonitor) retryDelay(failures int) time.Duration {
+	delay := m.initialDelay
+	for i := 1; i < failures && delay < m.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > m.maxDelay {
+		delay = m.maxDelay
+	}
+	if delay < m.initialDelay {
+		delay = m.initialDelay
+	}
+	return delay
+}
+
+func (m *connectionMonitor) close() {
+	m.Lock()
+	defer m.Unlock()
+	m.closed = true
+}
+
+func (m *connectionMonitor) healthy() error {
+	m.Lock()
+	defer m.Unlock()
+	if m.closed || m.up {
+		return nil
+	}
+	return ErrNotConnected
+}
+
+// watch sets the callbacks on the client configuration that report when the
+// connection is lost
+func (m *connectionMonitor) watch(cfg *paho.ClientConfig) {
+	cfg.OnClientError = m.disconnected
+	cfg.OnServerDisconnect = func(d *paho.Disconnect) {
+		m.disconnected(fmt.Errorf("server requested disconnect (reason: %d)", d.ReasonCode))
+	}
+}
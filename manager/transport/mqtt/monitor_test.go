@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConnectionMonitorRetryDelayDoublesUpToMaximum(t *testing.T) {
+	monitor := newConnectionMonitor("test", time.Second, 10*time.Second)
+
+	assert.Equal(t, time.Second, monitor.retryDelay(1))
+	assert.Equal(t, 2*time.Second, monitor.retryDelay(2))
+	assert.Equal(t, 8*time.Second, monitor.retryDelay(4))
+	assert.Equal(t, 10*time.Second, monitor.retryDelay(5))
+	assert.Equal(t, 10*time.Second, monitor.retryDelay(100))
+}
+
+func TestConnectionMonitorReportsHealth(t *testing.T) {
+	monitor := newConnectionMonitor("test", time.Millisecond, time.Millisecond)
+	assert.ErrorIs(t, monitor.healthy(), ErrNotConnected)
+
+	monitor.connected()
+	assert.NoError(t, monitor.healthy())
+
+	monitor.disconnected(errors.New("connection reset"))
+	assert.ErrorIs(t, monitor.healthy(), ErrNotConnected)
+
+	monitor.connectFailed(context.Background(), errors.New("connection refused"))
+	assert.Equal(t, 1, monitor.failures)
+
+	monitor.connected()
+	assert.NoError(t, monitor.healthy())
+	assert.Equal(t, 0, monitor.failures)
+
+	monitor.disconnected(errors.New("connection reset"))
+	monitor.close()
+	assert.NoError(t, monitor.healthy())
+}
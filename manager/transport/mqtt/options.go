@@ -3,6 +3,8 @@
 package mqtt
 
 import (
+	"crypto/tls"
+	"github.com/eclipse/paho.golang/autopaho"
 	"go.opentelemetry.io/otel/trace"
 	"net/url"
 	"time"
@@ -13,7 +15,18 @@ type connectionDetails struct {
 	mqttPrefix            string
 	mqttConnectTimeout    time.Duration
 	mqttConnectRetryDelay time.Duration
+	mqttMaxRetryDelay     time.Duration
 	mqttKeepAliveInterval uint16
+	mqttTlsConfig         *tls.Config
+	mqttUsername          string
+	mqttPassword          []byte
+}
+
+// applyCredentials adds the TLS configuration and the username and password, if any,
+// to the client configuration
+func (c *connectionDetails) applyCredentials(cfg *autopaho.ClientConfig) {
+	cfg.TlsCfg = c.mqttTlsConfig
+	cfg.SetUsernamePassword(c.mqttUsername, c.mqttPassword)
 }
 
 type Opt[T any] func(h *T)
@@ -66,6 +79,45 @@ func WithMqttConnectSettings[T Emitter | Listener](mqttConnectTimeout, mqttConne
 	}
 }
 
+// WithMqttTlsConfig sets the TLS configuration used to connect to brokers with a
+// mqtts:// (or ssl://, tls://, wss://) URL, e.g. to present a client certificate
+func WithMqttTlsConfig[T Emitter | Listener](tlsConfig *tls.Config) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Emitter:
+			x.mqttTlsConfig = tlsConfig
+		case *Listener:
+			x.mqttTlsConfig = tlsConfig
+		}
+	}
+}
+
+func WithMqttCredentials[T Emitter | Listener](username, password string) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Emitter:
+			x.mqttUsername = username
+			x.mqttPassword = []byte(password)
+		case *Listener:
+			x.mqttUsername = username
+			x.mqttPassword = []byte(password)
+		}
+	}
+}
+
+// WithMqttMaxConnectRetryDelay sets the upper bound for the delay between connection
+// attempts: the delay starts at the connect retry delay and doubles after each failure
+func WithMqttMaxConnectRetryDelay[T Emitter | Listener](mqttMaxRetryDelay time.Duration) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Emitter:
+			x.mqttMaxRetryDelay = mqttMaxRetryDelay
+		case *Listener:
+			x.mqttMaxRetryDelay = mqttMaxRetryDelay
+		}
+	}
+}
+
 func WithOtelTracer[T Emitter | Listener](tracer trace.Tracer) Opt[T] {
 	return func(h *T) {
 		switch x := any(h).(type) {
@@ -85,3 +137,16 @@ func WithMqttGroup[T Listener](mqttGroup string) Opt[T] {
 		}
 	}
 }
+
+// WithMqttWorkers sets the number of workers that handle the messages received on
+// each connection and the number of messages that each worker will queue before
+// the listener stops receiving messages
+func WithMqttWorkers[T Listener](workers, queueSize int) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Listener:
+			x.workers = workers
+			x.workerQueueSize = queueSize
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func newSpanContext(t *testing.T, traceId string) trace.SpanContext {
+	tid, err := trace.TraceIDFromHex(traceId)
+	require.NoError(t, err)
+	sid, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+func TestTraceContextIsCarriedInUserPropertiesAndCorrelationData(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	sc := newSpanContext(t, "4bf92f3577b34da6a3ce929d0e0e4736")
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	props := new(paho.PublishProperties)
+	err := injectTraceContext(ctx, props)
+	require.NoError(t, err)
+
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	assert.Equal(t, traceparent, props.User.Get("traceparent"))
+	correlationMap := make(map[string]string)
+	err = json.Unmarshal(props.CorrelationData, &correlationMap)
+	require.NoError(t, err)
+	assert.Equal(t, traceparent, correlationMap["traceparent"])
+
+	got := trace.SpanContextFromContext(extractTraceContext(context.Background(), props))
+	assert.Equal(t, sc.TraceID(), got.TraceID())
+	assert.Equal(t, sc.SpanID(), got.SpanID())
+}
+
+func TestExtractTraceContextPrefersUserProperties(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	props := &paho.PublishProperties{
+		CorrelationData: []byte(`{"traceparent":"00-0af7651916cd43dd8448eb211c80319c-00f067aa0ba902b7-01"}`),
+	}
+	props.User.Add("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	got := trace.SpanContextFromContext(extractTraceContext(context.Background(), props))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", got.TraceID().String())
+}
+
+func TestExtractTraceContextFallsBackToCorrelationData(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	props := &paho.PublishProperties{
+		CorrelationData: []byte(`{"traceparent":"00-0af7651916cd43dd8448eb211c80319c-00f067aa0ba902b7-01"}`),
+	}
+	props.User.Add("other", "value")
+
+	got := trace.SpanContextFromContext(extractTraceContext(context.Background(), props))
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", got.TraceID().String())
+
+	assert.False(t, trace.SpanContextFromContext(extractTraceContext(context.Background(), nil)).IsValid())
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	server "github.com/mochi-co/mqtt/v2"
+	"github.com/mochi-co/mqtt/v2/hooks/auth"
+	"github.com/mochi-co/mqtt/v2/listeners"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/mqtt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// startBrokerAt starts a broker listening on addr, so that a broker can be restarted
+// on the same address
+func startBrokerAt(t *testing.T, addr string) *server.Server {
+	broker := server.New(nil)
+	err := broker.AddHook(new(auth.AllowHook), nil)
+	require.NoError(t, err)
+	err = broker.AddListener(listeners.NewTCP("tcp1", addr, nil))
+	require.NoError(t, err)
+	err = broker.Serve()
+	require.NoError(t, err)
+	return broker
+}
+
+func TestListenerResubscribesAfterReconnecting(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	broker := startBrokerAt(t, addr)
+	brokerUrl, err := url.Parse(fmt.Sprintf("mqtt://%s", addr))
+	require.NoError(t, err)
+
+	receivedMsgCh := make(chan struct{}, 1)
+	handler := func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		receivedMsgCh <- struct{}{}
+	}
+
+	listener := mqtt.NewListener(
+		mqtt.WithMqttBrokerUrl[mqtt.Listener](brokerUrl),
+		mqtt.WithMqttConnectSettings[mqtt.Listener](time.Second, 50*time.Millisecond, 10*time.Second),
+		mqtt.WithMqttMaxConnectRetryDelay[mqtt.Listener](200*time.Millisecond))
+	conn, err := listener.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(handler))
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		assert.NoError(t, err)
+	}()
+	require.NoError(t, listener.Healthy())
+
+	// stop the broker: the listener reports that it is not connected
+	err = broker.Close()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return listener.Healthy() != nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.ErrorIs(t, listener.Healthy(), mqtt.ErrNotConnected)
+
+	// restart the broker: the listener reconnects and subscribes again
+	broker = startBrokerAt(t, addr)
+	defer func() {
+		_ = broker.Close()
+	}()
+	require.Eventually(t, func() bool {
+		return listener.Healthy() == nil
+	}, 10*time.Second, 20*time.Millisecond)
+
+	publishMessage(t, ctx, broker, transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "Test",
+		MessageId:      "my-message-id",
+		RequestPayload: json.RawMessage(`{"someKey":"someValue"}`),
+	})
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for message after reconnecting")
+	case <-receivedMsgCh:
+	}
+}
+
+func TestListenerIsHealthyAfterDisconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, clientUrl := mqtt.NewBroker(t)
+	defer func() {
+		_ = broker.Close()
+	}()
+	err := broker.Serve()
+	require.NoError(t, err)
+
+	listener := mqtt.NewListener(mqtt.WithMqttBrokerUrl[mqtt.Listener](clientUrl))
+	conn, err := listener.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {}))
+	require.NoError(t, err)
+
+	err = conn.Disconnect(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, listener.Healthy())
+}
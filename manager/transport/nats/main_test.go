@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package nats_test
+
+import (
+	"context"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"log"
+	"os"
+	"testing"
+)
+
+var serverUrl string
+
+func setup() func() {
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "nats:2.10",
+		Cmd:          []string{"--jetstream"},
+		ExposedPorts: []string{"4222/tcp"},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("Server is ready"),
+			wait.ForExposedPort(),
+		),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		log.Println("Failed to wait for started container")
+		log.Fatal(err)
+	}
+
+	serverUrl, err = container.PortEndpoint(ctx, "4222/tcp", "nats")
+	if err != nil {
+		log.Println("Container did not expose endpoint")
+		log.Fatal(err)
+	}
+
+	return func() {
+		if err := container.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err.Error())
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
+	teardown := setup()
+	exitVal := m.Run()
+	teardown()
+
+	os.Exit(exitVal)
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import "github.com/nats-io/nats.go"
+
+// headerCarrier adapts the headers of a NATS message so that they can carry the
+// trace context. Unlike HTTP headers, NATS header keys are case-sensitive.
+type headerCarrier nats.Header
+
+func (c headerCarrier) Get(key string) string {
+	return nats.Header(c).Get(key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	nats.Header(c).Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
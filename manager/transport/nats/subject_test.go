@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"context"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"testing"
+)
+
+func TestSubjectReplacesDotsInTheOcppVersion(t *testing.T) {
+	tr := NewTransport(WithPrefix("test"))
+
+	assert.Equal(t, "test.in.ocpp1_6.cs001", tr.subject("in", transport.OcppVersion16, "cs001"))
+	assert.Equal(t, "test.out.ocpp2_0_1.cs001", tr.subject("out", transport.OcppVersion201, "cs001"))
+}
+
+func TestValidChargeStationId(t *testing.T) {
+	assert.True(t, validChargeStationId("cs001"))
+	assert.True(t, validChargeStationId("CS-001_a"))
+	assert.False(t, validChargeStationId(""))
+	assert.False(t, validChargeStationId("cs.001"))
+	assert.False(t, validChargeStationId("cs*"))
+	assert.False(t, validChargeStationId("cs>"))
+	assert.False(t, validChargeStationId("cs 001"))
+}
+
+func TestHeaderCarrier(t *testing.T) {
+	header := nats.Header{}
+	carrier := headerCarrier(header)
+
+	carrier.Set("traceparent", "00-1-2-01")
+	carrier.Set("traceparent", "00-3-4-01")
+
+	assert.Equal(t, "00-3-4-01", carrier.Get("traceparent"))
+	assert.Equal(t, []string{"traceparent"}, carrier.Keys())
+	assert.Equal(t, "00-3-4-01", header.Get("traceparent"))
+}
+
+func TestEmitRejectsChargeStationIdsThatAreNotSubjectTokens(t *testing.T) {
+	tr := NewTransport()
+
+	err := tr.Emit(context.Background(), transport.OcppVersion16, "cs.001", &transport.Message{})
+	assert.ErrorContains(t, err, "cannot be used in a nats subject")
+	assert.NoError(t, tr.Healthy())
+}
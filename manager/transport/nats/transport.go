@@ -0,0 +1,400 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"golang.org/x/exp/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotConnected is reported by Healthy when the connection to the server is down
+var ErrNotConnected = errors.New("not connected to the nats server")
+
+// streamMaxAge is how long messages are kept in the stream: messages that have
+// not been consumed by then are of no use to the charge station
+const streamMaxAge = 24 * time.Hour
+
+// Transport is an implementation of transport.Transport that uses NATS JetStream
+// as the transport.
+//
+// Messages from charge stations are read from the subjects
+// <prefix>.in.<ocpp-version>.<cs-id> and messages for charge stations are published
+// on <prefix>.out.<ocpp-version>.<cs-id>. The dots in the OCPP version are replaced
+// with underscores, e.g. ocpp2_0_1, as dots separate the tokens of a subject. Both
+// sets of subjects are held by a stream which is created if it does not exist.
+//
+// Messages for all charge stations are received through a durable consumer named
+// <group>-<ocpp-version>, which the manager instances share and which keeps the
+// messages that arrive while no manager is running. A message is acknowledged once
+// it has been handled. Messages for a single charge station are received through
+// an ephemeral consumer.
+//
+// The Transport defaults to connecting to a server on 127.0.0.1:4222. The connection
+// is made when it is first needed and is re-established if it is lost.
+type Transport struct {
+	sync.Mutex
+	urls            []string
+	prefix          string
+	group           string
+	stream          string
+	connectTimeout  time.Duration
+	reconnectWait   time.Duration
+	username        string
+	password        string
+	workers         int
+	workerQueueSize int
+	tracer          trace.Tracer
+	conn            *nats.Conn
+	js              jetstream.JetStream
+}
+
+type Opt func(t *Transport)
+
+func WithUrls(urls []string) Opt {
+	return func(t *Transport) {
+		t.urls = urls
+	}
+}
+
+func WithPrefix(prefix string) Opt {
+	return func(t *Transport) {
+		t.prefix = prefix
+	}
+}
+
+func WithGroup(group string) Opt {
+	return func(t *Transport) {
+		t.group = group
+	}
+}
+
+// WithStream sets the name of the stream that holds the messages
+func WithStream(stream string) Opt {
+	return func(t *Transport) {
+		t.stream = stream
+	}
+}
+
+func WithConnectSettings(connectTimeout, reconnectWait time.Duration) Opt {
+	return func(t *Transport) {
+		t.connectTimeout = connectTimeout
+		t.reconnectWait = reconnectWait
+	}
+}
+
+func WithCredentials(username, password string) Opt {
+	return func(t *Transport) {
+		t.username = username
+		t.password = password
+	}
+}
+
+// WithWorkers sets the number of workers that handle the messages received on each
+// connection and the number of messages that each worker will queue
+func WithWorkers(workers, queueSize int) Opt {
+	return func(t *Transport) {
+		t.workers = workers
+		t.workerQueueSize = queueSize
+	}
+}
+
+func WithOtelTracer(tracer trace.Tracer) Opt {
+	return func(t *Transport) {
+		t.tracer = tracer
+	}
+}
+
+func NewTransport(opts ...Opt) *Transport {
+	t := new(Transport)
+	for _, opt := range opts {
+		opt(t)
+	}
+	ensureTransportDefaults(t)
+	return t
+}
+
+func ensureTransportDefaults(t *Transport) {
+	if len(t.urls) == 0 {
+		t.urls = []string{nats.DefaultURL}
+	}
+	if t.prefix == "" {
+		t.prefix = "cs"
+	}
+	if t.group == "" {
+		t.group = "manager"
+	}
+	if t.stream == "" {
+		t.stream = "OCPP"
+	}
+	if t.connectTimeout == 0 {
+		t.connectTimeout = 10 * time.Second
+	}
+	if t.reconnectWait == 0 {
+		t.reconnectWait = 1 * time.Second
+	}
+	if t.workers == 0 {
+		t.workers = 16
+	}
+	if t.workerQueueSize == 0 {
+		t.workerQueueSize = 100
+	}
+	if t.tracer == nil {
+		t.tracer = noop.NewTracerProvider().Tracer("")
+	}
+}
+
+// subject returns the subject for messages to or from a charge station
+func (t *Transport) subject(direction string, ocppVersion transport.OcppVersion, chargeStationId string) string {
+	return fmt.Sprintf("%s.%s.%s.%s", t.prefix, direction, versionToken(ocppVersion), chargeStationId)
+}
+
+func versionToken(ocppVersion transport.OcppVersion) string {
+	return strings.ReplaceAll(string(ocppVersion), ".", "_")
+}
+
+// validChargeStationId reports whether the id can be used as a token of a subject
+func validChargeStationId(chargeStationId string) bool {
+	return chargeStationId != "" && !strings.ContainsAny(chargeStationId, ".*> \t\r\n")
+}
+
+func (t *Transport) ensureConnection(ctx context.Context) (jetstream.JetStream, error) {
+	t.Lock()
+	defer t.Unlock()
+	if t.js != nil {
+		return t.js, nil
+	}
+
+	opts := []nats.Option{
+		nats.Name(t.group),
+		nats.Timeout(t.connectTimeout),
+		nats.ReconnectWait(t.reconnectWait),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			slog.Warn("lost connection to nats server", "err", err)
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			slog.Info("reconnected to nats server", "url", conn.ConnectedUrl())
+		}),
+	}
+	if t.username != "" || t.password != "" {
+		opts = append(opts, nats.UserInfo(t.username, t.password))
+	}
+
+	conn, err := nats.Connect(strings.Join(t.urls, ","), opts...)
+	if err != nil {
+		return nil, err
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.connectTimeout)
+	defer cancel()
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     t.stream,
+		Subjects: []string{fmt.Sprintf("%s.in.>", t.prefix), fmt.Sprintf("%s.out.>", t.prefix)},
+		MaxAge:   streamMaxAge,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("creating stream %s: %w", t.stream, err)
+	}
+
+	t.conn = conn
+	t.js = js
+	return js, nil
+}
+
+func (t *Transport) Emit(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	if !validChargeStationId(chargeStationId) {
+		return fmt.Errorf("charge station id %q cannot be used in a nats subject", chargeStationId)
+	}
+	subject := t.subject("out", ocppVersion, chargeStationId)
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("marshalling response of type %s: %v", message.Action, err)
+	}
+
+	newCtx, span := t.tracer.Start(ctx,
+		fmt.Sprintf("%s.out.%s.* publish", t.prefix, versionToken(ocppVersion)),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("nats"),
+			semconv.MessagingMessagePayloadSizeBytes(len(payload)),
+			semconv.MessagingOperationKey.String("publish"),
+			semconv.MessagingMessageConversationID(message.MessageId),
+			attribute.String("csId", chargeStationId),
+		))
+	defer span.End()
+
+	js, err := t.ensureConnection(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to NATS: %v", err)
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data = payload
+	otel.GetTextMapPropagator().Inject(newCtx, headerCarrier(msg.Header))
+
+	_, err = js.PublishMsg(newCtx, msg)
+	if err != nil {
+		return fmt.Errorf("publishing to %s: %v", subject, err)
+	}
+	return nil
+}
+
+type ackKey struct{}
+
+func (t *Transport) Connect(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId *string, handler transport.MessageHandler) (transport.Connection, error) {
+	js, err := t.ensureConnection(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to NATS: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.connectTimeout)
+	defer cancel()
+
+	var consumer jetstream.Consumer
+	if chargeStationId != nil {
+		if !validChargeStationId(*chargeStationId) {
+			return nil, fmt.Errorf("charge station id %q cannot be used in a nats subject", *chargeStationId)
+		}
+		consumer, err = js.OrderedConsumer(ctx, t.stream, jetstream.OrderedConsumerConfig{
+			FilterSubjects: []string{t.subject("in", ocppVersion, *chargeStationId)},
+			DeliverPolicy:  jetstream.DeliverNewPolicy,
+		})
+	} else {
+		consumer, err = js.CreateOrUpdateConsumer(ctx, t.stream, jetstream.ConsumerConfig{
+			Durable:       fmt.Sprintf("%s-%s", t.group, versionToken(ocppVersion)),
+			FilterSubject: t.subject("in", ocppVersion, "*"),
+			AckPolicy:     jetstream.AckExplicitPolicy,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating consumer: %w", err)
+	}
+
+	// acknowledge each message once it has been handled: messages that are not
+	// handled are delivered again
+	// the receive span ends once the message is queued: the worker handles the message
+	// in a process span of its own, a child of the receive span
+	processSpanName := fmt.Sprintf("%s.in.%s.* process", t.prefix, versionToken(ocppVersion))
+	pool := transport.NewWorkerPool(string(ocppVersion), transport.MessageHandlerFunc(func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		ctx, span := t.tracer.Start(ctx, processSpanName,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystem("nats"),
+				semconv.MessagingOperationKey.String("process"),
+				attribute.String("csId", chargeStationId),
+			))
+		defer span.End()
+		handler.Handle(ctx, chargeStationId, msg)
+		if natsMsg, ok := ctx.Value(ackKey{}).(jetstream.Msg); ok {
+			err := natsMsg.Ack()
+			if err != nil {
+				slog.Warn("unable to acknowledge message", "subject", natsMsg.Subject(), "err", err)
+			}
+		}
+	}), t.workers, t.workerQueueSize)
+
+	consumeContext, err := consumer.Consume(func(natsMsg jetstream.Msg) {
+		t.receive(ocppVersion, natsMsg, chargeStationId == nil, pool)
+	})
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("consuming messages: %w", err)
+	}
+
+	return &connection{
+		consumeContext: consumeContext,
+		pool:           pool,
+	}, nil
+}
+
+func (t *Transport) receive(ocppVersion transport.OcppVersion, natsMsg jetstream.Msg, ack bool, pool *transport.WorkerPool) {
+	// continue the trace started by the gateway
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier(natsMsg.Headers()))
+
+	newCtx, span := t.tracer.Start(ctx,
+		fmt.Sprintf("%s.in.%s.* receive", t.prefix, versionToken(ocppVersion)),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("nats"),
+			semconv.MessagingMessagePayloadSizeBytes(len(natsMsg.Data())),
+			semconv.MessagingOperationKey.String("receive"),
+		))
+
+	subjectTokens := strings.Split(natsMsg.Subject(), ".")
+	chargeStationId := subjectTokens[len(subjectTokens)-1]
+
+	var msg transport.Message
+	err := json.Unmarshal(natsMsg.Data(), &msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unable to unmarshal message")
+		slog.Warn("unable to unmarshal message", "err", err)
+		span.End()
+		// the message will never be handled so it is not delivered again
+		if ack {
+			_ = natsMsg.Term()
+		}
+		return
+	}
+
+	version, _ := strings.CutPrefix(string(ocppVersion), "ocpp")
+	span.SetAttributes(
+		attribute.String("csId", chargeStationId),
+		attribute.String("ocpp.version", version),
+		attribute.String(fmt.Sprintf("%s.action", msg.MessageType), msg.Action),
+		semconv.MessagingMessageConversationID(msg.MessageId),
+	)
+
+	if ack {
+		newCtx = context.WithValue(newCtx, ackKey{}, natsMsg)
+	}
+	// the message has been received: queue it for the handler, which blocks while the
+	// worker's queue is full
+	span.End()
+	pool.Handle(newCtx, chargeStationId, &msg)
+}
+
+// Healthy reports an error if the connection to the server is down. The transport
+// is healthy before it is first used as it has not tried to connect.
+func (t *Transport) Healthy() error {
+	t.Lock()
+	defer t.Unlock()
+	if t.conn == nil || t.conn.IsConnected() {
+		return nil
+	}
+	return ErrNotConnected
+}
+
+type connection struct {
+	consumeContext jetstream.ConsumeContext
+	pool           *transport.WorkerPool
+}
+
+// Disconnect stops consuming messages and then waits, until the context is done, for
+// the messages that have already been received to be handled: messages that have not
+// been handled when the manager exits are not acknowledged, so they are delivered again
+func (c *connection) Disconnect(ctx context.Context) error {
+	c.consumeContext.Stop()
+	return c.pool.Drain(ctx)
+}
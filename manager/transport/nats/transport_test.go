@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package nats_test
+
+import (
+	"context"
+	"encoding/json"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/nats"
+	"testing"
+	"time"
+)
+
+func TestTransportHandlesMessagesPublishedByTheGateway(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tr := nats.NewTransport(nats.WithUrls([]string{serverUrl}), nats.WithPrefix("handle"), nats.WithStream("HANDLE"))
+
+	receivedMsgCh := make(chan *transport.Message, 1)
+	handler := func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		assert.Equal(t, "cs001", chargeStationId)
+		receivedMsgCh <- msg
+	}
+
+	conn, err := tr.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(handler))
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		assert.NoError(t, err)
+	}()
+
+	// publish as the gateway would
+	nc, err := natsgo.Connect(serverUrl)
+	require.NoError(t, err)
+	defer nc.Close()
+	payload, err := json.Marshal(transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "Heartbeat",
+		MessageId:      "my-message-id",
+		RequestPayload: json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+	require.NoError(t, nc.Publish("handle.in.ocpp2_0_1.cs001", payload))
+
+	select {
+	case msg := <-receivedMsgCh:
+		assert.Equal(t, transport.MessageTypeCall, msg.MessageType)
+		assert.Equal(t, "Heartbeat", msg.Action)
+		assert.Equal(t, "my-message-id", msg.MessageId)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for message to be handled")
+	}
+	assert.NoError(t, tr.Healthy())
+}
+
+func TestTransportEmitsMessagesForAChargeStation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tr := nats.NewTransport(nats.WithUrls([]string{serverUrl}), nats.WithPrefix("emit"), nats.WithStream("EMIT"))
+
+	// subscribe as the gateway would
+	nc, err := natsgo.Connect(serverUrl)
+	require.NoError(t, err)
+	defer nc.Close()
+	sub, err := nc.SubscribeSync("emit.out.ocpp1_6.cs001")
+	require.NoError(t, err)
+
+	err = tr.Emit(ctx, transport.OcppVersion16, "cs001", &transport.Message{
+		MessageType:     transport.MessageTypeCallResult,
+		Action:          "Heartbeat",
+		MessageId:       "my-message-id",
+		ResponsePayload: json.RawMessage(`{"currentTime":"2023-06-15T15:05:00Z"}`),
+	})
+	require.NoError(t, err)
+
+	natsMsg, err := sub.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+
+	var msg transport.Message
+	require.NoError(t, json.Unmarshal(natsMsg.Data, &msg))
+	assert.Equal(t, transport.MessageTypeCallResult, msg.MessageType)
+	assert.Equal(t, "my-message-id", msg.MessageId)
+}
+
+func TestTransportRedeliversMessagesReceivedWhileDisconnected(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := []nats.Opt{nats.WithUrls([]string{serverUrl}), nats.WithPrefix("durable"), nats.WithStream("DURABLE")}
+
+	// create the durable consumer and then stop consuming
+	tr := nats.NewTransport(opts...)
+	conn, err := tr.Connect(ctx, transport.OcppVersion16, nil, transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {}))
+	require.NoError(t, err)
+	require.NoError(t, conn.Disconnect(ctx))
+
+	nc, err := natsgo.Connect(serverUrl)
+	require.NoError(t, err)
+	defer nc.Close()
+	payload, err := json.Marshal(transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "Heartbeat",
+		MessageId:      "while-disconnected",
+		RequestPayload: json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+	require.NoError(t, nc.Publish("durable.in.ocpp1_6.cs001", payload))
+
+	receivedMsgCh := make(chan string, 1)
+	conn, err = nats.NewTransport(opts...).Connect(ctx, transport.OcppVersion16, nil, transport.MessageHandlerFunc(func(_ context.Context, _ string, msg *transport.Message) {
+		receivedMsgCh <- msg.MessageId
+	}))
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		assert.NoError(t, err)
+	}()
+
+	select {
+	case messageId := <-receivedMsgCh:
+		assert.Equal(t, "while-disconnected", messageId)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for message to be redelivered")
+	}
+}
@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+// Transport carries messages between the manager and the gateway: messages from
+// charge stations are received with the Listener and messages for charge stations
+// are sent with the Emitter. Implementations that share a single connection to the
+// broker for both directions implement Transport.
+type Transport interface {
+	Emitter
+	Listener
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/exp/slog"
+	"hash/fnv"
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	messageQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "manager_message_queue_depth",
+		Help: "The number of received messages waiting to be handled, by worker pool",
+	}, []string{"pool"})
+	messageQueueFull = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_message_queue_full_total",
+		Help: "The number of received messages that had to wait for room in a full queue, by worker pool",
+	}, []string{"pool"})
+	messageHandlerPanics = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_message_handler_panics_total",
+		Help: "The number of received messages whose handler panicked, by worker pool",
+	}, []string{"pool"})
+)
+
+// WorkerPool is a MessageHandler that hands messages to a fixed number of workers.
+// All the messages for a charge station are handled by the same worker in the order
+// that they were received, while messages for different charge stations are handled
+// in parallel. Each worker has a bounded queue: when it is full Handle blocks until
+// there is room, which stops the transport from receiving more messages. A handler
+// that panics is recovered, so the worker carries on with the next message.
+type WorkerPool struct {
+	name    string
+	handler MessageHandler
+	queues  []chan queuedMessage
+	mu      sync.RWMutex
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+type queuedMessage struct {
+	ctx             context.Context
+	chargeStationId string
+	msg             *Message
+}
+
+// NewWorkerPool starts the workers that pass messages to the handler. The name
+// identifies the pool in the queue metrics.
+func NewWorkerPool(name string, handler MessageHandler, workers, queueSize int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	p := &WorkerPool{
+		name:    name,
+		handler: handler,
+		queues:  make([]chan queuedMessage, workers),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan queuedMessage, queueSize)
+		p.wg.Add(1)
+		go p.work(p.queues[i])
+	}
+	return p
+}
+
+func (p *WorkerPool) work(queue chan queuedMessage) {
+	defer p.wg.Done()
+	for item := range queue {
+		messageQueueDepth.WithLabelValues(p.name).Dec()
+		p.handle(item)
+	}
+}
+
+func (p *WorkerPool) handle(item queuedMessage) {
+	defer func() {
+		if r := recover(); r != nil {
+			messageHandlerPanics.WithLabelValues(p.name).Inc()
+			slog.Error("message handler panicked", slog.String("pool", p.name),
+				slog.String("chargeStationId", item.chargeStationId), slog.String("action", item.msg.Action),
+				"panic", r, "stack", string(debug.Stack()))
+		}
+	}()
+	p.handler.Handle(item.ctx, item.chargeStationId, item.msg)
+}
+
+func (p *WorkerPool) Handle(ctx context.Context, chargeStationId string, msg *Message) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return
+	}
+
+	item := queuedMessage{
+		ctx:             ctx,
+		chargeStationId: chargeStationId,
+		msg:             msg,
+	}
+	queue := p.queues[p.worker(chargeStationId)]
+
+	messageQueueDepth.WithLabelValues(p.name).Inc()
+	select {
+	case queue <- item:
+	default:
+		messageQueueFull.WithLabelValues(p.name).Inc()
+		queue <- item
+	}
+}
+
+func (p *WorkerPool) worker(chargeStationId string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(chargeStationId))
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+// Close stops accepting messages and waits for the workers to handle the messages
+// that are already queued
+func (p *WorkerPool) Close() {
+	_ = p.Drain(context.Background())
+}
+
+// Drain stops accepting messages and waits for the workers to handle the messages
+// that are already queued, or for the context to be done. It returns the context's
+// error if the workers had not finished: they carry on in the background.
+func (p *WorkerPool) Drain(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, queue := range p.queues {
+			close(queue)
+		}
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("draining %s worker pool: %w", p.name, ctx.Err())
+	}
+}
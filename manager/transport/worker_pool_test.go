@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolHandlesMessagesForAChargeStationInOrder(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string][]string)
+	handler := transport.MessageHandlerFunc(func(_ context.Context, chargeStationId string, msg *transport.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		received[chargeStationId] = append(received[chargeStationId], msg.MessageId)
+	})
+
+	pool := transport.NewWorkerPool("test", handler, 4, 10)
+	var want []string
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprintf("%d", i)
+		want = append(want, id)
+		for _, cs := range []string{"cs001", "cs002", "cs003"} {
+			pool.Handle(context.Background(), cs, &transport.Message{MessageId: id})
+		}
+	}
+	pool.Close()
+
+	for _, cs := range []string{"cs001", "cs002", "cs003"} {
+		assert.Equal(t, want, received[cs], cs)
+	}
+}
+
+func TestWorkerPoolHandlesChargeStationsInParallel(t *testing.T) {
+	blockCh := make(chan struct{})
+	receivedCh := make(chan string, 50)
+	handler := transport.MessageHandlerFunc(func(_ context.Context, chargeStationId string, _ *transport.Message) {
+		if chargeStationId == "blocked" {
+			<-blockCh
+		}
+		receivedCh <- chargeStationId
+	})
+
+	pool := transport.NewWorkerPool("test", handler, 2, 20)
+	defer pool.Close()
+
+	// find a charge station that is handled by the other worker
+	pool.Handle(context.Background(), "blocked", &transport.Message{})
+	for i := 0; ; i++ {
+		cs := fmt.Sprintf("cs%03d", i)
+		pool.Handle(context.Background(), cs, &transport.Message{})
+		select {
+		case got := <-receivedCh:
+			assert.Equal(t, cs, got)
+			close(blockCh)
+			assert.Equal(t, "blocked", <-receivedCh)
+			return
+		case <-time.After(50 * time.Millisecond):
+			require.Less(t, i, 20, "no message was handled while another charge station was blocked")
+		}
+	}
+}
+
+func TestWorkerPoolBlocksWhenQueueIsFull(t *testing.T) {
+	blockCh := make(chan struct{})
+	handler := transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {
+		<-blockCh
+	})
+
+	pool := transport.NewWorkerPool("test", handler, 1, 1)
+
+	// the first message is taken by the worker, the second fills the queue
+	pool.Handle(context.Background(), "cs001", &transport.Message{})
+	pool.Handle(context.Background(), "cs001", &transport.Message{})
+
+	handledCh := make(chan struct{})
+	go func() {
+		pool.Handle(context.Background(), "cs001", &transport.Message{})
+		close(handledCh)
+	}()
+
+	select {
+	case <-handledCh:
+		assert.Fail(t, "expected Handle to block while the queue is full")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(blockCh)
+	select {
+	case <-handledCh:
+	case <-time.After(time.Second):
+		assert.Fail(t, "timeout waiting for Handle to return")
+	}
+	pool.Close()
+}
+
+func TestWorkerPoolIgnoresMessagesAfterClose(t *testing.T) {
+	var count int
+	handler := transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {
+		count++
+	})
+
+	pool := transport.NewWorkerPool("test", handler, 1, 1)
+	pool.Handle(context.Background(), "cs001", &transport.Message{})
+	pool.Close()
+	pool.Handle(context.Background(), "cs001", &transport.Message{})
+	pool.Close()
+
+	assert.Equal(t, 1, count)
+}
+
+func TestWorkerPoolDrainStopsWaitingAtDeadline(t *testing.T) {
+	release := make(chan struct{})
+	handler := transport.MessageHandlerFunc(func(context.Context, string, *transport.Message) {
+		<-release
+	})
+
+	pool := transport.NewWorkerPool("test", handler, 1, 1)
+	pool.Handle(context.Background(), "cs001", &transport.Message{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := pool.Drain(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	err = pool.Drain(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestWorkerPoolRecoversFromPanickingHandler(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	handler := transport.MessageHandlerFunc(func(_ context.Context, _ string, msg *transport.Message) {
+		if msg.MessageId == "poison" {
+			panic("handler failed")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, msg.MessageId)
+	})
+
+	pool := transport.NewWorkerPool("test", handler, 1, 10)
+	pool.Handle(context.Background(), "cs001", &transport.Message{MessageId: "1"})
+	pool.Handle(context.Background(), "cs001", &transport.Message{MessageId: "poison"})
+	pool.Handle(context.Background(), "cs001", &transport.Message{MessageId: "2"})
+	pool.Close()
+
+	assert.Equal(t, []string{"1", "2"}, received)
+}
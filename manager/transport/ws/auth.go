@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slices"
+	"net/http"
+)
+
+// authorize returns the reason that the charge station is not allowed to connect
+// using its security profile, or an empty string if it is allowed
+func authorize(r *http.Request, chargeStationId string, auth *store.ChargeStationAuth, orgNames []string) string {
+	switch auth.SecurityProfile {
+	case store.UnsecuredTransportWithBasicAuth:
+		if r.TLS != nil {
+			return "tls for unsecured transport"
+		}
+		return checkPassword(r, chargeStationId, auth)
+	case store.TLSWithBasicAuth:
+		if r.TLS == nil {
+			return "no tls for secured transport"
+		}
+		return checkPassword(r, chargeStationId, auth)
+	case store.TLSWithClientSideCertificates:
+		if r.TLS == nil {
+			return "no tls for secured transport"
+		}
+		return checkCertificate(r, orgNames)
+	default:
+		return "unknown security profile"
+	}
+}
+
+func checkPassword(r *http.Request, chargeStationId string, auth *store.ChargeStationAuth) string {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return "no basic auth"
+	}
+	if username != chargeStationId && !auth.InvalidUsernameAllowed {
+		return "invalid username"
+	}
+	sha256pw := sha256.Sum256([]byte(password))
+	b64sha256 := base64.StdEncoding.EncodeToString(sha256pw[:])
+	if subtle.ConstantTimeCompare([]byte(b64sha256), []byte(auth.Base64SHA256Password)) != 1 {
+		return "invalid password"
+	}
+	return ""
+}
+
+// checkCertificate requires a client certificate, which has been verified against the
+// trusted certificates during the TLS handshake, issued to one of the organizations
+func checkCertificate(r *http.Request, orgNames []string) string {
+	if len(r.TLS.VerifiedChains) == 0 {
+		return "no client certificate"
+	}
+	leafCertificate := r.TLS.VerifiedChains[0][0]
+	for _, org := range leafCertificate.Subject.Organization {
+		if slices.Contains(orgNames, org) {
+			return ""
+		}
+	}
+	return "bad organization"
+}
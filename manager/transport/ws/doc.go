@@ -0,0 +1,5 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ws provides support for charge stations that connect to the manager
+// directly using OCPP-J over WebSockets, without a separate gateway
+package ws
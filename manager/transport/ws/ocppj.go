@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+)
+
+// marshalMessage returns the OCPP-J frame for a message, e.g. [2,"id","Action",{}]
+func marshalMessage(msg *transport.Message) ([]byte, error) {
+	var frame []any
+	switch msg.MessageType {
+	case transport.MessageTypeCall:
+		frame = []any{msg.MessageType, msg.MessageId, msg.Action, payload(msg.RequestPayload)}
+	case transport.MessageTypeCallResult:
+		frame = []any{msg.MessageType, msg.MessageId, payload(msg.ResponsePayload)}
+	case transport.MessageTypeCallError:
+		frame = []any{msg.MessageType, msg.MessageId, msg.ErrorCode, msg.ErrorDescription, json.RawMessage("{}")}
+	default:
+		return nil, fmt.Errorf("unknown message type: %d", msg.MessageType)
+	}
+	return json.Marshal(frame)
+}
+
+func payload(p json.RawMessage) json.RawMessage {
+	if p == nil {
+		return json.RawMessage("{}")
+	}
+	return p
+}
+
+// unmarshalMessage returns the message held by an OCPP-J frame
+func unmarshalMessage(b []byte) (*transport.Message, error) {
+	var frame []json.RawMessage
+	err := json.Unmarshal(b, &frame)
+	if err != nil {
+		return nil, err
+	}
+	if len(frame) < 3 {
+		return nil, fmt.Errorf("frame has %d elements", len(frame))
+	}
+
+	msg := new(transport.Message)
+	if err = json.Unmarshal(frame[0], &msg.MessageType); err != nil {
+		return nil, fmt.Errorf("message type: %w", err)
+	}
+	if err = json.Unmarshal(frame[1], &msg.MessageId); err != nil {
+		return nil, fmt.Errorf("message id: %w", err)
+	}
+
+	switch msg.MessageType {
+	case transport.MessageTypeCall:
+		if len(frame) != 4 {
+			return nil, fmt.Errorf("call has %d elements", len(frame))
+		}
+		if err = json.Unmarshal(frame[2], &msg.Action); err != nil {
+			return nil, fmt.Errorf("action: %w", err)
+		}
+		msg.RequestPayload = frame[3]
+	case transport.MessageTypeCallResult:
+		msg.ResponsePayload = frame[2]
+	case transport.MessageTypeCallError:
+		if len(frame) < 4 {
+			return nil, fmt.Errorf("call error has %d elements", len(frame))
+		}
+		if err = json.Unmarshal(frame[2], &msg.ErrorCode); err != nil {
+			return nil, fmt.Errorf("error code: %w", err)
+		}
+		if err = json.Unmarshal(frame[3], &msg.ErrorDescription); err != nil {
+			return nil, fmt.Errorf("error description: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown message type: %d", msg.MessageType)
+	}
+	return msg, nil
+}
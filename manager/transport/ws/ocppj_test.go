@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMarshalMessage(t *testing.T) {
+	call, err := marshalMessage(&transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		MessageId:      "1",
+		Action:         "Reset",
+		RequestPayload: json.RawMessage(`{"type":"Hard"}`),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[2,"1","Reset",{"type":"Hard"}]`, string(call))
+
+	result, err := marshalMessage(&transport.Message{
+		MessageType: transport.MessageTypeCallResult,
+		MessageId:   "2",
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[3,"2",{}]`, string(result))
+
+	callError, err := marshalMessage(&transport.Message{
+		MessageType:      transport.MessageTypeCallError,
+		MessageId:        "3",
+		ErrorCode:        transport.ErrorNotImplemented,
+		ErrorDescription: "not implemented",
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[4,"3","NotImplemented","not implemented",{}]`, string(callError))
+}
+
+func TestUnmarshalMessage(t *testing.T) {
+	call, err := unmarshalMessage([]byte(`[2,"1","Heartbeat",{}]`))
+	require.NoError(t, err)
+	assert.Equal(t, &transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		MessageId:      "1",
+		Action:         "Heartbeat",
+		RequestPayload: json.RawMessage(`{}`),
+	}, call)
+
+	result, err := unmarshalMessage([]byte(`[3,"2",{"status":"Accepted"}]`))
+	require.NoError(t, err)
+	assert.Equal(t, &transport.Message{
+		MessageType:     transport.MessageTypeCallResult,
+		MessageId:       "2",
+		ResponsePayload: json.RawMessage(`{"status":"Accepted"}`),
+	}, result)
+
+	callError, err := unmarshalMessage([]byte(`[4,"3","InternalError","failed",{}]`))
+	require.NoError(t, err)
+	assert.Equal(t, &transport.Message{
+		MessageType:      transport.MessageTypeCallError,
+		MessageId:        "3",
+		ErrorCode:        transport.ErrorInternalError,
+		ErrorDescription: "failed",
+	}, callError)
+}
+
+func TestUnmarshalMessageRejectsInvalidFrames(t *testing.T) {
+	for _, frame := range []string{
+		`{}`,
+		`[2,"1"]`,
+		`[2,"1","Heartbeat"]`,
+		`[4,"1","InternalError"]`,
+		`[5,"1",{}]`,
+		`["2","1","Heartbeat",{}]`,
+	} {
+		_, err := unmarshalMessage([]byte(frame))
+		assert.Error(t, err, frame)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	// sha256 of "password", base64 encoded
+	password := "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg="
+
+	tlsState := &tls.ConnectionState{}
+	certState := &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{Organization: []string{"Example"}}}}},
+	}
+
+	tests := map[string]struct {
+		auth     *store.ChargeStationAuth
+		username string
+		password string
+		tls      *tls.ConnectionState
+		want     string
+	}{
+		"basic auth": {
+			auth:     &store.ChargeStationAuth{SecurityProfile: store.UnsecuredTransportWithBasicAuth, Base64SHA256Password: password},
+			username: "cs001",
+			password: "password",
+		},
+		"basic auth with wrong password": {
+			auth:     &store.ChargeStationAuth{SecurityProfile: store.UnsecuredTransportWithBasicAuth, Base64SHA256Password: password},
+			username: "cs001",
+			password: "secret",
+			want:     "invalid password",
+		},
+		"basic auth with wrong username": {
+			auth:     &store.ChargeStationAuth{SecurityProfile: store.UnsecuredTransportWithBasicAuth, Base64SHA256Password: password},
+			username: "cs002",
+			password: "password",
+			want:     "invalid username",
+		},
+		"basic auth with invalid username allowed": {
+			auth:     &store.ChargeStationAuth{SecurityProfile: store.UnsecuredTransportWithBasicAuth, Base64SHA256Password: password, InvalidUsernameAllowed: true},
+			username: "cs002",
+			password: "password",
+		},
+		"basic auth over tls": {
+			auth:     &store.ChargeStationAuth{SecurityProfile: store.TLSWithBasicAuth, Base64SHA256Password: password},
+			username: "cs001",
+			password: "password",
+			tls:      tlsState,
+		},
+		"basic auth without tls": {
+			auth:     &store.ChargeStationAuth{SecurityProfile: store.TLSWithBasicAuth, Base64SHA256Password: password},
+			username: "cs001",
+			password: "password",
+			want:     "no tls for secured transport",
+		},
+		"client certificate": {
+			auth: &store.ChargeStationAuth{SecurityProfile: store.TLSWithClientSideCertificates},
+			tls:  certState,
+		},
+		"client certificate missing": {
+			auth: &store.ChargeStationAuth{SecurityProfile: store.TLSWithClientSideCertificates},
+			tls:  tlsState,
+			want: "no client certificate",
+		},
+		"client certificate from another organization": {
+			auth: &store.ChargeStationAuth{SecurityProfile: store.TLSWithClientSideCertificates},
+			tls: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{Organization: []string{"Other"}}}}},
+			},
+			want: "bad organization",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/ws/cs001", nil)
+			if tc.username != "" {
+				r.SetBasicAuth(tc.username, tc.password)
+			}
+			r.TLS = tc.tls
+			assert.Equal(t, tc.want, authorize(r, "cs001", tc.auth, []string{"Example"}))
+		})
+	}
+}
@@ -0,0 +1,385 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"golang.org/x/exp/slog"
+	"net/http"
+	"nhooyr.io/websocket"
+	"sync"
+	"time"
+)
+
+// ErrNotConnected is returned by Emit when the charge station is not connected to
+// this manager instance
+var ErrNotConnected = errors.New("charge station is not connected")
+
+// Transport is an implementation of transport.Transport that accepts OCPP-J
+// WebSocket connections from charge stations on /ws/{cs-id}, so that the manager can
+// be run without a gateway. Messages are handled by the MessageHandler registered
+// with Connect for the OCPP version negotiated by the charge station.
+//
+// Charge stations are authenticated using the security profile held in the store:
+// basic auth over an unsecured connection (profile 1), basic auth over TLS (profile
+// 2) or a TLS client certificate issued to one of the configured organizations
+// (profile 3). A charge station can only be reached through the manager instance
+// that it is connected to.
+type Transport struct {
+	auth        store.ChargeStationAuthStore
+	orgNames    []string
+	callTimeout time.Duration
+	tracer      trace.Tracer
+	mu          sync.RWMutex
+	handlers    map[transport.OcppVersion]transport.MessageHandler
+	connections map[string]*connection
+}
+
+type Opt func(t *Transport)
+
+// WithOrgNames sets the organizations that client certificates may be issued to
+func WithOrgNames(orgNames []string) Opt {
+	return func(t *Transport) {
+		t.orgNames = orgNames
+	}
+}
+
+// WithCallTimeout sets how long a call made by the CSMS waits for the charge
+// station's response: responses that arrive later are dropped
+func WithCallTimeout(callTimeout time.Duration) Opt {
+	return func(t *Transport) {
+		t.callTimeout = callTimeout
+	}
+}
+
+func WithOtelTracer(tracer trace.Tracer) Opt {
+	return func(t *Transport) {
+		t.tracer = tracer
+	}
+}
+
+func NewTransport(auth store.ChargeStationAuthStore, opts ...Opt) *Transport {
+	t := &Transport{
+		auth:        auth,
+		handlers:    make(map[transport.OcppVersion]transport.MessageHandler),
+		connections: make(map[string]*connection),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.callTimeout == 0 {
+		t.callTimeout = time.Minute
+	}
+	if t.tracer == nil {
+		t.tracer = noop.NewTracerProvider().Tracer("")
+	}
+	return t
+}
+
+// Handler returns the handler that accepts the connections from charge stations
+func (t *Transport) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.Use(middleware.Recoverer)
+	r.Get("/ws/{id}", t.accept)
+	return r
+}
+
+func (t *Transport) accept(w http.ResponseWriter, r *http.Request) {
+	chargeStationId := chi.URLParam(r, "id")
+
+	ctx, span := t.tracer.Start(r.Context(), "GET /ws/{id}",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("csId", chargeStationId)))
+	defer span.End()
+
+	auth, err := t.auth.LookupChargeStationAuth(ctx, chargeStationId)
+	if err != nil {
+		span.SetStatus(codes.Error, "lookup charge station failed")
+		span.RecordError(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if auth == nil {
+		span.SetStatus(codes.Error, "unknown charge station")
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("ocpp.security_profile", int(auth.SecurityProfile)))
+	if reason := authorize(r, chargeStationId, auth, t.orgNames); reason != "" {
+		span.SetAttributes(attribute.String("auth.failure_reason", reason))
+		span.SetStatus(codes.Error, "unauthorized")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	wsConn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		Subprotocols:       t.subprotocols(),
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		span.SetAttributes(attribute.String("websocket.accept_failure_reason", err.Error()))
+		return
+	}
+
+	ocppVersion := transport.OcppVersion(wsConn.Subprotocol())
+	if ocppVersion == "" {
+		ocppVersion = transport.OcppVersion201
+	}
+	span.SetAttributes(attribute.String("ocpp.protocol", string(ocppVersion)))
+
+	t.mu.RLock()
+	handler := t.handlers[ocppVersion]
+	t.mu.RUnlock()
+	if handler == nil {
+		span.SetStatus(codes.Error, "ocpp version not enabled")
+		_ = wsConn.Close(websocket.StatusPolicyViolation, fmt.Sprintf("%s is not enabled", ocppVersion))
+		return
+	}
+
+	conn := &connection{
+		wsConn:      wsConn,
+		ocppVersion: ocppVersion,
+		calls:       make(map[string]*pendingCall),
+	}
+	t.register(chargeStationId, conn)
+	defer t.unregister(chargeStationId, conn)
+
+	// the connection has been established: the rest of the exchange is recorded in
+	// the spans of the individual messages
+	span.End()
+
+	t.read(r.Context(), chargeStationId, conn, handler)
+}
+
+// register replaces any existing connection from the charge station
+func (t *Transport) register(chargeStationId string, conn *connection) {
+	t.mu.Lock()
+	previous := t.connections[chargeStationId]
+	t.connections[chargeStationId] = conn
+	t.mu.Unlock()
+
+	if previous != nil {
+		slog.Warn("charge station reconnected: closing previous connection", "chargeStationId", chargeStationId)
+		_ = previous.wsConn.Close(websocket.StatusPolicyViolation, "replaced by a new connection")
+	}
+}
+
+func (t *Transport) unregister(chargeStationId string, conn *connection) {
+	t.mu.Lock()
+	if t.connections[chargeStationId] == conn {
+		delete(t.connections, chargeStationId)
+	}
+	t.mu.Unlock()
+	_ = conn.wsConn.Close(websocket.StatusNormalClosure, "")
+}
+
+func (t *Transport) read(ctx context.Context, chargeStationId string, conn *connection, handler transport.MessageHandler) {
+	for {
+		typ, b, err := conn.wsConn.Read(ctx)
+		if err != nil {
+			if status := websocket.CloseStatus(err); status != -1 {
+				slog.Info("connection closed with status", "chargeStationId", chargeStationId, "status", status)
+			} else {
+				slog.Info("connection closed", "chargeStationId", chargeStationId, "err", err)
+			}
+			return
+		}
+
+		msgCtx, span := t.tracer.Start(context.Background(), fmt.Sprintf("%s receive", conn.ocppVersion),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystem("websocket"),
+				semconv.MessagingOperationKey.String("receive"),
+				semconv.MessagingMessagePayloadSizeBytes(len(b)),
+				attribute.String("csId", chargeStationId),
+			))
+
+		msg, err := unmarshalMessage(b)
+		if err == nil && typ != websocket.MessageText {
+			err = errors.New("websocket message type is not text")
+		}
+		if err != nil {
+			span.SetStatus(codes.Error, "unmarshal ocpp message error")
+			span.RecordError(err)
+			errMsg := transport.NewErrorMessage("", "-1", transport.ErrorRpcFrameworkError, err)
+			if err := t.write(msgCtx, chargeStationId, conn, errMsg); err != nil {
+				slog.Warn("writing to charge station", "chargeStationId", chargeStationId, "err", err)
+			}
+			span.End()
+			continue
+		}
+		span.SetAttributes(semconv.MessagingMessageConversationID(msg.MessageId))
+
+		if msg.MessageType != transport.MessageTypeCall {
+			// a response to a call from the CSMS continues the trace of the call
+			call := conn.completeCall(msg.MessageId)
+			if call == nil {
+				slog.Warn("response has no corresponding call", "chargeStationId", chargeStationId, "messageId", msg.MessageId)
+				span.End()
+				continue
+			}
+			msg.Action = call.msg.Action
+			msg.RequestPayload = call.msg.RequestPayload
+			msg.State = call.msg.State
+			msgCtx = trace.ContextWithSpanContext(msgCtx, call.spanContext)
+		}
+
+		// messages from a charge station are handled in the order they are received
+		handler.Handle(msgCtx, chargeStationId, msg)
+		span.End()
+	}
+}
+
+// preferredVersions are the OCPP versions in the order that they are preferred when
+// a charge station offers more than one
+var preferredVersions = []transport.OcppVersion{transport.OcppVersion21, transport.OcppVersion201, transport.OcppVersion16}
+
+// subprotocols returns the websocket subprotocols for the OCPP versions that are
+// being listened for, so that a charge station is not accepted using a version that
+// is not enabled when it also offers one that is
+func (t *Transport) subprotocols() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var subprotocols []string
+	for _, ocppVersion := range preferredVersions {
+		if t.handlers[ocppVersion] != nil {
+			subprotocols = append(subprotocols, string(ocppVersion))
+		}
+	}
+	return subprotocols
+}
+
+func (t *Transport) Emit(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	t.mu.RLock()
+	conn := t.connections[chargeStationId]
+	t.mu.RUnlock()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	if conn.ocppVersion != ocppVersion {
+		return fmt.Errorf("charge station is connected using %s", conn.ocppVersion)
+	}
+
+	if message.MessageType == transport.MessageTypeCall {
+		conn.addCall(message, trace.SpanContextFromContext(ctx), time.Now().Add(t.callTimeout))
+	}
+	return t.write(ctx, chargeStationId, conn, message)
+}
+
+func (t *Transport) write(ctx context.Context, chargeStationId string, conn *connection, message *transport.Message) error {
+	data, err := marshalMessage(message)
+	if err != nil {
+		return fmt.Errorf("marshalling message of type %s: %v", message.MessageType, err)
+	}
+
+	newCtx, span := t.tracer.Start(ctx, fmt.Sprintf("%s publish", conn.ocppVersion),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("websocket"),
+			semconv.MessagingMessagePayloadSizeBytes(len(data)),
+			semconv.MessagingOperationKey.String("publish"),
+			semconv.MessagingMessageConversationID(message.MessageId),
+			attribute.String("csId", chargeStationId),
+		))
+	defer span.End()
+
+	newCtx, cancel := context.WithTimeout(newCtx, t.callTimeout)
+	defer cancel()
+	return conn.wsConn.Write(newCtx, websocket.MessageText, data)
+}
+
+// Connect registers the handler for the messages from charge stations that use the
+// OCPP version: messages for a single charge station cannot be handled separately
+func (t *Transport) Connect(_ context.Context, ocppVersion transport.OcppVersion, chargeStationId *string, handler transport.MessageHandler) (transport.Connection, error) {
+	if chargeStationId != nil {
+		return nil, errors.New("cannot listen to a single charge station")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.handlers[ocppVersion] != nil {
+		return nil, fmt.Errorf("already listening for %s", ocppVersion)
+	}
+	t.handlers[ocppVersion] = handler
+	return &listenerConnection{transport: t, ocppVersion: ocppVersion}, nil
+}
+
+type listenerConnection struct {
+	transport   *Transport
+	ocppVersion transport.OcppVersion
+}
+
+// Disconnect stops accepting connections that use the OCPP version and closes the
+// existing connections
+func (l *listenerConnection) Disconnect(context.Context) error {
+	l.transport.mu.Lock()
+	delete(l.transport.handlers, l.ocppVersion)
+	var conns []*connection
+	for _, conn := range l.transport.connections {
+		if conn.ocppVersion == l.ocppVersion {
+			conns = append(conns, conn)
+		}
+	}
+	l.transport.mu.Unlock()
+
+	for _, conn := range conns {
+		_ = conn.wsConn.Close(websocket.StatusGoingAway, "shutting down")
+	}
+	return nil
+}
+
+type pendingCall struct {
+	msg         *transport.Message
+	spanContext trace.SpanContext
+	expiry      time.Time
+}
+
+// connection is a WebSocket connection from a charge station, together with the
+// calls made by the CSMS that are waiting for a response
+type connection struct {
+	wsConn      *websocket.Conn
+	ocppVersion transport.OcppVersion
+	mu          sync.Mutex
+	calls       map[string]*pendingCall
+}
+
+func (c *connection) addCall(msg *transport.Message, spanContext trace.SpanContext, expiry time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for messageId, call := range c.calls {
+		if now.After(call.expiry) {
+			delete(c.calls, messageId)
+		}
+	}
+	c.calls[msg.MessageId] = &pendingCall{
+		msg:         msg,
+		spanContext: spanContext,
+		expiry:      expiry,
+	}
+}
+
+// completeCall returns the call that a response is for, or nil if there is no call
+// waiting for the response
+func (c *connection) completeCall(messageId string) *pendingCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	call := c.calls[messageId]
+	delete(c.calls, messageId)
+	if call == nil || time.Now().After(call.expiry) {
+		return nil
+	}
+	return call
+}
@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ws_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/ws"
+	"k8s.io/utils/clock"
+	"net/http"
+	"net/http/httptest"
+	"nhooyr.io/websocket"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sha256 of "password", base64 encoded
+const passwordHash = "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg="
+
+type echoHandler struct {
+	tr         *ws.Transport
+	responseCh chan *transport.Message
+}
+
+// Handle responds to calls with their payload and passes responses to the test
+func (h *echoHandler) Handle(ctx context.Context, chargeStationId string, msg *transport.Message) {
+	if msg.MessageType == transport.MessageTypeCall {
+		_ = h.tr.Emit(ctx, transport.OcppVersion201, chargeStationId, &transport.Message{
+			MessageType:     transport.MessageTypeCallResult,
+			Action:          msg.Action,
+			MessageId:       msg.MessageId,
+			ResponsePayload: msg.RequestPayload,
+		})
+		return
+	}
+	h.responseCh <- msg
+}
+
+func setup(t *testing.T) (*ws.Transport, *echoHandler, string) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetChargeStationAuth(context.Background(), "cs001", &store.ChargeStationAuth{
+		SecurityProfile:      store.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: passwordHash,
+	})
+	require.NoError(t, err)
+
+	tr := ws.NewTransport(engine, ws.WithCallTimeout(time.Second))
+	handler := &echoHandler{tr: tr, responseCh: make(chan *transport.Message, 1)}
+	conn, err := tr.Connect(context.Background(), transport.OcppVersion201, nil, handler)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(tr.Handler())
+	t.Cleanup(func() {
+		_ = conn.Disconnect(context.Background())
+		server.Close()
+	})
+
+	return tr, handler, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dial(t *testing.T, url, password string) (*websocket.Conn, *http.Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	header := http.Header{}
+	header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("cs001:"+password)))
+	return websocket.Dial(ctx, url+"/ws/cs001", &websocket.DialOptions{
+		HTTPHeader:   header,
+		Subprotocols: []string{"ocpp2.0.1"},
+	})
+}
+
+func TestTransportHandlesCallsFromChargeStation(t *testing.T) {
+	_, _, url := setup(t)
+
+	conn, _, err := dial(t, url, "password")
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(websocket.StatusNormalClosure, "") }()
+	assert.Equal(t, "ocpp2.0.1", conn.Subprotocol())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = conn.Write(ctx, websocket.MessageText, []byte(`[2,"1","Heartbeat",{}]`))
+	require.NoError(t, err)
+
+	_, b, err := conn.Read(ctx)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[3,"1",{}]`, string(b))
+}
+
+func TestTransportEmitsCallsToChargeStation(t *testing.T) {
+	tr, handler, url := setup(t)
+
+	conn, _, err := dial(t, url, "password")
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(websocket.StatusNormalClosure, "") }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the server registers the connection once the handshake is complete
+	require.Eventually(t, func() bool {
+		err = tr.Emit(ctx, transport.OcppVersion201, "cs001", &transport.Message{
+			MessageType:    transport.MessageTypeCall,
+			Action:         "Reset",
+			MessageId:      "abc",
+			RequestPayload: json.RawMessage(`{"type":"Immediate"}`),
+			State:          json.RawMessage(`{"requestedBy":"test"}`),
+		})
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	_, b, err := conn.Read(ctx)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[2,"abc","Reset",{"type":"Immediate"}]`, string(b))
+
+	err = conn.Write(ctx, websocket.MessageText, []byte(`[3,"abc",{"status":"Accepted"}]`))
+	require.NoError(t, err)
+
+	select {
+	case msg := <-handler.responseCh:
+		assert.Equal(t, transport.MessageTypeCallResult, msg.MessageType)
+		assert.Equal(t, "Reset", msg.Action)
+		assert.JSONEq(t, `{"type":"Immediate"}`, string(msg.RequestPayload))
+		assert.JSONEq(t, `{"status":"Accepted"}`, string(msg.ResponsePayload))
+		assert.JSONEq(t, `{"requestedBy":"test"}`, string(msg.State))
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for response")
+	}
+}
+
+func TestTransportRespondsToInvalidFrames(t *testing.T) {
+	_, _, url := setup(t)
+
+	conn, _, err := dial(t, url, "password")
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(websocket.StatusNormalClosure, "") }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = conn.Write(ctx, websocket.MessageText, []byte(`not json`))
+	require.NoError(t, err)
+
+	_, b, err := conn.Read(ctx)
+	require.NoError(t, err)
+	var frame []any
+	require.NoError(t, json.Unmarshal(b, &frame))
+	assert.Equal(t, []any{float64(4), "-1", "RpcFrameworkError"}, frame[:3])
+}
+
+func TestTransportRejectsChargeStationWithWrongPassword(t *testing.T) {
+	_, _, url := setup(t)
+
+	_, resp, err := dial(t, url, "secret")
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestTransportEmitFailsWhenChargeStationIsNotConnected(t *testing.T) {
+	tr, _, _ := setup(t)
+
+	err := tr.Emit(context.Background(), transport.OcppVersion201, "cs001", &transport.Message{
+		MessageType: transport.MessageTypeCall,
+		Action:      "Reset",
+		MessageId:   "abc",
+	})
+	assert.ErrorIs(t, err, ws.ErrNotConnected)
+}
+
+func TestTransportNegotiatesEnabledOcppVersion(t *testing.T) {
+	tr, _, url := setup(t)
+
+	header := http.Header{}
+	header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("cs001:password")))
+	dialOcpp21 := func() *websocket.Conn {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, _, err := websocket.Dial(ctx, url+"/ws/cs001", &websocket.DialOptions{
+			HTTPHeader:   header,
+			Subprotocols: []string{"ocpp2.1", "ocpp2.0.1"},
+		})
+		require.NoError(t, err)
+		return conn
+	}
+
+	conn := dialOcpp21()
+	assert.Equal(t, "ocpp2.0.1", conn.Subprotocol())
+	_ = conn.Close(websocket.StatusNormalClosure, "")
+
+	listener, err := tr.Connect(context.Background(), transport.OcppVersion21, nil, &echoHandler{tr: tr})
+	require.NoError(t, err)
+	defer func() { _ = listener.Disconnect(context.Background()) }()
+
+	conn = dialOcpp21()
+	assert.Equal(t, "ocpp2.1", conn.Subprotocol())
+	_ = conn.Close(websocket.StatusNormalClosure, "")
+}